@@ -2,15 +2,29 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	plctrl "github.com/numaproj/numaflow/controllers/pipeline"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
+	"github.com/numaproj/numaflow/pkg/isbsvc"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func Test_Commands(t *testing.T) {
@@ -46,6 +60,30 @@ func Test_Commands(t *testing.T) {
 		assert.Contains(t, err.Error(), "unsupported isb service type")
 	})
 
+	t.Run("stream config flags", func(t *testing.T) {
+		cmd := NewISBSvcBufferCreateCommand()
+		f := &streamConfigFlags{}
+		sc, err := f.override(cmd.Flags(), nil)
+		assert.NoError(t, err)
+		assert.Nil(t, sc)
+		assert.NoError(t, cmd.Flags().Parse([]string{"--stream-replicas=3", "--stream-storage=Memory", "--stream-max-bytes=10Gi", "--stream-max-age=24h"}))
+		f = &streamConfigFlags{replicas: 3, storage: "Memory", maxBytes: "10Gi", maxAge: 24 * time.Hour}
+		base := &dfv1.JetStreamStreamConfig{Replicas: pointer.Int32(1), Storage: dfv1.JetStreamStorageFile}
+		sc, err = f.override(cmd.Flags(), base)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), *sc.Replicas)
+		assert.Equal(t, dfv1.JetStreamStorageMemory, sc.Storage)
+		assert.Equal(t, int64(10<<30), sc.MaxBytes.Value())
+		assert.Equal(t, 24*time.Hour, sc.MaxAge.Duration)
+		assert.Equal(t, int32(1), *base.Replicas)
+		f.storage = "Disk"
+		_, err = f.override(cmd.Flags(), base)
+		assert.Error(t, err)
+		f.storage, f.replicas = "File", 7
+		_, err = f.override(cmd.Flags(), base)
+		assert.Error(t, err)
+	})
+
 	t.Run("ISBSvcBufferDelete", func(t *testing.T) {
 		cmd := NewISBSvcBufferDeleteCommand()
 		assert.True(t, cmd.HasLocalFlags())
@@ -74,12 +112,399 @@ func Test_Commands(t *testing.T) {
 		err = cmd.Execute()
 		assert.Error(t, err)
 		assert.Equal(t, "unsupported isb service type", err.Error())
+		assert.Equal(t, "bool", cmd.Flag("skip-config-drift").Value.Type())
+	})
+
+	t.Run("print buffer validation result", func(t *testing.T) {
+		var buf bytes.Buffer
+		printBufferValidationResult(&buf, &isbsvc.BufferValidationResult{Checks: []isbsvc.BufferCheck{
+			{Check: isbsvc.BufferCheckReachable, Passed: true},
+			{Buffer: "buffer1", Check: isbsvc.BufferCheckConsumer, Message: "group buffer1-group not existing"},
+		}})
+		assert.Equal(t, `{"check":"reachable","passed":true}`+"\n"+`{"buffer":"buffer1","check":"consumer","passed":false,"message":"group buffer1-group not existing"}`+"\n", buf.String())
+	})
+
+	t.Run("ISBSvcBufferMigrate", func(t *testing.T) {
+		cmd := NewISBSvcBufferMigrateCommand()
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "isbsvc-buffer-migrate", cmd.Use)
+		assert.Equal(t, "stringSlice", cmd.Flag("buffers").Value.Type())
+		assert.Equal(t, "bool", cmd.Flag("delete-source").Value.Type())
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Equal(t, "buffer not supplied", err.Error())
+		cmd.SetArgs([]string{"--buffers=buffer1"})
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid buffer migration")
+		cmd = NewISBSvcBufferMigrateCommand()
+		cmd.SetArgs([]string{"--isbsvc-type=nonono", "--buffers=buffer1:buffer2"})
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported isb service type")
+	})
+
+	t.Run("VertexCapture", func(t *testing.T) {
+		cmd := NewVertexCaptureCommand()
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "capture", cmd.Use)
+		assert.Equal(t, "int", cmd.Flag("count").Value.Type())
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Equal(t, "buffer not supplied", err.Error())
+		cmd.SetArgs([]string{"--buffer=buffer1", "--count=0"})
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "count should be greater than 0")
+		cmd = NewVertexCaptureCommand()
+		os.Setenv(dfv1.EnvPipelineName, "test-pl")
+		cmd.SetArgs([]string{"--isbsvc-type=nonono", "--buffer=buffer1"})
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported isb service type")
+	})
+
+	t.Run("VertexReplay", func(t *testing.T) {
+		cmd := NewVertexReplayCommand()
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "replay", cmd.Use)
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Equal(t, "file not supplied", err.Error())
+
+		dir := t.TempDir()
+		messagesFile, vertexFile := filepath.Join(dir, "messages.jsonl"), filepath.Join(dir, "vertex.yaml")
+		f, err := os.Create(messagesFile)
+		assert.NoError(t, err)
+		gzipped, err := sharedutil.Compress("gzip", []byte("world"))
+		assert.NoError(t, err)
+		messages := []*isb.Message{
+			{Header: isb.Header{ID: "1", Key: []byte("k")}, Body: isb.Body{Payload: []byte("hello")}},
+			{Header: isb.Header{ID: "2", ContentEncoding: "gzip"}, Body: isb.Body{Payload: gzipped}},
+		}
+		assert.NoError(t, writeCapturedMessages(f, messages))
+		assert.NoError(t, f.Close())
+		assert.NoError(t, ioutil.WriteFile(vertexFile, []byte(`
+apiVersion: numaflow.numaproj.io/v1alpha1
+kind: Vertex
+metadata:
+  name: test-pl-cat
+spec:
+  name: cat
+  pipelineName: test-pl
+  udf:
+    builtin:
+      name: cat
+`), 0644))
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"--file=" + messagesFile, "--vertex=" + vertexFile})
+		assert.NoError(t, cmd.Execute())
+		output := b.String()
+		assert.Contains(t, output, `[1] id=1 key="k" payload="hello"`)
+		assert.Contains(t, output, `=> key="U+005C__ALL__" payload="hello"`)
+		assert.Contains(t, output, `[2] id=2 key="" payload="world"`)
+		assert.Contains(t, output, `=> key="U+005C__ALL__" payload="world"`)
+	})
+
+	t.Run("VertexDebug", func(t *testing.T) {
+		cmd := NewVertexDebugCommand()
+		assert.Equal(t, "debug PIPELINE VERTEX", cmd.Use)
+		assert.Equal(t, "duration", cmd.Flag("timeout").Value.Type())
+		cmd.SetArgs([]string{"pl"})
+		cmd.SetOut(bytes.NewBufferString(""))
+		assert.Error(t, cmd.Execute())
+		cmd.SetArgs([]string{"pl", "in", "--replica=-1"})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Equal(t, "replica should not be negative", err.Error())
+	})
+
+	t.Run("debug container", func(t *testing.T) {
+		assert.Equal(t, "quay.io/numaproj/numaflow-debug:v0.5.0", debugImage("quay.io/numaproj/numaflow:v0.5.0"))
+		assert.Equal(t, "localhost:5000/numaflow-debug", debugImage("localhost:5000/numaflow"))
+		assert.Equal(t, "numaflow-debug:latest", debugImage("numaflow:latest@sha256:abc"))
+
+		pod := func(name, replica string, phase corev1.PodPhase, ready bool) corev1.Pod {
+			p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{dfv1.KeyReplica: replica}}, Status: corev1.PodStatus{Phase: phase}}
+			if ready {
+				p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			}
+			return p
+		}
+		pods := []corev1.Pod{pod("p0", "0", corev1.PodPending, false), pod("p1", "1", corev1.PodRunning, false), pod("p2", "1", corev1.PodRunning, true), pod("p3", "1", corev1.PodRunning, false)}
+		assert.Nil(t, findReplicaPod(pods, 0))
+		assert.Equal(t, "p2", findReplicaPod(pods, 1).Name)
+		assert.Nil(t, findReplicaPod(pods, 2))
+
+		p := pods[1]
+		p.Spec.Containers = []corev1.Container{
+			{Name: dfv1.CtrUdf, Image: "my-udf"},
+			{Name: dfv1.CtrMain, Image: "quay.io/numaproj/numaflow:v0.5.0", Env: []corev1.EnvVar{{Name: dfv1.EnvPipelineName, Value: "pl"}}, VolumeMounts: []corev1.VolumeMount{{Name: "var-run", MountPath: "/var/run/numaflow"}}},
+		}
+		c, err := buildDebugContainer(&p, "debugger-abcde", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "debugger-abcde", c.Name)
+		assert.Equal(t, dfv1.CtrMain, c.TargetContainerName)
+		assert.Equal(t, "quay.io/numaproj/numaflow-debug:v0.5.0", c.Image)
+		assert.Equal(t, p.Spec.Containers[1].Env, c.Env)
+		assert.Equal(t, p.Spec.Containers[1].VolumeMounts, c.VolumeMounts)
+		assert.True(t, c.Stdin && c.TTY)
+		c, err = buildDebugContainer(&p, "debugger-abcde", "busybox")
+		assert.NoError(t, err)
+		assert.Equal(t, "busybox", c.Image)
+		p.Spec.Containers = p.Spec.Containers[:1]
+		_, err = buildDebugContainer(&p, "debugger-abcde", "")
+		assert.Error(t, err)
 	})
 
 	t.Run("Controller", func(t *testing.T) {
 		cmd := NewControllerCommand()
 		assert.Equal(t, "controller", cmd.Use)
-		assert.False(t, cmd.HasLocalFlags())
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "string", cmd.Flag("feature-gates").Value.Type())
+		cmd.SetArgs([]string{"--feature-gates=Abc=true"})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown feature gate "Abc"`)
+	})
+
+	t.Run("Doctor", func(t *testing.T) {
+		cmd := NewDoctorCommand()
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "doctor", cmd.Use)
+		assert.Equal(t, "string", cmd.Flag("namespace").Value.Type())
+		assert.Equal(t, "string", cmd.Flag("kubeconfig").Value.Type())
+		cmd.SetArgs([]string{"--pipeline="})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "pipeline not supplied")
+	})
+
+	t.Run("PipelineChanges", func(t *testing.T) {
+		cmd := NewPipelineChangesCommand()
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "pipeline-changes", cmd.Use)
+		assert.Equal(t, "bool", cmd.Flag("confirm").Value.Type())
+		cmd.SetArgs([]string{"--pipeline="})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "pipeline not supplied")
+	})
+
+	t.Run("print planned changes", func(t *testing.T) {
+		b := bytes.NewBufferString("")
+		printPlannedChanges(b, &dfv1.PlannedChanges{
+			Hash:                "abc",
+			BuffersToCreate:     []string{"ns-pl-a-c"},
+			BuffersToMigrate:    map[string]string{"ns-pl-a-b": "ns-pl-a-x"},
+			VerticesToDelete:    []string{"pl-b"},
+			DeploymentsToUpdate: []string{"pl-daemon"},
+			Omitted:             3,
+		})
+		output := b.String()
+		assert.Contains(t, output, "create   buffer     ns-pl-a-c")
+		assert.Contains(t, output, "migrate  buffer     ns-pl-a-b -> ns-pl-a-x")
+		assert.Contains(t, output, "delete   vertex     pl-b")
+		assert.Contains(t, output, "update   deployment pl-daemon")
+		assert.Contains(t, output, "... and 3 more changes not listed")
+		assert.Contains(t, output, "Hash: abc")
+	})
+
+	t.Run("PipelineRBAC", func(t *testing.T) {
+		cmd := NewPipelineRBACCommand()
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "pipeline-rbac", cmd.Use)
+		assert.Equal(t, "string", cmd.Flag("file").Value.Type())
+		cmd.SetArgs([]string{"--pipeline="})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "pipeline not supplied")
+	})
+
+	t.Run("print pipeline rbac", func(t *testing.T) {
+		b := bytes.NewBufferString("")
+		err := printPipelineRBAC(b, &dfv1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl"},
+			Spec: dfv1.PipelineSpec{
+				Vertices: []dfv1.AbstractVertex{
+					{Name: "in", Source: &dfv1.Source{}},
+					{Name: "out", Sink: &dfv1.Sink{}, ServiceAccountName: "sink-sa"},
+				},
+				Edges: []dfv1.Edge{{From: "in", To: "out"}},
+			},
+		})
+		assert.NoError(t, err)
+		output := b.String()
+		assert.Contains(t, output, "#   vertex/out           sink-sa")
+		assert.Contains(t, output, "kind: Role\n")
+		assert.Contains(t, output, "rules: []")
+		assert.Contains(t, output, "kind: RoleBinding")
+		assert.Contains(t, output, "name: sink-sa")
+	})
+
+	t.Run("PipelineAlerts", func(t *testing.T) {
+		cmd := NewPipelineAlertsCommand()
+		assert.Equal(t, "pipeline-alerts [PIPELINE...]", cmd.Use)
+		assert.Equal(t, "float64", cmd.Flag("buffer-usage").Value.Type())
+		assert.Equal(t, "duration", cmd.Flag("watermark-delay").Value.Type())
+		cmd.SetArgs([]string{})
+		cmd.SetOut(bytes.NewBufferString(""))
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "pipeline not supplied")
+	})
+
+	t.Run("print pipeline alerts", func(t *testing.T) {
+		b := bytes.NewBufferString("")
+		pls := []*dfv1.Pipeline{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl1"}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl2"}},
+		}
+		err := printPipelineAlerts(b, pls, plctrl.DefaultAlertOptions())
+		assert.NoError(t, err)
+		output := b.String()
+		assert.Equal(t, 2, strings.Count(output, "kind: PrometheusRule\n"))
+		assert.Contains(t, output, "name: pl1-alerts")
+		assert.Contains(t, output, "name: pl2-alerts")
+		assert.Contains(t, output, "alert: NumaflowBufferUsageHigh")
+
+		opts := plctrl.DefaultAlertOptions()
+		opts.BufferUsage = 2
+		assert.Error(t, printPipelineAlerts(b, pls, opts))
+	})
+
+	t.Run("PipelineAudit", func(t *testing.T) {
+		cmd := NewPipelineAuditCommand()
+		assert.Equal(t, "pipeline-audit", cmd.Use)
+		assert.Equal(t, "int", cmd.Flag("max-ids").Value.Type())
+		cmd.SetArgs([]string{})
+		cmd.SetOut(bytes.NewBufferString(""))
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Equal(t, "start not supplied", err.Error())
+		cmd.SetArgs([]string{"--start=2022-06-01T10:00:00Z", "--end=2022-06-01T09:00:00Z"})
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Equal(t, "end should be after start", err.Error())
+		cmd = NewPipelineAuditCommand()
+		cmd.SetOut(bytes.NewBufferString(""))
+		cmd.SetArgs([]string{"--start=2022-06-01T10:00:00Z", "-o", "yaml"})
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported output format")
+	})
+
+	t.Run("print audit report", func(t *testing.T) {
+		start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+		report := audit.Report{
+			Start:      start,
+			End:        start.Add(time.Hour),
+			Emitted:    5,
+			Processed:  map[string]int{"out": 4},
+			Lost:       []string{"in-1"},
+			Duplicated: map[string]map[string]int{"out": {"in-2": 2, "in-3": 3}},
+		}
+		b := bytes.NewBufferString("")
+		assert.NoError(t, printAuditReport(b, report, "text", 1))
+		assert.Equal(t, `Time range: 2022-06-01T10:00:00Z - 2022-06-01T11:00:00Z
+Emitted: 5
+Processed by out: 4, duplicated: 2
+Lost: 1
+  in-1
+Duplicated by out: 2
+  in-2 x2
+  ... 1 more
+FAILED
+`, b.String())
+		assert.Equal(t, 2, countDuplicated(report))
+
+		b.Reset()
+		assert.NoError(t, printAuditReport(b, report, "json", 1))
+		assert.Contains(t, b.String(), `"lost": [`)
+	})
+
+	t.Run("Pipeline", func(t *testing.T) {
+		cmd := NewPipelineCommand()
+		assert.Equal(t, "pipeline", cmd.Use)
+		assert.Len(t, cmd.Commands(), 3)
+		for _, c := range []*cobra.Command{NewPipelineGetCommand(), NewPipelineTopologyCommand()} {
+			assert.Equal(t, "bool", c.Flag("buffers").Value.Type())
+			assert.Equal(t, "string", c.Flag("namespace").Value.Type())
+			c.SetArgs([]string{})
+			c.SetOut(bytes.NewBufferString(""))
+			c.SetErr(bytes.NewBufferString(""))
+			assert.Error(t, c.Execute())
+		}
+		assert.Nil(t, NewPipelineListCommand().Flag("buffers"))
+	})
+
+	pl := &dfv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl", CreationTimestamp: metav1.NewTime(time.Unix(0, 0))},
+		Spec: dfv1.PipelineSpec{
+			Vertices: []dfv1.AbstractVertex{
+				{Name: "in", Source: &dfv1.Source{}},
+				{Name: "cat", UDF: &dfv1.UDF{}},
+				{Name: "even", Sink: &dfv1.Sink{}},
+				{Name: "odd", Sink: &dfv1.Sink{}},
+			},
+			Edges: []dfv1.Edge{
+				{From: "in", To: "cat"},
+				{From: "cat", To: "even", Conditions: &dfv1.ForwardConditions{KeyIn: []string{"even"}}},
+				{From: "cat", To: "odd"},
+				{From: "in", To: "odd"},
+			},
+		},
+		Status: dfv1.PipelineStatus{Phase: dfv1.PipelinePhaseRunning},
+	}
+	two := int32(2)
+	vertices := map[string]*dfv1.Vertex{
+		"in":   {Spec: dfv1.VertexSpec{AbstractVertex: pl.Spec.Vertices[0]}, Status: dfv1.VertexStatus{Phase: dfv1.VertexPhaseRunning, Replicas: 1}},
+		"cat":  {Spec: dfv1.VertexSpec{AbstractVertex: pl.Spec.Vertices[1], Replicas: &two}, Status: dfv1.VertexStatus{Phase: dfv1.VertexPhaseRunning, Replicas: 1}},
+		"even": {Spec: dfv1.VertexSpec{AbstractVertex: pl.Spec.Vertices[2]}, Status: dfv1.VertexStatus{Phase: dfv1.VertexPhaseFailed, Message: "oops"}},
+	}
+	pending, ackPending, full := int64(10), int64(3), true
+	buffers := map[edgeKey]*daemonpb.BufferInfo{
+		{from: "in", to: "cat"}: {PendingCount: &pending, AckPendingCount: &ackPending, IsFull: &full},
+	}
+
+	t.Run("print pipeline list", func(t *testing.T) {
+		b := bytes.NewBufferString("")
+		printPipelineList(b, []dfv1.Pipeline{*pl}, time.Unix(3600, 0))
+		output := b.String()
+		assert.Contains(t, output, "NAME  PHASE    VERTICES  AGE  MESSAGE")
+		assert.Contains(t, output, "pl    Running  4         60m")
+	})
+
+	t.Run("print pipeline", func(t *testing.T) {
+		b := bytes.NewBufferString("")
+		printPipeline(b, pl, vertices, buffers, time.Unix(3600, 0))
+		output := b.String()
+		assert.Contains(t, output, "Phase:      Running")
+		assert.Contains(t, output, "cat     udf     1/2       Running")
+		assert.Contains(t, output, "even    sink    0/1       Failed      oops")
+		assert.Contains(t, output, "odd     sink    -         NotCreated")
+		assert.Contains(t, output, "in -> cat    10       3            0.0%   true")
+		assert.Contains(t, output, "cat -> odd   -        -            -      -")
+	})
+
+	t.Run("print pipeline topology", func(t *testing.T) {
+		b := bytes.NewBufferString("")
+		printPipelineTopology(b, pl, vertices, buffers)
+		assert.Equal(t, `in [source] 1/1 Running
+├─▶ cat [udf] 1/2 Running (pending: 10, ack pending: 3, full)
+│   ├─▶ even [sink] 0/1 Failed (keyIn: even)
+│   └─▶ odd [sink] not created
+└─▶ odd [sink] not created (see above)
+`, b.String())
+	})
+
+	t.Run("Webhook", func(t *testing.T) {
+		cmd := NewWebhookCommand()
+		assert.True(t, cmd.HasLocalFlags())
+		assert.Equal(t, "webhook", cmd.Use)
+		assert.Equal(t, "int", cmd.Flag("port").Value.Type())
+		assert.Equal(t, "string", cmd.Flag("cert-dir").Value.Type())
 	})
 
 	t.Run("BuiltinUDF", func(t *testing.T) {
@@ -107,6 +532,11 @@ func Test_Commands(t *testing.T) {
 		err = cmd.Execute()
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to decode vertex string")
+		os.Setenv(dfv1.EnvVertexObjectVersion, "v1")
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid vertex encoding version")
+		os.Setenv(dfv1.EnvVertexObjectVersion, "1")
 		os.Setenv(dfv1.EnvVertexObject, generateEncodedVertexSpecs())
 		err = cmd.Execute()
 		assert.Error(t, err)
@@ -125,6 +555,81 @@ func Test_Commands(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unrecognized processor type")
 	})
+
+	t.Run("side-inputs-manager", func(t *testing.T) {
+		cmd := NewSideInputsManagerCommand()
+		assert.Equal(t, "side-inputs-manager", cmd.Use)
+		assert.Equal(t, "bool", cmd.Flag("once").Value.Type())
+		os.Unsetenv(dfv1.EnvVertexObject)
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), dfv1.EnvVertexObject+"' not defined")
+		os.Setenv(dfv1.EnvVertexObject, "xxxxx")
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decode vertex string")
+	})
+
+	t.Run("install", func(t *testing.T) {
+		cmd := NewInstallCommand()
+		assert.Equal(t, "install", cmd.Use)
+		assert.Equal(t, "numaflow-system", cmd.Flag("namespace").DefValue)
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"-n", "team-a", "--namespaced", "--image-tag", "v0.7.0"})
+		assert.NoError(t, cmd.Execute())
+		assert.Contains(t, b.String(), "kind: RoleBinding")
+		assert.Contains(t, b.String(), "image: quay.io/numaproj/numaflow:v0.7.0")
+		cmd.SetArgs([]string{"-n", "Team_A"})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+	})
+}
+
+func TestBufferWatch(t *testing.T) {
+	pending := int64(10)
+	calls := 0
+	list := func(context.Context) ([]*daemonpb.BufferInfo, error) {
+		calls++
+		if calls == 2 {
+			return nil, errors.New("connection refused")
+		}
+		pending += 5
+		return []*daemonpb.BufferInfo{
+			{BufferName: pointer.String("ns-pl-in-cat"), FromVertex: pointer.String("in"), ToVertex: pointer.String("cat"), PendingCount: &pending, AckPendingCount: new(int64), BufferUsage: new(float64), IsFull: new(bool)},
+			{BufferName: pointer.String("ns-pl-cat-out"), FromVertex: pointer.String("cat"), ToVertex: pointer.String("out"), PendingCount: new(int64), AckPendingCount: new(int64), BufferUsage: new(float64), IsFull: new(bool)},
+		}, nil
+	}
+	b := bytes.NewBufferString("")
+	bw := &bufferWatch{title: "Every 1s: buffers of pipeline ns/pl", selectors: []string{"in"}, previous: map[string]int64{}}
+	assert.NoError(t, bw.run(context.Background(), b, list, time.Millisecond, 3))
+	output := b.String()
+	assert.Contains(t, output, "ns-pl-in-cat  in -> cat  15       -")
+	assert.Contains(t, output, "ERROR: failed to query the buffers from the daemon server, connection refused")
+	assert.Contains(t, output, "ns-pl-in-cat  in -> cat  20       +5")
+	assert.NotContains(t, output, "ns-pl-cat-out")
+	assert.Equal(t, 3, calls)
+
+	bw = &bufferWatch{selectors: []string{"nonono"}, previous: map[string]int64{}}
+	b.Reset()
+	bw.print(b, nil, nil, time.Now())
+	assert.Contains(t, b.String(), "No buffers selected.")
+}
+
+func TestCapturedMessages(t *testing.T) {
+	messages := []*isb.Message{
+		{Header: isb.Header{ID: "1", Key: []byte("k"), ContentEncoding: "gzip"}, Body: isb.Body{Payload: []byte{0, 1, 2}}},
+		{Header: isb.Header{ID: "2"}, Body: isb.Body{Payload: []byte("{}")}},
+	}
+	b := bytes.NewBufferString("")
+	assert.NoError(t, writeCapturedMessages(b, messages))
+	read, err := readCapturedMessages(b)
+	assert.NoError(t, err)
+	assert.Equal(t, messages, read)
+	_, err = readCapturedMessages(bytes.NewBufferString("{}\nxxx"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode message 2")
 }
 
 func generateEncodedVertexSpecs() string {
@@ -147,3 +652,24 @@ func generateEncodedVertexSpecs() string {
 	vertexBytes, _ := json.Marshal(v)
 	return base64.StdEncoding.EncodeToString(vertexBytes)
 }
+
+func TestISBSvcConfigFromEnv(t *testing.T) {
+	_ = os.Unsetenv(dfv1.EnvISBSvcConfig)
+	c, err := isbSvcConfigFromEnv()
+	assert.NoError(t, err)
+	assert.Nil(t, c.JetStream)
+
+	config := dfv1.BufferServiceConfig{JetStream: &dfv1.JetStreamConfig{
+		URL:    "nats://isbsvc:4222",
+		Mirror: &dfv1.JetStreamMirror{URL: "nats://isbsvc-dr:4222", SourceAPIPrefix: "$JS.primary.API"},
+	}}
+	b, _ := json.Marshal(config)
+	t.Setenv(dfv1.EnvISBSvcConfig, base64.StdEncoding.EncodeToString(b))
+	c, err = isbSvcConfigFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, config, *c)
+
+	t.Setenv(dfv1.EnvISBSvcConfig, "xxx")
+	_, err = isbSvcConfigFromEnv()
+	assert.Error(t, err)
+}
@@ -1,18 +1,35 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	ctrlcmd "github.com/numaproj/numaflow/controllers/cmd"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/spf13/cobra"
 )
 
 func NewControllerCommand() *cobra.Command {
+	var (
+		featureGates            string
+		jetStreamMirrorFailover bool
+		namespaced              bool
+	)
 
 	command := &cobra.Command{
 		Use:   "controller",
 		Short: "Start a numaflow controller",
-		Run: func(cmd *cobra.Command, args []string) {
-			ctrlcmd.Start()
+		RunE: func(cmd *cobra.Command, args []string) error {
+			gates, err := dfv1.ParseFeatureGates(featureGates)
+			if err != nil {
+				return err
+			}
+			ctrlcmd.Start(gates, jetStreamMirrorFailover, namespaced)
+			return nil
 		},
 	}
+	command.Flags().StringVar(&featureGates, "feature-gates", "", fmt.Sprintf("Default feature gates of the pipelines, e.g. %s=true, known feature gates: %s", dfv1.FeatureGateExactlyOnce, strings.Join(dfv1.KnownFeatureGates(), ",")))
+	command.Flags().BoolVar(&jetStreamMirrorFailover, "jetstream-mirror-failover", false, "Create the new JetStream buffers sourcing the messages of their mirrors, used when failing over to the secondary JetStream of the mirrors")
+	command.Flags().BoolVar(&namespaced, "namespaced", false, "Only watch the objects of the namespace the controller runs in, for the installations without the cluster-wide permissions")
 	return command
 }
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/numaproj/numaflow/pkg/client/clientset/versioned"
+	"github.com/numaproj/numaflow/pkg/doctor"
+)
+
+func NewDoctorCommand() *cobra.Command {
+	var (
+		namespace  string
+		pipeline   string
+		kubeconfig string
+	)
+
+	command := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose a pipeline, and print the problems found with the actions to take",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pipeline == "" {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("pipeline not supplied")
+			}
+			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+			loadingRules.ExplicitPath = kubeconfig
+			clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+			if namespace == "" {
+				ns, _, err := clientConfig.Namespace()
+				if err != nil {
+					return fmt.Errorf("failed to get the namespace, %w", err)
+				}
+				namespace = ns
+			}
+			restConfig, err := clientConfig.ClientConfig()
+			if err != nil {
+				return fmt.Errorf("failed to get the kubeconfig, %w", err)
+			}
+			kubeClient, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create the kubernetes client, %w", err)
+			}
+			client, err := versioned.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create the numaflow client, %w", err)
+			}
+			findings := doctor.NewDoctor(kubeClient, client).Diagnose(context.Background(), namespace, pipeline)
+			if errs := doctor.Print(cmd.OutOrStdout(), findings); errs > 0 {
+				return fmt.Errorf("%d error(s) found", errs)
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the pipeline, defaults to the namespace of the current context")
+	command.Flags().StringVar(&pipeline, "pipeline", "", "Name of the pipeline to diagnose")
+	command.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to $KUBECONFIG or ~/.kube/config")
+	return command
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/numaproj/numaflow"
+	"github.com/numaproj/numaflow/pkg/install"
+)
+
+func NewInstallCommand() *cobra.Command {
+	var (
+		namespace     string
+		namespaced    bool
+		imageRegistry string
+		imageTag      string
+		webhook       bool
+		apply         bool
+		kubeconfig    string
+	)
+
+	command := &cobra.Command{
+		Use:   "install",
+		Short: "Print the installation manifests of the controller and the webhook with the chosen options, or apply them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if imageTag == "" {
+				imageTag = defaultImageTag()
+			}
+			objs, err := install.Render(
+				install.WithNamespace(namespace),
+				install.WithNamespaced(namespaced),
+				install.WithImageRegistry(imageRegistry),
+				install.WithImageTag(imageTag),
+				install.WithWebhook(webhook),
+			)
+			if err != nil {
+				return err
+			}
+			if !apply {
+				return install.Write(cmd.OutOrStdout(), objs)
+			}
+			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+			loadingRules.ExplicitPath = kubeconfig
+			restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).ClientConfig()
+			if err != nil {
+				return fmt.Errorf("failed to get the kubeconfig, %w", err)
+			}
+			return install.Apply(context.Background(), restConfig, objs, func(obj *unstructured.Unstructured) {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s/%s applied\n", obj.GetKind(), obj.GetName())
+			})
+		},
+	}
+	command.Flags().StringVarP(&namespace, "namespace", "n", install.DefaultNamespace, "Namespace to install numaflow in, it needs to exist")
+	command.Flags().BoolVar(&namespaced, "namespaced", false, "Install the controller with the permissions of the namespace only, watching the objects of the namespace")
+	command.Flags().StringVar(&imageRegistry, "image-registry", "", "Registry replacing the ones of the numaflow image and the built-in images, e.g. my-registry.example.com/mirror")
+	command.Flags().StringVar(&imageTag, "image-tag", "", "Tag of the numaflow image, defaults to the version of this binary")
+	command.Flags().BoolVar(&webhook, "webhook", false, "Install the validating webhook as well, it requires cert-manager")
+	command.Flags().BoolVar(&apply, "apply", false, "Apply the manifests to the cluster instead of printing them")
+	command.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to $KUBECONFIG or ~/.kube/config")
+	return command
+}
+
+// defaultImageTag returns the version of this binary if it's a release, otherwise latest.
+func defaultImageTag() string {
+	if v := numaflow.GetVersion(); v.GitTag != "" && semver.IsValid(v.Version) {
+		return v.Version
+	}
+	return "latest"
+}
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/client/daemon"
+)
+
+func NewISBSvcCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "isbsvc",
+		Short: "Inspect the inter-step buffers",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+	bufferCommand := &cobra.Command{
+		Use:   "buffer",
+		Short: "Inspect the buffers of a pipeline",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+	bufferCommand.AddCommand(NewISBSvcBufferWatchCommand())
+	command.AddCommand(bufferCommand)
+	return command
+}
+
+func NewISBSvcBufferWatchCommand() *cobra.Command {
+	var (
+		flags    = &pipelineFlags{}
+		interval time.Duration
+		count    int
+	)
+	command := &cobra.Command{
+		Use:   "watch PIPELINE [BUFFER|VERTEX...]",
+		Short: "Watch the pending messages and the usage of the buffers of a pipeline, refreshed periodically",
+		Long: `Watch the pending messages and the usage of the buffers of a pipeline, refreshed periodically.
+
+The buffers are selected by the buffer names, or the names of the vertices writing to or reading from them, all the
+buffers are watched if none is selected. The changes of the pending messages since the last refresh are shown in the
+DELTA column.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < time.Second {
+				return fmt.Errorf("interval should be at least 1s")
+			}
+			restConfig, _, namespace, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			pipeline := args[0]
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			var c *daemon.DaemonClient
+			defer func() {
+				if c != nil {
+					_ = c.Close()
+				}
+			}()
+			// The port-forward is kept open across the refreshes, and reconnected after a failure.
+			list := func(ctx context.Context) ([]*daemonpb.BufferInfo, error) {
+				if c == nil {
+					if c, err = daemon.NewDaemonServiceClientWithPortForward(ctx, restConfig, namespace, pipeline); err != nil {
+						c = nil
+						return nil, err
+					}
+				}
+				buffers, err := c.ListPipelineBuffers(ctx, pipeline)
+				if err != nil {
+					_ = c.Close()
+					c = nil
+				}
+				return buffers, err
+			}
+			w := cmd.OutOrStdout()
+			watch := &bufferWatch{
+				title:     fmt.Sprintf("Every %v: buffers of pipeline %s/%s", interval, namespace, pipeline),
+				selectors: args[1:],
+				clear:     isTerminal(w),
+				previous:  map[string]int64{},
+			}
+			return watch.run(ctx, w, list, interval, count)
+		},
+	}
+	flags.addTo(command, false)
+	command.Flags().DurationVar(&interval, "interval", 3*time.Second, "Interval of refreshing the buffers")
+	command.Flags().IntVar(&count, "count", 0, "Number of refreshes before exiting, 0 means until interrupted")
+	return command
+}
+
+// bufferWatch renders the buffers of a pipeline periodically.
+type bufferWatch struct {
+	title string
+	// selectors are the names of the buffers or the vertices to watch, all the buffers if empty
+	selectors []string
+	// clear clears the screen before each refresh
+	clear bool
+	// previous are the pending messages of the last refresh, keyed by the buffer names
+	previous map[string]int64
+}
+
+// run refreshes the buffers every interval until the context is done, or the refreshes reach the count if it's
+// greater than 0. A failed refresh is shown and retried at the next interval.
+func (bw *bufferWatch) run(ctx context.Context, w io.Writer, list func(context.Context) ([]*daemonpb.BufferInfo, error), interval time.Duration, count int) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := 1; ; i++ {
+		listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		buffers, err := list(listCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if bw.clear {
+			_, _ = fmt.Fprint(w, "\033[H\033[2J")
+		} else if i > 1 {
+			_, _ = fmt.Fprintln(w)
+		}
+		bw.print(w, buffers, err, time.Now())
+		if count > 0 && i >= count {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (bw *bufferWatch) print(w io.Writer, buffers []*daemonpb.BufferInfo, err error, now time.Time) {
+	_, _ = fmt.Fprintf(w, "%s    %s\n\n", bw.title, now.Format(time.RFC3339))
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "ERROR: failed to query the buffers from the daemon server, %v\n", err)
+		return
+	}
+	buffers = bw.selected(buffers)
+	sort.Slice(buffers, func(i, j int) bool { return buffers[i].GetBufferName() < buffers[j].GetBufferName() })
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "BUFFER\tEDGE\tPENDING\tDELTA\tACK PENDING\tUSAGE\tFULL")
+	for _, b := range buffers {
+		delta := "-"
+		if p, ok := bw.previous[b.GetBufferName()]; ok {
+			delta = fmt.Sprintf("%+d", b.GetPendingCount()-p)
+		}
+		bw.previous[b.GetBufferName()] = b.GetPendingCount()
+		_, _ = fmt.Fprintf(tw, "%s\t%s -> %s\t%d\t%s\t%d\t%.1f%%\t%t\n", b.GetBufferName(), b.GetFromVertex(), b.GetToVertex(), b.GetPendingCount(), delta, b.GetAckPendingCount(), b.GetBufferUsage()*100, b.GetIsFull())
+	}
+	_ = tw.Flush()
+	if len(buffers) == 0 {
+		_, _ = fmt.Fprintln(w, "No buffers selected.")
+	}
+}
+
+func (bw *bufferWatch) selected(buffers []*daemonpb.BufferInfo) []*daemonpb.BufferInfo {
+	if len(bw.selectors) == 0 {
+		return buffers
+	}
+	var result []*daemonpb.BufferInfo
+	for _, b := range buffers {
+		for _, s := range bw.selectors {
+			if s == b.GetBufferName() || s == b.GetFromVertex() || s == b.GetToVertex() {
+				result = append(result, b)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// isTerminal tells if the writer is a terminal, where the screen is cleared before each refresh.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -6,9 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isbsvc"
@@ -19,8 +23,13 @@ import (
 func NewISBSvcBufferCreateCommand() *cobra.Command {
 
 	var (
-		isbSvcType string
-		buffers    []string
+		isbSvcType     string
+		buffers        []string
+		deliverPolicy  string
+		startTime      string
+		purgeBefore    string
+		mirrorFailover bool
+		streamFlags    = &streamConfigFlags{}
 	)
 
 	command := &cobra.Command{
@@ -36,31 +45,51 @@ func NewISBSvcBufferCreateCommand() *cobra.Command {
 			if !defined {
 				return fmt.Errorf("required environment variable '%s' not defined", v1alpha1.EnvPipelineName)
 			}
-			isbSvcConfig := &v1alpha1.BufferServiceConfig{}
-			encodedBufferServiceConfig := os.Getenv(v1alpha1.EnvISBSvcConfig)
-			if len(encodedBufferServiceConfig) > 0 {
-				isbSvcConfigStr, err := base64.StdEncoding.DecodeString(encodedBufferServiceConfig)
+			isbSvcConfig, err := isbSvcConfigFromEnv()
+			if err != nil {
+				return err
+			}
+			var replayStartTime time.Time
+			if startTime != "" {
+				t, err := time.Parse(time.RFC3339, startTime)
 				if err != nil {
-					return fmt.Errorf("failed to decode ISB Svc config string, %w", err)
+					return fmt.Errorf("invalid start time %q, %w", startTime, err)
 				}
-				if err := json.Unmarshal(isbSvcConfigStr, isbSvcConfig); err != nil {
-					return fmt.Errorf("failed to unmarshal ISB Svc config, %w", err)
+				replayStartTime = t
+			}
+			opts := []isbsvc.BufferCreateOption{isbsvc.WithReplayPolicy(v1alpha1.DeliverPolicy(deliverPolicy), replayStartTime)}
+			if purgeBefore != "" {
+				t, err := time.Parse(time.RFC3339, purgeBefore)
+				if err != nil {
+					return fmt.Errorf("invalid purge before time %q, %w", purgeBefore, err)
 				}
+				opts = append(opts, isbsvc.WithPurgeBefore(t))
 			}
-			opts := []isbsvc.BufferCreateOption{}
 			var isbsClient isbsvc.ISBService
-			var err error
 			ctx := logging.WithLogger(context.Background(), logger)
 			switch v1alpha1.ISBSvcType(isbSvcType) {
 			case v1alpha1.ISBSvcTypeRedis:
 				isbsClient = isbsvc.NewISBRedisSvc(clients.NewInClusterRedisClient())
+				if isbSvcConfig.Redis != nil {
+					opts = append(opts, isbsvc.WithRedisConfig(isbSvcConfig.Redis))
+				}
 			case v1alpha1.ISBSvcTypeJetStream:
-				isbsClient, err = isbsvc.NewISBJetStreamSvc(pipelineName)
+				isbsClient, err = isbsvc.NewISBJetStreamSvc(pipelineName, isbsvc.WithJetStreamMirror(isbSvcConfig.JetStream.Mirror))
 				if err != nil {
 					logger.Errorw("Failed to get a ISB Service client.", zap.Error(err))
 					return err
 				}
-				opts = append(opts, isbsvc.WithBufferConfig(isbSvcConfig.JetStream.BufferConfig))
+				opts = append(opts, isbsvc.WithBufferConfig(isbSvcConfig.JetStream.BufferConfig), isbsvc.WithMirrorFailover(mirrorFailover))
+				streamConfig, err := streamFlags.override(cmd.Flags(), isbSvcConfig.JetStream.Stream)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, isbsvc.WithStreamConfig(streamConfig))
+			case v1alpha1.ISBSvcTypeKafka:
+				isbsClient = isbsvc.NewISBKafkaSvc(clients.NewInClusterKafkaClient())
+				if isbSvcConfig.Kafka != nil {
+					opts = append(opts, isbsvc.WithBufferConfig(isbSvcConfig.Kafka.BufferConfig))
+				}
 			default:
 				cmd.HelpFunc()(cmd, args)
 				return fmt.Errorf("unsupported isb service type %q", isbSvcType)
@@ -76,5 +105,76 @@ func NewISBSvcBufferCreateCommand() *cobra.Command {
 	}
 	command.Flags().StringVar(&isbSvcType, "isbsvc-type", "jetstream", "ISB Service type, e.g. jetstream")
 	command.Flags().StringSliceVar(&buffers, "buffers", []string{}, "Buffers to create") // --buffers=xxa,xxb --buffers=xxc
+	command.Flags().StringVar(&deliverPolicy, "deliver-policy", "", "Where the consumers start reading from, DeliverAll, DeliverNew or ByStartTime, defaults to DeliverAll")
+	command.Flags().StringVar(&startTime, "start-time", "", "Start time in RFC3339 format, required by the ByStartTime deliver policy")
+	command.Flags().StringVar(&purgeBefore, "purge-before", "", "Time in RFC3339 format, the existing buffers created or last written before it are purged instead of being adopted")
+	command.Flags().BoolVar(&mirrorFailover, "mirror-failover", false, "Create the new JetStream streams sourcing the messages of their mirrors, used when failing over to the secondary JetStream of the mirrors")
+	command.Flags().Int32Var(&streamFlags.replicas, "stream-replicas", 0, "Replicas of each JetStream stream, overrides the one of the ISB Service")
+	command.Flags().StringVar(&streamFlags.storage, "stream-storage", "", "Storage of the JetStream streams, File or Memory, overrides the one of the ISB Service")
+	command.Flags().StringVar(&streamFlags.maxBytes, "stream-max-bytes", "", "Max size of each JetStream stream, e.g. 10Gi, overrides the one of the ISB Service")
+	command.Flags().DurationVar(&streamFlags.maxAge, "stream-max-age", 0, "How long the messages are kept in each JetStream stream, overrides the one of the ISB Service")
 	return command
 }
+
+// isbSvcConfigFromEnv returns the ISB Service config encoded in the environment variable, it's empty if not set.
+func isbSvcConfigFromEnv() (*v1alpha1.BufferServiceConfig, error) {
+	isbSvcConfig := &v1alpha1.BufferServiceConfig{}
+	encodedBufferServiceConfig := os.Getenv(v1alpha1.EnvISBSvcConfig)
+	if len(encodedBufferServiceConfig) > 0 {
+		isbSvcConfigStr, err := base64.StdEncoding.DecodeString(encodedBufferServiceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode ISB Svc config string, %w", err)
+		}
+		if err := json.Unmarshal(isbSvcConfigStr, isbSvcConfig); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ISB Svc config, %w", err)
+		}
+	}
+	return isbSvcConfig, nil
+}
+
+// streamConfigFlags are the flags overriding the stream config of a JetStream ISB Service.
+type streamConfigFlags struct {
+	replicas int32
+	storage  string
+	maxBytes string
+	maxAge   time.Duration
+}
+
+// override returns a copy of the stream config with the flags set on the command line, it's nil if neither is set.
+func (f *streamConfigFlags) override(flags *pflag.FlagSet, sc *v1alpha1.JetStreamStreamConfig) (*v1alpha1.JetStreamStreamConfig, error) {
+	result := &v1alpha1.JetStreamStreamConfig{}
+	if sc != nil {
+		result = sc.DeepCopy()
+	}
+	if flags.Changed("stream-replicas") {
+		if f.replicas < 1 || f.replicas > 5 {
+			return nil, fmt.Errorf("stream replicas should be between 1 and 5, got %d", f.replicas)
+		}
+		result.Replicas = &f.replicas
+	}
+	if flags.Changed("stream-storage") {
+		switch storage := v1alpha1.JetStreamStorageType(f.storage); storage {
+		case v1alpha1.JetStreamStorageFile, v1alpha1.JetStreamStorageMemory:
+			result.Storage = storage
+		default:
+			return nil, fmt.Errorf("unsupported stream storage %q", f.storage)
+		}
+	}
+	if flags.Changed("stream-max-bytes") {
+		q, err := resource.ParseQuantity(f.maxBytes)
+		if err != nil || q.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid stream max bytes %q", f.maxBytes)
+		}
+		result.MaxBytes = &q
+	}
+	if flags.Changed("stream-max-age") {
+		if f.maxAge <= 0 {
+			return nil, fmt.Errorf("stream max age should be greater than 0, got %v", f.maxAge)
+		}
+		result.MaxAge = &metav1.Duration{Duration: f.maxAge}
+	}
+	if sc == nil && *result == (v1alpha1.JetStreamStreamConfig{}) {
+		return nil, nil
+	}
+	return result, nil
+}
@@ -33,18 +33,27 @@ func NewISBSvcBufferDeleteCommand() *cobra.Command {
 			if !defined {
 				return fmt.Errorf("required environment variable '%s' not defined", v1alpha1.EnvPipelineName)
 			}
+			isbSvcConfig, err := isbSvcConfigFromEnv()
+			if err != nil {
+				return err
+			}
 			var isbsClient isbsvc.ISBService
-			var err error
 			ctx := logging.WithLogger(context.Background(), logger)
 			switch v1alpha1.ISBSvcType(isbSvcType) {
 			case v1alpha1.ISBSvcTypeRedis:
 				isbsClient = isbsvc.NewISBRedisSvc(clients.NewInClusterRedisClient())
 			case v1alpha1.ISBSvcTypeJetStream:
-				isbsClient, err = isbsvc.NewISBJetStreamSvc(pipelineName)
+				var jsOpts []isbsvc.JSServiceOption
+				if isbSvcConfig.JetStream != nil {
+					jsOpts = append(jsOpts, isbsvc.WithJetStreamMirror(isbSvcConfig.JetStream.Mirror))
+				}
+				isbsClient, err = isbsvc.NewISBJetStreamSvc(pipelineName, jsOpts...)
 				if err != nil {
 					logger.Errorw("Failed to get a ISB Service client.", zap.Error(err))
 					return err
 				}
+			case v1alpha1.ISBSvcTypeKafka:
+				isbsClient = isbsvc.NewISBKafkaSvc(clients.NewInClusterKafkaClient())
 			default:
 				cmd.HelpFunc()(cmd, args)
 				return fmt.Errorf("unsupported isb service type %q", isbSvcType)
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isbsvc"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+func NewISBSvcBufferMigrateCommand() *cobra.Command {
+	var (
+		isbSvcType   string
+		buffers      []string
+		deleteSource bool
+	)
+
+	command := &cobra.Command{
+		Use:   "isbsvc-buffer-migrate",
+		Short: "Migrate the unconsumed messages of ISB Service buffers to new buffers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := logging.NewLogger().Named("isbsvc-buffer-migrate")
+			if len(buffers) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("buffer not supplied")
+			}
+			migrations := [][2]string{}
+			for _, b := range buffers {
+				names := strings.Split(b, ":")
+				if len(names) != 2 || names[0] == "" || names[1] == "" || names[0] == names[1] {
+					return fmt.Errorf("invalid buffer migration %q, expected format is from:to", b)
+				}
+				migrations = append(migrations, [2]string{names[0], names[1]})
+			}
+			pipelineName, defined := os.LookupEnv(v1alpha1.EnvPipelineName)
+			if !defined {
+				return fmt.Errorf("required environment variable '%s' not defined", v1alpha1.EnvPipelineName)
+			}
+			var isbsClient isbsvc.ISBService
+			var err error
+			ctx := logging.WithLogger(context.Background(), logger)
+			switch v1alpha1.ISBSvcType(isbSvcType) {
+			case v1alpha1.ISBSvcTypeRedis:
+				isbsClient = isbsvc.NewISBRedisSvc(clients.NewInClusterRedisClient())
+			case v1alpha1.ISBSvcTypeJetStream:
+				isbsClient, err = isbsvc.NewISBJetStreamSvc(pipelineName)
+				if err != nil {
+					logger.Errorw("Failed to get a ISB Service client.", zap.Error(err))
+					return err
+				}
+			case v1alpha1.ISBSvcTypeKafka:
+				isbsClient = isbsvc.NewISBKafkaSvc(clients.NewInClusterKafkaClient())
+			default:
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("unsupported isb service type %q", isbSvcType)
+			}
+			for _, m := range migrations {
+				if err = isbsClient.MigrateBuffer(ctx, m[0], m[1]); err != nil {
+					logger.Errorw("Failed buffer migration.", zap.String("from", m[0]), zap.String("to", m[1]), zap.Error(err))
+					return err
+				}
+				if deleteSource {
+					if err = isbsClient.DeleteBuffers(ctx, []string{m[0]}); err != nil {
+						logger.Errorw("Failed source buffer deletion.", zap.String("from", m[0]), zap.Error(err))
+						return err
+					}
+				}
+			}
+			logger.Info("Migrated buffers successfully")
+			return nil
+		},
+	}
+	command.Flags().StringVar(&isbSvcType, "isbsvc-type", "jetstream", "ISB Service type, e.g. jetstream")
+	command.Flags().StringSliceVar(&buffers, "buffers", []string{}, "Buffers to migrate in the format of from:to") // --buffers=xxa:xxb,xxc:xxd --buffers=xxe:xxf
+	command.Flags().BoolVar(&deleteSource, "delete-source", false, "Whether to delete the source buffers after the migration")
+	return command
+}
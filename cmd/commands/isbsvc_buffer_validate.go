@@ -2,7 +2,9 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -19,8 +21,9 @@ import (
 func NewISBSvcBufferValidateCommand() *cobra.Command {
 
 	var (
-		isbSvcType string
-		buffers    []string
+		isbSvcType      string
+		buffers         []string
+		skipConfigDrift bool
 	)
 
 	command := &cobra.Command{
@@ -36,8 +39,12 @@ func NewISBSvcBufferValidateCommand() *cobra.Command {
 			if !existing {
 				return fmt.Errorf("environment variable %q not existing", v1alpha1.EnvPipelineName)
 			}
+			isbSvcConfig, err := isbSvcConfigFromEnv()
+			if err != nil {
+				return err
+			}
 			var isbsClient isbsvc.ISBService
-			var err error
+			var opts []isbsvc.BufferValidateOption
 			ctx := logging.WithLogger(context.Background(), logger)
 			switch v1alpha1.ISBSvcType(isbSvcType) {
 			case v1alpha1.ISBSvcTypeRedis:
@@ -48,26 +55,54 @@ func NewISBSvcBufferValidateCommand() *cobra.Command {
 					logger.Errorw("Failed to get a ISB Service client.", zap.Error(err))
 					return err
 				}
+				if isbSvcConfig.JetStream != nil && !skipConfigDrift {
+					opts = append(opts, isbsvc.WithExpectedBufferConfig(isbSvcConfig.JetStream.BufferConfig), isbsvc.WithExpectedStreamConfig(isbSvcConfig.JetStream.Stream))
+				}
+			case v1alpha1.ISBSvcTypeKafka:
+				isbsClient = isbsvc.NewISBKafkaSvc(clients.NewInClusterKafkaClient())
+				if isbSvcConfig.Kafka != nil && !skipConfigDrift {
+					opts = append(opts, isbsvc.WithExpectedBufferConfig(isbSvcConfig.Kafka.BufferConfig))
+				}
 			default:
 				cmd.HelpFunc()(cmd, args)
 				return fmt.Errorf("unsupported isb service type")
 			}
 
+			var result *isbsvc.BufferValidationResult
 			if err := wait.ExponentialBackoffWithContext(ctx, sharedutil.DefaultRetryBackoff, func() (bool, error) {
-				if err := isbsClient.ValidateBuffers(ctx, buffers); err != nil {
-					logger.Errorw("Buffers validation failed, will retry if the limit is not reached", zap.Error(err))
+				if result, err = isbsClient.ValidateBuffers(ctx, buffers, opts...); err != nil {
+					return false, err
+				}
+				if failed := result.Failed(); len(failed) > 0 {
+					logger.Errorw("Buffers validation failed, will retry if the limit is not reached", zap.Any("failedChecks", failed))
 					return false, nil
 				}
 				return true, nil
 			}); err != nil {
+				if result != nil {
+					printBufferValidationResult(cmd.OutOrStdout(), result)
+					if resultErr := result.Err(); resultErr != nil {
+						err = resultErr
+					}
+				}
 				logger.Errorw("Failed buffer validation after retrying.", zap.Error(err))
 				return err
 			}
+			printBufferValidationResult(cmd.OutOrStdout(), result)
 			logger.Info("Validate buffers successfully")
 			return nil
 		},
 	}
 	command.Flags().StringVar(&isbSvcType, "isbsvc-type", "jetstream", "ISB Service type, e.g. jetstream")
 	command.Flags().StringSliceVar(&buffers, "buffers", []string{}, "Buffers to validate") // --buffers=xxa,xxb --buffers=xxc
+	command.Flags().BoolVar(&skipConfigDrift, "skip-config-drift", false, "Do not fail the validation if the buffers have drifted from the config of the ISB Service, e.g. the replicas of the JetStream streams")
 	return command
 }
+
+// printBufferValidationResult prints the result of the buffer validation in JSON, one line per check.
+func printBufferValidationResult(w io.Writer, result *isbsvc.BufferValidationResult) {
+	for _, c := range result.Checks {
+		data, _ := json.Marshal(c)
+		_, _ = fmt.Fprintln(w, string(data))
+	}
+}
@@ -0,0 +1,348 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/client/clientset/versioned"
+	"github.com/numaproj/numaflow/pkg/client/daemon"
+)
+
+func NewPipelineCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "pipeline",
+		Short: "Inspect the pipelines",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+	command.AddCommand(NewPipelineListCommand())
+	command.AddCommand(NewPipelineGetCommand())
+	command.AddCommand(NewPipelineTopologyCommand())
+	return command
+}
+
+// pipelineFlags are the flags to connect to the cluster shared by the pipeline commands.
+type pipelineFlags struct {
+	namespace  string
+	kubeconfig string
+	// buffers queries the buffers from the daemon server of the pipeline
+	buffers bool
+}
+
+func (f *pipelineFlags) addTo(command *cobra.Command, withBuffers bool) {
+	command.Flags().StringVarP(&f.namespace, "namespace", "n", "", "Namespace of the pipelines, defaults to the namespace of the current context")
+	command.Flags().StringVar(&f.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to $KUBECONFIG or ~/.kube/config")
+	if withBuffers {
+		command.Flags().BoolVar(&f.buffers, "buffers", true, "Show the pending messages of the buffers, queried from the daemon server of the pipeline")
+	}
+}
+
+// connect returns the rest config, the numaflow client and the namespace.
+func (f *pipelineFlags) connect() (*rest.Config, versioned.Interface, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = f.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	namespace := f.namespace
+	if namespace == "" {
+		ns, _, err := clientConfig.Namespace()
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to get the namespace, %w", err)
+		}
+		namespace = ns
+	}
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to get the kubeconfig, %w", err)
+	}
+	client, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to create the numaflow client, %w", err)
+	}
+	return restConfig, client, namespace, nil
+}
+
+func NewPipelineListCommand() *cobra.Command {
+	flags := &pipelineFlags{}
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the pipelines",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, client, namespace, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			pipelines, err := client.NumaflowV1alpha1().Pipelines(namespace).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list the pipelines, %w", err)
+			}
+			printPipelineList(cmd.OutOrStdout(), pipelines.Items, time.Now())
+			return nil
+		},
+	}
+	flags.addTo(command, false)
+	return command
+}
+
+func NewPipelineGetCommand() *cobra.Command {
+	flags := &pipelineFlags{}
+	command := &cobra.Command{
+		Use:   "get PIPELINE",
+		Short: "Print the status of a pipeline, its vertices and buffers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pl, vertices, buffers, err := getPipelineResources(cmd, flags, args[0])
+			if err != nil {
+				return err
+			}
+			printPipeline(cmd.OutOrStdout(), pl, vertices, buffers, time.Now())
+			return nil
+		},
+	}
+	flags.addTo(command, true)
+	return command
+}
+
+func NewPipelineTopologyCommand() *cobra.Command {
+	flags := &pipelineFlags{}
+	command := &cobra.Command{
+		Use:   "topology PIPELINE",
+		Short: "Render the vertices and edges of a pipeline as a tree, with the replicas and the pending messages",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pl, vertices, buffers, err := getPipelineResources(cmd, flags, args[0])
+			if err != nil {
+				return err
+			}
+			printPipelineTopology(cmd.OutOrStdout(), pl, vertices, buffers)
+			return nil
+		},
+	}
+	flags.addTo(command, true)
+	return command
+}
+
+// edgeKey identifies an edge by the names of its vertices.
+type edgeKey struct {
+	from string
+	to   string
+}
+
+// getPipelineResources gets the pipeline and its vertices keyed by the vertex names, as well as its buffers keyed by
+// the edges if they are queried. A daemon server not reachable is reported as a warning, as the pipeline could be
+// paused or failing.
+func getPipelineResources(cmd *cobra.Command, flags *pipelineFlags, name string) (*dfv1.Pipeline, map[string]*dfv1.Vertex, map[edgeKey]*daemonpb.BufferInfo, error) {
+	ctx := context.Background()
+	restConfig, client, namespace, err := flags.connect()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pl, err := client.NumaflowV1alpha1().Pipelines(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get the pipeline, %w", err)
+	}
+	vertexList, err := client.NumaflowV1alpha1().Vertices(namespace).List(ctx, metav1.ListOptions{LabelSelector: dfv1.KeyPipelineName + "=" + name})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list the vertices, %w", err)
+	}
+	vertices := make(map[string]*dfv1.Vertex, len(vertexList.Items))
+	for i := range vertexList.Items {
+		vertices[vertexList.Items[i].Spec.Name] = &vertexList.Items[i]
+	}
+	if !flags.buffers {
+		return pl, vertices, nil, nil
+	}
+	buffers, err := listPipelineBuffers(ctx, restConfig, namespace, name)
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: failed to query the buffers from the daemon server, %v\n", err)
+		return pl, vertices, nil, nil
+	}
+	return pl, vertices, buffers, nil
+}
+
+// listPipelineBuffers queries the buffers of the pipeline from its daemon server through a port-forward.
+func listPipelineBuffers(ctx context.Context, restConfig *rest.Config, namespace, pipeline string) (map[edgeKey]*daemonpb.BufferInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	c, err := daemon.NewDaemonServiceClientWithPortForward(ctx, restConfig, namespace, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.Close() }()
+	buffers, err := c.ListPipelineBuffers(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[edgeKey]*daemonpb.BufferInfo, len(buffers))
+	for _, b := range buffers {
+		result[edgeKey{from: b.GetFromVertex(), to: b.GetToVertex()}] = b
+	}
+	return result, nil
+}
+
+func printPipelineList(w io.Writer, pipelines []dfv1.Pipeline, now time.Time) {
+	sort.Slice(pipelines, func(i, j int) bool { return pipelines[i].Name < pipelines[j].Name })
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tPHASE\tVERTICES\tAGE\tMESSAGE")
+	for _, pl := range pipelines {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", pl.Name, orDash(string(pl.Status.Phase)), len(pl.Spec.Vertices), age(pl.CreationTimestamp, now), pl.Status.Message)
+	}
+	_ = tw.Flush()
+}
+
+func printPipeline(w io.Writer, pl *dfv1.Pipeline, vertices map[string]*dfv1.Vertex, buffers map[edgeKey]*daemonpb.BufferInfo, now time.Time) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "Name:\t%s\n", pl.Name)
+	_, _ = fmt.Fprintf(tw, "Namespace:\t%s\n", pl.Namespace)
+	_, _ = fmt.Fprintf(tw, "Phase:\t%s\n", orDash(string(pl.Status.Phase)))
+	if pl.Status.Message != "" {
+		_, _ = fmt.Fprintf(tw, "Message:\t%s\n", pl.Status.Message)
+	}
+	_, _ = fmt.Fprintf(tw, "Age:\t%s\n", age(pl.CreationTimestamp, now))
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "VERTEX\tTYPE\tREPLICAS\tPHASE\tMESSAGE")
+	for _, av := range pl.Spec.Vertices {
+		v := vertices[av.Name]
+		if v == nil {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t-\tNotCreated\t\n", av.Name, vertexType(av))
+			continue
+		}
+		message := v.Status.Message
+		if e := v.Status.LastError; e != nil {
+			message = fmt.Sprintf("%s/%s: %s %s", e.Pod, e.Container, e.Reason, e.Message)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", av.Name, vertexType(av), replicas(v), orDash(string(v.Status.Phase)), strings.TrimSpace(message))
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "EDGE\tPENDING\tACK PENDING\tUSAGE\tFULL")
+	for _, e := range pl.Spec.Edges {
+		name := e.From + " -> " + e.To
+		b := buffers[edgeKey{from: e.From, to: e.To}]
+		if b == nil {
+			_, _ = fmt.Fprintf(tw, "%s\t-\t-\t-\t-\n", name)
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f%%\t%t\n", name, b.GetPendingCount(), b.GetAckPendingCount(), b.GetBufferUsage()*100, b.GetIsFull())
+	}
+	_ = tw.Flush()
+}
+
+// printPipelineTopology renders the pipeline as trees from the source vertices, a vertex with multiple inbound edges
+// is only expanded the first time it's reached.
+func printPipelineTopology(w io.Writer, pl *dfv1.Pipeline, vertices map[string]*dfv1.Vertex, buffers map[edgeKey]*daemonpb.BufferInfo) {
+	abstractVertices := make(map[string]dfv1.AbstractVertex, len(pl.Spec.Vertices))
+	for _, av := range pl.Spec.Vertices {
+		abstractVertices[av.Name] = av
+	}
+	summary := func(name string) string {
+		av := abstractVertices[name]
+		v := vertices[name]
+		if v == nil {
+			return fmt.Sprintf("%s [%s] not created", name, vertexType(av))
+		}
+		return fmt.Sprintf("%s [%s] %s %s", name, vertexType(av), replicas(v), orDash(string(v.Status.Phase)))
+	}
+	outbound := make(map[string][]dfv1.Edge)
+	inbound := make(map[string]bool)
+	for _, e := range pl.Spec.Edges {
+		outbound[e.From] = append(outbound[e.From], e)
+		inbound[e.To] = true
+	}
+	expanded := make(map[string]bool)
+	var walk func(name, prefix string)
+	walk = func(name, prefix string) {
+		expanded[name] = true
+		edges := outbound[name]
+		for i, e := range edges {
+			branch, indent := "├─▶ ", "│   "
+			if i == len(edges)-1 {
+				branch, indent = "└─▶ ", "    "
+			}
+			line := prefix + branch + summary(e.To) + edgeSummary(e, buffers[edgeKey{from: e.From, to: e.To}])
+			if expanded[e.To] {
+				_, _ = fmt.Fprintln(w, line+" (see above)")
+				continue
+			}
+			_, _ = fmt.Fprintln(w, line)
+			walk(e.To, prefix+indent)
+		}
+	}
+	for _, av := range pl.Spec.Vertices {
+		if !inbound[av.Name] {
+			_, _ = fmt.Fprintln(w, summary(av.Name))
+			walk(av.Name, "")
+		}
+	}
+}
+
+// edgeSummary describes the conditions and the buffer of an edge.
+func edgeSummary(e dfv1.Edge, b *daemonpb.BufferInfo) string {
+	var parts []string
+	if e.Conditions != nil && len(e.Conditions.KeyIn) > 0 {
+		parts = append(parts, "keyIn: "+strings.Join(e.Conditions.KeyIn, ","))
+	}
+	if b != nil {
+		s := fmt.Sprintf("pending: %d, ack pending: %d", b.GetPendingCount(), b.GetAckPendingCount())
+		if b.GetIsFull() {
+			s += ", full"
+		}
+		parts = append(parts, s)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
+
+func vertexType(av dfv1.AbstractVertex) string {
+	switch {
+	case av.Source != nil:
+		return "source"
+	case av.Sink != nil:
+		return "sink"
+	case av.UDF != nil:
+		return "udf"
+	case av.Reduce != nil:
+		return "reduce"
+	default:
+		return "unknown"
+	}
+}
+
+// replicas returns the current and the desired replicas of the vertex.
+func replicas(v *dfv1.Vertex) string {
+	return fmt.Sprintf("%d/%d", v.Status.Replicas, v.Spec.GetReplicas())
+}
+
+func age(t metav1.Time, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return duration.HumanDuration(now.Sub(t.Time))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
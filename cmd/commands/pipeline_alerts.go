@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	plctrl "github.com/numaproj/numaflow/controllers/pipeline"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func NewPipelineAlertsCommand() *cobra.Command {
+	var (
+		flags = &pipelineFlags{}
+		files []string
+		opts  = plctrl.DefaultAlertOptions()
+	)
+
+	command := &cobra.Command{
+		Use:   "pipeline-alerts [PIPELINE...]",
+		Short: "Print the PrometheusRules with the recommended alerts of the pipelines",
+		Long: `Print the PrometheusRules with the recommended alerts of the pipelines, which are the pipeline failed or degraded,
+the buffer usage above the threshold, the watermark delay above the SLA and the pods crash looping.
+
+The alerts are based on the metrics of the controller, the daemon servers and the vertex pods, as well as the ones of
+kube-state-metrics for the crash looping pods.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && len(files) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("pipeline not supplied")
+			}
+			var pipelines []*dfv1.Pipeline
+			for _, file := range files {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read the pipeline file, %w", err)
+				}
+				pl := &dfv1.Pipeline{}
+				if err := yaml.Unmarshal(data, pl); err != nil {
+					return fmt.Errorf("failed to parse the pipeline file %q, %w", file, err)
+				}
+				if flags.namespace != "" {
+					pl.Namespace = flags.namespace
+				}
+				pipelines = append(pipelines, pl)
+			}
+			if len(args) > 0 {
+				_, client, namespace, err := flags.connect()
+				if err != nil {
+					return err
+				}
+				for _, name := range args {
+					pl, err := client.NumaflowV1alpha1().Pipelines(namespace).Get(context.Background(), name, metav1.GetOptions{})
+					if err != nil {
+						return fmt.Errorf("failed to get the pipeline %q, %w", name, err)
+					}
+					pipelines = append(pipelines, pl)
+				}
+			}
+			return printPipelineAlerts(cmd.OutOrStdout(), pipelines, opts)
+		},
+	}
+	flags.addTo(command, false)
+	command.Flags().StringArrayVarP(&files, "file", "f", nil, "Path to a pipeline manifest, read instead of getting the pipeline from the cluster, can be repeated")
+	command.Flags().Float64Var(&opts.BufferUsage, "buffer-usage", opts.BufferUsage, "Usage of a buffer between 0 and 1, above which it's alerted")
+	command.Flags().DurationVar(&opts.WatermarkDelay, "watermark-delay", opts.WatermarkDelay, "SLA of the watermarks of the reduce vertices, a watermark falling behind the current time more than it is alerted")
+	command.Flags().DurationVar(&opts.For, "for", opts.For, "How long a condition lasts before it's alerted")
+	command.Flags().StringToStringVar(&opts.Labels, "labels", nil, "Labels added to all the alerts, e.g. --labels team=data")
+	command.Flags().StringToStringVar(&opts.RuleLabels, "rule-labels", nil, "Labels added to the PrometheusRules, e.g. the ones required by the rule selector of the Prometheus")
+	return command
+}
+
+func printPipelineAlerts(w io.Writer, pipelines []*dfv1.Pipeline, opts plctrl.AlertOptions) error {
+	for _, pl := range pipelines {
+		rule, err := plctrl.BuildAlertRules(pl, opts)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(rule.Object)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "---\n%s", data)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+func NewPipelineAuditCommand() *cobra.Command {
+	var (
+		start  string
+		end    string
+		output string
+		maxIDs int
+	)
+
+	command := &cobra.Command{
+		Use:   "pipeline-audit",
+		Short: "Report the messages lost or duplicated by an audited pipeline within a time range",
+		Long: `Report the messages lost or duplicated by a pipeline in the audit mode, which are the messages emitted by the
+sources within the time range, but not processed by any sink, or processed more than once by a sink. It reads the audit
+log in the ISB Service, so it runs in a pod with the access to the ISB Service, e.g. a vertex pod of the pipeline.
+
+It fails if any message is lost or duplicated, the messages emitted by the end of the time range should have been
+processed by the sinks when it runs.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if start == "" {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("start not supplied")
+			}
+			startTime, err := time.Parse(time.RFC3339, start)
+			if err != nil {
+				return fmt.Errorf("invalid start %q, %w", start, err)
+			}
+			endTime := time.Now()
+			if end != "" {
+				if endTime, err = time.Parse(time.RFC3339, end); err != nil {
+					return fmt.Errorf("invalid end %q, %w", end, err)
+				}
+			}
+			if !endTime.After(startTime) {
+				return fmt.Errorf("end should be after start")
+			}
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unsupported output format %q", output)
+			}
+			pipelineName, defined := os.LookupEnv(dfv1.EnvPipelineName)
+			if !defined {
+				return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvPipelineName)
+			}
+			ctx := logging.WithLogger(context.Background(), logging.NewLogger().Named("pipeline-audit"))
+			conn, err := clients.NewInClusterJetStreamClient().Connect(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to connect to the ISB Service, %w", err)
+			}
+			defer conn.Close()
+			js, err := conn.JetStream()
+			if err != nil {
+				return err
+			}
+			verifier := audit.NewVerifier(startTime, endTime)
+			if err := audit.ReadJetStreamLog(js, pipelineName, startTime, func(r audit.Record) error {
+				verifier.Add(r)
+				return nil
+			}); err != nil {
+				return err
+			}
+			report := verifier.Report()
+			if err := printAuditReport(cmd.OutOrStdout(), report, output, maxIDs); err != nil {
+				return err
+			}
+			if !report.Passed() {
+				return fmt.Errorf("audit failed, %d lost, %d duplicated", len(report.Lost), countDuplicated(report))
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVar(&start, "start", "", "Start of the time range of the emitted messages in RFC3339, e.g. 2022-06-01T10:00:00Z")
+	command.Flags().StringVar(&end, "end", "", "End of the time range of the emitted messages in RFC3339, defaults to now")
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format, text or json")
+	command.Flags().IntVar(&maxIDs, "max-ids", 20, "Maximum number of the lost or duplicated audit IDs printed in the text format")
+	return command
+}
+
+// countDuplicated returns the number of the audit IDs duplicated by any of the sinks.
+func countDuplicated(report audit.Report) int {
+	ids := make(map[string]struct{})
+	for _, counts := range report.Duplicated {
+		for id := range counts {
+			ids[id] = struct{}{}
+		}
+	}
+	return len(ids)
+}
+
+func printAuditReport(w io.Writer, report audit.Report, output string, maxIDs int) error {
+	if output == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	fmt.Fprintf(w, "Time range: %s - %s\n", report.Start.Format(time.RFC3339), report.End.Format(time.RFC3339))
+	fmt.Fprintf(w, "Emitted: %d\n", report.Emitted)
+	sinks := make([]string, 0, len(report.Processed))
+	for sink := range report.Processed {
+		sinks = append(sinks, sink)
+	}
+	sort.Strings(sinks)
+	for _, sink := range sinks {
+		fmt.Fprintf(w, "Processed by %s: %d, duplicated: %d\n", sink, report.Processed[sink], len(report.Duplicated[sink]))
+	}
+	fmt.Fprintf(w, "Lost: %d\n", len(report.Lost))
+	for i, id := range report.Lost {
+		if i == maxIDs {
+			fmt.Fprintf(w, "  ... %d more\n", len(report.Lost)-maxIDs)
+			break
+		}
+		fmt.Fprintf(w, "  %s\n", id)
+	}
+	for _, sink := range sinks {
+		counts := report.Duplicated[sink]
+		if len(counts) == 0 {
+			continue
+		}
+		ids := make([]string, 0, len(counts))
+		for id := range counts {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		fmt.Fprintf(w, "Duplicated by %s: %d\n", sink, len(ids))
+		for i, id := range ids {
+			if i == maxIDs {
+				fmt.Fprintf(w, "  ... %d more\n", len(ids)-maxIDs)
+				break
+			}
+			fmt.Fprintf(w, "  %s x%d\n", id, counts[id])
+		}
+	}
+	if report.Passed() {
+		fmt.Fprintln(w, "PASSED")
+	} else {
+		fmt.Fprintln(w, "FAILED")
+	}
+	return nil
+}
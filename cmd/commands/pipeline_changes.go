@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/client/clientset/versioned"
+)
+
+func NewPipelineChangesCommand() *cobra.Command {
+	var (
+		namespace  string
+		pipeline   string
+		kubeconfig string
+		confirm    bool
+	)
+
+	command := &cobra.Command{
+		Use:   "pipeline-changes",
+		Short: "Print the disruptive changes of a pipeline waiting for confirmation, and confirm them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pipeline == "" {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("pipeline not supplied")
+			}
+			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+			loadingRules.ExplicitPath = kubeconfig
+			clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+			if namespace == "" {
+				ns, _, err := clientConfig.Namespace()
+				if err != nil {
+					return fmt.Errorf("failed to get the namespace, %w", err)
+				}
+				namespace = ns
+			}
+			restConfig, err := clientConfig.ClientConfig()
+			if err != nil {
+				return fmt.Errorf("failed to get the kubeconfig, %w", err)
+			}
+			client, err := versioned.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create the numaflow client, %w", err)
+			}
+			ctx := context.Background()
+			pl, err := client.NumaflowV1alpha1().Pipelines(namespace).Get(ctx, pipeline, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to get the pipeline, %w", err)
+			}
+			changes := pl.Status.PendingChanges
+			if changes == nil {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No pending changes.")
+				return nil
+			}
+			printPlannedChanges(cmd.OutOrStdout(), changes)
+			if !confirm {
+				return nil
+			}
+			body, err := json.Marshal(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{dfv1.KeyConfirmedChanges: changes.Hash},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := client.NumaflowV1alpha1().Pipelines(namespace).Patch(ctx, pipeline, types.MergePatchType, body, metav1.PatchOptions{}); err != nil {
+				return fmt.Errorf("failed to confirm the changes, %w", err)
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Changes confirmed.")
+			return nil
+		},
+	}
+	command.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the pipeline, defaults to the namespace of the current context")
+	command.Flags().StringVar(&pipeline, "pipeline", "", "Name of the pipeline")
+	command.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to $KUBECONFIG or ~/.kube/config")
+	command.Flags().BoolVar(&confirm, "confirm", false, "Confirm the pending changes to apply them")
+	return command
+}
+
+func printPlannedChanges(w io.Writer, changes *dfv1.PlannedChanges) {
+	printChanges := func(action, kind string, names []string) {
+		for _, n := range names {
+			_, _ = fmt.Fprintf(w, "%-8s %-10s %s\n", action, kind, n)
+		}
+	}
+	printChanges("create", "buffer", changes.BuffersToCreate)
+	printChanges("delete", "buffer", changes.BuffersToDelete)
+	migrations := []string{}
+	for from, to := range changes.BuffersToMigrate {
+		migrations = append(migrations, from+" -> "+to)
+	}
+	sort.Strings(migrations)
+	printChanges("migrate", "buffer", migrations)
+	printChanges("create", "vertex", changes.VerticesToCreate)
+	printChanges("update", "vertex", changes.VerticesToUpdate)
+	printChanges("delete", "vertex", changes.VerticesToDelete)
+	printChanges("create", "deployment", changes.DeploymentsToCreate)
+	printChanges("update", "deployment", changes.DeploymentsToUpdate)
+	if changes.Omitted > 0 {
+		_, _ = fmt.Fprintf(w, "... and %d more changes not listed\n", changes.Omitted)
+	}
+	_, _ = fmt.Fprintf(w, "\nHash: %s\n", changes.Hash)
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	plctrl "github.com/numaproj/numaflow/controllers/pipeline"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/client/clientset/versioned"
+)
+
+func NewPipelineRBACCommand() *cobra.Command {
+	var (
+		namespace  string
+		pipeline   string
+		kubeconfig string
+		file       string
+	)
+
+	command := &cobra.Command{
+		Use:   "pipeline-rbac",
+		Short: "Print the minimal Role and RoleBinding required by the service accounts of a pipeline",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if pipeline == "" && file == "" {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("pipeline not supplied")
+			}
+			var pl *dfv1.Pipeline
+			if file != "" {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read the pipeline file, %w", err)
+				}
+				pl = &dfv1.Pipeline{}
+				if err := yaml.Unmarshal(data, pl); err != nil {
+					return fmt.Errorf("failed to parse the pipeline file, %w", err)
+				}
+				if namespace != "" {
+					pl.Namespace = namespace
+				}
+			} else {
+				loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+				loadingRules.ExplicitPath = kubeconfig
+				clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+				if namespace == "" {
+					ns, _, err := clientConfig.Namespace()
+					if err != nil {
+						return fmt.Errorf("failed to get the namespace, %w", err)
+					}
+					namespace = ns
+				}
+				restConfig, err := clientConfig.ClientConfig()
+				if err != nil {
+					return fmt.Errorf("failed to get the kubeconfig, %w", err)
+				}
+				client, err := versioned.NewForConfig(restConfig)
+				if err != nil {
+					return fmt.Errorf("failed to create the numaflow client, %w", err)
+				}
+				if pl, err = client.NumaflowV1alpha1().Pipelines(namespace).Get(context.Background(), pipeline, metav1.GetOptions{}); err != nil {
+					return fmt.Errorf("failed to get the pipeline, %w", err)
+				}
+			}
+			return printPipelineRBAC(cmd.OutOrStdout(), pl)
+		},
+	}
+	command.Flags().StringVarP(&namespace, "namespace", "n", "", "Namespace of the pipeline, defaults to the namespace of the current context")
+	command.Flags().StringVar(&pipeline, "pipeline", "", "Name of the pipeline")
+	command.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to $KUBECONFIG or ~/.kube/config")
+	command.Flags().StringVarP(&file, "file", "f", "", "Path to a pipeline manifest, read instead of getting the pipeline from the cluster")
+	return command
+}
+
+func printPipelineRBAC(w io.Writer, pl *dfv1.Pipeline) error {
+	serviceAccounts, err := plctrl.GetServiceAccounts(pl)
+	if err != nil {
+		return err
+	}
+	role, binding, err := plctrl.BuildRBAC(pl)
+	if err != nil {
+		return err
+	}
+	components := make([]string, 0, len(serviceAccounts))
+	for c := range serviceAccounts {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+	_, _ = fmt.Fprintf(w, "# Service accounts of pipeline %q:\n", pl.Name)
+	for _, c := range components {
+		_, _ = fmt.Fprintf(w, "#   %-20s %s\n", c, serviceAccounts[c])
+	}
+	for _, obj := range []interface{}{role, binding} {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "---\n%s", data)
+	}
+	return nil
+}
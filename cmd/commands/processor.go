@@ -1,18 +1,19 @@
 package commands
 
 import (
-	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 
+	"github.com/numaproj/numaflow"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/numaproj/numaflow/pkg/sinks"
 	"github.com/numaproj/numaflow/pkg/sources"
 	"github.com/numaproj/numaflow/pkg/udf"
+	"github.com/numaproj/numaflow/pkg/udf/reduce"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 )
 
@@ -26,64 +27,111 @@ func NewProcessorCommand() *cobra.Command {
 		Use:   "processor",
 		Short: "Start a processor",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			log := logging.NewLogger().Named(fmt.Sprintf("%s-processor", processorType))
-			encodedVertex, defined := os.LookupEnv(dfv1.EnvVertexObject)
-			if !defined {
-				return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvVertexObject)
-			}
-			vertexBytes, err := base64.StdEncoding.DecodeString(encodedVertex)
-			if err != nil {
-				return fmt.Errorf("failed to decode vertex string, error: %w", err)
-			}
-			vertex := &dfv1.Vertex{}
-			if err = json.Unmarshal(vertexBytes, vertex); err != nil {
-				return fmt.Errorf("failed to unmarshal vertex object, error: %w", err)
-			}
-			hostname, defined := os.LookupEnv(dfv1.EnvPod)
-			if !defined {
-				return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvPod)
-			}
-			replicaStr, defined := os.LookupEnv(dfv1.EnvReplica)
-			if !defined {
-				return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvReplica)
-			}
-			replica, err := strconv.Atoi(replicaStr)
-			if err != nil {
-				return fmt.Errorf("invalid replica %q", replicaStr)
-			}
-			log = log.With("vertex", vertex.Name)
-			ctx := logging.WithLogger(signals.SetupSignalHandler(), log)
-			switch processorType {
-			case "source":
-				p := &sources.SourceProcessor{
-					ISBSvcType: dfv1.ISBSvcType(isbSvcType),
-					Vertex:     vertex,
-					Hostname:   hostname,
-					Replica:    replica,
-				}
-				return p.Start(ctx)
-			case "sink":
-				p := &sinks.SinkProcessor{
-					ISBSvcType: dfv1.ISBSvcType(isbSvcType),
-					Vertex:     vertex,
-					Hostname:   hostname,
-					Replica:    replica,
-				}
-				return p.Start(ctx)
-			case "udf":
-				p := &udf.UDFProcessor{
-					ISBSvcType: dfv1.ISBSvcType(isbSvcType),
-					Vertex:     vertex,
-					Hostname:   hostname,
-					Replica:    replica,
-				}
-				return p.Start(ctx)
-			default:
-				return fmt.Errorf("unrecognized processor type %q", processorType)
+			if err := startProcessor(processorType, isbSvcType); err != nil {
+				reportFatalError(err)
+				return err
 			}
+			return nil
 		},
 	}
-	command.Flags().StringVar(&processorType, "type", "", "Processor type, 'source', 'sink' or 'udf'")
+	command.Flags().StringVar(&processorType, "type", "", "Processor type, 'source', 'sink', 'udf' or 'reduce'")
 	command.Flags().StringVar(&isbSvcType, "isbsvc-type", "", "ISB Service type, e.g. jetstream")
 	return command
 }
+
+func startProcessor(processorType, isbSvcType string) error {
+	log := logging.NewLogger().Named(fmt.Sprintf("%s-processor", processorType))
+	encodedVertex, defined := os.LookupEnv(dfv1.EnvVertexObject)
+	if !defined {
+		return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvVertexObject)
+	}
+	encodingVersion, err := dfv1.ParseVertexEncodingVersion(os.Getenv(dfv1.EnvVertexObjectVersion))
+	if err != nil {
+		return err
+	}
+	vertex, unknownFields, err := dfv1.DecodeVertex(encodedVertex)
+	if err != nil {
+		return err
+	}
+	if len(unknownFields) > 0 {
+		// Typically the vertex is encoded by a newer controller, carry on with the fields this version understands
+		log.Warnw("Ignored the vertex spec fields not supported by this version", zap.Strings("fields", unknownFields), zap.Int("encodingVersion", encodingVersion), zap.Int("supportedEncodingVersion", dfv1.VertexEncodingVersion))
+	}
+	hostname, defined := os.LookupEnv(dfv1.EnvPod)
+	if !defined {
+		return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvPod)
+	}
+	replicaStr, defined := os.LookupEnv(dfv1.EnvReplica)
+	if !defined {
+		return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvReplica)
+	}
+	replica, err := strconv.Atoi(replicaStr)
+	if err != nil {
+		return fmt.Errorf("invalid replica %q", replicaStr)
+	}
+	log = log.With("vertex", vertex.Name)
+	if err := checkVersionSkew(log); err != nil {
+		return err
+	}
+	ctx := logging.WithLogger(signals.SetupSignalHandler(), log)
+	switch processorType {
+	case "source":
+		p := &sources.SourceProcessor{
+			ISBSvcType: dfv1.ISBSvcType(isbSvcType),
+			Vertex:     vertex,
+			Hostname:   hostname,
+			Replica:    replica,
+		}
+		return p.Start(ctx)
+	case "sink":
+		p := &sinks.SinkProcessor{
+			ISBSvcType: dfv1.ISBSvcType(isbSvcType),
+			Vertex:     vertex,
+			Hostname:   hostname,
+			Replica:    replica,
+		}
+		return p.Start(ctx)
+	case "udf":
+		p := &udf.UDFProcessor{
+			ISBSvcType: dfv1.ISBSvcType(isbSvcType),
+			Vertex:     vertex,
+			Hostname:   hostname,
+			Replica:    replica,
+		}
+		return p.Start(ctx)
+	case "reduce":
+		p := &reduce.ReduceProcessor{
+			ISBSvcType: dfv1.ISBSvcType(isbSvcType),
+			Vertex:     vertex,
+			Hostname:   hostname,
+			Replica:    replica,
+		}
+		return p.Start(ctx)
+	default:
+		return fmt.Errorf("unrecognized processor type %q", processorType)
+	}
+}
+
+// reportFatalError writes the error to the termination message of the container, which the vertex controller
+// reports in the vertex status.
+func reportFatalError(err error) {
+	_ = os.WriteFile(dfv1.PathTerminationMessage, []byte(err.Error()), 0644)
+}
+
+// checkVersionSkew compares the version of this binary with the one expected by the controller which created the pod,
+// an incompatible version is refused unless the version skew policy is "warn".
+func checkVersionSkew(log *zap.SugaredLogger) error {
+	expected := os.Getenv(dfv1.EnvExpectedVersion)
+	if expected == "" {
+		// Created by a controller without the version skew check
+		return nil
+	}
+	if err := numaflow.CheckVersionSkew(expected); err != nil {
+		if dfv1.VersionSkewPolicy(os.Getenv(dfv1.EnvVersionSkewPolicy)) == dfv1.VersionSkewPolicyWarn {
+			log.Warnw("Running with an incompatible version, the vertex could misbehave until the upgrade completes", zap.Error(err))
+			return nil
+		}
+		return fmt.Errorf("%w, refusing to run, upgrade the image or set the version skew policy to %q", err, dfv1.VersionSkewPolicyWarn)
+	}
+	return nil
+}
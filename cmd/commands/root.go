@@ -24,6 +24,18 @@ func init() {
 	rootCmd.AddCommand(NewISBSvcBufferCreateCommand())
 	rootCmd.AddCommand(NewISBSvcBufferDeleteCommand())
 	rootCmd.AddCommand(NewISBSvcBufferValidateCommand())
+	rootCmd.AddCommand(NewISBSvcBufferMigrateCommand())
 	rootCmd.AddCommand(NewBuiltinUDFCommand())
+	rootCmd.AddCommand(NewVertexCommand())
 	rootCmd.AddCommand(NewDaemonServerCommand())
+	rootCmd.AddCommand(NewDoctorCommand())
+	rootCmd.AddCommand(NewPipelineChangesCommand())
+	rootCmd.AddCommand(NewPipelineRBACCommand())
+	rootCmd.AddCommand(NewPipelineAlertsCommand())
+	rootCmd.AddCommand(NewPipelineAuditCommand())
+	rootCmd.AddCommand(NewPipelineCommand())
+	rootCmd.AddCommand(NewISBSvcCommand())
+	rootCmd.AddCommand(NewWebhookCommand())
+	rootCmd.AddCommand(NewSideInputsManagerCommand())
+	rootCmd.AddCommand(NewInstallCommand())
 }
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	"github.com/numaproj/numaflow/pkg/sideinputs"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+func NewSideInputsManagerCommand() *cobra.Command {
+	var once bool
+
+	command := &cobra.Command{
+		Use:   "side-inputs-manager",
+		Short: "Keep the side inputs of a vertex refreshed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logging.NewLogger().Named("side-inputs-manager")
+			encodedVertex, defined := os.LookupEnv(dfv1.EnvVertexObject)
+			if !defined {
+				return fmt.Errorf("required environment variable '%s' not defined", dfv1.EnvVertexObject)
+			}
+			vertex, unknownFields, err := dfv1.DecodeVertex(encodedVertex)
+			if err != nil {
+				return err
+			}
+			if len(unknownFields) > 0 {
+				log.Warnw("Ignored the vertex spec fields not supported by this version", zap.Strings("fields", unknownFields))
+			}
+			log = log.With("vertex", vertex.Name)
+			m, err := sideinputs.NewManager(vertex.Spec.SideInputs)
+			if err != nil {
+				return err
+			}
+			ctx := logging.WithLogger(signals.SetupSignalHandler(), log)
+			if once {
+				if err := m.RefreshAll(ctx); err != nil {
+					reportFatalError(err)
+					return err
+				}
+				return nil
+			}
+			m.Start(ctx)
+			return nil
+		},
+	}
+	command.Flags().BoolVar(&once, "once", false, "Refresh the side inputs once and exit, used by the init container")
+	return command
+}
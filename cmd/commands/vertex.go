@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+func NewVertexCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "vertex",
+		Short: "Capture the messages of a vertex and replay them locally, or debug a replica of a vertex",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+	command.AddCommand(NewVertexCaptureCommand())
+	command.AddCommand(NewVertexReplayCommand())
+	command.AddCommand(NewVertexDebugCommand())
+	return command
+}
+
+// writeCapturedMessages writes the messages as JSON lines, with the headers and the payloads.
+func writeCapturedMessages(w io.Writer, messages []*isb.Message) error {
+	encoder := json.NewEncoder(w)
+	for _, m := range messages {
+		if err := encoder.Encode(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readCapturedMessages reads the messages written by writeCapturedMessages.
+func readCapturedMessages(r io.Reader) ([]*isb.Message, error) {
+	decoder := json.NewDecoder(r)
+	messages := []*isb.Message{}
+	for {
+		m := &isb.Message{}
+		if err := decoder.Decode(m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return messages, nil
+			}
+			return nil, fmt.Errorf("failed to decode message %d, %w", len(messages)+1, err)
+		}
+		messages = append(messages, m)
+	}
+}
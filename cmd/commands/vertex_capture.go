@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isbsvc"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+func NewVertexCaptureCommand() *cobra.Command {
+	var (
+		isbSvcType string
+		buffer     string
+		count      int
+		file       string
+	)
+
+	command := &cobra.Command{
+		Use:   "capture",
+		Short: "Export the messages not yet consumed from a buffer to a file, without consuming them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := logging.NewLogger().Named("vertex-capture")
+			if buffer == "" {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("buffer not supplied")
+			}
+			if count < 1 {
+				return fmt.Errorf("count should be greater than 0")
+			}
+			pipelineName, defined := os.LookupEnv(v1alpha1.EnvPipelineName)
+			if !defined {
+				return fmt.Errorf("required environment variable '%s' not defined", v1alpha1.EnvPipelineName)
+			}
+			var isbsClient isbsvc.ISBService
+			var err error
+			ctx := logging.WithLogger(context.Background(), logger)
+			switch v1alpha1.ISBSvcType(isbSvcType) {
+			case v1alpha1.ISBSvcTypeRedis:
+				isbsClient = isbsvc.NewISBRedisSvc(clients.NewInClusterRedisClient())
+			case v1alpha1.ISBSvcTypeJetStream:
+				isbsClient, err = isbsvc.NewISBJetStreamSvc(pipelineName)
+				if err != nil {
+					logger.Errorw("Failed to get a ISB Service client.", zap.Error(err))
+					return err
+				}
+			case v1alpha1.ISBSvcTypeKafka:
+				isbsClient = isbsvc.NewISBKafkaSvc(clients.NewInClusterKafkaClient())
+			default:
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("unsupported isb service type %q", isbSvcType)
+			}
+			messages, err := isbsClient.PeekBuffer(ctx, buffer, count)
+			if err != nil {
+				logger.Errorw("Failed to read the buffer.", zap.String("buffer", buffer), zap.Error(err))
+				return err
+			}
+			var w io.Writer = cmd.OutOrStdout()
+			if file != "-" {
+				f, err := os.Create(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			if err := writeCapturedMessages(w, messages); err != nil {
+				return fmt.Errorf("failed to write the messages, %w", err)
+			}
+			logger.Infow("Captured messages successfully", zap.String("buffer", buffer), zap.Int("messages", len(messages)))
+			return nil
+		},
+	}
+	command.Flags().StringVar(&isbSvcType, "isbsvc-type", "jetstream", "ISB Service type, e.g. jetstream")
+	command.Flags().StringVar(&buffer, "buffer", "", "Name of the buffer to capture the messages from")
+	command.Flags().IntVar(&count, "count", 10, "Maximum number of messages to capture")
+	command.Flags().StringVar(&file, "file", "-", "File to write the messages to, - for the standard output")
+	return command
+}
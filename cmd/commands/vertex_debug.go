@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+func NewVertexDebugCommand() *cobra.Command {
+	var (
+		flags   = &pipelineFlags{}
+		replica int
+		image   string
+		timeout time.Duration
+	)
+
+	command := &cobra.Command{
+		Use:   "debug PIPELINE VERTEX",
+		Short: "Add an ephemeral debug container to a replica of a vertex",
+		Long: `Add an ephemeral debug container to a replica of a vertex, sharing the process namespace, the environment variables
+and the volume mounts of the main container, so that it has the same access to the ISB Service. The debug image comes
+with the nats and redis CLIs, and the numaflow binary for the diagnostics, e.g. "numaflow vertex capture".
+
+Ephemeral containers require Kubernetes 1.23 or later.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pipelineName, vertexName := args[0], args[1]
+			if replica < 0 {
+				return fmt.Errorf("replica should not be negative")
+			}
+			restConfig, _, namespace, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create the kubernetes client, %w", err)
+			}
+			ctx := context.Background()
+			selector := labels.SelectorFromSet(map[string]string{dfv1.KeyPipelineName: pipelineName, dfv1.KeyVertexName: vertexName})
+			pods, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				return fmt.Errorf("failed to list the pods of the vertex, %w", err)
+			}
+			pod := findReplicaPod(pods.Items, replica)
+			if pod == nil {
+				return fmt.Errorf("no running pod found for replica %d of vertex %q of pipeline %q", replica, vertexName, pipelineName)
+			}
+			c, err := buildDebugContainer(pod, "debugger-"+sharedutil.RandomLowerCaseString(5), image)
+			if err != nil {
+				return err
+			}
+			pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, c)
+			if _, err := kubeClient.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, pod.Name, pod, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("failed to add the ephemeral container to pod %q, %w", pod.Name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Waiting for the debug container %q of pod %q to start...\n", c.Name, pod.Name)
+			if err := waitForDebugContainer(ctx, kubeClient, namespace, pod.Name, c.Name, timeout); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Attach to the debug container with:\n\n  kubectl -n %s attach -it %s -c %s\n", namespace, pod.Name, c.Name)
+			return nil
+		},
+	}
+	flags.addTo(command, false)
+	command.Flags().IntVar(&replica, "replica", 0, "Replica of the vertex to debug")
+	command.Flags().StringVar(&image, "image", "", "Image of the debug container, defaults to the numaflow-debug image of the same version as the vertex")
+	command.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "How long to wait for the debug container to start")
+	return command
+}
+
+// findReplicaPod returns a running pod of the replica, a ready one is preferred in the middle of a rolling update.
+func findReplicaPod(pods []corev1.Pod, replica int) *corev1.Pod {
+	var result *corev1.Pod
+	for i := range pods {
+		p := &pods[i]
+		if p.Annotations[dfv1.KeyReplica] != strconv.Itoa(replica) || p.DeletionTimestamp != nil || p.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if result == nil || (isPodReady(p) && !isPodReady(result)) {
+			result = p
+		}
+	}
+	return result
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// buildDebugContainer returns the ephemeral debug container targeting the main container of the pod, with the same
+// environment variables, volume mounts and security context. The image defaults to the debug image of the main one.
+func buildDebugContainer(pod *corev1.Pod, name, image string) (corev1.EphemeralContainer, error) {
+	var main *corev1.Container
+	for i, c := range pod.Spec.Containers {
+		if c.Name == dfv1.CtrMain {
+			main = &pod.Spec.Containers[i]
+			break
+		}
+	}
+	if main == nil {
+		return corev1.EphemeralContainer{}, fmt.Errorf("main container not found in pod %q", pod.Name)
+	}
+	if image == "" {
+		image = debugImage(main.Image)
+	}
+	return corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:            name,
+			Image:           image,
+			ImagePullPolicy: main.ImagePullPolicy,
+			Command:         []string{"sh"},
+			Env:             main.Env,
+			EnvFrom:         main.EnvFrom,
+			VolumeMounts:    main.VolumeMounts,
+			SecurityContext: main.SecurityContext.DeepCopy(),
+			Stdin:           true,
+			TTY:             true,
+		},
+		TargetContainerName: dfv1.CtrMain,
+	}, nil
+}
+
+// debugImage returns the debug image of the numaflow image, e.g. "quay.io/numaproj/numaflow-debug:v0.5.0" for
+// "quay.io/numaproj/numaflow:v0.5.0". The digest is dropped, since it's the digest of the numaflow image.
+func debugImage(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i] + "-debug" + image[i:]
+	}
+	return image + "-debug"
+}
+
+// waitForDebugContainer waits until the ephemeral container is running.
+func waitForDebugContainer(ctx context.Context, kubeClient kubernetes.Interface, namespace, podName, name string, timeout time.Duration) error {
+	var lastState corev1.ContainerState
+	err := wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		pod, err := kubeClient.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, s := range pod.Status.EphemeralContainerStatuses {
+			if s.Name != name {
+				continue
+			}
+			lastState = s.State
+			if s.State.Terminated != nil {
+				return false, fmt.Errorf("debug container %q terminated, %s", name, s.State.Terminated.Reason)
+			}
+			return s.State.Running != nil, nil
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		if w := lastState.Waiting; w != nil {
+			return fmt.Errorf("timed out waiting for debug container %q to start, %s: %s", name, w.Reason, w.Message)
+		}
+		return fmt.Errorf("timed out waiting for debug container %q to start", name)
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+	"github.com/numaproj/numaflow/pkg/udf/applier"
+	"github.com/numaproj/numaflow/pkg/udf/builtin"
+	"github.com/numaproj/numaflow/pkg/udf/plugin"
+)
+
+func NewVertexReplayCommand() *cobra.Command {
+	var (
+		file         string
+		vertexFile   string
+		udfSocket    string
+		wasmModule   string
+		readyTimeout time.Duration
+	)
+
+	command := &cobra.Command{
+		Use:   "replay",
+		Short: "Run the UDF of a vertex locally against the captured messages, and print the outputs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				cmd.HelpFunc()(cmd, args)
+				return fmt.Errorf("file not supplied")
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			messages, err := readCapturedMessages(f)
+			if err != nil {
+				return err
+			}
+			udf := &dfv1.UDF{}
+			if vertexFile != "" {
+				data, err := ioutil.ReadFile(vertexFile)
+				if err != nil {
+					return err
+				}
+				vertex := &dfv1.Vertex{}
+				if err := yaml.Unmarshal(data, vertex); err != nil {
+					return fmt.Errorf("failed to decode the vertex, %w", err)
+				}
+				if vertex.Spec.UDF == nil {
+					return fmt.Errorf("vertex %q is not a UDF vertex", vertex.Spec.Name)
+				}
+				udf = vertex.Spec.UDF
+			}
+			log := logging.NewLogger().Named("vertex-replay")
+			ctx := logging.WithLogger(context.Background(), log)
+			udfHandler, closeFn, err := newReplayApplier(ctx, udf, udfSocket, wasmModule, readyTimeout)
+			if err != nil {
+				return err
+			}
+			defer closeFn()
+			if failed := replayMessages(ctx, cmd.OutOrStdout(), udfHandler, messages); failed > 0 {
+				return fmt.Errorf("%d of %d messages failed", failed, len(messages))
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVar(&file, "file", "", "File of the messages captured with 'vertex capture'")
+	command.Flags().StringVar(&vertexFile, "vertex", "", "Vertex object in YAML or JSON, e.g. the output of 'kubectl get vertex <name> -o yaml', the UDF is served on the socket if it's not specified")
+	command.Flags().StringVar(&udfSocket, "udf-socket", dfv1.PathVarRun+"/udf.sock", "Unix domain socket of the UDF server running locally, used if the UDF is a container")
+	command.Flags().StringVar(&wasmModule, "wasm-module", "", "Local path of the WebAssembly module, overrides the one in the vertex")
+	command.Flags().DurationVar(&readyTimeout, "ready-timeout", 30*time.Second, "How long to wait for the UDF server to be ready")
+	return command
+}
+
+// newReplayApplier returns the applier of the UDF, and a function to release it.
+func newReplayApplier(ctx context.Context, udf *dfv1.UDF, udfSocket, wasmModule string, readyTimeout time.Duration) (applier.Applier, func(), error) {
+	if x := udf.Builtin; x != nil {
+		b := &builtin.Builtin{Name: x.Name, Args: x.Args, KWArgs: x.KWArgs}
+		handle, err := b.Handle()
+		if err != nil {
+			return nil, nil, err
+		}
+		return applier.NewInProcessUDF(handle), func() {}, nil
+	} else if x := udf.Plugin; x != nil {
+		handle, err := plugin.Lookup(x.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		return applier.NewInProcessUDF(handle), func() {}, nil
+	} else if x := udf.WASM; x != nil || wasmModule != "" {
+		path := wasmModule
+		if path == "" {
+			path = x.GetPath()
+		}
+		module, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read wasm module, %w", err)
+		}
+		wasmHandler, err := applier.NewWASMUDF(ctx, module, 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return wasmHandler, func() { _ = wasmHandler.Close(context.Background()) }, nil
+	}
+	httpHandler := applier.NewUDSHTTPBasedUDF(udfSocket)
+	readyCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	if err := httpHandler.WaitUntilReady(readyCtx); err != nil {
+		return nil, nil, fmt.Errorf("failed on UDF readiness check, %w", err)
+	}
+	return httpHandler, func() {}, nil
+}
+
+// replayMessages applies the UDF on the messages one by one, prints the outputs, and returns the number of failed messages.
+func replayMessages(ctx context.Context, w io.Writer, udfHandler applier.Applier, messages []*isb.Message) int {
+	failed := 0
+	for i, m := range messages {
+		offset := strconv.Itoa(i)
+		readMessage := &isb.ReadMessage{
+			Message:    *m,
+			ReadOffset: isb.SimpleOffset(func() string { return offset }),
+		}
+		_, _ = fmt.Fprintf(w, "[%d] id=%s key=%q", i+1, m.ID, m.Key)
+		if readMessage.ContentEncoding != "" {
+			payload, err := sharedutil.Decompress(readMessage.ContentEncoding, readMessage.Payload)
+			if err != nil {
+				_, _ = fmt.Fprintf(w, "\n    => error: failed to decompress the payload, %v\n", err)
+				failed++
+				continue
+			}
+			readMessage.Payload, readMessage.ContentEncoding = payload, ""
+		}
+		_, _ = fmt.Fprintf(w, " payload=%q%s\n", readMessage.Payload, formatMetadata(readMessage.Metadata))
+		writeMessages, err := udfHandler.Apply(ctx, readMessage)
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "    => error: %v\n", err)
+			failed++
+			continue
+		}
+		for _, o := range writeMessages {
+			_, _ = fmt.Fprintf(w, "    => key=%q payload=%q%s\n", o.Key, o.Payload, formatMetadata(o.Metadata))
+		}
+	}
+	return failed
+}
+
+// formatMetadata formats the metadata of a message to be appended to the output, it's empty if there is no metadata.
+func formatMetadata(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" metadata=%v", metadata)
+}
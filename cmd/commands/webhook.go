@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	"github.com/numaproj/numaflow/pkg/webhook"
+)
+
+func NewWebhookCommand() *cobra.Command {
+	var (
+		port    int
+		certDir string
+	)
+
+	command := &cobra.Command{
+		Use:   "webhook",
+		Short: "Start the validating admission webhook server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := logging.NewLogger().Named("webhook")
+			ctx := logging.WithLogger(signals.SetupSignalHandler(), logger)
+			return webhook.NewWebhookServer(port, certDir).Run(ctx)
+		},
+	}
+	command.Flags().IntVar(&port, "port", 8443, "Port of the webhook server")
+	command.Flags().StringVar(&certDir, "cert-dir", "/etc/numaflow/webhook-certs", "Directory of the tls.crt and tls.key of the webhook server")
+	return command
+}
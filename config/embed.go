@@ -0,0 +1,8 @@
+// Package config embeds the installation manifests, so that the install command renders them with the chosen options
+// instead of them being copied and hand-edited.
+package config
+
+import "embed"
+
+//go:embed base/*.yaml base/crds/*.yaml base/controller-manager/*.yaml cluster-install/*.yaml cluster-install/rbac/*.yaml extensions/webhook/*.yaml
+var Manifests embed.FS
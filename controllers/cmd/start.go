@@ -17,6 +17,7 @@ limitations under the License.
 package controllers
 
 import (
+	"fmt"
 	"reflect"
 
 	numaflow "github.com/numaproj/numaflow"
@@ -24,6 +25,7 @@ import (
 	isbsvcctrl "github.com/numaproj/numaflow/controllers/isbsvc"
 	plctrl "github.com/numaproj/numaflow/controllers/pipeline"
 	vertexctrl "github.com/numaproj/numaflow/controllers/vertex"
+	"github.com/numaproj/numaflow/controllers/vertex/scaling"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	logging "github.com/numaproj/numaflow/pkg/shared/logging"
 	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
@@ -31,16 +33,18 @@ import (
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-func Start() {
+func Start(featureGates map[string]bool, jetStreamMirrorFailover bool, namespaced bool) {
 	logger := logging.NewLogger().Named("controller-manager")
 	config, err := controllers.LoadConfig(func(err error) {
 		logger.Errorf("Failed to reload global configuration file", zap.Error(err))
@@ -48,6 +52,8 @@ func Start() {
 	if err != nil {
 		logger.Fatalw("Failed to load global configuration file", zap.Error(err))
 	}
+	config.FeatureGates = featureGates
+	config.JetStreamMirrorFailover = jetStreamMirrorFailover
 
 	image := sharedutil.LookupEnvStringOr(dfv1.EnvImage, "")
 	if image == "" {
@@ -58,6 +64,16 @@ func Start() {
 		MetricsBindAddress:     ":9090",
 		HealthProbeBindAddress: ":8081",
 	}
+	if namespaced {
+		opts.Namespace = sharedutil.LookupEnvStringOr("NAMESPACE", "")
+		if opts.Namespace == "" {
+			logger.Fatal("ENV NAMESPACE not found")
+		}
+		// The namespaces are not readable in a namespaced installation, they are read without the cache, so that the
+		// forbidden errors are tolerated instead of the informer failing to sync.
+		opts.ClientDisableCacheFor = []client.Object{&corev1.Namespace{}}
+		logger.Infow("Watching the objects of the namespace only", zap.String("namespace", opts.Namespace))
+	}
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), opts)
 	if err != nil {
 		logger.Fatalw("Unable to get a controller-runtime manager", zap.Error(err))
@@ -76,38 +92,64 @@ func Start() {
 		logger.Fatalw("Unable to add scheme", zap.Error(err))
 	}
 
+	if err := SetupControllers(mgr, config, image, logger); err != nil {
+		logger.Fatalw("Unable to set up the controllers", zap.Error(err))
+	}
+
+	// ISB Svc watchdog
+	// watchdog, err := controller.New(dfv1.ControllerWatchdog, mgr, controller.Options{
+	// 	Reconciler: watchdogctrl.NewReconciler(mgr.GetClient(), mgr.GetScheme(), config, logger),
+	// })
+	// if err != nil {
+	// 	logger.Fatalw("Unable to set up Watchdog", zap.Error(err))
+	// }
+
+	// // Watch Pods
+	// if err := watchdog.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{OwnerType: &appv1.StatefulSet{}, IsController: true}); err != nil {
+	// 	logger.Fatalw("Unable to watch pods", zap.Error(err))
+	// }
+
+	logger.Infow("Starting controller manager", "version", numaflow.GetVersion())
+	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+		logger.Fatalw("Unable to run controller manager", zap.Error(err))
+	}
+}
+
+// SetupControllers sets up all the controllers with the manager, including the vertex auto scaler. The scheme of the
+// manager needs to have the numaflow types added.
+func SetupControllers(mgr manager.Manager, config *controllers.GlobalConfig, image string, logger *zap.SugaredLogger) error {
 	isbSvcController, err := controller.New(dfv1.ControllerISBSvc, mgr, controller.Options{
 		Reconciler: isbsvcctrl.NewReconciler(mgr.GetClient(), mgr.GetScheme(), config, logger),
 	})
 	if err != nil {
-		logger.Fatalw("Unable to set up ISB controller", zap.Error(err))
+		return fmt.Errorf("unable to set up ISB controller, %w", err)
 	}
 
 	if err := isbSvcController.Watch(&source.Kind{Type: &dfv1.InterStepBufferService{}}, &handler.EnqueueRequestForObject{},
 		predicate.Or(
 			predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{},
 		)); err != nil {
-		logger.Fatalw("Unable to watch InterStepBuffer", zap.Error(err))
+		return fmt.Errorf("unable to watch InterStepBuffer, %w", err)
 	}
 
 	// Watch ConfigMaps with ResourceVersion changes, and enqueue owning InterStepBuffer key
 	if err := isbSvcController.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.InterStepBufferService{}, IsController: true}, predicate.ResourceVersionChangedPredicate{}); err != nil {
-		logger.Fatalw("Unable to watch ConfigMaps", zap.Error(err))
+		return fmt.Errorf("unable to watch ConfigMaps, %w", err)
 	}
 
 	// Watch Secrets with ResourceVersion changes, and enqueue owning InterStepBuffer key
 	if err := isbSvcController.Watch(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.InterStepBufferService{}, IsController: true}, predicate.ResourceVersionChangedPredicate{}); err != nil {
-		logger.Fatalw("Unable to watch Secrets", zap.Error(err))
+		return fmt.Errorf("unable to watch Secrets, %w", err)
 	}
 
 	// Watch StatefulSets with Generation changes, and enqueue owning InterStepBuffer key
 	if err := isbSvcController.Watch(&source.Kind{Type: &appv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.InterStepBufferService{}, IsController: true}, predicate.GenerationChangedPredicate{}); err != nil {
-		logger.Fatalw("Unable to watch StatefulSets", zap.Error(err))
+		return fmt.Errorf("unable to watch StatefulSets, %w", err)
 	}
 
 	// Watch Services with ResourceVersion changes, and enqueue owning InterStepBuffer key
 	if err := isbSvcController.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.InterStepBufferService{}, IsController: true}, predicate.ResourceVersionChangedPredicate{}); err != nil {
-		logger.Fatalw("Unable to watch Services", zap.Error(err))
+		return fmt.Errorf("unable to watch Services, %w", err)
 	}
 
 	// Pipeline controller
@@ -115,41 +157,74 @@ func Start() {
 		Reconciler: plctrl.NewReconciler(mgr.GetClient(), mgr.GetScheme(), config, image, logger),
 	})
 	if err != nil {
-		logger.Fatalw("Unable to set up Pipeline controller", zap.Error(err))
+		return fmt.Errorf("unable to set up Pipeline controller, %w", err)
 	}
 
 	// Watch Pipelines
 	if err := pipelineController.Watch(&source.Kind{Type: &dfv1.Pipeline{}}, &handler.EnqueueRequestForObject{},
 		predicate.Or(
-			predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{},
+			predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{},
 		)); err != nil {
-		logger.Fatalw("Unable to watch Pipelines", zap.Error(err))
+		return fmt.Errorf("unable to watch Pipelines, %w", err)
 	}
 
-	// Watch Vertices with Generation changes (excluding scaling up/down)
-	if err := pipelineController.Watch(&source.Kind{Type: &dfv1.Vertex{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.Pipeline{}, IsController: true}, predicate.And(
-		predicate.GenerationChangedPredicate{},
+	// Watch Vertices with Generation changes (excluding scaling up/down), last error changes, or bounded sources succeeded
+	if err := pipelineController.Watch(&source.Kind{Type: &dfv1.Vertex{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.Pipeline{}, IsController: true}, predicate.Or(
+		predicate.And(
+			predicate.GenerationChangedPredicate{},
+			predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					if e.ObjectOld == nil || e.ObjectNew == nil {
+						return true
+					}
+					old, _ := e.ObjectOld.(*dfv1.Vertex)
+					new, _ := e.ObjectNew.(*dfv1.Vertex)
+					return !reflect.DeepEqual(new.Spec.WithOutReplicas(), old.Spec.WithOutReplicas())
+				}},
+		),
 		predicate.Funcs{
+			CreateFunc:  func(event.CreateEvent) bool { return false },
+			DeleteFunc:  func(event.DeleteEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				if e.ObjectOld == nil || e.ObjectNew == nil {
-					return true
+					return false
 				}
 				old, _ := e.ObjectOld.(*dfv1.Vertex)
 				new, _ := e.ObjectNew.(*dfv1.Vertex)
-				return !reflect.DeepEqual(new.Spec.WithOutReplicas(), old.Spec.WithOutReplicas())
+				if new.Status.Phase == dfv1.VertexPhaseSucceeded && old.Status.Phase != dfv1.VertexPhaseSucceeded {
+					return true
+				}
+				return !reflect.DeepEqual(new.Status.LastError, old.Status.LastError)
 			}},
 	)); err != nil {
-		logger.Fatalw("Unable to watch Vertices", zap.Error(err))
+		return fmt.Errorf("unable to watch Vertices, %w", err)
+	}
+
+	// Watch InterStepBufferServices with readiness changes, and enqueue the Pipelines using them
+	if err := pipelineController.Watch(&source.Kind{Type: &dfv1.InterStepBufferService{}}, plctrl.NewISBSvcEventHandler(mgr.GetClient(), logger), predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+			old, _ := e.ObjectOld.(*dfv1.InterStepBufferService)
+			new, _ := e.ObjectNew.(*dfv1.InterStepBufferService)
+			return old.Status.IsReady() != new.Status.IsReady() || old.Status.Phase != new.Status.Phase
+		}},
+	); err != nil {
+		return fmt.Errorf("unable to watch InterStepBufferServices, %w", err)
 	}
 
 	// Watch Services with ResourceVersion changes
 	if err := pipelineController.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.Pipeline{}, IsController: true}, predicate.ResourceVersionChangedPredicate{}); err != nil {
-		logger.Fatalw("Unable to watch Services", zap.Error(err))
+		return fmt.Errorf("unable to watch Services, %w", err)
 	}
 
 	// Watch Deployments with Genreation changes
 	if err := pipelineController.Watch(&source.Kind{Type: &appv1.Deployment{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.Pipeline{}, IsController: true}, predicate.GenerationChangedPredicate{}); err != nil {
-		logger.Fatalw("Unable to watch Deployments", zap.Error(err))
+		return fmt.Errorf("unable to watch Deployments, %w", err)
 	}
 
 	// Vertex controller
@@ -157,7 +232,7 @@ func Start() {
 		Reconciler: vertexctrl.NewReconciler(mgr.GetClient(), mgr.GetScheme(), config, image, logger),
 	})
 	if err != nil {
-		logger.Fatalw("Unable to set up Vertex controller", zap.Error(err))
+		return fmt.Errorf("unable to set up Vertex controller, %w", err)
 	}
 
 	// Watch Vertices
@@ -165,34 +240,22 @@ func Start() {
 		predicate.Or(
 			predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{},
 		)); err != nil {
-		logger.Fatalw("Unable to watch Vertices", zap.Error(err))
+		return fmt.Errorf("unable to watch Vertices, %w", err)
 	}
 
 	// Watch Pods
 	if err := vertexController.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.Vertex{}, IsController: true}); err != nil {
-		logger.Fatalw("Unable to watch Pods", zap.Error(err))
+		return fmt.Errorf("unable to watch Pods, %w", err)
 	}
 
 	// Watch Services with ResourceVersion changes
 	if err := vertexController.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{OwnerType: &dfv1.Vertex{}, IsController: true}, predicate.ResourceVersionChangedPredicate{}); err != nil {
-		logger.Fatalw("Unable to watch Services", zap.Error(err))
+		return fmt.Errorf("unable to watch Services, %w", err)
 	}
 
-	// ISB Svc watchdog
-	// watchdog, err := controller.New(dfv1.ControllerWatchdog, mgr, controller.Options{
-	// 	Reconciler: watchdogctrl.NewReconciler(mgr.GetClient(), mgr.GetScheme(), config, logger),
-	// })
-	// if err != nil {
-	// 	logger.Fatalw("Unable to set up Watchdog", zap.Error(err))
-	// }
-
-	// // Watch Pods
-	// if err := watchdog.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{OwnerType: &appv1.StatefulSet{}, IsController: true}); err != nil {
-	// 	logger.Fatalw("Unable to watch pods", zap.Error(err))
-	// }
-
-	logger.Infow("Starting controller manager", "version", numaflow.GetVersion())
-	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
-		logger.Fatalw("Unable to run controller manager", zap.Error(err))
+	// Vertex auto scaler
+	if err := mgr.Add(scaling.NewScaler(mgr.GetClient(), logger)); err != nil {
+		return fmt.Errorf("unable to set up vertex auto scaler, %w", err)
 	}
+	return nil
 }
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fsnotify/fsnotify"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
@@ -12,6 +13,17 @@ type GlobalConfig struct {
 	UDF    *UDFConfig    `json:"udf"`
 	Sink   *SinkConfig   `json:"sink"`
 	ISBSvc *ISBSvcConfig `json:"isbsvc"`
+	// RegistryPrefix replaces the registries of all the built-in images, e.g. the numaflow, redis and nats images,
+	// for the air-gapped clusters pulling the images from a mirror.
+	RegistryPrefix string `json:"registryPrefix"`
+	// VersionSkewPolicy is what the vertex pods do if their numaflow version is incompatible with the controller's,
+	// "fail" or "warn", defaults to "fail".
+	VersionSkewPolicy string `json:"versionSkewPolicy"`
+	// FeatureGates are the default feature gates of all the pipelines, set by the controller flag --feature-gates
+	FeatureGates map[string]bool `json:"-" mapstructure:"-"`
+	// JetStreamMirrorFailover makes the new JetStream buffers source the messages of their local mirrors, set by the
+	// controller flag --jetstream-mirror-failover when the pipelines fail over to the secondary JetStream.
+	JetStreamMirrorFailover bool `json:"-" mapstructure:"-"`
 }
 
 type UDFConfig struct {
@@ -91,12 +103,27 @@ func (g *GlobalConfig) GetUDSinkContentType() dfv1.ContentType {
 	}
 }
 
+func (g *GlobalConfig) GetVersionSkewPolicy() dfv1.VersionSkewPolicy {
+	switch dfv1.VersionSkewPolicy(g.VersionSkewPolicy) {
+	case "", dfv1.VersionSkewPolicyFail:
+		return dfv1.VersionSkewPolicyFail
+	case dfv1.VersionSkewPolicyWarn:
+		return dfv1.VersionSkewPolicyWarn
+	default:
+		panic(fmt.Sprintf("Unsupported version skew policy %q", g.VersionSkewPolicy))
+	}
+}
+
 func (g *GlobalConfig) GetRedisVersion(version string) (*RedisVersion, error) {
 	if g.ISBSvc == nil || g.ISBSvc.Redis == nil || len(g.ISBSvc.Redis.Versions) == 0 {
 		return nil, fmt.Errorf("no redis configuration found")
 	}
 	for _, r := range g.ISBSvc.Redis.Versions {
 		if r.Version == version {
+			r.RedisImage = g.GetImage(r.RedisImage)
+			r.SentinelImage = g.GetImage(r.SentinelImage)
+			r.InitContainerImage = g.GetImage(r.InitContainerImage)
+			r.RedisExporterImage = g.GetImage(r.RedisExporterImage)
 			return &r, nil
 		}
 	}
@@ -109,12 +136,30 @@ func (g *GlobalConfig) GetJetStreamVersion(version string) (*JetStreamVersion, e
 	}
 	for _, r := range g.ISBSvc.JetStream.Versions {
 		if r.Version == version {
+			r.NatsImage = g.GetImage(r.NatsImage)
+			r.MetricsExporterImage = g.GetImage(r.MetricsExporterImage)
+			r.ConfigReloaderImage = g.GetImage(r.ConfigReloaderImage)
 			return &r, nil
 		}
 	}
 	return nil, fmt.Errorf("no jetstream configuration found for %q", version)
 }
 
+// GetImage returns the built-in image with its registry replaced by the registry prefix, if it's configured.
+// The images without registries are from Docker Hub, e.g. "nats:2.8.1" becomes "${prefix}/nats:2.8.1".
+func (g *GlobalConfig) GetImage(image string) string {
+	prefix := strings.TrimSuffix(g.RegistryPrefix, "/")
+	if prefix == "" || image == "" {
+		return image
+	}
+	if i := strings.Index(image, "/"); i > 0 {
+		if host := image[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			image = image[i+1:]
+		}
+	}
+	return prefix + "/" + image
+}
+
 func LoadConfig(onErrorReloading func(error)) (*GlobalConfig, error) {
 	v := viper.New()
 	v.SetConfigName("controller-config")
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestGetImage(t *testing.T) {
+	g := &GlobalConfig{}
+	assert.Equal(t, "nats:2.8.1", g.GetImage("nats:2.8.1"))
+	g.RegistryPrefix = "mirror.example.com:5000/numaflow/"
+	for image, expected := range map[string]string{
+		"":                              "",
+		"nats:2.8.1":                    "mirror.example.com:5000/numaflow/nats:2.8.1",
+		"bitnami/redis:6.2":             "mirror.example.com:5000/numaflow/bitnami/redis:6.2",
+		"quay.io/numaproj/numaflow:v1":  "mirror.example.com:5000/numaflow/numaproj/numaflow:v1",
+		"localhost/numaflow:latest":     "mirror.example.com:5000/numaflow/numaflow:latest",
+		"registry:5000/numaflow:latest": "mirror.example.com:5000/numaflow/numaflow:latest",
+	} {
+		assert.Equal(t, expected, g.GetImage(image), image)
+	}
+}
+
+func TestGetVersionImages(t *testing.T) {
+	g := &GlobalConfig{
+		RegistryPrefix: "mirror.local",
+		ISBSvc: &ISBSvcConfig{
+			Redis: &RedisConfig{Versions: []RedisVersion{{
+				Version:            "6.2",
+				RedisImage:         "bitnami/redis:6.2",
+				SentinelImage:      "bitnami/redis-sentinel:6.2",
+				InitContainerImage: "debian:latest",
+				RedisExporterImage: "bitnami/redis-exporter:1",
+			}}},
+			JetStream: &JetStreamConfig{Versions: []JetStreamVersion{{
+				Version:              "2.8.1",
+				NatsImage:            "nats:2.8.1",
+				MetricsExporterImage: "natsio/prometheus-nats-exporter:0.9.1",
+				ConfigReloaderImage:  "natsio/nats-server-config-reloader:0.7.0",
+			}}},
+		},
+	}
+	r, err := g.GetRedisVersion("6.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "mirror.local/bitnami/redis:6.2", r.RedisImage)
+	assert.Equal(t, "mirror.local/bitnami/redis-sentinel:6.2", r.SentinelImage)
+	assert.Equal(t, "mirror.local/debian:latest", r.InitContainerImage)
+	assert.Equal(t, "mirror.local/bitnami/redis-exporter:1", r.RedisExporterImage)
+	assert.Equal(t, "bitnami/redis:6.2", g.ISBSvc.Redis.Versions[0].RedisImage)
+	j, err := g.GetJetStreamVersion("2.8.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "mirror.local/nats:2.8.1", j.NatsImage)
+	assert.Equal(t, "mirror.local/natsio/prometheus-nats-exporter:0.9.1", j.MetricsExporterImage)
+	assert.Equal(t, "mirror.local/natsio/nats-server-config-reloader:0.7.0", j.ConfigReloaderImage)
+}
+
+func TestGetVersionSkewPolicy(t *testing.T) {
+	g := &GlobalConfig{}
+	assert.Equal(t, dfv1.VersionSkewPolicyFail, g.GetVersionSkewPolicy())
+	g.VersionSkewPolicy = "warn"
+	assert.Equal(t, dfv1.VersionSkewPolicyWarn, g.GetVersionSkewPolicy())
+	g.VersionSkewPolicy = "ignore"
+	assert.Panics(t, func() { g.GetVersionSkewPolicy() })
+}
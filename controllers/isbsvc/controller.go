@@ -2,6 +2,7 @@ package isbsvc
 
 import (
 	"context"
+	"time"
 
 	"github.com/numaproj/numaflow/controllers"
 	"github.com/numaproj/numaflow/controllers/isbsvc/installer"
@@ -18,6 +19,9 @@ import (
 
 const (
 	finalizerName = dfv1.ControllerISBSvc
+
+	// usageRefreshInterval is the interval to collect the usage of the running ISB Services
+	usageRefreshInterval = time.Minute
 )
 
 // interStepBufferReconciler reconciles an Inter-Step Buffer Service object.
@@ -56,6 +60,9 @@ func (r *interStepBufferServiceReconciler) Reconcile(ctx context.Context, req ct
 	if err := r.client.Status().Update(ctx, isbsCopy); err != nil {
 		return reconcile.Result{}, err
 	}
+	if reconcileErr == nil && needsUsageRefresh(isbsCopy) {
+		return ctrl.Result{RequeueAfter: usageRefreshInterval}, nil
+	}
 	return ctrl.Result{}, reconcileErr
 }
 
@@ -86,7 +93,22 @@ func (r *interStepBufferServiceReconciler) reconcile(ctx context.Context, isbs *
 	} else {
 		isbs.Status.MarkConfigured()
 	}
-	return installer.Install(ctx, isbs, r.client, r.config, log)
+	if err := installer.Install(ctx, isbs, r.client, r.config, log); err != nil {
+		return err
+	}
+	usage, err := installer.CollectUsage(ctx, isbs, r.client, r.config, log)
+	if err != nil {
+		// The usage is informational, the last collected one is kept.
+		log.Warnw("Failed to collect the usage", zap.Error(err))
+		return nil
+	}
+	if usage != nil {
+		isbs.Status.Usage = usage
+		if usage.MemoryPressure {
+			log.Warnw("The isbs is under memory pressure", zap.String("memoryUtilization", usage.MemoryUtilization))
+		}
+	}
+	return nil
 }
 
 func (r *interStepBufferServiceReconciler) needsUpdate(old, new *dfv1.InterStepBufferService) bool {
@@ -99,6 +121,11 @@ func (r *interStepBufferServiceReconciler) needsUpdate(old, new *dfv1.InterStepB
 	return false
 }
 
+// needsUsageRefresh returns true if the usage of the isbs needs to be collected periodically.
+func needsUsageRefresh(isbs *dfv1.InterStepBufferService) bool {
+	return isbs.DeletionTimestamp.IsZero() && isbs.Spec.JetStream != nil
+}
+
 func needsFinalizer(isbs *dfv1.InterStepBufferService) bool {
 	if isbs.Spec.Redis != nil && isbs.Spec.Redis.Native != nil && isbs.Spec.Redis.Native.Persistence != nil {
 		return true
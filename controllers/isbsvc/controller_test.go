@@ -236,6 +236,15 @@ func TestNeedsFinalizer(t *testing.T) {
 	})
 }
 
+func TestNeedsUsageRefresh(t *testing.T) {
+	assert.False(t, needsUsageRefresh(nativeRedisIsbs))
+	testIsbs := testJetStreamIsbs.DeepCopy()
+	assert.True(t, needsUsageRefresh(testIsbs))
+	now := metav1.Now()
+	testIsbs.DeletionTimestamp = &now
+	assert.False(t, needsUsageRefresh(testIsbs))
+}
+
 func contains(arr []string, str string) bool {
 	for _, a := range arr {
 		if a == str {
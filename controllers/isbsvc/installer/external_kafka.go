@@ -0,0 +1,36 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"go.uber.org/zap"
+)
+
+type externalKafkaInstaller struct {
+	isbs   *dfv1.InterStepBufferService
+	logger *zap.SugaredLogger
+}
+
+func NewExternalKafkaInstaller(isbs *dfv1.InterStepBufferService, logger *zap.SugaredLogger) Installer {
+	return &externalKafkaInstaller{
+		isbs:   isbs,
+		logger: logger.With("isbs", isbs.Name),
+	}
+}
+
+func (eki *externalKafkaInstaller) Install(ctx context.Context) (*dfv1.BufferServiceConfig, error) {
+	if eki.isbs.Spec.Kafka == nil {
+		return nil, fmt.Errorf("invalid InterStepBufferService spec, no kafka config")
+	}
+	eki.isbs.Status.MarkConfigured()
+	eki.isbs.Status.MarkDeployed()
+	eki.logger.Info("Using external kafka config")
+	return &dfv1.BufferServiceConfig{Kafka: eki.isbs.Spec.Kafka}, nil
+}
+
+func (eki *externalKafkaInstaller) Uninstall(ctx context.Context) error {
+	eki.logger.Info("Nothing to uninstall")
+	return nil
+}
@@ -0,0 +1,45 @@
+package installer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExternalKafkaInstallation(t *testing.T) {
+	t.Run("bad installation", func(t *testing.T) {
+		badIsbs := testKafkaIsbSvc.DeepCopy()
+		badIsbs.Spec.Kafka = nil
+		installer := &externalKafkaInstaller{
+			isbs:   badIsbs,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		_, err := installer.Install(context.TODO())
+		assert.Error(t, err)
+	})
+
+	t.Run("good installation", func(t *testing.T) {
+		goodIsbs := testKafkaIsbSvc.DeepCopy()
+		installer := &externalKafkaInstaller{
+			isbs:   goodIsbs,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		c, err := installer.Install(context.TODO())
+		assert.NoError(t, err)
+		assert.NotNil(t, c.Kafka)
+		assert.Equal(t, goodIsbs.Spec.Kafka.Brokers, c.Kafka.Brokers)
+		assert.True(t, goodIsbs.Status.IsReady())
+	})
+}
+
+func TestExternalKafkaUninstallation(t *testing.T) {
+	obj := testKafkaIsbSvc.DeepCopy()
+	installer := &externalKafkaInstaller{
+		isbs:   obj,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	err := installer.Uninstall(context.TODO())
+	assert.NoError(t, err)
+}
@@ -18,6 +18,11 @@ type Installer interface {
 	Uninstall(ctx context.Context) error
 }
 
+// UsageCollector is implemented by the installers of the ISB Services the usage of which can be collected
+type UsageCollector interface {
+	CollectUsage(ctx context.Context) (*dfv1.BufferServiceUsage, error)
+}
+
 // Install function installs the ISBS
 func Install(ctx context.Context, isbsvc *dfv1.InterStepBufferService, client client.Client, config *controllers.GlobalConfig, logger *zap.SugaredLogger) error {
 	installer, err := getInstaller(isbsvc, client, config, logger)
@@ -34,6 +39,19 @@ func Install(ctx context.Context, isbsvc *dfv1.InterStepBufferService, client cl
 	return nil
 }
 
+// CollectUsage collects the resource usage of the ISBS, it returns nil if the ISBS type does not support it.
+func CollectUsage(ctx context.Context, isbsvc *dfv1.InterStepBufferService, client client.Client, config *controllers.GlobalConfig, logger *zap.SugaredLogger) (*dfv1.BufferServiceUsage, error) {
+	installer, err := getInstaller(isbsvc, client, config, logger)
+	if err != nil {
+		return nil, err
+	}
+	collector, ok := installer.(UsageCollector)
+	if !ok {
+		return nil, nil
+	}
+	return collector.CollectUsage(ctx)
+}
+
 // GetInstaller returns Installer implementation
 func getInstaller(isbsvc *dfv1.InterStepBufferService, client client.Client, config *controllers.GlobalConfig, logger *zap.SugaredLogger) (Installer, error) {
 	labels := map[string]string{
@@ -52,6 +70,9 @@ func getInstaller(isbsvc *dfv1.InterStepBufferService, client client.Client, con
 	} else if js := isbsvc.Spec.JetStream; js != nil {
 		labels[dfv1.KeyISBSvcType] = string(dfv1.ISBSvcTypeJetStream)
 		return NewJetStreamInstaller(client, isbsvc, config, labels, logger), nil
+	} else if isbsvc.Spec.Kafka != nil {
+		labels[dfv1.KeyISBSvcType] = string(dfv1.ISBSvcTypeKafka)
+		return NewExternalKafkaInstaller(isbsvc, logger), nil
 	}
 	return nil, fmt.Errorf("invalid isb service spec")
 }
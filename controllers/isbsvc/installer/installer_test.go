@@ -56,6 +56,18 @@ var (
 		},
 	}
 
+	testKafkaIsbSvc = &dfv1.InterStepBufferService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testISBSName,
+		},
+		Spec: dfv1.InterStepBufferServiceSpec{
+			Kafka: &dfv1.KafkaConfig{
+				Brokers: []string{"kafka:9092"},
+			},
+		},
+	}
+
 	testExternalRedisIsbSvc = &dfv1.InterStepBufferService{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: testNamespace,
@@ -128,6 +140,14 @@ func TestGetInstaller(t *testing.T) {
 		assert.True(t, ok)
 	})
 
+	t.Run("get external kafka installer", func(t *testing.T) {
+		installer, err := getInstaller(testKafkaIsbSvc, nil, fakeConfig, zaptest.NewLogger(t).Sugar())
+		assert.NoError(t, err)
+		assert.NotNil(t, installer)
+		_, ok := installer.(*externalKafkaInstaller)
+		assert.True(t, ok)
+	})
+
 	t.Run("test error", func(t *testing.T) {
 		testObj := testNativeRedisIsbSvc.DeepCopy()
 		testObj.Spec.Redis = nil
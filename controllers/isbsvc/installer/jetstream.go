@@ -81,6 +81,11 @@ func (r *jetStreamInstaller) Install(ctx context.Context) (*dfv1.BufferServiceCo
 		r.isbs.Status.MarkDeployFailed("JetStreamAuthSecretsFailed", err.Error())
 		return nil, err
 	}
+	if err := r.createCertificate(ctx); err != nil {
+		r.logger.Errorw("Failed to create jetstream TLS certificate", zap.Error(err))
+		r.isbs.Status.MarkDeployFailed("JetStreamCertificateFailed", err.Error())
+		return nil, err
+	}
 	if err := r.createConfigMap(ctx); err != nil {
 		r.logger.Errorw("Failed to create jetstream ConfigMap", zap.Error(err))
 		r.isbs.Status.MarkDeployFailed("JetStreamConfigMapFailed", err.Error())
@@ -116,10 +121,31 @@ func (r *jetStreamInstaller) Install(ctx context.Context) (*dfv1.BufferServiceCo
 			},
 			BufferConfig: string(b),
 			TLSEnabled:   r.isbs.Spec.JetStream.TLS,
+			TLSCACert:    r.tlsCACertSelector(),
+			Stream:       r.isbs.Spec.JetStream.Stream,
+			Mirror:       r.isbs.Spec.JetStream.Mirror,
 		},
 	}, nil
 }
 
+// tlsCACertSelector returns the secret of the CA certificate the clients verify the servers with.
+func (r *jetStreamInstaller) tlsCACertSelector() *corev1.SecretKeySelector {
+	js := r.isbs.Spec.JetStream
+	if !js.TLS {
+		return nil
+	}
+	if js.TLSIssuer != nil {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: generateJetStreamTLSSecretName(r.isbs)},
+			Key:                  dfv1.CertManagerSecretCACertKey,
+		}
+	}
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: generateJetStreamServerSecretName(r.isbs)},
+		Key:                  dfv1.JetStreamServerCACertKey,
+	}
+}
+
 func (r *jetStreamInstaller) createService(ctx context.Context) error {
 	spec := r.isbs.Spec.JetStream.GetServiceSpec(dfv1.GetJetStreamServiceSpecReq{
 		Labels:      r.labels,
@@ -171,6 +197,10 @@ func (r *jetStreamInstaller) createStatefulSet(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get jetstream version, err: %w", err)
 	}
+	tlsSecretName := ""
+	if js := r.isbs.Spec.JetStream; js.TLS && js.TLSIssuer != nil {
+		tlsSecretName = generateJetStreamTLSSecretName(r.isbs)
+	}
 	spec := r.isbs.Spec.JetStream.GetStatefulSetSpec(dfv1.GetJetStreamStatefulSetSpecReq{
 		ServiceName:                generateJetStreamServiceName(r.isbs),
 		Labels:                     r.labels,
@@ -186,6 +216,7 @@ func (r *jetStreamInstaller) createStatefulSet(ctx context.Context) error {
 		ConfigMapName:              generateJetStreamConfigMapName(r.isbs),
 		PvcNameIfNeeded:            generateJetStreamPVCName(r.isbs),
 		StartCommand:               jsVersion.StartCommand,
+		TLSSecretName:              tlsSecretName,
 	})
 	hash := sharedutil.MustHash(spec)
 	obj := &appv1.StatefulSet{
@@ -296,20 +327,12 @@ func (r *jetStreamInstaller) createSecrets(ctx context.Context) error {
 	r.logger.Info("Generating Certs")
 	certOrg := "io.numaproj"
 	// Generate server Cert
-	hosts := []string{
-		fmt.Sprintf("%s.%s.svc.cluster.local", generateJetStreamServiceName(r.isbs), r.isbs.Namespace),
-		fmt.Sprintf("%s.%s.svc", generateJetStreamServiceName(r.isbs), r.isbs.Namespace),
-	}
-	serverKeyPEM, serverCertPEM, caCertPEM, err := tls.CreateCerts(certOrg, hosts, time.Now().Add(10*365*24*time.Hour), true, false)
+	serverKeyPEM, serverCertPEM, caCertPEM, err := tls.CreateCerts(certOrg, jetStreamServerHosts(r.isbs), time.Now().Add(10*365*24*time.Hour), true, false)
 	if err != nil {
 		return fmt.Errorf("failed to generate JetStream server CERT, %w", err)
 	}
 	// Generate cluster Cert
-	clusterNodeHosts := []string{
-		fmt.Sprintf("*.%s.%s.svc.cluster.local", generateJetStreamServiceName(r.isbs), r.isbs.Namespace),
-		fmt.Sprintf("*.%s.%s.svc", generateJetStreamServiceName(r.isbs), r.isbs.Namespace),
-	}
-	clusterKeyPEM, clusterCertPEM, clusterCACertPEM, err := tls.CreateCerts(certOrg, clusterNodeHosts, time.Now().Add(10*365*24*time.Hour), true, true)
+	clusterKeyPEM, clusterCertPEM, clusterCACertPEM, err := tls.CreateCerts(certOrg, jetStreamClusterNodeHosts(r.isbs), time.Now().Add(10*365*24*time.Hour), true, true)
 	if err != nil {
 		return fmt.Errorf("failed to generate JetStream cluster CERT, %w", err)
 	}
@@ -498,6 +521,10 @@ func generateJetStreamServerSecretName(isbs *dfv1.InterStepBufferService) string
 	return fmt.Sprintf("isbsvc-%s-js-server", isbs.Name)
 }
 
+func generateJetStreamTLSSecretName(isbs *dfv1.InterStepBufferService) string {
+	return fmt.Sprintf("isbsvc-%s-js-tls", isbs.Name)
+}
+
 func generateJetStreamClientAuthSecretName(isbs *dfv1.InterStepBufferService) string {
 	return fmt.Sprintf("isbsvc-%s-js-client-auth", isbs.Name)
 }
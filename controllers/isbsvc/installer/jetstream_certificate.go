@@ -0,0 +1,101 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+// The cert-manager types are not imported, the certificate is built as an unstructured object since cert-manager is optional.
+var certificateGroupVersionKind = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// jetStreamServerHosts returns the host names the clients connect to the servers with.
+func jetStreamServerHosts(isbs *dfv1.InterStepBufferService) []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc.cluster.local", generateJetStreamServiceName(isbs), isbs.Namespace),
+		fmt.Sprintf("%s.%s.svc", generateJetStreamServiceName(isbs), isbs.Namespace),
+	}
+}
+
+// jetStreamClusterNodeHosts returns the host names the servers connect to each other with.
+func jetStreamClusterNodeHosts(isbs *dfv1.InterStepBufferService) []string {
+	return []string{
+		fmt.Sprintf("*.%s.%s.svc.cluster.local", generateJetStreamServiceName(isbs), isbs.Namespace),
+		fmt.Sprintf("*.%s.%s.svc", generateJetStreamServiceName(isbs), isbs.Namespace),
+	}
+}
+
+// createCertificate creates or updates the cert-manager Certificate of the servers if TLS is enabled with an issuer,
+// cert-manager stores the certificate in the TLS secret, which is used for both the client and the cluster connections.
+func (r *jetStreamInstaller) createCertificate(ctx context.Context) error {
+	js := r.isbs.Spec.JetStream
+	if !js.TLS || js.TLSIssuer == nil {
+		return nil
+	}
+	obj := buildJetStreamCertificate(r.isbs, r.labels)
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(certificateGroupVersionKind)
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing); err != nil {
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("cert-manager is not installed, which is required by \"spec.jetstream.tlsIssuer\", %w", err)
+		}
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to check if jetstream certificate is existing, err: %w", err)
+		}
+		if err := r.client.Create(ctx, obj); err != nil {
+			return fmt.Errorf("failed to create jetstream certificate, err: %w", err)
+		}
+		r.logger.Info("Created jetstream certificate successfully")
+		return nil
+	}
+	if hash := obj.GetAnnotations()[dfv1.KeyHash]; existing.GetAnnotations()[dfv1.KeyHash] != hash {
+		existing.Object["spec"] = obj.Object["spec"]
+		annotations := existing.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[dfv1.KeyHash] = hash
+		existing.SetAnnotations(annotations)
+		if err := r.client.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update jetstream certificate, err: %w", err)
+		}
+		r.logger.Info("Updated jetstream certificate successfully")
+	}
+	return nil
+}
+
+func buildJetStreamCertificate(isbs *dfv1.InterStepBufferService, labels map[string]string) *unstructured.Unstructured {
+	issuer := isbs.Spec.JetStream.TLSIssuer
+	dnsNames := []interface{}{}
+	for _, h := range append(jetStreamServerHosts(isbs), jetStreamClusterNodeHosts(isbs)...) {
+		dnsNames = append(dnsNames, h)
+	}
+	spec := map[string]interface{}{
+		"secretName": generateJetStreamTLSSecretName(isbs),
+		"dnsNames":   dnsNames,
+		// The servers also connect to each other as clients in the cluster.
+		"usages": []interface{}{"server auth", "client auth"},
+		"issuerRef": map[string]interface{}{
+			"name":  issuer.Name,
+			"kind":  issuer.GetKind(),
+			"group": certificateGroupVersionKind.Group,
+		},
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(certificateGroupVersionKind)
+	obj.SetNamespace(isbs.Namespace)
+	obj.SetName(generateJetStreamTLSSecretName(isbs))
+	obj.SetLabels(labels)
+	obj.SetAnnotations(map[string]string{dfv1.KeyHash: sharedutil.MustHash(spec)})
+	obj.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(isbs.GetObjectMeta(), dfv1.ISBGroupVersionKind)})
+	return obj
+}
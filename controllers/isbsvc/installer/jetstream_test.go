@@ -9,8 +9,11 @@ import (
 	"go.uber.org/zap/zaptest"
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -49,6 +52,8 @@ func TestJetStreamGenerateNames(t *testing.T) {
 	assert.Equal(t, "isbsvc-"+testJetStreamIsbSvc.Name+"-js-vol", n)
 	n = generateJetStreamServiceName(testJetStreamIsbSvc)
 	assert.Equal(t, "isbsvc-"+testJetStreamIsbSvc.Name+"-js-svc", n)
+	n = generateJetStreamTLSSecretName(testJetStreamIsbSvc)
+	assert.Equal(t, "isbsvc-"+testJetStreamIsbSvc.Name+"-js-tls", n)
 }
 
 func TestJetStreamCreateObjects(t *testing.T) {
@@ -126,6 +131,81 @@ func TestJetStreamCreateObjects(t *testing.T) {
 		assert.Equal(t, 1, len(c.Data))
 		assert.Contains(t, c.Annotations, dfv1.KeyHash)
 	})
+
+	t.Run("test create certificate", func(t *testing.T) {
+		testObj := testJetStreamIsbSvc.DeepCopy()
+		testObj.Spec.JetStream.TLS = true
+		testObj.Spec.JetStream.TLSIssuer = &dfv1.CertManagerIssuer{Name: "test-issuer", Kind: "ClusterIssuer"}
+		i.isbs = testObj
+		assert.NoError(t, i.createCertificate(ctx))
+		c := &unstructured.Unstructured{}
+		c.SetGroupVersionKind(certificateGroupVersionKind)
+		err := cl.Get(ctx, types.NamespacedName{Namespace: testObj.Namespace, Name: generateJetStreamTLSSecretName(testObj)}, c)
+		assert.NoError(t, err)
+		assert.Contains(t, c.GetAnnotations(), dfv1.KeyHash)
+		secretName, _, _ := unstructured.NestedString(c.Object, "spec", "secretName")
+		assert.Equal(t, generateJetStreamTLSSecretName(testObj), secretName)
+		issuer, _, _ := unstructured.NestedStringMap(c.Object, "spec", "issuerRef")
+		assert.Equal(t, map[string]string{"name": "test-issuer", "kind": "ClusterIssuer", "group": "cert-manager.io"}, issuer)
+		dnsNames, _, _ := unstructured.NestedStringSlice(c.Object, "spec", "dnsNames")
+		assert.ElementsMatch(t, append(jetStreamServerHosts(testObj), jetStreamClusterNodeHosts(testObj)...), dnsNames)
+
+		// a changed issuer updates the certificate
+		testObj.Spec.JetStream.TLSIssuer.Kind = ""
+		assert.NoError(t, i.createCertificate(ctx))
+		err = cl.Get(ctx, types.NamespacedName{Namespace: testObj.Namespace, Name: generateJetStreamTLSSecretName(testObj)}, c)
+		assert.NoError(t, err)
+		kind, _, _ := unstructured.NestedString(c.Object, "spec", "issuerRef", "kind")
+		assert.Equal(t, "Issuer", kind)
+
+		sts := &appv1.StatefulSet{}
+		assert.NoError(t, i.createStatefulSet(ctx))
+		err = cl.Get(ctx, types.NamespacedName{Namespace: testObj.Namespace, Name: generateJetStreamStatefulSetName(testObj)}, sts)
+		assert.NoError(t, err)
+		sources := sts.Spec.Template.Spec.Volumes[1].Projected.Sources
+		assert.Equal(t, generateJetStreamTLSSecretName(testObj), sources[len(sources)-1].Secret.Name)
+	})
+
+	t.Run("test create certificate without cert-manager", func(t *testing.T) {
+		testObj := testJetStreamIsbSvc.DeepCopy()
+		testObj.Spec.JetStream.TLS = true
+		testObj.Spec.JetStream.TLSIssuer = &dfv1.CertManagerIssuer{Name: "test-issuer"}
+		ni := &jetStreamInstaller{
+			client: noCertManagerClient{Client: fake.NewClientBuilder().Build()},
+			isbs:   testObj,
+			config: fakeConfig,
+			labels: testLabels,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		err := ni.createCertificate(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cert-manager is not installed")
+	})
+
+	t.Run("test tls ca cert", func(t *testing.T) {
+		testObj := testJetStreamIsbSvc.DeepCopy()
+		i.isbs = testObj
+		assert.Nil(t, i.tlsCACertSelector())
+		testObj.Spec.JetStream.TLS = true
+		assert.Equal(t, generateJetStreamServerSecretName(testObj), i.tlsCACertSelector().Name)
+		assert.Equal(t, dfv1.JetStreamServerCACertKey, i.tlsCACertSelector().Key)
+		testObj.Spec.JetStream.TLSIssuer = &dfv1.CertManagerIssuer{Name: "test-issuer"}
+		assert.Equal(t, generateJetStreamTLSSecretName(testObj), i.tlsCACertSelector().Name)
+		assert.Equal(t, dfv1.CertManagerSecretCACertKey, i.tlsCACertSelector().Key)
+	})
+}
+
+// noCertManagerClient mimics a cluster without the cert-manager CRDs.
+type noCertManagerClient struct {
+	client.Client
+}
+
+func (c noCertManagerClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		gvk := u.GroupVersionKind()
+		return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+	}
+	return c.Client.Get(ctx, key, obj)
 }
 
 func Test_JetStreamInstall_Uninstall(t *testing.T) {
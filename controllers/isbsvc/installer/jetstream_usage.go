@@ -0,0 +1,133 @@
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	appv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// memoryPressureThreshold is the percentage of the memory utilization considered as memory pressure
+const memoryPressureThreshold = 80
+
+var jszClient = &http.Client{Timeout: 5 * time.Second}
+
+// jszResponse is the part of the response of the JetStream monitoring endpoint "/jsz" used to collect the usage
+type jszResponse struct {
+	Config struct {
+		MaxMemory int64 `json:"max_memory"`
+		MaxStore  int64 `json:"max_storage"`
+	} `json:"config"`
+	Memory         int64 `json:"memory"`
+	Store          int64 `json:"storage"`
+	AccountDetails []struct {
+		Name    string `json:"name"`
+		Streams []struct {
+			Name string `json:"name"`
+		} `json:"stream_detail"`
+	} `json:"account_details"`
+}
+
+// CollectUsage collects the usage from the monitoring endpoints of all the JetStream servers. Nothing is collected
+// until all the servers are ready.
+func (r *jetStreamInstaller) CollectUsage(ctx context.Context) (*dfv1.BufferServiceUsage, error) {
+	ssName := generateJetStreamStatefulSetName(r.isbs)
+	ss := &appv1.StatefulSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.isbs.Namespace, Name: ssName}, ss); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get jetstream statefulset, err: %w", err)
+	}
+	replicas := r.isbs.Spec.JetStream.GetReplicas()
+	if int(ss.Status.ReadyReplicas) < replicas {
+		return nil, nil
+	}
+	urls := []string{}
+	for j := 0; j < replicas; j++ {
+		urls = append(urls, fmt.Sprintf("http://%s-%d.%s.%s.svc.cluster.local:%d/jsz?accounts=true&streams=true", ssName, j, generateJetStreamServiceName(r.isbs), r.isbs.Namespace, monitorPort))
+	}
+	return collectJetStreamUsage(ctx, urls)
+}
+
+// collectJetStreamUsage sums up the usage of the JetStream servers, a replicated stream is counted once.
+func collectJetStreamUsage(ctx context.Context, urls []string) (*dfv1.BufferServiceUsage, error) {
+	streams := make(map[string]bool)
+	var usedMemory, usedStore, maxMemory, maxStore int64
+	for _, url := range urls {
+		jsz, err := getJsz(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		usedMemory += jsz.Memory
+		usedStore += jsz.Store
+		// a server without a limit makes the total unknown
+		if maxMemory >= 0 && jsz.Config.MaxMemory > 0 {
+			maxMemory += jsz.Config.MaxMemory
+		} else {
+			maxMemory = -1
+		}
+		if maxStore >= 0 && jsz.Config.MaxStore > 0 {
+			maxStore += jsz.Config.MaxStore
+		} else {
+			maxStore = -1
+		}
+		for _, a := range jsz.AccountDetails {
+			for _, s := range a.Streams {
+				streams[a.Name+"/"+s.Name] = true
+			}
+		}
+	}
+	usage := &dfv1.BufferServiceUsage{
+		Streams:     int32(len(streams)),
+		UsedStorage: *apiresource.NewQuantity(usedStore, apiresource.BinarySI),
+		UsedMemory:  *apiresource.NewQuantity(usedMemory, apiresource.BinarySI),
+		LastUpdated: metav1.Now(),
+	}
+	if maxStore > 0 {
+		usage.AvailableStorage = apiresource.NewQuantity(availableOf(usedStore, maxStore), apiresource.BinarySI)
+		usage.StorageUtilization = fmt.Sprintf("%d%%", usedStore*100/maxStore)
+	}
+	if maxMemory > 0 {
+		usage.AvailableMemory = apiresource.NewQuantity(availableOf(usedMemory, maxMemory), apiresource.BinarySI)
+		usage.MemoryUtilization = fmt.Sprintf("%d%%", usedMemory*100/maxMemory)
+		usage.MemoryPressure = usedMemory*100 >= maxMemory*memoryPressureThreshold
+	}
+	return usage, nil
+}
+
+func getJsz(ctx context.Context, url string) (*jszResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := jszClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jetstream server info from %q, %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get jetstream server info from %q, status code %d", url, resp.StatusCode)
+	}
+	result := &jszResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("failed to decode jetstream server info from %q, %w", url, err)
+	}
+	return result, nil
+}
+
+func availableOf(used, max int64) int64 {
+	if used > max {
+		return 0
+	}
+	return max - used
+}
@@ -0,0 +1,108 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestJetStreamCollectUsage(t *testing.T) {
+	t.Run("not installed", func(t *testing.T) {
+		installer := &jetStreamInstaller{
+			client: fake.NewClientBuilder().Build(),
+			isbs:   testJetStreamIsbSvc,
+			config: fakeConfig,
+			labels: testLabels,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		usage, err := installer.CollectUsage(context.TODO())
+		assert.NoError(t, err)
+		assert.Nil(t, usage)
+	})
+
+	t.Run("servers not ready", func(t *testing.T) {
+		installer := &jetStreamInstaller{
+			client: fake.NewClientBuilder().Build(),
+			isbs:   testJetStreamIsbSvc.DeepCopy(),
+			config: fakeConfig,
+			labels: testLabels,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		_, err := installer.Install(context.TODO())
+		assert.NoError(t, err)
+		usage, err := installer.CollectUsage(context.TODO())
+		assert.NoError(t, err)
+		assert.Nil(t, usage)
+	})
+}
+
+func TestCollectJetStreamUsage(t *testing.T) {
+	jsz := func(maxMemory, memory int64, streams ...string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			details := ""
+			for i, s := range streams {
+				if i > 0 {
+					details += ","
+				}
+				details += fmt.Sprintf(`{"name":%q}`, s)
+			}
+			_, _ = fmt.Fprintf(w, `{"config":{"max_memory":%d,"max_storage":1000},"memory":%d,"storage":250,"account_details":[{"name":"$G","stream_detail":[%s]}]}`, maxMemory, memory, details)
+		}))
+	}
+
+	t.Run("sum up the servers", func(t *testing.T) {
+		a := jsz(100, 50, "s1", "s2")
+		defer a.Close()
+		b := jsz(100, 40, "s2", "s3")
+		defer b.Close()
+		usage, err := collectJetStreamUsage(context.TODO(), []string{a.URL, b.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), usage.Streams)
+		assert.Equal(t, int64(500), usage.UsedStorage.Value())
+		assert.Equal(t, int64(1500), usage.AvailableStorage.Value())
+		assert.Equal(t, "25%", usage.StorageUtilization)
+		assert.Equal(t, int64(90), usage.UsedMemory.Value())
+		assert.Equal(t, int64(110), usage.AvailableMemory.Value())
+		assert.Equal(t, "45%", usage.MemoryUtilization)
+		assert.False(t, usage.MemoryPressure)
+		assert.False(t, usage.LastUpdated.IsZero())
+	})
+
+	t.Run("memory pressure", func(t *testing.T) {
+		a := jsz(100, 80)
+		defer a.Close()
+		usage, err := collectJetStreamUsage(context.TODO(), []string{a.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), usage.Streams)
+		assert.Equal(t, "80%", usage.MemoryUtilization)
+		assert.True(t, usage.MemoryPressure)
+	})
+
+	t.Run("no memory limit", func(t *testing.T) {
+		a := jsz(100, 50)
+		defer a.Close()
+		b := jsz(0, 50)
+		defer b.Close()
+		usage, err := collectJetStreamUsage(context.TODO(), []string{a.URL, b.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), usage.UsedMemory.Value())
+		assert.Nil(t, usage.AvailableMemory)
+		assert.Empty(t, usage.MemoryUtilization)
+		assert.False(t, usage.MemoryPressure)
+	})
+
+	t.Run("server not reachable", func(t *testing.T) {
+		a := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer a.Close()
+		_, err := collectJetStreamUsage(context.TODO(), []string{a.URL})
+		assert.Error(t, err)
+	})
+}
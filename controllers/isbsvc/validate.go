@@ -2,14 +2,25 @@ package isbsvc
 
 import (
 	"fmt"
+	"strings"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
 )
 
 // ValidateInterStepBufferService accepts an isbs and performs validation against it
 func ValidateInterStepBufferService(isbs *dfv1.InterStepBufferService) error {
-	if isbs.Spec.Redis == nil && isbs.Spec.JetStream == nil {
-		return fmt.Errorf("invalid spec: either \"spec.redis\" or \"spec.jetstream\" needs to be specified")
+	defined := 0
+	for _, x := range []bool{isbs.Spec.Redis != nil, isbs.Spec.JetStream != nil, isbs.Spec.Kafka != nil} {
+		if x {
+			defined++
+		}
+	}
+	if defined == 0 {
+		return fmt.Errorf("invalid spec: one of \"spec.redis\", \"spec.jetstream\" and \"spec.kafka\" needs to be specified")
+	}
+	if defined > 1 {
+		return fmt.Errorf("invalid spec: only one of \"spec.redis\", \"spec.jetstream\" and \"spec.kafka\" can be specified")
 	}
 	if isbs.Spec.Redis != nil {
 		if isbs.Spec.Redis.Native != nil && isbs.Spec.Redis.External != nil {
@@ -23,11 +34,74 @@ func ValidateInterStepBufferService(isbs *dfv1.InterStepBufferService) error {
 				return fmt.Errorf("invalid spec: \"spec.redis.native.version\" is not defined")
 			}
 		}
+		if x := isbs.Spec.Redis.External; x != nil {
+			if t := x.TLS; t != nil && (t.CertSecret == nil) != (t.KeySecret == nil) {
+				return fmt.Errorf("invalid spec: both \"spec.redis.external.tls.clientCertSecret\" and \"spec.redis.external.tls.clientKeySecret\" need to be defined")
+			}
+			if x.Cluster && (x.URL == "" || x.SentinelURL != "" || x.MasterName != "") {
+				return fmt.Errorf("invalid spec: a Redis Cluster requires \"spec.redis.external.url\" to be the seed nodes, without Sentinel")
+			}
+			if m := x.Managed; m != nil {
+				// Only the primary endpoint of a cluster-mode-disabled deployment is supported
+				if x.URL == "" || strings.Contains(x.URL, ",") || x.SentinelURL != "" || x.MasterName != "" || x.Cluster {
+					return fmt.Errorf("invalid spec: a managed Redis requires \"spec.redis.external.url\" to be the primary endpoint, without Sentinel")
+				}
+				if m.RequireTLS && x.TLS == nil {
+					return fmt.Errorf("invalid spec: \"spec.redis.external.managed.requireTLS\" requires \"spec.redis.external.tls\" to be defined")
+				}
+			}
+		}
 	}
 	if x := isbs.Spec.JetStream; x != nil {
 		if x.Version == "" {
 			return fmt.Errorf("invalid spec: \"spec.jetstream.version\" is not defined")
 		}
+		if i := x.TLSIssuer; i != nil {
+			if !x.TLS {
+				return fmt.Errorf("invalid spec: \"spec.jetstream.tlsIssuer\" requires \"spec.jetstream.tls\" to be true")
+			}
+			if i.Name == "" {
+				return fmt.Errorf("invalid spec: \"spec.jetstream.tlsIssuer.name\" is not defined")
+			}
+		}
+		if st := x.Stream; st != nil {
+			if r := st.Replicas; r != nil && (*r < 1 || *r > 5 || int(*r) > x.GetReplicas()) {
+				return fmt.Errorf("invalid spec: \"spec.jetstream.stream.replicas\" should be between 1 and 5, and no more than the replicas of the service")
+			}
+			switch st.Storage {
+			case "", dfv1.JetStreamStorageFile, dfv1.JetStreamStorageMemory:
+			default:
+				return fmt.Errorf("invalid spec: unsupported \"spec.jetstream.stream.storage\" %q", st.Storage)
+			}
+			if st.MaxBytes != nil && st.MaxBytes.Sign() <= 0 {
+				return fmt.Errorf("invalid spec: \"spec.jetstream.stream.maxBytes\" should be greater than 0")
+			}
+			if st.MaxAge != nil && st.MaxAge.Duration <= 0 {
+				return fmt.Errorf("invalid spec: \"spec.jetstream.stream.maxAge\" should be greater than 0")
+			}
+		}
+		if m := x.Mirror; m != nil {
+			if m.URL == "" {
+				return fmt.Errorf("invalid spec: \"spec.jetstream.mirror.url\" is not defined")
+			}
+			if a := m.Auth; a != nil && (a.User == nil) != (a.Password == nil) {
+				return fmt.Errorf("invalid spec: both \"spec.jetstream.mirror.auth.user\" and \"spec.jetstream.mirror.auth.password\" need to be defined")
+			}
+			if m.TLSCACert != nil && !m.TLSEnabled {
+				return fmt.Errorf("invalid spec: \"spec.jetstream.mirror.tlsCACert\" requires \"spec.jetstream.mirror.tlsEnabled\" to be true")
+			}
+		}
+	}
+	if x := isbs.Spec.Kafka; x != nil {
+		if len(x.Brokers) == 0 {
+			return fmt.Errorf("invalid spec: \"spec.kafka.brokers\" is not defined")
+		}
+		if _, err := sharedutil.GetSaramaConfigFromYAMLString(x.Config); err != nil {
+			return fmt.Errorf("invalid spec: \"spec.kafka.config\", %w", err)
+		}
+		if t := x.TLS; t != nil && (t.CertSecret == nil) != (t.KeySecret == nil) {
+			return fmt.Errorf("invalid spec: both \"spec.kafka.tls.clientCertSecret\" and \"spec.kafka.tls.clientKeySecret\" need to be defined")
+		}
 	}
 	return nil
 }
@@ -5,7 +5,10 @@ import (
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var (
@@ -34,6 +37,18 @@ var (
 			},
 		},
 	}
+
+	testKafkaIsbs = &dfv1.InterStepBufferService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-ns",
+			Name:      dfv1.DefaultISBSvcName,
+		},
+		Spec: dfv1.InterStepBufferServiceSpec{
+			Kafka: &dfv1.KafkaConfig{
+				Brokers: []string{"kafka:9092"},
+			},
+		},
+	}
 )
 
 func TestValidateInterStepBuffer(t *testing.T) {
@@ -47,7 +62,7 @@ func TestValidateInterStepBuffer(t *testing.T) {
 		isbs.Spec.Redis = nil
 		err := ValidateInterStepBufferService(isbs)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "either \"spec.redis\" or \"spec.jetstream\" needs to be specified")
+		assert.Contains(t, err.Error(), "one of \"spec.redis\", \"spec.jetstream\" and \"spec.kafka\" needs to be specified")
 	})
 
 	t.Run("test missing version", func(t *testing.T) {
@@ -74,6 +89,67 @@ func TestValidateInterStepBuffer(t *testing.T) {
 		assert.Contains(t, err.Error(), "must be defined")
 	})
 
+	t.Run("test managed redis", func(t *testing.T) {
+		isbs := testRedisIsbs.DeepCopy()
+		isbs.Spec.Redis.Native = nil
+		isbs.Spec.Redis.External = &dfv1.RedisConfig{
+			URL:     "master.my-redis.xxxxxx.use1.cache.amazonaws.com:6379",
+			TLS:     &dfv1.TLS{},
+			Managed: &dfv1.ManagedRedis{RequireTLS: true},
+		}
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+		isbs.Spec.Redis.External.TLS = nil
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"spec.redis.external.managed.requireTLS\" requires \"spec.redis.external.tls\"")
+		isbs.Spec.Redis.External.Managed.RequireTLS = false
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+		isbs.Spec.Redis.External.URL = "node1:6379,node2:6379"
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "primary endpoint")
+		isbs.Spec.Redis.External.URL = ""
+		isbs.Spec.Redis.External.SentinelURL = "sentinel:26379"
+		isbs.Spec.Redis.External.MasterName = "master"
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "primary endpoint")
+		isbs.Spec.Redis.External.SentinelURL = ""
+		isbs.Spec.Redis.External.MasterName = ""
+		isbs.Spec.Redis.External.URL = "clustercfg.my-redis.xxxxxx.use1.cache.amazonaws.com:6379"
+		isbs.Spec.Redis.External.Cluster = true
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "primary endpoint")
+	})
+
+	t.Run("test redis cluster", func(t *testing.T) {
+		isbs := testRedisIsbs.DeepCopy()
+		isbs.Spec.Redis.Native = nil
+		isbs.Spec.Redis.External = &dfv1.RedisConfig{
+			URL:     "node1:6379,node2:6379",
+			Cluster: true,
+		}
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+		isbs.Spec.Redis.External.SentinelURL = "sentinel:26379"
+		isbs.Spec.Redis.External.MasterName = "master"
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "seed nodes")
+	})
+
+	t.Run("test external redis tls", func(t *testing.T) {
+		isbs := testRedisIsbs.DeepCopy()
+		isbs.Spec.Redis.Native = nil
+		isbs.Spec.Redis.External = &dfv1.RedisConfig{
+			URL: "redis:6379",
+			TLS: &dfv1.TLS{CertSecret: &corev1.SecretKeySelector{Key: "tls.crt"}},
+		}
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "clientKeySecret")
+	})
+
 	t.Run("test missing jetstream version", func(t *testing.T) {
 		isbs := testJetStreamIsbs.DeepCopy()
 		isbs.Spec.JetStream.Version = ""
@@ -81,4 +157,102 @@ func TestValidateInterStepBuffer(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "is not defined")
 	})
+
+	t.Run("test jetstream tls issuer", func(t *testing.T) {
+		isbs := testJetStreamIsbs.DeepCopy()
+		isbs.Spec.JetStream.TLSIssuer = &dfv1.CertManagerIssuer{Name: "test-issuer"}
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "requires \"spec.jetstream.tls\" to be true")
+
+		isbs.Spec.JetStream.TLS = true
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+
+		isbs.Spec.JetStream.TLSIssuer.Name = ""
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"spec.jetstream.tlsIssuer.name\" is not defined")
+	})
+
+	t.Run("test jetstream stream config", func(t *testing.T) {
+		isbs := testJetStreamIsbs.DeepCopy()
+		isbs.Spec.JetStream.Stream = &dfv1.JetStreamStreamConfig{Replicas: pointer.Int32(5)}
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no more than the replicas of the service")
+		isbs.Spec.JetStream.Stream.Replicas = pointer.Int32(3)
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+
+		isbs.Spec.JetStream.Stream.Storage = "Disk"
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported")
+		isbs.Spec.JetStream.Stream.Storage = dfv1.JetStreamStorageMemory
+		maxBytes := resource.MustParse("0")
+		isbs.Spec.JetStream.Stream.MaxBytes = &maxBytes
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "maxBytes")
+		isbs.Spec.JetStream.Stream.MaxBytes = nil
+		isbs.Spec.JetStream.Stream.MaxAge = &metav1.Duration{}
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "maxAge")
+	})
+
+	t.Run("test jetstream mirror", func(t *testing.T) {
+		isbs := testJetStreamIsbs.DeepCopy()
+		isbs.Spec.JetStream.Mirror = &dfv1.JetStreamMirror{}
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"spec.jetstream.mirror.url\" is not defined")
+		isbs.Spec.JetStream.Mirror.URL = "nats://isbsvc-dr:4222"
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+
+		isbs.Spec.JetStream.Mirror.Auth = &dfv1.NATSAuth{User: &corev1.SecretKeySelector{Key: "user"}}
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.jetstream.mirror.auth.password")
+		isbs.Spec.JetStream.Mirror.Auth.Password = &corev1.SecretKeySelector{Key: "password"}
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+
+		isbs.Spec.JetStream.Mirror.TLSCACert = &corev1.SecretKeySelector{Key: "ca.crt"}
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "tlsEnabled")
+		isbs.Spec.JetStream.Mirror.TLSEnabled = true
+		assert.NoError(t, ValidateInterStepBufferService(isbs))
+	})
+
+	t.Run("test good kafka isb", func(t *testing.T) {
+		assert.NoError(t, ValidateInterStepBufferService(testKafkaIsbs))
+	})
+
+	t.Run("test more than one isb", func(t *testing.T) {
+		isbs := testKafkaIsbs.DeepCopy()
+		isbs.Spec.JetStream = testJetStreamIsbs.Spec.JetStream.DeepCopy()
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only one of")
+	})
+
+	t.Run("test invalid kafka isb", func(t *testing.T) {
+		isbs := testKafkaIsbs.DeepCopy()
+		isbs.Spec.Kafka.Brokers = nil
+		err := ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"spec.kafka.brokers\" is not defined")
+
+		isbs = testKafkaIsbs.DeepCopy()
+		isbs.Spec.Kafka.Config = "net:\n  maxOpenRequests: -1"
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"spec.kafka.config\"")
+
+		isbs = testKafkaIsbs.DeepCopy()
+		isbs.Spec.Kafka.TLS = &dfv1.TLS{CertSecret: &corev1.SecretKeySelector{Key: "tls.crt"}}
+		err = ValidateInterStepBufferService(isbs)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "clientKeySecret")
+	})
 }
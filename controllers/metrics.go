@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+const (
+	metricsNamespace = "numaflow"
+
+	labelController = "controller"
+	labelNamespace  = "namespace"
+	labelPipeline   = "pipeline"
+)
+
+// reconcileDuration is used to indicate the time taken to reconcile the objects of a pipeline
+var reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Subsystem: "controller",
+	Name:      "reconcile_duration_seconds",
+	Help:      "Duration of the reconciliation of the objects of a pipeline",
+	Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+}, []string{labelController, labelNamespace, labelPipeline})
+
+// reconcileErrors is used to indicate the number of failed reconciliations of the objects of a pipeline
+var reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Subsystem: "controller",
+	Name:      "reconcile_error_total",
+	Help:      "Total number of failed reconciliations of the objects of a pipeline",
+}, []string{labelController, labelNamespace, labelPipeline})
+
+// pipelineUnhealthy is used to indicate the number of times a pipeline turned unhealthy
+var pipelineUnhealthy = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Subsystem: "pipeline",
+	Name:      "unhealthy_total",
+	Help:      "Total number of times a pipeline turned unhealthy",
+}, []string{labelNamespace, labelPipeline})
+
+// pipelineHealthy is used to indicate whether a pipeline is healthy, 1 if it is healthy, 0 if it's failed or degraded
+var pipelineHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Subsystem: "pipeline",
+	Name:      "healthy",
+	Help:      "Whether a pipeline is healthy, 1 if it is healthy, 0 if it is failed or degraded",
+}, []string{labelNamespace, labelPipeline})
+
+func init() {
+	// Served by the metrics endpoint of the controller manager.
+	metrics.Registry.MustRegister(reconcileDuration, reconcileErrors, pipelineUnhealthy, pipelineHealthy)
+}
+
+// ObserveReconcile records the duration and the result of a reconciliation of the objects of a pipeline.
+func ObserveReconcile(controller, namespace, pipeline string, start time.Time, err error) {
+	reconcileDuration.WithLabelValues(controller, namespace, pipeline).Observe(time.Since(start).Seconds())
+	if err != nil {
+		reconcileErrors.WithLabelValues(controller, namespace, pipeline).Inc()
+	}
+}
+
+// ObservePipelineHealth records the health of a pipeline, and counts its transition from healthy to unhealthy.
+func ObservePipelineHealth(old, new *dfv1.Pipeline) {
+	healthy := IsPipelineHealthy(new)
+	if IsPipelineHealthy(old) && !healthy {
+		pipelineUnhealthy.WithLabelValues(new.Namespace, new.Name).Inc()
+	}
+	if healthy {
+		pipelineHealthy.WithLabelValues(new.Namespace, new.Name).Set(1)
+	} else {
+		pipelineHealthy.WithLabelValues(new.Namespace, new.Name).Set(0)
+	}
+}
+
+// IsPipelineHealthy returns false if the pipeline is failed or degraded, or any of its conditions is false.
+func IsPipelineHealthy(pl *dfv1.Pipeline) bool {
+	if pl.Status.Phase == dfv1.PipelinePhaseFailed || pl.Status.GetCondition(dfv1.PipelineConditionDegraded) != nil {
+		return false
+	}
+	for _, c := range pl.Status.Conditions {
+		if c.Status == metav1.ConditionFalse {
+			return false
+		}
+	}
+	return true
+}
+
+// ForgetPipeline removes the metrics of a deleted pipeline.
+func ForgetPipeline(namespace, pipeline string) {
+	for _, c := range []string{dfv1.ControllerPipeline, dfv1.ControllerVertex} {
+		labels := prometheus.Labels{labelController: c, labelNamespace: namespace, labelPipeline: pipeline}
+		reconcileDuration.Delete(labels)
+		reconcileErrors.Delete(labels)
+	}
+	pipelineUnhealthy.Delete(prometheus.Labels{labelNamespace: namespace, labelPipeline: pipeline})
+	pipelineHealthy.Delete(prometheus.Labels{labelNamespace: namespace, labelPipeline: pipeline})
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func testPipeline() *dfv1.Pipeline {
+	pl := &dfv1.Pipeline{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-pl"}}
+	pl.Status.InitConditions()
+	return pl
+}
+
+func TestIsPipelineHealthy(t *testing.T) {
+	pl := testPipeline()
+	assert.True(t, IsPipelineHealthy(pl))
+	pl.Status.MarkConfigured()
+	assert.True(t, IsPipelineHealthy(pl))
+	pl.Status.MarkNotConfigured("reason", "message")
+	assert.False(t, IsPipelineHealthy(pl))
+	pl = testPipeline()
+	pl.Status.SetPhase(dfv1.PipelinePhaseFailed, "message")
+	assert.False(t, IsPipelineHealthy(pl))
+	pl = testPipeline()
+	pl.Status.MarkDegraded("reason", "message")
+	assert.False(t, IsPipelineHealthy(pl))
+}
+
+func TestObservePipelineHealth(t *testing.T) {
+	defer ForgetPipeline("test-ns", "test-pl")
+	healthy := testPipeline()
+	unhealthy := testPipeline()
+	unhealthy.Status.MarkNotConfigured("reason", "message")
+	counter := pipelineUnhealthy.WithLabelValues("test-ns", "test-pl")
+	gauge := pipelineHealthy.WithLabelValues("test-ns", "test-pl")
+	ObservePipelineHealth(healthy, unhealthy)
+	assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+	assert.Equal(t, float64(0), testutil.ToFloat64(gauge))
+	// Staying unhealthy is not counted again.
+	ObservePipelineHealth(unhealthy, unhealthy)
+	ObservePipelineHealth(healthy, healthy)
+	assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+	assert.Equal(t, float64(1), testutil.ToFloat64(gauge))
+}
+
+func TestObserveReconcile(t *testing.T) {
+	defer ForgetPipeline("test-ns", "test-pl")
+	ObserveReconcile(dfv1.ControllerPipeline, "test-ns", "test-pl", time.Now(), nil)
+	ObserveReconcile(dfv1.ControllerPipeline, "test-ns", "test-pl", time.Now(), fmt.Errorf("failed"))
+	assert.Equal(t, 1, testutil.CollectAndCount(reconcileDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(reconcileErrors.WithLabelValues(dfv1.ControllerPipeline, "test-ns", "test-pl")))
+	ForgetPipeline("test-ns", "test-pl")
+	assert.Equal(t, 0, testutil.CollectAndCount(reconcileDuration))
+}
@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// prometheusRuleGroupVersionKind is the PrometheusRule of the Prometheus Operator, built as an unstructured object
+// same as the monitors.
+var prometheusRuleGroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+// AlertOptions are the thresholds and the labels of the recommended alerts of a pipeline.
+type AlertOptions struct {
+	// BufferUsage is the usage of a buffer, between 0 and 1, above which it's alerted
+	BufferUsage float64
+	// WatermarkDelay is the SLA of the watermarks, a watermark falling behind the current time more than it is alerted
+	WatermarkDelay time.Duration
+	// For is how long a condition lasts before it's alerted
+	For time.Duration
+	// Labels are added to all the alerts, e.g. the team to route the alerts to
+	Labels map[string]string
+	// RuleLabels are added to the PrometheusRule, e.g. the ones required by the rule selector of the Prometheus
+	RuleLabels map[string]string
+}
+
+// DefaultAlertOptions returns the recommended thresholds.
+func DefaultAlertOptions() AlertOptions {
+	return AlertOptions{
+		BufferUsage:    0.8,
+		WatermarkDelay: 5 * time.Minute,
+		For:            5 * time.Minute,
+	}
+}
+
+func (o AlertOptions) validate() error {
+	if o.BufferUsage <= 0 || o.BufferUsage > 1 {
+		return fmt.Errorf("buffer usage threshold should be between 0 and 1, got %v", o.BufferUsage)
+	}
+	if o.WatermarkDelay <= 0 {
+		return fmt.Errorf("watermark delay threshold should be positive, got %v", o.WatermarkDelay)
+	}
+	if o.For < 0 {
+		return fmt.Errorf("alert duration should not be negative, got %v", o.For)
+	}
+	return nil
+}
+
+// BuildAlertRules returns the PrometheusRule with the recommended alerts of the pipeline, which are
+//   - the pipeline is failed or degraded, from the metrics of the controller
+//   - a buffer is filled above the threshold, from the metrics of the daemon server
+//   - the watermark of a reduce vertex falls behind the SLA, from the metrics of the vertex pods
+//   - a pod of the pipeline is crash looping, from the metrics of kube-state-metrics
+func BuildAlertRules(pl *dfv1.Pipeline, opts AlertOptions) (*unstructured.Unstructured, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	selector := fmt.Sprintf("namespace=%q,pipeline=%q", pl.Namespace, pl.Name)
+	labels := func(severity string) map[string]interface{} {
+		result := map[string]interface{}{
+			"severity":  severity,
+			"namespace": pl.Namespace,
+			"pipeline":  pl.Name,
+		}
+		for k, v := range opts.Labels {
+			result[k] = v
+		}
+		return result
+	}
+	rule := func(alert, expr, severity, summary, description string) interface{} {
+		return map[string]interface{}{
+			"alert":  alert,
+			"expr":   expr,
+			"for":    model.Duration(opts.For).String(),
+			"labels": labels(severity),
+			"annotations": map[string]interface{}{
+				"summary":     summary,
+				"description": description,
+			},
+		}
+	}
+	name := pl.Namespace + "/" + pl.Name
+	rules := []interface{}{
+		rule("NumaflowPipelineDegraded",
+			fmt.Sprintf("numaflow_pipeline_healthy{%s} == 0", selector),
+			"critical",
+			fmt.Sprintf("Pipeline %s is failed or degraded", name),
+			fmt.Sprintf("Pipeline %s has been failed or degraded for more than %s, check its status and the errors of its vertices.", name, model.Duration(opts.For))),
+		rule("NumaflowBufferUsageHigh",
+			fmt.Sprintf("pipeline_buffer_usage{%s} > %v", selector, opts.BufferUsage),
+			"warning",
+			fmt.Sprintf("Buffer {{ $labels.buffer }} of pipeline %s is {{ $value | humanizePercentage }} full", name),
+			fmt.Sprintf("The buffer from vertex {{ $labels.from_vertex }} to vertex {{ $labels.to_vertex }} of pipeline %s has been more than %v%% full, the vertex {{ $labels.to_vertex }} is not keeping up.", name, opts.BufferUsage*100)),
+	}
+	for _, v := range pl.Spec.Vertices {
+		if v.Reduce != nil {
+			rules = append(rules, rule("NumaflowWatermarkDelayed",
+				fmt.Sprintf("time() - min by (vertex) (reduce_watermark{%s} > 0) / 1000 > %v", selector, opts.WatermarkDelay.Seconds()),
+				"warning",
+				fmt.Sprintf("Watermark of vertex {{ $labels.vertex }} of pipeline %s is {{ $value | humanizeDuration }} behind", name),
+				fmt.Sprintf("The watermark of vertex {{ $labels.vertex }} of pipeline %s has been behind the current time more than the SLA of %s.", name, model.Duration(opts.WatermarkDelay))))
+			break
+		}
+	}
+	rules = append(rules, rule("NumaflowPodCrashLooping",
+		fmt.Sprintf("max by (pod, container) (kube_pod_container_status_waiting_reason{namespace=%q,pod=~%q,reason=\"CrashLoopBackOff\"}) > 0", pl.Namespace, podNamePattern(pl)),
+		"critical",
+		fmt.Sprintf("Pod {{ $labels.pod }} of pipeline %s is crash looping", name),
+		fmt.Sprintf("Container {{ $labels.container }} of pod {{ $labels.pod }} of pipeline %s has been crash looping for more than %s.", name, model.Duration(opts.For))))
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{
+					"name":  fmt.Sprintf("numaflow.%s.%s", pl.Namespace, pl.Name),
+					"rules": rules,
+				},
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(prometheusRuleGroupVersionKind)
+	obj.SetNamespace(pl.Namespace)
+	obj.SetName(pl.Name + "-alerts")
+	objLabels := map[string]string{
+		dfv1.KeyPartOf:       dfv1.Project,
+		dfv1.KeyPipelineName: pl.Name,
+	}
+	for k, v := range opts.RuleLabels {
+		objLabels[k] = v
+	}
+	obj.SetLabels(objLabels)
+	return obj, nil
+}
+
+// podNamePattern returns the regular expression matching the names of the daemon and the vertex pods of the pipeline,
+// which are "<pipeline>-daemon-<hash>-<suffix>" and "<pipeline>-<vertex>-<replica>-<suffix>".
+func podNamePattern(pl *dfv1.Pipeline) string {
+	vertices := make([]string, 0, len(pl.Spec.Vertices))
+	for _, v := range pl.Spec.Vertices {
+		vertices = append(vertices, regexp.QuoteMeta(v.Name))
+	}
+	sort.Strings(vertices)
+	prefix := regexp.QuoteMeta(pl.Name)
+	return fmt.Sprintf("%s-daemon-.+|%s-(%s)-[0-9]+-.+", prefix, prefix, strings.Join(vertices, "|"))
+}
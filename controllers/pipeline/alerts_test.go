@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func alertRules(t *testing.T, obj *unstructured.Unstructured) map[string]map[string]interface{} {
+	t.Helper()
+	groups, _, _ := unstructured.NestedSlice(obj.Object, "spec", "groups")
+	assert.Len(t, groups, 1)
+	rules := groups[0].(map[string]interface{})["rules"].([]interface{})
+	result := make(map[string]map[string]interface{})
+	for _, r := range rules {
+		rule := r.(map[string]interface{})
+		result[rule["alert"].(string)] = rule
+	}
+	return result
+}
+
+func TestBuildAlertRules(t *testing.T) {
+	pl := &dfv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl"},
+		Spec: dfv1.PipelineSpec{
+			Vertices: []dfv1.AbstractVertex{
+				{Name: "in", Source: &dfv1.Source{}},
+				{Name: "out", Sink: &dfv1.Sink{}},
+			},
+		},
+	}
+	t.Run("default", func(t *testing.T) {
+		obj, err := BuildAlertRules(pl, DefaultAlertOptions())
+		assert.NoError(t, err)
+		assert.Equal(t, "PrometheusRule", obj.GetKind())
+		assert.Equal(t, "ns", obj.GetNamespace())
+		assert.Equal(t, "pl-alerts", obj.GetName())
+		assert.Equal(t, "pl", obj.GetLabels()[dfv1.KeyPipelineName])
+		rules := alertRules(t, obj)
+		assert.Len(t, rules, 3)
+		assert.Equal(t, `numaflow_pipeline_healthy{namespace="ns",pipeline="pl"} == 0`, rules["NumaflowPipelineDegraded"]["expr"])
+		assert.Equal(t, `pipeline_buffer_usage{namespace="ns",pipeline="pl"} > 0.8`, rules["NumaflowBufferUsageHigh"]["expr"])
+		assert.Equal(t, "5m", rules["NumaflowBufferUsageHigh"]["for"])
+		assert.Equal(t, "warning", rules["NumaflowBufferUsageHigh"]["labels"].(map[string]interface{})["severity"])
+		assert.Contains(t, rules["NumaflowPodCrashLooping"]["expr"], `pod=~"pl-daemon-.+|pl-(in|out)-[0-9]+-.+"`)
+	})
+
+	t.Run("reduce and labels", func(t *testing.T) {
+		pl := pl.DeepCopy()
+		pl.Spec.Vertices = append(pl.Spec.Vertices, dfv1.AbstractVertex{Name: "count", Reduce: &dfv1.Reduce{}})
+		opts := DefaultAlertOptions()
+		opts.WatermarkDelay = 90 * time.Second
+		opts.For = time.Minute
+		opts.Labels = map[string]string{"team": "data"}
+		opts.RuleLabels = map[string]string{"release": "prometheus"}
+		obj, err := BuildAlertRules(pl, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, "prometheus", obj.GetLabels()["release"])
+		rules := alertRules(t, obj)
+		assert.Len(t, rules, 4)
+		assert.Equal(t, `time() - min by (vertex) (reduce_watermark{namespace="ns",pipeline="pl"} > 0) / 1000 > 90`, rules["NumaflowWatermarkDelayed"]["expr"])
+		assert.Equal(t, "1m", rules["NumaflowWatermarkDelayed"]["for"])
+		assert.Equal(t, "data", rules["NumaflowPipelineDegraded"]["labels"].(map[string]interface{})["team"])
+	})
+
+	t.Run("invalid thresholds", func(t *testing.T) {
+		opts := DefaultAlertOptions()
+		opts.BufferUsage = 80
+		_, err := BuildAlertRules(pl, opts)
+		assert.Error(t, err)
+		opts = DefaultAlertOptions()
+		opts.WatermarkDelay = 0
+		_, err = BuildAlertRules(pl, opts)
+		assert.Error(t, err)
+	})
+
+	t.Run("pod name pattern", func(t *testing.T) {
+		re := regexp.MustCompile("^(?:" + podNamePattern(pl) + ")$")
+		assert.True(t, re.MatchString("pl-daemon-5d8f7c9b4-x2x7k"))
+		assert.True(t, re.MatchString("pl-in-0-abcde"))
+		assert.False(t, re.MatchString("pl-2-in-0-abcde"))
+		assert.False(t, re.MatchString("other-in-0-abcde"))
+	})
+}
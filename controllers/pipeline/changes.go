@@ -0,0 +1,112 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+// planChanges returns the changes to be made to bring the existing vertices and daemon deployment to the new ones.
+// The buffer names are the ones to be created and deleted after the migrations are planned.
+func (r *pipelineReconciler) planChanges(ctx context.Context, pl *dfv1.Pipeline, isbSvcConfig dfv1.BufferServiceConfig, existingVertices, newVertices map[string]dfv1.Vertex, newBufferNames, oldBufferNames, migrations map[string]string) (*dfv1.PlannedChanges, error) {
+	deploy, err := r.buildDaemonDeployment(pl, isbSvcConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build daemon deployment spec, %w", err)
+	}
+	existingDeploy := &appv1.Deployment{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: pl.Namespace, Name: deploy.Name}, existingDeploy); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to find existing daemon deployment, %w", err)
+		}
+		existingDeploy = nil
+	}
+	return planVertexChanges(existingVertices, newVertices, newBufferNames, oldBufferNames, migrations, existingDeploy, deploy), nil
+}
+
+func planVertexChanges(existingVertices, newVertices map[string]dfv1.Vertex, newBufferNames, oldBufferNames, migrations map[string]string, existingDeploy, newDeploy *appv1.Deployment) *dfv1.PlannedChanges {
+	changes := &dfv1.PlannedChanges{
+		BuffersToCreate: sortedKeys(newBufferNames),
+		BuffersToDelete: sortedKeys(oldBufferNames),
+	}
+	if len(migrations) > 0 {
+		changes.BuffersToMigrate = make(map[string]string)
+		for from, to := range migrations {
+			changes.BuffersToMigrate[from] = to
+		}
+	}
+	for name, v := range newVertices {
+		if existing, ok := existingVertices[name]; !ok {
+			changes.VerticesToCreate = append(changes.VerticesToCreate, name)
+		} else if existing.GetAnnotations()[dfv1.KeyHash] != v.GetAnnotations()[dfv1.KeyHash] {
+			changes.VerticesToUpdate = append(changes.VerticesToUpdate, name)
+		}
+	}
+	for name := range existingVertices {
+		if _, ok := newVertices[name]; !ok {
+			changes.VerticesToDelete = append(changes.VerticesToDelete, name)
+		}
+	}
+	sort.Strings(changes.VerticesToCreate)
+	sort.Strings(changes.VerticesToUpdate)
+	sort.Strings(changes.VerticesToDelete)
+	if existingDeploy == nil {
+		changes.DeploymentsToCreate = []string{newDeploy.Name}
+	} else if existingDeploy.GetAnnotations()[dfv1.KeyHash] != newDeploy.GetAnnotations()[dfv1.KeyHash] {
+		changes.DeploymentsToUpdate = []string{newDeploy.Name}
+	}
+	changes.Hash = sharedutil.MustHash(changes)
+	return changes
+}
+
+// compactPlannedChanges returns the changes with at most max names listed, the buffers first, and the number of the
+// names omitted recorded instead. The hash is kept, which covers all the changes.
+func compactPlannedChanges(changes *dfv1.PlannedChanges, max int) *dfv1.PlannedChanges {
+	result := &dfv1.PlannedChanges{Hash: changes.Hash}
+	remaining := max
+	keep := func(names []string) []string {
+		n := len(names)
+		if n > remaining {
+			n = remaining
+		}
+		result.Omitted += int32(len(names) - n)
+		remaining -= n
+		if n == 0 {
+			return nil
+		}
+		return append([]string{}, names[:n]...)
+	}
+	result.BuffersToCreate = keep(changes.BuffersToCreate)
+	result.BuffersToDelete = keep(changes.BuffersToDelete)
+	migrated := keep(sortedKeys(changes.BuffersToMigrate))
+	if len(migrated) > 0 {
+		result.BuffersToMigrate = make(map[string]string)
+		for _, from := range migrated {
+			result.BuffersToMigrate[from] = changes.BuffersToMigrate[from]
+		}
+	}
+	result.VerticesToCreate = keep(changes.VerticesToCreate)
+	result.VerticesToUpdate = keep(changes.VerticesToUpdate)
+	result.VerticesToDelete = keep(changes.VerticesToDelete)
+	result.DeploymentsToCreate = keep(changes.DeploymentsToCreate)
+	result.DeploymentsToUpdate = keep(changes.DeploymentsToUpdate)
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
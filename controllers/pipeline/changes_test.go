@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func Test_planVertexChanges(t *testing.T) {
+	vertex := func(name, hash string) dfv1.Vertex {
+		return dfv1.Vertex{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{dfv1.KeyHash: hash}}}
+	}
+	deploy := func(hash string) *appv1.Deployment {
+		return &appv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "pl-daemon", Annotations: map[string]string{dfv1.KeyHash: hash}}}
+	}
+	existing := map[string]dfv1.Vertex{"pl-a": vertex("pl-a", "1"), "pl-b": vertex("pl-b", "1"), "pl-c": vertex("pl-c", "1")}
+	desired := map[string]dfv1.Vertex{"pl-a": vertex("pl-a", "1"), "pl-b": vertex("pl-b", "2"), "pl-d": vertex("pl-d", "1")}
+
+	changes := planVertexChanges(existing, desired, map[string]string{"b2": "b2", "b1": "b1"}, map[string]string{"b3": "b3"}, map[string]string{"b4": "b5"}, deploy("1"), deploy("2"))
+	assert.True(t, changes.IsDisruptive())
+	assert.Equal(t, []string{"b1", "b2"}, changes.BuffersToCreate)
+	assert.Equal(t, []string{"b3"}, changes.BuffersToDelete)
+	assert.Equal(t, map[string]string{"b4": "b5"}, changes.BuffersToMigrate)
+	assert.Equal(t, []string{"pl-d"}, changes.VerticesToCreate)
+	assert.Equal(t, []string{"pl-b"}, changes.VerticesToUpdate)
+	assert.Equal(t, []string{"pl-c"}, changes.VerticesToDelete)
+	assert.Equal(t, []string{"pl-daemon"}, changes.DeploymentsToUpdate)
+	assert.NotEmpty(t, changes.Hash)
+	again := planVertexChanges(existing, desired, map[string]string{"b1": "b1", "b2": "b2"}, map[string]string{"b3": "b3"}, map[string]string{"b4": "b5"}, deploy("1"), deploy("2"))
+	assert.Equal(t, changes.Hash, again.Hash)
+
+	changes = planVertexChanges(existing, desired, nil, nil, nil, nil, deploy("2"))
+	assert.False(t, changes.IsDisruptive())
+	assert.Equal(t, []string{"pl-daemon"}, changes.DeploymentsToCreate)
+}
+
+func Test_compactPlannedChanges(t *testing.T) {
+	changes := &dfv1.PlannedChanges{
+		Hash:             "abc",
+		BuffersToCreate:  []string{"b1", "b2"},
+		BuffersToMigrate: map[string]string{"b4": "b5", "b3": "b6"},
+		VerticesToCreate: []string{"pl-a", "pl-b"},
+		VerticesToDelete: []string{"pl-c"},
+	}
+	assert.Equal(t, changes, compactPlannedChanges(changes, 10))
+	compacted := compactPlannedChanges(changes, 3)
+	assert.Equal(t, &dfv1.PlannedChanges{
+		Hash:             "abc",
+		BuffersToCreate:  []string{"b1", "b2"},
+		BuffersToMigrate: map[string]string{"b3": "b6"},
+		Omitted:          4,
+	}, compacted)
+	assert.Len(t, changes.BuffersToMigrate, 2)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,13 +27,20 @@ import (
 
 	"github.com/numaproj/numaflow/controllers"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
-	daemonclient "github.com/numaproj/numaflow/pkg/daemon/client"
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	daemonclient "github.com/numaproj/numaflow/pkg/client/daemon"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
 )
 
 const (
 	finalizerName = dfv1.ControllerPipeline
+
+	// maxVertexErrorsInStatus is the number of the vertex errors listed in the status, the rest are only in the status
+	// of the vertices
+	maxVertexErrorsInStatus = 5
+	// maxPendingChangesInStatus is the number of the pending changes listed in the status
+	maxPendingChangesInStatus = 100
 )
 
 // pipelineReconciler reconciles a pipeline object.
@@ -52,19 +61,23 @@ func (r *pipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	pl := &dfv1.Pipeline{}
 	if err := r.client.Get(ctx, req.NamespacedName, pl); err != nil {
 		if apierrors.IsNotFound(err) {
+			controllers.ForgetPipeline(req.Namespace, req.Name)
 			return reconcile.Result{}, nil
 		}
 		r.logger.Errorw("Unable to get pipeline", zap.Any("request", req), zap.Error(err))
 		return ctrl.Result{}, err
 	}
-	log := r.logger.With("namespace", pl.Namespace).With("pipeline", pl.Name)
+	log := r.logger.With("namespace", pl.Namespace).With("pipeline", pl.Name).With(logging.CorrelationIDKey, pl.GetCorrelationID())
 	plCopy := pl.DeepCopy()
 	ctx = logging.WithLogger(ctx, log)
 
+	start := time.Now()
 	result, reconcileErr := r.reconcile(ctx, plCopy)
+	controllers.ObserveReconcile(dfv1.ControllerPipeline, pl.Namespace, pl.Name, start, reconcileErr)
 	if reconcileErr != nil {
 		log.Errorw("Reconcile error", zap.Error(reconcileErr))
 	}
+	controllers.ObservePipelineHealth(pl, plCopy)
 	plCopy.Status.LastUpdated = metav1.Now()
 	if needsUpdate(pl, plCopy) {
 		if err := r.client.Update(ctx, plCopy); err != nil {
@@ -74,6 +87,7 @@ func (r *pipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if err := r.client.Status().Update(ctx, plCopy); err != nil {
 		return result, err
 	}
+	r.notifyPhaseChange(pl, plCopy)
 	return result, reconcileErr
 }
 
@@ -83,18 +97,29 @@ func (r *pipelineReconciler) reconcile(ctx context.Context, pl *dfv1.Pipeline) (
 	if !pl.DeletionTimestamp.IsZero() {
 		log.Info("Deleting pipeline")
 		if controllerutil.ContainsFinalizer(pl, finalizerName) {
-			if time.Now().Before(pl.DeletionTimestamp.Add(time.Duration(pl.Spec.Lifecycle.DeleteGracePeriodSeconds) * time.Second)) {
-				safeToDelete, err := r.safeToDelete(ctx, pl)
+			if pl.Spec.Lifecycle.GetDeletionPolicy() == dfv1.DeletionPolicyOrphan {
+				// The buffers are left intact for a replacement pipeline, there's no need to drain them
+				log.Infow("Orphaned the buffers", zap.Strings("buffers", pl.GetAllBuffers()))
+				controllerutil.RemoveFinalizer(pl, finalizerName)
+				return ctrl.Result{}, nil
+			}
+			deadline := pl.DeletionTimestamp.Add(time.Duration(pl.Spec.Lifecycle.DeleteGracePeriodSeconds) * time.Second)
+			if remaining := time.Until(deadline); remaining > 0 {
+				drained, err := r.drainBeforeDelete(ctx, pl)
 				if err != nil {
-					log.Errorw("Failed to check if it's safe to delete the pipeline", zap.Error(err))
+					log.Errorw("Failed to drain the pipeline before deleting it", zap.Error(err))
 					return ctrl.Result{}, err
 				}
-
-				if !safeToDelete {
-					log.Info("Pipeline deletion is waiting to finish the unconsumed messages")
-					//Requeue request to process after 10s
-					return ctrl.Result{RequeueAfter: dfv1.DefaultRequeueAfter}, nil
+				if !drained {
+					log.Infow("Pipeline deletion is waiting for the pipeline to be drained", zap.String("status", pl.Status.Message))
+					// Requeue no later than the deadline, the buffers are deleted regardless then
+					if remaining > dfv1.DefaultRequeueAfter {
+						remaining = dfv1.DefaultRequeueAfter
+					}
+					return ctrl.Result{RequeueAfter: remaining}, nil
 				}
+			} else {
+				log.Warnw("The pipeline is not drained in the delete grace period, the messages not processed are deleted along with the buffers", zap.Int32("deleteGracePeriodSeconds", pl.Spec.Lifecycle.DeleteGracePeriodSeconds))
 			}
 			// Finalizer logic should be added here.
 			if err := r.cleanUpBuffers(ctx, pl, log); err != nil {
@@ -107,11 +132,28 @@ func (r *pipelineReconciler) reconcile(ctx context.Context, pl *dfv1.Pipeline) (
 		return ctrl.Result{}, nil
 	}
 
+	// The spec changed out of band is not rolled forward, until it's reverted or the field manager is allowed
+	managers, err := findOutOfBandManagers(pl)
+	if err != nil {
+		log.Errorw("Failed to check the field managers", zap.Error(err))
+		return ctrl.Result{}, err
+	}
+	if len(managers) > 0 {
+		log.Warnw("Reconciliation paused, the spec is changed by the field managers not allowed", zap.Strings("managers", managers))
+		pl.Status.SetPhase(pl.Status.Phase, fmt.Sprintf("Reconciliation paused, the spec is changed by %s, which are not in the %s annotation", strings.Join(managers, ","), dfv1.KeyAllowedFieldManagers))
+		return ctrl.Result{}, nil
+	}
+
 	// New, or reconciliation failed pipeline
 	if pl.Status.Phase == dfv1.PipelinePhaseUnknown || pl.Status.Phase == dfv1.PipelinePhaseFailed {
 		return r.reconcileNonLifecycleChanges(ctx, pl)
 	}
 
+	// A completed pipeline is left as it is, it's run again by recreating it
+	if pl.Status.Phase == dfv1.PipelinePhaseCompleted {
+		return ctrl.Result{}, nil
+	}
+
 	if oldPhase := pl.Status.Phase; oldPhase != pl.Spec.Lifecycle.DesiredPhase {
 		requeue, err := r.updateDesiredState(ctx, pl)
 		if err != nil {
@@ -128,7 +170,19 @@ func (r *pipelineReconciler) reconcile(ctx context.Context, pl *dfv1.Pipeline) (
 	}
 
 	// Regular pipeline update
-	return r.reconcileNonLifecycleChanges(ctx, pl)
+	result, err := r.reconcileNonLifecycleChanges(ctx, pl)
+	if err != nil || !pl.IsBounded() || pl.Status.Phase == dfv1.PipelinePhasePaused {
+		return result, err
+	}
+	requeue, err := r.completePipeline(ctx, pl)
+	if err != nil {
+		log.Errorw("Failed to check if the bounded pipeline is completed", zap.Error(err))
+		return ctrl.Result{}, err
+	}
+	if requeue {
+		return ctrl.Result{RequeueAfter: dfv1.DefaultRequeueAfter}, nil
+	}
+	return result, nil
 }
 
 // reconcileNonLifecycleChanges do the jobs not related to pipeline lifecycle changes.
@@ -143,14 +197,31 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 		pl.Status.MarkNotConfigured("InvalidSpec", err.Error())
 		return ctrl.Result{}, err
 	}
-	pl.Status.MarkConfigured()
+	plWithDefaults, err := r.withDefaults(ctx, pl)
+	if err != nil {
+		log.Errorw("Failed to apply the defaults", zap.Error(err))
+		pl.Status.MarkNotConfigured("InvalidDefaults", err.Error())
+		return ctrl.Result{}, err
+	}
+	renamedVertices, err := getRenamedVertices(pl)
+	if err != nil {
+		log.Errorw("Invalid renamed vertices", zap.Error(err))
+		pl.Status.MarkNotConfigured("InvalidRenamedVertices", err.Error())
+		return ctrl.Result{}, err
+	}
+	if warnings := GetPipelineWarnings(pl); len(warnings) > 0 {
+		log.Warnw("The pipeline is valid with warnings", zap.Strings("warnings", warnings))
+		pl.Status.MarkConfiguredWithWarnings("ValidWithWarnings", strings.Join(warnings, "; "))
+	} else {
+		pl.Status.MarkConfigured()
+	}
 
 	isbSvc := &dfv1.InterStepBufferService{}
 	isbSvcName := dfv1.DefaultISBSvcName
-	if len(pl.Spec.InterStepBufferServiceName) > 0 {
-		isbSvcName = pl.Spec.InterStepBufferServiceName
+	if len(plWithDefaults.Spec.InterStepBufferServiceName) > 0 {
+		isbSvcName = plWithDefaults.Spec.InterStepBufferServiceName
 	}
-	err := r.client.Get(ctx, types.NamespacedName{Namespace: pl.Namespace, Name: isbSvcName}, isbSvc)
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: pl.Namespace, Name: isbSvcName}, isbSvc)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			pl.Status.MarkDeployFailed("ISBSvcNotFound", "ISB Service not found.")
@@ -166,6 +237,11 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 		log.Errorw("ISB Service is not in ready status", zap.String("isbsvc", isbSvcName), zap.Error(err))
 		return ctrl.Result{}, fmt.Errorf("isbsvc not ready")
 	}
+	if err := ValidatePipelineOnISBSvc(pl, isbSvc); err != nil {
+		log.Errorw("Validation against the ISB Service failed", zap.String("isbsvc", isbSvcName), zap.Error(err))
+		pl.Status.MarkNotConfigured("UnsupportedByISBSvc", err.Error())
+		return ctrl.Result{}, err
+	}
 
 	existingObjs, err := r.findExistingVertices(ctx, pl)
 	if err != nil {
@@ -173,22 +249,48 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 		pl.Status.MarkDeployFailed("ListVerticesFailed", err.Error())
 		return ctrl.Result{}, err
 	}
+	pl.Status.Vertices = summarizeVertexPhases(existingObjs)
+	if msg := summarizeVertexErrors(existingObjs); msg != "" {
+		pl.Status.MarkDegraded("VertexFailing", msg)
+	} else {
+		pl.Status.MarkNotDegraded()
+	}
 	oldBufferNames := make(map[string]string)
 	newBufferNames := make(map[string]string)
 	for _, v := range existingObjs {
-		for _, b := range v.GetFromBuffers() {
+		for _, b := range append(append(v.GetFromBuffers(), v.GetDeadLetterBuffers()...), v.GetReplyBuffers()...) {
 			oldBufferNames[b] = b
 		}
 	}
-	newObjs := buildVertices(pl)
-	for vertexName, newObj := range newObjs {
-		for _, b := range newObj.GetFromBuffers() {
+	newObjs := buildVertices(plWithDefaults, r.config.FeatureGates)
+	for _, newObj := range newObjs {
+		for _, b := range append(append(newObj.GetFromBuffers(), newObj.GetDeadLetterBuffers()...), newObj.GetReplyBuffers()...) {
 			if _, existing := oldBufferNames[b]; existing {
 				delete(oldBufferNames, b)
 			} else {
 				newBufferNames[b] = b
 			}
 		}
+	}
+	// The buffers of the renamed vertices are migrated instead of being recreated
+	migrations := bufferMigrations(pl, renamedVertices, newBufferNames, oldBufferNames)
+
+	if pl.Spec.Lifecycle.ConfirmDisruptiveChanges && len(existingObjs) > 0 {
+		changes, err := r.planChanges(ctx, plWithDefaults, isbSvc.Status.Config, existingObjs, newObjs, newBufferNames, oldBufferNames, migrations)
+		if err != nil {
+			pl.Status.MarkDeployFailed("PlanChangesFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+		if changes.IsDisruptive() && pl.GetAnnotations()[dfv1.KeyConfirmedChanges] != changes.Hash {
+			log.Infow("Disruptive changes waiting for confirmation", zap.String("hash", changes.Hash))
+			pl.Status.PendingChanges = compactPlannedChanges(changes, maxPendingChangesInStatus)
+			pl.Status.SetPhase(pl.Status.Phase, fmt.Sprintf("Changes pending for confirmation, annotate the pipeline with %s=%s to apply", dfv1.KeyConfirmedChanges, changes.Hash))
+			return ctrl.Result{}, nil
+		}
+	}
+	pl.Status.PendingChanges = nil
+
+	for vertexName, newObj := range newObjs {
 		if oldObj, existing := existingObjs[vertexName]; !existing {
 			if err := r.client.Create(ctx, &newObj); err != nil {
 				if apierrors.IsAlreadyExists(err) { // probably somebody else already created it
@@ -201,6 +303,17 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 			log.Infow("Created vertex successfully", zap.String("vertex", vertexName))
 		} else {
 			if oldObj.GetAnnotations()[dfv1.KeyHash] != newObj.GetAnnotations()[dfv1.KeyHash] { // need to update
+				// The replicas might have been scaled, keep them within the new scale range
+				if x := oldObj.Spec.Replicas; x != nil && *x > 0 && *newObj.Spec.Replicas > 0 {
+					replicas := *x
+					if min := newObj.Spec.Scale.GetMinReplicas(); replicas < min {
+						replicas = min
+					}
+					if max := newObj.Spec.Scale.GetMaxReplicas(); max > 0 && replicas > max {
+						replicas = max
+					}
+					newObj.Spec.Replicas = &replicas
+				}
 				oldObj.Spec = newObj.Spec
 				oldObj.Annotations[dfv1.KeyHash] = newObj.GetAnnotations()[dfv1.KeyHash]
 				if err := r.client.Update(ctx, &oldObj); err != nil {
@@ -219,6 +332,19 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 		}
 	}
 
+	if len(migrations) > 0 {
+		args := []string{"--delete-source"}
+		for from, to := range migrations {
+			args = append(args, fmt.Sprintf("--buffers=%s:%s", from, to))
+		}
+		batchJob := buildISBBatchJob(pl, r.config.GetImage(r.image), isbSvc.Status.Config, "isbsvc-buffer-migrate", args, "migrate")
+		if err := r.client.Create(ctx, batchJob); err != nil && !apierrors.IsAlreadyExists(err) {
+			pl.Status.MarkDeployFailed("CreateBufferMigratingJobFailed", err.Error())
+			return ctrl.Result{}, fmt.Errorf("failed to create buffer migrating job, err: %w", err)
+		}
+		log.Infow("Created buffer migrating job successfully", zap.Any("buffers", migrations))
+	}
+
 	// create batch job
 	if len(newBufferNames) > 0 {
 		names := []string{}
@@ -226,7 +352,15 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 			names = append(names, n)
 		}
 		args := []string{fmt.Sprintf("--buffers=%s", strings.Join(names, ","))}
-		batchJob := buildISBBatchJob(pl, r.image, isbSvc.Status.Config, "isbsvc-buffer-create", args, "create")
+		args = append(args, replayPolicyArgs(pl.Spec.ReplayPolicy)...)
+		if !pl.Spec.Lifecycle.AdoptExistingBuffers && !pl.CreationTimestamp.IsZero() {
+			// The buffers existing before the pipeline is created are left by a previous pipeline with the same name
+			args = append(args, "--purge-before="+pl.CreationTimestamp.UTC().Format(time.RFC3339))
+		}
+		if r.config.JetStreamMirrorFailover && isbSvc.Status.Config.JetStream != nil {
+			args = append(args, "--mirror-failover")
+		}
+		batchJob := buildISBBatchJob(pl, r.config.GetImage(r.image), isbSvc.Status.Config, "isbsvc-buffer-create", args, "create")
 		if err := r.client.Create(ctx, batchJob); err != nil && !apierrors.IsAlreadyExists(err) {
 			pl.Status.MarkDeployFailed("CreateBufferCreatingJobFailed", err.Error())
 			return ctrl.Result{}, fmt.Errorf("failed to create buffer creating job, err: %w", err)
@@ -240,7 +374,7 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 			names = append(names, n)
 		}
 		args := []string{fmt.Sprintf("--buffers=%s", strings.Join(names, ","))}
-		batchJob := buildISBBatchJob(pl, r.image, isbSvc.Status.Config, "isbsvc-buffer-delete", args, "delete")
+		batchJob := buildISBBatchJob(pl, r.config.GetImage(r.image), isbSvc.Status.Config, "isbsvc-buffer-delete", args, "delete")
 		if err := r.client.Create(ctx, batchJob); err != nil && !apierrors.IsAlreadyExists(err) {
 			pl.Status.MarkDeployFailed("CreateBufferDeletingJobFailed", err.Error())
 			return ctrl.Result{}, fmt.Errorf("failed to create buffer deleting job, err: %w", err)
@@ -253,12 +387,20 @@ func (r *pipelineReconciler) reconcileNonLifecycleChanges(ctx context.Context, p
 		return ctrl.Result{}, err
 	}
 	// Daemon deployment
-	if err := r.createOrUpdateDaemonDeployment(ctx, pl, isbSvc.Status.Config); err != nil {
+	if err := r.createOrUpdateDaemonDeployment(ctx, plWithDefaults, isbSvc.Status.Config); err != nil {
+		return ctrl.Result{}, err
+	}
+	// ServiceMonitor and PodMonitor
+	if err := r.reconcileMonitors(ctx, pl); err != nil {
 		return ctrl.Result{}, err
 	}
 
 	pl.Status.MarkDeployed()
 	pl.Status.SetPhase(pl.Spec.Lifecycle.DesiredPhase, "")
+	// The generation is only observed once it's applied, the disruptive changes held, the pausing and the reconciliation
+	// paused by the out-of-band changes return before
+	pl.Status.ObservedGeneration = pl.Generation
+	pl.Status.ObservedOperationID = pl.Annotations[dfv1.KeyOperationID]
 	return ctrl.Result{}, nil
 }
 
@@ -296,23 +438,44 @@ func (r *pipelineReconciler) createOrUpdateDaemonService(ctx context.Context, pl
 	return nil
 }
 
-func (r *pipelineReconciler) createOrUpdateDaemonDeployment(ctx context.Context, pl *dfv1.Pipeline, isbSvcConfig dfv1.BufferServiceConfig) error {
-	log := logging.FromContext(ctx)
+// buildDaemonDeployment builds the daemon deployment with the hash of the spec annotated.
+func (r *pipelineReconciler) buildDaemonDeployment(pl *dfv1.Pipeline, isbSvcConfig dfv1.BufferServiceConfig) (*appv1.Deployment, error) {
 	isbSvcType, envs := sharedutil.GetIsbSvcEnvVars(isbSvcConfig)
-	envs = append(envs, corev1.EnvVar{Name: dfv1.EnvPipelineName, Value: pl.Name})
+	envs = append(envs, corev1.EnvVar{Name: dfv1.EnvPipelineName, Value: pl.Name}, corev1.EnvVar{Name: dfv1.EnvCorrelationID, Value: pl.GetCorrelationID()})
 	req := dfv1.GetDaemonDeploymentReq{
 		ISBSvcType: isbSvcType,
-		Image:      r.image,
+		Image:      r.config.GetImage(r.image),
 		PullPolicy: corev1.PullPolicy(sharedutil.LookupEnvStringOr(dfv1.EnvImagePullPolicy, "")),
 		Env:        envs,
 	}
 	deploy, err := pl.GetDaemonDeploymentObj(req)
+	if err != nil {
+		return nil, err
+	}
+	vols, volMounts := sharedutil.GetIsbSvcVolumes(isbSvcConfig)
+	deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, vols...)
+	deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, volMounts...)
+	if n := pl.Spec.Notifications; n != nil && n.SLA != nil {
+		// The SLA violations are sent by the daemon server, which reads the secrets of the targets from the volumes
+		vols, volMounts := sharedutil.VolumesFromSecretsAndConfigMaps(n)
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, vols...)
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, volMounts...)
+	}
+	if pl.Status.Phase == dfv1.PipelinePhasePaused {
+		deploy.Spec.Replicas = pointer.Int32(0)
+	}
+	deploy.Annotations = map[string]string{dfv1.KeyHash: sharedutil.MustHash(deploy.Spec)}
+	return deploy, nil
+}
+
+func (r *pipelineReconciler) createOrUpdateDaemonDeployment(ctx context.Context, pl *dfv1.Pipeline, isbSvcConfig dfv1.BufferServiceConfig) error {
+	log := logging.FromContext(ctx)
+	deploy, err := r.buildDaemonDeployment(pl, isbSvcConfig)
 	if err != nil {
 		pl.Status.MarkDeployFailed("BuildDaemonDeployFailed", err.Error())
 		return fmt.Errorf("failed to build daemon deployment spec, %w", err)
 	}
-	deployHash := sharedutil.MustHash(deploy.Spec)
-	deploy.Annotations = map[string]string{dfv1.KeyHash: deployHash}
+	deployHash := deploy.Annotations[dfv1.KeyHash]
 	existingDeploy := &appv1.Deployment{}
 	if err := r.client.Get(ctx, types.NamespacedName{Namespace: pl.Namespace, Name: deploy.Name}, existingDeploy); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -340,6 +503,41 @@ func (r *pipelineReconciler) createOrUpdateDaemonDeployment(ctx context.Context,
 	return nil
 }
 
+// summarizeVertexErrors returns the last errors of the failing vertices in one message, only the first ones are listed
+// if there are many of them.
+func summarizeVertexErrors(vertices map[string]dfv1.Vertex) string {
+	msgs := []string{}
+	for _, v := range vertices {
+		if e := v.Status.LastError; e != nil {
+			msgs = append(msgs, fmt.Sprintf("vertex %q: %s", v.Spec.Name, e.Summary()))
+		}
+	}
+	sort.Strings(msgs)
+	if len(msgs) > maxVertexErrorsInStatus {
+		more := len(msgs) - maxVertexErrorsInStatus
+		msgs = append(msgs[:maxVertexErrorsInStatus], fmt.Sprintf("and %d more failing vertices, see the status of the vertices", more))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// summarizeVertexPhases returns the number of the vertices in each phase.
+func summarizeVertexPhases(vertices map[string]dfv1.Vertex) *dfv1.VerticesSummary {
+	summary := &dfv1.VerticesSummary{Total: int32(len(vertices))}
+	for _, v := range vertices {
+		switch v.Status.Phase {
+		case dfv1.VertexPhaseRunning:
+			summary.Running++
+		case dfv1.VertexPhaseSucceeded:
+			summary.Succeeded++
+		case dfv1.VertexPhaseFailed:
+			summary.Failed++
+		default:
+			summary.Pending++
+		}
+	}
+	return summary
+}
+
 func (r *pipelineReconciler) findExistingVertices(ctx context.Context, pl *dfv1.Pipeline) (map[string]dfv1.Vertex, error) {
 	vertices := &dfv1.VertexList{}
 	selector, _ := labels.Parse(dfv1.KeyPipelineName + "=" + pl.Name)
@@ -356,12 +554,16 @@ func (r *pipelineReconciler) findExistingVertices(ctx context.Context, pl *dfv1.
 func (r *pipelineReconciler) cleanUpBuffers(ctx context.Context, pl *dfv1.Pipeline, log *zap.SugaredLogger) error {
 	allBuffers := pl.GetAllBuffers()
 	if len(allBuffers) > 0 {
+		plWithDefaults, err := r.withDefaults(ctx, pl)
+		if err != nil {
+			return err
+		}
 		isbSvc := &dfv1.InterStepBufferService{}
 		isbSvcName := dfv1.DefaultISBSvcName
-		if len(pl.Spec.InterStepBufferServiceName) > 0 {
-			isbSvcName = pl.Spec.InterStepBufferServiceName
+		if len(plWithDefaults.Spec.InterStepBufferServiceName) > 0 {
+			isbSvcName = plWithDefaults.Spec.InterStepBufferServiceName
 		}
-		err := r.client.Get(ctx, types.NamespacedName{Namespace: pl.Namespace, Name: isbSvcName}, isbSvc)
+		err = r.client.Get(ctx, types.NamespacedName{Namespace: pl.Namespace, Name: isbSvcName}, isbSvc)
 		if err != nil {
 			if apierrors.IsNotFound(err) { // somehow it doesn't need to clean up
 				return nil
@@ -374,7 +576,7 @@ func (r *pipelineReconciler) cleanUpBuffers(ctx context.Context, pl *dfv1.Pipeli
 		for _, n := range allBuffers {
 			args = append(args, fmt.Sprintf("--buffers=%s", n))
 		}
-		batchJob := buildISBBatchJob(pl, r.image, isbSvc.Status.Config, "isbsvc-buffer-delete", args, "cleanup")
+		batchJob := buildISBBatchJob(pl, r.config.GetImage(r.image), isbSvc.Status.Config, "isbsvc-buffer-delete", args, "cleanup")
 		batchJob.OwnerReferences = []metav1.OwnerReference{}
 		if err := r.client.Create(ctx, batchJob); err != nil && !apierrors.IsAlreadyExists(err) {
 			return fmt.Errorf("failed to create buffer clean up job, err: %w", err)
@@ -394,7 +596,8 @@ func needsUpdate(old, new *dfv1.Pipeline) bool {
 	return false
 }
 
-func buildVertices(pl *dfv1.Pipeline) map[string]dfv1.Vertex {
+func buildVertices(pl *dfv1.Pipeline, defaultFeatureGates map[string]bool) map[string]dfv1.Vertex {
+	featureGates := dfv1.ResolveFeatureGates(defaultFeatureGates, pl.Spec.FeatureGates)
 	result := make(map[string]dfv1.Vertex)
 	for _, v := range pl.Spec.Vertices {
 		vertexFullName := pl.Name + "-" + v.Name
@@ -407,14 +610,45 @@ func buildVertices(pl *dfv1.Pipeline) map[string]dfv1.Vertex {
 		}
 		fromVertexNames := []string{}
 		toVertices := []dfv1.ToVertex{}
+		var readWeights map[string]uint32
+		var deadLetterQueues map[string]dfv1.DeadLetterQueue
+		var maxMessageAges map[string]metav1.Duration
+		var exactlyOnce map[string]bool
 		for _, e := range pl.GetFromEdges(v.Name) {
 			fromVertexNames = append(fromVertexNames, e.From)
+			if e.ReadWeight != nil {
+				if readWeights == nil {
+					readWeights = make(map[string]uint32)
+				}
+				readWeights[e.From] = *e.ReadWeight
+			}
+			if e.DeadLetterQueue != nil {
+				if deadLetterQueues == nil {
+					deadLetterQueues = make(map[string]dfv1.DeadLetterQueue)
+				}
+				deadLetterQueues[e.From] = *e.DeadLetterQueue
+			}
+			if x := e.Limits.GetMaxMessageAge(); x > 0 {
+				if maxMessageAges == nil {
+					maxMessageAges = make(map[string]metav1.Duration)
+				}
+				maxMessageAges[e.From] = metav1.Duration{Duration: x}
+			}
+			if e.Limits != nil && e.Limits.ExactlyOnce != nil {
+				if exactlyOnce == nil {
+					exactlyOnce = make(map[string]bool)
+				}
+				exactlyOnce[e.From] = *e.Limits.ExactlyOnce
+			}
 		}
 		for _, e := range pl.GetToEdges(v.Name) {
-			toVertices = append(toVertices, dfv1.ToVertex{Name: e.To, Conditions: e.Conditions})
+			toVertices = append(toVertices, dfv1.ToVertex{Name: e.To, Conditions: e.Conditions, Limits: copyEdgeLimits(pl, e), Trace: e.Trace})
 		}
 		vCopy := v.DeepCopy()
 		copyLimits(pl, vCopy)
+		if pl.Spec.Templates != nil {
+			pl.Spec.Templates.Vertex.ApplyToVertex(vCopy)
+		}
 		replicas := int32(1)
 		if pl.Status.Phase == dfv1.PipelinePhasePaused {
 			replicas = int32(0)
@@ -433,6 +667,14 @@ func buildVertices(pl *dfv1.Pipeline) map[string]dfv1.Vertex {
 			InterStepBufferServiceName: pl.Spec.InterStepBufferServiceName,
 			FromVertices:               fromVertexNames,
 			ToVertices:                 toVertices,
+			ReadWeights:                readWeights,
+			DeadLetterQueues:           deadLetterQueues,
+			MaxMessageAges:             maxMessageAges,
+			ExactlyOnce:                exactlyOnce,
+			FeatureGates:               featureGates,
+			PodSecurity:                pl.Spec.PodSecurity.DeepCopy(),
+			Audit:                      pl.Spec.Audit.DeepCopy(),
+			Tracing:                    pl.Spec.Tracing.DeepCopy(),
 			Replicas:                   &replicas,
 		}
 		hash := sharedutil.MustHash(spec.WithOutReplicas())
@@ -474,23 +716,73 @@ func copyLimits(pl *dfv1.Pipeline, v *dfv1.AbstractVertex) {
 	if v.Sink == nil && v.Limits.BufferUsageLimit == nil {
 		v.Limits.BufferUsageLimit = pl.Spec.Limits.BufferUsageLimit
 	}
+	if v.Sink == nil && v.Limits.MaxKeySize == nil {
+		v.Limits.MaxKeySize = pl.Spec.Limits.MaxKeySize
+	}
+	if v.Sink == nil && v.Limits.MaxHeaderSize == nil {
+		v.Limits.MaxHeaderSize = pl.Spec.Limits.MaxHeaderSize
+	}
+}
+
+// copyEdgeLimits returns the limits of the edge, with the ones not set taken from the encoding of the source it's from,
+// or the pipeline limits.
+func copyEdgeLimits(pl *dfv1.Pipeline, e dfv1.Edge) *dfv1.EdgeLimits {
+	if e.Limits.GetCompression() != "" {
+		return e.Limits
+	}
+	var compression dfv1.ContentEncoding
+	if v := pl.GetVertex(e.From); v != nil && v.Source != nil && v.Source.Encoding != nil {
+		compression = v.Source.Encoding.ISB
+	}
+	if compression == "" && pl.Spec.Limits != nil {
+		compression = pl.Spec.Limits.Compression
+	}
+	if compression == "" {
+		return e.Limits
+	}
+	el := e.Limits.DeepCopy()
+	if el == nil {
+		el = &dfv1.EdgeLimits{}
+	}
+	el.Compression = compression
+	return el
 }
 
 func buildISBBatchJob(pl *dfv1.Pipeline, image string, isbSvcConfig dfv1.BufferServiceConfig, subCommand string, args []string, jobType string) *batchv1.Job {
 	isbsType, envs := sharedutil.GetIsbSvcEnvVars(isbSvcConfig)
-	envs = append(envs, corev1.EnvVar{Name: dfv1.EnvPipelineName, Value: pl.Name})
+	envs = append(envs, sharedutil.GetIsbSvcMirrorEnvVars(isbSvcConfig)...)
+	envs = append(envs, corev1.EnvVar{Name: dfv1.EnvPipelineName, Value: pl.Name}, corev1.EnvVar{Name: dfv1.EnvCorrelationID, Value: pl.GetCorrelationID()})
 	c := corev1.Container{
 		Name:            dfv1.CtrMain,
 		Image:           image,
 		ImagePullPolicy: corev1.PullPolicy(sharedutil.LookupEnvStringOr(dfv1.EnvImagePullPolicy, "")),
 		Env:             envs,
 	}
+	vols, volMounts := sharedutil.GetIsbSvcVolumes(isbSvcConfig)
+	c.VolumeMounts = volMounts
 	c.Args = []string{subCommand, "--isbsvc-type=" + string(isbsType)}
 	c.Args = append(c.Args, args...)
 	randomStr := sharedutil.MustHash(pl.Spec)
 	if len(randomStr) > 6 {
 		randomStr = strings.ToLower(randomStr[:6])
 	}
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyOnFailure,
+			Containers:    []corev1.Container{c},
+			Volumes:       vols,
+		},
+	}
+	if pl.Spec.Templates != nil {
+		pl.Spec.Templates.Job.ApplyToPodTemplate(&template)
+	}
+	pl.Spec.PodSecurity.ApplyToPodSpec(&template.Spec)
+	for k, v := range pl.Spec.PodSecurity.AppArmorAnnotations(&template.Spec, template.Annotations) {
+		if template.Annotations == nil {
+			template.Annotations = make(map[string]string)
+		}
+		template.Annotations[k] = v
+	}
 	return &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: pl.Namespace,
@@ -505,16 +797,64 @@ func buildISBBatchJob(pl *dfv1.Pipeline, image string, isbSvcConfig dfv1.BufferS
 		Spec: batchv1.JobSpec{
 			TTLSecondsAfterFinished: pointer.Int32(30),
 			BackoffLimit:            pointer.Int32(20),
-			Template: corev1.PodTemplateSpec{
-				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyOnFailure,
-					Containers:    []corev1.Container{c},
-				},
-			},
+			Template:                template,
 		},
 	}
 }
 
+// replayPolicyArgs returns the buffer creating command args of the replay policy.
+func replayPolicyArgs(rp *dfv1.ReplayPolicy) []string {
+	if rp == nil {
+		return nil
+	}
+	args := []string{"--deliver-policy=" + string(rp.GetDeliverPolicy())}
+	if rp.GetDeliverPolicy() == dfv1.DeliverPolicyByStartTime && rp.StartTime != nil {
+		args = append(args, "--start-time="+rp.StartTime.UTC().Format(time.RFC3339))
+	}
+	return args
+}
+
+// getRenamedVertices returns the new names to the old names of the renamed vertices.
+func getRenamedVertices(pl *dfv1.Pipeline) (map[string]string, error) {
+	renamed := map[string]string{}
+	if a, ok := pl.GetAnnotations()[dfv1.KeyRenamedVertices]; ok {
+		if err := json.Unmarshal([]byte(a), &renamed); err != nil {
+			return nil, fmt.Errorf("invalid annotation %q, %w", dfv1.KeyRenamedVertices, err)
+		}
+	}
+	return renamed, nil
+}
+
+// bufferMigrations returns the old buffer names to the new ones of the edges connecting renamed vertices,
+// and removes them from the new buffers to be created and the old buffers to be deleted.
+func bufferMigrations(pl *dfv1.Pipeline, renamedVertices map[string]string, newBufferNames, oldBufferNames map[string]string) map[string]string {
+	oldName := func(vertex string) string {
+		if n, ok := renamedVertices[vertex]; ok {
+			return n
+		}
+		return vertex
+	}
+	migrations := make(map[string]string)
+	migrate := func(oldBuffer, newBuffer string) {
+		if _, ok := newBufferNames[newBuffer]; !ok {
+			return
+		}
+		if _, ok := oldBufferNames[oldBuffer]; !ok {
+			return
+		}
+		migrations[oldBuffer] = newBuffer
+		delete(newBufferNames, newBuffer)
+		delete(oldBufferNames, oldBuffer)
+	}
+	for _, e := range pl.Spec.Edges {
+		newBuffer := dfv1.GenerateBufferName(pl.Namespace, pl.Name, e.From, e.To)
+		oldBuffer := dfv1.GenerateBufferName(pl.Namespace, pl.Name, oldName(e.From), oldName(e.To))
+		migrate(oldBuffer, newBuffer)
+		migrate(dfv1.DeadLetterBufferName(oldBuffer), dfv1.DeadLetterBufferName(newBuffer))
+	}
+	return migrations
+}
+
 type vertexFilterFunc func(v dfv1.Vertex) bool
 
 var allVertexFilter vertexFilterFunc = func(v dfv1.Vertex) bool { return true }
@@ -532,11 +872,15 @@ func (r *pipelineReconciler) updateDesiredState(ctx context.Context, pl *dfv1.Pi
 }
 
 func (r *pipelineReconciler) resumePipeline(ctx context.Context, pl *dfv1.Pipeline) (bool, error) {
+	if err := r.scaleDaemon(ctx, pl, 1); err != nil {
+		return false, err
+	}
 	_, err := r.scaleUpAllVertices(ctx, pl)
 	if err != nil {
 		return false, err
 	}
 	pl.Status.MarkPhaseRunning()
+	pl.Status.ObservedOperationID = pl.Annotations[dfv1.KeyOperationID]
 	return false, nil
 }
 
@@ -553,6 +897,7 @@ func (r *pipelineReconciler) pausePipeline(ctx context.Context, pl *dfv1.Pipelin
 	if err != nil {
 		return true, err
 	}
+	defer func() { _ = daemonClient.Close() }()
 	drainCompleted, err := daemonClient.IsDrained(ctx, pl.Name)
 	if err != nil {
 		return true, err
@@ -562,25 +907,71 @@ func (r *pipelineReconciler) pausePipeline(ctx context.Context, pl *dfv1.Pipelin
 		if err != nil {
 			return true, err
 		}
+		// The daemon is not needed until the pipeline is resumed
+		if err := r.scaleDaemon(ctx, pl, 0); err != nil {
+			return true, err
+		}
 		pl.Status.MarkPhasePaused()
+		pl.Status.ObservedOperationID = pl.Annotations[dfv1.KeyOperationID]
 		return false, nil
 	}
 	return true, nil
 }
 
+// completePipeline moves a bounded pipeline to completed, once all the replicas of its sources reach the end, and all
+// the buffers are drained, i.e. the messages are written and acknowledged by the sinks. The vertices are then scaled
+// down the same as pausing, so that the pods are drained before exiting. It returns true if it's to be checked again.
+func (r *pipelineReconciler) completePipeline(ctx context.Context, pl *dfv1.Pipeline) (bool, error) {
+	existingVertices, err := r.findExistingVertices(ctx, pl)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range existingVertices {
+		if v.IsASource() && v.Status.Phase != dfv1.VertexPhaseSucceeded {
+			return true, nil
+		}
+	}
+	daemonClient, err := daemonclient.NewDaemonServiceClient(pl.GetDaemonServiceURL())
+	if err != nil {
+		return true, err
+	}
+	defer func() { _ = daemonClient.Close() }()
+	drained, err := daemonClient.IsDrained(ctx, pl.Name)
+	if err != nil || !drained {
+		return true, err
+	}
+	if _, err := r.scaleDownAllVertices(ctx, pl); err != nil {
+		return true, err
+	}
+	if err := r.scaleDaemon(ctx, pl, 0); err != nil {
+		return true, err
+	}
+	logging.FromContext(ctx).Info("Bounded pipeline completed")
+	pl.Status.MarkPhaseCompleted()
+	return false, nil
+}
+
 func (r *pipelineReconciler) scaleDownSourceVertices(ctx context.Context, pl *dfv1.Pipeline) (bool, error) {
-	return r.scaleVertex(ctx, pl, sourceVertexFilter, 0)
+	return r.scaleVertex(ctx, pl, sourceVertexFilter, func(dfv1.Vertex) int32 { return 0 })
 }
 
 func (r *pipelineReconciler) scaleDownAllVertices(ctx context.Context, pl *dfv1.Pipeline) (bool, error) {
-	return r.scaleVertex(ctx, pl, allVertexFilter, 0)
+	return r.scaleVertex(ctx, pl, allVertexFilter, func(dfv1.Vertex) int32 { return 0 })
 }
 
+// scaleUpAllVertices restores the replicas of the vertices before the pipeline is paused, defaults to 1.
 func (r *pipelineReconciler) scaleUpAllVertices(ctx context.Context, pl *dfv1.Pipeline) (bool, error) {
-	return r.scaleVertex(ctx, pl, allVertexFilter, 1)
+	return r.scaleVertex(ctx, pl, allVertexFilter, func(v dfv1.Vertex) int32 {
+		if x, err := strconv.Atoi(v.GetAnnotations()[dfv1.KeyPausedReplicas]); err == nil && x > 0 {
+			return int32(x)
+		}
+		return 1
+	})
 }
 
-func (r *pipelineReconciler) scaleVertex(ctx context.Context, pl *dfv1.Pipeline, filter vertexFilterFunc, replicas int32) (bool, error) {
+// scaleVertex scales the vertices to the desired replicas. The replicas before scaling down to 0 are kept in the
+// annotation of the vertex, which is removed once it's scaled up.
+func (r *pipelineReconciler) scaleVertex(ctx context.Context, pl *dfv1.Pipeline, filter vertexFilterFunc, desiredReplicas func(dfv1.Vertex) int32) (bool, error) {
 	log := logging.FromContext(ctx)
 	existingVertices, err := r.findExistingVertices(ctx, pl)
 	if err != nil {
@@ -588,37 +979,117 @@ func (r *pipelineReconciler) scaleVertex(ctx context.Context, pl *dfv1.Pipeline,
 	}
 	isVertexPatched := false
 	for _, vertex := range existingVertices {
-		if origin := *vertex.Spec.Replicas; origin != replicas && filter(vertex) {
-			vertex.Spec.Replicas = pointer.Int32(replicas)
-			body, err := json.Marshal(vertex)
-			if err != nil {
-				return false, err
-			}
-			err = r.client.Patch(ctx, &vertex, client.RawPatch(types.MergePatchType, body))
-			if err != nil && !apierrors.IsNotFound(err) {
-				return false, err
-			}
-			log.Infow("Scaled vertex", zap.Int32("from", origin), zap.Int32("to", replicas), zap.String("vertex", vertex.Name))
-			isVertexPatched = true
+		if !filter(vertex) {
+			continue
+		}
+		replicas := desiredReplicas(vertex)
+		origin := *vertex.Spec.Replicas
+		if origin == replicas {
+			continue
+		}
+		annotations := map[string]interface{}{}
+		if replicas == 0 {
+			annotations[dfv1.KeyPausedReplicas] = strconv.Itoa(int(origin))
+		} else {
+			annotations[dfv1.KeyPausedReplicas] = nil
+		}
+		body, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"annotations": annotations},
+			"spec":     map[string]interface{}{"replicas": replicas},
+		})
+		if err != nil {
+			return false, err
 		}
+		err = r.client.Patch(ctx, &vertex, client.RawPatch(types.MergePatchType, body))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		log.Infow("Scaled vertex", zap.Int32("from", origin), zap.Int32("to", replicas), zap.String("vertex", vertex.Name))
+		isVertexPatched = true
 	}
 	return isVertexPatched, nil
 }
 
-func (r *pipelineReconciler) safeToDelete(ctx context.Context, pl *dfv1.Pipeline) (bool, error) {
-	// update the phase to deleting
-	pl.Status.MarkPhaseDeleting()
-	vertexPatched, err := r.scaleDownSourceVertices(ctx, pl)
+// scaleDaemon scales the daemon deployment of the pipeline to the replicas.
+func (r *pipelineReconciler) scaleDaemon(ctx context.Context, pl *dfv1.Pipeline, replicas int32) error {
+	deploy := &appv1.Deployment{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: pl.Namespace, Name: pl.GetDaemonDeploymentName()}, deploy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get daemon deployment, %w", err)
+	}
+	if deploy.Spec.Replicas != nil && *deploy.Spec.Replicas == replicas {
+		return nil
+	}
+	body := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	if err := r.client.Patch(ctx, deploy, client.RawPatch(types.MergePatchType, body)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to scale daemon deployment, %w", err)
+	}
+	logging.FromContext(ctx).Infow("Scaled daemon deployment", zap.Int32("to", replicas), zap.String("deployment", deploy.Name))
+	return nil
+}
+
+// drainBeforeDelete drains the pipeline being deleted, so that no data is dropped by deleting the buffers. The sources
+// are scaled down to stop reading first, once there are no pending or ack pending messages in the buffers, all the
+// vertices are scaled down, and the pods process the messages in flight before exiting. It returns true once all the
+// vertex pods are gone. A paused or completed pipeline, of which the vertices are scaled down already, is drained.
+func (r *pipelineReconciler) drainBeforeDelete(ctx context.Context, pl *dfv1.Pipeline) (bool, error) {
+	existingVertices, err := r.findExistingVertices(ctx, pl)
 	if err != nil {
 		return false, err
 	}
-	//Requeue pipeline to take effect the vertex replica changes
-	if vertexPatched {
+	running := false
+	for _, v := range existingVertices {
+		if v.Spec.Replicas == nil || *v.Spec.Replicas > 0 {
+			running = true
+		}
+	}
+	if running {
+		vertexPatched, err := r.scaleDownSourceVertices(ctx, pl)
+		if err != nil {
+			return false, err
+		}
+		// Requeue to give the sources some time to stop, otherwise the pending counts may be incorrect
+		if vertexPatched {
+			pl.Status.SetPhase(dfv1.PipelinePhaseDeleting, "Draining, the sources are stopped")
+			return false, nil
+		}
+		daemonClient, err := daemonclient.NewDaemonServiceClient(pl.GetDaemonServiceURL())
+		if err != nil {
+			return false, err
+		}
+		defer func() { _ = daemonClient.Close() }()
+		buffers, err := daemonClient.ListPipelineBuffers(ctx, pl.Name)
+		if err != nil {
+			return false, err
+		}
+		if pending := pendingMessages(buffers); pending > 0 {
+			pl.Status.SetPhase(dfv1.PipelinePhaseDeleting, fmt.Sprintf("Draining, %d messages pending in the buffers", pending))
+			return false, nil
+		}
+		if _, err := r.scaleDownAllVertices(ctx, pl); err != nil {
+			return false, err
+		}
+	}
+	pods := &corev1.PodList{}
+	selector, _ := labels.Parse(dfv1.KeyComponent + "=" + dfv1.ComponentVertex + "," + dfv1.KeyPipelineName + "=" + pl.Name)
+	if err := r.client.List(ctx, pods, &client.ListOptions{Namespace: pl.Namespace, LabelSelector: selector}); err != nil {
+		return false, fmt.Errorf("failed to list vertex pods, %w", err)
+	}
+	if len(pods.Items) > 0 {
+		pl.Status.SetPhase(dfv1.PipelinePhaseDeleting, fmt.Sprintf("Draining, waiting for %d vertex pods to exit", len(pods.Items)))
 		return false, nil
 	}
-	daemonClient, err := daemonclient.NewDaemonServiceClient(pl.GetDaemonServiceURL())
-	if err != nil {
-		return false, err
+	pl.Status.SetPhase(dfv1.PipelinePhaseDeleting, "Drained, deleting the buffers")
+	return true, nil
+}
+
+// pendingMessages returns the number of the pending and ack pending messages in the buffers.
+func pendingMessages(buffers []*daemonpb.BufferInfo) int64 {
+	var pending int64
+	for _, b := range buffers {
+		pending += b.GetPendingCount() + b.GetAckPendingCount()
 	}
-	return daemonClient.IsDrained(ctx, pl.Name)
+	return pending
 }
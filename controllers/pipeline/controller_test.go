@@ -2,10 +2,14 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/numaproj/numaflow/controllers"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zaptest"
 	appv1 "k8s.io/api/apps/v1"
@@ -13,10 +17,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -123,13 +130,331 @@ func Test_reconcile(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(jobs.Items))
 	})
+
+	t.Run("test reconcile purging existing buffers", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+		testIsbSvc.Status.MarkConfigured()
+		testIsbSvc.Status.MarkDeployed()
+		assert.NoError(t, cl.Create(ctx, testIsbSvc))
+		r := &pipelineReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testPipeline.DeepCopy()
+		testObj.CreationTimestamp = metav1.NewTime(time.Date(2022, 6, 1, 7, 0, 0, 0, time.UTC))
+		_, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		jobs := &batchv1.JobList{}
+		selector, _ := labels.Parse(dfv1.KeyPipelineName + "=" + testObj.Name)
+		assert.NoError(t, r.client.List(ctx, jobs, &client.ListOptions{Namespace: testNamespace, LabelSelector: selector}))
+		assert.Equal(t, 1, len(jobs.Items))
+		assert.Contains(t, jobs.Items[0].Spec.Template.Spec.Containers[0].Args, "--purge-before=2022-06-01T07:00:00Z")
+
+		// Adopting the existing buffers
+		testObj = testPipeline.DeepCopy()
+		testObj.Name = "adopting-pl"
+		testObj.CreationTimestamp = metav1.NewTime(time.Date(2022, 6, 1, 7, 0, 0, 0, time.UTC))
+		testObj.Spec.Lifecycle.AdoptExistingBuffers = true
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		selector, _ = labels.Parse(dfv1.KeyPipelineName + "=" + testObj.Name)
+		assert.NoError(t, r.client.List(ctx, jobs, &client.ListOptions{Namespace: testNamespace, LabelSelector: selector}))
+		assert.Equal(t, 1, len(jobs.Items))
+		for _, arg := range jobs.Items[0].Spec.Template.Spec.Containers[0].Args {
+			assert.NotContains(t, arg, "--purge-before")
+		}
+	})
+
+	t.Run("test reconcile failing over to jetstream mirrors", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+		testIsbSvc.Status.Config.JetStream = &dfv1.JetStreamConfig{URL: "nats://isbsvc:4222"}
+		testIsbSvc.Status.MarkConfigured()
+		testIsbSvc.Status.MarkDeployed()
+		assert.NoError(t, cl.Create(ctx, testIsbSvc))
+		config := *fakeConfig
+		config.JetStreamMirrorFailover = true
+		r := &pipelineReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: &config,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testPipeline.DeepCopy()
+		_, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		jobs := &batchv1.JobList{}
+		selector, _ := labels.Parse(dfv1.KeyPipelineName + "=" + testObj.Name)
+		assert.NoError(t, r.client.List(ctx, jobs, &client.ListOptions{Namespace: testNamespace, LabelSelector: selector}))
+		assert.Equal(t, 1, len(jobs.Items))
+		assert.Contains(t, jobs.Items[0].Spec.Template.Spec.Containers[0].Args, "--mirror-failover")
+	})
+
+	t.Run("test reconcile holding disruptive changes", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+		testIsbSvc.Status.MarkConfigured()
+		testIsbSvc.Status.MarkDeployed()
+		assert.NoError(t, cl.Create(ctx, testIsbSvc))
+		r := &pipelineReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Lifecycle.ConfirmDisruptiveChanges = true
+		_, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Nil(t, testObj.Status.PendingChanges)
+
+		testObj.Spec.Vertices = append(testObj.Spec.Vertices, dfv1.AbstractVertex{Name: "output2", Sink: &dfv1.Sink{}})
+		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "p1", To: "output2"})
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		changes := testObj.Status.PendingChanges
+		assert.NotNil(t, changes)
+		assert.Equal(t, []string{"test-ns-test-pl-p1-output2"}, changes.BuffersToCreate)
+		assert.Equal(t, []string{"test-pl-output2"}, changes.VerticesToCreate)
+		assert.Equal(t, []string{"test-pl-daemon"}, changes.DeploymentsToUpdate)
+		assert.Contains(t, testObj.Status.Message, changes.Hash)
+		vertices, err := r.findExistingVertices(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Len(t, vertices, 3)
+
+		testObj.Annotations = map[string]string{dfv1.KeyConfirmedChanges: changes.Hash}
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Nil(t, testObj.Status.PendingChanges)
+		vertices, err = r.findExistingVertices(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Len(t, vertices, 4)
+	})
+
+	t.Run("test reconcile paused by out-of-band changes", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+		testIsbSvc.Status.MarkConfigured()
+		testIsbSvc.Status.MarkDeployed()
+		assert.NoError(t, cl.Create(ctx, testIsbSvc))
+		r := &pipelineReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testPipeline.DeepCopy()
+		testObj.Annotations = map[string]string{dfv1.KeyAllowedFieldManagers: "argocd-controller"}
+		testObj.ManagedFields = []metav1.ManagedFieldsEntry{
+			{Manager: "argocd-controller", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:vertices":{}}}`)}},
+			{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:limits":{}}}`)}},
+		}
+		_, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Contains(t, testObj.Status.Message, "Reconciliation paused, the spec is changed by kubectl-edit")
+		vertices, err := r.findExistingVertices(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Len(t, vertices, 0)
+
+		testObj.Annotations[dfv1.KeyAllowedFieldManagers] = "argocd-controller,kubectl-edit"
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		vertices, err = r.findExistingVertices(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Len(t, vertices, 3)
+	})
+
+	t.Run("test reconcile keeping scaled replicas", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+		testIsbSvc.Status.MarkConfigured()
+		testIsbSvc.Status.MarkDeployed()
+		assert.NoError(t, cl.Create(ctx, testIsbSvc))
+		r := &pipelineReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices[1].Scale = dfv1.Scale{Min: pointer.Int32(1), Max: pointer.Int32(5)}
+		_, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		vertices, err := r.findExistingVertices(ctx, testObj)
+		assert.NoError(t, err)
+		v := vertices["test-pl-p1"]
+		v.Spec.Replicas = pointer.Int32(4)
+		assert.NoError(t, cl.Update(ctx, &v))
+
+		testObj.Spec.Vertices[1].Scale.Max = pointer.Int32(3)
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		vertices, err = r.findExistingVertices(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), *vertices["test-pl-p1"].Spec.Replicas)
+	})
+
+	t.Run("test reconcile deleting with orphan policy", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		r := &pipelineReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Lifecycle.DeletionPolicy = dfv1.DeletionPolicyOrphan
+		testObj.Spec.Lifecycle.DeleteGracePeriodSeconds = 30
+		now := metav1.Now()
+		testObj.DeletionTimestamp = &now
+		controllerutil.AddFinalizer(testObj, finalizerName)
+		_, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.False(t, controllerutil.ContainsFinalizer(testObj, finalizerName))
+		jobs := &batchv1.JobList{}
+		assert.NoError(t, r.client.List(ctx, jobs, &client.ListOptions{Namespace: testNamespace}))
+		assert.Equal(t, 0, len(jobs.Items))
+	})
+}
+
+func Test_getRenamedVertices(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	r, err := getRenamedVertices(pl)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(r))
+	pl.Annotations = map[string]string{dfv1.KeyRenamedVertices: `{"p1":"p0"}`}
+	r, err = getRenamedVertices(pl)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"p1": "p0"}, r)
+	pl.Annotations[dfv1.KeyRenamedVertices] = "p1"
+	_, err = getRenamedVertices(pl)
+	assert.Error(t, err)
+}
+
+func Test_bufferMigrations(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	name := func(from, to string) string { return dfv1.GenerateBufferName(pl.Namespace, pl.Name, from, to) }
+	newBufferNames := map[string]string{name("input", "p1"): name("input", "p1"), name("p1", "output"): name("p1", "output")}
+	oldBufferNames := map[string]string{name("input", "p0"): name("input", "p0"), name("p0", "output"): name("p0", "output")}
+	assert.Equal(t, 0, len(bufferMigrations(pl, map[string]string{}, newBufferNames, oldBufferNames)))
+	assert.Equal(t, 2, len(newBufferNames))
+	assert.Equal(t, 2, len(oldBufferNames))
+	r := bufferMigrations(pl, map[string]string{"p1": "p0"}, newBufferNames, oldBufferNames)
+	assert.Equal(t, map[string]string{name("input", "p0"): name("input", "p1"), name("p0", "output"): name("p1", "output")}, r)
+	assert.Equal(t, 0, len(newBufferNames))
+	assert.Equal(t, 0, len(oldBufferNames))
+
+	newBufferNames = map[string]string{dfv1.DeadLetterBufferName(name("input", "p1")): dfv1.DeadLetterBufferName(name("input", "p1"))}
+	oldBufferNames = map[string]string{dfv1.DeadLetterBufferName(name("input", "p0")): dfv1.DeadLetterBufferName(name("input", "p0"))}
+	r = bufferMigrations(pl, map[string]string{"p1": "p0"}, newBufferNames, oldBufferNames)
+	assert.Equal(t, map[string]string{dfv1.DeadLetterBufferName(name("input", "p0")): dfv1.DeadLetterBufferName(name("input", "p1"))}, r)
 }
 
 func Test_buildVertices(t *testing.T) {
-	r := buildVertices(testPipeline)
+	r := buildVertices(testPipeline, nil)
 	assert.Equal(t, 3, len(r))
 	_, existing := r[testPipeline.Name+"-"+testPipeline.Spec.Vertices[0].Name]
 	assert.True(t, existing)
+	assert.Nil(t, r[testPipeline.Name+"-"+testPipeline.Spec.Vertices[1].Name].Spec.ReadWeights)
+
+	pl := testPipeline.DeepCopy()
+	w := uint32(3)
+	pl.Spec.Edges[0].ReadWeight = &w
+	r = buildVertices(pl, nil)
+	assert.Equal(t, map[string]uint32{pl.Spec.Edges[0].From: 3}, r[pl.Name+"-"+pl.Spec.Edges[0].To].Spec.ReadWeights)
+	assert.Nil(t, r[pl.Name+"-"+pl.Spec.Edges[0].To].Spec.DeadLetterQueues)
+
+	pl.Spec.Edges[0].DeadLetterQueue = &dfv1.DeadLetterQueue{}
+	r = buildVertices(pl, nil)
+	assert.Equal(t, map[string]dfv1.DeadLetterQueue{pl.Spec.Edges[0].From: {}}, r[pl.Name+"-"+pl.Spec.Edges[0].To].Spec.DeadLetterQueues)
+	assert.Nil(t, r[pl.Name+"-"+pl.Spec.Edges[0].To].Spec.MaxMessageAges)
+
+	pl.Spec.Edges[0].Limits = &dfv1.EdgeLimits{MaxMessageAge: &metav1.Duration{Duration: time.Hour}}
+	r = buildVertices(pl, nil)
+	assert.Equal(t, map[string]metav1.Duration{pl.Spec.Edges[0].From: {Duration: time.Hour}}, r[pl.Name+"-"+pl.Spec.Edges[0].To].Spec.MaxMessageAges)
+
+	exactlyOnce := false
+	pl.Spec.Edges[0].Limits = &dfv1.EdgeLimits{ExactlyOnce: &exactlyOnce}
+	r = buildVertices(pl, nil)
+	from := r[pl.Name+"-"+pl.Spec.Edges[0].From]
+	assert.False(t, from.IsExactlyOnceToBuffer(from.GetToBufferName(pl.Spec.Edges[0].To)))
+	to := r[pl.Name+"-"+pl.Spec.Edges[0].To]
+	assert.Equal(t, map[string]bool{pl.Spec.Edges[0].From: false}, to.Spec.ExactlyOnce)
+	assert.False(t, to.IsExactlyOnceFromBuffer(from.GetToBufferName(pl.Spec.Edges[0].To)))
+	r = buildVertices(pl, map[string]bool{dfv1.FeatureGateExactlyOnce: true})
+	to = r[pl.Name+"-"+pl.Spec.Edges[0].To]
+	assert.False(t, to.IsExactlyOnceFromBuffer(from.GetToBufferName(pl.Spec.Edges[0].To)))
+	pl.Spec.Edges[0].Limits = nil
+	// The edges without limits of the pipelines created before the feature gate keep deduplicating
+	r = buildVertices(pl, nil)
+	from = r[pl.Name+"-"+pl.Spec.Edges[0].From]
+	assert.True(t, from.IsExactlyOnceToBuffer(from.GetToBufferName(pl.Spec.Edges[0].To)))
+	assert.True(t, r[pl.Name+"-"+pl.Spec.Edges[0].To].IsExactlyOnceFromBuffer(from.GetToBufferName(pl.Spec.Edges[0].To)))
+	r = buildVertices(pl, map[string]bool{dfv1.FeatureGateExactlyOnce: true})
+	from = r[pl.Name+"-"+pl.Spec.Edges[0].From]
+	assert.True(t, from.IsExactlyOnceToBuffer(from.GetToBufferName(pl.Spec.Edges[0].To)))
+	to = r[pl.Name+"-"+pl.Spec.Edges[0].To]
+	assert.Nil(t, to.Spec.ExactlyOnce)
+	assert.True(t, to.IsExactlyOnceFromBuffer(from.GetToBufferName(pl.Spec.Edges[0].To)))
+
+	pl.Spec.FeatureGates = map[string]bool{dfv1.FeatureGateWatermark: false}
+	r = buildVertices(pl, map[string]bool{dfv1.FeatureGateExactlyOnce: true, dfv1.FeatureGateWatermark: true})
+	v := r[pl.Name+"-"+pl.Spec.Vertices[0].Name]
+	assert.True(t, v.IsFeatureEnabled(dfv1.FeatureGateExactlyOnce))
+	assert.False(t, v.IsFeatureEnabled(dfv1.FeatureGateWatermark))
+
+	pl.Spec.PodSecurity = &dfv1.PodSecurity{Disabled: true}
+	r = buildVertices(pl, nil)
+	v = r[pl.Name+"-"+pl.Spec.Vertices[0].Name]
+	assert.True(t, v.Spec.PodSecurity.IsDisabled())
+
+	pl.Spec.Audit = &dfv1.PipelineAudit{Retention: &metav1.Duration{Duration: time.Hour}}
+	r = buildVertices(pl, nil)
+	v = r[pl.Name+"-"+pl.Spec.Vertices[0].Name]
+	assert.Equal(t, time.Hour, v.Spec.Audit.GetRetention())
+
+	pl.Spec.Tracing = &dfv1.PipelineTracing{Endpoint: "http://otel-collector:4318/v1/traces"}
+	r = buildVertices(pl, nil)
+	v = r[pl.Name+"-"+pl.Spec.Vertices[0].Name]
+	assert.Equal(t, "http://otel-collector:4318/v1/traces", v.Spec.Tracing.Endpoint)
+
+	pl.Spec.Vertices[0].NodeSelector = map[string]string{"disk": "ssd"}
+	pl.Spec.Templates = &dfv1.Templates{Vertex: &dfv1.AbstractPodTemplate{
+		NodeSelector: map[string]string{"pool": "numaflow", "disk": "hdd"},
+		Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "numaflow", Effect: corev1.TaintEffectNoSchedule}},
+	}}
+	r = buildVertices(pl, nil)
+	v = r[pl.Name+"-"+pl.Spec.Vertices[0].Name]
+	assert.Equal(t, map[string]string{"pool": "numaflow", "disk": "ssd"}, v.Spec.NodeSelector)
+	assert.Len(t, v.Spec.Tolerations, 1)
+	v = r[pl.Name+"-"+pl.Spec.Vertices[1].Name]
+	assert.Equal(t, map[string]string{"pool": "numaflow", "disk": "hdd"}, v.Spec.NodeSelector)
+	// the pipeline spec is not changed
+	assert.Equal(t, map[string]string{"disk": "ssd"}, pl.Spec.Vertices[0].NodeSelector)
+	assert.Nil(t, pl.Spec.Vertices[0].Tolerations)
+}
+
+func Test_replayPolicyArgs(t *testing.T) {
+	assert.Nil(t, replayPolicyArgs(nil))
+	assert.Equal(t, []string{"--deliver-policy=DeliverAll"}, replayPolicyArgs(&dfv1.ReplayPolicy{}))
+	assert.Equal(t, []string{"--deliver-policy=DeliverNew"}, replayPolicyArgs(&dfv1.ReplayPolicy{DeliverPolicy: dfv1.DeliverPolicyNew}))
+	startTime := metav1.NewTime(time.Date(2022, 6, 1, 8, 0, 0, 0, time.FixedZone("", 3600)))
+	assert.Equal(t, []string{"--deliver-policy=ByStartTime", "--start-time=2022-06-01T07:00:00Z"}, replayPolicyArgs(&dfv1.ReplayPolicy{DeliverPolicy: dfv1.DeliverPolicyByStartTime, StartTime: &startTime}))
 }
 
 func Test_copyLimits(t *testing.T) {
@@ -154,6 +479,34 @@ func Test_copyLimits(t *testing.T) {
 	copyLimits(pl, v1)
 	assert.Equal(t, int32One, *v1.Limits.UDFWorkers)
 
+	size := uint32(1024)
+	pl.Spec.Limits = &dfv1.PipelineLimits{MaxKeySize: &size, MaxHeaderSize: &size}
+	v2 := pl.Spec.Vertices[1].DeepCopy()
+	copyLimits(pl, v2)
+	assert.Equal(t, size, *v2.Limits.MaxKeySize)
+	assert.Equal(t, size, *v2.Limits.MaxHeaderSize)
+}
+
+func Test_copyEdgeLimits(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	e := pl.Spec.Edges[0]
+	assert.Nil(t, copyEdgeLimits(pl, e))
+	pl.Spec.Limits = &dfv1.PipelineLimits{Compression: dfv1.ContentEncodingZstd}
+	assert.Equal(t, dfv1.ContentEncodingZstd, copyEdgeLimits(pl, e).GetCompression())
+	e.Limits = &dfv1.EdgeLimits{OnFull: dfv1.OnFullDiscardLatest}
+	el := copyEdgeLimits(pl, e)
+	assert.Equal(t, dfv1.ContentEncodingZstd, el.GetCompression())
+	assert.Equal(t, dfv1.OnFullDiscardLatest, el.GetOnFull())
+	assert.Equal(t, dfv1.ContentEncoding(""), e.Limits.GetCompression())
+	e.Limits.Compression = dfv1.ContentEncodingLZ4
+	assert.Equal(t, dfv1.ContentEncodingLZ4, copyEdgeLimits(pl, e).GetCompression())
+
+	// the encoding of the source comes before the pipeline limits
+	pl.Spec.Vertices[0].Source.Encoding = &dfv1.SourceEncoding{ISB: dfv1.ContentEncodingSnappy}
+	assert.Equal(t, dfv1.ContentEncodingLZ4, copyEdgeLimits(pl, e).GetCompression())
+	e.Limits.Compression = ""
+	assert.Equal(t, dfv1.ContentEncodingSnappy, copyEdgeLimits(pl, e).GetCompression())
+	assert.Equal(t, dfv1.ContentEncodingZstd, copyEdgeLimits(pl, pl.Spec.Edges[1]).GetCompression())
 }
 
 func Test_buildISBBatchJob(t *testing.T) {
@@ -171,6 +524,50 @@ func Test_buildISBBatchJob(t *testing.T) {
 	assert.Contains(t, envNames, dfv1.EnvISBSvcRedisSentinelPassword)
 	assert.Contains(t, envNames, dfv1.EnvISBSvcRedisUser)
 	assert.Contains(t, envNames, dfv1.EnvISBSvcRedisURL)
+	assert.Contains(t, envNames, dfv1.EnvCorrelationID)
+	assert.True(t, *j.Spec.Template.Spec.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, dfv1.AppArmorProfileRuntimeDefault, j.Spec.Template.Annotations[dfv1.AppArmorAnnotationKeyPrefix+dfv1.CtrMain])
+
+	pl := testPipeline.DeepCopy()
+	pl.Spec.PodSecurity = &dfv1.PodSecurity{Disabled: true}
+	j = buildISBBatchJob(pl, testFlowImage, fakeIsbSvcConfig, "subcmd", []string{"sss"}, "test")
+	assert.Nil(t, j.Spec.Template.Spec.SecurityContext)
+	assert.Empty(t, j.Spec.Template.Annotations)
+	assert.Empty(t, j.Spec.Template.Spec.Volumes)
+
+	isbSvcConfig := fakeIsbSvcConfig.DeepCopy()
+	isbSvcConfig.Redis.Managed = &dfv1.ManagedRedis{PasswordRotation: true}
+	j = buildISBBatchJob(testPipeline, testFlowImage, *isbSvcConfig, "subcmd", []string{"sss"}, "test")
+	assert.Equal(t, isbSvcConfig.Redis.Password.Name, j.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, dfv1.PathISBSvcRedisAuth, j.Spec.Template.Spec.Containers[0].VolumeMounts[0].MountPath)
+
+	jsConfig := dfv1.BufferServiceConfig{JetStream: &dfv1.JetStreamConfig{
+		URL: "nats://isbsvc:4222",
+		Mirror: &dfv1.JetStreamMirror{
+			URL:  "nats://isbsvc-dr:4222",
+			Auth: &dfv1.NATSAuth{User: &corev1.SecretKeySelector{Key: "user"}, Password: &corev1.SecretKeySelector{Key: "password"}},
+		},
+	}}
+	j = buildISBBatchJob(testPipeline, testFlowImage, jsConfig, "subcmd", []string{"sss"}, "test")
+	envNames = []string{}
+	for _, e := range j.Spec.Template.Spec.Containers[0].Env {
+		envNames = append(envNames, e.Name)
+	}
+	assert.Contains(t, envNames, dfv1.EnvISBSvcMirrorUser)
+	assert.Contains(t, envNames, dfv1.EnvISBSvcMirrorPassword)
+	assert.NotContains(t, envNames, dfv1.EnvISBSvcMirrorTLSCACert)
+
+	pl = testPipeline.DeepCopy()
+	pl.Spec.Templates = &dfv1.Templates{Job: &dfv1.AbstractPodTemplate{
+		Metadata:           &dfv1.Metadata{Labels: map[string]string{"team": "a"}},
+		ServiceAccountName: "buffer-job",
+		ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "regcred"}},
+	}}
+	j = buildISBBatchJob(pl, testFlowImage, fakeIsbSvcConfig, "subcmd", []string{"sss"}, "test")
+	assert.Equal(t, "a", j.Spec.Template.Labels["team"])
+	assert.Equal(t, "buffer-job", j.Spec.Template.Spec.ServiceAccountName)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "regcred"}}, j.Spec.Template.Spec.ImagePullSecrets)
+	assert.Equal(t, dfv1.AppArmorProfileRuntimeDefault, j.Spec.Template.Annotations[dfv1.AppArmorAnnotationKeyPrefix+dfv1.CtrMain])
 }
 
 func Test_needsUpdate(t *testing.T) {
@@ -258,3 +655,430 @@ func TestCreateOrUpdateDaemon(t *testing.T) {
 		assert.Equal(t, "test-pl-daemon", deployList.Items[0].Name)
 	})
 }
+
+func Test_pauseAndResumeVertices(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	ctx := context.TODO()
+	testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+	testIsbSvc.Status.MarkConfigured()
+	testIsbSvc.Status.MarkDeployed()
+	err := cl.Create(ctx, testIsbSvc)
+	assert.Nil(t, err)
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	testObj := testPipeline.DeepCopy()
+	_, err = r.reconcile(ctx, testObj)
+	assert.NoError(t, err)
+	vertices, err := r.findExistingVertices(ctx, testObj)
+	assert.NoError(t, err)
+	v := vertices[testObj.Name+"-p1"]
+	v.Spec.Replicas = pointer.Int32(3)
+	err = cl.Update(ctx, &v)
+	assert.NoError(t, err)
+
+	patched, err := r.scaleDownAllVertices(ctx, testObj)
+	assert.NoError(t, err)
+	assert.True(t, patched)
+	vertices, err = r.findExistingVertices(ctx, testObj)
+	assert.NoError(t, err)
+	for _, v := range vertices {
+		assert.Equal(t, int32(0), *v.Spec.Replicas)
+	}
+	assert.Equal(t, "3", vertices[testObj.Name+"-p1"].Annotations[dfv1.KeyPausedReplicas])
+	assert.Equal(t, "1", vertices[testObj.Name+"-input"].Annotations[dfv1.KeyPausedReplicas])
+
+	err = r.scaleDaemon(ctx, testObj, 0)
+	assert.NoError(t, err)
+
+	patched, err = r.scaleUpAllVertices(ctx, testObj)
+	assert.NoError(t, err)
+	assert.True(t, patched)
+	vertices, err = r.findExistingVertices(ctx, testObj)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), *vertices[testObj.Name+"-p1"].Spec.Replicas)
+	assert.Equal(t, int32(1), *vertices[testObj.Name+"-input"].Spec.Replicas)
+	for _, v := range vertices {
+		_, ok := v.Annotations[dfv1.KeyPausedReplicas]
+		assert.False(t, ok)
+	}
+}
+
+func Test_drainBeforeDelete(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	ctx := context.TODO()
+	testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+	testIsbSvc.Status.MarkConfigured()
+	testIsbSvc.Status.MarkDeployed()
+	assert.NoError(t, cl.Create(ctx, testIsbSvc))
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	testObj := testPipeline.DeepCopy()
+	_, err := r.reconcile(ctx, testObj)
+	assert.NoError(t, err)
+
+	// The sources are stopped first
+	drained, err := r.drainBeforeDelete(ctx, testObj)
+	assert.NoError(t, err)
+	assert.False(t, drained)
+	assert.Equal(t, dfv1.PipelinePhaseDeleting, testObj.Status.Phase)
+	assert.Contains(t, testObj.Status.Message, "sources are stopped")
+	vertices, err := r.findExistingVertices(ctx, testObj)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *vertices[testObj.Name+"-input"].Spec.Replicas)
+	assert.Equal(t, int32(1), *vertices[testObj.Name+"-p1"].Spec.Replicas)
+
+	// Drained once the vertices are scaled down, and the pods are gone
+	_, err = r.scaleDownAllVertices(ctx, testObj)
+	assert.NoError(t, err)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testObj.Name + "-p1-0-xxxxx",
+			Labels: map[string]string{
+				dfv1.KeyComponent:    dfv1.ComponentVertex,
+				dfv1.KeyPipelineName: testObj.Name,
+			},
+		},
+	}
+	assert.NoError(t, cl.Create(ctx, pod))
+	drained, err = r.drainBeforeDelete(ctx, testObj)
+	assert.NoError(t, err)
+	assert.False(t, drained)
+	assert.Contains(t, testObj.Status.Message, "waiting for 1 vertex pods to exit")
+	assert.NoError(t, cl.Delete(ctx, pod))
+	drained, err = r.drainBeforeDelete(ctx, testObj)
+	assert.NoError(t, err)
+	assert.True(t, drained)
+
+	t.Run("test reconcile deleting", func(t *testing.T) {
+		pl := testObj.DeepCopy()
+		pl.Spec.Lifecycle.DeleteGracePeriodSeconds = 30
+		now := metav1.Now()
+		pl.DeletionTimestamp = &now
+		controllerutil.AddFinalizer(pl, finalizerName)
+		jobs := &batchv1.JobList{}
+		assert.NoError(t, cl.List(ctx, jobs, &client.ListOptions{Namespace: testNamespace}))
+		createJobs := len(jobs.Items)
+		pod.ResourceVersion = ""
+		assert.NoError(t, cl.Create(ctx, pod))
+		result, err := r.reconcile(ctx, pl)
+		assert.NoError(t, err)
+		assert.Equal(t, dfv1.DefaultRequeueAfter, result.RequeueAfter)
+		assert.True(t, controllerutil.ContainsFinalizer(pl, finalizerName))
+		assert.NoError(t, cl.List(ctx, jobs, &client.ListOptions{Namespace: testNamespace}))
+		assert.Equal(t, createJobs, len(jobs.Items))
+
+		// The buffers are deleted regardless once the grace period is over
+		past := metav1.NewTime(now.Add(-time.Minute))
+		pl.DeletionTimestamp = &past
+		_, err = r.reconcile(ctx, pl)
+		assert.NoError(t, err)
+		assert.False(t, controllerutil.ContainsFinalizer(pl, finalizerName))
+		assert.NoError(t, cl.List(ctx, jobs, &client.ListOptions{Namespace: testNamespace}))
+		assert.Equal(t, createJobs+1, len(jobs.Items))
+	})
+}
+
+func Test_pendingMessages(t *testing.T) {
+	assert.Equal(t, int64(0), pendingMessages(nil))
+	assert.Equal(t, int64(6), pendingMessages([]*daemonpb.BufferInfo{
+		{PendingCount: pointer.Int64(1), AckPendingCount: pointer.Int64(2)},
+		{PendingCount: pointer.Int64(3), AckPendingCount: pointer.Int64(0)},
+		{},
+	}))
+}
+
+func Test_scaleDaemon(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	ctx := context.TODO()
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	testObj := testPipeline.DeepCopy()
+	// no daemon deployment yet
+	err := r.scaleDaemon(ctx, testObj, 0)
+	assert.NoError(t, err)
+	err = r.createOrUpdateDaemonDeployment(ctx, testObj, fakeIsbSvcConfig)
+	assert.NoError(t, err)
+	err = r.scaleDaemon(ctx, testObj, 0)
+	assert.NoError(t, err)
+	deploy := &appv1.Deployment{}
+	err = cl.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: testObj.GetDaemonDeploymentName()}, deploy)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *deploy.Spec.Replicas)
+
+	t.Run("keep paused on update", func(t *testing.T) {
+		testObj.Status.MarkPhasePaused()
+		err = r.createOrUpdateDaemonDeployment(ctx, testObj, fakeIsbSvcConfig)
+		assert.NoError(t, err)
+		err = cl.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: testObj.GetDaemonDeploymentName()}, deploy)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), *deploy.Spec.Replicas)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		err = r.scaleDaemon(ctx, testObj, 1)
+		assert.NoError(t, err)
+		err = cl.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: testObj.GetDaemonDeploymentName()}, deploy)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), *deploy.Spec.Replicas)
+	})
+}
+
+func Test_observedGeneration(t *testing.T) {
+	ctx := context.TODO()
+	testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+	testIsbSvc.Status.MarkConfigured()
+	testIsbSvc.Status.MarkDeployed()
+	testObj := testPipeline.DeepCopy()
+	testObj.Generation = 2
+	cl := fake.NewClientBuilder().WithObjects(testIsbSvc, testObj).Build()
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testObj.Name}}
+	_, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	pl := &dfv1.Pipeline{}
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Equal(t, int64(2), pl.Status.ObservedGeneration)
+
+	// Not observed if the reconciliation fails
+	pl.Generation = 3
+	pl.Spec.Edges = nil
+	assert.NoError(t, cl.Update(ctx, pl))
+	_, err = r.Reconcile(ctx, req)
+	assert.Error(t, err)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Equal(t, int64(2), pl.Status.ObservedGeneration)
+}
+
+func Test_observedGenerationNotApplied(t *testing.T) {
+	ctx := context.TODO()
+	testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+	testIsbSvc.Status.MarkConfigured()
+	testIsbSvc.Status.MarkDeployed()
+	testObj := testPipeline.DeepCopy()
+	testObj.Generation = 1
+	testObj.Spec.Lifecycle.ConfirmDisruptiveChanges = true
+	testObj.Spec.Lifecycle.DesiredPhase = dfv1.PipelinePhaseRunning
+	cl := fake.NewClientBuilder().WithObjects(testIsbSvc, testObj).Build()
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testObj.Name}}
+	_, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	pl := &dfv1.Pipeline{}
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Equal(t, int64(1), pl.Status.ObservedGeneration)
+
+	// Not observed while the disruptive changes are pending
+	pl.Generation = 2
+	pl.Spec.Vertices = append(pl.Spec.Vertices, dfv1.AbstractVertex{Name: "output2", Sink: &dfv1.Sink{}})
+	pl.Spec.Edges = append(pl.Spec.Edges, dfv1.Edge{From: "p1", To: "output2"})
+	assert.NoError(t, cl.Update(ctx, pl))
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.NotNil(t, pl.Status.PendingChanges)
+	assert.Equal(t, int64(1), pl.Status.ObservedGeneration)
+
+	pl.Annotations = map[string]string{dfv1.KeyConfirmedChanges: pl.Status.PendingChanges.Hash}
+	assert.NoError(t, cl.Update(ctx, pl))
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Nil(t, pl.Status.PendingChanges)
+	assert.Equal(t, int64(2), pl.Status.ObservedGeneration)
+
+	// Not observed while pausing
+	pl.Generation = 3
+	pl.Spec.Lifecycle.DesiredPhase = dfv1.PipelinePhasePaused
+	assert.NoError(t, cl.Update(ctx, pl))
+	result, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, result.RequeueAfter > 0)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Equal(t, dfv1.PipelinePhasePausing, pl.Status.Phase)
+	assert.Equal(t, int64(2), pl.Status.ObservedGeneration)
+}
+
+func Test_observedOperationID(t *testing.T) {
+	ctx := context.TODO()
+	testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+	testIsbSvc.Status.MarkConfigured()
+	testIsbSvc.Status.MarkDeployed()
+	testObj := testPipeline.DeepCopy()
+	testObj.Annotations = map[string]string{dfv1.KeyOperationID: "op-1"}
+	testObj.Spec.Lifecycle.DesiredPhase = dfv1.PipelinePhaseRunning
+	cl := fake.NewClientBuilder().WithObjects(testIsbSvc, testObj).Build()
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testObj.Name}}
+	_, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	pl := &dfv1.Pipeline{}
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Equal(t, "op-1", pl.Status.ObservedOperationID)
+
+	// Not observed while pausing
+	pl.Annotations[dfv1.KeyOperationID] = "op-2"
+	pl.Spec.Lifecycle.DesiredPhase = dfv1.PipelinePhasePaused
+	assert.NoError(t, cl.Update(ctx, pl))
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Equal(t, dfv1.PipelinePhasePausing, pl.Status.Phase)
+	assert.Equal(t, "op-1", pl.Status.ObservedOperationID)
+
+	// Observed once it's resumed
+	pl.Status.MarkPhasePaused()
+	assert.NoError(t, cl.Status().Update(ctx, pl))
+	pl.Annotations[dfv1.KeyOperationID] = "op-3"
+	pl.Spec.Lifecycle.DesiredPhase = dfv1.PipelinePhaseRunning
+	assert.NoError(t, cl.Update(ctx, pl))
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Equal(t, dfv1.PipelinePhaseRunning, pl.Status.Phase)
+	assert.Equal(t, "op-3", pl.Status.ObservedOperationID)
+}
+
+func Test_degradedByVertexErrors(t *testing.T) {
+	ctx := context.TODO()
+	testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+	testIsbSvc.Status.MarkConfigured()
+	testIsbSvc.Status.MarkDeployed()
+	testObj := testPipeline.DeepCopy()
+	cl := fake.NewClientBuilder().WithObjects(testIsbSvc, testObj).Build()
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testObj.Name}}
+	_, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	pl := &dfv1.Pipeline{}
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Nil(t, pl.Status.GetCondition(dfv1.PipelineConditionDegraded))
+
+	v := &dfv1.Vertex{}
+	vertexKey := types.NamespacedName{Namespace: testNamespace, Name: testObj.Name + "-p1"}
+	assert.NoError(t, cl.Get(ctx, vertexKey, v))
+	v.Status.LastError = &dfv1.VertexError{Pod: "pod", Container: dfv1.CtrUdf, ExitCode: 1, Message: "panic"}
+	assert.NoError(t, cl.Status().Update(ctx, v))
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	c := pl.Status.GetCondition(dfv1.PipelineConditionDegraded)
+	assert.NotNil(t, c)
+	assert.Equal(t, "VertexFailing", c.Reason)
+	assert.Equal(t, `vertex "p1": container "udf" of pod "pod" exited with code 1: panic`, c.Message)
+	assert.False(t, pl.Status.IsReady())
+	assert.Equal(t, int32(3), pl.Status.Vertices.Total)
+
+	assert.NoError(t, cl.Get(ctx, vertexKey, v))
+	v.Status.LastError = nil
+	assert.NoError(t, cl.Status().Update(ctx, v))
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, req.NamespacedName, pl))
+	assert.Nil(t, pl.Status.GetCondition(dfv1.PipelineConditionDegraded))
+	assert.True(t, pl.Status.IsReady())
+}
+
+func Test_summarizeVertexErrors(t *testing.T) {
+	vertices := map[string]dfv1.Vertex{}
+	for i := 0; i < 8; i++ {
+		v := dfv1.Vertex{}
+		v.Spec.Name = fmt.Sprintf("v%d", i)
+		v.Status.LastError = &dfv1.VertexError{Pod: "pod", Container: dfv1.CtrMain, ExitCode: 1, Message: "panic"}
+		vertices[v.Spec.Name] = v
+	}
+	msg := summarizeVertexErrors(vertices)
+	assert.Contains(t, msg, `vertex "v4"`)
+	assert.NotContains(t, msg, `vertex "v5"`)
+	assert.True(t, strings.HasSuffix(msg, "; and 3 more failing vertices, see the status of the vertices"))
+}
+
+func Test_summarizeVertexPhases(t *testing.T) {
+	vertices := map[string]dfv1.Vertex{
+		"a": {Status: dfv1.VertexStatus{Phase: dfv1.VertexPhaseRunning}},
+		"b": {Status: dfv1.VertexStatus{Phase: dfv1.VertexPhaseRunning}},
+		"c": {Status: dfv1.VertexStatus{Phase: dfv1.VertexPhaseFailed}},
+		"d": {},
+	}
+	assert.Equal(t, &dfv1.VerticesSummary{Total: 4, Running: 2, Failed: 1, Pending: 1}, summarizeVertexPhases(vertices))
+}
+
+func Test_completePipeline(t *testing.T) {
+	ctx := context.TODO()
+	testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+	testIsbSvc.Status.MarkConfigured()
+	testIsbSvc.Status.MarkDeployed()
+	testObj := testPipeline.DeepCopy()
+	testObj.Spec.Vertices[0].Source = &dfv1.Source{Generator: &dfv1.GeneratorSource{MaxMessages: pointer.Int64(100)}}
+	testObj.Spec.Lifecycle.DesiredPhase = dfv1.PipelinePhaseRunning
+	cl := fake.NewClientBuilder().WithObjects(testIsbSvc).Build()
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	_, err := r.reconcile(ctx, testObj)
+	assert.NoError(t, err)
+	// the source has not reached the end yet
+	result, err := r.reconcile(ctx, testObj)
+	assert.NoError(t, err)
+	assert.Equal(t, dfv1.DefaultRequeueAfter, result.RequeueAfter)
+	assert.NotEqual(t, dfv1.PipelinePhaseCompleted, testObj.Status.Phase)
+	requeue, err := r.completePipeline(ctx, testObj)
+	assert.NoError(t, err)
+	assert.True(t, requeue)
+
+	t.Run("completed pipeline untouched", func(t *testing.T) {
+		pl := testObj.DeepCopy()
+		pl.Status.MarkPhaseCompleted()
+		pl.Spec.Vertices = pl.Spec.Vertices[:1]
+		result, err := r.reconcile(ctx, pl)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), result.RequeueAfter)
+		assert.Equal(t, dfv1.PipelinePhaseCompleted, pl.Status.Phase)
+		vertices, err := r.findExistingVertices(ctx, pl)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(vertices))
+	})
+}
@@ -0,0 +1,73 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// withDefaults returns a copy of the pipeline with the defaults applied, the namespace defaults declared with the
+// namespace annotations take precedence over the built-in defaults. The pipeline itself is not changed, so that
+// the defaults are never persisted into the pipeline spec.
+func (r *pipelineReconciler) withDefaults(ctx context.Context, pl *dfv1.Pipeline) (*dfv1.Pipeline, error) {
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: pl.Namespace}, ns); err != nil {
+		if !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+			return nil, fmt.Errorf("failed to get namespace %q, %w", pl.Namespace, err)
+		}
+		ns = nil // no namespace defaults
+	}
+	return applyDefaults(pl, ns)
+}
+
+func applyDefaults(pl *dfv1.Pipeline, ns *corev1.Namespace) (*dfv1.Pipeline, error) {
+	plCopy := pl.DeepCopy()
+	if ns != nil {
+		annotations := ns.GetAnnotations()
+		if plCopy.Spec.InterStepBufferServiceName == "" {
+			plCopy.Spec.InterStepBufferServiceName = annotations[dfv1.KeyDefaultISBSvcName]
+		}
+		if x, ok := annotations[dfv1.KeyDefaultLimits]; ok {
+			nsLimits := &dfv1.PipelineLimits{}
+			if err := json.Unmarshal([]byte(x), nsLimits); err != nil {
+				return nil, fmt.Errorf("invalid annotation %q of namespace %q, %w", dfv1.KeyDefaultLimits, ns.Name, err)
+			}
+			mergeLimits(plCopy, nsLimits)
+		}
+	}
+	readBatchSize, bufferMaxLength := uint64(dfv1.DefaultPipelineReadBatchSize), uint64(dfv1.DefaultPipelineBufferMaxLength)
+	udfWorkers, bufferUsageLimit := uint32(dfv1.DefaultPipelineUDFWorkers), uint32(dfv1.DefaultPipelineBufferUsageLimit)
+	mergeLimits(plCopy, &dfv1.PipelineLimits{
+		ReadBatchSize:    &readBatchSize,
+		UDFWorkers:       &udfWorkers,
+		BufferMaxLength:  &bufferMaxLength,
+		BufferUsageLimit: &bufferUsageLimit,
+	})
+	return plCopy, nil
+}
+
+// mergeLimits sets the limits of the pipeline not set yet with the defaults.
+func mergeLimits(pl *dfv1.Pipeline, defaults *dfv1.PipelineLimits) {
+	if pl.Spec.Limits == nil {
+		pl.Spec.Limits = &dfv1.PipelineLimits{}
+	}
+	l := pl.Spec.Limits
+	if l.ReadBatchSize == nil {
+		l.ReadBatchSize = defaults.ReadBatchSize
+	}
+	if l.UDFWorkers == nil {
+		l.UDFWorkers = defaults.UDFWorkers
+	}
+	if l.BufferMaxLength == nil {
+		l.BufferMaxLength = defaults.BufferMaxLength
+	}
+	if l.BufferUsageLimit == nil {
+		l.BufferUsageLimit = defaults.BufferUsageLimit
+	}
+}
@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func Test_applyDefaults(t *testing.T) {
+	t.Run("built-in defaults", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Limits = nil
+		r, err := applyDefaults(pl, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, pl.Spec.Limits)
+		assert.Equal(t, "", r.Spec.InterStepBufferServiceName)
+		assert.Equal(t, uint64(dfv1.DefaultPipelineReadBatchSize), *r.Spec.Limits.ReadBatchSize)
+		assert.Equal(t, uint32(dfv1.DefaultPipelineUDFWorkers), *r.Spec.Limits.UDFWorkers)
+		assert.Equal(t, uint64(dfv1.DefaultPipelineBufferMaxLength), *r.Spec.Limits.BufferMaxLength)
+		assert.Equal(t, uint32(dfv1.DefaultPipelineBufferUsageLimit), *r.Spec.Limits.BufferUsageLimit)
+	})
+
+	t.Run("namespace defaults", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		readBatchSize := uint64(20)
+		pl.Spec.Limits = &dfv1.PipelineLimits{ReadBatchSize: &readBatchSize}
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, Annotations: map[string]string{
+			dfv1.KeyDefaultISBSvcName: "team-a",
+			dfv1.KeyDefaultLimits:     `{"readBatchSize": 10, "bufferMaxLength": 30000}`,
+		}}}
+		r, err := applyDefaults(pl, ns)
+		assert.NoError(t, err)
+		assert.Equal(t, "team-a", r.Spec.InterStepBufferServiceName)
+		assert.Equal(t, uint64(20), *r.Spec.Limits.ReadBatchSize)
+		assert.Equal(t, uint64(30000), *r.Spec.Limits.BufferMaxLength)
+		assert.Equal(t, uint32(dfv1.DefaultPipelineUDFWorkers), *r.Spec.Limits.UDFWorkers)
+		assert.Nil(t, pl.Spec.Limits.BufferMaxLength)
+
+		pl.Spec.InterStepBufferServiceName = "my-isbsvc"
+		r, err = applyDefaults(pl, ns)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-isbsvc", r.Spec.InterStepBufferServiceName)
+	})
+
+	t.Run("invalid namespace defaults", func(t *testing.T) {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, Annotations: map[string]string{
+			dfv1.KeyDefaultLimits: `{"readBatchSize": "a"}`,
+		}}}
+		_, err := applyDefaults(testPipeline.DeepCopy(), ns)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), dfv1.KeyDefaultLimits)
+	})
+}
+
+func Test_withDefaults(t *testing.T) {
+	ctx := context.TODO()
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, Annotations: map[string]string{
+		dfv1.KeyDefaultISBSvcName: "team-a",
+	}}}
+	r := &pipelineReconciler{
+		client: fake.NewClientBuilder().WithObjects(ns).Build(),
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	pl, err := r.withDefaults(ctx, testPipeline.DeepCopy())
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", pl.Spec.InterStepBufferServiceName)
+
+	r.client = fake.NewClientBuilder().Build()
+	pl, err = r.withDefaults(ctx, testPipeline.DeepCopy())
+	assert.NoError(t, err)
+	assert.Equal(t, "", pl.Spec.InterStepBufferServiceName)
+}
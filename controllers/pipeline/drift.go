@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// getAllowedFieldManagers returns the field managers allowed to change the spec of the pipeline, nil means all are
+// allowed.
+func getAllowedFieldManagers(pl *dfv1.Pipeline) map[string]bool {
+	value := strings.TrimSpace(pl.GetAnnotations()[dfv1.KeyAllowedFieldManagers])
+	if value == "" {
+		return nil
+	}
+	result := make(map[string]bool)
+	for _, m := range strings.Split(value, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			result[m] = true
+		}
+	}
+	return result
+}
+
+// findOutOfBandManagers returns the field managers owning any field of the spec, but not in the allow-list of the
+// pipeline. A manual change made by kubectl for example is found this way, until the GitOps tool takes the changed
+// fields back by applying the spec in git.
+func findOutOfBandManagers(pl *dfv1.Pipeline) ([]string, error) {
+	allowed := getAllowedFieldManagers(pl)
+	if allowed == nil {
+		return nil, nil
+	}
+	managers := make(map[string]bool)
+	for _, mf := range pl.GetManagedFields() {
+		// The status is not part of the spec
+		if mf.Subresource != "" || mf.FieldsV1 == nil || allowed[mf.Manager] {
+			continue
+		}
+		fields := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(mf.FieldsV1.Raw, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse the managed fields of %q, %w", mf.Manager, err)
+		}
+		if _, ok := fields["f:spec"]; ok {
+			managers[mf.Manager] = true
+		}
+	}
+	result := make([]string, 0, len(managers))
+	for m := range managers {
+		result = append(result, m)
+	}
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func Test_getAllowedFieldManagers(t *testing.T) {
+	testObj := testPipeline.DeepCopy()
+	assert.Nil(t, getAllowedFieldManagers(testObj))
+	testObj.Annotations = map[string]string{dfv1.KeyAllowedFieldManagers: " "}
+	assert.Nil(t, getAllowedFieldManagers(testObj))
+	testObj.Annotations[dfv1.KeyAllowedFieldManagers] = "argocd-controller, kustomize-controller,"
+	assert.Equal(t, map[string]bool{"argocd-controller": true, "kustomize-controller": true}, getAllowedFieldManagers(testObj))
+}
+
+func Test_findOutOfBandManagers(t *testing.T) {
+	fields := func(raw string) *metav1.FieldsV1 {
+		return &metav1.FieldsV1{Raw: []byte(raw)}
+	}
+	testObj := testPipeline.DeepCopy()
+	testObj.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "kustomize-controller", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: fields(`{"f:metadata":{"f:labels":{}},"f:spec":{"f:vertices":{}}}`)},
+		{Manager: "numaflow", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: fields(`{"f:metadata":{"f:finalizers":{}}}`)},
+		{Manager: "numaflow", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", FieldsV1: fields(`{"f:status":{}}`)},
+	}
+
+	t.Run("no allow-list", func(t *testing.T) {
+		testObj.ManagedFields = append(testObj.ManagedFields, metav1.ManagedFieldsEntry{Manager: "kubectl-edit", FieldsV1: fields(`{"f:spec":{}}`)})
+		defer func() { testObj.ManagedFields = testObj.ManagedFields[:3] }()
+		managers, err := findOutOfBandManagers(testObj)
+		assert.NoError(t, err)
+		assert.Empty(t, managers)
+	})
+
+	testObj.Annotations = map[string]string{dfv1.KeyAllowedFieldManagers: "kustomize-controller"}
+
+	t.Run("only allowed managers", func(t *testing.T) {
+		managers, err := findOutOfBandManagers(testObj)
+		assert.NoError(t, err)
+		assert.Empty(t, managers)
+	})
+
+	t.Run("out-of-band changes", func(t *testing.T) {
+		testObj := testObj.DeepCopy()
+		testObj.ManagedFields = append(testObj.ManagedFields,
+			metav1.ManagedFieldsEntry{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: fields(`{"f:spec":{"f:limits":{}}}`)},
+			metav1.ManagedFieldsEntry{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: fields(`{"f:spec":{"f:edges":{}}}`)},
+			metav1.ManagedFieldsEntry{Manager: "kubectl-annotate", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: fields(`{"f:metadata":{"f:annotations":{}}}`)},
+		)
+		managers, err := findOutOfBandManagers(testObj)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"kubectl-client-side-apply", "kubectl-edit"}, managers)
+	})
+
+	t.Run("invalid managed fields", func(t *testing.T) {
+		testObj := testObj.DeepCopy()
+		testObj.ManagedFields = append(testObj.ManagedFields, metav1.ManagedFieldsEntry{Manager: "kubectl-edit", FieldsV1: fields(`abc`)})
+		_, err := findOutOfBandManagers(testObj)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// NewISBSvcEventHandler returns an event handler which enqueues the pipelines using the ISB Service, so that the
+// pipelines waiting for the ISB Service get reconciled as soon as its status changes.
+func NewISBSvcEventHandler(c client.Client, logger *zap.SugaredLogger) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		requests, err := pipelinesOfISBSvc(context.Background(), c, obj.GetNamespace(), obj.GetName())
+		if err != nil {
+			logger.Errorw("Failed to find the pipelines of the ISB Service", zap.String("namespace", obj.GetNamespace()), zap.String("isbsvc", obj.GetName()), zap.Error(err))
+		}
+		return requests
+	})
+}
+
+// pipelinesOfISBSvc returns the requests of the pipelines using the ISB Service, including the ones using it as the
+// namespace default or the built-in default.
+func pipelinesOfISBSvc(ctx context.Context, c client.Client, namespace, isbSvcName string) ([]reconcile.Request, error) {
+	nsDefault := ""
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+			return nil, fmt.Errorf("failed to get namespace %q, %w", namespace, err)
+		}
+	} else {
+		nsDefault = ns.GetAnnotations()[dfv1.KeyDefaultISBSvcName]
+	}
+	pls := &dfv1.PipelineList{}
+	if err := c.List(ctx, pls, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pipelines, %w", err)
+	}
+	requests := []reconcile.Request{}
+	for _, pl := range pls.Items {
+		name := pl.Spec.InterStepBufferServiceName
+		if name == "" {
+			name = nsDefault
+		}
+		if name == "" {
+			name = dfv1.DefaultISBSvcName
+		}
+		if name == isbSvcName {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pl.Namespace, Name: pl.Name}})
+		}
+	}
+	return requests, nil
+}
@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func Test_pipelinesOfISBSvc(t *testing.T) {
+	ctx := context.TODO()
+	withISBSvc := func(name, isbSvcName string) *dfv1.Pipeline {
+		pl := testPipeline.DeepCopy()
+		pl.Name = name
+		pl.Spec.InterStepBufferServiceName = isbSvcName
+		return pl
+	}
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: name}}
+	}
+	pls := []*dfv1.Pipeline{withISBSvc("a", ""), withISBSvc("b", "team-a"), withISBSvc("c", dfv1.DefaultISBSvcName)}
+
+	t.Run("built-in default", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithObjects(pls[0], pls[1], pls[2]).Build()
+		r, err := pipelinesOfISBSvc(ctx, c, testNamespace, dfv1.DefaultISBSvcName)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []reconcile.Request{request("a"), request("c")}, r)
+		r, err = pipelinesOfISBSvc(ctx, c, testNamespace, "team-a")
+		assert.NoError(t, err)
+		assert.Equal(t, []reconcile.Request{request("b")}, r)
+		r, err = pipelinesOfISBSvc(ctx, c, "other-ns", dfv1.DefaultISBSvcName)
+		assert.NoError(t, err)
+		assert.Empty(t, r)
+	})
+
+	t.Run("namespace default", func(t *testing.T) {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace, Annotations: map[string]string{
+			dfv1.KeyDefaultISBSvcName: "team-a",
+		}}}
+		c := fake.NewClientBuilder().WithObjects(ns, pls[0], pls[1], pls[2]).Build()
+		r, err := pipelinesOfISBSvc(ctx, c, testNamespace, "team-a")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []reconcile.Request{request("a"), request("b")}, r)
+	})
+}
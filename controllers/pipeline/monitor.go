@@ -0,0 +1,125 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+var (
+	// The Prometheus Operator types are not imported, the objects are built as unstructured ones since the CRDs are optional.
+	serviceMonitorGroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+	podMonitorGroupVersionKind     = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+)
+
+// reconcileMonitors creates or updates the ServiceMonitor of the daemon service and the PodMonitor of the vertex pods when
+// spec.metrics.serviceMonitor is on, or deletes them when it's off. It's a no-op if the Prometheus Operator CRDs are not installed.
+func (r *pipelineReconciler) reconcileMonitors(ctx context.Context, pl *dfv1.Pipeline) error {
+	log := logging.FromContext(ctx)
+	enabled := pl.Spec.Metrics.GetServiceMonitor()
+	for _, obj := range buildMonitors(pl) {
+		kind := obj.GetKind()
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing); err != nil {
+			if meta.IsNoMatchError(err) {
+				if enabled {
+					log.Warnw("The Prometheus Operator CRDs are not installed, skipped creating the monitor", zap.String("kind", kind))
+				}
+				continue
+			}
+			if !apierrors.IsNotFound(err) {
+				pl.Status.MarkDeployFailed("FindMonitorFailed", err.Error())
+				return fmt.Errorf("failed to find existing %s, %w", kind, err)
+			}
+			existing = nil
+		}
+		if !enabled {
+			if existing != nil {
+				if err := r.client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+					pl.Status.MarkDeployFailed("DeleteMonitorFailed", err.Error())
+					return fmt.Errorf("failed to delete %s, %w", kind, err)
+				}
+				log.Infow("Deleted monitor", zap.String("kind", kind), zap.String("name", obj.GetName()))
+			}
+			continue
+		}
+		if existing == nil {
+			if err := r.client.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+				pl.Status.MarkDeployFailed("CreateMonitorFailed", err.Error())
+				return fmt.Errorf("failed to create %s, %w", kind, err)
+			}
+			log.Infow("Created monitor", zap.String("kind", kind), zap.String("name", obj.GetName()))
+		} else if hash := obj.GetAnnotations()[dfv1.KeyHash]; existing.GetAnnotations()[dfv1.KeyHash] != hash {
+			existing.Object["spec"] = obj.Object["spec"]
+			annotations := existing.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[dfv1.KeyHash] = hash
+			existing.SetAnnotations(annotations)
+			if err := r.client.Update(ctx, existing); err != nil {
+				pl.Status.MarkDeployFailed("UpdateMonitorFailed", err.Error())
+				return fmt.Errorf("failed to update %s, %w", kind, err)
+			}
+			log.Infow("Updated monitor", zap.String("kind", kind), zap.String("name", obj.GetName()))
+		}
+	}
+	return nil
+}
+
+// buildMonitors returns the ServiceMonitor scraping the daemon service, and the PodMonitor scraping the vertex pods of the pipeline.
+// Both serve the metrics over HTTPS with a self-signed certificate.
+func buildMonitors(pl *dfv1.Pipeline) []*unstructured.Unstructured {
+	endpoint := func(portKey string, port interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			portKey:     port,
+			"path":      "/metrics",
+			"scheme":    "https",
+			"tlsConfig": map[string]interface{}{"insecureSkipVerify": true},
+		}
+	}
+	selector := func(component string) map[string]interface{} {
+		return map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				dfv1.KeyComponent:    component,
+				dfv1.KeyPipelineName: pl.Name,
+			},
+		}
+	}
+	serviceMonitor := newMonitor(pl, serviceMonitorGroupVersionKind, pl.GetDaemonDeploymentName(), map[string]interface{}{
+		"selector":  selector(dfv1.ComponentDaemon),
+		"endpoints": []interface{}{endpoint("targetPort", int64(dfv1.DaemonServicePort))},
+	})
+	podMonitor := newMonitor(pl, podMonitorGroupVersionKind, pl.Name+"-vertices", map[string]interface{}{
+		"selector":            selector(dfv1.ComponentVertex),
+		"podMetricsEndpoints": []interface{}{endpoint("port", dfv1.VertexMetricsPortName)},
+	})
+	return []*unstructured.Unstructured{serviceMonitor, podMonitor}
+}
+
+func newMonitor(pl *dfv1.Pipeline, gvk schema.GroupVersionKind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(pl.Namespace)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{
+		dfv1.KeyPartOf:       dfv1.Project,
+		dfv1.KeyManagedBy:    dfv1.ControllerPipeline,
+		dfv1.KeyPipelineName: pl.Name,
+	})
+	obj.SetAnnotations(map[string]string{dfv1.KeyHash: sharedutil.MustHash(spec)})
+	obj.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(pl.GetObjectMeta(), dfv1.PipelineGroupVersionKind)})
+	return obj
+}
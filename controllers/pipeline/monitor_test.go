@@ -0,0 +1,130 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// noMonitorCRDsClient mimics a cluster without the Prometheus Operator CRDs.
+type noMonitorCRDsClient struct {
+	client.Client
+}
+
+func (c noMonitorCRDsClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		gvk := u.GroupVersionKind()
+		return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func getMonitor(ctx context.Context, cl client.Client, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := cl.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+	return existing, err
+}
+
+func Test_buildMonitors(t *testing.T) {
+	monitors := buildMonitors(testPipeline)
+	assert.Len(t, monitors, 2)
+	sm := monitors[0]
+	assert.Equal(t, "ServiceMonitor", sm.GetKind())
+	assert.Equal(t, "monitoring.coreos.com/v1", sm.GetAPIVersion())
+	assert.Equal(t, testPipeline.GetDaemonDeploymentName(), sm.GetName())
+	assert.Equal(t, testNamespace, sm.GetNamespace())
+	assert.Equal(t, testPipeline.Name, sm.GetOwnerReferences()[0].Name)
+	assert.NotEmpty(t, sm.GetAnnotations()[dfv1.KeyHash])
+	matchLabels, _, _ := unstructured.NestedStringMap(sm.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, map[string]string{dfv1.KeyComponent: dfv1.ComponentDaemon, dfv1.KeyPipelineName: testPipeline.Name}, matchLabels)
+	endpoints, _, _ := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+	assert.Equal(t, int64(dfv1.DaemonServicePort), endpoints[0].(map[string]interface{})["targetPort"])
+
+	pm := monitors[1]
+	assert.Equal(t, "PodMonitor", pm.GetKind())
+	assert.Equal(t, "test-pl-vertices", pm.GetName())
+	matchLabels, _, _ = unstructured.NestedStringMap(pm.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, map[string]string{dfv1.KeyComponent: dfv1.ComponentVertex, dfv1.KeyPipelineName: testPipeline.Name}, matchLabels)
+	endpoints, _, _ = unstructured.NestedSlice(pm.Object, "spec", "podMetricsEndpoints")
+	assert.Equal(t, dfv1.VertexMetricsPortName, endpoints[0].(map[string]interface{})["port"])
+	assert.Equal(t, "https", endpoints[0].(map[string]interface{})["scheme"])
+}
+
+func Test_reconcileMonitors(t *testing.T) {
+	ctx := context.TODO()
+	newReconciler := func(cl client.Client) *pipelineReconciler {
+		return &pipelineReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		testObj := testPipeline.DeepCopy()
+		assert.NoError(t, newReconciler(cl).reconcileMonitors(ctx, testObj))
+		for _, m := range buildMonitors(testObj) {
+			_, err := getMonitor(ctx, cl, m)
+			assert.True(t, apierrors.IsNotFound(err))
+		}
+	})
+
+	t.Run("enabled, updated and disabled", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		r := newReconciler(cl)
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Metrics = &dfv1.PipelineMetrics{ServiceMonitor: true}
+		assert.NoError(t, r.reconcileMonitors(ctx, testObj))
+		for _, m := range buildMonitors(testObj) {
+			existing, err := getMonitor(ctx, cl, m)
+			assert.NoError(t, err)
+			assert.Equal(t, m.GetAnnotations()[dfv1.KeyHash], existing.GetAnnotations()[dfv1.KeyHash])
+		}
+
+		// a stale spec is overwritten
+		sm := buildMonitors(testObj)[0]
+		existing, err := getMonitor(ctx, cl, sm)
+		assert.NoError(t, err)
+		existing.Object["spec"] = map[string]interface{}{}
+		existing.SetAnnotations(map[string]string{dfv1.KeyHash: "stale"})
+		assert.NoError(t, cl.Update(ctx, existing))
+		assert.NoError(t, r.reconcileMonitors(ctx, testObj))
+		existing, err = getMonitor(ctx, cl, sm)
+		assert.NoError(t, err)
+		assert.Equal(t, sm.Object["spec"], existing.Object["spec"])
+		assert.Equal(t, sm.GetAnnotations()[dfv1.KeyHash], existing.GetAnnotations()[dfv1.KeyHash])
+
+		testObj.Spec.Metrics.ServiceMonitor = false
+		assert.NoError(t, r.reconcileMonitors(ctx, testObj))
+		for _, m := range buildMonitors(testObj) {
+			_, err := getMonitor(ctx, cl, m)
+			assert.True(t, apierrors.IsNotFound(err))
+		}
+	})
+
+	t.Run("CRDs not installed", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Metrics = &dfv1.PipelineMetrics{ServiceMonitor: true}
+		assert.NoError(t, newReconciler(noMonitorCRDsClient{Client: cl}).reconcileMonitors(ctx, testObj))
+		for _, m := range buildMonitors(testObj) {
+			_, err := getMonitor(ctx, cl, m)
+			assert.True(t, apierrors.IsNotFound(err))
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/notifications"
+)
+
+// notificationTimeout is how long the notifications of a phase change are sent before they are given up
+const notificationTimeout = 30 * time.Second
+
+// notifyPhaseChange sends the phase change of the pipeline to the notification targets. It's sent in the background,
+// so that a slow target doesn't block the reconciliation, and a failure is only logged. The first phase of a new
+// pipeline is not sent.
+func (r *pipelineReconciler) notifyPhaseChange(old, new *dfv1.Pipeline) {
+	if new.Spec.Notifications == nil || old.Status.Phase == new.Status.Phase || old.Status.Phase == dfv1.PipelinePhaseUnknown {
+		return
+	}
+	log := r.logger.With("namespace", new.Namespace).With("pipeline", new.Name)
+	notifier, err := notifications.NewNotifier(*new.Spec.Notifications, notifications.WithSecretGetter(r.secretGetter(new.Namespace)))
+	if err != nil {
+		log.Errorw("Failed to create the notifier", zap.Error(err))
+		return
+	}
+	message := fmt.Sprintf("Pipeline %s/%s phase changed from %s to %s", new.Namespace, new.Name, old.Status.Phase, new.Status.Phase)
+	if new.Status.Message != "" {
+		message += ": " + new.Status.Message
+	}
+	event := notifications.Event{
+		Type:          dfv1.NotificationEventPhaseChanged,
+		Namespace:     new.Namespace,
+		Pipeline:      new.Name,
+		Time:          time.Now(),
+		Message:       message,
+		Phase:         new.Status.Phase,
+		PreviousPhase: old.Status.Phase,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+		if err := notifier.Notify(ctx, event); err != nil {
+			log.Errorw("Failed to send the phase change notifications", zap.Error(err))
+		}
+	}()
+}
+
+// secretGetter reads the secrets of the notification targets in the namespace of the pipeline.
+func (r *pipelineReconciler) secretGetter(namespace string) notifications.SecretGetter {
+	return func(ctx context.Context, selector *corev1.SecretKeySelector) (string, error) {
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, secret); err != nil {
+			return "", fmt.Errorf("failed to get secret %q, %w", selector.Name, err)
+		}
+		v, ok := secret.Data[selector.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %q has no key %q", selector.Name, selector.Key)
+		}
+		return string(v), nil
+	}
+}
@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/notifications"
+)
+
+func Test_notifyPhaseChange(t *testing.T) {
+	events := make(chan notifications.Event, 10)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		e := notifications.Event{}
+		_ = json.NewDecoder(req.Body).Decode(&e)
+		assert.Equal(t, "Bearer abc", req.Header.Get("Authorization"))
+		events <- e
+	}))
+	defer s.Close()
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "hook"}, Data: map[string][]byte{"token": []byte("Bearer abc")}}
+	cl := fake.NewClientBuilder().WithObjects(secret).Build()
+	r := &pipelineReconciler{
+		client: cl,
+		scheme: scheme.Scheme,
+		config: fakeConfig,
+		image:  testFlowImage,
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+	old := testPipeline.DeepCopy()
+	old.Spec.Notifications = &dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{
+		{Name: "hook", Webhook: &dfv1.WebhookNotification{URL: s.URL, Authorization: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "hook"}, Key: "token"}}},
+	}}
+	new := old.DeepCopy()
+	new.Status.Phase = dfv1.PipelinePhaseRunning
+
+	// The first phase is not sent
+	r.notifyPhaseChange(old, new)
+	old.Status.Phase = dfv1.PipelinePhaseRunning
+	// Nor is an unchanged one
+	r.notifyPhaseChange(old, new)
+	new.Status.Phase = dfv1.PipelinePhaseFailed
+	new.Status.Message = "oops"
+	r.notifyPhaseChange(old, new)
+	select {
+	case e := <-events:
+		assert.Equal(t, dfv1.NotificationEventPhaseChanged, e.Type)
+		assert.Equal(t, dfv1.PipelinePhaseFailed, e.Phase)
+		assert.Equal(t, dfv1.PipelinePhaseRunning, e.PreviousPhase)
+		assert.Equal(t, "Pipeline test-ns/test-pl phase changed from Running to Failed: oops", e.Message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification sent")
+	}
+	assert.Len(t, events, 0)
+}
+
+func Test_secretGetter(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "s"}, Data: map[string][]byte{"k": []byte("v")}}
+	r := &pipelineReconciler{client: fake.NewClientBuilder().WithObjects(secret).Build()}
+	get := r.secretGetter(testNamespace)
+	v, err := get(context.TODO(), &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s"}, Key: "k"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v", v)
+	_, err = get(context.TODO(), &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s"}, Key: "x"})
+	assert.Error(t, err)
+	_, err = get(context.TODO(), &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "x"}, Key: "k"})
+	assert.Error(t, err)
+}
+
+func Test_buildDaemonDeployment_notificationSecrets(t *testing.T) {
+	r := &pipelineReconciler{config: fakeConfig, image: testFlowImage}
+	testObj := testPipeline.DeepCopy()
+	testObj.Spec.Notifications = &dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{
+		{Name: "slack", Slack: &dfv1.SlackNotification{URL: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"}}},
+	}}
+	deploy, err := r.buildDaemonDeployment(testObj, fakeIsbSvcConfig)
+	require.NoError(t, err)
+	hasVolume := func() bool {
+		for _, v := range deploy.Spec.Template.Spec.Volumes {
+			if v.Secret != nil && v.Secret.SecretName == "slack" {
+				return true
+			}
+		}
+		return false
+	}
+	// Only mounted if the SLA is checked by the daemon server
+	assert.False(t, hasVolume())
+	testObj.Spec.Notifications.SLA = &dfv1.PipelineSLA{MaxPendingMessages: pointer.Int64(100)}
+	deploy, err = r.buildDaemonDeployment(testObj, fakeIsbSvcConfig)
+	require.NoError(t, err)
+	assert.True(t, hasVolume())
+}
@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+const defaultServiceAccount = "default"
+
+// runtimeRules are the Kubernetes API permissions required by the daemon, vertex and buffer job pods. None of them
+// talks to the API server, the secrets and config maps they use are mounted as volumes by the controllers.
+var runtimeRules = []rbacv1.PolicyRule{}
+
+// GetServiceAccounts returns the service accounts of the pods created for the pipeline, keyed by the components
+// running with them, which are "daemon", "job" and "vertex/<name>".
+func GetServiceAccounts(pl *dfv1.Pipeline) (map[string]string, error) {
+	sa := func(name string) string {
+		if name == "" {
+			return defaultServiceAccount
+		}
+		return name
+	}
+	result := make(map[string]string)
+	deployment, err := pl.GetDaemonDeploymentObj(dfv1.GetDaemonDeploymentReq{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the daemon deployment, %w", err)
+	}
+	result["daemon"] = sa(deployment.Spec.Template.Spec.ServiceAccountName)
+	job := buildISBBatchJob(pl, "", dfv1.BufferServiceConfig{}, "", nil, "create")
+	result["job"] = sa(job.Spec.Template.Spec.ServiceAccountName)
+	for _, v := range buildVertices(pl, nil) {
+		result["vertex/"+v.Spec.Name] = sa(v.Spec.ServiceAccountName)
+	}
+	return result, nil
+}
+
+// BuildRBAC returns the minimal Role and RoleBinding required by the service accounts of the pipeline's pods.
+func BuildRBAC(pl *dfv1.Pipeline) (*rbacv1.Role, *rbacv1.RoleBinding, error) {
+	serviceAccounts, err := GetServiceAccounts(pl)
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make(map[string]bool)
+	for _, n := range serviceAccounts {
+		names[n] = true
+	}
+	subjects := []rbacv1.Subject{}
+	for n := range names {
+		subjects = append(subjects, rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: n, Namespace: pl.Namespace})
+	}
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].Name < subjects[j].Name })
+	objMeta := metav1.ObjectMeta{
+		Namespace: pl.Namespace,
+		Name:      pl.Name + "-pipeline",
+		Labels: map[string]string{
+			dfv1.KeyPartOf:       dfv1.Project,
+			dfv1.KeyPipelineName: pl.Name,
+		},
+	}
+	role := &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: objMeta,
+		Rules:      runtimeRules,
+	}
+	binding := &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		ObjectMeta: *objMeta.DeepCopy(),
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: objMeta.Name},
+		Subjects:   subjects,
+	}
+	return role, binding, nil
+}
@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestGetServiceAccounts(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	pl.Spec.Vertices[1].ServiceAccountName = "udf-sa"
+	sas, err := GetServiceAccounts(pl)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"daemon":        "default",
+		"job":           "default",
+		"vertex/input":  "default",
+		"vertex/p1":     "udf-sa",
+		"vertex/output": "default",
+	}, sas)
+}
+
+func TestBuildRBAC(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	pl.Spec.Vertices[1].ServiceAccountName = "udf-sa"
+	role, binding, err := BuildRBAC(pl)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-pl-pipeline", role.Name)
+	assert.Equal(t, "test-ns", role.Namespace)
+	assert.Empty(t, role.Rules)
+	assert.Equal(t, "Role", binding.RoleRef.Kind)
+	assert.Equal(t, role.Name, binding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Name: "default", Namespace: "test-ns"},
+		{Kind: rbacv1.ServiceAccountKind, Name: "udf-sa", Namespace: "test-ns"},
+	}, binding.Subjects)
+}
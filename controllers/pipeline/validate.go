@@ -1,9 +1,20 @@
 package pipeline
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/notifications"
 )
 
 func ValidatePipeline(pl *dfv1.Pipeline) error {
@@ -16,35 +27,68 @@ func ValidatePipeline(pl *dfv1.Pipeline) error {
 	if len(pl.Spec.Edges) == 0 {
 		return fmt.Errorf("no edges defined")
 	}
+	for name := range pl.Spec.FeatureGates {
+		if !dfv1.IsKnownFeatureGate(name) {
+			return fmt.Errorf("unknown feature gate %q, known feature gates: %s", name, strings.Join(dfv1.KnownFeatureGates(), ","))
+		}
+	}
+	if rp := pl.Spec.ReplayPolicy; rp != nil {
+		switch rp.GetDeliverPolicy() {
+		case dfv1.DeliverPolicyAll, dfv1.DeliverPolicyNew:
+		case dfv1.DeliverPolicyByStartTime:
+			if rp.StartTime == nil {
+				return fmt.Errorf("invalid replay policy, \"startTime\" is required by deliver policy %q", rp.DeliverPolicy)
+			}
+		default:
+			return fmt.Errorf("invalid replay policy, unsupported deliver policy %q", rp.DeliverPolicy)
+		}
+	}
+	if x := pl.Spec.Metrics.GetExport(); x != nil {
+		if err := validateMetricsExport(x); err != nil {
+			return err
+		}
+	}
+	if x := pl.Spec.Tracing; x != nil {
+		if err := validateTracing(x); err != nil {
+			return err
+		}
+	}
+	if x := pl.Spec.Notifications; x != nil {
+		if err := validateNotifications(x); err != nil {
+			return err
+		}
+	}
 	names := make(map[string]bool)
 	sources := make(map[string]dfv1.AbstractVertex)
 	sinks := make(map[string]dfv1.AbstractVertex)
 	udfs := make(map[string]dfv1.AbstractVertex)
+	reduces := make(map[string]dfv1.AbstractVertex)
 	for _, v := range pl.Spec.Vertices {
 		if names[v.Name] {
 			return fmt.Errorf("duplicate vertex name %q", v.Name)
 		}
 		names[v.Name] = true
-		if v.Source == nil && v.Sink == nil && v.UDF == nil {
-			return fmt.Errorf("invalid vertex %q, it could only be either a source, or a sink, or a UDF", v.Name)
-		}
-		if v.Source != nil {
-			if v.Sink != nil || v.UDF != nil {
-				return fmt.Errorf("invalid vertex %q, only one of 'source', 'sink' and 'udf' can be specified", v.Name)
+		kinds := 0
+		for _, x := range []bool{v.Source != nil, v.Sink != nil, v.UDF != nil, v.Reduce != nil} {
+			if x {
+				kinds++
 			}
-			sources[v.Name] = v
 		}
-		if v.Sink != nil {
-			if v.Source != nil || v.UDF != nil {
-				return fmt.Errorf("invalid vertex %q, only one of 'source', 'sink' and 'udf' can be specified", v.Name)
-			}
-			sinks[v.Name] = v
+		if kinds == 0 {
+			return fmt.Errorf("invalid vertex %q, it could only be either a source, or a sink, or a UDF, or a reduce", v.Name)
 		}
-		if v.UDF != nil {
-			if v.Source != nil || v.Sink != nil {
-				return fmt.Errorf("invalid vertex %q, only one of 'source', 'sink' and 'udf' can be specified", v.Name)
-			}
+		if kinds > 1 {
+			return fmt.Errorf("invalid vertex %q, only one of 'source', 'sink', 'udf' and 'reduce' can be specified", v.Name)
+		}
+		switch {
+		case v.Source != nil:
+			sources[v.Name] = v
+		case v.Sink != nil:
+			sinks[v.Name] = v
+		case v.UDF != nil:
 			udfs[v.Name] = v
+		default:
+			reduces[v.Name] = v
 		}
 	}
 
@@ -56,7 +100,68 @@ func ValidatePipeline(pl *dfv1.Pipeline) error {
 		return fmt.Errorf("pipeline has no sink, at lease one vertex with 'sink' defined is requried")
 	}
 
+	requestReplySources, replySinks := 0, 0
+	for _, v := range sources {
+		if v.Source.HTTP.IsRequestReply() {
+			requestReplySources++
+		}
+	}
+	for k, v := range sinks {
+		if x := v.Sink.Log; x != nil && x.SamplePercentage != nil && (*x.SamplePercentage == 0 || *x.SamplePercentage > 100) {
+			return fmt.Errorf("invalid vertex %q, log sink \"samplePercentage\" should be between 1 and 100", k)
+		}
+		if v.Sink.Reply != nil {
+			if v.Sink.Log != nil || v.Sink.Kafka != nil || v.Sink.UDSink != nil {
+				return fmt.Errorf("invalid vertex %q, reply sink can not be specified together with other sinks", k)
+			}
+			replySinks++
+		}
+	}
+	if requestReplySources > 0 && replySinks == 0 {
+		return fmt.Errorf("pipeline has http source in request-reply mode, but no reply sink")
+	}
+	if replySinks > 0 && requestReplySources == 0 {
+		return fmt.Errorf("pipeline has reply sink, but no http source in request-reply mode")
+	}
+
+	for k, v := range sources {
+		if x := v.Source.Kafka; x != nil && x.SASL != nil {
+			switch x.SASL.GetMechanism() {
+			case dfv1.SASLMechanismPlain, dfv1.SASLMechanismSCRAMSHA256, dfv1.SASLMechanismSCRAMSHA512:
+			default:
+				return fmt.Errorf("invalid vertex %q, unsupported SASL mechanism %q", k, x.SASL.Mechanism)
+			}
+			if x.SASL.UserSecret == nil || x.SASL.PasswordSecret == nil {
+				return fmt.Errorf("invalid vertex %q, both SASL userSecret and passwordSecret are required", k)
+			}
+		}
+	}
+
 	for k, u := range udfs {
+		if x := u.UDF.Plugin; x != nil {
+			if u.UDF.WASM != nil {
+				return fmt.Errorf("invalid vertex %q, can not specify both plugin function and wasm module", k)
+			}
+			if x.Name == "" {
+				return fmt.Errorf("invalid vertex %q, plugin function name is required", k)
+			}
+			if u.UDF.Builtin != nil || (u.UDF.Container != nil && u.UDF.Container.Image != "") {
+				return fmt.Errorf("invalid vertex %q, can not specify plugin function together with builtin function or customized image", k)
+			}
+			continue
+		}
+		if x := u.UDF.WASM; x != nil {
+			if u.UDF.Builtin != nil || (u.UDF.Container != nil && u.UDF.Container.Image != "") {
+				return fmt.Errorf("invalid vertex %q, can not specify wasm module together with builtin function or customized image", k)
+			}
+			if (x.ConfigMap == nil) == (x.Path == "") {
+				return fmt.Errorf("invalid vertex %q, exactly one of wasm module configMap and path is required", k)
+			}
+			if x.Path != "" && !filepath.IsAbs(x.Path) {
+				return fmt.Errorf("invalid vertex %q, wasm module path %q is not absolute", k, x.Path)
+			}
+			continue
+		}
 		if u.UDF.Container != nil {
 			if u.UDF.Container.Image == "" && u.UDF.Builtin == nil {
 				return fmt.Errorf("invalid vertex %q, either specify a builtin function, or a customized image", k)
@@ -69,7 +174,26 @@ func ValidatePipeline(pl *dfv1.Pipeline) error {
 		}
 	}
 
+	for k, r := range reduces {
+		if r.Reduce.Window.Fixed == nil {
+			return fmt.Errorf("invalid vertex %q, a fixed window is required by reduce", k)
+		}
+		if r.Reduce.Window.Fixed.GetLength() <= 0 {
+			return fmt.Errorf("invalid vertex %q, the length of the fixed window should be greater than 0", k)
+		}
+		if r.Reduce.GetAllowedLateness() < 0 {
+			return fmt.Errorf("invalid vertex %q, allowed lateness should not be negative", k)
+		}
+		if r.Reduce.Builtin == nil {
+			return fmt.Errorf("invalid vertex %q, a builtin reduce function is required", k)
+		}
+		if r.Scale.GetMaxReplicas() > 1 {
+			return fmt.Errorf("invalid vertex %q, a reduce vertex can not have more than 1 replica", k)
+		}
+	}
+
 	namesInEdges := make(map[string]bool)
+	inbound, outbound := make(map[string]int), make(map[string]int)
 	for _, e := range pl.Spec.Edges {
 		if e.From == "" || e.To == "" {
 			return fmt.Errorf("invalid edge: both from and to need to be specified")
@@ -87,27 +211,67 @@ func ValidatePipeline(pl *dfv1.Pipeline) error {
 			return fmt.Errorf("source vertex %q can not be define as 'to'", e.To)
 		}
 		if _, existing := sinks[e.From]; existing {
-			return fmt.Errorf("sink vertex %q can not be define as 'from'", e.To)
+			return fmt.Errorf("sink vertex %q can not be define as 'from'", e.From)
 		}
 		if e.Conditions != nil && len(e.Conditions.KeyIn) > 0 {
 			if _, ok := sources[e.From]; ok { // Source vertex should not do conditional forwarding
 				return fmt.Errorf("invalid edge, \"conditions.keysIn\" not allowed for %q", e.From)
 			}
+			for _, k := range e.Conditions.KeyIn {
+				if k == "" || k == dfv1.MessageKeyAll || k == dfv1.MessageKeyDrop {
+					return fmt.Errorf("invalid edge from %q to %q, %q is not allowed in \"conditions.keyIn\"", e.From, e.To, k)
+				}
+			}
+		}
+		if e.DeadLetterQueue != nil {
+			if _, ok := sinks[e.To]; ok { // Only the UDF failures are dead-lettered
+				return fmt.Errorf("invalid edge, \"deadLetterQueue\" not allowed for sink vertex %q", e.To)
+			}
+			if _, ok := reduces[e.To]; ok {
+				return fmt.Errorf("invalid edge, \"deadLetterQueue\" not allowed for reduce vertex %q", e.To)
+			}
+		}
+		if x := e.Limits; x != nil && x.MaxMessageAge != nil && x.MaxMessageAge.Duration <= 0 {
+			return fmt.Errorf("invalid edge from %q to %q, \"limits.maxMessageAge\" should be greater than 0", e.From, e.To)
+		}
+		if x := e.Trace; x != nil {
+			if r, err := strconv.ParseFloat(x.SampleRate, 64); err != nil || r <= 0 || r > 1 {
+				return fmt.Errorf("invalid edge from %q to %q, \"trace.sampleRate\" should be a number greater than 0 and no more than 1", e.From, e.To)
+			}
+			if x.MaxRecords != nil && *x.MaxRecords == 0 {
+				return fmt.Errorf("invalid edge from %q to %q, \"trace.maxRecords\" should be greater than 0", e.From, e.To)
+			}
 		}
 		namesInEdges[e.From] = true
 		namesInEdges[e.To] = true
+		outbound[e.From]++
+		inbound[e.To]++
 	}
 	if len(namesInEdges) != len(names) {
 		return fmt.Errorf("not all the vertex names are defined in edges")
 	}
+	// The UDF and reduce vertices can fan in from and fan out to any number of vertices, but at least one each
+	for _, vertices := range []map[string]dfv1.AbstractVertex{udfs, reduces} {
+		for name := range vertices {
+			if inbound[name] == 0 {
+				return fmt.Errorf("invalid vertex %q, it needs to be defined as 'to' in at least one edge", name)
+			}
+			if outbound[name] == 0 {
+				return fmt.Errorf("invalid vertex %q, it needs to be defined as 'from' in at least one edge", name)
+			}
+		}
+	}
 
-	// Do not support N FROM -> 1 TO for now.
-	toInEdges := make(map[string]bool)
+	edgesSeen := make(map[string]bool)
 	for _, e := range pl.Spec.Edges {
-		if _, existing := toInEdges[e.To]; existing {
-			return fmt.Errorf("vertex %q has multiple 'from', which is not supported yet", e.To)
+		key := e.From + "->" + e.To
+		if edgesSeen[key] {
+			return fmt.Errorf("duplicate edge from %q to %q", e.From, e.To)
 		}
-		toInEdges[e.To] = true
+		edgesSeen[key] = true
+	}
+	if err := validateNoCycles(pl.Spec.Edges); err != nil {
+		return err
 	}
 
 	for _, v := range pl.Spec.Vertices {
@@ -115,6 +279,232 @@ func ValidatePipeline(pl *dfv1.Pipeline) error {
 			return err
 		}
 	}
+	return validateLimits(pl)
+}
+
+const (
+	// objectSizeLimit is the default size limit of the objects stored in etcd
+	objectSizeLimit = 1536 * 1024
+	// specSizeWarningThreshold is the size of the pipeline spec warned about. The spec is stored twice in the objects
+	// applied by "kubectl apply", in the last-applied-configuration annotation, and the status takes some room as well.
+	specSizeWarningThreshold = 512 * 1024
+)
+
+// GetPipelineWarnings returns the warnings of a valid pipeline, about the settings which are likely to cause problems
+// later, e.g. a spec approaching the size limit of the objects.
+func GetPipelineWarnings(pl *dfv1.Pipeline) []string {
+	var warnings []string
+	if data, err := json.Marshal(pl.Spec); err == nil && len(data) > specSizeWarningThreshold {
+		warnings = append(warnings, fmt.Sprintf("the pipeline spec is %d KiB, approaching the %d KiB size limit of the objects, "+
+			"consider moving the settings shared by the vertices to spec.templates, or splitting the pipeline", len(data)/1024, objectSizeLimit/1024))
+	}
+	return warnings
+}
+
+// ValidatePipelineUpdate validates the changes from the old pipeline to the new one, on top of ValidatePipeline.
+func ValidatePipelineUpdate(old, new *dfv1.Pipeline) error {
+	isbSvcName := func(pl *dfv1.Pipeline) string {
+		if pl.Spec.InterStepBufferServiceName == "" {
+			return dfv1.DefaultISBSvcName
+		}
+		return pl.Spec.InterStepBufferServiceName
+	}
+	if isbSvcName(old) != isbSvcName(new) {
+		return fmt.Errorf("\"interStepBufferServiceName\" is immutable, can not be changed from %q to %q", isbSvcName(old), isbSvcName(new))
+	}
+	return nil
+}
+
+// ValidatePipelineOnISBSvc validates the pipeline against the ISB Service it runs on, on top of ValidatePipeline,
+// rejecting the settings not supported by the ISB Service instead of ignoring them.
+func ValidatePipelineOnISBSvc(pl *dfv1.Pipeline, isbSvc *dfv1.InterStepBufferService) error {
+	if isbSvc.Spec.Kafka == nil {
+		return nil
+	}
+	if x, ok := pl.Spec.FeatureGates[dfv1.FeatureGateExactlyOnce]; ok && x {
+		return fmt.Errorf("feature gate %q is not supported by the Kafka ISB Service", dfv1.FeatureGateExactlyOnce)
+	}
+	for _, e := range pl.Spec.Edges {
+		el := copyEdgeLimits(pl, e)
+		if el == nil {
+			continue
+		}
+		if el.ExactlyOnce != nil && *el.ExactlyOnce {
+			return fmt.Errorf("edge %q - %q: \"exactlyOnce\" is not supported by the Kafka ISB Service", e.From, e.To)
+		}
+		if el.Durability != "" {
+			return fmt.Errorf("edge %q - %q: \"durability\" is not supported by the Kafka ISB Service", e.From, e.To)
+		}
+		if el.OnFull != "" {
+			return fmt.Errorf("edge %q - %q: \"onFull\" is not supported by the Kafka ISB Service", e.From, e.To)
+		}
+		if el.Compression != "" {
+			return fmt.Errorf("edge %q - %q: \"compression\" is not supported by the Kafka ISB Service, set by the edge, \"encoding.isb\" of the source or the pipeline limits", e.From, e.To)
+		}
+	}
+	return nil
+}
+
+// validateNoCycles makes sure the edges don't form a cycle.
+func validateNoCycles(edges []dfv1.Edge) error {
+	toVertices := make(map[string][]string)
+	for _, e := range edges {
+		toVertices[e.From] = append(toVertices[e.From], e.To)
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int)
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch states[name] {
+		case visiting:
+			return fmt.Errorf("invalid edges, cycle detected: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		states[name] = visiting
+		for _, to := range toVertices[name] {
+			if err := visit(to, append(path, name)); err != nil {
+				return err
+			}
+		}
+		states[name] = visited
+		return nil
+	}
+	for _, e := range edges {
+		if err := visit(e.From, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLimits validates the limits of the vertices, with the pipeline limits as the defaults.
+func validateLimits(pl *dfv1.Pipeline) error {
+	limits := make(map[string]*dfv1.VertexLimits)
+	for _, v := range pl.Spec.Vertices {
+		vCopy := v.DeepCopy()
+		copyLimits(pl, vCopy)
+		if vCopy.Limits == nil {
+			continue
+		}
+		if x := vCopy.Limits.BufferUsageLimit; x != nil && (*x == 0 || *x > 100) {
+			return fmt.Errorf("vertex %q: buffer usage limit should be between 1 and 100", v.Name)
+		}
+		if x := vCopy.Limits.ReadBatchSize; x != nil && *x == 0 {
+			return fmt.Errorf("vertex %q: read batch size should be greater than 0", v.Name)
+		}
+		if x := vCopy.Limits.MaxKeySize; x != nil && *x == 0 {
+			return fmt.Errorf("vertex %q: max key size should be greater than 0", v.Name)
+		}
+		if x := vCopy.Limits.MaxHeaderSize; x != nil && *x == 0 {
+			return fmt.Errorf("vertex %q: max header size should be greater than 0", v.Name)
+		}
+		limits[v.Name] = vCopy.Limits
+	}
+	for _, e := range pl.Spec.Edges {
+		from, to := limits[e.From], limits[e.To]
+		if from == nil || to == nil || from.BufferMaxLength == nil || to.ReadBatchSize == nil {
+			continue
+		}
+		if *to.ReadBatchSize > *from.BufferMaxLength {
+			return fmt.Errorf("vertex %q: read batch size %d is greater than the buffer max length %d of vertex %q", e.To, *to.ReadBatchSize, *from.BufferMaxLength, e.From)
+		}
+	}
+	return nil
+}
+
+// validateMetricsExport validates the endpoint of the metrics export against its protocol.
+func validateMetricsExport(x *dfv1.MetricsExport) error {
+	switch x.Protocol {
+	case dfv1.MetricsExportProtocolOTLP:
+		u, err := url.Parse(x.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid metrics export, OTLP endpoint %q is not an http or https URL", x.Endpoint)
+		}
+	case dfv1.MetricsExportProtocolStatsD:
+		if _, _, err := net.SplitHostPort(x.Endpoint); err != nil {
+			return fmt.Errorf("invalid metrics export, StatsD endpoint %q is not in the format of \"host:port\"", x.Endpoint)
+		}
+	default:
+		return fmt.Errorf("invalid metrics export, unsupported protocol %q", x.Protocol)
+	}
+	if x.Interval != nil && x.Interval.Duration < time.Second {
+		return fmt.Errorf("invalid metrics export, interval should be at least 1s")
+	}
+	return nil
+}
+
+// validateTracing validates the OTLP endpoint and the export interval of the tracing.
+func validateTracing(x *dfv1.PipelineTracing) error {
+	u, err := url.Parse(x.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid tracing, OTLP endpoint %q is not an http or https URL", x.Endpoint)
+	}
+	if x.SamplingPercentage != nil && *x.SamplingPercentage > 100 {
+		return fmt.Errorf("invalid tracing, sampling percentage should be at most 100")
+	}
+	if x.Interval != nil && x.Interval.Duration < time.Second {
+		return fmt.Errorf("invalid tracing, interval should be at least 1s")
+	}
+	return nil
+}
+
+// validateNotifications validates the targets and the SLA of the notifications.
+func validateNotifications(x *dfv1.PipelineNotifications) error {
+	if len(x.Targets) == 0 {
+		return fmt.Errorf("invalid notifications, no targets defined")
+	}
+	names := make(map[string]bool)
+	for _, t := range x.Targets {
+		if t.Name == "" {
+			return fmt.Errorf("invalid notifications, target name is required")
+		}
+		if names[t.Name] {
+			return fmt.Errorf("invalid notifications, duplicate target name %q", t.Name)
+		}
+		names[t.Name] = true
+		kinds := 0
+		for _, k := range []bool{t.Webhook != nil, t.Slack != nil, t.Opsgenie != nil} {
+			if k {
+				kinds++
+			}
+		}
+		if kinds != 1 {
+			return fmt.Errorf("invalid notification target %q, exactly one of webhook, slack and opsgenie is required", t.Name)
+		}
+		switch {
+		case t.Webhook != nil:
+			u, err := url.Parse(t.Webhook.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid notification target %q, webhook URL %q is not an http or https URL", t.Name, t.Webhook.URL)
+			}
+		case t.Slack != nil:
+			if t.Slack.URL == nil {
+				return fmt.Errorf("invalid notification target %q, slack URL secret is required", t.Name)
+			}
+		case t.Opsgenie != nil:
+			if t.Opsgenie.APIKey == nil {
+				return fmt.Errorf("invalid notification target %q, opsgenie API key secret is required", t.Name)
+			}
+		}
+		if _, err := notifications.ParseTemplate(t.Name, t.Template); err != nil {
+			return err
+		}
+	}
+	if sla := x.SLA; sla != nil {
+		if sla.MaxPendingMessages == nil && sla.MaxWatermarkDelay == nil {
+			return fmt.Errorf("invalid notifications, SLA has no objectives")
+		}
+		if sla.MaxPendingMessages != nil && *sla.MaxPendingMessages < 0 {
+			return fmt.Errorf("invalid notifications, SLA max pending messages should not be negative")
+		}
+		if sla.MaxWatermarkDelay != nil && sla.MaxWatermarkDelay.Duration <= 0 {
+			return fmt.Errorf("invalid notifications, SLA max watermark delay should be positive")
+		}
+	}
 	return nil
 }
 
@@ -132,5 +522,130 @@ func validateVertex(v dfv1.AbstractVertex) error {
 	if min > max {
 		return fmt.Errorf("vertex %q: max number of replicas should be greater than or equal to min", v.Name)
 	}
+	if x := v.Scale.TargetBufferUsage; x != nil && (*x == 0 || *x > 100) {
+		return fmt.Errorf("vertex %q: scale target buffer usage should be between 1 and 100", v.Name)
+	}
+	if v.UpdateStrategy != nil && v.UpdateStrategy.MaxUnavailable != nil {
+		if n, err := intstr.GetScaledValueFromIntOrPercent(v.UpdateStrategy.MaxUnavailable, 100, false); err != nil || n < 0 {
+			return fmt.Errorf("vertex %q: update strategy maxUnavailable should be a non-negative number or percentage", v.Name)
+		}
+	}
+	if v.Source != nil && v.Source.HTTP != nil && v.Source.HTTP.RateLimit != nil && *v.Source.HTTP.RateLimit == 0 {
+		return fmt.Errorf("vertex %q: http source rate limit should be greater than 0", v.Name)
+	}
+	if v.Source != nil && v.Source.HTTP != nil && v.Source.HTTP.Signature != nil && v.Source.HTTP.Signature.Secret == nil {
+		return fmt.Errorf("vertex %q: http source signature secret is required", v.Name)
+	}
+	if v.Source != nil && v.Source.HTTP.IsRequestReply() && v.Source.HTTP.RequestReply.GetTimeout() <= 0 {
+		return fmt.Errorf("vertex %q: http source request-reply timeout should be greater than 0", v.Name)
+	}
+	if v.Source != nil && v.Source.HTTP != nil && v.Source.HTTP.Sharding != nil {
+		x := v.Source.HTTP.Sharding
+		if strings.ContainsAny(x.Header, " \t\r\n:") {
+			return fmt.Errorf("vertex %q: invalid http source sharding header %q", v.Name, x.Header)
+		}
+		if x.GetDedupWindow() < 0 {
+			return fmt.Errorf("vertex %q: http source sharding dedup window should not be negative", v.Name)
+		}
+	}
+	if v.Source != nil && v.Source.RateLimit != nil && v.Source.RateLimit.MessagesPerSecond == 0 {
+		return fmt.Errorf("vertex %q: source rate limit messagesPerSecond should be greater than 0", v.Name)
+	}
+	if v.Source != nil && v.Source.Generator != nil {
+		if err := validateGeneratorSource(v.Source.Generator); err != nil {
+			return fmt.Errorf("vertex %q: %w", v.Name, err)
+		}
+	}
+	if x := v.Storage; x != nil {
+		if err := validateStorage(v, x); err != nil {
+			return fmt.Errorf("vertex %q: %w", v.Name, err)
+		}
+	}
+	if len(v.SideInputs) > 0 {
+		if err := validateSideInputs(v); err != nil {
+			return fmt.Errorf("vertex %q: %w", v.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateSideInputs(v dfv1.AbstractVertex) error {
+	if v.UDF == nil {
+		return fmt.Errorf("side inputs are only supported by the UDF vertices")
+	}
+	names := map[string]bool{}
+	for _, si := range v.SideInputs {
+		// The name is used as the file name.
+		if errs := validation.IsDNS1123Label(si.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid side input name %q, %s", si.Name, strings.Join(errs, ", "))
+		}
+		if names[si.Name] {
+			return fmt.Errorf("duplicate side input name %q", si.Name)
+		}
+		names[si.Name] = true
+		if (si.ConfigMap == nil) == (si.HTTP == nil) {
+			return fmt.Errorf("side input %q: exactly one of configMap and http should be specified", si.Name)
+		}
+		if si.ConfigMap != nil && (si.ConfigMap.Name == "" || si.ConfigMap.Key == "") {
+			return fmt.Errorf("side input %q: configMap name and key are required", si.Name)
+		}
+		if si.HTTP != nil {
+			if u, err := url.Parse(si.HTTP.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("side input %q: invalid http url %q", si.Name, si.HTTP.URL)
+			}
+			if si.HTTP.Timeout != nil && si.HTTP.Timeout.Duration <= 0 {
+				return fmt.Errorf("side input %q: http timeout should be greater than 0", si.Name)
+			}
+		}
+		if si.RefreshInterval != nil && si.RefreshInterval.Duration <= 0 {
+			return fmt.Errorf("side input %q: refresh interval should be greater than 0", si.Name)
+		}
+	}
+	return nil
+}
+
+func validateGeneratorSource(x *dfv1.GeneratorSource) error {
+	if lp := x.LoadProfile; lp != nil && lp.Type != dfv1.LoadProfileSteady {
+		if lp.PeakRPU == nil || *lp.PeakRPU <= 0 {
+			return fmt.Errorf("generator load profile %q requires peakRpu to be greater than 0", lp.Type)
+		}
+		if lp.GetPeriod() <= 0 {
+			return fmt.Errorf("generator load profile period should be greater than 0")
+		}
+		if lp.Type == dfv1.LoadProfileSpike && (lp.GetSpikeDuration() <= 0 || lp.GetSpikeDuration() >= lp.GetPeriod()) {
+			return fmt.Errorf("generator load profile spike duration should be greater than 0 and less than the period")
+		}
+	}
+	if x.Keys != nil && x.Keys.Count <= 0 {
+		return fmt.Errorf("generator key count should be greater than 0")
+	}
+	if x.Jitter != nil && x.Jitter.GetMax() <= 0 {
+		return fmt.Errorf("generator jitter max should be greater than 0")
+	}
+	return nil
+}
+
+func validateStorage(v dfv1.AbstractVertex, x *dfv1.VertexStorage) error {
+	if x.Scratch != nil && x.Scratch.SizeLimit != nil && x.Scratch.SizeLimit.Sign() <= 0 {
+		return fmt.Errorf("scratch volume size limit should be greater than 0")
+	}
+	if x.UDF != nil && v.UDF == nil && (v.Sink == nil || v.Sink.UDSink == nil) {
+		return fmt.Errorf("ephemeral storage of the UDF container is only meaningful for the UDF and UDSink vertices")
+	}
+	for _, c := range []struct {
+		name string
+		es   *dfv1.EphemeralStorage
+	}{{"main", x.Numa}, {"UDF", x.UDF}} {
+		name, es := c.name, c.es
+		if es == nil {
+			continue
+		}
+		if (es.Request != nil && es.Request.Sign() <= 0) || (es.Limit != nil && es.Limit.Sign() <= 0) {
+			return fmt.Errorf("ephemeral storage of the %s container should be greater than 0", name)
+		}
+		if es.Request != nil && es.Limit != nil && es.Request.Cmp(*es.Limit) > 0 {
+			return fmt.Errorf("ephemeral storage request of the %s container should not be greater than the limit", name)
+		}
+	}
 	return nil
 }
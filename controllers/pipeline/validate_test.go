@@ -1,11 +1,16 @@
 package pipeline
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -59,6 +64,113 @@ func TestValidatePipeline(t *testing.T) {
 		assert.Contains(t, err.Error(), "it could only be either a source, or a sink, or a UDF")
 	})
 
+	t.Run("unknown feature gate", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.FeatureGates = map[string]bool{dfv1.FeatureGateExactlyOnce: true, "abc": true}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown feature gate "abc"`)
+	})
+
+	t.Run("invalid replay policy", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.ReplayPolicy = &dfv1.ReplayPolicy{DeliverPolicy: dfv1.DeliverPolicyNew}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.ReplayPolicy = &dfv1.ReplayPolicy{DeliverPolicy: dfv1.DeliverPolicyByStartTime}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `"startTime" is required`)
+		testObj.Spec.ReplayPolicy = &dfv1.ReplayPolicy{DeliverPolicy: "abc"}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported deliver policy")
+	})
+
+	t.Run("invalid metrics export", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Metrics = &dfv1.PipelineMetrics{Export: &dfv1.MetricsExport{Protocol: dfv1.MetricsExportProtocolOTLP, Endpoint: "http://otel-collector:4318/v1/metrics"}}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Metrics.Export.Endpoint = "otel-collector:4318"
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not an http or https URL")
+		testObj.Spec.Metrics.Export = &dfv1.MetricsExport{Protocol: dfv1.MetricsExportProtocolStatsD, Endpoint: "statsd:8125"}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Metrics.Export.Endpoint = "statsd"
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `is not in the format of "host:port"`)
+		testObj.Spec.Metrics.Export = &dfv1.MetricsExport{Protocol: "abc", Endpoint: "statsd:8125"}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `unsupported protocol "abc"`)
+		testObj.Spec.Metrics.Export = &dfv1.MetricsExport{Protocol: dfv1.MetricsExportProtocolStatsD, Endpoint: "statsd:8125", Interval: &metav1.Duration{Duration: time.Millisecond}}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "interval should be at least 1s")
+	})
+
+	t.Run("invalid tracing", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Tracing = &dfv1.PipelineTracing{Endpoint: "http://otel-collector:4318/v1/traces"}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Tracing.Endpoint = "otel-collector:4318"
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not an http or https URL")
+		percentage := uint32(101)
+		testObj.Spec.Tracing = &dfv1.PipelineTracing{Endpoint: "http://otel-collector:4318/v1/traces", SamplingPercentage: &percentage}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "sampling percentage should be at most 100")
+		testObj.Spec.Tracing = &dfv1.PipelineTracing{Endpoint: "http://otel-collector:4318/v1/traces", Interval: &metav1.Duration{Duration: time.Millisecond}}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "interval should be at least 1s")
+	})
+
+	t.Run("invalid notifications", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		secret := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s"}, Key: "k"}
+		testObj.Spec.Notifications = &dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{
+			{Name: "hook", Template: "{{.Pipeline}}", Webhook: &dfv1.WebhookNotification{URL: "https://example.com/hook"}},
+			{Name: "slack", Slack: &dfv1.SlackNotification{URL: secret}},
+			{Name: "opsgenie", Opsgenie: &dfv1.OpsgenieNotification{APIKey: secret}},
+		}}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Notifications.Targets[1].Name = "hook"
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `duplicate target name "hook"`)
+		testObj.Spec.Notifications.Targets[1].Name = "slack"
+		testObj.Spec.Notifications.Targets[1].Webhook = &dfv1.WebhookNotification{URL: "https://example.com/hook"}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of webhook, slack and opsgenie is required")
+		testObj.Spec.Notifications.Targets[1].Webhook = nil
+		testObj.Spec.Notifications.Targets[0].Webhook.URL = "example.com"
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not an http or https URL")
+		testObj.Spec.Notifications.Targets[0].Webhook.URL = "https://example.com/hook"
+		testObj.Spec.Notifications.Targets[0].Template = "{{.Pipeline"
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid template of notification target "hook"`)
+		testObj.Spec.Notifications.Targets[0].Template = ""
+		testObj.Spec.Notifications.Targets[2].Opsgenie.APIKey = nil
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "opsgenie API key secret is required")
+		testObj.Spec.Notifications.Targets[2].Opsgenie.APIKey = secret
+		testObj.Spec.Notifications.SLA = &dfv1.PipelineSLA{}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "SLA has no objectives")
+		testObj.Spec.Notifications.SLA.MaxWatermarkDelay = &metav1.Duration{Duration: time.Minute}
+		assert.NoError(t, ValidatePipeline(testObj))
+	})
+
 	t.Run("duplicate vertex", func(t *testing.T) {
 		testObj := testPipeline.DeepCopy()
 		testObj.Spec.Vertices = append(testObj.Spec.Vertices, dfv1.AbstractVertex{Name: "input", Source: &dfv1.Source{}})
@@ -75,6 +187,33 @@ func TestValidatePipeline(t *testing.T) {
 		assert.Contains(t, err.Error(), "only one of")
 	})
 
+	t.Run("reduce vertex", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices[1] = dfv1.AbstractVertex{Name: "p1", Reduce: &dfv1.Reduce{
+			Window:  dfv1.Window{Fixed: &dfv1.FixedWindow{Length: &metav1.Duration{Duration: time.Minute}}},
+			Builtin: &dfv1.ReduceFunction{Name: "count"},
+		}}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Vertices[1].UDF = &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only one of")
+		testObj.Spec.Vertices[1].UDF = nil
+		testObj.Spec.Vertices[1].Scale.Max = pointer.Int32(2)
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "can not have more than 1 replica")
+		testObj.Spec.Vertices[1].Scale.Max = nil
+		testObj.Spec.Vertices[1].Reduce.Window.Fixed.Length = nil
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "the length of the fixed window should be greater than 0")
+		testObj.Spec.Vertices[1].Reduce.Window.Fixed = nil
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "a fixed window is required")
+	})
+
 	t.Run("udf no image and builtin spedified", func(t *testing.T) {
 		testObj := testPipeline.DeepCopy()
 		testObj.Spec.Vertices[1].UDF.Builtin = nil
@@ -91,6 +230,46 @@ func TestValidatePipeline(t *testing.T) {
 		assert.Contains(t, err.Error(), "can not specify both builtin function, and a customized image")
 	})
 
+	t.Run("udf plugin", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices[1].UDF.Builtin = nil
+		testObj.Spec.Vertices[1].UDF.Plugin = &dfv1.PluginFunction{Name: "my-func"}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Vertices[1].UDF.Plugin.Name = ""
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "plugin function name is required")
+	})
+
+	t.Run("udf both plugin and builtin spedified", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices[1].UDF.Plugin = &dfv1.PluginFunction{Name: "my-func"}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "can not specify plugin function together with")
+	})
+
+	t.Run("udf wasm", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices[1].UDF.Builtin = nil
+		testObj.Spec.Vertices[1].UDF.WASM = &dfv1.WASMFunction{Path: "/wasm/udf.wasm"}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Vertices[1].UDF.WASM.Path = "udf.wasm"
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "is not absolute")
+		testObj.Spec.Vertices[1].UDF.WASM.ConfigMap = &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-cm"}, Key: "udf.wasm"}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of wasm module configMap and path is required")
+		testObj.Spec.Vertices[1].UDF.WASM.Path = ""
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Vertices[1].UDF.Builtin = &dfv1.Function{Name: "cat"}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "can not specify wasm module together with")
+	})
+
 	t.Run("edge - invalid vertex name", func(t *testing.T) {
 		testObj := testPipeline.DeepCopy()
 		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "a", To: "b"})
@@ -161,8 +340,15 @@ func TestValidatePipeline(t *testing.T) {
 		testObj := testPipeline.DeepCopy()
 		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "input", To: "output"})
 		err := ValidatePipeline(testObj)
+		assert.NoError(t, err)
+	})
+
+	t.Run("duplicate edges", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "input", To: "p1"})
+		err := ValidatePipeline(testObj)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not supported")
+		assert.Contains(t, err.Error(), "duplicate edge")
 	})
 
 	t.Run("good conditional forwarding", func(t *testing.T) {
@@ -179,6 +365,260 @@ func TestValidatePipeline(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid edge")
 	})
+
+	t.Run("reserved keys in conditional forwarding", func(t *testing.T) {
+		for _, k := range []string{"", dfv1.MessageKeyAll, dfv1.MessageKeyDrop} {
+			testObj := testPipeline.DeepCopy()
+			testObj.Spec.Edges[1].Conditions = &dfv1.ForwardConditions{KeyIn: []string{"hello", k}}
+			err := ValidatePipeline(testObj)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "is not allowed in \"conditions.keyIn\"")
+		}
+	})
+
+	t.Run("multiple sources and sinks with fan-in and fan-out", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices = append(testObj.Spec.Vertices,
+			dfv1.AbstractVertex{Name: "input1", Source: &dfv1.Source{}},
+			dfv1.AbstractVertex{Name: "output1", Sink: &dfv1.Sink{}},
+		)
+		testObj.Spec.Edges = append(testObj.Spec.Edges,
+			dfv1.Edge{From: "input1", To: "p1"},
+			dfv1.Edge{From: "p1", To: "output1", Conditions: &dfv1.ForwardConditions{KeyIn: []string{"odd"}}},
+		)
+		testObj.Spec.Edges[1].Conditions = &dfv1.ForwardConditions{KeyIn: []string{"even"}}
+		assert.NoError(t, ValidatePipeline(testObj))
+	})
+
+	t.Run("udf without inbound or outbound edges", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices = append(testObj.Spec.Vertices, dfv1.AbstractVertex{Name: "p2", UDF: &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}}})
+		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "p2", To: "output"})
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "it needs to be defined as 'to' in at least one edge")
+
+		testObj = testPipeline.DeepCopy()
+		testObj.Spec.Vertices = append(testObj.Spec.Vertices, dfv1.AbstractVertex{Name: "p2", UDF: &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}}})
+		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "p1", To: "p2"})
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "it needs to be defined as 'from' in at least one edge")
+	})
+
+	t.Run("dead-letter queue", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Edges[0].DeadLetterQueue = &dfv1.DeadLetterQueue{}
+		err := ValidatePipeline(testObj)
+		assert.NoError(t, err)
+		testObj.Spec.Edges[1].DeadLetterQueue = &dfv1.DeadLetterQueue{}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not allowed for sink vertex")
+	})
+
+	t.Run("max message age", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Edges[1].Limits = &dfv1.EdgeLimits{MaxMessageAge: &metav1.Duration{Duration: time.Hour}}
+		err := ValidatePipeline(testObj)
+		assert.NoError(t, err)
+		testObj.Spec.Edges[1].Limits.MaxMessageAge.Duration = 0
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "limits.maxMessageAge")
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Edges[0].Trace = &dfv1.EdgeTrace{SampleRate: "0.01", CapturePayload: true}
+		err := ValidatePipeline(testObj)
+		assert.NoError(t, err)
+		for _, r := range []string{"", "0", "1.5", "1%"} {
+			testObj.Spec.Edges[0].Trace.SampleRate = r
+			err = ValidatePipeline(testObj)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "trace.sampleRate")
+		}
+		zero := uint32(0)
+		testObj.Spec.Edges[0].Trace = &dfv1.EdgeTrace{SampleRate: "1", MaxRecords: &zero}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "trace.maxRecords")
+	})
+
+	t.Run("log sink", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		percentage := uint32(10)
+		testObj.Spec.Vertices[2].Sink = &dfv1.Sink{Log: &dfv1.Log{Format: dfv1.LogSinkFormatJSON, SamplePercentage: &percentage}}
+		err := ValidatePipeline(testObj)
+		assert.NoError(t, err)
+		for _, p := range []uint32{0, 101} {
+			percentage = p
+			err = ValidatePipeline(testObj)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "samplePercentage")
+		}
+	})
+
+	t.Run("request-reply", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices[0].Source = &dfv1.Source{HTTP: &dfv1.HTTPSource{RequestReply: &dfv1.RequestReply{}}}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no reply sink")
+		testObj.Spec.Vertices[2].Sink = &dfv1.Sink{Reply: &dfv1.ReplySink{}}
+		err = ValidatePipeline(testObj)
+		assert.NoError(t, err)
+		testObj.Spec.Vertices[2].Sink.Log = &dfv1.Log{}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "together with other sinks")
+		testObj.Spec.Vertices[2].Sink.Log = nil
+		testObj.Spec.Vertices[0].Source.HTTP.RequestReply = nil
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no http source in request-reply mode")
+	})
+
+	t.Run("kafka source SASL", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		sasl := &dfv1.SASL{Mechanism: "GSSAPI"}
+		testObj.Spec.Vertices[0].Source = &dfv1.Source{Kafka: &dfv1.KafkaSource{Topic: "t", SASL: sasl}}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported SASL mechanism")
+		sasl.Mechanism = dfv1.SASLMechanismSCRAMSHA256
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "both SASL userSecret and passwordSecret are required")
+		sasl.UserSecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka"}, Key: "user"}
+		sasl.PasswordSecret = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka"}, Key: "password"}
+		err = ValidatePipeline(testObj)
+		assert.NoError(t, err)
+	})
+
+	t.Run("cycle in edges", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		testObj.Spec.Vertices = append(testObj.Spec.Vertices, dfv1.AbstractVertex{Name: "p2", UDF: &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}}})
+		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "p1", To: "p2"}, dfv1.Edge{From: "p2", To: "output"})
+		err := ValidatePipeline(testObj)
+		assert.NoError(t, err)
+		testObj.Spec.Edges = append(testObj.Spec.Edges, dfv1.Edge{From: "p2", To: "p1"})
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cycle detected: input -> p1 -> p2 -> p1")
+	})
+
+	t.Run("bad buffer usage limit", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		tooLarge, ninety := uint32(120), uint32(90)
+		testObj.Spec.Limits = &dfv1.PipelineLimits{BufferUsageLimit: &tooLarge}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "buffer usage limit should be between 1 and 100")
+		testObj.Spec.Vertices[0].Limits = &dfv1.VertexLimits{BufferUsageLimit: &ninety}
+		testObj.Spec.Vertices[1].Limits = &dfv1.VertexLimits{BufferUsageLimit: &ninety}
+		err = ValidatePipeline(testObj)
+		assert.NoError(t, err)
+	})
+
+	t.Run("read batch size greater than buffer max length", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		batchSize, maxLength, smallMaxLength := uint64(500), uint64(1000), uint64(100)
+		testObj.Spec.Limits = &dfv1.PipelineLimits{ReadBatchSize: &batchSize, BufferMaxLength: &maxLength}
+		err := ValidatePipeline(testObj)
+		assert.NoError(t, err)
+		testObj.Spec.Vertices[0].Limits = &dfv1.VertexLimits{BufferMaxLength: &smallMaxLength}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `vertex "p1": read batch size 500 is greater than the buffer max length 100 of vertex "input"`)
+	})
+
+	t.Run("bad max key and header sizes", func(t *testing.T) {
+		testObj := testPipeline.DeepCopy()
+		zero, size := uint32(0), uint32(1024)
+		testObj.Spec.Limits = &dfv1.PipelineLimits{MaxKeySize: &zero}
+		err := ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "max key size should be greater than 0")
+		testObj.Spec.Limits = &dfv1.PipelineLimits{MaxKeySize: &size, MaxHeaderSize: &size}
+		assert.NoError(t, ValidatePipeline(testObj))
+		testObj.Spec.Vertices[1].Limits = &dfv1.VertexLimits{MaxHeaderSize: &zero}
+		err = ValidatePipeline(testObj)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "max header size should be greater than 0")
+	})
+}
+
+func TestGetPipelineWarnings(t *testing.T) {
+	assert.Empty(t, GetPipelineWarnings(testPipeline))
+	pl := testPipeline.DeepCopy()
+	pl.Spec.Vertices[1].NodeSelector = map[string]string{"large": strings.Repeat("x", specSizeWarningThreshold)}
+	warnings := GetPipelineWarnings(pl)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "approaching the 1536 KiB size limit")
+}
+
+func TestValidatePipelineUpdate(t *testing.T) {
+	old := testPipeline.DeepCopy()
+	new := testPipeline.DeepCopy()
+	new.Spec.InterStepBufferServiceName = dfv1.DefaultISBSvcName
+	assert.NoError(t, ValidatePipelineUpdate(old, new))
+	new.Spec.InterStepBufferServiceName = "another"
+	err := ValidatePipelineUpdate(old, new)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "immutable")
+}
+
+func TestValidatePipelineOnISBSvc(t *testing.T) {
+	jetStream := &dfv1.InterStepBufferService{Spec: dfv1.InterStepBufferServiceSpec{JetStream: &dfv1.JetStreamBufferService{}}}
+	kafka := &dfv1.InterStepBufferService{Spec: dfv1.InterStepBufferServiceSpec{Kafka: &dfv1.KafkaConfig{}}}
+	assert.NoError(t, ValidatePipelineOnISBSvc(testPipeline, jetStream))
+	assert.NoError(t, ValidatePipelineOnISBSvc(testPipeline, kafka))
+
+	t.Run("exactly once", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Edges[0].Limits = &dfv1.EdgeLimits{ExactlyOnce: pointer.Bool(false)}
+		assert.NoError(t, ValidatePipelineOnISBSvc(pl, kafka))
+		pl.Spec.Edges[0].Limits.ExactlyOnce = pointer.Bool(true)
+		assert.NoError(t, ValidatePipelineOnISBSvc(pl, jetStream))
+		err := ValidatePipelineOnISBSvc(pl, kafka)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"exactlyOnce\" is not supported by the Kafka ISB Service")
+		pl = testPipeline.DeepCopy()
+		pl.Spec.FeatureGates = map[string]bool{dfv1.FeatureGateExactlyOnce: false}
+		assert.NoError(t, ValidatePipelineOnISBSvc(pl, kafka))
+		pl.Spec.FeatureGates[dfv1.FeatureGateExactlyOnce] = true
+		assert.Error(t, ValidatePipelineOnISBSvc(pl, kafka))
+	})
+
+	t.Run("durability and on full", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Edges[1].Limits = &dfv1.EdgeLimits{Durability: dfv1.WriteDurabilityNone}
+		err := ValidatePipelineOnISBSvc(pl, kafka)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"durability\"")
+		pl.Spec.Edges[1].Limits = &dfv1.EdgeLimits{OnFull: dfv1.OnFullDiscardLatest}
+		err = ValidatePipelineOnISBSvc(pl, kafka)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "\"onFull\"")
+		assert.NoError(t, ValidatePipelineOnISBSvc(pl, jetStream))
+	})
+
+	t.Run("compression", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Limits = &dfv1.PipelineLimits{Compression: dfv1.ContentEncodingLZ4}
+		assert.Error(t, ValidatePipelineOnISBSvc(pl, kafka))
+		assert.NoError(t, ValidatePipelineOnISBSvc(pl, jetStream))
+		pl = testPipeline.DeepCopy()
+		pl.Spec.Vertices[0].Source.Encoding = &dfv1.SourceEncoding{ISB: dfv1.ContentEncodingGzip}
+		assert.Error(t, ValidatePipelineOnISBSvc(pl, kafka))
+		pl = testPipeline.DeepCopy()
+		pl.Spec.Edges[1].Limits = &dfv1.EdgeLimits{Compression: dfv1.ContentEncodingZstd}
+		err := ValidatePipelineOnISBSvc(pl, kafka)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "edge \"p1\" - \"output\"")
+	})
 }
 
 func TestValidateVertex(t *testing.T) {
@@ -205,4 +645,184 @@ func TestValidateVertex(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "or equal to")
 	})
+	t.Run("bad max unavailable", func(t *testing.T) {
+		x := intstr.FromString("a lot")
+		v := dfv1.AbstractVertex{
+			UpdateStrategy: &dfv1.UpdateStrategy{MaxUnavailable: &x},
+		}
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "maxUnavailable")
+		x = intstr.FromInt(-1)
+		assert.Error(t, validateVertex(v))
+		x = intstr.FromString("50%")
+		assert.NoError(t, validateVertex(v))
+	})
+	t.Run("bad target buffer usage", func(t *testing.T) {
+		target := uint32(0)
+		v := dfv1.AbstractVertex{
+			Scale: dfv1.Scale{TargetBufferUsage: &target},
+		}
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "target buffer usage")
+	})
+	t.Run("zero http source rate limit", func(t *testing.T) {
+		zero := uint32(0)
+		v := dfv1.AbstractVertex{
+			Name: "in",
+			Source: &dfv1.Source{
+				HTTP: &dfv1.HTTPSource{RateLimit: &zero},
+			},
+		}
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "rate limit")
+	})
+	t.Run("source rate limit zero", func(t *testing.T) {
+		v := dfv1.AbstractVertex{
+			Name: "in",
+			Source: &dfv1.Source{
+				Generator: &dfv1.GeneratorSource{},
+				RateLimit: &dfv1.SourceRateLimit{},
+			},
+		}
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "messagesPerSecond")
+	})
+	t.Run("http source request-reply timeout", func(t *testing.T) {
+		v := dfv1.AbstractVertex{
+			Name: "in",
+			Source: &dfv1.Source{
+				HTTP: &dfv1.HTTPSource{RequestReply: &dfv1.RequestReply{Timeout: &metav1.Duration{}}},
+			},
+		}
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "request-reply timeout")
+	})
+	t.Run("http source sharding", func(t *testing.T) {
+		v := dfv1.AbstractVertex{
+			Name: "in",
+			Source: &dfv1.Source{
+				HTTP: &dfv1.HTTPSource{Sharding: &dfv1.HTTPSharding{Header: "X-Customer-Id"}},
+			},
+		}
+		assert.NoError(t, validateVertex(v))
+		v.Source.HTTP.Sharding.Header = "X-Customer Id"
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid http source sharding header")
+		v.Source.HTTP.Sharding.Header = ""
+		v.Source.HTTP.Sharding.DedupWindow = &metav1.Duration{Duration: -time.Second}
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "dedup window should not be negative")
+	})
+	t.Run("http source signature without secret", func(t *testing.T) {
+		v := dfv1.AbstractVertex{
+			Name: "in",
+			Source: &dfv1.Source{
+				HTTP: &dfv1.HTTPSource{Signature: &dfv1.HMACSignature{}},
+			},
+		}
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "signature secret")
+	})
+	t.Run("generator load profile and keys", func(t *testing.T) {
+		v := dfv1.AbstractVertex{
+			Name: "in",
+			Source: &dfv1.Source{
+				Generator: &dfv1.GeneratorSource{LoadProfile: &dfv1.LoadProfile{Type: dfv1.LoadProfileSpike, PeakRPU: pointer.Int64(100)}},
+			},
+		}
+		assert.NoError(t, validateVertex(v))
+		v.Source.Generator.LoadProfile.SpikeDuration = &metav1.Duration{Duration: time.Hour}
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "less than the period")
+		v.Source.Generator.LoadProfile = &dfv1.LoadProfile{Type: dfv1.LoadProfileRamp}
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "requires peakRpu")
+		v.Source.Generator.LoadProfile = nil
+		v.Source.Generator.Keys = &dfv1.GeneratorKeys{}
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "key count")
+		v.Source.Generator.Keys = nil
+		v.Source.Generator.Jitter = &dfv1.GeneratorJitter{}
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "jitter max")
+	})
+	t.Run("storage", func(t *testing.T) {
+		zero := resource.MustParse("0")
+		small := resource.MustParse("1Gi")
+		large := resource.MustParse("2Gi")
+		v := dfv1.AbstractVertex{
+			Name:    "p1",
+			UDF:     &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}},
+			Storage: &dfv1.VertexStorage{Scratch: &dfv1.ScratchVolume{SizeLimit: &small}, UDF: &dfv1.EphemeralStorage{Request: &small, Limit: &large}},
+		}
+		assert.NoError(t, validateVertex(v))
+		v.Storage.Scratch.SizeLimit = &zero
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "scratch volume size limit")
+		v.Storage.Scratch = nil
+		v.Storage.Numa = &dfv1.EphemeralStorage{Request: &large, Limit: &small}
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "request of the main container should not be greater than the limit")
+		v.Storage.Numa = nil
+		v.UDF = nil
+		v.Sink = &dfv1.Sink{Log: &dfv1.Log{}}
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only meaningful for the UDF and UDSink vertices")
+	})
+
+	t.Run("side inputs", func(t *testing.T) {
+		v := dfv1.AbstractVertex{
+			Name: "p1",
+			UDF:  &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}},
+			SideInputs: []dfv1.SideInput{
+				{Name: "table", ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-cm"}, Key: "table"}},
+				{Name: "config", HTTP: &dfv1.HTTPSideInput{URL: "http://config-svc/config"}, RefreshInterval: &metav1.Duration{Duration: time.Minute}},
+			},
+		}
+		assert.NoError(t, validateVertex(v))
+		v.SideInputs[1].Name = "table"
+		err := validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate side input name")
+		v.SideInputs[1].Name = "../config"
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid side input name")
+		v.SideInputs[1].Name = "config"
+		v.SideInputs[1].HTTP.URL = "config-svc"
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid http url")
+		v.SideInputs[1].HTTP.URL = "https://config-svc"
+		v.SideInputs[1].RefreshInterval.Duration = 0
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "refresh interval should be greater than 0")
+		v.SideInputs[1].RefreshInterval = nil
+		v.SideInputs[1].ConfigMap = v.SideInputs[0].ConfigMap
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of configMap and http")
+		v.SideInputs[1].ConfigMap = nil
+		v.UDF = nil
+		v.Sink = &dfv1.Sink{Log: &dfv1.Log{}}
+		err = validateVertex(v)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "only supported by the UDF vertices")
+	})
 }
@@ -0,0 +1,145 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	gotesting "testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// Eventually polls the condition until it's true, or fails the test with the last error, or the description if there
+// is no error, once the timeout of the harness is reached.
+func (h *Harness) Eventually(t gotesting.TB, description string, condition func(ctx context.Context) (bool, error)) {
+	t.Helper()
+	var lastErr error
+	err := wait.PollImmediate(h.opts.interval, h.opts.timeout, func() (bool, error) {
+		ok, err := condition(context.Background())
+		lastErr = err
+		return ok, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			t.Fatalf("%s: %v", description, lastErr)
+		}
+		t.Fatalf("%s: not satisfied in %v", description, h.opts.timeout)
+	}
+}
+
+// EventuallyISBServiceReady waits for the InterStepBufferService to be ready, and returns it.
+func (h *Harness) EventuallyISBServiceReady(t gotesting.TB, namespace, name string) *dfv1.InterStepBufferService {
+	t.Helper()
+	isbSvc := &dfv1.InterStepBufferService{}
+	h.Eventually(t, fmt.Sprintf("InterStepBufferService %s/%s is ready", namespace, name), func(ctx context.Context) (bool, error) {
+		if err := h.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, isbSvc); err != nil {
+			return false, err
+		}
+		if !isbSvc.Status.IsReady() {
+			return false, fmt.Errorf("phase %q, message %q", isbSvc.Status.Phase, isbSvc.Status.Message)
+		}
+		return true, nil
+	})
+	return isbSvc
+}
+
+// EventuallyPipelinePhase waits for the Pipeline to be in the phase, and returns it.
+func (h *Harness) EventuallyPipelinePhase(t gotesting.TB, namespace, name string, phase dfv1.PipelinePhase) *dfv1.Pipeline {
+	t.Helper()
+	pl := &dfv1.Pipeline{}
+	h.Eventually(t, fmt.Sprintf("Pipeline %s/%s is %s", namespace, name, phase), func(ctx context.Context) (bool, error) {
+		if err := h.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pl); err != nil {
+			return false, err
+		}
+		if pl.Status.Phase != phase {
+			return false, fmt.Errorf("phase %q, message %q", pl.Status.Phase, pl.Status.Message)
+		}
+		return true, nil
+	})
+	return pl
+}
+
+// EventuallyPipelineRunning waits for the Pipeline to be running, and returns it.
+func (h *Harness) EventuallyPipelineRunning(t gotesting.TB, namespace, name string) *dfv1.Pipeline {
+	t.Helper()
+	return h.EventuallyPipelinePhase(t, namespace, name, dfv1.PipelinePhaseRunning)
+}
+
+// EventuallyVertices waits for the Pipeline to have the number of Vertices, and returns them.
+func (h *Harness) EventuallyVertices(t gotesting.TB, namespace, pipeline string, n int) []dfv1.Vertex {
+	t.Helper()
+	vertices := &dfv1.VertexList{}
+	h.Eventually(t, fmt.Sprintf("Pipeline %s/%s has %d vertices", namespace, pipeline, n), func(ctx context.Context) (bool, error) {
+		if err := h.Client.List(ctx, vertices, client.InNamespace(namespace), client.MatchingLabels{dfv1.KeyPipelineName: pipeline}); err != nil {
+			return false, err
+		}
+		if len(vertices.Items) != n {
+			return false, fmt.Errorf("%d vertices found", len(vertices.Items))
+		}
+		return true, nil
+	})
+	return vertices.Items
+}
+
+// BufferJobSucceeded waits for the buffer Job of the Pipeline, and marks it succeeded, as the Jobs are not run by the
+// control plane. The type of the Job is one of "create", "delete", "migrate" and "cleanup". It returns the Job.
+func (h *Harness) BufferJobSucceeded(t gotesting.TB, namespace, pipeline, jobType string) *batchv1.Job {
+	t.Helper()
+	prefix := fmt.Sprintf("%s-buffer-%s-", pipeline, jobType)
+	job := &batchv1.Job{}
+	h.Eventually(t, fmt.Sprintf("buffer %s job of Pipeline %s/%s succeeded", jobType, namespace, pipeline), func(ctx context.Context) (bool, error) {
+		jobs := &batchv1.JobList{}
+		if err := h.Client.List(ctx, jobs, client.InNamespace(namespace), client.MatchingLabels{dfv1.KeyPipelineName: pipeline}); err != nil {
+			return false, err
+		}
+		for _, j := range jobs.Items {
+			if !strings.HasPrefix(j.Name, prefix) {
+				continue
+			}
+			*job = j
+			if job.Status.Succeeded > 0 {
+				return true, nil
+			}
+			now := metav1.Now()
+			job.Status.StartTime = &now
+			job.Status.CompletionTime = &now
+			job.Status.Succeeded = 1
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+				Type:               batchv1.JobComplete,
+				Status:             corev1.ConditionTrue,
+				LastProbeTime:      now,
+				LastTransitionTime: now,
+			})
+			if err := h.Client.Status().Update(ctx, job); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		return false, fmt.Errorf("no job named %s* found", prefix)
+	})
+	return job
+}
+
+// EventuallyDeleted waits for the object to be gone. The finalizers of the object need to be removed by the
+// reconcilers, while the objects owned by it are not garbage collected by the control plane.
+func (h *Harness) EventuallyDeleted(t gotesting.TB, obj client.Object) {
+	t.Helper()
+	h.Eventually(t, fmt.Sprintf("%s/%s is deleted", obj.GetNamespace(), obj.GetName()), func(ctx context.Context) (bool, error) {
+		err := h.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("finalizers %v", obj.GetFinalizers())
+	})
+}
@@ -0,0 +1,237 @@
+/*
+Package testing is the test harness of the controllers. It starts a local control plane with envtest, installs the CRDs,
+and runs all the reconcilers against it, so that the interactions of the controllers can be tested beyond the fake
+client unit tests, e.g. a pipeline waiting for its InterStepBufferService to be ready.
+
+The control plane only runs etcd and kube-apiserver, there is no kube-controller-manager or kubelet. The Pods are
+never scheduled, the Jobs are never run, and the objects owned by a deleted object are not garbage collected. The
+helpers like BufferJobSucceeded simulate the missing parts where the reconcilers depend on them.
+
+The envtest binaries are required, e.g. installed by setup-envtest, with KUBEBUILDER_ASSETS set to their directory.
+*/
+package testing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"time"
+
+	"go.uber.org/zap"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/numaproj/numaflow/config"
+	"github.com/numaproj/numaflow/controllers"
+	ctrlcmd "github.com/numaproj/numaflow/controllers/cmd"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	// DefaultImage is the numaflow image of the controllers, which is never pulled
+	DefaultImage = "quay.io/numaproj/numaflow:test"
+	// DefaultTimeout is the default time the helpers wait for the objects to reach the expected states
+	DefaultTimeout = 30 * time.Second
+	// DefaultInterval is the default interval the helpers poll the objects
+	DefaultInterval = 200 * time.Millisecond
+)
+
+// Harness is a local control plane with all the reconcilers running against it.
+type Harness struct {
+	// Client is the client of the control plane, which reads from the API server directly
+	Client client.Client
+	// Config is the rest config of the control plane, e.g. to build other clients
+	Config *rest.Config
+
+	env    *envtest.Environment
+	cancel context.CancelFunc
+	done   chan error
+	opts   options
+}
+
+type options struct {
+	config           *controllers.GlobalConfig
+	image            string
+	crdDirectoryPath string
+	timeout          time.Duration
+	interval         time.Duration
+	logger           *zap.SugaredLogger
+}
+
+// Option is used to customize the harness
+type Option func(*options)
+
+// WithGlobalConfig sets the global config of the controllers, which defaults to a JetStream and a Redis version.
+func WithGlobalConfig(c *controllers.GlobalConfig) Option {
+	return func(o *options) {
+		o.config = c
+	}
+}
+
+// WithImage sets the numaflow image of the controllers.
+func WithImage(image string) Option {
+	return func(o *options) {
+		o.image = image
+	}
+}
+
+// WithCRDDirectoryPath sets the directory of the CRDs to install, e.g. the ones of a fork, the CRDs of this module are
+// installed by default.
+func WithCRDDirectoryPath(dir string) Option {
+	return func(o *options) {
+		o.crdDirectoryPath = dir
+	}
+}
+
+// WithTimeout sets the time the helpers wait for the objects to reach the expected states, and the interval they poll.
+func WithTimeout(timeout, interval time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+		o.interval = interval
+	}
+}
+
+// WithLogger sets the logger of the controllers.
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// DefaultGlobalConfig returns the global config of the controllers used by default, with a JetStream version "latest"
+// and a Redis version "latest".
+func DefaultGlobalConfig() *controllers.GlobalConfig {
+	return &controllers.GlobalConfig{
+		ISBSvc: &controllers.ISBSvcConfig{
+			Redis: &controllers.RedisConfig{
+				Versions: []controllers.RedisVersion{
+					{Version: "latest", RedisImage: "redis:test", SentinelImage: "redis-sentinel:test", RedisExporterImage: "redis-exporter:test", InitContainerImage: "busybox:test"},
+				},
+			},
+			JetStream: &controllers.JetStreamConfig{
+				Versions: []controllers.JetStreamVersion{
+					{Version: "latest", NatsImage: "nats:test", MetricsExporterImage: "nats-exporter:test", ConfigReloaderImage: "nats-reloader:test", StartCommand: "/nats-server"},
+				},
+			},
+		},
+	}
+}
+
+// Start starts the control plane, installs the CRDs, and runs all the reconcilers against it. The harness needs to
+// be stopped after use.
+func Start(opts ...Option) (*Harness, error) {
+	o := options{
+		image:    DefaultImage,
+		timeout:  DefaultTimeout,
+		interval: DefaultInterval,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.config == nil {
+		o.config = DefaultGlobalConfig()
+	}
+	if o.logger == nil {
+		o.logger = logging.NewLogger().Named("controller-harness")
+	}
+	env := &envtest.Environment{ErrorIfCRDPathMissing: true}
+	if o.crdDirectoryPath != "" {
+		env.CRDDirectoryPaths = []string{o.crdDirectoryPath}
+	} else {
+		crds, err := embeddedCRDs()
+		if err != nil {
+			return nil, err
+		}
+		env.CRDs = crds
+	}
+	restConfig, err := env.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the control plane, %w", err)
+	}
+	h := &Harness{Config: restConfig, env: env, opts: o, done: make(chan error, 1)}
+	if err := h.startManager(); err != nil {
+		_ = env.Stop()
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Harness) startManager() error {
+	s := scheme.Scheme
+	if err := dfv1.AddToScheme(s); err != nil {
+		return fmt.Errorf("failed to add the scheme, %w", err)
+	}
+	mgr, err := ctrl.NewManager(h.Config, ctrl.Options{
+		Scheme:                 s,
+		MetricsBindAddress:     "0",
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the manager, %w", err)
+	}
+	if err := ctrlcmd.SetupControllers(mgr, h.opts.config, h.opts.image, h.opts.logger); err != nil {
+		return err
+	}
+	// Read from the API server directly, so that the helpers see the latest states
+	c, err := client.New(h.Config, client.Options{Scheme: s})
+	if err != nil {
+		return fmt.Errorf("failed to create the client, %w", err)
+	}
+	h.Client = c
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go func() {
+		h.done <- mgr.Start(ctx)
+	}()
+	return nil
+}
+
+// Stop stops the reconcilers and the control plane.
+func (h *Harness) Stop() error {
+	h.cancel()
+	mgrErr := <-h.done
+	if err := h.env.Stop(); err != nil {
+		return fmt.Errorf("failed to stop the control plane, %w", err)
+	}
+	return mgrErr
+}
+
+// embeddedCRDs returns the CRDs of the installation manifests embedded in this module.
+func embeddedCRDs() ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	files, err := fs.Glob(config.Manifests, "base/crds/numaflow.numaproj.io_*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	for _, f := range files {
+		data, err := config.Manifests.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+		for {
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			if err := decoder.Decode(crd); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to decode %s, %w", path.Base(f), err)
+			}
+			if crd.Name != "" {
+				crds = append(crds, crd)
+			}
+		}
+	}
+	if len(crds) == 0 {
+		return nil, fmt.Errorf("no CRDs found in the manifests")
+	}
+	return crds, nil
+}
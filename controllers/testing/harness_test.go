@@ -0,0 +1,80 @@
+package testing
+
+import (
+	"context"
+	"os"
+	gotesting "testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+const testNamespace = "default"
+
+func Test_embeddedCRDs(t *gotesting.T) {
+	crds, err := embeddedCRDs()
+	assert.NoError(t, err)
+	var names []string
+	for _, crd := range crds {
+		names = append(names, crd.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"interstepbufferservices.numaflow.numaproj.io",
+		"pipelines.numaflow.numaproj.io",
+		"vertices.numaflow.numaproj.io",
+	}, names)
+}
+
+func TestHarness(t *gotesting.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+	h, err := Start(WithTimeout(time.Minute, DefaultInterval))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { assert.NoError(t, h.Stop()) }()
+	ctx := context.Background()
+
+	isbSvc := &dfv1.InterStepBufferService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: dfv1.DefaultISBSvcName},
+		Spec: dfv1.InterStepBufferServiceSpec{
+			JetStream: &dfv1.JetStreamBufferService{Version: "latest"},
+		},
+	}
+	assert.NoError(t, h.Client.Create(ctx, isbSvc))
+	isbSvc = h.EventuallyISBServiceReady(t, testNamespace, isbSvc.Name)
+	assert.NotNil(t, isbSvc.Status.Config.JetStream)
+
+	pl := &dfv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "simple-pipeline"},
+		Spec: dfv1.PipelineSpec{
+			Vertices: []dfv1.AbstractVertex{
+				{Name: "in", Source: &dfv1.Source{Generator: &dfv1.GeneratorSource{}}},
+				{Name: "cat", UDF: &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}}},
+				{Name: "out", Sink: &dfv1.Sink{Log: &dfv1.Log{}}},
+			},
+			Edges: []dfv1.Edge{
+				{From: "in", To: "cat"},
+				{From: "cat", To: "out"},
+			},
+			Lifecycle: dfv1.Lifecycle{DeleteGracePeriodSeconds: 1},
+		},
+	}
+	assert.NoError(t, h.Client.Create(ctx, pl))
+	job := h.BufferJobSucceeded(t, testNamespace, pl.Name, "create")
+	assert.Equal(t, pl.Name, job.Labels[dfv1.KeyPipelineName])
+	h.EventuallyPipelineRunning(t, testNamespace, pl.Name)
+	vertices := h.EventuallyVertices(t, testNamespace, pl.Name, 3)
+	for _, v := range vertices {
+		assert.Equal(t, pl.Name, v.Spec.PipelineName)
+		assert.True(t, metav1.IsControlledBy(&v, pl))
+	}
+
+	// The pipeline is not drained as the daemon is never running, the buffers are deleted after the grace period
+	assert.NoError(t, h.Client.Delete(ctx, pl))
+	h.EventuallyDeleted(t, pl)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/numaproj/numaflow"
 	"github.com/numaproj/numaflow/controllers"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
@@ -47,10 +48,12 @@ func (r *vertexReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		r.logger.Errorw("Unable to get vertex", zap.Any("request", req), zap.Error(err))
 		return ctrl.Result{}, err
 	}
-	log := r.logger.With("namespace", vertex.Namespace).With("vertex", vertex.Name).With("pipeline", vertex.Spec.PipelineName)
+	log := r.logger.With("namespace", vertex.Namespace).With("vertex", vertex.Name).With("pipeline", vertex.Spec.PipelineName).With(logging.CorrelationIDKey, vertex.GetCorrelationID())
 	ctx = logging.WithLogger(ctx, log)
 	vertexCopy := vertex.DeepCopy()
+	start := time.Now()
 	result, err := r.reconcile(ctx, vertexCopy)
+	controllers.ObserveReconcile(dfv1.ControllerVertex, vertex.Namespace, vertex.Spec.PipelineName, start, err)
 	if err != nil {
 		log.Errorw("Reconcile error", zap.Error(err))
 	}
@@ -124,56 +127,96 @@ func (r *vertexReconciler) reconcile(ctx context.Context, vertex *dfv1.Vertex) (
 		vertex.Status.MarkPhaseFailed("FindExistingPodFailed", err.Error())
 		return ctrl.Result{}, err
 	}
+	vertex.Status.LastError = lastPodError(existingPods)
+	completed := isCompleted(vertex, existingPods)
+	// The pods of the replicas are replaced in rolling batches when the hash changes, an outdated pod is drained before
+	// it's replaced, and no more than maxUnavailable replicas are unavailable at a time.
+	replicaPods := make([][]corev1.Pod, desiredReplicas)
+	available := 0
 	for replica := 0; replica < desiredReplicas; replica++ {
 		podNamePrefix := fmt.Sprintf("%s-%d-", vertex.Name, replica)
-		needToCreate := true
 		for existingPodName, existingPod := range existingPods {
 			if strings.HasPrefix(existingPodName, podNamePrefix) {
-				if existingPod.GetAnnotations()[dfv1.KeyHash] == hash {
-					needToCreate = false
-					delete(existingPods, existingPodName)
-				}
+				replicaPods[replica] = append(replicaPods[replica], existingPod)
+			}
+		}
+		for _, p := range replicaPods[replica] {
+			if p.DeletionTimestamp.IsZero() && p.GetAnnotations()[dfv1.KeyDrain] == "" && isPodReady(&p) {
+				available++
 				break
 			}
 		}
-		if needToCreate {
-			labels := map[string]string{}
-			annotations := map[string]string{}
-			if x := vertex.Spec.Metadata; x != nil {
-				for k, v := range x.Annotations {
-					annotations[k] = v
-				}
-				for k, v := range x.Labels {
-					labels[k] = v
+	}
+	unavailable := desiredReplicas - available
+	maxUnavailable := vertex.Spec.UpdateStrategy.GetMaxUnavailable(desiredReplicas)
+	requeueAfter := time.Duration(0)
+	updatedReplicas := 0
+	for replica := 0; replica < desiredReplicas; replica++ {
+		var outdated *corev1.Pod
+		upToDate := false
+		for i := range replicaPods[replica] {
+			p := &replicaPods[replica][i]
+			if !p.DeletionTimestamp.IsZero() {
+				continue
+			}
+			// A pod marked to be drained when scaling down is replaced, if the vertex is scaled up again.
+			if p.GetAnnotations()[dfv1.KeyHash] == hash && p.GetAnnotations()[dfv1.KeyDrain] == "" {
+				upToDate = true
+				delete(existingPods, p.Name)
+				break
+			}
+			if outdated == nil {
+				outdated = p
+			}
+		}
+		if !upToDate && outdated != nil {
+			if isPodReady(outdated) && outdated.GetAnnotations()[dfv1.KeyDrain] == "" {
+				if unavailable >= maxUnavailable {
+					// Kept until another replica is available again, which is reconciled by the pod update.
+					delete(existingPods, outdated.Name)
+					continue
 				}
+				unavailable++
 			}
-			labels[dfv1.KeyPartOf] = dfv1.Project
-			labels[dfv1.KeyManagedBy] = dfv1.ControllerVertex
-			labels[dfv1.KeyComponent] = dfv1.ComponentVertex
-			labels[dfv1.KeyPipelineName] = vertex.Spec.PipelineName
-			labels[dfv1.KeyVertexName] = vertex.Spec.Name
-			annotations[dfv1.KeyHash] = hash
-			annotations[dfv1.KeyReplica] = strconv.Itoa(replica)
-			pod := &corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace:       vertex.Namespace,
-					Name:            podNamePrefix + sharedutil.RandomLowerCaseString(5),
-					Labels:          labels,
-					Annotations:     annotations,
-					OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vertex.GetObjectMeta(), dfv1.VertexGroupVersionKind)},
-				},
-				Spec: *podSpec,
+			wait, err := r.drainPod(ctx, outdated, vertex.Spec.Scale.GetDrainTimeout())
+			if err != nil {
+				log.Errorw("Failed to mark pod to be drained", zap.String("pod", outdated.Name), zap.Error(err))
+				vertex.Status.MarkPhaseFailed("DrainPodFailed", err.Error())
+				return ctrl.Result{}, err
 			}
-			pod.Spec.Hostname = fmt.Sprintf("%s-%d", vertex.Name, replica)
-			if err := r.client.Create(ctx, pod); err != nil {
-				log.Errorw("Failed to create pod", zap.String("pod", pod.Name), zap.Error(err))
+			if wait > 0 {
+				delete(existingPods, outdated.Name)
+				if requeueAfter == 0 || wait < requeueAfter {
+					requeueAfter = wait
+				}
+				continue
+			}
+		}
+		updatedReplicas++
+		if !upToDate {
+			if err := r.createPod(ctx, vertex, podSpec, hash, replica); err != nil {
+				log.Errorw("Failed to create pod", zap.Int("replica", replica), zap.Error(err))
 				vertex.Status.MarkPhaseFailed("CreatePodFailed", err.Error())
 				return ctrl.Result{}, err
 			}
-			log.Infow("Succeeded to create a pod", zap.String("pod", pod.Name))
 		}
 	}
+	vertex.Status.UpdatedReplicas = uint32(updatedReplicas)
 	for _, v := range existingPods {
+		if isScaledDown(&v, desiredReplicas) {
+			wait, err := r.drainPod(ctx, &v, vertex.Spec.Scale.GetDrainTimeout())
+			if err != nil {
+				log.Errorw("Failed to mark pod to be drained", zap.String("pod", v.Name), zap.Error(err))
+				vertex.Status.MarkPhaseFailed("DrainPodFailed", err.Error())
+				return ctrl.Result{}, err
+			}
+			if wait > 0 {
+				if requeueAfter == 0 || wait < requeueAfter {
+					requeueAfter = wait
+				}
+				continue
+			}
+		}
 		if err := r.client.Delete(ctx, &v); err != nil && !apierrors.IsNotFound(err) {
 			log.Errorw("Failed to delete pod", zap.String("pod", v.Name), zap.Error(err))
 			vertex.Status.MarkPhaseFailed("DelPodFailed", err.Error())
@@ -234,15 +277,177 @@ func (r *vertexReconciler) reconcile(ctx context.Context, vertex *dfv1.Vertex) (
 		}
 	}
 
-	vertex.Status.MarkPhaseRunning()
-	return ctrl.Result{}, nil
+	// The phase stays succeeded after the vertex is scaled down by the completed pipeline
+	if completed || (desiredReplicas == 0 && vertex.Status.Phase == dfv1.VertexPhaseSucceeded) {
+		vertex.Status.MarkPhaseSucceeded()
+	} else {
+		vertex.Status.MarkPhaseRunning()
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// isCompleted tells if all the replicas of a bounded source have reached the end, i.e. their pods succeeded.
+// createPod creates a pod of the replica of the vertex.
+func (r *vertexReconciler) createPod(ctx context.Context, vertex *dfv1.Vertex, podSpec *corev1.PodSpec, hash string, replica int) error {
+	podNamePrefix := fmt.Sprintf("%s-%d-", vertex.Name, replica)
+	labels := map[string]string{}
+	annotations := map[string]string{}
+	if x := vertex.Spec.Metadata; x != nil {
+		for k, v := range x.Annotations {
+			annotations[k] = v
+		}
+		for k, v := range x.Labels {
+			labels[k] = v
+		}
+	}
+	labels[dfv1.KeyPartOf] = dfv1.Project
+	labels[dfv1.KeyManagedBy] = dfv1.ControllerVertex
+	labels[dfv1.KeyComponent] = dfv1.ComponentVertex
+	labels[dfv1.KeyPipelineName] = vertex.Spec.PipelineName
+	labels[dfv1.KeyVertexName] = vertex.Spec.Name
+	for k, v := range vertex.Spec.PodSecurity.AppArmorAnnotations(podSpec, annotations) {
+		annotations[k] = v
+	}
+	annotations[dfv1.KeyHash] = hash
+	annotations[dfv1.KeyReplica] = strconv.Itoa(replica)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       vertex.Namespace,
+			Name:            podNamePrefix + sharedutil.RandomLowerCaseString(5),
+			Labels:          labels,
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vertex.GetObjectMeta(), dfv1.VertexGroupVersionKind)},
+		},
+		Spec: *podSpec,
+	}
+	pod.Spec.Hostname = fmt.Sprintf("%s-%d", vertex.Name, replica)
+	if err := r.client.Create(ctx, pod); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Infow("Succeeded to create a pod", zap.String("pod", pod.Name))
+	return nil
+}
+
+func isCompleted(vertex *dfv1.Vertex, pods map[string]corev1.Pod) bool {
+	desiredReplicas := vertex.Spec.GetReplicas()
+	if !vertex.IsASource() || !vertex.Spec.Source.IsBounded() || desiredReplicas == 0 {
+		return false
+	}
+	succeeded := map[string]bool{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded {
+			succeeded[pod.GetAnnotations()[dfv1.KeyReplica]] = true
+		}
+	}
+	for replica := 0; replica < desiredReplicas; replica++ {
+		if !succeeded[strconv.Itoa(replica)] {
+			return false
+		}
+	}
+	return true
+}
+
+// isScaledDown tells if the pod is a replica removed by scaling down, rather than an outdated one to be replaced.
+func isScaledDown(pod *corev1.Pod, desiredReplicas int) bool {
+	replica, err := strconv.Atoi(pod.GetAnnotations()[dfv1.KeyReplica])
+	return err == nil && replica >= desiredReplicas && pod.DeletionTimestamp.IsZero()
+}
+
+// drainPod marks the pod to be drained, the pod stops reading, finishes the in-flight messages, and turns not ready.
+// It returns how long to wait before checking the pod again, or 0 if the pod is drained or the drain timeout expires,
+// which means it can be deleted.
+func (r *vertexReconciler) drainPod(ctx context.Context, pod *corev1.Pod, timeout time.Duration) (time.Duration, error) {
+	log := logging.FromContext(ctx)
+	checkInterval := 5 * time.Second
+	markedAt, marked := pod.GetAnnotations()[dfv1.KeyDrain]
+	if !marked {
+		if !isPodReady(pod) { // nothing to drain
+			return 0, nil
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[dfv1.KeyDrain] = time.Now().UTC().Format(time.RFC3339)
+		if err := r.client.Patch(ctx, pod, patch); err != nil {
+			if apierrors.IsNotFound(err) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		log.Infow("Marked pod to be drained", zap.String("pod", pod.Name))
+		return checkInterval, nil
+	}
+	if !isPodReady(pod) {
+		log.Infow("Pod drained", zap.String("pod", pod.Name))
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, markedAt)
+	if err != nil {
+		return 0, nil
+	}
+	if left := time.Until(t.Add(timeout)); left > 0 {
+		if left < checkInterval {
+			return left, nil
+		}
+		return checkInterval, nil
+	}
+	log.Infow("Pod drain timed out", zap.String("pod", pod.Name), zap.Duration("timeout", timeout))
+	return 0, nil
+}
+
+// lastPodError returns the latest fatal error of the containers not recovered from it, e.g. in crash loop.
+func lastPodError(pods map[string]corev1.Pod) *dfv1.VertexError {
+	var result *dfv1.VertexError
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				continue
+			}
+			terminated := cs.State.Terminated
+			if terminated == nil {
+				terminated = cs.LastTerminationState.Terminated
+			}
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			if result != nil && !result.Time.Before(&terminated.FinishedAt) {
+				continue
+			}
+			result = &dfv1.VertexError{
+				Pod:       pod.Name,
+				Container: cs.Name,
+				Reason:    terminated.Reason,
+				Message:   strings.TrimSpace(terminated.Message),
+				ExitCode:  terminated.ExitCode,
+				Restarts:  cs.RestartCount,
+				Time:      terminated.FinishedAt,
+			}
+		}
+	}
+	return result
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
 
 func (r *vertexReconciler) buildPodSpec(vertex *dfv1.Vertex, pl *dfv1.Pipeline, isbSvcConfig dfv1.BufferServiceConfig) (*corev1.PodSpec, error) {
 	isbSvcType, envs := sharedutil.GetIsbSvcEnvVars(isbSvcConfig)
+	// The pods check if their version is compatible with the controller's, in case of a partial upgrade.
+	envs = append(envs,
+		corev1.EnvVar{Name: dfv1.EnvExpectedVersion, Value: numaflow.GetVersion().Version},
+		corev1.EnvVar{Name: dfv1.EnvVersionSkewPolicy, Value: string(r.config.GetVersionSkewPolicy())},
+	)
+	envs = append(envs, corev1.EnvVar{Name: dfv1.EnvCorrelationID, Value: pl.GetCorrelationID()})
 	podSpec, err := vertex.GetPodSpec(dfv1.GetVertexPodSpecReq{
 		ISBSvcType: isbSvcType,
-		Image:      r.image,
+		Image:      r.config.GetImage(r.image),
 		PullPolicy: corev1.PullPolicy(sharedutil.LookupEnvStringOr(dfv1.EnvImagePullPolicy, "")),
 		Env:        envs,
 	})
@@ -254,6 +459,20 @@ func (r *vertexReconciler) buildPodSpec(vertex *dfv1.Vertex, pl *dfv1.Pipeline,
 	vols, volMounts := sharedutil.VolumesFromSecretsAndConfigMaps(vertex)
 	podSpec.Volumes = append(podSpec.Volumes, vols...)
 	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, volMounts...)
+	// The side-inputs manager reads the ConfigMaps of the side inputs from the same mounts
+	for i := range podSpec.InitContainers {
+		if c := &podSpec.InitContainers[i]; c.Name == dfv1.CtrSideInputsInit {
+			c.VolumeMounts = append(c.VolumeMounts, volMounts...)
+		}
+	}
+	for i := range podSpec.Containers {
+		if c := &podSpec.Containers[i]; c.Name == dfv1.CtrSideInputs {
+			c.VolumeMounts = append(c.VolumeMounts, volMounts...)
+		}
+	}
+	isbSvcVols, isbSvcVolMounts := sharedutil.GetIsbSvcVolumes(isbSvcConfig)
+	podSpec.Volumes = append(podSpec.Volumes, isbSvcVols...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, isbSvcVolMounts...)
 
 	// Only source vertices need to check all the pipeline buffers
 	if vertex.IsASource() {
@@ -261,7 +480,7 @@ func (r *vertexReconciler) buildPodSpec(vertex *dfv1.Vertex, pl *dfv1.Pipeline,
 			podSpec.InitContainers[0].Args = append(podSpec.InitContainers[0].Args, "--buffers="+b)
 		}
 	} else {
-		for _, b := range vertex.GetFromBuffers() {
+		for _, b := range append(vertex.GetFromBuffers(), vertex.GetDeadLetterBuffers()...) {
 			podSpec.InitContainers[0].Args = append(podSpec.InitContainers[0].Args, "--buffers="+b)
 		}
 		for _, b := range vertex.GetToBuffers() {
@@ -292,7 +511,7 @@ func (r *vertexReconciler) buildPodSpec(vertex *dfv1.Vertex, pl *dfv1.Pipeline,
 			}
 			podSpec.Containers[0].Env = envs
 		}
-	} else if vertex.IsAnUDF() {
+	} else if vertex.IsAnUDF() && len(podSpec.Containers) > 1 {
 		// Add default UDF content-type to udf container
 		envs := []corev1.EnvVar{}
 		userDefined := false
@@ -317,9 +536,14 @@ func (r *vertexReconciler) buildPodSpec(vertex *dfv1.Vertex, pl *dfv1.Pipeline,
 
 	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
 		Name:  dfv1.EnvWatermarkOn,
-		Value: fmt.Sprintf("%t", pl.Spec.Watermark.Propagate),
+		Value: fmt.Sprintf("%t", pl.Spec.Watermark.Propagate && vertex.IsFeatureEnabled(dfv1.FeatureGateWatermark)),
 	})
 
+	// The fatal errors are reported with the termination messages, the last logs are used for the containers not writing them.
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+	}
+
 	return podSpec, nil
 }
 
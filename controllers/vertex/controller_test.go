@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/numaproj/numaflow/controllers"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
@@ -13,7 +14,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -168,6 +171,8 @@ func Test_BuildPodSpec(t *testing.T) {
 		assert.Contains(t, envNames, dfv1.EnvISBSvcSentinelMaster)
 		assert.Contains(t, envNames, dfv1.EnvISBSvcRedisUser)
 		assert.Contains(t, envNames, dfv1.EnvISBSvcRedisURL)
+		assert.Contains(t, envNames, dfv1.EnvExpectedVersion)
+		assert.Contains(t, envNames, dfv1.EnvVersionSkewPolicy)
 		for _, b := range testObj.GetToBuffers() {
 			assert.Contains(t, spec.InitContainers[0].Args, "--buffers="+b)
 		}
@@ -290,6 +295,34 @@ func Test_BuildPodSpec(t *testing.T) {
 			assert.Contains(t, spec.InitContainers[0].Args, "--buffers="+b)
 		}
 	})
+
+	t.Run("test udf with side inputs", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		r := &vertexReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testVertex.DeepCopy()
+		testObj.Spec.UDF = &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}}
+		testObj.Spec.SideInputs = []dfv1.SideInput{
+			{Name: "table", ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-cm"}, Key: "table"}},
+		}
+		spec, err := r.buildPodSpec(testObj, testPipeline, fakeIsbSvcConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(spec.InitContainers))
+		assert.Equal(t, 3, len(spec.Containers))
+		for _, c := range []corev1.Container{spec.InitContainers[1], spec.Containers[2]} {
+			mountPaths := []string{}
+			for _, m := range c.VolumeMounts {
+				mountPaths = append(mountPaths, m.MountPath)
+			}
+			assert.Contains(t, mountPaths, "/var/numaflow/config/my-cm")
+			assert.Contains(t, mountPaths, dfv1.PathSideInputs)
+		}
+	})
 }
 
 func Test_reconcile(t *testing.T) {
@@ -403,4 +436,224 @@ func Test_reconcile(t *testing.T) {
 		assert.True(t, strings.HasPrefix(pods.Items[0].Name, testVertexName+"-0-"))
 		assert.Equal(t, 2, len(pods.Items[0].Spec.Containers))
 	})
+	t.Run("test reconcile scale down", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+		testIsbSvc.Status.MarkConfigured()
+		testIsbSvc.Status.MarkDeployed()
+		err := cl.Create(ctx, testIsbSvc)
+		assert.Nil(t, err)
+		testPl := testPipeline.DeepCopy()
+		err = cl.Create(ctx, testPl)
+		assert.Nil(t, err)
+		r := &vertexReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testVertex.DeepCopy()
+		testObj.Spec.Sink = &dfv1.Sink{}
+		testObj.Spec.Replicas = pointer.Int32(2)
+		testObj.Spec.Scale = dfv1.Scale{Max: pointer.Int32(2)}
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		selector, _ := labels.Parse(dfv1.KeyPipelineName + "=" + testPipelineName + "," + dfv1.KeyVertexName + "=" + testVertexSpecName)
+		listPods := func() map[string]corev1.Pod {
+			pods := &corev1.PodList{}
+			err := r.client.List(ctx, pods, &client.ListOptions{Namespace: testNamespace, LabelSelector: selector})
+			assert.NoError(t, err)
+			result := map[string]corev1.Pod{}
+			for _, p := range pods.Items {
+				result[p.Annotations[dfv1.KeyReplica]] = p
+			}
+			return result
+		}
+		setReady := func(pod corev1.Pod, ready corev1.ConditionStatus) {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}
+			assert.NoError(t, r.client.Update(ctx, &pod))
+		}
+		pods := listPods()
+		assert.Equal(t, 2, len(pods))
+		setReady(pods["0"], corev1.ConditionTrue)
+		setReady(pods["1"], corev1.ConditionTrue)
+
+		testObj.Spec.Replicas = pointer.Int32(1)
+		result, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.True(t, result.RequeueAfter > 0)
+		pods = listPods()
+		assert.Equal(t, 2, len(pods))
+		assert.NotEmpty(t, pods["1"].Annotations[dfv1.KeyDrain])
+		assert.Empty(t, pods["0"].Annotations[dfv1.KeyDrain])
+
+		// still draining
+		result, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.True(t, result.RequeueAfter > 0)
+		assert.Equal(t, 2, len(listPods()))
+
+		// drained
+		setReady(pods["1"], corev1.ConditionFalse)
+		result, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), result.RequeueAfter)
+		pods = listPods()
+		assert.Equal(t, 1, len(pods))
+		assert.Contains(t, pods, "0")
+	})
+	t.Run("test reconcile rolling update", func(t *testing.T) {
+		cl := fake.NewClientBuilder().Build()
+		ctx := context.TODO()
+		testIsbSvc := testNativeRedisIsbSvc.DeepCopy()
+		testIsbSvc.Status.MarkConfigured()
+		testIsbSvc.Status.MarkDeployed()
+		err := cl.Create(ctx, testIsbSvc)
+		assert.Nil(t, err)
+		testPl := testPipeline.DeepCopy()
+		err = cl.Create(ctx, testPl)
+		assert.Nil(t, err)
+		r := &vertexReconciler{
+			client: cl,
+			scheme: scheme.Scheme,
+			config: fakeConfig,
+			image:  testFlowImage,
+			logger: zaptest.NewLogger(t).Sugar(),
+		}
+		testObj := testVertex.DeepCopy()
+		testObj.Spec.Sink = &dfv1.Sink{}
+		testObj.Spec.Replicas = pointer.Int32(4)
+		testObj.Spec.Scale = dfv1.Scale{Max: pointer.Int32(4)}
+		maxUnavailable := intstr.FromInt(1)
+		testObj.Spec.UpdateStrategy = &dfv1.UpdateStrategy{MaxUnavailable: &maxUnavailable}
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(4), testObj.Status.UpdatedReplicas)
+		selector, _ := labels.Parse(dfv1.KeyPipelineName + "=" + testPipelineName + "," + dfv1.KeyVertexName + "=" + testVertexSpecName)
+		listPods := func() map[string]corev1.Pod {
+			pods := &corev1.PodList{}
+			err := r.client.List(ctx, pods, &client.ListOptions{Namespace: testNamespace, LabelSelector: selector})
+			assert.NoError(t, err)
+			result := map[string]corev1.Pod{}
+			for _, p := range pods.Items {
+				result[p.Annotations[dfv1.KeyReplica]] = p
+			}
+			return result
+		}
+		setReady := func(pod corev1.Pod, ready corev1.ConditionStatus) {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}
+			assert.NoError(t, r.client.Update(ctx, &pod))
+		}
+		pods := listPods()
+		assert.Equal(t, 4, len(pods))
+		for replica, p := range pods {
+			if replica == "3" {
+				setReady(p, corev1.ConditionFalse)
+			} else {
+				setReady(p, corev1.ConditionTrue)
+			}
+		}
+
+		// the unavailable pod is replaced right away, which uses up the budget
+		testObj.Spec.ContainerTemplate = &dfv1.ContainerTemplate{Env: []corev1.EnvVar{{Name: "a", Value: "b"}}}
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), testObj.Status.UpdatedReplicas)
+		newPods := listPods()
+		assert.Equal(t, 4, len(newPods))
+		assert.NotEqual(t, pods["3"].Name, newPods["3"].Name)
+		for _, replica := range []string{"0", "1", "2"} {
+			assert.Equal(t, pods[replica].Name, newPods[replica].Name)
+			assert.Empty(t, newPods[replica].Annotations[dfv1.KeyDrain])
+		}
+
+		// one pod at a time is drained and replaced
+		setReady(newPods["3"], corev1.ConditionTrue)
+		result, err := r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.True(t, result.RequeueAfter > 0)
+		assert.Equal(t, uint32(1), testObj.Status.UpdatedReplicas)
+		pods = listPods()
+		assert.NotEmpty(t, pods["0"].Annotations[dfv1.KeyDrain])
+		assert.Empty(t, pods["1"].Annotations[dfv1.KeyDrain])
+		assert.Empty(t, pods["2"].Annotations[dfv1.KeyDrain])
+
+		setReady(pods["0"], corev1.ConditionFalse)
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(2), testObj.Status.UpdatedReplicas)
+		newPods = listPods()
+		assert.Equal(t, 4, len(newPods))
+		assert.NotEqual(t, pods["0"].Name, newPods["0"].Name)
+		assert.Empty(t, newPods["1"].Annotations[dfv1.KeyDrain])
+
+		setReady(newPods["0"], corev1.ConditionTrue)
+		_, err = r.reconcile(ctx, testObj)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, listPods()["1"].Annotations[dfv1.KeyDrain])
+	})
+}
+
+func Test_lastPodError(t *testing.T) {
+	now := time.Now()
+	terminated := func(code int32, finishedAt time.Time, msg string) *corev1.ContainerStateTerminated {
+		return &corev1.ContainerStateTerminated{ExitCode: code, Reason: "Error", Message: msg, FinishedAt: metav1.NewTime(finishedAt)}
+	}
+	pods := map[string]corev1.Pod{
+		"p-0": {ObjectMeta: metav1.ObjectMeta{Name: "p-0"}, Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: dfv1.CtrMain, Ready: true},
+			{Name: dfv1.CtrUdf, RestartCount: 3, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: terminated(1, now.Add(-time.Minute), "panic\n")}},
+		}}},
+		"p-1": {ObjectMeta: metav1.ObjectMeta{Name: "p-1"}, Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: dfv1.CtrMain, State: corev1.ContainerState{Terminated: terminated(2, now, "auth failed")}},
+		}}},
+		"p-2": {ObjectMeta: metav1.ObjectMeta{Name: "p-2"}, Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			// recovered
+			{Name: dfv1.CtrMain, Ready: true, LastTerminationState: corev1.ContainerState{Terminated: terminated(1, now.Add(time.Minute), "")}},
+		}}},
+	}
+	e := lastPodError(pods)
+	assert.NotNil(t, e)
+	assert.Equal(t, "p-1", e.Pod)
+	assert.Equal(t, dfv1.CtrMain, e.Container)
+	assert.Equal(t, int32(2), e.ExitCode)
+	assert.Equal(t, "auth failed", e.Message)
+
+	delete(pods, "p-1")
+	e = lastPodError(pods)
+	assert.NotNil(t, e)
+	assert.Equal(t, dfv1.CtrUdf, e.Container)
+	assert.Equal(t, int32(3), e.Restarts)
+	assert.Equal(t, "panic", e.Message)
+
+	delete(pods, "p-0")
+	assert.Nil(t, lastPodError(pods))
+}
+
+func Test_isCompleted(t *testing.T) {
+	testObj := testVertex.DeepCopy()
+	testObj.Spec.Replicas = pointer.Int32(2)
+	pod := func(replica string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{dfv1.KeyReplica: replica}}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	pods := map[string]corev1.Pod{
+		"p-0": pod("0", corev1.PodSucceeded),
+		"p-1": pod("1", corev1.PodSucceeded),
+	}
+	// not a source
+	assert.False(t, isCompleted(testObj, pods))
+	testObj.Spec.Source = &dfv1.Source{Generator: &dfv1.GeneratorSource{}}
+	// not bounded
+	assert.False(t, isCompleted(testObj, pods))
+	testObj.Spec.Source.Generator.MaxMessages = pointer.Int64(100)
+	assert.True(t, isCompleted(testObj, pods))
+	pods["p-1"] = pod("1", corev1.PodRunning)
+	assert.False(t, isCompleted(testObj, pods))
+	delete(pods, "p-1")
+	assert.False(t, isCompleted(testObj, pods))
+	testObj.Spec.Replicas = pointer.Int32(0)
+	assert.False(t, isCompleted(testObj, pods))
 }
@@ -0,0 +1,170 @@
+/*
+Package scaling scales the vertices automatically between scale.min and scale.max, based on the usage of the buffers
+they read from, which is queried from the daemon server of the pipeline.
+*/
+package scaling
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	daemonclient "github.com/numaproj/numaflow/pkg/client/daemon"
+)
+
+const (
+	// defaultInterval is the interval of checking the buffer usage
+	defaultInterval = 30 * time.Second
+	// tolerance is the relative difference between the buffer usage and the target, within which no scaling happens
+	tolerance = 0.1
+)
+
+// bufferLister lists the buffers of a pipeline
+type bufferLister func(ctx context.Context, pl *dfv1.Pipeline) ([]*daemonpb.BufferInfo, error)
+
+// Scaler periodically scales the vertices with auto scaling enabled.
+type Scaler struct {
+	client   client.Client
+	interval time.Duration
+	logger   *zap.SugaredLogger
+	// listBuffers is replaceable for testing
+	listBuffers bufferLister
+}
+
+func NewScaler(client client.Client, logger *zap.SugaredLogger) *Scaler {
+	return &Scaler{
+		client:      client,
+		interval:    defaultInterval,
+		logger:      logger.Named("scaler"),
+		listBuffers: listBuffersFromDaemon,
+	}
+}
+
+// Start runs the scaler until the context is cancelled, it implements manager.Runnable.
+func (s *Scaler) Start(ctx context.Context) error {
+	s.logger.Infow("Starting vertex auto scaler", zap.Duration("interval", s.interval))
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.scaleAll(ctx); err != nil {
+				s.logger.Errorw("Failed to auto scale vertices", zap.Error(err))
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes the scaler only run in the leader.
+func (s *Scaler) NeedLeaderElection() bool {
+	return true
+}
+
+// scaleAll scales the vertices with auto scaling enabled, grouped by pipeline to query the buffers once per pipeline.
+func (s *Scaler) scaleAll(ctx context.Context) error {
+	vertices := &dfv1.VertexList{}
+	if err := s.client.List(ctx, vertices); err != nil {
+		return err
+	}
+	pipelines := make(map[types.NamespacedName][]dfv1.Vertex)
+	for _, v := range vertices.Items {
+		if !v.DeletionTimestamp.IsZero() || !v.Spec.Scale.IsAutoScalingEnabled() || v.IsASource() {
+			continue
+		}
+		key := types.NamespacedName{Namespace: v.Namespace, Name: v.Spec.PipelineName}
+		pipelines[key] = append(pipelines[key], v)
+	}
+	for key, vs := range pipelines {
+		log := s.logger.With("namespace", key.Namespace).With("pipeline", key.Name)
+		pl := &dfv1.Pipeline{}
+		if err := s.client.Get(ctx, key, pl); err != nil {
+			log.Errorw("Failed to get pipeline", zap.Error(err))
+			continue
+		}
+		if pl.Status.Phase != dfv1.PipelinePhaseRunning {
+			continue
+		}
+		buffers, err := s.listBuffers(ctx, pl)
+		if err != nil {
+			log.Errorw("Failed to list buffers", zap.Error(err))
+			continue
+		}
+		for i := range vs {
+			if err := s.scale(ctx, &vs[i], buffers); err != nil {
+				log.Errorw("Failed to scale vertex", zap.String("vertex", vs[i].Name), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+// scale patches the replicas of the vertex if they need to be changed.
+func (s *Scaler) scale(ctx context.Context, vertex *dfv1.Vertex, buffers []*daemonpb.BufferInfo) error {
+	if time.Since(vertex.Status.LastScaledAt.Time) < vertex.Spec.Scale.GetCooldown() {
+		return nil
+	}
+	current := int32(vertex.Spec.GetReplicas())
+	desired := desiredReplicas(vertex, bufferUsage(vertex, buffers))
+	if desired == current {
+		return nil
+	}
+	body, err := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"replicas": desired}})
+	if err != nil {
+		return err
+	}
+	if err := s.client.Patch(ctx, vertex, client.RawPatch(types.MergePatchType, body)); err != nil {
+		return err
+	}
+	s.logger.Infow("Auto scaled vertex", zap.String("namespace", vertex.Namespace), zap.String("vertex", vertex.Name), zap.Int32("from", current), zap.Int32("to", desired))
+	return nil
+}
+
+// bufferUsage returns the highest usage of the buffers the vertex reads from.
+func bufferUsage(vertex *dfv1.Vertex, buffers []*daemonpb.BufferInfo) float64 {
+	usage := 0.0
+	for _, b := range buffers {
+		if b.GetToVertex() == vertex.Spec.Name && b.GetBufferUsage() > usage {
+			usage = b.GetBufferUsage()
+		}
+	}
+	return usage
+}
+
+// desiredReplicas calculates the replicas keeping the buffer usage around the target, within scale.min and scale.max.
+// A vertex scaled down to 0, e.g. when the pipeline is paused, is left alone.
+func desiredReplicas(vertex *dfv1.Vertex, usage float64) int32 {
+	current := int32(vertex.Spec.GetReplicas())
+	if current == 0 {
+		return 0
+	}
+	ratio := usage * 100 / float64(vertex.Spec.Scale.GetTargetBufferUsage())
+	desired := current
+	if math.Abs(ratio-1) > tolerance {
+		desired = int32(math.Ceil(float64(current) * ratio))
+	}
+	if min := vertex.Spec.Scale.GetMinReplicas(); desired < min {
+		desired = min
+	}
+	if max := vertex.Spec.Scale.GetMaxReplicas(); desired > max {
+		desired = max
+	}
+	return desired
+}
+
+func listBuffersFromDaemon(ctx context.Context, pl *dfv1.Pipeline) ([]*daemonpb.BufferInfo, error) {
+	c, err := daemonclient.NewDaemonServiceClient(pl.GetDaemonServiceURL())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.Close() }()
+	return c.ListPipelineBuffers(ctx, pl.Name)
+}
@@ -0,0 +1,100 @@
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+)
+
+const testNamespace = "test-ns"
+
+func testVertex(name string, replicas, min, max int32) *dfv1.Vertex {
+	return &dfv1.Vertex{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-pl-" + name},
+		Spec: dfv1.VertexSpec{
+			AbstractVertex: dfv1.AbstractVertex{
+				Name:  name,
+				UDF:   &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}},
+				Scale: dfv1.Scale{Min: pointer.Int32(min), Max: pointer.Int32(max)},
+			},
+			PipelineName: "test-pl",
+			Replicas:     pointer.Int32(replicas),
+		},
+	}
+}
+
+func testBuffer(to string, usage float64) *daemonpb.BufferInfo {
+	return &daemonpb.BufferInfo{ToVertex: pointer.String(to), BufferUsage: pointer.Float64(usage)}
+}
+
+func Test_desiredReplicas(t *testing.T) {
+	v := testVertex("p1", 2, 1, 5)
+	assert.Equal(t, int32(2), desiredReplicas(v, 0.5))
+	assert.Equal(t, int32(2), desiredReplicas(v, 0.53))
+	assert.Equal(t, int32(4), desiredReplicas(v, 0.9))
+	assert.Equal(t, int32(1), desiredReplicas(v, 0.1))
+	assert.Equal(t, int32(1), desiredReplicas(v, 0))
+	target := uint32(20)
+	v.Spec.Scale.TargetBufferUsage = &target
+	assert.Equal(t, int32(3), desiredReplicas(v, 0.3))
+	v.Spec.Replicas = pointer.Int32(4)
+	assert.Equal(t, int32(5), desiredReplicas(v, 1))
+	v.Spec.Replicas = pointer.Int32(0)
+	assert.Equal(t, int32(0), desiredReplicas(v, 0.9))
+}
+
+func Test_bufferUsage(t *testing.T) {
+	v := testVertex("p1", 2, 1, 5)
+	assert.Equal(t, 0.0, bufferUsage(v, nil))
+	assert.Equal(t, 0.7, bufferUsage(v, []*daemonpb.BufferInfo{testBuffer("p1", 0.3), testBuffer("p1", 0.7), testBuffer("output", 0.9)}))
+}
+
+func TestScaler_scaleAll(t *testing.T) {
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	assert.NoError(t, dfv1.AddToScheme(scheme))
+	pl := &dfv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "test-pl"},
+		Status:     dfv1.PipelineStatus{Phase: dfv1.PipelinePhaseRunning},
+	}
+	busy := testVertex("busy", 2, 1, 5)
+	idle := testVertex("idle", 3, 1, 5)
+	cooling := testVertex("cooling", 2, 1, 5)
+	cooling.Status.LastScaledAt = metav1.Now()
+	fixed := testVertex("fixed", 2, 2, 2)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pl, busy, idle, cooling, fixed).Build()
+	s := NewScaler(cl, zaptest.NewLogger(t).Sugar())
+	s.listBuffers = func(ctx context.Context, pl *dfv1.Pipeline) ([]*daemonpb.BufferInfo, error) {
+		return []*daemonpb.BufferInfo{testBuffer("busy", 1), testBuffer("idle", 0), testBuffer("cooling", 1), testBuffer("fixed", 1)}, nil
+	}
+	assert.NoError(t, s.scaleAll(ctx))
+	replicas := func(v *dfv1.Vertex) int {
+		got := &dfv1.Vertex{}
+		assert.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: v.Name}, got))
+		return got.Spec.GetReplicas()
+	}
+	assert.Equal(t, 4, replicas(busy))
+	assert.Equal(t, 1, replicas(idle))
+	assert.Equal(t, 2, replicas(cooling))
+	assert.Equal(t, 2, replicas(fixed))
+
+	t.Run("pipeline not running", func(t *testing.T) {
+		pl.Status.Phase = dfv1.PipelinePhasePaused
+		assert.NoError(t, cl.Update(ctx, pl))
+		cooling.Status.LastScaledAt = metav1.NewTime(time.Now().Add(-time.Hour))
+		assert.NoError(t, cl.Update(ctx, cooling))
+		assert.NoError(t, s.scaleAll(ctx))
+		assert.Equal(t, 2, replicas(cooling))
+	})
+}
@@ -60,9 +60,10 @@ gen-protoc(){
       -I ./vendor \
       -I ${GOPATH}/src \
       -I ./vendor/github.com/gogo/protobuf/gogoproto \
-      --gogofast_out=plugins=grpc:${GOPATH}/src \
+      --gogofast_out=plugins=grpc,Mgoogle/protobuf/timestamp.proto=github.com/gogo/protobuf/types:${GOPATH}/src \
       --grpc-gateway_out=logtostderr=true:${GOPATH}/src \
       $@
 }
 
 gen-protoc pkg/apis/proto/daemon/daemon.proto
+gen-protoc pkg/apis/proto/function/function.proto
@@ -22,9 +22,30 @@ const (
 	KeyPipelineName = "numaflow.numaproj.io/pipeline-name"
 	KeyVertexName   = "numaflow.numaproj.io/vertex-name"
 	KeyReplica      = "numaflow.numaproj.io/replica"
+	KeyDrain        = "numaflow.numaproj.io/drain" // time the pod is marked to be drained before deletion
+
+	// vertex annotation key of the replicas before the pipeline is paused, they are restored on resume.
+	KeyPausedReplicas = "numaflow.numaproj.io/paused-replicas"
+
+	// pipeline annotation key of the renamed vertices, JSON of the new names to the old names, their buffers are migrated.
+	KeyRenamedVertices = "numaflow.numaproj.io/renamed-vertices"
+	// pipeline annotation key of the hash of the confirmed pending changes, see spec.lifecycle.confirmDisruptiveChanges.
+	KeyConfirmedChanges = "numaflow.numaproj.io/confirmed-changes"
+	// pipeline annotation key of the comma separated field managers allowed to change the spec, the reconciliation is
+	// paused once any other field manager changes the spec.
+	KeyAllowedFieldManagers = "numaflow.numaproj.io/allowed-field-managers"
+	// pipeline annotation key of the ID of the last lifecycle operation, e.g. pausing or resuming, it's set to
+	// status.observedOperationID once the operation finishes.
+	KeyOperationID = "numaflow.numaproj.io/operation-id"
+
+	// namespace annotation keys of the pipeline defaults.
+	KeyDefaultISBSvcName = "numaflow.numaproj.io/default-isbsvc-name"
+	KeyDefaultLimits     = "numaflow.numaproj.io/default-limits" // JSON of the pipeline limits
 
 	// ID key in the header of sources like http
 	KeyMetaID = "x-numaflow-id"
+	// prefix of the headers of sources like http and kafka, which are set to the message metadata without the prefix
+	KeyMetaMetadataPrefix = "x-numaflow-metadata-"
 
 	DefaultISBSvcName = "default"
 
@@ -42,6 +63,9 @@ const (
 	JetStreamClientAuthSecretUserKey     = "client-auth-user"     // key for client auth user secret
 	JetStreamClientAuthSecretPasswordKey = "client-auth-password" // key for client auth password secret
 	JetStreamConfigMapKey                = "nats-js"              // key for nats-js.conf in the configmap
+	CertManagerSecretPrivateKeyKey       = "tls.key"              // key for private key in the secrets of cert-manager
+	CertManagerSecretCertKey             = "tls.crt"              // key for TLS certificate in the secrets of cert-manager
+	CertManagerSecretCACertKey           = "ca.crt"               // key for CA certificate in the secrets of cert-manager
 
 	// container names.
 	CtrInit   = "init"
@@ -49,6 +73,9 @@ const (
 	CtrUdf    = "udf"
 	CtrUdsink = "udsink"
 
+	CtrSideInputs     = "side-inputs"
+	CtrSideInputsInit = "side-inputs-init"
+
 	// components
 	ComponentISBSvc = "isbsvc"
 	ComponentDaemon = "daemon"
@@ -67,38 +94,141 @@ const (
 	EnvPod                         = "NUMAFLOW_POD"
 	EnvReplica                     = "NUMAFLOW_REPLICA"
 	EnvVertexObject                = "NUMAFLOW_VERTEX_OBJECT"
+	EnvVertexObjectVersion         = "NUMAFLOW_VERTEX_OBJECT_VERSION"
 	EnvPipelineObject              = "NUMAFLOW_PIPELINE_OBJECT"
 	EnvImage                       = "NUMAFLOW_IMAGE"
 	EnvImagePullPolicy             = "NUMAFLOW_IMAGE_PULL_POLICY"
+	EnvExpectedVersion             = "NUMAFLOW_EXPECTED_VERSION"    // The version of the controller which created the pod
+	EnvVersionSkewPolicy           = "NUMAFLOW_VERSION_SKEW_POLICY" // What the pod does if its version is incompatible with the expected one
 	EnvUDFContentType              = "NUMAFLOW_UDF_CONTENT_TYPE"
 	EnvUDSinkContentType           = "NUMAFLOW_UDSINK_CONTENT_TYPE" // Content-Type for the user defined sinks
 	EnvISBSvcRedisSentinelURL      = "NUMAFLOW_ISBSVC_REDIS_SENTINEL_URL"
 	EnvISBSvcSentinelMaster        = "NUMAFLOW_ISBSVC_REDIS_SENTINEL_MASTER"
 	EnvISBSvcRedisURL              = "NUMAFLOW_ISBSVC_REDIS_URL"
+	EnvISBSvcRedisCluster          = "NUMAFLOW_ISBSVC_REDIS_CLUSTER"
 	EnvISBSvcRedisUser             = "NUMAFLOW_ISBSVC_REDIS_USER"
 	EnvISBSvcRedisPassword         = "NUMAFLOW_ISBSVC_REDIS_PASSWORD"
 	EnvISBSvcRedisSentinelPassword = "NUMAFLOW_ISBSVC_REDIS_SENTINEL_PASSWORD"
+	EnvISBSvcRedisPasswordFile     = "NUMAFLOW_ISBSVC_REDIS_PASSWORD_FILE"
+	EnvISBSvcRedisTLSEnabled       = "NUMAFLOW_ISBSVC_REDIS_TLS_ENABLED"
+	EnvISBSvcRedisTLSInsecure      = "NUMAFLOW_ISBSVC_REDIS_TLS_INSECURE_SKIP_VERIFY"
+	EnvISBSvcRedisTLSCACert        = "NUMAFLOW_ISBSVC_REDIS_TLS_CA_CERT"
+	EnvISBSvcRedisTLSCert          = "NUMAFLOW_ISBSVC_REDIS_TLS_CERT"
+	EnvISBSvcRedisTLSKey           = "NUMAFLOW_ISBSVC_REDIS_TLS_KEY"
 	EnvISBSvcJetStreamUser         = "NUMAFLOW_ISBSVC_JETSTREAM_USER"
 	EnvISBSvcJetStreamPassword     = "NUMAFLOW_ISBSVC_JETSTREAM_PASSWORD"
 	EnvISBSvcJetStreamURL          = "NUMAFLOW_ISBSVC_JETSTREAM_URL"
 	EnvISBSvcJetStreamTLSEnabled   = "NUMAFLOW_ISBSVC_JETSTREAM_TLS_ENABLED"
+	EnvISBSvcJetStreamTLSCACert    = "NUMAFLOW_ISBSVC_JETSTREAM_TLS_CA_CERT"
+	EnvISBSvcMirrorUser            = "NUMAFLOW_ISBSVC_JETSTREAM_MIRROR_USER"
+	EnvISBSvcMirrorPassword        = "NUMAFLOW_ISBSVC_JETSTREAM_MIRROR_PASSWORD"
+	EnvISBSvcMirrorTLSCACert       = "NUMAFLOW_ISBSVC_JETSTREAM_MIRROR_TLS_CA_CERT"
 	EnvISBSvcConfig                = "NUMAFLOW_ISBSVC_CONFIG"
+	EnvISBSvcKafkaBrokers          = "NUMAFLOW_ISBSVC_KAFKA_BROKERS"
+	EnvISBSvcKafkaConfig           = "NUMAFLOW_ISBSVC_KAFKA_CONFIG"
+	EnvISBSvcKafkaTLSEnabled       = "NUMAFLOW_ISBSVC_KAFKA_TLS_ENABLED"
+	EnvISBSvcKafkaTLSInsecure      = "NUMAFLOW_ISBSVC_KAFKA_TLS_INSECURE_SKIP_VERIFY"
+	EnvISBSvcKafkaTLSCACert        = "NUMAFLOW_ISBSVC_KAFKA_TLS_CA_CERT"
+	EnvISBSvcKafkaTLSCert          = "NUMAFLOW_ISBSVC_KAFKA_TLS_CERT"
+	EnvISBSvcKafkaTLSKey           = "NUMAFLOW_ISBSVC_KAFKA_TLS_KEY"
 	EnvDebug                       = "NUMAFLOW_DEBUG"
+	EnvCorrelationID               = "NUMAFLOW_CORRELATION_ID" // Added to the log lines of the pipeline components
 
 	// Watermark
 	EnvWatermarkOn = "NUMAFLOW_WATERMARK_ON"
 
-	PathVarRun        = "/var/run/numaflow"
-	VertexMetricsPort = 2469
-	VertexHTTPSPort   = 8443
-	DaemonServicePort = 4327
+	PathVarRun             = "/var/run/numaflow"
+	PathPodInfo            = "/var/numaflow/podinfo"
+	PathScratch            = "/var/numaflow/scratch"
+	PathSideInputs         = "/var/numaflow/side-inputs"
+	PathISBSvcRedisAuth    = "/var/numaflow/redis-auth"
+	PathTerminationMessage = "/dev/termination-log"
+	VertexMetricsPort      = 2469
+	VertexMetricsPortName  = "metrics"
+	VertexPreStopPort      = 2470
+	VertexPreStopPath      = "/prestop"
+	VertexTracesPath       = "/traces"
+	VertexLivenessPath     = "/healthz"
+	VertexHTTPSPort        = 8443
+	DaemonServicePort      = 4327
 
 	DefaultRequeueAfter = 10 * time.Second
 
 	DefaultBufferLength     = 50000
 	DefaultBufferUsageLimit = 0.8
 
-	UDFApplierMessageKey = "x-numa-message-key" // The key in the UDF applier HTTP header used to pass the map-reduce key
+	// Built-in defaults of the pipeline limits
+	DefaultPipelineReadBatchSize    = 100
+	DefaultPipelineUDFWorkers       = 100
+	DefaultPipelineBufferMaxLength  = 10000
+	DefaultPipelineBufferUsageLimit = 80
+
+	DefaultHMACSignatureHeader = "X-Hub-Signature-256"
+	DefaultRequestReplyTimeout = 30 * time.Second
+	DefaultHTTPDedupWindow     = 5 * time.Minute
+
+	DefaultSlowStartDuration = 60 * time.Second
+	DefaultUDFWarmUpTimeout  = 60 * time.Second
+	DefaultDrainTimeout      = 3 * time.Minute
+	DefaultTargetBufferUsage = 50
+	DefaultScaleCooldown     = 90 * time.Second
+	DefaultMaxUnavailable    = "25%"
+
+	// VertexLivenessProbePeriodSeconds is the period of the liveness probe of the vertex pods
+	VertexLivenessProbePeriodSeconds = 3
+	// DefaultLivenessStuckPeriods is the number of the liveness probe periods without any progress, after which a
+	// forwarder is considered stuck
+	DefaultLivenessStuckPeriods = 60
+
+	DefaultDeadLetterQueueMaxRetries = 3
+
+	DefaultSideInputRefreshInterval = 60 * time.Second
+	DefaultSideInputHTTPTimeout     = 10 * time.Second
+
+	DefaultEdgeTracePayloadSizeLimit = 256
+	DefaultEdgeTraceMaxRecords       = 100
+
+	DefaultMetricsExportInterval = 30 * time.Second
+	// DefaultMetricsHistoryRetention is how long the daemon server keeps the history of the vertex metrics
+	DefaultMetricsHistoryRetention = 6 * time.Hour
+	// DefaultAuditRetention is how long the audit records of a pipeline are kept
+	DefaultAuditRetention = 24 * time.Hour
+
+	DefaultTracingSamplingPercentage = 100
+	DefaultTracingExportInterval     = 5 * time.Second
+
+	DefaultOpsgenieURL = "https://api.opsgenie.com"
+	DefaultSLAFor      = 5 * time.Minute
+	// DefaultSLACheckInterval is how often the daemon server checks the SLA of a pipeline
+	DefaultSLACheckInterval = 30 * time.Second
+
+	UDFApplierMessageKey         = "x-numa-message-key"      // The key in the UDF applier HTTP header used to pass the map-reduce key
+	UDFApplierMessageMetadataKey = "x-numa-message-metadata" // The key in the UDF applier HTTP header used to pass the JSON of the message metadata
+	UDFApplierMaxBatchSizeKey    = "x-numa-max-batch-size"   // The key in the UDF readiness response HTTP header used by the UDF to declare the max number of messages in a batch
+	UDFApplierStreamingKey       = "x-numa-streaming"        // The key in the UDF readiness response HTTP header used by the UDF to declare the outputs can be streamed
+	UDFApplierStreamErrorKey     = "X-Numa-Stream-Error"     // The key in the UDF streaming response HTTP trailer used to pass the error of the UDF
+
+	DeadLetterErrorKey   = "x-numa-dlq-error"   // The key in the metadata of a dead-lettered message for the last error processing it
+	DeadLetterVertexKey  = "x-numa-dlq-vertex"  // The key in the metadata of a dead-lettered message for the vertex failing to process it
+	DeadLetterRetriesKey = "x-numa-dlq-retries" // The key in the metadata of a dead-lettered message for the number of retries
+
+	ReplyCorrelationIDKey = "x-numa-reply-id" // The key in the metadata of a request-reply message for the correlation ID of the request
+	ReplyToKey            = "x-numa-reply-to" // The key in the metadata of a request-reply message for the reply buffer of the source replica
+
+	AuditIDKey = "x-numa-audit-id" // The key in the metadata of an audited message for the audit ID stamped by the source
+
+	TraceParentKey = "traceparent" // The key in the metadata of a traced message for its W3C trace context
+)
+
+// VersionSkewPolicy is what the vertex pods do if their numaflow version is incompatible with the controller's, e.g. in
+// the middle of an upgrade.
+type VersionSkewPolicy string
+
+const (
+	// VersionSkewPolicyFail refuses to run the pod, it's the default
+	VersionSkewPolicyFail VersionSkewPolicy = "fail"
+	// VersionSkewPolicyWarn runs the pod with a warning
+	VersionSkewPolicyWarn VersionSkewPolicy = "warn"
 )
 
 type ContentType string
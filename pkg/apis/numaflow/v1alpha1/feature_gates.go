@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Feature gates of the experimental subsystems, they could be turned on or off by the controller flag --feature-gates
+// for all the pipelines, and overridden by each pipeline's spec.featureGates.
+const (
+	// FeatureGateWatermark gates the watermark propagation, spec.watermark.propagate only takes effect when it's on.
+	FeatureGateWatermark = "Watermark"
+	// FeatureGateExactlyOnce decides if the messages written to the buffers are deduplicated, and the JetStream readers
+	// wait for their acks to be confirmed, for the edges without limits.exactlyOnce.
+	FeatureGateExactlyOnce = "ExactlyOnce"
+)
+
+// defaultFeatureGates are the known feature gates and their default values.
+var defaultFeatureGates = map[string]bool{
+	FeatureGateWatermark:   true,
+	FeatureGateExactlyOnce: true,
+}
+
+// IsKnownFeatureGate tells if the feature gate is known.
+func IsKnownFeatureGate(name string) bool {
+	_, ok := defaultFeatureGates[name]
+	return ok
+}
+
+// ParseFeatureGates parses the feature gates in the format of "Name1=true,Name2=false".
+func ParseFeatureGates(s string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid feature gate %q, expected the format of Name=true|false", kv)
+		}
+		name := strings.TrimSpace(parts[0])
+		if !IsKnownFeatureGate(name) {
+			return nil, fmt.Errorf("unknown feature gate %q", name)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of feature gate %q, %w", name, err)
+		}
+		result[name] = enabled
+	}
+	return result, nil
+}
+
+// ResolveFeatureGates returns all the known feature gates, with the defaults overridden by the controller settings,
+// then by the pipeline settings.
+func ResolveFeatureGates(controllerGates, pipelineGates map[string]bool) map[string]bool {
+	result := make(map[string]bool)
+	for k, v := range defaultFeatureGates {
+		result[k] = v
+	}
+	for _, gates := range []map[string]bool{controllerGates, pipelineGates} {
+		for k, v := range gates {
+			if IsKnownFeatureGate(k) {
+				result[k] = v
+			}
+		}
+	}
+	return result
+}
+
+// KnownFeatureGates returns the names of the known feature gates.
+func KnownFeatureGates() []string {
+	result := []string{}
+	for k := range defaultFeatureGates {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// IsFeatureEnabled tells if the feature gate is turned on for the vertex.
+func (v Vertex) IsFeatureEnabled(name string) bool {
+	if enabled, ok := v.Spec.FeatureGates[name]; ok {
+		return enabled
+	}
+	return defaultFeatureGates[name]
+}
@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeatureGates(t *testing.T) {
+	gates, err := ParseFeatureGates("")
+	assert.NoError(t, err)
+	assert.Empty(t, gates)
+	gates, err = ParseFeatureGates("ExactlyOnce=true, Watermark=false")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{FeatureGateExactlyOnce: true, FeatureGateWatermark: false}, gates)
+	_, err = ParseFeatureGates("ExactlyOnce")
+	assert.Error(t, err)
+	_, err = ParseFeatureGates("Abc=true")
+	assert.Error(t, err)
+	_, err = ParseFeatureGates("PartitionedEdges=true")
+	assert.Error(t, err)
+	_, err = ParseFeatureGates("ExactlyOnce=yes")
+	assert.Error(t, err)
+}
+
+func TestResolveFeatureGates(t *testing.T) {
+	gates := ResolveFeatureGates(nil, nil)
+	assert.Equal(t, defaultFeatureGates, gates)
+	gates = ResolveFeatureGates(map[string]bool{FeatureGateExactlyOnce: false, FeatureGateWatermark: false}, map[string]bool{FeatureGateWatermark: true, "abc": true})
+	assert.True(t, gates[FeatureGateWatermark])
+	assert.False(t, gates[FeatureGateExactlyOnce])
+	_, ok := gates["abc"]
+	assert.False(t, ok)
+}
+
+func TestKnownFeatureGates(t *testing.T) {
+	assert.Equal(t, []string{FeatureGateExactlyOnce, FeatureGateWatermark}, KnownFeatureGates())
+}
+
+func TestVertexIsFeatureEnabled(t *testing.T) {
+	v := Vertex{}
+	assert.True(t, v.IsFeatureEnabled(FeatureGateWatermark))
+	assert.True(t, v.IsFeatureEnabled(FeatureGateExactlyOnce))
+	v.Spec.FeatureGates = map[string]bool{FeatureGateWatermark: false, FeatureGateExactlyOnce: false}
+	assert.False(t, v.IsFeatureEnabled(FeatureGateWatermark))
+	assert.False(t, v.IsFeatureEnabled(FeatureGateExactlyOnce))
+	assert.False(t, v.IsFeatureEnabled("abc"))
+}
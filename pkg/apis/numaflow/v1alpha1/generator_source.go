@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,4 +17,123 @@ type GeneratorSource struct {
 	// +kubebuilder:default=8
 	// +optional
 	MsgSize *int32 `json:"msgSize,omitempty" protobuf:"bytes,3,opt,name=msgSize"`
+	// LoadProfile varies the number of the messages generated per duration over time, RPU being the base rate.
+	// The rate is steady if it's not specified.
+	// +optional
+	LoadProfile *LoadProfile `json:"loadProfile,omitempty" protobuf:"bytes,4,opt,name=loadProfile"`
+	// Keys sets the keys of the generated messages, the messages have no key if it's not specified.
+	// +optional
+	Keys *GeneratorKeys `json:"keys,omitempty" protobuf:"bytes,5,opt,name=keys"`
+	// MaxMessages is the number of the messages generated by each replica, after which the source reaches its end.
+	// It makes the source bounded, a pipeline whose sources are all bounded is completed once all the messages are
+	// written by the sinks. Unbounded if it's not specified.
+	// +optional
+	MaxMessages *int64 `json:"maxMessages,omitempty" protobuf:"varint,6,opt,name=maxMessages"`
+	// Template is a Go template of the payload, with the sprig functions and the fake data functions, e.g.
+	// {"id": "{{ uuidv4 }}", "name": "{{ fakeName }}", "Createdts": {{ .Createdts }}}. The "Createdts" field, in
+	// nanoseconds, is taken as the event time. MsgSize is ignored with a template.
+	// The payload is a JSON of random bytes of MsgSize and the creation time if it's not specified.
+	// +optional
+	Template *string `json:"template,omitempty" protobuf:"bytes,7,opt,name=template"`
+	// Jitter spreads the messages generated per duration over a random delay, instead of generating them all at the
+	// beginning of the duration.
+	// +optional
+	Jitter *GeneratorJitter `json:"jitter,omitempty" protobuf:"bytes,8,opt,name=jitter"`
+}
+
+type LoadProfileType string
+
+const (
+	// LoadProfileSteady generates RPU messages per duration.
+	LoadProfileSteady LoadProfileType = "steady"
+	// LoadProfileSpike generates PeakRPU messages per duration for SpikeDuration at the beginning of every period,
+	// and RPU messages per duration for the rest of the period.
+	LoadProfileSpike LoadProfileType = "spike"
+	// LoadProfileSine varies the rate from RPU to PeakRPU and back following a sine wave, one cycle per period.
+	LoadProfileSine LoadProfileType = "sine"
+	// LoadProfileRamp increases the rate linearly from RPU to PeakRPU over the period, and stays at PeakRPU after.
+	LoadProfileRamp LoadProfileType = "ramp"
+)
+
+const (
+	DefaultLoadProfilePeriod        = 10 * time.Minute
+	DefaultLoadProfileSinePeriod    = 24 * time.Hour
+	DefaultLoadProfileSpikeDuration = time.Minute
+)
+
+type LoadProfile struct {
+	// +kubebuilder:validation:Enum=steady;spike;sine;ramp
+	Type LoadProfileType `json:"type" protobuf:"bytes,1,opt,name=type,casttype=LoadProfileType"`
+	// PeakRPU is the number of the messages generated per duration at the peak of the profile.
+	// +optional
+	PeakRPU *int64 `json:"peakRpu,omitempty" protobuf:"varint,2,opt,name=peakRpu"`
+	// Period of the profile, defaults to 24h for the sine profile, and 10m for the others.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty" protobuf:"bytes,3,opt,name=period"`
+	// SpikeDuration is how long a spike lasts in the spike profile, defaults to 1m.
+	// +optional
+	SpikeDuration *metav1.Duration `json:"spikeDuration,omitempty" protobuf:"bytes,4,opt,name=spikeDuration"`
+}
+
+func (lp LoadProfile) GetPeriod() time.Duration {
+	if lp.Period != nil {
+		return lp.Period.Duration
+	}
+	if lp.Type == LoadProfileSine {
+		return DefaultLoadProfileSinePeriod
+	}
+	return DefaultLoadProfilePeriod
+}
+
+func (lp LoadProfile) GetSpikeDuration() time.Duration {
+	if lp.SpikeDuration != nil {
+		return lp.SpikeDuration.Duration
+	}
+	return DefaultLoadProfileSpikeDuration
+}
+
+type KeyDistribution string
+
+const (
+	// KeyDistributionUniform picks the keys with the same probability.
+	KeyDistributionUniform KeyDistribution = "uniform"
+	// KeyDistributionZipf picks the keys following the Zipf's law, a few keys being hot.
+	KeyDistributionZipf KeyDistribution = "zipf"
+)
+
+type GeneratorKeys struct {
+	// Count is the number of the distinct keys, i.e. the key cardinality. The keys are "key-0" to "key-<count-1>".
+	Count int32 `json:"count" protobuf:"varint,1,opt,name=count"`
+	// Distribution of the keys picked by the messages, defaults to uniform.
+	// +kubebuilder:validation:Enum=uniform;zipf
+	// +optional
+	Distribution KeyDistribution `json:"distribution,omitempty" protobuf:"bytes,2,opt,name=distribution,casttype=KeyDistribution"`
+}
+
+type JitterDistribution string
+
+const (
+	// JitterDistributionUniform delays the messages with the same probability between 0 and the max.
+	JitterDistributionUniform JitterDistribution = "uniform"
+	// JitterDistributionNormal delays most of the messages around the half of the max, following a normal distribution.
+	JitterDistributionNormal JitterDistribution = "normal"
+	// JitterDistributionExponential delays most of the messages shortly, following an exponential distribution, i.e.
+	// the messages arrive like a Poisson process.
+	JitterDistributionExponential JitterDistribution = "exponential"
+)
+
+type GeneratorJitter struct {
+	// Max is the max delay of a message from the beginning of the duration.
+	Max *metav1.Duration `json:"max" protobuf:"bytes,1,opt,name=max"`
+	// Distribution of the delays, defaults to uniform.
+	// +kubebuilder:validation:Enum=uniform;normal;exponential
+	// +optional
+	Distribution JitterDistribution `json:"distribution,omitempty" protobuf:"bytes,2,opt,name=distribution,casttype=JitterDistribution"`
+}
+
+func (gj GeneratorJitter) GetMax() time.Duration {
+	if gj.Max != nil {
+		return gj.Max.Duration
+	}
+	return 0
 }
@@ -57,6 +57,9 @@ type GetJetStreamStatefulSetSpecReq struct {
 	ConfigMapName              string            `protobuf:"bytes,12,opt,name=configMapName"`
 	PvcNameIfNeeded            string            `protobuf:"bytes,13,opt,name=pvcNameIfNeeded"`
 	StartCommand               string            `protobuf:"bytes,14,opt,name=startCommand"`
+	// TLSSecretName is the secret of the certificates issued by cert-manager, the certificates generated by the
+	// controller in the server auth secret are used if it's empty.
+	TLSSecretName string `protobuf:"bytes,15,opt,name=tlsSecretName"`
 }
 
 type GetJetStreamServiceSpecReq struct {
@@ -1,6 +1,11 @@
 package v1alpha1
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 type HTTPSource struct {
 	// +optional
@@ -8,6 +13,88 @@ type HTTPSource struct {
 	// Whether to create a ClusterIP Service
 	// +optional
 	Service bool `json:"service" protobuf:"bytes,2,opt,name=service"`
+	// Maximum number of requests per second admitted by each replica of the source, the requests exceeding the rate
+	// are rejected with 429 and a Retry-After header. Not limited if it's not specified.
+	// +optional
+	RateLimit *uint32 `json:"rateLimit,omitempty" protobuf:"varint,3,opt,name=rateLimit"`
+	// HMAC-SHA256 signature verification of the request payloads, the requests without a valid signature are rejected.
+	// +optional
+	Signature *HMACSignature `json:"signature,omitempty" protobuf:"bytes,4,opt,name=signature"`
+	// Synchronous request-reply mode, the requests are held until the result of the message is returned by a reply sink
+	// of the pipeline, or the timeout is reached.
+	// +optional
+	RequestReply *RequestReply `json:"requestReply,omitempty" protobuf:"bytes,5,opt,name=requestReply"`
+	// Sharding distributes the requests across the replicas by a header, so that the requests with the same header value
+	// are always ingested by the same replica, which drops the duplicates of the requests it has ingested. It's only
+	// supported by the JetStream and Redis Inter-Step Buffer Services, where the replicas register themselves.
+	// +optional
+	Sharding *HTTPSharding `json:"sharding,omitempty" protobuf:"bytes,6,opt,name=sharding"`
+}
+
+// HTTPSharding assigns the requests to the replicas by hashing the value of a header over the live replicas, the
+// replicas receiving the requests they don't own forward them to the owners.
+type HTTPSharding struct {
+	// The header whose value is the shard key of a request, defaults to "X-Numaflow-Id". The requests without the
+	// header are ingested by the replicas receiving them.
+	// +optional
+	Header string `json:"header,omitempty" protobuf:"bytes,1,opt,name=header"`
+	// How long the owner of a shard key remembers the IDs, i.e. the "X-Numaflow-Id" headers, of the requests it has
+	// ingested, the requests with an ID seen in the window are acknowledged without being ingested again. Defaults to 5m,
+	// 0 turns off the deduplication.
+	// +optional
+	DedupWindow *metav1.Duration `json:"dedupWindow,omitempty" protobuf:"bytes,2,opt,name=dedupWindow"`
+}
+
+func (s HTTPSharding) GetHeader() string {
+	if s.Header != "" {
+		return s.Header
+	}
+	return KeyMetaID
+}
+
+func (s HTTPSharding) GetDedupWindow() time.Duration {
+	if s.DedupWindow != nil {
+		return s.DedupWindow.Duration
+	}
+	return DefaultHTTPDedupWindow
+}
+
+// RequestReply makes the http source respond to each request with the payload returned by a reply sink of the pipeline.
+// Each replica of the source reads the replies from its own reply buffer, the number of the reply buffers is
+// determined by the max replicas of the source vertex.
+type RequestReply struct {
+	// How long to wait for the reply before responding with 504, defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,1,opt,name=timeout"`
+}
+
+func (rr RequestReply) GetTimeout() time.Duration {
+	if rr.Timeout != nil {
+		return rr.Timeout.Duration
+	}
+	return DefaultRequestReplyTimeout
+}
+
+// IsRequestReply tells if the http source is in request-reply mode.
+func (s *HTTPSource) IsRequestReply() bool {
+	return s != nil && s.RequestReply != nil
+}
+
+// HMACSignature is used to verify the HMAC-SHA256 signature of the request payload, which is computed with a shared
+// secret and sent by the client in a request header, e.g. "X-Hub-Signature-256: sha256=<hex encoded signature>".
+type HMACSignature struct {
+	// A secret selector which contains the key to compute the signature
+	Secret *corev1.SecretKeySelector `json:"secret" protobuf:"bytes,1,opt,name=secret"`
+	// The request header carrying the signature, defaults to "X-Hub-Signature-256"
+	// +optional
+	Header string `json:"header,omitempty" protobuf:"bytes,2,opt,name=header"`
+}
+
+func (s HMACSignature) GetHeader() string {
+	if s.Header != "" {
+		return s.Header
+	}
+	return DefaultHMACSignatureHeader
 }
 
 type Authorization struct {
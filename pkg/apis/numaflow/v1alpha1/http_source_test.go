@@ -0,0 +1,38 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHMACSignatureGetHeader(t *testing.T) {
+	s := HMACSignature{}
+	assert.Equal(t, DefaultHMACSignatureHeader, s.GetHeader())
+	s.Header = "X-Signature"
+	assert.Equal(t, "X-Signature", s.GetHeader())
+}
+
+func TestRequestReply(t *testing.T) {
+	var s *HTTPSource
+	assert.False(t, s.IsRequestReply())
+	s = &HTTPSource{}
+	assert.False(t, s.IsRequestReply())
+	s.RequestReply = &RequestReply{}
+	assert.True(t, s.IsRequestReply())
+	assert.Equal(t, DefaultRequestReplyTimeout, s.RequestReply.GetTimeout())
+	s.RequestReply.Timeout = &metav1.Duration{Duration: 5 * time.Second}
+	assert.Equal(t, 5*time.Second, s.RequestReply.GetTimeout())
+}
+
+func TestHTTPSharding(t *testing.T) {
+	s := HTTPSharding{}
+	assert.Equal(t, KeyMetaID, s.GetHeader())
+	assert.Equal(t, DefaultHTTPDedupWindow, s.GetDedupWindow())
+	s.Header = "X-Customer-Id"
+	s.DedupWindow = &metav1.Duration{}
+	assert.Equal(t, "X-Customer-Id", s.GetHeader())
+	assert.Equal(t, time.Duration(0), s.GetDedupWindow())
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,6 +44,7 @@ const (
 	ISBSvcTypeUnknown   ISBSvcType = ""
 	ISBSvcTypeRedis     ISBSvcType = "redis"
 	ISBSvcTypeJetStream ISBSvcType = "jetstream"
+	ISBSvcTypeKafka     ISBSvcType = "kafka"
 )
 
 // +genclient
@@ -50,6 +52,9 @@ const (
 // +kubebuilder:resource:shortName=isbsvc
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Streams",type=integer,JSONPath=`.status.usage.streams`
+// +kubebuilder:printcolumn:name="Storage",type=string,JSONPath=`.status.usage.storageUtilization`
+// +kubebuilder:printcolumn:name="Memory",type=string,JSONPath=`.status.usage.memoryUtilization`
 // +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +k8s:openapi-gen=true
@@ -74,11 +79,13 @@ type InterStepBufferServiceList struct {
 type InterStepBufferServiceSpec struct {
 	Redis     *RedisBuferService      `json:"redis,omitempty" protobuf:"bytes,1,opt,name=redis"`
 	JetStream *JetStreamBufferService `json:"jetstream,omitempty" protobuf:"bytes,2,opt,name=jetstream"`
+	Kafka     *KafkaConfig            `json:"kafka,omitempty" protobuf:"bytes,3,opt,name=kafka"`
 }
 
 type BufferServiceConfig struct {
 	Redis     *RedisConfig     `json:"redis,omitempty" protobuf:"bytes,1,opt,name=redis"`
 	JetStream *JetStreamConfig `json:"jetstream,omitempty" protobuf:"bytes,2,opt,name=jetstream"`
+	Kafka     *KafkaConfig     `json:"kafka,omitempty" protobuf:"bytes,3,opt,name=kafka"`
 }
 
 type InterStepBufferServiceStatus struct {
@@ -86,6 +93,37 @@ type InterStepBufferServiceStatus struct {
 	Phase   ISBSvcPhase         `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase,casttype=ISBSvcPhase"`
 	Message string              `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
 	Config  BufferServiceConfig `json:"config,omitempty" protobuf:"bytes,4,opt,name=config"`
+	// Usage is the latest resource usage of the service, only collected for JetStream.
+	// +optional
+	Usage *BufferServiceUsage `json:"usage,omitempty" protobuf:"bytes,5,opt,name=usage"`
+}
+
+// BufferServiceUsage is the resource usage of an Inter-Step Buffer Service, summed up from all of its servers.
+type BufferServiceUsage struct {
+	// Streams is the number of the streams in the service.
+	Streams int32 `json:"streams" protobuf:"varint,1,opt,name=streams"`
+	// UsedStorage is the file storage used by the streams.
+	UsedStorage apiresource.Quantity `json:"usedStorage" protobuf:"bytes,2,opt,name=usedStorage"`
+	// AvailableStorage is the file storage not yet used, not set if the servers do not have a storage limit.
+	// +optional
+	AvailableStorage *apiresource.Quantity `json:"availableStorage,omitempty" protobuf:"bytes,3,opt,name=availableStorage"`
+	// UsedMemory is the memory used by the streams.
+	UsedMemory apiresource.Quantity `json:"usedMemory" protobuf:"bytes,4,opt,name=usedMemory"`
+	// AvailableMemory is the memory not yet used, not set if the servers do not have a memory limit.
+	// +optional
+	AvailableMemory *apiresource.Quantity `json:"availableMemory,omitempty" protobuf:"bytes,5,opt,name=availableMemory"`
+	// StorageUtilization is the percentage of the storage limit used, e.g. "35%".
+	// +optional
+	StorageUtilization string `json:"storageUtilization,omitempty" protobuf:"bytes,6,opt,name=storageUtilization"`
+	// MemoryUtilization is the percentage of the memory limit used, e.g. "35%".
+	// +optional
+	MemoryUtilization string `json:"memoryUtilization,omitempty" protobuf:"bytes,7,opt,name=memoryUtilization"`
+	// MemoryPressure is true when the memory utilization reaches 80%, the streams can not take new messages once the
+	// memory limit is reached.
+	// +optional
+	MemoryPressure bool `json:"memoryPressure,omitempty" protobuf:"varint,8,opt,name=memoryPressure"`
+	// LastUpdated is the time the usage is collected.
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty" protobuf:"bytes,9,opt,name=lastUpdated"`
 }
 
 func (isbsvc *InterStepBufferServiceStatus) SetPhase(phase ISBSvcPhase, msg string) {
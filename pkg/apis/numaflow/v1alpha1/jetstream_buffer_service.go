@@ -99,6 +99,87 @@ type JetStreamBufferService struct {
 	// Enabling TLS might impact the performace
 	// +optional
 	TLS bool `json:"tls,omitempty" protobuf:"bytes,20,opt,name=tls"`
+	// PodSecurity overrides the security defaults of the JetStream pods.
+	// +optional
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty" protobuf:"bytes,21,opt,name=podSecurity"`
+	// TLSIssuer issues the TLS certificate of the servers with cert-manager, instead of the self-signed one generated by
+	// the controller. It's only meaningful when TLS is enabled, and requires cert-manager to be installed.
+	// +optional
+	TLSIssuer *CertManagerIssuer `json:"tlsIssuer,omitempty" protobuf:"bytes,22,opt,name=tlsIssuer"`
+	// Stream sets the replication, the storage and the limits of the streams of the buffers, it overrides the ones in
+	// the buffer config. Existing streams are updated, except for the storage which can not be changed.
+	// +optional
+	Stream *JetStreamStreamConfig `json:"stream,omitempty" protobuf:"bytes,23,opt,name=stream"`
+	// Mirror mirrors the streams of the buffers to a secondary JetStream, e.g. in another region or cluster, for the
+	// disaster recovery of the in-flight messages.
+	// +optional
+	Mirror *JetStreamMirror `json:"mirror,omitempty" protobuf:"bytes,24,opt,name=mirror"`
+}
+
+// JetStreamMirror is a secondary JetStream the streams of the buffers are mirrored to. The mirrors are named
+// "{stream}_MIRROR", and they pull the messages from the streams of the ISB Service, so the secondary JetStream needs to
+// reach this one, e.g. through a leaf node connection or a gateway of a super cluster.
+type JetStreamMirror struct {
+	// URL of the secondary JetStream, e.g. "nats://isbsvc-dr.example.com:4222".
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// Auth is the user and the password to connect to the secondary JetStream.
+	// +optional
+	Auth *NATSAuth `json:"auth,omitempty" protobuf:"bytes,2,opt,name=auth"`
+	// TLSEnabled connects to the secondary JetStream with TLS.
+	// +optional
+	TLSEnabled bool `json:"tlsEnabled,omitempty" protobuf:"varint,3,opt,name=tlsEnabled"`
+	// TLSCACert is the secret of the CA certificate verifying the secondary JetStream servers, they are not verified if
+	// it's not set.
+	// +optional
+	TLSCACert *corev1.SecretKeySelector `json:"tlsCACert,omitempty" protobuf:"bytes,4,opt,name=tlsCACert"`
+	// SourceAPIPrefix is the JetStream API prefix of this ISB Service seen from the secondary JetStream, e.g.
+	// "$JS.primary.API" if this one is in the JetStream domain "primary". It's not needed if both are in the same
+	// JetStream domain.
+	// +optional
+	SourceAPIPrefix string `json:"sourceAPIPrefix,omitempty" protobuf:"bytes,5,opt,name=sourceAPIPrefix"`
+}
+
+// +kubebuilder:validation:Enum="";File;Memory
+type JetStreamStorageType string
+
+const (
+	// JetStreamStorageFile keeps the messages of the streams on disk
+	JetStreamStorageFile JetStreamStorageType = "File"
+	// JetStreamStorageMemory keeps the messages of the streams in memory, they are lost if the servers restart
+	JetStreamStorageMemory JetStreamStorageType = "Memory"
+)
+
+// JetStreamStreamConfig is the replication, the storage and the limits of the stream of each buffer.
+type JetStreamStreamConfig struct {
+	// Replicas of each stream, between 1 and 5, and no more than the JetStream servers.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty" protobuf:"varint,1,opt,name=replicas"`
+	// Storage of the streams, File or Memory, defaults to File.
+	// +optional
+	Storage JetStreamStorageType `json:"storage,omitempty" protobuf:"bytes,2,opt,name=storage,casttype=JetStreamStorageType"`
+	// MaxBytes is the max size of each stream, the oldest messages are discarded once it's reached.
+	// +optional
+	MaxBytes *apiresource.Quantity `json:"maxBytes,omitempty" protobuf:"bytes,3,opt,name=maxBytes"`
+	// MaxAge is how long the messages are kept in each stream at most.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty" protobuf:"bytes,4,opt,name=maxAge"`
+}
+
+// CertManagerIssuer refers to a cert-manager Issuer or ClusterIssuer.
+type CertManagerIssuer struct {
+	// Name of the issuer.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Kind of the issuer, defaults to Issuer.
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty" protobuf:"bytes,2,opt,name=kind"`
+}
+
+func (i CertManagerIssuer) GetKind() string {
+	if i.Kind == "" {
+		return "Issuer"
+	}
+	return i.Kind
 }
 
 func (j JetStreamBufferService) GetReplicas() int {
@@ -158,7 +239,20 @@ func (j JetStreamBufferService) GetStatefulSetSpec(req GetJetStreamStatefulSetSp
 			Path: "auth.conf",
 		},
 	}
-	if j.TLS {
+	// The certificates issued by cert-manager are projected from their own secret.
+	var tlsSecretKeyToPaths []corev1.KeyToPath
+	if j.TLS && req.TLSSecretName != "" {
+		for _, prefix := range []string{"", "cluster-"} {
+			tlsSecretKeyToPaths = append(tlsSecretKeyToPaths,
+				corev1.KeyToPath{Key: CertManagerSecretPrivateKeyKey, Path: prefix + "server-key.pem"},
+				corev1.KeyToPath{Key: CertManagerSecretCertKey, Path: prefix + "server-cert.pem"},
+			)
+		}
+		tlsSecretKeyToPaths = append(tlsSecretKeyToPaths,
+			corev1.KeyToPath{Key: CertManagerSecretCACertKey, Path: "ca-cert.pem"},
+			corev1.KeyToPath{Key: CertManagerSecretCACertKey, Path: "cluster-ca-cert.pem"},
+		)
+	} else if j.TLS {
 		projectedSecretKeyToPaths = append(projectedSecretKeyToPaths, corev1.KeyToPath{
 			Key:  JetStreamServerPrivateKeyKey,
 			Path: "server-key.pem",
@@ -372,6 +466,19 @@ func (j JetStreamBufferService) GetStatefulSetSpec(req GetJetStreamStatefulSetSp
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: emptyDirVolName, MountPath: "/data/jetstream"})
 		spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
 	}
+	if len(tlsSecretKeyToPaths) > 0 {
+		for _, v := range spec.Template.Spec.Volumes {
+			if v.Name == "config-volume" {
+				v.Projected.Sources = append(v.Projected.Sources, corev1.VolumeProjection{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: req.TLSSecretName},
+						Items:                tlsSecretKeyToPaths,
+					},
+				})
+			}
+		}
+	}
+	applyPodSecurity(j.PodSecurity, &spec.Template)
 	return spec
 }
 
@@ -383,6 +490,15 @@ type JetStreamConfig struct {
 	BufferConfig string `json:"bufferConfig,omitempty" protobuf:"bytes,3,opt,name=bufferConfig"`
 	// TLS enabled or not
 	TLSEnabled bool `json:"tlsEnabled,omitempty" protobuf:"bytes,4,opt,name=tlsEnabled"`
+	// TLSCACert is the secret of the CA certificate verifying the servers, the servers are not verified if it's not set.
+	// +optional
+	TLSCACert *corev1.SecretKeySelector `json:"tlsCACert,omitempty" protobuf:"bytes,5,opt,name=tlsCACert"`
+	// Stream overrides the settings of the streams in the buffer config.
+	// +optional
+	Stream *JetStreamStreamConfig `json:"stream,omitempty" protobuf:"bytes,6,opt,name=stream"`
+	// Mirror is the secondary JetStream the streams are mirrored to.
+	// +optional
+	Mirror *JetStreamMirror `json:"mirror,omitempty" protobuf:"bytes,7,opt,name=mirror"`
 }
 
 type NATSAuth struct {
@@ -68,6 +68,32 @@ func TestJetStreamGetStatefulSetSpec(t *testing.T) {
 		assert.Equal(t, "config-volume", spec.Template.Spec.Volumes[1].Name)
 		assert.Equal(t, 7, len(spec.Template.Spec.Volumes[1].VolumeSource.Projected.Sources[1].Secret.Items))
 	})
+
+	t.Run("with tls issued by cert-manager", func(t *testing.T) {
+		s := &JetStreamBufferService{
+			TLS:       true,
+			TLSIssuer: &CertManagerIssuer{Name: "test-issuer"},
+		}
+		r := req
+		r.TLSSecretName = "test-tls"
+		spec := s.GetStatefulSetSpec(r)
+		sources := spec.Template.Spec.Volumes[1].VolumeSource.Projected.Sources
+		assert.Equal(t, 3, len(sources))
+		assert.Equal(t, 1, len(sources[1].Secret.Items))
+		assert.Equal(t, "test-tls", sources[2].Secret.Name)
+		paths := map[string]string{}
+		for _, item := range sources[2].Secret.Items {
+			paths[item.Path] = item.Key
+		}
+		assert.Equal(t, map[string]string{
+			"server-key.pem":          CertManagerSecretPrivateKeyKey,
+			"server-cert.pem":         CertManagerSecretCertKey,
+			"ca-cert.pem":             CertManagerSecretCACertKey,
+			"cluster-server-key.pem":  CertManagerSecretPrivateKeyKey,
+			"cluster-server-cert.pem": CertManagerSecretCertKey,
+			"cluster-ca-cert.pem":     CertManagerSecretCACertKey,
+		}, paths)
+	})
 }
 
 func TestJetStreamGetServiceSpec(t *testing.T) {
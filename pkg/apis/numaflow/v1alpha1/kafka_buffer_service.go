@@ -0,0 +1,24 @@
+package v1alpha1
+
+// KafkaConfig configures an existing Kafka cluster used as the Inter-Step Buffer Service, nothing is installed by the
+// controller. Each buffer is a topic consumed by a consumer group.
+type KafkaConfig struct {
+	// Brokers of the Kafka cluster
+	Brokers []string `json:"brokers,omitempty" protobuf:"bytes,1,rep,name=brokers"`
+	// TLS configures the TLS connection to the brokers, the secrets are passed to the pods as environment variables.
+	// +optional
+	TLS *TLS `json:"tls,omitempty" protobuf:"bytes,2,opt,name=tls"`
+	// Config is the sarama config in YAML format, applied to all the clients of the buffers.
+	// +optional
+	Config string `json:"config,omitempty" protobuf:"bytes,3,opt,name=config"`
+	// BufferConfig is the config of the topics created for the buffers in YAML format, e.g.
+	//   topic:
+	//     partitions: 4
+	//     replicationFactor: 3
+	//     config:
+	//       retention.ms: "86400000"
+	// The number of partitions is the maximum number of replicas of a vertex reading the buffer in parallel,
+	// it defaults to 4. The replication factor defaults to the number of brokers, and at most 3.
+	// +optional
+	BufferConfig string `json:"bufferConfig,omitempty" protobuf:"bytes,4,opt,name=bufferConfig"`
+}
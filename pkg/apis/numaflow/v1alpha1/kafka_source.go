@@ -1,5 +1,7 @@
 package v1alpha1
 
+import "fmt"
+
 type KafkaSource struct {
 	Brokers           []string `json:"brokers,omitempty" protobuf:"bytes,1,rep,name=brokers"`
 	Topic             string   `json:"topic" protobuf:"bytes,2,opt,name=topic"`
@@ -10,4 +12,16 @@ type KafkaSource struct {
 	TLS *TLS `json:"tls" protobuf:"bytes,4,opt,name=tls"`
 	// +optional
 	Config string `json:"config,omitempty" protobuf:"bytes,5,opt,name=config"`
+	// SASL user to configure SASL authentication for kafka broker
+	// +optional
+	SASL *SASL `json:"sasl,omitempty" protobuf:"bytes,6,opt,name=sasl"`
+}
+
+// GetConsumerGroupName returns the consumer group shared by the replicas of the source vertex, across which the partitions
+// of the topic are distributed, it defaults to "numaflow-<pipeline>-<vertex>".
+func (ks KafkaSource) GetConsumerGroupName(pipelineName, vertexName string) string {
+	if ks.ConsumerGroupName != "" {
+		return ks.ConsumerGroupName
+	}
+	return fmt.Sprintf("numaflow-%s-%s", pipelineName, vertexName)
 }
@@ -1,4 +1,55 @@
 package v1alpha1
 
+// +kubebuilder:validation:Enum="";Text;JSON;Pretty
+type LogSinkFormat string
+
+const (
+	// LogSinkFormatText prints the payload of each message in a line, prefixed by the vertex name
+	LogSinkFormatText LogSinkFormat = "Text"
+	// LogSinkFormatJSON prints each message as a JSON object in a line, with the headers, for the log collectors
+	LogSinkFormatJSON LogSinkFormat = "JSON"
+	// LogSinkFormatPretty prints the headers of each message in a line, followed by the payload, indented if it's JSON
+	LogSinkFormatPretty LogSinkFormat = "Pretty"
+)
+
 type Log struct {
+	// Format of the logged messages, Text, JSON or Pretty, defaults to Text.
+	// +optional
+	Format LogSinkFormat `json:"format,omitempty" protobuf:"bytes,1,opt,name=format,casttype=LogSinkFormat"`
+	// SamplePercentage is the percentage of the messages logged, between 1 and 100, defaults to 100. The messages not
+	// sampled are acknowledged without being logged.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplePercentage *uint32 `json:"samplePercentage,omitempty" protobuf:"varint,2,opt,name=samplePercentage"`
+	// MaxMessagesPerSecond is the max number of messages logged per second by each replica, the ones over the limit
+	// are acknowledged without being logged. No limit if it's not set.
+	// +optional
+	MaxMessagesPerSecond *uint32 `json:"maxMessagesPerSecond,omitempty" protobuf:"varint,3,opt,name=maxMessagesPerSecond"`
+}
+
+func (l Log) GetFormat() LogSinkFormat {
+	if l.Format == "" {
+		return LogSinkFormatText
+	}
+	return l.Format
+}
+
+func (l Log) GetSamplePercentage() uint32 {
+	if l.SamplePercentage == nil || *l.SamplePercentage > 100 {
+		return 100
+	}
+	return *l.SamplePercentage
+}
+
+// GetMaxMessagesPerSecond returns the max number of messages logged per second, 0 if there's no limit.
+func (l Log) GetMaxMessagesPerSecond() uint32 {
+	if l.MaxMessagesPerSecond == nil {
+		return 0
+	}
+	return *l.MaxMessagesPerSecond
+}
+
+// ReplySink returns the messages to the http sources in request-reply mode which they are originated from.
+type ReplySink struct {
 }
@@ -0,0 +1,124 @@
+package v1alpha1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=PhaseChanged;SLAViolated;SLARecovered
+type NotificationEventType string
+
+const (
+	// NotificationEventPhaseChanged is sent by the controller when the phase of the pipeline changes
+	NotificationEventPhaseChanged NotificationEventType = "PhaseChanged"
+	// NotificationEventSLAViolated is sent by the daemon server when an objective of the SLA is violated
+	NotificationEventSLAViolated NotificationEventType = "SLAViolated"
+	// NotificationEventSLARecovered is sent by the daemon server when a violated objective of the SLA is met again
+	NotificationEventSLARecovered NotificationEventType = "SLARecovered"
+)
+
+// PipelineNotifications sends the health events of the pipeline, i.e. the phase transitions and the SLA violations, to
+// the webhooks, Slack or Opsgenie.
+type PipelineNotifications struct {
+	// Targets are the endpoints the events are sent to.
+	Targets []NotificationTarget `json:"targets" protobuf:"bytes,1,rep,name=targets"`
+	// SLA are the objectives of the pipeline checked by the daemon server, the violations and the recoveries of which
+	// are sent to the targets.
+	// +optional
+	SLA *PipelineSLA `json:"sla,omitempty" protobuf:"bytes,2,opt,name=sla"`
+}
+
+// NotificationTarget is an endpoint the events are sent to, exactly one of Webhook, Slack and Opsgenie is set.
+type NotificationTarget struct {
+	// Name of the target, unique in the pipeline.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Events are the types of the events sent to the target, all of them if it's empty.
+	// +optional
+	Events []NotificationEventType `json:"events,omitempty" protobuf:"bytes,2,rep,name=events,casttype=NotificationEventType"`
+	// Template is the Go template of the payload executed with the event, e.g. "{{.Pipeline}} is {{.Phase}}". It's the
+	// body of a webhook request, defaulting to the JSON of the event, and the text of a Slack message or the
+	// description of an Opsgenie alert, defaulting to the message of the event.
+	// +optional
+	Template string `json:"template,omitempty" protobuf:"bytes,3,opt,name=template"`
+	// Webhook posts the payload to an HTTP endpoint.
+	// +optional
+	Webhook *WebhookNotification `json:"webhook,omitempty" protobuf:"bytes,4,opt,name=webhook"`
+	// Slack posts the payload to a Slack incoming webhook.
+	// +optional
+	Slack *SlackNotification `json:"slack,omitempty" protobuf:"bytes,5,opt,name=slack"`
+	// Opsgenie creates an alert for each event, which is closed once the phase is Running again or the SLA recovers.
+	// +optional
+	Opsgenie *OpsgenieNotification `json:"opsgenie,omitempty" protobuf:"bytes,6,opt,name=opsgenie"`
+}
+
+// Subscribes tells if the events of the type are sent to the target.
+func (nt NotificationTarget) Subscribes(t NotificationEventType) bool {
+	if len(nt.Events) == 0 {
+		return true
+	}
+	for _, e := range nt.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookNotification struct {
+	// URL of the endpoint the payload is posted to.
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// ContentType of the payload, defaults to "application/json".
+	// +optional
+	ContentType string `json:"contentType,omitempty" protobuf:"bytes,2,opt,name=contentType"`
+	// Authorization is the secret holding the value of the Authorization header, e.g. "Bearer <token>".
+	// +optional
+	Authorization *corev1.SecretKeySelector `json:"authorization,omitempty" protobuf:"bytes,3,opt,name=authorization"`
+}
+
+type SlackNotification struct {
+	// URL is the secret holding the URL of the Slack incoming webhook.
+	URL *corev1.SecretKeySelector `json:"url" protobuf:"bytes,1,opt,name=url"`
+}
+
+type OpsgenieNotification struct {
+	// APIKey is the secret holding the key of the Opsgenie API integration.
+	APIKey *corev1.SecretKeySelector `json:"apiKey" protobuf:"bytes,1,opt,name=apiKey"`
+	// URL of the Opsgenie API, defaults to "https://api.opsgenie.com", e.g. "https://api.eu.opsgenie.com" in the EU.
+	// +optional
+	URL string `json:"url,omitempty" protobuf:"bytes,2,opt,name=url"`
+	// Priority of the alerts, from P1 to P5, defaults to the default of Opsgenie, which is P3.
+	// +kubebuilder:validation:Enum="";P1;P2;P3;P4;P5
+	// +optional
+	Priority string `json:"priority,omitempty" protobuf:"bytes,3,opt,name=priority"`
+}
+
+func (on OpsgenieNotification) GetURL() string {
+	if on.URL != "" {
+		return on.URL
+	}
+	return DefaultOpsgenieURL
+}
+
+// PipelineSLA are the objectives of a pipeline, each of which is violated once it's not met for a while.
+type PipelineSLA struct {
+	// MaxPendingMessages is the max number of the messages pending in all the buffers of the pipeline.
+	// +optional
+	MaxPendingMessages *int64 `json:"maxPendingMessages,omitempty" protobuf:"varint,1,opt,name=maxPendingMessages"`
+	// MaxWatermarkDelay is the max delay of the watermarks of the sink vertices behind the current time, the sinks
+	// without a watermark are not checked.
+	// +optional
+	MaxWatermarkDelay *metav1.Duration `json:"maxWatermarkDelay,omitempty" protobuf:"bytes,2,opt,name=maxWatermarkDelay"`
+	// For is how long an objective is not met before it's violated, and met again before it recovers, defaults to 5m.
+	// +kubebuilder:default="5m"
+	// +optional
+	For *metav1.Duration `json:"for,omitempty" protobuf:"bytes,3,opt,name=for"`
+}
+
+func (ps PipelineSLA) GetFor() time.Duration {
+	if ps.For != nil && ps.For.Duration >= 0 {
+		return ps.For.Duration
+	}
+	return DefaultSLAFor
+}
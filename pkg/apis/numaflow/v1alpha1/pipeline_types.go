@@ -21,15 +21,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
-// +kubebuilder:validation:Enum="";Running;Succeeded;Failed;Pausing;Paused;Deleting
+// +kubebuilder:validation:Enum="";Running;Succeeded;Failed;Pausing;Paused;Deleting;Completed
 type PipelinePhase string
 
 const (
@@ -40,6 +44,9 @@ const (
 	PipelinePhasePausing   PipelinePhase = "Pausing"
 	PipelinePhasePaused    PipelinePhase = "Paused"
 	PipelinePhaseDeleting  PipelinePhase = "Deleting"
+	// PipelinePhaseCompleted is the phase of a bounded pipeline, once all of its sources reach the end and all the
+	// messages are written by the sinks.
+	PipelinePhaseCompleted PipelinePhase = "Completed"
 
 	// PipelineConditionConfigured has the status True when the Pipeline
 	// has valid configuration.
@@ -47,6 +54,9 @@ const (
 	// PipelineConditionDeployed has the status True when the Pipeline
 	// has its Vertices and Jobs created.
 	PipelineConditionDeployed ConditionType = "Deployed"
+	// PipelineConditionDegraded is added with the status True when any vertex of the Pipeline
+	// is failing, and removed once they recover.
+	PipelineConditionDegraded ConditionType = "Degraded"
 )
 
 // +genclient
@@ -76,8 +86,27 @@ func (p Pipeline) GetVertex(vertexName string) *AbstractVertex {
 	return nil
 }
 
+// IsBounded tells if all the sources of the pipeline are bounded, which makes the pipeline completed once they reach
+// the end, and all the messages are written by the sinks.
+func (p Pipeline) IsBounded() bool {
+	hasSource := false
+	for _, v := range p.Spec.Vertices {
+		if v.Source == nil {
+			continue
+		}
+		if !v.Source.IsBounded() {
+			return false
+		}
+		hasSource = true
+	}
+	return hasSource
+}
+
 // FindVerticesWithBuffer is used to locate the vertices who write and read from the buffer.
 func (p Pipeline) FindVerticesWithBuffer(buffer string) (from, to *AbstractVertex) {
+	if from, to := p.findVerticesWithReplyBuffer(buffer); to != nil {
+		return from, to
+	}
 	for _, e := range p.Spec.Edges {
 		if buffer == GenerateBufferName(p.Namespace, p.Name, e.From, e.To) {
 			for _, v := range p.Spec.Vertices {
@@ -95,6 +124,31 @@ func (p Pipeline) FindVerticesWithBuffer(buffer string) (from, to *AbstractVerte
 	return from, to
 }
 
+// findVerticesWithReplyBuffer locates the reply sink writing to the reply buffer, and the source reading from it. If
+// there are multiple reply sinks, the first one is returned.
+func (p Pipeline) findVerticesWithReplyBuffer(buffer string) (from, to *AbstractVertex) {
+	for _, v := range p.Spec.Vertices {
+		if v.Source == nil || !v.Source.HTTP.IsRequestReply() {
+			continue
+		}
+		for i := int32(0); i < v.Scale.GetMaxReplicas(); i++ {
+			if buffer == GenerateReplyBufferName(p.Namespace, p.Name, v.Name, int(i)) {
+				to = v.DeepCopy()
+				break
+			}
+		}
+	}
+	if to == nil {
+		return nil, nil
+	}
+	for _, v := range p.Spec.Vertices {
+		if v.Sink != nil && v.Sink.Reply != nil {
+			return v.DeepCopy(), to
+		}
+	}
+	return nil, to
+}
+
 func (p Pipeline) GetToEdges(vertexName string) []Edge {
 	edges := []Edge{}
 	for _, e := range p.Spec.Edges {
@@ -119,6 +173,23 @@ func (p Pipeline) GetAllBuffers() []string {
 	r := []string{}
 	for _, e := range p.Spec.Edges {
 		r = append(r, GenerateBufferName(p.Namespace, p.Name, e.From, e.To))
+		if e.DeadLetterQueue != nil {
+			r = append(r, GenerateDeadLetterBufferName(p.Namespace, p.Name, e.From, e.To))
+		}
+	}
+	return append(r, p.GetReplyBuffers()...)
+}
+
+// GetReplyBuffers returns the reply buffers of the http sources in request-reply mode.
+func (p Pipeline) GetReplyBuffers() []string {
+	r := []string{}
+	for _, v := range p.Spec.Vertices {
+		if v.Source == nil || !v.Source.HTTP.IsRequestReply() {
+			continue
+		}
+		for i := int32(0); i < v.Scale.GetMaxReplicas(); i++ {
+			r = append(r, GenerateReplyBufferName(p.Namespace, p.Name, v.Name, int(i)))
+		}
 	}
 	return r
 }
@@ -134,6 +205,20 @@ func (p Pipeline) GetDaemonServiceURL() string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", p.GetDaemonServiceName(), p.Namespace, DaemonServicePort)
 }
 
+// GetCorrelationID returns the ID added to the log lines of all the components of the pipeline, it's empty if the UID is not set.
+func (p Pipeline) GetCorrelationID() string {
+	return CorrelationID(p.UID)
+}
+
+// CorrelationID returns the correlation ID derived from the UID of a pipeline, the first 12 hex digits of it.
+func CorrelationID(uid types.UID) string {
+	id := strings.ReplaceAll(string(uid), "-", "")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return id
+}
+
 func (p Pipeline) GetDaemonDeploymentObj(req GetDaemonDeploymentReq) (*appv1.Deployment, error) {
 	pipelineCopy := &Pipeline{
 		ObjectMeta: metav1.ObjectMeta{
@@ -185,6 +270,10 @@ func (p Pipeline) GetDaemonDeploymentObj(req GetDaemonDeploymentReq) (*appv1.Dep
 			},
 		},
 	}
+	if p.Spec.Templates != nil {
+		p.Spec.Templates.Daemon.ApplyToPodTemplate(&spec.Template)
+	}
+	applyPodSecurity(p.Spec.PodSecurity, &spec.Template)
 	return &appv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: p.Namespace,
@@ -243,7 +332,8 @@ func (p Pipeline) GetDaemonServiceObj() *corev1.Service {
 }
 
 type Lifecycle struct {
-	// DeleteGracePeriodSeconds used to delete pipeline gracefully
+	// DeleteGracePeriodSeconds used to delete pipeline gracefully, it's the time to drain the pipeline before deleting
+	// the buffers, which are deleted regardless afterwards, along with the messages not processed
 	// +kubebuilder:default=30
 	// +optional
 	DeleteGracePeriodSeconds int32 `json:"deleteGracePeriodSeconds,omitempty" protobuf:"varint,1,opt,name=deleteGracePeriodSeconds"`
@@ -252,8 +342,42 @@ type Lifecycle struct {
 	// +kubebuilder:default=Running
 	// +optional
 	DesiredPhase PipelinePhase `json:"desiredPhase,omitempty" protobuf:"bytes,2,opt,name=desiredPhase"`
+
+	// DeletionPolicy decides what happens to the buffers when the pipeline is deleted, Delete or Orphan, defaults to Delete.
+	// With Orphan, the pipeline is deleted without draining, and the buffers are left intact for a replacement pipeline to adopt.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty" protobuf:"bytes,3,opt,name=deletionPolicy"`
+
+	// AdoptExistingBuffers is used to adopt the buffers left by a previous pipeline with the same name, e.g. orphaned on deletion.
+	// Otherwise, the buffers existing before the pipeline is created are purged.
+	// +optional
+	AdoptExistingBuffers bool `json:"adoptExistingBuffers,omitempty" protobuf:"varint,4,opt,name=adoptExistingBuffers"`
+
+	// ConfirmDisruptiveChanges holds the changes adding or removing buffers of a deployed pipeline until they are confirmed.
+	// The planned changes are shown in status.pendingChanges, annotate the pipeline with "numaflow.numaproj.io/confirmed-changes"
+	// set to their hash to apply them.
+	// +optional
+	ConfirmDisruptiveChanges bool `json:"confirmDisruptiveChanges,omitempty" protobuf:"varint,5,opt,name=confirmDisruptiveChanges"`
 }
 
+// GetDeletionPolicy returns the deletion policy, defaults to Delete.
+func (lc Lifecycle) GetDeletionPolicy() DeletionPolicy {
+	if lc.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return lc.DeletionPolicy
+}
+
+// +kubebuilder:validation:Enum="";Delete;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete drains the pipeline and deletes the buffers on deletion
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan leaves the buffers intact on deletion
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
 type PipelineSpec struct {
 	// +optional
 	InterStepBufferServiceName string `json:"interStepBufferServiceName,omitempty" protobuf:"bytes,1,opt,name=interStepBufferServiceName"`
@@ -268,8 +392,8 @@ type PipelineSpec struct {
 	// +kubebuilder:default={"deleteGracePeriodSeconds": 30, "desiredPhase": Running}
 	// +optional
 	Lifecycle Lifecycle `json:"lifecycle,omitempty" protobuf:"bytes,4,opt,name=lifecycle"`
-	// Limits define the limitations such as buffer read batch size for all the vertices of a pipleine, they could be overridden by each vertex's settings
-	// +kubebuilder:default={"readBatchSize": 100, "udfWorkers": 100, "bufferMaxLength": 10000, "bufferUsageLimit": 80}
+	// Limits define the limitations such as buffer read batch size for all the vertices of a pipleine, they could be overridden by each vertex's settings.
+	// The limits not set fall back to the namespace defaults, and then the built-in defaults.
 	// +optional
 	Limits *PipelineLimits `json:"limits,omitempty" protobuf:"bytes,5,opt,name=limits"`
 	// Watermark enables watermark progression across the entire pipeline. Updating this after the pipeline has been
@@ -278,6 +402,180 @@ type PipelineSpec struct {
 	// +kubebuilder:default={"propagate": false}
 	// +optional
 	Watermark Watermark `json:"watermark,omitempty" protobuf:"bytes,6,opt,name=watermark"`
+	// FeatureGates turn on or off the experimental features for the pipeline, keyed by the feature names, e.g. "ExactlyOnce".
+	// They override the controller defaults, which are set by the controller flag --feature-gates.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty" protobuf:"bytes,7,rep,name=featureGates"`
+	// ReplayPolicy decides where the consumers of the buffers start reading from when they are created, it matters
+	// when the pipeline is created against existing buffers, e.g. the pipeline is recreated after a controller migration.
+	// Updating this after the buffers have been created has no impact.
+	// +optional
+	ReplayPolicy *ReplayPolicy `json:"replayPolicy,omitempty" protobuf:"bytes,8,opt,name=replayPolicy"`
+	// Metrics defines how the metrics of the pipeline are collected.
+	// +optional
+	Metrics *PipelineMetrics `json:"metrics,omitempty" protobuf:"bytes,9,opt,name=metrics"`
+	// PodSecurity overrides the security defaults of the daemon, vertex and job pods of the pipeline.
+	// +optional
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty" protobuf:"bytes,10,opt,name=podSecurity"`
+	// Audit turns on the at-least-once audit mode, to certify that no message is lost or duplicated, e.g. during an upgrade.
+	// +optional
+	Audit *PipelineAudit `json:"audit,omitempty" protobuf:"bytes,11,opt,name=audit"`
+	// Tracing turns on the OpenTelemetry tracing of the messages, to follow the journey of a message across the vertices.
+	// +optional
+	Tracing *PipelineTracing `json:"tracing,omitempty" protobuf:"bytes,12,opt,name=tracing"`
+	// Templates are the pod settings shared by the vertex, daemon and job pods, e.g. the tolerations and the node selector,
+	// so that they don't need to be repeated on every vertex.
+	// +optional
+	Templates *Templates `json:"templates,omitempty" protobuf:"bytes,13,opt,name=templates"`
+	// Notifications sends the phase transitions and the SLA violations of the pipeline to the webhooks, Slack or Opsgenie.
+	// +optional
+	Notifications *PipelineNotifications `json:"notifications,omitempty" protobuf:"bytes,14,opt,name=notifications"`
+}
+
+// PipelineTracing is the OpenTelemetry tracing of the messages of a pipeline. The sources start a trace for a sample of
+// the messages, whose W3C trace context is carried along in the metadata, and the vertices export the spans of reading,
+// processing and writing the traced messages with OTLP over HTTP, in JSON encoding. A message coming into a source with
+// a trace context, e.g. the "traceparent" header of an HTTP source request, continues that trace instead.
+type PipelineTracing struct {
+	// Endpoint to export the spans to, the URL of the OTLP/HTTP traces endpoint, e.g. "http://otel-collector:4318/v1/traces".
+	Endpoint string `json:"endpoint" protobuf:"bytes,1,opt,name=endpoint"`
+	// SamplingPercentage is the percentage of the messages the sources start a trace for, defaults to 100.
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingPercentage *uint32 `json:"samplingPercentage,omitempty" protobuf:"varint,2,opt,name=samplingPercentage"`
+	// Interval of exporting the spans, defaults to 5s.
+	// +kubebuilder:default="5s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty" protobuf:"bytes,3,opt,name=interval"`
+}
+
+func (pt *PipelineTracing) GetSamplingPercentage() uint32 {
+	if pt == nil || pt.SamplingPercentage == nil {
+		return DefaultTracingSamplingPercentage
+	}
+	if *pt.SamplingPercentage > 100 {
+		return 100
+	}
+	return *pt.SamplingPercentage
+}
+
+func (pt *PipelineTracing) GetInterval() time.Duration {
+	if pt == nil || pt.Interval == nil || pt.Interval.Duration <= 0 {
+		return DefaultTracingExportInterval
+	}
+	return pt.Interval.Duration
+}
+
+// PipelineAudit is the at-least-once audit mode of a pipeline. The sources stamp each message with an audit ID carried
+// along in the metadata, and record the audit IDs of the messages they emit, the sinks record the audit IDs of the
+// messages they process. The records go to the audit log in the Inter-Step Buffer Service, which is only supported by
+// JetStream, and the "pipeline-audit" command compares them for a time range to report the lost and the duplicated
+// messages.
+type PipelineAudit struct {
+	// Retention is how long the audit records are kept, defaults to 24h.
+	// +kubebuilder:default="24h"
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty" protobuf:"bytes,1,opt,name=retention"`
+}
+
+func (pa *PipelineAudit) GetRetention() time.Duration {
+	if pa == nil || pa.Retention == nil || pa.Retention.Duration <= 0 {
+		return DefaultAuditRetention
+	}
+	return pa.Retention.Duration
+}
+
+type PipelineMetrics struct {
+	// ServiceMonitor makes the controller create a ServiceMonitor for the daemon service, and a PodMonitor for the vertex pods,
+	// owned by the pipeline, so that they are scraped by the Prometheus Operator. It takes effect only when the Prometheus
+	// Operator CRDs are installed.
+	// +optional
+	ServiceMonitor bool `json:"serviceMonitor,omitempty" protobuf:"varint,1,opt,name=serviceMonitor"`
+	// Export makes the daemon server push the pipeline metrics to an external system periodically, for the environments
+	// without a Prometheus to scrape them.
+	// +optional
+	Export *MetricsExport `json:"export,omitempty" protobuf:"bytes,2,opt,name=export"`
+	// HistoryRetention is how long the daemon server keeps the history of the processing rates, pending counts and
+	// watermarks of the vertices in memory, downsampled to a sample per minute after the latest hour, defaults to 6h.
+	// +kubebuilder:default="6h"
+	// +optional
+	HistoryRetention *metav1.Duration `json:"historyRetention,omitempty" protobuf:"bytes,3,opt,name=historyRetention"`
+}
+
+func (pm *PipelineMetrics) GetServiceMonitor() bool {
+	return pm != nil && pm.ServiceMonitor
+}
+
+func (pm *PipelineMetrics) GetHistoryRetention() time.Duration {
+	if pm == nil || pm.HistoryRetention == nil || pm.HistoryRetention.Duration <= 0 {
+		return DefaultMetricsHistoryRetention
+	}
+	return pm.HistoryRetention.Duration
+}
+
+func (pm *PipelineMetrics) GetExport() *MetricsExport {
+	if pm == nil {
+		return nil
+	}
+	return pm.Export
+}
+
+// +kubebuilder:validation:Enum=OTLP;StatsD
+type MetricsExportProtocol string
+
+const (
+	// MetricsExportProtocolOTLP pushes the metrics with OTLP over HTTP, in JSON encoding
+	MetricsExportProtocolOTLP MetricsExportProtocol = "OTLP"
+	// MetricsExportProtocolStatsD pushes the metrics with StatsD over UDP, with the labels as DogStatsD tags
+	MetricsExportProtocolStatsD MetricsExportProtocol = "StatsD"
+)
+
+type MetricsExport struct {
+	// Protocol is either OTLP or StatsD.
+	Protocol MetricsExportProtocol `json:"protocol" protobuf:"bytes,1,opt,name=protocol,casttype=MetricsExportProtocol"`
+	// Endpoint to push the metrics to, the URL of the OTLP/HTTP metrics endpoint, e.g. "http://otel-collector:4318/v1/metrics",
+	// or the "host:port" of the StatsD server, e.g. "statsd:8125".
+	Endpoint string `json:"endpoint" protobuf:"bytes,2,opt,name=endpoint"`
+	// Interval of pushing the metrics, defaults to 30s.
+	// +kubebuilder:default="30s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty" protobuf:"bytes,3,opt,name=interval"`
+}
+
+func (me MetricsExport) GetInterval() time.Duration {
+	if me.Interval == nil || me.Interval.Duration <= 0 {
+		return DefaultMetricsExportInterval
+	}
+	return me.Interval.Duration
+}
+
+// +kubebuilder:validation:Enum="";DeliverAll;DeliverNew;ByStartTime
+type DeliverPolicy string
+
+const (
+	// DeliverPolicyAll reads all the existing messages in the buffer
+	DeliverPolicyAll DeliverPolicy = "DeliverAll"
+	// DeliverPolicyNew only reads the messages written after the consumer is created
+	DeliverPolicyNew DeliverPolicy = "DeliverNew"
+	// DeliverPolicyByStartTime reads the messages written since the start time
+	DeliverPolicyByStartTime DeliverPolicy = "ByStartTime"
+)
+
+type ReplayPolicy struct {
+	// DeliverPolicy is one of DeliverAll, DeliverNew and ByStartTime, defaults to DeliverAll.
+	// +optional
+	DeliverPolicy DeliverPolicy `json:"deliverPolicy,omitempty" protobuf:"bytes,1,opt,name=deliverPolicy"`
+	// StartTime is required by the ByStartTime deliver policy.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty" protobuf:"bytes,2,opt,name=startTime"`
+}
+
+func (rp *ReplayPolicy) GetDeliverPolicy() DeliverPolicy {
+	if rp == nil || rp.DeliverPolicy == "" {
+		return DeliverPolicyAll
+	}
+	return rp.DeliverPolicy
 }
 
 type Watermark struct {
@@ -289,26 +587,42 @@ type Watermark struct {
 
 type PipelineLimits struct {
 	// Read batch size for all the vertices in the pipeline, can be overridden by the vertex's limit settings
-	// +kubebuilder:default=100
+	// Defaults to 100.
 	// +optional
 	ReadBatchSize *uint64 `json:"readBatchSize,omitempty" protobuf:"varint,1,opt,name=readBatchSize"`
 	// Workers used to concurrently call UDF functions, it's only meaningful for UDF vertex, and will be ignored by source and sink vertices.
 	// It can be overridden by the vertex's limit settings
-	// +kubebuilder:default=100
+	// Defaults to 100.
 	// +optional
 	UDFWorkers *uint32 `json:"udfWorkers,omitempty" protobuf:"varint,2,opt,name=udfWorkers"`
 	// BufferMaxLength is used to define the max length of a buffer
 	// Only applies to UDF and Source vertice as only they do buffer write.
 	// It can be overridden by the settings in vertex limits.
-	// +kubebuilder:default=10000
+	// Defaults to 10000.
 	// +optional
 	BufferMaxLength *uint64 `json:"bufferMaxLength,omitempty" protobuf:"varint,3,opt,name=bufferMaxLength"`
 	// BufferUsageLimit is used to define the pencentage of the buffer usage limit, a valid value should be less than 100, for example, 85.
 	// Only applies to UDF and Source vertice as only they do buffer write.
 	// It will be overridden by the settings in vertex limits.
-	// +kubebuilder:default=80
+	// Defaults to 80.
 	// +optional
 	BufferUsageLimit *uint32 `json:"bufferUsageLimit,omitempty" protobuf:"varint,4,opt,name=bufferUsageLimit"`
+	// Compression is the content encoding used to compress the payloads written to the buffers of all the edges, one
+	// of gzip, snappy, zstd and lz4. It can be overridden by the settings in edge limits.
+	// Not compressed if it's not specified.
+	// +optional
+	Compression ContentEncoding `json:"compression,omitempty" protobuf:"bytes,5,opt,name=compression,casttype=ContentEncoding"`
+	// MaxKeySize is the max size in bytes of the keys of the messages written to the buffers, the oversized messages
+	// fail to be written. It can be overridden by the settings in vertex limits.
+	// Not limited if it's not specified.
+	// +optional
+	MaxKeySize *uint32 `json:"maxKeySize,omitempty" protobuf:"varint,6,opt,name=maxKeySize"`
+	// MaxHeaderSize is the max size in bytes of the headers of the messages written to the buffers as they are encoded
+	// by the Inter-Step Buffer Service, including the keys and the metadata, the oversized messages fail to be written.
+	// It can be overridden by the settings in vertex limits.
+	// Not limited if it's not specified.
+	// +optional
+	MaxHeaderSize *uint32 `json:"maxHeaderSize,omitempty" protobuf:"varint,7,opt,name=maxHeaderSize"`
 }
 
 type Edge struct {
@@ -317,6 +631,183 @@ type Edge struct {
 	// Conditional forwarding, only allowed when "From" is a Sink or UDF
 	// +optional
 	Conditions *ForwardConditions `json:"conditions" protobuf:"bytes,3,opt,name=conditions"`
+	// ReadWeight is the relative weight of the edge when the "To" vertex reads from multiple inbound edges,
+	// an edge with a higher weight gets a larger share of each read batch. Defaults to 1.
+	// +optional
+	ReadWeight *uint32 `json:"readWeight,omitempty" protobuf:"varint,4,opt,name=readWeight"`
+	// DeadLetterQueue routes the messages the "To" vertex fails to process to a dead-letter buffer of the edge,
+	// instead of retrying them forever and blocking the edge.
+	// +optional
+	DeadLetterQueue *DeadLetterQueue `json:"deadLetterQueue,omitempty" protobuf:"bytes,5,opt,name=deadLetterQueue"`
+	// Limits of the buffer of the edge.
+	// +optional
+	Limits *EdgeLimits `json:"limits,omitempty" protobuf:"bytes,6,opt,name=limits"`
+	// Trace records a sample of the messages written to the buffer of the edge, which can be queried through the
+	// daemon service for debugging.
+	// +optional
+	Trace *EdgeTrace `json:"trace,omitempty" protobuf:"bytes,7,opt,name=trace"`
+}
+
+type EdgeLimits struct {
+	// ExactlyOnce deduplicates the messages written to the buffer of the edge by their IDs, so that the messages
+	// processed again after retries or restarts are not delivered twice. Disabling it saves the deduplication
+	// cost for the edges tolerating duplicates. Rejected on the Kafka Inter-Step Buffer Service, which doesn't deduplicate.
+	// Defaults to the ExactlyOnce feature gate of the pipeline, which is on by default.
+	// +optional
+	ExactlyOnce *bool `json:"exactlyOnce,omitempty" protobuf:"varint,1,opt,name=exactlyOnce"`
+	// Durability is the acknowledgement the writes to the buffer of the edge wait for, it trades the latency of
+	// the writes for their durability. Rejected on the Kafka Inter-Step Buffer Service. Defaults to Acknowledged.
+	// +optional
+	Durability WriteDurability `json:"durability,omitempty" protobuf:"bytes,2,opt,name=durability,casttype=WriteDurability"`
+	// OnFull is the strategy of the writes once the buffer of the edge is full, it trades the completeness of the data
+	// for the latency of the pipeline, e.g. for an edge carrying metrics whose latest values matter the most.
+	// Rejected on the Kafka Inter-Step Buffer Service. Defaults to retryUntilSuccess.
+	// +optional
+	OnFull OnFullWritingStrategy `json:"onFull,omitempty" protobuf:"bytes,3,opt,name=onFull,casttype=OnFullWritingStrategy"`
+	// Compression is the content encoding used to compress the payloads written to the buffer of the edge, one of
+	// gzip, snappy, zstd and lz4. The payloads are decompressed when they are read by the "To" vertex, it trades the
+	// CPU of both vertices for the storage and the network of the Inter-Step Buffer Service, e.g. for large JSON
+	// payloads. Rejected on the Kafka Inter-Step Buffer Service. Not compressed if it's not specified.
+	// +optional
+	Compression ContentEncoding `json:"compression,omitempty" protobuf:"bytes,4,opt,name=compression,casttype=ContentEncoding"`
+	// MaxMessageAge is the time to live of the messages in the buffer of the edge by their event time, the "To" vertex
+	// drops and acknowledges the messages older than it without processing them, e.g. the stale ones after a long
+	// outage. The messages without an event time are never dropped. Not dropped if it's not specified.
+	// +optional
+	MaxMessageAge *metav1.Duration `json:"maxMessageAge,omitempty" protobuf:"bytes,5,opt,name=maxMessageAge"`
+}
+
+// OnFullWritingStrategy is the strategy of the writes once a buffer is full.
+// +kubebuilder:validation:Enum="";retryUntilSuccess;discardLatest;discardOldest
+type OnFullWritingStrategy string
+
+const (
+	// OnFullRetryUntilSuccess retries the writes until the buffer is no longer full, which back-pressures the upstream
+	// vertices.
+	OnFullRetryUntilSuccess OnFullWritingStrategy = "retryUntilSuccess"
+	// OnFullDiscardLatest discards the messages being written.
+	OnFullDiscardLatest OnFullWritingStrategy = "discardLatest"
+	// OnFullDiscardOldest discards the oldest messages in the buffer to make room for the ones being written, whether
+	// they are read or not.
+	OnFullDiscardOldest OnFullWritingStrategy = "discardOldest"
+)
+
+// WriteDurability is the acknowledgement the writes to a buffer wait for.
+// +kubebuilder:validation:Enum="";None;Acknowledged;Replicated
+type WriteDurability string
+
+const (
+	// WriteDurabilityNone doesn't wait for the writes to be acknowledged, the messages could be lost if the
+	// Inter-Step Buffer Service fails. Only supported by JetStream, the same as Acknowledged for Redis.
+	WriteDurabilityNone WriteDurability = "None"
+	// WriteDurabilityAcknowledged waits for the writes to be acknowledged by the Inter-Step Buffer Service, i.e. the
+	// JetStream stream, or the Redis primary.
+	WriteDurabilityAcknowledged WriteDurability = "Acknowledged"
+	// WriteDurabilityReplicated waits for the writes to be acknowledged by the replicas. For Redis, at least one
+	// replica acknowledges them with WAIT. The same as Acknowledged for JetStream, whose acknowledgement is only
+	// sent after a quorum of the stream replicas store the message.
+	WriteDurabilityReplicated WriteDurability = "Replicated"
+)
+
+// IsExactlyOnce tells if the messages written to the buffer of the edge are deduplicated, featureEnabled is the
+// ExactlyOnce feature gate, which decides it if the edge does not.
+func (el *EdgeLimits) IsExactlyOnce(featureEnabled bool) bool {
+	if el == nil || el.ExactlyOnce == nil {
+		return featureEnabled
+	}
+	return *el.ExactlyOnce
+}
+
+// GetDurability returns the acknowledgement the writes to the buffer of the edge wait for.
+func (el *EdgeLimits) GetDurability() WriteDurability {
+	if el == nil || el.Durability == "" {
+		return WriteDurabilityAcknowledged
+	}
+	return el.Durability
+}
+
+// GetOnFull returns the strategy of the writes once the buffer of the edge is full.
+func (el *EdgeLimits) GetOnFull() OnFullWritingStrategy {
+	if el == nil || el.OnFull == "" {
+		return OnFullRetryUntilSuccess
+	}
+	return el.OnFull
+}
+
+// GetCompression returns the content encoding of the payloads written to the buffer of the edge, empty means no
+// compression.
+func (el *EdgeLimits) GetCompression() ContentEncoding {
+	if el == nil {
+		return ""
+	}
+	return el.Compression
+}
+
+// GetMaxMessageAge returns the time to live of the messages in the buffer of the edge, 0 means they never expire.
+func (el *EdgeLimits) GetMaxMessageAge() time.Duration {
+	if el == nil || el.MaxMessageAge == nil {
+		return 0
+	}
+	return el.MaxMessageAge.Duration
+}
+
+// EdgeTrace is the message tracing config of an edge.
+type EdgeTrace struct {
+	// SampleRate is the fraction of the messages recorded, between 0 and 1, e.g. "0.01" records 1% of the messages.
+	SampleRate string `json:"sampleRate" protobuf:"bytes,1,opt,name=sampleRate"`
+	// CapturePayload records the payloads of the messages as well as the headers, the payloads are truncated to
+	// PayloadSizeLimit.
+	// +optional
+	CapturePayload bool `json:"capturePayload,omitempty" protobuf:"varint,2,opt,name=capturePayload"`
+	// PayloadSizeLimit is the max number of bytes of a payload recorded, defaults to 256.
+	// +optional
+	PayloadSizeLimit *uint32 `json:"payloadSizeLimit,omitempty" protobuf:"varint,3,opt,name=payloadSizeLimit"`
+	// MaxRecords is the number of the latest messages kept by each replica, the older ones are overwritten.
+	// Defaults to 100.
+	// +optional
+	MaxRecords *uint32 `json:"maxRecords,omitempty" protobuf:"varint,4,opt,name=maxRecords"`
+}
+
+// GetSampleRate returns the fraction of the messages recorded, 0 if the edge is not traced.
+func (et *EdgeTrace) GetSampleRate() float64 {
+	if et == nil {
+		return 0
+	}
+	r, err := strconv.ParseFloat(et.SampleRate, 64)
+	if err != nil {
+		return 0
+	}
+	return r
+}
+
+func (et EdgeTrace) GetPayloadSizeLimit() int {
+	if et.PayloadSizeLimit != nil {
+		return int(*et.PayloadSizeLimit)
+	}
+	return DefaultEdgeTracePayloadSizeLimit
+}
+
+func (et EdgeTrace) GetMaxRecords() int {
+	if et.MaxRecords != nil {
+		return int(*et.MaxRecords)
+	}
+	return DefaultEdgeTraceMaxRecords
+}
+
+// DeadLetterQueue is the dead-letter queue config of an edge.
+type DeadLetterQueue struct {
+	// MaxRetries is the number of times a failed message is retried before it's written to the dead-letter buffer.
+	// Defaults to 3.
+	// +optional
+	MaxRetries *uint32 `json:"maxRetries,omitempty" protobuf:"varint,1,opt,name=maxRetries"`
+}
+
+// GetMaxRetries returns the number of retries before a message is dead-lettered.
+func (dlq DeadLetterQueue) GetMaxRetries() int {
+	if dlq.MaxRetries != nil {
+		return int(*dlq.MaxRetries)
+	}
+	return DefaultDeadLetterQueueMaxRetries
 }
 
 type ForwardConditions struct {
@@ -328,6 +819,67 @@ type PipelineStatus struct {
 	Phase       PipelinePhase `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase,casttype=PipelinePhase"`
 	Message     string        `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
 	LastUpdated metav1.Time   `json:"lastUpdated,omitempty" protobuf:"bytes,4,opt,name=lastUpdated"`
+	// PendingChanges are the disruptive changes waiting for confirmation, see spec.lifecycle.confirmDisruptiveChanges.
+	// +optional
+	PendingChanges *PlannedChanges `json:"pendingChanges,omitempty" protobuf:"bytes,5,opt,name=pendingChanges"`
+	// ObservedGeneration is the generation of the pipeline spec last applied, i.e. without the disruptive changes
+	// pending for confirmation, and with the desired phase reached, the status reflects the spec changes once it
+	// equals to metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,6,opt,name=observedGeneration"`
+	// Vertices summarizes the phases of the vertices, the details of each vertex are in the status of the Vertex object,
+	// so that the status doesn't grow with the number of the vertices.
+	// +optional
+	Vertices *VerticesSummary `json:"vertices,omitempty" protobuf:"bytes,7,opt,name=vertices"`
+	// ObservedOperationID is the numaflow.numaproj.io/operation-id annotation of the last finished lifecycle operation,
+	// it's set once the pipeline is paused, resumed, or the spec is applied.
+	// +optional
+	ObservedOperationID string `json:"observedOperationID,omitempty" protobuf:"bytes,8,opt,name=observedOperationID"`
+}
+
+// VerticesSummary is the number of the vertices of a pipeline in each phase.
+type VerticesSummary struct {
+	Total int32 `json:"total" protobuf:"varint,1,opt,name=total"`
+	// +optional
+	Pending int32 `json:"pending,omitempty" protobuf:"varint,2,opt,name=pending"`
+	// +optional
+	Running int32 `json:"running,omitempty" protobuf:"varint,3,opt,name=running"`
+	// +optional
+	Succeeded int32 `json:"succeeded,omitempty" protobuf:"varint,4,opt,name=succeeded"`
+	// +optional
+	Failed int32 `json:"failed,omitempty" protobuf:"varint,5,opt,name=failed"`
+}
+
+// PlannedChanges are the objects to be created, updated or deleted to apply a pipeline spec change.
+type PlannedChanges struct {
+	// Hash of the planned changes, used to confirm them.
+	Hash string `json:"hash" protobuf:"bytes,1,opt,name=hash"`
+	// +optional
+	BuffersToCreate []string `json:"buffersToCreate,omitempty" protobuf:"bytes,2,rep,name=buffersToCreate"`
+	// +optional
+	BuffersToDelete []string `json:"buffersToDelete,omitempty" protobuf:"bytes,3,rep,name=buffersToDelete"`
+	// BuffersToMigrate are the old buffer names to the new buffer names of the renamed vertices.
+	// +optional
+	BuffersToMigrate map[string]string `json:"buffersToMigrate,omitempty" protobuf:"bytes,4,rep,name=buffersToMigrate"`
+	// +optional
+	VerticesToCreate []string `json:"verticesToCreate,omitempty" protobuf:"bytes,5,rep,name=verticesToCreate"`
+	// +optional
+	VerticesToUpdate []string `json:"verticesToUpdate,omitempty" protobuf:"bytes,6,rep,name=verticesToUpdate"`
+	// +optional
+	VerticesToDelete []string `json:"verticesToDelete,omitempty" protobuf:"bytes,7,rep,name=verticesToDelete"`
+	// +optional
+	DeploymentsToCreate []string `json:"deploymentsToCreate,omitempty" protobuf:"bytes,8,rep,name=deploymentsToCreate"`
+	// +optional
+	DeploymentsToUpdate []string `json:"deploymentsToUpdate,omitempty" protobuf:"bytes,9,rep,name=deploymentsToUpdate"`
+	// Omitted is the number of the changes not listed, to keep the status of a large pipeline small. The hash covers
+	// all the changes.
+	// +optional
+	Omitted int32 `json:"omitted,omitempty" protobuf:"varint,10,opt,name=omitted"`
+}
+
+// IsDisruptive tells if any buffer is added, removed or migrated.
+func (pc PlannedChanges) IsDisruptive() bool {
+	return len(pc.BuffersToCreate) > 0 || len(pc.BuffersToDelete) > 0 || len(pc.BuffersToMigrate) > 0
 }
 
 func (pls *PipelineStatus) SetPhase(phase PipelinePhase, msg string) {
@@ -351,11 +903,39 @@ func (pls *PipelineStatus) MarkNotConfigured(reason, message string) {
 	pls.SetPhase(PipelinePhaseFailed, message)
 }
 
+// MarkConfiguredWithWarnings set the Pipeline has valid configuration, with the warnings of it in the message.
+func (pls *PipelineStatus) MarkConfiguredWithWarnings(reason, message string) {
+	pls.MarkTrueWithReason(PipelineConditionConfigured, reason, message)
+}
+
 // MarkDeployed set the Pipeline has been deployed.
 func (pls *PipelineStatus) MarkDeployed() {
 	pls.MarkTrue(PipelineConditionDeployed)
 }
 
+// MarkDegraded set the Pipeline is degraded by failing vertices.
+func (pls *PipelineStatus) MarkDegraded(reason, message string) {
+	pls.MarkTrueWithReason(PipelineConditionDegraded, reason, message)
+}
+
+// MarkNotDegraded removes the Degraded condition.
+func (pls *PipelineStatus) MarkNotDegraded() {
+	pls.RemoveCondition(PipelineConditionDegraded)
+}
+
+// IsReady returns true when the Pipeline is configured and deployed, and not degraded.
+func (pls *PipelineStatus) IsReady() bool {
+	if pls.GetCondition(PipelineConditionDegraded) != nil {
+		return false
+	}
+	for _, t := range []ConditionType{PipelineConditionConfigured, PipelineConditionDeployed} {
+		if c := pls.GetCondition(t); c == nil || c.Status != metav1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
+
 // MarkPhaseRunning set the Pipeline has been running.
 func (pls *PipelineStatus) MarkPhaseRunning() {
 	pls.SetPhase(PipelinePhaseRunning, "")
@@ -377,6 +957,11 @@ func (pls *PipelineStatus) MarkPhasePausing() {
 	pls.SetPhase(PipelinePhasePausing, "Pausing in progess")
 }
 
+// MarkPhaseCompleted set the Pipeline has been completed.
+func (pls *PipelineStatus) MarkPhaseCompleted() {
+	pls.SetPhase(PipelinePhaseCompleted, "Pipeline completed")
+}
+
 // MarkPhaseDeleting set the Pipeline is deleting.
 func (pls *PipelineStatus) MarkPhaseDeleting() {
 	pls.SetPhase(PipelinePhaseDeleting, "Deleting in progress")
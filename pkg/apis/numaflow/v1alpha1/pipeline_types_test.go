@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,18 @@ func Test_GetToEdges(t *testing.T) {
 	assert.Equal(t, 0, len(es))
 }
 
+func Test_IsBounded(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	assert.False(t, pl.IsBounded())
+	n := int64(100)
+	pl.Spec.Vertices[0].Source = &Source{Generator: &GeneratorSource{MaxMessages: &n}}
+	assert.True(t, pl.IsBounded())
+	pl.Spec.Vertices = append(pl.Spec.Vertices, AbstractVertex{Name: "input2", Source: &Source{}})
+	assert.False(t, pl.IsBounded())
+	pl.Spec.Vertices = pl.Spec.Vertices[1:3]
+	assert.False(t, pl.IsBounded())
+}
+
 func Test_GetFromEdges(t *testing.T) {
 	es := testPipeline.GetFromEdges("p1")
 	assert.Equal(t, 1, len(es))
@@ -52,6 +65,81 @@ func Test_GetAllBuffers(t *testing.T) {
 	assert.Equal(t, 2, len(s))
 	assert.Contains(t, s, testPipeline.Namespace+"-"+testPipeline.Name+"-input-p1")
 	assert.Contains(t, s, testPipeline.Namespace+"-"+testPipeline.Name+"-p1-output")
+
+	pl := testPipeline.DeepCopy()
+	pl.Spec.Edges[0].DeadLetterQueue = &DeadLetterQueue{}
+	s = pl.GetAllBuffers()
+	assert.Equal(t, 3, len(s))
+	assert.Contains(t, s, testPipeline.Namespace+"-"+testPipeline.Name+"-input-p1-dlq")
+}
+
+func Test_ReplyBuffers(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	assert.Equal(t, 0, len(pl.GetReplyBuffers()))
+	max := int32(2)
+	pl.Spec.Vertices[0].Source = &Source{HTTP: &HTTPSource{RequestReply: &RequestReply{}}}
+	pl.Spec.Vertices[0].Scale.Max = &max
+	pl.Spec.Vertices[2].Sink = &Sink{Reply: &ReplySink{}}
+	replyBuffers := []string{testPipeline.Namespace + "-" + testPipeline.Name + "-input-reply-0", testPipeline.Namespace + "-" + testPipeline.Name + "-input-reply-1"}
+	assert.Equal(t, replyBuffers, pl.GetReplyBuffers())
+	s := pl.GetAllBuffers()
+	assert.Equal(t, 4, len(s))
+	assert.Contains(t, s, replyBuffers[1])
+	from, to := pl.FindVerticesWithBuffer(replyBuffers[1])
+	assert.Equal(t, "output", from.Name)
+	assert.Equal(t, "input", to.Name)
+	from, to = pl.FindVerticesWithBuffer(testPipeline.Namespace + "-" + testPipeline.Name + "-input-reply-2")
+	assert.Nil(t, from)
+	assert.Nil(t, to)
+}
+
+func TestDeadLetterQueue_GetMaxRetries(t *testing.T) {
+	dlq := DeadLetterQueue{}
+	assert.Equal(t, DefaultDeadLetterQueueMaxRetries, dlq.GetMaxRetries())
+	x := uint32(0)
+	dlq.MaxRetries = &x
+	assert.Equal(t, 0, dlq.GetMaxRetries())
+}
+
+func TestEdgeLimits_IsExactlyOnce(t *testing.T) {
+	var el *EdgeLimits
+	assert.True(t, el.IsExactlyOnce(true))
+	assert.False(t, el.IsExactlyOnce(false))
+	el = &EdgeLimits{}
+	assert.True(t, el.IsExactlyOnce(true))
+	assert.False(t, el.IsExactlyOnce(false))
+	exactlyOnce := false
+	el.ExactlyOnce = &exactlyOnce
+	assert.False(t, el.IsExactlyOnce(true))
+	exactlyOnce = true
+	assert.True(t, el.IsExactlyOnce(false))
+}
+
+func TestEdgeLimits_GetDurability(t *testing.T) {
+	var el *EdgeLimits
+	assert.Equal(t, WriteDurabilityAcknowledged, el.GetDurability())
+	el = &EdgeLimits{}
+	assert.Equal(t, WriteDurabilityAcknowledged, el.GetDurability())
+	el.Durability = WriteDurabilityNone
+	assert.Equal(t, WriteDurabilityNone, el.GetDurability())
+}
+
+func TestEdgeLimits_GetOnFull(t *testing.T) {
+	var el *EdgeLimits
+	assert.Equal(t, OnFullRetryUntilSuccess, el.GetOnFull())
+	el = &EdgeLimits{}
+	assert.Equal(t, OnFullRetryUntilSuccess, el.GetOnFull())
+	el.OnFull = OnFullDiscardOldest
+	assert.Equal(t, OnFullDiscardOldest, el.GetOnFull())
+}
+
+func TestEdgeLimits_GetMaxMessageAge(t *testing.T) {
+	var el *EdgeLimits
+	assert.Equal(t, time.Duration(0), el.GetMaxMessageAge())
+	el = &EdgeLimits{}
+	assert.Equal(t, time.Duration(0), el.GetMaxMessageAge())
+	el.MaxMessageAge = &metav1.Duration{Duration: time.Hour}
+	assert.Equal(t, time.Hour, el.GetMaxMessageAge())
 }
 
 func Test_GetVertex(t *testing.T) {
@@ -72,6 +160,24 @@ func Test_FindVertexWithBuffer(t *testing.T) {
 	assert.Equal(t, "p1", vTo.Name)
 }
 
+func TestLifecycle_GetDeletionPolicy(t *testing.T) {
+	lc := Lifecycle{}
+	assert.Equal(t, DeletionPolicyDelete, lc.GetDeletionPolicy())
+	lc.DeletionPolicy = DeletionPolicyOrphan
+	assert.Equal(t, DeletionPolicyOrphan, lc.GetDeletionPolicy())
+}
+
+func TestGetCorrelationID(t *testing.T) {
+	pl := testPipeline.DeepCopy()
+	assert.Equal(t, "", pl.GetCorrelationID())
+	pl.UID = "3f2a9c1e-4b7d-4e2a-9c1e-4b7d3f2a9c1e"
+	assert.Equal(t, "3f2a9c1e4b7d", pl.GetCorrelationID())
+	v := testVertex.DeepCopy()
+	assert.Equal(t, "", v.GetCorrelationID())
+	v.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(pl.GetObjectMeta(), PipelineGroupVersionKind)}
+	assert.Equal(t, "3f2a9c1e4b7d", v.GetCorrelationID())
+}
+
 func TestGetDaemonServiceName(t *testing.T) {
 	n := testPipeline.GetDaemonServiceName()
 	assert.Equal(t, testPipeline.Name+"-daemon-svc", n)
@@ -115,6 +221,18 @@ func TestGetDaemonDeploy(t *testing.T) {
 		assert.Contains(t, envNames, "test-env")
 	})
 
+	t.Run("test get deployment obj with template", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Templates = &Templates{Daemon: &AbstractPodTemplate{
+			NodeSelector:      map[string]string{"pool": "system"},
+			PriorityClassName: "high",
+		}}
+		s, err := pl.GetDaemonDeploymentObj(req)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"pool": "system"}, s.Spec.Template.Spec.NodeSelector)
+		assert.Equal(t, "high", s.Spec.Template.Spec.PriorityClassName)
+	})
+
 	t.Run("test get init container", func(t *testing.T) {
 		c := testPipeline.getDaemonPodInitContainer(req)
 		assert.Equal(t, CtrInit, c.Name)
@@ -171,6 +289,16 @@ func Test_PipelineMarkStatus(t *testing.T) {
 		}
 	}
 	assert.True(t, s.IsReady())
+	s.MarkDegraded("VertexFailing", "message")
+	c := s.GetCondition(PipelineConditionDegraded)
+	assert.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, "message", c.Message)
+	assert.False(t, s.IsReady())
+	s.MarkNotDegraded()
+	assert.Nil(t, s.GetCondition(PipelineConditionDegraded))
+	assert.Equal(t, 2, len(s.Conditions))
+	assert.True(t, s.IsReady())
 }
 
 func Test_PipelineMarkPhases(t *testing.T) {
@@ -184,3 +312,39 @@ func Test_PipelineMarkPhases(t *testing.T) {
 	s.MarkPhaseRunning()
 	assert.Equal(t, PipelinePhaseRunning, s.Phase)
 }
+
+func Test_PipelineMetricsExport(t *testing.T) {
+	var pm *PipelineMetrics
+	assert.Nil(t, pm.GetExport())
+	pm = &PipelineMetrics{Export: &MetricsExport{Protocol: MetricsExportProtocolStatsD, Endpoint: "statsd:8125"}}
+	assert.Equal(t, "statsd:8125", pm.GetExport().Endpoint)
+	assert.Equal(t, DefaultMetricsExportInterval, pm.GetExport().GetInterval())
+	pm.Export.Interval = &metav1.Duration{Duration: 10 * time.Second}
+	assert.Equal(t, 10*time.Second, pm.GetExport().GetInterval())
+}
+
+func Test_PipelineMetricsHistoryRetention(t *testing.T) {
+	var pm *PipelineMetrics
+	assert.Equal(t, DefaultMetricsHistoryRetention, pm.GetHistoryRetention())
+	pm = &PipelineMetrics{HistoryRetention: &metav1.Duration{Duration: 2 * time.Hour}}
+	assert.Equal(t, 2*time.Hour, pm.GetHistoryRetention())
+}
+
+func Test_PipelineAuditRetention(t *testing.T) {
+	var pa *PipelineAudit
+	assert.Equal(t, DefaultAuditRetention, pa.GetRetention())
+	pa = &PipelineAudit{Retention: &metav1.Duration{Duration: 2 * time.Hour}}
+	assert.Equal(t, 2*time.Hour, pa.GetRetention())
+}
+
+func Test_PipelineTracing(t *testing.T) {
+	var pt *PipelineTracing
+	assert.Equal(t, uint32(DefaultTracingSamplingPercentage), pt.GetSamplingPercentage())
+	assert.Equal(t, DefaultTracingExportInterval, pt.GetInterval())
+	ten, twoHundred := uint32(10), uint32(200)
+	pt = &PipelineTracing{SamplingPercentage: &ten, Interval: &metav1.Duration{Duration: time.Second}}
+	assert.Equal(t, uint32(10), pt.GetSamplingPercentage())
+	assert.Equal(t, time.Second, pt.GetInterval())
+	pt.SamplingPercentage = &twoHundred
+	assert.Equal(t, uint32(100), pt.GetSamplingPercentage())
+}
@@ -0,0 +1,163 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultRunAsUser is the user ID the generated containers run as, if not set otherwise
+	DefaultRunAsUser = int64(9737)
+
+	// AppArmorAnnotationKeyPrefix is the prefix of the pod annotations setting the AppArmor profiles of the containers
+	AppArmorAnnotationKeyPrefix = "container.apparmor.security.beta.kubernetes.io/"
+	// AppArmorProfileRuntimeDefault is the default AppArmor profile of the container runtime
+	AppArmorProfileRuntimeDefault = "runtime/default"
+
+	tmpVolumeName = "security-tmp"
+)
+
+// PodSecurity configures the security defaults of the generated pods. By default, the containers run as a non-root
+// user, with the RuntimeDefault seccomp and AppArmor profiles, no privilege escalation, no capabilities and a read-only
+// root filesystem, which passes the "restricted" Pod Security Standard. The defaults only apply to the fields not set
+// in the pod or container security contexts.
+type PodSecurity struct {
+	// Disabled turns off the security defaults, e.g. for the UDF images which need to run as root.
+	// +optional
+	Disabled bool `json:"disabled,omitempty" protobuf:"varint,1,opt,name=disabled"`
+	// RunAsUser is the user ID the containers run as, defaults to 9737.
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty" protobuf:"varint,2,opt,name=runAsUser"`
+	// ReadOnlyRootFilesystem mounts the root filesystems of the containers as read-only, defaults to true.
+	// An emptyDir volume is mounted at /tmp for the temporary files of the containers.
+	// +optional
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty" protobuf:"varint,3,opt,name=readOnlyRootFilesystem"`
+}
+
+func (ps *PodSecurity) IsDisabled() bool {
+	return ps != nil && ps.Disabled
+}
+
+func (ps *PodSecurity) GetRunAsUser() int64 {
+	if ps == nil || ps.RunAsUser == nil {
+		return DefaultRunAsUser
+	}
+	return *ps.RunAsUser
+}
+
+func (ps *PodSecurity) GetReadOnlyRootFilesystem() bool {
+	if ps == nil || ps.ReadOnlyRootFilesystem == nil {
+		return true
+	}
+	return *ps.ReadOnlyRootFilesystem
+}
+
+// ApplyToPodSpec fills the security settings not set in the pod spec with the defaults.
+func (ps *PodSecurity) ApplyToPodSpec(spec *corev1.PodSpec) {
+	if ps.IsDisabled() {
+		return
+	}
+	// The security contexts could be shared with the specs of the custom resources, they are copied before updated.
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	} else {
+		spec.SecurityContext = spec.SecurityContext.DeepCopy()
+	}
+	psc := spec.SecurityContext
+	if psc.RunAsUser == nil {
+		runAsUser := ps.GetRunAsUser()
+		psc.RunAsUser = &runAsUser
+	}
+	if psc.RunAsNonRoot == nil && *psc.RunAsUser != 0 {
+		runAsNonRoot := true
+		psc.RunAsNonRoot = &runAsNonRoot
+	}
+	if psc.FSGroup == nil {
+		// Makes the persistent volumes writable by the non-root user.
+		fsGroup := *psc.RunAsUser
+		psc.FSGroup = &fsGroup
+	}
+	if psc.SeccompProfile == nil {
+		psc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	readOnly := false
+	for i := range spec.InitContainers {
+		readOnly = ps.applyToContainer(&spec.InitContainers[i]) || readOnly
+	}
+	for i := range spec.Containers {
+		readOnly = ps.applyToContainer(&spec.Containers[i]) || readOnly
+	}
+	if readOnly {
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name:         tmpVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+}
+
+// applyToContainer fills the security settings not set in the container with the defaults, and returns whether the
+// tmp volume is mounted for its read-only root filesystem.
+func (ps *PodSecurity) applyToContainer(c *corev1.Container) bool {
+	if c.SecurityContext == nil {
+		c.SecurityContext = &corev1.SecurityContext{}
+	} else {
+		c.SecurityContext = c.SecurityContext.DeepCopy()
+	}
+	sc := c.SecurityContext
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 && sc.RunAsNonRoot == nil {
+		// The containers explicitly running as root are exempted from the non-root pod default.
+		runAsNonRoot := false
+		sc.RunAsNonRoot = &runAsNonRoot
+	}
+	if sc.AllowPrivilegeEscalation == nil {
+		allowPrivilegeEscalation := false
+		sc.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+	}
+	if sc.ReadOnlyRootFilesystem == nil {
+		readOnly := ps.GetReadOnlyRootFilesystem()
+		sc.ReadOnlyRootFilesystem = &readOnly
+	}
+	if !*sc.ReadOnlyRootFilesystem {
+		return false
+	}
+	for _, m := range c.VolumeMounts {
+		if m.MountPath == "/tmp" {
+			return false
+		}
+	}
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: tmpVolumeName, MountPath: "/tmp"})
+	return true
+}
+
+// applyPodSecurity applies the security defaults to the pod template, including the AppArmor annotations.
+func applyPodSecurity(ps *PodSecurity, template *corev1.PodTemplateSpec) {
+	ps.ApplyToPodSpec(&template.Spec)
+	annotations := ps.AppArmorAnnotations(&template.Spec, template.Annotations)
+	if len(annotations) == 0 {
+		return
+	}
+	// The annotations could be shared with the spec of the custom resource.
+	for k, v := range template.Annotations {
+		annotations[k] = v
+	}
+	template.Annotations = annotations
+}
+
+// AppArmorAnnotations returns the pod annotations setting the RuntimeDefault AppArmor profile for the containers
+// without one in the existing annotations.
+func (ps *PodSecurity) AppArmorAnnotations(spec *corev1.PodSpec, existing map[string]string) map[string]string {
+	result := make(map[string]string)
+	if ps.IsDisabled() {
+		return result
+	}
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		key := AppArmorAnnotationKeyPrefix + c.Name
+		if _, ok := existing[key]; !ok {
+			result[key] = AppArmorProfileRuntimeDefault
+		}
+	}
+	return result
+}
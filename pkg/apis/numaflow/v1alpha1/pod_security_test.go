@@ -0,0 +1,89 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestPodSecurity_Getters(t *testing.T) {
+	var ps *PodSecurity
+	assert.False(t, ps.IsDisabled())
+	assert.Equal(t, DefaultRunAsUser, ps.GetRunAsUser())
+	assert.True(t, ps.GetReadOnlyRootFilesystem())
+	ps = &PodSecurity{Disabled: true, RunAsUser: pointer.Int64(1000), ReadOnlyRootFilesystem: pointer.Bool(false)}
+	assert.True(t, ps.IsDisabled())
+	assert.Equal(t, int64(1000), ps.GetRunAsUser())
+	assert.False(t, ps.GetReadOnlyRootFilesystem())
+}
+
+func TestPodSecurity_ApplyToPodSpec(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		var ps *PodSecurity
+		spec := &corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: CtrInit}},
+			Containers:     []corev1.Container{{Name: CtrMain}, {Name: CtrUdf}},
+		}
+		ps.ApplyToPodSpec(spec)
+		assert.Equal(t, DefaultRunAsUser, *spec.SecurityContext.RunAsUser)
+		assert.Equal(t, DefaultRunAsUser, *spec.SecurityContext.FSGroup)
+		assert.True(t, *spec.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, spec.SecurityContext.SeccompProfile.Type)
+		for _, c := range append(spec.InitContainers, spec.Containers...) {
+			assert.False(t, *c.SecurityContext.AllowPrivilegeEscalation)
+			assert.Equal(t, []corev1.Capability{"ALL"}, c.SecurityContext.Capabilities.Drop)
+			assert.True(t, *c.SecurityContext.ReadOnlyRootFilesystem)
+			assert.Equal(t, []corev1.VolumeMount{{Name: tmpVolumeName, MountPath: "/tmp"}}, c.VolumeMounts)
+		}
+		assert.Len(t, spec.Volumes, 1)
+		assert.Equal(t, tmpVolumeName, spec.Volumes[0].Name)
+	})
+
+	t.Run("existing settings are kept", func(t *testing.T) {
+		ps := &PodSecurity{ReadOnlyRootFilesystem: pointer.Bool(false)}
+		podSC := &corev1.PodSecurityContext{RunAsUser: pointer.Int64(0)}
+		ctrSC := &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}}}
+		spec := &corev1.PodSpec{
+			SecurityContext: podSC,
+			Containers:      []corev1.Container{{Name: CtrMain, SecurityContext: ctrSC}},
+		}
+		ps.ApplyToPodSpec(spec)
+		assert.Nil(t, podSC.SeccompProfile, "shared security context should not be updated")
+		assert.Nil(t, ctrSC.AllowPrivilegeEscalation, "shared security context should not be updated")
+		assert.Equal(t, int64(0), *spec.SecurityContext.RunAsUser)
+		assert.Nil(t, spec.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, []corev1.Capability{"NET_BIND_SERVICE"}, spec.Containers[0].SecurityContext.Capabilities.Add)
+		assert.False(t, *spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+		assert.Empty(t, spec.Volumes)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ps := &PodSecurity{Disabled: true}
+		spec := &corev1.PodSpec{Containers: []corev1.Container{{Name: CtrMain}}}
+		ps.ApplyToPodSpec(spec)
+		assert.Nil(t, spec.SecurityContext)
+		assert.Nil(t, spec.Containers[0].SecurityContext)
+		assert.Empty(t, ps.AppArmorAnnotations(spec, nil))
+	})
+}
+
+func TestPodSecurity_AppArmorAnnotations(t *testing.T) {
+	var ps *PodSecurity
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: CtrInit}},
+		Containers:     []corev1.Container{{Name: CtrMain}},
+	}
+	a := ps.AppArmorAnnotations(spec, map[string]string{AppArmorAnnotationKeyPrefix + CtrMain: "unconfined"})
+	assert.Equal(t, map[string]string{AppArmorAnnotationKeyPrefix + CtrInit: AppArmorProfileRuntimeDefault}, a)
+}
+
+func Test_applyPodSecurity(t *testing.T) {
+	annotations := map[string]string{"a": "b"}
+	template := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: CtrMain}}}}
+	template.Annotations = annotations
+	applyPodSecurity(nil, template)
+	assert.Equal(t, map[string]string{"a": "b", AppArmorAnnotationKeyPrefix + CtrMain: AppArmorProfileRuntimeDefault}, template.Annotations)
+	assert.Len(t, annotations, 1)
+}
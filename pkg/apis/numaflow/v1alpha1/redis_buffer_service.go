@@ -7,6 +7,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 type RedisBuferService struct {
@@ -35,6 +36,34 @@ type RedisConfig struct {
 	// Sentinel password secret selector
 	// +optional
 	SentinelPassword *corev1.SecretKeySelector `json:"sentinelPassword,omitempty" protobuf:"bytes,6,opt,name=sentinelPassword"`
+	// TLS configuration for the connections to Redis, the connections are not encrypted if it's not specified
+	// +optional
+	TLS *TLS `json:"tls,omitempty" protobuf:"bytes,7,opt,name=tls"`
+	// Managed is specified if it's a managed Redis offering, e.g. Amazon ElastiCache or MemoryDB, which comes with
+	// the restrictions of a cluster-mode-disabled primary endpoint, and some commands not available
+	// +optional
+	Managed *ManagedRedis `json:"managed,omitempty" protobuf:"bytes,8,opt,name=managed"`
+	// Cluster is true if it's a Redis Cluster, the Redis URL is a comma separated list of the seed nodes then. The
+	// keys of a buffer are hash tagged to the same slot, so that the buffers are spread across the shards.
+	// +optional
+	Cluster bool `json:"cluster,omitempty" protobuf:"varint,9,opt,name=cluster"`
+}
+
+// ManagedRedis describes the restrictions of a managed Redis offering. Only the primary endpoint of a cluster-mode-disabled
+// deployment is supported, which is given as the Redis URL.
+type ManagedRedis struct {
+	// RequireTLS fails the validation if TLS is not configured, e.g. for a deployment with the in-transit encryption
+	// +optional
+	RequireTLS bool `json:"requireTLS,omitempty" protobuf:"varint,1,opt,name=requireTLS"`
+	// DisabledCommands are the commands not available on the offering, e.g. CONFIG. The buffer creation fails if any
+	// of them is used by the ISB Service.
+	// +kubebuilder:default={"CONFIG"}
+	// +optional
+	DisabledCommands []string `json:"disabledCommands,omitempty" protobuf:"bytes,2,rep,name=disabledCommands"`
+	// PasswordRotation mounts the password secret to the pods using the ISB Service, where it's read by each new
+	// connection, so that the auth token can be rotated by updating the secret, without restarting the pods.
+	// +optional
+	PasswordRotation bool `json:"passwordRotation,omitempty" protobuf:"varint,3,opt,name=passwordRotation"`
 }
 
 type NativeRedis struct {
@@ -103,6 +132,10 @@ type NativeRedis struct {
 	// Redis configuration, if not specified, global settings in numaflow-controller-config will be used.
 	// +optional
 	Settings *RedisSettings `json:"settings,omitempty" protobuf:"bytes,16,opt,name=settings"`
+	// PodSecurity overrides the security defaults of the Redis pods. The root filesystems are not read-only by default,
+	// because the Redis images write their configurations there.
+	// +optional
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty" protobuf:"bytes,17,opt,name=podSecurity"`
 }
 
 type RedisSettings struct {
@@ -408,18 +441,23 @@ redis_exporter`},
 		runAsUser0 := int64(0)
 		spec.Template.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{RunAsUser: &runAsUser}
 		spec.Template.Spec.Containers[1].SecurityContext = &corev1.SecurityContext{RunAsUser: &runAsUser}
-		spec.Template.Spec.InitContainers = []corev1.Container{
-			{
-				Name:            "volume-permissions",
-				Resources:       standardResources,
-				SecurityContext: &corev1.SecurityContext{RunAsUser: &runAsUser0},
-				VolumeMounts:    []corev1.VolumeMount{{Name: req.PvcNameIfNeeded, MountPath: "/data"}},
-				Image:           req.InitContainerImage,
-				Command:         []string{"/bin/bash", "-ec", "chown -R 1001:1001 /data"},
-			},
+		if nr.PodSecurity.IsDisabled() {
+			// The fsGroup is enough for the volume permission of the non-root pods.
+			spec.Template.Spec.InitContainers = []corev1.Container{
+				{
+					Name:            "volume-permissions",
+					Resources:       standardResources,
+					SecurityContext: &corev1.SecurityContext{RunAsUser: &runAsUser0},
+					VolumeMounts:    []corev1.VolumeMount{{Name: req.PvcNameIfNeeded, MountPath: "/data"}},
+					Image:           req.InitContainerImage,
+					Command:         []string{"/bin/bash", "-ec", "chown -R 1001:1001 /data"},
+				},
+			}
 		}
 		if spec.Template.Spec.SecurityContext == nil {
 			spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
+		} else {
+			spec.Template.Spec.SecurityContext = spec.Template.Spec.SecurityContext.DeepCopy()
 		}
 		spec.Template.Spec.SecurityContext.FSGroup = &fsGroup
 	} else {
@@ -434,5 +472,13 @@ redis_exporter`},
 		vm1s = append(vm1s, corev1.VolumeMount{Name: emptyDirVolName, MountPath: "/data"})
 		spec.Template.Spec.Containers[1].VolumeMounts = vm1s
 	}
+	podSecurity := nr.PodSecurity.DeepCopy()
+	if podSecurity == nil {
+		podSecurity = &PodSecurity{}
+	}
+	if podSecurity.ReadOnlyRootFilesystem == nil {
+		podSecurity.ReadOnlyRootFilesystem = pointer.Bool(false)
+	}
+	applyPodSecurity(podSecurity, &spec.Template)
 	return spec
 }
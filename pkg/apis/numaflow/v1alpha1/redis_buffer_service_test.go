@@ -49,11 +49,15 @@ func TestRedisGetStatefulSetSpec(t *testing.T) {
 		}
 		spec := s.GetStatefulSetSpec(req)
 		assert.True(t, len(spec.VolumeClaimTemplates) > 0)
-		assert.True(t, len(spec.Template.Spec.InitContainers) > 0)
+		assert.Empty(t, spec.Template.Spec.InitContainers)
 		assert.NotNil(t, spec.Template.Spec.SecurityContext)
 		assert.NotNil(t, spec.Template.Spec.Containers[0].SecurityContext)
 		assert.NotNil(t, spec.Template.Spec.Containers[1].SecurityContext)
-		assert.NotNil(t, spec.Template.Spec.SecurityContext.FSGroup)
+		assert.Equal(t, int64(1001), *spec.Template.Spec.SecurityContext.FSGroup)
+		assert.False(t, *spec.Template.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+		s.PodSecurity = &PodSecurity{Disabled: true}
+		spec = s.GetStatefulSetSpec(req)
+		assert.True(t, len(spec.Template.Spec.InitContainers) > 0)
 	})
 }
 
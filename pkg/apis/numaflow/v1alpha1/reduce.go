@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Reduce groups the messages by key in the windows of their event time, and aggregates the messages of each key in
+// a window when the watermark passes the end of the window.
+type Reduce struct {
+	// Window defines how the messages are grouped by their event time.
+	Window Window `json:"window" protobuf:"bytes,1,opt,name=window"`
+	// Builtin is the reduce function aggregating the messages of a key in a window.
+	Builtin *ReduceFunction `json:"builtin" protobuf:"bytes,2,opt,name=builtin"`
+	// AllowedLateness is how long the watermark of the vertex trails the largest event time read, so that the messages
+	// arriving out of order within it are still counted in their windows, defaults to 0. The messages arriving after
+	// their windows are closed are dropped.
+	// +optional
+	AllowedLateness *metav1.Duration `json:"allowedLateness,omitempty" protobuf:"bytes,3,opt,name=allowedLateness"`
+}
+
+type Window struct {
+	// Fixed windows, a.k.a. tumbling windows, are the non-overlapping windows of a fixed length, aligned to the epoch.
+	// +optional
+	Fixed *FixedWindow `json:"fixed,omitempty" protobuf:"bytes,1,opt,name=fixed"`
+}
+
+type FixedWindow struct {
+	// Length of the windows.
+	Length *metav1.Duration `json:"length" protobuf:"bytes,1,opt,name=length"`
+}
+
+type ReduceFunction struct {
+	// Name of the function, "count" counts the messages, "sum", "min" and "max" take the payloads as numbers.
+	// +kubebuilder:validation:Enum=count;sum;min;max
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+}
+
+func (w FixedWindow) GetLength() time.Duration {
+	if w.Length == nil {
+		return 0
+	}
+	return w.Length.Duration
+}
+
+func (r Reduce) GetAllowedLateness() time.Duration {
+	if r.AllowedLateness == nil {
+		return 0
+	}
+	return r.AllowedLateness.Duration
+}
+
+// getContainers returns the main container only, the built-in reduce functions run in-process.
+func (r Reduce) getContainers(req getContainerReq) ([]corev1.Container, error) {
+	return []corev1.Container{
+		containerBuilder{}.init(req).args("processor", "--type=reduce", "--isbsvc-type="+string(req.isbSvcType)).build(),
+	}, nil
+}
@@ -0,0 +1,32 @@
+package v1alpha1
+
+import corev1 "k8s.io/api/core/v1"
+
+// +kubebuilder:validation:Enum="";PLAIN;SCRAM-SHA-256;SCRAM-SHA-512
+type SASLMechanism string
+
+const (
+	// SASLMechanismPlain authenticates with a plain text user name and password
+	SASLMechanismPlain SASLMechanism = "PLAIN"
+	// SASLMechanismSCRAMSHA256 authenticates with SCRAM using SHA-256
+	SASLMechanismSCRAMSHA256 SASLMechanism = "SCRAM-SHA-256"
+	// SASLMechanismSCRAMSHA512 authenticates with SCRAM using SHA-512
+	SASLMechanismSCRAMSHA512 SASLMechanism = "SCRAM-SHA-512"
+)
+
+type SASL struct {
+	// Mechanism is one of PLAIN, SCRAM-SHA-256 and SCRAM-SHA-512, defaults to PLAIN.
+	// +optional
+	Mechanism SASLMechanism `json:"mechanism,omitempty" protobuf:"bytes,1,opt,name=mechanism,casttype=SASLMechanism"`
+	// UserSecret refers to the secret that contains the user name
+	UserSecret *corev1.SecretKeySelector `json:"userSecret" protobuf:"bytes,2,opt,name=userSecret"`
+	// PasswordSecret refers to the secret that contains the password
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret" protobuf:"bytes,3,opt,name=passwordSecret"`
+}
+
+func (s *SASL) GetMechanism() SASLMechanism {
+	if s == nil || s.Mechanism == "" {
+		return SASLMechanismPlain
+	}
+	return s.Mechanism
+}
@@ -0,0 +1,16 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSASL_GetMechanism(t *testing.T) {
+	var s *SASL
+	assert.Equal(t, SASLMechanismPlain, s.GetMechanism())
+	s = &SASL{}
+	assert.Equal(t, SASLMechanismPlain, s.GetMechanism())
+	s.Mechanism = SASLMechanismSCRAMSHA512
+	assert.Equal(t, SASLMechanismSCRAMSHA512, s.GetMechanism())
+}
@@ -0,0 +1,90 @@
+package v1alpha1
+
+import (
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const sideInputsVolumeName = "side-inputs"
+
+// SideInput is a slowly-changing reference dataset made available to the UDF, e.g. a lookup table or a model config.
+// The data is refreshed by the side-inputs manager container on an interval, and written to the file
+// /var/numaflow/side-inputs/<name>, which is replaced atomically, so that the UDF never reads a partially written file.
+type SideInput struct {
+	// Name of the side input, which is the name of the file in /var/numaflow/side-inputs.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// ConfigMap key holding the data.
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty" protobuf:"bytes,2,opt,name=configMap"`
+	// HTTP endpoint polled for the data.
+	// +optional
+	HTTP *HTTPSideInput `json:"http,omitempty" protobuf:"bytes,3,opt,name=http"`
+	// RefreshInterval is how often the data is refreshed, defaults to 60s.
+	// +kubebuilder:default="60s"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty" protobuf:"bytes,4,opt,name=refreshInterval"`
+}
+
+type HTTPSideInput struct {
+	// URL of the endpoint, the body of a 2xx response of a GET request is the data.
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// Timeout of each request, defaults to 10s.
+	// +kubebuilder:default="10s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,2,opt,name=timeout"`
+}
+
+func (si SideInput) GetRefreshInterval() time.Duration {
+	if si.RefreshInterval != nil && si.RefreshInterval.Duration > 0 {
+		return si.RefreshInterval.Duration
+	}
+	return DefaultSideInputRefreshInterval
+}
+
+func (h HTTPSideInput) GetTimeout() time.Duration {
+	if h.Timeout != nil && h.Timeout.Duration > 0 {
+		return h.Timeout.Duration
+	}
+	return DefaultSideInputHTTPTimeout
+}
+
+// applySideInputsToPodSpec adds the side-inputs volume, mounted read-only in the vertex containers, and the side-inputs
+// manager containers writing to it. The init container fetches the data once before the UDF starts, the sidecar keeps
+// refreshing it.
+func (v Vertex) applySideInputsToPodSpec(spec *corev1.PodSpec, req GetVertexPodSpecReq, encodedVertexSpec string) {
+	if len(v.Spec.SideInputs) == 0 {
+		return
+	}
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name:         sideInputsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	for i := range spec.Containers {
+		c := &spec.Containers[i]
+		// Copied before appended, as the volume mounts could share the backing array among the containers.
+		c.VolumeMounts = append(append([]corev1.VolumeMount{}, c.VolumeMounts...), corev1.VolumeMount{Name: sideInputsVolumeName, MountPath: PathSideInputs, ReadOnly: true})
+	}
+	envVars := []corev1.EnvVar{
+		{Name: EnvVertexObject, Value: encodedVertexSpec},
+		{Name: EnvVertexObjectVersion, Value: strconv.Itoa(VertexEncodingVersion)},
+	}
+	envVars = append(envVars, v.commonEvns()...)
+	envVars = append(envVars, req.Env...)
+	c := corev1.Container{
+		Env:             envVars,
+		Image:           req.Image,
+		ImagePullPolicy: req.PullPolicy,
+		Resources:       standardResources,
+		VolumeMounts:    []corev1.VolumeMount{{Name: sideInputsVolumeName, MountPath: PathSideInputs}},
+	}
+	initCtr := *c.DeepCopy()
+	initCtr.Name = CtrSideInputsInit
+	initCtr.Args = []string{"side-inputs-manager", "--once"}
+	spec.InitContainers = append(spec.InitContainers, initCtr)
+	c.Name = CtrSideInputs
+	c.Args = []string{"side-inputs-manager"}
+	spec.Containers = append(spec.Containers, c)
+}
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSideInput_Defaults(t *testing.T) {
+	si := SideInput{HTTP: &HTTPSideInput{}}
+	assert.Equal(t, DefaultSideInputRefreshInterval, si.GetRefreshInterval())
+	assert.Equal(t, DefaultSideInputHTTPTimeout, si.HTTP.GetTimeout())
+	si.RefreshInterval = &metav1.Duration{Duration: 5 * time.Minute}
+	si.HTTP.Timeout = &metav1.Duration{Duration: time.Second}
+	assert.Equal(t, 5*time.Minute, si.GetRefreshInterval())
+	assert.Equal(t, time.Second, si.HTTP.GetTimeout())
+}
+
+func TestVertex_applySideInputsToPodSpec(t *testing.T) {
+	req := GetVertexPodSpecReq{
+		ISBSvcType: ISBSvcTypeRedis,
+		Image:      testFlowImage,
+		PullPolicy: corev1.PullIfNotPresent,
+		Env:        []corev1.EnvVar{{Name: "test-env", Value: "test-val"}},
+	}
+
+	t.Run("no side inputs", func(t *testing.T) {
+		testObj := testVertex.DeepCopy()
+		testObj.Spec.UDF = &UDF{Builtin: &Function{Name: "cat"}}
+		s, err := testObj.GetPodSpec(req)
+		assert.NoError(t, err)
+		assert.Len(t, s.InitContainers, 1)
+		assert.Len(t, s.Containers, 2)
+	})
+
+	t.Run("side inputs", func(t *testing.T) {
+		testObj := testVertex.DeepCopy()
+		testObj.Spec.UDF = &UDF{Builtin: &Function{Name: "cat"}}
+		testObj.Spec.SideInputs = []SideInput{{Name: "config", HTTP: &HTTPSideInput{URL: "http://config-svc"}}}
+		s, err := testObj.GetPodSpec(req)
+		assert.NoError(t, err)
+		assert.Len(t, s.InitContainers, 2)
+		assert.Equal(t, CtrSideInputsInit, s.InitContainers[1].Name)
+		assert.Equal(t, []string{"side-inputs-manager", "--once"}, s.InitContainers[1].Args)
+		assert.Len(t, s.Containers, 3)
+		assert.Equal(t, CtrSideInputs, s.Containers[2].Name)
+		assert.Equal(t, []string{"side-inputs-manager"}, s.Containers[2].Args)
+		assert.Equal(t, testFlowImage, s.Containers[2].Image)
+		assert.Equal(t, EnvVertexObject, s.Containers[2].Env[0].Name)
+		assert.Contains(t, s.Containers[2].Env, corev1.EnvVar{Name: "test-env", Value: "test-val"})
+		writable := corev1.VolumeMount{Name: sideInputsVolumeName, MountPath: PathSideInputs}
+		assert.Contains(t, s.InitContainers[1].VolumeMounts, writable)
+		assert.Contains(t, s.Containers[2].VolumeMounts, writable)
+		for _, c := range s.Containers[:2] {
+			assert.Contains(t, c.VolumeMounts, corev1.VolumeMount{Name: sideInputsVolumeName, MountPath: PathSideInputs, ReadOnly: true})
+		}
+		found := false
+		for _, v := range s.Volumes {
+			if v.Name == sideInputsVolumeName {
+				found = true
+				assert.NotNil(t, v.EmptyDir)
+			}
+		}
+		assert.True(t, found)
+	})
+}
@@ -8,6 +8,8 @@ type Sink struct {
 	Log    *Log       `json:"log,omitempty" protobuf:"bytes,1,opt,name=log"`
 	Kafka  *KafkaSink `json:"kafka,omitempty" protobuf:"bytes,2,opt,name=kafka"`
 	UDSink *UDSink    `json:"udsink,omitempty" protobuf:"bytes,3,opt,name=udsink"`
+	// +optional
+	Reply *ReplySink `json:"reply,omitempty" protobuf:"bytes,4,opt,name=reply"`
 }
 
 func (s Sink) getContainers(req getContainerReq) ([]corev1.Container, error) {
@@ -24,3 +24,15 @@ func Test_Sink_getContainers(t *testing.T) {
 	assert.Equal(t, testFlowImage, c[0].Image)
 	assert.Equal(t, corev1.ResourceRequirements{Requests: map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("2")}}, c[0].Resources)
 }
+
+func Test_Log(t *testing.T) {
+	l := Log{}
+	assert.Equal(t, LogSinkFormatText, l.GetFormat())
+	assert.Equal(t, uint32(100), l.GetSamplePercentage())
+	assert.Equal(t, uint32(0), l.GetMaxMessagesPerSecond())
+	percentage, max := uint32(10), uint32(5)
+	l = Log{Format: LogSinkFormatJSON, SamplePercentage: &percentage, MaxMessagesPerSecond: &max}
+	assert.Equal(t, LogSinkFormatJSON, l.GetFormat())
+	assert.Equal(t, uint32(10), l.GetSamplePercentage())
+	assert.Equal(t, uint32(5), l.GetMaxMessagesPerSecond())
+}
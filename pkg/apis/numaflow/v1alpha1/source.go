@@ -11,6 +11,60 @@ type Source struct {
 	Kafka *KafkaSource `json:"kafka,omitempty" protobuf:"bytes,2,opt,name=kafka"`
 	// +optional
 	HTTP *HTTPSource `json:"http,omitempty" protobuf:"bytes,3,opt,name=http"`
+	// Encoding configures the decompression of the payloads read by the source, and the compression of the payloads
+	// written to the inter-step buffers.
+	// +optional
+	Encoding *SourceEncoding `json:"encoding,omitempty" protobuf:"bytes,4,opt,name=encoding"`
+	// RateLimit caps the number of messages per second admitted by the source across all of its replicas.
+	// +optional
+	RateLimit *SourceRateLimit `json:"rateLimit,omitempty" protobuf:"bytes,5,opt,name=rateLimit"`
+}
+
+// SourceRateLimit is a token bucket shared by all the replicas of a source vertex, it's kept in the key-value store of
+// the Inter-Step Buffer Service, so that the aggregate ingestion rate does not change when the vertex scales.
+type SourceRateLimit struct {
+	// Maximum number of messages per second admitted by all the replicas of the source.
+	MessagesPerSecond uint32 `json:"messagesPerSecond" protobuf:"varint,1,opt,name=messagesPerSecond"`
+	// Maximum number of messages admitted at once after the source has been idle, defaults to messagesPerSecond.
+	// +optional
+	Burst *uint32 `json:"burst,omitempty" protobuf:"varint,2,opt,name=burst"`
+}
+
+func (r SourceRateLimit) GetBurst() int {
+	if r.Burst != nil && *r.Burst > 0 {
+		return int(*r.Burst)
+	}
+	return int(r.MessagesPerSecond)
+}
+
+// +kubebuilder:validation:Enum="";gzip;snappy;zstd;lz4
+type ContentEncoding string
+
+const (
+	ContentEncodingGzip   ContentEncoding = "gzip"
+	ContentEncodingSnappy ContentEncoding = "snappy"
+	ContentEncodingZstd   ContentEncoding = "zstd"
+	ContentEncodingLZ4    ContentEncoding = "lz4"
+)
+
+// SourceEncoding configures the content encoding of the payloads. The payloads with a content encoding header, e.g.
+// "Content-Encoding" of the HTTP requests, or "content-encoding" of the Kafka record headers, are always decompressed
+// before entering the pipeline, so that the UDFs and sinks always see plain payloads.
+type SourceEncoding struct {
+	// The content encoding of the payloads without a content encoding header, one of gzip, snappy, zstd and lz4.
+	// Not compressed if it's not specified.
+	// +optional
+	Default ContentEncoding `json:"default,omitempty" protobuf:"bytes,1,opt,name=default"`
+	// The content encoding used to compress the payloads written to the inter-step buffers of the outbound edges, one
+	// of gzip, snappy, zstd and lz4, see EdgeLimits.Compression, which overrides it, while it overrides the compression
+	// of the pipeline limits. Not compressed if it's not specified.
+	// +optional
+	ISB ContentEncoding `json:"isb,omitempty" protobuf:"bytes,2,opt,name=isb"`
+}
+
+// IsBounded tells if the source reaches its end, after which its replicas exit.
+func (s Source) IsBounded() bool {
+	return s.Generator != nil && s.Generator.MaxMessages != nil
 }
 
 func (s Source) getContainers(req getContainerReq) ([]corev1.Container, error) {
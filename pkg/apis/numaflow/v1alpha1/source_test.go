@@ -24,3 +24,28 @@ func Test_Source_getContainers(t *testing.T) {
 	assert.Equal(t, testFlowImage, c[0].Image)
 	assert.Equal(t, corev1.ResourceRequirements{Requests: map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("2")}}, c[0].Resources)
 }
+
+func Test_SourceRateLimit_GetBurst(t *testing.T) {
+	r := SourceRateLimit{MessagesPerSecond: 100}
+	assert.Equal(t, 100, r.GetBurst())
+	burst := uint32(10)
+	r.Burst = &burst
+	assert.Equal(t, 10, r.GetBurst())
+}
+
+func Test_Source_IsBounded(t *testing.T) {
+	s := Source{}
+	assert.False(t, s.IsBounded())
+	s.Generator = &GeneratorSource{}
+	assert.False(t, s.IsBounded())
+	n := int64(100)
+	s.Generator.MaxMessages = &n
+	assert.True(t, s.IsBounded())
+}
+
+func Test_KafkaSource_GetConsumerGroupName(t *testing.T) {
+	s := KafkaSource{}
+	assert.Equal(t, "numaflow-my-pl-in", s.GetConsumerGroupName("my-pl", "in"))
+	s.ConsumerGroupName = "my-group"
+	assert.Equal(t, "my-group", s.GetConsumerGroupName("my-pl", "in"))
+}
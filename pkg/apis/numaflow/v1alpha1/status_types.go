@@ -102,6 +102,17 @@ func (s *Status) MarkUnknown(t ConditionType, reason, message string) {
 	s.markTypeStatus(t, metav1.ConditionUnknown, reason, message)
 }
 
+// RemoveCondition removes the condition of a condition type
+func (s *Status) RemoveCondition(t ConditionType) {
+	var conditions []metav1.Condition
+	for _, c := range s.Conditions {
+		if c.Type != string(t) {
+			conditions = append(conditions, c)
+		}
+	}
+	s.Conditions = conditions
+}
+
 // GetCondition returns the condition of a condition type
 func (s *Status) GetCondition(t ConditionType) *metav1.Condition {
 	for _, c := range s.Conditions {
@@ -53,6 +53,16 @@ func Test_setCondition(t *testing.T) {
 	assert.Equal(t, 2, len(s.Conditions))
 }
 
+func Test_RemoveCondition(t *testing.T) {
+	s := &Status{}
+	s.InitializeConditions(ConditionType("c1"), ConditionType("c2"))
+	s.RemoveCondition(ConditionType("c1"))
+	assert.Equal(t, 1, len(s.Conditions))
+	assert.Equal(t, "c2", s.Conditions[0].Type)
+	s.RemoveCondition(ConditionType("c3"))
+	assert.Equal(t, 1, len(s.Conditions))
+}
+
 func Test_markTypeStatus(t *testing.T) {
 	s := &Status{}
 	s.markTypeStatus("test-type", "status1", "reason1", "message1")
@@ -0,0 +1,173 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Templates are the pod settings shared by the pods of a kind generated for a pipeline, so that they don't need to be
+// repeated on every vertex.
+type Templates struct {
+	// Vertex is merged into all the vertices, the settings of a vertex take precedence.
+	// +optional
+	Vertex *AbstractPodTemplate `json:"vertex,omitempty" protobuf:"bytes,1,opt,name=vertex"`
+	// Daemon is applied to the pod of the daemon server.
+	// +optional
+	Daemon *AbstractPodTemplate `json:"daemon,omitempty" protobuf:"bytes,2,opt,name=daemon"`
+	// Job is applied to the pods of the buffer creation and deletion jobs.
+	// +optional
+	Job *AbstractPodTemplate `json:"job,omitempty" protobuf:"bytes,3,opt,name=job"`
+}
+
+// AbstractPodTemplate is the settings of a generated pod. The fields already set in the pod are kept, except that the
+// metadata and the node selector are merged key by key, and the tolerations and the image pull secrets are added.
+type AbstractPodTemplate struct {
+	// Metadata sets the pods's metadata, i.e. annotations and labels
+	// +optional
+	Metadata *Metadata `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// NodeSelector is a selector which must be true for the pod to fit on a node.
+	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty" protobuf:"bytes,2,rep,name=nodeSelector"`
+	// If specified, the pod's tolerations.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,3,rep,name=tolerations"`
+	// SecurityContext holds pod-level security attributes and common container settings.
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty" protobuf:"bytes,4,opt,name=securityContext"`
+	// ImagePullSecrets is a list of references to secrets in the same namespace to use for pulling the images.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,5,rep,name=imagePullSecrets"`
+	// If specified, indicates the pod's priority.
+	// More info: https://kubernetes.io/docs/concepts/configuration/pod-priority-preemption/
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty" protobuf:"bytes,6,opt,name=priorityClassName"`
+	// The pod's scheduling constraints
+	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty" protobuf:"bytes,7,opt,name=affinity"`
+	// ServiceAccountName of the pod
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty" protobuf:"bytes,8,opt,name=serviceAccountName"`
+}
+
+// ApplyToVertex merges the template into the vertex.
+func (tpl *AbstractPodTemplate) ApplyToVertex(v *AbstractVertex) {
+	if tpl == nil {
+		return
+	}
+	v.Metadata = tpl.mergeMetadata(v.Metadata)
+	v.NodeSelector = mergeStringMap(tpl.NodeSelector, v.NodeSelector)
+	v.Tolerations = mergeTolerations(v.Tolerations, tpl.Tolerations)
+	if v.SecurityContext == nil && tpl.SecurityContext != nil {
+		v.SecurityContext = tpl.SecurityContext.DeepCopy()
+	}
+	v.ImagePullSecrets = mergeImagePullSecrets(v.ImagePullSecrets, tpl.ImagePullSecrets)
+	if v.PriorityClassName == "" {
+		v.PriorityClassName = tpl.PriorityClassName
+	}
+	if v.Affinity == nil && tpl.Affinity != nil {
+		v.Affinity = tpl.Affinity.DeepCopy()
+	}
+	if v.ServiceAccountName == "" {
+		v.ServiceAccountName = tpl.ServiceAccountName
+	}
+}
+
+// ApplyToPodTemplate merges the template into the pod template.
+func (tpl *AbstractPodTemplate) ApplyToPodTemplate(template *corev1.PodTemplateSpec) {
+	if tpl == nil {
+		return
+	}
+	if tpl.Metadata != nil {
+		template.Labels = mergeStringMap(tpl.Metadata.Labels, template.Labels)
+		template.Annotations = mergeStringMap(tpl.Metadata.Annotations, template.Annotations)
+	}
+	spec := &template.Spec
+	spec.NodeSelector = mergeStringMap(tpl.NodeSelector, spec.NodeSelector)
+	spec.Tolerations = mergeTolerations(spec.Tolerations, tpl.Tolerations)
+	if spec.SecurityContext == nil && tpl.SecurityContext != nil {
+		spec.SecurityContext = tpl.SecurityContext.DeepCopy()
+	}
+	spec.ImagePullSecrets = mergeImagePullSecrets(spec.ImagePullSecrets, tpl.ImagePullSecrets)
+	if spec.PriorityClassName == "" {
+		spec.PriorityClassName = tpl.PriorityClassName
+	}
+	if spec.Affinity == nil && tpl.Affinity != nil {
+		spec.Affinity = tpl.Affinity.DeepCopy()
+	}
+	if spec.ServiceAccountName == "" {
+		spec.ServiceAccountName = tpl.ServiceAccountName
+	}
+}
+
+func (tpl *AbstractPodTemplate) mergeMetadata(m *Metadata) *Metadata {
+	if tpl.Metadata == nil {
+		return m
+	}
+	if m == nil {
+		m = &Metadata{}
+	}
+	return &Metadata{
+		Annotations: mergeStringMap(tpl.Metadata.Annotations, m.Annotations),
+		Labels:      mergeStringMap(tpl.Metadata.Labels, m.Labels),
+	}
+}
+
+// mergeStringMap returns a new map with the entries of both, the ones of the override take precedence.
+func mergeStringMap(base, override map[string]string) map[string]string {
+	if len(base) == 0 {
+		return override
+	}
+	result := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range override {
+		result[k] = v
+	}
+	return result
+}
+
+// mergeTolerations returns the tolerations with the ones of the template not in them added.
+func mergeTolerations(tolerations, fromTemplate []corev1.Toleration) []corev1.Toleration {
+	if len(fromTemplate) == 0 {
+		return tolerations
+	}
+	result := append([]corev1.Toleration{}, tolerations...)
+	for _, t := range fromTemplate {
+		found := false
+		for _, existing := range tolerations {
+			if existing.MatchToleration(&t) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// mergeImagePullSecrets returns the secrets with the ones of the template not in them added.
+func mergeImagePullSecrets(secrets, fromTemplate []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	if len(fromTemplate) == 0 {
+		return secrets
+	}
+	result := append([]corev1.LocalObjectReference{}, secrets...)
+	for _, s := range fromTemplate {
+		found := false
+		for _, existing := range secrets {
+			if existing.Name == s.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, s)
+		}
+	}
+	return result
+}
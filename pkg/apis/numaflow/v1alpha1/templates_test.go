@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+var testPodTemplate = &AbstractPodTemplate{
+	Metadata:     &Metadata{Labels: map[string]string{"team": "a", "tier": "data"}},
+	NodeSelector: map[string]string{"pool": "numaflow"},
+	Tolerations: []corev1.Toleration{
+		{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "numaflow", Effect: corev1.TaintEffectNoSchedule},
+	},
+	SecurityContext:    &corev1.PodSecurityContext{RunAsUser: pointer.Int64(1000)},
+	ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "regcred"}},
+	PriorityClassName:  "high",
+	Affinity:           &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}},
+	ServiceAccountName: "numaflow-pipeline",
+}
+
+func TestAbstractPodTemplate_ApplyToVertex(t *testing.T) {
+	var nilTpl *AbstractPodTemplate
+	v := &AbstractVertex{Name: "in"}
+	nilTpl.ApplyToVertex(v)
+	assert.Equal(t, &AbstractVertex{Name: "in"}, v)
+
+	v = &AbstractVertex{
+		Name:               "in",
+		Metadata:           &Metadata{Labels: map[string]string{"tier": "ingest"}},
+		Tolerations:        []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "numaflow", Effect: corev1.TaintEffectNoSchedule}},
+		ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "other"}},
+		ServiceAccountName: "in",
+	}
+	testPodTemplate.ApplyToVertex(v)
+	assert.Equal(t, map[string]string{"team": "a", "tier": "ingest"}, v.Metadata.Labels)
+	assert.Equal(t, map[string]string{"pool": "numaflow"}, v.NodeSelector)
+	assert.Len(t, v.Tolerations, 1)
+	assert.Equal(t, int64(1000), *v.SecurityContext.RunAsUser)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "other"}, {Name: "regcred"}}, v.ImagePullSecrets)
+	assert.Equal(t, "high", v.PriorityClassName)
+	assert.NotNil(t, v.Affinity)
+	assert.Equal(t, "in", v.ServiceAccountName)
+	// the template is not shared
+	v.SecurityContext.RunAsUser = pointer.Int64(0)
+	v.NodeSelector["pool"] = "other"
+	assert.Equal(t, int64(1000), *testPodTemplate.SecurityContext.RunAsUser)
+	assert.Equal(t, "numaflow", testPodTemplate.NodeSelector["pool"])
+}
+
+func TestAbstractPodTemplate_ApplyToPodTemplate(t *testing.T) {
+	template := &corev1.PodTemplateSpec{}
+	template.Labels = map[string]string{"tier": "daemon"}
+	template.Spec.PriorityClassName = "low"
+	testPodTemplate.ApplyToPodTemplate(template)
+	assert.Equal(t, map[string]string{"team": "a", "tier": "daemon"}, template.Labels)
+	assert.Equal(t, map[string]string{"pool": "numaflow"}, template.Spec.NodeSelector)
+	assert.Len(t, template.Spec.Tolerations, 1)
+	assert.Equal(t, int64(1000), *template.Spec.SecurityContext.RunAsUser)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "regcred"}}, template.Spec.ImagePullSecrets)
+	assert.Equal(t, "low", template.Spec.PriorityClassName)
+	assert.Equal(t, "numaflow-pipeline", template.Spec.ServiceAccountName)
+}
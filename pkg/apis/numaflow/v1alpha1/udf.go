@@ -4,8 +4,10 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Container struct {
@@ -24,7 +26,7 @@ type Container struct {
 }
 
 type Function struct {
-	// +kubebuilder:validation:Enum=cat;filter
+	// +kubebuilder:validation:Enum=cat;filter;infer
 	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
 	// +optional
 	Args []string `json:"args,omitempty" protobuf:"bytes,2,rep,name=args"`
@@ -37,15 +39,96 @@ type UDF struct {
 	Container *Container `json:"container" protobuf:"bytes,1,opt,name=container"`
 	// +optional
 	Builtin *Function `json:"builtin" protobuf:"bytes,12,opt,name=builtin"`
+	// WarmUp defines the calls made to the UDF before a new replica starts reading messages,
+	// which is useful for the UDFs with cold start cost such as JIT compiling or model loading.
+	// +optional
+	WarmUp *UDFWarmUp `json:"warmUp,omitempty" protobuf:"bytes,13,opt,name=warmUp"`
+	// Batch enables the batch mode, in which the whole read batch is sent to the UDF in one call, and the results are
+	// returned keyed by the input IDs. It cuts the per-message call overhead for the high-throughput, low-latency functions,
+	// and requires the UDF server to support the batch protocol.
+	// +optional
+	Batch bool `json:"batch,omitempty" protobuf:"varint,14,opt,name=batch"`
+	// Plugin selects a function compiled into the numa binary, which is invoked in-process without a UDF container.
+	// It is only meant for trusted environments, since the function runs in the same process as the platform code.
+	// +optional
+	Plugin *PluginFunction `json:"plugin,omitempty" protobuf:"bytes,15,opt,name=plugin"`
+	// WASM runs a WebAssembly module in-process with the wazero runtime, without a UDF container, so that the function
+	// can be written in any language compiling to WebAssembly.
+	// +optional
+	WASM *WASMFunction `json:"wasm,omitempty" protobuf:"bytes,16,opt,name=wasm"`
+}
+
+type PluginFunction struct {
+	// Name of the function, which is registered with "pkg/udf/plugin.Register".
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+}
+
+type WASMFunction struct {
+	// ConfigMap key holding the module, the binary module is expected to be in the "binaryData" of the ConfigMap,
+	// and it is subject to the 1MiB size limit of ConfigMaps.
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty" protobuf:"bytes,1,opt,name=configMap"`
+	// Path of the module in the main container, used together with VolumeMount to load the module from one of the
+	// vertex volumes, e.g. an OCI artifact mounted by a CSI image driver.
+	// +optional
+	Path string `json:"path,omitempty" protobuf:"bytes,2,opt,name=path"`
+	// VolumeMount mounts one of the vertex volumes to the main container, so that the module can be loaded from Path.
+	// +optional
+	VolumeMount *corev1.VolumeMount `json:"volumeMount,omitempty" protobuf:"bytes,3,opt,name=volumeMount"`
+}
+
+// GetPath returns the path of the module in the main container.
+func (w WASMFunction) GetPath() string {
+	if w.ConfigMap != nil {
+		return fmt.Sprintf("/var/numaflow/config/%s/%s", w.ConfigMap.Name, w.ConfigMap.Key)
+	}
+	return w.Path
+}
+
+type UDFWarmUp struct {
+	// Payload of the synthetic message sent to the UDF, it is expected to be a valid input of the UDF.
+	Payload string `json:"payload" protobuf:"bytes,1,opt,name=payload"`
+	// Key of the synthetic message.
+	// +optional
+	Key string `json:"key,omitempty" protobuf:"bytes,2,opt,name=key"`
+	// Number of the warm-up calls, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	Requests *uint32 `json:"requests,omitempty" protobuf:"varint,3,opt,name=requests"`
+	// Timeout of the whole warm-up, defaults to 60s. Warm-up failures or timeout do not block the replica from reading messages.
+	// +kubebuilder:default="60s"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,4,opt,name=timeout"`
+}
+
+func (w UDFWarmUp) GetRequests() int {
+	if w.Requests != nil && *w.Requests > 0 {
+		return int(*w.Requests)
+	}
+	return 1
+}
+
+func (w UDFWarmUp) GetTimeout() time.Duration {
+	if w.Timeout != nil {
+		return w.Timeout.Duration
+	}
+	return DefaultUDFWarmUpTimeout
 }
 
 func (in UDF) getContainers(req getContainerReq) ([]corev1.Container, error) {
+	if in.Plugin != nil || in.WASM != nil { // in-process function, no UDF container
+		return []corev1.Container{in.getMainContainer(req)}, nil
+	}
 	return []corev1.Container{in.getMainContainer(req), in.getUDFContainer(req)}, nil
 }
 
 func (in UDF) getMainContainer(req getContainerReq) corev1.Container {
-	return containerBuilder{}.
-		init(req).args("processor", "--type=udf", "--isbsvc-type="+string(req.isbSvcType)).build()
+	c := containerBuilder{}.
+		init(req).args("processor", "--type=udf", "--isbsvc-type="+string(req.isbSvcType))
+	if in.WASM != nil && in.WASM.VolumeMount != nil {
+		c = c.appendVolumeMounts(*in.WASM.VolumeMount)
+	}
+	return c.build()
 }
 
 func (in UDF) getUDFContainer(req getContainerReq) corev1.Container {
@@ -2,10 +2,12 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestUDF_getContainers(t *testing.T) {
@@ -37,6 +39,36 @@ func TestUDF_getContainers(t *testing.T) {
 	assert.Equal(t, corev1.ResourceRequirements{Requests: map[corev1.ResourceName]resource.Quantity{"cpu": resource.MustParse("2")}}, c[1].Resources)
 }
 
+func TestUDF_getContainersWithPlugin(t *testing.T) {
+	x := UDF{Plugin: &PluginFunction{Name: "my-func"}}
+	c, err := x.getContainers(getContainerReq{
+		image: "main-image",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(c))
+	assert.Equal(t, "main-image", c[0].Image)
+}
+
+func TestUDF_getContainersWithWASM(t *testing.T) {
+	x := UDF{WASM: &WASMFunction{
+		Path:        "/wasm/udf.wasm",
+		VolumeMount: &corev1.VolumeMount{Name: "wasm", MountPath: "/wasm"},
+	}}
+	c, err := x.getContainers(getContainerReq{
+		image: "main-image",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(c))
+	assert.Contains(t, c[0].VolumeMounts, corev1.VolumeMount{Name: "wasm", MountPath: "/wasm"})
+}
+
+func TestWASMFunction_GetPath(t *testing.T) {
+	w := WASMFunction{Path: "/wasm/udf.wasm"}
+	assert.Equal(t, "/wasm/udf.wasm", w.GetPath())
+	w.ConfigMap = &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-cm"}, Key: "udf.wasm"}
+	assert.Equal(t, "/var/numaflow/config/my-cm/udf.wasm", w.GetPath())
+}
+
 func Test_getUDFContainer(t *testing.T) {
 	t.Run("with customized image", func(t *testing.T) {
 		x := UDF{
@@ -79,3 +111,14 @@ func Test_getUDFContainer(t *testing.T) {
 		assert.NotContains(t, envNames, "a")
 	})
 }
+
+func TestUDFWarmUp(t *testing.T) {
+	w := UDFWarmUp{}
+	assert.Equal(t, 1, w.GetRequests())
+	assert.Equal(t, DefaultUDFWarmUpTimeout, w.GetTimeout())
+	requests := uint32(3)
+	w.Requests = &requests
+	w.Timeout = &metav1.Duration{Duration: 5 * time.Second}
+	assert.Equal(t, 3, w.GetRequests())
+	assert.Equal(t, 5*time.Second, w.GetTimeout())
+}
@@ -0,0 +1,131 @@
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// VertexEncodingVersion is the version of the vertex object encoding passed to the vertex pods through the environment
+// variable NUMAFLOW_VERTEX_OBJECT, it increases whenever a field is added to the vertex spec.
+//
+// The encoding is base64 encoded JSON, which older images are able to read. The version is passed separately through
+// NUMAFLOW_VERTEX_OBJECT_VERSION, so that an image decoding an object encoded by a newer controller knows the object
+// may contain fields it doesn't understand, and reports them instead of failing.
+const VertexEncodingVersion = 18
+
+// EncodeVertex encodes the vertex object to be passed to the vertex pods.
+func EncodeVertex(v *Vertex) (string, error) {
+	vertexBytes, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.New("failed to marshal vertex spec")
+	}
+	return base64.StdEncoding.EncodeToString(vertexBytes), nil
+}
+
+// ParseVertexEncodingVersion parses the value of NUMAFLOW_VERTEX_OBJECT_VERSION, empty means the object is encoded by
+// a controller released before the versioning, which is version 0.
+func ParseVertexEncodingVersion(version string) (int, error) {
+	if version == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(version)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid vertex encoding version %q", version)
+	}
+	return v, nil
+}
+
+// DecodeVertex decodes the vertex object encoded by EncodeVertex. The fields which are not recognized by this version
+// are ignored and returned, so that the caller can report them.
+func DecodeVertex(encoded string) (*Vertex, []string, error) {
+	vertexBytes, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode vertex string, error: %w", err)
+	}
+	vertex := &Vertex{}
+	if err = json.Unmarshal(vertexBytes, vertex); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal vertex object, error: %w", err)
+	}
+	unknownFields, err := findUnknownFields(vertexBytes, vertex)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vertex, unknownFields, nil
+}
+
+// findUnknownFields returns the paths of the fields in the raw JSON which are dropped after decoding to the vertex.
+func findUnknownFields(raw []byte, vertex *Vertex) ([]string, error) {
+	var original interface{}
+	if err := json.Unmarshal(raw, &original); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal vertex object, error: %w", err)
+	}
+	decodedBytes, err := json.Marshal(vertex)
+	if err != nil {
+		return nil, errors.New("failed to marshal vertex spec")
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(decodedBytes, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal vertex object, error: %w", err)
+	}
+	result := []string{}
+	diffFields("", original, decoded, &result)
+	sort.Strings(result)
+	return result, nil
+}
+
+func diffFields(path string, original, decoded interface{}, result *[]string) {
+	switch o := original.(type) {
+	case map[string]interface{}:
+		d, _ := decoded.(map[string]interface{})
+		for k, v := range o {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			dv, ok := d[k]
+			if !ok {
+				// Zero values are omitted when re-encoding, they are not unknown fields
+				if !isZeroJSONValue(v) {
+					*result = append(*result, p)
+				}
+				continue
+			}
+			diffFields(p, v, dv, result)
+		}
+	case []interface{}:
+		d, _ := decoded.([]interface{})
+		for i, v := range o {
+			if i < len(d) {
+				diffFields(fmt.Sprintf("%s[%d]", path, i), v, d[i], result)
+			}
+		}
+	}
+}
+
+func isZeroJSONValue(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !x
+	case float64:
+		return x == 0
+	case string:
+		return x == ""
+	case []interface{}:
+		return len(x) == 0
+	case map[string]interface{}:
+		for _, e := range x {
+			if !isZeroJSONValue(e) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVertexEncodingVersion(t *testing.T) {
+	v, err := ParseVertexEncodingVersion("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, v)
+	v, err = ParseVertexEncodingVersion("2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	_, err = ParseVertexEncodingVersion("v2")
+	assert.Error(t, err)
+	_, err = ParseVertexEncodingVersion("-1")
+	assert.Error(t, err)
+}
+
+func TestEncodeDecodeVertex(t *testing.T) {
+	encoded, err := EncodeVertex(testVertex)
+	assert.NoError(t, err)
+	v, unknownFields, err := DecodeVertex(encoded)
+	assert.NoError(t, err)
+	assert.Empty(t, unknownFields)
+	assert.Equal(t, testVertex.Spec, v.Spec)
+
+	_, _, err = DecodeVertex("xxxxx")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode vertex string")
+}
+
+func TestDecodeVertexWithUnknownFields(t *testing.T) {
+	// Encoded by a newer controller
+	raw := `{"metadata":{"name":"test-pl-v"},"spec":{"name":"v","pipelineName":"test-pl","newField":{"a":1},"limits":{"readBatchSize":10,"newLimit":2},"sink":{"log":{}},"zeroField":false}}`
+	v, unknownFields, err := DecodeVertex(base64.StdEncoding.EncodeToString([]byte(raw)))
+	assert.NoError(t, err)
+	assert.Equal(t, "v", v.Spec.Name)
+	assert.Equal(t, uint64(10), *v.Spec.Limits.ReadBatchSize)
+	assert.Equal(t, []string{"spec.limits.newLimit", "spec.newField"}, unknownFields)
+}
@@ -0,0 +1,91 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const scratchVolumeName = "scratch"
+
+// VertexStorage configures the local storage of the vertex pods, so that the large temporary files do not get the pods
+// evicted by exceeding the ephemeral storage of the nodes unnoticed.
+type VertexStorage struct {
+	// Scratch adds an emptyDir volume mounted at /var/numaflow/scratch in the main container and the UDF or UDSink
+	// container, where TMPDIR points to.
+	// +optional
+	Scratch *ScratchVolume `json:"scratch,omitempty" protobuf:"bytes,1,opt,name=scratch"`
+	// Numa sets the ephemeral storage of the main container.
+	// +optional
+	Numa *EphemeralStorage `json:"numa,omitempty" protobuf:"bytes,2,opt,name=numa"`
+	// UDF sets the ephemeral storage of the UDF or UDSink container.
+	// +optional
+	UDF *EphemeralStorage `json:"udf,omitempty" protobuf:"bytes,3,opt,name=udf"`
+}
+
+type ScratchVolume struct {
+	// Memory backs the volume with tmpfs, whose usage counts towards the memory limits of the containers instead of
+	// the ephemeral storage.
+	// +optional
+	Memory bool `json:"memory,omitempty" protobuf:"varint,1,opt,name=memory"`
+	// SizeLimit is the max size of the volume, the pod is evicted once exceeded.
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty" protobuf:"bytes,2,opt,name=sizeLimit"`
+}
+
+// EphemeralStorage is the ephemeral-storage request and limit of a container. They override the ones in the container
+// resources.
+type EphemeralStorage struct {
+	// +optional
+	Request *resource.Quantity `json:"request,omitempty" protobuf:"bytes,1,opt,name=request"`
+	// +optional
+	Limit *resource.Quantity `json:"limit,omitempty" protobuf:"bytes,2,opt,name=limit"`
+}
+
+// applyToContainer sets the ephemeral storage of the container, the resources are copied before updated, as they could
+// be shared with the specs of the custom resources.
+func (es *EphemeralStorage) applyToContainer(c *corev1.Container) {
+	if es == nil || (es.Request == nil && es.Limit == nil) {
+		return
+	}
+	c.Resources = *c.Resources.DeepCopy()
+	if es.Request != nil {
+		if c.Resources.Requests == nil {
+			c.Resources.Requests = corev1.ResourceList{}
+		}
+		c.Resources.Requests[corev1.ResourceEphemeralStorage] = *es.Request
+	}
+	if es.Limit != nil {
+		if c.Resources.Limits == nil {
+			c.Resources.Limits = corev1.ResourceList{}
+		}
+		c.Resources.Limits[corev1.ResourceEphemeralStorage] = *es.Limit
+	}
+}
+
+// applyToPodSpec adds the scratch volume and sets the ephemeral storage of the containers of a vertex pod, the first
+// container being the main one, the second one being the UDF or UDSink container if any.
+func (vs *VertexStorage) applyToPodSpec(spec *corev1.PodSpec) {
+	if vs == nil {
+		return
+	}
+	if x := vs.Scratch; x != nil {
+		source := &corev1.EmptyDirVolumeSource{SizeLimit: x.SizeLimit}
+		if x.Memory {
+			source.Medium = corev1.StorageMediumMemory
+		}
+		spec.Volumes = append(spec.Volumes, corev1.Volume{Name: scratchVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: source}})
+		for i := range spec.Containers {
+			c := &spec.Containers[i]
+			// Copied before appended, as the volume mounts could share the backing array among the containers.
+			c.VolumeMounts = append(append([]corev1.VolumeMount{}, c.VolumeMounts...), corev1.VolumeMount{Name: scratchVolumeName, MountPath: PathScratch})
+			// Prepended, so that the TMPDIR set by the users takes precedence.
+			c.Env = append([]corev1.EnvVar{{Name: "TMPDIR", Value: PathScratch}}, c.Env...)
+		}
+	}
+	if len(spec.Containers) > 0 {
+		vs.Numa.applyToContainer(&spec.Containers[0])
+	}
+	if len(spec.Containers) > 1 {
+		vs.UDF.applyToContainer(&spec.Containers[1])
+	}
+}
@@ -0,0 +1,51 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestVertexStorage_applyToPodSpec(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var vs *VertexStorage
+		spec := &corev1.PodSpec{Containers: []corev1.Container{{Name: CtrMain}}}
+		vs.applyToPodSpec(spec)
+		assert.Empty(t, spec.Volumes)
+		assert.Empty(t, spec.Containers[0].Resources)
+	})
+
+	t.Run("scratch and ephemeral storage", func(t *testing.T) {
+		size := resource.MustParse("1Gi")
+		request := resource.MustParse("2Gi")
+		limit := resource.MustParse("4Gi")
+		vs := &VertexStorage{
+			Scratch: &ScratchVolume{Memory: true, SizeLimit: &size},
+			Numa:    &EphemeralStorage{Request: &request},
+			UDF:     &EphemeralStorage{Request: &request, Limit: &limit},
+		}
+		shared := corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}}
+		spec := &corev1.PodSpec{Containers: []corev1.Container{
+			{Name: CtrMain, Resources: shared},
+			{Name: CtrUdf, Env: []corev1.EnvVar{{Name: "TMPDIR", Value: "/tmp"}}},
+		}}
+		vs.applyToPodSpec(spec)
+		assert.Len(t, spec.Volumes, 1)
+		assert.Equal(t, corev1.StorageMediumMemory, spec.Volumes[0].EmptyDir.Medium)
+		assert.Equal(t, "1Gi", spec.Volumes[0].EmptyDir.SizeLimit.String())
+		for _, c := range spec.Containers {
+			assert.Equal(t, []corev1.VolumeMount{{Name: scratchVolumeName, MountPath: PathScratch}}, c.VolumeMounts)
+			assert.Equal(t, corev1.EnvVar{Name: "TMPDIR", Value: PathScratch}, c.Env[0])
+		}
+		// the one set by the user comes later, which takes precedence
+		assert.Equal(t, "/tmp", spec.Containers[1].Env[1].Value)
+		main := spec.Containers[0].Resources
+		assert.Equal(t, "2Gi", main.Requests.StorageEphemeral().String())
+		assert.Equal(t, "100m", main.Requests.Cpu().String())
+		assert.Empty(t, main.Limits)
+		assert.NotContains(t, shared.Requests, corev1.ResourceEphemeralStorage)
+		assert.Equal(t, "4Gi", spec.Containers[1].Resources.Limits.StorageEphemeral().String())
+	})
+}
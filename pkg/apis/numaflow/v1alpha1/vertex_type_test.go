@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -53,6 +55,59 @@ func TestGetToBufferName(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%s-%s-%s-%s", testVertex.Namespace, testVertex.Spec.PipelineName, testVertex.Spec.Name, "abc"), n)
 }
 
+func TestIsExactlyOnceToBuffer(t *testing.T) {
+	v := testVertex.DeepCopy()
+	// The ExactlyOnce feature gate is on by default, so the edges without limits keep deduplicating after upgrades
+	assert.True(t, v.IsExactlyOnceToBuffer(v.GetToBufferName("output")))
+	assert.True(t, v.IsExactlyOnceToBuffer("unknown"))
+	v.Spec.FeatureGates = map[string]bool{FeatureGateExactlyOnce: false}
+	assert.False(t, v.IsExactlyOnceToBuffer(v.GetToBufferName("output")))
+	assert.False(t, v.IsExactlyOnceToBuffer("unknown"))
+	v.Spec.ToVertices[0].Limits = &EdgeLimits{ExactlyOnce: pointer.Bool(true)}
+	assert.True(t, v.IsExactlyOnceToBuffer(v.GetToBufferName(v.Spec.ToVertices[0].Name)))
+	v.Spec.FeatureGates = nil
+	v.Spec.ToVertices[0].Limits = &EdgeLimits{ExactlyOnce: pointer.Bool(false)}
+	assert.False(t, v.IsExactlyOnceToBuffer(v.GetToBufferName(v.Spec.ToVertices[0].Name)))
+}
+
+func TestIsExactlyOnceFromBuffer(t *testing.T) {
+	v := testVertex.DeepCopy()
+	b := v.GetFromBuffers()[0]
+	assert.True(t, v.IsExactlyOnceFromBuffer(b))
+	v.Spec.FeatureGates = map[string]bool{FeatureGateExactlyOnce: false}
+	assert.False(t, v.IsExactlyOnceFromBuffer(b))
+	v.Spec.ExactlyOnce = map[string]bool{"input": true}
+	assert.True(t, v.IsExactlyOnceFromBuffer(b))
+	assert.False(t, v.IsExactlyOnceFromBuffer("unknown"))
+	v.Spec.FeatureGates = nil
+	v.Spec.ExactlyOnce = map[string]bool{"input": false}
+	assert.False(t, v.IsExactlyOnceFromBuffer(b))
+}
+
+func TestGetToBufferDurability(t *testing.T) {
+	v := testVertex.DeepCopy()
+	assert.Equal(t, WriteDurabilityAcknowledged, v.GetToBufferDurability(v.GetToBufferName("output")))
+	v.Spec.ToVertices[0].Limits = &EdgeLimits{Durability: WriteDurabilityReplicated}
+	assert.Equal(t, WriteDurabilityReplicated, v.GetToBufferDurability(v.GetToBufferName(v.Spec.ToVertices[0].Name)))
+	assert.Equal(t, WriteDurabilityAcknowledged, v.GetToBufferDurability("unknown"))
+}
+
+func TestGetToBufferOnFull(t *testing.T) {
+	v := testVertex.DeepCopy()
+	assert.Equal(t, OnFullRetryUntilSuccess, v.GetToBufferOnFull(v.GetToBufferName("output")))
+	v.Spec.ToVertices[0].Limits = &EdgeLimits{OnFull: OnFullDiscardLatest}
+	assert.Equal(t, OnFullDiscardLatest, v.GetToBufferOnFull(v.GetToBufferName(v.Spec.ToVertices[0].Name)))
+	assert.Equal(t, OnFullRetryUntilSuccess, v.GetToBufferOnFull("unknown"))
+}
+
+func TestGetToBufferCompression(t *testing.T) {
+	v := testVertex.DeepCopy()
+	assert.Equal(t, ContentEncoding(""), v.GetToBufferCompression(v.GetToBufferName(v.Spec.ToVertices[0].Name)))
+	v.Spec.ToVertices[0].Limits = &EdgeLimits{Compression: ContentEncodingLZ4}
+	assert.Equal(t, ContentEncodingLZ4, v.GetToBufferCompression(v.GetToBufferName(v.Spec.ToVertices[0].Name)))
+	assert.Equal(t, ContentEncoding(""), v.GetToBufferCompression("unknown"))
+}
+
 func TestWithoutReplicas(t *testing.T) {
 	s := &VertexSpec{
 		Replicas: pointer.Int32(3),
@@ -135,11 +190,27 @@ func TestGetPodSpec(t *testing.T) {
 		assert.Contains(t, envNames, EnvPipelineName)
 		assert.Contains(t, envNames, EnvVertexName)
 		assert.Contains(t, envNames, EnvVertexObject)
+		assert.Contains(t, envNames, EnvVertexObjectVersion)
 		assert.Contains(t, envNames, EnvReplica)
 		assert.Contains(t, s.Containers[0].Args, "processor")
 		assert.Contains(t, s.Containers[0].Args, "--type=source")
+		assert.Equal(t, 3, len(s.Volumes))
+		assert.NotNil(t, s.Volumes[1].DownwardAPI)
+		assert.Equal(t, tmpVolumeName, s.Volumes[2].Name)
+		assert.Equal(t, PathPodInfo, s.Containers[0].VolumeMounts[len(s.Containers[0].VolumeMounts)-2].MountPath)
+		assert.True(t, *s.SecurityContext.RunAsNonRoot)
 		assert.Equal(t, 1, len(s.InitContainers))
 		assert.Equal(t, CtrInit, s.InitContainers[0].Name)
+		assert.Equal(t, corev1.RestartPolicy(""), s.RestartPolicy)
+	})
+
+	t.Run("test bounded source", func(t *testing.T) {
+		testObj := testVertex.DeepCopy()
+		n := int64(100)
+		testObj.Spec.Source = &Source{Generator: &GeneratorSource{MaxMessages: &n}}
+		s, err := testObj.GetPodSpec(req)
+		assert.NoError(t, err)
+		assert.Equal(t, corev1.RestartPolicyOnFailure, s.RestartPolicy)
 	})
 
 	t.Run("test sink", func(t *testing.T) {
@@ -151,6 +222,7 @@ func TestGetPodSpec(t *testing.T) {
 		assert.Equal(t, CtrMain, s.Containers[0].Name)
 		assert.Equal(t, testFlowImage, s.Containers[0].Image)
 		assert.Equal(t, corev1.PullIfNotPresent, s.Containers[0].ImagePullPolicy)
+		assert.Equal(t, []corev1.ContainerPort{{Name: VertexMetricsPortName, ContainerPort: VertexMetricsPort}}, s.Containers[0].Ports)
 		assert.NotNil(t, s.Containers[0].ReadinessProbe)
 		assert.NotNil(t, s.Containers[0].ReadinessProbe.HTTPGet)
 		assert.Equal(t, corev1.URISchemeHTTPS, s.Containers[0].ReadinessProbe.HTTPGet.Scheme)
@@ -159,6 +231,8 @@ func TestGetPodSpec(t *testing.T) {
 		assert.NotNil(t, s.Containers[0].LivenessProbe.HTTPGet)
 		assert.Equal(t, corev1.URISchemeHTTPS, s.Containers[0].LivenessProbe.HTTPGet.Scheme)
 		assert.Equal(t, VertexMetricsPort, s.Containers[0].LivenessProbe.HTTPGet.Port.IntValue())
+		assert.Equal(t, VertexLivenessPath, s.Containers[0].LivenessProbe.HTTPGet.Path)
+		assert.Equal(t, int32(VertexLivenessProbePeriodSeconds), s.Containers[0].LivenessProbe.PeriodSeconds)
 		envNames := []string{}
 		for _, e := range s.Containers[0].Env {
 			envNames = append(envNames, e.Name)
@@ -195,6 +269,16 @@ func TestGetPodSpec(t *testing.T) {
 		assert.Equal(t, "cmd", s.Containers[1].Command[0])
 		assert.Equal(t, 1, len(s.Containers[1].Args))
 		assert.Equal(t, "arg0", s.Containers[1].Args[0])
+		assert.NotNil(t, s.Containers[1].Lifecycle)
+		assert.NotNil(t, s.Containers[1].Lifecycle.PreStop.HTTPGet)
+		assert.Equal(t, VertexPreStopPath, s.Containers[1].Lifecycle.PreStop.HTTPGet.Path)
+		assert.Equal(t, VertexPreStopPort, s.Containers[1].Lifecycle.PreStop.HTTPGet.Port.IntValue())
+		assert.Nil(t, s.Containers[0].Lifecycle)
+		assert.Nil(t, s.TerminationGracePeriodSeconds)
+		testObj.Spec.TerminationGracePeriodSeconds = pointer.Int64(120)
+		s, err = testObj.GetPodSpec(req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(120), *s.TerminationGracePeriodSeconds)
 	})
 
 	t.Run("test udf", func(t *testing.T) {
@@ -286,6 +370,29 @@ func Test_VertexIsSink(t *testing.T) {
 	assert.True(t, o.IsASink())
 }
 
+func Test_IsAReduce(t *testing.T) {
+	o := testVertex.DeepCopy()
+	assert.False(t, o.IsAReduce())
+	assert.True(t, o.IsAnUDF())
+	o.Spec.Reduce = &Reduce{}
+	assert.True(t, o.IsAReduce())
+	assert.False(t, o.IsAnUDF())
+}
+
+func Test_ReducePodSpec(t *testing.T) {
+	o := testVertex.DeepCopy()
+	o.Spec.Reduce = &Reduce{
+		Window:  Window{Fixed: &FixedWindow{Length: &metav1.Duration{Duration: time.Minute}}},
+		Builtin: &ReduceFunction{Name: "count"},
+	}
+	assert.Equal(t, time.Minute, o.Spec.Reduce.Window.Fixed.GetLength())
+	assert.Equal(t, time.Duration(0), o.Spec.Reduce.GetAllowedLateness())
+	s, err := o.GetPodSpec(GetVertexPodSpecReq{ISBSvcType: ISBSvcTypeJetStream, Image: testFlowImage})
+	assert.NoError(t, err)
+	assert.Len(t, s.Containers, 1)
+	assert.Equal(t, []string{"processor", "--type=reduce", "--isbsvc-type=jetstream"}, s.Containers[0].Args)
+}
+
 func Test_VertexGetInitContainer(t *testing.T) {
 	req := GetVertexPodSpecReq{
 		ISBSvcType: ISBSvcTypeRedis,
@@ -311,3 +418,92 @@ func Test_VertexGetInitContainer(t *testing.T) {
 func TestGenerateBufferName(t *testing.T) {
 	assert.Equal(t, "a-b-c-d", GenerateBufferName("a", "b", "c", "d"))
 }
+
+func TestSlowStart(t *testing.T) {
+	ss := SlowStart{}
+	assert.Equal(t, DefaultSlowStartDuration, ss.GetDuration())
+	assert.Equal(t, uint64(1), ss.GetInitialReadBatchSize())
+	ss.Duration = &metav1.Duration{Duration: 10 * time.Second}
+	size := uint64(5)
+	ss.InitialReadBatchSize = &size
+	assert.Equal(t, 10*time.Second, ss.GetDuration())
+	assert.Equal(t, uint64(5), ss.GetInitialReadBatchSize())
+}
+
+func TestScale_GetDrainTimeout(t *testing.T) {
+	s := Scale{}
+	assert.Equal(t, DefaultDrainTimeout, s.GetDrainTimeout())
+	s.DrainTimeout = &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, s.GetDrainTimeout())
+}
+
+func TestLiveness_GetStuckThreshold(t *testing.T) {
+	var l *Liveness
+	assert.Equal(t, 3*time.Minute, l.GetStuckThreshold())
+	periods := uint32(10)
+	l = &Liveness{StuckPeriods: &periods}
+	assert.Equal(t, 30*time.Second, l.GetStuckThreshold())
+	l.Disabled = true
+	assert.Equal(t, time.Duration(0), l.GetStuckThreshold())
+}
+
+func TestUpdateStrategy_GetMaxUnavailable(t *testing.T) {
+	var us *UpdateStrategy
+	assert.Equal(t, 1, us.GetMaxUnavailable(3))
+	assert.Equal(t, 2, us.GetMaxUnavailable(8))
+	x := intstr.FromInt(3)
+	us = &UpdateStrategy{MaxUnavailable: &x}
+	assert.Equal(t, 3, us.GetMaxUnavailable(8))
+	x = intstr.FromString("50%")
+	assert.Equal(t, 2, us.GetMaxUnavailable(5))
+	x = intstr.FromInt(0)
+	assert.Equal(t, 1, us.GetMaxUnavailable(5))
+}
+
+func TestGetFromBufferReadWeights(t *testing.T) {
+	v := testVertex.DeepCopy()
+	v.Spec.FromVertices = []string{"a", "b", "c"}
+	assert.Equal(t, []int64{1, 1, 1}, v.GetFromBufferReadWeights())
+	v.Spec.ReadWeights = map[string]uint32{"a": 3, "c": 0}
+	assert.Equal(t, []int64{3, 1, 1}, v.GetFromBufferReadWeights())
+}
+
+func TestGetDeadLetterQueues(t *testing.T) {
+	v := testVertex.DeepCopy()
+	v.Spec.FromVertices = []string{"a", "b"}
+	assert.Equal(t, 0, len(v.GetDeadLetterQueues()))
+	assert.Equal(t, 0, len(v.GetDeadLetterBuffers()))
+	x := uint32(5)
+	v.Spec.DeadLetterQueues = map[string]DeadLetterQueue{"b": {MaxRetries: &x}}
+	fromBuffer := GenerateBufferName(v.Namespace, v.Spec.PipelineName, "b", v.Spec.Name)
+	assert.Equal(t, map[string]DeadLetterQueue{fromBuffer: {MaxRetries: &x}}, v.GetDeadLetterQueues())
+	assert.Equal(t, []string{fromBuffer + "-dlq"}, v.GetDeadLetterBuffers())
+}
+
+func TestGetFromBufferMaxMessageAges(t *testing.T) {
+	v := testVertex.DeepCopy()
+	v.Spec.FromVertices = []string{"a", "b"}
+	assert.Equal(t, 0, len(v.GetFromBufferMaxMessageAges()))
+	v.Spec.MaxMessageAges = map[string]metav1.Duration{"a": {Duration: time.Hour}, "b": {}}
+	fromBuffer := GenerateBufferName(v.Namespace, v.Spec.PipelineName, "a", v.Spec.Name)
+	assert.Equal(t, map[string]time.Duration{fromBuffer: time.Hour}, v.GetFromBufferMaxMessageAges())
+}
+
+func TestGetReplyBuffers(t *testing.T) {
+	v := testVertex.DeepCopy()
+	assert.Equal(t, 0, len(v.GetReplyBuffers()))
+	v.Spec.Source = &Source{HTTP: &HTTPSource{}}
+	assert.Equal(t, 0, len(v.GetReplyBuffers()))
+	v.Spec.Source.HTTP.RequestReply = &RequestReply{}
+	v.Spec.Scale.Max = pointer.Int32(2)
+	assert.Equal(t, []string{"test-ns-test-pl-vtx-reply-0", "test-ns-test-pl-vtx-reply-1"}, v.GetReplyBuffers())
+}
+
+func Test_VertexErrorString(t *testing.T) {
+	e := VertexError{Pod: "p", Container: "udf", ExitCode: 1}
+	assert.Equal(t, `container "udf" of pod "p" exited with code 1`, e.Summary())
+	e.Reason = "Error"
+	e.Restarts = 3
+	e.Message = "panic"
+	assert.Equal(t, `container "udf" of pod "p" exited with code 1 (Error), restarted 3 times: panic`, e.Summary())
+}
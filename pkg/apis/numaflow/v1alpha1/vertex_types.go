@@ -13,11 +13,10 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"encoding/base64"
-	"encoding/json"
-	"errors"
 	fmt "fmt"
 	"os"
+	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,7 +64,11 @@ func (v Vertex) IsASink() bool {
 }
 
 func (v Vertex) IsAnUDF() bool {
-	return v.Spec.Sink == nil && v.Spec.Source == nil
+	return v.Spec.Sink == nil && v.Spec.Source == nil && v.Spec.Reduce == nil
+}
+
+func (v Vertex) IsAReduce() bool {
+	return v.Spec.Reduce != nil
 }
 
 func (v Vertex) GetHeadlessServiceName() string {
@@ -131,13 +134,13 @@ func (v Vertex) GetPodSpec(req GetVertexPodSpecReq) (*corev1.PodSpec, error) {
 		},
 		Spec: v.Spec.WithOutReplicas(),
 	}
-	vertexBytes, err := json.Marshal(vertexCopy)
+	encodedVertexSpec, err := EncodeVertex(vertexCopy)
 	if err != nil {
-		return nil, errors.New("failed to marshal vertex spec")
+		return nil, err
 	}
-	encodedVertexSpec := base64.StdEncoding.EncodeToString(vertexBytes)
 	envVars := []corev1.EnvVar{
 		{Name: EnvVertexObject, Value: encodedVertexSpec},
+		{Name: EnvVertexObjectVersion, Value: strconv.Itoa(VertexEncodingVersion)},
 	}
 	envVars = append(envVars, v.commonEvns()...)
 	envVars = append(envVars, req.Env...)
@@ -171,6 +174,18 @@ func (v Vertex) GetPodSpec(req GetVertexPodSpecReq) (*corev1.PodSpec, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The annotations are projected for the main container to notice the drain request before the pod is scaled down.
+	podInfoVolumeName := "podinfo"
+	volumes = append(volumes, corev1.Volume{
+		Name: podInfoVolumeName,
+		VolumeSource: corev1.VolumeSource{DownwardAPI: &corev1.DownwardAPIVolumeSource{
+			Items: []corev1.DownwardAPIVolumeFile{
+				{Path: "annotations", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations"}},
+			},
+		}},
+	})
+	containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{Name: podInfoVolumeName, MountPath: PathPodInfo, ReadOnly: true})
+	containers[0].Ports = append(containers[0].Ports, corev1.ContainerPort{Name: VertexMetricsPortName, ContainerPort: VertexMetricsPort})
 	containers[0].ReadinessProbe = &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
@@ -183,39 +198,62 @@ func (v Vertex) GetPodSpec(req GetVertexPodSpecReq) (*corev1.PodSpec, error) {
 		PeriodSeconds:       3,
 		TimeoutSeconds:      1,
 	}
+	// The liveness probe also fails once the forwarder is stuck, see Liveness.
 	containers[0].LivenessProbe = &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
-				Path:   "/healthz",
+				Path:   VertexLivenessPath,
 				Port:   intstr.FromInt(VertexMetricsPort),
 				Scheme: corev1.URISchemeHTTPS,
 			},
 		},
 		InitialDelaySeconds: 3,
-		PeriodSeconds:       3,
+		PeriodSeconds:       VertexLivenessProbePeriodSeconds,
 		TimeoutSeconds:      1,
 	}
 
+	if x := v.Spec.ContainerTemplate; x != nil && x.SecurityContext != nil {
+		containers[0].SecurityContext = x.SecurityContext.DeepCopy()
+	}
+
 	if len(containers) > 1 { // udf and udsink
 		containers[1].Env = append(containers[1].Env, v.commonEvns()...)
+		// Keep the sidecar running until the main container finishes draining, which still depends on it.
+		containers[1].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   VertexPreStopPath,
+					Port:   intstr.FromInt(VertexPreStopPort),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			},
+		}
 	}
 
 	spec := &corev1.PodSpec{
-		Subdomain:          v.GetHeadlessServiceName(),
-		NodeSelector:       v.Spec.NodeSelector,
-		Tolerations:        v.Spec.Tolerations,
-		SecurityContext:    v.Spec.SecurityContext,
-		ImagePullSecrets:   v.Spec.ImagePullSecrets,
-		PriorityClassName:  v.Spec.PriorityClassName,
-		Priority:           v.Spec.Priority,
-		Affinity:           v.Spec.Affinity,
-		ServiceAccountName: v.Spec.ServiceAccountName,
-		Volumes:            append(volumes, v.Spec.Volumes...),
+		Subdomain:                     v.GetHeadlessServiceName(),
+		NodeSelector:                  v.Spec.NodeSelector,
+		Tolerations:                   v.Spec.Tolerations,
+		SecurityContext:               v.Spec.SecurityContext,
+		ImagePullSecrets:              v.Spec.ImagePullSecrets,
+		PriorityClassName:             v.Spec.PriorityClassName,
+		Priority:                      v.Spec.Priority,
+		Affinity:                      v.Spec.Affinity,
+		ServiceAccountName:            v.Spec.ServiceAccountName,
+		Volumes:                       append(volumes, v.Spec.Volumes...),
+		TerminationGracePeriodSeconds: v.Spec.TerminationGracePeriodSeconds,
 		InitContainers: []corev1.Container{
 			v.getInitContainer(req),
 		},
 		Containers: containers,
 	}
+	if v.IsASource() && v.Spec.Source.IsBounded() {
+		// The replicas of a bounded source exit once they reach the end, which are not restarted
+		spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	}
+	v.applySideInputsToPodSpec(spec, req, encodedVertexSpec)
+	v.Spec.Storage.applyToPodSpec(spec)
+	v.Spec.PodSecurity.ApplyToPodSpec(spec)
 	return spec, nil
 }
 
@@ -235,6 +273,14 @@ func (v Vertex) getInitContainer(req GetVertexPodSpecReq) corev1.Container {
 	}
 }
 
+// GetCorrelationID returns the correlation ID of the pipeline owning the vertex, it's empty if it's not owned by a pipeline.
+func (v Vertex) GetCorrelationID() string {
+	if ref := metav1.GetControllerOf(&v); ref != nil && ref.Kind == PipelineGroupVersionKind.Kind {
+		return CorrelationID(ref.UID)
+	}
+	return ""
+}
+
 func (v Vertex) GetFromBuffers() []string {
 	r := []string{}
 	for _, vt := range v.Spec.FromVertices {
@@ -243,6 +289,53 @@ func (v Vertex) GetFromBuffers() []string {
 	return r
 }
 
+// GetFromBufferReadWeights returns the read weights of the from buffers, in the same order as GetFromBuffers().
+func (v Vertex) GetFromBufferReadWeights() []int64 {
+	r := []int64{}
+	for _, vt := range v.Spec.FromVertices {
+		w := int64(1)
+		if x, ok := v.Spec.ReadWeights[vt]; ok && x > 0 {
+			w = int64(x)
+		}
+		r = append(r, w)
+	}
+	return r
+}
+
+// GetDeadLetterQueues returns the dead-letter queue configs of the from buffers which have one, keyed by the from buffer names.
+func (v Vertex) GetDeadLetterQueues() map[string]DeadLetterQueue {
+	r := map[string]DeadLetterQueue{}
+	for _, vt := range v.Spec.FromVertices {
+		if x, ok := v.Spec.DeadLetterQueues[vt]; ok {
+			r[GenerateBufferName(v.Namespace, v.Spec.PipelineName, vt, v.Spec.Name)] = x
+		}
+	}
+	return r
+}
+
+// GetFromBufferMaxMessageAges returns the time to live of the messages of the from buffers which have one, keyed by the
+// from buffer names.
+func (v Vertex) GetFromBufferMaxMessageAges() map[string]time.Duration {
+	r := make(map[string]time.Duration)
+	for _, vt := range v.Spec.FromVertices {
+		if x, ok := v.Spec.MaxMessageAges[vt]; ok && x.Duration > 0 {
+			r[GenerateBufferName(v.Namespace, v.Spec.PipelineName, vt, v.Spec.Name)] = x.Duration
+		}
+	}
+	return r
+}
+
+// GetDeadLetterBuffers returns the dead-letter buffers of the from buffers which have one.
+func (v Vertex) GetDeadLetterBuffers() []string {
+	r := []string{}
+	for _, vt := range v.Spec.FromVertices {
+		if _, ok := v.Spec.DeadLetterQueues[vt]; ok {
+			r = append(r, GenerateDeadLetterBufferName(v.Namespace, v.Spec.PipelineName, vt, v.Spec.Name))
+		}
+	}
+	return r
+}
+
 func (v Vertex) GetToBuffers() []string {
 	r := []string{}
 	for _, vt := range v.Spec.ToVertices {
@@ -251,6 +344,82 @@ func (v Vertex) GetToBuffers() []string {
 	return r
 }
 
+// GetReplyBuffers returns the reply buffers of an http source in request-reply mode, one for each possible replica.
+func (v Vertex) GetReplyBuffers() []string {
+	r := []string{}
+	if !v.IsASource() || !v.Spec.Source.HTTP.IsRequestReply() {
+		return r
+	}
+	for i := int32(0); i < v.Spec.Scale.GetMaxReplicas(); i++ {
+		r = append(r, GenerateReplyBufferName(v.Namespace, v.Spec.PipelineName, v.Spec.Name, int(i)))
+	}
+	return r
+}
+
+// IsExactlyOnceFromBuffer tells if the messages of the from buffer are deduplicated by the writers, so that the readers
+// wait for their acks to be confirmed, see EdgeLimits.
+func (v Vertex) IsExactlyOnceFromBuffer(bufferName string) bool {
+	for _, vt := range v.Spec.FromVertices {
+		if GenerateBufferName(v.Namespace, v.Spec.PipelineName, vt, v.Spec.Name) == bufferName {
+			if x, ok := v.Spec.ExactlyOnce[vt]; ok {
+				return x
+			}
+		}
+	}
+	return v.IsFeatureEnabled(FeatureGateExactlyOnce)
+}
+
+// IsExactlyOnceToBuffer tells if the messages written to the to buffer are deduplicated, see EdgeLimits.
+func (v Vertex) IsExactlyOnceToBuffer(bufferName string) bool {
+	featureEnabled := v.IsFeatureEnabled(FeatureGateExactlyOnce)
+	for _, vt := range v.Spec.ToVertices {
+		if v.GetToBufferName(vt.Name) == bufferName {
+			return vt.Limits.IsExactlyOnce(featureEnabled)
+		}
+	}
+	return featureEnabled
+}
+
+// GetToBufferDurability returns the acknowledgement the writes to the to buffer wait for, see EdgeLimits.
+func (v Vertex) GetToBufferDurability(bufferName string) WriteDurability {
+	for _, vt := range v.Spec.ToVertices {
+		if v.GetToBufferName(vt.Name) == bufferName {
+			return vt.Limits.GetDurability()
+		}
+	}
+	return WriteDurabilityAcknowledged
+}
+
+// GetToBufferOnFull returns the strategy of the writes once the to buffer is full, see EdgeLimits.
+func (v Vertex) GetToBufferOnFull(bufferName string) OnFullWritingStrategy {
+	for _, vt := range v.Spec.ToVertices {
+		if v.GetToBufferName(vt.Name) == bufferName {
+			return vt.Limits.GetOnFull()
+		}
+	}
+	return OnFullRetryUntilSuccess
+}
+
+// GetToBufferCompression returns the content encoding of the payloads written to the to buffer, see EdgeLimits.
+func (v Vertex) GetToBufferCompression(bufferName string) ContentEncoding {
+	for _, vt := range v.Spec.ToVertices {
+		if v.GetToBufferName(vt.Name) == bufferName {
+			return vt.Limits.GetCompression()
+		}
+	}
+	return ""
+}
+
+// GetToBufferTrace returns the tracing config of the messages written to the to buffer, nil if it's not traced.
+func (v Vertex) GetToBufferTrace(bufferName string) *EdgeTrace {
+	for _, vt := range v.Spec.ToVertices {
+		if v.GetToBufferName(vt.Name) == bufferName {
+			return vt.Trace
+		}
+	}
+	return nil
+}
+
 func (v Vertex) GetToBufferName(toVertexName string) string {
 	return GenerateBufferName(v.Namespace, v.Spec.PipelineName, v.Spec.Name, toVertexName)
 }
@@ -267,12 +436,44 @@ type VertexSpec struct {
 	FromVertices []string `json:"fromVertices,omitempty" protobuf:"bytes,5,rep,name=fromVertices"`
 	// +optional
 	ToVertices []ToVertex `json:"toVertices,omitempty" protobuf:"bytes,6,rep,name=toVertices"`
+	// ReadWeights of the inbound edges, keyed by the from vertex names, a missing one defaults to 1.
+	// +optional
+	ReadWeights map[string]uint32 `json:"readWeights,omitempty" protobuf:"bytes,7,rep,name=readWeights"`
+	// FeatureGates are the resolved feature gates of the pipeline, a missing one uses the default.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty" protobuf:"bytes,8,rep,name=featureGates"`
+	// DeadLetterQueues of the inbound edges, keyed by the from vertex names.
+	// +optional
+	DeadLetterQueues map[string]DeadLetterQueue `json:"deadLetterQueues,omitempty" protobuf:"bytes,9,rep,name=deadLetterQueues"`
+	// PodSecurity overrides the security defaults of the pods, copied from the pipeline.
+	// +optional
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty" protobuf:"bytes,10,opt,name=podSecurity"`
+	// Audit is the audit mode of the pipeline, copied from the pipeline.
+	// +optional
+	Audit *PipelineAudit `json:"audit,omitempty" protobuf:"bytes,11,opt,name=audit"`
+	// Tracing is the tracing of the pipeline, copied from the pipeline.
+	// +optional
+	Tracing *PipelineTracing `json:"tracing,omitempty" protobuf:"bytes,12,opt,name=tracing"`
+	// MaxMessageAges of the inbound edges, keyed by the from vertex names, the messages of an edge without one never
+	// expire.
+	// +optional
+	MaxMessageAges map[string]metav1.Duration `json:"maxMessageAges,omitempty" protobuf:"bytes,13,rep,name=maxMessageAges"`
+	// ExactlyOnce of the inbound edges, keyed by the from vertex names, an edge without one follows the ExactlyOnce
+	// feature gate.
+	// +optional
+	ExactlyOnce map[string]bool `json:"exactlyOnce,omitempty" protobuf:"bytes,14,rep,name=exactlyOnce"`
 }
 
 type ToVertex struct {
 	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
 	// +optional
 	Conditions *ForwardConditions `json:"conditions" protobuf:"bytes,2,opt,name=conditions"`
+	// Limits of the buffer of the edge.
+	// +optional
+	Limits *EdgeLimits `json:"limits,omitempty" protobuf:"bytes,3,opt,name=limits"`
+	// Trace of the messages written to the buffer of the edge.
+	// +optional
+	Trace *EdgeTrace `json:"trace,omitempty" protobuf:"bytes,4,opt,name=trace"`
 }
 
 func (vs VertexSpec) WithOutReplicas() VertexSpec {
@@ -353,6 +554,38 @@ type AbstractVertex struct {
 	Limits *VertexLimits `json:"limits,omitempty" protobuf:"bytes,17,opt,name=limits"`
 	// +optional
 	Scale Scale `json:"scale,omitempty" protobuf:"bytes,18,opt,name=scale"`
+	// SlowStart ramps up the read batch size of a newly started replica gradually, it is only meaningful for UDF and Sink vertices.
+	// +optional
+	SlowStart *SlowStart `json:"slowStart,omitempty" protobuf:"bytes,19,opt,name=slowStart"`
+	// TerminationGracePeriodSeconds is the duration the pod is given to drain the in-flight messages and terminate,
+	// defaults to the Kubernetes default (30 seconds). The UDF or UDSink container is only terminated after the main
+	// container finishes draining, or the grace period expires.
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty" protobuf:"varint,20,opt,name=terminationGracePeriodSeconds"`
+	// TenantKey is the key of the message metadata identifying the tenant of a message. If it's specified, the metrics
+	// of the vertex are also counted by tenant, and the rate limit of a source vertex applies to each tenant separately.
+	// +optional
+	TenantKey string `json:"tenantKey,omitempty" protobuf:"bytes,21,opt,name=tenantKey"`
+	// Reduce makes the vertex a reduce vertex, which aggregates the messages by key in the windows of their event time.
+	// A reduce vertex runs with one replica, as the messages of a key are not partitioned across the replicas.
+	// +optional
+	Reduce *Reduce `json:"reduce,omitempty" protobuf:"bytes,22,opt,name=reduce"`
+	// Storage configures the scratch volume and the ephemeral storage of the containers.
+	// +optional
+	Storage *VertexStorage `json:"storage,omitempty" protobuf:"bytes,23,opt,name=storage"`
+	// UpdateStrategy is how the pods are replaced when the pod spec changes, e.g. the image, the env or the resources.
+	// +optional
+	UpdateStrategy *UpdateStrategy `json:"updateStrategy,omitempty" protobuf:"bytes,24,opt,name=updateStrategy"`
+	// SideInputs are the slowly-changing reference datasets made available to the UDF in /var/numaflow/side-inputs,
+	// it is only meaningful for UDF vertices.
+	// +optional
+	// +patchStrategy=merge
+	// +patchMergeKey=name
+	SideInputs []SideInput `json:"sideInputs,omitempty" protobuf:"bytes,25,rep,name=sideInputs"`
+	// Liveness restarts the pod of which the forwarder is stuck, i.e. it makes no progress reading, writing or acking the
+	// messages for a number of the liveness probe periods. It's not applicable to reduce vertices.
+	// +optional
+	Liveness *Liveness `json:"liveness,omitempty" protobuf:"bytes,26,opt,name=liveness"`
 }
 
 type Scale struct {
@@ -364,6 +597,137 @@ type Scale struct {
 	// +kubebuilder:default=1
 	// +optional
 	Max *int32 `json:"max,omitempty" protobuf:"varint,2,opt,name=max"`
+	// DrainTimeout is the maximum time to wait for a replica to drain its in-flight messages when scaling down,
+	// before it is deleted, defaults to 3m.
+	// +kubebuilder:default="3m"
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty" protobuf:"bytes,3,opt,name=drainTimeout"`
+	// Disabled disables auto scaling, the replicas are then managed manually between min and max.
+	// +optional
+	Disabled bool `json:"disabled,omitempty" protobuf:"varint,4,opt,name=disabled"`
+	// TargetBufferUsage is the percentage of the usage of the buffers the vertex reads from, which auto scaling tries to
+	// keep the vertex at, defaults to 50.
+	// +optional
+	TargetBufferUsage *uint32 `json:"targetBufferUsage,omitempty" protobuf:"varint,5,opt,name=targetBufferUsage"`
+	// Cooldown is the minimal time between two auto scaling operations, defaults to 90s.
+	// +kubebuilder:default="90s"
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty" protobuf:"bytes,6,opt,name=cooldown"`
+}
+
+// GetMinReplicas returns the minimal replicas, defaults to 1.
+func (s Scale) GetMinReplicas() int32 {
+	if s.Min != nil {
+		return *s.Min
+	}
+	return 1
+}
+
+// GetMaxReplicas returns the maximum replicas, defaults to 1.
+func (s Scale) GetMaxReplicas() int32 {
+	if s.Max != nil {
+		return *s.Max
+	}
+	return 1
+}
+
+// IsAutoScalingEnabled tells if the vertex is scaled automatically, which requires max to be greater than min.
+func (s Scale) IsAutoScalingEnabled() bool {
+	return !s.Disabled && s.GetMaxReplicas() > s.GetMinReplicas()
+}
+
+func (s Scale) GetTargetBufferUsage() uint32 {
+	if s.TargetBufferUsage != nil {
+		return *s.TargetBufferUsage
+	}
+	return DefaultTargetBufferUsage
+}
+
+func (s Scale) GetCooldown() time.Duration {
+	if s.Cooldown != nil {
+		return s.Cooldown.Duration
+	}
+	return DefaultScaleCooldown
+}
+
+func (s Scale) GetDrainTimeout() time.Duration {
+	if s.DrainTimeout != nil {
+		return s.DrainTimeout.Duration
+	}
+	return DefaultDrainTimeout
+}
+
+type SlowStart struct {
+	// Duration is the time used to ramp the read batch size up from the initial read batch size to the one defined in the limits.
+	// +kubebuilder:default="60s"
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty" protobuf:"bytes,1,opt,name=duration"`
+	// InitialReadBatchSize is the read batch size a new replica starts with, defaults to 1.
+	// +kubebuilder:default=1
+	// +optional
+	InitialReadBatchSize *uint64 `json:"initialReadBatchSize,omitempty" protobuf:"varint,2,opt,name=initialReadBatchSize"`
+}
+
+func (ss SlowStart) GetDuration() time.Duration {
+	if ss.Duration != nil {
+		return ss.Duration.Duration
+	}
+	return DefaultSlowStartDuration
+}
+
+func (ss SlowStart) GetInitialReadBatchSize() uint64 {
+	if ss.InitialReadBatchSize != nil && *ss.InitialReadBatchSize > 0 {
+		return *ss.InitialReadBatchSize
+	}
+	return 1
+}
+
+type Liveness struct {
+	// StuckPeriods is the number of the liveness probe periods without any progress of the forwarder, after which the
+	// forwarder is considered stuck and the pod is restarted, defaults to 60 (3 minutes). It needs to allow for the
+	// longest UDF call, as the forwarder waits for it.
+	// +optional
+	StuckPeriods *uint32 `json:"stuckPeriods,omitempty" protobuf:"varint,1,opt,name=stuckPeriods"`
+	// Disabled disables the detection of the stuck forwarder, the liveness probe then only checks the pod is serving.
+	// +optional
+	Disabled bool `json:"disabled,omitempty" protobuf:"varint,2,opt,name=disabled"`
+}
+
+// GetStuckThreshold returns the time without any progress of the forwarder after which it's considered stuck, 0 means
+// the detection is disabled.
+func (l *Liveness) GetStuckThreshold() time.Duration {
+	periods := uint32(DefaultLivenessStuckPeriods)
+	if l != nil {
+		if l.Disabled {
+			return 0
+		}
+		if l.StuckPeriods != nil && *l.StuckPeriods > 0 {
+			periods = *l.StuckPeriods
+		}
+	}
+	return time.Duration(periods) * VertexLivenessProbePeriodSeconds * time.Second
+}
+
+// UpdateStrategy replaces the outdated pods in batches, each of them is drained before it's deleted, and the next batch
+// starts once the new pods are ready.
+type UpdateStrategy struct {
+	// MaxUnavailable is the max number or percentage of the replicas being unavailable during the update, the percentage
+	// is rounded down, and at least one replica is updated at a time. Defaults to 25%.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" protobuf:"bytes,1,opt,name=maxUnavailable"`
+}
+
+// GetMaxUnavailable returns the max number of the unavailable replicas out of the replicas, at least 1.
+func (us *UpdateStrategy) GetMaxUnavailable(replicas int) int {
+	maxUnavailable := intstr.FromString(DefaultMaxUnavailable)
+	if us != nil && us.MaxUnavailable != nil {
+		maxUnavailable = *us.MaxUnavailable
+	}
+	n, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, replicas, false)
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
 }
 
 type VertexLimits struct {
@@ -384,6 +748,16 @@ type VertexLimits struct {
 	// Only meaningful for UDF and Source vertice as only they do buffer write.
 	// +optional
 	BufferUsageLimit *uint32 `json:"bufferUsageLimit,omitempty" protobuf:"varint,4,opt,name=bufferUsageLimit"`
+	// MaxKeySize is the max size in bytes of the keys of the messages written to the buffers.
+	// It overrides the settings from pipeline limits.
+	// Only meaningful for UDF and Source vertice as only they do buffer write.
+	// +optional
+	MaxKeySize *uint32 `json:"maxKeySize,omitempty" protobuf:"varint,5,opt,name=maxKeySize"`
+	// MaxHeaderSize is the max size in bytes of the headers of the messages written to the buffers.
+	// It overrides the settings from pipeline limits.
+	// Only meaningful for UDF and Source vertice as only they do buffer write.
+	// +optional
+	MaxHeaderSize *uint32 `json:"maxHeaderSize,omitempty" protobuf:"varint,6,opt,name=maxHeaderSize"`
 }
 
 func (v VertexSpec) getType() containerSupplier {
@@ -393,6 +767,8 @@ func (v VertexSpec) getType() containerSupplier {
 		return x
 	} else if x := v.UDF; x != nil {
 		return x
+	} else if x := v.Reduce; x != nil {
+		return x
 	} else {
 		panic("invalid vertex spec")
 	}
@@ -405,6 +781,43 @@ type VertexStatus struct {
 	Replicas     uint32      `json:"replicas" protobuf:"varint,3,opt,name=replicas"`
 	Selector     string      `json:"selector,omitempty" protobuf:"bytes,5,opt,name=selector"`
 	LastScaledAt metav1.Time `json:"lastScaledAt,omitempty" protobuf:"bytes,4,opt,name=lastScaledAt"`
+	// LastError is the last fatal error of the vertex pods, e.g. a crash looping UDF or a sink failing to
+	// authenticate, it's cleared once the pods recover.
+	// +optional
+	LastError *VertexError `json:"lastError,omitempty" protobuf:"bytes,7,opt,name=lastError"`
+	// UpdatedReplicas is the number of the replicas running with the latest pod spec.
+	// +optional
+	UpdatedReplicas uint32 `json:"updatedReplicas,omitempty" protobuf:"varint,8,opt,name=updatedReplicas"`
+}
+
+// VertexError is the fatal error of a container of a vertex pod.
+type VertexError struct {
+	Pod       string `json:"pod" protobuf:"bytes,1,opt,name=pod"`
+	Container string `json:"container" protobuf:"bytes,2,opt,name=container"`
+	// Reason of the termination, e.g. Error, OOMKilled.
+	// +optional
+	Reason string `json:"reason,omitempty" protobuf:"bytes,3,opt,name=reason"`
+	// Message is the termination message of the container.
+	// +optional
+	Message  string      `json:"message,omitempty" protobuf:"bytes,4,opt,name=message"`
+	ExitCode int32       `json:"exitCode" protobuf:"varint,5,opt,name=exitCode"`
+	Restarts int32       `json:"restarts" protobuf:"varint,6,opt,name=restarts"`
+	Time     metav1.Time `json:"time,omitempty" protobuf:"bytes,7,opt,name=time"`
+}
+
+// Summary returns a one line description of the error.
+func (ve VertexError) Summary() string {
+	s := fmt.Sprintf("container %q of pod %q exited with code %d", ve.Container, ve.Pod, ve.ExitCode)
+	if ve.Reason != "" {
+		s += fmt.Sprintf(" (%s)", ve.Reason)
+	}
+	if ve.Restarts > 0 {
+		s += fmt.Sprintf(", restarted %d times", ve.Restarts)
+	}
+	if ve.Message != "" {
+		s += ": " + ve.Message
+	}
+	return s
 }
 
 func (vs *VertexStatus) MarkPhase(phase VertexPhase, reason, message string) {
@@ -421,6 +834,11 @@ func (vs *VertexStatus) MarkPhaseRunning() {
 	vs.MarkPhase(VertexPhaseRunning, "", "")
 }
 
+// MarkPhaseSucceeded set the Vertex has been succeeded, i.e. all the replicas of a bounded source reach the end.
+func (vs *VertexStatus) MarkPhaseSucceeded() {
+	vs.MarkPhase(VertexPhaseSucceeded, "", "")
+}
+
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type VertexList struct {
@@ -433,3 +851,18 @@ type VertexList struct {
 func GenerateBufferName(namespace, pipelineName, fromVetex, toVertex string) string {
 	return fmt.Sprintf("%s-%s-%s-%s", namespace, pipelineName, fromVetex, toVertex)
 }
+
+// GenerateDeadLetterBufferName returns the name of the dead-letter buffer of an edge.
+func GenerateDeadLetterBufferName(namespace, pipelineName, fromVetex, toVertex string) string {
+	return DeadLetterBufferName(GenerateBufferName(namespace, pipelineName, fromVetex, toVertex))
+}
+
+// GenerateReplyBufferName returns the name of the reply buffer of a replica of an http source in request-reply mode.
+func GenerateReplyBufferName(namespace, pipelineName, sourceVertex string, replica int) string {
+	return fmt.Sprintf("%s-%s-%s-reply-%d", namespace, pipelineName, sourceVertex, replica)
+}
+
+// DeadLetterBufferName returns the name of the dead-letter buffer of a buffer.
+func DeadLetterBufferName(bufferName string) string {
+	return bufferName + "-dlq"
+}
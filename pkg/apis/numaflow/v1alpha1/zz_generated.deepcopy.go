@@ -25,8 +25,59 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbstractPodTemplate) DeepCopyInto(out *AbstractPodTemplate) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbstractPodTemplate.
+func (in *AbstractPodTemplate) DeepCopy() *AbstractPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AbstractPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AbstractVertex) DeepCopyInto(out *AbstractVertex) {
 	*out = *in
@@ -102,6 +153,43 @@ func (in *AbstractVertex) DeepCopyInto(out *AbstractVertex) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Scale.DeepCopyInto(&out.Scale)
+	if in.SlowStart != nil {
+		in, out := &in.SlowStart, &out.SlowStart
+		*out = new(SlowStart)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Reduce != nil {
+		in, out := &in.Reduce, &out.Reduce
+		*out = new(Reduce)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(VertexStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(UpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SideInputs != nil {
+		in, out := &in.SideInputs, &out.SideInputs
+		*out = make([]SideInput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(Liveness)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -149,6 +237,11 @@ func (in *BufferServiceConfig) DeepCopyInto(out *BufferServiceConfig) {
 		*out = new(JetStreamConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -162,6 +255,51 @@ func (in *BufferServiceConfig) DeepCopy() *BufferServiceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BufferServiceUsage) DeepCopyInto(out *BufferServiceUsage) {
+	*out = *in
+	out.UsedStorage = in.UsedStorage.DeepCopy()
+	if in.AvailableStorage != nil {
+		in, out := &in.AvailableStorage, &out.AvailableStorage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	out.UsedMemory = in.UsedMemory.DeepCopy()
+	if in.AvailableMemory != nil {
+		in, out := &in.AvailableMemory, &out.AvailableMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BufferServiceUsage.
+func (in *BufferServiceUsage) DeepCopy() *BufferServiceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(BufferServiceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuer) DeepCopyInto(out *CertManagerIssuer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuer.
+func (in *CertManagerIssuer) DeepCopy() *CertManagerIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Container) DeepCopyInto(out *Container) {
 	*out = *in
@@ -232,6 +370,27 @@ func (in *ContainerTemplate) DeepCopy() *ContainerTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadLetterQueue) DeepCopyInto(out *DeadLetterQueue) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadLetterQueue.
+func (in *DeadLetterQueue) DeepCopy() *DeadLetterQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadLetterQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Edge) DeepCopyInto(out *Edge) {
 	*out = *in
@@ -240,6 +399,26 @@ func (in *Edge) DeepCopyInto(out *Edge) {
 		*out = new(ForwardConditions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReadWeight != nil {
+		in, out := &in.ReadWeight, &out.ReadWeight
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.DeadLetterQueue != nil {
+		in, out := &in.DeadLetterQueue, &out.DeadLetterQueue
+		*out = new(DeadLetterQueue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(EdgeLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trace != nil {
+		in, out := &in.Trace, &out.Trace
+		*out = new(EdgeTrace)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -253,6 +432,105 @@ func (in *Edge) DeepCopy() *Edge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EdgeLimits) DeepCopyInto(out *EdgeLimits) {
+	*out = *in
+	if in.ExactlyOnce != nil {
+		in, out := &in.ExactlyOnce, &out.ExactlyOnce
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxMessageAge != nil {
+		in, out := &in.MaxMessageAge, &out.MaxMessageAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EdgeLimits.
+func (in *EdgeLimits) DeepCopy() *EdgeLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(EdgeLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EdgeTrace) DeepCopyInto(out *EdgeTrace) {
+	*out = *in
+	if in.PayloadSizeLimit != nil {
+		in, out := &in.PayloadSizeLimit, &out.PayloadSizeLimit
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxRecords != nil {
+		in, out := &in.MaxRecords, &out.MaxRecords
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EdgeTrace.
+func (in *EdgeTrace) DeepCopy() *EdgeTrace {
+	if in == nil {
+		return nil
+	}
+	out := new(EdgeTrace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralStorage) DeepCopyInto(out *EphemeralStorage) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralStorage.
+func (in *EphemeralStorage) DeepCopy() *EphemeralStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FixedWindow) DeepCopyInto(out *FixedWindow) {
+	*out = *in
+	if in.Length != nil {
+		in, out := &in.Length, &out.Length
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FixedWindow.
+func (in *FixedWindow) DeepCopy() *FixedWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(FixedWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardConditions) DeepCopyInto(out *ForwardConditions) {
 	*out = *in
@@ -302,6 +580,43 @@ func (in *Function) DeepCopy() *Function {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratorJitter) DeepCopyInto(out *GeneratorJitter) {
+	*out = *in
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorJitter.
+func (in *GeneratorJitter) DeepCopy() *GeneratorJitter {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratorJitter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratorKeys) DeepCopyInto(out *GeneratorKeys) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratorKeys.
+func (in *GeneratorKeys) DeepCopy() *GeneratorKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratorKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneratorSource) DeepCopyInto(out *GeneratorSource) {
 	*out = *in
@@ -320,6 +635,31 @@ func (in *GeneratorSource) DeepCopyInto(out *GeneratorSource) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.LoadProfile != nil {
+		in, out := &in.LoadProfile, &out.LoadProfile
+		*out = new(LoadProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = new(GeneratorKeys)
+		**out = **in
+	}
+	if in.MaxMessages != nil {
+		in, out := &in.MaxMessages, &out.MaxMessages
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(GeneratorJitter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -472,50 +812,133 @@ func (in *GetVertexPodSpecReq) DeepCopy() *GetVertexPodSpecReq {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPSource) DeepCopyInto(out *HTTPSource) {
+func (in *HMACSignature) DeepCopyInto(out *HMACSignature) {
 	*out = *in
-	if in.Auth != nil {
-		in, out := &in.Auth, &out.Auth
-		*out = new(Authorization)
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSource.
-func (in *HTTPSource) DeepCopy() *HTTPSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACSignature.
+func (in *HMACSignature) DeepCopy() *HMACSignature {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPSource)
+	out := new(HMACSignature)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InterStepBufferService) DeepCopyInto(out *InterStepBufferService) {
+func (in *HTTPSharding) DeepCopyInto(out *HTTPSharding) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.DedupWindow != nil {
+		in, out := &in.DedupWindow, &out.DedupWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterStepBufferService.
-func (in *InterStepBufferService) DeepCopy() *InterStepBufferService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSharding.
+func (in *HTTPSharding) DeepCopy() *HTTPSharding {
 	if in == nil {
 		return nil
 	}
-	out := new(InterStepBufferService)
+	out := new(HTTPSharding)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InterStepBufferService) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSideInput) DeepCopyInto(out *HTTPSideInput) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSideInput.
+func (in *HTTPSideInput) DeepCopy() *HTTPSideInput {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSideInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSource) DeepCopyInto(out *HTTPSource) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(Authorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(HMACSignature)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestReply != nil {
+		in, out := &in.RequestReply, &out.RequestReply
+		*out = new(RequestReply)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(HTTPSharding)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSource.
+func (in *HTTPSource) DeepCopy() *HTTPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterStepBufferService) DeepCopyInto(out *InterStepBufferService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterStepBufferService.
+func (in *InterStepBufferService) DeepCopy() *InterStepBufferService {
+	if in == nil {
+		return nil
+	}
+	out := new(InterStepBufferService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterStepBufferService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
 	return nil
 }
@@ -566,6 +989,11 @@ func (in *InterStepBufferServiceSpec) DeepCopyInto(out *InterStepBufferServiceSp
 		*out = new(JetStreamBufferService)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -584,6 +1012,11 @@ func (in *InterStepBufferServiceStatus) DeepCopyInto(out *InterStepBufferService
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
 	in.Config.DeepCopyInto(&out.Config)
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(BufferServiceUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -679,6 +1112,26 @@ func (in *JetStreamBufferService) DeepCopyInto(out *JetStreamBufferService) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSIssuer != nil {
+		in, out := &in.TLSIssuer, &out.TLSIssuer
+		*out = new(CertManagerIssuer)
+		**out = **in
+	}
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(JetStreamStreamConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(JetStreamMirror)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -700,6 +1153,21 @@ func (in *JetStreamConfig) DeepCopyInto(out *JetStreamConfig) {
 		*out = new(NATSAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLSCACert != nil {
+		in, out := &in.TLSCACert, &out.TLSCACert
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(JetStreamStreamConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(JetStreamMirror)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -713,6 +1181,89 @@ func (in *JetStreamConfig) DeepCopy() *JetStreamConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JetStreamMirror) DeepCopyInto(out *JetStreamMirror) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(NATSAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSCACert != nil {
+		in, out := &in.TLSCACert, &out.TLSCACert
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamMirror.
+func (in *JetStreamMirror) DeepCopy() *JetStreamMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(JetStreamMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JetStreamStreamConfig) DeepCopyInto(out *JetStreamStreamConfig) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBytes != nil {
+		in, out := &in.MaxBytes, &out.MaxBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JetStreamStreamConfig.
+func (in *JetStreamStreamConfig) DeepCopy() *JetStreamStreamConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JetStreamStreamConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaConfig) DeepCopyInto(out *KafkaConfig) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConfig.
+func (in *KafkaConfig) DeepCopy() *KafkaConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaSink) DeepCopyInto(out *KafkaSink) {
 	*out = *in
@@ -752,6 +1303,11 @@ func (in *KafkaSource) DeepCopyInto(out *KafkaSource) {
 		*out = new(TLS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SASL != nil {
+		in, out := &in.SASL, &out.SASL
+		*out = new(SASL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -781,9 +1337,71 @@ func (in *Lifecycle) DeepCopy() *Lifecycle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Liveness) DeepCopyInto(out *Liveness) {
+	*out = *in
+	if in.StuckPeriods != nil {
+		in, out := &in.StuckPeriods, &out.StuckPeriods
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Liveness.
+func (in *Liveness) DeepCopy() *Liveness {
+	if in == nil {
+		return nil
+	}
+	out := new(Liveness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadProfile) DeepCopyInto(out *LoadProfile) {
+	*out = *in
+	if in.PeakRPU != nil {
+		in, out := &in.PeakRPU, &out.PeakRPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SpikeDuration != nil {
+		in, out := &in.SpikeDuration, &out.SpikeDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadProfile.
+func (in *LoadProfile) DeepCopy() *LoadProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Log) DeepCopyInto(out *Log) {
 	*out = *in
+	if in.SamplePercentage != nil {
+		in, out := &in.SamplePercentage, &out.SamplePercentage
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxMessagesPerSecond != nil {
+		in, out := &in.MaxMessagesPerSecond, &out.MaxMessagesPerSecond
+		*out = new(uint32)
+		**out = **in
+	}
 	return
 }
 
@@ -797,6 +1415,27 @@ func (in *Log) DeepCopy() *Log {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedRedis) DeepCopyInto(out *ManagedRedis) {
+	*out = *in
+	if in.DisabledCommands != nil {
+		in, out := &in.DisabledCommands, &out.DisabledCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedRedis.
+func (in *ManagedRedis) DeepCopy() *ManagedRedis {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedRedis)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
@@ -827,6 +1466,27 @@ func (in *Metadata) DeepCopy() *Metadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsExport) DeepCopyInto(out *MetricsExport) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsExport.
+func (in *MetricsExport) DeepCopy() *MetricsExport {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NATSAuth) DeepCopyInto(out *NATSAuth) {
 	*out = *in
@@ -925,6 +1585,11 @@ func (in *NativeRedis) DeepCopyInto(out *NativeRedis) {
 		*out = new(RedisSettings)
 		**out = **in
 	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurity)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -939,42 +1604,99 @@ func (in *NativeRedis) DeepCopy() *NativeRedis {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistenceStrategy) DeepCopyInto(out *PersistenceStrategy) {
+func (in *NotificationTarget) DeepCopyInto(out *NotificationTarget) {
 	*out = *in
-	if in.StorageClassName != nil {
-		in, out := &in.StorageClassName, &out.StorageClassName
-		*out = new(string)
-		**out = **in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
 	}
-	if in.AccessMode != nil {
-		in, out := &in.AccessMode, &out.AccessMode
-		*out = new(v1.PersistentVolumeAccessMode)
-		**out = **in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookNotification)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.VolumeSize != nil {
-		in, out := &in.VolumeSize, &out.VolumeSize
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackNotification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Opsgenie != nil {
+		in, out := &in.Opsgenie, &out.Opsgenie
+		*out = new(OpsgenieNotification)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceStrategy.
-func (in *PersistenceStrategy) DeepCopy() *PersistenceStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTarget.
+func (in *NotificationTarget) DeepCopy() *NotificationTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistenceStrategy)
+	out := new(NotificationTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+func (in *OpsgenieNotification) DeepCopyInto(out *OpsgenieNotification) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsgenieNotification.
+func (in *OpsgenieNotification) DeepCopy() *OpsgenieNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsgenieNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceStrategy) DeepCopyInto(out *PersistenceStrategy) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessMode != nil {
+		in, out := &in.AccessMode, &out.AccessMode
+		*out = new(v1.PersistentVolumeAccessMode)
+		**out = **in
+	}
+	if in.VolumeSize != nil {
+		in, out := &in.VolumeSize, &out.VolumeSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceStrategy.
+func (in *PersistenceStrategy) DeepCopy() *PersistenceStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -997,6 +1719,27 @@ func (in *Pipeline) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineAudit) DeepCopyInto(out *PipelineAudit) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineAudit.
+func (in *PipelineAudit) DeepCopy() *PipelineAudit {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineAudit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineLimits) DeepCopyInto(out *PipelineLimits) {
 	*out = *in
@@ -1020,6 +1763,16 @@ func (in *PipelineLimits) DeepCopyInto(out *PipelineLimits) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.MaxKeySize != nil {
+		in, out := &in.MaxKeySize, &out.MaxKeySize
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxHeaderSize != nil {
+		in, out := &in.MaxHeaderSize, &out.MaxHeaderSize
+		*out = new(uint32)
+		**out = **in
+	}
 	return
 }
 
@@ -1066,6 +1819,91 @@ func (in *PipelineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineMetrics) DeepCopyInto(out *PipelineMetrics) {
+	*out = *in
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(MetricsExport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HistoryRetention != nil {
+		in, out := &in.HistoryRetention, &out.HistoryRetention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineMetrics.
+func (in *PipelineMetrics) DeepCopy() *PipelineMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineNotifications) DeepCopyInto(out *PipelineNotifications) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]NotificationTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SLA != nil {
+		in, out := &in.SLA, &out.SLA
+		*out = new(PipelineSLA)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineNotifications.
+func (in *PipelineNotifications) DeepCopy() *PipelineNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineSLA) DeepCopyInto(out *PipelineSLA) {
+	*out = *in
+	if in.MaxPendingMessages != nil {
+		in, out := &in.MaxPendingMessages, &out.MaxPendingMessages
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxWatermarkDelay != nil {
+		in, out := &in.MaxWatermarkDelay, &out.MaxWatermarkDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSLA.
+func (in *PipelineSLA) DeepCopy() *PipelineSLA {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineSLA)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 	*out = *in
@@ -1090,6 +1928,48 @@ func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.Watermark = in.Watermark
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReplayPolicy != nil {
+		in, out := &in.ReplayPolicy, &out.ReplayPolicy
+		*out = new(ReplayPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(PipelineMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(PipelineAudit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(PipelineTracing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = new(Templates)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(PipelineNotifications)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1108,6 +1988,16 @@ func (in *PipelineStatus) DeepCopyInto(out *PipelineStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
 	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PlannedChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vertices != nil {
+		in, out := &in.Vertices, &out.Vertices
+		*out = new(VerticesSummary)
+		**out = **in
+	}
 	return
 }
 
@@ -1121,6 +2011,132 @@ func (in *PipelineStatus) DeepCopy() *PipelineStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTracing) DeepCopyInto(out *PipelineTracing) {
+	*out = *in
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTracing.
+func (in *PipelineTracing) DeepCopy() *PipelineTracing {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedChanges) DeepCopyInto(out *PlannedChanges) {
+	*out = *in
+	if in.BuffersToCreate != nil {
+		in, out := &in.BuffersToCreate, &out.BuffersToCreate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BuffersToDelete != nil {
+		in, out := &in.BuffersToDelete, &out.BuffersToDelete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BuffersToMigrate != nil {
+		in, out := &in.BuffersToMigrate, &out.BuffersToMigrate
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VerticesToCreate != nil {
+		in, out := &in.VerticesToCreate, &out.VerticesToCreate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerticesToUpdate != nil {
+		in, out := &in.VerticesToUpdate, &out.VerticesToUpdate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerticesToDelete != nil {
+		in, out := &in.VerticesToDelete, &out.VerticesToDelete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeploymentsToCreate != nil {
+		in, out := &in.DeploymentsToCreate, &out.DeploymentsToCreate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeploymentsToUpdate != nil {
+		in, out := &in.DeploymentsToUpdate, &out.DeploymentsToUpdate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedChanges.
+func (in *PlannedChanges) DeepCopy() *PlannedChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginFunction) DeepCopyInto(out *PluginFunction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginFunction.
+func (in *PluginFunction) DeepCopy() *PluginFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
+	*out = *in
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReadOnlyRootFilesystem != nil {
+		in, out := &in.ReadOnlyRootFilesystem, &out.ReadOnlyRootFilesystem
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurity.
+func (in *PodSecurity) DeepCopy() *PodSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RedisBuferService) DeepCopyInto(out *RedisBuferService) {
 	*out = *in
@@ -1160,6 +2176,16 @@ func (in *RedisConfig) DeepCopyInto(out *RedisConfig) {
 		*out = new(v1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(ManagedRedis)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1189,6 +2215,132 @@ func (in *RedisSettings) DeepCopy() *RedisSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reduce) DeepCopyInto(out *Reduce) {
+	*out = *in
+	in.Window.DeepCopyInto(&out.Window)
+	if in.Builtin != nil {
+		in, out := &in.Builtin, &out.Builtin
+		*out = new(ReduceFunction)
+		**out = **in
+	}
+	if in.AllowedLateness != nil {
+		in, out := &in.AllowedLateness, &out.AllowedLateness
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reduce.
+func (in *Reduce) DeepCopy() *Reduce {
+	if in == nil {
+		return nil
+	}
+	out := new(Reduce)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReduceFunction) DeepCopyInto(out *ReduceFunction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReduceFunction.
+func (in *ReduceFunction) DeepCopy() *ReduceFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(ReduceFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplayPolicy) DeepCopyInto(out *ReplayPolicy) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplayPolicy.
+func (in *ReplayPolicy) DeepCopy() *ReplayPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplayPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplySink) DeepCopyInto(out *ReplySink) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplySink.
+func (in *ReplySink) DeepCopy() *ReplySink {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplySink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestReply) DeepCopyInto(out *RequestReply) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestReply.
+func (in *RequestReply) DeepCopy() *RequestReply {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestReply)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SASL) DeepCopyInto(out *SASL) {
+	*out = *in
+	if in.UserSecret != nil {
+		in, out := &in.UserSecret, &out.UserSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SASL.
+func (in *SASL) DeepCopy() *SASL {
+	if in == nil {
+		return nil
+	}
+	out := new(SASL)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scale) DeepCopyInto(out *Scale) {
 	*out = *in
@@ -1202,6 +2354,21 @@ func (in *Scale) DeepCopyInto(out *Scale) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TargetBufferUsage != nil {
+		in, out := &in.TargetBufferUsage, &out.TargetBufferUsage
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1210,7 +2377,59 @@ func (in *Scale) DeepCopy() *Scale {
 	if in == nil {
 		return nil
 	}
-	out := new(Scale)
+	out := new(Scale)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScratchVolume) DeepCopyInto(out *ScratchVolume) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScratchVolume.
+func (in *ScratchVolume) DeepCopy() *ScratchVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(ScratchVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SideInput) DeepCopyInto(out *SideInput) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSideInput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SideInput.
+func (in *SideInput) DeepCopy() *SideInput {
+	if in == nil {
+		return nil
+	}
+	out := new(SideInput)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1221,7 +2440,7 @@ func (in *Sink) DeepCopyInto(out *Sink) {
 	if in.Log != nil {
 		in, out := &in.Log, &out.Log
 		*out = new(Log)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Kafka != nil {
 		in, out := &in.Kafka, &out.Kafka
@@ -1233,6 +2452,11 @@ func (in *Sink) DeepCopyInto(out *Sink) {
 		*out = new(UDSink)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Reply != nil {
+		in, out := &in.Reply, &out.Reply
+		*out = new(ReplySink)
+		**out = **in
+	}
 	return
 }
 
@@ -1246,6 +2470,53 @@ func (in *Sink) DeepCopy() *Sink {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackNotification) DeepCopyInto(out *SlackNotification) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotification.
+func (in *SlackNotification) DeepCopy() *SlackNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlowStart) DeepCopyInto(out *SlowStart) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InitialReadBatchSize != nil {
+		in, out := &in.InitialReadBatchSize, &out.InitialReadBatchSize
+		*out = new(uint64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlowStart.
+func (in *SlowStart) DeepCopy() *SlowStart {
+	if in == nil {
+		return nil
+	}
+	out := new(SlowStart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -1264,6 +2535,16 @@ func (in *Source) DeepCopyInto(out *Source) {
 		*out = new(HTTPSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encoding != nil {
+		in, out := &in.Encoding, &out.Encoding
+		*out = new(SourceEncoding)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(SourceRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1277,6 +2558,43 @@ func (in *Source) DeepCopy() *Source {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceEncoding) DeepCopyInto(out *SourceEncoding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceEncoding.
+func (in *SourceEncoding) DeepCopy() *SourceEncoding {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceEncoding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceRateLimit) DeepCopyInto(out *SourceRateLimit) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(uint32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceRateLimit.
+func (in *SourceRateLimit) DeepCopy() *SourceRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -1331,6 +2649,37 @@ func (in *TLS) DeepCopy() *TLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Templates) DeepCopyInto(out *Templates) {
+	*out = *in
+	if in.Vertex != nil {
+		in, out := &in.Vertex, &out.Vertex
+		*out = new(AbstractPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Daemon != nil {
+		in, out := &in.Daemon, &out.Daemon
+		*out = new(AbstractPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(AbstractPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Templates.
+func (in *Templates) DeepCopy() *Templates {
+	if in == nil {
+		return nil
+	}
+	out := new(Templates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToVertex) DeepCopyInto(out *ToVertex) {
 	*out = *in
@@ -1339,6 +2688,16 @@ func (in *ToVertex) DeepCopyInto(out *ToVertex) {
 		*out = new(ForwardConditions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(EdgeLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trace != nil {
+		in, out := &in.Trace, &out.Trace
+		*out = new(EdgeTrace)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1365,6 +2724,21 @@ func (in *UDF) DeepCopyInto(out *UDF) {
 		*out = new(Function)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WarmUp != nil {
+		in, out := &in.WarmUp, &out.WarmUp
+		*out = new(UDFWarmUp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginFunction)
+		**out = **in
+	}
+	if in.WASM != nil {
+		in, out := &in.WASM, &out.WASM
+		*out = new(WASMFunction)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1378,6 +2752,32 @@ func (in *UDF) DeepCopy() *UDF {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDFWarmUp) DeepCopyInto(out *UDFWarmUp) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDFWarmUp.
+func (in *UDFWarmUp) DeepCopy() *UDFWarmUp {
+	if in == nil {
+		return nil
+	}
+	out := new(UDFWarmUp)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDSink) DeepCopyInto(out *UDSink) {
 	*out = *in
@@ -1395,6 +2795,27 @@ func (in *UDSink) DeepCopy() *UDSink {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateStrategy) DeepCopyInto(out *UpdateStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStrategy.
+func (in *UpdateStrategy) DeepCopy() *UpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Vertex) DeepCopyInto(out *Vertex) {
 	*out = *in
@@ -1423,6 +2844,23 @@ func (in *Vertex) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VertexError) DeepCopyInto(out *VertexError) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VertexError.
+func (in *VertexError) DeepCopy() *VertexError {
+	if in == nil {
+		return nil
+	}
+	out := new(VertexError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VertexLimits) DeepCopyInto(out *VertexLimits) {
 	*out = *in
@@ -1446,6 +2884,16 @@ func (in *VertexLimits) DeepCopyInto(out *VertexLimits) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.MaxKeySize != nil {
+		in, out := &in.MaxKeySize, &out.MaxKeySize
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxHeaderSize != nil {
+		in, out := &in.MaxHeaderSize, &out.MaxHeaderSize
+		*out = new(uint32)
+		**out = **in
+	}
 	return
 }
 
@@ -1513,6 +2961,56 @@ func (in *VertexSpec) DeepCopyInto(out *VertexSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReadWeights != nil {
+		in, out := &in.ReadWeights, &out.ReadWeights
+		*out = make(map[string]uint32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeadLetterQueues != nil {
+		in, out := &in.DeadLetterQueues, &out.DeadLetterQueues
+		*out = make(map[string]DeadLetterQueue, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(PipelineAudit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(PipelineTracing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxMessageAges != nil {
+		in, out := &in.MaxMessageAges, &out.MaxMessageAges
+		*out = make(map[string]metav1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExactlyOnce != nil {
+		in, out := &in.ExactlyOnce, &out.ExactlyOnce
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1530,6 +3028,11 @@ func (in *VertexSpec) DeepCopy() *VertexSpec {
 func (in *VertexStatus) DeepCopyInto(out *VertexStatus) {
 	*out = *in
 	in.LastScaledAt.DeepCopyInto(&out.LastScaledAt)
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(VertexError)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1543,6 +3046,79 @@ func (in *VertexStatus) DeepCopy() *VertexStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VertexStorage) DeepCopyInto(out *VertexStorage) {
+	*out = *in
+	if in.Scratch != nil {
+		in, out := &in.Scratch, &out.Scratch
+		*out = new(ScratchVolume)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Numa != nil {
+		in, out := &in.Numa, &out.Numa
+		*out = new(EphemeralStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UDF != nil {
+		in, out := &in.UDF, &out.UDF
+		*out = new(EphemeralStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VertexStorage.
+func (in *VertexStorage) DeepCopy() *VertexStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(VertexStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticesSummary) DeepCopyInto(out *VerticesSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticesSummary.
+func (in *VerticesSummary) DeepCopy() *VerticesSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticesSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WASMFunction) DeepCopyInto(out *WASMFunction) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeMount != nil {
+		in, out := &in.VolumeMount, &out.VolumeMount
+		*out = new(v1.VolumeMount)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WASMFunction.
+func (in *WASMFunction) DeepCopy() *WASMFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(WASMFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Watermark) DeepCopyInto(out *Watermark) {
 	*out = *in
@@ -1558,3 +3134,45 @@ func (in *Watermark) DeepCopy() *Watermark {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotification) DeepCopyInto(out *WebhookNotification) {
+	*out = *in
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotification.
+func (in *WebhookNotification) DeepCopy() *WebhookNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Window) DeepCopyInto(out *Window) {
+	*out = *in
+	if in.Fixed != nil {
+		in, out := &in.Fixed, &out.Fixed
+		*out = new(FixedWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Window.
+func (in *Window) DeepCopy() *Window {
+	if in == nil {
+		return nil
+	}
+	out := new(Window)
+	in.DeepCopyInto(out)
+	return out
+}
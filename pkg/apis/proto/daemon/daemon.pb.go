@@ -13,7 +13,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	_ "google.golang.org/protobuf/types/known/emptypb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -40,11 +40,14 @@ type BufferInfo struct {
 	PendingCount    *int64  `protobuf:"varint,5,req,name=pendingCount" json:"pendingCount,omitempty"`
 	AckPendingCount *int64  `protobuf:"varint,6,req,name=ackPendingCount" json:"ackPendingCount,omitempty"`
 	// Total messages existing in the buffer, including pending, ackPending and acked.
-	TotalMessages        *int64   `protobuf:"varint,7,req,name=totalMessages" json:"totalMessages,omitempty"`
-	BufferLength         *int64   `protobuf:"varint,8,req,name=bufferLength" json:"bufferLength,omitempty"`
-	BufferUsageLimit     *float64 `protobuf:"fixed64,9,req,name=bufferUsageLimit" json:"bufferUsageLimit,omitempty"`
-	BufferUsage          *float64 `protobuf:"fixed64,10,req,name=bufferUsage" json:"bufferUsage,omitempty"`
-	IsFull               *bool    `protobuf:"varint,11,req,name=isFull" json:"isFull,omitempty"`
+	TotalMessages    *int64   `protobuf:"varint,7,req,name=totalMessages" json:"totalMessages,omitempty"`
+	BufferLength     *int64   `protobuf:"varint,8,req,name=bufferLength" json:"bufferLength,omitempty"`
+	BufferUsageLimit *float64 `protobuf:"fixed64,9,req,name=bufferUsageLimit" json:"bufferUsageLimit,omitempty"`
+	BufferUsage      *float64 `protobuf:"fixed64,10,req,name=bufferUsage" json:"bufferUsage,omitempty"`
+	IsFull           *bool    `protobuf:"varint,11,req,name=isFull" json:"isFull,omitempty"`
+	// Number of the messages acknowledged per second by the "to" vertex, calculated from the ack counters of its pods
+	// sampled every 10 seconds. Unknown until the pods are sampled twice, and for the buffers read by the reduce vertices.
+	AckRate              *float64 `protobuf:"fixed64,12,opt,name=ackRate" json:"ackRate,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -160,6 +163,13 @@ func (m *BufferInfo) GetIsFull() bool {
 	return false
 }
 
+func (m *BufferInfo) GetAckRate() float64 {
+	if m != nil && m.AckRate != nil {
+		return *m.AckRate
+	}
+	return 0
+}
+
 type ListBuffersRequest struct {
 	Pipeline             *string  `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -356,589 +366,6761 @@ func (m *GetBufferResponse) GetBuffer() *BufferInfo {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*BufferInfo)(nil), "daemon.BufferInfo")
-	proto.RegisterType((*ListBuffersRequest)(nil), "daemon.ListBuffersRequest")
-	proto.RegisterType((*ListBuffersResponse)(nil), "daemon.ListBuffersResponse")
-	proto.RegisterType((*GetBufferRequest)(nil), "daemon.GetBufferRequest")
-	proto.RegisterType((*GetBufferResponse)(nil), "daemon.GetBufferResponse")
+// ServerInfo describes the daemon server, it is used by the clients to negotiate the API features,
+// so that a newer client (e.g. an upgraded controller) degrades gracefully when talking to an older daemon server.
+type ServerInfo struct {
+	// Numaflow version of the daemon server.
+	Version *string `protobuf:"bytes,1,req,name=version" json:"version,omitempty"`
+	// API version of the daemon server, it increases when new endpoints or fields are added.
+	ApiVersion *int32 `protobuf:"varint,2,req,name=apiVersion" json:"apiVersion,omitempty"`
+	// Features supported by the daemon server.
+	Features             []string `protobuf:"bytes,3,rep,name=features" json:"features,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("pkg/apis/proto/daemon/daemon.proto", fileDescriptor_93e327fd0d673221)
+func (m *ServerInfo) Reset()         { *m = ServerInfo{} }
+func (m *ServerInfo) String() string { return proto.CompactTextString(m) }
+func (*ServerInfo) ProtoMessage()    {}
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{5}
 }
-
-var fileDescriptor_93e327fd0d673221 = []byte{
-	// 511 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x6f, 0x6b, 0xd3, 0x40,
-	0x18, 0x27, 0xa9, 0x76, 0xed, 0x53, 0x87, 0xf3, 0x04, 0x39, 0x33, 0x29, 0x21, 0x0c, 0x09, 0x63,
-	0xf6, 0xb4, 0xe0, 0x6b, 0x65, 0x93, 0x89, 0x50, 0x45, 0x22, 0xfa, 0xc2, 0x77, 0x69, 0x77, 0xc9,
-	0xce, 0x25, 0x77, 0x31, 0x77, 0xd9, 0x94, 0xb1, 0x37, 0xfb, 0x0a, 0x7e, 0x29, 0x5f, 0x0a, 0x7e,
-	0x01, 0x29, 0x7e, 0x0e, 0x91, 0xdc, 0x5d, 0xba, 0x74, 0x2b, 0x63, 0xaf, 0x7a, 0xcf, 0xef, 0xcf,
-	0xf3, 0x7b, 0xee, 0x9e, 0xb6, 0x10, 0x14, 0x47, 0x29, 0x89, 0x0b, 0x26, 0x49, 0x51, 0x0a, 0x25,
-	0xc8, 0x41, 0x4c, 0x73, 0xc1, 0xed, 0xc7, 0x48, 0x63, 0xa8, 0x6b, 0x2a, 0xef, 0x51, 0x2a, 0x44,
-	0x9a, 0xd1, 0x5a, 0x4e, 0x62, 0xce, 0x85, 0x8a, 0x15, 0x13, 0x5c, 0x1a, 0x95, 0xb7, 0x69, 0x59,
-	0x5d, 0x4d, 0xab, 0x84, 0xd0, 0xbc, 0x50, 0xdf, 0x0d, 0x19, 0x9c, 0x77, 0x00, 0x76, 0xab, 0x24,
-	0xa1, 0xe5, 0x1b, 0x9e, 0x08, 0xe4, 0x41, 0xaf, 0x60, 0x05, 0xcd, 0x18, 0xa7, 0xd8, 0xf1, 0xdd,
-	0xb0, 0x1f, 0x2d, 0x6a, 0x34, 0x04, 0x48, 0x4a, 0x91, 0x7f, 0xa2, 0xa5, 0xa2, 0xdf, 0xb0, 0xab,
-	0xd9, 0x16, 0x52, 0x7b, 0x95, 0xb0, 0x6c, 0xc7, 0x78, 0x9b, 0xba, 0xf6, 0x4e, 0x75, 0xca, 0xbb,
-	0x38, 0xa7, 0xf8, 0x96, 0xf1, 0x5e, 0x20, 0x28, 0x80, 0x3b, 0x05, 0xe5, 0x07, 0x8c, 0xa7, 0x7b,
-	0xa2, 0xe2, 0x0a, 0xdf, 0xf6, 0xdd, 0xb0, 0x13, 0x2d, 0x61, 0x28, 0x84, 0xbb, 0xf1, 0xec, 0xe8,
-	0x7d, 0x5b, 0xd6, 0xd5, 0xb2, 0xcb, 0x30, 0xda, 0x82, 0x75, 0x25, 0x54, 0x9c, 0xbd, 0xa5, 0x52,
-	0xc6, 0x29, 0x95, 0x78, 0x4d, 0xeb, 0x96, 0xc1, 0x3a, 0xd3, 0x4c, 0x30, 0xa1, 0x3c, 0x55, 0x87,
-	0xb8, 0x67, 0x32, 0xdb, 0x18, 0xda, 0x86, 0x0d, 0x53, 0x7f, 0xac, 0x3d, 0x13, 0x96, 0x33, 0x85,
-	0xfb, 0xbe, 0x1b, 0x3a, 0xd1, 0x15, 0x1c, 0xf9, 0x30, 0x68, 0x61, 0x18, 0xb4, 0xac, 0x0d, 0xa1,
-	0x07, 0xd0, 0x65, 0x72, 0xbf, 0xca, 0x32, 0x3c, 0xf0, 0xdd, 0xb0, 0x17, 0xd9, 0x2a, 0x78, 0x0a,
-	0x68, 0xc2, 0xa4, 0x32, 0x7b, 0x90, 0x11, 0xfd, 0x5a, 0x51, 0xa9, 0xae, 0xdb, 0x45, 0xb0, 0x07,
-	0xf7, 0x97, 0x1c, 0xb2, 0x10, 0x5c, 0x52, 0xb4, 0x03, 0x6b, 0x26, 0x4f, 0x62, 0xc7, 0xef, 0x84,
-	0x83, 0x31, 0x1a, 0xd9, 0x2f, 0xcc, 0xc5, 0x8e, 0xa3, 0x46, 0x12, 0xec, 0xc3, 0xc6, 0x6b, 0x6a,
-	0x7b, 0xdc, 0x20, 0xb4, 0x1e, 0xdf, 0x58, 0xed, 0xf2, 0x6d, 0x15, 0xbc, 0x80, 0x7b, 0xad, 0x3e,
-	0x76, 0x94, 0xed, 0x85, 0xb8, 0x6e, 0xb3, 0x7a, 0x12, 0xab, 0x18, 0xff, 0x73, 0x60, 0xfd, 0x95,
-	0x66, 0x3f, 0xd0, 0xf2, 0x98, 0xcd, 0x28, 0x52, 0x30, 0x68, 0xdd, 0x0f, 0x79, 0x8d, 0xf9, 0xea,
-	0x33, 0x79, 0x9b, 0x2b, 0x39, 0x33, 0x45, 0xb0, 0x73, 0xfe, 0xfb, 0xef, 0x0f, 0xf7, 0x31, 0xda,
-	0xd2, 0xbf, 0x8d, 0xe3, 0x67, 0xa4, 0xb9, 0x8c, 0x24, 0xa7, 0xcd, 0xf1, 0x8c, 0xd8, 0x07, 0x41,
-	0x27, 0xd0, 0x5f, 0x5c, 0x04, 0xe1, 0xa6, 0xef, 0xe5, 0x37, 0xf2, 0x1e, 0xae, 0x60, 0x6c, 0xde,
-	0x73, 0x9d, 0x47, 0xd0, 0x93, 0x9b, 0xe4, 0x91, 0x53, 0x73, 0x38, 0xdb, 0x7d, 0xf9, 0x73, 0x3e,
-	0x74, 0x7e, 0xcd, 0x87, 0xce, 0x9f, 0xf9, 0xd0, 0xf9, 0x3c, 0x4e, 0x99, 0x3a, 0xac, 0xa6, 0xa3,
-	0x99, 0xc8, 0x09, 0xaf, 0xf2, 0xb8, 0x28, 0xc5, 0x17, 0x7d, 0x48, 0x32, 0x71, 0x42, 0x56, 0xfe,
-	0x2f, 0xfc, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x7c, 0x49, 0x28, 0x49, 0x2f, 0x04, 0x00, 0x00,
+func (m *ServerInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServerInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ServerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerInfo.Merge(m, src)
+}
+func (m *ServerInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerInfo.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_ServerInfo proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ServerInfo) GetVersion() string {
+	if m != nil && m.Version != nil {
+		return *m.Version
+	}
+	return ""
+}
 
-// DaemonServiceClient is the client API for DaemonService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DaemonServiceClient interface {
-	ListBuffers(ctx context.Context, in *ListBuffersRequest, opts ...grpc.CallOption) (*ListBuffersResponse, error)
-	GetBuffer(ctx context.Context, in *GetBufferRequest, opts ...grpc.CallOption) (*GetBufferResponse, error)
+func (m *ServerInfo) GetApiVersion() int32 {
+	if m != nil && m.ApiVersion != nil {
+		return *m.ApiVersion
+	}
+	return 0
 }
 
-type daemonServiceClient struct {
-	cc *grpc.ClientConn
+func (m *ServerInfo) GetFeatures() []string {
+	if m != nil {
+		return m.Features
+	}
+	return nil
 }
 
-func NewDaemonServiceClient(cc *grpc.ClientConn) DaemonServiceClient {
-	return &daemonServiceClient{cc}
+// TracedMessage is a message sampled from the messages written to the buffer of a traced edge.
+type TracedMessage struct {
+	// Pod writing the message.
+	Pod *string `protobuf:"bytes,1,req,name=pod" json:"pod,omitempty"`
+	// Time the message is written, in Unix nanoseconds.
+	WriteTime *int64  `protobuf:"varint,2,req,name=writeTime" json:"writeTime,omitempty"`
+	Id        *string `protobuf:"bytes,3,req,name=id" json:"id,omitempty"`
+	Key       *string `protobuf:"bytes,4,opt,name=key" json:"key,omitempty"`
+	// Event time of the message, in Unix nanoseconds.
+	EventTime       *int64            `protobuf:"varint,5,req,name=eventTime" json:"eventTime,omitempty"`
+	Metadata        map[string]string `protobuf:"bytes,6,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ContentEncoding *string           `protobuf:"bytes,7,opt,name=contentEncoding" json:"contentEncoding,omitempty"`
+	PayloadSize     *int64            `protobuf:"varint,8,req,name=payloadSize" json:"payloadSize,omitempty"`
+	// Payload of the message, only if the payloads are captured, truncated to the payload size limit of the edge.
+	Payload              []byte   `protobuf:"bytes,9,opt,name=payload" json:"payload,omitempty"`
+	Truncated            *bool    `protobuf:"varint,10,opt,name=truncated" json:"truncated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *daemonServiceClient) ListBuffers(ctx context.Context, in *ListBuffersRequest, opts ...grpc.CallOption) (*ListBuffersResponse, error) {
-	out := new(ListBuffersResponse)
-	err := c.cc.Invoke(ctx, "/daemon.DaemonService/ListBuffers", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TracedMessage) Reset()         { *m = TracedMessage{} }
+func (m *TracedMessage) String() string { return proto.CompactTextString(m) }
+func (*TracedMessage) ProtoMessage()    {}
+func (*TracedMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{6}
+}
+func (m *TracedMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TracedMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TracedMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *TracedMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TracedMessage.Merge(m, src)
+}
+func (m *TracedMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *TracedMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_TracedMessage.DiscardUnknown(m)
 }
 
-func (c *daemonServiceClient) GetBuffer(ctx context.Context, in *GetBufferRequest, opts ...grpc.CallOption) (*GetBufferResponse, error) {
-	out := new(GetBufferResponse)
-	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetBuffer", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_TracedMessage proto.InternalMessageInfo
+
+func (m *TracedMessage) GetPod() string {
+	if m != nil && m.Pod != nil {
+		return *m.Pod
 	}
-	return out, nil
+	return ""
 }
 
-// DaemonServiceServer is the server API for DaemonService service.
-type DaemonServiceServer interface {
-	ListBuffers(context.Context, *ListBuffersRequest) (*ListBuffersResponse, error)
-	GetBuffer(context.Context, *GetBufferRequest) (*GetBufferResponse, error)
+func (m *TracedMessage) GetWriteTime() int64 {
+	if m != nil && m.WriteTime != nil {
+		return *m.WriteTime
+	}
+	return 0
 }
 
-// UnimplementedDaemonServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedDaemonServiceServer struct {
+func (m *TracedMessage) GetId() string {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return ""
 }
 
-func (*UnimplementedDaemonServiceServer) ListBuffers(ctx context.Context, req *ListBuffersRequest) (*ListBuffersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListBuffers not implemented")
-}
-func (*UnimplementedDaemonServiceServer) GetBuffer(ctx context.Context, req *GetBufferRequest) (*GetBufferResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBuffer not implemented")
+func (m *TracedMessage) GetKey() string {
+	if m != nil && m.Key != nil {
+		return *m.Key
+	}
+	return ""
 }
 
-func RegisterDaemonServiceServer(s *grpc.Server, srv DaemonServiceServer) {
-	s.RegisterService(&_DaemonService_serviceDesc, srv)
+func (m *TracedMessage) GetEventTime() int64 {
+	if m != nil && m.EventTime != nil {
+		return *m.EventTime
+	}
+	return 0
 }
 
-func _DaemonService_ListBuffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBuffersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DaemonServiceServer).ListBuffers(ctx, in)
+func (m *TracedMessage) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/daemon.DaemonService/ListBuffers",
+	return nil
+}
+
+func (m *TracedMessage) GetContentEncoding() string {
+	if m != nil && m.ContentEncoding != nil {
+		return *m.ContentEncoding
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServiceServer).ListBuffers(ctx, req.(*ListBuffersRequest))
+	return ""
+}
+
+func (m *TracedMessage) GetPayloadSize() int64 {
+	if m != nil && m.PayloadSize != nil {
+		return *m.PayloadSize
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _DaemonService_GetBuffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBufferRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *TracedMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	if interceptor == nil {
-		return srv.(DaemonServiceServer).GetBuffer(ctx, in)
+	return nil
+}
+
+func (m *TracedMessage) GetTruncated() bool {
+	if m != nil && m.Truncated != nil {
+		return *m.Truncated
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/daemon.DaemonService/GetBuffer",
+	return false
+}
+
+type ListEdgeTracesRequest struct {
+	Pipeline             *string  `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
+	FromVertex           *string  `protobuf:"bytes,2,req,name=fromVertex" json:"fromVertex,omitempty"`
+	ToVertex             *string  `protobuf:"bytes,3,req,name=toVertex" json:"toVertex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEdgeTracesRequest) Reset()         { *m = ListEdgeTracesRequest{} }
+func (m *ListEdgeTracesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEdgeTracesRequest) ProtoMessage()    {}
+func (*ListEdgeTracesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{7}
+}
+func (m *ListEdgeTracesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListEdgeTracesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListEdgeTracesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DaemonServiceServer).GetBuffer(ctx, req.(*GetBufferRequest))
+}
+func (m *ListEdgeTracesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEdgeTracesRequest.Merge(m, src)
+}
+func (m *ListEdgeTracesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListEdgeTracesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEdgeTracesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEdgeTracesRequest proto.InternalMessageInfo
+
+func (m *ListEdgeTracesRequest) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _DaemonService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "daemon.DaemonService",
-	HandlerType: (*DaemonServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListBuffers",
-			Handler:    _DaemonService_ListBuffers_Handler,
-		},
-		{
-			MethodName: "GetBuffer",
-			Handler:    _DaemonService_GetBuffer_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pkg/apis/proto/daemon/daemon.proto",
+func (m *ListEdgeTracesRequest) GetFromVertex() string {
+	if m != nil && m.FromVertex != nil {
+		return *m.FromVertex
+	}
+	return ""
 }
 
-func (m *BufferInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ListEdgeTracesRequest) GetToVertex() string {
+	if m != nil && m.ToVertex != nil {
+		return *m.ToVertex
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *BufferInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ListEdgeTracesResponse struct {
+	// Messages recorded by all the replicas of the "from" vertex, the latest first.
+	Messages []*TracedMessage `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+	// Addresses of the pods failed to respond, their messages are missing.
+	FailedPods           []string `protobuf:"bytes,2,rep,name=failedPods" json:"failedPods,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BufferInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.IsFull == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("isFull")
+func (m *ListEdgeTracesResponse) Reset()         { *m = ListEdgeTracesResponse{} }
+func (m *ListEdgeTracesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEdgeTracesResponse) ProtoMessage()    {}
+func (*ListEdgeTracesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{8}
+}
+func (m *ListEdgeTracesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListEdgeTracesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListEdgeTracesResponse.Marshal(b, m, deterministic)
 	} else {
-		i--
-		if *m.IsFull {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x58
+		return b[:n], nil
 	}
-	if m.BufferUsage == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsage")
-	} else {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.BufferUsage))))
-		i--
-		dAtA[i] = 0x51
+}
+func (m *ListEdgeTracesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEdgeTracesResponse.Merge(m, src)
+}
+func (m *ListEdgeTracesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListEdgeTracesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEdgeTracesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEdgeTracesResponse proto.InternalMessageInfo
+
+func (m *ListEdgeTracesResponse) GetMessages() []*TracedMessage {
+	if m != nil {
+		return m.Messages
 	}
-	if m.BufferUsageLimit == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsageLimit")
-	} else {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.BufferUsageLimit))))
-		i--
-		dAtA[i] = 0x49
+	return nil
+}
+
+func (m *ListEdgeTracesResponse) GetFailedPods() []string {
+	if m != nil {
+		return m.FailedPods
 	}
-	if m.BufferLength == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferLength")
+	return nil
+}
+
+// VertexMetricsSample is the metrics of a vertex at a time. The samples older than an hour are downsampled to one per
+// minute, with the average processing rate, the max pending count and the latest watermark of the minute.
+type VertexMetricsSample struct {
+	// Time of the sample, in Unix milliseconds.
+	Time *int64 `protobuf:"varint,1,req,name=time" json:"time,omitempty"`
+	// Number of the messages read per second by all the replicas of the vertex.
+	ProcessingRate *float64 `protobuf:"fixed64,2,req,name=processingRate" json:"processingRate,omitempty"`
+	// Number of the pending messages in the input buffers of the vertex, 0 for the sources.
+	PendingCount *int64 `protobuf:"varint,3,req,name=pendingCount" json:"pendingCount,omitempty"`
+	// Watermark of the vertex in Unix milliseconds, only available for the reduce vertices.
+	Watermark            *int64   `protobuf:"varint,4,opt,name=watermark" json:"watermark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VertexMetricsSample) Reset()         { *m = VertexMetricsSample{} }
+func (m *VertexMetricsSample) String() string { return proto.CompactTextString(m) }
+func (*VertexMetricsSample) ProtoMessage()    {}
+func (*VertexMetricsSample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{9}
+}
+func (m *VertexMetricsSample) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VertexMetricsSample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VertexMetricsSample.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintDaemon(dAtA, i, uint64(*m.BufferLength))
-		i--
-		dAtA[i] = 0x40
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.TotalMessages == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalMessages")
-	} else {
-		i = encodeVarintDaemon(dAtA, i, uint64(*m.TotalMessages))
-		i--
-		dAtA[i] = 0x38
+}
+func (m *VertexMetricsSample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VertexMetricsSample.Merge(m, src)
+}
+func (m *VertexMetricsSample) XXX_Size() int {
+	return m.Size()
+}
+func (m *VertexMetricsSample) XXX_DiscardUnknown() {
+	xxx_messageInfo_VertexMetricsSample.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VertexMetricsSample proto.InternalMessageInfo
+
+func (m *VertexMetricsSample) GetTime() int64 {
+	if m != nil && m.Time != nil {
+		return *m.Time
 	}
-	if m.AckPendingCount == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("ackPendingCount")
-	} else {
-		i = encodeVarintDaemon(dAtA, i, uint64(*m.AckPendingCount))
-		i--
-		dAtA[i] = 0x30
+	return 0
+}
+
+func (m *VertexMetricsSample) GetProcessingRate() float64 {
+	if m != nil && m.ProcessingRate != nil {
+		return *m.ProcessingRate
 	}
-	if m.PendingCount == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingCount")
-	} else {
-		i = encodeVarintDaemon(dAtA, i, uint64(*m.PendingCount))
-		i--
-		dAtA[i] = 0x28
+	return 0
+}
+
+func (m *VertexMetricsSample) GetPendingCount() int64 {
+	if m != nil && m.PendingCount != nil {
+		return *m.PendingCount
 	}
-	if m.BufferName == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
-	} else {
-		i -= len(*m.BufferName)
-		copy(dAtA[i:], *m.BufferName)
-		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.BufferName)))
-		i--
-		dAtA[i] = 0x22
+	return 0
+}
+
+func (m *VertexMetricsSample) GetWatermark() int64 {
+	if m != nil && m.Watermark != nil {
+		return *m.Watermark
 	}
-	if m.ToVertex == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	return 0
+}
+
+type GetVertexMetricsHistoryRequest struct {
+	Pipeline             *string  `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
+	Vertex               *string  `protobuf:"bytes,2,req,name=vertex" json:"vertex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetVertexMetricsHistoryRequest) Reset()         { *m = GetVertexMetricsHistoryRequest{} }
+func (m *GetVertexMetricsHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetVertexMetricsHistoryRequest) ProtoMessage()    {}
+func (*GetVertexMetricsHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{10}
+}
+func (m *GetVertexMetricsHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetVertexMetricsHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetVertexMetricsHistoryRequest.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.ToVertex)
-		copy(dAtA[i:], *m.ToVertex)
-		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.ToVertex)))
-		i--
-		dAtA[i] = 0x1a
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.FromVertex == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
-	} else {
-		i -= len(*m.FromVertex)
-		copy(dAtA[i:], *m.FromVertex)
-		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.FromVertex)))
-		i--
-		dAtA[i] = 0x12
+}
+func (m *GetVertexMetricsHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVertexMetricsHistoryRequest.Merge(m, src)
+}
+func (m *GetVertexMetricsHistoryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetVertexMetricsHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVertexMetricsHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetVertexMetricsHistoryRequest proto.InternalMessageInfo
+
+func (m *GetVertexMetricsHistoryRequest) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
 	}
-	if m.Pipeline == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	return ""
+}
+
+func (m *GetVertexMetricsHistoryRequest) GetVertex() string {
+	if m != nil && m.Vertex != nil {
+		return *m.Vertex
+	}
+	return ""
+}
+
+type GetVertexMetricsHistoryResponse struct {
+	// Samples of the vertex, the oldest first.
+	Samples              []*VertexMetricsSample `protobuf:"bytes,1,rep,name=samples" json:"samples,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetVertexMetricsHistoryResponse) Reset()         { *m = GetVertexMetricsHistoryResponse{} }
+func (m *GetVertexMetricsHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetVertexMetricsHistoryResponse) ProtoMessage()    {}
+func (*GetVertexMetricsHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{11}
+}
+func (m *GetVertexMetricsHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetVertexMetricsHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetVertexMetricsHistoryResponse.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Pipeline)
-		copy(dAtA[i:], *m.Pipeline)
-		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *GetVertexMetricsHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVertexMetricsHistoryResponse.Merge(m, src)
+}
+func (m *GetVertexMetricsHistoryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetVertexMetricsHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVertexMetricsHistoryResponse.DiscardUnknown(m)
 }
 
-func (m *ListBuffersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetVertexMetricsHistoryResponse proto.InternalMessageInfo
+
+func (m *GetVertexMetricsHistoryResponse) GetSamples() []*VertexMetricsSample {
+	if m != nil {
+		return m.Samples
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ListBuffersRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// EdgeWatermark is the watermark of an edge, which is the min of the latest watermarks published to the watermark KV
+// store of its buffer by the active processors of the "from" vertex.
+type EdgeWatermark struct {
+	FromVertex *string `protobuf:"bytes,1,req,name=fromVertex" json:"fromVertex,omitempty"`
+	ToVertex   *string `protobuf:"bytes,2,req,name=toVertex" json:"toVertex,omitempty"`
+	BufferName *string `protobuf:"bytes,3,req,name=bufferName" json:"bufferName,omitempty"`
+	// Watermark in Unix milliseconds, not set if no active processor has published one.
+	Watermark *int64 `protobuf:"varint,4,opt,name=watermark" json:"watermark,omitempty"`
+	// Number of the active processors publishing the watermarks.
+	ActiveProcessors     *int32   `protobuf:"varint,5,req,name=activeProcessors" json:"activeProcessors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListBuffersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Pipeline == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+func (m *EdgeWatermark) Reset()         { *m = EdgeWatermark{} }
+func (m *EdgeWatermark) String() string { return proto.CompactTextString(m) }
+func (*EdgeWatermark) ProtoMessage()    {}
+func (*EdgeWatermark) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{12}
+}
+func (m *EdgeWatermark) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EdgeWatermark) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EdgeWatermark.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Pipeline)
-		copy(dAtA[i:], *m.Pipeline)
-		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *EdgeWatermark) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EdgeWatermark.Merge(m, src)
+}
+func (m *EdgeWatermark) XXX_Size() int {
+	return m.Size()
+}
+func (m *EdgeWatermark) XXX_DiscardUnknown() {
+	xxx_messageInfo_EdgeWatermark.DiscardUnknown(m)
 }
 
-func (m *ListBuffersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_EdgeWatermark proto.InternalMessageInfo
+
+func (m *EdgeWatermark) GetFromVertex() string {
+	if m != nil && m.FromVertex != nil {
+		return *m.FromVertex
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ListBuffersResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *EdgeWatermark) GetToVertex() string {
+	if m != nil && m.ToVertex != nil {
+		return *m.ToVertex
+	}
+	return ""
 }
 
-func (m *ListBuffersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *EdgeWatermark) GetBufferName() string {
+	if m != nil && m.BufferName != nil {
+		return *m.BufferName
 	}
-	if len(m.Buffers) > 0 {
-		for iNdEx := len(m.Buffers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Buffers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintDaemon(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	return ""
+}
+
+func (m *EdgeWatermark) GetWatermark() int64 {
+	if m != nil && m.Watermark != nil {
+		return *m.Watermark
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *GetBufferRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *EdgeWatermark) GetActiveProcessors() int32 {
+	if m != nil && m.ActiveProcessors != nil {
+		return *m.ActiveProcessors
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GetBufferRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// VertexWatermark is the watermark of a vertex, which is the min of the watermarks of its input edges, or of its
+// output edges for a source. The watermark reported by the pods is used for a reduce vertex without one in the store.
+type VertexWatermark struct {
+	Vertex *string `protobuf:"bytes,1,req,name=vertex" json:"vertex,omitempty"`
+	// Watermark in Unix milliseconds, not set if unknown.
+	Watermark            *int64   `protobuf:"varint,2,opt,name=watermark" json:"watermark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetBufferRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *VertexWatermark) Reset()         { *m = VertexWatermark{} }
+func (m *VertexWatermark) String() string { return proto.CompactTextString(m) }
+func (*VertexWatermark) ProtoMessage()    {}
+func (*VertexWatermark) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{13}
+}
+func (m *VertexWatermark) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VertexWatermark) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VertexWatermark.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Buffer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+}
+func (m *VertexWatermark) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VertexWatermark.Merge(m, src)
+}
+func (m *VertexWatermark) XXX_Size() int {
+	return m.Size()
+}
+func (m *VertexWatermark) XXX_DiscardUnknown() {
+	xxx_messageInfo_VertexWatermark.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VertexWatermark proto.InternalMessageInfo
+
+func (m *VertexWatermark) GetVertex() string {
+	if m != nil && m.Vertex != nil {
+		return *m.Vertex
+	}
+	return ""
+}
+
+func (m *VertexWatermark) GetWatermark() int64 {
+	if m != nil && m.Watermark != nil {
+		return *m.Watermark
+	}
+	return 0
+}
+
+type GetPipelineWatermarksRequest struct {
+	Pipeline             *string  `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPipelineWatermarksRequest) Reset()         { *m = GetPipelineWatermarksRequest{} }
+func (m *GetPipelineWatermarksRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPipelineWatermarksRequest) ProtoMessage()    {}
+func (*GetPipelineWatermarksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{14}
+}
+func (m *GetPipelineWatermarksRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetPipelineWatermarksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetPipelineWatermarksRequest.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Buffer)
-		copy(dAtA[i:], *m.Buffer)
-		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Buffer)))
-		i--
-		dAtA[i] = 0x12
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Pipeline == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+}
+func (m *GetPipelineWatermarksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPipelineWatermarksRequest.Merge(m, src)
+}
+func (m *GetPipelineWatermarksRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetPipelineWatermarksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPipelineWatermarksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPipelineWatermarksRequest proto.InternalMessageInfo
+
+func (m *GetPipelineWatermarksRequest) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
+	}
+	return ""
+}
+
+type GetPipelineWatermarksResponse struct {
+	Edges                []*EdgeWatermark   `protobuf:"bytes,1,rep,name=edges" json:"edges,omitempty"`
+	Vertices             []*VertexWatermark `protobuf:"bytes,2,rep,name=vertices" json:"vertices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetPipelineWatermarksResponse) Reset()         { *m = GetPipelineWatermarksResponse{} }
+func (m *GetPipelineWatermarksResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPipelineWatermarksResponse) ProtoMessage()    {}
+func (*GetPipelineWatermarksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{15}
+}
+func (m *GetPipelineWatermarksResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetPipelineWatermarksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetPipelineWatermarksResponse.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Pipeline)
-		copy(dAtA[i:], *m.Pipeline)
-		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *GetPipelineWatermarksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPipelineWatermarksResponse.Merge(m, src)
+}
+func (m *GetPipelineWatermarksResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetPipelineWatermarksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPipelineWatermarksResponse.DiscardUnknown(m)
 }
 
-func (m *GetBufferResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetPipelineWatermarksResponse proto.InternalMessageInfo
+
+func (m *GetPipelineWatermarksResponse) GetEdges() []*EdgeWatermark {
+	if m != nil {
+		return m.Edges
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetBufferResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GetPipelineWatermarksResponse) GetVertices() []*VertexWatermark {
+	if m != nil {
+		return m.Vertices
+	}
+	return nil
 }
 
-func (m *GetBufferResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+// Replay is an operation writing the messages of the buffer of an edge written since a start time to the buffer
+// again, so that they are processed again by the "to" vertex, e.g. to backfill after fixing a UDF. The replayed
+// messages get new IDs, so they are not deduplicated.
+type Replay struct {
+	Id         *string `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
+	Pipeline   *string `protobuf:"bytes,2,req,name=pipeline" json:"pipeline,omitempty"`
+	FromVertex *string `protobuf:"bytes,3,req,name=fromVertex" json:"fromVertex,omitempty"`
+	ToVertex   *string `protobuf:"bytes,4,req,name=toVertex" json:"toVertex,omitempty"`
+	BufferName *string `protobuf:"bytes,5,req,name=bufferName" json:"bufferName,omitempty"`
+	// Time since which the messages written to the buffer are replayed, in Unix milliseconds.
+	StartTime *int64 `protobuf:"varint,6,req,name=startTime" json:"startTime,omitempty"`
+	// One of Running, Paused, Succeeded, Failed and Canceled.
+	Phase   *string `protobuf:"bytes,7,req,name=phase" json:"phase,omitempty"`
+	Message *string `protobuf:"bytes,8,opt,name=message" json:"message,omitempty"`
+	// Number of the messages to replay, counted when the replay is created.
+	Total     *int64 `protobuf:"varint,9,req,name=total" json:"total,omitempty"`
+	Replayed  *int64 `protobuf:"varint,10,req,name=replayed" json:"replayed,omitempty"`
+	Remaining *int64 `protobuf:"varint,11,req,name=remaining" json:"remaining,omitempty"`
+	// Estimated seconds to finish at the rate of the replay so far, not set before a batch is replayed, or once the
+	// replay is paused or finished.
+	EtaSeconds *int64 `protobuf:"varint,12,opt,name=etaSeconds" json:"etaSeconds,omitempty"`
+	// Time the replay is created, in Unix milliseconds.
+	CreateTime *int64 `protobuf:"varint,13,req,name=createTime" json:"createTime,omitempty"`
+	// Time the replay is finished, in Unix milliseconds.
+	FinishTime           *int64   `protobuf:"varint,14,opt,name=finishTime" json:"finishTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Replay) Reset()         { *m = Replay{} }
+func (m *Replay) String() string { return proto.CompactTextString(m) }
+func (*Replay) ProtoMessage()    {}
+func (*Replay) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{16}
+}
+func (m *Replay) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Replay) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Replay.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Buffer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+}
+func (m *Replay) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Replay.Merge(m, src)
+}
+func (m *Replay) XXX_Size() int {
+	return m.Size()
+}
+func (m *Replay) XXX_DiscardUnknown() {
+	xxx_messageInfo_Replay.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Replay proto.InternalMessageInfo
+
+func (m *Replay) GetId() string {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return ""
+}
+
+func (m *Replay) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
+	}
+	return ""
+}
+
+func (m *Replay) GetFromVertex() string {
+	if m != nil && m.FromVertex != nil {
+		return *m.FromVertex
+	}
+	return ""
+}
+
+func (m *Replay) GetToVertex() string {
+	if m != nil && m.ToVertex != nil {
+		return *m.ToVertex
+	}
+	return ""
+}
+
+func (m *Replay) GetBufferName() string {
+	if m != nil && m.BufferName != nil {
+		return *m.BufferName
+	}
+	return ""
+}
+
+func (m *Replay) GetStartTime() int64 {
+	if m != nil && m.StartTime != nil {
+		return *m.StartTime
+	}
+	return 0
+}
+
+func (m *Replay) GetPhase() string {
+	if m != nil && m.Phase != nil {
+		return *m.Phase
+	}
+	return ""
+}
+
+func (m *Replay) GetMessage() string {
+	if m != nil && m.Message != nil {
+		return *m.Message
+	}
+	return ""
+}
+
+func (m *Replay) GetTotal() int64 {
+	if m != nil && m.Total != nil {
+		return *m.Total
+	}
+	return 0
+}
+
+func (m *Replay) GetReplayed() int64 {
+	if m != nil && m.Replayed != nil {
+		return *m.Replayed
+	}
+	return 0
+}
+
+func (m *Replay) GetRemaining() int64 {
+	if m != nil && m.Remaining != nil {
+		return *m.Remaining
+	}
+	return 0
+}
+
+func (m *Replay) GetEtaSeconds() int64 {
+	if m != nil && m.EtaSeconds != nil {
+		return *m.EtaSeconds
+	}
+	return 0
+}
+
+func (m *Replay) GetCreateTime() int64 {
+	if m != nil && m.CreateTime != nil {
+		return *m.CreateTime
+	}
+	return 0
+}
+
+func (m *Replay) GetFinishTime() int64 {
+	if m != nil && m.FinishTime != nil {
+		return *m.FinishTime
+	}
+	return 0
+}
+
+type CreateReplayRequest struct {
+	Pipeline   *string `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
+	FromVertex *string `protobuf:"bytes,2,req,name=fromVertex" json:"fromVertex,omitempty"`
+	ToVertex   *string `protobuf:"bytes,3,req,name=toVertex" json:"toVertex,omitempty"`
+	// Time since which the messages written to the buffer are replayed, in Unix milliseconds.
+	StartTime *int64 `protobuf:"varint,4,req,name=startTime" json:"startTime,omitempty"`
+	// ID of the replay, generated if it's not set. Creating a replay with an existing ID returns the existing one, so
+	// that a retried request does not replay the messages twice.
+	Id                   *string  `protobuf:"bytes,5,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateReplayRequest) Reset()         { *m = CreateReplayRequest{} }
+func (m *CreateReplayRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateReplayRequest) ProtoMessage()    {}
+func (*CreateReplayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{17}
+}
+func (m *CreateReplayRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateReplayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateReplayRequest.Marshal(b, m, deterministic)
 	} else {
-		{
-			size, err := m.Buffer.MarshalToSizedBuffer(dAtA[:i])
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CreateReplayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateReplayRequest.Merge(m, src)
+}
+func (m *CreateReplayRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateReplayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateReplayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateReplayRequest proto.InternalMessageInfo
+
+func (m *CreateReplayRequest) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
+	}
+	return ""
+}
+
+func (m *CreateReplayRequest) GetFromVertex() string {
+	if m != nil && m.FromVertex != nil {
+		return *m.FromVertex
+	}
+	return ""
+}
+
+func (m *CreateReplayRequest) GetToVertex() string {
+	if m != nil && m.ToVertex != nil {
+		return *m.ToVertex
+	}
+	return ""
+}
+
+func (m *CreateReplayRequest) GetStartTime() int64 {
+	if m != nil && m.StartTime != nil {
+		return *m.StartTime
+	}
+	return 0
+}
+
+func (m *CreateReplayRequest) GetId() string {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return ""
+}
+
+type ListReplaysRequest struct {
+	Pipeline             *string  `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListReplaysRequest) Reset()         { *m = ListReplaysRequest{} }
+func (m *ListReplaysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListReplaysRequest) ProtoMessage()    {}
+func (*ListReplaysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{18}
+}
+func (m *ListReplaysRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListReplaysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListReplaysRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListReplaysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReplaysRequest.Merge(m, src)
+}
+func (m *ListReplaysRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListReplaysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReplaysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReplaysRequest proto.InternalMessageInfo
+
+func (m *ListReplaysRequest) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
+	}
+	return ""
+}
+
+type ListReplaysResponse struct {
+	// Replays of the pipeline created since the daemon server started, the oldest first.
+	Replays              []*Replay `protobuf:"bytes,1,rep,name=replays" json:"replays,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListReplaysResponse) Reset()         { *m = ListReplaysResponse{} }
+func (m *ListReplaysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListReplaysResponse) ProtoMessage()    {}
+func (*ListReplaysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{19}
+}
+func (m *ListReplaysResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListReplaysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListReplaysResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListReplaysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReplaysResponse.Merge(m, src)
+}
+func (m *ListReplaysResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListReplaysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReplaysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReplaysResponse proto.InternalMessageInfo
+
+func (m *ListReplaysResponse) GetReplays() []*Replay {
+	if m != nil {
+		return m.Replays
+	}
+	return nil
+}
+
+type ReplayRequest struct {
+	Pipeline             *string  `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
+	Id                   *string  `protobuf:"bytes,2,req,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayRequest) Reset()         { *m = ReplayRequest{} }
+func (m *ReplayRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayRequest) ProtoMessage()    {}
+func (*ReplayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{20}
+}
+func (m *ReplayRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplayRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReplayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayRequest.Merge(m, src)
+}
+func (m *ReplayRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayRequest proto.InternalMessageInfo
+
+func (m *ReplayRequest) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
+	}
+	return ""
+}
+
+func (m *ReplayRequest) GetId() string {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return ""
+}
+
+type ReplayResponse struct {
+	Replay               *Replay  `protobuf:"bytes,1,req,name=replay" json:"replay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayResponse) Reset()         { *m = ReplayResponse{} }
+func (m *ReplayResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplayResponse) ProtoMessage()    {}
+func (*ReplayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{21}
+}
+func (m *ReplayResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReplayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReplayResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReplayResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayResponse.Merge(m, src)
+}
+func (m *ReplayResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReplayResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayResponse proto.InternalMessageInfo
+
+func (m *ReplayResponse) GetReplay() *Replay {
+	if m != nil {
+		return m.Replay
+	}
+	return nil
+}
+
+type WatchReplaysRequest struct {
+	Pipeline             *string  `protobuf:"bytes,1,req,name=pipeline" json:"pipeline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchReplaysRequest) Reset()         { *m = WatchReplaysRequest{} }
+func (m *WatchReplaysRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchReplaysRequest) ProtoMessage()    {}
+func (*WatchReplaysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_93e327fd0d673221, []int{22}
+}
+func (m *WatchReplaysRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchReplaysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchReplaysRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchReplaysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchReplaysRequest.Merge(m, src)
+}
+func (m *WatchReplaysRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchReplaysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchReplaysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchReplaysRequest proto.InternalMessageInfo
+
+func (m *WatchReplaysRequest) GetPipeline() string {
+	if m != nil && m.Pipeline != nil {
+		return *m.Pipeline
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*BufferInfo)(nil), "daemon.BufferInfo")
+	proto.RegisterType((*ListBuffersRequest)(nil), "daemon.ListBuffersRequest")
+	proto.RegisterType((*ListBuffersResponse)(nil), "daemon.ListBuffersResponse")
+	proto.RegisterType((*GetBufferRequest)(nil), "daemon.GetBufferRequest")
+	proto.RegisterType((*GetBufferResponse)(nil), "daemon.GetBufferResponse")
+	proto.RegisterType((*ServerInfo)(nil), "daemon.ServerInfo")
+	proto.RegisterType((*TracedMessage)(nil), "daemon.TracedMessage")
+	proto.RegisterMapType((map[string]string)(nil), "daemon.TracedMessage.MetadataEntry")
+	proto.RegisterType((*ListEdgeTracesRequest)(nil), "daemon.ListEdgeTracesRequest")
+	proto.RegisterType((*ListEdgeTracesResponse)(nil), "daemon.ListEdgeTracesResponse")
+	proto.RegisterType((*VertexMetricsSample)(nil), "daemon.VertexMetricsSample")
+	proto.RegisterType((*GetVertexMetricsHistoryRequest)(nil), "daemon.GetVertexMetricsHistoryRequest")
+	proto.RegisterType((*GetVertexMetricsHistoryResponse)(nil), "daemon.GetVertexMetricsHistoryResponse")
+	proto.RegisterType((*EdgeWatermark)(nil), "daemon.EdgeWatermark")
+	proto.RegisterType((*VertexWatermark)(nil), "daemon.VertexWatermark")
+	proto.RegisterType((*GetPipelineWatermarksRequest)(nil), "daemon.GetPipelineWatermarksRequest")
+	proto.RegisterType((*GetPipelineWatermarksResponse)(nil), "daemon.GetPipelineWatermarksResponse")
+	proto.RegisterType((*Replay)(nil), "daemon.Replay")
+	proto.RegisterType((*CreateReplayRequest)(nil), "daemon.CreateReplayRequest")
+	proto.RegisterType((*ListReplaysRequest)(nil), "daemon.ListReplaysRequest")
+	proto.RegisterType((*ListReplaysResponse)(nil), "daemon.ListReplaysResponse")
+	proto.RegisterType((*ReplayRequest)(nil), "daemon.ReplayRequest")
+	proto.RegisterType((*ReplayResponse)(nil), "daemon.ReplayResponse")
+	proto.RegisterType((*WatchReplaysRequest)(nil), "daemon.WatchReplaysRequest")
+}
+
+func init() {
+	proto.RegisterFile("pkg/apis/proto/daemon/daemon.proto", fileDescriptor_93e327fd0d673221)
+}
+
+var fileDescriptor_93e327fd0d673221 = []byte{
+	// 1564 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdb, 0x6e, 0x1b, 0xc5,
+	0x1b, 0xd7, 0xee, 0xe6, 0xf8, 0x25, 0x4e, 0xfb, 0x9f, 0xfc, 0x93, 0x2e, 0x4e, 0x1b, 0xac, 0xa5,
+	0xa4, 0x6e, 0xda, 0x66, 0x9b, 0xa0, 0x42, 0xd4, 0x4a, 0x14, 0x1a, 0xd2, 0x50, 0xa9, 0x45, 0xd1,
+	0xa6, 0xb4, 0x15, 0x77, 0x93, 0xdd, 0x89, 0x33, 0xc4, 0x7b, 0xe8, 0xce, 0xd8, 0x21, 0x44, 0xb9,
+	0x28, 0x0f, 0xc0, 0x4d, 0xc5, 0x0d, 0xf0, 0x04, 0xdc, 0xf3, 0x0e, 0x5c, 0x22, 0xf1, 0x02, 0xa8,
+	0xe2, 0x05, 0x78, 0x03, 0x34, 0x27, 0xef, 0xda, 0x71, 0x5c, 0x53, 0x04, 0x57, 0xf1, 0x77, 0x9a,
+	0xdf, 0x37, 0xdf, 0x69, 0xbe, 0x0d, 0x78, 0xd9, 0x41, 0xc3, 0xc7, 0x19, 0x65, 0x7e, 0x96, 0xa7,
+	0x3c, 0xf5, 0x23, 0x4c, 0xe2, 0x34, 0xd1, 0x7f, 0x56, 0x24, 0x0f, 0x8d, 0x29, 0xaa, 0x7a, 0xb1,
+	0x91, 0xa6, 0x8d, 0x26, 0x11, 0xea, 0x3e, 0x4e, 0x92, 0x94, 0x63, 0x4e, 0xd3, 0x84, 0x29, 0xad,
+	0xea, 0x82, 0x96, 0x4a, 0x6a, 0xb7, 0xb5, 0xe7, 0x93, 0x38, 0xe3, 0x47, 0x4a, 0xe8, 0xfd, 0xe8,
+	0x00, 0xdc, 0x6b, 0xed, 0xed, 0x91, 0xfc, 0x41, 0xb2, 0x97, 0xa2, 0x2a, 0x4c, 0x64, 0x34, 0x23,
+	0x4d, 0x9a, 0x10, 0xd7, 0xaa, 0xd9, 0xf5, 0xc9, 0xa0, 0x43, 0xa3, 0x45, 0x80, 0xbd, 0x3c, 0x8d,
+	0x9f, 0x90, 0x9c, 0x93, 0xaf, 0x5c, 0x5b, 0x4a, 0x4b, 0x1c, 0x61, 0xcb, 0x53, 0x2d, 0x75, 0x94,
+	0xad, 0xa1, 0x85, 0xed, 0xae, 0x44, 0xf9, 0x0c, 0xc7, 0xc4, 0x1d, 0x51, 0xb6, 0x05, 0x07, 0x79,
+	0x30, 0x9d, 0x91, 0x24, 0xa2, 0x49, 0x63, 0x23, 0x6d, 0x25, 0xdc, 0x1d, 0xad, 0xd9, 0x75, 0x27,
+	0xe8, 0xe2, 0xa1, 0x3a, 0x9c, 0xc3, 0xe1, 0xc1, 0x76, 0x59, 0x6d, 0x4c, 0xaa, 0xf5, 0xb2, 0xd1,
+	0x65, 0xa8, 0xf0, 0x94, 0xe3, 0xe6, 0x23, 0xc2, 0x18, 0x6e, 0x10, 0xe6, 0x8e, 0x4b, 0xbd, 0x6e,
+	0xa6, 0xc0, 0x54, 0x1e, 0x3c, 0x24, 0x49, 0x83, 0xef, 0xbb, 0x13, 0x0a, 0xb3, 0xcc, 0x43, 0xcb,
+	0x70, 0x5e, 0xd1, 0x9f, 0x0b, 0x9b, 0x87, 0x34, 0xa6, 0xdc, 0x9d, 0xac, 0xd9, 0x75, 0x2b, 0x38,
+	0xc5, 0x47, 0x35, 0x98, 0x2a, 0xf1, 0x5c, 0x90, 0x6a, 0x65, 0x16, 0x9a, 0x87, 0x31, 0xca, 0xee,
+	0xb7, 0x9a, 0x4d, 0x77, 0xaa, 0x66, 0xd7, 0x27, 0x02, 0x4d, 0x21, 0x17, 0xc6, 0x71, 0x78, 0x10,
+	0x60, 0x4e, 0xdc, 0xe9, 0x9a, 0x55, 0xb7, 0x02, 0x43, 0x7a, 0x37, 0x01, 0x3d, 0xa4, 0x8c, 0xab,
+	0x0c, 0xb1, 0x80, 0x3c, 0x6f, 0x11, 0xc6, 0x07, 0x65, 0xc9, 0xdb, 0x80, 0xd9, 0x2e, 0x0b, 0x96,
+	0xa5, 0x09, 0x23, 0xe8, 0x3a, 0x8c, 0x2b, 0x4f, 0x98, 0x6b, 0xd5, 0x9c, 0xfa, 0xd4, 0x1a, 0x5a,
+	0xd1, 0xa5, 0x54, 0x64, 0x3f, 0x30, 0x2a, 0xde, 0x7d, 0x38, 0xbf, 0x45, 0xf4, 0x19, 0x43, 0x80,
+	0x8a, 0x8b, 0x29, 0x53, 0x5d, 0x16, 0x9a, 0xf2, 0xee, 0xc2, 0xff, 0x4a, 0xe7, 0x68, 0x57, 0x96,
+	0x3b, 0xca, 0xe2, 0x98, 0xfe, 0x9e, 0x98, 0x03, 0x76, 0x01, 0x76, 0x48, 0xde, 0x56, 0x5c, 0x11,
+	0xa7, 0x36, 0xc9, 0x19, 0x4d, 0x13, 0xed, 0x81, 0x21, 0x45, 0x7d, 0xe1, 0x8c, 0x3e, 0xd1, 0x42,
+	0xe1, 0xc4, 0x68, 0x50, 0xe2, 0x08, 0xe7, 0xf7, 0x08, 0xe6, 0xad, 0x9c, 0x30, 0xd7, 0xa9, 0x39,
+	0xc2, 0x79, 0x43, 0x7b, 0x2f, 0x1c, 0xa8, 0x3c, 0xce, 0x71, 0x48, 0x22, 0x5d, 0x1a, 0xe8, 0x3c,
+	0x38, 0x59, 0x1a, 0x69, 0x0c, 0xf1, 0x13, 0x5d, 0x84, 0xc9, 0xc3, 0x9c, 0x72, 0xf2, 0x98, 0xc6,
+	0x44, 0x1e, 0xef, 0x04, 0x05, 0x03, 0xcd, 0x80, 0x4d, 0x23, 0x5d, 0xf3, 0x36, 0x8d, 0x84, 0xfd,
+	0x01, 0x39, 0x72, 0x47, 0x6a, 0x96, 0xb0, 0x3f, 0x20, 0x47, 0xc2, 0x9e, 0xb4, 0x49, 0xc2, 0xa5,
+	0xbd, 0x2a, 0xee, 0x82, 0x81, 0xee, 0xc2, 0x44, 0x4c, 0x38, 0x8e, 0x30, 0xc7, 0xee, 0x98, 0xcc,
+	0xce, 0x3b, 0x26, 0x26, 0x5d, 0x8e, 0xad, 0x3c, 0xd2, 0x5a, 0x9b, 0x09, 0xcf, 0x8f, 0x82, 0x8e,
+	0x91, 0x68, 0x8d, 0x30, 0x4d, 0x38, 0x49, 0xf8, 0x66, 0x12, 0xa6, 0xa2, 0x11, 0xdc, 0x71, 0x09,
+	0xde, 0xcb, 0x16, 0x45, 0x9a, 0xe1, 0xa3, 0x66, 0x8a, 0xa3, 0x1d, 0xfa, 0x35, 0xd1, 0x35, 0x5f,
+	0x66, 0x89, 0x20, 0x6b, 0xd2, 0x9d, 0xac, 0x59, 0xf5, 0xe9, 0xc0, 0x90, 0xe2, 0x12, 0x3c, 0x6f,
+	0x25, 0x21, 0xe6, 0x24, 0x72, 0xa1, 0x66, 0xd5, 0x27, 0x82, 0x82, 0x51, 0xbd, 0x03, 0x95, 0x2e,
+	0xf7, 0x4c, 0x14, 0xac, 0x22, 0x0a, 0xff, 0x87, 0xd1, 0x36, 0x6e, 0xb6, 0x44, 0x04, 0x05, 0x4f,
+	0x11, 0xb7, 0xed, 0x75, 0xcb, 0x4b, 0x61, 0x4e, 0x54, 0xed, 0x66, 0xd4, 0x20, 0xf2, 0xc6, 0xc3,
+	0x94, 0xfa, 0x3f, 0x19, 0x48, 0xde, 0x01, 0xcc, 0xf7, 0x02, 0xea, 0xf2, 0x5c, 0x15, 0xc9, 0xd0,
+	0x73, 0x43, 0xb5, 0xca, 0x5c, 0xdf, 0x64, 0x04, 0x1d, 0x35, 0xe9, 0x08, 0xa6, 0x4d, 0x12, 0x6d,
+	0xa7, 0x11, 0x73, 0x6d, 0x59, 0x5f, 0x25, 0x8e, 0xf7, 0xd2, 0x82, 0x59, 0x85, 0xfb, 0x88, 0xf0,
+	0x9c, 0x86, 0x6c, 0x07, 0xc7, 0x59, 0x93, 0x20, 0x04, 0x23, 0x9c, 0xc6, 0xea, 0x62, 0x4e, 0x20,
+	0x7f, 0xa3, 0x25, 0x98, 0xc9, 0xf2, 0x34, 0x24, 0x8c, 0xd1, 0xa4, 0x21, 0x47, 0x82, 0x2d, 0x07,
+	0x49, 0x0f, 0xf7, 0xd4, 0xc4, 0x74, 0xfa, 0x4c, 0x4c, 0x51, 0xb5, 0x98, 0x93, 0x3c, 0xc6, 0xf9,
+	0x81, 0xac, 0x46, 0x27, 0x28, 0x18, 0xde, 0x63, 0x58, 0xdc, 0x22, 0xbc, 0xcb, 0xaf, 0x4f, 0x29,
+	0xe3, 0x69, 0x7e, 0x34, 0x64, 0xcb, 0xb7, 0xcb, 0x81, 0xd7, 0x94, 0xf7, 0x0c, 0xde, 0x3e, 0xf3,
+	0x54, 0x1d, 0xe1, 0x5b, 0x30, 0xce, 0x64, 0x00, 0x4c, 0x80, 0x17, 0x4c, 0x80, 0xfb, 0x04, 0x29,
+	0x30, 0xba, 0xde, 0xcf, 0x16, 0x54, 0x44, 0xbe, 0x9e, 0x9a, 0x1b, 0xf4, 0x14, 0x80, 0x35, 0xb0,
+	0x00, 0xec, 0x81, 0x2f, 0x92, 0x73, 0xea, 0x45, 0x1a, 0x18, 0x3b, 0xf1, 0x2e, 0xe0, 0x90, 0xd3,
+	0x36, 0xd9, 0x56, 0x59, 0x49, 0x73, 0x26, 0xdb, 0x7a, 0x34, 0x38, 0xc5, 0xf7, 0xb6, 0xe0, 0x9c,
+	0xc2, 0x2c, 0x1c, 0x2f, 0x82, 0x67, 0x95, 0x83, 0xd7, 0x0d, 0x6a, 0xf7, 0x26, 0xec, 0x36, 0x5c,
+	0xdc, 0x22, 0x7c, 0x5b, 0x67, 0xa0, 0x73, 0xda, 0x50, 0xcf, 0xc2, 0x0b, 0x0b, 0x2e, 0x9d, 0x61,
+	0xac, 0xb3, 0x72, 0x0d, 0x46, 0x49, 0xd4, 0xa7, 0xe8, 0xbb, 0x42, 0x1e, 0x28, 0x1d, 0xf4, 0x1e,
+	0x4c, 0x08, 0x97, 0x69, 0x48, 0x54, 0xbd, 0x4f, 0xad, 0x5d, 0xe8, 0xce, 0x61, 0x61, 0xd1, 0x51,
+	0xf4, 0xbe, 0x75, 0x60, 0x2c, 0x20, 0x59, 0x13, 0x1f, 0xe9, 0x89, 0x69, 0x75, 0x26, 0x66, 0xd9,
+	0x75, 0x7b, 0x60, 0x9b, 0x3b, 0x03, 0xb3, 0x3c, 0x32, 0x30, 0xcb, 0xa3, 0xfd, 0xb2, 0xcc, 0x38,
+	0xce, 0xd5, 0x5c, 0x56, 0xdb, 0x44, 0xc1, 0x10, 0xf3, 0x2a, 0xdb, 0xc7, 0x8c, 0xc8, 0xfd, 0x61,
+	0x32, 0x50, 0x84, 0x18, 0x90, 0xba, 0xf3, 0xdd, 0x09, 0x39, 0xc7, 0x0c, 0x29, 0xf4, 0xe5, 0x8a,
+	0x21, 0x57, 0x04, 0x27, 0x50, 0x84, 0xf0, 0x2f, 0x97, 0xb7, 0x96, 0x53, 0x53, 0x08, 0x3a, 0xb4,
+	0xc0, 0xcf, 0x49, 0x8c, 0x69, 0x22, 0x46, 0xf6, 0x94, 0xc2, 0xef, 0x30, 0x84, 0xf7, 0x84, 0xe3,
+	0x1d, 0x12, 0xa6, 0x49, 0xc4, 0xe4, 0x6a, 0xe0, 0x04, 0x25, 0x8e, 0x90, 0x87, 0x39, 0xc1, 0xfa,
+	0x59, 0xaa, 0x48, 0xf3, 0x12, 0x47, 0x46, 0x8e, 0x26, 0x94, 0xed, 0x4b, 0xf9, 0x8c, 0xb2, 0x2f,
+	0x38, 0xde, 0x0f, 0x16, 0xcc, 0x6e, 0x48, 0x75, 0x95, 0x96, 0x7f, 0x79, 0xe8, 0x76, 0x47, 0x7b,
+	0xa4, 0x37, 0xda, 0xaa, 0x26, 0x46, 0x65, 0x48, 0x6d, 0x1a, 0x99, 0xdd, 0x47, 0xb9, 0x36, 0x54,
+	0x91, 0xdf, 0x85, 0xd9, 0x2e, 0x0b, 0x5d, 0xd9, 0x75, 0x18, 0x57, 0x01, 0x37, 0xb5, 0x3d, 0x63,
+	0x6a, 0x55, 0x5f, 0xdb, 0x88, 0xbd, 0x3b, 0x50, 0x19, 0x3e, 0x12, 0xca, 0x5f, 0xdb, 0xd4, 0xb0,
+	0xb7, 0x0e, 0x33, 0xc6, 0x58, 0x03, 0x2f, 0xc1, 0x98, 0x3a, 0x59, 0x6f, 0x3a, 0xbd, 0xb8, 0x5a,
+	0xea, 0xad, 0xc2, 0xec, 0x53, 0xcc, 0xc3, 0xfd, 0xe1, 0xaf, 0xba, 0xf6, 0xe7, 0x34, 0x54, 0x3e,
+	0x91, 0x87, 0x89, 0xfd, 0x88, 0x86, 0x04, 0x71, 0x98, 0x2a, 0x2d, 0x7e, 0xa8, 0x6a, 0xb0, 0x4e,
+	0xef, 0x8f, 0xd5, 0x85, 0xbe, 0x32, 0xe5, 0xb4, 0x77, 0xfd, 0x9b, 0xdf, 0xfe, 0x78, 0x69, 0x2f,
+	0xa1, 0xcb, 0xf2, 0x73, 0xa2, 0xbd, 0xea, 0x1b, 0x4c, 0xe6, 0x1f, 0x9b, 0x9f, 0x27, 0xbe, 0xde,
+	0x14, 0xd1, 0x21, 0x4c, 0x76, 0x36, 0x3c, 0xe4, 0x9a, 0x73, 0x7b, 0x97, 0xc7, 0xea, 0x5b, 0x7d,
+	0x24, 0x1a, 0xef, 0x96, 0xc4, 0xf3, 0xd1, 0x8d, 0x61, 0xf0, 0xfc, 0x63, 0xf5, 0xe3, 0x04, 0x3d,
+	0x83, 0xca, 0x16, 0xe1, 0xa5, 0xe5, 0x70, 0x7e, 0x45, 0x7d, 0xe7, 0xac, 0x98, 0xef, 0x9c, 0x95,
+	0x4d, 0xf1, 0x9d, 0x53, 0xed, 0xac, 0x97, 0x85, 0xae, 0xb7, 0x20, 0x31, 0xe7, 0xd0, 0xac, 0xc1,
+	0x64, 0x52, 0x76, 0x83, 0x8a, 0x83, 0xbe, 0xb7, 0x60, 0xa6, 0x7b, 0x37, 0x40, 0x97, 0xca, 0x01,
+	0x3b, 0xb5, 0xa4, 0x54, 0x17, 0xcf, 0x12, 0xeb, 0x2b, 0x3e, 0x90, 0x70, 0x1b, 0xe8, 0xe3, 0x81,
+	0x57, 0x94, 0x93, 0xd5, 0x3f, 0x2e, 0x7a, 0xe9, 0xc4, 0x3f, 0x36, 0xad, 0x73, 0xe2, 0x73, 0xe5,
+	0xc9, 0x4f, 0x16, 0x5c, 0x38, 0xe3, 0x7d, 0x45, 0x4b, 0xa5, 0x20, 0x0f, 0x78, 0xd6, 0xab, 0x57,
+	0x5e, 0xab, 0xa7, 0xfd, 0xfe, 0x50, 0xfa, 0xbd, 0x8e, 0xde, 0x1f, 0xe8, 0xb7, 0x99, 0xef, 0xfe,
+	0x71, 0x5b, 0xfb, 0xba, 0xaf, 0x1d, 0xfa, 0xce, 0x82, 0xb9, 0xbe, 0x8f, 0x0e, 0xba, 0x5c, 0x72,
+	0xe1, 0xcc, 0x07, 0xad, 0xfa, 0xee, 0x6b, 0xb4, 0xb4, 0x9b, 0xbe, 0x74, 0xf3, 0x2a, 0xba, 0x32,
+	0xd0, 0xcd, 0xc3, 0x02, 0x9d, 0xc3, 0x74, 0x79, 0xec, 0xa1, 0x4e, 0x3f, 0xf4, 0x19, 0x86, 0xd5,
+	0xf9, 0x9e, 0xa6, 0xed, 0x41, 0xf5, 0x06, 0xf7, 0x89, 0x9e, 0x2c, 0xb7, 0xad, 0x65, 0xd3, 0xa0,
+	0xba, 0xc9, 0xbb, 0x1b, 0xb4, 0xbb, 0xf3, 0xab, 0x0b, 0x7d, 0x65, 0x7f, 0xab, 0x41, 0x35, 0x30,
+	0x8a, 0x65, 0x83, 0xea, 0x8b, 0xce, 0xf5, 0xde, 0x65, 0xf0, 0x15, 0x57, 0x25, 0xd2, 0x35, 0x74,
+	0x75, 0x18, 0x24, 0xff, 0x98, 0x46, 0x27, 0xa8, 0x05, 0x53, 0xdb, 0xb8, 0xc5, 0xc8, 0x9b, 0x01,
+	0x7e, 0x20, 0x01, 0x57, 0x3d, 0x7f, 0x68, 0x40, 0x3f, 0x13, 0x68, 0xe8, 0x10, 0xa6, 0x03, 0xc2,
+	0x5a, 0xf1, 0x1b, 0xe2, 0xae, 0x4b, 0xdc, 0x35, 0xef, 0xe6, 0xf0, 0xb8, 0xb9, 0x84, 0x13, 0xc0,
+	0x1b, 0x38, 0x09, 0x49, 0xf3, 0x3f, 0x03, 0x0e, 0x25, 0x1c, 0x7a, 0x0e, 0xd3, 0xe5, 0x37, 0xa3,
+	0xa8, 0xe1, 0x3e, 0x2f, 0x49, 0xb5, 0xe7, 0xe1, 0xf1, 0xd6, 0x24, 0xec, 0x75, 0xb4, 0x3c, 0x04,
+	0xec, 0x0d, 0xf9, 0x9d, 0xca, 0x6e, 0x5a, 0xf7, 0x3e, 0xfa, 0xe5, 0xd5, 0xa2, 0xf5, 0xeb, 0xab,
+	0x45, 0xeb, 0xf7, 0x57, 0x8b, 0xd6, 0x17, 0x6b, 0x0d, 0xca, 0xf7, 0x5b, 0xbb, 0x2b, 0x61, 0x1a,
+	0xfb, 0x49, 0x2b, 0xc6, 0x59, 0x9e, 0x7e, 0x29, 0x7f, 0xec, 0x35, 0xd3, 0x43, 0xbf, 0xef, 0x7f,
+	0xaf, 0xfe, 0x1a, 0x00, 0x43, 0x82, 0x55, 0xc7, 0xd5, 0x12, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DaemonServiceClient is the client API for DaemonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DaemonServiceClient interface {
+	ListBuffers(ctx context.Context, in *ListBuffersRequest, opts ...grpc.CallOption) (*ListBuffersResponse, error)
+	GetBuffer(ctx context.Context, in *GetBufferRequest, opts ...grpc.CallOption) (*GetBufferResponse, error)
+	GetServerInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerInfo, error)
+	ListEdgeTraces(ctx context.Context, in *ListEdgeTracesRequest, opts ...grpc.CallOption) (*ListEdgeTracesResponse, error)
+	GetVertexMetricsHistory(ctx context.Context, in *GetVertexMetricsHistoryRequest, opts ...grpc.CallOption) (*GetVertexMetricsHistoryResponse, error)
+	GetPipelineWatermarks(ctx context.Context, in *GetPipelineWatermarksRequest, opts ...grpc.CallOption) (*GetPipelineWatermarksResponse, error)
+	CreateReplay(ctx context.Context, in *CreateReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
+	ListReplays(ctx context.Context, in *ListReplaysRequest, opts ...grpc.CallOption) (*ListReplaysResponse, error)
+	GetReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
+	PauseReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
+	ResumeReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
+	CancelReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
+	// WatchReplays streams the replays of the pipeline whenever their phases change, including when they finish.
+	WatchReplays(ctx context.Context, in *WatchReplaysRequest, opts ...grpc.CallOption) (DaemonService_WatchReplaysClient, error)
+}
+
+type daemonServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDaemonServiceClient(cc *grpc.ClientConn) DaemonServiceClient {
+	return &daemonServiceClient{cc}
+}
+
+func (c *daemonServiceClient) ListBuffers(ctx context.Context, in *ListBuffersRequest, opts ...grpc.CallOption) (*ListBuffersResponse, error) {
+	out := new(ListBuffersResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/ListBuffers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetBuffer(ctx context.Context, in *GetBufferRequest, opts ...grpc.CallOption) (*GetBufferResponse, error) {
+	out := new(GetBufferResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetBuffer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetServerInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerInfo, error) {
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ListEdgeTraces(ctx context.Context, in *ListEdgeTracesRequest, opts ...grpc.CallOption) (*ListEdgeTracesResponse, error) {
+	out := new(ListEdgeTracesResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/ListEdgeTraces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetVertexMetricsHistory(ctx context.Context, in *GetVertexMetricsHistoryRequest, opts ...grpc.CallOption) (*GetVertexMetricsHistoryResponse, error) {
+	out := new(GetVertexMetricsHistoryResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetVertexMetricsHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetPipelineWatermarks(ctx context.Context, in *GetPipelineWatermarksRequest, opts ...grpc.CallOption) (*GetPipelineWatermarksResponse, error) {
+	out := new(GetPipelineWatermarksResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetPipelineWatermarks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) CreateReplay(ctx context.Context, in *CreateReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
+	out := new(ReplayResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/CreateReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ListReplays(ctx context.Context, in *ListReplaysRequest, opts ...grpc.CallOption) (*ListReplaysResponse, error) {
+	out := new(ListReplaysResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/ListReplays", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
+	out := new(ReplayResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) PauseReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
+	out := new(ReplayResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/PauseReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) ResumeReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
+	out := new(ReplayResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/ResumeReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) CancelReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
+	out := new(ReplayResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/CancelReplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) WatchReplays(ctx context.Context, in *WatchReplaysRequest, opts ...grpc.CallOption) (DaemonService_WatchReplaysClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DaemonService_serviceDesc.Streams[0], "/daemon.DaemonService/WatchReplays", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonServiceWatchReplaysClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DaemonService_WatchReplaysClient interface {
+	Recv() (*Replay, error)
+	grpc.ClientStream
+}
+
+type daemonServiceWatchReplaysClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonServiceWatchReplaysClient) Recv() (*Replay, error) {
+	m := new(Replay)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DaemonServiceServer is the server API for DaemonService service.
+type DaemonServiceServer interface {
+	ListBuffers(context.Context, *ListBuffersRequest) (*ListBuffersResponse, error)
+	GetBuffer(context.Context, *GetBufferRequest) (*GetBufferResponse, error)
+	GetServerInfo(context.Context, *emptypb.Empty) (*ServerInfo, error)
+	ListEdgeTraces(context.Context, *ListEdgeTracesRequest) (*ListEdgeTracesResponse, error)
+	GetVertexMetricsHistory(context.Context, *GetVertexMetricsHistoryRequest) (*GetVertexMetricsHistoryResponse, error)
+	GetPipelineWatermarks(context.Context, *GetPipelineWatermarksRequest) (*GetPipelineWatermarksResponse, error)
+	CreateReplay(context.Context, *CreateReplayRequest) (*ReplayResponse, error)
+	ListReplays(context.Context, *ListReplaysRequest) (*ListReplaysResponse, error)
+	GetReplay(context.Context, *ReplayRequest) (*ReplayResponse, error)
+	PauseReplay(context.Context, *ReplayRequest) (*ReplayResponse, error)
+	ResumeReplay(context.Context, *ReplayRequest) (*ReplayResponse, error)
+	CancelReplay(context.Context, *ReplayRequest) (*ReplayResponse, error)
+	// WatchReplays streams the replays of the pipeline whenever their phases change, including when they finish.
+	WatchReplays(*WatchReplaysRequest, DaemonService_WatchReplaysServer) error
+}
+
+// UnimplementedDaemonServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDaemonServiceServer struct {
+}
+
+func (*UnimplementedDaemonServiceServer) ListBuffers(ctx context.Context, req *ListBuffersRequest) (*ListBuffersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuffers not implemented")
+}
+func (*UnimplementedDaemonServiceServer) GetBuffer(ctx context.Context, req *GetBufferRequest) (*GetBufferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuffer not implemented")
+}
+func (*UnimplementedDaemonServiceServer) GetServerInfo(ctx context.Context, req *emptypb.Empty) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (*UnimplementedDaemonServiceServer) ListEdgeTraces(ctx context.Context, req *ListEdgeTracesRequest) (*ListEdgeTracesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEdgeTraces not implemented")
+}
+func (*UnimplementedDaemonServiceServer) GetVertexMetricsHistory(ctx context.Context, req *GetVertexMetricsHistoryRequest) (*GetVertexMetricsHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVertexMetricsHistory not implemented")
+}
+func (*UnimplementedDaemonServiceServer) GetPipelineWatermarks(ctx context.Context, req *GetPipelineWatermarksRequest) (*GetPipelineWatermarksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineWatermarks not implemented")
+}
+func (*UnimplementedDaemonServiceServer) CreateReplay(ctx context.Context, req *CreateReplayRequest) (*ReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReplay not implemented")
+}
+func (*UnimplementedDaemonServiceServer) ListReplays(ctx context.Context, req *ListReplaysRequest) (*ListReplaysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReplays not implemented")
+}
+func (*UnimplementedDaemonServiceServer) GetReplay(ctx context.Context, req *ReplayRequest) (*ReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplay not implemented")
+}
+func (*UnimplementedDaemonServiceServer) PauseReplay(ctx context.Context, req *ReplayRequest) (*ReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseReplay not implemented")
+}
+func (*UnimplementedDaemonServiceServer) ResumeReplay(ctx context.Context, req *ReplayRequest) (*ReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeReplay not implemented")
+}
+func (*UnimplementedDaemonServiceServer) CancelReplay(ctx context.Context, req *ReplayRequest) (*ReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReplay not implemented")
+}
+func (*UnimplementedDaemonServiceServer) WatchReplays(req *WatchReplaysRequest, srv DaemonService_WatchReplaysServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchReplays not implemented")
+}
+
+func RegisterDaemonServiceServer(s *grpc.Server, srv DaemonServiceServer) {
+	s.RegisterService(&_DaemonService_serviceDesc, srv)
+}
+
+func _DaemonService_ListBuffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBuffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ListBuffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/ListBuffers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ListBuffers(ctx, req.(*ListBuffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetBuffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBufferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetBuffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetBuffer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetBuffer(ctx, req.(*GetBufferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetServerInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ListEdgeTraces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEdgeTracesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ListEdgeTraces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/ListEdgeTraces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ListEdgeTraces(ctx, req.(*ListEdgeTracesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetVertexMetricsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVertexMetricsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetVertexMetricsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetVertexMetricsHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetVertexMetricsHistory(ctx, req.(*GetVertexMetricsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetPipelineWatermarks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineWatermarksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetPipelineWatermarks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetPipelineWatermarks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetPipelineWatermarks(ctx, req.(*GetPipelineWatermarksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_CreateReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).CreateReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/CreateReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).CreateReplay(ctx, req.(*CreateReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ListReplays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReplaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ListReplays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/ListReplays",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ListReplays(ctx, req.(*ListReplaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetReplay(ctx, req.(*ReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_PauseReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).PauseReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/PauseReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).PauseReplay(ctx, req.(*ReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_ResumeReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).ResumeReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/ResumeReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).ResumeReplay(ctx, req.(*ReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_CancelReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).CancelReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/CancelReplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).CancelReplay(ctx, req.(*ReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_WatchReplays_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchReplaysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DaemonServiceServer).WatchReplays(m, &daemonServiceWatchReplaysServer{stream})
+}
+
+type DaemonService_WatchReplaysServer interface {
+	Send(*Replay) error
+	grpc.ServerStream
+}
+
+type daemonServiceWatchReplaysServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonServiceWatchReplaysServer) Send(m *Replay) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _DaemonService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "daemon.DaemonService",
+	HandlerType: (*DaemonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBuffers",
+			Handler:    _DaemonService_ListBuffers_Handler,
+		},
+		{
+			MethodName: "GetBuffer",
+			Handler:    _DaemonService_GetBuffer_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _DaemonService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "ListEdgeTraces",
+			Handler:    _DaemonService_ListEdgeTraces_Handler,
+		},
+		{
+			MethodName: "GetVertexMetricsHistory",
+			Handler:    _DaemonService_GetVertexMetricsHistory_Handler,
+		},
+		{
+			MethodName: "GetPipelineWatermarks",
+			Handler:    _DaemonService_GetPipelineWatermarks_Handler,
+		},
+		{
+			MethodName: "CreateReplay",
+			Handler:    _DaemonService_CreateReplay_Handler,
+		},
+		{
+			MethodName: "ListReplays",
+			Handler:    _DaemonService_ListReplays_Handler,
+		},
+		{
+			MethodName: "GetReplay",
+			Handler:    _DaemonService_GetReplay_Handler,
+		},
+		{
+			MethodName: "PauseReplay",
+			Handler:    _DaemonService_PauseReplay_Handler,
+		},
+		{
+			MethodName: "ResumeReplay",
+			Handler:    _DaemonService_ResumeReplay_Handler,
+		},
+		{
+			MethodName: "CancelReplay",
+			Handler:    _DaemonService_CancelReplay_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchReplays",
+			Handler:       _DaemonService_WatchReplays_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/apis/proto/daemon/daemon.proto",
+}
+
+func (m *BufferInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BufferInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BufferInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.AckRate != nil {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.AckRate))))
+		i--
+		dAtA[i] = 0x61
+	}
+	if m.IsFull == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("isFull")
+	} else {
+		i--
+		if *m.IsFull {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.BufferUsage == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsage")
+	} else {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.BufferUsage))))
+		i--
+		dAtA[i] = 0x51
+	}
+	if m.BufferUsageLimit == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsageLimit")
+	} else {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.BufferUsageLimit))))
+		i--
+		dAtA[i] = 0x49
+	}
+	if m.BufferLength == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferLength")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.BufferLength))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.TotalMessages == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalMessages")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.TotalMessages))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.AckPendingCount == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("ackPendingCount")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.AckPendingCount))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.PendingCount == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingCount")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.PendingCount))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.BufferName == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
+	} else {
+		i -= len(*m.BufferName)
+		copy(dAtA[i:], *m.BufferName)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.BufferName)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ToVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	} else {
+		i -= len(*m.ToVertex)
+		copy(dAtA[i:], *m.ToVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.ToVertex)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.FromVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	} else {
+		i -= len(*m.FromVertex)
+		copy(dAtA[i:], *m.FromVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.FromVertex)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListBuffersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListBuffersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListBuffersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListBuffersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListBuffersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListBuffersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Buffers) > 0 {
+		for iNdEx := len(m.Buffers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Buffers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDaemon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetBufferRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetBufferRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetBufferRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Buffer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+	} else {
+		i -= len(*m.Buffer)
+		copy(dAtA[i:], *m.Buffer)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Buffer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetBufferResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetBufferResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetBufferResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Buffer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+	} else {
+		{
+			size, err := m.Buffer.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDaemon(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ServerInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServerInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ServerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Features) > 0 {
+		for iNdEx := len(m.Features) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Features[iNdEx])
+			copy(dAtA[i:], m.Features[iNdEx])
+			i = encodeVarintDaemon(dAtA, i, uint64(len(m.Features[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.ApiVersion == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("apiVersion")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.ApiVersion))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Version == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("version")
+	} else {
+		i -= len(*m.Version)
+		copy(dAtA[i:], *m.Version)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TracedMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TracedMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TracedMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Truncated != nil {
+		i--
+		if *m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Payload != nil {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.PayloadSize == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("payloadSize")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.PayloadSize))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.ContentEncoding != nil {
+		i -= len(*m.ContentEncoding)
+		copy(dAtA[i:], *m.ContentEncoding)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.ContentEncoding)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Metadata) > 0 {
+		for k := range m.Metadata {
+			v := m.Metadata[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintDaemon(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintDaemon(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintDaemon(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.EventTime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("eventTime")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.EventTime))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Key != nil {
+		i -= len(*m.Key)
+		copy(dAtA[i:], *m.Key)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Key)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(*m.Id)
+		copy(dAtA[i:], *m.Id)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Id)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.WriteTime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("writeTime")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.WriteTime))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Pod == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pod")
+	} else {
+		i -= len(*m.Pod)
+		copy(dAtA[i:], *m.Pod)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pod)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListEdgeTracesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListEdgeTracesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListEdgeTracesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ToVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	} else {
+		i -= len(*m.ToVertex)
+		copy(dAtA[i:], *m.ToVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.ToVertex)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.FromVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	} else {
+		i -= len(*m.FromVertex)
+		copy(dAtA[i:], *m.FromVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.FromVertex)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListEdgeTracesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListEdgeTracesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListEdgeTracesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.FailedPods) > 0 {
+		for iNdEx := len(m.FailedPods) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.FailedPods[iNdEx])
+			copy(dAtA[i:], m.FailedPods[iNdEx])
+			i = encodeVarintDaemon(dAtA, i, uint64(len(m.FailedPods[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Messages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDaemon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VertexMetricsSample) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VertexMetricsSample) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VertexMetricsSample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Watermark != nil {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.Watermark))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.PendingCount == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingCount")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.PendingCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ProcessingRate == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("processingRate")
+	} else {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.ProcessingRate))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Time == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("time")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.Time))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetVertexMetricsHistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVertexMetricsHistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetVertexMetricsHistoryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Vertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("vertex")
+	} else {
+		i -= len(*m.Vertex)
+		copy(dAtA[i:], *m.Vertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Vertex)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetVertexMetricsHistoryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetVertexMetricsHistoryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetVertexMetricsHistoryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Samples) > 0 {
+		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Samples[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDaemon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EdgeWatermark) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EdgeWatermark) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EdgeWatermark) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ActiveProcessors == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("activeProcessors")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.ActiveProcessors))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Watermark != nil {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.Watermark))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.BufferName == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
+	} else {
+		i -= len(*m.BufferName)
+		copy(dAtA[i:], *m.BufferName)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.BufferName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ToVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	} else {
+		i -= len(*m.ToVertex)
+		copy(dAtA[i:], *m.ToVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.ToVertex)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.FromVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	} else {
+		i -= len(*m.FromVertex)
+		copy(dAtA[i:], *m.FromVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.FromVertex)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VertexWatermark) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VertexWatermark) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VertexWatermark) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Watermark != nil {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.Watermark))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Vertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("vertex")
+	} else {
+		i -= len(*m.Vertex)
+		copy(dAtA[i:], *m.Vertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Vertex)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetPipelineWatermarksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetPipelineWatermarksRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetPipelineWatermarksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetPipelineWatermarksResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetPipelineWatermarksResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetPipelineWatermarksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Vertices) > 0 {
+		for iNdEx := len(m.Vertices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Vertices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDaemon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Edges) > 0 {
+		for iNdEx := len(m.Edges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Edges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDaemon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Replay) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Replay) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Replay) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.FinishTime != nil {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.FinishTime))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.CreateTime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("createTime")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.CreateTime))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.EtaSeconds != nil {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.EtaSeconds))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Remaining == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("remaining")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.Remaining))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.Replayed == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("replayed")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.Replayed))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Total == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("total")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.Total))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Message != nil {
+		i -= len(*m.Message)
+		copy(dAtA[i:], *m.Message)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Message)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Phase == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("phase")
+	} else {
+		i -= len(*m.Phase)
+		copy(dAtA[i:], *m.Phase)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Phase)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.StartTime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.StartTime))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BufferName == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
+	} else {
+		i -= len(*m.BufferName)
+		copy(dAtA[i:], *m.BufferName)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.BufferName)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.ToVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	} else {
+		i -= len(*m.ToVertex)
+		copy(dAtA[i:], *m.ToVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.ToVertex)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.FromVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	} else {
+		i -= len(*m.FromVertex)
+		copy(dAtA[i:], *m.FromVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.FromVertex)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(*m.Id)
+		copy(dAtA[i:], *m.Id)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateReplayRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateReplayRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateReplayRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Id != nil {
+		i -= len(*m.Id)
+		copy(dAtA[i:], *m.Id)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Id)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.StartTime == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
+	} else {
+		i = encodeVarintDaemon(dAtA, i, uint64(*m.StartTime))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ToVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	} else {
+		i -= len(*m.ToVertex)
+		copy(dAtA[i:], *m.ToVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.ToVertex)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.FromVertex == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	} else {
+		i -= len(*m.FromVertex)
+		copy(dAtA[i:], *m.FromVertex)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.FromVertex)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListReplaysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListReplaysRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListReplaysRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListReplaysResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListReplaysResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListReplaysResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Replays) > 0 {
+		for iNdEx := len(m.Replays) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Replays[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDaemon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReplayRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplayRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplayRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(*m.Id)
+		copy(dAtA[i:], *m.Id)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Id)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReplayResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReplayResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReplayResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Replay == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("replay")
+	} else {
+		{
+			size, err := m.Replay.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDaemon(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchReplaysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchReplaysRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchReplaysRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pipeline == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	} else {
+		i -= len(*m.Pipeline)
+		copy(dAtA[i:], *m.Pipeline)
+		i = encodeVarintDaemon(dAtA, i, uint64(len(*m.Pipeline)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintDaemon(dAtA []byte, offset int, v uint64) int {
+	offset -= sovDaemon(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BufferInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.FromVertex != nil {
+		l = len(*m.FromVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.ToVertex != nil {
+		l = len(*m.ToVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.BufferName != nil {
+		l = len(*m.BufferName)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.PendingCount != nil {
+		n += 1 + sovDaemon(uint64(*m.PendingCount))
+	}
+	if m.AckPendingCount != nil {
+		n += 1 + sovDaemon(uint64(*m.AckPendingCount))
+	}
+	if m.TotalMessages != nil {
+		n += 1 + sovDaemon(uint64(*m.TotalMessages))
+	}
+	if m.BufferLength != nil {
+		n += 1 + sovDaemon(uint64(*m.BufferLength))
+	}
+	if m.BufferUsageLimit != nil {
+		n += 9
+	}
+	if m.BufferUsage != nil {
+		n += 9
+	}
+	if m.IsFull != nil {
+		n += 2
+	}
+	if m.AckRate != nil {
+		n += 9
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListBuffersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListBuffersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Buffers) > 0 {
+		for _, e := range m.Buffers {
+			l = e.Size()
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetBufferRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Buffer != nil {
+		l = len(*m.Buffer)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetBufferResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Buffer != nil {
+		l = m.Buffer.Size()
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ServerInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != nil {
+		l = len(*m.Version)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.ApiVersion != nil {
+		n += 1 + sovDaemon(uint64(*m.ApiVersion))
+	}
+	if len(m.Features) > 0 {
+		for _, s := range m.Features {
+			l = len(s)
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TracedMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pod != nil {
+		l = len(*m.Pod)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.WriteTime != nil {
+		n += 1 + sovDaemon(uint64(*m.WriteTime))
+	}
+	if m.Id != nil {
+		l = len(*m.Id)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Key != nil {
+		l = len(*m.Key)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.EventTime != nil {
+		n += 1 + sovDaemon(uint64(*m.EventTime))
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDaemon(uint64(len(k))) + 1 + len(v) + sovDaemon(uint64(len(v)))
+			n += mapEntrySize + 1 + sovDaemon(uint64(mapEntrySize))
+		}
+	}
+	if m.ContentEncoding != nil {
+		l = len(*m.ContentEncoding)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.PayloadSize != nil {
+		n += 1 + sovDaemon(uint64(*m.PayloadSize))
+	}
+	if m.Payload != nil {
+		l = len(m.Payload)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Truncated != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListEdgeTracesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.FromVertex != nil {
+		l = len(*m.FromVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.ToVertex != nil {
+		l = len(*m.ToVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListEdgeTracesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if len(m.FailedPods) > 0 {
+		for _, s := range m.FailedPods {
+			l = len(s)
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VertexMetricsSample) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Time != nil {
+		n += 1 + sovDaemon(uint64(*m.Time))
+	}
+	if m.ProcessingRate != nil {
+		n += 9
+	}
+	if m.PendingCount != nil {
+		n += 1 + sovDaemon(uint64(*m.PendingCount))
+	}
+	if m.Watermark != nil {
+		n += 1 + sovDaemon(uint64(*m.Watermark))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetVertexMetricsHistoryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Vertex != nil {
+		l = len(*m.Vertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetVertexMetricsHistoryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Samples) > 0 {
+		for _, e := range m.Samples {
+			l = e.Size()
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EdgeWatermark) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromVertex != nil {
+		l = len(*m.FromVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.ToVertex != nil {
+		l = len(*m.ToVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.BufferName != nil {
+		l = len(*m.BufferName)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Watermark != nil {
+		n += 1 + sovDaemon(uint64(*m.Watermark))
+	}
+	if m.ActiveProcessors != nil {
+		n += 1 + sovDaemon(uint64(*m.ActiveProcessors))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VertexWatermark) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vertex != nil {
+		l = len(*m.Vertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Watermark != nil {
+		n += 1 + sovDaemon(uint64(*m.Watermark))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetPipelineWatermarksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetPipelineWatermarksResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Edges) > 0 {
+		for _, e := range m.Edges {
+			l = e.Size()
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if len(m.Vertices) > 0 {
+		for _, e := range m.Vertices {
+			l = e.Size()
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Replay) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(*m.Id)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.FromVertex != nil {
+		l = len(*m.FromVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.ToVertex != nil {
+		l = len(*m.ToVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.BufferName != nil {
+		l = len(*m.BufferName)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.StartTime != nil {
+		n += 1 + sovDaemon(uint64(*m.StartTime))
+	}
+	if m.Phase != nil {
+		l = len(*m.Phase)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Message != nil {
+		l = len(*m.Message)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Total != nil {
+		n += 1 + sovDaemon(uint64(*m.Total))
+	}
+	if m.Replayed != nil {
+		n += 1 + sovDaemon(uint64(*m.Replayed))
+	}
+	if m.Remaining != nil {
+		n += 1 + sovDaemon(uint64(*m.Remaining))
+	}
+	if m.EtaSeconds != nil {
+		n += 1 + sovDaemon(uint64(*m.EtaSeconds))
+	}
+	if m.CreateTime != nil {
+		n += 1 + sovDaemon(uint64(*m.CreateTime))
+	}
+	if m.FinishTime != nil {
+		n += 1 + sovDaemon(uint64(*m.FinishTime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateReplayRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.FromVertex != nil {
+		l = len(*m.FromVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.ToVertex != nil {
+		l = len(*m.ToVertex)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.StartTime != nil {
+		n += 1 + sovDaemon(uint64(*m.StartTime))
+	}
+	if m.Id != nil {
+		l = len(*m.Id)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListReplaysRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListReplaysResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Replays) > 0 {
+		for _, e := range m.Replays {
+			l = e.Size()
+			n += 1 + l + sovDaemon(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReplayRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.Id != nil {
+		l = len(*m.Id)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReplayResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Replay != nil {
+		l = m.Replay.Size()
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WatchReplaysRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pipeline != nil {
+		l = len(*m.Pipeline)
+		n += 1 + l + sovDaemon(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovDaemon(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDaemon(x uint64) (n int) {
+	return sovDaemon(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *BufferInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BufferInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BufferInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.FromVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ToVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.BufferName = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000008)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingCount", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PendingCount = &v
+			hasFields[0] |= uint64(0x00000010)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckPendingCount", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AckPendingCount = &v
+			hasFields[0] |= uint64(0x00000020)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalMessages", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TotalMessages = &v
+			hasFields[0] |= uint64(0x00000040)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferLength", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BufferLength = &v
+			hasFields[0] |= uint64(0x00000080)
+		case 9:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferUsageLimit", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.BufferUsageLimit = &v2
+			hasFields[0] |= uint64(0x00000100)
+		case 10:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferUsage", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.BufferUsage = &v2
+			hasFields[0] |= uint64(0x00000200)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsFull", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.IsFull = &b
+			hasFields[0] |= uint64(0x00000400)
+		case 12:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AckRate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.AckRate = &v2
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
+	}
+	if hasFields[0]&uint64(0x00000010) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingCount")
+	}
+	if hasFields[0]&uint64(0x00000020) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("ackPendingCount")
+	}
+	if hasFields[0]&uint64(0x00000040) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalMessages")
+	}
+	if hasFields[0]&uint64(0x00000080) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferLength")
+	}
+	if hasFields[0]&uint64(0x00000100) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsageLimit")
+	}
+	if hasFields[0]&uint64(0x00000200) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsage")
+	}
+	if hasFields[0]&uint64(0x00000400) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("isFull")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListBuffersRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListBuffersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListBuffersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListBuffersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListBuffersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListBuffersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buffers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Buffers = append(m.Buffers, &BufferInfo{})
+			if err := m.Buffers[len(m.Buffers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetBufferRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetBufferRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetBufferRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buffer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Buffer = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetBufferResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetBufferResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetBufferResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buffer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Buffer == nil {
+				m.Buffer = &BufferInfo{}
+			}
+			if err := m.Buffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServerInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServerInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Version = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApiVersion", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ApiVersion = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Features", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Features = append(m.Features, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("version")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("apiVersion")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TracedMessage) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TracedMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TracedMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pod", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pod = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteTime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WriteTime = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Id = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Key = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventTime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EventTime = &v
+			hasFields[0] |= uint64(0x00000008)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDaemon
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDaemon
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDaemon
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDaemon
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDaemon
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthDaemon
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthDaemon
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDaemon(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthDaemon
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentEncoding", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ContentEncoding = &s
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadSize", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PayloadSize = &v
+			hasFields[0] |= uint64(0x00000010)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Truncated = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pod")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("writeTime")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("eventTime")
+	}
+	if hasFields[0]&uint64(0x00000010) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("payloadSize")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListEdgeTracesRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListEdgeTracesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListEdgeTracesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.FromVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ToVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListEdgeTracesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListEdgeTracesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListEdgeTracesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, &TracedMessage{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedPods", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FailedPods = append(m.FailedPods, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VertexMetricsSample) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VertexMetricsSample: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VertexMetricsSample: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Time = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessingRate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.ProcessingRate = &v2
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingCount", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PendingCount = &v
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Watermark", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Watermark = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("time")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("processingRate")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingCount")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetVertexMetricsHistoryRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetVertexMetricsHistoryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetVertexMetricsHistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Vertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("vertex")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetVertexMetricsHistoryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetVertexMetricsHistoryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetVertexMetricsHistoryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, &VertexMetricsSample{})
+			if err := m.Samples[len(m.Samples)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EdgeWatermark) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EdgeWatermark: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EdgeWatermark: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.FromVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ToVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.BufferName = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Watermark", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Watermark = &v
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveProcessors", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ActiveProcessors = &v
+			hasFields[0] |= uint64(0x00000008)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("activeProcessors")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VertexWatermark) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VertexWatermark: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VertexWatermark: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Vertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Watermark", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Watermark = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("vertex")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetPipelineWatermarksRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetPipelineWatermarksRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetPipelineWatermarksRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetPipelineWatermarksResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetPipelineWatermarksResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetPipelineWatermarksResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Edges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Edges = append(m.Edges, &EdgeWatermark{})
+			if err := m.Edges[len(m.Edges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vertices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Vertices = append(m.Vertices, &VertexWatermark{})
+			if err := m.Vertices[len(m.Vertices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Replay) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Replay: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Replay: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Id = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.FromVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToVertex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ToVertex = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000008)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BufferName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.BufferName = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000010)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StartTime = &v
+			hasFields[0] |= uint64(0x00000020)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Phase", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Phase = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000040)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Message = &s
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Total = &v
+			hasFields[0] |= uint64(0x00000080)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replayed", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Replayed = &v
+			hasFields[0] |= uint64(0x00000100)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remaining", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Remaining = &v
+			hasFields[0] |= uint64(0x00000200)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EtaSeconds", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EtaSeconds = &v
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CreateTime = &v
+			hasFields[0] |= uint64(0x00000400)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinishTime", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FinishTime = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
 			if err != nil {
-				return 0, err
+				return err
 			}
-			i -= size
-			i = encodeVarintDaemon(dAtA, i, uint64(size))
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintDaemon(dAtA []byte, offset int, v uint64) int {
-	offset -= sovDaemon(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *BufferInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Pipeline != nil {
-		l = len(*m.Pipeline)
-		n += 1 + l + sovDaemon(uint64(l))
-	}
-	if m.FromVertex != nil {
-		l = len(*m.FromVertex)
-		n += 1 + l + sovDaemon(uint64(l))
-	}
-	if m.ToVertex != nil {
-		l = len(*m.ToVertex)
-		n += 1 + l + sovDaemon(uint64(l))
-	}
-	if m.BufferName != nil {
-		l = len(*m.BufferName)
-		n += 1 + l + sovDaemon(uint64(l))
-	}
-	if m.PendingCount != nil {
-		n += 1 + sovDaemon(uint64(*m.PendingCount))
-	}
-	if m.AckPendingCount != nil {
-		n += 1 + sovDaemon(uint64(*m.AckPendingCount))
-	}
-	if m.TotalMessages != nil {
-		n += 1 + sovDaemon(uint64(*m.TotalMessages))
-	}
-	if m.BufferLength != nil {
-		n += 1 + sovDaemon(uint64(*m.BufferLength))
-	}
-	if m.BufferUsageLimit != nil {
-		n += 9
-	}
-	if m.BufferUsage != nil {
-		n += 9
-	}
-	if m.IsFull != nil {
-		n += 2
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
 	}
-	return n
-}
-
-func (m *ListBuffersRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
 	}
-	var l int
-	_ = l
-	if m.Pipeline != nil {
-		l = len(*m.Pipeline)
-		n += 1 + l + sovDaemon(uint64(l))
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("fromVertex")
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
 	}
-	return n
-}
-
-func (m *ListBuffersResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	if hasFields[0]&uint64(0x00000010) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
 	}
-	var l int
-	_ = l
-	if len(m.Buffers) > 0 {
-		for _, e := range m.Buffers {
-			l = e.Size()
-			n += 1 + l + sovDaemon(uint64(l))
-		}
+	if hasFields[0]&uint64(0x00000020) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if hasFields[0]&uint64(0x00000040) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("phase")
 	}
-	return n
-}
-
-func (m *GetBufferRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if hasFields[0]&uint64(0x00000080) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("total")
 	}
-	var l int
-	_ = l
-	if m.Pipeline != nil {
-		l = len(*m.Pipeline)
-		n += 1 + l + sovDaemon(uint64(l))
+	if hasFields[0]&uint64(0x00000100) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("replayed")
 	}
-	if m.Buffer != nil {
-		l = len(*m.Buffer)
-		n += 1 + l + sovDaemon(uint64(l))
+	if hasFields[0]&uint64(0x00000200) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("remaining")
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if hasFields[0]&uint64(0x00000400) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("createTime")
 	}
-	return n
-}
 
-func (m *GetBufferResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Buffer != nil {
-		l = m.Buffer.Size()
-		n += 1 + l + sovDaemon(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovDaemon(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozDaemon(x uint64) (n int) {
-	return sovDaemon(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *BufferInfo) Unmarshal(dAtA []byte) error {
+func (m *CreateReplayRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -962,10 +7144,10 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BufferInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateReplayRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BufferInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateReplayRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1004,41 +7186,7 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FromVertex", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDaemon
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDaemon
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthDaemon
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.FromVertex = &s
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000002)
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToVertex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FromVertex", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1067,12 +7215,12 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			s := string(dAtA[iNdEx:postIndex])
-			m.ToVertex = &s
+			m.FromVertex = &s
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000004)
-		case 4:
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BufferName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ToVertex", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1101,54 +7249,12 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			s := string(dAtA[iNdEx:postIndex])
-			m.BufferName = &s
+			m.ToVertex = &s
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000008)
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PendingCount", wireType)
-			}
-			var v int64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDaemon
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.PendingCount = &v
-			hasFields[0] |= uint64(0x00000010)
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AckPendingCount", wireType)
-			}
-			var v int64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDaemon
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.AckPendingCount = &v
-			hasFields[0] |= uint64(0x00000020)
-		case 7:
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalMessages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
 			}
 			var v int64
 			for shift := uint(0); ; shift += 7 {
@@ -1165,13 +7271,13 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.TotalMessages = &v
-			hasFields[0] |= uint64(0x00000040)
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BufferLength", wireType)
+			m.StartTime = &v
+			hasFields[0] |= uint64(0x00000008)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var v int64
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDaemon
@@ -1181,61 +7287,25 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.BufferLength = &v
-			hasFields[0] |= uint64(0x00000080)
-		case 9:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BufferUsageLimit", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
 			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			v2 := float64(math.Float64frombits(v))
-			m.BufferUsageLimit = &v2
-			hasFields[0] |= uint64(0x00000100)
-		case 10:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BufferUsage", wireType)
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
 			}
-			var v uint64
-			if (iNdEx + 8) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			v2 := float64(math.Float64frombits(v))
-			m.BufferUsage = &v2
-			hasFields[0] |= uint64(0x00000200)
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsFull", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDaemon
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			b := bool(v != 0)
-			m.IsFull = &b
-			hasFields[0] |= uint64(0x00000400)
+			s := string(dAtA[iNdEx:postIndex])
+			m.Id = &s
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDaemon(dAtA[iNdEx:])
@@ -1262,28 +7332,7 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("toVertex")
 	}
 	if hasFields[0]&uint64(0x00000008) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferName")
-	}
-	if hasFields[0]&uint64(0x00000010) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pendingCount")
-	}
-	if hasFields[0]&uint64(0x00000020) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("ackPendingCount")
-	}
-	if hasFields[0]&uint64(0x00000040) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("totalMessages")
-	}
-	if hasFields[0]&uint64(0x00000080) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferLength")
-	}
-	if hasFields[0]&uint64(0x00000100) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsageLimit")
-	}
-	if hasFields[0]&uint64(0x00000200) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("bufferUsage")
-	}
-	if hasFields[0]&uint64(0x00000400) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("isFull")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("startTime")
 	}
 
 	if iNdEx > l {
@@ -1291,7 +7340,7 @@ func (m *BufferInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListBuffersRequest) Unmarshal(dAtA []byte) error {
+func (m *ListReplaysRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -1315,10 +7364,10 @@ func (m *ListBuffersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListBuffersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListReplaysRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListBuffersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListReplaysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1380,7 +7429,7 @@ func (m *ListBuffersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListBuffersResponse) Unmarshal(dAtA []byte) error {
+func (m *ListReplaysResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1403,15 +7452,15 @@ func (m *ListBuffersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListBuffersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListReplaysResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListBuffersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListReplaysResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Buffers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replays", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1438,8 +7487,8 @@ func (m *ListBuffersResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Buffers = append(m.Buffers, &BufferInfo{})
-			if err := m.Buffers[len(m.Buffers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Replays = append(m.Replays, &Replay{})
+			if err := m.Replays[len(m.Replays)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1465,7 +7514,7 @@ func (m *ListBuffersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetBufferRequest) Unmarshal(dAtA []byte) error {
+func (m *ReplayRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -1489,10 +7538,10 @@ func (m *GetBufferRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetBufferRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReplayRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetBufferRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReplayRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1531,7 +7580,7 @@ func (m *GetBufferRequest) Unmarshal(dAtA []byte) error {
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Buffer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1560,7 +7609,7 @@ func (m *GetBufferRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			s := string(dAtA[iNdEx:postIndex])
-			m.Buffer = &s
+			m.Id = &s
 			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000002)
 		default:
@@ -1583,7 +7632,7 @@ func (m *GetBufferRequest) Unmarshal(dAtA []byte) error {
 		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
 	}
 	if hasFields[0]&uint64(0x00000002) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
 	}
 
 	if iNdEx > l {
@@ -1591,7 +7640,7 @@ func (m *GetBufferRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetBufferResponse) Unmarshal(dAtA []byte) error {
+func (m *ReplayResponse) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -1615,15 +7664,15 @@ func (m *GetBufferResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetBufferResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReplayResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetBufferResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReplayResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Buffer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replay", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1650,10 +7699,10 @@ func (m *GetBufferResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Buffer == nil {
-				m.Buffer = &BufferInfo{}
+			if m.Replay == nil {
+				m.Replay = &Replay{}
 			}
-			if err := m.Buffer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Replay.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1675,7 +7724,96 @@ func (m *GetBufferResponse) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("buffer")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("replay")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchReplaysRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDaemon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchReplaysRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchReplaysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pipeline", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDaemon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Pipeline = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDaemon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDaemon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("pipeline")
 	}
 
 	if iNdEx > l {
@@ -0,0 +1,32 @@
+package daemon
+
+// APIVersion is the version of the daemon service API, it increases whenever an endpoint, or a field the clients
+// depend on, is added. Clients use it together with the feature list in ServerInfo to decide what they can call.
+//
+// Version 2 adds the ackRate of BufferInfo.
+const APIVersion int32 = 6
+
+// Features of the daemon service API, they are reported by the daemon server in ServerInfo.
+const (
+	FeatureListBuffers   = "ListBuffers"
+	FeatureGetBuffer     = "GetBuffer"
+	FeatureGetServerInfo = "GetServerInfo"
+	// FeatureListEdgeTraces is added in API version 3
+	FeatureListEdgeTraces = "ListEdgeTraces"
+	// FeatureGetVertexMetricsHistory is added in API version 4
+	FeatureGetVertexMetricsHistory = "GetVertexMetricsHistory"
+	// FeatureGetPipelineWatermarks is added in API version 5
+	FeatureGetPipelineWatermarks = "GetPipelineWatermarks"
+	// FeatureReplays is added in API version 6, it covers CreateReplay, ListReplays, GetReplay, PauseReplay,
+	// ResumeReplay, CancelReplay and WatchReplays
+	FeatureReplays = "Replays"
+)
+
+// LegacyFeatures are the features supported by the daemon servers released before the API version negotiation,
+// which respond GetServerInfo with codes.Unimplemented.
+var LegacyFeatures = []string{FeatureListBuffers, FeatureGetBuffer}
+
+// SupportedFeatures returns the features supported by the daemon server built from this version.
+func SupportedFeatures() []string {
+	return []string{FeatureListBuffers, FeatureGetBuffer, FeatureGetServerInfo, FeatureListEdgeTraces, FeatureGetVertexMetricsHistory, FeatureGetPipelineWatermarks, FeatureReplays}
+}
@@ -0,0 +1,1227 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pkg/apis/proto/function/function.proto
+
+package function
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	types "github.com/gogo/protobuf/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Datum is an input or output message of the UDF.
+type Datum struct {
+	// key of the message. For the outputs, it's the name of the downstream vertex the message goes to, or one of the
+	// special keys to drop the message or send it to all the downstream vertices.
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// event_time of the input message, it's ignored in the outputs.
+	EventTime *types.Timestamp `protobuf:"bytes,3,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	// metadata of the message. The outputs carry over the metadata of the input if they have none.
+	Metadata             map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Datum) Reset()         { *m = Datum{} }
+func (m *Datum) String() string { return proto.CompactTextString(m) }
+func (*Datum) ProtoMessage()    {}
+func (*Datum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0297d00cbed1e959, []int{0}
+}
+func (m *Datum) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Datum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Datum.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Datum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Datum.Merge(m, src)
+}
+func (m *Datum) XXX_Size() int {
+	return m.Size()
+}
+func (m *Datum) XXX_DiscardUnknown() {
+	xxx_messageInfo_Datum.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Datum proto.InternalMessageInfo
+
+func (m *Datum) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *Datum) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Datum) GetEventTime() *types.Timestamp {
+	if m != nil {
+		return m.EventTime
+	}
+	return nil
+}
+
+func (m *Datum) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// DatumList is the list of the output data.
+type DatumList struct {
+	Elements             []*Datum `protobuf:"bytes,1,rep,name=elements,proto3" json:"elements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatumList) Reset()         { *m = DatumList{} }
+func (m *DatumList) String() string { return proto.CompactTextString(m) }
+func (*DatumList) ProtoMessage()    {}
+func (*DatumList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0297d00cbed1e959, []int{1}
+}
+func (m *DatumList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DatumList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DatumList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DatumList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatumList.Merge(m, src)
+}
+func (m *DatumList) XXX_Size() int {
+	return m.Size()
+}
+func (m *DatumList) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatumList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatumList proto.InternalMessageInfo
+
+func (m *DatumList) GetElements() []*Datum {
+	if m != nil {
+		return m.Elements
+	}
+	return nil
+}
+
+// ReadyResponse is the response of the readiness check.
+type ReadyResponse struct {
+	Ready bool `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	// streaming declares the UDF implements MapStreamFn.
+	Streaming            bool     `protobuf:"varint,2,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadyResponse) Reset()         { *m = ReadyResponse{} }
+func (m *ReadyResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadyResponse) ProtoMessage()    {}
+func (*ReadyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0297d00cbed1e959, []int{2}
+}
+func (m *ReadyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadyResponse.Merge(m, src)
+}
+func (m *ReadyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadyResponse proto.InternalMessageInfo
+
+func (m *ReadyResponse) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *ReadyResponse) GetStreaming() bool {
+	if m != nil {
+		return m.Streaming
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*Datum)(nil), "function.Datum")
+	proto.RegisterMapType((map[string]string)(nil), "function.Datum.MetadataEntry")
+	proto.RegisterType((*DatumList)(nil), "function.DatumList")
+	proto.RegisterType((*ReadyResponse)(nil), "function.ReadyResponse")
+}
+
+func init() {
+	proto.RegisterFile("pkg/apis/proto/function/function.proto", fileDescriptor_0297d00cbed1e959)
+}
+
+var fileDescriptor_0297d00cbed1e959 = []byte{
+	// 409 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xd5, 0x36, 0x04, 0xec, 0x09, 0x15, 0x68, 0x8b, 0xc0, 0x32, 0x10, 0xa2, 0x1c, 0x50, 0x24,
+	0x84, 0x0d, 0x81, 0x43, 0x5b, 0x6e, 0xa5, 0xad, 0x84, 0x44, 0x2e, 0x0b, 0x5c, 0xb8, 0xa0, 0x4d,
+	0x33, 0x36, 0xa6, 0xde, 0x0f, 0x79, 0xd7, 0x45, 0xfe, 0x5d, 0xfc, 0x09, 0x8e, 0xfc, 0x04, 0xe4,
+	0x5f, 0x82, 0xbc, 0x4e, 0x5c, 0x62, 0xc2, 0x6d, 0xde, 0x9b, 0xb7, 0xf3, 0xde, 0x68, 0x07, 0x9e,
+	0xea, 0xcb, 0x34, 0xe6, 0x3a, 0x33, 0xb1, 0x2e, 0x94, 0x55, 0x71, 0x52, 0xca, 0x0b, 0x9b, 0x29,
+	0xd9, 0x15, 0x91, 0xe3, 0xa9, 0xb7, 0xc1, 0xe1, 0xc3, 0x54, 0xa9, 0x34, 0xc7, 0x56, 0xbf, 0x2c,
+	0x93, 0x18, 0x85, 0xb6, 0x55, 0x2b, 0x0b, 0x9f, 0xf4, 0x9b, 0x36, 0x13, 0x68, 0x2c, 0x17, 0xba,
+	0x15, 0x4c, 0x6b, 0x02, 0xc3, 0x53, 0x6e, 0x4b, 0x41, 0xef, 0xc2, 0xe0, 0x12, 0xab, 0x80, 0x4c,
+	0xc8, 0xec, 0x36, 0x6b, 0x4a, 0x7a, 0x0f, 0x86, 0x57, 0x3c, 0x2f, 0x31, 0xd8, 0x73, 0x5c, 0x0b,
+	0xe8, 0x11, 0x00, 0x5e, 0xa1, 0xb4, 0x5f, 0x9a, 0x51, 0xc1, 0x60, 0x42, 0x66, 0xa3, 0x79, 0x18,
+	0xb5, 0x3e, 0xd1, 0xc6, 0x27, 0xfa, 0xb8, 0xf1, 0x61, 0xbe, 0x53, 0x37, 0x98, 0x1e, 0x81, 0x27,
+	0xd0, 0xf2, 0x15, 0xb7, 0x3c, 0xb8, 0x31, 0x19, 0xcc, 0x46, 0xf3, 0xc7, 0x51, 0xb7, 0x97, 0x4b,
+	0x11, 0x2d, 0xd6, 0xfd, 0x33, 0x69, 0x8b, 0x8a, 0x75, 0xf2, 0xf0, 0x0d, 0xec, 0x6f, 0xb5, 0xfe,
+	0x8e, 0xeb, 0xef, 0x88, 0xeb, 0xaf, 0xe3, 0x1e, 0xef, 0x1d, 0x92, 0xe9, 0x21, 0xf8, 0x6e, 0xfa,
+	0xfb, 0xcc, 0x58, 0xfa, 0x0c, 0x3c, 0xcc, 0x51, 0xa0, 0xb4, 0x26, 0x20, 0x2e, 0xc4, 0x9d, 0x5e,
+	0x08, 0xd6, 0x09, 0xa6, 0x6f, 0x61, 0x9f, 0x21, 0x5f, 0x55, 0x0c, 0x8d, 0x56, 0xd2, 0x60, 0x63,
+	0x52, 0x34, 0x84, 0x33, 0xf6, 0x58, 0x0b, 0xe8, 0x23, 0xf0, 0x8d, 0x2d, 0x90, 0x8b, 0x4c, 0xa6,
+	0xce, 0xde, 0x63, 0xd7, 0xc4, 0xfc, 0x07, 0x81, 0x83, 0x4f, 0x06, 0x8b, 0x53, 0x4c, 0x32, 0x89,
+	0xab, 0xf3, 0xb5, 0x19, 0x7d, 0x0e, 0xc3, 0x05, 0xd7, 0xe7, 0x92, 0xf6, 0x03, 0x84, 0x07, 0x3d,
+	0xc2, 0x05, 0x7f, 0x09, 0xa3, 0x05, 0xd7, 0x1f, 0xdc, 0xd8, 0x5d, 0x8f, 0xfa, 0xc4, 0x0b, 0x42,
+	0x8f, 0xe1, 0xd6, 0x3b, 0xe3, 0x16, 0xa0, 0xf7, 0xff, 0xf9, 0xa2, 0xb3, 0xe6, 0x4e, 0xc2, 0x07,
+	0xd7, 0xaf, 0xb6, 0x36, 0x3d, 0x39, 0xf9, 0x59, 0x8f, 0xc9, 0xaf, 0x7a, 0x4c, 0x7e, 0xd7, 0x63,
+	0xf2, 0xf9, 0x75, 0x9a, 0xd9, 0xaf, 0xe5, 0x32, 0xba, 0x50, 0x22, 0x96, 0xa5, 0xe0, 0xba, 0x50,
+	0xdf, 0x5c, 0x91, 0xe4, 0xea, 0x7b, 0xfc, 0x9f, 0xa3, 0x5d, 0xde, 0x74, 0xf8, 0xd5, 0x9f, 0x01,
+	0x00, 0x19, 0x9a, 0x6e, 0x6c, 0xd6, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UserDefinedFunctionClient is the client API for UserDefinedFunction service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UserDefinedFunctionClient interface {
+	// MapFn applies the function to the datum, and returns the output data.
+	MapFn(ctx context.Context, in *Datum, opts ...grpc.CallOption) (*DatumList, error)
+	// MapStreamFn applies the function to the datum, and streams back the output data as they are produced. It's only
+	// called if the UDF declares streaming in IsReady.
+	MapStreamFn(ctx context.Context, in *Datum, opts ...grpc.CallOption) (UserDefinedFunction_MapStreamFnClient, error)
+	// IsReady is the readiness check of the UDF.
+	IsReady(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReadyResponse, error)
+}
+
+type userDefinedFunctionClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUserDefinedFunctionClient(cc *grpc.ClientConn) UserDefinedFunctionClient {
+	return &userDefinedFunctionClient{cc}
+}
+
+func (c *userDefinedFunctionClient) MapFn(ctx context.Context, in *Datum, opts ...grpc.CallOption) (*DatumList, error) {
+	out := new(DatumList)
+	err := c.cc.Invoke(ctx, "/function.UserDefinedFunction/MapFn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDefinedFunctionClient) MapStreamFn(ctx context.Context, in *Datum, opts ...grpc.CallOption) (UserDefinedFunction_MapStreamFnClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserDefinedFunction_serviceDesc.Streams[0], "/function.UserDefinedFunction/MapStreamFn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userDefinedFunctionMapStreamFnClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserDefinedFunction_MapStreamFnClient interface {
+	Recv() (*Datum, error)
+	grpc.ClientStream
+}
+
+type userDefinedFunctionMapStreamFnClient struct {
+	grpc.ClientStream
+}
+
+func (x *userDefinedFunctionMapStreamFnClient) Recv() (*Datum, error) {
+	m := new(Datum)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userDefinedFunctionClient) IsReady(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ReadyResponse, error) {
+	out := new(ReadyResponse)
+	err := c.cc.Invoke(ctx, "/function.UserDefinedFunction/IsReady", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserDefinedFunctionServer is the server API for UserDefinedFunction service.
+type UserDefinedFunctionServer interface {
+	// MapFn applies the function to the datum, and returns the output data.
+	MapFn(context.Context, *Datum) (*DatumList, error)
+	// MapStreamFn applies the function to the datum, and streams back the output data as they are produced. It's only
+	// called if the UDF declares streaming in IsReady.
+	MapStreamFn(*Datum, UserDefinedFunction_MapStreamFnServer) error
+	// IsReady is the readiness check of the UDF.
+	IsReady(context.Context, *emptypb.Empty) (*ReadyResponse, error)
+}
+
+// UnimplementedUserDefinedFunctionServer can be embedded to have forward compatible implementations.
+type UnimplementedUserDefinedFunctionServer struct {
+}
+
+func (*UnimplementedUserDefinedFunctionServer) MapFn(ctx context.Context, req *Datum) (*DatumList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MapFn not implemented")
+}
+func (*UnimplementedUserDefinedFunctionServer) MapStreamFn(req *Datum, srv UserDefinedFunction_MapStreamFnServer) error {
+	return status.Errorf(codes.Unimplemented, "method MapStreamFn not implemented")
+}
+func (*UnimplementedUserDefinedFunctionServer) IsReady(ctx context.Context, req *emptypb.Empty) (*ReadyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsReady not implemented")
+}
+
+func RegisterUserDefinedFunctionServer(s *grpc.Server, srv UserDefinedFunctionServer) {
+	s.RegisterService(&_UserDefinedFunction_serviceDesc, srv)
+}
+
+func _UserDefinedFunction_MapFn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Datum)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDefinedFunctionServer).MapFn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/function.UserDefinedFunction/MapFn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDefinedFunctionServer).MapFn(ctx, req.(*Datum))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDefinedFunction_MapStreamFn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Datum)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserDefinedFunctionServer).MapStreamFn(m, &userDefinedFunctionMapStreamFnServer{stream})
+}
+
+type UserDefinedFunction_MapStreamFnServer interface {
+	Send(*Datum) error
+	grpc.ServerStream
+}
+
+type userDefinedFunctionMapStreamFnServer struct {
+	grpc.ServerStream
+}
+
+func (x *userDefinedFunctionMapStreamFnServer) Send(m *Datum) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserDefinedFunction_IsReady_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDefinedFunctionServer).IsReady(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/function.UserDefinedFunction/IsReady",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDefinedFunctionServer).IsReady(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UserDefinedFunction_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "function.UserDefinedFunction",
+	HandlerType: (*UserDefinedFunctionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MapFn",
+			Handler:    _UserDefinedFunction_MapFn_Handler,
+		},
+		{
+			MethodName: "IsReady",
+			Handler:    _UserDefinedFunction_IsReady_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MapStreamFn",
+			Handler:       _UserDefinedFunction_MapStreamFn_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/apis/proto/function/function.proto",
+}
+
+func (m *Datum) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Datum) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Datum) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Metadata) > 0 {
+		for k := range m.Metadata {
+			v := m.Metadata[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintFunction(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintFunction(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintFunction(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.EventTime != nil {
+		{
+			size, err := m.EventTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFunction(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintFunction(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintFunction(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DatumList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DatumList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DatumList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Elements) > 0 {
+		for iNdEx := len(m.Elements) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Elements[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFunction(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReadyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Streaming {
+		i--
+		if m.Streaming {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Ready {
+		i--
+		if m.Ready {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintFunction(dAtA []byte, offset int, v uint64) int {
+	offset -= sovFunction(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Datum) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovFunction(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovFunction(uint64(l))
+	}
+	if m.EventTime != nil {
+		l = m.EventTime.Size()
+		n += 1 + l + sovFunction(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovFunction(uint64(len(k))) + 1 + len(v) + sovFunction(uint64(len(v)))
+			n += mapEntrySize + 1 + sovFunction(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DatumList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Elements) > 0 {
+		for _, e := range m.Elements {
+			l = e.Size()
+			n += 1 + l + sovFunction(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReadyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Ready {
+		n += 2
+	}
+	if m.Streaming {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovFunction(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozFunction(x uint64) (n int) {
+	return sovFunction(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Datum) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFunction
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Datum: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Datum: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFunction
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFunction
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFunction
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EventTime == nil {
+				m.EventTime = &types.Timestamp{}
+			}
+			if err := m.EventTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFunction
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFunction
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFunction
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFunction
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthFunction
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFunction
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFunction
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthFunction
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFunction(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthFunction
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFunction(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DatumList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFunction
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DatumList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DatumList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Elements", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFunction
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Elements = append(m.Elements, &Datum{})
+			if err := m.Elements[len(m.Elements)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFunction(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFunction
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ready", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ready = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Streaming", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Streaming = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFunction(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFunction
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipFunction(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowFunction
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFunction
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthFunction
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupFunction
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthFunction
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthFunction        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowFunction          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupFunction = fmt.Errorf("proto: unexpected end of group")
+)
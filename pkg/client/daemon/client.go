@@ -0,0 +1,365 @@
+/*
+Package daemon is the Go client of the pipeline daemon service, it can be used by the controller, CLI tools and tests to
+query the pipeline information such as buffer pending messages programmatically.
+*/
+package daemon
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+)
+
+// ErrFeatureNotSupported is returned when calling an API which is not supported by the daemon server, typically
+// because the daemon server is running an older version than the client.
+var ErrFeatureNotSupported = errors.New("feature not supported by the daemon server")
+
+type DaemonClient struct {
+	client daemonpb.DaemonServiceClient
+	conn   *grpc.ClientConn
+	opts   options
+	// closeFn is called when the client is closed, used to release the resources such as a port-forward
+	closeFn func()
+
+	infoLock sync.Mutex
+	// serverInfo is the negotiated server information, nil if not negotiated yet
+	serverInfo *daemonpb.ServerInfo
+}
+
+// NewDaemonServiceClient returns a client connecting to the daemon service address, e.g. "my-pl-daemon-svc.my-ns.svc.cluster.local:4327".
+func NewDaemonServiceClient(address string, opts ...Option) (*DaemonClient, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	config := &tls.Config{
+		// The daemon server uses a self-signed certificate
+		InsecureSkipVerify: true,
+	}
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}, o.dialOptions...)
+	conn, err := grpc.Dial(address, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	daemonClient := daemonpb.NewDaemonServiceClient(conn)
+	return &DaemonClient{client: daemonClient, conn: conn, opts: *o}, nil
+}
+
+// Close closes the underlying connection, as well as the port-forward if there is.
+func (dc *DaemonClient) Close() error {
+	err := dc.conn.Close()
+	if dc.closeFn != nil {
+		dc.closeFn()
+	}
+	return err
+}
+
+func (dc *DaemonClient) IsDrained(ctx context.Context, pipeline string) (bool, error) {
+	buffers, err := dc.ListPipelineBuffers(ctx, pipeline)
+	if err != nil {
+		return false, err
+	}
+	for _, bufferInfo := range buffers {
+		if *bufferInfo.PendingCount > 0 || *bufferInfo.AckPendingCount > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (dc *DaemonClient) ListPipelineBuffers(ctx context.Context, pipeline string) ([]*daemonpb.BufferInfo, error) {
+	var rspn *daemonpb.ListBuffersResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = dc.client.ListBuffers(ctx, &daemonpb.ListBuffersRequest{
+			Pipeline: &pipeline,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rspn.Buffers, nil
+}
+
+func (dc *DaemonClient) GetPipelineBuffer(ctx context.Context, pipeline, buffer string) (*daemonpb.BufferInfo, error) {
+	var rspn *daemonpb.GetBufferResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = dc.client.GetBuffer(ctx, &daemonpb.GetBufferRequest{
+			Pipeline: &pipeline,
+			Buffer:   &buffer,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rspn.Buffer, nil
+}
+
+// ListEdgeTraces returns the sampled messages written to the buffer of a traced edge, the latest first, as well as
+// the addresses of the pods failed to respond.
+func (dc *DaemonClient) ListEdgeTraces(ctx context.Context, pipeline, fromVertex, toVertex string) ([]*daemonpb.TracedMessage, []string, error) {
+	if err := dc.requireFeature(ctx, daemonpb.FeatureListEdgeTraces); err != nil {
+		return nil, nil, err
+	}
+	var rspn *daemonpb.ListEdgeTracesResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = dc.client.ListEdgeTraces(ctx, &daemonpb.ListEdgeTracesRequest{
+			Pipeline:   &pipeline,
+			FromVertex: &fromVertex,
+			ToVertex:   &toVertex,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rspn.Messages, rspn.FailedPods, nil
+}
+
+// GetVertexMetricsHistory returns the history of the processing rates, pending counts and watermarks of a vertex kept
+// by the daemon server, the oldest first.
+func (dc *DaemonClient) GetVertexMetricsHistory(ctx context.Context, pipeline, vertex string) ([]*daemonpb.VertexMetricsSample, error) {
+	if err := dc.requireFeature(ctx, daemonpb.FeatureGetVertexMetricsHistory); err != nil {
+		return nil, err
+	}
+	var rspn *daemonpb.GetVertexMetricsHistoryResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = dc.client.GetVertexMetricsHistory(ctx, &daemonpb.GetVertexMetricsHistoryRequest{
+			Pipeline: &pipeline,
+			Vertex:   &vertex,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rspn.Samples, nil
+}
+
+// GetPipelineWatermarks returns the current watermarks of the edges and the vertices of the pipeline.
+func (dc *DaemonClient) GetPipelineWatermarks(ctx context.Context, pipeline string) ([]*daemonpb.EdgeWatermark, []*daemonpb.VertexWatermark, error) {
+	if err := dc.requireFeature(ctx, daemonpb.FeatureGetPipelineWatermarks); err != nil {
+		return nil, nil, err
+	}
+	var rspn *daemonpb.GetPipelineWatermarksResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = dc.client.GetPipelineWatermarks(ctx, &daemonpb.GetPipelineWatermarksRequest{
+			Pipeline: &pipeline,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return rspn.Edges, rspn.Vertices, nil
+}
+
+// CreateReplay replays the messages written to the buffer of an edge since startTime, so that they are processed again
+// by the "to" vertex. An ID is generated if id is empty, and the existing replay is returned if there is one with the
+// same ID, which makes the retries safe.
+func (dc *DaemonClient) CreateReplay(ctx context.Context, pipeline, fromVertex, toVertex string, startTime time.Time, id string) (*daemonpb.Replay, error) {
+	if err := dc.requireFeature(ctx, daemonpb.FeatureReplays); err != nil {
+		return nil, err
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	var rspn *daemonpb.ReplayResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = dc.client.CreateReplay(ctx, &daemonpb.CreateReplayRequest{
+			Pipeline:   &pipeline,
+			FromVertex: &fromVertex,
+			ToVertex:   &toVertex,
+			StartTime:  pointer.Int64(startTime.UnixMilli()),
+			Id:         &id,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rspn.Replay, nil
+}
+
+// ListReplays returns the replays of the pipeline created since the daemon server started, the oldest first.
+func (dc *DaemonClient) ListReplays(ctx context.Context, pipeline string) ([]*daemonpb.Replay, error) {
+	if err := dc.requireFeature(ctx, daemonpb.FeatureReplays); err != nil {
+		return nil, err
+	}
+	var rspn *daemonpb.ListReplaysResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = dc.client.ListReplays(ctx, &daemonpb.ListReplaysRequest{
+			Pipeline: &pipeline,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rspn.Replays, nil
+}
+
+// GetReplay returns the progress of a replay.
+func (dc *DaemonClient) GetReplay(ctx context.Context, pipeline, id string) (*daemonpb.Replay, error) {
+	return dc.updateReplay(ctx, dc.client.GetReplay, pipeline, id)
+}
+
+// PauseReplay pauses a running replay after the batch in progress.
+func (dc *DaemonClient) PauseReplay(ctx context.Context, pipeline, id string) (*daemonpb.Replay, error) {
+	return dc.updateReplay(ctx, dc.client.PauseReplay, pipeline, id)
+}
+
+// ResumeReplay resumes a paused replay.
+func (dc *DaemonClient) ResumeReplay(ctx context.Context, pipeline, id string) (*daemonpb.Replay, error) {
+	return dc.updateReplay(ctx, dc.client.ResumeReplay, pipeline, id)
+}
+
+// CancelReplay cancels a running or paused replay, the messages already replayed are not removed.
+func (dc *DaemonClient) CancelReplay(ctx context.Context, pipeline, id string) (*daemonpb.Replay, error) {
+	return dc.updateReplay(ctx, dc.client.CancelReplay, pipeline, id)
+}
+
+func (dc *DaemonClient) updateReplay(ctx context.Context, call func(context.Context, *daemonpb.ReplayRequest, ...grpc.CallOption) (*daemonpb.ReplayResponse, error), pipeline, id string) (*daemonpb.Replay, error) {
+	if err := dc.requireFeature(ctx, daemonpb.FeatureReplays); err != nil {
+		return nil, err
+	}
+	var rspn *daemonpb.ReplayResponse
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		rspn, err = call(ctx, &daemonpb.ReplayRequest{
+			Pipeline: &pipeline,
+			Id:       &id,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rspn.Replay, nil
+}
+
+// WatchReplays calls f with the replays of the pipeline whenever their phases change, including when they finish,
+// until the context is done or the stream is broken.
+func (dc *DaemonClient) WatchReplays(ctx context.Context, pipeline string, f func(*daemonpb.Replay)) error {
+	if err := dc.requireFeature(ctx, daemonpb.FeatureReplays); err != nil {
+		return err
+	}
+	stream, err := dc.client.WatchReplays(ctx, &daemonpb.WatchReplaysRequest{Pipeline: &pipeline})
+	if err != nil {
+		return err
+	}
+	for {
+		replay, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		f(replay)
+	}
+}
+
+// ServerInfo returns the version and the features of the daemon server, it's negotiated once and cached.
+// A daemon server released before the API version negotiation is reported with API version 0 and the legacy features.
+func (dc *DaemonClient) ServerInfo(ctx context.Context) (*daemonpb.ServerInfo, error) {
+	dc.infoLock.Lock()
+	defer dc.infoLock.Unlock()
+	if dc.serverInfo != nil {
+		return dc.serverInfo, nil
+	}
+	var info *daemonpb.ServerInfo
+	err := dc.withRetry(ctx, func() error {
+		var err error
+		info, err = dc.client.GetServerInfo(ctx, &emptypb.Empty{})
+		return err
+	})
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			return nil, err
+		}
+		version, apiVersion := "", int32(0)
+		info = &daemonpb.ServerInfo{Version: &version, ApiVersion: &apiVersion, Features: daemonpb.LegacyFeatures}
+	}
+	dc.serverInfo = info
+	return info, nil
+}
+
+// SupportsFeature tells if the feature is supported by the daemon server.
+func (dc *DaemonClient) SupportsFeature(ctx context.Context, feature string) (bool, error) {
+	info, err := dc.ServerInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, f := range info.GetFeatures() {
+		if f == feature {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// requireFeature returns ErrFeatureNotSupported if the feature is not supported by the daemon server, it should be
+// called before invoking any API added after the API version negotiation.
+func (dc *DaemonClient) requireFeature(ctx context.Context, feature string) error {
+	ok, err := dc.SupportsFeature(ctx, feature)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrFeatureNotSupported, feature)
+	}
+	return nil
+}
+
+// withRetry calls f until it succeeds, returns a non-retryable error, or the retry backoff is exhausted.
+func (dc *DaemonClient) withRetry(ctx context.Context, f func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, dc.opts.retryBackoff, func() (bool, error) {
+		lastErr = f()
+		if lastErr == nil {
+			return true, nil
+		}
+		if isRetryable(lastErr) {
+			return false, nil
+		}
+		return false, lastErr
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+// isRetryable tells if the error returned by the daemon server is transient.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,369 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+
+	daemonpb "github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+)
+
+type fakeDaemonServer struct {
+	daemonpb.UnimplementedDaemonServiceServer
+	// failures is the number of calls to fail before succeeding
+	failures int
+	code     codes.Code
+	calls    int
+	pending  int64
+	// info is nil to simulate a daemon server released before the API version negotiation
+	info *daemonpb.ServerInfo
+}
+
+func (s *fakeDaemonServer) GetServerInfo(ctx context.Context, _ *emptypb.Empty) (*daemonpb.ServerInfo, error) {
+	s.calls++
+	if s.info == nil {
+		return nil, status.Error(codes.Unimplemented, "method GetServerInfo not implemented")
+	}
+	return s.info, nil
+}
+
+func (s *fakeDaemonServer) ListBuffers(ctx context.Context, req *daemonpb.ListBuffersRequest) (*daemonpb.ListBuffersResponse, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, status.Error(s.code, "failed")
+	}
+	return &daemonpb.ListBuffersResponse{Buffers: []*daemonpb.BufferInfo{s.buffer(req.GetPipeline(), "b1")}}, nil
+}
+
+func (s *fakeDaemonServer) GetBuffer(ctx context.Context, req *daemonpb.GetBufferRequest) (*daemonpb.GetBufferResponse, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, status.Error(s.code, "failed")
+	}
+	return &daemonpb.GetBufferResponse{Buffer: s.buffer(req.GetPipeline(), req.GetBuffer())}, nil
+}
+
+func (s *fakeDaemonServer) ListEdgeTraces(ctx context.Context, req *daemonpb.ListEdgeTracesRequest) (*daemonpb.ListEdgeTracesResponse, error) {
+	s.calls++
+	return &daemonpb.ListEdgeTracesResponse{
+		Messages: []*daemonpb.TracedMessage{{
+			Pod:         pointer.String(req.GetFromVertex() + "-0"),
+			WriteTime:   pointer.Int64(time.Now().UnixNano()),
+			Id:          pointer.String("1"),
+			EventTime:   pointer.Int64(0),
+			PayloadSize: pointer.Int64(0),
+		}},
+		FailedPods: []string{"10.0.0.1"},
+	}, nil
+}
+
+func (s *fakeDaemonServer) GetVertexMetricsHistory(ctx context.Context, req *daemonpb.GetVertexMetricsHistoryRequest) (*daemonpb.GetVertexMetricsHistoryResponse, error) {
+	s.calls++
+	return &daemonpb.GetVertexMetricsHistoryResponse{
+		Samples: []*daemonpb.VertexMetricsSample{{
+			Time:           pointer.Int64(time.Now().UnixMilli()),
+			ProcessingRate: pointer.Float64(10),
+			PendingCount:   pointer.Int64(s.pending),
+		}},
+	}, nil
+}
+
+func (s *fakeDaemonServer) GetPipelineWatermarks(ctx context.Context, req *daemonpb.GetPipelineWatermarksRequest) (*daemonpb.GetPipelineWatermarksResponse, error) {
+	s.calls++
+	return &daemonpb.GetPipelineWatermarksResponse{
+		Edges: []*daemonpb.EdgeWatermark{{
+			FromVertex:       pointer.String("from"),
+			ToVertex:         pointer.String("to"),
+			BufferName:       pointer.String("b1"),
+			Watermark:        pointer.Int64(1000),
+			ActiveProcessors: pointer.Int32(1),
+		}},
+		Vertices: []*daemonpb.VertexWatermark{{Vertex: pointer.String("from"), Watermark: pointer.Int64(1000)}, {Vertex: pointer.String("to"), Watermark: pointer.Int64(1000)}},
+	}, nil
+}
+
+func testReplay(id, phase string, startTime int64) *daemonpb.Replay {
+	return &daemonpb.Replay{
+		Id:         pointer.String(id),
+		Pipeline:   pointer.String("pl"),
+		FromVertex: pointer.String("in"),
+		ToVertex:   pointer.String("out"),
+		BufferName: pointer.String("ns-pl-in-out"),
+		StartTime:  pointer.Int64(startTime),
+		Phase:      pointer.String(phase),
+		Total:      pointer.Int64(10),
+		Replayed:   pointer.Int64(0),
+		Remaining:  pointer.Int64(10),
+		CreateTime: pointer.Int64(startTime),
+	}
+}
+
+func (s *fakeDaemonServer) CreateReplay(ctx context.Context, req *daemonpb.CreateReplayRequest) (*daemonpb.ReplayResponse, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, status.Error(s.code, "failed")
+	}
+	return &daemonpb.ReplayResponse{Replay: testReplay(req.GetId(), "Running", req.GetStartTime())}, nil
+}
+
+func (s *fakeDaemonServer) PauseReplay(ctx context.Context, req *daemonpb.ReplayRequest) (*daemonpb.ReplayResponse, error) {
+	s.calls++
+	return &daemonpb.ReplayResponse{Replay: testReplay(req.GetId(), "Paused", 0)}, nil
+}
+
+func (s *fakeDaemonServer) WatchReplays(req *daemonpb.WatchReplaysRequest, stream daemonpb.DaemonService_WatchReplaysServer) error {
+	s.calls++
+	for _, phase := range []string{"Paused", "Succeeded"} {
+		if err := stream.Send(testReplay("r1", phase, 0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeDaemonServer) buffer(pipeline, name string) *daemonpb.BufferInfo {
+	return &daemonpb.BufferInfo{
+		Pipeline:         pointer.String(pipeline),
+		FromVertex:       pointer.String("from"),
+		ToVertex:         pointer.String("to"),
+		BufferName:       pointer.String(name),
+		PendingCount:     pointer.Int64(s.pending),
+		AckPendingCount:  pointer.Int64(0),
+		TotalMessages:    pointer.Int64(s.pending),
+		BufferLength:     pointer.Int64(10000),
+		BufferUsageLimit: pointer.Float64(0.8),
+		BufferUsage:      pointer.Float64(0),
+		IsFull:           pointer.Bool(false),
+	}
+}
+
+func newTestClient(t *testing.T, s *fakeDaemonServer) *DaemonClient {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	daemonpb.RegisterDaemonServiceServer(server, s)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	c, err := NewDaemonServiceClient("bufnet",
+		WithRetryBackoff(wait.Backoff{Steps: 3, Duration: time.Millisecond, Factor: 1}),
+		WithDialOptions(grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		})))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestDaemonClient_ListPipelineBuffers(t *testing.T) {
+	s := &fakeDaemonServer{}
+	c := newTestClient(t, s)
+	buffers, err := c.ListPipelineBuffers(context.Background(), "pl")
+	assert.NoError(t, err)
+	assert.Len(t, buffers, 1)
+	assert.Equal(t, "pl", buffers[0].GetPipeline())
+}
+
+func TestDaemonClient_GetPipelineBuffer(t *testing.T) {
+	s := &fakeDaemonServer{}
+	c := newTestClient(t, s)
+	buffer, err := c.GetPipelineBuffer(context.Background(), "pl", "b2")
+	assert.NoError(t, err)
+	assert.Equal(t, "b2", buffer.GetBufferName())
+}
+
+func TestDaemonClient_IsDrained(t *testing.T) {
+	s := &fakeDaemonServer{pending: 10}
+	c := newTestClient(t, s)
+	drained, err := c.IsDrained(context.Background(), "pl")
+	assert.NoError(t, err)
+	assert.False(t, drained)
+	s.pending = 0
+	drained, err = c.IsDrained(context.Background(), "pl")
+	assert.NoError(t, err)
+	assert.True(t, drained)
+}
+
+func TestDaemonClient_ListEdgeTraces(t *testing.T) {
+	s := &fakeDaemonServer{}
+	c := newTestClient(t, s)
+	_, _, err := c.ListEdgeTraces(context.Background(), "pl", "in", "out")
+	assert.True(t, errors.Is(err, ErrFeatureNotSupported))
+
+	s = &fakeDaemonServer{info: &daemonpb.ServerInfo{
+		Version:    pointer.String("v0.6.0"),
+		ApiVersion: pointer.Int32(daemonpb.APIVersion),
+		Features:   daemonpb.SupportedFeatures(),
+	}}
+	c = newTestClient(t, s)
+	messages, failedPods, err := c.ListEdgeTraces(context.Background(), "pl", "in", "out")
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "in-0", messages[0].GetPod())
+	assert.Equal(t, []string{"10.0.0.1"}, failedPods)
+}
+
+func TestDaemonClient_GetVertexMetricsHistory(t *testing.T) {
+	s := &fakeDaemonServer{info: &daemonpb.ServerInfo{
+		Version:    pointer.String("v0.6.0"),
+		ApiVersion: pointer.Int32(2),
+		Features:   []string{daemonpb.FeatureListBuffers, daemonpb.FeatureGetBuffer, daemonpb.FeatureGetServerInfo, daemonpb.FeatureListEdgeTraces},
+	}}
+	c := newTestClient(t, s)
+	_, err := c.GetVertexMetricsHistory(context.Background(), "pl", "p1")
+	assert.True(t, errors.Is(err, ErrFeatureNotSupported))
+
+	s = &fakeDaemonServer{pending: 5, info: &daemonpb.ServerInfo{
+		Version:    pointer.String("v0.6.0"),
+		ApiVersion: pointer.Int32(daemonpb.APIVersion),
+		Features:   daemonpb.SupportedFeatures(),
+	}}
+	c = newTestClient(t, s)
+	samples, err := c.GetVertexMetricsHistory(context.Background(), "pl", "p1")
+	assert.NoError(t, err)
+	assert.Len(t, samples, 1)
+	assert.Equal(t, float64(10), samples[0].GetProcessingRate())
+	assert.Equal(t, int64(5), samples[0].GetPendingCount())
+}
+
+func TestDaemonClient_GetPipelineWatermarks(t *testing.T) {
+	s := &fakeDaemonServer{info: &daemonpb.ServerInfo{
+		Version:    pointer.String("v0.6.0"),
+		ApiVersion: pointer.Int32(3),
+		Features:   []string{daemonpb.FeatureListBuffers, daemonpb.FeatureGetBuffer, daemonpb.FeatureGetServerInfo, daemonpb.FeatureListEdgeTraces, daemonpb.FeatureGetVertexMetricsHistory},
+	}}
+	c := newTestClient(t, s)
+	_, _, err := c.GetPipelineWatermarks(context.Background(), "pl")
+	assert.True(t, errors.Is(err, ErrFeatureNotSupported))
+
+	s = &fakeDaemonServer{info: &daemonpb.ServerInfo{
+		Version:    pointer.String("v0.6.0"),
+		ApiVersion: pointer.Int32(daemonpb.APIVersion),
+		Features:   daemonpb.SupportedFeatures(),
+	}}
+	c = newTestClient(t, s)
+	edges, vertices, err := c.GetPipelineWatermarks(context.Background(), "pl")
+	assert.NoError(t, err)
+	assert.Len(t, edges, 1)
+	assert.Equal(t, int64(1000), edges[0].GetWatermark())
+	assert.Len(t, vertices, 2)
+}
+
+func TestDaemonClient_Replays(t *testing.T) {
+	s := &fakeDaemonServer{info: &daemonpb.ServerInfo{
+		Version:    pointer.String("v0.6.0"),
+		ApiVersion: pointer.Int32(5),
+		Features:   []string{daemonpb.FeatureListBuffers, daemonpb.FeatureGetBuffer, daemonpb.FeatureGetServerInfo, daemonpb.FeatureListEdgeTraces, daemonpb.FeatureGetVertexMetricsHistory, daemonpb.FeatureGetPipelineWatermarks},
+	}}
+	c := newTestClient(t, s)
+	_, err := c.CreateReplay(context.Background(), "pl", "in", "out", time.Now(), "")
+	assert.True(t, errors.Is(err, ErrFeatureNotSupported))
+
+	s = &fakeDaemonServer{failures: 2, code: codes.Unavailable, info: &daemonpb.ServerInfo{
+		Version:    pointer.String("v0.6.0"),
+		ApiVersion: pointer.Int32(daemonpb.APIVersion),
+		Features:   daemonpb.SupportedFeatures(),
+	}}
+	c = newTestClient(t, s)
+	startTime := time.UnixMilli(1636470000000)
+	// The generated ID is kept across the retries
+	replay, err := c.CreateReplay(context.Background(), "pl", "in", "out", startTime, "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, replay.GetId())
+	assert.Equal(t, int64(1636470000000), replay.GetStartTime())
+	replay, err = c.CreateReplay(context.Background(), "pl", "in", "out", startTime, "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, "r1", replay.GetId())
+	replay, err = c.PauseReplay(context.Background(), "pl", "r1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Paused", replay.GetPhase())
+	var phases []string
+	assert.NoError(t, c.WatchReplays(context.Background(), "pl", func(r *daemonpb.Replay) {
+		phases = append(phases, r.GetPhase())
+	}))
+	assert.Equal(t, []string{"Paused", "Succeeded"}, phases)
+}
+
+func TestDaemonClient_Retry(t *testing.T) {
+	t.Run("test transient errors", func(t *testing.T) {
+		s := &fakeDaemonServer{failures: 2, code: codes.Unavailable}
+		c := newTestClient(t, s)
+		_, err := c.ListPipelineBuffers(context.Background(), "pl")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, s.calls)
+	})
+
+	t.Run("test running out of retries", func(t *testing.T) {
+		s := &fakeDaemonServer{failures: 5, code: codes.Unavailable}
+		c := newTestClient(t, s)
+		_, err := c.ListPipelineBuffers(context.Background(), "pl")
+		assert.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 3, s.calls)
+	})
+
+	t.Run("test non-retryable errors", func(t *testing.T) {
+		s := &fakeDaemonServer{failures: 5, code: codes.NotFound}
+		c := newTestClient(t, s)
+		_, err := c.GetPipelineBuffer(context.Background(), "pl", "b1")
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Equal(t, 1, s.calls)
+	})
+}
+
+func TestDaemonClient_ServerInfo(t *testing.T) {
+	t.Run("test negotiating with a legacy server", func(t *testing.T) {
+		s := &fakeDaemonServer{}
+		c := newTestClient(t, s)
+		info, err := c.ServerInfo(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), info.GetApiVersion())
+		assert.Equal(t, daemonpb.LegacyFeatures, info.GetFeatures())
+		ok, err := c.SupportsFeature(context.Background(), daemonpb.FeatureListBuffers)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		err = c.requireFeature(context.Background(), daemonpb.FeatureGetServerInfo)
+		assert.True(t, errors.Is(err, ErrFeatureNotSupported))
+		// Unimplemented is not retried, and the negotiated result is cached
+		assert.Equal(t, 1, s.calls)
+	})
+
+	t.Run("test negotiating with a server supporting it", func(t *testing.T) {
+		s := &fakeDaemonServer{info: &daemonpb.ServerInfo{
+			Version:    pointer.String("v0.6.0"),
+			ApiVersion: pointer.Int32(daemonpb.APIVersion),
+			Features:   daemonpb.SupportedFeatures(),
+		}}
+		c := newTestClient(t, s)
+		info, err := c.ServerInfo(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "v0.6.0", info.GetVersion())
+		assert.NoError(t, c.requireFeature(context.Background(), daemonpb.FeatureGetServerInfo))
+		ok, err := c.SupportsFeature(context.Background(), "Unknown")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, 1, s.calls)
+	})
+
+	t.Run("test transient errors are not cached", func(t *testing.T) {
+		s := &fakeDaemonServer{info: &daemonpb.ServerInfo{Version: pointer.String("v0.6.0"), ApiVersion: pointer.Int32(daemonpb.APIVersion)}}
+		c := newTestClient(t, s)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := c.ServerInfo(ctx)
+		assert.Error(t, err)
+		_, err = c.ServerInfo(context.Background())
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,42 @@
+package daemon
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type options struct {
+	// retryBackoff is used to retry the calls failed with transient errors
+	retryBackoff wait.Backoff
+	// dialOptions are appended to the default dial options
+	dialOptions []grpc.DialOption
+}
+
+func defaultOptions() *options {
+	return &options{
+		retryBackoff: wait.Backoff{
+			Steps:    3,
+			Duration: 200 * time.Millisecond,
+			Factor:   2.0,
+			Jitter:   0.1,
+		},
+	}
+}
+
+type Option func(*options)
+
+// WithRetryBackoff sets the backoff to retry the calls failed with transient errors, use Steps=1 to disable retrying.
+func WithRetryBackoff(b wait.Backoff) Option {
+	return func(o *options) {
+		o.retryBackoff = b
+	}
+}
+
+// WithDialOptions appends the gRPC dial options, they take precedence over the default ones.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
@@ -0,0 +1,91 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// NewDaemonServiceClientWithPortForward port-forwards a local port to the daemon pod of the pipeline, and returns a client
+// connecting to it. It is useful for the tools running outside the cluster. Closing the client stops the port-forward.
+func NewDaemonServiceClientWithPortForward(ctx context.Context, config *rest.Config, namespace, pipelineName string, opts ...Option) (*DaemonClient, error) {
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client, %w", err)
+	}
+	labelSelector := fmt.Sprintf("%s=%s,%s=%s", dfv1.KeyComponent, dfv1.ComponentDaemon, dfv1.KeyPipelineName, pipelineName)
+	podList, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: "status.phase=" + string(corev1.PodRunning)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemon pods of pipeline %q, %w", pipelineName, err)
+	}
+	if len(podList.Items) == 0 {
+		return nil, fmt.Errorf("no running daemon pod found for pipeline %q", pipelineName)
+	}
+	stopCh := make(chan struct{})
+	localPort, err := PodPortForward(config, namespace, podList.Items[0].Name, dfv1.DaemonServicePort, stopCh)
+	if err != nil {
+		close(stopCh)
+		return nil, err
+	}
+	c, err := NewDaemonServiceClient(fmt.Sprintf("localhost:%d", localPort), opts...)
+	if err != nil {
+		close(stopCh)
+		return nil, err
+	}
+	c.closeFn = func() { close(stopCh) }
+	return c, nil
+}
+
+// PodPortForward forwards a random local port to the remote port of the pod, and returns the local port once it's ready.
+// The port-forward stops when stopCh is closed.
+func PodPortForward(config *rest.Config, namespace, podName string, remotePort int, stopCh <-chan struct{}) (int, error) {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", namespace, podName)
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return 0, err
+	}
+	var scheme string
+	var host string
+	if strings.HasPrefix(config.Host, "https://") {
+		scheme = "https"
+		host = strings.TrimPrefix(config.Host, "https://")
+	} else {
+		scheme = "http"
+		host = strings.TrimPrefix(config.Host, "http://")
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, &url.URL{Scheme: scheme, Path: path, Host: host})
+	readyCh := make(chan struct{})
+	fw, err := portforward.New(dialer, []string{fmt.Sprintf("0:%d", remotePort)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return 0, err
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		return 0, fmt.Errorf("failed to port-forward to pod %q, %w", podName, err)
+	}
+	ports, err := fw.GetPorts()
+	if err != nil {
+		return 0, err
+	}
+	if len(ports) == 0 {
+		return 0, fmt.Errorf("no port forwarded to pod %q", podName)
+	}
+	return int(ports[0].Local), nil
+}
@@ -11,6 +11,8 @@ import (
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/soheilhy/cmux"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -21,6 +23,8 @@ import (
 	"github.com/numaproj/numaflow/pkg/daemon/server/service"
 	"github.com/numaproj/numaflow/pkg/isbsvc"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/metrics/export"
+	"github.com/numaproj/numaflow/pkg/notifications"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	sharedtls "github.com/numaproj/numaflow/pkg/shared/tls"
 )
@@ -48,6 +52,7 @@ func (ds *daemonServer) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to listen: %v", listerErr)
 	}
 	var isbSvcClient isbsvc.ISBService
+	var queryOpts []service.QueryServiceOption
 	switch ds.isbSvcType {
 	case v1alpha1.ISBSvcTypeRedis:
 		isbSvcClient = isbsvc.NewISBRedisSvc(clients.NewInClusterRedisClient())
@@ -61,6 +66,13 @@ func (ds *daemonServer) Run(ctx context.Context) error {
 			log.Errorw("Failed to get a ISB Service client.", zap.Error(err))
 			return err
 		}
+		js, err := nc.JetStream()
+		if err != nil {
+			return fmt.Errorf("failed to get a JetStream context from nats connection, %w", err)
+		}
+		queryOpts = append(queryOpts, service.WithWatermarkKV(js))
+	case v1alpha1.ISBSvcTypeKafka:
+		isbSvcClient = isbsvc.NewISBKafkaSvc(clients.NewInClusterKafkaClient())
 	default:
 		return fmt.Errorf("unsupported isbs buffer type %q", ds.isbSvcType)
 	}
@@ -72,7 +84,32 @@ func (ds *daemonServer) Run(ctx context.Context) error {
 
 	tlsConfig := &tls.Config{Certificates: []tls.Certificate{*cer}, MinVersion: tls.VersionTLS12}
 
-	grpcServer := ds.newGRPCServer(isbSvcClient)
+	queryService := service.NewISBSvcQueryService(isbSvcClient, ds.pipeline, queryOpts...)
+	prometheus.MustRegister(newBufferCollector(ctx, queryService, ds.pipeline.Name))
+	go queryService.RecordAckRates(ctx)
+	go queryService.RecordMetricsHistory(ctx)
+	go queryService.RunReplays(ctx)
+	if x := ds.pipeline.Spec.Metrics.GetExport(); x != nil {
+		exporter, err := export.NewExporter(*x, export.WithAttributes(map[string]string{
+			"namespace": ds.pipeline.Namespace,
+			"pipeline":  ds.pipeline.Name,
+		}))
+		if err != nil {
+			return fmt.Errorf("failed to create the metrics exporter, %w", err)
+		}
+		go exporter.Run(ctx)
+		log.Infow("Exporting the metrics", zap.String("protocol", string(x.Protocol)), zap.String("endpoint", x.Endpoint))
+	}
+	if x := ds.pipeline.Spec.Notifications; x != nil && x.SLA != nil {
+		notifier, err := notifications.NewNotifier(*x)
+		if err != nil {
+			return fmt.Errorf("failed to create the notifier, %w", err)
+		}
+		go newSLAChecker(ds.pipeline, queryService, notifier).Run(ctx)
+		log.Info("Checking the SLA of the pipeline")
+	}
+
+	grpcServer := ds.newGRPCServer(queryService)
 	httpServer := ds.newHTTPServer(ctx, v1alpha1.DaemonServicePort, tlsConfig)
 
 	conn = tls.NewListener(conn, tlsConfig)
@@ -90,7 +127,7 @@ func (ds *daemonServer) Run(ctx context.Context) error {
 	return nil
 }
 
-func (ds *daemonServer) newGRPCServer(isbSvcClient isbsvc.ISBService) *grpc.Server {
+func (ds *daemonServer) newGRPCServer(queryService daemon.DaemonServiceServer) *grpc.Server {
 
 	// "Prometheus histograms are a great way to measure latency distributions of your RPCs. However, since it is bad practice to have metrics of high cardinality the latency monitoring metrics are disabled by default. To enable them please call the following in your server initialization code:"
 	grpc_prometheus.EnableHandlingTimeHistogram()
@@ -103,7 +140,7 @@ func (ds *daemonServer) newGRPCServer(isbSvcClient isbsvc.ISBService) *grpc.Serv
 	}
 	grpcServer := grpc.NewServer(sOpts...)
 	grpc_prometheus.Register(grpcServer)
-	daemon.RegisterDaemonServiceServer(grpcServer, service.NewISBSvcQueryService(isbSvcClient, ds.pipeline))
+	daemon.RegisterDaemonServiceServer(grpcServer, queryService)
 	return grpcServer
 }
 
@@ -129,5 +166,7 @@ func (ds *daemonServer) newHTTPServer(ctx context.Context, port int, tlsConfig *
 	if err := daemon.RegisterDaemonServiceHandlerFromEndpoint(ctx, gwmux, endpoint, dialOpts); err != nil {
 		log.Errorw("Failed to Register Daemon handler on HTTP Server", zap.Error(err))
 	}
+	mux.Handle("/api/", gwmux)
+	mux.Handle("/metrics", promhttp.Handler())
 	return &httpServer
 }
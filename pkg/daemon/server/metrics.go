@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+var bufferLabels = []string{"pipeline", "buffer", "from_vertex", "to_vertex"}
+
+var (
+	bufferPendingDesc    = prometheus.NewDesc("pipeline_buffer_pending", "Number of the pending messages of the buffer", bufferLabels, nil)
+	bufferAckPendingDesc = prometheus.NewDesc("pipeline_buffer_ack_pending", "Number of the messages read but not acknowledged of the buffer", bufferLabels, nil)
+	bufferUsageDesc      = prometheus.NewDesc("pipeline_buffer_usage", "Usage of the buffer, between 0 and 1", bufferLabels, nil)
+	bufferFullDesc       = prometheus.NewDesc("pipeline_buffer_full", "Whether the buffer is full, 1 if it is full", bufferLabels, nil)
+)
+
+// bufferLister lists the buffers of the pipeline, it's implemented by the daemon service.
+type bufferLister interface {
+	ListBuffers(ctx context.Context, req *daemon.ListBuffersRequest) (*daemon.ListBuffersResponse, error)
+}
+
+// bufferCollector queries the buffers of the pipeline when the metrics are gathered, for both the scraping and the
+// export of the metrics.
+type bufferCollector struct {
+	ctx      context.Context
+	lister   bufferLister
+	pipeline string
+}
+
+func newBufferCollector(ctx context.Context, lister bufferLister, pipeline string) *bufferCollector {
+	return &bufferCollector{ctx: ctx, lister: lister, pipeline: pipeline}
+}
+
+func (bc *bufferCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bufferPendingDesc
+	ch <- bufferAckPendingDesc
+	ch <- bufferUsageDesc
+	ch <- bufferFullDesc
+}
+
+// Collect skips the buffer metrics if the buffers can not be queried, rather than failing the other metrics.
+func (bc *bufferCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(bc.ctx, 10*time.Second)
+	defer cancel()
+	resp, err := bc.lister.ListBuffers(ctx, &daemon.ListBuffersRequest{Pipeline: &bc.pipeline})
+	if err != nil {
+		logging.FromContext(bc.ctx).Warnw("Failed to query the buffers for the metrics", zap.Error(err))
+		return
+	}
+	for _, b := range resp.GetBuffers() {
+		lvs := []string{bc.pipeline, b.GetBufferName(), b.GetFromVertex(), b.GetToVertex()}
+		full := 0.0
+		if b.GetIsFull() {
+			full = 1
+		}
+		ch <- prometheus.MustNewConstMetric(bufferPendingDesc, prometheus.GaugeValue, float64(b.GetPendingCount()), lvs...)
+		ch <- prometheus.MustNewConstMetric(bufferAckPendingDesc, prometheus.GaugeValue, float64(b.GetAckPendingCount()), lvs...)
+		ch <- prometheus.MustNewConstMetric(bufferUsageDesc, prometheus.GaugeValue, b.GetBufferUsage(), lvs...)
+		ch <- prometheus.MustNewConstMetric(bufferFullDesc, prometheus.GaugeValue, full, lvs...)
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+)
+
+type fakeBufferLister struct {
+	buffers []*daemon.BufferInfo
+	err     error
+}
+
+func (f *fakeBufferLister) ListBuffers(_ context.Context, _ *daemon.ListBuffersRequest) (*daemon.ListBuffersResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &daemon.ListBuffersResponse{Buffers: f.buffers}, nil
+}
+
+func TestBufferCollector(t *testing.T) {
+	lister := &fakeBufferLister{buffers: []*daemon.BufferInfo{{
+		BufferName:      pointer.String("ns-pl-in-out"),
+		FromVertex:      pointer.String("in"),
+		ToVertex:        pointer.String("out"),
+		PendingCount:    pointer.Int64(10),
+		AckPendingCount: pointer.Int64(2),
+		BufferUsage:     pointer.Float64(0.9),
+		IsFull:          pointer.Bool(true),
+	}}}
+	c := newBufferCollector(context.Background(), lister, "pl")
+	expected := `
+# HELP pipeline_buffer_full Whether the buffer is full, 1 if it is full
+# TYPE pipeline_buffer_full gauge
+pipeline_buffer_full{buffer="ns-pl-in-out",from_vertex="in",pipeline="pl",to_vertex="out"} 1
+# HELP pipeline_buffer_pending Number of the pending messages of the buffer
+# TYPE pipeline_buffer_pending gauge
+pipeline_buffer_pending{buffer="ns-pl-in-out",from_vertex="in",pipeline="pl",to_vertex="out"} 10
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "pipeline_buffer_pending", "pipeline_buffer_full"))
+
+	lister.err = fmt.Errorf("unreachable")
+	r := prometheus.NewRegistry()
+	r.MustRegister(c)
+	families, err := r.Gather()
+	assert.NoError(t, err)
+	assert.Empty(t, families)
+}
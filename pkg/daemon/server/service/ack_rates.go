@@ -0,0 +1,208 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	// ackRateInterval is the interval of sampling the ack counters of the vertex pods
+	ackRateInterval = 10 * time.Second
+	// metricForwarderAckTotal is the ack counter of the forwarders, labeled by the buffers they read from
+	metricForwarderAckTotal = "forwarder_ack_total"
+)
+
+// ackRates calculates the numbers of the messages acknowledged per second of the buffers, from the ack counters
+// scraped from the metrics servers of the pods reading from them.
+type ackRates struct {
+	lock sync.RWMutex
+	// counts are the ack counters sampled last time, keyed by the buffers and then the pod addresses
+	counts      map[string]map[string]float64
+	rates       map[string]float64
+	lastSampled time.Time
+	// lookupHost returns the addresses of the pods behind the headless service of a vertex
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	// port of the metrics servers of the pods
+	port   int
+	client *http.Client
+}
+
+func newAckRates() *ackRates {
+	return &ackRates{
+		counts:     make(map[string]map[string]float64),
+		rates:      make(map[string]float64),
+		lookupHost: net.DefaultResolver.LookupHost,
+		port:       v1alpha1.VertexMetricsPort,
+		client: &http.Client{
+			Timeout: ackRateInterval,
+			// The metrics servers of the pods use self-signed certificates
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		},
+	}
+}
+
+// parseAckCounts returns the ack counters in the metrics keyed by the buffers. The forwarders of the vertices with
+// multiple inbound edges label the counters by each buffer read, the counters labelled by the comma-joined buffer names
+// of the older versions can not be told apart, so they are left out.
+func parseAckCounts(body []byte) (map[string]float64, error) {
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics, %w", err)
+	}
+	result := make(map[string]float64)
+	if f := families[metricForwarderAckTotal]; f != nil {
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "buffer" && !strings.Contains(l.GetValue(), ",") {
+					result[l.GetValue()] += m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// scrape returns the ack counters of the pods of the vertex keyed by the buffers and then the pod addresses, the pods
+// failed to respond are left out.
+func (a *ackRates) scrape(ctx context.Context, pl *v1alpha1.Pipeline, vertex string) (map[string]map[string]float64, error) {
+	v := v1alpha1.Vertex{ObjectMeta: metav1.ObjectMeta{Namespace: pl.Namespace, Name: pl.Name + "-" + vertex}}
+	addrs, err := a.lookupHost(ctx, fmt.Sprintf("%s.%s.svc", v.GetHeadlessServiceName(), v.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]float64)
+	for _, addr := range addrs {
+		counts, err := a.scrapePod(ctx, addr)
+		if err != nil {
+			logging.FromContext(ctx).Debugw("Failed to get the metrics from the pod", zap.String("vertex", vertex), zap.String("address", addr), zap.Error(err))
+			continue
+		}
+		for buffer, c := range counts {
+			if result[buffer] == nil {
+				result[buffer] = make(map[string]float64)
+			}
+			result[buffer][addr] = c
+		}
+	}
+	return result, nil
+}
+
+func (a *ackRates) scrapePod(ctx context.Context, addr string) (map[string]float64, error) {
+	u := fmt.Sprintf("https://%s/metrics", net.JoinHostPort(addr, strconv.Itoa(a.port)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseAckCounts(body)
+}
+
+// record calculates the rates of the buffers at now from the ack counters of the pods. A pod failed to respond keeps
+// its last counter, so that its messages are counted in the next sample.
+func (a *ackRates) record(now time.Time, acks map[string]map[string]float64) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	elapsed := now.Sub(a.lastSampled).Seconds()
+	for buffer := range a.counts {
+		if _, ok := acks[buffer]; !ok {
+			acks[buffer] = nil
+		}
+	}
+	for buffer, current := range acks {
+		last := a.counts[buffer]
+		counts := make(map[string]float64, len(current))
+		var acked float64
+		for addr, c := range current {
+			counts[addr] = c
+			if prev, ok := last[addr]; ok {
+				if c >= prev {
+					acked += c - prev
+				} else { // the pod restarted
+					acked += c
+				}
+			}
+		}
+		for addr, prev := range last {
+			if _, ok := counts[addr]; !ok {
+				counts[addr] = prev
+			}
+		}
+		a.counts[buffer] = counts
+		if !a.lastSampled.IsZero() && elapsed > 0 {
+			a.rates[buffer] = acked / elapsed
+		}
+	}
+	a.lastSampled = now
+}
+
+// get returns the number of the messages acknowledged per second of the buffer in the latest sample, false if it's
+// unknown, e.g. before the second sample.
+func (a *ackRates) get(buffer string) (float64, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	rate, ok := a.rates[buffer]
+	return rate, ok
+}
+
+// sampleAckRates samples the ack counters of the pods of the vertices reading from the buffers at now.
+func (is *isbSvcQueryService) sampleAckRates(ctx context.Context, now time.Time) {
+	log := logging.FromContext(ctx)
+	acks := make(map[string]map[string]float64)
+	for _, v := range is.pipeline.Spec.Vertices {
+		if len(is.pipeline.GetFromEdges(v.Name)) == 0 {
+			continue
+		}
+		counts, err := is.ackRates.scrape(ctx, is.pipeline, v.Name)
+		if err != nil {
+			log.Warnw("Failed to find the pods of the vertex for the ack rates", zap.String("vertex", v.Name), zap.Error(err))
+			continue
+		}
+		for buffer, c := range counts {
+			acks[buffer] = c
+		}
+	}
+	is.ackRates.record(now, acks)
+}
+
+// RecordAckRates samples the ack rates of the buffers periodically until the context is done.
+func (is *isbSvcQueryService) RecordAckRates(ctx context.Context) {
+	ticker := time.NewTicker(ackRateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			sctx, cancel := context.WithTimeout(ctx, ackRateInterval)
+			is.sampleAckRates(sctx, now)
+			cancel()
+		}
+	}
+}
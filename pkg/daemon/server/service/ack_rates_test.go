@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isbsvc"
+)
+
+type emptyBuffersISBService struct {
+	isbsvc.ISBService
+}
+
+func (emptyBuffersISBService) GetBufferInfo(_ context.Context, buffer string) (*isbsvc.BufferInfo, error) {
+	return &isbsvc.BufferInfo{Name: buffer}, nil
+}
+
+func TestParseAckCounts(t *testing.T) {
+	counts, err := parseAckCounts([]byte(`# HELP forwarder_ack_total Total number of Messages Acknowledged
+# TYPE forwarder_ack_total counter
+forwarder_ack_total{buffer="b1",pipeline="pl",vertex="p1"} 8
+forwarder_ack_total{buffer="b2",pipeline="pl",vertex="p1"} 5
+forwarder_ack_total{buffer="b3",pipeline="pl",vertex="p2"} 4
+forwarder_ack_total{buffer="b3,b4",pipeline="pl",vertex="p3"} 7
+forwarder_ack_total{buffer="b4",pipeline="pl",vertex="p2"} 3
+# HELP forwarder_read_total Total number of Messages Read
+# TYPE forwarder_read_total counter
+forwarder_read_total{buffer="b1",pipeline="pl",vertex="p1"} 10
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"b1": 8, "b2": 5, "b3": 4, "b4": 3}, counts)
+
+	_, err = parseAckCounts([]byte("invalid metrics{"))
+	assert.Error(t, err)
+}
+
+func TestAckRatesRecord(t *testing.T) {
+	a := newAckRates()
+	now := time.Unix(100, 0)
+	a.record(now, map[string]map[string]float64{"b1": {"a": 100, "b": 50}, "b2": {"c": 10}})
+	_, ok := a.get("b1")
+	assert.False(t, ok)
+
+	now = now.Add(10 * time.Second)
+	// pod "a" restarted, and pod "c" failed to respond
+	a.record(now, map[string]map[string]float64{"b1": {"a": 20, "b": 130}})
+	rate, ok := a.get("b1")
+	assert.True(t, ok)
+	assert.Equal(t, float64(10), rate)
+	rate, ok = a.get("b2")
+	assert.True(t, ok)
+	assert.Equal(t, float64(0), rate)
+
+	now = now.Add(10 * time.Second)
+	a.record(now, map[string]map[string]float64{"b2": {"c": 60}})
+	rate, _ = a.get("b2")
+	assert.Equal(t, float64(5), rate)
+	rate, _ = a.get("b1")
+	assert.Equal(t, float64(0), rate)
+}
+
+func TestListBuffersAckRate(t *testing.T) {
+	pl := &v1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl"},
+		Spec: v1alpha1.PipelineSpec{
+			Vertices: []v1alpha1.AbstractVertex{{Name: "in"}, {Name: "p1"}},
+			Edges:    []v1alpha1.Edge{{From: "in", To: "p1"}},
+		},
+	}
+	buffer := v1alpha1.GenerateBufferName(pl.Namespace, pl.Name, "in", "p1")
+	ackCount := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ackCount += 50
+		_, _ = fmt.Fprintf(w, "# TYPE forwarder_ack_total counter\nforwarder_ack_total{buffer=%q,pipeline=\"pl\",vertex=\"p1\"} %d\n", buffer, ackCount)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+
+	is := NewISBSvcQueryService(emptyBuffersISBService{}, pl)
+	is.ackRates.port, _ = strconv.Atoi(port)
+	is.ackRates.client = server.Client()
+	var lookedUp []string
+	is.ackRates.lookupHost = func(_ context.Context, h string) ([]string, error) {
+		lookedUp = append(lookedUp, h)
+		return []string{host}, nil
+	}
+	now := time.Now()
+	is.sampleAckRates(context.Background(), now)
+	is.sampleAckRates(context.Background(), now.Add(10*time.Second))
+	// The source vertex reads from no buffer
+	assert.Equal(t, []string{"pl-p1-headless.ns.svc", "pl-p1-headless.ns.svc"}, lookedUp)
+
+	resp, err := is.ListBuffers(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Buffers, 1)
+	assert.Equal(t, float64(5), resp.Buffers[0].GetAckRate())
+}
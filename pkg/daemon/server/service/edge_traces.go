@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+// collectTraces returns the records of the buffer from all the pods of the vertex, as well as the addresses of the
+// pods failed to respond.
+func (pc *podClient) collectTraces(ctx context.Context, pl *v1alpha1.Pipeline, vertex, buffer string) ([]tracing.Record, []string, error) {
+	addrs, err := pc.lookupPods(ctx, pl, vertex)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		lock    sync.Mutex
+		wg      sync.WaitGroup
+		records []tracing.Record
+		failed  []string
+	)
+	for _, addr := range addrs {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			rs, err := pc.fetchTraces(ctx, addr, buffer)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				logging.FromContext(ctx).Warnw("Failed to get the traces from the pod", zap.String("address", addr), zap.Error(err))
+				failed = append(failed, addr)
+				return
+			}
+			records = append(records, rs...)
+		}(addr)
+	}
+	wg.Wait()
+	sort.Strings(failed)
+	return records, failed, nil
+}
+
+func (pc *podClient) fetchTraces(ctx context.Context, addr, buffer string) ([]tracing.Record, error) {
+	body, err := pc.get(ctx, addr, v1alpha1.VertexTracesPath, url.Values{"buffer": []string{buffer}})
+	if err != nil {
+		return nil, err
+	}
+	var records []tracing.Record
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode the traces, %w", err)
+	}
+	return records, nil
+}
+
+// ListEdgeTraces is used to obtain the sampled messages written to the buffer of a traced edge
+func (is *isbSvcQueryService) ListEdgeTraces(ctx context.Context, req *daemon.ListEdgeTracesRequest) (*daemon.ListEdgeTracesResponse, error) {
+	var edge *v1alpha1.Edge
+	for i, e := range is.pipeline.Spec.Edges {
+		if e.From == req.GetFromVertex() && e.To == req.GetToVertex() {
+			edge = &is.pipeline.Spec.Edges[i]
+			break
+		}
+	}
+	if edge == nil {
+		return nil, fmt.Errorf("edge from %q to %q not found from the pipeline", req.GetFromVertex(), req.GetToVertex())
+	}
+	if edge.Trace == nil {
+		return nil, fmt.Errorf("edge from %q to %q is not traced", edge.From, edge.To)
+	}
+	buffer := v1alpha1.GenerateBufferName(is.pipeline.Namespace, is.pipeline.Name, edge.From, edge.To)
+	records, failed, err := is.pods.collectTraces(ctx, is.pipeline, edge.From, buffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the pods of vertex %q, %w", edge.From, err)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].WriteTime.After(records[j].WriteTime) })
+	resp := &daemon.ListEdgeTracesResponse{FailedPods: failed}
+	for _, r := range records {
+		m := &daemon.TracedMessage{
+			Pod:         pointer.String(r.Pod),
+			WriteTime:   pointer.Int64(r.WriteTime.UnixNano()),
+			Id:          pointer.String(r.ID),
+			EventTime:   pointer.Int64(0),
+			Metadata:    r.Metadata,
+			PayloadSize: pointer.Int64(int64(r.PayloadSize)),
+			Payload:     r.Payload,
+		}
+		if !r.EventTime.IsZero() {
+			m.EventTime = pointer.Int64(r.EventTime.UnixNano())
+		}
+		if r.Key != "" {
+			m.Key = pointer.String(r.Key)
+		}
+		if r.ContentEncoding != "" {
+			m.ContentEncoding = pointer.String(r.ContentEncoding)
+		}
+		if r.Truncated {
+			m.Truncated = pointer.Bool(true)
+		}
+		resp.Messages = append(resp.Messages, m)
+	}
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
+)
+
+func TestListEdgeTraces(t *testing.T) {
+	pl := &v1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl"},
+		Spec: v1alpha1.PipelineSpec{
+			Vertices: []v1alpha1.AbstractVertex{{Name: "in"}, {Name: "p1"}, {Name: "out"}},
+			Edges: []v1alpha1.Edge{
+				{From: "in", To: "p1", Trace: &v1alpha1.EdgeTrace{SampleRate: "1"}},
+				{From: "p1", To: "out"},
+			},
+		},
+	}
+	buffer := v1alpha1.GenerateBufferName("ns", "pl", "in", "p1")
+	recorder := tracing.NewRecorder("pl-in-0", buffer, v1alpha1.EdgeTrace{SampleRate: "1", CapturePayload: true})
+	recorder.Record([]isb.Message{{Header: isb.Header{ID: "1", Key: []byte("k")}, Body: isb.Body{Payload: []byte("hello")}}})
+	time.Sleep(time.Millisecond)
+	recorder.Record([]isb.Message{{Header: isb.Header{ID: "2", PaneInfo: isb.PaneInfo{EventTime: time.Unix(1, 0)}}}})
+	server := httptest.NewTLSServer(tracing.Recorders{buffer: recorder})
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+
+	is := NewISBSvcQueryService(nil, pl)
+	is.pods.client = server.Client()
+	is.pods.port, _ = strconv.Atoi(port)
+	var lookedUp string
+	is.pods.lookupHost = func(_ context.Context, h string) ([]string, error) {
+		lookedUp = h
+		// the second pod is not reachable
+		return []string{host, "127.0.0.2"}, nil
+	}
+	req := &daemon.ListEdgeTracesRequest{Pipeline: pointer.String("pl"), FromVertex: pointer.String("in"), ToVertex: pointer.String("p1")}
+	resp, err := is.ListEdgeTraces(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "pl-in-headless.ns.svc", lookedUp)
+	assert.Equal(t, []string{"127.0.0.2"}, resp.GetFailedPods())
+	assert.Len(t, resp.GetMessages(), 2)
+	// the latest first
+	m := resp.GetMessages()[0]
+	assert.Equal(t, "2", m.GetId())
+	assert.Equal(t, time.Unix(1, 0).UnixNano(), m.GetEventTime())
+	assert.Nil(t, m.Key)
+	m = resp.GetMessages()[1]
+	assert.Equal(t, "1", m.GetId())
+	assert.Equal(t, "pl-in-0", m.GetPod())
+	assert.Equal(t, "k", m.GetKey())
+	assert.Equal(t, int64(0), m.GetEventTime())
+	assert.Equal(t, []byte("hello"), m.GetPayload())
+	assert.Equal(t, int64(5), m.GetPayloadSize())
+
+	req.FromVertex, req.ToVertex = pointer.String("p1"), pointer.String("out")
+	_, err = is.ListEdgeTraces(context.Background(), req)
+	assert.EqualError(t, err, `edge from "p1" to "out" is not traced`)
+	req.ToVertex = pointer.String("in")
+	_, err = is.ListEdgeTraces(context.Background(), req)
+	assert.Error(t, err)
+
+	is.pods.lookupHost = func(context.Context, string) ([]string, error) { return nil, fmt.Errorf("no such host") }
+	req.FromVertex, req.ToVertex = pointer.String("in"), pointer.String("p1")
+	_, err = is.ListEdgeTraces(context.Background(), req)
+	assert.Error(t, err)
+}
@@ -4,23 +4,51 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow"
 	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
 	"github.com/numaproj/numaflow/pkg/isbsvc"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
-	"k8s.io/utils/pointer"
 )
 
 type isbSvcQueryService struct {
 	client   isbsvc.ISBService
 	pipeline *v1alpha1.Pipeline
+	ackRates *ackRates
+	pods     *podClient
+	history  *metricsHistory
+	replays  *replays
+	// watermarks is the watermark KV store, nil if the ISB Service does not have one
+	watermarks watermarkStore
+}
+
+// QueryServiceOption sets an option of the query service.
+type QueryServiceOption func(*isbSvcQueryService)
+
+// WithWatermarkKV reads the watermarks of the buffers from the JetStream KV store.
+func WithWatermarkKV(js nats.JetStreamContext) QueryServiceOption {
+	return func(is *isbSvcQueryService) {
+		is.watermarks = newKVWatermarkStore(js)
+	}
 }
 
-func NewISBSvcQueryService(client isbsvc.ISBService, pipeline *v1alpha1.Pipeline) *isbSvcQueryService {
-	return &isbSvcQueryService{
+func NewISBSvcQueryService(client isbsvc.ISBService, pipeline *v1alpha1.Pipeline, opts ...QueryServiceOption) *isbSvcQueryService {
+	is := &isbSvcQueryService{
 		client:   client,
 		pipeline: pipeline,
+		ackRates: newAckRates(),
+		pods:     newPodClient(),
+		history:  newMetricsHistory(pipeline),
+		replays:  newReplays(client, pipeline),
+	}
+	for _, opt := range opts {
+		opt(is)
 	}
+	return is
 }
 
 // ListBuffers is used to obtain the all the buffers information of a pipeline
@@ -57,6 +85,9 @@ func (is *isbSvcQueryService) ListBuffers(ctx context.Context, req *daemon.ListB
 			BufferUsage:      &usage,
 			IsFull:           pointer.Bool(usage >= bufferUsageLimit),
 		}
+		if rate, ok := is.ackRates.get(buffer); ok {
+			b.AckRate = pointer.Float64(rate)
+		}
 		buffers = append(buffers, b)
 	}
 	resp.Buffers = buffers
@@ -91,11 +122,23 @@ func (is *isbSvcQueryService) GetBuffer(ctx context.Context, req *daemon.GetBuff
 		BufferUsage:      &usage,
 		IsFull:           pointer.Bool(usage >= bufferUsageLimit),
 	}
+	if rate, ok := is.ackRates.get(*req.Buffer); ok {
+		b.AckRate = pointer.Float64(rate)
+	}
 	resp := new(daemon.GetBufferResponse)
 	resp.Buffer = b
 	return resp, nil
 }
 
+// GetServerInfo is used by the clients to negotiate the API version and features supported by the daemon server
+func (is *isbSvcQueryService) GetServerInfo(ctx context.Context, _ *emptypb.Empty) (*daemon.ServerInfo, error) {
+	return &daemon.ServerInfo{
+		Version:    pointer.String(numaflow.GetVersion().String()),
+		ApiVersion: pointer.Int32(daemon.APIVersion),
+		Features:   daemon.SupportedFeatures(),
+	}, nil
+}
+
 func getVertexLimits(pl *v1alpha1.Pipeline, v *v1alpha1.AbstractVertex) (bufferLength int64, bufferUsageLimit float64) {
 	bufferLength = int64(v1alpha1.DefaultBufferLength)
 	bufferUsageLimit = v1alpha1.DefaultBufferUsageLimit
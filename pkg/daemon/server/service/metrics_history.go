@@ -0,0 +1,337 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	// historyInterval is the interval of sampling the metrics of the vertices
+	historyInterval = 10 * time.Second
+	// historyFineRetention is how long the samples are kept before they are downsampled
+	historyFineRetention = time.Hour
+	// historyCoarseInterval is the interval of the downsampled samples
+	historyCoarseInterval = time.Minute
+)
+
+// The metrics of the vertex pods the history is sampled from.
+const (
+	metricForwarderReadTotal = "forwarder_read_total"
+	metricReduceReadTotal    = "reduce_read_total"
+	metricReduceWatermark    = "reduce_watermark"
+)
+
+type vertexSample struct {
+	time time.Time
+	// rate is the number of the messages read per second
+	rate         float64
+	pendingCount int64
+	// watermark in Unix milliseconds, 0 if unknown
+	watermark int64
+}
+
+// downsample returns the sample of the interval starting at start, with the average rate, the max pending count and
+// the latest watermark of the samples.
+func downsample(start time.Time, samples []vertexSample) vertexSample {
+	result := vertexSample{time: start}
+	for _, s := range samples {
+		result.rate += s.rate
+		if s.pendingCount > result.pendingCount {
+			result.pendingCount = s.pendingCount
+		}
+		if s.watermark > result.watermark {
+			result.watermark = s.watermark
+		}
+	}
+	result.rate /= float64(len(samples))
+	return result
+}
+
+// sampleRing keeps the latest samples up to its capacity.
+type sampleRing struct {
+	samples []vertexSample
+	next    int
+	full    bool
+}
+
+func newSampleRing(capacity int) *sampleRing {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &sampleRing{samples: make([]vertexSample, capacity)}
+}
+
+func (r *sampleRing) push(s vertexSample) {
+	r.samples[r.next] = s
+	r.next++
+	if r.next == len(r.samples) {
+		r.next, r.full = 0, true
+	}
+}
+
+// list returns the samples, the oldest first.
+func (r *sampleRing) list() []vertexSample {
+	if !r.full {
+		return append([]vertexSample(nil), r.samples[:r.next]...)
+	}
+	return append(append([]vertexSample(nil), r.samples[r.next:]...), r.samples[:r.next]...)
+}
+
+// vertexHistory keeps the samples of the latest hour, and the older ones downsampled to one per minute.
+type vertexHistory struct {
+	fine   *sampleRing
+	coarse *sampleRing
+	// current are the samples of the current minute, which are downsampled once the minute is over
+	current []vertexSample
+}
+
+func newVertexHistory(retention time.Duration) *vertexHistory {
+	fineRetention := historyFineRetention
+	if retention < fineRetention {
+		fineRetention = retention
+	}
+	return &vertexHistory{
+		fine:   newSampleRing(int(fineRetention / historyInterval)),
+		coarse: newSampleRing(int(retention / historyCoarseInterval)),
+	}
+}
+
+func (h *vertexHistory) add(s vertexSample) {
+	h.fine.push(s)
+	if len(h.current) > 0 {
+		if start := h.current[0].time.Truncate(historyCoarseInterval); !s.time.Truncate(historyCoarseInterval).Equal(start) {
+			h.coarse.push(downsample(start, h.current))
+			h.current = h.current[:0]
+		}
+	}
+	h.current = append(h.current, s)
+}
+
+// samples returns the downsampled samples of the minutes ended before the fine ones, followed by the fine ones.
+func (h *vertexHistory) samples() []vertexSample {
+	fine := h.fine.list()
+	var result []vertexSample
+	for _, s := range h.coarse.list() {
+		if len(fine) == 0 || !s.time.Add(historyCoarseInterval).After(fine[0].time) {
+			result = append(result, s)
+		}
+	}
+	return append(result, fine...)
+}
+
+// metricsHistory samples the processing rates, the pending counts and the watermarks of the vertices periodically,
+// so that their trends can be looked up after an incident without a Prometheus.
+type metricsHistory struct {
+	lock     sync.RWMutex
+	vertices map[string]*vertexHistory
+	// readCounts are the read counters of the pods sampled last time, keyed by the vertices and then the pod addresses
+	readCounts  map[string]map[string]float64
+	lastSampled time.Time
+}
+
+func newMetricsHistory(pl *v1alpha1.Pipeline) *metricsHistory {
+	h := &metricsHistory{
+		vertices:   make(map[string]*vertexHistory),
+		readCounts: make(map[string]map[string]float64),
+	}
+	for _, v := range pl.Spec.Vertices {
+		h.vertices[v.Name] = newVertexHistory(pl.Spec.Metrics.GetHistoryRetention())
+	}
+	return h
+}
+
+// podMetrics are the metrics scraped from a pod.
+type podMetrics struct {
+	readCount float64
+	// watermark in Unix milliseconds, 0 if not reported
+	watermark int64
+}
+
+func parsePodMetrics(body []byte) (podMetrics, error) {
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return podMetrics{}, fmt.Errorf("failed to parse the metrics, %w", err)
+	}
+	var result podMetrics
+	for _, name := range []string{metricForwarderReadTotal, metricReduceReadTotal} {
+		if f := families[name]; f != nil {
+			for _, m := range f.GetMetric() {
+				result.readCount += m.GetCounter().GetValue()
+			}
+		}
+	}
+	if f := families[metricReduceWatermark]; f != nil && f.GetType() == dto.MetricType_GAUGE {
+		for _, m := range f.GetMetric() {
+			if wm := int64(m.GetGauge().GetValue()); wm > result.watermark {
+				result.watermark = wm
+			}
+		}
+	}
+	return result, nil
+}
+
+// scrapeVertex returns the metrics of the pods of the vertex keyed by the addresses, the ones failed to respond are
+// left out.
+func (pc *podClient) scrapeVertex(ctx context.Context, pl *v1alpha1.Pipeline, vertex string) (map[string]podMetrics, error) {
+	addrs, err := pc.lookupPods(ctx, pl, vertex)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		lock   sync.Mutex
+		wg     sync.WaitGroup
+		result = make(map[string]podMetrics, len(addrs))
+	)
+	for _, addr := range addrs {
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+			body, err := pc.get(ctx, addr, "/metrics", nil)
+			if err == nil {
+				var m podMetrics
+				if m, err = parsePodMetrics(body); err == nil {
+					lock.Lock()
+					result[addr] = m
+					lock.Unlock()
+					return
+				}
+			}
+			logging.FromContext(ctx).Debugw("Failed to get the metrics from the pod", zap.String("vertex", vertex), zap.String("address", addr), zap.Error(err))
+		}(addr)
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// record adds the samples of the vertices at now, calculating the rates from the read counters of the pods. A pod
+// failed to respond keeps its last counter, so that its messages are counted in the next sample.
+func (h *metricsHistory) record(now time.Time, pods map[string]map[string]podMetrics, pending map[string]int64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	elapsed := now.Sub(h.lastSampled).Seconds()
+	for vertex, history := range h.vertices {
+		last := h.readCounts[vertex]
+		counts := make(map[string]float64, len(pods[vertex]))
+		s := vertexSample{time: now, pendingCount: pending[vertex]}
+		var read float64
+		for addr, m := range pods[vertex] {
+			counts[addr] = m.readCount
+			if prev, ok := last[addr]; ok {
+				if m.readCount >= prev {
+					read += m.readCount - prev
+				} else { // the pod restarted
+					read += m.readCount
+				}
+			}
+			if m.watermark > 0 && (s.watermark == 0 || m.watermark < s.watermark) {
+				s.watermark = m.watermark
+			}
+		}
+		for addr, prev := range last {
+			if _, ok := counts[addr]; !ok {
+				counts[addr] = prev
+			}
+		}
+		h.readCounts[vertex] = counts
+		if !h.lastSampled.IsZero() && elapsed > 0 {
+			s.rate = read / elapsed
+		}
+		history.add(s)
+	}
+	h.lastSampled = now
+}
+
+// get returns the samples of the vertex, the oldest first.
+func (h *metricsHistory) get(vertex string) ([]vertexSample, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	history, ok := h.vertices[vertex]
+	if !ok {
+		return nil, false
+	}
+	return history.samples(), true
+}
+
+// sampleMetrics samples the metrics of all the vertices at now.
+func (is *isbSvcQueryService) sampleMetrics(ctx context.Context, now time.Time) {
+	log := logging.FromContext(ctx)
+	pending := make(map[string]int64)
+	resp, err := is.ListBuffers(ctx, &daemon.ListBuffersRequest{Pipeline: &is.pipeline.Name})
+	if err != nil {
+		log.Warnw("Failed to query the buffers for the metrics history", zap.Error(err))
+	}
+	for _, b := range resp.GetBuffers() {
+		pending[b.GetToVertex()] += b.GetPendingCount()
+	}
+	var (
+		lock sync.Mutex
+		wg   sync.WaitGroup
+		pods = make(map[string]map[string]podMetrics)
+	)
+	for _, v := range is.pipeline.Spec.Vertices {
+		wg.Add(1)
+		go func(vertex string) {
+			defer wg.Done()
+			metrics, err := is.pods.scrapeVertex(ctx, is.pipeline, vertex)
+			if err != nil {
+				log.Warnw("Failed to find the pods of the vertex for the metrics history", zap.String("vertex", vertex), zap.Error(err))
+				return
+			}
+			lock.Lock()
+			pods[vertex] = metrics
+			lock.Unlock()
+		}(v.Name)
+	}
+	wg.Wait()
+	is.history.record(now, pods, pending)
+}
+
+// RecordMetricsHistory samples the metrics of the vertices periodically until the context is done.
+func (is *isbSvcQueryService) RecordMetricsHistory(ctx context.Context) {
+	ticker := time.NewTicker(historyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			sctx, cancel := context.WithTimeout(ctx, historyInterval)
+			is.sampleMetrics(sctx, now)
+			cancel()
+		}
+	}
+}
+
+// GetVertexMetricsHistory is used to obtain the history of the processing rates, pending counts and watermarks of a
+// vertex kept by the daemon server
+func (is *isbSvcQueryService) GetVertexMetricsHistory(ctx context.Context, req *daemon.GetVertexMetricsHistoryRequest) (*daemon.GetVertexMetricsHistoryResponse, error) {
+	samples, ok := is.history.get(req.GetVertex())
+	if !ok {
+		return nil, fmt.Errorf("vertex %q not found from the pipeline", req.GetVertex())
+	}
+	resp := &daemon.GetVertexMetricsHistoryResponse{}
+	for _, s := range samples {
+		m := &daemon.VertexMetricsSample{
+			Time:           pointer.Int64(s.time.UnixMilli()),
+			ProcessingRate: pointer.Float64(s.rate),
+			PendingCount:   pointer.Int64(s.pendingCount),
+		}
+		if s.watermark > 0 {
+			m.Watermark = pointer.Int64(s.watermark)
+		}
+		resp.Samples = append(resp.Samples, m)
+	}
+	return resp, nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/isbsvc"
+)
+
+// fakeISBService reports the same pending count for all the buffers.
+type fakeISBService struct {
+	isbsvc.ISBService
+	pending int64
+}
+
+func (f fakeISBService) GetBufferInfo(_ context.Context, buffer string) (*isbsvc.BufferInfo, error) {
+	return &isbsvc.BufferInfo{Name: buffer, PendingCount: f.pending, TotalMessages: f.pending}, nil
+}
+
+func TestVertexHistory(t *testing.T) {
+	t.Run("downsample", func(t *testing.T) {
+		// keeps 2 minutes of both the fine and the downsampled samples
+		h := newVertexHistory(2 * time.Minute)
+		start := time.Unix(0, 0)
+		for i := 0; i < 36; i++ { // 6 minutes
+			h.add(vertexSample{time: start.Add(time.Duration(i) * historyInterval), rate: float64(i), pendingCount: int64(i % 6), watermark: int64(i)})
+		}
+		samples := h.samples()
+		// the 4th minute downsampled, followed by the latest 2 minutes
+		assert.Len(t, samples, 13)
+		assert.Equal(t, start.Add(3*time.Minute), samples[0].time)
+		assert.Equal(t, start.Add(4*time.Minute), samples[1].time)
+		assert.Equal(t, float64(35), samples[12].rate)
+		coarse := h.coarse.list()
+		assert.Len(t, coarse, 2)
+		assert.Equal(t, start.Add(3*time.Minute), coarse[0].time)
+		assert.Equal(t, start.Add(4*time.Minute), coarse[1].time)
+		assert.Equal(t, float64(26.5), coarse[1].rate)
+		assert.Equal(t, int64(5), coarse[1].pendingCount)
+		assert.Equal(t, int64(29), coarse[1].watermark)
+	})
+
+	t.Run("older samples are downsampled", func(t *testing.T) {
+		h := newVertexHistory(6 * time.Hour)
+		start := time.Unix(0, 0)
+		n := int(90 * time.Minute / historyInterval)
+		for i := 0; i < n; i++ {
+			h.add(vertexSample{time: start.Add(time.Duration(i) * historyInterval), rate: 1})
+		}
+		samples := h.samples()
+		// 30 minutes downsampled, followed by the latest hour
+		assert.Len(t, samples, 30+int(historyFineRetention/historyInterval))
+		assert.Equal(t, start, samples[0].time)
+		assert.Equal(t, float64(1), samples[0].rate)
+		assert.Equal(t, start.Add(30*time.Minute), samples[30].time)
+	})
+}
+
+func TestParsePodMetrics(t *testing.T) {
+	m, err := parsePodMetrics([]byte(`# HELP forwarder_read_total Total number of Messages Read
+# TYPE forwarder_read_total counter
+forwarder_read_total{buffer="b1",pipeline="pl",vertex="p1"} 10
+forwarder_read_total{buffer="b2",pipeline="pl",vertex="p1"} 5
+# HELP reduce_watermark Watermark of the reduce vertex in Unix milliseconds, 0 before any message is read
+# TYPE reduce_watermark gauge
+reduce_watermark{pipeline="pl",vertex="p1"} 1.6e+12
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(15), m.readCount)
+	assert.Equal(t, int64(1.6e12), m.watermark)
+
+	_, err = parsePodMetrics([]byte("invalid metrics{"))
+	assert.Error(t, err)
+}
+
+func TestMetricsHistoryRecord(t *testing.T) {
+	pl := &v1alpha1.Pipeline{Spec: v1alpha1.PipelineSpec{Vertices: []v1alpha1.AbstractVertex{{Name: "in"}, {Name: "p1"}}}}
+	h := newMetricsHistory(pl)
+	now := time.Unix(100, 0)
+	h.record(now, map[string]map[string]podMetrics{"p1": {"a": {readCount: 100}, "b": {readCount: 50}}}, map[string]int64{"p1": 7})
+	now = now.Add(10 * time.Second)
+	// pod "a" restarted, and pod "b" failed to respond
+	h.record(now, map[string]map[string]podMetrics{"p1": {"a": {readCount: 20, watermark: 2000}, "c": {readCount: 5, watermark: 1000}}}, nil)
+	now = now.Add(10 * time.Second)
+	h.record(now, map[string]map[string]podMetrics{"p1": {"a": {readCount: 40, watermark: 3000}, "b": {readCount: 150}}}, nil)
+
+	samples, ok := h.get("p1")
+	assert.True(t, ok)
+	assert.Len(t, samples, 3)
+	assert.Equal(t, float64(0), samples[0].rate)
+	assert.Equal(t, int64(7), samples[0].pendingCount)
+	assert.Equal(t, float64(2), samples[1].rate)
+	assert.Equal(t, int64(1000), samples[1].watermark)
+	assert.Equal(t, float64(12), samples[2].rate)
+	assert.Equal(t, int64(3000), samples[2].watermark)
+
+	samples, ok = h.get("in")
+	assert.True(t, ok)
+	assert.Len(t, samples, 3)
+	_, ok = h.get("out")
+	assert.False(t, ok)
+}
+
+func TestGetVertexMetricsHistory(t *testing.T) {
+	pl := &v1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl"},
+		Spec: v1alpha1.PipelineSpec{
+			Vertices: []v1alpha1.AbstractVertex{{Name: "in"}, {Name: "p1"}},
+			Edges:    []v1alpha1.Edge{{From: "in", To: "p1"}},
+		},
+	}
+	readCount := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readCount += 100
+		_, _ = fmt.Fprintf(w, "# TYPE forwarder_read_total counter\nforwarder_read_total{buffer=\"b\",pipeline=\"pl\",vertex=\"p1\"} %d\n", readCount)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	host, port, _ := net.SplitHostPort(u.Host)
+
+	is := NewISBSvcQueryService(fakeISBService{pending: 3}, pl)
+	is.pods.client = server.Client()
+	is.pods.port, _ = strconv.Atoi(port)
+	is.pods.lookupHost = func(_ context.Context, h string) ([]string, error) {
+		if h == "pl-p1-headless.ns.svc" {
+			return []string{host}, nil
+		}
+		return nil, fmt.Errorf("no such host")
+	}
+	now := time.Now()
+	is.sampleMetrics(context.Background(), now)
+	is.sampleMetrics(context.Background(), now.Add(10*time.Second))
+
+	resp, err := is.GetVertexMetricsHistory(context.Background(), &daemon.GetVertexMetricsHistoryRequest{Pipeline: pointer.String("pl"), Vertex: pointer.String("p1")})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetSamples(), 2)
+	s := resp.GetSamples()[1]
+	assert.Equal(t, now.Add(10*time.Second).UnixMilli(), s.GetTime())
+	assert.Equal(t, float64(10), s.GetProcessingRate())
+	assert.Equal(t, int64(3), s.GetPendingCount())
+	assert.Nil(t, s.Watermark)
+
+	resp, err = is.GetVertexMetricsHistory(context.Background(), &daemon.GetVertexMetricsHistoryRequest{Pipeline: pointer.String("pl"), Vertex: pointer.String("in")})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetSamples(), 2)
+	assert.Equal(t, float64(0), resp.GetSamples()[1].GetProcessingRate())
+	assert.Equal(t, int64(0), resp.GetSamples()[1].GetPendingCount())
+
+	_, err = is.GetVertexMetricsHistory(context.Background(), &daemon.GetVertexMetricsHistoryRequest{Pipeline: pointer.String("pl"), Vertex: pointer.String("out")})
+	assert.Error(t, err)
+}
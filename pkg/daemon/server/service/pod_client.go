@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/httpclient"
+)
+
+// podClient queries the metrics servers of the pods of the vertices, e.g. for the traces of the edges and the metrics.
+type podClient struct {
+	// lookupHost returns the addresses of the pods behind the headless service of a vertex
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	// port of the metrics servers of the pods
+	port   int
+	client *http.Client
+}
+
+func newPodClient() *podClient {
+	return &podClient{
+		lookupHost: net.DefaultResolver.LookupHost,
+		port:       v1alpha1.VertexMetricsPort,
+		// The metrics servers of the pods use self-signed certificates
+		client: httpclient.NewClient(10*time.Second, httpclient.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})),
+	}
+}
+
+// lookupPods returns the addresses of the pods of the vertex, resolved with its headless service.
+func (pc *podClient) lookupPods(ctx context.Context, pl *v1alpha1.Pipeline, vertex string) ([]string, error) {
+	v := v1alpha1.Vertex{ObjectMeta: metav1.ObjectMeta{Namespace: pl.Namespace, Name: pl.Name + "-" + vertex}}
+	return pc.lookupHost(ctx, fmt.Sprintf("%s.%s.svc", v.GetHeadlessServiceName(), v.Namespace))
+}
+
+// get returns the response body of the path from the metrics server of the pod.
+func (pc *podClient) get(ctx context.Context, addr, path string, query url.Values) ([]byte, error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     net.JoinHostPort(addr, strconv.Itoa(pc.port)),
+		Path:     path,
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := pc.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
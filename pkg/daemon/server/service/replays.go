@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/isbsvc"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	ReplayPhaseRunning   = "Running"
+	ReplayPhasePaused    = "Paused"
+	ReplayPhaseSucceeded = "Succeeded"
+	ReplayPhaseFailed    = "Failed"
+	ReplayPhaseCanceled  = "Canceled"
+
+	// replayBatchSize is the number of the messages replayed in a batch, a replay is paused or canceled between batches
+	replayBatchSize = 500
+)
+
+// replays runs the replays of the buffers of the pipeline, and keeps them in memory for the lifetime of the daemon
+// server, so that their progress can be queried.
+type replays struct {
+	client    isbsvc.ISBService
+	pipeline  *v1alpha1.Pipeline
+	batchSize int
+	// now returns the current time, replaced in the tests
+	now func() time.Time
+
+	lock sync.Mutex
+	// ctx is the context of the daemon server the replays run in, nil until run is called
+	ctx        context.Context
+	operations map[string]*replayOperation
+	// order is the IDs of the replays in the order they are created
+	order    []string
+	watchers map[chan *daemon.Replay]struct{}
+}
+
+// replayOperation is a replay in progress or finished, its fields are protected by the lock of replays.
+type replayOperation struct {
+	replay *daemon.Replay
+	rng    isbsvc.ReplayRange
+	// next is the position of the next message to replay
+	next   string
+	cancel context.CancelFunc
+	// resumed is closed when a paused replay is resumed, nil if the replay is not paused
+	resumed chan struct{}
+	// running is how long the replay has been running, excluding the pauses, used to estimate the time to finish
+	running time.Duration
+}
+
+func newReplays(client isbsvc.ISBService, pipeline *v1alpha1.Pipeline) *replays {
+	return &replays{
+		client:     client,
+		pipeline:   pipeline,
+		batchSize:  replayBatchSize,
+		now:        time.Now,
+		operations: make(map[string]*replayOperation),
+		watchers:   make(map[chan *daemon.Replay]struct{}),
+	}
+}
+
+// run sets the context the replays run in, and cancels the running ones once it's done.
+func (r *replays) run(ctx context.Context) {
+	r.lock.Lock()
+	r.ctx = ctx
+	r.lock.Unlock()
+	<-ctx.Done()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, op := range r.operations {
+		op.cancel()
+	}
+}
+
+// create starts a replay of the buffer of the edge, or returns the existing one with the same ID.
+func (r *replays) create(ctx context.Context, id, fromVertex, toVertex string, startTime time.Time) (*daemon.Replay, error) {
+	found := false
+	for _, e := range r.pipeline.Spec.Edges {
+		if e.From == fromVertex && e.To == toVertex {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("edge from %q to %q not found from the pipeline", fromVertex, toVertex)
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	r.lock.Lock()
+	if op, ok := r.operations[id]; ok {
+		defer r.lock.Unlock()
+		return copyReplay(op.replay), nil
+	}
+	runCtx := r.ctx
+	r.lock.Unlock()
+	if runCtx == nil {
+		return nil, fmt.Errorf("the replays are not started yet")
+	}
+	buffer := v1alpha1.GenerateBufferName(r.pipeline.Namespace, r.pipeline.Name, fromVertex, toVertex)
+	rng, err := r.client.GetReplayRange(ctx, buffer, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the messages to replay of buffer %q, %w", buffer, err)
+	}
+	replay := &daemon.Replay{
+		Id:         pointer.String(id),
+		Pipeline:   pointer.String(r.pipeline.Name),
+		FromVertex: pointer.String(fromVertex),
+		ToVertex:   pointer.String(toVertex),
+		BufferName: pointer.String(buffer),
+		StartTime:  pointer.Int64(startTime.UnixMilli()),
+		Phase:      pointer.String(ReplayPhaseRunning),
+		Total:      pointer.Int64(0),
+		Replayed:   pointer.Int64(0),
+		Remaining:  pointer.Int64(0),
+		CreateTime: pointer.Int64(r.now().UnixMilli()),
+	}
+	opCtx, cancel := context.WithCancel(runCtx)
+	op := &replayOperation{replay: replay, cancel: cancel}
+	if rng != nil {
+		op.rng = *rng
+		op.next = rng.First
+		replay.Total = pointer.Int64(rng.Total)
+		replay.Remaining = pointer.Int64(rng.Total)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if existing, ok := r.operations[id]; ok { // created by a concurrent request
+		cancel()
+		return copyReplay(existing.replay), nil
+	}
+	r.operations[id] = op
+	r.order = append(r.order, id)
+	if rng == nil {
+		r.finish(op, ReplayPhaseSucceeded, "no message to replay")
+	} else {
+		go r.replay(opCtx, op)
+	}
+	return copyReplay(replay), nil
+}
+
+// replay replays the messages of the operation batch by batch until all of them are replayed, or it's canceled.
+func (r *replays) replay(ctx context.Context, op *replayOperation) {
+	log := logging.FromContext(ctx).With(zap.String("replay", op.replay.GetId()), zap.String("buffer", op.replay.GetBufferName()))
+	log.Infow("Replaying the messages", zap.Int64("total", op.rng.Total))
+	idSuffix := "-replay-" + op.replay.GetId()
+	for {
+		r.lock.Lock()
+		resumed, next := op.resumed, op.next
+		r.lock.Unlock()
+		if resumed != nil {
+			select {
+			case <-resumed:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			r.lock.Lock()
+			r.finish(op, ReplayPhaseCanceled, "")
+			r.lock.Unlock()
+			log.Infow("Replay is canceled", zap.Int64("replayed", op.replay.GetReplayed()))
+			return
+		}
+		start := r.now()
+		replayed, next, err := r.client.ReplayBuffer(ctx, op.replay.GetBufferName(), op.rng, next, r.batchSize, idSuffix)
+		r.lock.Lock()
+		op.running += r.now().Sub(start)
+		op.next = next
+		op.replay.Replayed = pointer.Int64(op.replay.GetReplayed() + int64(replayed))
+		remaining := op.replay.GetTotal() - op.replay.GetReplayed()
+		if remaining < 0 {
+			remaining = 0
+		}
+		op.replay.Remaining = pointer.Int64(remaining)
+		if op.replay.GetReplayed() > 0 {
+			eta := time.Duration(float64(op.running) / float64(op.replay.GetReplayed()) * float64(remaining))
+			op.replay.EtaSeconds = pointer.Int64(int64(eta.Round(time.Second).Seconds()))
+		}
+		switch {
+		case err != nil && ctx.Err() != nil:
+			r.finish(op, ReplayPhaseCanceled, "")
+			r.lock.Unlock()
+			log.Infow("Replay is canceled", zap.Int64("replayed", op.replay.GetReplayed()))
+			return
+		case err != nil:
+			r.finish(op, ReplayPhaseFailed, err.Error())
+			r.lock.Unlock()
+			log.Errorw("Replay failed", zap.Error(err))
+			return
+		case next == "":
+			// The messages removed from the buffer since the replay is created are skipped
+			op.replay.Remaining = pointer.Int64(0)
+			r.finish(op, ReplayPhaseSucceeded, "")
+			r.lock.Unlock()
+			log.Infow("Replay succeeded", zap.Int64("replayed", op.replay.GetReplayed()))
+			return
+		}
+		r.lock.Unlock()
+	}
+}
+
+// finish sets the final phase of the replay and notifies the watchers, it's called with the lock held.
+func (r *replays) finish(op *replayOperation, phase, message string) {
+	op.cancel()
+	op.replay.Phase = pointer.String(phase)
+	if message != "" {
+		op.replay.Message = pointer.String(message)
+	}
+	op.replay.EtaSeconds = nil
+	op.replay.FinishTime = pointer.Int64(r.now().UnixMilli())
+	r.notify(op.replay)
+}
+
+// notify sends the replay to the watchers, a watcher not keeping up misses it. It's called with the lock held.
+func (r *replays) notify(replay *daemon.Replay) {
+	for ch := range r.watchers {
+		select {
+		case ch <- copyReplay(replay):
+		default:
+		}
+	}
+}
+
+func (r *replays) get(id string) (*daemon.Replay, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	op, ok := r.operations[id]
+	if !ok {
+		return nil, fmt.Errorf("replay %q not found", id)
+	}
+	return copyReplay(op.replay), nil
+}
+
+func (r *replays) list() []*daemon.Replay {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	result := make([]*daemon.Replay, 0, len(r.order))
+	for _, id := range r.order {
+		result = append(result, copyReplay(r.operations[id].replay))
+	}
+	return result
+}
+
+// pause stops the replay after the batch in progress, until it's resumed or canceled.
+func (r *replays) pause(id string) (*daemon.Replay, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	op, ok := r.operations[id]
+	if !ok {
+		return nil, fmt.Errorf("replay %q not found", id)
+	}
+	switch op.replay.GetPhase() {
+	case ReplayPhasePaused:
+	case ReplayPhaseRunning:
+		op.resumed = make(chan struct{})
+		op.replay.Phase = pointer.String(ReplayPhasePaused)
+		op.replay.EtaSeconds = nil
+		r.notify(op.replay)
+	default:
+		return nil, fmt.Errorf("replay %q is %s, can not be paused", id, op.replay.GetPhase())
+	}
+	return copyReplay(op.replay), nil
+}
+
+func (r *replays) resume(id string) (*daemon.Replay, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	op, ok := r.operations[id]
+	if !ok {
+		return nil, fmt.Errorf("replay %q not found", id)
+	}
+	switch op.replay.GetPhase() {
+	case ReplayPhaseRunning:
+	case ReplayPhasePaused:
+		close(op.resumed)
+		op.resumed = nil
+		op.replay.Phase = pointer.String(ReplayPhaseRunning)
+		r.notify(op.replay)
+	default:
+		return nil, fmt.Errorf("replay %q is %s, can not be resumed", id, op.replay.GetPhase())
+	}
+	return copyReplay(op.replay), nil
+}
+
+// cancel stops the replay, the messages already replayed are not removed. The replay is Canceled once the batch in
+// progress is done.
+func (r *replays) cancel(id string) (*daemon.Replay, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	op, ok := r.operations[id]
+	if !ok {
+		return nil, fmt.Errorf("replay %q not found", id)
+	}
+	switch op.replay.GetPhase() {
+	case ReplayPhaseRunning, ReplayPhasePaused:
+		op.cancel()
+	case ReplayPhaseCanceled:
+	default:
+		return nil, fmt.Errorf("replay %q is %s, can not be canceled", id, op.replay.GetPhase())
+	}
+	return copyReplay(op.replay), nil
+}
+
+// watch returns the channel receiving the replays whenever their phases change, and the function to stop watching.
+func (r *replays) watch() (<-chan *daemon.Replay, func()) {
+	ch := make(chan *daemon.Replay, 64)
+	r.lock.Lock()
+	r.watchers[ch] = struct{}{}
+	r.lock.Unlock()
+	return ch, func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.watchers, ch)
+	}
+}
+
+func copyReplay(replay *daemon.Replay) *daemon.Replay {
+	c := *replay
+	return &c
+}
+
+// CreateReplay is used to replay the messages written to the buffer of an edge since a start time
+func (is *isbSvcQueryService) CreateReplay(ctx context.Context, req *daemon.CreateReplayRequest) (*daemon.ReplayResponse, error) {
+	replay, err := is.replays.create(ctx, req.GetId(), req.GetFromVertex(), req.GetToVertex(), time.UnixMilli(req.GetStartTime()))
+	if err != nil {
+		return nil, err
+	}
+	return &daemon.ReplayResponse{Replay: replay}, nil
+}
+
+// ListReplays is used to obtain the progress of the replays of the pipeline
+func (is *isbSvcQueryService) ListReplays(ctx context.Context, req *daemon.ListReplaysRequest) (*daemon.ListReplaysResponse, error) {
+	return &daemon.ListReplaysResponse{Replays: is.replays.list()}, nil
+}
+
+// GetReplay is used to obtain the progress of a replay
+func (is *isbSvcQueryService) GetReplay(ctx context.Context, req *daemon.ReplayRequest) (*daemon.ReplayResponse, error) {
+	replay, err := is.replays.get(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &daemon.ReplayResponse{Replay: replay}, nil
+}
+
+// PauseReplay is used to pause a running replay
+func (is *isbSvcQueryService) PauseReplay(ctx context.Context, req *daemon.ReplayRequest) (*daemon.ReplayResponse, error) {
+	replay, err := is.replays.pause(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &daemon.ReplayResponse{Replay: replay}, nil
+}
+
+// ResumeReplay is used to resume a paused replay
+func (is *isbSvcQueryService) ResumeReplay(ctx context.Context, req *daemon.ReplayRequest) (*daemon.ReplayResponse, error) {
+	replay, err := is.replays.resume(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &daemon.ReplayResponse{Replay: replay}, nil
+}
+
+// CancelReplay is used to cancel a running or paused replay
+func (is *isbSvcQueryService) CancelReplay(ctx context.Context, req *daemon.ReplayRequest) (*daemon.ReplayResponse, error) {
+	replay, err := is.replays.cancel(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &daemon.ReplayResponse{Replay: replay}, nil
+}
+
+// WatchReplays is used to receive the replays of the pipeline whenever their phases change
+func (is *isbSvcQueryService) WatchReplays(req *daemon.WatchReplaysRequest, stream daemon.DaemonService_WatchReplaysServer) error {
+	ch, stop := is.replays.watch()
+	defer stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case replay := <-ch:
+			if err := stream.Send(replay); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RunReplays runs the replays created through the API until the context is done
+func (is *isbSvcQueryService) RunReplays(ctx context.Context) {
+	is.replays.run(ctx)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/isbsvc"
+)
+
+// replayISBService has total messages in each buffer at positions 1 to total, a batch is replayed once it's released
+// through the batches channel.
+type replayISBService struct {
+	isbsvc.ISBService
+	total   int64
+	batches chan struct{}
+	// failAt fails the batch starting from the position, 0 never fails
+	failAt int64
+
+	lock     sync.Mutex
+	suffixes []string
+}
+
+func (f *replayISBService) GetReplayRange(_ context.Context, _ string, _ time.Time) (*isbsvc.ReplayRange, error) {
+	if f.total == 0 {
+		return nil, nil
+	}
+	return &isbsvc.ReplayRange{First: "1", Last: strconv.FormatInt(f.total, 10), Total: f.total}, nil
+}
+
+func (f *replayISBService) ReplayBuffer(ctx context.Context, _ string, r isbsvc.ReplayRange, from string, count int, idSuffix string) (int, string, error) {
+	select {
+	case <-f.batches:
+	case <-ctx.Done():
+		return 0, from, ctx.Err()
+	}
+	f.lock.Lock()
+	f.suffixes = append(f.suffixes, idSuffix)
+	f.lock.Unlock()
+	pos, _ := strconv.ParseInt(from, 10, 64)
+	if pos == f.failAt {
+		return 0, from, fmt.Errorf("failed")
+	}
+	n := int64(count)
+	if pos+n > f.total {
+		n = f.total - pos + 1
+	}
+	if pos+n > f.total {
+		return int(n), "", nil
+	}
+	return int(n), strconv.FormatInt(pos+n, 10), nil
+}
+
+func newTestReplays(t *testing.T, f *replayISBService) (*replays, context.CancelFunc) {
+	t.Helper()
+	pl := &v1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "pl", Namespace: "ns"},
+		Spec: v1alpha1.PipelineSpec{
+			Vertices: []v1alpha1.AbstractVertex{{Name: "in"}, {Name: "out"}},
+			Edges:    []v1alpha1.Edge{{From: "in", To: "out"}},
+		},
+	}
+	r := newReplays(f, pl)
+	r.batchSize = 10
+	ctx, cancel := context.WithCancel(context.Background())
+	go r.run(ctx)
+	assert.Eventually(t, func() bool {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		return r.ctx != nil
+	}, time.Second, time.Millisecond)
+	return r, cancel
+}
+
+// releaseBatch releases the batch in progress, if there is one.
+func releaseBatch(f *replayISBService) {
+	select {
+	case f.batches <- struct{}{}:
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// waitForPhase returns the replay once it's in the phase.
+func waitForPhase(t *testing.T, r *replays, id, phase string) *daemon.Replay {
+	t.Helper()
+	var replay *daemon.Replay
+	assert.Eventually(t, func() bool {
+		var err error
+		replay, err = r.get(id)
+		return err == nil && replay.GetPhase() == phase
+	}, 5*time.Second, time.Millisecond)
+	return replay
+}
+
+func TestReplays(t *testing.T) {
+	t.Run("replayed batch by batch", func(t *testing.T) {
+		f := &replayISBService{total: 25, batches: make(chan struct{})}
+		r, cancel := newTestReplays(t, f)
+		defer cancel()
+		ch, stop := r.watch()
+		defer stop()
+		startTime := time.UnixMilli(1636470000000)
+		replay, err := r.create(context.Background(), "r1", "in", "out", startTime)
+		assert.NoError(t, err)
+		assert.Equal(t, ReplayPhaseRunning, replay.GetPhase())
+		assert.Equal(t, v1alpha1.GenerateBufferName("ns", "pl", "in", "out"), replay.GetBufferName())
+		assert.Equal(t, int64(1636470000000), replay.GetStartTime())
+		assert.Equal(t, int64(25), replay.GetTotal())
+		assert.Equal(t, int64(25), replay.GetRemaining())
+		assert.Nil(t, replay.EtaSeconds)
+
+		f.batches <- struct{}{}
+		assert.Eventually(t, func() bool {
+			replay, _ = r.get("r1")
+			return replay.GetReplayed() == 10
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, int64(15), replay.GetRemaining())
+		assert.NotNil(t, replay.EtaSeconds)
+
+		// The same ID returns the existing replay
+		again, err := r.create(context.Background(), "r1", "in", "out", time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1636470000000), again.GetStartTime())
+		assert.Len(t, r.list(), 1)
+
+		f.batches <- struct{}{}
+		f.batches <- struct{}{}
+		replay = waitForPhase(t, r, "r1", ReplayPhaseSucceeded)
+		assert.Equal(t, int64(25), replay.GetReplayed())
+		assert.Equal(t, int64(0), replay.GetRemaining())
+		assert.Nil(t, replay.EtaSeconds)
+		assert.NotNil(t, replay.FinishTime)
+		assert.Equal(t, []string{"-replay-r1", "-replay-r1", "-replay-r1"}, f.suffixes)
+		event := <-ch
+		assert.Equal(t, "r1", event.GetId())
+		assert.Equal(t, ReplayPhaseSucceeded, event.GetPhase())
+	})
+
+	t.Run("pause, resume and cancel", func(t *testing.T) {
+		f := &replayISBService{total: 100, batches: make(chan struct{})}
+		r, cancel := newTestReplays(t, f)
+		defer cancel()
+		ch, stop := r.watch()
+		defer stop()
+		_, err := r.create(context.Background(), "r1", "in", "out", time.Now())
+		assert.NoError(t, err)
+		replay, err := r.pause("r1")
+		assert.NoError(t, err)
+		assert.Equal(t, ReplayPhasePaused, replay.GetPhase())
+		assert.Equal(t, ReplayPhasePaused, (<-ch).GetPhase())
+		// The batch in progress, if any, is done, and no more
+		releaseBatch(f)
+		select {
+		case f.batches <- struct{}{}:
+			t.Fatal("expected no batch replayed while paused")
+		case <-time.After(50 * time.Millisecond):
+		}
+		paused, _ := r.get("r1")
+		assert.LessOrEqual(t, paused.GetReplayed(), int64(10))
+		_, err = r.pause("r1")
+		assert.NoError(t, err)
+
+		replay, err = r.resume("r1")
+		assert.NoError(t, err)
+		assert.Equal(t, ReplayPhaseRunning, replay.GetPhase())
+		assert.Equal(t, ReplayPhaseRunning, (<-ch).GetPhase())
+		f.batches <- struct{}{}
+		assert.Eventually(t, func() bool {
+			replay, _ = r.get("r1")
+			return replay.GetReplayed() == paused.GetReplayed()+10
+		}, time.Second, time.Millisecond)
+
+		_, err = r.cancel("r1")
+		assert.NoError(t, err)
+		replay = waitForPhase(t, r, "r1", ReplayPhaseCanceled)
+		assert.Equal(t, paused.GetReplayed()+10, replay.GetReplayed())
+		assert.Equal(t, 100-replay.GetReplayed(), replay.GetRemaining())
+		assert.Equal(t, ReplayPhaseCanceled, (<-ch).GetPhase())
+		_, err = r.resume("r1")
+		assert.Error(t, err)
+		_, err = r.pause("r1")
+		assert.Error(t, err)
+	})
+
+	t.Run("canceled while paused", func(t *testing.T) {
+		f := &replayISBService{total: 100, batches: make(chan struct{})}
+		r, cancel := newTestReplays(t, f)
+		defer cancel()
+		_, err := r.create(context.Background(), "r1", "in", "out", time.Now())
+		assert.NoError(t, err)
+		_, err = r.pause("r1")
+		assert.NoError(t, err)
+		releaseBatch(f)
+		_, err = r.cancel("r1")
+		assert.NoError(t, err)
+		waitForPhase(t, r, "r1", ReplayPhaseCanceled)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		f := &replayISBService{total: 100, batches: make(chan struct{}), failAt: 11}
+		r, cancel := newTestReplays(t, f)
+		defer cancel()
+		_, err := r.create(context.Background(), "r1", "in", "out", time.Now())
+		assert.NoError(t, err)
+		f.batches <- struct{}{}
+		f.batches <- struct{}{}
+		replay := waitForPhase(t, r, "r1", ReplayPhaseFailed)
+		assert.Equal(t, "failed", replay.GetMessage())
+		assert.Equal(t, int64(10), replay.GetReplayed())
+		_, err = r.cancel("r1")
+		assert.Error(t, err)
+	})
+
+	t.Run("nothing to replay", func(t *testing.T) {
+		r, cancel := newTestReplays(t, &replayISBService{})
+		defer cancel()
+		replay, err := r.create(context.Background(), "", "in", "out", time.Now())
+		assert.NoError(t, err)
+		assert.NotEmpty(t, replay.GetId())
+		assert.Equal(t, ReplayPhaseSucceeded, replay.GetPhase())
+		assert.Equal(t, "no message to replay", replay.GetMessage())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r, cancel := newTestReplays(t, &replayISBService{})
+		defer cancel()
+		_, err := r.create(context.Background(), "", "out", "in", time.Now())
+		assert.Error(t, err)
+		_, err = r.get("abc")
+		assert.Error(t, err)
+		_, err = r.pause("abc")
+		assert.Error(t, err)
+		_, err = r.cancel("abc")
+		assert.Error(t, err)
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		r := newReplays(&replayISBService{}, &v1alpha1.Pipeline{Spec: v1alpha1.PipelineSpec{Edges: []v1alpha1.Edge{{From: "in", To: "out"}}}})
+		_, err := r.create(context.Background(), "", "in", "out", time.Now())
+		assert.Error(t, err)
+	})
+
+	t.Run("canceled with the daemon server", func(t *testing.T) {
+		f := &replayISBService{total: 100, batches: make(chan struct{})}
+		r, cancel := newTestReplays(t, f)
+		_, err := r.create(context.Background(), "r1", "in", "out", time.Now())
+		assert.NoError(t, err)
+		cancel()
+		waitForPhase(t, r, "r1", ReplayPhaseCanceled)
+	})
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/watermark/processor"
+)
+
+// watermarkHeartbeatTimeout is how long since the last heartbeat a processor is still considered active, it tolerates
+// a couple of missed heartbeats, which are sent every 5 seconds.
+const watermarkHeartbeatTimeout = 15 * time.Second
+
+// watermarkStore reads the watermarks published to the watermark KV store.
+type watermarkStore interface {
+	// getWatermarks returns the latest watermarks in Unix milliseconds published to the keyspace by the active
+	// processors, keyed by the processors.
+	getWatermarks(ctx context.Context, keyspace string) (map[string]int64, error)
+}
+
+// kvWatermarkStore reads the watermarks from the JetStream KV buckets of the keyspaces, which are the heartbeat bucket
+// "<keyspace>_PROCESSORS", and the offset timeline bucket "<keyspace>_OT" shared by the processors, or
+// "<keyspace>_OT_<processor>" of each processor.
+type kvWatermarkStore struct {
+	js  nats.JetStreamContext
+	now func() time.Time
+}
+
+func newKVWatermarkStore(js nats.JetStreamContext) *kvWatermarkStore {
+	return &kvWatermarkStore{js: js, now: time.Now}
+}
+
+func (s *kvWatermarkStore) getWatermarks(_ context.Context, keyspace string) (map[string]int64, error) {
+	heartbeats, err := s.readBucket(keyspace + "_PROCESSORS")
+	if err != nil {
+		return nil, err
+	}
+	active := activeProcessors(heartbeats, s.now())
+	result := make(map[string]int64, len(active))
+	if len(active) == 0 {
+		return result, nil
+	}
+	shared, err := s.readBucket(keyspace + "_OT")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range active {
+		otKeys := shared
+		entity := processor.NewProcessorEntity(p, keyspace)
+		if otKeys == nil {
+			entity = processor.NewProcessorEntity(p, keyspace, processor.WithSeparateOTBuckets(true))
+			if otKeys, err = s.readBucket(entity.GetBucketName()); err != nil {
+				return nil, err
+			}
+		}
+		if wm, ok := latestWatermark(entity, otKeys); ok {
+			result[p] = wm
+		}
+	}
+	return result, nil
+}
+
+// readBucket returns the keys and the values of the bucket, it's nil if the bucket does not exist.
+func (s *kvWatermarkStore) readBucket(bucket string) (map[string][]byte, error) {
+	kv, err := s.js.KeyValue(bucket)
+	if err != nil {
+		if errors.Is(err, nats.ErrBucketNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the bucket %q, %w", bucket, err)
+	}
+	keys, err := kv.Keys()
+	if err != nil && !errors.Is(err, nats.ErrNoKeysFound) {
+		return nil, fmt.Errorf("failed to list the keys of the bucket %q, %w", bucket, err)
+	}
+	result := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		entry, err := kv.Get(k)
+		if err != nil {
+			if errors.Is(err, nats.ErrKeyNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get the key %q of the bucket %q, %w", k, bucket, err)
+		}
+		result[k] = entry.Value()
+	}
+	return result, nil
+}
+
+// activeProcessors returns the processors whose last heartbeat, in Unix seconds, is within the timeout.
+func activeProcessors(heartbeats map[string][]byte, now time.Time) []string {
+	var result []string
+	for p, v := range heartbeats {
+		hb, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			continue
+		}
+		if now.Sub(time.Unix(hb, 0)) <= watermarkHeartbeatTimeout {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// latestWatermark returns the latest watermark in Unix milliseconds of the processor in the offset timeline keys.
+func latestWatermark(entity *processor.ProcessorEntity, otKeys map[string][]byte) (int64, bool) {
+	var (
+		latest int64
+		found  bool
+	)
+	for k := range otKeys {
+		epoch, skip, err := entity.ParseOTWatcherKey(k)
+		if skip || err != nil {
+			continue
+		}
+		if !found || epoch > latest {
+			latest, found = epoch, true
+		}
+	}
+	return latest * 1000, found
+}
+
+// GetPipelineWatermarks is used to obtain the current watermarks of the edges and the vertices of the pipeline.
+func (is *isbSvcQueryService) GetPipelineWatermarks(ctx context.Context, req *daemon.GetPipelineWatermarksRequest) (*daemon.GetPipelineWatermarksResponse, error) {
+	if req.GetPipeline() != is.pipeline.Name {
+		return nil, fmt.Errorf("pipeline %q not found", req.GetPipeline())
+	}
+	resp := &daemon.GetPipelineWatermarksResponse{}
+	inputs := make(map[string][]*daemon.EdgeWatermark)
+	outputs := make(map[string][]*daemon.EdgeWatermark)
+	// The dead letter queues and the reply buffers do not carry the watermarks
+	for _, e := range is.pipeline.Spec.Edges {
+		buffer := v1alpha1.GenerateBufferName(is.pipeline.Namespace, is.pipeline.Name, e.From, e.To)
+		edge := &daemon.EdgeWatermark{
+			FromVertex:       pointer.String(e.From),
+			ToVertex:         pointer.String(e.To),
+			BufferName:       pointer.String(buffer),
+			ActiveProcessors: pointer.Int32(0),
+		}
+		if is.watermarks != nil {
+			watermarks, err := is.watermarks.getWatermarks(ctx, buffer)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the watermarks of buffer %q, %w", buffer, err)
+			}
+			edge.ActiveProcessors = pointer.Int32(int32(len(watermarks)))
+			if wm, ok := minWatermark(watermarks); ok {
+				edge.Watermark = pointer.Int64(wm)
+			}
+		}
+		resp.Edges = append(resp.Edges, edge)
+		inputs[e.To] = append(inputs[e.To], edge)
+		outputs[e.From] = append(outputs[e.From], edge)
+	}
+	for _, v := range is.pipeline.Spec.Vertices {
+		edges := inputs[v.Name]
+		if v.Source != nil {
+			edges = outputs[v.Name]
+		}
+		vw := &daemon.VertexWatermark{Vertex: pointer.String(v.Name)}
+		if wm, ok := minEdgeWatermark(edges); ok {
+			vw.Watermark = pointer.Int64(wm)
+		} else if v.Reduce != nil {
+			if samples, ok := is.history.get(v.Name); ok && len(samples) > 0 && samples[len(samples)-1].watermark > 0 {
+				vw.Watermark = pointer.Int64(samples[len(samples)-1].watermark)
+			}
+		}
+		resp.Vertices = append(resp.Vertices, vw)
+	}
+	return resp, nil
+}
+
+func minWatermark(watermarks map[string]int64) (int64, bool) {
+	var (
+		result int64
+		found  bool
+	)
+	for _, wm := range watermarks {
+		if !found || wm < result {
+			result, found = wm, true
+		}
+	}
+	return result, found
+}
+
+// minEdgeWatermark returns the min watermark of the edges, it's unknown if any of them is unknown.
+func minEdgeWatermark(edges []*daemon.EdgeWatermark) (int64, bool) {
+	if len(edges) == 0 {
+		return 0, false
+	}
+	var result int64
+	for i, e := range edges {
+		if e.Watermark == nil {
+			return 0, false
+		}
+		if i == 0 || *e.Watermark < result {
+			result = *e.Watermark
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/watermark/processor"
+)
+
+type fakeWatermarkStore map[string]map[string]int64
+
+func (f fakeWatermarkStore) getWatermarks(_ context.Context, keyspace string) (map[string]int64, error) {
+	return f[keyspace], nil
+}
+
+func TestWatermarkKeys(t *testing.T) {
+	now := time.Unix(1000, 0)
+	active := activeProcessors(map[string][]byte{
+		"p0": []byte("995"),
+		"p1": []byte("980"),
+		"p2": []byte("invalid"),
+	}, now)
+	assert.Equal(t, []string{"p0"}, active)
+
+	otKeys := map[string][]byte{"p0_100": nil, "p0_200": nil, "p1_300": nil, "invalid": nil}
+	wm, ok := latestWatermark(processor.NewProcessorEntity("p0", "ks"), otKeys)
+	assert.True(t, ok)
+	assert.Equal(t, int64(200000), wm)
+	_, ok = latestWatermark(processor.NewProcessorEntity("p2", "ks"), otKeys)
+	assert.False(t, ok)
+	wm, ok = latestWatermark(processor.NewProcessorEntity("p0", "ks", processor.WithSeparateOTBuckets(true)), map[string][]byte{"100": nil, "50": nil})
+	assert.True(t, ok)
+	assert.Equal(t, int64(100000), wm)
+}
+
+func TestGetPipelineWatermarks(t *testing.T) {
+	pl := &v1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl"},
+		Spec: v1alpha1.PipelineSpec{
+			Vertices: []v1alpha1.AbstractVertex{{Name: "in", Source: &v1alpha1.Source{}}, {Name: "count", Reduce: &v1alpha1.Reduce{}}, {Name: "out", Sink: &v1alpha1.Sink{}}},
+			Edges:    []v1alpha1.Edge{{From: "in", To: "count"}, {From: "count", To: "out"}},
+		},
+	}
+	buffer := func(from, to string) string { return v1alpha1.GenerateBufferName("ns", "pl", from, to) }
+	vertexWatermarks := func(resp *daemon.GetPipelineWatermarksResponse) map[string]*int64 {
+		result := map[string]*int64{}
+		for _, v := range resp.GetVertices() {
+			result[v.GetVertex()] = v.Watermark
+		}
+		return result
+	}
+
+	t.Run("no watermark store", func(t *testing.T) {
+		is := NewISBSvcQueryService(fakeISBService{}, pl)
+		is.history.record(time.Now(), map[string]map[string]podMetrics{"count": {"10.0.0.1": {watermark: 5000}}}, nil)
+		resp, err := is.GetPipelineWatermarks(context.Background(), &daemon.GetPipelineWatermarksRequest{Pipeline: pointer.String("pl")})
+		assert.NoError(t, err)
+		assert.Len(t, resp.GetEdges(), 2)
+		assert.Nil(t, resp.GetEdges()[0].Watermark)
+		assert.Equal(t, int32(0), resp.GetEdges()[0].GetActiveProcessors())
+		wms := vertexWatermarks(resp)
+		assert.Nil(t, wms["in"])
+		assert.Equal(t, int64(5000), *wms["count"])
+		assert.Nil(t, wms["out"])
+	})
+
+	t.Run("watermark store", func(t *testing.T) {
+		is := NewISBSvcQueryService(fakeISBService{}, pl)
+		is.watermarks = fakeWatermarkStore{
+			buffer("in", "count"):  {"in-0": 2000, "in-1": 1000},
+			buffer("count", "out"): {"count-0": 500},
+		}
+		resp, err := is.GetPipelineWatermarks(context.Background(), &daemon.GetPipelineWatermarksRequest{Pipeline: pointer.String("pl")})
+		assert.NoError(t, err)
+		edges := resp.GetEdges()
+		sort.Slice(edges, func(i, j int) bool { return edges[i].GetFromVertex() > edges[j].GetFromVertex() })
+		assert.Equal(t, "in", edges[0].GetFromVertex())
+		assert.Equal(t, buffer("in", "count"), edges[0].GetBufferName())
+		assert.Equal(t, int64(1000), edges[0].GetWatermark())
+		assert.Equal(t, int32(2), edges[0].GetActiveProcessors())
+		assert.Equal(t, int64(500), edges[1].GetWatermark())
+		wms := vertexWatermarks(resp)
+		assert.Equal(t, int64(1000), *wms["in"])
+		assert.Equal(t, int64(1000), *wms["count"])
+		assert.Equal(t, int64(500), *wms["out"])
+	})
+
+	t.Run("unknown pipeline", func(t *testing.T) {
+		is := NewISBSvcQueryService(fakeISBService{}, pl)
+		_, err := is.GetPipelineWatermarks(context.Background(), &daemon.GetPipelineWatermarksRequest{Pipeline: pointer.String("other")})
+		assert.Error(t, err)
+		assert.Equal(t, fmt.Sprintf("pipeline %q not found", "other"), err.Error())
+	})
+}
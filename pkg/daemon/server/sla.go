@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/notifications"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	objectiveMaxPendingMessages = "maxPendingMessages"
+	objectiveMaxWatermarkDelay  = "maxWatermarkDelay"
+)
+
+// slaQuerier queries the states of the pipeline the SLA is checked against, it's implemented by the daemon service.
+type slaQuerier interface {
+	bufferLister
+	GetPipelineWatermarks(ctx context.Context, req *daemon.GetPipelineWatermarksRequest) (*daemon.GetPipelineWatermarksResponse, error)
+}
+
+// eventNotifier sends the SLA events, it's implemented by the notifier of the pipeline.
+type eventNotifier interface {
+	Notify(ctx context.Context, e notifications.Event) error
+}
+
+// objectiveState tracks whether an objective is violated, and since when the observations have been different from it.
+type objectiveState struct {
+	violated bool
+	since    time.Time
+}
+
+// slaChecker checks the objectives of the SLA periodically, an objective is violated once it's not met for the
+// duration of the SLA, and recovers once it's met again for the same duration, each of which is sent as an event.
+type slaChecker struct {
+	pipeline *v1alpha1.Pipeline
+	sla      v1alpha1.PipelineSLA
+	querier  slaQuerier
+	notifier eventNotifier
+	interval time.Duration
+	now      func() time.Time
+	states   map[string]*objectiveState
+}
+
+func newSLAChecker(pl *v1alpha1.Pipeline, querier slaQuerier, notifier eventNotifier) *slaChecker {
+	return &slaChecker{
+		pipeline: pl,
+		sla:      *pl.Spec.Notifications.SLA,
+		querier:  querier,
+		notifier: notifier,
+		interval: v1alpha1.DefaultSLACheckInterval,
+		now:      time.Now,
+		states:   make(map[string]*objectiveState),
+	}
+}
+
+// Run checks the SLA until the context is done.
+func (sc *slaChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(sc.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sc.check(ctx)
+		}
+	}
+}
+
+// check observes the objectives once, an objective which can not be queried is skipped.
+func (sc *slaChecker) check(ctx context.Context) {
+	log := logging.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, sc.interval)
+	defer cancel()
+	if sc.sla.MaxPendingMessages != nil {
+		resp, err := sc.querier.ListBuffers(ctx, &daemon.ListBuffersRequest{Pipeline: &sc.pipeline.Name})
+		if err != nil {
+			log.Warnw("Failed to query the buffers for the SLA", zap.Error(err))
+		} else {
+			var pending int64
+			for _, b := range resp.GetBuffers() {
+				pending += b.GetPendingCount() + b.GetAckPendingCount()
+			}
+			max := *sc.sla.MaxPendingMessages
+			sc.observe(ctx, objectiveMaxPendingMessages, pending > max, fmt.Sprint(pending), fmt.Sprint(max))
+		}
+	}
+	if sc.sla.MaxWatermarkDelay != nil {
+		resp, err := sc.querier.GetPipelineWatermarks(ctx, &daemon.GetPipelineWatermarksRequest{Pipeline: &sc.pipeline.Name})
+		if err != nil {
+			log.Warnw("Failed to query the watermarks for the SLA", zap.Error(err))
+		} else if delay, ok := sc.sinkWatermarkDelay(resp); ok {
+			max := sc.sla.MaxWatermarkDelay.Duration
+			sc.observe(ctx, objectiveMaxWatermarkDelay, delay > max, delay.String(), max.String())
+		}
+	}
+}
+
+// sinkWatermarkDelay returns the max delay of the watermarks of the sink vertices, the ones without a watermark are
+// skipped. It returns false if none of them has a watermark.
+func (sc *slaChecker) sinkWatermarkDelay(resp *daemon.GetPipelineWatermarksResponse) (time.Duration, bool) {
+	sinks := make(map[string]bool)
+	for _, v := range sc.pipeline.Spec.Vertices {
+		if v.Sink != nil {
+			sinks[v.Name] = true
+		}
+	}
+	var (
+		delay time.Duration
+		found bool
+	)
+	for _, v := range resp.GetVertices() {
+		if !sinks[v.GetVertex()] || v.Watermark == nil || v.GetWatermark() < 0 {
+			continue
+		}
+		d := sc.now().Sub(time.UnixMilli(v.GetWatermark())).Truncate(time.Second)
+		if !found || d > delay {
+			delay, found = d, true
+		}
+	}
+	return delay, found
+}
+
+// observe records an observation of the objective, and sends an event once it's violated or recovers.
+func (sc *slaChecker) observe(ctx context.Context, objective string, unmet bool, value, threshold string) {
+	s, ok := sc.states[objective]
+	if !ok {
+		s = &objectiveState{}
+		sc.states[objective] = s
+	}
+	if unmet == s.violated {
+		s.since = time.Time{}
+		return
+	}
+	now := sc.now()
+	if s.since.IsZero() {
+		s.since = now
+	}
+	if now.Sub(s.since) < sc.sla.GetFor() {
+		return
+	}
+	s.violated, s.since = unmet, time.Time{}
+	e := notifications.Event{
+		Type:      v1alpha1.NotificationEventSLARecovered,
+		Namespace: sc.pipeline.Namespace,
+		Pipeline:  sc.pipeline.Name,
+		Time:      now,
+		Message:   fmt.Sprintf("Pipeline %s/%s SLA %s recovered, %s is within %s", sc.pipeline.Namespace, sc.pipeline.Name, objective, value, threshold),
+		Objective: objective,
+		Value:     value,
+		Threshold: threshold,
+	}
+	if unmet {
+		e.Type = v1alpha1.NotificationEventSLAViolated
+		e.Message = fmt.Sprintf("Pipeline %s/%s SLA %s violated, %s is above %s", sc.pipeline.Namespace, sc.pipeline.Name, objective, value, threshold)
+	}
+	log := logging.FromContext(ctx)
+	log.Infow("SLA state changed", zap.String("objective", objective), zap.Bool("violated", unmet), zap.String("value", value))
+	if err := sc.notifier.Notify(ctx, e); err != nil {
+		log.Errorw("Failed to send the SLA notifications", zap.Error(err))
+	}
+}
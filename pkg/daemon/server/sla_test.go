@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/apis/proto/daemon"
+	"github.com/numaproj/numaflow/pkg/notifications"
+)
+
+type fakeSLAQuerier struct {
+	fakeBufferLister
+	watermarks []*daemon.VertexWatermark
+}
+
+func (f *fakeSLAQuerier) GetPipelineWatermarks(_ context.Context, _ *daemon.GetPipelineWatermarksRequest) (*daemon.GetPipelineWatermarksResponse, error) {
+	return &daemon.GetPipelineWatermarksResponse{Vertices: f.watermarks}, nil
+}
+
+type fakeNotifier struct {
+	events []notifications.Event
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, e notifications.Event) error {
+	f.events = append(f.events, e)
+	return nil
+}
+
+func testSLAChecker(sla v1alpha1.PipelineSLA) (*slaChecker, *fakeSLAQuerier, *fakeNotifier, *time.Time) {
+	pl := &v1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl"},
+		Spec: v1alpha1.PipelineSpec{
+			Vertices: []v1alpha1.AbstractVertex{
+				{Name: "in", Source: &v1alpha1.Source{}},
+				{Name: "out", Sink: &v1alpha1.Sink{}},
+			},
+			Notifications: &v1alpha1.PipelineNotifications{SLA: &sla},
+		},
+	}
+	querier := &fakeSLAQuerier{}
+	notifier := &fakeNotifier{}
+	now := time.Unix(1660000000, 0)
+	sc := newSLAChecker(pl, querier, notifier)
+	sc.now = func() time.Time { return now }
+	return sc, querier, notifier, &now
+}
+
+func TestSLAChecker_maxPendingMessages(t *testing.T) {
+	sc, querier, notifier, now := testSLAChecker(v1alpha1.PipelineSLA{MaxPendingMessages: pointer.Int64(100), For: &metav1.Duration{Duration: time.Minute}})
+	ctx := context.Background()
+	querier.buffers = []*daemon.BufferInfo{{PendingCount: pointer.Int64(90), AckPendingCount: pointer.Int64(20)}}
+	sc.check(ctx)
+	*now = now.Add(30 * time.Second)
+	sc.check(ctx)
+	assert.Empty(t, notifier.events)
+	// Violated once it has not been met for a minute
+	*now = now.Add(30 * time.Second)
+	sc.check(ctx)
+	require.Len(t, notifier.events, 1)
+	e := notifier.events[0]
+	assert.Equal(t, v1alpha1.NotificationEventSLAViolated, e.Type)
+	assert.Equal(t, objectiveMaxPendingMessages, e.Objective)
+	assert.Equal(t, "110", e.Value)
+	assert.Equal(t, "100", e.Threshold)
+	assert.Equal(t, "Pipeline ns/pl SLA maxPendingMessages violated, 110 is above 100", e.Message)
+	// Still violated, nothing sent
+	*now = now.Add(5 * time.Minute)
+	sc.check(ctx)
+	assert.Len(t, notifier.events, 1)
+
+	querier.buffers = []*daemon.BufferInfo{{PendingCount: pointer.Int64(10), AckPendingCount: pointer.Int64(0)}}
+	sc.check(ctx)
+	*now = now.Add(30 * time.Second)
+	// Violated again before recovering, the recovery starts over
+	querier.buffers[0].PendingCount = pointer.Int64(200)
+	sc.check(ctx)
+	querier.buffers[0].PendingCount = pointer.Int64(10)
+	sc.check(ctx)
+	*now = now.Add(30 * time.Second)
+	sc.check(ctx)
+	assert.Len(t, notifier.events, 1)
+	*now = now.Add(30 * time.Second)
+	sc.check(ctx)
+	require.Len(t, notifier.events, 2)
+	assert.Equal(t, v1alpha1.NotificationEventSLARecovered, notifier.events[1].Type)
+	assert.Equal(t, "10", notifier.events[1].Value)
+}
+
+func TestSLAChecker_maxWatermarkDelay(t *testing.T) {
+	sc, querier, notifier, now := testSLAChecker(v1alpha1.PipelineSLA{MaxWatermarkDelay: &metav1.Duration{Duration: time.Minute}, For: &metav1.Duration{}})
+	ctx := context.Background()
+	// No watermark of the sinks, not checked
+	querier.watermarks = []*daemon.VertexWatermark{{Vertex: pointer.String("in"), Watermark: pointer.Int64(0)}, {Vertex: pointer.String("out")}}
+	sc.check(ctx)
+	assert.Empty(t, notifier.events)
+	querier.watermarks[1].Watermark = pointer.Int64(now.Add(-2 * time.Minute).UnixMilli())
+	sc.check(ctx)
+	require.Len(t, notifier.events, 1)
+	assert.Equal(t, v1alpha1.NotificationEventSLAViolated, notifier.events[0].Type)
+	assert.Equal(t, "2m0s", notifier.events[0].Value)
+	assert.Equal(t, "1m0s", notifier.events[0].Threshold)
+	querier.watermarks[1].Watermark = pointer.Int64(now.Add(-10 * time.Second).UnixMilli())
+	sc.check(ctx)
+	require.Len(t, notifier.events, 2)
+	assert.Equal(t, v1alpha1.NotificationEventSLARecovered, notifier.events[1].Type)
+}
@@ -0,0 +1,370 @@
+/*
+Package doctor diagnoses a pipeline and the resources it depends on, and reports the problems found with the actions
+to take, it is used as a first-line support tool.
+*/
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/numaproj/numaflow"
+	plctrl "github.com/numaproj/numaflow/controllers/pipeline"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/client/clientset/versioned"
+)
+
+// Severity is the severity of a finding.
+type Severity string
+
+const (
+	SeverityOK      Severity = "OK"
+	SeverityWarning Severity = "WARN"
+	SeverityError   Severity = "ERROR"
+)
+
+// Finding is the result of a check.
+type Finding struct {
+	// Check is the name of the check, e.g. "ISB Service"
+	Check    string
+	Severity Severity
+	Message  string
+	// Suggestion is the action to take for a warning or an error
+	Suggestion string
+}
+
+// Doctor diagnoses the pipelines.
+type Doctor struct {
+	kubeClient kubernetes.Interface
+	client     versioned.Interface
+	// daemonGet gets a resource of the REST API of the daemon server of a pipeline
+	daemonGet func(ctx context.Context, pl *dfv1.Pipeline, path string) ([]byte, error)
+}
+
+// NewDoctor returns a Doctor. The daemon servers are reached through the service proxy of the API server, so that the
+// diagnosis also works outside the cluster.
+func NewDoctor(kubeClient kubernetes.Interface, client versioned.Interface) *Doctor {
+	d := &Doctor{kubeClient: kubeClient, client: client}
+	d.daemonGet = func(ctx context.Context, pl *dfv1.Pipeline, path string) ([]byte, error) {
+		return kubeClient.CoreV1().Services(pl.Namespace).ProxyGet("https", pl.GetDaemonServiceName(), strconv.Itoa(dfv1.DaemonServicePort), path, nil).DoRaw(ctx)
+	}
+	return d
+}
+
+// Diagnose checks the pipeline, and returns the findings in the order of the checks.
+func (d *Doctor) Diagnose(ctx context.Context, namespace, pipelineName string) []Finding {
+	var findings []Finding
+	findings = append(findings, d.checkCRDs()...)
+	pl, err := d.client.NumaflowV1alpha1().Pipelines(namespace).Get(ctx, pipelineName, metav1.GetOptions{})
+	if err != nil {
+		f := Finding{Check: "Pipeline", Severity: SeverityError, Message: fmt.Sprintf("Failed to get pipeline %q in namespace %q, %v", pipelineName, namespace, err)}
+		if apierrors.IsNotFound(err) {
+			f.Message = fmt.Sprintf("Pipeline %q is not found in namespace %q", pipelineName, namespace)
+			f.Suggestion = "Check the pipeline name and the namespace"
+		}
+		return append(findings, f)
+	}
+	findings = append(findings, checkPipeline(pl)...)
+	findings = append(findings, d.checkISBSvc(ctx, pl)...)
+	findings = append(findings, d.checkVertices(ctx, pl)...)
+	daemonFindings, reachable := d.checkDaemon(ctx, pl)
+	findings = append(findings, daemonFindings...)
+	if reachable {
+		findings = append(findings, d.checkBuffers(ctx, pl)...)
+	} else {
+		findings = append(findings, Finding{Check: "Buffers", Severity: SeverityWarning, Message: "The buffers are not checked since the daemon server is not reachable", Suggestion: "Fix the daemon server first"})
+	}
+	return findings
+}
+
+// checkCRDs checks if the API version of the CRDs used by this version of numaflow is served.
+func (d *Doctor) checkCRDs() []Finding {
+	gv := dfv1.SchemeGroupVersion.String()
+	suggestion := fmt.Sprintf("Install the CRDs of numaflow %s", numaflow.GetVersion().Version)
+	resources, err := d.kubeClient.Discovery().ServerResourcesForGroupVersion(gv)
+	if err != nil {
+		return []Finding{{Check: "CRDs", Severity: SeverityError, Message: fmt.Sprintf("API version %s is not served, %v", gv, err), Suggestion: suggestion}}
+	}
+	served := make(map[string]bool)
+	for _, r := range resources.APIResources {
+		served[r.Kind] = true
+	}
+	var missing []string
+	for _, kind := range []string{dfv1.PipelineGroupVersionKind.Kind, dfv1.VertexGroupVersionKind.Kind, dfv1.ISBGroupVersionKind.Kind} {
+		if !served[kind] {
+			missing = append(missing, kind)
+		}
+	}
+	if len(missing) > 0 {
+		return []Finding{{Check: "CRDs", Severity: SeverityError, Message: fmt.Sprintf("Kinds %v of API version %s are not served", missing, gv), Suggestion: suggestion}}
+	}
+	return []Finding{{Check: "CRDs", Severity: SeverityOK, Message: fmt.Sprintf("API version %s is served", gv)}}
+}
+
+// checkPipeline checks the spec and the status of the pipeline.
+func checkPipeline(pl *dfv1.Pipeline) []Finding {
+	var findings []Finding
+	if err := plctrl.ValidatePipeline(pl); err != nil {
+		findings = append(findings, Finding{Check: "Pipeline", Severity: SeverityError, Message: fmt.Sprintf("Invalid spec, %v", err), Suggestion: "Fix the pipeline spec"})
+	} else {
+		for _, w := range plctrl.GetPipelineWarnings(pl) {
+			findings = append(findings, Finding{Check: "Pipeline", Severity: SeverityWarning, Message: w})
+		}
+	}
+	switch pl.Status.Phase {
+	case dfv1.PipelinePhaseRunning:
+		findings = append(findings, Finding{Check: "Pipeline", Severity: SeverityOK, Message: "Phase is Running"})
+	case dfv1.PipelinePhaseFailed:
+		findings = append(findings, Finding{Check: "Pipeline", Severity: SeverityError, Message: fmt.Sprintf("Phase is Failed, %s", pl.Status.Message), Suggestion: "Check the events of the pipeline, and the logs of the controller"})
+	case dfv1.PipelinePhasePaused, dfv1.PipelinePhasePausing:
+		findings = append(findings, Finding{Check: "Pipeline", Severity: SeverityWarning, Message: fmt.Sprintf("Phase is %s, no message is processed", pl.Status.Phase), Suggestion: "Set spec.lifecycle.desiredPhase to Running to resume it"})
+	default:
+		findings = append(findings, Finding{Check: "Pipeline", Severity: SeverityWarning, Message: fmt.Sprintf("Phase is %q", pl.Status.Phase), Suggestion: "Wait for the controller to reconcile it, or check the logs of the controller"})
+	}
+	for _, c := range pl.Status.Conditions {
+		if c.Status != metav1.ConditionTrue {
+			findings = append(findings, Finding{Check: "Pipeline", Severity: SeverityWarning, Message: fmt.Sprintf("Condition %s is %s, %s %s", c.Type, c.Status, c.Reason, c.Message)})
+		}
+	}
+	return findings
+}
+
+// checkISBSvc checks the ISB Service used by the pipeline and its pods.
+func (d *Doctor) checkISBSvc(ctx context.Context, pl *dfv1.Pipeline) []Finding {
+	const check = "ISB Service"
+	name := pl.Spec.InterStepBufferServiceName
+	if name == "" {
+		name = dfv1.DefaultISBSvcName
+		if ns, err := d.kubeClient.CoreV1().Namespaces().Get(ctx, pl.Namespace, metav1.GetOptions{}); err == nil {
+			if x := ns.GetAnnotations()[dfv1.KeyDefaultISBSvcName]; x != "" {
+				name = x
+			}
+		}
+	}
+	isbSvc, err := d.client.NumaflowV1alpha1().InterStepBufferServices(pl.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("ISB Service %q is not found", name), Suggestion: fmt.Sprintf("Create ISB Service %q in namespace %q, or set spec.interStepBufferServiceName of the pipeline", name, pl.Namespace)}}
+		}
+		return []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Failed to get ISB Service %q, %v", name, err)}}
+	}
+	var findings []Finding
+	if isbSvc.Status.Phase != dfv1.ISBSvcPhaseRunning {
+		findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: fmt.Sprintf("ISB Service %q is %q, %s", name, isbSvc.Status.Phase, isbSvc.Status.Message), Suggestion: "Check the events of the ISB Service, and the logs of the controller"})
+	} else {
+		findings = append(findings, Finding{Check: check, Severity: SeverityOK, Message: fmt.Sprintf("ISB Service %q is Running", name)})
+	}
+	if err := plctrl.ValidatePipelineOnISBSvc(pl, isbSvc); err != nil {
+		findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Pipeline is not supported by ISB Service %q, %v", name, err), Suggestion: "Remove the unsupported settings from the pipeline spec"})
+	}
+	if isbSvc.Spec.Redis != nil && isbSvc.Spec.Redis.External != nil || isbSvc.Spec.Kafka != nil {
+		// no pods of an external redis or kafka
+		return findings
+	}
+	selector := labels.SelectorFromSet(map[string]string{dfv1.KeyISBSvcName: name, dfv1.KeyComponent: dfv1.ComponentISBSvc}).String()
+	return append(findings, d.checkPods(ctx, check, pl.Namespace, selector)...)
+}
+
+// checkVertices checks the vertex objects of the pipeline, their pods and limits.
+func (d *Doctor) checkVertices(ctx context.Context, pl *dfv1.Pipeline) []Finding {
+	selector := labels.SelectorFromSet(map[string]string{dfv1.KeyPipelineName: pl.Name}).String()
+	vertices, err := d.client.NumaflowV1alpha1().Vertices(pl.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return []Finding{{Check: "Vertices", Severity: SeverityError, Message: fmt.Sprintf("Failed to list the vertices, %v", err)}}
+	}
+	byName := make(map[string]dfv1.Vertex)
+	for _, v := range vertices.Items {
+		byName[v.Spec.Name] = v
+	}
+	var findings []Finding
+	for _, av := range pl.Spec.Vertices {
+		check := fmt.Sprintf("Vertex %q", av.Name)
+		v, ok := byName[av.Name]
+		if !ok {
+			findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: "Vertex object is not created", Suggestion: "Check the events of the pipeline, and the logs of the controller"})
+			continue
+		}
+		if v.Status.Phase == dfv1.VertexPhaseFailed {
+			findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Phase is Failed, %s %s", v.Status.Reason, v.Status.Message), Suggestion: "Check the events of the vertex, and the logs of the controller"})
+		}
+		findings = append(findings, checkVertexLimits(check, v)...)
+		if v.Status.Replicas == 0 {
+			findings = append(findings, Finding{Check: check, Severity: SeverityOK, Message: "Scaled to 0"})
+			continue
+		}
+		podSelector := labels.SelectorFromSet(map[string]string{dfv1.KeyPipelineName: pl.Name, dfv1.KeyVertexName: av.Name}).String()
+		findings = append(findings, d.checkPods(ctx, check, pl.Namespace, podSelector)...)
+	}
+	return findings
+}
+
+// checkVertexLimits checks the common misconfigurations of the limits of a vertex.
+func checkVertexLimits(check string, v dfv1.Vertex) []Finding {
+	var findings []Finding
+	if x := v.Spec.Scale; x.Min != nil && x.Max != nil && *x.Min > *x.Max {
+		findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("scale.min %d is greater than scale.max %d", *x.Min, *x.Max), Suggestion: "Set scale.max to be at least scale.min"})
+	}
+	if l := v.Spec.Limits; l != nil && l.ReadBatchSize != nil && l.BufferMaxLength != nil && v.Spec.Sink == nil {
+		usageLimit := uint64(dfv1.DefaultPipelineBufferUsageLimit)
+		if l.BufferUsageLimit != nil {
+			usageLimit = uint64(*l.BufferUsageLimit)
+		}
+		if writable := *l.BufferMaxLength * usageLimit / 100; *l.ReadBatchSize > writable {
+			findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("limits.readBatchSize %d is greater than the %d messages that can be written to a buffer (bufferMaxLength * bufferUsageLimit)", *l.ReadBatchSize, writable), Suggestion: "Decrease limits.readBatchSize, or increase limits.bufferMaxLength"})
+		}
+	}
+	return findings
+}
+
+// checkPods checks the pods selected by the label selector.
+func (d *Doctor) checkPods(ctx context.Context, check, namespace, selector string) []Finding {
+	pods, err := d.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Failed to list the pods, %v", err)}}
+	}
+	if len(pods.Items) == 0 {
+		return []Finding{{Check: check, Severity: SeverityError, Message: "No pod is found", Suggestion: "Check the events of the StatefulSet or the Deployment creating the pods"}}
+	}
+	var findings []Finding
+	for _, pod := range pods.Items {
+		findings = append(findings, checkPod(check, pod)...)
+	}
+	if len(findings) == 0 {
+		findings = append(findings, Finding{Check: check, Severity: SeverityOK, Message: fmt.Sprintf("%d pod(s) running and ready", len(pods.Items))})
+	}
+	return findings
+}
+
+// checkPod checks if a pod is scheduled, and all its containers are running and ready.
+func checkPod(check string, pod corev1.Pod) []Finding {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			return []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Pod %s is not scheduled, %s", pod.Name, c.Message), Suggestion: "Check the resource requests, node selectors, tolerations and affinity of the pod"}}
+		}
+	}
+	var findings []Finding
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		if w := s.State.Waiting; w != nil {
+			switch w.Reason {
+			case "CrashLoopBackOff":
+				findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Container %s of pod %s is crash looping, restarted %d times", s.Name, pod.Name, s.RestartCount), Suggestion: fmt.Sprintf("Check the logs with: kubectl -n %s logs %s -c %s --previous", pod.Namespace, pod.Name, s.Name)})
+				continue
+			case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+				findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Container %s of pod %s can not pull image %s, %s", s.Name, pod.Name, s.Image, w.Message), Suggestion: "Check the image name, and the imagePullSecrets of the vertex"})
+				continue
+			case "CreateContainerConfigError", "CreateContainerError":
+				findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Container %s of pod %s can not be created, %s", s.Name, pod.Name, w.Message), Suggestion: "Check the secrets and the config maps referenced by the container"})
+				continue
+			}
+		}
+		if t := s.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" {
+			findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("Container %s of pod %s was OOMKilled", s.Name, pod.Name), Suggestion: "Increase the memory limit of the container, or decrease limits.readBatchSize"})
+			continue
+		}
+		if !s.Ready && pod.Status.Phase == corev1.PodRunning && s.State.Running != nil {
+			findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("Container %s of pod %s is running but not ready", s.Name, pod.Name), Suggestion: fmt.Sprintf("Check the logs with: kubectl -n %s logs %s -c %s", pod.Namespace, pod.Name, s.Name)})
+		}
+	}
+	if len(findings) == 0 && pod.Status.Phase != corev1.PodRunning {
+		findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("Pod %s is %s", pod.Name, pod.Status.Phase), Suggestion: fmt.Sprintf("Check the events with: kubectl -n %s describe pod %s", pod.Namespace, pod.Name)})
+	}
+	return findings
+}
+
+// checkDaemon checks the daemon deployment of the pipeline, and if the daemon server is reachable.
+func (d *Doctor) checkDaemon(ctx context.Context, pl *dfv1.Pipeline) ([]Finding, bool) {
+	const check = "Daemon"
+	deploy, err := d.kubeClient.AppsV1().Deployments(pl.Namespace).Get(ctx, pl.GetDaemonDeploymentName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Deployment %s is not found", pl.GetDaemonDeploymentName()), Suggestion: "Check the events of the pipeline, and the logs of the controller"}}, false
+		}
+		return []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Failed to get deployment %s, %v", pl.GetDaemonDeploymentName(), err)}}, false
+	}
+	if !deploymentAvailable(deploy) {
+		selector := labels.SelectorFromSet(map[string]string{dfv1.KeyPipelineName: pl.Name, dfv1.KeyComponent: dfv1.ComponentDaemon}).String()
+		findings := []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Deployment %s is not available", deploy.Name)}}
+		return append(findings, d.checkPods(ctx, check, pl.Namespace, selector)...), false
+	}
+	data, err := d.daemonGet(ctx, pl, "/api/v1/server-info")
+	if err != nil {
+		return []Finding{{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Daemon server is not reachable, %v", err), Suggestion: fmt.Sprintf("Check the logs with: kubectl -n %s logs deployment/%s", pl.Namespace, deploy.Name)}}, false
+	}
+	info := struct {
+		Version string `json:"version"`
+	}{}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return []Finding{{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("Failed to decode the server info, %v", err)}}, true
+	}
+	findings := []Finding{{Check: check, Severity: SeverityOK, Message: fmt.Sprintf("Daemon server %s is reachable", info.Version)}}
+	if v := numaflow.GetVersion().Version; info.Version != v {
+		findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("Daemon server version %s is different from this CLI version %s", info.Version, v), Suggestion: "Use the CLI of the same version as the controller, or upgrade the controller"})
+	}
+	return findings, true
+}
+
+func deploymentAvailable(deploy *appv1.Deployment) bool {
+	for _, c := range deploy.Status.Conditions {
+		if c.Type == appv1.DeploymentAvailable {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return deploy.Status.AvailableReplicas > 0
+}
+
+// checkBuffers checks if the buffers of the pipeline exist in the ISB Service, and if they are full.
+func (d *Doctor) checkBuffers(ctx context.Context, pl *dfv1.Pipeline) []Finding {
+	const check = "Buffers"
+	var findings []Finding
+	buffers := pl.GetAllBuffers()
+	for _, buffer := range buffers {
+		data, err := d.daemonGet(ctx, pl, fmt.Sprintf("/api/v1/pipelines/%s/buffers/%s", pl.Name, buffer))
+		if err != nil {
+			findings = append(findings, Finding{Check: check, Severity: SeverityError, Message: fmt.Sprintf("Buffer %s is not accessible, %v", buffer, err), Suggestion: "Check if the buffer exists with 'numaflow isbsvc-buffer-validate', and the logs of the buffer creation job of the pipeline"})
+			continue
+		}
+		resp := struct {
+			Buffer struct {
+				ToVertex string `json:"toVertex"`
+				IsFull   bool   `json:"isFull"`
+			} `json:"buffer"`
+		}{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("Failed to decode the information of buffer %s, %v", buffer, err)})
+			continue
+		}
+		if resp.Buffer.IsFull {
+			findings = append(findings, Finding{Check: check, Severity: SeverityWarning, Message: fmt.Sprintf("Buffer %s is full, the upstream vertex is blocked", buffer), Suggestion: fmt.Sprintf("Scale up vertex %q, or increase the buffer limits", resp.Buffer.ToVertex)})
+		}
+	}
+	if len(findings) == 0 {
+		findings = append(findings, Finding{Check: check, Severity: SeverityOK, Message: fmt.Sprintf("%d buffer(s) exist", len(buffers))})
+	}
+	return findings
+}
+
+// Print prints the findings, and returns the number of errors.
+func Print(w io.Writer, findings []Finding) int {
+	errs := 0
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			errs++
+		}
+		_, _ = fmt.Fprintf(w, "%-7s %s: %s\n", "["+string(f.Severity)+"]", f.Check, f.Message)
+		if f.Suggestion != "" {
+			_, _ = fmt.Fprintf(w, "        -> %s\n", f.Suggestion)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,277 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/numaproj/numaflow"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/client/clientset/versioned/fake"
+)
+
+const testNamespace = "test-ns"
+
+var (
+	testPipeline = &dfv1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pl",
+			Namespace: testNamespace,
+		},
+		Spec: dfv1.PipelineSpec{
+			Vertices: []dfv1.AbstractVertex{
+				{Name: "input", Source: &dfv1.Source{}},
+				{Name: "output", Sink: &dfv1.Sink{}},
+			},
+			Edges: []dfv1.Edge{{From: "input", To: "output"}},
+		},
+		Status: dfv1.PipelineStatus{Phase: dfv1.PipelinePhaseRunning},
+	}
+
+	testISBSvc = &dfv1.InterStepBufferService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dfv1.DefaultISBSvcName,
+			Namespace: testNamespace,
+		},
+		Status: dfv1.InterStepBufferServiceStatus{Phase: dfv1.ISBSvcPhaseRunning},
+	}
+)
+
+func testVertex(name string) *dfv1.Vertex {
+	return &dfv1.Vertex{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testPipeline.Name + "-" + name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{dfv1.KeyPipelineName: testPipeline.Name},
+		},
+		Spec:   dfv1.VertexSpec{AbstractVertex: dfv1.AbstractVertex{Name: name}, PipelineName: testPipeline.Name},
+		Status: dfv1.VertexStatus{Phase: dfv1.VertexPhaseRunning, Replicas: 1},
+	}
+}
+
+func testPod(name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: labels},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "main", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}},
+		},
+	}
+}
+
+func healthyObjects() ([]runtime.Object, []runtime.Object) {
+	kubeObjs := []runtime.Object{
+		testPod("isbsvc-0", map[string]string{dfv1.KeyISBSvcName: dfv1.DefaultISBSvcName, dfv1.KeyComponent: dfv1.ComponentISBSvc}),
+		testPod("input-0", map[string]string{dfv1.KeyPipelineName: testPipeline.Name, dfv1.KeyVertexName: "input"}),
+		testPod("output-0", map[string]string{dfv1.KeyPipelineName: testPipeline.Name, dfv1.KeyVertexName: "output"}),
+		&appv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: testPipeline.GetDaemonDeploymentName(), Namespace: testNamespace},
+			Status:     appv1.DeploymentStatus{AvailableReplicas: 1},
+		},
+	}
+	objs := []runtime.Object{testPipeline.DeepCopy(), testISBSvc.DeepCopy(), testVertex("input"), testVertex("output")}
+	return kubeObjs, objs
+}
+
+func newTestDoctor(kubeObjs, objs []runtime.Object, daemon map[string]string) *Doctor {
+	kubeClient := kubefake.NewSimpleClientset(kubeObjs...)
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: dfv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{{Kind: "Pipeline"}, {Kind: "Vertex"}, {Kind: "InterStepBufferService"}},
+		},
+	}
+	client := fake.NewSimpleClientset()
+	for _, obj := range objs {
+		// created with the clients rather than added to the tracker, which guesses a wrong resource of the vertices
+		switch x := obj.(type) {
+		case *dfv1.Pipeline:
+			_, _ = client.NumaflowV1alpha1().Pipelines(testNamespace).Create(context.Background(), x, metav1.CreateOptions{})
+		case *dfv1.Vertex:
+			_, _ = client.NumaflowV1alpha1().Vertices(testNamespace).Create(context.Background(), x, metav1.CreateOptions{})
+		case *dfv1.InterStepBufferService:
+			_, _ = client.NumaflowV1alpha1().InterStepBufferServices(testNamespace).Create(context.Background(), x, metav1.CreateOptions{})
+		}
+	}
+	d := NewDoctor(kubeClient, client)
+	d.daemonGet = func(ctx context.Context, pl *dfv1.Pipeline, path string) ([]byte, error) {
+		if data, ok := daemon[path]; ok {
+			return []byte(data), nil
+		}
+		return nil, fmt.Errorf("the server could not find the requested resource")
+	}
+	return d
+}
+
+func healthyDaemon() map[string]string {
+	return map[string]string{
+		"/api/v1/server-info": fmt.Sprintf(`{"version":%q}`, numaflow.GetVersion().Version),
+		fmt.Sprintf("/api/v1/pipelines/test-pl/buffers/%s", testPipeline.GetAllBuffers()[0]): `{"buffer":{"toVertex":"output","isFull":false}}`,
+	}
+}
+
+func findingsOf(findings []Finding, severity Severity) []Finding {
+	var result []Finding
+	for _, f := range findings {
+		if f.Severity == severity {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func TestDiagnose(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		kubeObjs, objs := healthyObjects()
+		findings := newTestDoctor(kubeObjs, objs, healthyDaemon()).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		assert.Empty(t, findingsOf(findings, SeverityError))
+		assert.Empty(t, findingsOf(findings, SeverityWarning))
+		var b bytes.Buffer
+		assert.Equal(t, 0, Print(&b, findings))
+		assert.Contains(t, b.String(), "[OK]    CRDs: ")
+		assert.Contains(t, b.String(), "1 buffer(s) exist")
+	})
+
+	t.Run("pipeline not found", func(t *testing.T) {
+		findings := newTestDoctor(nil, nil, nil).Diagnose(context.Background(), testNamespace, "abc")
+		errs := findingsOf(findings, SeverityError)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "Pipeline", errs[0].Check)
+		assert.Contains(t, errs[0].Message, `Pipeline "abc" is not found`)
+	})
+
+	t.Run("CRDs not served", func(t *testing.T) {
+		d := newTestDoctor(nil, nil, nil)
+		d.kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+			{GroupVersion: dfv1.SchemeGroupVersion.String(), APIResources: []metav1.APIResource{{Kind: "Pipeline"}}},
+		}
+		findings := d.checkCRDs()
+		assert.Len(t, findings, 1)
+		assert.Equal(t, SeverityError, findings[0].Severity)
+		assert.Contains(t, findings[0].Message, "[Vertex InterStepBufferService]")
+	})
+
+	t.Run("ISB Service not found", func(t *testing.T) {
+		kubeObjs, objs := healthyObjects()
+		findings := newTestDoctor(kubeObjs, objs[:1], healthyDaemon()).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		errs := findingsOf(findings, SeverityError)
+		assert.NotEmpty(t, errs)
+		assert.Equal(t, "ISB Service", errs[0].Check)
+		assert.Contains(t, errs[0].Message, `ISB Service "default" is not found`)
+		assert.Contains(t, errs[0].Suggestion, "interStepBufferServiceName")
+	})
+
+	t.Run("not supported by the ISB Service", func(t *testing.T) {
+		kubeObjs, objs := healthyObjects()
+		pl := objs[0].(*dfv1.Pipeline)
+		pl.Spec.Edges[0].Limits = &dfv1.EdgeLimits{OnFull: dfv1.OnFullDiscardLatest}
+		isbSvc := objs[1].(*dfv1.InterStepBufferService)
+		isbSvc.Spec = dfv1.InterStepBufferServiceSpec{Kafka: &dfv1.KafkaConfig{}}
+		findings := newTestDoctor(kubeObjs, objs, healthyDaemon()).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		errs := findingsOf(findings, SeverityError)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "ISB Service", errs[0].Check)
+		assert.Contains(t, errs[0].Message, `"onFull" is not supported by the Kafka ISB Service`)
+	})
+
+	t.Run("crash looping vertex pod", func(t *testing.T) {
+		kubeObjs, objs := healthyObjects()
+		pod := kubeObjs[1].(*corev1.Pod)
+		pod.Status.ContainerStatuses[0] = corev1.ContainerStatus{
+			Name:         "main",
+			RestartCount: 5,
+			State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}
+		findings := newTestDoctor(kubeObjs, objs, healthyDaemon()).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		errs := findingsOf(findings, SeverityError)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, `Vertex "input"`, errs[0].Check)
+		assert.Contains(t, errs[0].Message, "restarted 5 times")
+		assert.Equal(t, "Check the logs with: kubectl -n test-ns logs input-0 -c main --previous", errs[0].Suggestion)
+	})
+
+	t.Run("vertex not created", func(t *testing.T) {
+		kubeObjs, objs := healthyObjects()
+		findings := newTestDoctor(kubeObjs, objs[:3], healthyDaemon()).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		errs := findingsOf(findings, SeverityError)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, `Vertex "output"`, errs[0].Check)
+	})
+
+	t.Run("daemon not reachable", func(t *testing.T) {
+		kubeObjs, objs := healthyObjects()
+		findings := newTestDoctor(kubeObjs, objs, nil).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		errs := findingsOf(findings, SeverityError)
+		assert.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Message, "Daemon server is not reachable")
+		warns := findingsOf(findings, SeverityWarning)
+		assert.Len(t, warns, 1)
+		assert.Equal(t, "Buffers", warns[0].Check)
+	})
+
+	t.Run("buffer not found and full", func(t *testing.T) {
+		kubeObjs, objs := healthyObjects()
+		daemon := healthyDaemon()
+		for k := range daemon {
+			if strings.Contains(k, "/buffers/") {
+				delete(daemon, k)
+			}
+		}
+		findings := newTestDoctor(kubeObjs, objs, daemon).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		errs := findingsOf(findings, SeverityError)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "Buffers", errs[0].Check)
+
+		daemon = healthyDaemon()
+		for k := range daemon {
+			if strings.Contains(k, "/buffers/") {
+				daemon[k] = `{"buffer":{"toVertex":"output","isFull":true}}`
+			}
+		}
+		findings = newTestDoctor(kubeObjs, objs, daemon).Diagnose(context.Background(), testNamespace, testPipeline.Name)
+		warns := findingsOf(findings, SeverityWarning)
+		assert.Len(t, warns, 1)
+		assert.Contains(t, warns[0].Suggestion, `Scale up vertex "output"`)
+	})
+}
+
+func TestCheckVertexLimits(t *testing.T) {
+	v := testVertex("p1")
+	min, max := int32(3), int32(2)
+	readBatchSize, bufferMaxLength := uint64(500), uint64(100)
+	v.Spec.Scale = dfv1.Scale{Min: &min, Max: &max}
+	v.Spec.Limits = &dfv1.VertexLimits{ReadBatchSize: &readBatchSize, BufferMaxLength: &bufferMaxLength}
+	findings := checkVertexLimits("Vertex", *v)
+	assert.Len(t, findings, 2)
+	assert.Contains(t, findings[0].Message, "scale.min 3 is greater than scale.max 2")
+	assert.Contains(t, findings[1].Message, "limits.readBatchSize 500")
+}
+
+func TestCheckPod(t *testing.T) {
+	pod := testPod("p-0", nil)
+	assert.Empty(t, checkPod("Vertex", *pod))
+
+	pod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{Reason: "OOMKilled"}
+	findings := checkPod("Vertex", *pod)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, SeverityWarning, findings[0].Severity)
+
+	pod = testPod("p-0", nil)
+	pod.Status.Phase = corev1.PodPending
+	pod.Status.ContainerStatuses = nil
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Message: "0/3 nodes are available"}}
+	findings = checkPod("Vertex", *pod)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "0/3 nodes are available")
+}
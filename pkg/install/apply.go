@@ -0,0 +1,57 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// fieldManager is the field manager of the server-side apply
+const fieldManager = "numaflow-install"
+
+// Apply applies the objects to the cluster by server-side apply in order, the objects of the kinds not known to the
+// cluster fail, e.g. the certificates if cert-manager is not installed.
+func Apply(ctx context.Context, restConfig *rest.Config, objs []*unstructured.Unstructured, onApplied func(*unstructured.Unstructured)) error {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create the discovery client, %w", err)
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create the dynamic client, %w", err)
+	}
+	force := true
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return fmt.Errorf("failed to get the resource of %s, %w", gvk, err)
+		}
+		data, err := json.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		var ri dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			ri = dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+		}
+		if _, err := ri.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: fieldManager, Force: &force}); err != nil {
+			return fmt.Errorf("failed to apply %s %q, %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if onApplied != nil {
+			onApplied(obj)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+/*
+Package install renders the installation manifests of numaflow with the chosen options, e.g. the namespace, the scope of
+the permissions and the image registry, so that the installation variants don't require hand-editing the YAML.
+
+The manifests are the ones under the config directory, only the resources of the kustomizations are interpreted, the
+rest, e.g. the namespace and the images, are set by the options.
+*/
+package install
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/numaproj/numaflow/config"
+	"github.com/numaproj/numaflow/controllers"
+)
+
+const (
+	// DefaultNamespace is the namespace of the installation manifests
+	DefaultNamespace = "numaflow-system"
+
+	image              = "quay.io/numaproj/numaflow"
+	controllerConfig   = "numaflow-controller-config"
+	controllerName     = "controller-manager"
+	clusterRoleFile    = "cluster-install/rbac/numaflow-cluster-role.yaml"
+	clusterBindingFile = "cluster-install/rbac/numaflow-binding.yaml"
+)
+
+type options struct {
+	// namespace is where the controller and the webhook are installed
+	namespace string
+	// namespaced installs the controller with the permissions of its namespace only
+	namespaced bool
+	// imageRegistry replaces the registries of the numaflow image and the built-in images
+	imageRegistry string
+	// imageTag is the tag of the numaflow image, the one of the manifests is kept if it's empty
+	imageTag string
+	// webhook installs the validating webhook as well
+	webhook bool
+}
+
+type Option func(*options) error
+
+// WithNamespace sets the namespace of the installation, defaults to numaflow-system.
+func WithNamespace(namespace string) Option {
+	return func(o *options) error {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid namespace %q, %s", namespace, strings.Join(errs, ", "))
+		}
+		o.namespace = namespace
+		return nil
+	}
+}
+
+// WithNamespaced sets whether the controller only watches the objects of its namespace, with the Role and the
+// RoleBinding of the namespace instead of the ClusterRoles.
+func WithNamespaced(namespaced bool) Option {
+	return func(o *options) error {
+		o.namespaced = namespaced
+		return nil
+	}
+}
+
+// WithImageRegistry sets the registry replacing the ones of the numaflow image and the built-in images, e.g.
+// "my-registry.example.com/mirror" for the air-gapped clusters.
+func WithImageRegistry(registry string) Option {
+	return func(o *options) error {
+		o.imageRegistry = strings.TrimSuffix(registry, "/")
+		return nil
+	}
+}
+
+// WithImageTag sets the tag of the numaflow image.
+func WithImageTag(tag string) Option {
+	return func(o *options) error {
+		if strings.ContainsAny(tag, ":/@ ") {
+			return fmt.Errorf("invalid image tag %q", tag)
+		}
+		o.imageTag = tag
+		return nil
+	}
+}
+
+// WithWebhook sets whether the validating webhook is installed, it requires cert-manager.
+func WithWebhook(webhook bool) Option {
+	return func(o *options) error {
+		o.webhook = webhook
+		return nil
+	}
+}
+
+// Render returns the objects of the installation with the options applied, the CustomResourceDefinitions come first.
+func Render(opts ...Option) ([]*unstructured.Unstructured, error) {
+	o := &options{namespace: DefaultNamespace}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	var objs []*unstructured.Unstructured
+	if o.namespaced {
+		base, err := loadKustomization(config.Manifests, "base")
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, base...)
+		for _, f := range []string{clusterRoleFile, clusterBindingFile} {
+			rbac, err := loadFile(config.Manifests, f)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, rbac...)
+		}
+	} else {
+		clusterInstall, err := loadKustomization(config.Manifests, "cluster-install")
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, clusterInstall...)
+	}
+	if o.webhook {
+		webhook, err := loadKustomization(config.Manifests, "extensions/webhook")
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, webhook...)
+	}
+	for _, obj := range objs {
+		if err := o.apply(obj); err != nil {
+			return nil, fmt.Errorf("failed to render %s %q, %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+	return objs, nil
+}
+
+// Write writes the objects as a multi-document YAML.
+func Write(w io.Writer, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply applies the options to the object.
+func (o *options) apply(obj *unstructured.Unstructured) error {
+	if !isClusterScoped(obj.GetKind()) {
+		obj.SetNamespace(o.namespace)
+	}
+	switch obj.GetKind() {
+	case "ClusterRole":
+		if o.namespaced {
+			return o.toRole(obj)
+		}
+	case "ClusterRoleBinding":
+		if o.namespaced {
+			obj.SetKind("RoleBinding")
+			obj.SetNamespace(o.namespace)
+			if err := unstructured.SetNestedField(obj.Object, "Role", "roleRef", "kind"); err != nil {
+				return err
+			}
+		}
+		return o.setSubjectsNamespace(obj)
+	case "ConfigMap":
+		if obj.GetName() == controllerConfig && o.imageRegistry != "" {
+			return o.setRegistryPrefix(obj)
+		}
+	case "Deployment":
+		return o.setContainers(obj)
+	case "Certificate":
+		names, _, err := unstructured.NestedStringSlice(obj.Object, "spec", "dnsNames")
+		if err != nil {
+			return err
+		}
+		for i, n := range names {
+			names[i] = strings.Replace(n, "."+DefaultNamespace+".", "."+o.namespace+".", 1)
+		}
+		return unstructured.SetNestedStringSlice(obj.Object, names, "spec", "dnsNames")
+	case "ValidatingWebhookConfiguration":
+		return o.setWebhooks(obj)
+	}
+	return nil
+}
+
+// toRole turns the ClusterRole into a Role of the namespace, without the rules of the cluster-scoped resources.
+func (o *options) toRole(obj *unstructured.Unstructured) error {
+	obj.SetKind("Role")
+	obj.SetNamespace(o.namespace)
+	rules, _, err := unstructured.NestedSlice(obj.Object, "rules")
+	if err != nil {
+		return err
+	}
+	var namespacedRules []interface{}
+	for _, r := range rules {
+		resources, _, err := unstructured.NestedStringSlice(r.(map[string]interface{}), "resources")
+		if err != nil {
+			return err
+		}
+		if len(resources) == 1 && resources[0] == "namespaces" {
+			continue
+		}
+		namespacedRules = append(namespacedRules, r)
+	}
+	return unstructured.SetNestedSlice(obj.Object, namespacedRules, "rules")
+}
+
+func (o *options) setSubjectsNamespace(obj *unstructured.Unstructured) error {
+	subjects, _, err := unstructured.NestedSlice(obj.Object, "subjects")
+	if err != nil {
+		return err
+	}
+	for _, s := range subjects {
+		if subject := s.(map[string]interface{}); subject["kind"] == "ServiceAccount" {
+			subject["namespace"] = o.namespace
+		}
+	}
+	return unstructured.SetNestedSlice(obj.Object, subjects, "subjects")
+}
+
+// setRegistryPrefix sets the registry prefix of the controller configuration, so that the built-in images, e.g. the
+// ones of the vertices and the ISB Services, are pulled from the registry as well.
+func (o *options) setRegistryPrefix(obj *unstructured.Unstructured) error {
+	conf, _, err := unstructured.NestedString(obj.Object, "data", "controller-config.yaml")
+	if err != nil {
+		return err
+	}
+	conf = fmt.Sprintf("registryPrefix: %s\n%s", o.imageRegistry, conf)
+	return unstructured.SetNestedField(obj.Object, conf, "data", "controller-config.yaml")
+}
+
+func (o *options) setContainers(obj *unstructured.Unstructured) error {
+	containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		container := c.(map[string]interface{})
+		if img, ok := container["image"].(string); ok {
+			container["image"] = o.getImage(img)
+		}
+		if env, ok := container["env"].([]interface{}); ok {
+			for _, e := range env {
+				if ev := e.(map[string]interface{}); ev["name"] == "NUMAFLOW_IMAGE" {
+					if img, ok := ev["value"].(string); ok {
+						ev["value"] = o.getImage(img)
+					}
+				}
+			}
+		}
+		if container["name"] == controllerName && o.namespaced {
+			args, _ := container["args"].([]interface{})
+			container["args"] = append(args, "--namespaced")
+		}
+	}
+	return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+func (o *options) setWebhooks(obj *unstructured.Unstructured) error {
+	if o.namespaced {
+		// The webhook configurations of the namespaced installations co-exist in the cluster
+		obj.SetName(obj.GetName() + "-" + o.namespace)
+	}
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations["cert-manager.io/inject-ca-from"]; ok {
+		annotations["cert-manager.io/inject-ca-from"] = o.namespace + "/numaflow-webhook-cert"
+		obj.SetAnnotations(annotations)
+	}
+	webhooks, _, err := unstructured.NestedSlice(obj.Object, "webhooks")
+	if err != nil {
+		return err
+	}
+	for _, w := range webhooks {
+		webhook := w.(map[string]interface{})
+		if err := unstructured.SetNestedField(webhook, o.namespace, "clientConfig", "service", "namespace"); err != nil {
+			return err
+		}
+		if o.namespaced {
+			selector := map[string]interface{}{
+				"matchLabels": map[string]interface{}{"kubernetes.io/metadata.name": o.namespace},
+			}
+			if err := unstructured.SetNestedMap(webhook, selector, "namespaceSelector"); err != nil {
+				return err
+			}
+		}
+	}
+	return unstructured.SetNestedSlice(obj.Object, webhooks, "webhooks")
+}
+
+// getImage returns the image with the tag and the registry replaced, only the numaflow image is re-tagged.
+func (o *options) getImage(img string) string {
+	if o.imageTag != "" && strings.HasPrefix(img, image+":") {
+		img = image + ":" + o.imageTag
+	}
+	g := &controllers.GlobalConfig{RegistryPrefix: o.imageRegistry}
+	return g.GetImage(img)
+}
+
+func isClusterScoped(kind string) bool {
+	switch kind {
+	case "CustomResourceDefinition", "ClusterRole", "ClusterRoleBinding", "ValidatingWebhookConfiguration", "Namespace":
+		return true
+	}
+	return false
+}
+
+// loadKustomization loads the objects of the resources of the kustomization in the directory, recursively.
+func loadKustomization(fsys fs.FS, dir string) ([]*unstructured.Unstructured, error) {
+	data, err := fs.ReadFile(fsys, path.Join(dir, "kustomization.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	k := struct {
+		Resources []string `json:"resources"`
+	}{}
+	if err := yaml.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("failed to parse the kustomization of %q, %w", dir, err)
+	}
+	var objs []*unstructured.Unstructured
+	for _, r := range k.Resources {
+		p := path.Join(dir, r)
+		info, err := fs.Stat(fsys, p)
+		if err != nil {
+			return nil, err
+		}
+		var rObjs []*unstructured.Unstructured
+		if info.IsDir() {
+			rObjs, err = loadKustomization(fsys, p)
+		} else {
+			rObjs, err = loadFile(fsys, p)
+		}
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, rObjs...)
+	}
+	return objs, nil
+}
+
+// loadFile loads the objects of a multi-document YAML file.
+func loadFile(fsys fs.FS, name string) ([]*unstructured.Unstructured, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objs []*unstructured.Unstructured
+	for {
+		obj := map[string]interface{}{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				return objs, nil
+			}
+			return nil, fmt.Errorf("failed to parse %q, %w", name, err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: obj})
+	}
+}
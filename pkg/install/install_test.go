@@ -0,0 +1,115 @@
+package install
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func findObj(objs []*unstructured.Unstructured, kind, name string) *unstructured.Unstructured {
+	for _, obj := range objs {
+		if obj.GetKind() == kind && obj.GetName() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+func containerOf(t *testing.T, deploy *unstructured.Unstructured) map[string]interface{} {
+	t.Helper()
+	containers, _, err := unstructured.NestedSlice(deploy.Object, "spec", "template", "spec", "containers")
+	assert.NoError(t, err)
+	assert.Len(t, containers, 1)
+	return containers[0].(map[string]interface{})
+}
+
+func TestRender_Default(t *testing.T) {
+	objs, err := Render()
+	assert.NoError(t, err)
+	// the same objects as the install.yaml
+	assert.Len(t, objs, 11)
+	for i, name := range []string{"interstepbufferservices", "pipelines", "vertices"} {
+		assert.Equal(t, "CustomResourceDefinition", objs[i].GetKind())
+		assert.Equal(t, name+".numaflow.numaproj.io", objs[i].GetName())
+	}
+	for _, obj := range objs {
+		if !isClusterScoped(obj.GetKind()) {
+			assert.Equal(t, DefaultNamespace, obj.GetNamespace())
+		}
+	}
+	assert.NotNil(t, findObj(objs, "ClusterRole", "numaflow-role"))
+	assert.NotNil(t, findObj(objs, "ClusterRole", "numaflow-aggregate-to-view"))
+	assert.Nil(t, findObj(objs, "ValidatingWebhookConfiguration", "numaflow-validating-webhook"))
+	c := containerOf(t, findObj(objs, "Deployment", "controller-manager"))
+	assert.Equal(t, "quay.io/numaproj/numaflow:latest", c["image"])
+	assert.Equal(t, []interface{}{"controller"}, c["args"])
+
+	b := bytes.NewBufferString("")
+	assert.NoError(t, Write(b, objs))
+	assert.Contains(t, b.String(), "---\napiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\n")
+}
+
+func TestRender_Namespaced(t *testing.T) {
+	objs, err := Render(WithNamespace("team-a"), WithNamespaced(true), WithWebhook(true))
+	assert.NoError(t, err)
+	for _, obj := range objs {
+		assert.NotEqual(t, "ClusterRole", obj.GetKind())
+		assert.NotEqual(t, "ClusterRoleBinding", obj.GetKind())
+		if !isClusterScoped(obj.GetKind()) {
+			assert.Equal(t, "team-a", obj.GetNamespace())
+		}
+	}
+	role := findObj(objs, "Role", "numaflow-role")
+	assert.NotNil(t, role)
+	rules, _, _ := unstructured.NestedSlice(role.Object, "rules")
+	for _, r := range rules {
+		resources, _, _ := unstructured.NestedStringSlice(r.(map[string]interface{}), "resources")
+		assert.NotContains(t, resources, "namespaces")
+	}
+	binding := findObj(objs, "RoleBinding", "numaflow-binding")
+	assert.NotNil(t, binding)
+	kind, _, _ := unstructured.NestedString(binding.Object, "roleRef", "kind")
+	assert.Equal(t, "Role", kind)
+	subjects, _, _ := unstructured.NestedSlice(binding.Object, "subjects")
+	assert.Equal(t, "team-a", subjects[0].(map[string]interface{})["namespace"])
+
+	c := containerOf(t, findObj(objs, "Deployment", "controller-manager"))
+	assert.Equal(t, []interface{}{"controller", "--namespaced"}, c["args"])
+
+	cert := findObj(objs, "Certificate", "numaflow-webhook-cert")
+	dnsNames, _, _ := unstructured.NestedStringSlice(cert.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"numaflow-webhook.team-a.svc", "numaflow-webhook.team-a.svc.cluster.local"}, dnsNames)
+	webhook := findObj(objs, "ValidatingWebhookConfiguration", "numaflow-validating-webhook-team-a")
+	assert.NotNil(t, webhook)
+	assert.Equal(t, "team-a/numaflow-webhook-cert", webhook.GetAnnotations()["cert-manager.io/inject-ca-from"])
+	webhooks, _, _ := unstructured.NestedSlice(webhook.Object, "webhooks")
+	assert.Len(t, webhooks, 2)
+	for _, w := range webhooks {
+		ns, _, _ := unstructured.NestedString(w.(map[string]interface{}), "clientConfig", "service", "namespace")
+		assert.Equal(t, "team-a", ns)
+		selected, _, _ := unstructured.NestedString(w.(map[string]interface{}), "namespaceSelector", "matchLabels", "kubernetes.io/metadata.name")
+		assert.Equal(t, "team-a", selected)
+	}
+}
+
+func TestRender_Images(t *testing.T) {
+	objs, err := Render(WithImageRegistry("my-registry.example.com/mirror/"), WithImageTag("v0.7.0"), WithWebhook(true))
+	assert.NoError(t, err)
+	c := containerOf(t, findObj(objs, "Deployment", "controller-manager"))
+	assert.Equal(t, "my-registry.example.com/mirror/numaproj/numaflow:v0.7.0", c["image"])
+	env := c["env"].([]interface{})
+	assert.Equal(t, "my-registry.example.com/mirror/numaproj/numaflow:v0.7.0", env[1].(map[string]interface{})["value"])
+	c = containerOf(t, findObj(objs, "Deployment", "numaflow-webhook"))
+	assert.Equal(t, "my-registry.example.com/mirror/numaproj/numaflow:v0.7.0", c["image"])
+	conf, _, _ := unstructured.NestedString(findObj(objs, "ConfigMap", "numaflow-controller-config").Object, "data", "controller-config.yaml")
+	assert.Contains(t, conf, "registryPrefix: my-registry.example.com/mirror\n")
+}
+
+func TestRender_InvalidOptions(t *testing.T) {
+	_, err := Render(WithNamespace("Team_A"))
+	assert.Error(t, err)
+	_, err = Render(WithImageTag("v1:latest"))
+	assert.Error(t, err)
+}
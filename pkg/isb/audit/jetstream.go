@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+// readTimeout is how long to wait for the next record when reading the audit log
+const readTimeout = 10 * time.Second
+
+// JetStreamLog is the audit log of a pipeline kept in the JetStream stream "<pipeline>_AUDIT", the records are published
+// to the subjects "<pipeline>_AUDIT.<role>".
+type JetStreamLog struct {
+	js     nats.JetStreamContext
+	stream string
+}
+
+// NewJetStreamLog returns the audit log of the pipeline, the stream is created if it does not exist, or updated if the
+// retention has changed.
+func NewJetStreamLog(js nats.JetStreamContext, pipelineName string, retention time.Duration) (*JetStreamLog, error) {
+	stream := auditStreamName(pipelineName)
+	config := &nats.StreamConfig{
+		Name:      stream,
+		Subjects:  []string{stream + ".>"},
+		Retention: nats.LimitsPolicy,
+		Storage:   nats.FileStorage,
+		MaxAge:    retention,
+	}
+	info, err := js.StreamInfo(stream)
+	switch {
+	case errors.Is(err, nats.ErrStreamNotFound):
+		_, err = js.AddStream(config)
+	case err == nil && info.Config.MaxAge != retention:
+		_, err = js.UpdateStream(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the audit log stream %q, %w", stream, err)
+	}
+	return &JetStreamLog{js: js, stream: stream}, nil
+}
+
+func auditStreamName(pipelineName string) string {
+	return pipelineName + "_AUDIT"
+}
+
+// Append publishes the record to the stream.
+func (l *JetStreamLog) Append(ctx context.Context, r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := l.js.Publish(l.stream+"."+string(r.Role), data, nats.Context(ctx)); err != nil {
+		return fmt.Errorf("failed to append to the audit log stream %q, %w", l.stream, err)
+	}
+	return nil
+}
+
+// ReadJetStreamLog calls fn with the records appended to the audit log of the pipeline since the time, until the ones
+// appended when it's called.
+func ReadJetStreamLog(js nats.JetStreamContext, pipelineName string, since time.Time, fn func(Record) error) error {
+	stream := auditStreamName(pipelineName)
+	info, err := js.StreamInfo(stream)
+	if err != nil {
+		return fmt.Errorf("failed to get the audit log stream %q, %w", stream, err)
+	}
+	if info.State.Msgs == 0 || info.State.LastTime.Before(since) {
+		return nil
+	}
+	last := info.State.LastSeq
+	sub, err := js.SubscribeSync(stream+".>", nats.OrderedConsumer(), nats.StartTime(since))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to the audit log stream %q, %w", stream, err)
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+	for {
+		msg, err := sub.NextMsg(readTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to read the audit log stream %q, %w", stream, err)
+		}
+		meta, err := msg.Metadata()
+		if err != nil {
+			return err
+		}
+		var r Record
+		if err := json.Unmarshal(msg.Data, &r); err != nil {
+			return fmt.Errorf("failed to unmarshal the audit record, %w", err)
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+		if meta.Sequence.Stream >= last {
+			return nil
+		}
+	}
+}
+
+// NewInClusterRecorder returns the recorder of the pod of the vertex appending to the audit log in the ISB Service, nil
+// if the pipeline is not audited. The audit log is only supported by JetStream.
+func NewInClusterRecorder(ctx context.Context, isbSvcType dfv1.ISBSvcType, vertex *dfv1.Vertex, pod string, logger *zap.SugaredLogger) (*Recorder, error) {
+	x := vertex.Spec.Audit
+	if x == nil {
+		return nil, nil
+	}
+	if isbSvcType != dfv1.ISBSvcTypeJetStream {
+		logger.Warnw("The audit mode is only supported with the JetStream ISB Service, the messages are not audited", zap.String("isbs", string(isbSvcType)))
+		return nil, nil
+	}
+	conn, err := clients.NewInClusterJetStreamClient().Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	l, err := NewJetStreamLog(js, vertex.Spec.PipelineName, x.GetRetention())
+	if err != nil {
+		return nil, err
+	}
+	return NewRecorder(vertex, pod, l), nil
+}
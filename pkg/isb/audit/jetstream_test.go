@@ -0,0 +1,60 @@
+//go:build isb_jetstream
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+var natsJetStreamUrl = "nats://localhost:4222"
+
+func TestJetStreamLog(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	conn, err := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", "")).Connect(ctx)
+	assert.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+	defer func() { _ = js.DeleteStream("test-pipeline_AUDIT") }()
+
+	l, err := NewJetStreamLog(js, "test-pipeline", time.Hour)
+	assert.NoError(t, err)
+	// updated with the new retention
+	l, err = NewJetStreamLog(js, "test-pipeline", 2*time.Hour)
+	assert.NoError(t, err)
+	info, err := js.StreamInfo("test-pipeline_AUDIT")
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, info.Config.MaxAge)
+
+	start := time.Now().Add(-time.Minute)
+	records := []Record{
+		{Role: RoleSource, Vertex: "in", Pod: "in-0", Time: time.Now().UTC(), IDs: []string{"in-1", "in-2"}},
+		{Role: RoleSink, Vertex: "out", Pod: "out-0", Time: time.Now().UTC(), IDs: []string{"in-1"}},
+	}
+	for _, r := range records {
+		assert.NoError(t, l.Append(ctx, r))
+	}
+	var read []Record
+	assert.NoError(t, ReadJetStreamLog(js, "test-pipeline", start, func(r Record) error {
+		read = append(read, r)
+		return nil
+	}))
+	assert.Len(t, read, 2)
+	assert.Equal(t, records[0].IDs, read[0].IDs)
+	assert.Equal(t, RoleSink, read[1].Role)
+
+	read = nil
+	assert.NoError(t, ReadJetStreamLog(js, "test-pipeline", time.Now().Add(time.Minute), func(r Record) error {
+		read = append(read, r)
+		return nil
+	}))
+	assert.Empty(t, read)
+}
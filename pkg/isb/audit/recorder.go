@@ -0,0 +1,90 @@
+/*
+Package audit implements the at-least-once audit mode of a pipeline. The sources stamp each message with an audit ID,
+which is carried along in the metadata across the vertices, and record the audit IDs of the messages they emit to the
+audit log, while the sinks record the audit IDs of the messages they process. Comparing the records for a time range
+tells which of the emitted messages are lost, or duplicated, on the way to the sinks.
+*/
+package audit
+
+import (
+	"context"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// Role is the role of the vertex recording the audit IDs.
+type Role string
+
+const (
+	// RoleSource records the audit IDs of the messages emitted by a source
+	RoleSource Role = "source"
+	// RoleSink records the audit IDs of the messages processed by a sink
+	RoleSink Role = "sink"
+)
+
+// Record is a batch of audit IDs recorded by a replica of a vertex.
+type Record struct {
+	Role   Role   `json:"role"`
+	Vertex string `json:"vertex"`
+	Pod    string `json:"pod"`
+	// Time is the time the messages are emitted or processed
+	Time time.Time `json:"time"`
+	IDs  []string  `json:"ids"`
+}
+
+// Log is the audit log the records are appended to.
+type Log interface {
+	// Append appends the record to the log.
+	Append(ctx context.Context, r Record) error
+}
+
+// Recorder records the audit IDs of the messages emitted by a source vertex, or processed by a sink vertex.
+type Recorder struct {
+	role   Role
+	vertex string
+	pod    string
+	log    Log
+	now    func() time.Time
+}
+
+// NewRecorder returns the recorder of the pod of the vertex, which is either a source or a sink.
+func NewRecorder(vertex *dfv1.Vertex, pod string, log Log) *Recorder {
+	role := RoleSink
+	if vertex.IsASource() {
+		role = RoleSource
+	}
+	return &Recorder{role: role, vertex: vertex.Spec.Name, pod: pod, log: log, now: time.Now}
+}
+
+// Stamp sets the audit IDs of the messages read by a source, which are the IDs of the messages prefixed with the source
+// vertex name, it does nothing for a sink.
+func (r *Recorder) Stamp(messages []*isb.ReadMessage) {
+	if r.role != RoleSource {
+		return
+	}
+	for _, m := range messages {
+		// the metadata is copied, since it could be shared with the other messages
+		metadata := make(map[string]string, len(m.Metadata)+1)
+		for k, v := range m.Metadata {
+			metadata[k] = v
+		}
+		metadata[dfv1.AuditIDKey] = r.vertex + "-" + m.ID
+		m.Metadata = metadata
+	}
+}
+
+// Record appends the audit IDs of the messages to the audit log, the messages without an audit ID are not audited.
+func (r *Recorder) Record(ctx context.Context, messages []*isb.ReadMessage) error {
+	ids := make([]string, 0, len(messages))
+	for _, m := range messages {
+		if id, ok := m.Metadata[dfv1.AuditIDKey]; ok {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.log.Append(ctx, Record{Role: r.role, Vertex: r.vertex, Pod: r.pod, Time: r.now(), IDs: ids})
+}
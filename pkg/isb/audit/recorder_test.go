@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+type testLog struct {
+	records []Record
+}
+
+func (l *testLog) Append(_ context.Context, r Record) error {
+	l.records = append(l.records, r)
+	return nil
+}
+
+func testMessages(metadata ...map[string]string) []*isb.ReadMessage {
+	var result []*isb.ReadMessage
+	for i, m := range metadata {
+		result = append(result, &isb.ReadMessage{Message: isb.Message{Header: isb.Header{ID: string(rune('a' + i)), Metadata: m}}})
+	}
+	return result
+}
+
+func TestRecorder(t *testing.T) {
+	now := time.Unix(1000, 0)
+	t.Run("source", func(t *testing.T) {
+		log := &testLog{}
+		r := NewRecorder(&dfv1.Vertex{Spec: dfv1.VertexSpec{AbstractVertex: dfv1.AbstractVertex{Name: "in", Source: &dfv1.Source{}}}}, "pod-0", log)
+		r.now = func() time.Time { return now }
+		shared := map[string]string{"k": "v"}
+		messages := testMessages(nil, shared)
+		r.Stamp(messages)
+		assert.Equal(t, map[string]string{dfv1.AuditIDKey: "in-a"}, messages[0].Metadata)
+		assert.Equal(t, map[string]string{"k": "v", dfv1.AuditIDKey: "in-b"}, messages[1].Metadata)
+		assert.Equal(t, map[string]string{"k": "v"}, shared)
+		assert.NoError(t, r.Record(context.Background(), messages))
+		assert.Equal(t, []Record{{Role: RoleSource, Vertex: "in", Pod: "pod-0", Time: now, IDs: []string{"in-a", "in-b"}}}, log.records)
+	})
+
+	t.Run("sink", func(t *testing.T) {
+		log := &testLog{}
+		r := NewRecorder(&dfv1.Vertex{Spec: dfv1.VertexSpec{AbstractVertex: dfv1.AbstractVertex{Name: "out", Sink: &dfv1.Sink{}}}}, "pod-1", log)
+		r.now = func() time.Time { return now }
+		messages := testMessages(map[string]string{dfv1.AuditIDKey: "in-x"}, nil)
+		r.Stamp(messages)
+		assert.Nil(t, messages[1].Metadata)
+		assert.NoError(t, r.Record(context.Background(), messages))
+		assert.Equal(t, []Record{{Role: RoleSink, Vertex: "out", Pod: "pod-1", Time: now, IDs: []string{"in-x"}}}, log.records)
+		assert.NoError(t, r.Record(context.Background(), testMessages(nil)))
+		assert.Len(t, log.records, 1)
+	})
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"sort"
+	"time"
+)
+
+// Report is the result of comparing the audit IDs emitted by the sources within a time range, with the ones processed
+// by the sinks.
+type Report struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Emitted is the number of the distinct audit IDs emitted by the sources within the time range
+	Emitted int `json:"emitted"`
+	// Processed is the number of the emitted audit IDs processed by each sink, keyed by the sink vertex names
+	Processed map[string]int `json:"processed"`
+	// Lost are the emitted audit IDs not processed by any sink
+	Lost []string `json:"lost"`
+	// Duplicated are the emitted audit IDs processed more than once by a sink, with the number of times they are
+	// processed, keyed by the sink vertex names
+	Duplicated map[string]map[string]int `json:"duplicated"`
+}
+
+// Passed tells if none of the emitted messages is lost or duplicated.
+func (r Report) Passed() bool {
+	return len(r.Lost) == 0 && len(r.Duplicated) == 0
+}
+
+// Verifier builds the report from the audit records.
+type Verifier struct {
+	start   time.Time
+	end     time.Time
+	emitted map[string]struct{}
+	// processed are the numbers of times the audit IDs are processed, keyed by the sink vertex names
+	processed map[string]map[string]int
+}
+
+// NewVerifier returns the verifier of the messages emitted by the sources within the time range.
+func NewVerifier(start, end time.Time) *Verifier {
+	return &Verifier{start: start, end: end, emitted: make(map[string]struct{}), processed: make(map[string]map[string]int)}
+}
+
+// Add adds a record. The records of the sources are only taken within the time range, while the ones of the sinks are
+// taken since the start, since the messages emitted in the end are processed afterwards.
+func (v *Verifier) Add(r Record) {
+	if r.Time.Before(v.start) {
+		return
+	}
+	switch r.Role {
+	case RoleSource:
+		if r.Time.After(v.end) {
+			return
+		}
+		for _, id := range r.IDs {
+			v.emitted[id] = struct{}{}
+		}
+	case RoleSink:
+		counts := v.processed[r.Vertex]
+		if counts == nil {
+			counts = make(map[string]int)
+			v.processed[r.Vertex] = counts
+		}
+		for _, id := range r.IDs {
+			counts[id]++
+		}
+	}
+}
+
+// Report returns the report of the records added.
+func (v *Verifier) Report() Report {
+	report := Report{Start: v.start, End: v.end, Emitted: len(v.emitted), Processed: make(map[string]int, len(v.processed)), Lost: []string{}, Duplicated: make(map[string]map[string]int)}
+	for id := range v.emitted {
+		found := false
+		for sink, counts := range v.processed {
+			n := counts[id]
+			if n == 0 {
+				continue
+			}
+			found = true
+			report.Processed[sink]++
+			if n > 1 {
+				if report.Duplicated[sink] == nil {
+					report.Duplicated[sink] = make(map[string]int)
+				}
+				report.Duplicated[sink][id] = n
+			}
+		}
+		if !found {
+			report.Lost = append(report.Lost, id)
+		}
+	}
+	for sink := range v.processed {
+		if _, ok := report.Processed[sink]; !ok {
+			report.Processed[sink] = 0
+		}
+	}
+	sort.Strings(report.Lost)
+	return report
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifier(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := start.Add(time.Minute)
+	v := NewVerifier(start, end)
+	// emitted before the start, or after the end, not verified
+	v.Add(Record{Role: RoleSource, Vertex: "in", Time: start.Add(-time.Second), IDs: []string{"in-0"}})
+	v.Add(Record{Role: RoleSource, Vertex: "in", Time: end.Add(time.Second), IDs: []string{"in-9"}})
+	v.Add(Record{Role: RoleSource, Vertex: "in", Time: start, IDs: []string{"in-1", "in-2", "in-3"}})
+	v.Add(Record{Role: RoleSource, Vertex: "in", Time: end, IDs: []string{"in-4", "in-1"}})
+	v.Add(Record{Role: RoleSink, Vertex: "out", Time: start.Add(time.Second), IDs: []string{"in-0", "in-1", "in-2"}})
+	// processed after the end
+	v.Add(Record{Role: RoleSink, Vertex: "out", Time: end.Add(time.Minute), IDs: []string{"in-2", "in-9"}})
+	v.Add(Record{Role: RoleSink, Vertex: "log", Time: end, IDs: []string{"in-3"}})
+	report := v.Report()
+	assert.False(t, report.Passed())
+	assert.Equal(t, 4, report.Emitted)
+	assert.Equal(t, map[string]int{"out": 2, "log": 1}, report.Processed)
+	assert.Equal(t, []string{"in-4"}, report.Lost)
+	assert.Equal(t, map[string]map[string]int{"out": {"in-2": 2}}, report.Duplicated)
+
+	v = NewVerifier(start, end)
+	v.Add(Record{Role: RoleSource, Vertex: "in", Time: start, IDs: []string{"in-1"}})
+	v.Add(Record{Role: RoleSink, Vertex: "out", Time: start, IDs: []string{"in-1"}})
+	report = v.Report()
+	assert.True(t, report.Passed())
+	assert.Equal(t, map[string]int{"out": 1}, report.Processed)
+}
@@ -0,0 +1,40 @@
+package isb
+
+import (
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+// CompressMessages returns the messages with the payloads compressed with the content encoding, which is recorded in
+// the ContentEncoding of the headers. The messages already compressed are left as they are, and the ones given are not
+// modified.
+func CompressMessages(encoding string, messages []Message) ([]Message, error) {
+	if encoding == "" {
+		return messages, nil
+	}
+	result := make([]Message, len(messages))
+	for i, m := range messages {
+		if m.ContentEncoding == "" {
+			payload, err := sharedutil.Compress(encoding, m.Payload)
+			if err != nil {
+				return nil, err
+			}
+			m.Payload, m.ContentEncoding = payload, encoding
+		}
+		result[i] = m
+	}
+	return result, nil
+}
+
+// DecompressMessage decompresses the payload of the message with the content encoding in its header. The message is
+// left as it is if it can not be decompressed.
+func DecompressMessage(m *Message) error {
+	if m.ContentEncoding == "" {
+		return nil
+	}
+	payload, err := sharedutil.Decompress(m.ContentEncoding, m.Payload)
+	if err != nil {
+		return err
+	}
+	m.Payload, m.ContentEncoding = payload, ""
+	return nil
+}
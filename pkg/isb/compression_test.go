@@ -0,0 +1,43 @@
+package isb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressMessages(t *testing.T) {
+	messages := []Message{
+		{Header: Header{ID: "0"}, Body: Body{Payload: []byte("hello hello hello")}},
+		{Header: Header{ID: "1", ContentEncoding: "gzip"}, Body: Body{Payload: []byte("compressed")}},
+	}
+	result, err := CompressMessages("", messages)
+	assert.NoError(t, err)
+	assert.Equal(t, messages, result)
+
+	result, err = CompressMessages("lz4", messages)
+	assert.NoError(t, err)
+	assert.Equal(t, "lz4", result[0].ContentEncoding)
+	assert.NotEqual(t, []byte("hello hello hello"), result[0].Payload)
+	assert.Equal(t, messages[1], result[1])
+	// the messages given are not modified
+	assert.Equal(t, "", messages[0].ContentEncoding)
+	assert.Equal(t, []byte("hello hello hello"), messages[0].Payload)
+
+	assert.NoError(t, DecompressMessage(&result[0]))
+	assert.Equal(t, messages[0], result[0])
+
+	_, err = CompressMessages("br", messages)
+	assert.Error(t, err)
+}
+
+func TestDecompressMessage(t *testing.T) {
+	m := Message{Body: Body{Payload: []byte("hello")}}
+	assert.NoError(t, DecompressMessage(&m))
+	assert.Equal(t, []byte("hello"), m.Payload)
+
+	m.ContentEncoding = "zstd"
+	assert.Error(t, DecompressMessage(&m))
+	assert.Equal(t, "zstd", m.ContentEncoding)
+	assert.Equal(t, []byte("hello"), m.Payload)
+}
@@ -1,6 +1,10 @@
 package isb
 
-import "fmt"
+import (
+	"fmt"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
 
 // MessageWriteErr is associated with message write errors.
 type MessageWriteErr struct {
@@ -14,6 +18,29 @@ func (e MessageWriteErr) Error() string {
 	return fmt.Sprintf("(%s) %s Header: %#v Body:%#v", e.Name, e.Message, e.Header, e.Body)
 }
 
+// Category returns Fatal, the message can't be written by retrying.
+func (e MessageWriteErr) Category() isberrors.Category {
+	return isberrors.Fatal
+}
+
+// MessageTooLargeErr is returned when the key or the header of a message is larger than the max size of the buffer.
+type MessageTooLargeErr struct {
+	Name string
+	// Part is the part of the message exceeding the limit, either "key" or "header"
+	Part  string
+	Size  int
+	Limit int
+}
+
+func (e MessageTooLargeErr) Error() string {
+	return fmt.Sprintf("(%s) the %s of the message is %d bytes, larger than the max %s size %d bytes", e.Name, e.Part, e.Size, e.Part, e.Limit)
+}
+
+// Category returns Fatal, the message can't be written by retrying.
+func (e MessageTooLargeErr) Category() isberrors.Category {
+	return isberrors.Fatal
+}
+
 // BufferWriteErr when we cannot write to the buffer because of a full buffer.
 type BufferWriteErr struct {
 	Name        string
@@ -36,6 +63,14 @@ func (e BufferWriteErr) IsInternalErr() bool {
 	return e.InternalErr
 }
 
+// Category returns BufferFull if the buffer is full, otherwise Transient.
+func (e BufferWriteErr) Category() isberrors.Category {
+	if e.Full {
+		return isberrors.BufferFull
+	}
+	return isberrors.Transient
+}
+
 // MessageAckErr is for acknowledgement errors.
 type MessageAckErr struct {
 	Name    string
@@ -47,6 +82,11 @@ func (e MessageAckErr) Error() string {
 	return fmt.Sprintf("(%s) %s", e.Name, e.Message)
 }
 
+// Category returns Transient.
+func (e MessageAckErr) Category() isberrors.Category {
+	return isberrors.Transient
+}
+
 // BufferReadErr when we cannot read from the buffer.
 type BufferReadErr struct {
 	Name        string
@@ -69,6 +109,11 @@ func (e BufferReadErr) IsInternalErr() bool {
 	return e.InternalErr
 }
 
+// Category returns Transient.
+func (e BufferReadErr) Category() isberrors.Category {
+	return isberrors.Transient
+}
+
 // MessageReadErr is associated with message read errors.
 type MessageReadErr struct {
 	Name    string
@@ -80,3 +125,8 @@ type MessageReadErr struct {
 func (e MessageReadErr) Error() string {
 	return fmt.Sprintf("(%s) %s Header: %s Body:%s", e.Name, e.Message, string(e.Header), string(e.Body))
 }
+
+// Category returns Fatal, the message can't be read by retrying.
+func (e MessageReadErr) Category() isberrors.Category {
+	return isberrors.Fatal
+}
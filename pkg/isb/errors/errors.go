@@ -0,0 +1,75 @@
+// Package errors defines the categories of the errors returned by the inter-step buffer readers and writers, which are
+// used to decide whether and how soon to retry, and to label the error metrics.
+package errors
+
+import (
+	"errors"
+)
+
+// Category is the category of an error.
+type Category string
+
+const (
+	// Transient errors are expected to recover by retrying, e.g. timeouts and connection resets.
+	Transient Category = "Transient"
+	// BufferFull errors are returned when the buffer reaches its usage limit, they recover once the consumers catch up.
+	BufferFull Category = "BufferFull"
+	// NotFound errors are returned when the buffer does not exist, e.g. it is being created, or it has been deleted.
+	NotFound Category = "NotFound"
+	// Auth errors are returned when the ISB Service rejects the credentials or the permissions.
+	Auth Category = "Auth"
+	// Fatal errors are not expected to recover by retrying, e.g. invalid messages.
+	Fatal Category = "Fatal"
+)
+
+// Categorized is implemented by the errors knowing their categories.
+type Categorized interface {
+	error
+	Category() Category
+}
+
+// Error wraps an error with its category.
+type Error struct {
+	category Category
+	err      error
+}
+
+// New wraps the error with the category, returns nil if the error is nil.
+func New(category Category, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{category: category, err: err}
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Category returns the category of the error.
+func (e *Error) Category() Category {
+	return e.category
+}
+
+// CategoryOf returns the category of the error, it's Transient if the error is not categorized.
+func CategoryOf(err error) Category {
+	var c Categorized
+	if errors.As(err, &c) {
+		return c.Category()
+	}
+	return Transient
+}
+
+// IsRetryable returns true if the error is expected to recover by retrying right away.
+func IsRetryable(err error) bool {
+	switch CategoryOf(err) {
+	case Transient, BufferFull:
+		return true
+	default:
+		return false
+	}
+}
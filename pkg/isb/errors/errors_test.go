@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testErr struct{}
+
+func (testErr) Error() string { return "test" }
+
+func (testErr) Category() Category { return Auth }
+
+func TestNew(t *testing.T) {
+	assert.Nil(t, New(Fatal, nil))
+	cause := fmt.Errorf("cause")
+	err := New(NotFound, cause)
+	assert.Equal(t, "cause", err.Error())
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, NotFound, CategoryOf(err))
+	assert.Equal(t, NotFound, CategoryOf(fmt.Errorf("wrapped, %w", err)))
+}
+
+func TestCategoryOf(t *testing.T) {
+	assert.Equal(t, Transient, CategoryOf(fmt.Errorf("unknown")))
+	assert.Equal(t, Auth, CategoryOf(testErr{}))
+	assert.Equal(t, Auth, CategoryOf(fmt.Errorf("wrapped, %w", testErr{})))
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(fmt.Errorf("unknown")))
+	assert.True(t, IsRetryable(New(Transient, fmt.Errorf("timeout"))))
+	assert.True(t, IsRetryable(New(BufferFull, fmt.Errorf("full"))))
+	assert.False(t, IsRetryable(New(NotFound, fmt.Errorf("not found"))))
+	assert.False(t, IsRetryable(testErr{}))
+	assert.False(t, IsRetryable(New(Fatal, fmt.Errorf("invalid"))))
+}
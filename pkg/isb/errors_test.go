@@ -0,0 +1,19 @@
+package isb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
+
+func TestErrorCategories(t *testing.T) {
+	assert.Equal(t, isberrors.BufferFull, isberrors.CategoryOf(BufferWriteErr{Name: "test", Full: true}))
+	assert.Equal(t, isberrors.Transient, isberrors.CategoryOf(BufferWriteErr{Name: "test", InternalErr: true}))
+	assert.Equal(t, isberrors.Transient, isberrors.CategoryOf(BufferReadErr{Name: "test", Empty: true}))
+	assert.Equal(t, isberrors.Transient, isberrors.CategoryOf(MessageAckErr{Name: "test"}))
+	assert.Equal(t, isberrors.Fatal, isberrors.CategoryOf(MessageWriteErr{Name: "test"}))
+	assert.Equal(t, isberrors.Fatal, isberrors.CategoryOf(MessageReadErr{Name: "test"}))
+	assert.Equal(t, isberrors.Fatal, isberrors.CategoryOf(MessageTooLargeErr{Name: "test", Part: MessagePartKey}))
+}
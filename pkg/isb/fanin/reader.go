@@ -0,0 +1,244 @@
+/*
+Package fanin reads from multiple inter-step buffers as a single isb.BufferReader, it is used by the vertices with
+multiple inbound edges. Reading is done in a deficit round-robin fashion, each buffer gets a share of the read batch
+proportional to its weight, so that one chatty upstream can not starve the others. The buffers are read concurrently, so
+that an idle one does not hold up the reads of the others for its whole read timeout.
+*/
+package fanin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// WeightedReader reads from multiple buffers with weighted fairness.
+type WeightedReader struct {
+	name    string
+	readers []isb.BufferReader
+	weights []int64
+	// deficits carries the unused read quota of each reader over to the next Read call
+	deficits []int64
+	// next is the index of the reader to start with in the next Read call
+	next int
+}
+
+var _ isb.BufferReader = (*WeightedReader)(nil)
+
+// fanInOffset wraps the offset of the underlying reader, so that it can be acked by the right reader.
+type fanInOffset struct {
+	isb.Offset
+	readerIdx int
+	// bufferName is the name of the buffer the offset belongs to
+	bufferName string
+}
+
+// String returns the offset identifier prefixed with the buffer name, since the offsets of different buffers could be
+// the same, and the identifier is used to generate the IDs of the messages written to the next buffers.
+func (o fanInOffset) String() string {
+	return o.bufferName + "-" + o.Offset.String()
+}
+
+// BufferName returns the name of the buffer a read offset belongs to. The offsets not read by a fan-in reader belong
+// to the only buffer read, the name of which is given by readerName.
+func BufferName(o isb.Offset, readerName string) string {
+	if fo, ok := o.(fanInOffset); ok {
+		return fo.bufferName
+	}
+	return readerName
+}
+
+// BufferNames returns the names of the buffers read by a reader, i.e. the underlying buffers of a fan-in reader, or
+// the only buffer read by the others.
+func BufferNames(r isb.BufferReader) []string {
+	if wr, ok := r.(*WeightedReader); ok {
+		names := make([]string, 0, len(wr.readers))
+		for _, ur := range wr.readers {
+			names = append(names, ur.GetName())
+		}
+		return names
+	}
+	return []string{r.GetName()}
+}
+
+// readErr wraps the error of an underlying reader with the name of its buffer.
+type readErr struct {
+	bufferName string
+	err        error
+}
+
+func (e readErr) Error() string {
+	return fmt.Sprintf("failed to read buffer %q, %v", e.bufferName, e.err)
+}
+
+func (e readErr) Unwrap() error {
+	return e.err
+}
+
+// ErrBufferName returns the name of the buffer a read error comes from. The errors not returned by a fan-in reader
+// come from the only buffer read, the name of which is given by readerName.
+func ErrBufferName(err error, readerName string) string {
+	var re readErr
+	if errors.As(err, &re) {
+		return re.bufferName
+	}
+	return readerName
+}
+
+// NewWeightedReader returns a WeightedReader. The weights are aligned with the readers, a weight less than 1 is treated as 1.
+func NewWeightedReader(readers []isb.BufferReader, weights []int64) (*WeightedReader, error) {
+	if len(readers) == 0 {
+		return nil, fmt.Errorf("no buffer readers")
+	}
+	if len(weights) != len(readers) {
+		return nil, fmt.Errorf("number of weights %d does not match number of buffer readers %d", len(weights), len(readers))
+	}
+	names := make([]string, 0, len(readers))
+	ws := make([]int64, len(readers))
+	for i, r := range readers {
+		names = append(names, r.GetName())
+		ws[i] = weights[i]
+		if ws[i] < 1 {
+			ws[i] = 1
+		}
+	}
+	return &WeightedReader{
+		name:     strings.Join(names, ","),
+		readers:  readers,
+		weights:  ws,
+		deficits: make([]int64, len(readers)),
+	}, nil
+}
+
+// NewReader returns the only reader if there is just one, otherwise a WeightedReader reading from all of them.
+func NewReader(readers []isb.BufferReader, weights []int64) (isb.BufferReader, error) {
+	if len(readers) == 1 {
+		return readers[0], nil
+	}
+	return NewWeightedReader(readers, weights)
+}
+
+// GetName returns the names of all the underlying buffers, separated by comma, use BufferNames, BufferName and
+// ErrBufferName for the names of the underlying buffers.
+func (wr *WeightedReader) GetName() string {
+	return wr.name
+}
+
+// Read reads up to count messages from the underlying buffers. Each buffer gets a quota proportional to its weight, the
+// quota not used due to the batch being filled up is carried over to the next Read, while a buffer which can not fill
+// its quota loses the rest of it. The buffers are read concurrently, the messages read are returned in the round-robin
+// order together with the first error.
+func (wr *WeightedReader) Read(ctx context.Context, count int64) ([]*isb.ReadMessage, error) {
+	quanta := wr.quanta(count)
+	wants := make([]int64, len(wr.readers))
+	remaining := count
+	for i := 0; i < len(wr.readers); i++ {
+		idx := (wr.next + i) % len(wr.readers)
+		wr.deficits[idx] += quanta[idx]
+		if wr.deficits[idx] > count {
+			wr.deficits[idx] = count
+		}
+		wants[idx] = wr.deficits[idx]
+		if wants[idx] > remaining {
+			wants[idx] = remaining
+		}
+		remaining -= wants[idx]
+	}
+	msgs := make([][]*isb.ReadMessage, len(wr.readers))
+	errs := make([]error, len(wr.readers))
+	var wg sync.WaitGroup
+	for idx := range wr.readers {
+		if wants[idx] <= 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			msgs[idx], errs[idx] = wr.readers[idx].Read(ctx, wants[idx])
+		}(idx)
+	}
+	wg.Wait()
+	result := make([]*isb.ReadMessage, 0, count)
+	var firstErr error
+	for i := 0; i < len(wr.readers); i++ {
+		idx := (wr.next + i) % len(wr.readers)
+		if wants[idx] <= 0 {
+			continue
+		}
+		name := wr.readers[idx].GetName()
+		for _, m := range msgs[idx] {
+			m.ReadOffset = fanInOffset{Offset: m.ReadOffset, readerIdx: idx, bufferName: name}
+			result = append(result, m)
+		}
+		if int64(len(msgs[idx])) < wants[idx] {
+			// the buffer has nothing more to offer
+			wr.deficits[idx] = 0
+		} else {
+			wr.deficits[idx] -= int64(len(msgs[idx]))
+		}
+		if errs[idx] != nil && firstErr == nil {
+			firstErr = readErr{bufferName: name, err: errs[idx]}
+		}
+	}
+	wr.next = (wr.next + 1) % len(wr.readers)
+	return result, firstErr
+}
+
+// quanta returns the read quota of each reader for a batch of count messages, each reader gets at least 1.
+func (wr *WeightedReader) quanta(count int64) []int64 {
+	total := int64(0)
+	for _, w := range wr.weights {
+		total += w
+	}
+	result := make([]int64, len(wr.weights))
+	for i, w := range wr.weights {
+		result[i] = count * w / total
+		if result[i] < 1 {
+			result[i] = 1
+		}
+	}
+	return result
+}
+
+// Ack acknowledges the offsets with the readers they were read from.
+func (wr *WeightedReader) Ack(ctx context.Context, offsets []isb.Offset) []error {
+	errs := make([]error, len(offsets))
+	grouped := make(map[int][]isb.Offset)
+	positions := make(map[int][]int)
+	for i, o := range offsets {
+		fo, ok := o.(fanInOffset)
+		if !ok {
+			errs[i] = isb.MessageAckErr{Name: wr.name, Offset: o, Message: "offset was not read by the fan-in reader"}
+			continue
+		}
+		grouped[fo.readerIdx] = append(grouped[fo.readerIdx], fo.Offset)
+		positions[fo.readerIdx] = append(positions[fo.readerIdx], i)
+	}
+	for idx, os := range grouped {
+		ackErrs := wr.readers[idx].Ack(ctx, os)
+		for j, err := range ackErrs {
+			if j < len(positions[idx]) {
+				errs[positions[idx][j]] = err
+			}
+		}
+	}
+	return errs
+}
+
+// Close closes all the underlying readers.
+func (wr *WeightedReader) Close() error {
+	var errs []string
+	for _, r := range wr.readers {
+		if err := r.Close(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.GetName(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close buffer readers, %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
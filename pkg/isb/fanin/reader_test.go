@@ -0,0 +1,108 @@
+package fanin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/simplebuffer"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testStartTime = time.Unix(1636470000, 0).UTC()
+)
+
+func TestNewWeightedReader(t *testing.T) {
+	_, err := NewWeightedReader(nil, nil)
+	assert.Error(t, err)
+	a := simplebuffer.NewInMemoryBuffer("a", 10)
+	_, err = NewWeightedReader([]isb.BufferReader{a}, []int64{1, 2})
+	assert.Error(t, err)
+	r, err := NewWeightedReader([]isb.BufferReader{a, simplebuffer.NewInMemoryBuffer("b", 10)}, []int64{0, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b", r.GetName())
+	assert.Equal(t, []string{"a", "b"}, BufferNames(r))
+	assert.Equal(t, []string{"a"}, BufferNames(a))
+	assert.Equal(t, []int64{1, 2}, r.weights)
+}
+
+func TestWeightedReader_Read(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	a := simplebuffer.NewInMemoryBuffer("a", 100)
+	b := simplebuffer.NewInMemoryBuffer("b", 100)
+	_, errs := a.Write(ctx, testutils.BuildTestWriteMessages(50, testStartTime))
+	assert.Equal(t, make([]error, 50), errs)
+	_, errs = b.Write(ctx, testutils.BuildTestWriteMessages(50, testStartTime))
+	assert.Equal(t, make([]error, 50), errs)
+
+	r, err := NewWeightedReader([]isb.BufferReader{a, b}, []int64{3, 1})
+	assert.NoError(t, err)
+	msgs, err := r.Read(ctx, 20)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 20)
+	counts := map[int]int{}
+	offsets := []isb.Offset{}
+	for _, m := range msgs {
+		counts[m.ReadOffset.(fanInOffset).readerIdx]++
+		assert.True(t, strings.HasPrefix(m.ReadOffset.String(), m.ReadOffset.(fanInOffset).bufferName+"-"))
+		assert.Equal(t, m.ReadOffset.(fanInOffset).bufferName, BufferName(m.ReadOffset, r.GetName()))
+		offsets = append(offsets, m.ReadOffset)
+	}
+	assert.Equal(t, 15, counts[0])
+	assert.Equal(t, 5, counts[1])
+	assert.Equal(t, make([]error, 20), r.Ack(ctx, offsets))
+
+	errs = r.Ack(ctx, []isb.Offset{isb.SimpleOffset(func() string { return "0" })})
+	assert.Error(t, errs[0])
+	assert.Equal(t, "a", BufferName(isb.SimpleOffset(func() string { return "0" }), "a"))
+	assert.NoError(t, r.Close())
+}
+
+func TestWeightedReader_ReadWithEmptyBuffer(t *testing.T) {
+	ctx := context.Background()
+	a := simplebuffer.NewInMemoryBuffer("a", 100)
+	b := simplebuffer.NewInMemoryBuffer("b", 100)
+	_, errs := a.Write(ctx, testutils.BuildTestWriteMessages(50, testStartTime))
+	assert.Equal(t, make([]error, 50), errs)
+
+	r, err := NewWeightedReader([]isb.BufferReader{a, b}, []int64{1, 1})
+	assert.NoError(t, err)
+	readCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	msgs, err := r.Read(readCtx, 10)
+	// b is empty, the read on it times out
+	assert.Error(t, err)
+	assert.Equal(t, "b", ErrBufferName(err, r.GetName()))
+	assert.Len(t, msgs, 5)
+	assert.Equal(t, int64(0), r.deficits[1])
+}
+
+func TestWeightedReader_ReadConcurrently(t *testing.T) {
+	ctx := context.Background()
+	a := simplebuffer.NewInMemoryBuffer("a", 100)
+	b := simplebuffer.NewInMemoryBuffer("b", 100)
+	c := simplebuffer.NewInMemoryBuffer("c", 100)
+	_, errs := c.Write(ctx, testutils.BuildTestWriteMessages(50, testStartTime))
+	assert.Equal(t, make([]error, 50), errs)
+
+	r, err := NewWeightedReader([]isb.BufferReader{a, b, c}, []int64{1, 1, 1})
+	assert.NoError(t, err)
+	readCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	msgs, err := r.Read(readCtx, 9)
+	// a and b are empty, they time out together
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Error(t, err)
+	assert.Equal(t, "a", ErrBufferName(err, r.GetName()))
+	assert.Len(t, msgs, 3)
+	for _, m := range msgs {
+		assert.Equal(t, "c", BufferName(m.ReadOffset, r.GetName()))
+	}
+	assert.Equal(t, "a,b,c", ErrBufferName(assert.AnError, r.GetName()))
+}
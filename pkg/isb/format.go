@@ -11,7 +11,7 @@ type PaneInfo struct {
 	StartTime time.Time
 	EndTime   time.Time
 	// IsWindow is used to represent whether we have applied window operator.
-	// ATM we do not support window as it requires an implicit reduce in the downstream.
+	// It's set on the aggregates produced by the reduce vertices, StartTime and EndTime being the window.
 	IsWindow bool
 }
 
@@ -22,6 +22,10 @@ type Header struct {
 	ID string
 	// Key is (key,value) in the map-reduce paradigm which will be used for conditional forwarding.
 	Key []byte
+	// ContentEncoding is the encoding of the payload, e.g. gzip, empty means the payload is not compressed.
+	ContentEncoding string
+	// Metadata is the user defined metadata, e.g. correlation IDs, which is carried along with the message across the vertices.
+	Metadata map[string]string `json:",omitempty"`
 }
 
 // Body is the body of the message
@@ -0,0 +1,25 @@
+package forward
+
+import (
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+// NewConditionalForwarder returns the step decider routing the messages of a vertex to its outbound buffers by the
+// keys of the messages. A message goes to the buffers of the edges listing its key in "conditions.keyIn", and the ones
+// without conditions. The keys "ALL" and "DROP" are passed through as they are.
+func NewConditionalForwarder(vertex *dfv1.Vertex) GoWhere {
+	return func(key []byte) ([]string, error) {
+		_key := string(key)
+		if _key == dfv1.MessageKeyAll || _key == dfv1.MessageKeyDrop {
+			return []string{_key}, nil
+		}
+		result := []string{}
+		for _, to := range vertex.Spec.ToVertices {
+			if to.Conditions == nil || len(to.Conditions.KeyIn) == 0 || sharedutil.StringSliceContains(to.Conditions.KeyIn, _key) {
+				result = append(result, vertex.GetToBufferName(to.Name))
+			}
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,49 @@
+package forward
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestNewConditionalForwarder(t *testing.T) {
+	vertex := &dfv1.Vertex{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns"},
+		Spec: dfv1.VertexSpec{
+			AbstractVertex: dfv1.AbstractVertex{Name: "p1"},
+			PipelineName:   "test-pl",
+			ToVertices: []dfv1.ToVertex{
+				{Name: "even", Conditions: &dfv1.ForwardConditions{KeyIn: []string{"even", "zero"}}},
+				{Name: "odd", Conditions: &dfv1.ForwardConditions{KeyIn: []string{"odd"}}},
+				{Name: "log"},
+			},
+		},
+	}
+	even, odd, log := vertex.GetToBufferName("even"), vertex.GetToBufferName("odd"), vertex.GetToBufferName("log")
+	fsd := NewConditionalForwarder(vertex)
+	for _, tc := range []struct {
+		key      string
+		expected []string
+	}{
+		{key: "even", expected: []string{even, log}},
+		{key: "zero", expected: []string{even, log}},
+		{key: "odd", expected: []string{odd, log}},
+		{key: "", expected: []string{log}},
+		{key: "other", expected: []string{log}},
+		{key: dfv1.MessageKeyAll, expected: []string{dfv1.MessageKeyAll}},
+		{key: dfv1.MessageKeyDrop, expected: []string{dfv1.MessageKeyDrop}},
+	} {
+		to, err := fsd.WhereTo([]byte(tc.key))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, to, tc.key)
+	}
+
+	// the messages with keys matching no conditions go nowhere if all the edges have conditions
+	vertex.Spec.ToVertices = vertex.Spec.ToVertices[:2]
+	to, err := NewConditionalForwarder(vertex).WhereTo([]byte("other"))
+	assert.NoError(t, err)
+	assert.Empty(t, to)
+}
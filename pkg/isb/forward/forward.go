@@ -5,19 +5,29 @@ package forward
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+	"github.com/numaproj/numaflow/pkg/isb/fanin"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/metrics"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
 	udfapplier "github.com/numaproj/numaflow/pkg/udf/applier"
 )
 
+// maxRetryInterval is the max interval of retrying the buffer operations failed with the non-retryable errors.
+const maxRetryInterval = 5 * time.Second
+
 // InterStepDataForward forwards the data from previous step to the current step via inter-step buffer.
 type InterStepDataForward struct {
 	// I have my reasons for overriding the default principle https://github.com/golang/go/issues/22602
@@ -32,6 +42,12 @@ type InterStepDataForward struct {
 	opts         options
 	vertexName   string
 	pipelineName string
+	// startTime is the time the forwarder starts, used to calculate the read batch size during slow start
+	startTime time.Time
+	// streamLock serializes the writes of the outputs streamed by the UDF processors
+	streamLock sync.Mutex
+	// lastProgress is the unix nano time of the last progress made by the forwarder, 0 if it's not running
+	lastProgress *atomic.Int64
 	Shutdown
 }
 
@@ -54,6 +70,12 @@ func NewInterStepDataForward(vertex *dfv1.Vertex,
 			return nil, err
 		}
 	}
+	if _, ok := applyUDF.(udfapplier.BatchApplier); options.udfBatch && !ok {
+		return nil, fmt.Errorf("the UDF does not support the batch mode")
+	}
+	if _, ok := applyUDF.(udfapplier.StreamApplier); options.udfStreamChunkSize > 0 && !ok {
+		return nil, fmt.Errorf("the UDF does not support streaming")
+	}
 	// creating a context here which is managed by the forwarder's lifecycle
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -67,6 +89,7 @@ func NewInterStepDataForward(vertex *dfv1.Vertex,
 		// should we do a check here for the values not being null?
 		vertexName:   vertex.Spec.Name,
 		pipelineName: vertex.Spec.PipelineName,
+		lastProgress: atomic.NewInt64(0),
 		Shutdown: Shutdown{
 			rwlock: new(sync.RWMutex),
 		},
@@ -83,12 +106,16 @@ func NewInterStepDataForward(vertex *dfv1.Vertex,
 func (isdf *InterStepDataForward) Start() <-chan struct{} {
 	log := logging.FromContext(isdf.ctx)
 	stopped := make(chan struct{})
+	isdf.startTime = time.Now()
+	isdf.progress()
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		log.Info("Starting forwarder...")
 		// with wg approach can do more cleanup in case we need in the future.
 		defer wg.Done()
+		// a stopped forwarder is not stuck
+		defer isdf.lastProgress.Store(0)
 		for {
 			select {
 			case <-isdf.ctx.Done():
@@ -125,6 +152,13 @@ func (isdf *InterStepDataForward) Start() <-chan struct{} {
 				log.Infow("Closed buffer writer", zap.String("bufferTo", v.GetName()))
 			}
 		}
+		for _, dlq := range isdf.opts.deadLetterQueues {
+			if err := dlq.writer.Close(); err != nil {
+				log.Errorw("Failed to close dead-letter buffer writer, shutdown anyways...", zap.Error(err), zap.String("bufferTo", dlq.writer.GetName()))
+			} else {
+				log.Infow("Closed dead-letter buffer writer", zap.String("bufferTo", dlq.writer.GetName()))
+			}
+		}
 		close(stopped)
 	}()
 
@@ -136,6 +170,24 @@ type readWriteMessagePair struct {
 	readMessage   *isb.ReadMessage
 	writeMessages []*isb.Message
 	udfError      error
+	// deadLetter is set if the UDF keeps failing on the read message, which is written to the dead-letter buffer instead
+	deadLetter *deadLetterError
+	// trace is the trace of the read message, nil if it is not traced
+	trace *telemetry.MessageTrace
+}
+
+// deadLetterError is the error of a message which fails the UDF more times than the retries of its dead-letter queue.
+type deadLetterError struct {
+	err     error
+	retries int
+}
+
+func (e *deadLetterError) Error() string {
+	return fmt.Sprintf("dead-lettered after %d retries, %v", e.retries, e.err)
+}
+
+func (e *deadLetterError) Unwrap() error {
+	return e.err
 }
 
 // forwardAChunk forwards a chunk of message from the fromBuffer to the toBuffers. It does the Read -> Process -> Forward -> Ack chain
@@ -147,18 +199,49 @@ func (isdf *InterStepDataForward) forwardAChunk(ctx context.Context) {
 	// There is a chance that we have read the message and the container got forcefully terminated before processing. To provide
 	// at-least-once semantics for reading, during restart we will have to reprocess all unacknowledged messages. It is the
 	// responsibility of the Read function to do that.
-	readMessages, err := isdf.fromBuffer.Read(ctx, isdf.opts.readBatchSize)
+	readMessages, err := isdf.fromBuffer.Read(ctx, isdf.currentReadBatchSize())
+	readEnd := time.Now()
+	isdf.progress()
+	readCounts := isdf.countByFromBuffer(readOffsetsOf(readMessages))
+	for from := range readCounts {
+		metrics.ObserveWithID(readProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}), float64(time.Since(start).Microseconds()), readMessages[0].ID)
+	}
 	if err != nil {
 		isdf.opts.logger.Warnw("failed to read fromBuffer", zap.Error(err))
-		readMessagesError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBuffer.GetName()}).Inc()
+		readMessagesError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": fanin.ErrBufferName(err, isdf.fromBuffer.GetName()), "category": string(isberrors.CategoryOf(err))}).Inc()
+	}
+	for _, from := range fanin.BufferNames(isdf.fromBuffer) {
+		readMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Add(float64(readCounts[from]))
+	}
+	if len(isdf.opts.maxMessageAges) > 0 && len(readMessages) > 0 {
+		readMessages = isdf.dropExpired(ctx, readMessages)
 	}
-	readMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBuffer.GetName()}).Add(float64(len(readMessages)))
 
 	// process only if we have any read messages. There is a natural looping here if there is an internal error while
 	// reading, and we are not able to proceed.
 	if len(readMessages) == 0 {
 		return
 	}
+	var readTenants map[string]int
+	if isdf.opts.tenantKey != "" {
+		readTenants = make(map[string]int)
+		type bufferTenant struct{ buffer, tenant string }
+		bufferTenants := make(map[bufferTenant]int)
+		for _, m := range readMessages {
+			readTenants[m.Metadata[isdf.opts.tenantKey]]++
+			bufferTenants[bufferTenant{buffer: isdf.fromBufferName(m.ReadOffset), tenant: m.Metadata[isdf.opts.tenantKey]}]++
+		}
+		for bt, n := range bufferTenants {
+			tenantReadMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": bt.buffer, "tenant": bt.tenant}).Add(float64(n))
+		}
+	}
+	// the messages are already read, so they are forwarded anyway if the context is done while waiting.
+	if isdf.opts.rateLimiter != nil {
+		isdf.waitRateLimiter(ctx, len(readMessages), readTenants)
+	}
+	if isdf.opts.auditRecorder != nil {
+		isdf.opts.auditRecorder.Stamp(readMessages)
+	}
 	// create space for writeMessages specific to each step as we could forward to all the steps too.
 	var messageToStep = make(map[string][]isb.Message)
 	var toBuffers string
@@ -168,88 +251,277 @@ func (isdf *InterStepDataForward) forwardAChunk(ctx context.Context) {
 		toBuffers += step
 	}
 
-	// udf concurrent processing request channel
-	udfCh := make(chan *readWriteMessagePair)
 	// udfResults stores the results after UDF processing for all read messages. It indexes
 	// a read message to the corresponding write message
 	udfResults := make([]readWriteMessagePair, len(readMessages))
-	// applyUDF, if there is an Internal error it is a blocking call and will return only if shutdown has been initiated.
-
-	// create a pool of UDF Processors
-	var wg sync.WaitGroup
-	for i := 0; i < isdf.opts.udfConcurrency; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			isdf.concurrentApplyUDF(ctx, udfCh)
-		}()
-	}
-	concurrentUDFProcessingStart := time.Now()
-	// send UDF processing work to the channel
 	for idx, readMessage := range readMessages {
 		udfResults[idx].readMessage = readMessage
-		udfCh <- &udfResults[idx]
 	}
-	// let the go routines know that there is no more work
-	close(udfCh)
-	// wait till the processing is done. this will not be an infinite wait because the UDF processing will exit if
-	// context.Done() is closed.
-	wg.Wait()
-	isdf.opts.logger.Debugw("concurrent applyUDF completed", zap.Int("concurrency", isdf.opts.udfConcurrency), zap.Duration("took", time.Since(concurrentUDFProcessingStart)))
-	concurrentUDFProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBuffer.GetName()}).Observe(float64(time.Since(concurrentUDFProcessingStart).Microseconds()))
+	if isdf.opts.tracer != nil {
+		for idx, trace := range isdf.opts.tracer.StartMessages(readMessages, start, readEnd) {
+			udfResults[idx].trace = trace
+		}
+	}
+	// applyUDF, if there is an Internal error it is a blocking call and will return only if shutdown has been initiated.
+	if isdf.opts.udfBatch {
+		isdf.batchApplyUDF(ctx, udfResults)
+	} else {
+		isdf.poolApplyUDF(ctx, udfResults)
+	}
+	// the dead-lettered messages are acked along with the others once they are in the dead-letter buffers.
+	if err := isdf.writeToDeadLetterQueues(ctx, udfResults); err != nil {
+		isdf.opts.logger.Errorw("failed to write to dead-letter buffers", zap.Error(err))
+		return
+	}
 
 	// Now that we know the UDF processing is done, let's figure out which vertex to send the results to.
 	// Update the toBuffer(s) with writeMessages.
+	var writeSpans []*telemetry.Span
 	for _, m := range udfResults {
 		// look for errors in udf processing, if we see even 1 error let's return. handling partial retrying is not worth ATM.
 		if m.udfError != nil {
-			udfError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBuffer.GetName()}).Inc()
+			udfError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBufferName(m.readMessage.ReadOffset)}).Inc()
 			isdf.opts.logger.Errorw("failed to applyUDF", zap.Error(err))
+			finishSpans(writeSpans, m.udfError)
 			return
 		}
+		if m.trace != nil && len(m.writeMessages) > 0 {
+			span := m.trace.StartWrite()
+			span.Inject(m.writeMessages)
+			writeSpans = append(writeSpans, span)
+		}
 		// update toBuffers
 		for _, message := range m.writeMessages {
 			if err := isdf.whereToStep(message, messageToStep, m.readMessage); err != nil {
 				isdf.opts.logger.Errorw("failed in whereToStep", zap.Error(err))
+				finishSpans(writeSpans, err)
 				return
 			}
 		}
 	}
 	// forward the message to the edge buffer (could be multiple edges)
 	_, err = isdf.writeToBuffers(ctx, messageToStep)
+	finishSpans(writeSpans, err)
 	if err != nil {
 		isdf.opts.logger.Errorw("failed to write to toBuffers", zap.Error(err))
 		return
 	}
 
+	if isdf.opts.auditRecorder != nil {
+		isdf.recordAudit(ctx, udfResults)
+	}
+
 	// let us ack the only if we have successfully forwarded all the messages.
 	// we need the readOffsets to acknowledge later
-	var readOffsets = make([]isb.Offset, len(readMessages))
-	for idx, m := range udfResults {
-		readOffsets[idx] = m.readMessage.ReadOffset
-	}
+	var readOffsets = readOffsetsOfPairs(udfResults)
 	err = isdf.ackFromBuffer(ctx, readOffsets)
 	// implicit return for posterity :-)
 	if err != nil {
 		isdf.opts.logger.Errorw("failed to ack from buffer", zap.Error(err))
-		ackMessageError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBuffer.GetName()}).Add(float64(len(readOffsets)))
 		return
 	}
-	ackMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBuffer.GetName()}).Add(float64(len(readOffsets)))
+	ackCounts := isdf.countByFromBuffer(readOffsets)
+	for from, n := range ackCounts {
+		ackMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Add(float64(n))
+	}
 
 	// ProcessingTimes of the entire forwardAChunk
-	forwardAChunkProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "from": isdf.fromBuffer.GetName(), "to": toBuffers}).Observe(float64(time.Since(start).Microseconds()))
+	for from := range ackCounts {
+		forwardAChunkProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "from": from, "to": toBuffers}).Observe(float64(time.Since(start).Microseconds()))
+	}
+}
+
+// writeToDeadLetterQueues writes the dead-lettered messages to the dead-letter buffers of the buffers they are read from.
+// The messages are written as they are read, with the failure recorded in the metadata. It's a blocking call until
+// all the messages are written, or a shutdown has been initiated.
+func (isdf *InterStepDataForward) writeToDeadLetterQueues(ctx context.Context, udfResults []readWriteMessagePair) error {
+	var deadLetters map[string][]isb.Message
+	for _, m := range udfResults {
+		if m.deadLetter == nil {
+			continue
+		}
+		if deadLetters == nil {
+			deadLetters = make(map[string][]isb.Message)
+		}
+		message := m.readMessage.Message
+		metadata := make(map[string]string, len(message.Metadata)+3)
+		for k, v := range message.Metadata {
+			metadata[k] = v
+		}
+		metadata[dfv1.DeadLetterErrorKey] = m.deadLetter.err.Error()
+		metadata[dfv1.DeadLetterVertexKey] = isdf.vertexName
+		metadata[dfv1.DeadLetterRetriesKey] = strconv.Itoa(m.deadLetter.retries)
+		message.Metadata = metadata
+		from := isdf.fromBufferName(m.readMessage.ReadOffset)
+		deadLetters[from] = append(deadLetters[from], message)
+	}
+	for from, messages := range deadLetters {
+		dlq := isdf.opts.deadLetterQueues[from]
+		if _, err := isdf.writeToBuffer(ctx, dlq.writer, messages); err != nil {
+			return err
+		}
+		deadLetterMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Add(float64(len(messages)))
+	}
+	return nil
+}
+
+// dropExpired acknowledges the read messages older than the max message ages of the buffers they are read from by their
+// event time, and returns the others to be processed. If the acks fail, the expired messages are redelivered and
+// dropped again.
+func (isdf *InterStepDataForward) dropExpired(ctx context.Context, readMessages []*isb.ReadMessage) []*isb.ReadMessage {
+	now := time.Now()
+	kept := make([]*isb.ReadMessage, 0, len(readMessages))
+	var expired []isb.Offset
+	expiredCounts := make(map[string]int)
+	for _, m := range readMessages {
+		from := isdf.fromBufferName(m.ReadOffset)
+		if age, ok := isdf.opts.maxMessageAges[from]; ok && !m.EventTime.IsZero() && now.Sub(m.EventTime) > age {
+			expired = append(expired, m.ReadOffset)
+			expiredCounts[from]++
+			continue
+		}
+		kept = append(kept, m)
+	}
+	if len(expired) == 0 {
+		return readMessages
+	}
+	if err := isdf.ackFromBuffer(ctx, expired); err != nil {
+		isdf.opts.logger.Errorw("failed to ack the expired messages", zap.Error(err))
+		return kept
+	}
+	for from, n := range expiredCounts {
+		ackMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Add(float64(n))
+		expiredMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Add(float64(n))
+	}
+	return kept
+}
+
+// recordAudit records the audit IDs of the forwarded messages, the dead-lettered ones are left out. A failure is only
+// logged, so that the processing is not held up by the audit log.
+func (isdf *InterStepDataForward) recordAudit(ctx context.Context, udfResults []readWriteMessagePair) {
+	messages := make([]*isb.ReadMessage, 0, len(udfResults))
+	for _, m := range udfResults {
+		if m.deadLetter == nil {
+			messages = append(messages, m.readMessage)
+		}
+	}
+	if err := isdf.opts.auditRecorder.Record(ctx, messages); err != nil {
+		isdf.opts.logger.Errorw("failed to record the audit IDs", zap.Error(err))
+	}
+}
+
+// finishSpans ends the spans with the error, nil if they succeed.
+func finishSpans(spans []*telemetry.Span, err error) {
+	for _, s := range spans {
+		s.Finish(err)
+	}
+}
+
+// deadLetterQueueOf returns the dead-letter queue of the buffer the message is read from, nil if there is none.
+func (isdf *InterStepDataForward) deadLetterQueueOf(readMessage *isb.ReadMessage) *deadLetterQueue {
+	if len(isdf.opts.deadLetterQueues) == 0 {
+		return nil
+	}
+	dlq, ok := isdf.opts.deadLetterQueues[isdf.fromBufferName(readMessage.ReadOffset)]
+	if !ok {
+		return nil
+	}
+	return &dlq
+}
+
+// waitRateLimiter waits for the read messages to be admitted by the rate limiter. The messages of the tenants are
+// admitted separately and concurrently if the rate limiter is a TenantRateLimiter, so the whole batch waits for the
+// most throttled tenant in it.
+func (isdf *InterStepDataForward) waitRateLimiter(ctx context.Context, n int, tenants map[string]int) {
+	l, ok := isdf.opts.rateLimiter.(TenantRateLimiter)
+	if !ok || tenants == nil {
+		if err := isdf.opts.rateLimiter.Wait(ctx, n); err != nil {
+			isdf.opts.logger.Warnw("failed to wait for the rate limiter", zap.Error(err))
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for tenant, n := range tenants {
+		wg.Add(1)
+		go func(tenant string, n int) {
+			defer wg.Done()
+			if err := l.WaitTenant(ctx, tenant, n); err != nil {
+				isdf.opts.logger.Warnw("failed to wait for the rate limiter", zap.String("tenant", tenant), zap.Error(err))
+			}
+		}(tenant, n)
+	}
+	wg.Wait()
+}
+
+// currentReadBatchSize returns the read batch size for the next read. With slow start enabled, it grows linearly from the
+// initial read batch size to the configured read batch size within the slow start duration.
+func (isdf *InterStepDataForward) currentReadBatchSize() int64 {
+	if isdf.opts.slowStartDuration <= 0 || isdf.opts.initialReadBatchSize >= isdf.opts.readBatchSize {
+		return isdf.opts.readBatchSize
+	}
+	elapsed := time.Since(isdf.startTime)
+	if elapsed >= isdf.opts.slowStartDuration {
+		return isdf.opts.readBatchSize
+	}
+	delta := float64(isdf.opts.readBatchSize-isdf.opts.initialReadBatchSize) * float64(elapsed) / float64(isdf.opts.slowStartDuration)
+	return isdf.opts.initialReadBatchSize + int64(delta)
+}
+
+// fromBufferName returns the name of the buffer a read offset belongs to, which is used as the buffer label of the
+// metrics, so that the buffers read by a fan-in reader are labelled on their own.
+func (isdf *InterStepDataForward) fromBufferName(offset isb.Offset) string {
+	return fanin.BufferName(offset, isdf.fromBuffer.GetName())
+}
+
+// countByFromBuffer returns the number of the offsets read from each buffer.
+func (isdf *InterStepDataForward) countByFromBuffer(offsets []isb.Offset) map[string]int {
+	counts := make(map[string]int)
+	for _, o := range offsets {
+		counts[isdf.fromBufferName(o)]++
+	}
+	return counts
+}
+
+func readOffsetsOf(messages []*isb.ReadMessage) []isb.Offset {
+	offsets := make([]isb.Offset, len(messages))
+	for i, m := range messages {
+		offsets[i] = m.ReadOffset
+	}
+	return offsets
+}
+
+func readOffsetsOfPairs(pairs []readWriteMessagePair) []isb.Offset {
+	offsets := make([]isb.Offset, len(pairs))
+	for i, p := range pairs {
+		offsets[i] = p.readMessage.ReadOffset
+	}
+	return offsets
 }
 
 // ackFromBuffer acknowledges an array of offsets back to fromBuffer and is a blocking call or until shutdown has been initiated.
 func (isdf *InterStepDataForward) ackFromBuffer(ctx context.Context, offsets []isb.Offset) (err error) {
+	start := time.Now()
+	defer func() {
+		if err == nil {
+			for from := range isdf.countByFromBuffer(offsets) {
+				ackProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Observe(float64(time.Since(start).Microseconds()))
+			}
+		}
+	}()
+	interval := isdf.opts.retryInterval
 	for {
 		errs := isdf.fromBuffer.Ack(ctx, offsets)
+		isdf.progress()
 		summarizedErr := errorArrayToMap(errs)
 		if len(summarizedErr) > 0 {
 			isdf.opts.logger.Errorw("failed to ack from buffer", zap.Any("errors", summarizedErr))
-			// TODO: implement retry with backoff etc.
-			time.Sleep(isdf.opts.retryInterval)
+			for i, err := range errs {
+				if err != nil {
+					ackMessageError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBufferName(offsets[i]), "category": string(isberrors.CategoryOf(err))}).Inc()
+				}
+			}
+			interval = isdf.nextRetryInterval(interval, errs)
+			time.Sleep(interval)
 			if ok, _ := isdf.IsShuttingDown(); ok {
 				err := fmt.Errorf("ackFromBuffer, Stop called while stuck on an internal error, %v", summarizedErr)
 				return err
@@ -271,6 +543,15 @@ func (isdf *InterStepDataForward) writeToBuffers(ctx context.Context, messageToS
 		if err != nil {
 			return writeOffsetsEdge, err
 		}
+		if isdf.opts.tenantKey != "" {
+			writeTenants := make(map[string]int)
+			for _, m := range messageToStep[key] {
+				writeTenants[m.Metadata[isdf.opts.tenantKey]]++
+			}
+			for tenant, n := range writeTenants {
+				tenantWriteMessagesCount.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": toBuffer.GetName(), "tenant": tenant}).Add(float64(n))
+			}
+		}
 	}
 
 	return writeOffsetsEdge, nil
@@ -279,10 +560,22 @@ func (isdf *InterStepDataForward) writeToBuffers(ctx context.Context, messageToS
 // writeToBuffer forwards an array of messages to a single buffer and is a blocking call or until shutdown has been initiated.
 func (isdf *InterStepDataForward) writeToBuffer(ctx context.Context, toBuffer isb.BufferWriter, messages []isb.Message) (writeOffsets []isb.Offset, err error) {
 	writeOffsets = make([]isb.Offset, 0, len(messages))
+	isdf.opts.traceRecorders.Record(toBuffer.GetName(), messages)
+	if len(messages) > 0 {
+		// the messages are replaced by the failed ones while retrying
+		start, id := time.Now(), messages[0].ID
+		defer func() {
+			if err == nil {
+				metrics.ObserveWithID(writeProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": toBuffer.GetName()}), float64(time.Since(start).Microseconds()), id)
+			}
+		}()
+	}
+	interval := isdf.opts.retryInterval
 retry:
 	needRetry := false
 	for {
 		_writeOffsets, errs := toBuffer.Write(ctx, messages)
+		isdf.progress()
 		// Note: this is an unwanted memory allocation during a happy path. We want only minimal allocation since using failedMessages is an unlikely path.
 		var failedMessages []isb.Message
 		for idx := range messages {
@@ -293,7 +586,7 @@ retry:
 				needRetry = true
 				// we retry only failed messages
 				failedMessages = append(failedMessages, messages[idx])
-				writeMessagesError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": toBuffer.GetName()}).Inc()
+				writeMessagesError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": toBuffer.GetName(), "category": string(isberrors.CategoryOf(err))}).Inc()
 				// a shutdown can break the blocking loop caused due to InternalErr
 				if ok, _ := isdf.IsShuttingDown(); ok {
 					err := fmt.Errorf("writeToBuffer failed, Stop called while stuck on an internal error with failed messages:%d, %v", len(failedMessages), errs)
@@ -311,8 +604,8 @@ retry:
 		if needRetry {
 			isdf.opts.logger.Errorw("Retrying failed msgs", zap.Any("errors", errorArrayToMap(errs)))
 			messages = failedMessages
-			// TODO: implement retry with backoff etc.
-			time.Sleep(isdf.opts.retryInterval)
+			interval = isdf.nextRetryInterval(interval, errs)
+			time.Sleep(interval)
 			goto retry
 		} else {
 			break
@@ -324,25 +617,139 @@ retry:
 	return writeOffsets, nil
 }
 
+// poolApplyUDF applies the UDF on the read messages one by one with a pool of UDF processors.
+func (isdf *InterStepDataForward) poolApplyUDF(ctx context.Context, udfResults []readWriteMessagePair) {
+	// udf concurrent processing request channel
+	udfCh := make(chan *readWriteMessagePair)
+	// create a pool of UDF Processors
+	var wg sync.WaitGroup
+	for i := 0; i < isdf.opts.udfConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			isdf.concurrentApplyUDF(ctx, udfCh)
+		}()
+	}
+	concurrentUDFProcessingStart := time.Now()
+	// send UDF processing work to the channel
+	for idx := range udfResults {
+		udfCh <- &udfResults[idx]
+	}
+	// let the go routines know that there is no more work
+	close(udfCh)
+	// wait till the processing is done. this will not be an infinite wait because the UDF processing will exit if
+	// context.Done() is closed.
+	wg.Wait()
+	isdf.opts.logger.Debugw("concurrent applyUDF completed", zap.Int("concurrency", isdf.opts.udfConcurrency), zap.Duration("took", time.Since(concurrentUDFProcessingStart)))
+	for from := range isdf.countByFromBuffer(readOffsetsOfPairs(udfResults)) {
+		concurrentUDFProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Observe(float64(time.Since(concurrentUDFProcessingStart).Microseconds()))
+	}
+}
+
+// batchApplyUDF applies the UDF on all the read messages in one call. Same as applyUDF, it will block if there is any
+// InternalErr, until shutdown has been initiated. With dead-letter queues, the UDF is applied on the messages one by one
+// once the batch fails more than the least retries of the dead-letter queues, so that only the failing ones are dead-lettered.
+func (isdf *InterStepDataForward) batchApplyUDF(ctx context.Context, udfResults []readWriteMessagePair) {
+	start := time.Now()
+	readMessages := make([]*isb.ReadMessage, len(udfResults))
+	for idx := range udfResults {
+		readMessages[idx] = udfResults[idx].readMessage
+	}
+	spans := make([]*telemetry.Span, len(udfResults))
+	for idx := range udfResults {
+		spans[idx] = udfResults[idx].trace.StartUDF()
+	}
+	batchApplier := isdf.UDF.(udfapplier.BatchApplier)
+	maxRetries := -1
+	for _, dlq := range isdf.opts.deadLetterQueues {
+		if maxRetries < 0 || dlq.maxRetries < maxRetries {
+			maxRetries = dlq.maxRetries
+		}
+	}
+	for retries := 0; ; retries++ {
+		callStart := time.Now()
+		writeMessages, err := batchApplier.ApplyBatch(ctx, readMessages)
+		isdf.observeUDFCall(udfModeBatch, callStart, err)
+		if err != nil {
+			isdf.opts.logger.Errorw("UDF.ApplyBatch error", zap.Error(err))
+			if maxRetries >= 0 && retries >= maxRetries {
+				isdf.opts.logger.Warnw("UDF.ApplyBatch keeps failing, applying the UDF on the messages one by one", zap.Int("retries", retries))
+				finishSpans(spans, err)
+				isdf.poolApplyUDF(ctx, udfResults)
+				return
+			}
+			time.Sleep(isdf.opts.retryInterval)
+			if ok, _ := isdf.IsShuttingDown(); ok {
+				isdf.opts.logger.Errorw("UDF.ApplyBatch, Stop called while stuck on an internal error", zap.Error(err))
+				platformError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName}).Inc()
+				for idx := range udfResults {
+					udfResults[idx].udfError = err
+				}
+				finishSpans(spans, err)
+				return
+			}
+			continue
+		}
+		for idx := range udfResults {
+			// if we do not get a time from UDF, we set it to the time from (N-1)th vertex
+			for _, m := range writeMessages[idx] {
+				if m.EventTime.IsZero() {
+					m.EventTime = udfResults[idx].readMessage.EventTime
+				}
+			}
+			udfResults[idx].writeMessages = append(udfResults[idx].writeMessages, writeMessages[idx]...)
+		}
+		finishSpans(spans, nil)
+		break
+	}
+	isdf.opts.logger.Debugw("batch applyUDF completed", zap.Int("messages", len(readMessages)), zap.Duration("took", time.Since(start)))
+	for from := range isdf.countByFromBuffer(readOffsetsOfPairs(udfResults)) {
+		concurrentUDFProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": from}).Observe(float64(time.Since(start).Microseconds()))
+	}
+}
+
 // concurrentApplyUDF applies the UDF based on the request from the channel
 func (isdf *InterStepDataForward) concurrentApplyUDF(ctx context.Context, readMessagePair <-chan *readWriteMessagePair) {
 	for message := range readMessagePair {
 		start := time.Now()
-		writeMessages, err := isdf.applyUDF(ctx, message.readMessage)
+		span := message.trace.StartUDF()
+		writeMessages, err := isdf.applyUDF(ctx, message.readMessage, message.trace)
+		span.Finish(err)
 		message.writeMessages = append(message.writeMessages, writeMessages...)
-		message.udfError = err
-		udfProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBuffer.GetName()}).Observe(float64(time.Since(start).Microseconds()))
+		var dle *deadLetterError
+		if errors.As(err, &dle) {
+			message.deadLetter = dle
+		} else {
+			message.udfError = err
+		}
+		metrics.ObserveWithID(udfProcessingTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "buffer": isdf.fromBufferName(message.readMessage.ReadOffset)}), float64(time.Since(start).Microseconds()), message.readMessage.ID)
 	}
 }
 
 // applyUDF applies the UDF and will block if there is any InternalErr. On the other hand, if this is an UserError
 // the skip flag is set. ShutDown flag will only if there is an InternalErr and ForceStop has been invoked.
-// The UserError retry will be done on the ApplyUDF.
-func (isdf *InterStepDataForward) applyUDF(ctx context.Context, readMessage *isb.ReadMessage) ([]*isb.Message, error) {
-	for {
-		writeMessages, err := isdf.UDF.Apply(ctx, readMessage)
+// The UserError retry will be done on the ApplyUDF. If the message has a dead-letter queue, a deadLetterError is returned
+// once the retries are used up. The trace of the read message is only used by the streamed outputs written right away.
+func (isdf *InterStepDataForward) applyUDF(ctx context.Context, readMessage *isb.ReadMessage, trace *telemetry.MessageTrace) ([]*isb.Message, error) {
+	dlq := isdf.deadLetterQueueOf(readMessage)
+	for retries := 0; ; retries++ {
+		var writeMessages []*isb.Message
+		var err error
+		callStart := time.Now()
+		if isdf.opts.udfStreamChunkSize > 0 {
+			err = isdf.UDF.(udfapplier.StreamApplier).ApplyStream(ctx, readMessage, isdf.opts.udfStreamChunkSize, func(chunk []*isb.Message) error {
+				return isdf.writeStreamChunk(ctx, readMessage, trace, chunk)
+			})
+			isdf.observeUDFCall(udfModeStream, callStart, err)
+		} else {
+			writeMessages, err = isdf.UDF.Apply(ctx, readMessage)
+			isdf.observeUDFCall(udfModeMap, callStart, err)
+		}
 		if err != nil {
 			isdf.opts.logger.Errorw("UDF.Apply error", zap.Error(err))
+			if dlq != nil && retries >= dlq.maxRetries {
+				return nil, &deadLetterError{err: err, retries: retries}
+			}
 			// TODO: implement retry with backoff etc.
 			time.Sleep(isdf.opts.retryInterval)
 			// keep retrying, I cannot think of a use case where a user could say, errors are fine :-)
@@ -366,6 +773,49 @@ func (isdf *InterStepDataForward) applyUDF(ctx context.Context, readMessage *isb
 	}
 }
 
+// the modes of calling the UDF, the label of the UDF invocation metrics
+const (
+	udfModeMap    = "map"
+	udfModeBatch  = "batch"
+	udfModeStream = "stream"
+)
+
+// observeUDFCall records the latency of a call to the UDF, and the class of the error if it fails. The latency of a
+// streaming call includes writing the outputs streamed back.
+func (isdf *InterStepDataForward) observeUDFCall(mode string, start time.Time, err error) {
+	isdf.progress()
+	udfInvocationTime.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "mode": mode}).Observe(float64(time.Since(start).Microseconds()))
+	if err != nil {
+		udfInvocationError.With(map[string]string{"vertex": isdf.vertexName, "pipeline": isdf.pipelineName, "mode": mode, "class": string(udfapplier.ClassifyError(err))}).Inc()
+	}
+}
+
+// writeStreamChunk writes a chunk of the outputs streamed by the UDF to the toBuffers right away, so the outputs already
+// written are not returned to forwardAChunk. If the UDF fails in the middle of the stream, the outputs written before
+// the failure are written again on the retry, which are deduplicated by their IDs.
+func (isdf *InterStepDataForward) writeStreamChunk(ctx context.Context, readMessage *isb.ReadMessage, trace *telemetry.MessageTrace, chunk []*isb.Message) error {
+	messageToStep := make(map[string][]isb.Message, len(isdf.toBuffers))
+	for step := range isdf.toBuffers {
+		messageToStep[step] = make([]isb.Message, 0, len(chunk))
+	}
+	span := trace.StartWrite()
+	span.Inject(chunk)
+	for _, m := range chunk {
+		if m.EventTime.IsZero() {
+			m.EventTime = readMessage.EventTime
+		}
+		if err := isdf.whereToStep(m, messageToStep, readMessage); err != nil {
+			return err
+		}
+	}
+	// the buffer writers are shared by the UDF processors
+	isdf.streamLock.Lock()
+	defer isdf.streamLock.Unlock()
+	_, err := isdf.writeToBuffers(ctx, messageToStep)
+	span.Finish(err)
+	return err
+}
+
 // whereToStep executes the WhereTo interfaces and then updates the to step's writeToBuffers buffer.
 func (isdf *InterStepDataForward) whereToStep(writeMessage *isb.Message, messageToStep map[string][]isb.Message, readMessage *isb.ReadMessage) error {
 	// call WhereTo and drop it on errors
@@ -402,6 +852,23 @@ func (isdf *InterStepDataForward) whereToStep(writeMessage *isb.Message, message
 	return nil
 }
 
+// nextRetryInterval returns the interval before retrying the failed buffer operation. It's the configured retry interval
+// if all the errors are retryable, otherwise the last interval is doubled up to maxRetryInterval.
+func (isdf *InterStepDataForward) nextRetryInterval(last time.Duration, errs []error) time.Duration {
+	for _, err := range errs {
+		if err != nil && !isberrors.IsRetryable(err) {
+			if next := 2 * last; next < maxRetryInterval {
+				return next
+			}
+			if isdf.opts.retryInterval > maxRetryInterval {
+				return isdf.opts.retryInterval
+			}
+			return maxRetryInterval
+		}
+	}
+	return isdf.opts.retryInterval
+}
+
 // errorArrayToMap summarizes an error array to map
 func errorArrayToMap(errs []error) map[string]int64 {
 	result := make(map[string]int64)
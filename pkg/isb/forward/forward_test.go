@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,8 +14,13 @@ import (
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+	"github.com/numaproj/numaflow/pkg/isb/fanin"
 	"github.com/numaproj/numaflow/pkg/isb/simplebuffer"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
 	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
 	udfapplier "github.com/numaproj/numaflow/pkg/udf/applier"
 	"github.com/stretchr/testify/assert"
 )
@@ -83,6 +89,160 @@ func TestNewInterStepDataForward(t *testing.T) {
 	<-stopped
 }
 
+func TestNewInterStepDataForward_WithTraceRecorders(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	recorders := tracing.Recorders{"to1": tracing.NewRecorder("pod-0", "to1", dfv1.EdgeTrace{SampleRate: "1", CapturePayload: true})}
+	f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(5), WithTraceRecorders(recorders))
+	assert.NoError(t, err)
+	stopped := f.Start()
+	writeMessages := testutils.BuildTestWriteMessages(int64(5), testStartTime)
+	_, errs := fromStep.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+	readMessages, err := to1.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+
+	records := recorders["to1"].Records()
+	assert.Len(t, records, 5)
+	assert.Equal(t, readMessages[0].ID, records[0].ID)
+	assert.Equal(t, readMessages[0].Payload, records[0].Payload)
+	f.Stop()
+	<-stopped
+}
+
+type auditTestLog struct {
+	lock    sync.Mutex
+	records []audit.Record
+}
+
+func (l *auditTestLog) Append(_ context.Context, r audit.Record) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.records = append(l.records, r)
+	return nil
+}
+
+func (l *auditTestLog) ids() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	var result []string
+	for _, r := range l.records {
+		result = append(result, r.IDs...)
+	}
+	return result
+}
+
+func TestNewInterStepDataForward_WithAuditRecorder(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name:   "testVertex",
+			Source: &dfv1.Source{},
+		},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	log := &auditTestLog{}
+	f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(5), WithAuditRecorder(audit.NewRecorder(vertex, "pod-0", log)))
+	assert.NoError(t, err)
+	stopped := f.Start()
+	writeMessages := testutils.BuildTestWriteMessages(int64(5), testStartTime)
+	_, errs := fromStep.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+	readMessages, err := to1.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+	assert.Equal(t, "testVertex-"+writeMessages[0].ID, readMessages[0].Metadata[dfv1.AuditIDKey])
+	f.Stop()
+	<-stopped
+
+	ids := log.ids()
+	assert.Len(t, ids, 5)
+	assert.Equal(t, readMessages[0].Metadata[dfv1.AuditIDKey], ids[0])
+}
+
+type tracingTestExporter struct {
+	lock  sync.Mutex
+	spans []*telemetry.Span
+}
+
+func (e *tracingTestExporter) Export(_ context.Context, spans []*telemetry.Span) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func TestNewInterStepDataForward_WithTracer(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+			UDF:  &dfv1.UDF{},
+		},
+		Tracing: &dfv1.PipelineTracing{Endpoint: "http://localhost:4318/v1/traces"},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	exporter := &tracingTestExporter{}
+	tracer := telemetry.NewTracer(vertex, exporter)
+	f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(5), WithTracer(tracer))
+	assert.NoError(t, err)
+	stopped := f.Start()
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	writeMessages := testutils.BuildTestWriteMessages(int64(5), testStartTime)
+	writeMessages[0].Metadata = map[string]string{dfv1.TraceParentKey: traceParent}
+	_, errs := fromStep.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+	readMessages, err := to1.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+	f.Stop()
+	<-stopped
+
+	// only the message coming with a trace context is traced out of a source
+	sc, ok := telemetry.ParseTraceParent(readMessages[0].Metadata[dfv1.TraceParentKey])
+	assert.True(t, ok)
+	parent, _ := telemetry.ParseTraceParent(traceParent)
+	assert.Equal(t, parent.TraceID, sc.TraceID)
+	assert.NotEqual(t, parent.SpanID, sc.SpanID)
+	assert.Empty(t, readMessages[1].Metadata[dfv1.TraceParentKey])
+
+	exportCtx, cancelExport := context.WithCancel(context.Background())
+	cancelExport()
+	tracer.Run(exportCtx)
+	assert.Len(t, exporter.spans, 2)
+	assert.Equal(t, telemetry.SpanUDFInvoke, exporter.spans[0].Name)
+	assert.Equal(t, parent.SpanID, exporter.spans[0].Parent.SpanID)
+	assert.Equal(t, telemetry.SpanISBWrite, exporter.spans[1].Name)
+	assert.Equal(t, sc, exporter.spans[1].Context)
+}
+
 type myForwardDropTest struct {
 }
 
@@ -274,6 +434,11 @@ func TestNewInterStepDataForward_UDFError(t *testing.T) {
 	assert.Equal(t, make([]error, 5), errs)
 
 	assert.True(t, to1.IsEmpty())
+	// not an error of applying the UDF
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(udfInvocationError.WithLabelValues("testVertex", "testPipeline", udfModeMap, string(udfapplier.ErrorClassPlatform))) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, testutil.CollectAndCount(udfInvocationTime, "udf_invocation_time") > 0)
 
 	f.Stop()
 	time.Sleep(1 * time.Millisecond)
@@ -433,11 +598,24 @@ func TestWriteToBufferError(t *testing.T) {
 	// asserting the number of failed messages
 	_, err = f.writeToBuffers(ctx, messageToStep)
 	assert.True(t, strings.Contains(err.Error(), "with failed messages:1"))
+	assert.True(t, testutil.ToFloat64(writeMessagesError.With(map[string]string{"vertex": "testVertex", "pipeline": "testPipeline", "buffer": "to1", "category": string(isberrors.BufferFull)})) > 0)
 
 	<-stopped
 
 }
 
+func TestNextRetryInterval(t *testing.T) {
+	isdf := &InterStepDataForward{opts: options{retryInterval: time.Millisecond}}
+	retryable := []error{nil, isb.BufferWriteErr{Name: "test", Full: true}}
+	notRetryable := []error{nil, isberrors.New(isberrors.Auth, fmt.Errorf("rejected"))}
+	assert.Equal(t, time.Millisecond, isdf.nextRetryInterval(time.Millisecond, retryable))
+	assert.Equal(t, 2*time.Millisecond, isdf.nextRetryInterval(time.Millisecond, notRetryable))
+	assert.Equal(t, time.Millisecond, isdf.nextRetryInterval(4*time.Millisecond, retryable))
+	assert.Equal(t, maxRetryInterval, isdf.nextRetryInterval(maxRetryInterval, notRetryable))
+	isdf.opts.retryInterval = 2 * maxRetryInterval
+	assert.Equal(t, 2*maxRetryInterval, isdf.nextRetryInterval(2*maxRetryInterval, notRetryable))
+}
+
 func validateMetrics(t *testing.T) {
 	metadata := `
 		# HELP forwarder_read_total Total number of Messages Read
@@ -478,4 +656,444 @@ func validateMetrics(t *testing.T) {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 
+	assert.Equal(t, 1, testutil.CollectAndCount(readProcessingTime, "forwarder_read_processing_time"))
+	assert.Equal(t, 1, testutil.CollectAndCount(writeProcessingTime, "forwarder_write_processing_time"))
+	assert.Equal(t, 1, testutil.CollectAndCount(ackProcessingTime, "forwarder_ack_processing_time"))
+
+}
+
+func TestCurrentReadBatchSize(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}}
+
+	t.Run("test no slow start", func(t *testing.T) {
+		f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(100))
+		assert.NoError(t, err)
+		f.startTime = time.Now()
+		assert.Equal(t, int64(100), f.currentReadBatchSize())
+	})
+
+	t.Run("test slow start", func(t *testing.T) {
+		f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(101), WithSlowStart(100*time.Second, 1))
+		assert.NoError(t, err)
+		f.startTime = time.Now()
+		assert.Equal(t, int64(1), f.currentReadBatchSize())
+		f.startTime = time.Now().Add(-50 * time.Second)
+		assert.Equal(t, int64(51), f.currentReadBatchSize())
+		f.startTime = time.Now().Add(-200 * time.Second)
+		assert.Equal(t, int64(101), f.currentReadBatchSize())
+	})
+
+	t.Run("test initial size larger than read batch size", func(t *testing.T) {
+		f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(10), WithSlowStart(100*time.Second, 20))
+		assert.NoError(t, err)
+		f.startTime = time.Now()
+		assert.Equal(t, int64(10), f.currentReadBatchSize())
+	})
+}
+
+type myForwardBatchTest struct {
+	myForwardTest
+	batches int
+}
+
+func (f *myForwardBatchTest) ApplyBatch(ctx context.Context, messages []*isb.ReadMessage) ([][]*isb.Message, error) {
+	f.batches++
+	results := make([][]*isb.Message, len(messages))
+	for i, m := range messages {
+		writeMessages, err := testutils.CopyUDFTestApply(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = writeMessages
+	}
+	return results, nil
+}
+
+func TestNewInterStepDataForward_UDFBatch(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}}
+
+	t.Run("test not a batch applier", func(t *testing.T) {
+		_, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithUDFBatch())
+		assert.Error(t, err)
+	})
+
+	t.Run("test batch", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		writeMessages := testutils.BuildTestWriteMessages(int64(5), testStartTime)
+		udf := &myForwardBatchTest{}
+		f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, udf, WithReadBatchSize(5), WithUDFBatch())
+		assert.NoError(t, err)
+		_, errs := fromStep.Write(ctx, writeMessages)
+		assert.Equal(t, make([]error, 5), errs)
+		f.forwardAChunk(ctx)
+
+		assert.Equal(t, 1, udf.batches)
+		readMessages, err := to1.Read(ctx, 5)
+		assert.NoError(t, err)
+		assert.Len(t, readMessages, 5)
+		for i := range readMessages {
+			assert.Equal(t, writeMessages[i].Header, readMessages[i].Header)
+			assert.Equal(t, writeMessages[i].Body, readMessages[i].Body)
+		}
+	})
+}
+
+type myForwardStreamTest struct {
+	myForwardTest
+	outputs int
+	chunks  []int
+}
+
+// ApplyStream explodes each message into a number of outputs, which are handed over in chunks.
+func (f *myForwardStreamTest) ApplyStream(_ context.Context, message *isb.ReadMessage, chunkSize int, handle func([]*isb.Message) error) error {
+	chunk := []*isb.Message{}
+	for i := 0; i < f.outputs; i++ {
+		chunk = append(chunk, &isb.Message{Header: isb.Header{ID: fmt.Sprintf("%s-%d", message.ID, i)}, Body: isb.Body{Payload: message.Payload}})
+		if len(chunk) == chunkSize || i == f.outputs-1 {
+			f.chunks = append(f.chunks, len(chunk))
+			if err := handle(chunk); err != nil {
+				return err
+			}
+			chunk = []*isb.Message{}
+		}
+	}
+	return nil
+}
+
+func TestNewInterStepDataForward_UDFStreaming(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}}
+
+	t.Run("test not a stream applier", func(t *testing.T) {
+		_, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithUDFStreaming(2))
+		assert.Error(t, err)
+		_, err = NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, &myForwardStreamTest{}, WithUDFStreaming(0))
+		assert.Error(t, err)
+	})
+
+	t.Run("test streaming", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		writeMessages := testutils.BuildTestWriteMessages(int64(1), testStartTime)
+		udf := &myForwardStreamTest{outputs: 5}
+		f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, udf, WithReadBatchSize(1), WithUDFStreaming(2))
+		assert.NoError(t, err)
+		_, errs := fromStep.Write(ctx, writeMessages)
+		assert.Equal(t, make([]error, 1), errs)
+		f.forwardAChunk(ctx)
+
+		assert.Equal(t, []int{2, 2, 1}, udf.chunks)
+		readMessages, err := to1.Read(ctx, 5)
+		assert.NoError(t, err)
+		assert.Len(t, readMessages, 5)
+		for i, m := range readMessages {
+			assert.Equal(t, fmt.Sprintf("%s-%d", writeMessages[0].ID, i), m.ID)
+			assert.Equal(t, writeMessages[0].Payload, m.Payload)
+			// the event time is carried over from the read message
+			assert.Equal(t, writeMessages[0].EventTime, m.EventTime)
+		}
+		assert.True(t, fromStep.IsEmpty())
+	})
+}
+
+type countingRateLimiter struct {
+	lock     sync.Mutex
+	admitted int
+}
+
+func (l *countingRateLimiter) Wait(_ context.Context, n int) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.admitted += n
+	return nil
+}
+
+func TestNewInterStepDataForward_RateLimiter(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	limiter := &countingRateLimiter{}
+	f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(5), WithRateLimiter(limiter))
+	assert.NoError(t, err)
+	stopped := f.Start()
+	writeMessages := testutils.BuildTestWriteMessages(int64(5), testStartTime)
+	_, errs := fromStep.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+	readMessages, err := to1.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+
+	f.Stop()
+	<-stopped
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	assert.Equal(t, 5, limiter.admitted)
+}
+
+type tenantCountingRateLimiter struct {
+	countingRateLimiter
+	tenants map[string]int
+}
+
+func (l *tenantCountingRateLimiter) WaitTenant(_ context.Context, tenant string, n int) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.tenants[tenant] += n
+	return nil
+}
+
+func TestNewInterStepDataForward_TenantKey(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name:      "testTenantVertex",
+			TenantKey: "tenant",
+		},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	limiter := &tenantCountingRateLimiter{tenants: map[string]int{}}
+	f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(5), WithRateLimiter(limiter), WithTenantKey("tenant"))
+	assert.NoError(t, err)
+	stopped := f.Start()
+	writeMessages := testutils.BuildTestWriteMessages(int64(5), testStartTime)
+	for i := range writeMessages {
+		if i%2 == 0 {
+			writeMessages[i].Metadata = map[string]string{"tenant": "a"}
+		} else {
+			writeMessages[i].Metadata = map[string]string{"tenant": "b"}
+		}
+	}
+	_, errs := fromStep.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+	readMessages, err := to1.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+
+	f.Stop()
+	<-stopped
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	assert.Equal(t, 0, limiter.admitted)
+	assert.Equal(t, map[string]int{"a": 3, "b": 2}, limiter.tenants)
+	assert.Equal(t, float64(3), testutil.ToFloat64(tenantReadMessagesCount.With(map[string]string{"vertex": "testTenantVertex", "pipeline": "testPipeline", "buffer": "from", "tenant": "a"})))
+	assert.Equal(t, float64(2), testutil.ToFloat64(tenantWriteMessagesCount.With(map[string]string{"vertex": "testTenantVertex", "pipeline": "testPipeline", "buffer": "to1", "tenant": "b"})))
+}
+
+func TestNewInterStepDataForward_FanInMetrics(t *testing.T) {
+	fromA := simplebuffer.NewInMemoryBuffer("fromA", 25)
+	fromB := simplebuffer.NewInMemoryBuffer("fromB", 25)
+	fromStep, err := fanin.NewWeightedReader([]isb.BufferReader{fromA, fromB}, []int64{3, 1})
+	assert.NoError(t, err)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testFanInVertex",
+		},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	f, err := NewInterStepDataForward(vertex, fromStep, toSteps, myForwardTest{}, myForwardTest{}, WithReadBatchSize(4))
+	assert.NoError(t, err)
+	stopped := f.Start()
+	_, errs := fromA.Write(ctx, testutils.BuildTestWriteMessages(int64(3), testStartTime))
+	assert.Equal(t, make([]error, 3), errs)
+	_, errs = fromB.Write(ctx, testutils.BuildTestWriteMessages(int64(1), testStartTime))
+	assert.Equal(t, make([]error, 1), errs)
+	readMessages, err := to1.Read(ctx, 4)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 4)
+
+	f.Stop()
+	<-stopped
+	assert.Equal(t, float64(3), testutil.ToFloat64(readMessagesCount.WithLabelValues("testFanInVertex", "testPipeline", "fromA")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(readMessagesCount.WithLabelValues("testFanInVertex", "testPipeline", "fromB")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(ackMessagesCount.WithLabelValues("testFanInVertex", "testPipeline", "fromA")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ackMessagesCount.WithLabelValues("testFanInVertex", "testPipeline", "fromB")))
+}
+
+type myForwardPoisonTest struct {
+	myForwardTest
+	lock     sync.Mutex
+	failures int
+}
+
+func (f *myForwardPoisonTest) Apply(ctx context.Context, message *isb.ReadMessage) ([]*isb.Message, error) {
+	if message.ID == "2" {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.failures++
+		return nil, fmt.Errorf("poison message")
+	}
+	return testutils.CopyUDFTestApply(ctx, message)
+}
+
+func (f *myForwardPoisonTest) ApplyBatch(ctx context.Context, messages []*isb.ReadMessage) ([][]*isb.Message, error) {
+	results := make([][]*isb.Message, len(messages))
+	for i, m := range messages {
+		if m.ID == "2" {
+			return nil, fmt.Errorf("poison message in the batch")
+		}
+		results[i], _ = testutils.CopyUDFTestApply(ctx, m)
+	}
+	return results, nil
+}
+
+func TestNewInterStepDataForward_DeadLetterQueue(t *testing.T) {
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}}
+
+	t.Run("test invalid max retries", func(t *testing.T) {
+		_, err := NewInterStepDataForward(vertex, simplebuffer.NewInMemoryBuffer("from", 25), map[string]isb.BufferWriter{}, myForwardTest{}, myForwardTest{}, WithDeadLetterQueue("from", simplebuffer.NewInMemoryBuffer("dlq", 10), -1))
+		assert.Error(t, err)
+	})
+
+	for _, batch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("test dead-lettering with batch %v", batch), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+			defer cancel()
+			fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+			to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+			dlq := simplebuffer.NewInMemoryBuffer("from-dlq", 10)
+			udf := &myForwardPoisonTest{}
+			opts := []Option{WithReadBatchSize(5), WithUDFConcurrency(2), WithDeadLetterQueue("from", dlq, 2)}
+			if batch {
+				opts = append(opts, WithUDFBatch())
+			}
+			f, err := NewInterStepDataForward(vertex, fromStep, map[string]isb.BufferWriter{"to1": to1}, myForwardTest{}, udf, opts...)
+			assert.NoError(t, err)
+			writeMessages := testutils.BuildTestWriteMessages(int64(5), testStartTime)
+			writeMessages[2].Metadata = map[string]string{"tenant": "a"}
+			_, errs := fromStep.Write(ctx, writeMessages)
+			assert.Equal(t, make([]error, 5), errs)
+			f.forwardAChunk(ctx)
+
+			assert.Equal(t, 3, udf.failures)
+			readMessages, err := to1.Read(ctx, 4)
+			assert.NoError(t, err)
+			assert.Len(t, readMessages, 4)
+			assert.True(t, to1.IsEmpty())
+			deadLetters, err := dlq.Read(ctx, 1)
+			assert.NoError(t, err)
+			assert.Len(t, deadLetters, 1)
+			assert.Equal(t, writeMessages[2].ID, deadLetters[0].ID)
+			assert.Equal(t, writeMessages[2].Payload, deadLetters[0].Payload)
+			assert.Equal(t, "a", deadLetters[0].Metadata["tenant"])
+			assert.Equal(t, "testVertex", deadLetters[0].Metadata[dfv1.DeadLetterVertexKey])
+			assert.Equal(t, "2", deadLetters[0].Metadata[dfv1.DeadLetterRetriesKey])
+			assert.Equal(t, "poison message", deadLetters[0].Metadata[dfv1.DeadLetterErrorKey])
+			assert.True(t, fromStep.IsEmpty())
+			assert.Equal(t, float64(1), testutil.ToFloat64(deadLetterMessagesCount.WithLabelValues("testVertex", "testPipeline", "from")))
+			deadLetterMessagesCount.Reset()
+		})
+	}
+}
+
+func TestNewInterStepDataForward_MaxMessageAges(t *testing.T) {
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testExpiringVertex",
+		},
+	}}
+
+	t.Run("test invalid max message age", func(t *testing.T) {
+		_, err := NewInterStepDataForward(vertex, simplebuffer.NewInMemoryBuffer("from", 25), map[string]isb.BufferWriter{}, myForwardTest{}, myForwardTest{}, WithMaxMessageAges(map[string]time.Duration{"from": 0}))
+		assert.Error(t, err)
+	})
+
+	t.Run("test dropping expired messages", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+		to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+		f, err := NewInterStepDataForward(vertex, fromStep, map[string]isb.BufferWriter{"to1": to1}, myForwardTest{}, myForwardTest{}, WithReadBatchSize(5), WithMaxMessageAges(map[string]time.Duration{"from": time.Hour}))
+		assert.NoError(t, err)
+		writeMessages := testutils.BuildTestWriteMessages(int64(5), time.Now().Add(-2*time.Hour))
+		writeMessages[3].EventTime = time.Now()
+		// the messages without an event time never expire
+		writeMessages[4].EventTime = time.Time{}
+		_, errs := fromStep.Write(ctx, writeMessages)
+		assert.Equal(t, make([]error, 5), errs)
+		f.forwardAChunk(ctx)
+
+		readMessages, err := to1.Read(ctx, 2)
+		assert.NoError(t, err)
+		assert.Len(t, readMessages, 2)
+		assert.Equal(t, writeMessages[3].ID, readMessages[0].ID)
+		assert.Equal(t, writeMessages[4].ID, readMessages[1].ID)
+		assert.True(t, to1.IsEmpty())
+		assert.True(t, fromStep.IsEmpty())
+		assert.Equal(t, float64(3), testutil.ToFloat64(expiredMessagesCount.WithLabelValues("testExpiringVertex", "testPipeline", "from")))
+	})
+
+	t.Run("test all messages expired", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		fromStep := simplebuffer.NewInMemoryBuffer("from", 25)
+		to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+		f, err := NewInterStepDataForward(vertex, fromStep, map[string]isb.BufferWriter{"to1": to1}, myForwardTest{}, myForwardTest{}, WithReadBatchSize(5), WithMaxMessageAges(map[string]time.Duration{"from": time.Hour}))
+		assert.NoError(t, err)
+		_, errs := fromStep.Write(ctx, testutils.BuildTestWriteMessages(int64(5), testStartTime))
+		assert.Equal(t, make([]error, 5), errs)
+		f.forwardAChunk(ctx)
+		assert.True(t, to1.IsEmpty())
+		assert.True(t, fromStep.IsEmpty())
+	})
 }
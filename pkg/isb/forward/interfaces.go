@@ -1,6 +1,9 @@
 package forward
 
 import (
+	"context"
+	"time"
+
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 )
 
@@ -31,3 +34,22 @@ type StarterStopper interface {
 	Stop()
 	ForceStop()
 }
+
+// ProgressChecker checks the forwarding makes progress, which is used by the liveness probe.
+type ProgressChecker interface {
+	// CheckProgress returns an error if the forwarding has made no progress within the threshold.
+	CheckProgress(threshold time.Duration) error
+}
+
+// RateLimiter admits the messages read from the fromBuffer.
+type RateLimiter interface {
+	// Wait blocks until n messages are admitted, or the context is done.
+	Wait(ctx context.Context, n int) error
+}
+
+// TenantRateLimiter is a RateLimiter admitting the messages of each tenant separately.
+type TenantRateLimiter interface {
+	RateLimiter
+	// WaitTenant blocks until n messages of the tenant are admitted, or the context is done.
+	WaitTenant(ctx context.Context, tenant string, n int) error
+}
@@ -12,12 +12,19 @@ var readMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
 	Help:      "Total number of Messages Read",
 }, []string{"vertex", "pipeline", "buffer"})
 
+// tenantReadMessagesCount is used to indicate the number of messages read by tenant, only if the tenant key is specified
+var tenantReadMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "forwarder",
+	Name:      "tenant_read_total",
+	Help:      "Total number of Messages Read by tenant",
+}, []string{"vertex", "pipeline", "buffer", "tenant"})
+
 // readMessagesError is used to indicate the number of errors messages read
 var readMessagesError = promauto.NewCounterVec(prometheus.CounterOpts{
 	Subsystem: "forwarder",
 	Name:      "read_error_total",
 	Help:      "Total number of Read Errors",
-}, []string{"vertex", "pipeline", "buffer"})
+}, []string{"vertex", "pipeline", "buffer", "category"})
 
 // writeMessagesCount is used to indicate the number of messages written
 var writeMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -26,11 +33,32 @@ var writeMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
 	Help:      "Total number of Messages Written",
 }, []string{"vertex", "pipeline", "buffer"})
 
+// tenantWriteMessagesCount is used to indicate the number of messages written by tenant, only if the tenant key is specified
+var tenantWriteMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "forwarder",
+	Name:      "tenant_write_total",
+	Help:      "Total number of Messages Written by tenant",
+}, []string{"vertex", "pipeline", "buffer", "tenant"})
+
 // writeMessagesError is used to indicate the number of errors messages written
 var writeMessagesError = promauto.NewCounterVec(prometheus.CounterOpts{
 	Subsystem: "forwarder",
 	Name:      "write_error_total",
 	Help:      "Total number of Write Errors",
+}, []string{"vertex", "pipeline", "buffer", "category"})
+
+// deadLetterMessagesCount is used to indicate the number of messages written to the dead-letter buffers
+var deadLetterMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "forwarder",
+	Name:      "dead_letter_total",
+	Help:      "Total number of Messages written to the dead-letter buffers",
+}, []string{"vertex", "pipeline", "buffer"})
+
+// expiredMessagesCount is used to indicate the number of messages dropped for being older than the max message age
+var expiredMessagesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "forwarder",
+	Name:      "expired_total",
+	Help:      "Total number of Messages dropped for being older than the max message age of the buffer",
 }, []string{"vertex", "pipeline", "buffer"})
 
 // ackMessagesCount is used to indicate the number of  messages acknowledged
@@ -45,7 +73,7 @@ var ackMessageError = promauto.NewCounterVec(prometheus.CounterOpts{
 	Subsystem: "forwarder",
 	Name:      "ack_error_total",
 	Help:      "Total number of Acknowledged Errors",
-}, []string{"vertex", "pipeline", "buffer"})
+}, []string{"vertex", "pipeline", "buffer", "category"})
 
 // udfError is used to indicate the number of UDF errors
 var udfError = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -69,6 +97,30 @@ var forwardAChunkProcessingTime = promauto.NewHistogramVec(prometheus.HistogramO
 	Buckets:   prometheus.ExponentialBucketsRange(1, 6000000, 40),
 }, []string{"vertex", "pipeline", "from", "to"})
 
+// readProcessingTime is a histogram to Observe the latencies of reading a batch of messages from the buffer
+var readProcessingTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Subsystem: "forwarder",
+	Name:      "read_processing_time",
+	Help:      "Processing times of reading a batch of messages from the buffer (microseconds)",
+	Buckets:   prometheus.ExponentialBucketsRange(1, 6000000, 40),
+}, []string{"vertex", "pipeline", "buffer"})
+
+// writeProcessingTime is a histogram to Observe the latencies of writing a batch of messages to a buffer, retries included
+var writeProcessingTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Subsystem: "forwarder",
+	Name:      "write_processing_time",
+	Help:      "Processing times of writing a batch of messages to the buffer, retries included (microseconds)",
+	Buckets:   prometheus.ExponentialBucketsRange(1, 6000000, 40),
+}, []string{"vertex", "pipeline", "buffer"})
+
+// ackProcessingTime is a histogram to Observe the latencies of acknowledging a batch of messages, retries included
+var ackProcessingTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Subsystem: "forwarder",
+	Name:      "ack_processing_time",
+	Help:      "Processing times of acknowledging a batch of messages to the buffer, retries included (microseconds)",
+	Buckets:   prometheus.ExponentialBucketsRange(1, 6000000, 40),
+}, []string{"vertex", "pipeline", "buffer"})
+
 // udfProcessingTime is a histogram to Observe UDF Processing times as a whole
 var udfProcessingTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Subsystem: "forwarder",
@@ -77,6 +129,22 @@ var udfProcessingTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Buckets:   prometheus.ExponentialBucketsRange(1, 6000000, 40),
 }, []string{"vertex", "pipeline", "buffer"})
 
+// udfInvocationTime is a histogram to Observe the latencies of the calls to the UDF, each retry observed separately, to
+// tell the time spent in the UDF from the time spent by the platform
+var udfInvocationTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Subsystem: "udf",
+	Name:      "invocation_time",
+	Help:      "Latencies of the calls to the UDF, each retry observed separately (microseconds)",
+	Buckets:   prometheus.ExponentialBucketsRange(1, 6000000, 40),
+}, []string{"vertex", "pipeline", "mode"})
+
+// udfInvocationError is used to indicate the number of the failed calls to the UDF by the class of the error
+var udfInvocationError = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "udf",
+	Name:      "invocation_error_total",
+	Help:      "Total number of the failed calls to the UDF by the class of the error, timeout, protocol, user or platform",
+}, []string{"vertex", "pipeline", "mode", "class"})
+
 // concurrentUDFProcessingTime is a histogram to Observe UDF Processing times as a whole
 var concurrentUDFProcessingTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Subsystem: "forwarder",
@@ -1,9 +1,15 @@
 package forward
 
 import (
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
 )
 
 // options for forwarding the message
@@ -16,6 +22,35 @@ type options struct {
 	retryInterval time.Duration
 	// logger is used to pass the logger variable
 	logger *zap.SugaredLogger
+	// slowStartDuration is the time used to ramp the read batch size up to readBatchSize, slow start is disabled if it is 0
+	slowStartDuration time.Duration
+	// initialReadBatchSize is the read batch size to start with when slow start is enabled
+	initialReadBatchSize int64
+	// udfBatch sends the whole read batch to the UDF in one call, the UDF needs to be a BatchApplier
+	udfBatch bool
+	// udfStreamChunkSize is the number of the outputs streamed by the UDF written to the buffers at a time, the UDF
+	// needs to be a StreamApplier. Streaming is disabled if it is 0
+	udfStreamChunkSize int
+	// rateLimiter admits the read messages before they are forwarded, not limited if it is nil
+	rateLimiter RateLimiter
+	// tenantKey is the key of the message metadata identifying the tenant, the messages are not counted by tenant if it is empty
+	tenantKey string
+	// deadLetterQueues are the dead-letter queues of the from buffers, keyed by the from buffer names
+	deadLetterQueues map[string]deadLetterQueue
+	// maxMessageAges are the time to live of the messages of the from buffers by their event time, keyed by the from buffer names
+	maxMessageAges map[string]time.Duration
+	// traceRecorders sample the messages written to the traced to buffers
+	traceRecorders tracing.Recorders
+	// auditRecorder stamps and records the audit IDs of the read messages, they are not audited if it is nil
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced read messages, they are not traced if it is nil
+	tracer *telemetry.Tracer
+}
+
+// deadLetterQueue is where the messages of a from buffer go once they fail the UDF more than maxRetries times.
+type deadLetterQueue struct {
+	writer     isb.BufferWriter
+	maxRetries int
 }
 
 type Option func(*options) error
@@ -44,6 +79,105 @@ func WithUDFConcurrency(f int) Option {
 	}
 }
 
+// WithUDFBatch sends the whole read batch to the UDF in one call instead of one call per message
+func WithUDFBatch() Option {
+	return func(o *options) error {
+		o.udfBatch = true
+		return nil
+	}
+}
+
+// WithUDFStreaming writes the outputs of the UDF to the buffers in chunks as they are streamed back, instead of
+// holding all the outputs of a read batch in memory
+func WithUDFStreaming(chunkSize int) Option {
+	return func(o *options) error {
+		if chunkSize < 1 {
+			return fmt.Errorf("invalid UDF stream chunk size %d", chunkSize)
+		}
+		o.udfStreamChunkSize = chunkSize
+		return nil
+	}
+}
+
+// WithSlowStart ramps the read batch size up from initialReadBatchSize to the read batch size within the duration
+func WithSlowStart(duration time.Duration, initialReadBatchSize int64) Option {
+	return func(o *options) error {
+		o.slowStartDuration = duration
+		o.initialReadBatchSize = initialReadBatchSize
+		return nil
+	}
+}
+
+// WithRateLimiter waits for the read messages to be admitted by the rate limiter before forwarding them
+func WithRateLimiter(l RateLimiter) Option {
+	return func(o *options) error {
+		o.rateLimiter = l
+		return nil
+	}
+}
+
+// WithTenantKey counts the messages by the tenant in their metadata, and waits for the messages of each tenant
+// separately if the rate limiter is a TenantRateLimiter
+func WithTenantKey(key string) Option {
+	return func(o *options) error {
+		o.tenantKey = key
+		return nil
+	}
+}
+
+// WithTraceRecorders samples the messages written to the traced to buffers
+func WithTraceRecorders(r tracing.Recorders) Option {
+	return func(o *options) error {
+		o.traceRecorders = r
+		return nil
+	}
+}
+
+// WithAuditRecorder stamps the audit IDs of the read messages, and records them once they are forwarded
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(o *options) error {
+		o.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced read messages, and propagates their trace contexts to the written messages
+func WithTracer(t *telemetry.Tracer) Option {
+	return func(o *options) error {
+		o.tracer = t
+		return nil
+	}
+}
+
+// WithDeadLetterQueue writes the messages read from the from buffer to the dead-letter buffer writer, once the UDF
+// fails to process them more than maxRetries times. The messages of the from buffers without one are retried forever.
+func WithDeadLetterQueue(fromBuffer string, writer isb.BufferWriter, maxRetries int) Option {
+	return func(o *options) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("invalid max retries %d of the dead-letter queue of %q", maxRetries, fromBuffer)
+		}
+		if o.deadLetterQueues == nil {
+			o.deadLetterQueues = make(map[string]deadLetterQueue)
+		}
+		o.deadLetterQueues[fromBuffer] = deadLetterQueue{writer: writer, maxRetries: maxRetries}
+		return nil
+	}
+}
+
+// WithMaxMessageAges sets the time to live of the messages of the from buffers, keyed by the from buffer names. The
+// messages older than it by their event time are dropped without being processed.
+func WithMaxMessageAges(ages map[string]time.Duration) Option {
+	return func(o *options) error {
+		for b, age := range ages {
+			if age <= 0 {
+				return fmt.Errorf("invalid max message age %v of %q", age, b)
+			}
+		}
+		o.maxMessageAges = ages
+		return nil
+	}
+}
+
 // WithLogger is used to return logger information
 func WithLogger(l *zap.SugaredLogger) Option {
 	return func(o *options) error {
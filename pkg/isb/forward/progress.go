@@ -0,0 +1,25 @@
+package forward
+
+import (
+	"fmt"
+	"time"
+)
+
+// progress records the time the forwarder makes progress, i.e. a read returns, a write or an ack is attempted, or a UDF
+// call returns. The failed attempts count too, as a forwarder retrying a full buffer is throttled rather than stuck.
+func (isdf *InterStepDataForward) progress() {
+	isdf.lastProgress.Store(time.Now().UnixNano())
+}
+
+// CheckProgress returns an error if the forwarder is running, and has made no progress within the threshold, which
+// means it's stuck, e.g. on a call never returning. A forwarder not started or stopped is not stuck.
+func (isdf *InterStepDataForward) CheckProgress(threshold time.Duration) error {
+	last := isdf.lastProgress.Load()
+	if last == 0 || threshold <= 0 {
+		return nil
+	}
+	if since := time.Since(time.Unix(0, last)); since > threshold {
+		return fmt.Errorf("the forwarder of vertex %q has made no progress for %v", isdf.vertexName, since.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package forward
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/simplebuffer"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+)
+
+// myForwardBlockingTest blocks the UDF calls until it's released.
+type myForwardBlockingTest struct {
+	release chan struct{}
+}
+
+func (f myForwardBlockingTest) WhereTo(_ []byte) ([]string, error) {
+	return []string{"to1"}, nil
+}
+
+func (f myForwardBlockingTest) Apply(ctx context.Context, message *isb.ReadMessage) ([]*isb.Message, error) {
+	<-f.release
+	return testutils.CopyUDFTestApply(ctx, message)
+}
+
+// readTimeoutBuffer returns from reading the empty buffer after the read timeout, like the buffers of the ISB Services.
+type readTimeoutBuffer struct {
+	*simplebuffer.InMemoryBuffer
+}
+
+func (b readTimeoutBuffer) Read(ctx context.Context, count int64) ([]*isb.ReadMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	msgs, err := b.InMemoryBuffer.Read(ctx, count)
+	var readErr isb.BufferReadErr
+	if errors.As(err, &readErr) && readErr.Empty {
+		return msgs, nil
+	}
+	return msgs, err
+}
+
+func TestCheckProgress(t *testing.T) {
+	fromStep := readTimeoutBuffer{simplebuffer.NewInMemoryBuffer("from", 25)}
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	toSteps := map[string]isb.BufferWriter{
+		"to1": to1,
+	}
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "testVertex",
+		},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	udf := myForwardBlockingTest{release: make(chan struct{})}
+	f, err := NewInterStepDataForward(vertex, fromStep, toSteps, udf, udf, WithReadBatchSize(5))
+	assert.NoError(t, err)
+	threshold := 100 * time.Millisecond
+	// not started
+	assert.NoError(t, f.CheckProgress(threshold))
+
+	stopped := f.Start()
+	// reading the empty buffer is progress
+	time.Sleep(2 * threshold)
+	assert.NoError(t, f.CheckProgress(threshold))
+
+	// stuck on the UDF call
+	_, errs := fromStep.Write(ctx, testutils.BuildTestWriteMessages(int64(1), testStartTime))
+	assert.Equal(t, make([]error, 1), errs)
+	assert.Eventually(t, func() bool { return f.CheckProgress(threshold) != nil }, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, f.CheckProgress(time.Minute))
+
+	close(udf.release)
+	assert.Eventually(t, func() bool { return f.CheckProgress(threshold) == nil }, 5*time.Second, 10*time.Millisecond)
+	readMessages, err := to1.Read(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 1)
+
+	f.Stop()
+	<-stopped
+	// not stuck once stopped
+	time.Sleep(2 * threshold)
+	assert.NoError(t, f.CheckProgress(threshold))
+}
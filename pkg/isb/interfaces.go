@@ -43,6 +43,15 @@ type BufferWriterInformation interface {
 	GetName() string
 }
 
+// BufferUsageReporter is implemented by the buffer writers which are able to report the usage of the buffer, it's used
+// to apply back pressure before the buffer gets full.
+type BufferUsageReporter interface {
+	// GetUsage returns the fraction of the buffer in use. It could be approximate.
+	GetUsage() float64
+	// GetUsageLimit returns the usage at which the buffer is considered full.
+	GetUsageLimit() float64
+}
+
 // Offset is an interface used in the ReadMessage referencing offset information.
 type Offset interface {
 	// String return the offset identifier
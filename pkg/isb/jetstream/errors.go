@@ -0,0 +1,25 @@
+package jetstream
+
+import (
+	"errors"
+
+	"github.com/nats-io/nats.go"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
+
+// categorize wraps the error returned by the JetStream client with its category.
+func categorize(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, nats.ErrStreamNotFound), errors.Is(err, nats.ErrConsumerNotFound), errors.Is(err, nats.ErrNoMatchingStream), errors.Is(err, nats.ErrNoResponders):
+		return isberrors.New(isberrors.NotFound, err)
+	case errors.Is(err, nats.ErrAuthorization), errors.Is(err, nats.ErrAuthExpired), errors.Is(err, nats.ErrAuthRevoked), errors.Is(err, nats.ErrAccountAuthExpired):
+		return isberrors.New(isberrors.Auth, err)
+	case errors.Is(err, nats.ErrMaxPayload), errors.Is(err, nats.ErrBadSubject):
+		return isberrors.New(isberrors.Fatal, err)
+	default:
+		return isberrors.New(isberrors.Transient, err)
+	}
+}
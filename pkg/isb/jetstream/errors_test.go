@@ -0,0 +1,23 @@
+package jetstream
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
+
+func TestCategorize(t *testing.T) {
+	assert.Nil(t, categorize(nil))
+	assert.Equal(t, isberrors.NotFound, isberrors.CategoryOf(categorize(nats.ErrNoResponders)))
+	assert.Equal(t, isberrors.NotFound, isberrors.CategoryOf(categorize(fmt.Errorf("failed, %w", nats.ErrStreamNotFound))))
+	assert.Equal(t, isberrors.Auth, isberrors.CategoryOf(categorize(nats.ErrAuthorization)))
+	assert.Equal(t, isberrors.Fatal, isberrors.CategoryOf(categorize(nats.ErrMaxPayload)))
+	assert.Equal(t, isberrors.Transient, isberrors.CategoryOf(categorize(nats.ErrTimeout)))
+	err := categorize(nats.ErrTimeout)
+	assert.ErrorIs(t, err, nats.ErrTimeout)
+	assert.Equal(t, nats.ErrTimeout.Error(), err.Error())
+}
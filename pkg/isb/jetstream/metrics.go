@@ -26,6 +26,13 @@ var isbIsFull = promauto.NewCounterVec(prometheus.CounterOpts{
 	Help:      "Total number of IsFull",
 }, []string{"buffer"})
 
+// isbDiscarded is used to indicate the number of messages discarded by the writes to the full buffer
+var isbDiscarded = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_jetstream",
+	Name:      "discarded_total",
+	Help:      "Total number of messages discarded by the writes to the full buffer, either the latest or the oldest ones",
+}, []string{"buffer", "strategy"})
+
 // isbWriteErrors is used to indicate the number of errors in the jetstream write check
 var isbWriteErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 	Subsystem: "isb_jetstream",
@@ -46,3 +53,31 @@ var isbBufferSolidUsage = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Name:      "buffer_solid_usage",
 	Help:      "percentage of buffer solid usage",
 }, []string{"buffer"})
+
+// isbBufferPending is used to indicate the number of messages in the buffer not yet delivered to the readers
+var isbBufferPending = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: "isb_jetstream",
+	Name:      "buffer_pending",
+	Help:      "number of messages pending in the buffer",
+}, []string{"buffer"})
+
+// isbBufferAckPending is used to indicate the number of messages delivered to the readers but not yet acknowledged
+var isbBufferAckPending = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: "isb_jetstream",
+	Name:      "buffer_ack_pending",
+	Help:      "number of messages pending acknowledgement in the buffer",
+}, []string{"buffer"})
+
+// isbRedelivered is used to indicate the number of messages delivered more than once
+var isbRedelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_jetstream",
+	Name:      "redelivered_total",
+	Help:      "Total number of messages delivered more than once",
+}, []string{"buffer"})
+
+// isbPoisonMessages is used to indicate the number of deliveries of the messages which have been delivered too many times
+var isbPoisonMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_jetstream",
+	Name:      "poison_message_total",
+	Help:      "Total number of deliveries of the messages delivered at least 5 times",
+}, []string{"buffer"})
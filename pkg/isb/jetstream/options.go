@@ -1,9 +1,12 @@
 package jetstream
 
 import (
+	"fmt"
 	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
 )
 
 // options for writing to JetStream
@@ -14,6 +17,18 @@ type writeOptions struct {
 	bufferUsageLimit float64
 	// refreshInterval is used to provide the default refresh interval
 	refreshInterval time.Duration
+	// exactlyOnce publishes the messages with their IDs as the Nats-Msg-Id, which JetStream deduplicates in the
+	// duplicates window of the stream
+	exactlyOnce bool
+	// durability is the acknowledgement the writes wait for, the messages are published asynchronously without
+	// waiting for the acknowledgements if it's None
+	durability dfv1.WriteDurability
+	// onFull is the strategy of the writes once the buffer is full
+	onFull dfv1.OnFullWritingStrategy
+	// compression is the content encoding used to compress the payloads, empty means no compression
+	compression dfv1.ContentEncoding
+	// sizeLimits are the max sizes of the keys and the headers of the messages
+	sizeLimits isb.MessageSizeLimits
 }
 
 func defaultWriteOptions() *writeOptions {
@@ -21,6 +36,9 @@ func defaultWriteOptions() *writeOptions {
 		maxLength:        dfv1.DefaultBufferLength,
 		bufferUsageLimit: dfv1.DefaultBufferUsageLimit,
 		refreshInterval:  1 * time.Second,
+		exactlyOnce:      true,
+		durability:       dfv1.WriteDurabilityAcknowledged,
+		onFull:           dfv1.OnFullRetryUntilSuccess,
 	}
 }
 
@@ -50,10 +68,101 @@ func WithRefreshInterval(refreshInterval time.Duration) WriteOption {
 	}
 }
 
+// WithExactlyOnce sets whether the messages are deduplicated by their IDs
+func WithExactlyOnce(exactlyOnce bool) WriteOption {
+	return func(o *writeOptions) error {
+		o.exactlyOnce = exactlyOnce
+		return nil
+	}
+}
+
+// WithDurability sets the acknowledgement the writes wait for
+func WithDurability(durability dfv1.WriteDurability) WriteOption {
+	return func(o *writeOptions) error {
+		o.durability = durability
+		return nil
+	}
+}
+
+// WithOnFull sets the strategy of the writes once the buffer is full
+func WithOnFull(onFull dfv1.OnFullWritingStrategy) WriteOption {
+	return func(o *writeOptions) error {
+		switch onFull {
+		case dfv1.OnFullRetryUntilSuccess, dfv1.OnFullDiscardLatest, dfv1.OnFullDiscardOldest:
+			o.onFull = onFull
+			return nil
+		default:
+			return fmt.Errorf("unsupported on full writing strategy %q", onFull)
+		}
+	}
+}
+
+// WithCompression sets the content encoding used to compress the payloads
+func WithCompression(encoding dfv1.ContentEncoding) WriteOption {
+	return func(o *writeOptions) error {
+		if _, err := sharedutil.Compress(string(encoding), nil); err != nil {
+			return err
+		}
+		o.compression = encoding
+		return nil
+	}
+}
+
+// WithMaxKeySize sets the max size in bytes of the keys of the messages
+func WithMaxKeySize(size int) WriteOption {
+	return func(o *writeOptions) error {
+		o.sizeLimits.MaxKeySize = size
+		return nil
+	}
+}
+
+// WithMaxHeaderSize sets the max size in bytes of the NATS headers of the messages
+func WithMaxHeaderSize(size int) WriteOption {
+	return func(o *writeOptions) error {
+		o.sizeLimits.MaxHeaderSize = size
+		return nil
+	}
+}
+
+// WriterOptionsFor returns the write options of the writer of a to buffer of the vertex, decided by the limits of
+// its edge.
+func WriterOptionsFor(vertex *dfv1.Vertex, toBufferName string) []WriteOption {
+	return []WriteOption{
+		WithExactlyOnce(vertex.IsExactlyOnceToBuffer(toBufferName)),
+		WithDurability(vertex.GetToBufferDurability(toBufferName)),
+		WithOnFull(vertex.GetToBufferOnFull(toBufferName)),
+		WithCompression(vertex.GetToBufferCompression(toBufferName)),
+	}
+}
+
 // options for reading from JetStream
 type readOptions struct {
 	// readTimeOut is the timeout needed for read timeout
 	readTimeOut time.Duration
+	// ackPolicy is how the messages are acknowledged
+	ackPolicy AckPolicy
+}
+
+// AckPolicy is how the reader acknowledges a batch of messages, both of them take a single round trip for the batch.
+type AckPolicy string
+
+const (
+	// AckPolicyFlush publishes the acks without waiting for the server to confirm them, then flushes the connection,
+	// which makes sure the server has received them. An ack lost in a failure of the server makes the message
+	// redelivered.
+	AckPolicyFlush AckPolicy = "flush"
+	// AckPolicyDouble publishes the acks with the replies requested, and waits for the server to confirm all of them,
+	// so that a confirmed message is never redelivered.
+	AckPolicyDouble AckPolicy = "double"
+)
+
+// AckPolicyFor returns the ack policy of the reader of an edge, the acks are only confirmed by the server on the
+// exactly-once edges, the redelivery of a message with a lost ack is tolerated by the at-least-once ones.
+func AckPolicyFor(exactlyOnce bool) AckPolicy {
+	if exactlyOnce {
+		return AckPolicyDouble
+	}
+	return AckPolicyFlush
 }
 
 type ReadOption func(*readOptions) error
@@ -65,8 +174,29 @@ func WithReadTimeOut(timeout time.Duration) ReadOption {
 		return nil
 	}
 }
+
+// WithAckPolicy is used to set how the messages are acknowledged
+func WithAckPolicy(policy AckPolicy) ReadOption {
+	return func(o *readOptions) error {
+		switch policy {
+		case AckPolicyFlush, AckPolicyDouble:
+			o.ackPolicy = policy
+			return nil
+		default:
+			return fmt.Errorf("invalid ack policy %q", policy)
+		}
+	}
+}
+
 func defaultReadOptions() *readOptions {
 	return &readOptions{
 		readTimeOut: time.Second,
+		ackPolicy:   AckPolicyDouble,
 	}
 }
+
+// ReadOptionsFor returns the read options of the reader of a from buffer of the vertex, decided by the limits of
+// its edge.
+func ReadOptionsFor(vertex *dfv1.Vertex, fromBufferName string) []ReadOption {
+	return []ReadOption{WithAckPolicy(AckPolicyFor(vertex.IsExactlyOnceFromBuffer(fromBufferName)))}
+}
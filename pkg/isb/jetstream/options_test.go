@@ -0,0 +1,71 @@
+package jetstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestWithAckPolicy(t *testing.T) {
+	o := defaultReadOptions()
+	assert.Equal(t, AckPolicyDouble, o.ackPolicy)
+	assert.NoError(t, WithAckPolicy(AckPolicyFlush)(o))
+	assert.Equal(t, AckPolicyFlush, o.ackPolicy)
+	assert.NoError(t, WithAckPolicy(AckPolicyDouble)(o))
+	assert.Equal(t, AckPolicyDouble, o.ackPolicy)
+	assert.Error(t, WithAckPolicy("abc")(o))
+	assert.Equal(t, AckPolicyDouble, o.ackPolicy)
+}
+
+func TestAckPolicyFor(t *testing.T) {
+	assert.Equal(t, AckPolicyDouble, AckPolicyFor(true))
+	assert.Equal(t, AckPolicyFlush, AckPolicyFor(false))
+}
+
+func TestReadOptionsFor(t *testing.T) {
+	v := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName:   "p",
+		AbstractVertex: dfv1.AbstractVertex{Name: "out"},
+		FromVertices:   []string{"in"},
+	}}
+	b := v.GetFromBuffers()[0]
+	o := defaultReadOptions()
+	for _, opt := range ReadOptionsFor(v, b) {
+		assert.NoError(t, opt(o))
+	}
+	// The edges without limits keep confirming the acks after upgrades
+	assert.Equal(t, AckPolicyDouble, o.ackPolicy)
+	v.Spec.ExactlyOnce = map[string]bool{"in": false}
+	for _, opt := range ReadOptionsFor(v, b) {
+		assert.NoError(t, opt(o))
+	}
+	assert.Equal(t, AckPolicyFlush, o.ackPolicy)
+}
+
+func TestWriterOptionsFor(t *testing.T) {
+	v := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName:   "p",
+		AbstractVertex: dfv1.AbstractVertex{Name: "in"},
+		ToVertices:     []dfv1.ToVertex{{Name: "out"}},
+	}}
+	b := v.GetToBuffers()[0]
+	o := defaultWriteOptions()
+	for _, opt := range WriterOptionsFor(v, b) {
+		assert.NoError(t, opt(o))
+	}
+	assert.True(t, o.exactlyOnce)
+	assert.Equal(t, dfv1.WriteDurabilityAcknowledged, o.durability)
+	assert.Equal(t, dfv1.OnFullRetryUntilSuccess, o.onFull)
+	assert.Equal(t, dfv1.ContentEncoding(""), o.compression)
+	v.Spec.ToVertices[0].Limits = &dfv1.EdgeLimits{ExactlyOnce: pointer.Bool(false), Durability: dfv1.WriteDurabilityNone, OnFull: dfv1.OnFullDiscardLatest, Compression: dfv1.ContentEncodingGzip}
+	for _, opt := range WriterOptionsFor(v, b) {
+		assert.NoError(t, opt(o))
+	}
+	assert.False(t, o.exactlyOnce)
+	assert.Equal(t, dfv1.WriteDurabilityNone, o.durability)
+	assert.Equal(t, dfv1.OnFullDiscardLatest, o.onFull)
+	assert.Equal(t, dfv1.ContentEncodingGzip, o.compression)
+}
@@ -2,6 +2,7 @@ package jetstream
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -16,41 +17,31 @@ import (
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 )
 
+// ackTimeout is how long the reader waits for the server to receive or to confirm the acks of a batch
+const ackTimeout = 5 * time.Second
+
+// ackAck is the payload of an ack
+var ackAck = []byte("+ACK")
+
 type jetStreamReader struct {
-	name                  string
-	stream                string
-	subject               string
+	name    string
+	stream  string
+	subject string
+	client  *clients.SharedJetStreamClient
+	// lock guards the connection and the subscriptions, which are recreated once the connection is reconnected or
+	// replaced
+	lock                  sync.RWMutex
 	conn                  *nats.Conn
 	sub                   *nats.Subscription
+	unregister            func()
 	opts                  *readOptions
 	inProgessTickDuration time.Duration
+	redeliverySampler     *isb.RedeliverySampler
 	log                   *zap.SugaredLogger
 }
 
 // NewJetStreamBufferReader is used to provide a new JetStream buffer reader connection
 func NewJetStreamBufferReader(ctx context.Context, client clients.JetStreamClient, name, stream, subject string, opts ...ReadOption) (isb.BufferReader, error) {
-	connectAndSubscribe := func() (*nats.Conn, nats.JetStreamContext, *nats.Subscription, error) {
-		conn, err := client.Connect(ctx)
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to get nats connection, %w", err)
-		}
-		js, err := conn.JetStream()
-		if err != nil {
-			conn.Close()
-			return nil, nil, nil, fmt.Errorf("failed to get jetstream context, %w", err)
-		}
-		sub, err := js.PullSubscribe(subject, stream, nats.Bind(stream, stream))
-		if err != nil {
-			conn.Close()
-			return nil, nil, nil, fmt.Errorf("failed to subscribe jet stream subject %q, %w", subject, err)
-		}
-		return conn, js, sub, nil
-	}
-	conn, js, sub, err := connectAndSubscribe()
-	if err != nil {
-		return nil, err
-	}
-
 	o := defaultReadOptions()
 	for _, opt := range opts {
 		if opt != nil {
@@ -59,10 +50,27 @@ func NewJetStreamBufferReader(ctx context.Context, client clients.JetStreamClien
 			}
 		}
 	}
+	sharedClient := clients.ShareJetStreamClient(client)
+	conn, err := sharedClient.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nats connection, %w", err)
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		sharedClient.Release()
+		return nil, fmt.Errorf("failed to get jetstream context, %w", err)
+	}
+	sub, err := js.PullSubscribe(subject, stream, nats.Bind(stream, stream))
+	if err != nil {
+		sharedClient.Release()
+		return nil, fmt.Errorf("failed to subscribe jet stream subject %q, %w", subject, err)
+	}
+
 	log := logging.FromContext(ctx).With("bufferReader", name).With("stream", stream).With("subject", subject)
 	consumer, err := js.ConsumerInfo(stream, stream)
 	if err != nil {
-		conn.Close()
+		_ = sub.Unsubscribe()
+		sharedClient.Release()
 		return nil, fmt.Errorf("failed to get consumer info, %w", err)
 	}
 	// If ackWait is 3s, ticks every 2s.
@@ -75,75 +83,216 @@ func NewJetStreamBufferReader(ctx context.Context, client clients.JetStreamClien
 		name:                  name,
 		stream:                stream,
 		subject:               subject,
+		client:                sharedClient,
 		conn:                  conn,
 		sub:                   sub,
 		opts:                  o,
 		inProgessTickDuration: time.Duration(inProgessTickSeconds * int64(time.Second)),
+		redeliverySampler:     isb.NewRedeliverySampler(time.Minute, 10),
 		log:                   log,
 	}
+	result.unregister = sharedClient.OnReconnect(result.resubscribe)
 	return result, nil
 }
 
+// resubscribe recreates the subscription on the reconnected or replaced connection, the one on a closed connection
+// fails forever otherwise. A failure is logged, and retried by the next reconnection.
+func (jr *jetStreamReader) resubscribe(conn *nats.Conn) {
+	js, err := conn.JetStream()
+	if err != nil {
+		jr.log.Errorw("Failed to get jetstream context to resubscribe", zap.Error(err))
+		return
+	}
+	sub, err := js.PullSubscribe(jr.subject, jr.stream, nats.Bind(jr.stream, jr.stream))
+	if err != nil {
+		jr.log.Errorw("Failed to resubscribe", zap.Error(err))
+		return
+	}
+	jr.lock.Lock()
+	oldSub := jr.sub
+	jr.conn, jr.sub = conn, sub
+	jr.lock.Unlock()
+	// The old one might be gone with the connection already
+	_ = oldSub.Unsubscribe()
+	jr.log.Info("Resubscribed to the jet stream subject")
+}
+
+// subscription returns the current subscription.
+func (jr *jetStreamReader) subscription() *nats.Subscription {
+	jr.lock.RLock()
+	defer jr.lock.RUnlock()
+	return jr.sub
+}
+
 func (jr *jetStreamReader) GetName() string {
 	return jr.name
 }
 
 func (jr *jetStreamReader) Close() error {
-	if jr.sub != nil {
-		if err := jr.sub.Unsubscribe(); err != nil {
+	jr.unregister()
+	if sub := jr.subscription(); sub != nil {
+		if err := sub.Unsubscribe(); err != nil {
 			jr.log.Errorw("Failed to unsubscribe", zap.Error(err))
 		}
 	}
-	if jr.conn != nil && !jr.conn.IsClosed() {
-		jr.conn.Close()
-	}
+	jr.client.Release()
 	return nil
 }
 
 func (jr *jetStreamReader) Read(_ context.Context, count int64) ([]*isb.ReadMessage, error) {
+	sub := jr.subscription()
 	result := []*isb.ReadMessage{}
-	msgs, err := jr.sub.Fetch(int(count), nats.MaxWait(jr.opts.readTimeOut))
+	msgs, err := sub.Fetch(int(count), nats.MaxWait(jr.opts.readTimeOut))
 	if err != nil && !errors.Is(err, nats.ErrTimeout) {
 		isbReadErrors.With(map[string]string{"buffer": jr.GetName()}).Inc()
-		return nil, fmt.Errorf("failed to fetch messages from jet stream subject %q, %w", jr.subject, err)
+		return nil, categorize(fmt.Errorf("failed to fetch messages from jet stream subject %q, %w", jr.subject, err))
 	}
 	for _, msg := range msgs {
+		jr.observeRedelivery(msg)
 		m := &isb.ReadMessage{
 			ReadOffset: newOffset(msg, jr.inProgessTickDuration, jr.log),
-			Message: isb.Message{
-				Header: convert2IsbMsgHeader(msg.Header),
-				Body: isb.Body{
-					Payload: msg.Data,
-				},
-			},
+			Message:    ConvertToIsbMessage(msg.Header, msg.Data),
 		}
+		jr.decompress(&m.Message)
 		result = append(result, m)
 	}
 	return result, nil
 }
 
+// decompress decompresses the payload compressed by the writer, the message is left compressed if it fails.
+func (jr *jetStreamReader) decompress(m *isb.Message) {
+	if err := isb.DecompressMessage(m); err != nil {
+		jr.log.Errorw("Failed to decompress the payload", zap.String("id", m.ID), zap.String("encoding", m.ContentEncoding), zap.Error(err))
+	}
+}
+
+// observeRedelivery counts the redelivered message, and logs it if it's sampled.
+func (jr *jetStreamReader) observeRedelivery(msg *nats.Msg) {
+	metadata, err := msg.Metadata()
+	if err != nil || metadata.NumDelivered <= 1 {
+		return
+	}
+	labels := map[string]string{"buffer": jr.GetName()}
+	isbRedelivered.With(labels).Inc()
+	poison := metadata.NumDelivered >= isb.PoisonMessageDeliveryCount
+	if poison {
+		isbPoisonMessages.With(labels).Inc()
+	}
+	if jr.redeliverySampler.Sample() {
+		jr.log.Warnw("Message redelivered", zap.String("id", msg.Header.Get(_id)), zap.String("key", msg.Header.Get(_key)),
+			zap.Uint64("seq", metadata.Sequence.Stream), zap.Uint64("deliveryCount", metadata.NumDelivered), zap.Bool("poison", poison))
+	}
+}
+
 func (jr *jetStreamReader) Ack(_ context.Context, offsets []isb.Offset) []error {
 	errs := make([]error, len(offsets))
-	done := make(chan struct{})
-	wg := &sync.WaitGroup{}
+	msgs := make([]*nats.Msg, len(offsets))
 	for idx, o := range offsets {
-		wg.Add(1)
-		go func(index int, o isb.Offset) {
-			defer wg.Done()
-			if err := o.AckIt(); err != nil {
-				jr.log.Errorw("Failed to ack message", zap.Error(err))
-				errs[index] = err
-			}
-		}(idx, o)
+		jo, ok := o.(*offset)
+		if !ok {
+			errs[idx] = fmt.Errorf("unexpected offset type %T", o)
+			continue
+		}
+		jo.stopInProgress()
+		if jo.msg.Reply == "" {
+			errs[idx] = nats.ErrMsgNoReply
+			continue
+		}
+		msgs[idx] = jo.msg
+	}
+	// The acks are sent to the subjects of the messages, which work on any connection, including the replaced one
+	jr.lock.RLock()
+	conn := jr.conn
+	jr.lock.RUnlock()
+	if jr.opts.ackPolicy == AckPolicyFlush {
+		ackFlush(conn, msgs, errs)
+	} else {
+		ackDouble(conn, msgs, errs)
+	}
+	for idx, err := range errs {
+		if err != nil {
+			jr.log.Errorw("Failed to ack message", zap.Error(err))
+			errs[idx] = categorize(err)
+		}
 	}
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
-	<-done
 	return errs
 }
 
+// ackFlush publishes the acks of the messages, and flushes the connection. The messages already failed are nil.
+func ackFlush(conn *nats.Conn, msgs []*nats.Msg, errs []error) {
+	for idx, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		if err := conn.Publish(msg.Reply, ackAck); err != nil {
+			errs[idx] = err
+			msgs[idx] = nil
+		}
+	}
+	if err := conn.FlushTimeout(ackTimeout); err != nil {
+		for idx, msg := range msgs {
+			if msg != nil {
+				errs[idx] = fmt.Errorf("failed to flush the acks, %w", err)
+			}
+		}
+	}
+}
+
+// ackDouble publishes the acks of the messages with the replies requested to an inbox of the batch, and waits for the
+// replies of all of them. The messages already failed are nil.
+func ackDouble(conn *nats.Conn, msgs []*nats.Msg, errs []error) {
+	inbox := nats.NewInbox()
+	sub, err := conn.SubscribeSync(inbox + ".*")
+	if err != nil {
+		for idx, msg := range msgs {
+			if msg != nil {
+				errs[idx] = fmt.Errorf("failed to subscribe the replies of the acks, %w", err)
+			}
+		}
+		return
+	}
+	defer func() { _ = sub.Unsubscribe() }()
+	pending := make(map[string]int)
+	for idx, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		reply := inbox + "." + strconv.Itoa(idx)
+		if err := conn.PublishRequest(msg.Reply, reply, ackAck); err != nil {
+			errs[idx] = err
+			continue
+		}
+		pending[reply] = idx
+	}
+	deadline := time.Now().Add(ackTimeout)
+	for len(pending) > 0 {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			err = nats.ErrTimeout
+		} else {
+			var r *nats.Msg
+			if r, err = sub.NextMsg(remaining); err == nil {
+				delete(pending, r.Subject)
+				continue
+			}
+		}
+		for _, idx := range pending {
+			errs[idx] = fmt.Errorf("failed to wait for the confirmation of the ack, %w", err)
+		}
+		return
+	}
+}
+
+// ConvertToIsbMessage converts the header and the data of a message in the JetStream buffer to an ISB message.
+func ConvertToIsbMessage(header nats.Header, data []byte) isb.Message {
+	return isb.Message{
+		Header: convert2IsbMsgHeader(header),
+		Body: isb.Body{
+			Payload: data,
+		},
+	}
+}
+
 func convert2IsbMsgHeader(header nats.Header) isb.Header {
 	r := isb.Header{}
 	if header.Get(_window) == "1" {
@@ -167,6 +316,10 @@ func convert2IsbMsgHeader(header nats.Header) isb.Header {
 		i, _ := strconv.ParseInt(x, 10, 64)
 		r.EndTime = time.UnixMilli(i)
 	}
+	r.ContentEncoding = header.Get(_encoding)
+	if x := header.Get(_metadata); x != "" {
+		_ = json.Unmarshal([]byte(x), &r.Metadata)
+	}
 	return r
 }
 
@@ -212,10 +365,15 @@ func (o *offset) String() string {
 	return fmt.Sprint(o.seq)
 }
 
-func (o *offset) AckIt() error {
+// stopInProgress stops marking the message in progress, once it's being acknowledged.
+func (o *offset) stopInProgress() {
 	if o.cancelFunc != nil {
 		o.cancelFunc()
 	}
+}
+
+func (o *offset) AckIt() error {
+	o.stopInProgress()
 	if err := o.msg.AckSync(); err != nil && !errors.Is(err, nats.ErrMsgAlreadyAckd) && !errors.Is(err, nats.ErrMsgNotFound) {
 		return err
 	}
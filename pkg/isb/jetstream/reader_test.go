@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/nats-io/nats.go"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/numaproj/numaflow/pkg/isb"
@@ -67,7 +68,7 @@ func TestJetStreamBufferRead(t *testing.T) {
 
 	assert.Equal(t, 20, len(readMessages))
 
-	fromStepJs, err := fromStep.conn.JetStream()
+	fromStepJs, err := conn.JetStream()
 	assert.NoError(t, err)
 	streamInfo, err := fromStepJs.StreamInfo(streamName)
 	assert.NoError(t, err)
@@ -99,6 +100,111 @@ func TestJetStreamBufferRead(t *testing.T) {
 }
 
 // TestGetName is used to test the GetName function
+func TestJetStreamBufferReadRedelivery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	streamName := "testJetStreamBufferReadRedelivery"
+	addStream(t, js, streamName)
+	defer deleteStream(js, streamName)
+
+	bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName)
+	assert.NoError(t, err)
+	for bw.(*jetStreamWriter).isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+	_, errs := bw.Write(ctx, testutils.BuildTestWriteMessages(int64(5), time.Unix(1636470000, 0)))
+	assert.Equal(t, make([]error, 5), errs)
+
+	bufferReader, err := NewJetStreamBufferReader(ctx, defaultJetStreamClient, streamName, streamName, streamName)
+	assert.NoError(t, err)
+	readMessages, err := bufferReader.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+	redelivered := isbRedelivered.With(map[string]string{"buffer": streamName})
+	assert.Equal(t, float64(0), promtestutil.ToFloat64(redelivered))
+
+	// Not acked, they are redelivered after ackWait
+	time.Sleep(3 * time.Second)
+	readMessages, err = bufferReader.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+	assert.Equal(t, float64(5), promtestutil.ToFloat64(redelivered))
+}
+
+func TestJetStreamBufferReadAckPending(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	streamName := "testJetStreamBufferReadAckPending"
+	_, err = js.AddStream(&nats.StreamConfig{Name: streamName, Subjects: []string{streamName}, Retention: nats.LimitsPolicy, Storage: nats.MemoryStorage})
+	assert.NoError(t, err)
+	_, err = js.AddConsumer(streamName, &nats.ConsumerConfig{Durable: streamName, AckPolicy: nats.AckExplicitPolicy, AckWait: 2 * time.Second, FilterSubject: streamName})
+	assert.NoError(t, err)
+	defer deleteStream(js, streamName)
+
+	bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName)
+	assert.NoError(t, err)
+	for bw.(*jetStreamWriter).isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+	_, errs := bw.Write(ctx, testutils.BuildTestWriteMessages(int64(10), time.Unix(1636470000, 0)))
+	assert.Equal(t, make([]error, 10), errs)
+
+	bufferReader, err := NewJetStreamBufferReader(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithReadTimeOut(time.Second))
+	assert.NoError(t, err)
+	defer func() { _ = bufferReader.Close() }()
+	readMessages, err := bufferReader.Read(ctx, 10)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 10)
+	// The messages read are pending till they're acked, which the writer counts to tell whether the buffer is full
+	consumer, err := js.ConsumerInfo(streamName, streamName)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, int(consumer.NumPending)+consumer.NumAckPending)
+
+	var offsets []isb.Offset
+	for _, m := range readMessages[:6] {
+		offsets = append(offsets, m.ReadOffset)
+	}
+	assert.Equal(t, make([]error, 6), bufferReader.Ack(ctx, offsets))
+	consumer, err = js.ConsumerInfo(streamName, streamName)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), consumer.AckFloor.Stream)
+	assert.Equal(t, 4, int(consumer.NumPending)+consumer.NumAckPending)
+
+	// A new reader resumes after the ack floor, the messages not acked are redelivered
+	assert.NoError(t, bufferReader.Close())
+	bufferReader, err = NewJetStreamBufferReader(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithReadTimeOut(3*time.Second))
+	assert.NoError(t, err)
+	readMessages, err = bufferReader.Read(ctx, 10)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 4)
+	seq, _ := readMessages[0].ReadOffset.Sequence()
+	assert.Equal(t, int64(7), seq)
+}
+
 func TestGetName(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
@@ -153,8 +259,14 @@ func TestConvert2IsbMsgHeader(t *testing.T) {
 	natsHeader.Set("w", "1")
 	natsHeader.Set("ps", "1636470000")
 	natsHeader.Set("pen", "1636470060")
+	natsHeader.Set("ce", "gzip")
 
 	assert.NotNil(t, convert2IsbMsgHeader(natsHeader))
+	assert.Equal(t, "gzip", convert2IsbMsgHeader(natsHeader).ContentEncoding)
+	assert.Nil(t, convert2IsbMsgHeader(natsHeader).Metadata)
+
+	natsHeader.Set("md", `{"tenant":"a"}`)
+	assert.Equal(t, map[string]string{"tenant": "a"}, convert2IsbMsgHeader(natsHeader).Metadata)
 }
 
 func addStream(t *testing.T, js nats.JetStreamContext, streamName string) {
@@ -184,3 +296,110 @@ func deleteStream(js nats.JetStreamContext, streamName string) {
 	_ = js.DeleteConsumer(streamName, streamName)
 	_ = js.DeleteStream(streamName)
 }
+
+func TestJetStreamBufferRead_Resubscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	adminConn, err := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", "")).Connect(ctx)
+	assert.NoError(t, err)
+	defer adminConn.Close()
+	js, err := adminConn.JetStream()
+	assert.NoError(t, err)
+
+	streamName := "testJetStreamBufferReadResubscribe"
+	addStream(t, js, streamName)
+	defer deleteStream(js, streamName)
+
+	sharedClient := clients.NewSharedJetStreamClient(clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", "")))
+
+	bw, err := NewJetStreamBufferWriter(ctx, sharedClient, streamName, streamName, streamName)
+	assert.NoError(t, err)
+	defer func() { _ = bw.Close() }()
+	br, err := NewJetStreamBufferReader(ctx, sharedClient, streamName, streamName, streamName, WithReadTimeOut(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer func() { _ = br.Close() }()
+	jr := br.(*jetStreamReader)
+	oldSub := jr.subscription()
+
+	// The reader and the writer share the connection, which is replaced once it's closed
+	conn, err := sharedClient.Connect(ctx)
+	assert.NoError(t, err)
+	sharedClient.Release()
+	conn.Close()
+	assert.Eventually(t, func() bool {
+		sub := jr.subscription()
+		return sub != oldSub
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for bw.(*jetStreamWriter).isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+	_, errs := bw.Write(ctx, testutils.BuildTestWriteMessages(2, time.Unix(1636470000, 0)))
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	msgs, err := br.Read(ctx, 2)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 2)
+}
+
+func TestJetStreamBufferRead_AckPolicy(t *testing.T) {
+	for _, policy := range []AckPolicy{AckPolicyFlush, AckPolicyDouble} {
+		t.Run(string(policy), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+			conn, err := client.Connect(ctx)
+			assert.NoError(t, err)
+			defer conn.Close()
+			js, err := conn.JetStream()
+			assert.NoError(t, err)
+
+			streamName := "testJetStreamBufferReadAckPolicy" + string(policy)
+			addStream(t, js, streamName)
+			defer deleteStream(js, streamName)
+
+			bw, err := NewJetStreamBufferWriter(ctx, client, streamName, streamName, streamName)
+			assert.NoError(t, err)
+			defer func() { _ = bw.Close() }()
+			for bw.(*jetStreamWriter).isFull.Load() {
+				select {
+				case <-ctx.Done():
+					t.Fatalf("expected not to be full, %s", ctx.Err())
+				default:
+					time.Sleep(time.Millisecond)
+				}
+			}
+			_, errs := bw.Write(ctx, testutils.BuildTestWriteMessages(5, time.Unix(1636470000, 0)))
+			for _, err := range errs {
+				assert.NoError(t, err)
+			}
+
+			br, err := NewJetStreamBufferReader(ctx, client, streamName, streamName, streamName, WithAckPolicy(policy))
+			assert.NoError(t, err)
+			defer func() { _ = br.Close() }()
+			msgs, err := br.Read(ctx, 5)
+			assert.NoError(t, err)
+			assert.Len(t, msgs, 5)
+			offsets := make([]isb.Offset, len(msgs))
+			for i, m := range msgs {
+				offsets[i] = m.ReadOffset
+			}
+			// Acking again is confirmed as well
+			for _, err := range br.Ack(ctx, append(offsets, offsets[0])) {
+				assert.NoError(t, err)
+			}
+			assert.Eventually(t, func() bool {
+				c, err := js.ConsumerInfo(streamName, streamName)
+				return err == nil && c.NumAckPending == 0 && c.AckFloor.Stream == 5
+			}, 5*time.Second, 10*time.Millisecond)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package jetstream
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
@@ -19,12 +22,18 @@ type jetStreamWriter struct {
 	name    string
 	stream  string
 	subject string
-	conn    *nats.Conn
-	js      nats.JetStreamContext
-	opts    *writeOptions
-	log     *zap.SugaredLogger
+	client  *clients.SharedJetStreamClient
+	// lock guards the connection and the JetStream context, which are replaced once the connection is replaced
+	lock       sync.RWMutex
+	conn       *nats.Conn
+	js         nats.JetStreamContext
+	unregister func()
+	opts       *writeOptions
+	log        *zap.SugaredLogger
 
 	isFull *atomic.Bool
+	// usage is the lower one of the solid and soft usages, the buffer is full when it reaches the usage limit
+	usage *atomic.Float64
 }
 
 // NewJetStreamBufferWriter is used to provide a new instance of JetStreamBufferWriter
@@ -37,41 +46,81 @@ func NewJetStreamBufferWriter(ctx context.Context, client clients.JetStreamClien
 			}
 		}
 	}
-	conn, err := client.Connect(ctx)
+	sharedClient := clients.ShareJetStreamClient(client)
+	conn, err := sharedClient.Connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nats connection, %w", err)
 	}
 
-	js, err := conn.JetStream(nats.PublishAsyncMaxPending(1024))
-	if err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("failed to get JetStream context for writer")
-	}
-
 	result := &jetStreamWriter{
 		name:    name,
 		stream:  stream,
 		subject: subject,
-		conn:    conn,
-		js:      js,
+		client:  sharedClient,
 		opts:    o,
 		isFull:  atomic.NewBool(true),
+		usage:   atomic.NewFloat64(1),
 		log:     logging.FromContext(ctx).With("bufferWriter", name).With("stream", stream).With("subject", subject),
 	}
+	js, err := result.jetStream(conn)
+	if err != nil {
+		sharedClient.Release()
+		return nil, err
+	}
+	result.conn, result.js = conn, js
+	result.unregister = sharedClient.OnReconnect(result.reconnected)
 
 	go result.runStatusChecker(ctx)
 	return result, nil
 }
 
-func (jw *jetStreamWriter) runStatusChecker(ctx context.Context) {
-	labels := map[string]string{"buffer": jw.GetName()}
-	// Use a separated JetStrea context for status checker
-	js, err := jw.conn.JetStream()
+// jetStream returns the JetStream context to publish the messages on the connection.
+func (jw *jetStreamWriter) jetStream(conn *nats.Conn) (nats.JetStreamContext, error) {
+	js, err := conn.JetStream(nats.PublishAsyncMaxPending(1024), nats.PublishAsyncErrHandler(func(_ nats.JetStream, m *nats.Msg, err error) {
+		// Only the messages published without waiting for the acknowledgements end up here
+		isbWriteErrors.With(map[string]string{"buffer": jw.name}).Inc()
+		jw.log.Errorw("Failed to publish a message asynchronously", zap.String("id", m.Header.Get(_id)), zap.Error(err))
+	}))
 	if err != nil {
-		// Let it exit if it fails to start the status checker
-		jw.log.Fatal("Failed to get Jet Stream context, %w", err)
+		return nil, fmt.Errorf("failed to get JetStream context for writer, %w", err)
 	}
+	return js, nil
+}
+
+// reconnected switches to the replaced connection, the JetStream context works on a reconnected one as it is.
+func (jw *jetStreamWriter) reconnected(conn *nats.Conn) {
+	if current, _ := jw.connection(); current == conn {
+		return
+	}
+	js, err := jw.jetStream(conn)
+	if err != nil {
+		jw.log.Errorw("Failed to switch to the replaced connection", zap.Error(err))
+		return
+	}
+	jw.lock.Lock()
+	jw.conn, jw.js = conn, js
+	jw.lock.Unlock()
+	jw.log.Info("Switched to the replaced connection")
+}
+
+// connection returns the current connection and the JetStream context on it.
+func (jw *jetStreamWriter) connection() (*nats.Conn, nats.JetStreamContext) {
+	jw.lock.RLock()
+	defer jw.lock.RUnlock()
+	return jw.conn, jw.js
+}
+
+func (jw *jetStreamWriter) runStatusChecker(ctx context.Context) {
+	labels := map[string]string{"buffer": jw.GetName()}
 	checkStatus := func() {
+		// Use a separated JetStream context for status checker, on the current connection
+		conn, _ := jw.connection()
+		js, err := conn.JetStream()
+		if err != nil {
+			isbIsFullErrors.With(labels).Inc()
+			jw.log.Errorw("Failed to get Jet Stream context", zap.Error(err))
+			return
+		}
 		s, err := js.StreamInfo(jw.stream)
 		if err != nil {
 			isbIsFullErrors.With(labels).Inc()
@@ -99,8 +148,11 @@ func (jw *jetStreamWriter) runStatusChecker(ctx context.Context) {
 		} else {
 			jw.isFull.Store(false)
 		}
+		jw.usage.Store(math.Min(solidUsage, softUsage))
 		isbBufferSoftUsage.With(labels).Set(softUsage)
 		isbBufferSolidUsage.With(labels).Set(solidUsage)
+		isbBufferPending.With(labels).Set(float64(c.NumPending))
+		isbBufferAckPending.With(labels).Set(float64(c.NumAckPending))
 		jw.log.Infow("Consumption information", zap.Any("totalMsgs", s.State.Msgs), zap.Any("pending", c.NumPending),
 			zap.Any("ackPending", c.NumAckPending), zap.Any("waiting", c.NumWaiting),
 			zap.Any("ackFloorStreamId", c.AckFloor.Stream), zap.Any("deliveredStreamId", c.Delivered.Stream),
@@ -120,31 +172,89 @@ func (jw *jetStreamWriter) runStatusChecker(ctx context.Context) {
 	}
 }
 
+// GetUsage returns the usage of the buffer, which is refreshed by the status checker.
+func (jw *jetStreamWriter) GetUsage() float64 {
+	return jw.usage.Load()
+}
+
+// GetUsageLimit returns the usage limit of the buffer.
+func (jw *jetStreamWriter) GetUsageLimit() float64 {
+	return jw.opts.bufferUsageLimit
+}
+
 func (jw *jetStreamWriter) GetName() string {
 	return jw.name
 }
 
 func (jw *jetStreamWriter) Close() error {
-	if jw.conn != nil && !jw.conn.IsClosed() {
-		jw.conn.Close()
+	jw.unregister()
+	if jw.opts.durability == dfv1.WriteDurabilityNone {
+		// give the messages published asynchronously a chance to be acknowledged
+		_, js := jw.connection()
+		select {
+		case <-js.PublishAsyncComplete():
+		case <-time.After(5 * time.Second):
+			jw.log.Warnw("Closing with messages not acknowledged", zap.Int("pending", js.PublishAsyncPending()))
+		}
 	}
+	jw.client.Release()
 	return nil
 }
 
-func (jw *jetStreamWriter) Write(_ context.Context, messages []isb.Message) ([]isb.Offset, []error) {
+// Write writes the messages to the stream, the ones with the keys or the headers larger than the max sizes fail without
+// being published.
+func (jw *jetStreamWriter) Write(ctx context.Context, messages []isb.Message) ([]isb.Offset, []error) {
+	headerSize := func(header isb.Header) int {
+		return natsHeaderSize(header, jw.opts.exactlyOnce)
+	}
+	return isb.WriteWithinSizeLimits(jw.name, jw.opts.sizeLimits, headerSize, messages, func(messages []isb.Message) ([]isb.Offset, []error) {
+		return jw.write(ctx, messages)
+	})
+}
+
+func (jw *jetStreamWriter) write(_ context.Context, messages []isb.Message) ([]isb.Offset, []error) {
 	labels := map[string]string{"buffer": jw.GetName()}
 	var errs = make([]error, len(messages))
 	var writeOffsets = make([]isb.Offset, len(messages))
 	if jw.isFull.Load() {
 		jw.log.Debugw("Is full")
 		isbIsFull.With(map[string]string{"buffer": jw.GetName()}).Inc()
+		switch jw.opts.onFull {
+		case dfv1.OnFullDiscardLatest:
+			isbDiscarded.With(map[string]string{"buffer": jw.GetName(), "strategy": string(dfv1.OnFullDiscardLatest)}).Add(float64(len(messages)))
+			return writeOffsets, errs
+		case dfv1.OnFullDiscardOldest:
+			if err := jw.discardOldest(len(messages)); err != nil {
+				jw.log.Errorw("Failed to discard the oldest messages", zap.Error(err))
+				for i := range errs {
+					errs[i] = isb.BufferWriteErr{Name: jw.name, Full: true, Message: "Buffer full!"}
+				}
+				isbWriteErrors.With(labels).Inc()
+				return nil, errs
+			}
+		default:
+			for i := range errs {
+				errs[i] = isb.BufferWriteErr{Name: jw.name, Full: true, Message: "Buffer full!"}
+			}
+			isbWriteErrors.With(labels).Inc()
+			return nil, errs
+		}
+	}
+
+	messages, err := isb.CompressMessages(string(jw.opts.compression), messages)
+	if err != nil {
 		for i := range errs {
-			errs[i] = isb.BufferWriteErr{Name: jw.name, Full: true, Message: "Buffer full!"}
+			errs[i] = isb.BufferWriteErr{Name: jw.name, Message: fmt.Sprintf("failed to compress the payload, %v", err)}
 		}
 		isbWriteErrors.With(labels).Inc()
 		return nil, errs
 	}
 
+	if jw.opts.durability == dfv1.WriteDurabilityNone {
+		return nil, jw.publishAsync(messages)
+	}
+
+	_, js := jw.connection()
 	wg := new(sync.WaitGroup)
 	for index, msg := range messages {
 		wg.Add(1)
@@ -155,8 +265,12 @@ func (jw *jetStreamWriter) Write(_ context.Context, messages []isb.Message) ([]i
 				Subject: jw.subject,
 				Data:    message.Payload,
 			}
-			if pubAck, err := jw.js.PublishMsg(m, nats.MsgId(message.Header.ID)); err != nil { // nats.MsgId() is for exactly-once writing
-				errs[idx] = err
+			pubOpts := []nats.PubOpt{}
+			if jw.opts.exactlyOnce {
+				pubOpts = append(pubOpts, nats.MsgId(message.Header.ID)) // nats.MsgId() is for exactly-once writing
+			}
+			if pubAck, err := js.PublishMsg(m, pubOpts...); err != nil {
+				errs[idx] = categorize(err)
 				isbWriteErrors.With(labels).Inc()
 			} else {
 				writeOffsets[idx] = &writeOffset{seq: pubAck.Sequence}
@@ -168,6 +282,65 @@ func (jw *jetStreamWriter) Write(_ context.Context, messages []isb.Message) ([]i
 	return writeOffsets, errs
 }
 
+// discardOldest purges the n oldest messages of the stream to make room for the ones being written. The purge by
+// sequence is not exposed by the client, so the JetStream API is requested directly.
+func (jw *jetStreamWriter) discardOldest(n int) error {
+	conn, js := jw.connection()
+	s, err := js.StreamInfo(jw.stream)
+	if err != nil {
+		return fmt.Errorf("failed to get stream info, %w", err)
+	}
+	req, _ := json.Marshal(map[string]uint64{"seq": s.State.FirstSeq + uint64(n)})
+	resp, err := conn.Request(fmt.Sprintf("$JS.API.STREAM.PURGE.%s", jw.stream), req, 5*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to purge the stream, %w", err)
+	}
+	var result struct {
+		Success bool   `json:"success"`
+		Purged  uint64 `json:"purged"`
+		Error   *struct {
+			Description string `json:"description"`
+		} `json:"error,omitempty"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to parse the purge response, %w", err)
+	}
+	if !result.Success {
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge the stream, %s", result.Error.Description)
+		}
+		return fmt.Errorf("failed to purge the stream")
+	}
+	isbDiscarded.With(map[string]string{"buffer": jw.GetName(), "strategy": string(dfv1.OnFullDiscardOldest)}).Add(float64(result.Purged))
+	jw.log.Debugw("Discarded the oldest messages", zap.Uint64("purged", result.Purged))
+	return nil
+}
+
+// publishAsync publishes the messages without waiting for the acknowledgements, so the offsets are unknown. The
+// publishing only fails if there are too many messages pending acknowledgements, the failures afterwards are reported
+// to the async error handler.
+func (jw *jetStreamWriter) publishAsync(messages []isb.Message) []error {
+	labels := map[string]string{"buffer": jw.GetName()}
+	errs := make([]error, len(messages))
+	_, js := jw.connection()
+	for idx, message := range messages {
+		m := &nats.Msg{
+			Header:  convert2NatsMsgHeader(message.Header),
+			Subject: jw.subject,
+			Data:    message.Payload,
+		}
+		pubOpts := []nats.PubOpt{}
+		if jw.opts.exactlyOnce {
+			pubOpts = append(pubOpts, nats.MsgId(message.Header.ID))
+		}
+		if _, err := js.PublishMsgAsync(m, pubOpts...); err != nil {
+			errs[idx] = categorize(err)
+			isbWriteErrors.With(labels).Inc()
+		}
+	}
+	return errs
+}
+
 // writeOffset is the offset of the location in the JS stream we wrote to.
 type writeOffset struct {
 	seq uint64
@@ -192,8 +365,30 @@ const (
 	_eventTime = "pev"
 	_startTime = "ps"
 	_endTime   = "pen"
+	_encoding  = "ce"
+	_metadata  = "md"
 )
 
+// natsHeaderSize returns the size of the NATS header of the message on the wire, which is the version line followed by
+// a line for each value and an empty line, with the message ID line added for the exactly-once writes.
+func natsHeaderSize(header isb.Header, exactlyOnce bool) int {
+	size := len("NATS/1.0\r\n") + len("\r\n")
+	if exactlyOnce {
+		size += len(nats.MsgIdHdr) + len(": ") + len(header.ID) + len("\r\n")
+	}
+	for k, values := range convert2NatsMsgHeader(header) {
+		for _, v := range values {
+			size += len(k) + len(": ") + len(v) + len("\r\n")
+		}
+	}
+	return size
+}
+
+// ConvertToNatsMsgHeader converts the header of a message to the NATS header it's written with.
+func ConvertToNatsMsgHeader(header isb.Header) nats.Header {
+	return convert2NatsMsgHeader(header)
+}
+
 func convert2NatsMsgHeader(header isb.Header) nats.Header {
 	r := nats.Header{}
 	r.Add(_id, header.ID)
@@ -212,5 +407,12 @@ func convert2NatsMsgHeader(header isb.Header) nats.Header {
 	if !header.EndTime.IsZero() {
 		r.Add(_endTime, fmt.Sprint(header.EndTime.UnixMilli()))
 	}
+	if header.ContentEncoding != "" {
+		r.Add(_encoding, header.ContentEncoding)
+	}
+	if len(header.Metadata) > 0 {
+		md, _ := json.Marshal(header.Metadata)
+		r.Add(_metadata, string(md))
+	}
 	return r
 }
@@ -4,6 +4,7 @@ package jetstream
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -125,7 +126,7 @@ func TestForwarderJetStreamBuffer(t *testing.T) {
 	// assert toBuffer is full and all messages appear in toBuffer
 	assert.True(t, to1.isFull.Load())
 
-	fromStepJs, err := fromStep.conn.JetStream()
+	fromStepJs, err := conn.JetStream()
 	assert.NoError(t, err)
 	fromStepInfo, err := fromStepJs.StreamInfo(streamName)
 	assert.NoError(t, err)
@@ -241,6 +242,277 @@ func TestWriteClose(t *testing.T) {
 
 }
 
+func TestJetStreamBufferWriterExactlyOnce(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	for _, exactlyOnce := range []bool{true, false} {
+		streamName := fmt.Sprintf("TestJetStreamBufferWriterExactlyOnce%t", exactlyOnce)
+		addStream(t, js, streamName)
+		bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithExactlyOnce(exactlyOnce))
+		assert.NoError(t, err)
+		for bw.(*jetStreamWriter).isFull.Load() {
+			select {
+			case <-ctx.Done():
+				t.Fatalf("expected not to be full, %s", ctx.Err())
+			default:
+				time.Sleep(1 * time.Millisecond)
+			}
+		}
+		messages := testutils.BuildTestWriteMessages(int64(5), time.Unix(1636470000, 0))
+		// Written twice, e.g. processed again after a retry
+		for i := 0; i < 2; i++ {
+			_, errs := bw.Write(ctx, messages)
+			assert.Equal(t, make([]error, 5), errs)
+		}
+		streamInfo, err := js.StreamInfo(streamName)
+		assert.NoError(t, err)
+		if exactlyOnce {
+			assert.Equal(t, uint64(5), streamInfo.State.Msgs)
+		} else {
+			assert.Equal(t, uint64(10), streamInfo.State.Msgs)
+		}
+		_ = bw.Close()
+		deleteStream(js, streamName)
+	}
+}
+
+// TestJetStreamBufferWriterExactlyOnceUpgrade tests that the edges without limits, e.g. of the pipelines created
+// before limits.exactlyOnce, are still written with the message IDs and deduplicated
+func TestJetStreamBufferWriterExactlyOnceUpgrade(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	vertex := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName:   "p",
+		AbstractVertex: dfv1.AbstractVertex{Name: "in"},
+		ToVertices:     []dfv1.ToVertex{{Name: "out"}},
+	}}
+	streamName := "TestJetStreamBufferWriterExactlyOnceUpgrade"
+	addStream(t, js, streamName)
+	defer deleteStream(js, streamName)
+	bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithExactlyOnce(vertex.IsExactlyOnceToBuffer(vertex.GetToBufferName("out"))))
+	assert.NoError(t, err)
+	defer func() { _ = bw.Close() }()
+	for bw.(*jetStreamWriter).isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+	messages := testutils.BuildTestWriteMessages(int64(5), time.Unix(1636470000, 0))
+	for i := 0; i < 2; i++ {
+		_, errs := bw.Write(ctx, messages)
+		assert.Equal(t, make([]error, 5), errs)
+	}
+	streamInfo, err := js.StreamInfo(streamName)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), streamInfo.State.Msgs)
+	var ids, msgIds []string
+	for i, m := range messages {
+		ids = append(ids, m.Header.ID)
+		msg, err := js.GetMsg(streamName, uint64(i+1))
+		assert.NoError(t, err)
+		msgIds = append(msgIds, msg.Header.Get(nats.MsgIdHdr))
+	}
+	assert.ElementsMatch(t, ids, msgIds)
+}
+
+func TestJetStreamBufferWriterDurabilityNone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	streamName := "TestJetStreamBufferWriterDurabilityNone"
+	addStream(t, js, streamName)
+	defer deleteStream(js, streamName)
+	bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithDurability(dfv1.WriteDurabilityNone))
+	assert.NoError(t, err)
+	for bw.(*jetStreamWriter).isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+	messages := testutils.BuildTestWriteMessages(int64(5), time.Unix(1636470000, 0))
+	offsets, errs := bw.Write(ctx, messages)
+	assert.Nil(t, offsets)
+	assert.Equal(t, make([]error, 5), errs)
+	// the pending acknowledgements are waited on closing
+	assert.NoError(t, bw.Close())
+	streamInfo, err := js.StreamInfo(streamName)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), streamInfo.State.Msgs)
+}
+
+func TestJetStreamBufferWriterOnFull(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	streamName := "TestJetStreamBufferWriterOnFull"
+	addStream(t, js, streamName)
+	defer deleteStream(js, streamName)
+	bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithMaxLength(10), WithBufferUsageLimit(0.2), WithOnFull(dfv1.OnFullDiscardOldest))
+	assert.NoError(t, err)
+	jw := bw.(*jetStreamWriter)
+	for jw.isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+	_, errs := jw.Write(ctx, testutils.BuildTestWriteMessages(int64(2), time.Unix(1636470000, 0)))
+	assert.Equal(t, make([]error, 2), errs)
+	for !jw.isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+
+	// the 2 oldest messages are discarded to make room for the new ones
+	_, errs = jw.Write(ctx, testutils.BuildTestWriteMessages(int64(2), time.Unix(1636470001, 0)))
+	assert.Equal(t, make([]error, 2), errs)
+	streamInfo, err := js.StreamInfo(streamName)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), streamInfo.State.Msgs)
+	assert.Equal(t, uint64(3), streamInfo.State.FirstSeq)
+
+	// the new messages are discarded
+	jw.opts.onFull = dfv1.OnFullDiscardLatest
+	_, errs = jw.Write(ctx, testutils.BuildTestWriteMessages(int64(2), time.Unix(1636470002, 0)))
+	assert.Equal(t, make([]error, 2), errs)
+	streamInfo, err = js.StreamInfo(streamName)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), streamInfo.State.Msgs)
+	assert.Equal(t, uint64(4), streamInfo.State.LastSeq)
+
+	assert.Error(t, WithOnFull("abc")(defaultWriteOptions()))
+}
+
+func TestJetStreamBufferWriterCompression(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	streamName := "TestJetStreamBufferWriterCompression"
+	addStream(t, js, streamName)
+	defer deleteStream(js, streamName)
+	bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithCompression(dfv1.ContentEncodingLZ4))
+	assert.NoError(t, err)
+	for bw.(*jetStreamWriter).isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+	messages := testutils.BuildTestWriteMessages(int64(5), time.Unix(1636470000, 0))
+	_, errs := bw.Write(ctx, messages)
+	assert.Equal(t, make([]error, 5), errs)
+
+	// the payloads are compressed in the stream
+	msg, err := js.GetMsg(streamName, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "lz4", msg.Header.Get(_encoding))
+	assert.NotEqual(t, messages[0].Payload, msg.Data)
+
+	// and decompressed by the reader
+	br, err := NewJetStreamBufferReader(ctx, defaultJetStreamClient, streamName, streamName, streamName)
+	assert.NoError(t, err)
+	readMessages, err := br.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+	for i, m := range readMessages {
+		assert.Equal(t, "", m.ContentEncoding)
+		assert.Equal(t, messages[i].Payload, m.Payload)
+	}
+
+	assert.Error(t, WithCompression("br")(defaultWriteOptions()))
+}
+
+func TestJetStreamBufferWriterMaxSizes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
+	defaultJetStreamClient := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", ""))
+	conn, err := defaultJetStreamClient.Connect(ctx)
+	assert.NoError(t, err)
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+
+	streamName := "TestJetStreamBufferWriterMaxSizes"
+	addStream(t, js, streamName)
+	defer deleteStream(js, streamName)
+	bw, err := NewJetStreamBufferWriter(ctx, defaultJetStreamClient, streamName, streamName, streamName, WithMaxKeySize(4), WithMaxHeaderSize(256))
+	assert.NoError(t, err)
+	for bw.(*jetStreamWriter).isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(1 * time.Millisecond)
+		}
+	}
+	messages := testutils.BuildTestWriteMessages(int64(3), time.Unix(1636470000, 0))
+	messages[1].Key = []byte("too long")
+	messages[2].Metadata = map[string]string{"large": string(make([]byte, 256))}
+	offsets, errs := bw.Write(ctx, messages)
+	assert.NoError(t, errs[0])
+	assert.NotNil(t, offsets[0])
+	assert.Equal(t, isb.MessageTooLargeErr{Name: streamName, Part: isb.MessagePartKey, Size: 8, Limit: 4}, errs[1])
+	assert.IsType(t, isb.MessageTooLargeErr{}, errs[2])
+	assert.Equal(t, isb.MessagePartHeader, errs[2].(isb.MessageTooLargeErr).Part)
+	si, err := js.StreamInfo(streamName)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), si.State.Msgs)
+}
+
+func TestNatsHeaderSize(t *testing.T) {
+	// "NATS/1.0\r\n", "i: 1\r\n", "k: \r\n", "w: 0\r\n" and "\r\n"
+	assert.Equal(t, 29, natsHeaderSize(isb.Header{ID: "1"}, false))
+	// and "Nats-Msg-Id: 1\r\n"
+	assert.Equal(t, 45, natsHeaderSize(isb.Header{ID: "1"}, true))
+	assert.Equal(t, 32, natsHeaderSize(isb.Header{ID: "1", Key: []byte("abc")}, false))
+}
+
 // TestConvert2NatsMsgHeader is used to convert nats header
 func TestConvert2NatsMsgHeader(t *testing.T) {
 	isbHeader := isb.Header{
@@ -249,9 +521,15 @@ func TestConvert2NatsMsgHeader(t *testing.T) {
 			StartTime: time.Unix(1636470000, 0),
 			EndTime:   time.Unix(1636470060, 0),
 		},
-		ID: "1",
+		ID:              "1",
+		ContentEncoding: "zstd",
 	}
 
 	assert.NotNil(t, convert2NatsMsgHeader(isbHeader))
+	assert.Equal(t, "zstd", convert2NatsMsgHeader(isbHeader).Get(_encoding))
+	assert.Empty(t, convert2NatsMsgHeader(isbHeader).Get(_metadata))
+
+	isbHeader.Metadata = map[string]string{"tenant": "a"}
+	assert.Equal(t, map[string]string{"tenant": "a"}, convert2IsbMsgHeader(convert2NatsMsgHeader(isbHeader)).Metadata)
 
 }
@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// GroupName returns the name of the consumer group reading the buffer, all the replicas of the vertex reading the buffer join it.
+func GroupName(buffer string) string {
+	return buffer + "-group"
+}
+
+// GetPendingCount returns the number of messages in the topic not yet committed by the consumer group of the buffer, and
+// the number of messages remaining in the topic. The messages removed by the retention of the topic are not pending.
+func GetPendingCount(client sarama.Client, admin sarama.ClusterAdmin, topic string) (pending int64, total int64, err error) {
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the partitions of topic %q, %w", topic, err)
+	}
+	committed, err := admin.ListConsumerGroupOffsets(GroupName(topic), map[string][]int32{topic: partitions})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list the committed offsets of topic %q, %w", topic, err)
+	}
+	for _, p := range partitions {
+		newest, err := client.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get the newest offset of partition %d of topic %q, %w", p, topic, err)
+		}
+		oldest, err := client.GetOffset(topic, p, sarama.OffsetOldest)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get the oldest offset of partition %d of topic %q, %w", p, topic, err)
+		}
+		from := oldest
+		if b := committed.GetBlock(topic, p); b != nil && b.Err == sarama.ErrNoError && b.Offset > oldest {
+			from = b.Offset
+		}
+		pending += newest - from
+		total += newest - oldest
+	}
+	return pending, total, nil
+}
@@ -0,0 +1,27 @@
+package kafka
+
+import (
+	"errors"
+
+	"github.com/Shopify/sarama"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
+
+// categorize wraps the error returned by the Kafka client with its category.
+func categorize(err error) error {
+	var configErr sarama.ConfigurationError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sarama.ErrUnknownTopicOrPartition):
+		return isberrors.New(isberrors.NotFound, err)
+	case errors.Is(err, sarama.ErrTopicAuthorizationFailed), errors.Is(err, sarama.ErrGroupAuthorizationFailed),
+		errors.Is(err, sarama.ErrClusterAuthorizationFailed), errors.Is(err, sarama.ErrSASLAuthenticationFailed):
+		return isberrors.New(isberrors.Auth, err)
+	case errors.Is(err, sarama.ErrMessageSizeTooLarge), errors.Is(err, sarama.ErrInvalidMessage), errors.As(err, &configErr):
+		return isberrors.New(isberrors.Fatal, err)
+	default:
+		return isberrors.New(isberrors.Transient, err)
+	}
+}
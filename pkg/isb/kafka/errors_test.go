@@ -0,0 +1,23 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
+
+func TestCategorize(t *testing.T) {
+	assert.Nil(t, categorize(nil))
+	assert.Equal(t, isberrors.NotFound, isberrors.CategoryOf(categorize(fmt.Errorf("failed, %w", sarama.ErrUnknownTopicOrPartition))))
+	assert.Equal(t, isberrors.Auth, isberrors.CategoryOf(categorize(sarama.ErrTopicAuthorizationFailed)))
+	assert.Equal(t, isberrors.Fatal, isberrors.CategoryOf(categorize(&sarama.ProducerError{Err: sarama.ErrMessageSizeTooLarge})))
+	assert.Equal(t, isberrors.Fatal, isberrors.CategoryOf(categorize(sarama.ConfigurationError("bad"))))
+	assert.Equal(t, isberrors.Transient, isberrors.CategoryOf(categorize(sarama.ErrNotLeaderForPartition)))
+	err := categorize(sarama.ErrRequestTimedOut)
+	assert.ErrorIs(t, err, sarama.ErrRequestTimedOut)
+	assert.Equal(t, sarama.ErrRequestTimedOut.Error(), err.Error())
+}
@@ -0,0 +1,118 @@
+package kafka
+
+import (
+	"sync"
+
+	"github.com/Shopify/sarama"
+)
+
+// claimedMessage is a message fetched in a consumer group session.
+type claimedMessage struct {
+	*sarama.ConsumerMessage
+	generation int32
+}
+
+// partitionTracker tracks the messages of a partition read but not yet committed, in the order they are read.
+type partitionTracker struct {
+	pending []int64
+	acked   map[int64]bool
+}
+
+// consumerHandler passes the messages of the claimed partitions to the reader, and commits the offsets of the acknowledged ones.
+// The messages read in a previous session are dropped when a new session starts, they are redelivered from the committed offsets.
+type consumerHandler struct {
+	name     string
+	topic    string
+	messages chan *claimedMessage
+
+	lock       sync.Mutex
+	sess       sarama.ConsumerGroupSession
+	generation int32
+	partitions map[int32]*partitionTracker
+}
+
+func newConsumerHandler(name, topic string, bufferSize int) *consumerHandler {
+	return &consumerHandler{
+		name:     name,
+		topic:    topic,
+		messages: make(chan *claimedMessage, bufferSize),
+	}
+}
+
+// Setup is run at the beginning of a new session, before ConsumeClaim
+func (h *consumerHandler) Setup(sess sarama.ConsumerGroupSession) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.sess = sess
+	h.generation = sess.GenerationID()
+	h.partitions = map[int32]*partitionTracker{}
+	isbRebalances.With(map[string]string{"buffer": h.name}).Inc()
+	return nil
+}
+
+// Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
+func (h *consumerHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.sess = nil
+	return nil
+}
+
+// ConsumeClaim passes the messages of a claimed partition to the reader until the session ends.
+func (h *consumerHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case m, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			select {
+			case h.messages <- &claimedMessage{ConsumerMessage: m, generation: sess.GenerationID()}:
+			case <-sess.Context().Done():
+				return nil
+			}
+		case <-sess.Context().Done():
+			return nil
+		}
+	}
+}
+
+// track returns the offset of a message to be read, or nil if it was fetched in a previous session.
+func (h *consumerHandler) track(m *claimedMessage) *offset {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.sess == nil || m.generation != h.generation {
+		return nil
+	}
+	t, ok := h.partitions[m.Partition]
+	if !ok {
+		t = &partitionTracker{acked: map[int64]bool{}}
+		h.partitions[m.Partition] = t
+	}
+	t.pending = append(t.pending, m.Offset)
+	return &offset{partition: m.Partition, offset: m.Offset, generation: m.generation, handler: h}
+}
+
+// ack marks the offset as acknowledged, and marks the offset of the partition to be committed up to the first message
+// not yet acknowledged. An offset of a previous session is ignored, the message is redelivered.
+func (h *consumerHandler) ack(o *offset) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.sess == nil || o.generation != h.generation {
+		return
+	}
+	t, ok := h.partitions[o.partition]
+	if !ok {
+		return
+	}
+	t.acked[o.offset] = true
+	next := int64(-1)
+	for len(t.pending) > 0 && t.acked[t.pending[0]] {
+		delete(t.acked, t.pending[0])
+		next = t.pending[0] + 1
+		t.pending = t.pending[1:]
+	}
+	if next >= 0 {
+		h.sess.MarkOffset(h.topic, o.partition, next, "")
+	}
+}
@@ -0,0 +1,125 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// fakeSession records the marked offsets.
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	generation int32
+	marked     map[int32]int64
+}
+
+func newFakeSession(generation int32) *fakeSession {
+	return &fakeSession{generation: generation, marked: map[int32]int64{}}
+}
+
+func (s *fakeSession) GenerationID() int32 {
+	return s.generation
+}
+
+func (s *fakeSession) MarkOffset(_ string, partition int32, offset int64, _ string) {
+	s.marked[partition] = offset
+}
+
+func newClaimedMessage(generation, partition int32, offset int64) *claimedMessage {
+	return &claimedMessage{ConsumerMessage: &sarama.ConsumerMessage{Partition: partition, Offset: offset}, generation: generation}
+}
+
+func TestConsumerHandler_Ack(t *testing.T) {
+	h := newConsumerHandler("test", "test-topic", 10)
+	sess := newFakeSession(1)
+	assert.NoError(t, h.Setup(sess))
+	var offsets []*offset
+	for i := int64(0); i < 3; i++ {
+		o := h.track(newClaimedMessage(1, 0, 10+i))
+		assert.NotNil(t, o)
+		offsets = append(offsets, o)
+	}
+	o := h.track(newClaimedMessage(1, 1, 5))
+	assert.Equal(t, "1-5", o.String())
+
+	// out of order, nothing before the first pending message is committed
+	assert.NoError(t, offsets[1].AckIt())
+	assert.NotContains(t, sess.marked, int32(0))
+	assert.NoError(t, offsets[0].AckIt())
+	assert.Equal(t, int64(12), sess.marked[0])
+	assert.NoError(t, offsets[2].AckIt())
+	assert.Equal(t, int64(13), sess.marked[0])
+	assert.NoError(t, o.AckIt())
+	assert.Equal(t, int64(6), sess.marked[1])
+}
+
+func TestConsumerHandler_Rebalance(t *testing.T) {
+	h := newConsumerHandler("test", "test-topic", 10)
+	sess := newFakeSession(1)
+	assert.NoError(t, h.Setup(sess))
+	o := h.track(newClaimedMessage(1, 0, 1))
+	assert.NoError(t, h.Cleanup(sess))
+
+	newSess := newFakeSession(2)
+	assert.NoError(t, h.Setup(newSess))
+	// the message fetched in the previous session is dropped, and its ack is ignored
+	assert.Nil(t, h.track(newClaimedMessage(1, 0, 2)))
+	assert.NoError(t, o.AckIt())
+	assert.Empty(t, sess.marked)
+	assert.Empty(t, newSess.marked)
+}
+
+func TestKafkaReader_Read(t *testing.T) {
+	h := newConsumerHandler("test", "test-topic", 10)
+	assert.NoError(t, h.Setup(newFakeSession(1)))
+	r := &kafkaReader{name: "test", handler: h, opts: &readOptions{readTimeOut: 100 * time.Millisecond}}
+	for i := int64(0); i < 3; i++ {
+		m := newClaimedMessage(1, 0, i)
+		m.Key = []byte("key")
+		m.Value = []byte{byte(i)}
+		h.messages <- m
+	}
+	msgs, err := r.Read(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, []byte("key"), msgs[0].Key)
+	assert.Equal(t, []byte{1}, msgs[1].Payload)
+	msgs, err = r.Read(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	errs := r.Ack(context.Background(), []isb.Offset{msgs[0].ReadOffset})
+	assert.NoError(t, errs[0])
+
+	start := time.Now()
+	msgs, err = r.Read(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestHeaderConversion(t *testing.T) {
+	header := isb.Header{
+		PaneInfo: isb.PaneInfo{
+			EventTime: time.UnixMilli(1663000000000),
+			StartTime: time.UnixMilli(1663000000000),
+			EndTime:   time.UnixMilli(1663000060000),
+			IsWindow:  true,
+		},
+		ID:              "0-1",
+		Key:             []byte("key"),
+		ContentEncoding: "gzip",
+		Metadata:        map[string]string{"a": "b"},
+	}
+	var headers []*sarama.RecordHeader
+	for _, h := range convert2KafkaHeaders(header) {
+		h := h
+		headers = append(headers, &h)
+	}
+	assert.Equal(t, header, convert2IsbMsgHeader(headers, header.Key))
+	assert.Equal(t, isb.Header{ID: "0-2"}, convert2IsbMsgHeader([]*sarama.RecordHeader{{Key: []byte(_id), Value: []byte("0-2")}, {Key: []byte(_window), Value: []byte("0")}}, nil))
+}
@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// isbReadErrors is used to indicate the number of errors in the kafka READ operations
+var isbReadErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_kafka",
+	Name:      "read_error_total",
+	Help:      "Total number of kafka read errors",
+}, []string{"buffer"})
+
+// isbAckErrors is used to indicate the number of errors in the kafka ack operations
+var isbAckErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_kafka",
+	Name:      "ack_error_total",
+	Help:      "Total number of kafka ack errors",
+}, []string{"buffer"})
+
+// isbIsFullErrors is used to indicate the number of errors in the kafka isFull check
+var isbIsFullErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_kafka",
+	Name:      "isFull_error_total",
+	Help:      "Total number of kafka isFull errors",
+}, []string{"buffer"})
+
+// isbIsFull is used to indicate the counter for number of times buffer is full
+var isbIsFull = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_kafka",
+	Name:      "isFull_total",
+	Help:      "Total number of IsFull",
+}, []string{"buffer"})
+
+// isbWriteErrors is used to indicate the number of errors in the kafka write check
+var isbWriteErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_kafka",
+	Name:      "write_error_total",
+	Help:      "Total number of kafka write errors",
+}, []string{"buffer"})
+
+// isbBufferUsage is used to indicate of buffer that is used up, it is calculated based on the messages not yet committed by the consumer group
+var isbBufferUsage = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: "isb_kafka",
+	Name:      "buffer_usage",
+	Help:      "percentage of buffer usage",
+}, []string{"buffer"})
+
+// isbRebalances is used to indicate the number of consumer group sessions started by the reader, each one follows a rebalance
+var isbRebalances = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_kafka",
+	Name:      "rebalance_total",
+	Help:      "Total number of consumer group rebalances",
+}, []string{"buffer"})
@@ -0,0 +1,107 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// options for writing to Kafka
+type writeOptions struct {
+	// maxLength is the maximum number of messages pending in the topic before it reaches full
+	maxLength int64
+	// bufferUsageLimit is the limit of buffer usage before we declare it as full
+	bufferUsageLimit float64
+	// refreshInterval is used to provide the default refresh interval
+	refreshInterval time.Duration
+	// sizeLimits are the max sizes of the keys and the headers of the messages
+	sizeLimits isb.MessageSizeLimits
+}
+
+func defaultWriteOptions() *writeOptions {
+	return &writeOptions{
+		maxLength:        dfv1.DefaultBufferLength,
+		bufferUsageLimit: dfv1.DefaultBufferUsageLimit,
+		refreshInterval:  1 * time.Second,
+	}
+}
+
+type WriteOption func(*writeOptions) error
+
+// WithMaxLength sets buffer max length option
+func WithMaxLength(length int64) WriteOption {
+	return func(o *writeOptions) error {
+		o.maxLength = length
+		return nil
+	}
+}
+
+// WithBufferUsageLimit sets buffer usage limit option
+func WithBufferUsageLimit(usageLimit float64) WriteOption {
+	return func(o *writeOptions) error {
+		o.bufferUsageLimit = usageLimit
+		return nil
+	}
+}
+
+// WithRefreshInterval sets refresh interval option
+func WithRefreshInterval(refreshInterval time.Duration) WriteOption {
+	return func(o *writeOptions) error {
+		o.refreshInterval = refreshInterval
+		return nil
+	}
+}
+
+// WithMaxKeySize sets the max size in bytes of the keys of the messages
+func WithMaxKeySize(size int) WriteOption {
+	return func(o *writeOptions) error {
+		o.sizeLimits.MaxKeySize = size
+		return nil
+	}
+}
+
+// WithMaxHeaderSize sets the max size in bytes of the record headers of the messages
+func WithMaxHeaderSize(size int) WriteOption {
+	return func(o *writeOptions) error {
+		o.sizeLimits.MaxHeaderSize = size
+		return nil
+	}
+}
+
+// options for reading from Kafka
+type readOptions struct {
+	// readTimeOut is the timeout needed for read timeout
+	readTimeOut time.Duration
+	// handlerBufferSize is the number of messages fetched from the claimed partitions and waiting to be read
+	handlerBufferSize int
+}
+
+type ReadOption func(*readOptions) error
+
+// WithReadTimeOut is used to set read timeout option
+func WithReadTimeOut(timeout time.Duration) ReadOption {
+	return func(o *readOptions) error {
+		o.readTimeOut = timeout
+		return nil
+	}
+}
+
+// WithHandlerBufferSize is used to set the number of messages fetched and waiting to be read
+func WithHandlerBufferSize(size int) ReadOption {
+	return func(o *readOptions) error {
+		if size <= 0 {
+			return fmt.Errorf("handler buffer size should be greater than 0")
+		}
+		o.handlerBufferSize = size
+		return nil
+	}
+}
+
+func defaultReadOptions() *readOptions {
+	return &readOptions{
+		readTimeOut:       time.Second,
+		handlerBufferSize: 500,
+	}
+}
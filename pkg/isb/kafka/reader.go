@@ -0,0 +1,206 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+type kafkaReader struct {
+	name    string
+	topic   string
+	client  sarama.Client
+	group   sarama.ConsumerGroup
+	handler *consumerHandler
+	opts    *readOptions
+	log     *zap.SugaredLogger
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewKafkaBufferReader is used to provide a new Kafka buffer reader, which joins the consumer group of the buffer.
+func NewKafkaBufferReader(ctx context.Context, client clients.KafkaClient, name, topic string, opts ...ReadOption) (isb.BufferReader, error) {
+	o := defaultReadOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt(o); err != nil {
+				return nil, err
+			}
+		}
+	}
+	c, err := client.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kafka client, %w", err)
+	}
+	group, err := sarama.NewConsumerGroupFromClient(GroupName(topic), c)
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to create consumer group %q, %w", GroupName(topic), err)
+	}
+	log := logging.FromContext(ctx).With("bufferReader", name).With("topic", topic)
+	consumeCtx, cancel := context.WithCancel(logging.WithLogger(context.Background(), log))
+	result := &kafkaReader{
+		name:    name,
+		topic:   topic,
+		client:  c,
+		group:   group,
+		handler: newConsumerHandler(name, topic, o.handlerBufferSize),
+		opts:    o,
+		log:     log,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go result.consume(consumeCtx)
+	return result, nil
+}
+
+// consume keeps the reader in the consumer group, a new session is started after each rebalance.
+func (kr *kafkaReader) consume(ctx context.Context) {
+	defer close(kr.done)
+	for {
+		if err := kr.group.Consume(ctx, []string{kr.topic}, kr.handler); err != nil {
+			isbReadErrors.With(map[string]string{"buffer": kr.GetName()}).Inc()
+			kr.log.Errorw("Failed to consume", zap.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (kr *kafkaReader) GetName() string {
+	return kr.name
+}
+
+func (kr *kafkaReader) Close() error {
+	kr.cancel()
+	<-kr.done
+	if err := kr.group.Close(); err != nil {
+		kr.log.Errorw("Failed to close consumer group", zap.Error(err))
+	}
+	return kr.client.Close()
+}
+
+// Read waits up to the read timeout for the first message, and returns it along with the ones already fetched.
+func (kr *kafkaReader) Read(ctx context.Context, count int64) ([]*isb.ReadMessage, error) {
+	result := []*isb.ReadMessage{}
+	timer := time.NewTimer(kr.opts.readTimeOut)
+	defer timer.Stop()
+	add := func(m *claimedMessage) {
+		if o := kr.handler.track(m); o != nil {
+			result = append(result, &isb.ReadMessage{
+				ReadOffset: o,
+				Message:    ConvertToIsbMessage(m.Headers, m.Key, m.Value),
+			})
+		}
+	}
+	for int64(len(result)) < count {
+		if len(result) == 0 {
+			select {
+			case m := <-kr.handler.messages:
+				add(m)
+			case <-timer.C:
+				return result, nil
+			case <-ctx.Done():
+				return result, nil
+			}
+		} else {
+			select {
+			case m := <-kr.handler.messages:
+				add(m)
+			default:
+				return result, nil
+			}
+		}
+	}
+	return result, nil
+}
+
+func (kr *kafkaReader) Ack(_ context.Context, offsets []isb.Offset) []error {
+	errs := make([]error, len(offsets))
+	for idx, o := range offsets {
+		if err := o.AckIt(); err != nil {
+			isbAckErrors.With(map[string]string{"buffer": kr.GetName()}).Inc()
+			kr.log.Errorw("Failed to ack message", zap.Error(err))
+			errs[idx] = categorize(err)
+		}
+	}
+	return errs
+}
+
+// ConvertToIsbMessage converts the headers, the key and the value of a record in the Kafka buffer to an ISB message.
+func ConvertToIsbMessage(headers []*sarama.RecordHeader, key, value []byte) isb.Message {
+	return isb.Message{
+		Header: convert2IsbMsgHeader(headers, key),
+		Body: isb.Body{
+			Payload: value,
+		},
+	}
+}
+
+func convert2IsbMsgHeader(headers []*sarama.RecordHeader, key []byte) isb.Header {
+	r := isb.Header{}
+	if len(key) > 0 {
+		r.Key = key
+	}
+	for _, h := range headers {
+		x := string(h.Value)
+		switch string(h.Key) {
+		case _id:
+			r.ID = x
+		case _window:
+			r.IsWindow = x == "1"
+		case _eventTime:
+			i, _ := strconv.ParseInt(x, 10, 64)
+			r.EventTime = time.UnixMilli(i)
+		case _startTime:
+			i, _ := strconv.ParseInt(x, 10, 64)
+			r.StartTime = time.UnixMilli(i)
+		case _endTime:
+			i, _ := strconv.ParseInt(x, 10, 64)
+			r.EndTime = time.UnixMilli(i)
+		case _encoding:
+			r.ContentEncoding = x
+		case _metadata:
+			_ = json.Unmarshal(h.Value, &r.Metadata)
+		}
+	}
+	return r
+}
+
+// offset implements ID interface for Kafka.
+type offset struct {
+	partition  int32
+	offset     int64
+	generation int32
+	handler    *consumerHandler
+}
+
+func (o *offset) String() string {
+	return fmt.Sprintf("%d-%d", o.partition, o.offset)
+}
+
+// AckIt marks the message as processed, the offset of the partition is committed once all the messages before it are acknowledged.
+func (o *offset) AckIt() error {
+	o.handler.ack(o)
+	return nil
+}
+
+// Sequence returns the offset in the partition. The offsets are only ordered within a partition, so the watermarks
+// fetched with them are approximate if the topic has more than one partition.
+func (o *offset) Sequence() (int64, error) {
+	return o.offset, nil
+}
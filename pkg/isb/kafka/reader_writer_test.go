@@ -0,0 +1,114 @@
+//go:build isb_kafka
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+var kafkaBrokers = []string{"localhost:9092"}
+
+func createTopic(t *testing.T, client clients.KafkaClient, topic string) func() {
+	c, err := client.Connect(context.Background())
+	assert.NoError(t, err)
+	admin, err := sarama.NewClusterAdminFromClient(c)
+	assert.NoError(t, err)
+	assert.NoError(t, admin.CreateTopic(topic, &sarama.TopicDetail{NumPartitions: 2, ReplicationFactor: 1}, false))
+	return func() {
+		_ = admin.DeleteTopic(topic)
+		_ = admin.DeleteConsumerGroup(GroupName(topic))
+		_ = admin.Close()
+	}
+}
+
+func TestKafkaBufferReadWrite(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	client := clients.NewDefaultKafkaClient(kafkaBrokers, nil)
+	topic := "testKafkaBufferReadWrite"
+	defer createTopic(t, client, topic)()
+
+	bw, err := NewKafkaBufferWriter(ctx, client, topic, topic, WithMaxLength(100))
+	assert.NoError(t, err)
+	defer bw.Close()
+	kw := bw.(*kafkaWriter)
+	for kw.isFull.Load() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected not to be full, %s", ctx.Err())
+		default:
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	messages := testutils.BuildTestWriteMessages(int64(20), time.Unix(1636470000, 0))
+	offsets, errs := kw.Write(ctx, messages)
+	for i, e := range errs {
+		assert.NoError(t, e)
+		assert.NotNil(t, offsets[i])
+	}
+
+	br, err := NewKafkaBufferReader(ctx, client, topic, topic, WithReadTimeOut(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer br.Close()
+	var readMessages []*isb.ReadMessage
+	for len(readMessages) < 20 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected to read 20 messages, %s", ctx.Err())
+		default:
+			msgs, err := br.Read(ctx, 20)
+			assert.NoError(t, err)
+			readMessages = append(readMessages, msgs...)
+		}
+	}
+	readOffsets := make([]isb.Offset, len(readMessages))
+	for i, m := range readMessages {
+		readOffsets[i] = m.ReadOffset
+		assert.NotEmpty(t, m.ID)
+	}
+	for _, e := range br.Ack(ctx, readOffsets) {
+		assert.NoError(t, e)
+	}
+
+	// the committed offsets reach the end of the topic
+	c, err := client.Connect(ctx)
+	assert.NoError(t, err)
+	admin, err := sarama.NewClusterAdminFromClient(c)
+	assert.NoError(t, err)
+	defer admin.Close()
+	assert.Eventually(t, func() bool {
+		pending, total, err := GetPendingCount(c, admin, topic)
+		return err == nil && pending == 0 && total == 20
+	}, 30*time.Second, 500*time.Millisecond)
+}
+
+func TestKafkaBufferWrite_Full(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	client := clients.NewDefaultKafkaClient(kafkaBrokers, nil)
+	topic := "testKafkaBufferWriteFull"
+	defer createTopic(t, client, topic)()
+
+	bw, err := NewKafkaBufferWriter(ctx, client, topic, topic, WithMaxLength(10), WithBufferUsageLimit(0.5), WithRefreshInterval(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer bw.Close()
+	kw := bw.(*kafkaWriter)
+	assert.Eventually(t, func() bool { return !kw.isFull.Load() }, 30*time.Second, 10*time.Millisecond)
+	_, errs := kw.Write(ctx, testutils.BuildTestWriteMessages(int64(6), time.Unix(1636470000, 0)))
+	for _, e := range errs {
+		assert.NoError(t, e)
+	}
+	assert.Eventually(t, func() bool { return kw.isFull.Load() }, 30*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0.6, kw.GetUsage())
+	_, errs = kw.Write(ctx, testutils.BuildTestWriteMessages(int64(1), time.Unix(1636470000, 0)))
+	assert.ErrorIs(t, errs[0], isb.BufferWriteErr{Name: topic, Full: true, Message: "Buffer full!"})
+}
@@ -0,0 +1,252 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+type kafkaWriter struct {
+	name     string
+	topic    string
+	client   sarama.Client
+	admin    sarama.ClusterAdmin
+	producer sarama.SyncProducer
+	opts     *writeOptions
+	log      *zap.SugaredLogger
+
+	isFull *atomic.Bool
+	// usage is the fraction of the max length taken by the messages not yet committed by the consumer group
+	usage *atomic.Float64
+}
+
+// NewKafkaBufferWriter is used to provide a new instance of KafkaBufferWriter
+func NewKafkaBufferWriter(ctx context.Context, client clients.KafkaClient, name, topic string, opts ...WriteOption) (isb.BufferWriter, error) {
+	o := defaultWriteOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt(o); err != nil {
+				return nil, err
+			}
+		}
+	}
+	c, err := client.Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kafka client, %w", err)
+	}
+	producer, err := sarama.NewSyncProducerFromClient(c)
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to create kafka producer, %w", err)
+	}
+	// The client is closed along with the admin
+	admin, err := sarama.NewClusterAdminFromClient(c)
+	if err != nil {
+		_ = producer.Close()
+		_ = c.Close()
+		return nil, fmt.Errorf("failed to create kafka cluster admin, %w", err)
+	}
+
+	result := &kafkaWriter{
+		name:     name,
+		topic:    topic,
+		client:   c,
+		admin:    admin,
+		producer: producer,
+		opts:     o,
+		isFull:   atomic.NewBool(true),
+		usage:    atomic.NewFloat64(1),
+		log:      logging.FromContext(ctx).With("bufferWriter", name).With("topic", topic),
+	}
+
+	go result.runStatusChecker(ctx)
+	return result, nil
+}
+
+func (kw *kafkaWriter) runStatusChecker(ctx context.Context) {
+	labels := map[string]string{"buffer": kw.GetName()}
+	checkStatus := func() {
+		pending, total, err := GetPendingCount(kw.client, kw.admin, kw.topic)
+		if err != nil {
+			isbIsFullErrors.With(labels).Inc()
+			kw.log.Errorw("Failed to get pending count", zap.Error(err))
+			return
+		}
+		usage := float64(pending) / float64(kw.opts.maxLength)
+		if usage >= kw.opts.bufferUsageLimit {
+			kw.log.Infow("Usage is greater than bufferUsageLimit", zap.Float64("usage", usage))
+			kw.isFull.Store(true)
+		} else {
+			kw.isFull.Store(false)
+		}
+		kw.usage.Store(usage)
+		isbBufferUsage.With(labels).Set(usage)
+		kw.log.Infow("Consumption information", zap.Int64("totalMsgs", total), zap.Int64("pending", pending), zap.Float64("usage", usage))
+	}
+	checkStatus()
+
+	ticker := time.NewTicker(kw.opts.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			checkStatus()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// GetUsage returns the usage of the buffer, which is refreshed by the status checker.
+func (kw *kafkaWriter) GetUsage() float64 {
+	return kw.usage.Load()
+}
+
+// GetUsageLimit returns the usage limit of the buffer.
+func (kw *kafkaWriter) GetUsageLimit() float64 {
+	return kw.opts.bufferUsageLimit
+}
+
+func (kw *kafkaWriter) GetName() string {
+	return kw.name
+}
+
+func (kw *kafkaWriter) Close() error {
+	if err := kw.producer.Close(); err != nil {
+		kw.log.Errorw("Failed to close producer", zap.Error(err))
+	}
+	return kw.admin.Close()
+}
+
+// Write sends the messages in one batch, the keys of the messages decide the partitions they go to. Unlike JetStream,
+// the retried writes are not deduplicated. The messages with the keys or the headers larger than the max sizes fail
+// without being sent.
+func (kw *kafkaWriter) Write(_ context.Context, messages []isb.Message) ([]isb.Offset, []error) {
+	return isb.WriteWithinSizeLimits(kw.name, kw.opts.sizeLimits, recordHeadersSize, messages, kw.write)
+}
+
+func (kw *kafkaWriter) write(messages []isb.Message) ([]isb.Offset, []error) {
+	labels := map[string]string{"buffer": kw.GetName()}
+	var errs = make([]error, len(messages))
+	if kw.isFull.Load() {
+		kw.log.Debugw("Is full")
+		isbIsFull.With(labels).Inc()
+		for i := range errs {
+			errs[i] = isb.BufferWriteErr{Name: kw.name, Full: true, Message: "Buffer full!"}
+		}
+		isbWriteErrors.With(labels).Inc()
+		return nil, errs
+	}
+
+	msgs := make([]*sarama.ProducerMessage, len(messages))
+	indexes := make(map[*sarama.ProducerMessage]int, len(messages))
+	for i, message := range messages {
+		m := &sarama.ProducerMessage{
+			Topic:   kw.topic,
+			Headers: convert2KafkaHeaders(message.Header),
+			Value:   sarama.ByteEncoder(message.Payload),
+		}
+		if len(message.Header.Key) > 0 {
+			m.Key = sarama.ByteEncoder(message.Header.Key)
+		}
+		msgs[i] = m
+		indexes[m] = i
+	}
+	if err := kw.producer.SendMessages(msgs); err != nil {
+		var producerErrs sarama.ProducerErrors
+		if errors.As(err, &producerErrs) {
+			for _, pe := range producerErrs {
+				errs[indexes[pe.Msg]] = categorize(pe.Err)
+			}
+		} else {
+			for i := range errs {
+				errs[i] = categorize(err)
+			}
+		}
+		isbWriteErrors.With(labels).Inc()
+	}
+	var writeOffsets = make([]isb.Offset, len(messages))
+	for i, m := range msgs {
+		if errs[i] == nil {
+			writeOffsets[i] = &writeOffset{partition: m.Partition, offset: m.Offset}
+		}
+	}
+	return writeOffsets, errs
+}
+
+// writeOffset is the offset of the location in the topic we wrote to.
+type writeOffset struct {
+	partition int32
+	offset    int64
+}
+
+func (w *writeOffset) String() string {
+	return fmt.Sprintf("%d-%d", w.partition, w.offset)
+}
+
+func (w *writeOffset) Sequence() (int64, error) {
+	return w.offset, nil
+}
+
+func (w *writeOffset) AckIt() error {
+	return fmt.Errorf("not supported")
+}
+
+// The header keys are the same as the JetStream ones, the key of the message is the key of the Kafka record.
+const (
+	_id        = "i"
+	_window    = "w"
+	_eventTime = "pev"
+	_startTime = "ps"
+	_endTime   = "pen"
+	_encoding  = "ce"
+	_metadata  = "md"
+)
+
+// recordHeadersSize returns the total size of the keys and the values of the record headers of the message.
+func recordHeadersSize(header isb.Header) int {
+	size := 0
+	for _, h := range convert2KafkaHeaders(header) {
+		size += len(h.Key) + len(h.Value)
+	}
+	return size
+}
+
+func convert2KafkaHeaders(header isb.Header) []sarama.RecordHeader {
+	add := func(r []sarama.RecordHeader, key, value string) []sarama.RecordHeader {
+		return append(r, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+	r := add(nil, _id, header.ID)
+	if header.IsWindow {
+		r = add(r, _window, "1")
+	} else {
+		r = add(r, _window, "0")
+	}
+	if !header.EventTime.IsZero() {
+		r = add(r, _eventTime, fmt.Sprint(header.EventTime.UnixMilli()))
+	}
+	if !header.StartTime.IsZero() {
+		r = add(r, _startTime, fmt.Sprint(header.StartTime.UnixMilli()))
+	}
+	if !header.EndTime.IsZero() {
+		r = add(r, _endTime, fmt.Sprint(header.EndTime.UnixMilli()))
+	}
+	if header.ContentEncoding != "" {
+		r = add(r, _encoding, header.ContentEncoding)
+	}
+	if len(header.Metadata) > 0 {
+		md, _ := json.Marshal(header.Metadata)
+		r = add(r, _metadata, string(md))
+	}
+	return r
+}
@@ -0,0 +1,41 @@
+package isb
+
+import (
+	"sync"
+	"time"
+)
+
+// PoisonMessageDeliveryCount is the delivery count from which a redelivered message is considered as a poison message,
+// which keeps failing the processing.
+const PoisonMessageDeliveryCount = 5
+
+// RedeliverySampler samples the redelivered messages to be logged, it gives the operators a lead on the poison messages
+// without flooding the logs. At most maxPerInterval messages are sampled in each interval.
+type RedeliverySampler struct {
+	lock           sync.Mutex
+	interval       time.Duration
+	maxPerInterval int
+	windowStart    time.Time
+	sampled        int
+}
+
+// NewRedeliverySampler returns a sampler sampling at most maxPerInterval messages in each interval.
+func NewRedeliverySampler(interval time.Duration, maxPerInterval int) *RedeliverySampler {
+	return &RedeliverySampler{interval: interval, maxPerInterval: maxPerInterval}
+}
+
+// Sample tells if the redelivered message should be logged.
+func (s *RedeliverySampler) Sample() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := time.Now()
+	if now.Sub(s.windowStart) >= s.interval {
+		s.windowStart = now
+		s.sampled = 0
+	}
+	if s.sampled >= s.maxPerInterval {
+		return false
+	}
+	s.sampled++
+	return true
+}
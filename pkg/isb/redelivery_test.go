@@ -0,0 +1,17 @@
+package isb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeliverySampler_Sample(t *testing.T) {
+	s := NewRedeliverySampler(100*time.Millisecond, 2)
+	assert.True(t, s.Sample())
+	assert.True(t, s.Sample())
+	assert.False(t, s.Sample())
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, s.Sample())
+}
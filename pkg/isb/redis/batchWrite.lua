@@ -0,0 +1,34 @@
+-- Lua script writes a batch of messages into the stream in a single round trip, and trims the stream afterwards.
+-- Each message is inserted only if it has not been previously written (exactly-once-semantics), the uniqueness of
+-- the message is defined by its ID, which is tracked in the hash of the message's event time window.
+-- KEYS: stream
+--       hash of message 1, ..., hash of message N
+-- ARGS: minid
+--       hash expiry in seconds
+--       ID, field (header) and value (body) of message 1, ..., ID, field (header) and value (body) of message N
+-- RET:  the inserted offsets (or the storedOffsets during replay) of the messages, in the same order
+
+local stream = KEYS[1]
+local minid = ARGV[1]
+local expiry = ARGV[2]
+
+local offsets = {}
+local touched = {}
+for i = 2, #KEYS do
+    local hash = KEYS[i]
+    local argIdx = 3 + (i - 2) * 3
+    local offset = ARGV[argIdx]
+    local storedOffset = redis.call('HGET', hash, offset)
+    if storedOffset == false then
+        storedOffset = redis.call('XADD', stream, '*', ARGV[argIdx + 1], ARGV[argIdx + 2])
+        redis.call('HSET', hash, offset, storedOffset)
+        if touched[hash] == nil then
+            redis.call('EXPIRE', hash, expiry)
+            touched[hash] = true
+        end
+    end
+    offsets[#offsets + 1] = storedOffset
+end
+-- XADD is not given MINID, trim once for the whole batch
+redis.call('XTRIM', stream, 'MINID', '~', minid)
+return offsets
@@ -0,0 +1,26 @@
+package redis
+
+import (
+	"strings"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
+
+// categorize wraps the error returned by the Redis client with its category, the server side errors are prefixed with the
+// error codes.
+func categorize(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	switch {
+	case strings.HasPrefix(msg, "NOAUTH"), strings.HasPrefix(msg, "WRONGPASS"), strings.HasPrefix(msg, "NOPERM"):
+		return isberrors.New(isberrors.Auth, err)
+	case strings.HasPrefix(msg, "NOGROUP"):
+		return isberrors.New(isberrors.NotFound, err)
+	case strings.HasPrefix(msg, "WRONGTYPE"):
+		return isberrors.New(isberrors.Fatal, err)
+	default:
+		return isberrors.New(isberrors.Transient, err)
+	}
+}
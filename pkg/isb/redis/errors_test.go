@@ -0,0 +1,19 @@
+package redis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
+)
+
+func TestCategorize(t *testing.T) {
+	assert.Nil(t, categorize(nil))
+	assert.Equal(t, isberrors.Auth, isberrors.CategoryOf(categorize(fmt.Errorf("NOAUTH Authentication required."))))
+	assert.Equal(t, isberrors.Auth, isberrors.CategoryOf(categorize(fmt.Errorf("WRONGPASS invalid username-password pair"))))
+	assert.Equal(t, isberrors.NotFound, isberrors.CategoryOf(categorize(fmt.Errorf("NOGROUP No such key 'stream' or consumer group 'group'"))))
+	assert.Equal(t, isberrors.Fatal, isberrors.CategoryOf(categorize(fmt.Errorf("WRONGTYPE Operation against a key holding the wrong kind of value"))))
+	assert.Equal(t, isberrors.Transient, isberrors.CategoryOf(categorize(fmt.Errorf("i/o timeout"))))
+}
@@ -40,6 +40,13 @@ var isbIsEmpty = promauto.NewCounterVec(prometheus.CounterOpts{
 	Help:      "Total number of IsEmpty",
 }, []string{"buffer"})
 
+// isbDiscarded is used to indicate the number of messages discarded by the writes to the full buffer
+var isbDiscarded = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_redis",
+	Name:      "discarded_total",
+	Help:      "Total number of messages discarded by the writes to the full buffer, either the latest or the oldest ones",
+}, []string{"buffer", "strategy"})
+
 // isbWriteErrors is used to indicate the number of errors in the redis write check
 var isbWriteErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 	Subsystem: "isb_redis",
@@ -60,3 +67,17 @@ var isbConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Name:      "consumer_lag",
 	Help:      "indicates consumer consumerLag",
 }, []string{"buffer"})
+
+// isbRedelivered is used to indicate the number of messages delivered more than once
+var isbRedelivered = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_redis",
+	Name:      "redelivered_total",
+	Help:      "Total number of messages delivered more than once",
+}, []string{"buffer"})
+
+// isbPoisonMessages is used to indicate the number of deliveries of the messages which have been delivered too many times
+var isbPoisonMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb_redis",
+	Name:      "poison_message_total",
+	Help:      "Total number of deliveries of the messages delivered at least 5 times",
+}, []string{"buffer"})
@@ -2,6 +2,9 @@ package redis
 
 import (
 	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
 )
 
 // options for writing to redis
@@ -22,6 +25,19 @@ type options struct {
 	bufferUsageLimit float64
 	// refreshBufferWriteInfo is used to determine if we refresh buffer write info
 	refreshBufferWriteInfo bool
+	// exactlyOnce deduplicates the messages by their IDs while writing
+	exactlyOnce bool
+	// waitReplicas is the number of replicas the writes wait for the acknowledgements of with WAIT, 0 means the writes
+	// are only acknowledged by the primary
+	waitReplicas int
+	// waitTimeout is the timeout of WAIT
+	waitTimeout time.Duration
+	// onFull is the strategy of the writes once the buffer is full
+	onFull dfv1.OnFullWritingStrategy
+	// compression is the content encoding used to compress the payloads, empty means no compression
+	compression dfv1.ContentEncoding
+	// sizeLimits are the max sizes of the keys and the headers of the messages
+	sizeLimits isb.MessageSizeLimits
 }
 
 // Option to apply different options
@@ -124,3 +140,91 @@ func (r refreshBufferWriteInfo) apply(o *options) {
 func WithRefreshBufferWriteInfo(r bool) Option {
 	return refreshBufferWriteInfo(r)
 }
+
+// exactlyOnce option
+type exactlyOnce bool
+
+func (e exactlyOnce) apply(o *options) {
+	o.exactlyOnce = bool(e)
+}
+
+// WithExactlyOnce sets whether the messages are deduplicated by their IDs
+func WithExactlyOnce(e bool) Option {
+	return exactlyOnce(e)
+}
+
+// durability option
+type durability dfv1.WriteDurability
+
+func (d durability) apply(o *options) {
+	if dfv1.WriteDurability(d) == dfv1.WriteDurabilityReplicated {
+		o.waitReplicas = 1
+		o.waitTimeout = 5 * time.Second
+	} else {
+		// redis always acknowledges the writes, None is the same as Acknowledged
+		o.waitReplicas = 0
+	}
+}
+
+// WithDurability sets the acknowledgement the writes wait for
+func WithDurability(d dfv1.WriteDurability) Option {
+	return durability(d)
+}
+
+// onFull option
+type onFull dfv1.OnFullWritingStrategy
+
+func (f onFull) apply(o *options) {
+	o.onFull = dfv1.OnFullWritingStrategy(f)
+}
+
+// WithOnFull sets the strategy of the writes once the buffer is full
+func WithOnFull(f dfv1.OnFullWritingStrategy) Option {
+	return onFull(f)
+}
+
+// compression option
+type compression dfv1.ContentEncoding
+
+func (c compression) apply(o *options) {
+	o.compression = dfv1.ContentEncoding(c)
+}
+
+// WithCompression sets the content encoding used to compress the payloads
+func WithCompression(encoding dfv1.ContentEncoding) Option {
+	return compression(encoding)
+}
+
+// maxKeySize option
+type maxKeySize int
+
+func (m maxKeySize) apply(o *options) {
+	o.sizeLimits.MaxKeySize = int(m)
+}
+
+// WithMaxKeySize sets the max size in bytes of the keys of the messages
+func WithMaxKeySize(size int) Option {
+	return maxKeySize(size)
+}
+
+// maxHeaderSize option
+type maxHeaderSize int
+
+func (m maxHeaderSize) apply(o *options) {
+	o.sizeLimits.MaxHeaderSize = int(m)
+}
+
+// WithMaxHeaderSize sets the max size in bytes of the encoded headers of the messages
+func WithMaxHeaderSize(size int) Option {
+	return maxHeaderSize(size)
+}
+
+// WriterOptionsFor returns the options of the writer of a to buffer of the vertex, decided by the limits of its edge.
+func WriterOptionsFor(vertex *dfv1.Vertex, toBufferName string) []Option {
+	return []Option{
+		WithExactlyOnce(vertex.IsExactlyOnceToBuffer(toBufferName)),
+		WithDurability(vertex.GetToBufferDurability(toBufferName)),
+		WithOnFull(vertex.GetToBufferOnFull(toBufferName)),
+		WithCompression(vertex.GetToBufferCompression(toBufferName)),
+	}
+}
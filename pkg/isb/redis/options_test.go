@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestWriterOptionsFor(t *testing.T) {
+	v := &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName:   "p",
+		AbstractVertex: dfv1.AbstractVertex{Name: "in"},
+		ToVertices:     []dfv1.ToVertex{{Name: "out", Limits: &dfv1.EdgeLimits{Durability: dfv1.WriteDurabilityReplicated, OnFull: dfv1.OnFullDiscardLatest, Compression: dfv1.ContentEncodingGzip}}},
+	}}
+	o := &options{}
+	for _, opt := range WriterOptionsFor(v, v.GetToBuffers()[0]) {
+		opt.apply(o)
+	}
+	assert.True(t, o.exactlyOnce)
+	assert.Equal(t, 1, o.waitReplicas)
+	assert.Equal(t, 5*time.Second, o.waitTimeout)
+	assert.Equal(t, dfv1.OnFullDiscardLatest, o.onFull)
+	assert.Equal(t, dfv1.ContentEncodingGzip, o.compression)
+}
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/numaproj/numaflow/pkg/isb"
+	isberrors "github.com/numaproj/numaflow/pkg/isb/errors"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 )
@@ -25,7 +26,8 @@ type BufferRead struct {
 	*BufferReadInfo
 	*clients.RedisClient
 	options
-	log *zap.SugaredLogger
+	redeliverySampler *isb.RedeliverySampler
+	log               *zap.SugaredLogger
 }
 
 // BufferReadInfo will contain the buffer information from the reader point of view.
@@ -51,11 +53,12 @@ func NewBufferRead(ctx context.Context, client *clients.RedisClient, name string
 	}
 
 	rqr := &BufferRead{
-		Name:        name,
-		Stream:      name,
-		Group:       group,
-		Consumer:    consumer,
-		RedisClient: client,
+		Name:              name,
+		Stream:            name,
+		Group:             group,
+		Consumer:          consumer,
+		RedisClient:       client,
+		redeliverySampler: isb.NewRedeliverySampler(time.Minute, 10),
 		BufferReadInfo: &BufferReadInfo{
 			rwLock:            new(sync.RWMutex),
 			isEmpty:           true,
@@ -210,6 +213,8 @@ func (br *BufferRead) Read(_ context.Context, count int64) ([]*isb.ReadMessage,
 	var messages = make([]*isb.ReadMessage, 0, count)
 	var xstreams []redis.XStream
 	var err error
+	// readBacklog tells if the messages are the pending ones, which are redelivered
+	var readBacklog bool
 	// start with 0-0 if checkBackLog is true
 	labels := map[string]string{"buffer": br.GetName()}
 	if br.options.checkBackLog {
@@ -223,7 +228,7 @@ func (br *BufferRead) Read(_ context.Context, count int64) ([]*isb.ReadMessage,
 			// we should try to do our best effort to convert our data here, if there is data available in xstream from the previous loop
 			messages, errMsg := br.convertXStreamToMessages(xstreams, messages, labels)
 			br.log.Errorw("checkBacklog true, convertXStreamToMessages failed", zap.Error(errMsg))
-			return messages, fmt.Errorf("XReadGroup failed, %w", err)
+			return messages, categorize(fmt.Errorf("XReadGroup failed, %w", err))
 		}
 
 		// NOTE: If all messages have been delivered and acknowleged, the XREADGROUP 0-0 call returns an empty
@@ -231,6 +236,8 @@ func (br *BufferRead) Read(_ context.Context, count int64) ([]*isb.ReadMessage,
 		if len(xstreams) == 1 && len(xstreams[0].Messages) == 0 {
 			br.log.Infow("We have delivered and acknowledged all PENDING msgs, setting checkBacklog to false")
 			br.checkBackLog = false
+		} else {
+			readBacklog = true
 		}
 	}
 	if !br.options.checkBackLog {
@@ -244,12 +251,53 @@ func (br *BufferRead) Read(_ context.Context, count int64) ([]*isb.ReadMessage,
 			// we should try to do our best effort to convert our data here, if there is data available in xstream from the previous loop
 			messages, errMsg := br.convertXStreamToMessages(xstreams, messages, labels)
 			br.log.Errorw("checkBacklog false, convertXStreamToMessages failed", zap.Error(errMsg))
-			return messages, fmt.Errorf("XReadGroup failed, %w", err)
+			return messages, categorize(fmt.Errorf("XReadGroup failed, %w", err))
 		}
 	}
 
 	// for each XMessage in []XStream
-	return br.convertXStreamToMessages(xstreams, messages, labels)
+	messages, err = br.convertXStreamToMessages(xstreams, messages, labels)
+	if readBacklog && len(messages) > 0 {
+		br.observeRedeliveries(messages)
+	}
+	return messages, err
+}
+
+// observeRedeliveries counts the redelivered messages, and logs the sampled ones.
+func (br *BufferRead) observeRedeliveries(messages []*isb.ReadMessage) {
+	// the pending entries are sorted by ID, query the delivery counts of the range in one call
+	pendings, err := br.Client.XPendingExt(clients.RedisContext, &redis.XPendingExtArgs{
+		Stream:   br.Stream,
+		Group:    br.Group,
+		Start:    messages[0].ReadOffset.String(),
+		End:      messages[len(messages)-1].ReadOffset.String(),
+		Count:    int64(len(messages)),
+		Consumer: br.Consumer,
+	}).Result()
+	if err != nil {
+		br.log.Warnw("Failed to get the delivery counts of the pending messages", zap.Error(err))
+		return
+	}
+	deliveryCounts := make(map[string]int64, len(pendings))
+	for _, p := range pendings {
+		deliveryCounts[p.ID] = p.RetryCount
+	}
+	labels := map[string]string{"buffer": br.GetName()}
+	for _, m := range messages {
+		deliveryCount := deliveryCounts[m.ReadOffset.String()]
+		if deliveryCount <= 1 {
+			continue
+		}
+		isbRedelivered.With(labels).Inc()
+		poison := deliveryCount >= isb.PoisonMessageDeliveryCount
+		if poison {
+			isbPoisonMessages.With(labels).Inc()
+		}
+		if br.redeliverySampler.Sample() {
+			br.log.Warnw("Message redelivered", zap.String("id", m.ID), zap.ByteString("key", m.Key),
+				zap.String("offset", m.ReadOffset.String()), zap.Int64("deliveryCount", deliveryCount), zap.Bool("poison", poison))
+		}
+	}
 }
 
 // Ack acknowledges the offset to the read queue. Ack is always pipelined, if you want to avoid it then
@@ -262,7 +310,7 @@ func (br *BufferRead) Ack(_ context.Context, offsets []isb.Offset) []error {
 	}
 	if err := br.Client.XAck(clients.RedisContext, br.Stream, br.Group, strOffsets...).Err(); err != nil {
 		for i := 0; i < len(offsets); i++ {
-			errs[i] = err
+			errs[i] = categorize(err)
 		}
 	}
 	return errs
@@ -287,15 +335,25 @@ func (br *BufferRead) convertXStreamToMessages(xstreams []redis.XStream, message
 		for _, message := range xstream.Messages {
 			var readOffset = message.ID
 
+			// a pending entry trimmed from the stream, e.g. discarded as the oldest by the writer, is acknowledged
+			if len(message.Values) == 0 {
+				if err := br.Client.XAck(clients.RedisContext, br.Stream, br.Group, readOffset).Err(); err != nil {
+					br.log.Errorw("Failed to ack the trimmed entry", zap.String("id", readOffset), zap.Error(err))
+				}
+				continue
+			}
 			// our messages have only one field/value pair (i.e., header/payload)
 			if len(message.Values) != 1 {
 				isbReadErrors.With(labels).Inc()
-				return messages, fmt.Errorf("expected only 1 pair of field/value in stream %+v", message.Values)
+				return messages, isberrors.New(isberrors.Fatal, fmt.Errorf("expected only 1 pair of field/value in stream %+v", message.Values))
 			}
 			for f, v := range message.Values {
 				msg, err := getHeaderAndBody(f, v)
 				if err != nil {
-					return messages, fmt.Errorf("%w", err)
+					return messages, isberrors.New(isberrors.Fatal, err)
+				}
+				if err := isb.DecompressMessage(&msg); err != nil {
+					br.log.Errorw("Failed to decompress the payload", zap.String("id", msg.ID), zap.String("encoding", msg.ContentEncoding), zap.Error(err))
 				}
 				readMessage := isb.ReadMessage{
 					Message:    msg,
@@ -310,6 +368,18 @@ func (br *BufferRead) convertXStreamToMessages(xstreams []redis.XStream, message
 	return messages, nil
 }
 
+// ConvertToIsbMessage converts the field/value pairs of a stream entry in the Redis buffer to an ISB message.
+func ConvertToIsbMessage(values map[string]interface{}) (isb.Message, error) {
+	// our messages have only one field/value pair (i.e., header/payload)
+	if len(values) != 1 {
+		return isb.Message{}, fmt.Errorf("expected only 1 pair of field/value in stream %+v", values)
+	}
+	for f, v := range values {
+		return getHeaderAndBody(f, v)
+	}
+	return isb.Message{}, nil
+}
+
 func getHeaderAndBody(field string, value interface{}) (msg isb.Message, err error) {
 	err = msg.Header.UnmarshalBinary([]byte(field))
 	if err != nil {
@@ -20,9 +20,20 @@ import (
 // exactlyOnceHashWindow groups a set of time range to a single bucket
 const exactlyOnceHashWindow = time.Minute * 5
 
+// exactlyOnceHashExpiry is the expiry of the hash used to dedup the messages, it needs to be longer than exactlyOnceHashWindow
+const exactlyOnceHashExpiry = 600
+
 //go:embed exactlyOnceInsert.lua
 var exactlyOnceInsertLuaScript string
 
+//go:embed batchWrite.lua
+var batchWriteLuaScript string
+
+var (
+	exactlyOnceInsertScript = redis.NewScript(exactlyOnceInsertLuaScript)
+	batchWriteScript        = redis.NewScript(batchWriteLuaScript)
+)
+
 // BufferWrite is the write queue implementation powered by RedisClient.
 type BufferWrite struct {
 	Name   string
@@ -47,6 +58,7 @@ type BufferWriteInfo struct {
 }
 
 var _ isb.BufferWriter = (*BufferWrite)(nil)
+var _ isb.BufferUsageReporter = (*BufferWrite)(nil)
 
 // NewBufferWrite returns a new redis queue writer.
 func NewBufferWrite(ctx context.Context, client *clients.RedisClient, name string, group string, opts ...Option) isb.BufferWriter {
@@ -57,6 +69,8 @@ func NewBufferWrite(ctx context.Context, client *clients.RedisClient, name strin
 		maxLength:              dfv1.DefaultBufferLength,
 		bufferUsageLimit:       dfv1.DefaultBufferUsageLimit,
 		refreshBufferWriteInfo: true,
+		exactlyOnce:            true,
+		onFull:                 dfv1.OnFullRetryUntilSuccess,
 	}
 
 	for _, o := range opts {
@@ -84,6 +98,9 @@ func NewBufferWrite(ctx context.Context, client *clients.RedisClient, name strin
 
 	rqw.log = logging.FromContext(ctx).With("bufferWriter", rqw.GetName())
 
+	// preload the scripts, so that the writes could use EVALSHA without sending the scripts
+	rqw.loadScripts()
+
 	//setWriteInfo is used to update isFull flag and minId once
 	rqw.setWriteInfo(ctx)
 
@@ -127,49 +144,107 @@ func (bw *BufferWrite) trim(_ context.Context) {
 	}
 }
 
+// loadScripts loads the lua scripts into the redis script cache.
+func (bw *BufferWrite) loadScripts() {
+	ctx := clients.RedisContext
+	for _, script := range []*redis.Script{exactlyOnceInsertScript, batchWriteScript} {
+		if err := script.Load(ctx, bw.Client).Err(); err != nil {
+			// Not fatal, the scripts are loaded again if they are missing while writing
+			bw.log.Warnw("Failed to preload the lua script", zap.Error(err))
+		}
+	}
+}
+
 func (br *BufferWrite) Close() error {
 	return nil
 }
 
-// Write is used to write data to the redis interstep buffer
+// Write is used to write data to the redis interstep buffer, the messages with the keys or the headers larger than the
+// max sizes fail without being written
 func (bw *BufferWrite) Write(_ context.Context, messages []isb.Message) ([]isb.Offset, []error) {
+	return isb.WriteWithinSizeLimits(bw.Name, bw.sizeLimits, encodedHeaderSize, messages, bw.write)
+}
+
+// encodedHeaderSize returns the size of the header encoded in the stream entry.
+func encodedHeaderSize(header isb.Header) int {
+	data, _ := header.MarshalBinary()
+	return len(data)
+}
+
+func (bw *BufferWrite) write(messages []isb.Message) ([]isb.Offset, []error) {
 	ctx := clients.RedisContext
 	var errs = make([]error, len(messages))
 
-	script := redis.NewScript(exactlyOnceInsertLuaScript)
 	labels := map[string]string{"buffer": bw.GetName()}
 
 	if bw.IsFull() {
 		bw.log.Debugw("Is full")
 		isbIsFull.With(labels).Inc()
-		initializeErrorArray(errs, isb.BufferWriteErr{Name: bw.Name, Full: true, Message: "Buffer full!"})
+		switch bw.onFull {
+		case dfv1.OnFullDiscardLatest:
+			isbDiscarded.With(map[string]string{"buffer": bw.GetName(), "strategy": string(dfv1.OnFullDiscardLatest)}).Add(float64(len(messages)))
+			return nil, errs
+		case dfv1.OnFullDiscardOldest:
+			if err := bw.discardOldest(ctx, int64(len(messages))); err != nil {
+				bw.log.Errorw("Failed to discard the oldest messages", zap.Error(err))
+				initializeErrorArray(errs, isb.BufferWriteErr{Name: bw.Name, Full: true, Message: "Buffer full!"})
+				isbWriteErrors.With(labels).Inc()
+				return nil, errs
+			}
+		default:
+			initializeErrorArray(errs, isb.BufferWriteErr{Name: bw.Name, Full: true, Message: "Buffer full!"})
+			isbWriteErrors.With(labels).Inc()
+			return nil, errs
+		}
+	}
+	messages, err := isb.CompressMessages(string(bw.compression), messages)
+	if err != nil {
+		initializeErrorArray(errs, isb.BufferWriteErr{Name: bw.Name, Message: fmt.Sprintf("failed to compress the payload, %v", err)})
 		isbWriteErrors.With(labels).Inc()
 		return nil, errs
 	}
-	// Maybe just do pipelined write, always?
-	if !bw.pipelining {
+	if bw.waitReplicas > 0 {
+		errs = bw.writeReplicated(ctx, messages)
+	} else if !bw.exactlyOnce {
+		errs = bw.xAdd(ctx, messages)
+	} else if !bw.pipelining {
 		for idx, message := range messages {
-			errs[idx] = script.Run(ctx, bw.Client, []string{bw.GetHashKeyName(message.EventTime), bw.Stream}, message.Header.ID, message.Header, message.Body, bw.BufferWriteInfo.minId.String()).Err()
+			// Run uses EVALSHA, and falls back to EVAL if the script is missing
+			errs[idx] = categorize(exactlyOnceInsertScript.Run(ctx, bw.Client, []string{bw.GetHashKeyName(message.EventTime), bw.Stream}, message.Header.ID, message.Header, message.Body, bw.BufferWriteInfo.minId.String()).Err())
 		}
 	} else {
-		var scriptMissing bool
-		// use pipelining
-		errs, scriptMissing = bw.pipelinedWrite(ctx, script, messages)
-		// if scriptMissing, then load and retry
-		if scriptMissing {
-			if err := bw.Client.ScriptLoad(ctx, exactlyOnceInsertLuaScript).Err(); err != nil {
-				initializeErrorArray(errs, err)
-				isbWriteErrors.With(labels).Inc()
-				return nil, errs
-			}
-			// now that we have loaded, we do not care about whether the script exists or not.
-			errs, _ = bw.pipelinedWrite(ctx, script, messages)
+		// the whole batch is written by one script call, in a single round trip
+		errs = bw.batchWrite(ctx, messages)
+	}
+	for _, err := range errs {
+		if err != nil {
+			isbWriteErrors.With(labels).Inc()
+			break
 		}
 	}
 
 	return nil, errs
 }
 
+// discardOldest trims the n oldest entries of the stream to make room for the ones being written.
+func (bw *BufferWrite) discardOldest(ctx context.Context, n int64) error {
+	length, err := bw.Client.XLen(ctx, bw.GetStreamName()).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get the stream length, %w", err)
+	}
+	maxLen := length - n
+	if maxLen < 0 {
+		maxLen = 0
+	}
+	trimmed, err := bw.Client.XTrimMaxLen(ctx, bw.GetStreamName(), maxLen).Result()
+	if err != nil {
+		return fmt.Errorf("failed to trim the stream, %w", err)
+	}
+	isbDiscarded.With(map[string]string{"buffer": bw.GetName(), "strategy": string(dfv1.OnFullDiscardOldest)}).Add(float64(trimmed))
+	bw.log.Debugw("Discarded the oldest messages", zap.Int64("trimmed", trimmed))
+	return nil
+}
+
 // initializeErrorArray is used to initialize an empty array for
 func initializeErrorArray(errs []error, err error) {
 	for i := range errs {
@@ -177,40 +252,133 @@ func initializeErrorArray(errs []error, err error) {
 	}
 }
 
-// pipelinedWrite is used to perform pipelined write messages
-func (bw *BufferWrite) pipelinedWrite(ctx context.Context, script *redis.Script, messages []isb.Message) ([]error, bool) {
+// batchWrite writes the messages with the batch write script, which does the dedup, XADD and trim atomically on the
+// server side. The script is called with EVALSHA, if it's missing from the script cache (e.g. redis restarted or failed
+// over), it's loaded and called again.
+func (bw *BufferWrite) batchWrite(ctx context.Context, messages []isb.Message) []error {
 	var errs = make([]error, len(messages))
-	var cmds = make([]*redis.Cmd, len(messages))
-	pipe := bw.Client.Pipeline()
+	if len(messages) == 0 {
+		return errs
+	}
+	keys, args := bw.batchWriteKeysAndArgs(messages)
+	err := batchWriteScript.EvalSha(ctx, bw.Client, keys, args...).Err()
+	if isNoScriptErr(err) {
+		if err = batchWriteScript.Load(ctx, bw.Client).Err(); err == nil {
+			err = batchWriteScript.EvalSha(ctx, bw.Client, keys, args...).Err()
+		}
+	}
+	if err != nil {
+		initializeErrorArray(errs, categorize(err))
+	}
+	return errs
+}
 
-	for idx, message := range messages {
-		cmds[idx] = script.Run(ctx, pipe, []string{bw.GetHashKeyName(message.EventTime), bw.Stream}, message.Header.ID, message.Header, message.Body, bw.BufferWriteInfo.minId.String())
+// xAdd writes the messages without deduplicating them, in a single round trip if pipelining is enabled.
+func (bw *BufferWrite) xAdd(ctx context.Context, messages []isb.Message) []error {
+	var errs = make([]error, len(messages))
+	if !bw.pipelining {
+		for idx, message := range messages {
+			errs[idx] = categorize(bw.xAddCmd(ctx, bw.Client, message).Err())
+		}
+		return errs
+	}
+	cmds, _ := bw.Client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for _, message := range messages {
+			bw.xAddCmd(ctx, p, message)
+		}
+		return nil
+	})
+	for idx, cmd := range cmds {
+		errs[idx] = categorize(cmd.Err())
 	}
+	return errs
+}
+
+// xAddCmd adds the message to the stream without deduplicating it.
+func (bw *BufferWrite) xAddCmd(ctx context.Context, c redis.Cmdable, message isb.Message) *redis.StringCmd {
+	return c.XAdd(ctx, &redis.XAddArgs{
+		Stream: bw.Stream,
+		MinID:  bw.BufferWriteInfo.minId.String(),
+		Approx: true,
+		Values: []interface{}{message.Header, message.Body},
+	})
+}
 
-	scriptMissing := false
-	_, err := pipe.Exec(ctx)
+// writeReplicated writes the messages, and waits for the writes to be acknowledged by the replicas with WAIT. As WAIT
+// only covers the writes sent on its own connection, the writes and WAIT are sent in one pipeline. If the writes are
+// not acknowledged by enough replicas in time, they fail to be retried, which are deduplicated if exactly-once is on.
+func (bw *BufferWrite) writeReplicated(ctx context.Context, messages []isb.Message) []error {
+	var errs = make([]error, len(messages))
+	if len(messages) == 0 {
+		return errs
+	}
+	// WAIT has no key, it's sent to the node of the stream for a Redis Cluster
+	client, err := bw.ForKey(ctx, bw.Stream)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "NOSCRIPT ") {
-			scriptMissing = true
+		initializeErrorArray(errs, categorize(err))
+		return errs
+	}
+	write := func() []redis.Cmder {
+		cmds, _ := client.Pipelined(ctx, func(p redis.Pipeliner) error {
+			if bw.exactlyOnce {
+				keys, args := bw.batchWriteKeysAndArgs(messages)
+				batchWriteScript.EvalSha(ctx, p, keys, args...)
+			} else {
+				for _, message := range messages {
+					bw.xAddCmd(ctx, p, message)
+				}
+			}
+			p.Do(ctx, "WAIT", bw.waitReplicas, bw.waitTimeout.Milliseconds())
+			return nil
+		})
+		return cmds
+	}
+	cmds := write()
+	if bw.exactlyOnce && isNoScriptErr(cmds[0].Err()) {
+		if err := batchWriteScript.Load(ctx, client).Err(); err == nil {
+			cmds = write()
 		}
-		initializeErrorArray(errs, err)
-		return errs, scriptMissing
 	}
-
-	for idx, cmd := range cmds {
-		err := cmd.Err()
-		if err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT ") {
-			scriptMissing = true
+	if bw.exactlyOnce {
+		initializeErrorArray(errs, categorize(cmds[0].Err()))
+	} else {
+		for idx := range messages {
+			errs[idx] = categorize(cmds[idx].Err())
+		}
+	}
+	replicas, waitErr := cmds[len(cmds)-1].(*redis.Cmd).Int64()
+	if waitErr != nil || replicas < int64(bw.waitReplicas) {
+		err := isb.BufferWriteErr{Name: bw.Name, Message: fmt.Sprintf("written but acknowledged by %d of %d replicas in %v, %v", replicas, bw.waitReplicas, bw.waitTimeout, waitErr)}
+		for idx := range errs {
+			if errs[idx] == nil {
+				errs[idx] = err
+			}
 		}
-		errs[idx] = err
 	}
+	return errs
+}
+
+// batchWriteKeysAndArgs builds the keys and args of the batch write script for the messages.
+func (bw *BufferWrite) batchWriteKeysAndArgs(messages []isb.Message) ([]string, []interface{}) {
+	keys := make([]string, 0, len(messages)+1)
+	args := make([]interface{}, 0, len(messages)*3+2)
+	keys = append(keys, bw.Stream)
+	args = append(args, bw.BufferWriteInfo.minId.String(), exactlyOnceHashExpiry)
+	for _, message := range messages {
+		keys = append(keys, bw.GetHashKeyName(message.EventTime))
+		args = append(args, message.Header.ID, message.Header, message.Body)
+	}
+	return keys, args
+}
 
-	return errs, scriptMissing
+// isNoScriptErr tells if the error is caused by the script missing from the redis script cache.
+func isNoScriptErr(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT ")
 }
 
 // GetHashKeyName gets the hash key name.
 func (bw *BufferWrite) GetHashKeyName(startTime time.Time) string {
-	return fmt.Sprintf("%s-h-%d", bw.Stream, startTime.Truncate(exactlyOnceHashWindow).Unix())
+	return fmt.Sprintf("%s-h-%d", bw.HashTag(bw.Stream), startTime.Truncate(exactlyOnceHashWindow).Unix())
 }
 
 // GetStreamName gets the stream name. Stream name is derived from the name.
@@ -280,6 +280,16 @@ func (bw *BufferWrite) GetBufferLength() int64 {
 	return bw.BufferWriteInfo.bufferLength.Load()
 }
 
+// GetUsage returns the usage of the buffer derived from the buffer length. It could be approximate.
+func (bw *BufferWrite) GetUsage() float64 {
+	return float64(bw.GetBufferLength()) / float64(bw.maxLength)
+}
+
+// GetUsageLimit returns the usage limit of the buffer.
+func (bw *BufferWrite) GetUsageLimit() float64 {
+	return bw.bufferUsageLimit
+}
+
 // GetConsumerLag returns the consumerLag of the buffer
 func (bw *BufferWrite) GetConsumerLag() time.Duration {
 	return bw.BufferWriteInfo.consumerLag.Load()
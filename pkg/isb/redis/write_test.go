@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
@@ -119,6 +120,110 @@ func TestRedisQWrite_WithoutPipeline(t *testing.T) {
 	assert.Equal(t, make([]error, len(writeMessages)), errs, "Write failed")
 }
 
+func TestRedisQWrite_WithoutExactlyOnce(t *testing.T) {
+	client := clients.NewRedisClient(redisOptions)
+	ctx := context.Background()
+	for _, pipelining := range []bool{true, false} {
+		stream := fmt.Sprintf("withoutExactlyOnce-%t", pipelining)
+		rqw, _ := NewBufferWrite(ctx, client, stream, "test", WithExactlyOnce(false)).(*BufferWrite)
+		rqw.options.pipelining = pipelining
+		writeMessages, internalKeys := buildTestWriteMessages(rqw, 10, time.Unix(1636470000, 0))
+		// Not deduplicated when written twice
+		for i := 0; i < 2; i++ {
+			_, errs := rqw.Write(ctx, writeMessages)
+			assert.Equal(t, make([]error, len(writeMessages)), errs, "Write failed")
+		}
+		length, err := client.Client.XLen(ctx, rqw.GetStreamName()).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), length)
+		_ = client.DeleteKeys(ctx, append(internalKeys, rqw.GetStreamName())...)
+	}
+}
+
+func TestRedisQWrite_WithDurabilityReplicated(t *testing.T) {
+	client := clients.NewRedisClient(redisOptions)
+	ctx := context.Background()
+	for _, exactlyOnce := range []bool{true, false} {
+		stream := fmt.Sprintf("durabilityReplicated-%t", exactlyOnce)
+		rqw, _ := NewBufferWrite(ctx, client, stream, "test", WithExactlyOnce(exactlyOnce), WithDurability(dfv1.WriteDurabilityReplicated)).(*BufferWrite)
+		rqw.options.waitTimeout = 100 * time.Millisecond
+		writeMessages, internalKeys := buildTestWriteMessages(rqw, 10, time.Unix(1636470000, 0))
+		// The test redis has no replicas, the messages are written but not acknowledged by the replicas
+		_, errs := rqw.Write(ctx, writeMessages)
+		for _, err := range errs {
+			assert.Contains(t, err.Error(), "acknowledged by 0 of 1 replicas")
+		}
+		length, err := client.Client.XLen(ctx, rqw.GetStreamName()).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), length)
+		_ = client.DeleteKeys(ctx, append(internalKeys, rqw.GetStreamName())...)
+	}
+}
+
+func TestRedisQWrite_OnFull(t *testing.T) {
+	client := clients.NewRedisClient(redisOptions)
+	ctx := context.Background()
+	stream := "onFull"
+	rqw, _ := NewBufferWrite(ctx, client, stream, "test", WithRefreshBufferWriteInfo(false), WithOnFull(dfv1.OnFullDiscardLatest)).(*BufferWrite)
+	writeMessages, internalKeys := buildTestWriteMessages(rqw, 10, time.Unix(1636470000, 0))
+	defer func() { _ = client.DeleteKeys(ctx, append(internalKeys, rqw.GetStreamName())...) }()
+	rqw.setIsFull(false)
+	_, errs := rqw.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 10), errs)
+
+	// the new messages are discarded
+	rqw.setIsFull(true)
+	writeMessages, keys := buildTestWriteMessages(rqw, 5, time.Unix(1636470010, 0))
+	internalKeys = append(internalKeys, keys...)
+	_, errs = rqw.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+	length, err := client.Client.XLen(ctx, rqw.GetStreamName()).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), length)
+
+	// the 5 oldest messages are discarded to make room for the new ones
+	rqw.onFull = dfv1.OnFullDiscardOldest
+	_, errs = rqw.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+	length, err = client.Client.XLen(ctx, rqw.GetStreamName()).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), length)
+}
+
+func TestRedisQWrite_WithCompression(t *testing.T) {
+	client := clients.NewRedisClient(redisOptions)
+	ctx := context.Background()
+	stream := "compression"
+	group := "compression-group"
+	rqw, _ := NewBufferWrite(ctx, client, stream, group, WithRefreshBufferWriteInfo(false), WithCompression(dfv1.ContentEncodingGzip)).(*BufferWrite)
+	rqr, _ := NewBufferRead(ctx, client, stream, group, "con-0").(*BufferRead)
+	err := client.CreateStreamGroup(ctx, rqr.GetStreamName(), group, clients.ReadFromEarliest)
+	assert.NoError(t, err)
+	writeMessages, internalKeys := buildTestWriteMessages(rqw, 5, time.Unix(1636470000, 0))
+	defer func() { _ = client.DeleteStreamGroup(ctx, rqr.GetStreamName(), group) }()
+	defer func() { _ = client.DeleteKeys(ctx, append(internalKeys, rqw.GetStreamName())...) }()
+	_, errs := rqw.Write(ctx, writeMessages)
+	assert.Equal(t, make([]error, 5), errs)
+
+	// the payloads are compressed in the stream
+	entries, err := client.Client.XRange(ctx, rqw.GetStreamName(), "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 5)
+	msg, err := ConvertToIsbMessage(entries[0].Values)
+	assert.NoError(t, err)
+	assert.Equal(t, "gzip", msg.ContentEncoding)
+	assert.NotEqual(t, writeMessages[0].Payload, msg.Payload)
+
+	// and decompressed by the reader
+	readMessages, err := rqr.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.Len(t, readMessages, 5)
+	for i, m := range readMessages {
+		assert.Equal(t, "", m.ContentEncoding)
+		assert.Equal(t, writeMessages[i].Payload, m.Payload)
+	}
+}
+
 func TestRedisQWrite_WithInfoRefreshInterval(t *testing.T) {
 	client := clients.NewRedisClient(redisOptions)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -202,6 +307,52 @@ func TestLua(t *testing.T) {
 	assert.Positive(t, id, res)
 }
 
+func TestBatchWriteLua(t *testing.T) {
+	ctx := context.Background()
+	client := clients.NewRedisClient(redisOptions)
+	rqw := &BufferWrite{Name: "{step-1}:stream-batch", Stream: "{step-1}:stream-batch", BufferWriteInfo: &BufferWriteInfo{minId: atomic.NewString("0-0")}, RedisClient: client}
+	messages := testutils.BuildTestWriteMessages(10, testStartTime)
+	keys, args := rqw.batchWriteKeysAndArgs(messages)
+	// cleanup afterwards
+	defer func() { _ = client.DeleteKeys(ctx, keys...) }()
+	// remove the script
+	assert.NoError(t, client.Client.ScriptFlush(ctx).Err())
+
+	// first insert, the missing script is loaded
+	assert.Equal(t, make([]error, 10), rqw.batchWrite(ctx, messages))
+	result, err := client.Client.XLen(ctx, rqw.Stream).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), result)
+	firstOffsets, err := batchWriteScript.Run(ctx, client.Client, keys, args...).StringSlice()
+	assert.NoError(t, err)
+	assert.Len(t, firstOffsets, 10)
+
+	// duplicate insert returns the stored offsets
+	assert.Equal(t, make([]error, 10), rqw.batchWrite(ctx, messages))
+	result, err = client.Client.XLen(ctx, rqw.Stream).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), result)
+	offsets, err := batchWriteScript.Run(ctx, client.Client, keys, args...).StringSlice()
+	assert.NoError(t, err)
+	assert.Equal(t, firstOffsets, offsets)
+}
+
+func Test_batchWriteKeysAndArgs(t *testing.T) {
+	rqw := &BufferWrite{Stream: "stream", BufferWriteInfo: &BufferWriteInfo{minId: atomic.NewString("1-0")}}
+	messages := testutils.BuildTestWriteMessages(2, testStartTime)
+	keys, args := rqw.batchWriteKeysAndArgs(messages)
+	assert.Equal(t, []string{"stream", rqw.GetHashKeyName(messages[0].EventTime), rqw.GetHashKeyName(messages[1].EventTime)}, keys)
+	assert.Len(t, args, 8)
+	assert.Equal(t, "1-0", args[0])
+	assert.Equal(t, messages[1].Header.ID, args[5])
+}
+
+func Test_isNoScriptErr(t *testing.T) {
+	assert.False(t, isNoScriptErr(nil))
+	assert.False(t, isNoScriptErr(fmt.Errorf("ERR xxx")))
+	assert.True(t, isNoScriptErr(fmt.Errorf("NOSCRIPT No matching script. Please use EVAL.")))
+}
+
 func Test_initializeErrorArray(t *testing.T) {
 	count := 10
 	var errs = make([]error, count)
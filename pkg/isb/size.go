@@ -0,0 +1,76 @@
+package isb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	MessagePartKey    = "key"
+	MessagePartHeader = "header"
+)
+
+// oversizedMessages is used to indicate the number of messages not written because of their oversized keys or headers
+var oversizedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "isb",
+	Name:      "oversized_total",
+	Help:      "Total number of messages not written to the buffer because of their keys or headers larger than the max sizes",
+}, []string{"buffer", "part"})
+
+// MessageSizeLimits are the max sizes in bytes of the keys and the headers of the messages written to a buffer, zero
+// means no limit.
+type MessageSizeLimits struct {
+	MaxKeySize    int
+	MaxHeaderSize int
+}
+
+// Check returns a MessageTooLargeErr if the key or the header of the message is larger than the limits, headerSize
+// returns the size of the header as it's encoded by the buffer.
+func (l MessageSizeLimits) Check(name string, m Message, headerSize func(Header) int) error {
+	if l.MaxKeySize > 0 && len(m.Key) > l.MaxKeySize {
+		return MessageTooLargeErr{Name: name, Part: MessagePartKey, Size: len(m.Key), Limit: l.MaxKeySize}
+	}
+	if l.MaxHeaderSize > 0 {
+		if size := headerSize(m.Header); size > l.MaxHeaderSize {
+			return MessageTooLargeErr{Name: name, Part: MessagePartHeader, Size: size, Limit: l.MaxHeaderSize}
+		}
+	}
+	return nil
+}
+
+// WriteWithinSizeLimits writes the messages within the size limits with write, the oversized ones fail with
+// MessageTooLargeErr without being written. The offsets and the errors are in the same order as the messages.
+func WriteWithinSizeLimits(name string, limits MessageSizeLimits, headerSize func(Header) int, messages []Message, write func([]Message) ([]Offset, []error)) ([]Offset, []error) {
+	if limits.MaxKeySize <= 0 && limits.MaxHeaderSize <= 0 {
+		return write(messages)
+	}
+	errs := make([]error, len(messages))
+	valid := make([]Message, 0, len(messages))
+	indexes := make([]int, 0, len(messages))
+	for i, m := range messages {
+		if err := limits.Check(name, m, headerSize); err != nil {
+			errs[i] = err
+			oversizedMessages.With(map[string]string{"buffer": name, "part": err.(MessageTooLargeErr).Part}).Inc()
+			continue
+		}
+		valid = append(valid, m)
+		indexes = append(indexes, i)
+	}
+	if len(valid) == len(messages) {
+		return write(messages)
+	}
+	var offsets []Offset
+	if len(valid) > 0 {
+		validOffsets, validErrs := write(valid)
+		if validOffsets != nil {
+			offsets = make([]Offset, len(messages))
+		}
+		for j, i := range indexes {
+			errs[i] = validErrs[j]
+			if validOffsets != nil {
+				offsets[i] = validOffsets[j]
+			}
+		}
+	}
+	return offsets, errs
+}
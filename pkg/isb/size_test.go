@@ -0,0 +1,67 @@
+package isb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testOffset int
+
+func (o testOffset) String() string           { return fmt.Sprint(int(o)) }
+func (o testOffset) Sequence() (int64, error) { return int64(o), nil }
+func (o testOffset) AckIt() error             { return nil }
+
+func TestMessageSizeLimits_Check(t *testing.T) {
+	headerSize := func(h Header) int { return len(h.ID) }
+	m := Message{Header: Header{ID: "0123456789", Key: []byte("abcd")}}
+	assert.NoError(t, MessageSizeLimits{}.Check("buffer", m, headerSize))
+	assert.NoError(t, MessageSizeLimits{MaxKeySize: 4, MaxHeaderSize: 10}.Check("buffer", m, headerSize))
+	err := MessageSizeLimits{MaxKeySize: 3}.Check("buffer", m, headerSize)
+	assert.Equal(t, MessageTooLargeErr{Name: "buffer", Part: MessagePartKey, Size: 4, Limit: 3}, err)
+	assert.Equal(t, "(buffer) the key of the message is 4 bytes, larger than the max key size 3 bytes", err.Error())
+	err = MessageSizeLimits{MaxHeaderSize: 9}.Check("buffer", m, headerSize)
+	assert.Equal(t, MessageTooLargeErr{Name: "buffer", Part: MessagePartHeader, Size: 10, Limit: 9}, err)
+}
+
+func TestWriteWithinSizeLimits(t *testing.T) {
+	headerSize := func(h Header) int { return len(h.ID) }
+	messages := []Message{
+		{Header: Header{ID: "1", Key: []byte("a")}},
+		{Header: Header{ID: "2", Key: []byte("too long")}},
+		{Header: Header{ID: "3", Key: []byte("b")}},
+	}
+	var written []Message
+	write := func(ms []Message) ([]Offset, []error) {
+		written = ms
+		offsets := make([]Offset, len(ms))
+		errs := make([]error, len(ms))
+		for i, m := range ms {
+			offsets[i] = testOffset(i)
+			if m.ID == "3" {
+				errs[i] = errors.New("write failed")
+			}
+		}
+		return offsets, errs
+	}
+
+	offsets, errs := WriteWithinSizeLimits("buffer", MessageSizeLimits{}, headerSize, messages, write)
+	assert.Len(t, written, 3)
+	assert.Len(t, offsets, 3)
+	assert.Nil(t, errs[1])
+
+	offsets, errs = WriteWithinSizeLimits("buffer", MessageSizeLimits{MaxKeySize: 4}, headerSize, messages, write)
+	assert.Equal(t, []Message{messages[0], messages[2]}, written)
+	assert.Equal(t, []Offset{testOffset(0), nil, testOffset(1)}, offsets)
+	assert.NoError(t, errs[0])
+	assert.IsType(t, MessageTooLargeErr{}, errs[1])
+	assert.EqualError(t, errs[2], "write failed")
+
+	written = nil
+	offsets, errs = WriteWithinSizeLimits("buffer", MessageSizeLimits{MaxKeySize: 4}, headerSize, messages[1:2], write)
+	assert.Nil(t, written)
+	assert.Nil(t, offsets)
+	assert.IsType(t, MessageTooLargeErr{}, errs[0])
+}
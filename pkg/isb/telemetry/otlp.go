@@ -0,0 +1,159 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+)
+
+// The messages of OTLP traces in the JSON encoding, see https://github.com/open-telemetry/opentelemetry-proto.
+// The trace IDs and span IDs are hex encoded, and the 64-bit integers are encoded as strings.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes,omitempty"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano uint64         `json:"startTimeUnixNano,string"`
+		EndTimeUnixNano   uint64         `json:"endTimeUnixNano,string"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+	otlpAnyValue struct {
+		StringValue string `json:"stringValue"`
+	}
+	otlpStatus struct {
+		Message string `json:"message,omitempty"`
+		Code    int    `json:"code"`
+	}
+)
+
+// The span kinds and status codes of OTLP.
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindClient   = 3
+	otlpSpanKindProducer = 4
+	otlpSpanKindConsumer = 5
+
+	otlpStatusCodeOK    = 1
+	otlpStatusCodeError = 2
+)
+
+// OTLPExporter posts the spans to an OTLP/HTTP traces endpoint in the JSON encoding.
+type OTLPExporter struct {
+	endpoint   string
+	client     *http.Client
+	attributes map[string]string
+}
+
+// NewOTLPExporter returns the exporter posting the spans to the endpoint, with the attributes as the resource attributes.
+func NewOTLPExporter(endpoint string, client *http.Client, attributes map[string]string) *OTLPExporter {
+	return &OTLPExporter{endpoint: endpoint, client: client, attributes: attributes}
+}
+
+func (o *OTLPExporter) Export(ctx context.Context, spans []*Span) error {
+	body, err := json.Marshal(o.request(spans))
+	if err != nil {
+		return fmt.Errorf("failed to marshal the OTLP spans, %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the OTLP spans, %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to post the OTLP spans, status code %d: %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
+
+func (o *OTLPExporter) request(spans []*Span) *otlpRequest {
+	result := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.Context.TraceID[:]),
+			SpanID:            hex.EncodeToString(s.Context.SpanID[:]),
+			Name:              s.Name,
+			Kind:              spanKind(s.Name),
+			StartTimeUnixNano: uint64(s.Start.UnixNano()),
+			EndTimeUnixNano:   uint64(s.End.UnixNano()),
+			Attributes:        keyValues(map[string]string{"numaflow.message.id": s.MessageID}),
+			Status:            otlpStatus{Code: otlpStatusCodeOK},
+		}
+		if s.Parent.SpanID != [8]byte{} {
+			span.ParentSpanID = hex.EncodeToString(s.Parent.SpanID[:])
+		}
+		if s.Err != nil {
+			span.Status = otlpStatus{Code: otlpStatusCodeError, Message: s.Err.Error()}
+		}
+		result = append(result, span)
+	}
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource:   otlpResource{Attributes: keyValues(o.attributes)},
+			ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "numaflow"}, Spans: result}},
+		}},
+	}
+}
+
+// spanKind returns the OTLP span kind of the span, the buffers and the sinks are like the queues the messages are
+// produced to, and the sources are like the queues the messages are consumed from.
+func spanKind(name string) int {
+	switch name {
+	case SpanSourceRead:
+		return otlpSpanKindConsumer
+	case SpanISBWrite, SpanSinkWrite:
+		return otlpSpanKindProducer
+	case SpanUDFInvoke:
+		return otlpSpanKindClient
+	default:
+		return otlpSpanKindInternal
+	}
+}
+
+func keyValues(m map[string]string) []otlpKeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: m[k]}})
+	}
+	return result
+}
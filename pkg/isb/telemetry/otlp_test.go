@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	parent, _ := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	start := time.Unix(0, 1000)
+	spans := []*Span{
+		{Name: SpanUDFInvoke, Context: SpanContext{TraceID: parent.TraceID, SpanID: [8]byte{1}}, Parent: parent, MessageID: "m1", Start: start, End: start.Add(time.Microsecond), Err: errors.New("failed")},
+		{Name: SpanSourceRead, Context: SpanContext{TraceID: parent.TraceID, SpanID: [8]byte{2}}, MessageID: "m2", Start: start, End: start},
+	}
+	e := NewOTLPExporter(server.URL+"/v1/traces", server.Client(), map[string]string{"service.name": "pl-p1"})
+	require.NoError(t, e.Export(context.Background(), spans))
+
+	var req otlpRequest
+	require.NoError(t, json.Unmarshal(body, &req))
+	require.Len(t, req.ResourceSpans, 1)
+	assert.Equal(t, []otlpKeyValue{{Key: "service.name", Value: otlpAnyValue{StringValue: "pl-p1"}}}, req.ResourceSpans[0].Resource.Attributes)
+	result := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, result, 2)
+	assert.Equal(t, otlpSpan{
+		TraceID:           "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:            "0100000000000000",
+		ParentSpanID:      "00f067aa0ba902b7",
+		Name:              SpanUDFInvoke,
+		Kind:              otlpSpanKindClient,
+		StartTimeUnixNano: 1000,
+		EndTimeUnixNano:   2000,
+		Attributes:        []otlpKeyValue{{Key: "numaflow.message.id", Value: otlpAnyValue{StringValue: "m1"}}},
+		Status:            otlpStatus{Code: otlpStatusCodeError, Message: "failed"},
+	}, result[0])
+	assert.Equal(t, "", result[1].ParentSpanID)
+	assert.Equal(t, otlpSpanKindConsumer, result[1].Kind)
+	assert.Equal(t, otlpStatus{Code: otlpStatusCodeOK}, result[1].Status)
+	assert.Contains(t, string(body), `"startTimeUnixNano":"1000"`)
+}
+
+func TestOTLPExporter_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad spans"))
+	}))
+	defer server.Close()
+	e := NewOTLPExporter(server.URL, server.Client(), nil)
+	err := e.Export(context.Background(), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status code 400: bad spans")
+}
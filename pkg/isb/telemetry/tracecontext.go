@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SpanContext is the W3C trace context of a span, see https://www.w3.org/TR/trace-context/.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid tells if the span context has both a trace ID and a span ID.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// TraceParent returns the traceparent of the span context, in version 00.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + flags
+}
+
+// ParseTraceParent parses a traceparent, it returns false if the traceparent is not valid. The fields appended by the
+// versions after 00 are ignored.
+func ParseTraceParent(s string) (SpanContext, bool) {
+	var sc SpanContext
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	if len(s) < 55 || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return sc, false
+	}
+	version, ok := parseHex(s[0:2])
+	if !ok || version[0] == 0xff || (version[0] == 0 && len(s) != 55) || (len(s) > 55 && s[55] != '-') {
+		return sc, false
+	}
+	traceID, ok := parseHex(s[3:35])
+	if !ok {
+		return sc, false
+	}
+	spanID, ok := parseHex(s[36:52])
+	if !ok {
+		return sc, false
+	}
+	flags, ok := parseHex(s[53:55])
+	if !ok {
+		return sc, false
+	}
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = flags[0]&0x01 == 0x01
+	return sc, sc.IsValid()
+}
+
+// parseHex decodes the lower case hex string, the upper case letters are not allowed by the trace context.
+func parseHex(s string) ([]byte, bool) {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return nil, false
+		}
+	}
+	b, err := hex.DecodeString(s)
+	return b, err == nil
+}
+
+// idGenerator generates the random trace IDs and span IDs, and makes the sampling decisions.
+type idGenerator struct {
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+func newIDGenerator() *idGenerator {
+	seed := time.Now().UnixNano()
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err == nil {
+		seed = int64(binary.LittleEndian.Uint64(b[:]))
+	}
+	return &idGenerator{rand: rand.New(rand.NewSource(seed))}
+}
+
+func (g *idGenerator) traceID() (id [16]byte) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for id == [16]byte{} {
+		_, _ = g.rand.Read(id[:])
+	}
+	return id
+}
+
+func (g *idGenerator) spanID() (id [8]byte) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for id == [8]byte{} {
+		_, _ = g.rand.Read(id[:])
+	}
+	return id
+}
+
+// sample returns true for the percentage of the calls.
+func (g *idGenerator) sample(percentage uint32) bool {
+	if percentage >= 100 {
+		return true
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return uint32(g.rand.Intn(100)) < percentage
+}
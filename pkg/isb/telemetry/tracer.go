@@ -0,0 +1,253 @@
+/*
+Package telemetry implements the OpenTelemetry tracing of the messages of a pipeline. The sources start a trace for a
+sample of the messages, and the W3C trace context of the last span of a message in a vertex is carried along in the
+metadata to the next vertex, where the spans of the message are children of it. So the spans of reading a message from
+a source, invoking the UDFs on it, writing it to the buffers and writing it to a sink are all in the same trace.
+*/
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/shared/httpclient"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+// The names of the spans of a message in a vertex.
+const (
+	// SpanSourceRead is the span of reading a message from a source
+	SpanSourceRead = "source-read"
+	// SpanUDFInvoke is the span of invoking the UDF on a message
+	SpanUDFInvoke = "udf-invoke"
+	// SpanISBWrite is the span of writing the outputs of a message to the Inter-Step Buffers
+	SpanISBWrite = "isb-write"
+	// SpanSinkWrite is the span of writing a message to a sink
+	SpanSinkWrite = "sink-write"
+)
+
+const (
+	// spanQueueSize is the number of the finished spans waiting to be exported, the spans finished when it is full are dropped
+	spanQueueSize = 10000
+	// maxExportBatchSize is the max number of the spans exported in one request
+	maxExportBatchSize = 1000
+)
+
+// Exporter exports the finished spans.
+type Exporter interface {
+	Export(ctx context.Context, spans []*Span) error
+}
+
+// Tracer records the spans of the traced messages of a vertex, and exports them periodically.
+type Tracer struct {
+	vertex             *dfv1.Vertex
+	samplingPercentage uint32
+	interval           time.Duration
+	exporter           Exporter
+	ids                *idGenerator
+	spans              chan *Span
+	dropped            int64
+	now                func() time.Time
+}
+
+// NewTracer returns the tracer of the vertex exporting the spans with the exporter.
+func NewTracer(vertex *dfv1.Vertex, exporter Exporter) *Tracer {
+	return &Tracer{
+		vertex:             vertex,
+		samplingPercentage: vertex.Spec.Tracing.GetSamplingPercentage(),
+		interval:           vertex.Spec.Tracing.GetInterval(),
+		exporter:           exporter,
+		ids:                newIDGenerator(),
+		spans:              make(chan *Span, spanQueueSize),
+		now:                time.Now,
+	}
+}
+
+// NewInClusterTracer returns the tracer of the pod of the vertex exporting the spans to the OTLP endpoint of the
+// pipeline, it returns nil if the tracing is not turned on.
+func NewInClusterTracer(vertex *dfv1.Vertex, pod string) *Tracer {
+	x := vertex.Spec.Tracing
+	if x == nil {
+		return nil
+	}
+	attributes := map[string]string{
+		"service.name":       fmt.Sprintf("%s-%s", vertex.Spec.PipelineName, vertex.Spec.Name),
+		"numaflow.pipeline":  vertex.Spec.PipelineName,
+		"numaflow.vertex":    vertex.Spec.Name,
+		"k8s.namespace.name": vertex.Namespace,
+		"k8s.pod.name":       pod,
+	}
+	return NewTracer(vertex, NewOTLPExporter(x.Endpoint, httpclient.NewClient(0), attributes))
+}
+
+// StartMessages returns the traces of the read messages, nil for the ones not traced. In a source vertex, a trace is
+// started for a sample of the messages coming without a trace context, and the source-read span of each traced
+// message is recorded.
+func (t *Tracer) StartMessages(messages []*isb.ReadMessage, readStart, readEnd time.Time) []*MessageTrace {
+	traces := make([]*MessageTrace, len(messages))
+	for idx, m := range messages {
+		parent, ok := ParseTraceParent(m.Metadata[dfv1.TraceParentKey])
+		if !t.vertex.IsASource() {
+			if ok && parent.Sampled {
+				traces[idx] = &MessageTrace{tracer: t, parent: parent, messageID: m.ID}
+			}
+			continue
+		}
+		if !ok {
+			if !t.ids.sample(t.samplingPercentage) {
+				continue
+			}
+			parent = SpanContext{TraceID: t.ids.traceID(), Sampled: true}
+		} else if !parent.Sampled {
+			continue
+		}
+		read := t.startSpan(SpanSourceRead, parent, m.ID, readStart)
+		read.end(readEnd, nil)
+		traces[idx] = &MessageTrace{tracer: t, parent: read.Context, messageID: m.ID}
+	}
+	return traces
+}
+
+func (t *Tracer) startSpan(name string, parent SpanContext, messageID string, start time.Time) *Span {
+	return &Span{
+		Name:      name,
+		Context:   SpanContext{TraceID: parent.TraceID, SpanID: t.ids.spanID(), Sampled: true},
+		Parent:    parent,
+		MessageID: messageID,
+		Start:     start,
+		tracer:    t,
+	}
+}
+
+// finish queues the finished span to be exported, it is dropped if the queue is full.
+func (t *Tracer) finish(s *Span) {
+	select {
+	case t.spans <- s:
+	default:
+		atomic.AddInt64(&t.dropped, 1)
+	}
+}
+
+// Run exports the finished spans every interval until the context is done, the spans finished by then are exported
+// before it returns. The failures are logged, and the spans failed to be exported are dropped.
+func (t *Tracer) Run(ctx context.Context) {
+	log := logging.FromContext(ctx)
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			exportCtx, cancel := context.WithTimeout(context.Background(), t.interval)
+			t.export(exportCtx, log)
+			cancel()
+			return
+		case <-ticker.C:
+			t.export(ctx, log)
+		}
+	}
+}
+
+func (t *Tracer) export(ctx context.Context, log *zap.SugaredLogger) {
+	if dropped := atomic.SwapInt64(&t.dropped, 0); dropped > 0 {
+		log.Warnw("Dropped the spans since the export is falling behind", zap.Int64("dropped", dropped))
+	}
+	for n := len(t.spans); n > 0; {
+		size := n
+		if size > maxExportBatchSize {
+			size = maxExportBatchSize
+		}
+		n -= size
+		spans := make([]*Span, size)
+		for i := range spans {
+			spans[i] = <-t.spans
+		}
+		exportCtx, cancel := context.WithTimeout(ctx, t.interval)
+		err := t.exporter.Export(exportCtx, spans)
+		cancel()
+		if err != nil {
+			log.Warnw("Failed to export the spans", zap.Int("spans", len(spans)), zap.Error(err))
+		}
+	}
+}
+
+// MessageTrace is the trace of a read message in a vertex. The spans of the message in the vertex are children of its
+// parent, which is the span writing the message in the previous vertex, or the source-read span in a source vertex.
+// A nil MessageTrace is a message not traced, which starts no span.
+type MessageTrace struct {
+	tracer    *Tracer
+	parent    SpanContext
+	messageID string
+}
+
+// StartUDF starts the udf-invoke span of the message, it returns nil if the vertex is not a UDF vertex.
+func (mt *MessageTrace) StartUDF() *Span {
+	if mt == nil || !mt.tracer.vertex.IsAnUDF() {
+		return nil
+	}
+	return mt.tracer.startSpan(SpanUDFInvoke, mt.parent, mt.messageID, mt.tracer.now())
+}
+
+// StartWrite starts the span writing the outputs of the message, which is the sink-write span in a sink vertex, or the
+// isb-write span otherwise.
+func (mt *MessageTrace) StartWrite() *Span {
+	if mt == nil {
+		return nil
+	}
+	name := SpanISBWrite
+	if mt.tracer.vertex.IsASink() {
+		name = SpanSinkWrite
+	}
+	return mt.tracer.startSpan(name, mt.parent, mt.messageID, mt.tracer.now())
+}
+
+// Span is a span of a traced message, a nil Span records nothing.
+type Span struct {
+	Name    string
+	Context SpanContext
+	// Parent is the context of the parent span, the span is the root of the trace if the parent has no span ID
+	Parent    SpanContext
+	MessageID string
+	Start     time.Time
+	End       time.Time
+	// Err is the error failing the span, nil if it succeeds
+	Err    error
+	tracer *Tracer
+}
+
+// Inject sets the trace context of the span to the metadata of the messages, so that their spans in the next vertex are
+// children of the span.
+func (s *Span) Inject(messages []*isb.Message) {
+	if s == nil {
+		return
+	}
+	traceParent := s.Context.TraceParent()
+	for _, m := range messages {
+		// the metadata is copied, since it could be shared with the read message or the other messages
+		metadata := make(map[string]string, len(m.Metadata)+1)
+		for k, v := range m.Metadata {
+			metadata[k] = v
+		}
+		metadata[dfv1.TraceParentKey] = traceParent
+		m.Metadata = metadata
+	}
+}
+
+// Finish ends the span with the error, nil if it succeeds, and queues it to be exported.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.end(s.tracer.now(), err)
+}
+
+func (s *Span) end(t time.Time, err error) {
+	s.End = t
+	s.Err = err
+	s.tracer.finish(s)
+}
@@ -0,0 +1,145 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+type testExporter struct {
+	lock  sync.Mutex
+	spans []*Span
+}
+
+func (e *testExporter) Export(_ context.Context, spans []*Span) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *testExporter) exported() []*Span {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]*Span(nil), e.spans...)
+}
+
+func testVertex(v dfv1.AbstractVertex) *dfv1.Vertex {
+	return &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName:   "pl",
+		AbstractVertex: v,
+		Tracing:        &dfv1.PipelineTracing{Endpoint: "http://localhost:4318/v1/traces", Interval: &metav1.Duration{Duration: 50 * time.Millisecond}},
+	}}
+}
+
+func testReadMessages(metadata ...map[string]string) []*isb.ReadMessage {
+	result := make([]*isb.ReadMessage, len(metadata))
+	for i, m := range metadata {
+		result[i] = &isb.ReadMessage{Message: isb.Message{Header: isb.Header{ID: string(rune('a' + i)), Metadata: m}}}
+	}
+	return result
+}
+
+func TestNewInClusterTracer(t *testing.T) {
+	assert.Nil(t, NewInClusterTracer(&dfv1.Vertex{}, "pod-0"))
+	assert.NotNil(t, NewInClusterTracer(testVertex(dfv1.AbstractVertex{Name: "in", Source: &dfv1.Source{}}), "pod-0"))
+}
+
+func TestTracer_Source(t *testing.T) {
+	exporter := &testExporter{}
+	vertex := testVertex(dfv1.AbstractVertex{Name: "in", Source: &dfv1.Source{}})
+	tracer := NewTracer(vertex, exporter)
+	incoming := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	messages := testReadMessages(nil, map[string]string{dfv1.TraceParentKey: incoming}, map[string]string{dfv1.TraceParentKey: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"})
+	start := time.Now()
+	traces := tracer.StartMessages(messages, start, start.Add(time.Millisecond))
+	assert.Len(t, traces, 3)
+	assert.NotNil(t, traces[0])
+	assert.NotNil(t, traces[1])
+	// the incoming trace context is not sampled
+	assert.Nil(t, traces[2])
+	// a source is not a UDF
+	assert.Nil(t, traces[0].StartUDF())
+
+	write := traces[1].StartWrite()
+	outputs := []*isb.Message{{Header: isb.Header{Metadata: messages[1].Metadata}}}
+	write.Inject(outputs)
+	write.Finish(nil)
+	assert.Equal(t, incoming, messages[1].Metadata[dfv1.TraceParentKey])
+	assert.Equal(t, write.Context.TraceParent(), outputs[0].Metadata[dfv1.TraceParentKey])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		tracer.Run(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+	spans := exporter.exported()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, SpanSourceRead, spans[0].Name)
+	assert.Equal(t, [8]byte{}, spans[0].Parent.SpanID)
+	assert.Equal(t, start, spans[0].Start)
+	assert.Equal(t, SpanSourceRead, spans[1].Name)
+	assert.Equal(t, "00f067aa0ba902b7", spanIDHex(spans[1].Parent))
+	assert.Equal(t, spans[1].Context.TraceID, spans[1].Parent.TraceID)
+	assert.Equal(t, SpanISBWrite, spans[2].Name)
+	assert.Equal(t, spans[1].Context.SpanID, spans[2].Parent.SpanID)
+}
+
+func TestTracer_Sampling(t *testing.T) {
+	vertex := testVertex(dfv1.AbstractVertex{Name: "in", Source: &dfv1.Source{}})
+	zero := uint32(0)
+	vertex.Spec.Tracing.SamplingPercentage = &zero
+	tracer := NewTracer(vertex, &testExporter{})
+	traces := tracer.StartMessages(testReadMessages(nil, nil), time.Now(), time.Now())
+	assert.Equal(t, []*MessageTrace{nil, nil}, traces)
+}
+
+func TestTracer_UDFAndSink(t *testing.T) {
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	messages := testReadMessages(map[string]string{dfv1.TraceParentKey: parent}, nil)
+
+	exporter := &testExporter{}
+	tracer := NewTracer(testVertex(dfv1.AbstractVertex{Name: "p1", UDF: &dfv1.UDF{}}), exporter)
+	traces := tracer.StartMessages(messages, time.Now(), time.Now())
+	assert.NotNil(t, traces[0])
+	// not traced without a trace context out of a source
+	assert.Nil(t, traces[1])
+	traces[1].StartUDF().Finish(nil)
+	udf := traces[0].StartUDF()
+	udf.Finish(errors.New("failed"))
+	traces[0].StartWrite().Finish(nil)
+	tracer.export(context.Background(), zap.NewNop().Sugar())
+	spans := exporter.exported()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, SpanUDFInvoke, spans[0].Name)
+	assert.EqualError(t, spans[0].Err, "failed")
+	assert.Equal(t, "00f067aa0ba902b7", spanIDHex(spans[0].Parent))
+	assert.Equal(t, SpanISBWrite, spans[1].Name)
+	assert.Equal(t, "00f067aa0ba902b7", spanIDHex(spans[1].Parent))
+
+	exporter = &testExporter{}
+	tracer = NewTracer(testVertex(dfv1.AbstractVertex{Name: "out", Sink: &dfv1.Sink{}}), exporter)
+	traces = tracer.StartMessages(messages, time.Now(), time.Now())
+	assert.Nil(t, traces[0].StartUDF())
+	traces[0].StartWrite().Finish(nil)
+	tracer.export(context.Background(), zap.NewNop().Sugar())
+	spans = exporter.exported()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, SpanSinkWrite, spans[0].Name)
+}
+
+func spanIDHex(sc SpanContext) string {
+	return sc.TraceParent()[36:52]
+}
@@ -24,6 +24,7 @@ func CopyUDFTestApply(ctx context.Context, readMessage *isb.ReadMessage) ([]*isb
 			PaneInfo: parentPaneInfo,
 			ID:       offset.String(),
 			Key:      key,
+			Metadata: readMessage.Metadata,
 		},
 		Body: isb.Body{
 			Payload: result,
@@ -0,0 +1,147 @@
+/*
+Package tracing records a sample of the messages written to the buffers of the traced edges, so that what's flowing on
+an edge can be inspected for debugging. Each replica keeps the latest sampled messages in a ring, which is served on
+the metrics server and collected from the replicas by the daemon service.
+*/
+package tracing
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// Record is a sampled message.
+type Record struct {
+	// Pod is the replica writing the message
+	Pod string `json:"pod"`
+	// WriteTime is the time the message is written to the buffer
+	WriteTime time.Time `json:"writeTime"`
+	// Buffer is the buffer of the edge
+	Buffer          string            `json:"buffer"`
+	ID              string            `json:"id"`
+	Key             string            `json:"key,omitempty"`
+	EventTime       time.Time         `json:"eventTime"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	ContentEncoding string            `json:"contentEncoding,omitempty"`
+	PayloadSize     int               `json:"payloadSize"`
+	// Payload is only recorded if the payloads are captured, truncated to the payload size limit
+	Payload   []byte `json:"payload,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// Recorder samples the messages written to a buffer into a ring of the latest records, it's safe for concurrent use.
+type Recorder struct {
+	pod              string
+	buffer           string
+	sampleRate       float64
+	capturePayload   bool
+	payloadSizeLimit int
+
+	lock sync.Mutex
+	rand *rand.Rand
+	ring []Record
+	// next is the index of the ring to record the next message
+	next int
+	// full tells if the ring has been filled, i.e. the older records are being overwritten
+	full bool
+}
+
+// NewRecorder returns the recorder of the messages written by the pod to the buffer.
+func NewRecorder(pod, buffer string, t dfv1.EdgeTrace) *Recorder {
+	return &Recorder{
+		pod:              pod,
+		buffer:           buffer,
+		sampleRate:       t.GetSampleRate(),
+		capturePayload:   t.CapturePayload,
+		payloadSizeLimit: t.GetPayloadSizeLimit(),
+		rand:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		ring:             make([]Record, t.GetMaxRecords()),
+	}
+}
+
+// Record samples the messages written to the buffer.
+func (r *Recorder) Record(messages []isb.Message) {
+	now := time.Now()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, m := range messages {
+		if r.rand.Float64() >= r.sampleRate {
+			continue
+		}
+		rec := Record{
+			Pod:             r.pod,
+			WriteTime:       now,
+			Buffer:          r.buffer,
+			ID:              m.ID,
+			Key:             string(m.Key),
+			EventTime:       m.EventTime,
+			Metadata:        m.Metadata,
+			ContentEncoding: m.ContentEncoding,
+			PayloadSize:     len(m.Payload),
+		}
+		if r.capturePayload {
+			payload := m.Payload
+			if len(payload) > r.payloadSizeLimit {
+				payload, rec.Truncated = payload[:r.payloadSizeLimit], true
+			}
+			// the payload is copied, so that the message can be reused by the writer
+			rec.Payload = append([]byte(nil), payload...)
+		}
+		r.ring[r.next] = rec
+		r.next++
+		if r.next == len(r.ring) {
+			r.next, r.full = 0, true
+		}
+	}
+}
+
+// Records returns the recorded messages, the oldest first.
+func (r *Recorder) Records() []Record {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.full {
+		return append([]Record(nil), r.ring[:r.next]...)
+	}
+	return append(append([]Record(nil), r.ring[r.next:]...), r.ring[:r.next]...)
+}
+
+// Recorders are the recorders of the traced buffers, keyed by the buffer names.
+type Recorders map[string]*Recorder
+
+// NewRecorders returns the recorders of the traced to buffers of the vertex, nil if none is traced.
+func NewRecorders(vertex *dfv1.Vertex, pod string) Recorders {
+	var recorders Recorders
+	for _, b := range vertex.GetToBuffers() {
+		if t := vertex.GetToBufferTrace(b); t != nil {
+			if recorders == nil {
+				recorders = make(Recorders)
+			}
+			recorders[b] = NewRecorder(pod, b, *t)
+		}
+	}
+	return recorders
+}
+
+// Record samples the messages written to the buffer if it's traced.
+func (rs Recorders) Record(buffer string, messages []isb.Message) {
+	if r := rs[buffer]; r != nil {
+		r.Record(messages)
+	}
+}
+
+// ServeHTTP responds the records of the buffer in the "buffer" query parameter, 404 if the buffer is not traced.
+func (rs Recorders) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r := rs[req.URL.Query().Get("buffer")]
+	if r == nil {
+		http.Error(w, "buffer not traced", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(r.Records())
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+func testMessages(n int) []isb.Message {
+	var messages []isb.Message
+	for i := 0; i < n; i++ {
+		messages = append(messages, isb.Message{
+			Header: isb.Header{ID: fmt.Sprint(i), Key: []byte("key"), Metadata: map[string]string{"k": "v"}},
+			Body:   isb.Body{Payload: []byte(fmt.Sprintf("payload-%d", i))},
+		})
+	}
+	return messages
+}
+
+func TestRecorder(t *testing.T) {
+	t.Run("sample all", func(t *testing.T) {
+		max := uint32(3)
+		r := NewRecorder("pod-0", "b1", dfv1.EdgeTrace{SampleRate: "1", MaxRecords: &max})
+		r.Record(testMessages(2))
+		records := r.Records()
+		assert.Len(t, records, 2)
+		assert.Equal(t, "0", records[0].ID)
+		assert.Equal(t, "pod-0", records[0].Pod)
+		assert.Equal(t, "b1", records[0].Buffer)
+		assert.Equal(t, "key", records[0].Key)
+		assert.Equal(t, map[string]string{"k": "v"}, records[0].Metadata)
+		assert.Equal(t, 9, records[0].PayloadSize)
+		assert.Nil(t, records[0].Payload)
+
+		// the oldest ones are overwritten
+		r.Record(testMessages(5)[2:])
+		records = r.Records()
+		assert.Len(t, records, 3)
+		assert.Equal(t, "2", records[0].ID)
+		assert.Equal(t, "4", records[2].ID)
+	})
+
+	t.Run("capture payload", func(t *testing.T) {
+		limit := uint32(7)
+		r := NewRecorder("pod-0", "b1", dfv1.EdgeTrace{SampleRate: "1", CapturePayload: true, PayloadSizeLimit: &limit})
+		messages := testMessages(1)
+		messages = append(messages, isb.Message{Body: isb.Body{Payload: []byte("short")}})
+		r.Record(messages)
+		records := r.Records()
+		assert.Equal(t, []byte("payload"), records[0].Payload)
+		assert.True(t, records[0].Truncated)
+		assert.Equal(t, []byte("short"), records[1].Payload)
+		assert.False(t, records[1].Truncated)
+		// the payload is copied
+		messages[1].Payload[0] = 'S'
+		assert.Equal(t, []byte("short"), r.Records()[1].Payload)
+	})
+
+	t.Run("sample rate", func(t *testing.T) {
+		max := uint32(10000)
+		r := NewRecorder("pod-0", "b1", dfv1.EdgeTrace{SampleRate: "0.1", MaxRecords: &max})
+		for i := 0; i < 100; i++ {
+			r.Record(testMessages(10))
+		}
+		n := len(r.Records())
+		assert.Greater(t, n, 50)
+		assert.Less(t, n, 200)
+		// defaults to keep the latest 100
+		r = NewRecorder("pod-0", "b1", dfv1.EdgeTrace{SampleRate: "0.1"})
+		for i := 0; i < 1000; i++ {
+			r.Record(testMessages(10))
+		}
+		assert.Len(t, r.Records(), dfv1.DefaultEdgeTraceMaxRecords)
+	})
+}
+
+func TestRecorders(t *testing.T) {
+	vertex := &dfv1.Vertex{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pl-p1"},
+		Spec: dfv1.VertexSpec{
+			PipelineName:   "pl",
+			AbstractVertex: dfv1.AbstractVertex{Name: "p1"},
+			ToVertices:     []dfv1.ToVertex{{Name: "out1"}, {Name: "out2", Trace: &dfv1.EdgeTrace{SampleRate: "1"}}},
+		},
+	}
+	rs := NewRecorders(vertex, "pod-0")
+	assert.Len(t, rs, 1)
+	traced := vertex.GetToBufferName("out2")
+	rs.Record(vertex.GetToBufferName("out1"), testMessages(1))
+	rs.Record(traced, testMessages(2))
+
+	w := httptest.NewRecorder()
+	rs.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/traces?buffer="+traced, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var records []Record
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &records))
+	assert.Len(t, records, 2)
+
+	w = httptest.NewRecorder()
+	rs.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/traces?buffer="+vertex.GetToBufferName("out1"), nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	vertex.Spec.ToVertices[1].Trace = nil
+	assert.Nil(t, NewRecorders(vertex, "pod-0"))
+}
@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Shopify/sarama"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+// KafkaClient is used to provide a kafka client
+type KafkaClient interface {
+	Connect(ctx context.Context) (sarama.Client, error)
+}
+
+// inClusterKafkaClient is used to provide a kafka client with the configuration passed to the pods as environment variables
+type inClusterKafkaClient struct {
+}
+
+// NewInClusterKafkaClient is used to provide NewInClusterKafkaClient
+func NewInClusterKafkaClient() *inClusterKafkaClient {
+	return &inClusterKafkaClient{}
+}
+
+// Connect is used to establish an in-cluster kafka client
+func (ikc *inClusterKafkaClient) Connect(ctx context.Context) (sarama.Client, error) {
+	brokers, existing := os.LookupEnv(dfv1.EnvISBSvcKafkaBrokers)
+	if !existing {
+		return nil, fmt.Errorf("environment variable %q not found", dfv1.EnvISBSvcKafkaBrokers)
+	}
+	config, err := sharedutil.GetSaramaConfigFromYAMLString(os.Getenv(dfv1.EnvISBSvcKafkaConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the kafka config, %w", err)
+	}
+	if sharedutil.LookupEnvStringOr(dfv1.EnvISBSvcKafkaTLSEnabled, "false") == "true" {
+		tlsConfig, err := tlsConfigFromEnv(dfv1.EnvISBSvcKafkaTLSInsecure, dfv1.EnvISBSvcKafkaTLSCACert, dfv1.EnvISBSvcKafkaTLSCert, dfv1.EnvISBSvcKafkaTLSKey)
+		if err != nil {
+			return nil, err
+		}
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+	return kafkaConnection(ctx, strings.Split(brokers, ","), config)
+}
+
+// tlsConfigFromEnv builds the TLS config from the PEM contents of the certificates in the environment variables with
+// the given names.
+func tlsConfigFromEnv(insecureEnv, caCertEnv, certEnv, keyEnv string) (*tls.Config, error) {
+	c := &tls.Config{
+		InsecureSkipVerify: sharedutil.LookupEnvStringOr(insecureEnv, "false") == "true",
+	}
+	if caCert := os.Getenv(caCertEnv); caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("failed to parse the ca cert in environment variable %q", caCertEnv)
+		}
+		c.RootCAs = pool
+	}
+	cert, key := os.Getenv(certEnv), os.Getenv(keyEnv)
+	if cert != "" && key != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client cert key pair, %w", err)
+		}
+		c.Certificates = []tls.Certificate{clientCert}
+	}
+	return c, nil
+}
+
+// defaultKafkaClient is used to provide a kafka client with the given brokers and config
+type defaultKafkaClient struct {
+	brokers []string
+	config  *sarama.Config
+}
+
+// NewDefaultKafkaClient is used to provide NewDefaultKafkaClient, the default sarama config is used if config is nil
+func NewDefaultKafkaClient(brokers []string, config *sarama.Config) *defaultKafkaClient {
+	if config == nil {
+		config = sarama.NewConfig()
+		config.Producer.Return.Successes = true
+	}
+	return &defaultKafkaClient{
+		brokers: brokers,
+		config:  config,
+	}
+}
+
+// Connect is used to establish a default kafka client
+func (dc *defaultKafkaClient) Connect(ctx context.Context) (sarama.Client, error) {
+	return kafkaConnection(ctx, dc.brokers, dc.config)
+}
+
+// kafkaConnection is used to provide a kafka client, the settings the buffers rely on are applied on top of the given config
+func kafkaConnection(ctx context.Context, brokers []string, config *sarama.Config) (sarama.Client, error) {
+	log := logging.FromContext(ctx)
+	// The record headers and deleting consumer groups require at least 1.1
+	if !config.Version.IsAtLeast(sarama.V1_1_0_0) {
+		config.Version = sarama.V1_1_0_0
+	}
+	// The buffer writers rely on the successes to get the offsets, and the readers start from the oldest messages of
+	// a new consumer group, the committed offsets of which are set when the buffer is created.
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRoundRobin
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka brokers %v, %w", brokers, err)
+	}
+	log.Info("Connected to kafka brokers")
+	return client, nil
+}
@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 
@@ -44,13 +45,65 @@ func (isc *inClusterJetStreamClient) Connect(ctx context.Context) (*nats.Conn, e
 	// pass nats options for username password
 	opts := []nats.Option{nats.UserInfo(user, password)}
 	if sharedutil.LookupEnvStringOr(dfv1.EnvISBSvcJetStreamTLSEnabled, "false") == "true" {
-		opts = append(opts, nats.Secure(&tls.Config{
-			InsecureSkipVerify: true,
-		}))
+		tlsConfig, err := jetStreamTLSConfigFromEnv(dfv1.EnvISBSvcJetStreamTLSCACert)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
 	}
 	return natsJetStreamConnection(ctx, url, opts)
 }
 
+// inClusterJetStreamMirrorClient is used to connect to the secondary JetStream the streams are mirrored to, with the
+// credentials in the environment variables
+type inClusterJetStreamMirrorClient struct {
+	mirror *dfv1.JetStreamMirror
+}
+
+// NewInClusterJetStreamMirrorClient is used to provide a client of the secondary JetStream of the mirror
+func NewInClusterJetStreamMirrorClient(mirror *dfv1.JetStreamMirror) *inClusterJetStreamMirrorClient {
+	return &inClusterJetStreamMirrorClient{mirror: mirror}
+}
+
+// Connect is used to establish a NATS jetstream connection to the secondary JetStream
+func (imc *inClusterJetStreamMirrorClient) Connect(ctx context.Context) (*nats.Conn, error) {
+	var opts []nats.Option
+	if imc.mirror.Auth != nil {
+		user, existing := os.LookupEnv(dfv1.EnvISBSvcMirrorUser)
+		if !existing {
+			return nil, fmt.Errorf("environment variable %q not found", dfv1.EnvISBSvcMirrorUser)
+		}
+		password, existing := os.LookupEnv(dfv1.EnvISBSvcMirrorPassword)
+		if !existing {
+			return nil, fmt.Errorf("environment variable %q not found", dfv1.EnvISBSvcMirrorPassword)
+		}
+		opts = append(opts, nats.UserInfo(user, password))
+	}
+	if imc.mirror.TLSEnabled {
+		tlsConfig, err := jetStreamTLSConfigFromEnv(dfv1.EnvISBSvcMirrorTLSCACert)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+	return natsJetStreamConnection(ctx, imc.mirror.URL, opts)
+}
+
+// jetStreamTLSConfigFromEnv builds the TLS config to verify the servers with the PEM contents of the CA certificate in
+// the environment variable. The verification is skipped if it's not set, which is the case with the ISB Services
+// created by the older controllers.
+func jetStreamTLSConfigFromEnv(caCertEnv string) (*tls.Config, error) {
+	caCert := os.Getenv(caCertEnv)
+	if caCert == "" {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, fmt.Errorf("failed to parse the ca cert in environment variable %q", caCertEnv)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
 // defaultJetStreamClient is used to provide default jetstream client credentials
 type defaultJetStreamClient struct {
 	url  string
@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/tls"
+)
+
+func TestJetStreamTLSConfigFromEnv(t *testing.T) {
+	t.Run("without ca cert", func(t *testing.T) {
+		_ = os.Unsetenv(dfv1.EnvISBSvcJetStreamTLSCACert)
+		c, err := jetStreamTLSConfigFromEnv(dfv1.EnvISBSvcJetStreamTLSCACert)
+		assert.NoError(t, err)
+		assert.True(t, c.InsecureSkipVerify)
+		assert.Nil(t, c.RootCAs)
+	})
+
+	t.Run("with ca cert", func(t *testing.T) {
+		_, _, caCert, err := tls.CreateCerts("numaflow", []string{"localhost"}, time.Now().Add(time.Hour), true, false)
+		assert.NoError(t, err)
+		t.Setenv(dfv1.EnvISBSvcJetStreamTLSCACert, string(caCert))
+		c, err := jetStreamTLSConfigFromEnv(dfv1.EnvISBSvcJetStreamTLSCACert)
+		assert.NoError(t, err)
+		assert.False(t, c.InsecureSkipVerify)
+		assert.NotNil(t, c.RootCAs)
+	})
+
+	t.Run("with invalid ca cert", func(t *testing.T) {
+		t.Setenv(dfv1.EnvISBSvcJetStreamTLSCACert, "invalid")
+		_, err := jetStreamTLSConfigFromEnv(dfv1.EnvISBSvcJetStreamTLSCACert)
+		assert.Error(t, err)
+	})
+}
+
+func TestInClusterJetStreamMirrorClient(t *testing.T) {
+	_ = os.Unsetenv(dfv1.EnvISBSvcMirrorUser)
+	_ = os.Unsetenv(dfv1.EnvISBSvcMirrorPassword)
+	c := NewInClusterJetStreamMirrorClient(&dfv1.JetStreamMirror{URL: "nats://isbsvc-dr:4222", Auth: &dfv1.NATSAuth{}})
+	_, err := c.Connect(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), dfv1.EnvISBSvcMirrorUser)
+	t.Setenv(dfv1.EnvISBSvcMirrorUser, "user")
+	_, err = c.Connect(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), dfv1.EnvISBSvcMirrorPassword)
+}
@@ -2,15 +2,26 @@ package clients
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
 
 	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
 )
 
-const ReadFromEarliest = "0-0"
+const (
+	ReadFromEarliest = "0-0"
+	// ReadFromLatest is the special ID of the last entry of the stream
+	ReadFromLatest = "$"
+	// redisClusterSlots is the number of the hash slots of a Redis Cluster
+	redisClusterSlots = 16384
+)
 
 // RedisContext is used to pass the context specifically for REDIS operations.
 // A cancelled context during SIGTERM or Ctrl-C that is propagated down will throw a context cancelled error because redis uses context to obtain connection from the connection pool.
@@ -20,6 +31,8 @@ var RedisContext = context.Background()
 // RedisClient datatype to hold redis client attributes.
 type RedisClient struct {
 	Client redis.UniversalClient
+	// cluster is true if the client is a Redis Cluster client
+	cluster bool
 }
 
 // NewRedisClient returns a new Redis Client.
@@ -29,6 +42,14 @@ func NewRedisClient(options *redis.UniversalOptions) *RedisClient {
 	return client
 }
 
+// NewRedisClusterClient returns a new Redis Cluster Client, the addresses are the seed nodes of the cluster.
+func NewRedisClusterClient(options *redis.UniversalOptions) *RedisClient {
+	client := new(RedisClient)
+	client.Client = redis.NewClusterClient(options.Cluster())
+	client.cluster = true
+	return client
+}
+
 // NewInClusterRedisClient returns a new Redis Client, it assums it's in a vertex pod,
 // where those requied environment variables are available.
 func NewInClusterRedisClient() *RedisClient {
@@ -49,9 +70,95 @@ func NewInClusterRedisClient() *RedisClient {
 			opts.Addrs = strings.Split(urls, ",")
 		}
 	}
+	if sharedutil.LookupEnvStringOr(v1alpha1.EnvISBSvcRedisTLSEnabled, "false") == "true" {
+		tlsConfig, err := tlsConfigFromEnv(v1alpha1.EnvISBSvcRedisTLSInsecure, v1alpha1.EnvISBSvcRedisTLSCACert, v1alpha1.EnvISBSvcRedisTLSCert, v1alpha1.EnvISBSvcRedisTLSKey)
+		if err != nil {
+			// Never fall back to the plain connections, the default TLS config fails the connections to be diagnosed
+			logging.NewLogger().Errorw("Failed to build the Redis TLS config, using the default one", zap.Error(err))
+			tlsConfig = &tls.Config{}
+		}
+		opts.TLSConfig = tlsConfig
+	}
+	if file := os.Getenv(v1alpha1.EnvISBSvcRedisPasswordFile); file != "" {
+		opts.OnConnect = authWithPasswordFile(opts.Username, opts.Password, file)
+		opts.Password = ""
+	}
+	if sharedutil.LookupEnvStringOr(v1alpha1.EnvISBSvcRedisCluster, "false") == "true" {
+		return NewRedisClusterClient(opts)
+	}
 	return NewRedisClient(opts)
 }
 
+// IsCluster returns true if it's a Redis Cluster client.
+func (cl *RedisClient) IsCluster() bool {
+	return cl.cluster
+}
+
+// ForKey returns the client to send the commands without a key, e.g. WAIT, which need to go to the node of the key.
+// It's the client of the master owning the slot of the key for a Redis Cluster, or the client itself otherwise.
+func (cl *RedisClient) ForKey(ctx context.Context, key string) (redis.UniversalClient, error) {
+	if c, ok := cl.Client.(*redis.ClusterClient); ok {
+		return c.MasterForKey(ctx, key)
+	}
+	return cl.Client, nil
+}
+
+// HashTag returns the key as the hash tag for a Redis Cluster, only the tag is hashed for the slot of a key prefixed
+// with it, which is the same slot as the key itself. It's used to keep the keys derived from a stream, e.g. the dedup
+// hashes used together with the stream by the lua scripts, in the same slot as the stream, while the streams of the
+// buffers are in the slots of their own names, spread across the shards. The key is returned as is otherwise.
+func (cl *RedisClient) HashTag(key string) string {
+	if !cl.cluster {
+		return key
+	}
+	return "{" + key + "}"
+}
+
+// KeySlot returns the slot of the key in a Redis Cluster, which is the CRC16 of the key, or the hash tag in it, modulo
+// the number of the slots.
+func KeySlot(key string) int {
+	if s := strings.IndexByte(key, '{'); s > -1 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			key = key[s+1 : s+1+e]
+		}
+	}
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return int(crc) % redisClusterSlots
+}
+
+// authWithPasswordFile returns the hook authenticating the new connections with the auth token in the file, which is
+// read each time, so that the new connections pick up a rotated token, while the existing ones stay authenticated. The
+// password is used instead if the file does not exist, e.g. in the pods not mounting it.
+func authWithPasswordFile(username, password, file string) func(ctx context.Context, cn *redis.Conn) error {
+	return func(ctx context.Context, cn *redis.Conn) error {
+		token := password
+		data, err := os.ReadFile(file)
+		switch {
+		case err == nil:
+			token = strings.TrimSpace(string(data))
+		case !os.IsNotExist(err):
+			return fmt.Errorf("failed to read the Redis password file %q, %w", file, err)
+		}
+		if token == "" {
+			return nil
+		}
+		if username != "" {
+			return cn.AuthACL(ctx, username, token).Err()
+		}
+		return cn.Auth(ctx, token).Err()
+	}
+}
+
 // CreateStreamGroup creates a redis stream group and creates an empty stream if it does not exist.
 func (cl *RedisClient) CreateStreamGroup(ctx context.Context, stream string, group string, start string) error {
 	return cl.Client.XGroupCreateMkStream(ctx, stream, group, start).Err()
@@ -64,7 +171,16 @@ func (cl *RedisClient) DeleteStreamGroup(ctx context.Context, stream string, gro
 
 // DeleteKeys deletes a redis keys
 func (cl *RedisClient) DeleteKeys(ctx context.Context, keys ...string) error {
-	return cl.Client.Del(ctx, keys...).Err()
+	if !cl.cluster {
+		return cl.Client.Del(ctx, keys...).Err()
+	}
+	// The keys of a multi-key command need to be in the same slot of a Redis Cluster
+	for _, key := range keys {
+		if err := cl.Client.Del(ctx, key).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // StreamInfo returns redis stream info
@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 )
 
 func TestNewRedisClient(t *testing.T) {
@@ -27,3 +29,61 @@ func TestNewRedisClient(t *testing.T) {
 	err = client.CreateStreamGroup(ctx, stream, streamGroup, ReadFromEarliest)
 	assert.Error(t, err)
 }
+
+func TestNewInClusterRedisClient_Managed(t *testing.T) {
+	t.Setenv(v1alpha1.EnvISBSvcRedisURL, "master.my-redis.xxxxxx.use1.cache.amazonaws.com:6379")
+	t.Setenv(v1alpha1.EnvISBSvcRedisPassword, "token")
+	t.Setenv(v1alpha1.EnvISBSvcRedisTLSEnabled, "true")
+	t.Setenv(v1alpha1.EnvISBSvcRedisTLSInsecure, "true")
+	t.Run("password", func(t *testing.T) {
+		c, ok := NewInClusterRedisClient().Client.(*redis.Client)
+		assert.True(t, ok)
+		assert.NotNil(t, c.Options().TLSConfig)
+		assert.True(t, c.Options().TLSConfig.InsecureSkipVerify)
+		assert.Equal(t, "token", c.Options().Password)
+		assert.Nil(t, c.Options().OnConnect)
+	})
+	t.Run("password file", func(t *testing.T) {
+		t.Setenv(v1alpha1.EnvISBSvcRedisPasswordFile, "/etc/redis-auth/token")
+		c, ok := NewInClusterRedisClient().Client.(*redis.Client)
+		assert.True(t, ok)
+		assert.Equal(t, "", c.Options().Password)
+		assert.NotNil(t, c.Options().OnConnect)
+	})
+	t.Run("invalid ca cert", func(t *testing.T) {
+		t.Setenv(v1alpha1.EnvISBSvcRedisTLSCACert, "invalid")
+		c, ok := NewInClusterRedisClient().Client.(*redis.Client)
+		assert.True(t, ok)
+		assert.NotNil(t, c.Options().TLSConfig)
+		assert.False(t, c.Options().TLSConfig.InsecureSkipVerify)
+	})
+}
+
+func TestNewInClusterRedisClient_Cluster(t *testing.T) {
+	t.Setenv(v1alpha1.EnvISBSvcRedisURL, "node1:6379,node2:6379,node3:6379")
+	t.Setenv(v1alpha1.EnvISBSvcRedisPassword, "password")
+	t.Setenv(v1alpha1.EnvISBSvcRedisCluster, "true")
+	client := NewInClusterRedisClient()
+	assert.True(t, client.IsCluster())
+	c, ok := client.Client.(*redis.ClusterClient)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"node1:6379", "node2:6379", "node3:6379"}, c.Options().Addrs)
+	assert.Equal(t, "password", c.Options().Password)
+	assert.Equal(t, "{buffer}", client.HashTag("buffer"))
+	assert.False(t, NewRedisClient(&redis.UniversalOptions{Addrs: []string{":6379"}}).IsCluster())
+	assert.Equal(t, "buffer", NewRedisClient(&redis.UniversalOptions{Addrs: []string{":6379"}}).HashTag("buffer"))
+}
+
+func TestKeySlot(t *testing.T) {
+	assert.Equal(t, 12739, KeySlot("123456789"))
+	assert.Equal(t, 12182, KeySlot("foo"))
+	assert.Equal(t, KeySlot("user1000"), KeySlot("{user1000}.following"))
+	assert.Equal(t, KeySlot("{user1000}.followers"), KeySlot("{user1000}.following"))
+	// Empty or unclosed hash tags are not used
+	assert.NotEqual(t, KeySlot(""), KeySlot("{}foo"))
+	assert.NotEqual(t, KeySlot("foo"), KeySlot("{foo"))
+	// The dedup hashes of a buffer are in the slot of the stream, while the streams are spread across the slots
+	client := NewRedisClusterClient(&redis.UniversalOptions{Addrs: []string{":6379"}})
+	assert.Equal(t, KeySlot("pl-p1"), KeySlot(client.HashTag("pl-p1")+"-h-1636470000"))
+	assert.NotEqual(t, KeySlot("pl-p1"), KeySlot("pl-p2"))
+}
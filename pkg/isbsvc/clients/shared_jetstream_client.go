@@ -0,0 +1,176 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+// redialInterval is the interval a closed shared connection is connected again
+const redialInterval = time.Second
+
+// ReconnectHandler is called with the connection once it's reconnected, or replaced by a new one after it's closed.
+type ReconnectHandler func(conn *nats.Conn)
+
+// SharedJetStreamClient shares one connection among the readers and the writers of a process, instead of one connection
+// each. The connection reconnects forever, and if it's closed anyway, e.g. by an authorization error, a new one is
+// connected in the background. The users register the handlers with OnReconnect to recreate their subscriptions on
+// the connection, and Release the connection instead of closing it.
+type SharedJetStreamClient struct {
+	client   JetStreamClient
+	lock     sync.Mutex
+	conn     *nats.Conn
+	refs     int
+	handlers map[uint64]ReconnectHandler
+	nextID   uint64
+}
+
+var (
+	inClusterSharedClient     *SharedJetStreamClient
+	inClusterSharedClientOnce sync.Once
+)
+
+// InClusterSharedJetStreamClient returns the client sharing one in-cluster connection among all the readers and the
+// writers of the process.
+func InClusterSharedJetStreamClient() *SharedJetStreamClient {
+	inClusterSharedClientOnce.Do(func() {
+		inClusterSharedClient = NewSharedJetStreamClient(NewInClusterJetStreamClient())
+	})
+	return inClusterSharedClient
+}
+
+// NewSharedJetStreamClient returns a client sharing the connections of the client.
+func NewSharedJetStreamClient(client JetStreamClient) *SharedJetStreamClient {
+	return &SharedJetStreamClient{client: client, handlers: make(map[uint64]ReconnectHandler)}
+}
+
+// ShareJetStreamClient returns the client if it's already shared, or a client sharing its connections otherwise.
+func ShareJetStreamClient(client JetStreamClient) *SharedJetStreamClient {
+	if sc, ok := client.(*SharedJetStreamClient); ok {
+		return sc
+	}
+	return NewSharedJetStreamClient(client)
+}
+
+// Connect returns the shared connection, it's connected if there is none yet. Each call needs to be paired with a
+// Release.
+func (sc *SharedJetStreamClient) Connect(ctx context.Context) (*nats.Conn, error) {
+	sc.lock.Lock()
+	if sc.conn != nil && !sc.conn.IsClosed() {
+		sc.refs++
+		conn := sc.conn
+		sc.lock.Unlock()
+		return conn, nil
+	}
+	replaced := sc.conn != nil
+	conn, err := sc.dial(ctx)
+	if err != nil {
+		sc.lock.Unlock()
+		return nil, err
+	}
+	sc.refs++
+	handlers := sc.currentHandlers()
+	sc.lock.Unlock()
+	if replaced {
+		notify(handlers, conn)
+	}
+	return conn, nil
+}
+
+// Release releases a connection returned by Connect, the shared connection is closed once it's released by all the
+// users.
+func (sc *SharedJetStreamClient) Release() {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+	if sc.refs > 0 {
+		sc.refs--
+	}
+	if sc.refs == 0 && sc.conn != nil {
+		conn := sc.conn
+		sc.conn = nil
+		conn.Close()
+	}
+}
+
+// OnReconnect registers the handler called once the shared connection is reconnected or replaced, and returns a
+// function to unregister it.
+func (sc *SharedJetStreamClient) OnReconnect(h ReconnectHandler) func() {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+	id := sc.nextID
+	sc.nextID++
+	sc.handlers[id] = h
+	return func() {
+		sc.lock.Lock()
+		defer sc.lock.Unlock()
+		delete(sc.handlers, id)
+	}
+}
+
+// dial connects a new shared connection, the lock is held by the caller.
+func (sc *SharedJetStreamClient) dial(ctx context.Context) (*nats.Conn, error) {
+	conn, err := sc.client.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log := logging.FromContext(ctx)
+	conn.SetReconnectHandler(func(c *nats.Conn) {
+		log.Info("Reconnected to nats server")
+		sc.lock.Lock()
+		handlers := sc.currentHandlers()
+		sc.lock.Unlock()
+		notify(handlers, c)
+	})
+	conn.SetClosedHandler(func(c *nats.Conn) {
+		go sc.redial(ctx, c)
+	})
+	sc.conn = conn
+	return conn, nil
+}
+
+// redial replaces the closed connection with a new one, unless it's released or already replaced.
+func (sc *SharedJetStreamClient) redial(ctx context.Context, closed *nats.Conn) {
+	log := logging.FromContext(ctx)
+	for {
+		sc.lock.Lock()
+		if sc.conn != closed {
+			sc.lock.Unlock()
+			return
+		}
+		conn, err := sc.dial(ctx)
+		if err == nil {
+			handlers := sc.currentHandlers()
+			sc.lock.Unlock()
+			log.Info("Replaced the closed nats connection")
+			notify(handlers, conn)
+			return
+		}
+		sc.lock.Unlock()
+		log.Errorw("Failed to replace the closed nats connection", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(redialInterval):
+		}
+	}
+}
+
+// currentHandlers returns the registered handlers, the lock is held by the caller.
+func (sc *SharedJetStreamClient) currentHandlers() []ReconnectHandler {
+	handlers := make([]ReconnectHandler, 0, len(sc.handlers))
+	for _, h := range sc.handlers {
+		handlers = append(handlers, h)
+	}
+	return handlers
+}
+
+func notify(handlers []ReconnectHandler, conn *nats.Conn) {
+	for _, h := range handlers {
+		h(conn)
+	}
+}
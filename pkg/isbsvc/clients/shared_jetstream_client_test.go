@@ -0,0 +1,55 @@
+//go:build isb_jetstream
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var natsJetStreamUrl = "nats://localhost:4222"
+
+func TestSharedJetStreamClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sc := NewSharedJetStreamClient(NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", "")))
+	assert.Same(t, sc, ShareJetStreamClient(sc))
+
+	conn1, err := sc.Connect(ctx)
+	require.NoError(t, err)
+	conn2, err := sc.Connect(ctx)
+	require.NoError(t, err)
+	assert.Same(t, conn1, conn2)
+
+	reconnected := make(chan *nats.Conn, 1)
+	unregister := sc.OnReconnect(func(conn *nats.Conn) {
+		reconnected <- conn
+	})
+	// A closed connection is replaced, and the handlers are called with the new one
+	conn1.Close()
+	var conn3 *nats.Conn
+	select {
+	case conn3 = <-reconnected:
+	case <-ctx.Done():
+		t.Fatal("the closed connection is not replaced")
+	}
+	assert.NotSame(t, conn1, conn3)
+	assert.True(t, conn3.IsConnected())
+	unregister()
+
+	sc.Release()
+	assert.False(t, conn3.IsClosed())
+	sc.Release()
+	assert.True(t, conn3.IsClosed())
+	// Not replaced once it's released
+	time.Sleep(2 * redialInterval)
+	assert.Len(t, reconnected, 0)
+	sc.lock.Lock()
+	assert.Nil(t, sc.conn)
+	sc.lock.Unlock()
+}
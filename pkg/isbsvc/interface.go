@@ -2,20 +2,62 @@ package isbsvc
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
 )
 
 // ISBService is an interface used to do the operations on ISBS
 type ISBService interface {
 	CreateBuffers(ctx context.Context, buffers []string, opts ...BufferCreateOption) error
 	DeleteBuffers(ctx context.Context, buffers []string) error
-	ValidateBuffers(ctx context.Context, buffers []string) error
+	// ValidateBuffers checks the ISB Service is reachable and the buffers are correctly set up, the failed checks are
+	// reported in the result, and an error is only returned if the validation can not be done.
+	ValidateBuffers(ctx context.Context, buffers []string, opts ...BufferValidateOption) (*BufferValidationResult, error)
 	GetBufferInfo(ctx context.Context, buffer string) (*BufferInfo, error)
+	// MigrateBuffer copies the messages not yet acknowledged in buffer "from" to buffer "to", which is created like "from" if it does not exist.
+	MigrateBuffer(ctx context.Context, from, to string) error
+	// PeekBuffer returns up to count messages not yet acknowledged in the buffer, without consuming them.
+	PeekBuffer(ctx context.Context, buffer string, count int) ([]*isb.Message, error)
+	// GetReplayRange returns the range of the messages written to the buffer since startTime, nil if there is none.
+	GetReplayRange(ctx context.Context, buffer string, startTime time.Time) (*ReplayRange, error)
+	// ReplayBuffer writes up to count messages of the range to the buffer again from position "from", with idSuffix
+	// appended to their IDs so that they are not deduplicated, and returns the number of the messages replayed and the
+	// position to continue from, which is empty once the whole range is replayed.
+	ReplayBuffer(ctx context.Context, buffer string, r ReplayRange, from string, count int, idSuffix string) (int, string, error)
+}
+
+// ReplayRange is the range of the messages of a buffer to be replayed, the positions are the stream sequences of
+// JetStream, or the stream entry IDs of Redis.
+type ReplayRange struct {
+	// First is the position of the first message written since the start time
+	First string
+	// Last is the position of the last message when the range is taken, the messages written later are not replayed,
+	// including the replayed ones
+	Last string
+	// Total is the number of the messages in the range
+	Total int64
 }
 
 // bufferCreateOptions describes the options for creating buffers
 type bufferCreateOptions struct {
 	// bufferConfig is configuratiion for the to be created buffer
 	bufferConfig string
+	// deliverPolicy decides where the consumer of the to be created buffer starts reading from
+	deliverPolicy dfv1.DeliverPolicy
+	// startTime is used by the ByStartTime deliver policy
+	startTime time.Time
+	// purgeBefore is the time before which the existing buffers are considered left by others and purged, nothing is purged if it's zero
+	purgeBefore time.Time
+	// streamConfig overrides the settings of the JetStream streams in the buffer config
+	streamConfig *dfv1.JetStreamStreamConfig
+	// redisConfig is the config of the external Redis, validated before creating the Redis buffers if it's managed
+	redisConfig *dfv1.RedisConfig
+	// mirrorFailover makes the new JetStream streams source the messages of their local mirrors
+	mirrorFailover bool
 }
 
 type BufferCreateOption func(*bufferCreateOptions) error
@@ -28,6 +70,143 @@ func WithBufferConfig(conf string) BufferCreateOption {
 	}
 }
 
+// WithReplayPolicy sets the deliver policy of the consumers, startTime is only used by the ByStartTime policy
+func WithReplayPolicy(policy dfv1.DeliverPolicy, startTime time.Time) BufferCreateOption {
+	return func(o *bufferCreateOptions) error {
+		switch policy {
+		case "", dfv1.DeliverPolicyAll, dfv1.DeliverPolicyNew:
+		case dfv1.DeliverPolicyByStartTime:
+			if startTime.IsZero() {
+				return fmt.Errorf("start time is required by deliver policy %q", policy)
+			}
+		default:
+			return fmt.Errorf("unsupported deliver policy %q", policy)
+		}
+		o.deliverPolicy = policy
+		o.startTime = startTime
+		return nil
+	}
+}
+
+// WithPurgeBefore sets the time before which the existing buffers are purged instead of being adopted
+func WithPurgeBefore(t time.Time) BufferCreateOption {
+	return func(o *bufferCreateOptions) error {
+		o.purgeBefore = t
+		return nil
+	}
+}
+
+// WithStreamConfig sets the replication, the storage and the limits of the JetStream streams, overriding the ones in the buffer config
+func WithStreamConfig(c *dfv1.JetStreamStreamConfig) BufferCreateOption {
+	return func(o *bufferCreateOptions) error {
+		o.streamConfig = c
+		return nil
+	}
+}
+
+// WithRedisConfig sets the config of the external Redis, the restrictions of a managed one are validated before creating the Redis buffers
+func WithRedisConfig(c *dfv1.RedisConfig) BufferCreateOption {
+	return func(o *bufferCreateOptions) error {
+		o.redisConfig = c
+		return nil
+	}
+}
+
+// WithMirrorFailover sets if the new JetStream streams source the messages of their mirrors in the same JetStream, which
+// is the case when the pipeline fails over to the secondary JetStream of the mirrors
+func WithMirrorFailover(failover bool) BufferCreateOption {
+	return func(o *bufferCreateOptions) error {
+		o.mirrorFailover = failover
+		return nil
+	}
+}
+
+// bufferValidateOptions describes the options for validating buffers
+type bufferValidateOptions struct {
+	// bufferConfig is the configuration the buffers are expected to be created with, the config drift is not checked if it's empty
+	bufferConfig string
+	// streamConfig overrides the expected settings of the JetStream streams in the buffer config
+	streamConfig *dfv1.JetStreamStreamConfig
+}
+
+type BufferValidateOption func(*bufferValidateOptions) error
+
+// WithExpectedBufferConfig sets the buffer config the buffers are expected to be created with, the drift from it fails the validation
+func WithExpectedBufferConfig(conf string) BufferValidateOption {
+	return func(o *bufferValidateOptions) error {
+		o.bufferConfig = conf
+		return nil
+	}
+}
+
+// WithExpectedStreamConfig sets the replication, the storage and the limits the JetStream streams are expected to have, overriding the ones in the buffer config
+func WithExpectedStreamConfig(c *dfv1.JetStreamStreamConfig) BufferValidateOption {
+	return func(o *bufferValidateOptions) error {
+		o.streamConfig = c
+		return nil
+	}
+}
+
+const (
+	// BufferCheckReachable checks the ISB Service can be connected to with the credentials
+	BufferCheckReachable = "reachable"
+	// BufferCheckExists checks the buffer exists
+	BufferCheckExists = "exists"
+	// BufferCheckConsumer checks the consumer of the buffer exists and is durable
+	BufferCheckConsumer = "consumer"
+	// BufferCheckConfig checks the buffer has not drifted from the config it's expected to be created with
+	BufferCheckConfig = "config"
+)
+
+// BufferCheck is the result of one check of a buffer, or of the ISB Service if Buffer is empty
+type BufferCheck struct {
+	Buffer  string `json:"buffer,omitempty"`
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// BufferValidationResult is the structured result of validating the buffers
+type BufferValidationResult struct {
+	Checks []BufferCheck `json:"checks"`
+}
+
+func (r *BufferValidationResult) pass(buffer, check string) {
+	r.Checks = append(r.Checks, BufferCheck{Buffer: buffer, Check: check, Passed: true})
+}
+
+func (r *BufferValidationResult) fail(buffer, check, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, BufferCheck{Buffer: buffer, Check: check, Message: fmt.Sprintf(format, args...)})
+}
+
+// Failed returns the checks not passed
+func (r *BufferValidationResult) Failed() []BufferCheck {
+	var failed []BufferCheck
+	for _, c := range r.Checks {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// Err returns an error describing the checks not passed, or nil if all of them passed
+func (r *BufferValidationResult) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, c := range failed {
+		if c.Buffer == "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", c.Check, c.Message))
+		} else {
+			msgs = append(msgs, fmt.Sprintf("%s of buffer %q: %s", c.Check, c.Buffer, c.Message))
+		}
+	}
+	return fmt.Errorf("%d buffer checks failed, %s", len(failed), strings.Join(msgs, "; "))
+}
+
 // BufferInfo wraps the buffer state information
 type BufferInfo struct {
 	Name            string
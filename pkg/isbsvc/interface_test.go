@@ -0,0 +1,195 @@
+package isbsvc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/Shopify/sarama"
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+func TestWithReplayPolicy(t *testing.T) {
+	o := &bufferCreateOptions{}
+	assert.NoError(t, WithReplayPolicy("", time.Time{})(o))
+	assert.NoError(t, WithReplayPolicy(dfv1.DeliverPolicyNew, time.Time{})(o))
+	assert.Equal(t, dfv1.DeliverPolicyNew, o.deliverPolicy)
+	assert.Error(t, WithReplayPolicy(dfv1.DeliverPolicyByStartTime, time.Time{})(o))
+	assert.Error(t, WithReplayPolicy("abc", time.Time{})(o))
+	startTime := time.Unix(1636470000, 0)
+	assert.NoError(t, WithReplayPolicy(dfv1.DeliverPolicyByStartTime, startTime)(o))
+	assert.Equal(t, startTime, o.startTime)
+}
+
+func TestWithPurgeBefore(t *testing.T) {
+	o := &bufferCreateOptions{}
+	purgeBefore := time.Unix(1636470000, 0)
+	assert.NoError(t, WithPurgeBefore(purgeBefore)(o))
+	assert.Equal(t, purgeBefore, o.purgeBefore)
+}
+
+func TestWrittenBefore(t *testing.T) {
+	purgeBefore := time.UnixMilli(1636470000123)
+	assert.True(t, writtenBefore("0-0", purgeBefore))
+	assert.True(t, writtenBefore("1636470000122-5", purgeBefore))
+	assert.False(t, writtenBefore("1636470000123-0", purgeBefore))
+	assert.False(t, writtenBefore("1636470000122-5", time.Time{}))
+	assert.False(t, writtenBefore("invalid", purgeBefore))
+}
+
+func TestNextStreamEntryID(t *testing.T) {
+	assert.Equal(t, "1636470000000-1", nextStreamEntryID("1636470000000-0"))
+	assert.Equal(t, "1636470000000-10", nextStreamEntryID("1636470000000-9"))
+	assert.Equal(t, "abc", nextStreamEntryID("abc"))
+}
+
+func TestRedisGroupStartID(t *testing.T) {
+	assert.Equal(t, clients.ReadFromEarliest, redisGroupStartID(&bufferCreateOptions{}))
+	assert.Equal(t, clients.ReadFromEarliest, redisGroupStartID(&bufferCreateOptions{deliverPolicy: dfv1.DeliverPolicyAll}))
+	assert.Equal(t, clients.ReadFromLatest, redisGroupStartID(&bufferCreateOptions{deliverPolicy: dfv1.DeliverPolicyNew}))
+	assert.Equal(t, "1636470000122-18446744073709551615", redisGroupStartID(&bufferCreateOptions{deliverPolicy: dfv1.DeliverPolicyByStartTime, startTime: time.UnixMilli(1636470000123)}))
+}
+
+func TestSetConsumerDeliverPolicy(t *testing.T) {
+	c := &nats.ConsumerConfig{}
+	setConsumerDeliverPolicy(c, &bufferCreateOptions{})
+	assert.Equal(t, nats.DeliverAllPolicy, c.DeliverPolicy)
+	setConsumerDeliverPolicy(c, &bufferCreateOptions{deliverPolicy: dfv1.DeliverPolicyNew})
+	assert.Equal(t, nats.DeliverNewPolicy, c.DeliverPolicy)
+	startTime := time.Unix(1636470000, 0)
+	setConsumerDeliverPolicy(c, &bufferCreateOptions{deliverPolicy: dfv1.DeliverPolicyByStartTime, startTime: startTime})
+	assert.Equal(t, nats.DeliverByStartTimePolicy, c.DeliverPolicy)
+	assert.Equal(t, startTime, *c.OptStartTime)
+}
+
+func TestApplyStreamConfig(t *testing.T) {
+	o := &bufferCreateOptions{}
+	replicas := int32(5)
+	maxBytes := resource.MustParse("1Gi")
+	sc := &dfv1.JetStreamStreamConfig{Replicas: &replicas, Storage: dfv1.JetStreamStorageMemory, MaxBytes: &maxBytes, MaxAge: &metav1.Duration{Duration: time.Hour}}
+	assert.NoError(t, WithStreamConfig(sc)(o))
+	c := &nats.StreamConfig{Replicas: 3, Storage: nats.FileStorage, MaxBytes: -1, MaxAge: 72 * time.Hour}
+	applyStreamConfig(c, nil)
+	assert.Equal(t, 3, c.Replicas)
+	applyStreamConfig(c, o.streamConfig)
+	assert.Equal(t, 5, c.Replicas)
+	assert.Equal(t, nats.MemoryStorage, c.Storage)
+	assert.Equal(t, int64(1<<30), c.MaxBytes)
+	assert.Equal(t, time.Hour, c.MaxAge)
+	applyStreamConfig(c, &dfv1.JetStreamStreamConfig{Storage: dfv1.JetStreamStorageFile})
+	assert.Equal(t, nats.FileStorage, c.Storage)
+	assert.Equal(t, 5, c.Replicas)
+}
+
+func TestValidateManagedRedis(t *testing.T) {
+	ctx := context.Background()
+	// nothing listens on the address, the commands on the server are not checked
+	r := &isbsRedisSvc{client: clients.NewRedisClient(&goredis.UniversalOptions{Addrs: []string{"127.0.0.1:1"}, MaxRetries: -1})}
+	c := &dfv1.RedisConfig{URL: "127.0.0.1:1", Managed: &dfv1.ManagedRedis{RequireTLS: true, DisabledCommands: []string{"CONFIG"}}}
+	err := r.validateManagedRedis(ctx, c)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "TLS is required")
+	c.TLS = &dfv1.TLS{}
+	assert.NoError(t, r.validateManagedRedis(ctx, c))
+	c.Managed.DisabledCommands = []string{"config", "evalsha", "wait"}
+	err = r.validateManagedRedis(ctx, c)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "[EVALSHA WAIT]")
+	err = r.CreateBuffers(ctx, []string{"buffer"}, WithRedisConfig(c))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disabled on the managed Redis")
+}
+
+func TestMirrorStreamConfig(t *testing.T) {
+	c := &nats.StreamConfig{Name: "pl-in-out", Retention: nats.WorkQueuePolicy, MaxMsgs: 100, MaxBytes: -1, MaxAge: time.Hour, Storage: nats.FileStorage, Replicas: 3}
+	mc := mirrorStreamConfig(c, &dfv1.JetStreamMirror{URL: "nats://isbsvc-dr:4222"})
+	assert.Equal(t, "pl-in-out_MIRROR", mc.Name)
+	assert.Equal(t, nats.LimitsPolicy, mc.Retention)
+	assert.Empty(t, mc.Subjects)
+	assert.Equal(t, int64(100), mc.MaxMsgs)
+	assert.Equal(t, time.Hour, mc.MaxAge)
+	assert.Equal(t, 3, mc.Replicas)
+	assert.Equal(t, &nats.StreamSource{Name: "pl-in-out"}, mc.Mirror)
+	mc = mirrorStreamConfig(c, &dfv1.JetStreamMirror{URL: "nats://isbsvc-dr:4222", SourceAPIPrefix: "$JS.primary.API"})
+	assert.Equal(t, "$JS.primary.API", mc.Mirror.External.APIPrefix)
+}
+
+func TestWithMirrorFailover(t *testing.T) {
+	o := &bufferCreateOptions{}
+	assert.NoError(t, WithMirrorFailover(true)(o))
+	assert.True(t, o.mirrorFailover)
+}
+
+func TestBufferValidationResult(t *testing.T) {
+	r := &BufferValidationResult{}
+	r.pass("", BufferCheckReachable)
+	r.pass("a", BufferCheckExists)
+	assert.Empty(t, r.Failed())
+	assert.NoError(t, r.Err())
+	r.fail("b", BufferCheckConsumer, "group %s not existing", "b-group")
+	assert.Equal(t, []BufferCheck{{Buffer: "b", Check: BufferCheckConsumer, Message: "group b-group not existing"}}, r.Failed())
+	assert.EqualError(t, r.Err(), `1 buffer checks failed, consumer of buffer "b": group b-group not existing`)
+}
+
+func TestCheckStreamConfig(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	assert.NoError(t, v.ReadConfig(bytes.NewBufferString("stream:\n  retention: 2\n  replicas: 3")))
+	replicas := int32(5)
+	expected := newStreamConfig("pl-in-out", v, &dfv1.JetStreamStreamConfig{Replicas: &replicas})
+	r := &BufferValidationResult{}
+	checkStreamConfig(r, "in-out", &nats.StreamConfig{Name: "pl-in-out", Retention: nats.WorkQueuePolicy, Replicas: 5}, expected)
+	assert.NoError(t, r.Err())
+	checkStreamConfig(r, "in-out", &nats.StreamConfig{Name: "pl-in-out", Retention: nats.LimitsPolicy, Replicas: 3}, expected)
+	assert.Len(t, r.Failed(), 1)
+	assert.Equal(t, BufferCheckConfig, r.Failed()[0].Check)
+	assert.Contains(t, r.Failed()[0].Message, "replicas is 3 instead of 5")
+	assert.Contains(t, r.Failed()[0].Message, "retention is Limits instead of WorkQueue")
+}
+
+func TestCheckConsumerConfig(t *testing.T) {
+	r := &BufferValidationResult{}
+	checkConsumerConfig(r, "in-out", &nats.ConsumerConfig{Durable: "pl-in-out", AckPolicy: nats.AckExplicitPolicy, FilterSubject: "pl-in-out"}, "pl-in-out")
+	assert.NoError(t, r.Err())
+	checkConsumerConfig(r, "in-out", &nats.ConsumerConfig{AckPolicy: nats.AckNonePolicy, DeliverSubject: "deliver", FilterSubject: "pl-in-out"}, "pl-in-out")
+	assert.Len(t, r.Failed(), 1)
+	assert.Equal(t, BufferCheckConsumer, r.Failed()[0].Check)
+	assert.Contains(t, r.Failed()[0].Message, `durable name is "" instead of "pl-in-out"`)
+	assert.Contains(t, r.Failed()[0].Message, "ack policy is AckNone instead of AckExplicit")
+	assert.Contains(t, r.Failed()[0].Message, `push consumer delivering to "deliver"`)
+}
+
+func TestCheckTopicConfig(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	assert.NoError(t, v.ReadConfig(bytes.NewBufferString("topic:\n  partitions: 2\n  replicationFactor: 3")))
+	m := &sarama.TopicMetadata{Name: "in-out", Partitions: []*sarama.PartitionMetadata{{ID: 0, Replicas: []int32{1, 2, 3}}, {ID: 1, Replicas: []int32{1, 2, 3}}}}
+	r := &BufferValidationResult{}
+	checkTopicConfig(r, m, v)
+	assert.NoError(t, r.Err())
+	m.Partitions = m.Partitions[:1]
+	m.Partitions[0].Replicas = []int32{1}
+	checkTopicConfig(r, m, v)
+	assert.Len(t, r.Failed(), 1)
+	assert.Contains(t, r.Failed()[0].Message, "partitions is 1 instead of 2")
+	assert.Contains(t, r.Failed()[0].Message, "replication factor of partition 0 is 1 instead of 3")
+}
+
+func TestValidateBuffersUnreachableRedis(t *testing.T) {
+	r := &isbsRedisSvc{client: clients.NewRedisClient(&goredis.UniversalOptions{Addrs: []string{"127.0.0.1:1"}, MaxRetries: -1})}
+	result, err := r.ValidateBuffers(context.Background(), []string{"buffer"})
+	assert.NoError(t, err)
+	assert.Len(t, result.Checks, 1)
+	assert.Equal(t, BufferCheckReachable, result.Checks[0].Check)
+	assert.False(t, result.Checks[0].Passed)
+}
@@ -5,8 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/nats-io/nats.go"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	jetstreamisb "github.com/numaproj/numaflow/pkg/isb/jetstream"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/spf13/viper"
@@ -16,6 +22,8 @@ import (
 type jetStreamSvc struct {
 	pipelineName string
 	js           nats.JetStreamContext
+	// mirror is the secondary JetStream the streams are mirrored to, nil if they are not mirrored
+	mirror *dfv1.JetStreamMirror
 }
 
 func NewISBJetStreamSvc(pipelineName string, opts ...JSServiceOption) (ISBService, error) {
@@ -41,6 +49,14 @@ func WithNatsConnection(conn *nats.Conn) JSServiceOption {
 	}
 }
 
+// WithJetStreamMirror sets the secondary JetStream the streams are mirrored to.
+func WithJetStreamMirror(mirror *dfv1.JetStreamMirror) JSServiceOption {
+	return func(j *jetStreamSvc) error {
+		j.mirror = mirror
+		return nil
+	}
+}
+
 func (jss *jetStreamSvc) CreateBuffers(ctx context.Context, buffers []string, opts ...BufferCreateOption) error {
 	log := logging.FromContext(ctx)
 	bufferCreatOpts := &bufferCreateOptions{}
@@ -64,45 +80,194 @@ func (jss *jetStreamSvc) CreateBuffers(ctx context.Context, buffers []string, op
 	if err != nil {
 		return fmt.Errorf("failed to get a js context from nats connection, %w", err)
 	}
+	var mirrorJS nats.JetStreamContext
+	if jss.mirror != nil {
+		mnc, err := clients.NewInClusterJetStreamMirrorClient(jss.mirror).Connect(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get a nats connection to the mirror %q, %w", jss.mirror.URL, err)
+		}
+		defer mnc.Close()
+		if mirrorJS, err = mnc.JetStream(); err != nil {
+			return fmt.Errorf("failed to get a js context from the nats connection to the mirror, %w", err)
+		}
+	}
 	for _, b := range buffers {
 		// Create a stream for each buffer
 		streamName := streamName(jss.pipelineName, b)
-		_, err := js.StreamInfo(streamName)
+		si, err := js.StreamInfo(streamName)
+		if err == nil && si.Created.Before(bufferCreatOpts.purgeBefore) {
+			// Left by a previous pipeline, recreate it along with the consumer
+			if err := js.DeleteStream(streamName); err != nil {
+				return fmt.Errorf("failed to purge existing stream %q, %w", streamName, err)
+			}
+			log.Infow("Purged existing stream", zap.String("stream", streamName), zap.Time("created", si.Created))
+			err = nats.ErrStreamNotFound
+		}
 		if err != nil {
 			if !errors.Is(err, nats.ErrStreamNotFound) {
 				return fmt.Errorf("failed to query information of stream %q during buffer creating, %w", streamName, err)
 			}
-			if _, err = js.AddStream(&nats.StreamConfig{
-				Name:       streamName,
-				Subjects:   []string{streamName}, // Use the stream name as the only subject
-				Retention:  nats.RetentionPolicy(v.GetInt("stream.retention")),
-				Discard:    nats.DiscardOld,
-				MaxMsgs:    v.GetInt64("stream.maxMsgs"),
-				MaxAge:     v.GetDuration("stream.maxAge"),
-				MaxBytes:   v.GetInt64("stream.maxBytes"),
-				Storage:    nats.FileStorage,
-				Replicas:   v.GetInt("stream.replicas"),
-				Duplicates: v.GetDuration("stream.duplicates"), // No duplication in this period
-			}); err != nil {
+			streamConfig := newStreamConfig(streamName, v, bufferCreatOpts.streamConfig)
+			if bufferCreatOpts.mirrorFailover {
+				// Failing over to the secondary JetStream, the in-flight messages are replayed from the local mirror
+				if _, err := js.StreamInfo(mirrorStreamName(streamName)); err == nil {
+					streamConfig.Sources = []*nats.StreamSource{{Name: mirrorStreamName(streamName)}}
+					log.Infow("Sourcing the messages of the mirror", zap.String("stream", streamName), zap.String("mirror", mirrorStreamName(streamName)))
+				} else {
+					log.Warnw("No mirror to source the messages from", zap.String("stream", streamName), zap.String("mirror", mirrorStreamName(streamName)), zap.Error(err))
+				}
+			}
+			if _, err = js.AddStream(streamConfig); err != nil {
 				return fmt.Errorf("failed to create stream %q and buffers, %w", streamName, err)
 			}
-			log.Infow("Succeeded to create a stream and buffers", zap.String("stream", streamName), zap.Strings("buffers", []string{streamName}))
-			if _, err := js.AddConsumer(streamName, &nats.ConsumerConfig{
-				Durable:       streamName,
-				DeliverPolicy: nats.DeliverAllPolicy,
-				AckPolicy:     nats.AckExplicitPolicy,
-				AckWait:       v.GetDuration("consumer.ackWait"),
-				MaxAckPending: v.GetInt("consumer.maxAckPending"),
-				FilterSubject: streamName,
-			}); err != nil {
-				return fmt.Errorf("failed to create a consumer for stream %q, %w", streamName, err)
+			log.Infow("Succeeded to create a stream and buffers", zap.String("stream", streamName), zap.Strings("buffers", []string{streamName}),
+				zap.Int("replicas", streamConfig.Replicas), zap.String("storage", streamConfig.Storage.String()))
+		} else if bufferCreatOpts.streamConfig != nil {
+			// An existing stream is updated to the configured replicas and limits, the storage can not be changed
+			streamConfig := si.Config
+			applyStreamConfig(&streamConfig, bufferCreatOpts.streamConfig)
+			if streamConfig.Storage != si.Config.Storage {
+				log.Warnw("The storage of an existing stream can not be changed", zap.String("stream", streamName), zap.String("storage", si.Config.Storage.String()))
+				streamConfig.Storage = si.Config.Storage
+			}
+			if streamConfig.Replicas != si.Config.Replicas || streamConfig.MaxBytes != si.Config.MaxBytes || streamConfig.MaxAge != si.Config.MaxAge {
+				if _, err := js.UpdateStream(&streamConfig); err != nil {
+					return fmt.Errorf("failed to update stream %q, %w", streamName, err)
+				}
+				log.Infow("Succeeded to update a stream", zap.String("stream", streamName), zap.Int("replicas", streamConfig.Replicas))
+			}
+		}
+		if mirrorJS != nil {
+			if err := jss.createMirror(ctx, js, mirrorJS, streamName); err != nil {
+				return err
 			}
-			log.Infow("Succeeded to create a consumer for a stream", zap.String("stream", streamName), zap.String("consumer", streamName))
 		}
+		// The consumer might be missing from an existing stream, e.g. the pipeline is recreated against existing buffers
+		if _, err := js.ConsumerInfo(streamName, streamName); err == nil {
+			// The existing consumer keeps reading from where it was, the replay policy does not apply
+			log.Infow("Consumer already exists", zap.String("stream", streamName), zap.String("consumer", streamName))
+			continue
+		} else if !errors.Is(err, nats.ErrConsumerNotFound) {
+			return fmt.Errorf("failed to query information of consumer for stream %q during buffer creating, %w", streamName, err)
+		}
+		consumerConfig := &nats.ConsumerConfig{
+			Durable:       streamName,
+			DeliverPolicy: nats.DeliverAllPolicy,
+			AckPolicy:     nats.AckExplicitPolicy,
+			AckWait:       v.GetDuration("consumer.ackWait"),
+			MaxAckPending: v.GetInt("consumer.maxAckPending"),
+			FilterSubject: streamName,
+		}
+		setConsumerDeliverPolicy(consumerConfig, bufferCreatOpts)
+		if _, err := js.AddConsumer(streamName, consumerConfig); err != nil {
+			return fmt.Errorf("failed to create a consumer for stream %q, %w", streamName, err)
+		}
+		log.Infow("Succeeded to create a consumer for a stream", zap.String("stream", streamName), zap.String("consumer", streamName), zap.String("deliverPolicy", string(bufferCreatOpts.deliverPolicy)))
+	}
+	return nil
+}
+
+// createMirror creates the mirror of the stream in the secondary JetStream if it doesn't exist.
+func (jss *jetStreamSvc) createMirror(ctx context.Context, js, mirrorJS nats.JetStreamContext, streamName string) error {
+	log := logging.FromContext(ctx)
+	name := mirrorStreamName(streamName)
+	if _, err := mirrorJS.StreamInfo(name); err == nil {
+		log.Infow("Mirror already exists", zap.String("stream", streamName), zap.String("mirror", name))
+		return nil
+	} else if !errors.Is(err, nats.ErrStreamNotFound) {
+		return fmt.Errorf("failed to query information of mirror %q, %w", name, err)
+	}
+	si, err := js.StreamInfo(streamName)
+	if err != nil {
+		return fmt.Errorf("failed to query information of stream %q during mirror creating, %w", streamName, err)
+	}
+	if _, err := mirrorJS.AddStream(mirrorStreamConfig(&si.Config, jss.mirror)); err != nil {
+		return fmt.Errorf("failed to create mirror %q of stream %q, %w", name, streamName, err)
 	}
+	log.Infow("Succeeded to create a mirror", zap.String("stream", streamName), zap.String("mirror", name), zap.String("url", jss.mirror.URL))
 	return nil
 }
 
+// mirrorStreamConfig returns the config of the mirror of the stream, which has the limits, the storage and the replicas
+// of the stream. The mirror keeps the messages until the limits, since the acknowledgements of a work queue stream are
+// not mirrored.
+func mirrorStreamConfig(c *nats.StreamConfig, mirror *dfv1.JetStreamMirror) *nats.StreamConfig {
+	source := &nats.StreamSource{Name: c.Name}
+	if mirror.SourceAPIPrefix != "" {
+		source.External = &nats.ExternalStream{APIPrefix: mirror.SourceAPIPrefix}
+	}
+	return &nats.StreamConfig{
+		Name:      mirrorStreamName(c.Name),
+		Retention: nats.LimitsPolicy,
+		Discard:   nats.DiscardOld,
+		MaxMsgs:   c.MaxMsgs,
+		MaxAge:    c.MaxAge,
+		MaxBytes:  c.MaxBytes,
+		Storage:   c.Storage,
+		Replicas:  c.Replicas,
+		Mirror:    source,
+	}
+}
+
+// mirrorStreamName returns the name of the mirror of the stream.
+func mirrorStreamName(streamName string) string {
+	return streamName + "_MIRROR"
+}
+
+// newStreamConfig returns the config of a stream created with the buffer config and the stream config overriding it.
+func newStreamConfig(streamName string, v *viper.Viper, sc *dfv1.JetStreamStreamConfig) *nats.StreamConfig {
+	c := &nats.StreamConfig{
+		Name:       streamName,
+		Subjects:   []string{streamName}, // Use the stream name as the only subject
+		Retention:  nats.RetentionPolicy(v.GetInt("stream.retention")),
+		Discard:    nats.DiscardOld,
+		MaxMsgs:    v.GetInt64("stream.maxMsgs"),
+		MaxAge:     v.GetDuration("stream.maxAge"),
+		MaxBytes:   v.GetInt64("stream.maxBytes"),
+		Storage:    nats.FileStorage,
+		Replicas:   v.GetInt("stream.replicas"),
+		Duplicates: v.GetDuration("stream.duplicates"), // No duplication in this period
+	}
+	applyStreamConfig(c, sc)
+	return c
+}
+
+// applyStreamConfig overrides the stream config with the replicas, the storage and the limits set.
+func applyStreamConfig(c *nats.StreamConfig, sc *dfv1.JetStreamStreamConfig) {
+	if sc == nil {
+		return
+	}
+	if sc.Replicas != nil {
+		c.Replicas = int(*sc.Replicas)
+	}
+	switch sc.Storage {
+	case dfv1.JetStreamStorageFile:
+		c.Storage = nats.FileStorage
+	case dfv1.JetStreamStorageMemory:
+		c.Storage = nats.MemoryStorage
+	}
+	if sc.MaxBytes != nil {
+		c.MaxBytes = sc.MaxBytes.Value()
+	}
+	if sc.MaxAge != nil {
+		c.MaxAge = sc.MaxAge.Duration
+	}
+}
+
+// setConsumerDeliverPolicy sets the deliver policy of the consumer config based on the replay policy.
+func setConsumerDeliverPolicy(c *nats.ConsumerConfig, opts *bufferCreateOptions) {
+	switch opts.deliverPolicy {
+	case dfv1.DeliverPolicyNew:
+		c.DeliverPolicy = nats.DeliverNewPolicy
+	case dfv1.DeliverPolicyByStartTime:
+		startTime := opts.startTime
+		c.DeliverPolicy = nats.DeliverByStartTimePolicy
+		c.OptStartTime = &startTime
+	default:
+		c.DeliverPolicy = nats.DeliverAllPolicy
+	}
+}
+
 func (jss *jetStreamSvc) DeleteBuffers(ctx context.Context, buffers []string) error {
 	log := logging.FromContext(ctx)
 	nc, err := clients.NewInClusterJetStreamClient().Connect(ctx)
@@ -121,27 +286,127 @@ func (jss *jetStreamSvc) DeleteBuffers(ctx context.Context, buffers []string) er
 		}
 		log.Infow("succeeded to delete a stream", zap.String("stream", streamName))
 	}
+	if jss.mirror != nil {
+		jss.deleteMirrors(ctx, buffers)
+	}
 	return nil
 }
 
-func (jss *jetStreamSvc) ValidateBuffers(ctx context.Context, buffers []string) error {
+// deleteMirrors deletes the mirrors of the streams in the secondary JetStream. It's best effort, the failures are
+// logged and the mirrors left need to be deleted manually, so that the buffers can be deleted when the secondary
+// JetStream is not available.
+func (jss *jetStreamSvc) deleteMirrors(ctx context.Context, buffers []string) {
+	log := logging.FromContext(ctx)
+	nc, err := clients.NewInClusterJetStreamMirrorClient(jss.mirror).Connect(ctx)
+	if err != nil {
+		log.Warnw("Failed to get a nats connection to the mirror, the mirrors are not deleted", zap.String("url", jss.mirror.URL), zap.Error(err))
+		return
+	}
+	defer nc.Close()
+	js, err := nc.JetStream()
+	if err != nil {
+		log.Warnw("Failed to get a js context from the nats connection to the mirror, the mirrors are not deleted", zap.Error(err))
+		return
+	}
+	for _, b := range buffers {
+		name := mirrorStreamName(streamName(jss.pipelineName, b))
+		if err := js.DeleteStream(name); err != nil && !errors.Is(err, nats.ErrStreamNotFound) {
+			log.Warnw("Failed to delete a mirror", zap.String("mirror", name), zap.Error(err))
+			continue
+		}
+		log.Infow("Succeeded to delete a mirror", zap.String("mirror", name))
+	}
+}
+
+func (jss *jetStreamSvc) ValidateBuffers(ctx context.Context, buffers []string, opts ...BufferValidateOption) (*BufferValidationResult, error) {
+	bufferValidateOpts := &bufferValidateOptions{}
+	for _, opt := range opts {
+		if err := opt(bufferValidateOpts); err != nil {
+			return nil, err
+		}
+	}
+	var expected *viper.Viper
+	if bufferValidateOpts.bufferConfig != "" {
+		expected = viper.New()
+		expected.SetConfigType("yaml")
+		if err := expected.ReadConfig(bytes.NewBufferString(bufferValidateOpts.bufferConfig)); err != nil {
+			return nil, fmt.Errorf("failed to read the expected buffer config, %w", err)
+		}
+	}
+	result := &BufferValidationResult{}
 	nc, err := clients.NewInClusterJetStreamClient().Connect(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get an in-cluster nats connection, %w", err)
+		result.fail("", BufferCheckReachable, "failed to get an in-cluster nats connection, %v", err)
+		return result, nil
 	}
 	defer nc.Close()
 	js, err := nc.JetStream()
 	if err != nil {
-		return fmt.Errorf("failed to get a js context from nats connection, %w", err)
+		result.fail("", BufferCheckReachable, "failed to get a js context from nats connection, %v", err)
+		return result, nil
+	}
+	if _, err := js.AccountInfo(); err != nil {
+		result.fail("", BufferCheckReachable, "failed to query the JetStream account information, %v", err)
+		return result, nil
 	}
+	result.pass("", BufferCheckReachable)
 	for _, b := range buffers {
-		streamName := fmt.Sprintf("%s-%s", jss.pipelineName, b)
-		_, err := js.StreamInfo(streamName)
+		streamName := streamName(jss.pipelineName, b)
+		si, err := js.StreamInfo(streamName)
 		if err != nil {
-			return fmt.Errorf("failed to query information of stream %q, %w", streamName, err)
+			result.fail(b, BufferCheckExists, "failed to query information of stream %q, %v", streamName, err)
+			continue
+		}
+		result.pass(b, BufferCheckExists)
+		if expected != nil {
+			checkStreamConfig(result, b, &si.Config, newStreamConfig(streamName, expected, bufferValidateOpts.streamConfig))
 		}
+		ci, err := js.ConsumerInfo(streamName, streamName)
+		if err != nil {
+			result.fail(b, BufferCheckConsumer, "failed to query information of consumer %q, %v", streamName, err)
+			continue
+		}
+		checkConsumerConfig(result, b, &ci.Config, streamName)
 	}
-	return nil
+	return result, nil
+}
+
+// checkStreamConfig checks the replicas and the retention policy of the stream have not drifted from the expected ones.
+func checkStreamConfig(result *BufferValidationResult, buffer string, c, expected *nats.StreamConfig) {
+	var drifts []string
+	if c.Replicas != expected.Replicas {
+		drifts = append(drifts, fmt.Sprintf("replicas is %d instead of %d", c.Replicas, expected.Replicas))
+	}
+	if c.Retention != expected.Retention {
+		drifts = append(drifts, fmt.Sprintf("retention is %s instead of %s", c.Retention, expected.Retention))
+	}
+	if len(drifts) > 0 {
+		result.fail(buffer, BufferCheckConfig, "stream %q has drifted, %s", c.Name, strings.Join(drifts, ", "))
+		return
+	}
+	result.pass(buffer, BufferCheckConfig)
+}
+
+// checkConsumerConfig checks the consumer is the durable pull consumer of the stream, which acknowledges the messages explicitly.
+func checkConsumerConfig(result *BufferValidationResult, buffer string, c *nats.ConsumerConfig, streamName string) {
+	var problems []string
+	if c.Durable != streamName {
+		problems = append(problems, fmt.Sprintf("durable name is %q instead of %q", c.Durable, streamName))
+	}
+	if c.AckPolicy != nats.AckExplicitPolicy {
+		problems = append(problems, fmt.Sprintf("ack policy is %s instead of %s", c.AckPolicy, nats.AckExplicitPolicy))
+	}
+	if c.DeliverSubject != "" {
+		problems = append(problems, fmt.Sprintf("it's a push consumer delivering to %q", c.DeliverSubject))
+	}
+	if c.FilterSubject != streamName {
+		problems = append(problems, fmt.Sprintf("filter subject is %q instead of %q", c.FilterSubject, streamName))
+	}
+	if len(problems) > 0 {
+		result.fail(buffer, BufferCheckConsumer, "consumer of stream %q is misconfigured, %s", streamName, strings.Join(problems, ", "))
+		return
+	}
+	result.pass(buffer, BufferCheckConsumer)
 }
 
 func (jss *jetStreamSvc) GetBufferInfo(ctx context.Context, buffer string) (*BufferInfo, error) {
@@ -181,6 +446,215 @@ func (jss *jetStreamSvc) GetBufferInfo(ctx context.Context, buffer string) (*Buf
 	return bufferInfo, nil
 }
 
+func (jss *jetStreamSvc) MigrateBuffer(ctx context.Context, from, to string) error {
+	log := logging.FromContext(ctx)
+	nc, err := clients.NewInClusterJetStreamClient().Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get an in-cluster nats connection, %w", err)
+	}
+	defer nc.Close()
+	js, err := nc.JetStream()
+	if err != nil {
+		return fmt.Errorf("failed to get a js context from nats connection, %w", err)
+	}
+	fromStream, toStream := streamName(jss.pipelineName, from), streamName(jss.pipelineName, to)
+	fromStreamInfo, err := js.StreamInfo(fromStream)
+	if err != nil {
+		return fmt.Errorf("failed to query information of stream %q, %w", fromStream, err)
+	}
+	fromConsumerInfo, err := js.ConsumerInfo(fromStream, fromStream)
+	if err != nil {
+		return fmt.Errorf("failed to query information of consumer for stream %q, %w", fromStream, err)
+	}
+	if _, err := js.StreamInfo(toStream); err != nil {
+		if !errors.Is(err, nats.ErrStreamNotFound) {
+			return fmt.Errorf("failed to query information of stream %q, %w", toStream, err)
+		}
+		streamConfig := fromStreamInfo.Config
+		streamConfig.Name = toStream
+		streamConfig.Subjects = []string{toStream}
+		if _, err := js.AddStream(&streamConfig); err != nil {
+			return fmt.Errorf("failed to create stream %q, %w", toStream, err)
+		}
+		log.Infow("Succeeded to create a stream", zap.String("stream", toStream))
+	}
+	if _, err := js.ConsumerInfo(toStream, toStream); err != nil {
+		if !errors.Is(err, nats.ErrConsumerNotFound) {
+			return fmt.Errorf("failed to query information of consumer for stream %q, %w", toStream, err)
+		}
+		consumerConfig := fromConsumerInfo.Config
+		consumerConfig.Durable = toStream
+		consumerConfig.FilterSubject = toStream
+		consumerConfig.DeliverPolicy = nats.DeliverAllPolicy
+		consumerConfig.OptStartSeq = 0
+		consumerConfig.OptStartTime = nil
+		if _, err := js.AddConsumer(toStream, &consumerConfig); err != nil {
+			return fmt.Errorf("failed to create a consumer for stream %q, %w", toStream, err)
+		}
+		log.Infow("Succeeded to create a consumer for a stream", zap.String("stream", toStream), zap.String("consumer", toStream))
+	}
+	// Copy the messages after the ack floor of the consumer, the message IDs in the headers dedup the retried copies.
+	copied := 0
+	for seq := fromConsumerInfo.AckFloor.Stream + 1; seq <= fromStreamInfo.State.LastSeq; seq++ {
+		m, err := js.GetMsg(fromStream, seq)
+		if err != nil {
+			if errors.Is(err, nats.ErrMsgNotFound) { // acknowledged or removed
+				continue
+			}
+			return fmt.Errorf("failed to get message %d of stream %q, %w", seq, fromStream, err)
+		}
+		if _, err := js.PublishMsg(&nats.Msg{Subject: toStream, Header: m.Header, Data: m.Data}); err != nil {
+			return fmt.Errorf("failed to publish message to stream %q, %w", toStream, err)
+		}
+		copied++
+	}
+	log.Infow("Succeeded to migrate a stream", zap.String("from", fromStream), zap.String("to", toStream), zap.Int("messages", copied))
+	return nil
+}
+
+func (jss *jetStreamSvc) PeekBuffer(ctx context.Context, buffer string, count int) ([]*isb.Message, error) {
+	nc, err := clients.NewInClusterJetStreamClient().Connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get an in-cluster nats connection, %w", err)
+	}
+	defer nc.Close()
+	js, err := nc.JetStream()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a js context from nats connection, %w", err)
+	}
+	stream := streamName(jss.pipelineName, buffer)
+	streamInfo, err := js.StreamInfo(stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information of stream %q, %w", stream, err)
+	}
+	consumerInfo, err := js.ConsumerInfo(stream, stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information of consumer for stream %q, %w", stream, err)
+	}
+	messages := []*isb.Message{}
+	for seq := consumerInfo.AckFloor.Stream + 1; seq <= streamInfo.State.LastSeq && len(messages) < count; seq++ {
+		m, err := js.GetMsg(stream, seq)
+		if err != nil {
+			if errors.Is(err, nats.ErrMsgNotFound) { // acknowledged or removed
+				continue
+			}
+			return nil, fmt.Errorf("failed to get message %d of stream %q, %w", seq, stream, err)
+		}
+		message := jetstreamisb.ConvertToIsbMessage(m.Header, m.Data)
+		messages = append(messages, &message)
+	}
+	return messages, nil
+}
+
+// GetReplayRange returns the stream sequences of the messages written to the buffer since startTime.
+func (jss *jetStreamSvc) GetReplayRange(ctx context.Context, buffer string, startTime time.Time) (*ReplayRange, error) {
+	js, closeFn, err := jss.jetStreamContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+	stream := streamName(jss.pipelineName, buffer)
+	streamInfo, err := js.StreamInfo(stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query information of stream %q, %w", stream, err)
+	}
+	first, last := streamInfo.State.FirstSeq, streamInfo.State.LastSeq
+	if streamInfo.State.Msgs == 0 {
+		return nil, nil
+	}
+	// Binary search for the first message written at or after the start time, the removed messages are skipped
+	lo, hi := first, last+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		m, err := nextStreamMsg(js, stream, mid, hi)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil || !m.Time.Before(startTime) {
+			hi = mid
+		} else {
+			lo = m.Sequence + 1
+		}
+	}
+	if lo > last {
+		return nil, nil
+	}
+	return &ReplayRange{First: strconv.FormatUint(lo, 10), Last: strconv.FormatUint(last, 10), Total: int64(last - lo + 1)}, nil
+}
+
+// nextStreamMsg returns the first message of the stream with a sequence in [from, until), nil if there is none.
+func nextStreamMsg(js nats.JetStreamContext, stream string, from, until uint64) (*nats.RawStreamMsg, error) {
+	for seq := from; seq < until; seq++ {
+		m, err := js.GetMsg(stream, seq)
+		if err != nil {
+			if errors.Is(err, nats.ErrMsgNotFound) { // removed
+				continue
+			}
+			return nil, fmt.Errorf("failed to get message %d of stream %q, %w", seq, stream, err)
+		}
+		return m, nil
+	}
+	return nil, nil
+}
+
+// ReplayBuffer publishes the messages of the range to the stream again, with the new IDs as the Nats-Msg-Id, so that
+// a batch retried after a failure is deduplicated.
+func (jss *jetStreamSvc) ReplayBuffer(ctx context.Context, buffer string, r ReplayRange, from string, count int, idSuffix string) (int, string, error) {
+	seq, err := strconv.ParseUint(from, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid stream sequence %q, %w", from, err)
+	}
+	last, err := strconv.ParseUint(r.Last, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid stream sequence %q, %w", r.Last, err)
+	}
+	js, closeFn, err := jss.jetStreamContext(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	defer closeFn()
+	stream := streamName(jss.pipelineName, buffer)
+	replayed := 0
+	for ; seq <= last && replayed < count; seq++ {
+		m, err := js.GetMsg(stream, seq)
+		if err != nil {
+			if errors.Is(err, nats.ErrMsgNotFound) { // removed
+				continue
+			}
+			return replayed, strconv.FormatUint(seq, 10), fmt.Errorf("failed to get message %d of stream %q, %w", seq, stream, err)
+		}
+		message := jetstreamisb.ConvertToIsbMessage(m.Header, m.Data)
+		message.ID += idSuffix
+		msg := &nats.Msg{Subject: stream, Header: jetstreamisb.ConvertToNatsMsgHeader(message.Header), Data: m.Data}
+		if _, err := js.PublishMsg(msg, nats.MsgId(message.ID), nats.Context(ctx)); err != nil {
+			return replayed, strconv.FormatUint(seq, 10), fmt.Errorf("failed to publish message to stream %q, %w", stream, err)
+		}
+		replayed++
+	}
+	if seq > last {
+		return replayed, "", nil
+	}
+	return replayed, strconv.FormatUint(seq, 10), nil
+}
+
+// jetStreamContext returns the JetStream context of the service, or of a new in-cluster connection, along with the
+// function to close the connection.
+func (jss *jetStreamSvc) jetStreamContext(ctx context.Context) (nats.JetStreamContext, func(), error) {
+	if jss.js != nil {
+		return jss.js, func() {}, nil
+	}
+	nc, err := clients.NewInClusterJetStreamClient().Connect(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get an in-cluster nats connection, %w", err)
+	}
+	js, err := nc.JetStream()
+	if err != nil {
+		nc.Close()
+		return nil, nil, fmt.Errorf("failed to get a js context from nats connection, %w", err)
+	}
+	return js, nc.Close, nil
+}
+
 func streamName(pipelineName, bufferName string) string {
 	return fmt.Sprintf("%s-%s", pipelineName, bufferName)
 }
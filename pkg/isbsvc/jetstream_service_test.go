@@ -0,0 +1,74 @@
+//go:build isb_jetstream
+
+package isbsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	jetstreamisb "github.com/numaproj/numaflow/pkg/isb/jetstream"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+)
+
+func TestJetStreamSvc_ReplayBuffer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	nc, err := nats.Connect(nats.DefaultURL)
+	assert.NoError(t, err)
+	defer nc.Close()
+	js, err := nc.JetStream()
+	assert.NoError(t, err)
+	buffer := "replay-buffer"
+	stream := streamName("test-pl", buffer)
+	_, err = js.AddStream(&nats.StreamConfig{Name: stream, Subjects: []string{stream}, Duplicates: time.Minute})
+	assert.NoError(t, err)
+	defer func() { _ = js.DeleteStream(stream) }()
+	jss, err := NewISBJetStreamSvc("test-pl", WithNatsConnection(nc))
+	assert.NoError(t, err)
+
+	rr, err := jss.GetReplayRange(ctx, buffer, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Nil(t, rr)
+	messages := testutils.BuildTestWriteMessages(int64(5), time.Unix(1636470000, 0))
+	var startTime time.Time
+	for i, m := range messages {
+		if i == 3 {
+			time.Sleep(10 * time.Millisecond)
+			startTime = time.Now()
+			time.Sleep(10 * time.Millisecond)
+		}
+		_, err := js.PublishMsg(&nats.Msg{Subject: stream, Header: jetstreamisb.ConvertToNatsMsgHeader(m.Header), Data: m.Payload}, nats.MsgId(m.ID))
+		assert.NoError(t, err)
+	}
+	rr, err = jss.GetReplayRange(ctx, buffer, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, &ReplayRange{First: "1", Last: "5", Total: 5}, rr)
+	rr, err = jss.GetReplayRange(ctx, buffer, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Nil(t, rr)
+	rr, err = jss.GetReplayRange(ctx, buffer, startTime)
+	assert.NoError(t, err)
+	assert.Equal(t, &ReplayRange{First: "4", Last: "5", Total: 2}, rr)
+
+	replayed, next, err := jss.ReplayBuffer(ctx, buffer, *rr, rr.First, 1, "-r1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	assert.Equal(t, "5", next)
+	// Retried, deduplicated by the new IDs
+	replayed, next, err = jss.ReplayBuffer(ctx, buffer, *rr, rr.First, 5, "-r1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	assert.Empty(t, next)
+	info, err := js.StreamInfo(stream)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), info.State.Msgs)
+	m, err := js.GetMsg(stream, 7)
+	assert.NoError(t, err)
+	message := jetstreamisb.ConvertToIsbMessage(m.Header, m.Data)
+	assert.Equal(t, messages[4].ID+"-r1", message.ID)
+	assert.Equal(t, messages[4].Payload, message.Payload)
+}
@@ -0,0 +1,459 @@
+package isbsvc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	kafkaisb "github.com/numaproj/numaflow/pkg/isb/kafka"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+type kafkaSvc struct {
+	client clients.KafkaClient
+}
+
+// NewISBKafkaSvc is used to return a new object of type kafkaSvc, each buffer is a topic with the same name
+func NewISBKafkaSvc(client clients.KafkaClient) ISBService {
+	return &kafkaSvc{client: client}
+}
+
+// connect returns a kafka client and a cluster admin, closing the admin closes the client.
+func (ks *kafkaSvc) connect(ctx context.Context) (sarama.Client, sarama.ClusterAdmin, error) {
+	c, err := ks.client.Connect(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get a kafka client, %w", err)
+	}
+	admin, err := sarama.NewClusterAdminFromClient(c)
+	if err != nil {
+		_ = c.Close()
+		return nil, nil, fmt.Errorf("failed to get a kafka cluster admin, %w", err)
+	}
+	return c, admin, nil
+}
+
+// CreateBuffers creates the topics of the buffers, and sets the offsets the consumer groups start reading from.
+func (ks *kafkaSvc) CreateBuffers(ctx context.Context, buffers []string, opts ...BufferCreateOption) error {
+	log := logging.FromContext(ctx)
+	bufferCreatOpts := &bufferCreateOptions{}
+	for _, opt := range opts {
+		if err := opt(bufferCreatOpts); err != nil {
+			return err
+		}
+	}
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewBufferString(bufferCreatOpts.bufferConfig)); err != nil {
+		return err
+	}
+	c, admin, err := ks.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+	partitions := int32(4)
+	if x := v.GetInt32("topic.partitions"); x > 0 {
+		partitions = x
+	}
+	replicationFactor := int16(len(c.Brokers()))
+	if replicationFactor > 3 {
+		replicationFactor = 3
+	}
+	if x := v.GetInt("topic.replicationFactor"); x > 0 {
+		replicationFactor = int16(x)
+	}
+	configEntries := map[string]*string{}
+	for k, val := range v.GetStringMapString("topic.config") {
+		val := val
+		configEntries[k] = &val
+	}
+	for _, b := range buffers {
+		created := false
+		err := admin.CreateTopic(b, &sarama.TopicDetail{NumPartitions: partitions, ReplicationFactor: replicationFactor, ConfigEntries: configEntries}, false)
+		if err == nil {
+			created = true
+			log.Infow("Succeeded to create a topic", zap.String("topic", b), zap.Int32("partitions", partitions), zap.Int16("replicationFactor", replicationFactor))
+		} else if !isTopicAlreadyExists(err) {
+			return fmt.Errorf("failed to create topic %q, %w", b, err)
+		}
+		if err := c.RefreshMetadata(b); err != nil {
+			return fmt.Errorf("failed to refresh the metadata of topic %q, %w", b, err)
+		}
+		if err := ks.setStartOffsets(ctx, c, admin, b, created, bufferCreatOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setStartOffsets commits the offsets the consumer group of the buffer starts reading from. The consumer group of an
+// existing topic keeps reading from where it was, unless the messages written before purgeBefore are to be purged.
+func (ks *kafkaSvc) setStartOffsets(ctx context.Context, c sarama.Client, admin sarama.ClusterAdmin, topic string, created bool, opts *bufferCreateOptions) error {
+	log := logging.FromContext(ctx)
+	group := kafkaisb.GroupName(topic)
+	partitions, err := c.Partitions(topic)
+	if err != nil {
+		return fmt.Errorf("failed to get the partitions of topic %q, %w", topic, err)
+	}
+	committed, err := admin.ListConsumerGroupOffsets(group, map[string][]int32{topic: partitions})
+	if err != nil {
+		return fmt.Errorf("failed to list the committed offsets of consumer group %q, %w", group, err)
+	}
+	hasCommitted := false
+	for _, p := range partitions {
+		if b := committed.GetBlock(topic, p); b != nil && b.Err == sarama.ErrNoError && b.Offset >= 0 {
+			hasCommitted = true
+		}
+	}
+	applyReplayPolicy := created || !hasCommitted
+	if !applyReplayPolicy && opts.purgeBefore.IsZero() {
+		log.Infow("Consumer group already exists", zap.String("topic", topic), zap.String("group", group))
+		return nil
+	}
+	om, err := sarama.NewOffsetManagerFromClient(group, c)
+	if err != nil {
+		return fmt.Errorf("failed to create offset manager of consumer group %q, %w", group, err)
+	}
+	defer om.Close()
+	for _, p := range partitions {
+		pom, err := om.ManagePartition(topic, p)
+		if err != nil {
+			return fmt.Errorf("failed to manage partition %d of topic %q, %w", p, topic, err)
+		}
+		start, _ := pom.NextOffset()
+		if applyReplayPolicy {
+			switch opts.deliverPolicy {
+			case dfv1.DeliverPolicyNew:
+				start, err = c.GetOffset(topic, p, sarama.OffsetNewest)
+			case dfv1.DeliverPolicyByStartTime:
+				start, err = offsetAt(c, topic, p, opts.startTime)
+			default:
+				start, err = c.GetOffset(topic, p, sarama.OffsetOldest)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get the start offset of partition %d of topic %q, %w", p, topic, err)
+			}
+		}
+		if !opts.purgeBefore.IsZero() {
+			// The messages written before it were left by others
+			purged, err := offsetAt(c, topic, p, opts.purgeBefore)
+			if err != nil {
+				return fmt.Errorf("failed to get the purged offset of partition %d of topic %q, %w", p, topic, err)
+			}
+			if purged > start {
+				start = purged
+			}
+		}
+		// MarkOffset only moves the offset forward, and ResetOffset only moves it backward
+		pom.MarkOffset(start, "")
+		pom.ResetOffset(start, "")
+	}
+	om.Commit()
+	log.Infow("Succeeded to set the start offsets of a consumer group", zap.String("topic", topic), zap.String("group", group), zap.String("deliverPolicy", string(opts.deliverPolicy)))
+	return nil
+}
+
+// isTopicAlreadyExists returns true if the error returned by creating a topic is caused by an existing one.
+func isTopicAlreadyExists(err error) bool {
+	var topicErr *sarama.TopicError
+	if errors.As(err, &topicErr) {
+		return topicErr.Err == sarama.ErrTopicAlreadyExists
+	}
+	return errors.Is(err, sarama.ErrTopicAlreadyExists)
+}
+
+// offsetAt returns the offset of the first message written at or after t, or the newest offset if there is none.
+func offsetAt(c sarama.Client, topic string, partition int32, t time.Time) (int64, error) {
+	o, err := c.GetOffset(topic, partition, t.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+	if o < 0 {
+		return c.GetOffset(topic, partition, sarama.OffsetNewest)
+	}
+	return o, nil
+}
+
+func (ks *kafkaSvc) DeleteBuffers(ctx context.Context, buffers []string) error {
+	log := logging.FromContext(ctx)
+	_, admin, err := ks.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+	for _, b := range buffers {
+		if err := admin.DeleteTopic(b); err != nil && !errors.Is(err, sarama.ErrUnknownTopicOrPartition) {
+			return fmt.Errorf("failed to delete topic %q, %w", b, err)
+		}
+		if err := admin.DeleteConsumerGroup(kafkaisb.GroupName(b)); err != nil && !errors.Is(err, sarama.ErrGroupIDNotFound) {
+			return fmt.Errorf("failed to delete consumer group %q, %w", kafkaisb.GroupName(b), err)
+		}
+		log.Infow("Succeeded to delete a topic", zap.String("topic", b))
+	}
+	return nil
+}
+
+// ValidateBuffers checks the topics of the buffers exist, the consumer groups have committed the offsets to start
+// reading from, and the partitions and the replication factor of the topics have not drifted from the buffer config.
+func (ks *kafkaSvc) ValidateBuffers(ctx context.Context, buffers []string, opts ...BufferValidateOption) (*BufferValidationResult, error) {
+	bufferValidateOpts := &bufferValidateOptions{}
+	for _, opt := range opts {
+		if err := opt(bufferValidateOpts); err != nil {
+			return nil, err
+		}
+	}
+	var expected *viper.Viper
+	if bufferValidateOpts.bufferConfig != "" {
+		expected = viper.New()
+		expected.SetConfigType("yaml")
+		if err := expected.ReadConfig(bytes.NewBufferString(bufferValidateOpts.bufferConfig)); err != nil {
+			return nil, fmt.Errorf("failed to read the expected buffer config, %w", err)
+		}
+	}
+	result := &BufferValidationResult{}
+	_, admin, err := ks.connect(ctx)
+	if err != nil {
+		result.fail("", BufferCheckReachable, "%v", err)
+		return result, nil
+	}
+	defer admin.Close()
+	metadata, err := admin.DescribeTopics(buffers)
+	if err != nil {
+		result.fail("", BufferCheckReachable, "failed to describe topics, %v", err)
+		return result, nil
+	}
+	result.pass("", BufferCheckReachable)
+	for _, m := range metadata {
+		if m.Err != sarama.ErrNoError {
+			result.fail(m.Name, BufferCheckExists, "failed to query information of topic %q, %v", m.Name, m.Err)
+			continue
+		}
+		result.pass(m.Name, BufferCheckExists)
+		if expected != nil {
+			checkTopicConfig(result, m, expected)
+		}
+		partitions := make([]int32, 0, len(m.Partitions))
+		for _, p := range m.Partitions {
+			partitions = append(partitions, p.ID)
+		}
+		group := kafkaisb.GroupName(m.Name)
+		committed, err := admin.ListConsumerGroupOffsets(group, map[string][]int32{m.Name: partitions})
+		if err != nil {
+			result.fail(m.Name, BufferCheckConsumer, "failed to list the committed offsets of consumer group %q, %v", group, err)
+			continue
+		}
+		var missing []int32
+		for _, p := range partitions {
+			if b := committed.GetBlock(m.Name, p); b == nil || b.Err != sarama.ErrNoError || b.Offset < 0 {
+				missing = append(missing, p)
+			}
+		}
+		if len(missing) > 0 {
+			result.fail(m.Name, BufferCheckConsumer, "consumer group %q has no committed offsets of partitions %v", group, missing)
+			continue
+		}
+		result.pass(m.Name, BufferCheckConsumer)
+	}
+	return result, nil
+}
+
+// checkTopicConfig checks the partitions and the replication factor of the topic have not drifted from the ones set in
+// the buffer config, the defaults depending on the brokers are not checked.
+func checkTopicConfig(result *BufferValidationResult, m *sarama.TopicMetadata, expected *viper.Viper) {
+	var drifts []string
+	if x := expected.GetInt("topic.partitions"); x > 0 && len(m.Partitions) != x {
+		drifts = append(drifts, fmt.Sprintf("partitions is %d instead of %d", len(m.Partitions), x))
+	}
+	if x := expected.GetInt("topic.replicationFactor"); x > 0 {
+		for _, p := range m.Partitions {
+			if len(p.Replicas) != x {
+				drifts = append(drifts, fmt.Sprintf("replication factor of partition %d is %d instead of %d", p.ID, len(p.Replicas), x))
+			}
+		}
+	}
+	if len(drifts) > 0 {
+		result.fail(m.Name, BufferCheckConfig, "topic %q has drifted, %s", m.Name, strings.Join(drifts, ", "))
+		return
+	}
+	result.pass(m.Name, BufferCheckConfig)
+}
+
+func (ks *kafkaSvc) GetBufferInfo(ctx context.Context, buffer string) (*BufferInfo, error) {
+	c, admin, err := ks.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close()
+	pending, total, err := kafkaisb.GetPendingCount(c, admin, buffer)
+	if err != nil {
+		return nil, err
+	}
+	return &BufferInfo{
+		Name:          buffer,
+		PendingCount:  pending,
+		TotalMessages: total,
+	}, nil
+}
+
+func (ks *kafkaSvc) MigrateBuffer(ctx context.Context, from, to string) error {
+	log := logging.FromContext(ctx)
+	c, admin, err := ks.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+	metadata, err := admin.DescribeTopics([]string{from})
+	if err != nil {
+		return fmt.Errorf("failed to query information of topic %q, %w", from, err)
+	}
+	if len(metadata) == 0 || metadata[0].Err != sarama.ErrNoError || len(metadata[0].Partitions) == 0 {
+		return fmt.Errorf("failed to query information of topic %q, %w", from, sarama.ErrUnknownTopicOrPartition)
+	}
+	if err := admin.CreateTopic(to, &sarama.TopicDetail{NumPartitions: int32(len(metadata[0].Partitions)), ReplicationFactor: int16(len(metadata[0].Partitions[0].Replicas))}, false); err != nil {
+		if !isTopicAlreadyExists(err) {
+			return fmt.Errorf("failed to create topic %q, %w", to, err)
+		}
+	} else {
+		log.Infow("Succeeded to create a topic", zap.String("topic", to))
+	}
+	producer, err := sarama.NewSyncProducerFromClient(c)
+	if err != nil {
+		return fmt.Errorf("failed to create a kafka producer, %w", err)
+	}
+	defer producer.Close()
+	// Copy the messages after the committed offsets, the keys keep them in the partitions by key.
+	copied := 0
+	var sendErr error
+	err = ks.forEachPending(ctx, c, admin, from, func(m *sarama.ConsumerMessage) bool {
+		headers := make([]sarama.RecordHeader, len(m.Headers))
+		for i, h := range m.Headers {
+			headers[i] = *h
+		}
+		pm := &sarama.ProducerMessage{Topic: to, Headers: headers, Value: sarama.ByteEncoder(m.Value)}
+		if len(m.Key) > 0 {
+			pm.Key = sarama.ByteEncoder(m.Key)
+		}
+		if _, _, err := producer.SendMessage(pm); err != nil {
+			sendErr = fmt.Errorf("failed to send message to topic %q, %w", to, err)
+			return false
+		}
+		copied++
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	log.Infow("Succeeded to migrate a topic", zap.String("from", from), zap.String("to", to), zap.Int("messages", copied))
+	return nil
+}
+
+func (ks *kafkaSvc) PeekBuffer(ctx context.Context, buffer string, count int) ([]*isb.Message, error) {
+	c, admin, err := ks.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close()
+	messages := []*isb.Message{}
+	err = ks.forEachPending(ctx, c, admin, buffer, func(m *sarama.ConsumerMessage) bool {
+		if len(messages) >= count {
+			return false
+		}
+		message := kafkaisb.ConvertToIsbMessage(m.Headers, m.Key, m.Value)
+		messages = append(messages, &message)
+		return len(messages) < count
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// GetReplayRange is not supported by Kafka, a consumer group can be reset to an offset by time instead.
+func (ks *kafkaSvc) GetReplayRange(ctx context.Context, buffer string, startTime time.Time) (*ReplayRange, error) {
+	return nil, fmt.Errorf("replaying the buffers is not supported by the Kafka ISB Service")
+}
+
+// ReplayBuffer is not supported by Kafka.
+func (ks *kafkaSvc) ReplayBuffer(ctx context.Context, buffer string, r ReplayRange, from string, count int, idSuffix string) (int, string, error) {
+	return 0, "", fmt.Errorf("replaying the buffers is not supported by the Kafka ISB Service")
+}
+
+// forEachPending calls f with the messages after the committed offsets of the consumer group of the topic, partition by
+// partition, until f returns false. Nothing is committed. A partition is skipped if no message arrives in 10 seconds,
+// e.g. the last offsets are taken by transaction markers.
+func (ks *kafkaSvc) forEachPending(ctx context.Context, c sarama.Client, admin sarama.ClusterAdmin, topic string, f func(*sarama.ConsumerMessage) bool) error {
+	partitions, err := c.Partitions(topic)
+	if err != nil {
+		return fmt.Errorf("failed to get the partitions of topic %q, %w", topic, err)
+	}
+	committed, err := admin.ListConsumerGroupOffsets(kafkaisb.GroupName(topic), map[string][]int32{topic: partitions})
+	if err != nil {
+		return fmt.Errorf("failed to list the committed offsets of topic %q, %w", topic, err)
+	}
+	consumer, err := sarama.NewConsumerFromClient(c)
+	if err != nil {
+		return fmt.Errorf("failed to create a kafka consumer, %w", err)
+	}
+	defer consumer.Close()
+	for _, p := range partitions {
+		newest, err := c.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return fmt.Errorf("failed to get the newest offset of partition %d of topic %q, %w", p, topic, err)
+		}
+		start, err := c.GetOffset(topic, p, sarama.OffsetOldest)
+		if err != nil {
+			return fmt.Errorf("failed to get the oldest offset of partition %d of topic %q, %w", p, topic, err)
+		}
+		if b := committed.GetBlock(topic, p); b != nil && b.Err == sarama.ErrNoError && b.Offset > start {
+			start = b.Offset
+		}
+		if start >= newest {
+			continue
+		}
+		pc, err := consumer.ConsumePartition(topic, p, start)
+		if err != nil {
+			return fmt.Errorf("failed to consume partition %d of topic %q, %w", p, topic, err)
+		}
+		done, err := consumePartition(ctx, pc, newest, f)
+		_ = pc.Close()
+		if err != nil {
+			return fmt.Errorf("failed to consume partition %d of topic %q, %w", p, topic, err)
+		}
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+// consumePartition calls f with the messages of the partition consumer before the newest offset, it returns true if f returns false.
+func consumePartition(ctx context.Context, pc sarama.PartitionConsumer, newest int64, f func(*sarama.ConsumerMessage) bool) (bool, error) {
+	for {
+		select {
+		case m := <-pc.Messages():
+			if !f(m) {
+				return true, nil
+			}
+			if m.Offset >= newest-1 {
+				return false, nil
+			}
+		case <-time.After(10 * time.Second):
+			return false, nil
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,62 @@
+//go:build isb_kafka
+
+package isbsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	kafkaisb "github.com/numaproj/numaflow/pkg/isb/kafka"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+func TestIsbsKafkaSvc_Buffers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	client := clients.NewDefaultKafkaClient([]string{"localhost:9092"}, nil)
+	buffer := "isbsKafkaSvcBuffer"
+	buffers := []string{buffer}
+	isbsKafkaSvc := NewISBKafkaSvc(client)
+	bufferConfig := "topic:\n  partitions: 2\n  replicationFactor: 1"
+	assert.NoError(t, isbsKafkaSvc.CreateBuffers(ctx, buffers, WithBufferConfig(bufferConfig)))
+	defer func() { assert.NoError(t, isbsKafkaSvc.DeleteBuffers(ctx, buffers)) }()
+
+	// validate buffer
+	result, err := isbsKafkaSvc.ValidateBuffers(ctx, buffers, WithExpectedBufferConfig(bufferConfig))
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err())
+	result, err = isbsKafkaSvc.ValidateBuffers(ctx, buffers, WithExpectedBufferConfig("topic:\n  partitions: 3"))
+	assert.NoError(t, err)
+	assert.Error(t, result.Err())
+	result, err = isbsKafkaSvc.ValidateBuffers(ctx, []string{"isbsKafkaSvcBufferNotExisting"})
+	assert.NoError(t, err)
+	assert.Error(t, result.Err())
+
+	bw, err := kafkaisb.NewKafkaBufferWriter(ctx, client, buffer, buffer)
+	assert.NoError(t, err)
+	defer bw.Close()
+	assert.Eventually(t, func() bool {
+		_, errs := bw.Write(ctx, testutils.BuildTestWriteMessages(int64(10), time.Unix(1636470000, 0)))
+		return errs[0] == nil
+	}, 30*time.Second, 100*time.Millisecond)
+
+	bufferInfo, err := isbsKafkaSvc.GetBufferInfo(ctx, buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), bufferInfo.PendingCount)
+	assert.Equal(t, int64(10), bufferInfo.TotalMessages)
+
+	messages, err := isbsKafkaSvc.PeekBuffer(ctx, buffer, 3)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 3)
+
+	// the consumer group of an existing buffer skips the messages written before purgeBefore
+	assert.NoError(t, isbsKafkaSvc.CreateBuffers(ctx, buffers, WithReplayPolicy(dfv1.DeliverPolicyAll, time.Time{}), WithPurgeBefore(time.Now().Add(time.Minute))))
+	bufferInfo, err = isbsKafkaSvc.GetBufferInfo(ctx, buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), bufferInfo.PendingCount)
+}
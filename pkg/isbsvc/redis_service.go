@@ -3,9 +3,16 @@ package isbsvc
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
+	goredis "github.com/go-redis/redis/v8"
 	"go.uber.org/zap"
 
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/isb/redis"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
@@ -23,19 +30,43 @@ func NewISBRedisSvc(client *clients.RedisClient) ISBService {
 // CreateBuffers is used to create the inter-step redis buffers.
 func (r *isbsRedisSvc) CreateBuffers(ctx context.Context, buffers []string, opts ...BufferCreateOption) error {
 	log := logging.FromContext(ctx)
+	bufferCreatOpts := &bufferCreateOptions{}
+	for _, opt := range opts {
+		if err := opt(bufferCreatOpts); err != nil {
+			return err
+		}
+	}
+	if c := bufferCreatOpts.redisConfig; c != nil && c.Managed != nil {
+		if err := r.validateManagedRedis(ctx, c); err != nil {
+			return err
+		}
+	}
+	start := redisGroupStartID(bufferCreatOpts)
 	failToCreate := false
 	for _, stream := range buffers {
 		group := fmt.Sprintf("%s-group", stream)
-		err := r.client.CreateStreamGroup(ctx, stream, group, clients.ReadFromEarliest)
+		if si, err := r.client.StreamInfo(ctx, stream); err == nil && writtenBefore(si.LastGeneratedID, bufferCreatOpts.purgeBefore) {
+			// Left by a previous pipeline, recreate it along with the group
+			if err := r.client.DeleteKeys(ctx, stream); err != nil {
+				failToCreate = true
+				log.Errorw("Failed to purge existing Redis Stream.", zap.String("stream", stream), zap.Error(err))
+				continue
+			}
+			log.Infow("Purged existing Redis Stream", zap.String("stream", stream), zap.String("lastID", si.LastGeneratedID))
+		}
+		err := r.client.CreateStreamGroup(ctx, stream, group, start)
 		if err != nil {
 			if clients.IsAlreadyExistError(err) {
+				// The existing group keeps reading from where it was, the replay policy does not apply
 				log.Warnw("Stream already exists.", zap.String("group", group), zap.String("stream", stream))
 			} else {
 				failToCreate = true
 				log.Errorw("Failed to Redis Stream and Group creation.", zap.String("group", group), zap.String("stream", stream), zap.Error(err))
 			}
+		} else if r.client.IsCluster() {
+			log.Infow("Redis StreamGroup created", zap.String("group", group), zap.String("stream", stream), zap.String("start", start), zap.Int("slot", clients.KeySlot(stream)))
 		} else {
-			log.Infow("Redis StreamGroup created", zap.String("group", group), zap.String("stream", stream))
+			log.Infow("Redis StreamGroup created", zap.String("group", group), zap.String("stream", stream), zap.String("start", start))
 		}
 	}
 	if failToCreate {
@@ -44,6 +75,250 @@ func (r *isbsRedisSvc) CreateBuffers(ctx context.Context, buffers []string, opts
 	return nil
 }
 
+// redisCommands are the commands used by the Redis ISB Service, the ones of the dedup scripts and WAIT included.
+var redisCommands = []string{"XADD", "XGROUP", "XREADGROUP", "XACK", "XPENDING", "XINFO", "XLEN", "XRANGE", "XTRIM", "DEL", "EVALSHA", "SCRIPT", "WAIT"}
+
+// validateManagedRedis fails if TLS is required but not configured, or any of the commands used by the ISB Service is
+// disabled on the managed Redis, either in the config or on the server, where a renamed or removed command is missing
+// from COMMAND INFO. The server is not checked if COMMAND INFO is not available either.
+func (r *isbsRedisSvc) validateManagedRedis(ctx context.Context, c *dfv1.RedisConfig) error {
+	log := logging.FromContext(ctx)
+	m := c.Managed
+	if m.RequireTLS && c.TLS == nil {
+		return fmt.Errorf("TLS is required by the managed Redis but not configured")
+	}
+	disabled := make(map[string]bool, len(m.DisabledCommands))
+	for _, cmd := range m.DisabledCommands {
+		disabled[strings.ToUpper(cmd)] = true
+	}
+	var unavailable []string
+	for _, cmd := range redisCommands {
+		if disabled[cmd] {
+			unavailable = append(unavailable, cmd)
+		}
+	}
+	if len(unavailable) > 0 {
+		return fmt.Errorf("commands %v used by the ISB Service are disabled on the managed Redis", unavailable)
+	}
+	args := []interface{}{"COMMAND", "INFO"}
+	for _, cmd := range redisCommands {
+		args = append(args, strings.ToLower(cmd))
+	}
+	infos, err := r.client.Client.Do(ctx, args...).Slice()
+	if err != nil {
+		log.Warnw("Failed to get the command info from the managed Redis, skipped checking the commands on the server", zap.Error(err))
+		return nil
+	}
+	for i, info := range infos {
+		if info == nil && i < len(redisCommands) {
+			unavailable = append(unavailable, redisCommands[i])
+		}
+	}
+	if len(unavailable) > 0 {
+		return fmt.Errorf("commands %v used by the ISB Service are not available on the managed Redis", unavailable)
+	}
+	return nil
+}
+
+// MigrateBuffer is used to copy the messages not acknowledged by the group of a redis buffer to another one.
+func (r *isbsRedisSvc) MigrateBuffer(ctx context.Context, from, to string) error {
+	log := logging.FromContext(ctx)
+	fromGroup, toGroup := fmt.Sprintf("%s-group", from), fmt.Sprintf("%s-group", to)
+	if err := r.client.CreateStreamGroup(ctx, to, toGroup, clients.ReadFromEarliest); err != nil && !clients.IsAlreadyExistError(err) {
+		return fmt.Errorf("failed to create stream %q and group %q, %w", to, toGroup, err)
+	}
+	start, skip, err := r.unacknowledgedRange(ctx, from, fromGroup)
+	if err != nil {
+		return err
+	}
+	copied := 0
+	for {
+		entries, err := r.client.Client.XRangeN(ctx, from, start, "+", 100).Result()
+		if err != nil {
+			return fmt.Errorf("failed to read stream %q, %w", from, err)
+		}
+		n := 0
+		for _, e := range entries {
+			if e.ID == skip {
+				continue
+			}
+			if err := r.client.Client.XAdd(ctx, &goredis.XAddArgs{Stream: to, Values: e.Values}).Err(); err != nil {
+				return fmt.Errorf("failed to write stream %q, %w", to, err)
+			}
+			n++
+		}
+		copied += n
+		if n == 0 {
+			break
+		}
+		// The range is inclusive, skip the last copied entry in the next round
+		start = entries[len(entries)-1].ID
+		skip = start
+	}
+	log.Infow("Migrated Redis Stream", zap.String("from", from), zap.String("to", to), zap.Int("messages", copied))
+	return nil
+}
+
+// PeekBuffer is used to read the messages not acknowledged by the group of a redis buffer without consuming them.
+func (r *isbsRedisSvc) PeekBuffer(ctx context.Context, buffer string, count int) ([]*isb.Message, error) {
+	group := fmt.Sprintf("%s-group", buffer)
+	start, skip, err := r.unacknowledgedRange(ctx, buffer, group)
+	if err != nil {
+		return nil, err
+	}
+	messages := []*isb.Message{}
+	for len(messages) < count {
+		entries, err := r.client.Client.XRangeN(ctx, buffer, start, "+", 100).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stream %q, %w", buffer, err)
+		}
+		n := 0
+		for _, e := range entries {
+			if e.ID == skip {
+				continue
+			}
+			m, err := redis.ConvertToIsbMessage(e.Values)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert entry %q of stream %q, %w", e.ID, buffer, err)
+			}
+			messages = append(messages, &m)
+			n++
+			if len(messages) == count {
+				break
+			}
+		}
+		if n == 0 {
+			break
+		}
+		// The range is inclusive, skip the last read entry in the next round
+		start = entries[len(entries)-1].ID
+		skip = start
+	}
+	return messages, nil
+}
+
+// GetReplayRange returns the IDs of the stream entries added to the redis buffer since startTime.
+func (r *isbsRedisSvc) GetReplayRange(ctx context.Context, buffer string, startTime time.Time) (*ReplayRange, error) {
+	entries, err := r.client.Client.XRevRangeN(ctx, buffer, "+", "-", 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stream %q, %w", buffer, err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	rr := &ReplayRange{Last: entries[0].ID}
+	// The entry IDs are prefixed with the milliseconds timestamps when they were added
+	start := strconv.FormatInt(startTime.UnixMilli(), 10)
+	for {
+		entries, err := r.client.Client.XRangeN(ctx, buffer, start, rr.Last, 1000).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stream %q, %w", buffer, err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+		if rr.First == "" {
+			rr.First = entries[0].ID
+		}
+		rr.Total += int64(len(entries))
+		start = nextStreamEntryID(entries[len(entries)-1].ID)
+	}
+	if rr.Total == 0 {
+		return nil, nil
+	}
+	return rr, nil
+}
+
+// ReplayBuffer adds the entries of the range to the redis buffer again.
+func (r *isbsRedisSvc) ReplayBuffer(ctx context.Context, buffer string, rr ReplayRange, from string, count int, idSuffix string) (int, string, error) {
+	entries, err := r.client.Client.XRangeN(ctx, buffer, from, rr.Last, int64(count)).Result()
+	if err != nil {
+		return 0, from, fmt.Errorf("failed to read stream %q, %w", buffer, err)
+	}
+	for i, e := range entries {
+		m, err := redis.ConvertToIsbMessage(e.Values)
+		if err != nil {
+			return i, e.ID, fmt.Errorf("failed to convert entry %q of stream %q, %w", e.ID, buffer, err)
+		}
+		m.ID += idSuffix
+		if err := r.client.Client.XAdd(ctx, &goredis.XAddArgs{Stream: buffer, Values: []interface{}{m.Header, m.Body}}).Err(); err != nil {
+			return i, e.ID, fmt.Errorf("failed to write stream %q, %w", buffer, err)
+		}
+	}
+	if len(entries) < count || entries[len(entries)-1].ID == rr.Last {
+		return len(entries), "", nil
+	}
+	return len(entries), nextStreamEntryID(entries[len(entries)-1].ID), nil
+}
+
+// nextStreamEntryID returns the smallest stream entry ID after the given one.
+func nextStreamEntryID(id string) string {
+	parts := strings.SplitN(id, "-", 2)
+	if len(parts) != 2 {
+		return id
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return id
+	}
+	return fmt.Sprintf("%s-%d", parts[0], seq+1)
+}
+
+// unacknowledgedRange returns the ID of the stream entry to start reading the messages not yet acknowledged by the
+// group from, and the ID to skip if it's the last delivered entry, which has been acknowledged.
+func (r *isbsRedisSvc) unacknowledgedRange(ctx context.Context, stream, group string) (start, skip string, err error) {
+	groups, err := r.client.StreamGroupInfo(ctx, stream)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query groups of stream %q, %w", stream, err)
+	}
+	for _, g := range groups {
+		if g.Name == group {
+			start = g.LastDeliveredID
+		}
+	}
+	if start == "" {
+		return "", "", fmt.Errorf("group %q of stream %q not found", group, stream)
+	}
+	pending, err := r.client.Client.XPending(ctx, stream, group).Result()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query pending messages of group %q, %w", group, err)
+	}
+	// The entries delivered but not acknowledged are included as well, along with the acknowledged ones in between
+	if pending.Count > 0 {
+		return pending.Lower, "", nil
+	}
+	return start, start, nil
+}
+
+// writtenBefore returns true if the stream entry ID, prefixed with the milliseconds timestamp when it was added, is before the given time.
+func writtenBefore(id string, t time.Time) bool {
+	if t.IsZero() {
+		return false
+	}
+	ms, err := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+	if err != nil {
+		return false
+	}
+	return ms < t.UnixMilli()
+}
+
+// redisGroupStartID returns the ID of the stream entry the consumer group starts reading from.
+func redisGroupStartID(opts *bufferCreateOptions) string {
+	switch opts.deliverPolicy {
+	case dfv1.DeliverPolicyNew:
+		return clients.ReadFromLatest
+	case dfv1.DeliverPolicyByStartTime:
+		// Stream entry IDs are prefixed with the milliseconds timestamp when the entries were added, the group reads
+		// the entries with IDs greater than the start ID, so start from the last possible ID of the previous millisecond.
+		if ms := opts.startTime.UnixMilli(); ms > 0 {
+			return fmt.Sprintf("%d-%d", ms-1, uint64(math.MaxUint64))
+		}
+		return clients.ReadFromEarliest
+	default:
+		return clients.ReadFromEarliest
+	}
+}
+
 // DeleteBuffers is used to delete the inter-step redis buffers.
 func (r *isbsRedisSvc) DeleteBuffers(ctx context.Context, buffers []string) error {
 	log := logging.FromContext(ctx)
@@ -77,18 +352,34 @@ func (r *isbsRedisSvc) DeleteBuffers(ctx context.Context, buffers []string) erro
 	return nil
 }
 
-// ValidateBuffers is used to validate inter-step redis buffers to see if the stream/stream group exist
-func (r *isbsRedisSvc) ValidateBuffers(ctx context.Context, buffers []string) error {
+// ValidateBuffers is used to validate inter-step redis buffers to see if Redis is reachable and the stream/stream group exist
+func (r *isbsRedisSvc) ValidateBuffers(ctx context.Context, buffers []string, opts ...BufferValidateOption) (*BufferValidationResult, error) {
+	bufferValidateOpts := &bufferValidateOptions{}
+	for _, opt := range opts {
+		if err := opt(bufferValidateOpts); err != nil {
+			return nil, err
+		}
+	}
+	result := &BufferValidationResult{}
+	if err := r.client.Client.Ping(ctx).Err(); err != nil {
+		result.fail("", BufferCheckReachable, "failed to ping Redis, %v", err)
+		return result, nil
+	}
+	result.pass("", BufferCheckReachable)
 	for _, streamName := range buffers {
 		if !r.client.IsStreamExists(ctx, streamName) {
-			return fmt.Errorf("stream %s not existing", streamName)
+			result.fail(streamName, BufferCheckExists, "stream %s not existing", streamName)
+			continue
 		}
+		result.pass(streamName, BufferCheckExists)
 		group := fmt.Sprintf("%s-group", streamName)
 		if !r.client.IsStreamGroupExists(ctx, streamName, group) {
-			return fmt.Errorf("group %s not existing", group)
+			result.fail(streamName, BufferCheckConsumer, "group %s not existing", group)
+			continue
 		}
+		result.pass(streamName, BufferCheckConsumer)
 	}
-	return nil
+	return result, nil
 }
 
 // GetBufferInfo is used to provide buffer information like pending count, buffer length, has unprocessed data etc.
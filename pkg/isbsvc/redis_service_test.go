@@ -28,7 +28,9 @@ func TestIsbsRedisSvc_Buffers(t *testing.T) {
 	assert.NoError(t, isbsRedisSvc.CreateBuffers(ctx, buffers))
 
 	// validate buffer
-	assert.NoError(t, isbsRedisSvc.ValidateBuffers(ctx, buffers))
+	result, err := isbsRedisSvc.ValidateBuffers(ctx, buffers)
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err())
 
 	// Verify
 	// Add some data
@@ -60,6 +62,141 @@ func TestIsbsRedisSvc_Buffers(t *testing.T) {
 		assert.Equal(t, bufferInfo.PendingCount, int64(9))
 	}
 
+	// adopt the existing buffer
+	assert.NoError(t, isbsRedisSvc.CreateBuffers(ctx, buffers))
+	length, err := redisClient.Client.XLen(ctx, buffer).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), length)
+
+	// purge the existing buffer
+	assert.NoError(t, isbsRedisSvc.CreateBuffers(ctx, buffers, WithPurgeBefore(time.Now().Add(time.Minute))))
+	length, err = redisClient.Client.XLen(ctx, buffer).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), length)
+	result, err = isbsRedisSvc.ValidateBuffers(ctx, buffers)
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err())
+
 	// delete buffer
 	assert.NoError(t, isbsRedisSvc.DeleteBuffers(ctx, buffers))
 }
+
+func TestIsbsRedisSvc_MigrateBuffer(t *testing.T) {
+	ctx := context.Background()
+	redisOptions := &goredis.UniversalOptions{
+		Addrs: []string{":6379"},
+	}
+	from, to := "isbsRedisSvcFromBuffer", "isbsRedisSvcToBuffer"
+	redisClient := clients.NewRedisClient(redisOptions)
+	isbsRedisSvc := NewISBRedisSvc(redisClient)
+	assert.NoError(t, isbsRedisSvc.CreateBuffers(ctx, []string{from}))
+	defer func() { _ = isbsRedisSvc.DeleteBuffers(ctx, []string{from, to}) }()
+
+	messages := testutils.BuildTestWriteMessages(int64(10), time.Unix(1636470000, 0))
+	for _, msg := range messages {
+		err := redisClient.Client.XAdd(ctx, &goredis.XAddArgs{
+			Stream: from,
+			Values: []interface{}{msg.Header, msg.Body},
+		}).Err()
+		assert.NoError(t, err)
+	}
+	// Read 5 messages and ACK 2 of them, which leaves 8 messages not acknowledged
+	rqr, _ := redis.NewBufferRead(ctx, redisClient, from, from+"-group", "consumer").(*redis.BufferRead)
+	readMessages, err := rqr.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, redisClient.Client.XAck(clients.RedisContext, from, from+"-group", readMessages[0].ReadOffset.String(), readMessages[1].ReadOffset.String()).Err())
+
+	assert.NoError(t, isbsRedisSvc.MigrateBuffer(ctx, from, to))
+	result, err := isbsRedisSvc.ValidateBuffers(ctx, []string{to})
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err())
+	length, err := redisClient.Client.XLen(ctx, to).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), length)
+}
+
+func TestIsbsRedisSvc_PeekBuffer(t *testing.T) {
+	ctx := context.Background()
+	redisOptions := &goredis.UniversalOptions{
+		Addrs: []string{":6379"},
+	}
+	buffer := "isbsRedisSvcPeekBuffer"
+	group := buffer + "-group"
+	redisClient := clients.NewRedisClient(redisOptions)
+	isbsRedisSvc := NewISBRedisSvc(redisClient)
+	assert.NoError(t, isbsRedisSvc.CreateBuffers(ctx, []string{buffer}))
+	defer func() { _ = isbsRedisSvc.DeleteBuffers(ctx, []string{buffer}) }()
+
+	messages := testutils.BuildTestWriteMessages(int64(10), time.Unix(1636470000, 0))
+	for _, msg := range messages {
+		err := redisClient.Client.XAdd(ctx, &goredis.XAddArgs{
+			Stream: buffer,
+			Values: []interface{}{msg.Header, msg.Body},
+		}).Err()
+		assert.NoError(t, err)
+	}
+	// Read 5 messages and ACK 2 of them, which leaves 8 messages not acknowledged
+	rqr, _ := redis.NewBufferRead(ctx, redisClient, buffer, group, "consumer").(*redis.BufferRead)
+	readMessages, err := rqr.Read(ctx, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, redisClient.Client.XAck(clients.RedisContext, buffer, group, readMessages[0].ReadOffset.String(), readMessages[1].ReadOffset.String()).Err())
+
+	peeked, err := isbsRedisSvc.PeekBuffer(ctx, buffer, 5)
+	assert.NoError(t, err)
+	assert.Len(t, peeked, 5)
+	assert.Equal(t, messages[2].Header.ID, peeked[0].Header.ID)
+	assert.Equal(t, messages[2].Payload, peeked[0].Payload)
+	peeked, err = isbsRedisSvc.PeekBuffer(ctx, buffer, 100)
+	assert.NoError(t, err)
+	assert.Len(t, peeked, 8)
+	// Nothing is consumed
+	pending, err := redisClient.Client.XPending(ctx, buffer, group).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pending.Count)
+}
+
+func TestIsbsRedisSvc_ReplayBuffer(t *testing.T) {
+	ctx := context.Background()
+	redisOptions := &goredis.UniversalOptions{
+		Addrs: []string{":6379"},
+	}
+	buffer := "isbsRedisSvcReplayBuffer"
+	redisClient := clients.NewRedisClient(redisOptions)
+	isbsRedisSvc := NewISBRedisSvc(redisClient)
+	assert.NoError(t, isbsRedisSvc.CreateBuffers(ctx, []string{buffer}))
+	defer func() { _ = isbsRedisSvc.DeleteBuffers(ctx, []string{buffer}) }()
+
+	rr, err := isbsRedisSvc.GetReplayRange(ctx, buffer, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Nil(t, rr)
+	messages := testutils.BuildTestWriteMessages(int64(5), time.Unix(1636470000, 0))
+	for _, msg := range messages {
+		err := redisClient.Client.XAdd(ctx, &goredis.XAddArgs{
+			Stream: buffer,
+			Values: []interface{}{msg.Header, msg.Body},
+		}).Err()
+		assert.NoError(t, err)
+	}
+	rr, err = isbsRedisSvc.GetReplayRange(ctx, buffer, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), rr.Total)
+	rr2, err := isbsRedisSvc.GetReplayRange(ctx, buffer, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Nil(t, rr2)
+
+	replayed, next, err := isbsRedisSvc.ReplayBuffer(ctx, buffer, *rr, rr.First, 3, "-r1")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, replayed)
+	assert.NotEmpty(t, next)
+	replayed, next, err = isbsRedisSvc.ReplayBuffer(ctx, buffer, *rr, next, 3, "-r1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	assert.Empty(t, next)
+	entries, err := redisClient.Client.XRange(ctx, buffer, "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 10)
+	m, err := redis.ConvertToIsbMessage(entries[5].Values)
+	assert.NoError(t, err)
+	assert.Equal(t, messages[0].Header.ID+"-r1", m.Header.ID)
+	assert.Equal(t, messages[0].Payload, m.Payload)
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ExemplarIDLabel is the label of the exemplars holding the ID of the message observed.
+const ExemplarIDLabel = "message_id"
+
+// maxExemplarIDLength is the maximum length of the ID in the exemplar, the observation panics if the names and the values
+// of the exemplar labels are longer than prometheus.ExemplarMaxRunes in total.
+const maxExemplarIDLength = prometheus.ExemplarMaxRunes - len(ExemplarIDLabel)
+
+// ObserveWithID observes the value with the ID of the message as the exemplar, so that the slow messages in a histogram
+// can be looked up, e.g. in the traces of the edges. The value is observed without an exemplar if the ID is empty, or
+// the observer does not support the exemplars.
+func ObserveWithID(o prometheus.Observer, value float64, id string) {
+	eo, ok := o.(prometheus.ExemplarObserver)
+	if !ok || id == "" {
+		o.Observe(value)
+		return
+	}
+	if r := []rune(id); len(r) > maxExemplarIDLength {
+		id = string(r[:maxExemplarIDLength])
+	}
+	eo.ObserveWithExemplar(value, prometheus.Labels{ExemplarIDLabel: id})
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObserveWithID(t *testing.T) {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_processing_time", Buckets: []float64{10, 100}})
+	ObserveWithID(h, 5, "")
+	ObserveWithID(h, 50, "in-0")
+	m := &dto.Metric{}
+	assert.NoError(t, h.Write(m))
+	assert.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+	buckets := m.GetHistogram().GetBucket()
+	assert.Nil(t, buckets[0].GetExemplar())
+	assert.Equal(t, ExemplarIDLabel, buckets[1].GetExemplar().GetLabel()[0].GetName())
+	assert.Equal(t, "in-0", buckets[1].GetExemplar().GetLabel()[0].GetValue())
+	assert.Equal(t, 50.0, buckets[1].GetExemplar().GetValue())
+	assert.Len(t, buckets[1].GetExemplar().GetLabel(), 1)
+}
+
+func Test_ObserveWithIDTruncated(t *testing.T) {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_processing_time", Buckets: []float64{10}})
+	ObserveWithID(h, 5, strings.Repeat("x", 200))
+	m := &dto.Metric{}
+	assert.NoError(t, h.Write(m))
+	assert.Equal(t, strings.Repeat("x", maxExemplarIDLength), m.GetHistogram().GetBucket()[0].GetExemplar().GetLabel()[0].GetValue())
+}
@@ -0,0 +1,136 @@
+// Package export pushes the metrics gathered from Prometheus to the external systems, for the environments without a
+// Prometheus to scrape them.
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/httpclient"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+// pusher pushes the metric families with a protocol.
+type pusher interface {
+	push(ctx context.Context, families []*dto.MetricFamily, now time.Time) error
+	close() error
+}
+
+type options struct {
+	// gatherer is where the metrics are gathered from, defaults to the default Prometheus registry
+	gatherer prometheus.Gatherer
+	// attributes are added to all the metrics, as the resource attributes of OTLP, or the tags of StatsD
+	attributes map[string]string
+	// httpClient is used by OTLP
+	httpClient *http.Client
+}
+
+type Option func(*options)
+
+// WithGatherer sets where the metrics are gathered from.
+func WithGatherer(g prometheus.Gatherer) Option {
+	return func(o *options) {
+		o.gatherer = g
+	}
+}
+
+// WithAttributes sets the attributes added to all the metrics.
+func WithAttributes(attributes map[string]string) Option {
+	return func(o *options) {
+		o.attributes = attributes
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by OTLP.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = c
+	}
+}
+
+// Exporter pushes the gathered metrics periodically.
+type Exporter struct {
+	gatherer prometheus.Gatherer
+	pusher   pusher
+	interval time.Duration
+}
+
+func NewExporter(spec dfv1.MetricsExport, opts ...Option) (*Exporter, error) {
+	o := &options{
+		gatherer:   prometheus.DefaultGatherer,
+		httpClient: httpclient.NewClient(0),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	var p pusher
+	switch spec.Protocol {
+	case dfv1.MetricsExportProtocolOTLP:
+		p = newOTLPPusher(spec.Endpoint, o.httpClient, o.attributes)
+	case dfv1.MetricsExportProtocolStatsD:
+		sp, err := newStatsDPusher(spec.Endpoint, o.attributes)
+		if err != nil {
+			return nil, err
+		}
+		p = sp
+	default:
+		return nil, fmt.Errorf("unsupported metrics export protocol %q", spec.Protocol)
+	}
+	return &Exporter{
+		gatherer: o.gatherer,
+		pusher:   p,
+		interval: spec.GetInterval(),
+	}, nil
+}
+
+// Run pushes the metrics every interval until the context is done. The failures are logged, and the metrics are
+// pushed again in the next interval.
+func (e *Exporter) Run(ctx context.Context) {
+	log := logging.FromContext(ctx)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	defer func() { _ = e.pusher.close() }()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.export(ctx); err != nil {
+				log.Warnw("Failed to export the metrics", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (e *Exporter) export(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		if len(families) == 0 {
+			return fmt.Errorf("failed to gather the metrics, %w", err)
+		}
+		// The metrics gathered successfully are still pushed
+		logging.FromContext(ctx).Warnw("Failed to gather some of the metrics", zap.Error(err))
+	}
+	ctx, cancel := context.WithTimeout(ctx, e.interval)
+	defer cancel()
+	return e.pusher.push(ctx, families, time.Now())
+}
+
+// labels returns the labels of a metric, on top of the attributes. A label overrides an attribute with the same name.
+func labels(attributes map[string]string, m *dto.Metric) map[string]string {
+	result := make(map[string]string, len(attributes)+len(m.GetLabel()))
+	for k, v := range attributes {
+		result[k] = v
+	}
+	for _, l := range m.GetLabel() {
+		result[l.GetName()] = l.GetValue()
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func testRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_total", Help: "Test counter"}, []string{"vertex"})
+	c.WithLabelValues("in").Add(3)
+	g := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge", Help: "Test gauge"})
+	g.Set(1.5)
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds", Help: "Test histogram", Buckets: []float64{1, 5}})
+	h.Observe(0.5)
+	h.Observe(2)
+	h.Observe(10)
+	r.MustRegister(c, g, h)
+	return r
+}
+
+func TestNewExporter(t *testing.T) {
+	_, err := NewExporter(dfv1.MetricsExport{Protocol: "abc"})
+	assert.Error(t, err)
+	e, err := NewExporter(dfv1.MetricsExport{Protocol: dfv1.MetricsExportProtocolOTLP, Endpoint: "http://localhost:4318/v1/metrics"})
+	assert.NoError(t, err)
+	assert.Equal(t, dfv1.DefaultMetricsExportInterval, e.interval)
+}
+
+func TestExporter_StatsD(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	e, err := NewExporter(dfv1.MetricsExport{
+		Protocol: dfv1.MetricsExportProtocolStatsD,
+		Endpoint: conn.LocalAddr().String(),
+		Interval: &metav1.Duration{Duration: 100 * time.Millisecond},
+	}, WithGatherer(testRegistry()), WithAttributes(map[string]string{"pipeline": "pl"}))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	buf := make([]byte, statsDMaxPacketSize)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	lines := strings.Split(string(buf[:n]), "\n")
+	assert.Contains(t, lines, "test_total:3|g|#pipeline:pl,vertex:in")
+	assert.Contains(t, lines, "test_gauge:1.5|g|#pipeline:pl")
+	assert.Contains(t, lines, "test_seconds_sum:12.5|g|#pipeline:pl")
+	assert.Contains(t, lines, "test_seconds_count:3|g|#pipeline:pl")
+	assert.Contains(t, lines, "test_seconds_bucket:2|g|#le:5,pipeline:pl")
+}
+
+func TestStatsDPusher_PacketSize(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	r := prometheus.NewRegistry()
+	g := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_gauge", Help: "Test gauge"}, []string{"id"})
+	for i := 0; i < 100; i++ {
+		g.WithLabelValues(strings.Repeat("x", i)).Set(1)
+	}
+	r.MustRegister(g)
+	families, err := r.Gather()
+	require.NoError(t, err)
+	p, err := newStatsDPusher(conn.LocalAddr().String(), nil)
+	require.NoError(t, err)
+	defer p.close()
+	require.NoError(t, p.push(context.Background(), families, time.Now()))
+	buf := make([]byte, 65536)
+	lines := 0
+	for lines < 100 {
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, n, statsDMaxPacketSize)
+		lines += len(strings.Split(string(buf[:n]), "\n"))
+	}
+	assert.Equal(t, 100, lines)
+}
+
+func TestExporter_OTLP(t *testing.T) {
+	received := make(chan otlpRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		var req otlpRequest
+		assert.NoError(t, json.Unmarshal(body, &req))
+		received <- req
+	}))
+	defer server.Close()
+	e, err := NewExporter(dfv1.MetricsExport{
+		Protocol: dfv1.MetricsExportProtocolOTLP,
+		Endpoint: server.URL + "/v1/metrics",
+		Interval: &metav1.Duration{Duration: 100 * time.Millisecond},
+	}, WithGatherer(testRegistry()), WithAttributes(map[string]string{"pipeline": "pl"}))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+
+	var req otlpRequest
+	select {
+	case req = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no metrics received")
+	}
+	require.Len(t, req.ResourceMetrics, 1)
+	assert.Equal(t, []otlpKeyValue{{Key: "pipeline", Value: otlpAnyValue{StringValue: "pl"}}}, req.ResourceMetrics[0].Resource.Attributes)
+	metrics := make(map[string]otlpMetric)
+	for _, m := range req.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	counter := metrics["test_total"]
+	require.NotNil(t, counter.Sum)
+	assert.True(t, counter.Sum.IsMonotonic)
+	assert.Equal(t, otlpAggregationTemporalityCumulative, counter.Sum.AggregationTemporality)
+	assert.Equal(t, 3.0, counter.Sum.DataPoints[0].AsDouble)
+	assert.Equal(t, "vertex", counter.Sum.DataPoints[0].Attributes[0].Key)
+	require.NotNil(t, metrics["test_gauge"].Gauge)
+	assert.Equal(t, 1.5, metrics["test_gauge"].Gauge.DataPoints[0].AsDouble)
+	histogram := metrics["test_seconds"].Histogram
+	require.NotNil(t, histogram)
+	assert.Equal(t, uint64(3), histogram.DataPoints[0].Count)
+	assert.Equal(t, []float64{1, 5}, histogram.DataPoints[0].ExplicitBounds)
+	assert.Equal(t, []string{"1", "1", "1"}, histogram.DataPoints[0].BucketCounts)
+}
+
+func TestOTLPPusher_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad metrics"))
+	}))
+	defer server.Close()
+	families, err := testRegistry().Gather()
+	require.NoError(t, err)
+	err = newOTLPPusher(server.URL, &http.Client{}, nil).push(context.Background(), families, time.Now())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status code 400: bad metrics")
+}
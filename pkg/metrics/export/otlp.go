@@ -0,0 +1,250 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// The messages of OTLP in the JSON encoding, see https://github.com/open-telemetry/opentelemetry-proto.
+// The 64-bit integers are encoded as strings, as required by the protobuf JSON mapping.
+type (
+	otlpRequest struct {
+		ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+	}
+	otlpResourceMetrics struct {
+		Resource     otlpResource       `json:"resource"`
+		ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes,omitempty"`
+	}
+	otlpScopeMetrics struct {
+		Scope   otlpScope    `json:"scope"`
+		Metrics []otlpMetric `json:"metrics"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpMetric struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description,omitempty"`
+		Gauge       *otlpGauge     `json:"gauge,omitempty"`
+		Sum         *otlpSum       `json:"sum,omitempty"`
+		Histogram   *otlpHistogram `json:"histogram,omitempty"`
+		Summary     *otlpSummary   `json:"summary,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+	otlpAnyValue struct {
+		StringValue string `json:"stringValue"`
+	}
+	otlpGauge struct {
+		DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+	}
+	otlpSum struct {
+		DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+		AggregationTemporality int                   `json:"aggregationTemporality"`
+		IsMonotonic            bool                  `json:"isMonotonic"`
+	}
+	otlpNumberDataPoint struct {
+		Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+		TimeUnixNano uint64         `json:"timeUnixNano,string"`
+		AsDouble     float64        `json:"asDouble"`
+	}
+	otlpHistogram struct {
+		DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+		AggregationTemporality int                      `json:"aggregationTemporality"`
+	}
+	otlpHistogramDataPoint struct {
+		Attributes     []otlpKeyValue `json:"attributes,omitempty"`
+		TimeUnixNano   uint64         `json:"timeUnixNano,string"`
+		Count          uint64         `json:"count,string"`
+		Sum            float64        `json:"sum"`
+		BucketCounts   []string       `json:"bucketCounts"`
+		ExplicitBounds []float64      `json:"explicitBounds"`
+	}
+	otlpSummary struct {
+		DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+	}
+	otlpSummaryDataPoint struct {
+		Attributes     []otlpKeyValue      `json:"attributes,omitempty"`
+		TimeUnixNano   uint64              `json:"timeUnixNano,string"`
+		Count          uint64              `json:"count,string"`
+		Sum            float64             `json:"sum"`
+		QuantileValues []otlpQuantileValue `json:"quantileValues,omitempty"`
+	}
+	otlpQuantileValue struct {
+		Quantile float64 `json:"quantile"`
+		Value    float64 `json:"value"`
+	}
+)
+
+// otlpAggregationTemporalityCumulative is AGGREGATION_TEMPORALITY_CUMULATIVE, the Prometheus counters and histograms
+// are cumulative.
+const otlpAggregationTemporalityCumulative = 2
+
+// otlpPusher posts the metrics to an OTLP/HTTP endpoint in the JSON encoding.
+type otlpPusher struct {
+	endpoint   string
+	client     *http.Client
+	attributes map[string]string
+}
+
+func newOTLPPusher(endpoint string, client *http.Client, attributes map[string]string) *otlpPusher {
+	return &otlpPusher{endpoint: endpoint, client: client, attributes: attributes}
+}
+
+func (o *otlpPusher) push(ctx context.Context, families []*dto.MetricFamily, now time.Time) error {
+	body, err := json.Marshal(o.request(families, now))
+	if err != nil {
+		return fmt.Errorf("failed to marshal the OTLP metrics, %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the OTLP metrics, %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to post the OTLP metrics, status code %d: %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
+
+func (o *otlpPusher) request(families []*dto.MetricFamily, now time.Time) *otlpRequest {
+	ts := uint64(now.UnixNano())
+	metrics := make([]otlpMetric, 0, len(families))
+	for _, f := range families {
+		metric := otlpMetric{Name: f.GetName(), Description: f.GetHelp()}
+		switch f.GetType() {
+		case dto.MetricType_COUNTER:
+			metric.Sum = &otlpSum{AggregationTemporality: otlpAggregationTemporalityCumulative, IsMonotonic: true}
+			for _, m := range f.GetMetric() {
+				if p, ok := numberDataPoint(m, m.GetCounter().GetValue(), ts); ok {
+					metric.Sum.DataPoints = append(metric.Sum.DataPoints, p)
+				}
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			metric.Gauge = &otlpGauge{}
+			for _, m := range f.GetMetric() {
+				v := m.GetGauge().GetValue()
+				if f.GetType() == dto.MetricType_UNTYPED {
+					v = m.GetUntyped().GetValue()
+				}
+				if p, ok := numberDataPoint(m, v, ts); ok {
+					metric.Gauge.DataPoints = append(metric.Gauge.DataPoints, p)
+				}
+			}
+		case dto.MetricType_HISTOGRAM:
+			metric.Histogram = &otlpHistogram{AggregationTemporality: otlpAggregationTemporalityCumulative}
+			for _, m := range f.GetMetric() {
+				metric.Histogram.DataPoints = append(metric.Histogram.DataPoints, histogramDataPoint(m, ts))
+			}
+		case dto.MetricType_SUMMARY:
+			metric.Summary = &otlpSummary{}
+			for _, m := range f.GetMetric() {
+				metric.Summary.DataPoints = append(metric.Summary.DataPoints, summaryDataPoint(m, ts))
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return &otlpRequest{
+		ResourceMetrics: []otlpResourceMetrics{{
+			Resource:     otlpResource{Attributes: keyValues(o.attributes)},
+			ScopeMetrics: []otlpScopeMetrics{{Scope: otlpScope{Name: "numaflow"}, Metrics: metrics}},
+		}},
+	}
+}
+
+// numberDataPoint returns the data point of a number, the NaN and infinite values can not be encoded in JSON.
+func numberDataPoint(m *dto.Metric, value float64, ts uint64) (otlpNumberDataPoint, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return otlpNumberDataPoint{}, false
+	}
+	return otlpNumberDataPoint{Attributes: keyValues(labels(nil, m)), TimeUnixNano: ts, AsDouble: value}, true
+}
+
+// histogramDataPoint converts the cumulative bucket counts of Prometheus to the bucket counts of OTLP, which has one
+// more bucket than the explicit bounds for the values above the last bound.
+func histogramDataPoint(m *dto.Metric, ts uint64) otlpHistogramDataPoint {
+	h := m.GetHistogram()
+	p := otlpHistogramDataPoint{
+		Attributes:     keyValues(labels(nil, m)),
+		TimeUnixNano:   ts,
+		Count:          h.GetSampleCount(),
+		Sum:            finite(h.GetSampleSum()),
+		BucketCounts:   []string{},
+		ExplicitBounds: []float64{},
+	}
+	var previous uint64
+	for _, b := range h.GetBucket() {
+		if math.IsInf(b.GetUpperBound(), +1) {
+			break
+		}
+		p.ExplicitBounds = append(p.ExplicitBounds, b.GetUpperBound())
+		p.BucketCounts = append(p.BucketCounts, fmt.Sprint(b.GetCumulativeCount()-previous))
+		previous = b.GetCumulativeCount()
+	}
+	p.BucketCounts = append(p.BucketCounts, fmt.Sprint(h.GetSampleCount()-previous))
+	return p
+}
+
+func summaryDataPoint(m *dto.Metric, ts uint64) otlpSummaryDataPoint {
+	s := m.GetSummary()
+	p := otlpSummaryDataPoint{
+		Attributes:   keyValues(labels(nil, m)),
+		TimeUnixNano: ts,
+		Count:        s.GetSampleCount(),
+		Sum:          finite(s.GetSampleSum()),
+	}
+	for _, q := range s.GetQuantile() {
+		if math.IsNaN(q.GetValue()) || math.IsInf(q.GetValue(), 0) {
+			continue
+		}
+		p.QuantileValues = append(p.QuantileValues, otlpQuantileValue{Quantile: q.GetQuantile(), Value: q.GetValue()})
+	}
+	return p
+}
+
+func keyValues(m map[string]string) []otlpKeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: m[k]}})
+	}
+	return result
+}
+
+func finite(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
+
+func (o *otlpPusher) close() error {
+	return nil
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// statsDMaxPacketSize keeps the UDP packets within the common MTU.
+const statsDMaxPacketSize = 1432
+
+var statsDTagReplacer = strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_")
+
+// statsDPusher sends all the metrics as StatsD gauges, with the labels as DogStatsD tags. The counters are sent with
+// their cumulative values, as the gauges. The histograms and summaries are sent as the "_sum" and "_count" gauges,
+// together with the "_bucket" gauges tagged with "le" or the gauges tagged with "quantile".
+type statsDPusher struct {
+	conn       net.Conn
+	attributes map[string]string
+}
+
+func newStatsDPusher(endpoint string, attributes map[string]string) (*statsDPusher, error) {
+	conn, err := net.Dial("udp", endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial the StatsD endpoint %q, %w", endpoint, err)
+	}
+	return &statsDPusher{conn: conn, attributes: attributes}, nil
+}
+
+func (s *statsDPusher) push(ctx context.Context, families []*dto.MetricFamily, _ time.Time) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.conn.SetWriteDeadline(deadline)
+	}
+	var packet bytes.Buffer
+	flush := func() error {
+		if packet.Len() == 0 {
+			return nil
+		}
+		defer packet.Reset()
+		if _, err := s.conn.Write(packet.Bytes()); err != nil {
+			return fmt.Errorf("failed to send the StatsD metrics, %w", err)
+		}
+		return nil
+	}
+	for _, f := range families {
+		for _, line := range s.lines(f) {
+			if packet.Len() > 0 && packet.Len()+1+len(line) > statsDMaxPacketSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			if packet.Len() > 0 {
+				packet.WriteByte('\n')
+			}
+			packet.WriteString(line)
+		}
+	}
+	return flush()
+}
+
+// lines returns the StatsD lines of a metric family.
+func (s *statsDPusher) lines(f *dto.MetricFamily) []string {
+	var result []string
+	add := func(name string, value float64, tags map[string]string) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return
+		}
+		result = append(result, statsDLine(name, value, tags))
+	}
+	name := f.GetName()
+	for _, m := range f.GetMetric() {
+		tags := labels(s.attributes, m)
+		switch f.GetType() {
+		case dto.MetricType_COUNTER:
+			add(name, m.GetCounter().GetValue(), tags)
+		case dto.MetricType_GAUGE:
+			add(name, m.GetGauge().GetValue(), tags)
+		case dto.MetricType_UNTYPED:
+			add(name, m.GetUntyped().GetValue(), tags)
+		case dto.MetricType_HISTOGRAM:
+			h := m.GetHistogram()
+			add(name+"_sum", h.GetSampleSum(), tags)
+			add(name+"_count", float64(h.GetSampleCount()), tags)
+			for _, b := range h.GetBucket() {
+				add(name+"_bucket", float64(b.GetCumulativeCount()), withTag(tags, "le", strconv.FormatFloat(b.GetUpperBound(), 'g', -1, 64)))
+			}
+		case dto.MetricType_SUMMARY:
+			sm := m.GetSummary()
+			add(name+"_sum", sm.GetSampleSum(), tags)
+			add(name+"_count", float64(sm.GetSampleCount()), tags)
+			for _, q := range sm.GetQuantile() {
+				add(name, q.GetValue(), withTag(tags, "quantile", strconv.FormatFloat(q.GetQuantile(), 'g', -1, 64)))
+			}
+		}
+	}
+	return result
+}
+
+func statsDLine(name string, value float64, tags map[string]string) string {
+	line := name + ":" + strconv.FormatFloat(value, 'f', -1, 64) + "|g"
+	if len(tags) == 0 {
+		return line
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, statsDTagReplacer.Replace(k)+":"+statsDTagReplacer.Replace(tags[k]))
+	}
+	return line + "|#" + strings.Join(pairs, ",")
+}
+
+func withTag(tags map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+func (s *statsDPusher) close() error {
+	return s.conn.Close()
+}
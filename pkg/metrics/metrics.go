@@ -11,25 +11,81 @@ import (
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	sharedtls "github.com/numaproj/numaflow/pkg/shared/tls"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 )
 
-func StartMetricsServer(ctx context.Context) (func(ctx context.Context) error, error) {
+type options struct {
+	// ready is checked by the readiness probe, the pod is considered ready if it is nil.
+	ready func() bool
+	// live is checked by the liveness probe, the pod is considered live if it is nil or returns nil.
+	live func() error
+	// handlers are the additional handlers served by the server, keyed by the patterns
+	handlers map[string]http.Handler
+}
+
+type Option func(*options)
+
+// WithReadiness sets the function checked by the readiness probe.
+func WithReadiness(ready func() bool) Option {
+	return func(o *options) {
+		o.ready = ready
+	}
+}
+
+// WithLiveness sets the function checked by the liveness probe, e.g. checking the forwarder is not stuck.
+func WithLiveness(live func() error) Option {
+	return func(o *options) {
+		o.live = live
+	}
+}
+
+// WithHandler serves the requests matching the pattern with the handler, e.g. the traces of the edges.
+func WithHandler(pattern string, handler http.Handler) Option {
+	return func(o *options) {
+		if o.handlers == nil {
+			o.handlers = make(map[string]http.Handler)
+		}
+		o.handlers[pattern] = handler
+	}
+}
+
+func StartMetricsServer(ctx context.Context, opts ...Option) (func(ctx context.Context) error, error) {
 	log := logging.FromContext(ctx)
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
 	log.Info("generating self-signed certificate")
 	cer, err := sharedtls.GenerateX509KeyPair()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate cert: %w", err)
 	}
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
+	// the exemplars are only exposed in the OpenMetrics format, which is negotiated with the scrapers supporting it
+	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})))
 	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if o.ready != nil && !o.ready() {
+			w.WriteHeader(503)
+			return
+		}
 		w.WriteHeader(204)
 	})
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc(dfv1.VertexLivenessPath, func(w http.ResponseWriter, r *http.Request) {
+		if o.live != nil {
+			if err := o.live(); err != nil {
+				log.Warnw("Liveness check failed", zap.Error(err))
+				w.WriteHeader(503)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+		}
 		w.WriteHeader(204)
 	})
+	for pattern, handler := range o.handlers {
+		mux.Handle(pattern, handler)
+	}
 	debugEnabled := os.Getenv(dfv1.EnvDebug)
 	if debugEnabled == "true" {
 		mux.HandleFunc("/debug/pprof/", pprof.Index)
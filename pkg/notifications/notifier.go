@@ -0,0 +1,161 @@
+// Package notifications sends the health events of the pipelines, i.e. the phase transitions and the SLA violations,
+// to the targets configured under spec.notifications, e.g. the webhooks, Slack or Opsgenie.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/httpclient"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+// Event is a health event of a pipeline, which is the data the payload templates are executed with.
+type Event struct {
+	Type      dfv1.NotificationEventType `json:"type"`
+	Namespace string                     `json:"namespace"`
+	Pipeline  string                     `json:"pipeline"`
+	Time      time.Time                  `json:"time"`
+	// Message describes the event, e.g. why the phase changed
+	Message string `json:"message"`
+	// Phase and PreviousPhase are set for the PhaseChanged events
+	Phase         dfv1.PipelinePhase `json:"phase,omitempty"`
+	PreviousPhase dfv1.PipelinePhase `json:"previousPhase,omitempty"`
+	// Objective, Value and Threshold are set for the SLA events, e.g. "maxPendingMessages", "12000" and "10000"
+	Objective string `json:"objective,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Threshold string `json:"threshold,omitempty"`
+}
+
+// alias identifies the incident of the event, a violation and its recovery have the same alias.
+func (e Event) alias() string {
+	if e.Objective != "" {
+		return fmt.Sprintf("numaflow/%s/%s/sla/%s", e.Namespace, e.Pipeline, e.Objective)
+	}
+	return fmt.Sprintf("numaflow/%s/%s/phase", e.Namespace, e.Pipeline)
+}
+
+// resolves tells if the event ends the incident, i.e. the SLA recovers or the pipeline is running again.
+func (e Event) resolves() bool {
+	return e.Type == dfv1.NotificationEventSLARecovered || (e.Type == dfv1.NotificationEventPhaseChanged && e.Phase == dfv1.PipelinePhaseRunning)
+}
+
+// SecretGetter returns the value of a secret key.
+type SecretGetter func(ctx context.Context, selector *corev1.SecretKeySelector) (string, error)
+
+type options struct {
+	httpClient   *http.Client
+	secretGetter SecretGetter
+}
+
+type Option func(*options)
+
+// WithHTTPClient sets the HTTP client sending the events.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = c
+	}
+}
+
+// WithSecretGetter sets how the secrets of the targets are read, which defaults to reading them from the volumes
+// mounted by the controller.
+func WithSecretGetter(g SecretGetter) Option {
+	return func(o *options) {
+		o.secretGetter = g
+	}
+}
+
+// sender sends the payload rendered from an event to a target.
+type sender interface {
+	send(ctx context.Context, e Event, payload []byte) error
+}
+
+type target struct {
+	name   string
+	spec   dfv1.NotificationTarget
+	tmpl   *template.Template
+	sender sender
+}
+
+// Notifier sends the events to the targets subscribing to them.
+type Notifier struct {
+	targets []target
+}
+
+func NewNotifier(spec dfv1.PipelineNotifications, opts ...Option) (*Notifier, error) {
+	o := &options{
+		httpClient: httpclient.NewClient(10 * time.Second),
+		secretGetter: func(_ context.Context, selector *corev1.SecretKeySelector) (string, error) {
+			return sharedutil.GetSecretFromVolume(selector)
+		},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	n := &Notifier{}
+	for _, t := range spec.Targets {
+		tmpl, err := ParseTemplate(t.Name, t.Template)
+		if err != nil {
+			return nil, err
+		}
+		var s sender
+		switch {
+		case t.Webhook != nil:
+			s = &webhookSender{spec: *t.Webhook, client: o.httpClient, secrets: o.secretGetter}
+		case t.Slack != nil:
+			s = &slackSender{spec: *t.Slack, client: o.httpClient, secrets: o.secretGetter}
+		case t.Opsgenie != nil:
+			s = &opsgenieSender{spec: *t.Opsgenie, client: o.httpClient, secrets: o.secretGetter}
+		default:
+			return nil, fmt.Errorf("notification target %q has no webhook, slack or opsgenie", t.Name)
+		}
+		n.targets = append(n.targets, target{name: t.Name, spec: t, tmpl: tmpl, sender: s})
+	}
+	return n, nil
+}
+
+// ParseTemplate parses the payload template of a target, it returns nil if the template is empty.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template of notification target %q, %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// Notify sends the event to all the targets subscribing to it, a target failing doesn't stop the others.
+func (n *Notifier) Notify(ctx context.Context, e Event) error {
+	var errs []string
+	for _, t := range n.targets {
+		if !t.spec.Subscribes(e.Type) {
+			continue
+		}
+		var payload []byte
+		if t.tmpl != nil {
+			var buf bytes.Buffer
+			if err := t.tmpl.Execute(&buf, e); err != nil {
+				errs = append(errs, fmt.Sprintf("failed to execute the template of target %q, %v", t.name, err))
+				continue
+			}
+			payload = buf.Bytes()
+		}
+		if err := t.sender.send(ctx, e, payload); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to notify target %q, %v", t.name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+type request struct {
+	path    string
+	query   string
+	headers http.Header
+	body    string
+}
+
+func testServer(t *testing.T, status int) (*httptest.Server, chan request) {
+	t.Helper()
+	requests := make(chan request, 10)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{path: r.URL.EscapedPath(), query: r.URL.RawQuery, headers: r.Header, body: string(body)}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("oops"))
+	}))
+	t.Cleanup(s.Close)
+	return s, requests
+}
+
+func testSecrets(values map[string]string) Option {
+	return WithSecretGetter(func(_ context.Context, selector *corev1.SecretKeySelector) (string, error) {
+		v, ok := values[selector.Name+"/"+selector.Key]
+		if !ok {
+			return "", fmt.Errorf("secret %s/%s not found", selector.Name, selector.Key)
+		}
+		return v, nil
+	})
+}
+
+func secretSelector(name, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+}
+
+var testEvent = Event{
+	Type:          dfv1.NotificationEventPhaseChanged,
+	Namespace:     "ns",
+	Pipeline:      "pl",
+	Time:          time.Unix(1660000000, 0).UTC(),
+	Message:       "Pipeline ns/pl phase changed from Running to Failed",
+	Phase:         dfv1.PipelinePhaseFailed,
+	PreviousPhase: dfv1.PipelinePhaseRunning,
+}
+
+func TestNewNotifier(t *testing.T) {
+	_, err := NewNotifier(dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{{Name: "t"}}})
+	assert.Error(t, err)
+	_, err = NewNotifier(dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{{Name: "t", Template: "{{.Pipeline", Webhook: &dfv1.WebhookNotification{URL: "http://a"}}}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `"t"`)
+	n, err := NewNotifier(dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{{Name: "t", Webhook: &dfv1.WebhookNotification{URL: "http://a"}}}})
+	assert.NoError(t, err)
+	assert.Len(t, n.targets, 1)
+}
+
+func TestNotify_Webhook(t *testing.T) {
+	s, requests := testServer(t, http.StatusOK)
+	n, err := NewNotifier(dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{
+		{Name: "json", Webhook: &dfv1.WebhookNotification{URL: s.URL + "/json", Authorization: secretSelector("s", "token")}},
+		{Name: "text", Template: "{{.Pipeline}} is {{.Phase}}", Webhook: &dfv1.WebhookNotification{URL: s.URL + "/text", ContentType: "text/plain"}},
+		{Name: "sla", Events: []dfv1.NotificationEventType{dfv1.NotificationEventSLAViolated}, Webhook: &dfv1.WebhookNotification{URL: s.URL + "/sla"}},
+	}}, testSecrets(map[string]string{"s/token": "Bearer abc"}))
+	require.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testEvent))
+	close(requests)
+	var got []request
+	for r := range requests {
+		got = append(got, r)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, "/json", got[0].path)
+	assert.Equal(t, "application/json", got[0].headers.Get("Content-Type"))
+	assert.Equal(t, "Bearer abc", got[0].headers.Get("Authorization"))
+	e := Event{}
+	require.NoError(t, json.Unmarshal([]byte(got[0].body), &e))
+	assert.Equal(t, testEvent, e)
+	assert.Equal(t, "/text", got[1].path)
+	assert.Equal(t, "text/plain", got[1].headers.Get("Content-Type"))
+	assert.Equal(t, "pl is Failed", got[1].body)
+}
+
+func TestNotify_Slack(t *testing.T) {
+	s, requests := testServer(t, http.StatusOK)
+	n, err := NewNotifier(dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{
+		{Name: "slack", Slack: &dfv1.SlackNotification{URL: secretSelector("s", "url")}},
+	}}, testSecrets(map[string]string{"s/url": s.URL + "/services/abc"}))
+	require.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testEvent))
+	r := <-requests
+	assert.Equal(t, "/services/abc", r.path)
+	assert.JSONEq(t, `{"text":"Pipeline ns/pl phase changed from Running to Failed"}`, r.body)
+}
+
+func TestNotify_Opsgenie(t *testing.T) {
+	s, requests := testServer(t, http.StatusAccepted)
+	n, err := NewNotifier(dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{
+		{Name: "opsgenie", Opsgenie: &dfv1.OpsgenieNotification{APIKey: secretSelector("s", "key"), URL: s.URL, Priority: "P2"}},
+	}}, testSecrets(map[string]string{"s/key": "abc"}))
+	require.NoError(t, err)
+
+	assert.NoError(t, n.Notify(context.Background(), testEvent))
+	r := <-requests
+	assert.Equal(t, "/v2/alerts", r.path)
+	assert.Equal(t, "GenieKey abc", r.headers.Get("Authorization"))
+	alert := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(r.body), &alert))
+	assert.Equal(t, testEvent.Message, alert["message"])
+	assert.Equal(t, "numaflow/ns/pl/phase", alert["alias"])
+	assert.Equal(t, "P2", alert["priority"])
+
+	running := testEvent
+	running.Phase = dfv1.PipelinePhaseRunning
+	running.PreviousPhase = dfv1.PipelinePhaseFailed
+	assert.NoError(t, n.Notify(context.Background(), running))
+	r = <-requests
+	assert.Equal(t, "/v2/alerts/numaflow%2Fns%2Fpl%2Fphase/close", r.path)
+	assert.Equal(t, "identifierType=alias", r.query)
+}
+
+func TestNotify_Errors(t *testing.T) {
+	s, requests := testServer(t, http.StatusInternalServerError)
+	n, err := NewNotifier(dfv1.PipelineNotifications{Targets: []dfv1.NotificationTarget{
+		{Name: "failing", Webhook: &dfv1.WebhookNotification{URL: s.URL}},
+		{Name: "no-secret", Slack: &dfv1.SlackNotification{URL: secretSelector("s", "url")}},
+		{Name: "bad-template", Template: "{{.Unknown}}", Webhook: &dfv1.WebhookNotification{URL: s.URL}},
+		{Name: "working", Template: "ok", Webhook: &dfv1.WebhookNotification{URL: s.URL}},
+	}}, testSecrets(nil))
+	require.NoError(t, err)
+	err = n.Notify(context.Background(), testEvent)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `target "failing", status code 500: oops`)
+	assert.Contains(t, err.Error(), `target "no-secret"`)
+	assert.Contains(t, err.Error(), `target "bad-template"`)
+	// the failures don't stop the other targets
+	assert.Len(t, requests, 2)
+}
+
+func TestEvent_alias(t *testing.T) {
+	assert.Equal(t, "numaflow/ns/pl/phase", testEvent.alias())
+	e := Event{Type: dfv1.NotificationEventSLAViolated, Namespace: "ns", Pipeline: "pl", Objective: "maxPendingMessages"}
+	assert.Equal(t, "numaflow/ns/pl/sla/maxPendingMessages", e.alias())
+	assert.False(t, e.resolves())
+	e.Type = dfv1.NotificationEventSLARecovered
+	assert.True(t, e.resolves())
+}
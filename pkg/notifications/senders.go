@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// opsgenieMessageLimit is the max length of the message of an Opsgenie alert
+const opsgenieMessageLimit = 130
+
+// webhookSender posts the payload, which defaults to the JSON of the event.
+type webhookSender struct {
+	spec    dfv1.WebhookNotification
+	client  *http.Client
+	secrets SecretGetter
+}
+
+func (s *webhookSender) send(ctx context.Context, e Event, payload []byte) error {
+	if payload == nil {
+		var err error
+		if payload, err = json.Marshal(e); err != nil {
+			return err
+		}
+	}
+	headers := map[string]string{"Content-Type": "application/json"}
+	if s.spec.ContentType != "" {
+		headers["Content-Type"] = s.spec.ContentType
+	}
+	if s.spec.Authorization != nil {
+		auth, err := s.secrets(ctx, s.spec.Authorization)
+		if err != nil {
+			return err
+		}
+		headers["Authorization"] = auth
+	}
+	return post(ctx, s.client, s.spec.URL, headers, payload)
+}
+
+// slackSender posts the payload as the text of a message, which defaults to the message of the event.
+type slackSender struct {
+	spec    dfv1.SlackNotification
+	client  *http.Client
+	secrets SecretGetter
+}
+
+func (s *slackSender) send(ctx context.Context, e Event, payload []byte) error {
+	text := e.Message
+	if payload != nil {
+		text = string(payload)
+	}
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	webhookURL, err := s.secrets(ctx, s.spec.URL)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, webhookURL, map[string]string{"Content-Type": "application/json"}, body)
+}
+
+// opsgenieSender creates an alert for the event, or closes the alert of the incident the event ends. The payload is
+// the description of the alert, which defaults to the message of the event.
+type opsgenieSender struct {
+	spec    dfv1.OpsgenieNotification
+	client  *http.Client
+	secrets SecretGetter
+}
+
+func (s *opsgenieSender) send(ctx context.Context, e Event, payload []byte) error {
+	description := e.Message
+	if payload != nil {
+		description = string(payload)
+	}
+	apiKey, err := s.secrets(ctx, s.spec.APIKey)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{"Content-Type": "application/json", "Authorization": "GenieKey " + apiKey}
+	alertsURL := strings.TrimSuffix(s.spec.GetURL(), "/") + "/v2/alerts"
+	if e.resolves() {
+		body, err := json.Marshal(map[string]string{"source": "numaflow", "note": description})
+		if err != nil {
+			return err
+		}
+		return post(ctx, s.client, alertsURL+"/"+url.PathEscape(e.alias())+"/close?identifierType=alias", headers, body)
+	}
+	message := e.Message
+	if len(message) > opsgenieMessageLimit {
+		message = message[:opsgenieMessageLimit-3] + "..."
+	}
+	alert := map[string]interface{}{
+		"message":     message,
+		"alias":       e.alias(),
+		"description": description,
+		"source":      "numaflow",
+		"tags":        []string{"numaflow", string(e.Type)},
+		"details":     map[string]string{"namespace": e.Namespace, "pipeline": e.Pipeline},
+	}
+	if s.spec.Priority != "" {
+		alert["priority"] = s.spec.Priority
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, alertsURL, headers, body)
+}
+
+func post(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("status code %d: %s", resp.StatusCode, string(msg))
+	}
+	return nil
+}
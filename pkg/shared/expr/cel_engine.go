@@ -0,0 +1,147 @@
+package expr
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// celEngine compiles the expressions of the Common Expression Language.
+type celEngine struct {
+	env *cel.Env
+}
+
+func newCELEngine() (*celEngine, error) {
+	env, err := cel.NewEnv(cel.Declarations(
+		decls.NewVar(root, decls.String),
+		decls.NewVar(keyVar, decls.String),
+		decls.NewFunction("json", decls.NewOverload("json_string", []*exprpb.Type{decls.String}, decls.Dyn)),
+		decls.NewFunction("toJSON", decls.NewOverload("toJSON_dyn", []*exprpb.Type{decls.Dyn}, decls.String)),
+		decls.NewFunction("now", decls.NewOverload("now", []*exprpb.Type{}, decls.Timestamp)),
+		decls.NewFunction("parseTime", decls.NewOverload("parseTime_string", []*exprpb.Type{decls.String}, decls.Timestamp)),
+		decls.NewFunction("unixMilli", decls.NewOverload("unixMilli_timestamp", []*exprpb.Type{decls.Timestamp}, decls.Int)),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the CEL environment, %w", err)
+	}
+	return &celEngine{env: env}, nil
+}
+
+// celFunctions are the implementations of the helper functions, keyed by both the function names and the overload
+// IDs, which are used by the interpreter to dispatch the calls.
+var celFunctions = func() []*functions.Overload {
+	jsonFn := func(v ref.Val) ref.Val {
+		s, ok := v.(types.String)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(v)
+		}
+		var x interface{}
+		if err := json.Unmarshal([]byte(s), &x); err != nil {
+			return types.NewErr("cannot convert %q to object: %v", string(s), err)
+		}
+		return types.DefaultTypeAdapter.NativeToValue(x)
+	}
+	toJSONFn := func(v ref.Val) ref.Val {
+		x, err := celToNative(v)
+		if err != nil {
+			return types.NewErr("cannot convert %v to JSON: %v", v, err)
+		}
+		b, err := json.Marshal(x)
+		if err != nil {
+			return types.NewErr("cannot convert %v to JSON: %v", v, err)
+		}
+		return types.String(b)
+	}
+	nowFn := func(...ref.Val) ref.Val {
+		return types.Timestamp{Time: time.Now()}
+	}
+	parseTimeFn := func(v ref.Val) ref.Val {
+		s, ok := v.(types.String)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(v)
+		}
+		t, err := time.Parse(time.RFC3339Nano, string(s))
+		if err != nil {
+			return types.NewErr("cannot parse %q as an RFC3339 time: %v", string(s), err)
+		}
+		return types.Timestamp{Time: t}
+	}
+	unixMilliFn := func(v ref.Val) ref.Val {
+		t, ok := v.(types.Timestamp)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(v)
+		}
+		return types.Int(t.Time.UnixMilli())
+	}
+	return []*functions.Overload{
+		{Operator: "json", Unary: jsonFn},
+		{Operator: "json_string", Unary: jsonFn},
+		{Operator: "toJSON", Unary: toJSONFn},
+		{Operator: "toJSON_dyn", Unary: toJSONFn},
+		{Operator: "now", Function: nowFn},
+		{Operator: "parseTime", Unary: parseTimeFn},
+		{Operator: "parseTime_string", Unary: parseTimeFn},
+		{Operator: "unixMilli", Unary: unixMilliFn},
+		{Operator: "unixMilli_timestamp", Unary: unixMilliFn},
+	}
+}()
+
+func (e *celEngine) Compile(expression string) (Program, error) {
+	if err := checkLength(expression); err != nil {
+		return nil, err
+	}
+	ast, issues := e.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("unable to compile expression '%s': %s", expression, issues.Err())
+	}
+	p, err := e.env.Program(ast, cel.Functions(celFunctions...))
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile expression '%s': %s", expression, err)
+	}
+	return &celProgram{expression: expression, program: p}, nil
+}
+
+type celProgram struct {
+	expression string
+	program    cel.Program
+}
+
+func (p *celProgram) Eval(env Env) (interface{}, error) {
+	out, _, err := p.program.Eval(map[string]interface{}{root: string(env.Payload), keyVar: string(env.Key)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate expression '%s': %s", p.expression, err)
+	}
+	result, err := celToNative(out)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert the result of expression '%s': %s", p.expression, err)
+	}
+	return result, nil
+}
+
+var structpbValueType = reflect.TypeOf(&structpb.Value{})
+
+// celToNative converts the CEL value into the Go types of JSON, the integers are kept as int.
+func celToNative(v ref.Val) (interface{}, error) {
+	switch x := v.(type) {
+	case types.Int:
+		return int(x), nil
+	case types.Uint:
+		return int(x), nil
+	case types.Timestamp:
+		return x.Time, nil
+	}
+	pb, err := v.ConvertToNative(structpbValueType)
+	if err != nil {
+		return nil, err
+	}
+	return pb.(*structpb.Value).AsInterface(), nil
+}
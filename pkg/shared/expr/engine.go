@@ -0,0 +1,86 @@
+package expr
+
+import (
+	"fmt"
+)
+
+// Language of the expressions.
+type Language string
+
+const (
+	// LanguageExpr is the expression language of github.com/antonmedv/expr, it's the default one.
+	LanguageExpr Language = "expr"
+	// LanguageCEL is the Common Expression Language, see https://github.com/google/cel-spec.
+	LanguageCEL Language = "cel"
+)
+
+// maxExpressionLength is the max length of an expression, to keep the cost of compiling and evaluating it bounded.
+const maxExpressionLength = 4096
+
+// Env is what an expression is evaluated against, the payload and the key are accessible as "payload" and "key" in
+// the expression, both as strings.
+type Env struct {
+	Key     []byte
+	Payload []byte
+}
+
+// Program is a compiled expression, it's safe for concurrent use.
+type Program interface {
+	// Eval evaluates the expression, the result is converted into the Go types of JSON, i.e. bool, float64 or int,
+	// string, []interface{}, map[string]interface{} and nil, plus time.Time.
+	Eval(env Env) (interface{}, error)
+}
+
+// Engine compiles the expressions of a language. The expressions are sandboxed, they can only access the variables of
+// the Env and the helper functions, and any other identifier is rejected by the compilation.
+//
+// Helper functions available in both languages:
+//   - json(string) parses the JSON string into an object
+//   - toJSON(value) serializes the value into a JSON string
+//   - now() returns the current time
+//   - parseTime(string) parses the RFC3339 string into a time
+//   - unixMilli(time) returns the Unix milliseconds of the time
+type Engine interface {
+	Compile(expression string) (Program, error)
+}
+
+// NewEngine returns the engine of the language, the expr language if it's empty.
+func NewEngine(lang Language) (Engine, error) {
+	switch lang {
+	case "", LanguageExpr:
+		return exprEngine{}, nil
+	case LanguageCEL:
+		return newCELEngine()
+	default:
+		return nil, fmt.Errorf("unsupported expression language %q", lang)
+	}
+}
+
+// Compile compiles the expression in the language.
+func Compile(lang Language, expression string) (Program, error) {
+	e, err := NewEngine(lang)
+	if err != nil {
+		return nil, err
+	}
+	return e.Compile(expression)
+}
+
+func checkLength(expression string) error {
+	if len(expression) > maxExpressionLength {
+		return fmt.Errorf("expression is longer than %d characters", maxExpressionLength)
+	}
+	return nil
+}
+
+// Bool evaluates the program, the result is expected to be a bool.
+func Bool(p Program, env Env) (bool, error) {
+	result, err := p.Eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("unable to cast expression result '%v' to bool", result)
+	}
+	return b, nil
+}
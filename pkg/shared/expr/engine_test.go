@@ -0,0 +1,112 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testEnv = Env{Key: []byte("k1"), Payload: []byte(`{"a": 2, "name": "numa", "ts": "2022-08-01T10:00:00Z"}`)}
+
+func TestEngines(t *testing.T) {
+	tests := []struct {
+		name   string
+		lang   Language
+		exprs  map[string]string
+		expect map[string]interface{}
+	}{
+		{
+			name: "expr",
+			lang: LanguageExpr,
+			exprs: map[string]string{
+				"bool":      `key == "k1" && json(payload).a > 1`,
+				"map":       `{"name": json(payload).name, "key": key}`,
+				"toJSON":    `toJSON({"a": json(payload).a})`,
+				"unixMilli": `unixMilli(parseTime(json(payload).ts))`,
+			},
+		},
+		{
+			name: "cel",
+			lang: LanguageCEL,
+			exprs: map[string]string{
+				"bool":      `key == "k1" && json(payload).a > 1.0`,
+				"map":       `{"name": json(payload).name, "key": key}`,
+				"toJSON":    `toJSON({"a": json(payload).a})`,
+				"unixMilli": `unixMilli(parseTime(json(payload).ts))`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEngine(tt.lang)
+			assert.NoError(t, err)
+
+			p, err := e.Compile(tt.exprs["bool"])
+			assert.NoError(t, err)
+			b, err := Bool(p, testEnv)
+			assert.NoError(t, err)
+			assert.True(t, b)
+			b, err = Bool(p, Env{Key: []byte("k2"), Payload: testEnv.Payload})
+			assert.NoError(t, err)
+			assert.False(t, b)
+
+			p, err = e.Compile(tt.exprs["map"])
+			assert.NoError(t, err)
+			result, err := p.Eval(testEnv)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"name": "numa", "key": "k1"}, result)
+
+			p, err = e.Compile(tt.exprs["toJSON"])
+			assert.NoError(t, err)
+			result, err = p.Eval(testEnv)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"a": 2}`, result.(string))
+
+			p, err = e.Compile(tt.exprs["unixMilli"])
+			assert.NoError(t, err)
+			result, err = p.Eval(testEnv)
+			assert.NoError(t, err)
+			assert.Equal(t, int(time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC).UnixMilli()), result)
+
+			p, err = e.Compile(`now()`)
+			assert.NoError(t, err)
+			result, err = p.Eval(testEnv)
+			assert.NoError(t, err)
+			assert.WithinDuration(t, time.Now(), result.(time.Time), time.Minute)
+
+			p, err = e.Compile(`payload`)
+			assert.NoError(t, err)
+			_, err = Bool(p, testEnv)
+			assert.Error(t, err)
+
+			p, err = e.Compile(`json(key)`)
+			assert.NoError(t, err)
+			_, err = p.Eval(testEnv)
+			assert.Error(t, err)
+
+			// only the variables and the helper functions are accessible
+			_, err = e.Compile(`os.Getenv("HOME")`)
+			assert.Error(t, err)
+			_, err = e.Compile(`unknown == 1`)
+			assert.Error(t, err)
+			_, err = e.Compile(strings.Repeat("a", maxExpressionLength+1))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "longer than")
+		})
+	}
+
+	t.Run("default language", func(t *testing.T) {
+		p, err := Compile("", `sprig.upper(key)`)
+		assert.NoError(t, err)
+		result, err := p.Eval(testEnv)
+		assert.NoError(t, err)
+		assert.Equal(t, "K1", result)
+	})
+
+	t.Run("unsupported language", func(t *testing.T) {
+		_, err := NewEngine("lua")
+		assert.Error(t, err)
+	})
+}
@@ -3,23 +3,49 @@ package expr
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/Masterminds/sprig/v3"
 	"github.com/antonmedv/expr"
-	"strconv"
 )
 
 var sprigFuncMap = sprig.GenericFuncMap()
 
-const root = "payload"
+const (
+	root   = "payload"
+	keyVar = "key"
+)
 
-func EvalBool(expression string, msg []byte) (bool, error) {
+// Eval evaluates the expression against the message and returns the raw result.
+func Eval(expression string, msg []byte) (interface{}, error) {
+	return EvalWithKey(expression, nil, msg)
+}
+
+// EvalWithKey evaluates the expression against the message and its key, which is accessible as "key" in the
+// expression, and returns the raw result.
+func EvalWithKey(expression string, key, msg []byte) (interface{}, error) {
 	msgMap := map[string]interface{}{
-		root: string(msg),
+		root:   string(msg),
+		keyVar: string(key),
 	}
 	env := getFuncMap(msgMap)
 	result, err := expr.Eval(expression, env)
 	if err != nil {
-		return false, fmt.Errorf("unable to evaluate expression '%s': %s", expression, err)
+		return nil, fmt.Errorf("unable to evaluate expression '%s': %s", expression, err)
+	}
+	return result, nil
+}
+
+func EvalBool(expression string, msg []byte) (bool, error) {
+	return EvalBoolWithKey(expression, nil, msg)
+}
+
+// EvalBoolWithKey evaluates the expression against the message and its key, the result is expected to be a bool.
+func EvalBoolWithKey(expression string, key, msg []byte) (bool, error) {
+	result, err := EvalWithKey(expression, key, msg)
+	if err != nil {
+		return false, err
 	}
 	resultBool, ok := result.(bool)
 	if !ok {
@@ -34,9 +60,33 @@ func getFuncMap(m map[string]interface{}) map[string]interface{} {
 	env["json"] = _json
 	env["int"] = _int
 	env["string"] = _string
+	env["toJSON"] = _toJSON
+	env["now"] = time.Now
+	env["parseTime"] = _parseTime
+	env["unixMilli"] = _unixMilli
 	return env
 }
 
+func _toJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Errorf("cannot convert %v to JSON: %v", v, err))
+	}
+	return string(b)
+}
+
+func _parseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		panic(fmt.Errorf("cannot parse %q as an RFC3339 time: %v", s, err))
+	}
+	return t
+}
+
+func _unixMilli(t time.Time) int {
+	return int(t.UnixMilli())
+}
+
 func _int(v interface{}) int {
 	switch w := v.(type) {
 	case []byte:
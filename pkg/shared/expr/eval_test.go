@@ -99,6 +99,29 @@ func Test_eval_getFuncMap(t *testing.T) {
 	assert.Contains(t, a, "sprig")
 }
 
+func Test_eval_Eval(t *testing.T) {
+	t.Run("test good", func(t *testing.T) {
+		a, err := Eval(`[int(json(payload).a), int(json(payload).b)]`, []byte(`{"a": 1, "b": 2}`))
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{1, 2}, a)
+	})
+
+	t.Run("test invalid expression", func(t *testing.T) {
+		_, err := Eval(`ab\na`, []byte(`{"a": "b"}`))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unable to evaluate expression")
+	})
+
+	t.Run("test key", func(t *testing.T) {
+		a, err := EvalWithKey(`key + "-" + json(payload).a`, []byte("k1"), []byte(`{"a": "b"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "k1-b", a)
+		a, err = Eval(`key`, []byte(`{"a": "b"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "", a)
+	})
+}
+
 func Test_eval_EvalBool(t *testing.T) {
 	t.Run("test good", func(t *testing.T) {
 		a, err := EvalBool(`json(payload).a == "b"`, []byte(`{"a": "b"}`))
@@ -123,4 +146,10 @@ func Test_eval_EvalBool(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unable to evaluate expression")
 	})
+
+	t.Run("test key", func(t *testing.T) {
+		a, err := EvalBoolWithKey(`key == "k1" && json(payload).a == "b"`, []byte("k1"), []byte(`{"a": "b"}`))
+		assert.NoError(t, err)
+		assert.True(t, a)
+	})
 }
@@ -0,0 +1,36 @@
+package expr
+
+import (
+	"fmt"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/vm"
+)
+
+// exprEngine compiles the expressions of github.com/antonmedv/expr, with the sprig functions accessible as "sprig.xxx".
+type exprEngine struct{}
+
+func (exprEngine) Compile(expression string) (Program, error) {
+	if err := checkLength(expression); err != nil {
+		return nil, err
+	}
+	// the variables are only declared for the type checking
+	p, err := expr.Compile(expression, expr.Env(getFuncMap(map[string]interface{}{root: "", keyVar: ""})))
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile expression '%s': %s", expression, err)
+	}
+	return &exprProgram{expression: expression, program: p}, nil
+}
+
+type exprProgram struct {
+	expression string
+	program    *vm.Program
+}
+
+func (p *exprProgram) Eval(env Env) (interface{}, error) {
+	result, err := expr.Run(p.program, getFuncMap(map[string]interface{}{root: string(env.Payload), keyVar: string(env.Key)}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate expression '%s': %s", p.expression, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package httpclient
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+	// next is the index of the address the next connection starts with, so that the connections are spread over them
+	next uint32
+}
+
+// dnsCache caches the resolved addresses of the hosts. An expired entry is still used if the lookup fails, so that a
+// flaky DNS server doesn't fail the calls to the hosts already known.
+type dnsCache struct {
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	ttl        time.Duration
+	now        func() time.Time
+
+	lock    sync.RWMutex
+	entries map[string]*dnsEntry
+}
+
+func newDNSCache(lookupHost func(ctx context.Context, host string) ([]string, error), ttl time.Duration) *dnsCache {
+	return &dnsCache{
+		lookupHost: lookupHost,
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    make(map[string]*dnsEntry),
+	}
+}
+
+// lookup returns the addresses of the host, rotated to start with a different one each time.
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	c.lock.RLock()
+	e := c.entries[host]
+	c.lock.RUnlock()
+	if e == nil || c.now().After(e.expires) {
+		addrs, err := c.lookupHost(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			if e == nil {
+				if err == nil {
+					err = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+				}
+				return nil, err
+			}
+		} else {
+			e = &dnsEntry{addrs: addrs, expires: c.now().Add(c.ttl)}
+			c.lock.Lock()
+			c.entries[host] = e
+			c.lock.Unlock()
+		}
+	}
+	start := int(atomic.AddUint32(&e.next, 1)-1) % len(e.addrs)
+	return append(append([]string(nil), e.addrs[start:]...), e.addrs[:start]...), nil
+}
+
+// dialContext returns the dial function resolving the hosts with the cache, the addresses are tried in turn until one
+// of them is connected.
+func (c *dnsCache) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		ips, err := c.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			var conn net.Conn
+			if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip, port)); err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
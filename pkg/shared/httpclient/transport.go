@@ -0,0 +1,137 @@
+/*
+Package httpclient provides the HTTP transports tuned for the high request rates of the vertices, which reuse the
+connections and cache the DNS lookups, so that the connections are not churned by the calls.
+*/
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxIdleConns    = 100
+	defaultIdleConnTimeout = 90 * time.Second
+	defaultDNSCacheTTL     = 30 * time.Second
+	defaultDialTimeout     = 30 * time.Second
+	defaultDialKeepAlive   = 30 * time.Second
+)
+
+type options struct {
+	// maxIdleConns is the max number of the idle connections kept in total, and for each host
+	maxIdleConns int
+	// maxConnsPerHost is the max number of the connections to a host, 0 means no limit
+	maxConnsPerHost int
+	idleConnTimeout time.Duration
+	// dnsCacheTTL is how long the resolved addresses of a host are cached, 0 disables the caching
+	dnsCacheTTL time.Duration
+	// unixSocket is the path of the Unix Domain Socket all the connections are made to, if it's set
+	unixSocket string
+	tlsConfig  *tls.Config
+}
+
+type Option func(*options)
+
+// WithMaxIdleConns sets the max number of the idle connections kept in total and for each host, defaults to 100.
+func WithMaxIdleConns(n int) Option {
+	return func(o *options) {
+		o.maxIdleConns = n
+	}
+}
+
+// WithMaxConnsPerHost sets the max number of the connections to a host, defaults to no limit.
+func WithMaxConnsPerHost(n int) Option {
+	return func(o *options) {
+		o.maxConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept, defaults to 90 seconds.
+func WithIdleConnTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.idleConnTimeout = d
+	}
+}
+
+// WithDNSCacheTTL sets how long the resolved addresses of a host are cached, defaults to 30 seconds, 0 disables the
+// caching.
+func WithDNSCacheTTL(d time.Duration) Option {
+	return func(o *options) {
+		o.dnsCacheTTL = d
+	}
+}
+
+// WithUnixSocket makes all the connections to the Unix Domain Socket, regardless of the hosts of the requests.
+func WithUnixSocket(path string) Option {
+	return func(o *options) {
+		o.unixSocket = path
+	}
+}
+
+// WithTLSConfig sets the TLS config of the HTTPS connections.
+func WithTLSConfig(c *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = c
+	}
+}
+
+// NewTransport returns a new transport, it's safe for concurrent use and is supposed to be shared by the clients.
+func NewTransport(opts ...Option) *http.Transport {
+	o := &options{
+		maxIdleConns:    defaultMaxIdleConns,
+		idleConnTimeout: defaultIdleConnTimeout,
+		dnsCacheTTL:     defaultDNSCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = o.maxIdleConns
+	// the default of 2 idle connections per host churns the connections when the requests go to a few hosts
+	t.MaxIdleConnsPerHost = o.maxIdleConns
+	t.MaxConnsPerHost = o.maxConnsPerHost
+	t.IdleConnTimeout = o.idleConnTimeout
+	if o.tlsConfig != nil {
+		t.TLSClientConfig = o.tlsConfig
+	}
+	dialer := &net.Dialer{Timeout: defaultDialTimeout, KeepAlive: defaultDialKeepAlive}
+	switch {
+	case o.unixSocket != "":
+		t.Proxy = nil
+		t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", o.unixSocket)
+		}
+	case o.dnsCacheTTL > 0:
+		t.DialContext = newDNSCache(net.DefaultResolver.LookupHost, o.dnsCacheTTL).dialContext(dialer)
+	default:
+		t.DialContext = dialer.DialContext
+	}
+	return t
+}
+
+var (
+	sharedTransport     *http.Transport
+	sharedTransportOnce sync.Once
+)
+
+// SharedTransport returns the transport with the default options shared in the process, the clients using it share
+// the connections and the DNS cache.
+func SharedTransport() *http.Transport {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = NewTransport()
+	})
+	return sharedTransport
+}
+
+// NewClient returns a client of the shared transport with the timeout, or of a new transport if there are options.
+func NewClient(timeout time.Duration, opts ...Option) *http.Client {
+	transport := SharedTransport()
+	if len(opts) > 0 {
+		transport = NewTransport(opts...)
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
@@ -0,0 +1,112 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSCache(t *testing.T) {
+	var lookups int32
+	fail := false
+	c := newDNSCache(func(_ context.Context, host string) ([]string, error) {
+		atomic.AddInt32(&lookups, 1)
+		if fail {
+			return nil, fmt.Errorf("dns server down")
+		}
+		if host == "svc" {
+			return []string{"10.0.0.1", "10.0.0.2"}, nil
+		}
+		return nil, nil
+	}, time.Minute)
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	addrs, err := c.lookup(context.Background(), "svc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, addrs)
+	// cached, and rotated
+	addrs, err = c.lookup(context.Background(), "svc")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.1"}, addrs)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lookups))
+
+	// expired, but the lookup fails
+	now = now.Add(2 * time.Minute)
+	fail = true
+	addrs, err = c.lookup(context.Background(), "svc")
+	assert.NoError(t, err)
+	assert.Len(t, addrs, 2)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&lookups))
+
+	_, err = c.lookup(context.Background(), "other")
+	assert.Error(t, err)
+	fail = false
+	_, err = c.lookup(context.Background(), "other")
+	assert.Error(t, err)
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Run("dns cache", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+		u, _ := url.Parse(server.URL)
+		_, port, _ := net.SplitHostPort(u.Host)
+
+		transport := NewTransport(WithMaxIdleConns(10))
+		assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+		var lookups int32
+		dialer := &net.Dialer{Timeout: time.Second}
+		transport.DialContext = newDNSCache(func(_ context.Context, host string) ([]string, error) {
+			atomic.AddInt32(&lookups, 1)
+			return []string{"127.0.0.1"}, nil
+		}, time.Minute).dialContext(dialer)
+		// no connection is kept, so that each request dials
+		transport.DisableKeepAlives = true
+		client := &http.Client{Transport: transport}
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(fmt.Sprintf("http://svc.local:%s/", port))
+			assert.NoError(t, err)
+			b, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			assert.Equal(t, "ok", string(b))
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&lookups))
+	})
+
+	t.Run("unix socket", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.sock")
+		l, err := net.Listen("unix", path)
+		assert.NoError(t, err)
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		})}
+		go func() { _ = server.Serve(l) }()
+		defer func() { _ = server.Close(); _ = os.Remove(path) }()
+
+		client := NewClient(time.Second, WithUnixSocket(path))
+		resp, err := client.Get("http://unix/ready")
+		assert.NoError(t, err)
+		b, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, "ok", string(b))
+	})
+
+	t.Run("shared", func(t *testing.T) {
+		assert.Same(t, SharedTransport(), NewClient(time.Second).Transport)
+		assert.NotSame(t, SharedTransport(), NewClient(time.Second, WithMaxIdleConns(1)).Transport)
+	})
+}
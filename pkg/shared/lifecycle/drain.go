@@ -0,0 +1,64 @@
+package lifecycle
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+// WatchDrainRequest returns a channel which is closed once the pod is marked to be drained by the controller before
+// scaling down. The annotations of the pod are projected by the downward API into the podinfo volume, which is polled
+// with the given interval, the kubelet refreshes the file periodically, so the drain request might take a while to be
+// noticed.
+func WatchDrainRequest(ctx context.Context, interval time.Duration) <-chan struct{} {
+	return watchDrainRequest(ctx, filepath.Join(dfv1.PathPodInfo, "annotations"), interval)
+}
+
+func watchDrainRequest(ctx context.Context, annotationsFile string, interval time.Duration) <-chan struct{} {
+	log := logging.FromContext(ctx)
+	requested := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			marked, err := hasAnnotation(annotationsFile, dfv1.KeyDrain)
+			if err != nil && !os.IsNotExist(err) {
+				log.Warnw("Failed to read pod annotations", "file", annotationsFile, "error", err)
+			}
+			if marked {
+				close(requested)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return requested
+}
+
+// hasAnnotation checks if the key is in the annotations file projected by the downward API, each line of which
+// looks like `key="value"`.
+func hasAnnotation(annotationsFile, key string) (bool, error) {
+	f, err := os.Open(annotationsFile)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), key+"=") {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,43 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestWatchDrainRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	file := filepath.Join(t.TempDir(), "annotations")
+	requested := watchDrainRequest(ctx, file, 10*time.Millisecond)
+	assert.NoError(t, os.WriteFile(file, []byte(dfv1.KeyReplica+"=\"0\"\n"), 0644))
+	select {
+	case <-requested:
+		t.Fatal("should not be requested")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.NoError(t, os.WriteFile(file, []byte(dfv1.KeyReplica+"=\"0\"\n"+dfv1.KeyDrain+"=\"2022-08-01T00:00:00Z\"\n"), 0644))
+	select {
+	case <-requested:
+	case <-time.After(time.Second):
+		t.Fatal("should be requested")
+	}
+}
+
+func TestWatchDrainRequest_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	requested := watchDrainRequest(ctx, filepath.Join(t.TempDir(), "annotations"), 10*time.Millisecond)
+	cancel()
+	select {
+	case <-requested:
+		t.Fatal("should not be requested")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,71 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+// Drainer tracks whether the main container has finished draining the in-flight messages. It serves the pre-stop hook
+// of the sidecar containers (UDF and UDSink), which blocks until the draining is done, so that the sidecars are not
+// terminated while the main container still depends on them.
+type Drainer struct {
+	drained chan struct{}
+	once    sync.Once
+}
+
+// NewDrainer returns a Drainer.
+func NewDrainer() *Drainer {
+	return &Drainer{drained: make(chan struct{})}
+}
+
+// Done marks the draining as finished, it is safe to be called multiple times.
+func (d *Drainer) Done() {
+	d.once.Do(func() { close(d.drained) })
+}
+
+// Drained returns true once the draining is finished.
+func (d *Drainer) Drained() bool {
+	select {
+	case <-d.drained:
+		return true
+	default:
+		return false
+	}
+}
+
+// ServeHTTP blocks until the draining is finished or the request is canceled.
+func (d *Drainer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	select {
+	case <-d.drained:
+		w.WriteHeader(http.StatusNoContent)
+	case <-r.Context().Done():
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+// StartPreStopServer starts the plain HTTP server serving the pre-stop hook of the sidecar containers. It is not served
+// by the metrics server, because the kubelet does not respect the HTTPS scheme of the lifecycle hooks in some versions.
+func StartPreStopServer(ctx context.Context, d *Drainer) (func(ctx context.Context) error, error) {
+	log := logging.FromContext(ctx)
+	mux := http.NewServeMux()
+	mux.Handle(dfv1.VertexPreStopPath, d)
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", dfv1.VertexPreStopPort),
+		Handler: mux,
+	}
+	go func() {
+		log.Info("Starting pre-stop hook HTTP server")
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalw("Failed to listen-and-server on HTTP", zap.Error(err))
+		}
+		log.Info("Pre-stop hook server shutdown")
+	}()
+	return httpServer.Shutdown, nil
+}
@@ -0,0 +1,44 @@
+package lifecycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainer(t *testing.T) {
+	d := NewDrainer()
+	returned := make(chan int)
+	go func() {
+		w := httptest.NewRecorder()
+		d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/prestop", nil))
+		returned <- w.Code
+	}()
+	select {
+	case <-returned:
+		t.Fatal("should block before drained")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.False(t, d.Drained())
+	d.Done()
+	d.Done()
+	assert.True(t, d.Drained())
+	assert.Equal(t, http.StatusNoContent, <-returned)
+
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/prestop", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestDrainer_Canceled(t *testing.T) {
+	d := NewDrainer()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/prestop", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -7,7 +7,10 @@ import (
 	zap "go.uber.org/zap"
 )
 
-// NewLogger returns a new zap.SugaredLogger
+// CorrelationIDKey is the key of the correlation ID of the pipeline in the log lines
+const CorrelationIDKey = "pipelineCorrelationID"
+
+// NewLogger returns a new zap.SugaredLogger, with the correlation ID of the pipeline if it's set in the environment
 func NewLogger() *zap.SugaredLogger {
 	var config zap.Config
 	debugMode, ok := os.LookupEnv("NUMAFLOW_DEBUG")
@@ -22,7 +25,12 @@ func NewLogger() *zap.SugaredLogger {
 	if err != nil {
 		panic(err)
 	}
-	return logger.Named("numaflow").Sugar()
+	sugar := logger.Named("numaflow").Sugar()
+	// Same as v1alpha1.EnvCorrelationID, set in the pods of the pipeline
+	if id := os.Getenv("NUMAFLOW_CORRELATION_ID"); id != "" {
+		sugar = sugar.With(CorrelationIDKey, id)
+	}
+	return sugar
 }
 
 type loggerKey struct{}
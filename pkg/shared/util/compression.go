@@ -0,0 +1,99 @@
+package util
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+var (
+	// The zstd encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// Compress compresses the data with the content encoding, empty or "identity" means no compression.
+func Compress(encoding string, data []byte) ([]byte, error) {
+	switch dfv1.ContentEncoding(normalizeEncoding(encoding)) {
+	case "", "identity":
+		return data, nil
+	case dfv1.ContentEncodingGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to gzip data, %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("failed to gzip data, %w", err)
+		}
+		return buf.Bytes(), nil
+	case dfv1.ContentEncodingSnappy:
+		return snappy.Encode(nil, data), nil
+	case dfv1.ContentEncodingZstd:
+		return zstdEncoder.EncodeAll(data, nil), nil
+	case dfv1.ContentEncodingLZ4:
+		var buf bytes.Buffer
+		w := lz4.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to compress lz4 data, %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress lz4 data, %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// Decompress decompresses the data compressed with the content encoding, empty or "identity" means no compression.
+func Decompress(encoding string, data []byte) ([]byte, error) {
+	switch dfv1.ContentEncoding(normalizeEncoding(encoding)) {
+	case "", "identity":
+		return data, nil
+	case dfv1.ContentEncodingGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to gunzip data, %w", err)
+		}
+		defer r.Close()
+		result, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to gunzip data, %w", err)
+		}
+		return result, nil
+	case dfv1.ContentEncodingSnappy:
+		result, err := snappy.Decode(nil, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode snappy data, %w", err)
+		}
+		return result, nil
+	case dfv1.ContentEncodingZstd:
+		result, err := zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode zstd data, %w", err)
+		}
+		return result, nil
+	case dfv1.ContentEncodingLZ4:
+		result, err := ioutil.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode lz4 data, %w", err)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// normalizeEncoding makes the content encoding from the headers comparable, e.g. "GZIP" and "x-gzip" are both "gzip".
+func normalizeEncoding(encoding string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(encoding)), "x-")
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompression(t *testing.T) {
+	data := []byte("hello hello hello hello world")
+	for _, encoding := range []string{"", "identity", "gzip", "x-gzip", "GZIP", "snappy", "zstd", "lz4"} {
+		compressed, err := Compress(encoding, data)
+		assert.NoError(t, err, encoding)
+		decompressed, err := Decompress(encoding, compressed)
+		assert.NoError(t, err, encoding)
+		assert.Equal(t, data, decompressed, encoding)
+	}
+}
+
+func TestCompressionErrors(t *testing.T) {
+	_, err := Compress("br", []byte("a"))
+	assert.Error(t, err)
+	_, err = Decompress("br", []byte("a"))
+	assert.Error(t, err)
+	for _, encoding := range []string{"gzip", "snappy", "zstd", "lz4"} {
+		_, err = Decompress(encoding, []byte("not compressed"))
+		assert.Error(t, err, encoding)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"strconv"
+	"strings"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,9 @@ func GetIsbSvcEnvVars(isbSvcConfig dfv1.BufferServiceConfig) (dfv1.ISBSvcType, [
 		if x.MasterName != "" {
 			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcSentinelMaster, Value: x.MasterName})
 		}
+		if x.Cluster {
+			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcRedisCluster, Value: "true"})
+		}
 		if x.User != "" {
 			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcRedisUser, Value: x.User})
 		}
@@ -57,10 +61,20 @@ func GetIsbSvcEnvVars(isbSvcConfig dfv1.BufferServiceConfig) (dfv1.ISBSvcType, [
 				},
 			})
 		}
+		if t := x.TLS; t != nil {
+			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcRedisTLSEnabled, Value: "true"})
+			env = append(env, tlsEnvVars(t, dfv1.EnvISBSvcRedisTLSInsecure, dfv1.EnvISBSvcRedisTLSCACert, dfv1.EnvISBSvcRedisTLSCert, dfv1.EnvISBSvcRedisTLSKey)...)
+		}
+		if rotatesRedisPassword(x) {
+			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcRedisPasswordFile, Value: dfv1.PathISBSvcRedisAuth + "/" + redisPasswordFileName})
+		}
 		isbSvcType = dfv1.ISBSvcTypeRedis
 	} else if x := isbSvcConfig.JetStream; x != nil {
 		env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcJetStreamURL, Value: x.URL})
 		env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcJetStreamTLSEnabled, Value: strconv.FormatBool(x.TLSEnabled)})
+		if x.TLSEnabled && x.TLSCACert != nil {
+			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcJetStreamTLSCACert, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: x.TLSCACert.DeepCopy()}})
+		}
 		if x.Auth != nil && x.Auth.User != nil && x.Auth.Password != nil {
 			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcJetStreamUser, ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
@@ -80,6 +94,81 @@ func GetIsbSvcEnvVars(isbSvcConfig dfv1.BufferServiceConfig) (dfv1.ISBSvcType, [
 			}})
 		}
 		isbSvcType = dfv1.ISBSvcTypeJetStream
+	} else if x := isbSvcConfig.Kafka; x != nil {
+		env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcKafkaBrokers, Value: strings.Join(x.Brokers, ",")})
+		if x.Config != "" {
+			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcKafkaConfig, Value: x.Config})
+		}
+		if t := x.TLS; t != nil {
+			env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcKafkaTLSEnabled, Value: "true"})
+			env = append(env, tlsEnvVars(t, dfv1.EnvISBSvcKafkaTLSInsecure, dfv1.EnvISBSvcKafkaTLSCACert, dfv1.EnvISBSvcKafkaTLSCert, dfv1.EnvISBSvcKafkaTLSKey)...)
+		}
+		isbSvcType = dfv1.ISBSvcTypeKafka
 	}
 	return isbSvcType, env
 }
+
+// GetIsbSvcMirrorEnvVars returns the environment variables of the credentials of the secondary JetStream the streams of
+// the buffers are mirrored to, only the jobs creating and deleting the buffers need them.
+func GetIsbSvcMirrorEnvVars(isbSvcConfig dfv1.BufferServiceConfig) []corev1.EnvVar {
+	if isbSvcConfig.JetStream == nil || isbSvcConfig.JetStream.Mirror == nil {
+		return nil
+	}
+	x := isbSvcConfig.JetStream.Mirror
+	var env []corev1.EnvVar
+	if x.Auth != nil && x.Auth.User != nil && x.Auth.Password != nil {
+		env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcMirrorUser, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: x.Auth.User.DeepCopy()}})
+		env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcMirrorPassword, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: x.Auth.Password.DeepCopy()}})
+	}
+	if x.TLSEnabled && x.TLSCACert != nil {
+		env = append(env, corev1.EnvVar{Name: dfv1.EnvISBSvcMirrorTLSCACert, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: x.TLSCACert.DeepCopy()}})
+	}
+	return env
+}
+
+// GetIsbSvcVolumes returns the volumes of the ISB service to be added to the pods, and their mounts to the main
+// containers, i.e. the password secret of a managed Redis rotating it.
+func GetIsbSvcVolumes(isbSvcConfig dfv1.BufferServiceConfig) ([]corev1.Volume, []corev1.VolumeMount) {
+	x := isbSvcConfig.Redis
+	if x == nil || !rotatesRedisPassword(x) {
+		return nil, nil
+	}
+	volumes := []corev1.Volume{{
+		Name: redisAuthVolumeName,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName: x.Password.Name,
+			Items:      []corev1.KeyToPath{{Key: x.Password.Key, Path: redisPasswordFileName}},
+		}},
+	}}
+	mounts := []corev1.VolumeMount{{Name: redisAuthVolumeName, MountPath: dfv1.PathISBSvcRedisAuth, ReadOnly: true}}
+	return volumes, mounts
+}
+
+const (
+	redisAuthVolumeName   = "isbsvc-redis-auth"
+	redisPasswordFileName = "password"
+)
+
+// rotatesRedisPassword tells if the password secret of the Redis is mounted to be read by each new connection.
+func rotatesRedisPassword(x *dfv1.RedisConfig) bool {
+	return x.Managed != nil && x.Managed.PasswordRotation && x.Password != nil
+}
+
+// tlsEnvVars returns the environment variables of the TLS config with the given names. The PEM contents are passed
+// instead of mounting the secrets, so that all the pods using the ISB Service get them the same way.
+func tlsEnvVars(t *dfv1.TLS, insecure, caCert, cert, key string) []corev1.EnvVar {
+	env := []corev1.EnvVar{{Name: insecure, Value: strconv.FormatBool(t.InsecureSkipVerify)}}
+	for _, x := range []struct {
+		name     string
+		selector *corev1.SecretKeySelector
+	}{
+		{name: caCert, selector: t.CACertSecret},
+		{name: cert, selector: t.CertSecret},
+		{name: key, selector: t.KeySecret},
+	} {
+		if x.selector != nil {
+			env = append(env, corev1.EnvVar{Name: x.name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: x.selector.DeepCopy()}})
+		}
+	}
+	return env
+}
@@ -56,6 +56,11 @@ func TestGetRedisIsbSvcEnvVars(t *testing.T) {
 	assert.Contains(t, eNames, dfv1.EnvISBSvcConfig)
 	assert.Contains(t, eNames, dfv1.EnvISBSvcSentinelMaster)
 	assert.Contains(t, eNames, dfv1.EnvISBSvcRedisSentinelURL)
+	assert.NotContains(t, eNames, dfv1.EnvISBSvcRedisCluster)
+
+	fakeIsbSvcConfig.Redis = &dfv1.RedisConfig{URL: "node1:6379,node2:6379", Cluster: true}
+	_, env = GetIsbSvcEnvVars(fakeIsbSvcConfig)
+	assert.Contains(t, env, corev1.EnvVar{Name: dfv1.EnvISBSvcRedisCluster, Value: "true"})
 }
 
 func TestGetJSIsbSvcEnvVars(t *testing.T) {
@@ -91,4 +96,102 @@ func TestGetJSIsbSvcEnvVars(t *testing.T) {
 	assert.Contains(t, eNames, dfv1.EnvISBSvcJetStreamUser)
 	assert.Contains(t, eNames, dfv1.EnvISBSvcJetStreamPassword)
 	assert.Contains(t, eNames, dfv1.EnvISBSvcConfig)
+	assert.NotContains(t, eNames, dfv1.EnvISBSvcJetStreamTLSCACert)
+
+	fakeIsbsConfig.JetStream.TLSEnabled = true
+	fakeIsbsConfig.JetStream.TLSCACert = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "test-tls"}, Key: "ca.crt"}
+	_, env = GetIsbSvcEnvVars(fakeIsbsConfig)
+	envs := map[string]corev1.EnvVar{}
+	for _, e := range env {
+		envs[e.Name] = e
+	}
+	assert.Equal(t, "true", envs[dfv1.EnvISBSvcJetStreamTLSEnabled].Value)
+	assert.Equal(t, fakeIsbsConfig.JetStream.TLSCACert, envs[dfv1.EnvISBSvcJetStreamTLSCACert].ValueFrom.SecretKeyRef)
+}
+
+func TestGetKafkaIsbSvcEnvVars(t *testing.T) {
+	caCert := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "kafka-tls"}, Key: "ca.crt"}
+	tp, env := GetIsbSvcEnvVars(dfv1.BufferServiceConfig{
+		Kafka: &dfv1.KafkaConfig{
+			Brokers: []string{"b1:9092", "b2:9092"},
+			Config:  "producer:\n  maxMessageBytes: 2000000",
+			TLS:     &dfv1.TLS{CACertSecret: caCert},
+		},
+	})
+	assert.Equal(t, dfv1.ISBSvcTypeKafka, tp)
+	envs := map[string]corev1.EnvVar{}
+	for _, e := range env {
+		envs[e.Name] = e
+	}
+	assert.Equal(t, "b1:9092,b2:9092", envs[dfv1.EnvISBSvcKafkaBrokers].Value)
+	assert.Equal(t, "producer:\n  maxMessageBytes: 2000000", envs[dfv1.EnvISBSvcKafkaConfig].Value)
+	assert.Equal(t, "true", envs[dfv1.EnvISBSvcKafkaTLSEnabled].Value)
+	assert.Equal(t, "false", envs[dfv1.EnvISBSvcKafkaTLSInsecure].Value)
+	assert.Equal(t, caCert, envs[dfv1.EnvISBSvcKafkaTLSCACert].ValueFrom.SecretKeyRef)
+	assert.NotContains(t, envs, dfv1.EnvISBSvcKafkaTLSCert)
+	assert.NotContains(t, envs, dfv1.EnvISBSvcKafkaTLSKey)
+}
+
+func TestGetManagedRedisIsbSvcEnvVars(t *testing.T) {
+	caCert := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-tls"}, Key: "ca.crt"}
+	config := dfv1.BufferServiceConfig{
+		Redis: &dfv1.RedisConfig{
+			URL:      "master.my-redis.xxxxxx.use1.cache.amazonaws.com:6379",
+			TLS:      &dfv1.TLS{InsecureSkipVerify: true, CACertSecret: caCert},
+			Password: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-auth"}, Key: "token"},
+			Managed:  &dfv1.ManagedRedis{RequireTLS: true, PasswordRotation: true},
+		},
+	}
+	tp, env := GetIsbSvcEnvVars(config)
+	assert.Equal(t, dfv1.ISBSvcTypeRedis, tp)
+	envs := map[string]corev1.EnvVar{}
+	for _, e := range env {
+		envs[e.Name] = e
+	}
+	assert.Equal(t, "true", envs[dfv1.EnvISBSvcRedisTLSEnabled].Value)
+	assert.Equal(t, "true", envs[dfv1.EnvISBSvcRedisTLSInsecure].Value)
+	assert.Equal(t, caCert, envs[dfv1.EnvISBSvcRedisTLSCACert].ValueFrom.SecretKeyRef)
+	assert.NotContains(t, envs, dfv1.EnvISBSvcRedisTLSCert)
+	assert.Equal(t, "/var/numaflow/redis-auth/password", envs[dfv1.EnvISBSvcRedisPasswordFile].Value)
+	volumes, mounts := GetIsbSvcVolumes(config)
+	assert.Len(t, volumes, 1)
+	assert.Equal(t, "redis-auth", volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "token", Path: "password"}}, volumes[0].Secret.Items)
+	assert.Equal(t, []corev1.VolumeMount{{Name: volumes[0].Name, MountPath: dfv1.PathISBSvcRedisAuth, ReadOnly: true}}, mounts)
+
+	config.Redis.Managed.PasswordRotation = false
+	_, env = GetIsbSvcEnvVars(config)
+	for _, e := range env {
+		assert.NotEqual(t, dfv1.EnvISBSvcRedisPasswordFile, e.Name)
+	}
+	volumes, mounts = GetIsbSvcVolumes(config)
+	assert.Nil(t, volumes)
+	assert.Nil(t, mounts)
+}
+
+func TestGetIsbSvcMirrorEnvVars(t *testing.T) {
+	config := dfv1.BufferServiceConfig{JetStream: &dfv1.JetStreamConfig{URL: "nats://isbsvc:4222"}}
+	assert.Nil(t, GetIsbSvcMirrorEnvVars(config))
+
+	user := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "dr-auth"}, Key: "user"}
+	password := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "dr-auth"}, Key: "password"}
+	caCert := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "dr-ca"}, Key: "ca.crt"}
+	config.JetStream.Mirror = &dfv1.JetStreamMirror{
+		URL:        "nats://isbsvc-dr:4222",
+		Auth:       &dfv1.NATSAuth{User: user, Password: password},
+		TLSEnabled: true,
+		TLSCACert:  caCert,
+	}
+	envs := map[string]corev1.EnvVar{}
+	for _, e := range GetIsbSvcMirrorEnvVars(config) {
+		envs[e.Name] = e
+	}
+	assert.Len(t, envs, 3)
+	assert.Equal(t, user, envs[dfv1.EnvISBSvcMirrorUser].ValueFrom.SecretKeyRef)
+	assert.Equal(t, password, envs[dfv1.EnvISBSvcMirrorPassword].ValueFrom.SecretKeyRef)
+	assert.Equal(t, caCert, envs[dfv1.EnvISBSvcMirrorTLSCACert].ValueFrom.SecretKeyRef)
+
+	config.JetStream.Mirror.TLSEnabled = false
+	config.JetStream.Mirror.Auth = nil
+	assert.Empty(t, GetIsbSvcMirrorEnvVars(config))
 }
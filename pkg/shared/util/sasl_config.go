@@ -0,0 +1,67 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"github.com/xdg-go/scram"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// SetSASLConfig configures the SASL authentication of the sarama config, the user name and password are read from the
+// mounted secret volumes.
+func SetSASLConfig(cfg *sarama.Config, s *dfv1.SASL) error {
+	if s == nil {
+		return nil
+	}
+	user, err := GetSecretFromVolume(s.UserSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get SASL user, %w", err)
+	}
+	password, err := GetSecretFromVolume(s.PasswordSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get SASL password, %w", err)
+	}
+	cfg.Net.SASL.Enable = true
+	cfg.Net.SASL.User = user
+	cfg.Net.SASL.Password = password
+	switch m := s.GetMechanism(); m {
+	case dfv1.SASLMechanismPlain:
+		cfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	case dfv1.SASLMechanismSCRAMSHA256:
+		cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hashGenerator: scram.SHA256} }
+	case dfv1.SASLMechanismSCRAMSHA512:
+		cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hashGenerator: scram.SHA512} }
+	default:
+		return fmt.Errorf("unsupported SASL mechanism %q", m)
+	}
+	return nil
+}
+
+// scramClient implements sarama.SCRAMClient with the xdg-go/scram conversation.
+type scramClient struct {
+	*scram.Client
+	*scram.ClientConversation
+	hashGenerator scram.HashGeneratorFcn
+}
+
+func (c *scramClient) Begin(userName, password, authzID string) error {
+	client, err := c.hashGenerator.NewClient(userName, password, authzID)
+	if err != nil {
+		return err
+	}
+	c.Client = client
+	c.ClientConversation = client.NewConversation()
+	return nil
+}
+
+func (c *scramClient) Step(challenge string) (string, error) {
+	return c.ClientConversation.Step(challenge)
+}
+
+func (c *scramClient) Done() bool {
+	return c.ClientConversation.Done()
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/xdg-go/scram"
+	corev1 "k8s.io/api/core/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestSetSASLConfig(t *testing.T) {
+	t.Run("nil SASL", func(t *testing.T) {
+		cfg := sarama.NewConfig()
+		assert.NoError(t, SetSASLConfig(cfg, nil))
+		assert.False(t, cfg.Net.SASL.Enable)
+	})
+
+	t.Run("secret not mounted", func(t *testing.T) {
+		cfg := sarama.NewConfig()
+		err := SetSASLConfig(cfg, &dfv1.SASL{
+			UserSecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "not-exist"},
+				Key:                  "user",
+			},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get SASL user")
+		assert.False(t, cfg.Net.SASL.Enable)
+	})
+
+	t.Run("nil secret selector", func(t *testing.T) {
+		cfg := sarama.NewConfig()
+		assert.Error(t, SetSASLConfig(cfg, &dfv1.SASL{}))
+	})
+}
+
+func TestSCRAMClient(t *testing.T) {
+	c := &scramClient{hashGenerator: scram.SHA512}
+	assert.NoError(t, c.Begin("user", "password", ""))
+	first, err := c.Step("")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first, "n,,n=user,r="))
+	assert.False(t, c.Done())
+}
@@ -0,0 +1,182 @@
+/*
+Package sideinputs implements the side-inputs manager, which keeps the side inputs of a vertex refreshed in the shared
+volume read by the UDF.
+*/
+package sideinputs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+type options struct {
+	// dir is where the side inputs are written
+	dir string
+	// configMapDir is where the ConfigMaps are mounted, in sub-directories named after them
+	configMapDir string
+}
+
+type Option func(*options) error
+
+// WithDir sets the directory the side inputs are written to, defaults to /var/numaflow/side-inputs.
+func WithDir(dir string) Option {
+	return func(o *options) error {
+		o.dir = dir
+		return nil
+	}
+}
+
+// WithConfigMapDir sets the directory the ConfigMaps are mounted in, defaults to /var/numaflow/config.
+func WithConfigMapDir(dir string) Option {
+	return func(o *options) error {
+		o.configMapDir = dir
+		return nil
+	}
+}
+
+// Manager fetches the side inputs, and writes them to the files named after them. A file is replaced atomically by a
+// rename, so that the readers see either the old or the new data, and it is only replaced when the data changes.
+type Manager struct {
+	sideInputs []dfv1.SideInput
+	opts       *options
+	httpClient *http.Client
+}
+
+func NewManager(sideInputs []dfv1.SideInput, opts ...Option) (*Manager, error) {
+	o := &options{
+		dir:          dfv1.PathSideInputs,
+		configMapDir: "/var/numaflow/config",
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return &Manager{
+		sideInputs: sideInputs,
+		opts:       o,
+		httpClient: &http.Client{},
+	}, nil
+}
+
+// RefreshAll refreshes all the side inputs once, it fails if any of them fails.
+func (m *Manager) RefreshAll(ctx context.Context) error {
+	for _, si := range m.sideInputs {
+		if err := m.refresh(ctx, si); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start refreshes each side input on its interval until the context is done. A failed refresh is logged and retried
+// on the next interval, the last data fetched is kept in the meantime.
+func (m *Manager) Start(ctx context.Context) {
+	log := logging.FromContext(ctx)
+	var wg sync.WaitGroup
+	for _, si := range m.sideInputs {
+		wg.Add(1)
+		go func(si dfv1.SideInput) {
+			defer wg.Done()
+			ticker := time.NewTicker(si.GetRefreshInterval())
+			defer ticker.Stop()
+			for {
+				if err := m.refresh(ctx, si); err != nil {
+					log.Errorw("Failed to refresh the side input", zap.String("sideInput", si.Name), zap.Error(err))
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(si)
+	}
+	wg.Wait()
+}
+
+func (m *Manager) refresh(ctx context.Context, si dfv1.SideInput) error {
+	data, err := m.fetch(ctx, si)
+	if err != nil {
+		return fmt.Errorf("failed to fetch side input %q, %w", si.Name, err)
+	}
+	changed, err := writeFileIfChanged(m.opts.dir, si.Name, data)
+	if err != nil {
+		return fmt.Errorf("failed to write side input %q, %w", si.Name, err)
+	}
+	if changed {
+		logging.FromContext(ctx).Infow("Side input updated", zap.String("sideInput", si.Name), zap.Int("size", len(data)))
+	}
+	return nil
+}
+
+func (m *Manager) fetch(ctx context.Context, si dfv1.SideInput) ([]byte, error) {
+	switch {
+	case si.ConfigMap != nil:
+		return os.ReadFile(filepath.Join(m.opts.configMapDir, si.ConfigMap.Name, si.ConfigMap.Key))
+	case si.HTTP != nil:
+		return m.fetchHTTP(ctx, *si.HTTP)
+	default:
+		return nil, fmt.Errorf("no source specified")
+	}
+}
+
+func (m *Manager) fetchHTTP(ctx context.Context, h dfv1.HTTPSideInput) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.GetTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// writeFileIfChanged replaces the file with the data by writing a temporary file and renaming it, it returns whether
+// the data changed.
+func writeFileIfChanged(dir, name string, data []byte) (bool, error) {
+	path := filepath.Join(dir, name)
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, data) {
+		return false, nil
+	}
+	// Hidden, so that the readers listing the directory don't pick it up.
+	tmp, err := os.CreateTemp(dir, "."+name+".")
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	// Readable by the UDF containers running as a different user.
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
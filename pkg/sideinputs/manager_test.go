@@ -0,0 +1,118 @@
+package sideinputs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestManager_RefreshAll(t *testing.T) {
+	dir := t.TempDir()
+	cmDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(cmDir, "my-cm"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(cmDir, "my-cm", "table"), []byte("a=1"), 0644))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"threshold":3}`))
+	}))
+	defer server.Close()
+
+	m, err := NewManager([]dfv1.SideInput{
+		{Name: "table", ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-cm"}, Key: "table"}},
+		{Name: "config", HTTP: &dfv1.HTTPSideInput{URL: server.URL}},
+	}, WithDir(dir), WithConfigMapDir(cmDir))
+	assert.NoError(t, err)
+	assert.NoError(t, m.RefreshAll(context.Background()))
+	data, err := os.ReadFile(filepath.Join(dir, "table"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a=1", string(data))
+	data, err = os.ReadFile(filepath.Join(dir, "config"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"threshold":3}`, string(data))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files left")
+}
+
+func TestManager_RefreshAllFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	m, err := NewManager([]dfv1.SideInput{{Name: "config", HTTP: &dfv1.HTTPSideInput{URL: server.URL}}}, WithDir(t.TempDir()))
+	assert.NoError(t, err)
+	err = m.RefreshAll(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+
+	m, err = NewManager([]dfv1.SideInput{{Name: "table", ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-cm"}, Key: "table"}}},
+		WithDir(t.TempDir()), WithConfigMapDir(t.TempDir()))
+	assert.NoError(t, err)
+	assert.Error(t, m.RefreshAll(context.Background()))
+}
+
+func TestManager_Start(t *testing.T) {
+	dir := t.TempDir()
+	value := make(chan string, 1)
+	value <- "v1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case v := <-value:
+			_, _ = w.Write([]byte(v))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	m, err := NewManager([]dfv1.SideInput{
+		{Name: "config", HTTP: &dfv1.HTTPSideInput{URL: server.URL}, RefreshInterval: &metav1.Duration{Duration: 10 * time.Millisecond}},
+	}, WithDir(dir))
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Start(ctx)
+		close(done)
+	}()
+	read := func() string {
+		data, _ := os.ReadFile(filepath.Join(dir, "config"))
+		return string(data)
+	}
+	assert.Eventually(t, func() bool { return read() == "v1" }, 5*time.Second, 10*time.Millisecond)
+	// The failures keep the last data.
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "v1", read())
+	value <- "v2"
+	assert.Eventually(t, func() bool { return read() == "v2" }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("manager not stopped")
+	}
+}
+
+func TestWriteFileIfChanged(t *testing.T) {
+	dir := t.TempDir()
+	changed, err := writeFileIfChanged(dir, "a", []byte("1"))
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = writeFileIfChanged(dir, "a", []byte("1"))
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	changed, err = writeFileIfChanged(dir, "a", []byte("2"))
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	info, err := os.Stat(filepath.Join(dir, "a"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
 	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/numaproj/numaflow/pkg/shared/util"
 	"github.com/numaproj/numaflow/pkg/udf/applier"
@@ -25,6 +28,10 @@ type ToKafka struct {
 	isdf         *forward.InterStepDataForward
 	log          *zap.SugaredLogger
 	concurrency  uint32
+	// auditRecorder records the audit IDs of the processed messages
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced messages
+	tracer *telemetry.Tracer
 }
 
 type Option func(*ToKafka) error
@@ -41,6 +48,22 @@ func WithLogger(log *zap.SugaredLogger) Option {
 	}
 }
 
+// WithAuditRecorder records the audit IDs of the processed messages
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(t *ToKafka) error {
+		t.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced messages
+func WithTracer(tracer *telemetry.Tracer) Option {
+	return func(t *ToKafka) error {
+		t.tracer = tracer
+		return nil
+	}
+}
+
 // NewToKafka returns ToKafka type.
 func NewToKafka(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, opts ...Option) (*ToKafka, error) {
 	kafkaSink := vertex.Spec.Sink.Kafka
@@ -68,6 +91,21 @@ func NewToKafka(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, opts ...Option
 			forwardOpts = append(forwardOpts, forward.WithReadBatchSize(int64(*x.ReadBatchSize)))
 		}
 	}
+	if x := vertex.Spec.SlowStart; x != nil {
+		forwardOpts = append(forwardOpts, forward.WithSlowStart(x.GetDuration(), int64(x.GetInitialReadBatchSize())))
+	}
+	if vertex.Spec.TenantKey != "" {
+		forwardOpts = append(forwardOpts, forward.WithTenantKey(vertex.Spec.TenantKey))
+	}
+	if ages := vertex.GetFromBufferMaxMessageAges(); len(ages) > 0 {
+		forwardOpts = append(forwardOpts, forward.WithMaxMessageAges(ages))
+	}
+	if toKafka.auditRecorder != nil {
+		forwardOpts = append(forwardOpts, forward.WithAuditRecorder(toKafka.auditRecorder))
+	}
+	if toKafka.tracer != nil {
+		forwardOpts = append(forwardOpts, forward.WithTracer(toKafka.tracer))
+	}
 	f, err := forward.NewInterStepDataForward(vertex, fromBuffer, map[string]isb.BufferWriter{vertex.Name: toKafka}, forward.All, applier.Terminal, forwardOpts...)
 	if err != nil {
 		return nil, err
@@ -157,3 +195,8 @@ func (tk *ToKafka) ForceStop() {
 	tk.isdf.ForceStop()
 	tk.log.Info("forwarder force stopped successfully")
 }
+
+// CheckProgress checks the sinking makes progress
+func (tk *ToKafka) CheckProgress(threshold time.Duration) error {
+	return tk.isdf.CheckProgress(threshold)
+}
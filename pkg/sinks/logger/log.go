@@ -1,13 +1,26 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
 	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/numaproj/numaflow/pkg/udf/applier"
 	"go.uber.org/zap"
@@ -19,6 +32,17 @@ type ToLog struct {
 	pipelineName string
 	isdf         *forward.InterStepDataForward
 	logger       *zap.SugaredLogger
+	format       dfv1.LogSinkFormat
+	// samplePercentage is the percentage of the messages printed
+	samplePercentage uint32
+	// limiter limits the messages printed per second, nil if there's no limit
+	limiter *rate.Limiter
+	rand    *rand.Rand
+	printer *log.Logger
+	// auditRecorder records the audit IDs of the processed messages
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced messages
+	tracer *telemetry.Tracer
 }
 
 type Option func(*ToLog) error
@@ -30,13 +54,39 @@ func WithLogger(log *zap.SugaredLogger) Option {
 	}
 }
 
+// WithAuditRecorder records the audit IDs of the processed messages
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(t *ToLog) error {
+		t.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced messages
+func WithTracer(tracer *telemetry.Tracer) Option {
+	return func(t *ToLog) error {
+		t.tracer = tracer
+		return nil
+	}
+}
+
 // NewToLog returns ToLog type.
 func NewToLog(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, opts ...Option) (*ToLog, error) {
 	toLog := new(ToLog)
 	name := vertex.Spec.Name
 	toLog.name = name
 	toLog.pipelineName = vertex.Spec.PipelineName
-	// use opts in future for specifying logger format etc
+	spec := dfv1.Log{}
+	if x := vertex.Spec.Sink; x != nil && x.Log != nil {
+		spec = *x.Log
+	}
+	toLog.format = spec.GetFormat()
+	toLog.samplePercentage = spec.GetSamplePercentage()
+	if x := spec.GetMaxMessagesPerSecond(); x > 0 {
+		toLog.limiter = rate.NewLimiter(rate.Limit(x), int(x))
+	}
+	toLog.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	toLog.setOutput(os.Stderr)
 	for _, o := range opts {
 		if err := o(toLog); err != nil {
 			return nil, err
@@ -52,6 +102,21 @@ func NewToLog(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, opts ...Option)
 			forwardOpts = append(forwardOpts, forward.WithReadBatchSize(int64(*x.ReadBatchSize)))
 		}
 	}
+	if x := vertex.Spec.SlowStart; x != nil {
+		forwardOpts = append(forwardOpts, forward.WithSlowStart(x.GetDuration(), int64(x.GetInitialReadBatchSize())))
+	}
+	if vertex.Spec.TenantKey != "" {
+		forwardOpts = append(forwardOpts, forward.WithTenantKey(vertex.Spec.TenantKey))
+	}
+	if ages := vertex.GetFromBufferMaxMessageAges(); len(ages) > 0 {
+		forwardOpts = append(forwardOpts, forward.WithMaxMessageAges(ages))
+	}
+	if toLog.auditRecorder != nil {
+		forwardOpts = append(forwardOpts, forward.WithAuditRecorder(toLog.auditRecorder))
+	}
+	if toLog.tracer != nil {
+		forwardOpts = append(forwardOpts, forward.WithTracer(toLog.tracer))
+	}
 	isdf, err := forward.NewInterStepDataForward(vertex, fromBuffer, map[string]isb.BufferWriter{name: toLog}, forward.All, applier.Terminal, forwardOpts...)
 	if err != nil {
 		return nil, err
@@ -72,16 +137,85 @@ func (s *ToLog) IsFull() bool {
 	return false
 }
 
-// Write writes to the log.
+// setOutput sets the destination of the printed messages, the timestamps are left out of the JSON lines.
+func (s *ToLog) setOutput(w io.Writer) {
+	flags := log.LstdFlags
+	if s.format == dfv1.LogSinkFormatJSON {
+		flags = 0
+	}
+	s.printer = log.New(w, "", flags)
+}
+
+// Write writes to the log, the messages not sampled or over the rate limit are skipped.
 func (s *ToLog) Write(_ context.Context, messages []isb.Message) ([]isb.Offset, []error) {
-	prefix := "(" + s.GetName() + ")"
+	labels := map[string]string{"vertex": s.name, "pipeline": s.pipelineName}
 	for _, message := range messages {
-		logSinkReadCount.With(map[string]string{"vertex": s.name, "pipeline": s.pipelineName}).Inc()
-		log.Println(prefix, string(message.Payload))
+		logSinkReadCount.With(labels).Inc()
+		if (s.samplePercentage < 100 && s.rand.Float64()*100 >= float64(s.samplePercentage)) || (s.limiter != nil && !s.limiter.Allow()) {
+			logSinkSkippedCount.With(labels).Inc()
+			continue
+		}
+		s.print(message)
 	}
 	return nil, make([]error, len(messages))
 }
 
+// logRecord is a message printed in the JSON format.
+type logRecord struct {
+	Vertex    string            `json:"vertex"`
+	ID        string            `json:"id"`
+	Key       string            `json:"key,omitempty"`
+	EventTime *time.Time        `json:"eventTime,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	// Payload is embedded as it is if it's JSON, or as a string otherwise
+	Payload interface{} `json:"payload"`
+}
+
+func (s *ToLog) print(message isb.Message) {
+	prefix := "(" + s.GetName() + ")"
+	switch s.format {
+	case dfv1.LogSinkFormatJSON:
+		r := logRecord{Vertex: s.name, ID: message.ID, Key: string(message.Key), Metadata: message.Metadata, Payload: string(message.Payload)}
+		if !message.EventTime.IsZero() {
+			r.EventTime = &message.EventTime
+		}
+		if json.Valid(message.Payload) {
+			r.Payload = json.RawMessage(message.Payload)
+		}
+		b, err := json.Marshal(r)
+		if err != nil {
+			s.logger.Errorw("Failed to marshal the message", zap.String("id", message.ID), zap.Error(err))
+			return
+		}
+		s.printer.Println(string(b))
+	case dfv1.LogSinkFormatPretty:
+		var header strings.Builder
+		header.WriteString(prefix + " id=" + message.ID)
+		if len(message.Key) > 0 {
+			header.WriteString(" key=" + string(message.Key))
+		}
+		if !message.EventTime.IsZero() {
+			header.WriteString(" eventTime=" + message.EventTime.Format(time.RFC3339Nano))
+		}
+		keys := make([]string, 0, len(message.Metadata))
+		for k := range message.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			header.WriteString(fmt.Sprintf(" %s=%q", k, message.Metadata[k]))
+		}
+		payload := message.Payload
+		var indented bytes.Buffer
+		if json.Indent(&indented, payload, "", "  ") == nil {
+			payload = indented.Bytes()
+		}
+		s.printer.Println(header.String() + "\n" + string(payload))
+	default:
+		s.printer.Println(prefix, string(message.Payload))
+	}
+}
+
 func (br *ToLog) Close() error {
 	return nil
 }
@@ -100,3 +234,8 @@ func (s *ToLog) Stop() {
 func (s *ToLog) ForceStop() {
 	s.isdf.ForceStop()
 }
+
+// CheckProgress checks the sinking makes progress
+func (s *ToLog) CheckProgress(threshold time.Duration) error {
+	return s.isdf.CheckProgress(threshold)
+}
@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,6 +51,82 @@ func TestToLog_Start(t *testing.T) {
 	<-stopped
 }
 
+func testLogVertex(spec dfv1.Log) *dfv1.Vertex {
+	return &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "testPipeline",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "out",
+			Sink: &dfv1.Sink{Log: &spec},
+		},
+	}}
+}
+
+func TestToLog_Write(t *testing.T) {
+	message := isb.Message{
+		Header: isb.Header{ID: "1", Key: []byte("k"), Metadata: map[string]string{"b": "2", "a": "1"}, PaneInfo: isb.PaneInfo{EventTime: testStartTime}},
+		Body:   isb.Body{Payload: []byte(`{"x": 1}`)},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		s, err := NewToLog(testLogVertex(dfv1.Log{}), simplebuffer.NewInMemoryBuffer("from", 5))
+		assert.NoError(t, err)
+		out := new(bytes.Buffer)
+		s.setOutput(out)
+		_, errs := s.Write(context.Background(), []isb.Message{message})
+		assert.Equal(t, []error{nil}, errs)
+		assert.True(t, strings.HasSuffix(out.String(), `(out) {"x": 1}`+"\n"))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		s, err := NewToLog(testLogVertex(dfv1.Log{Format: dfv1.LogSinkFormatJSON}), simplebuffer.NewInMemoryBuffer("from", 5))
+		assert.NoError(t, err)
+		out := new(bytes.Buffer)
+		s.setOutput(out)
+		text := message
+		text.Payload = []byte("hello")
+		s.Write(context.Background(), []isb.Message{message, text})
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.JSONEq(t, `{"vertex":"out","id":"1","key":"k","eventTime":"2021-11-09T15:00:00Z","metadata":{"a":"1","b":"2"},"payload":{"x":1}}`, lines[0])
+		assert.JSONEq(t, `{"vertex":"out","id":"1","key":"k","eventTime":"2021-11-09T15:00:00Z","metadata":{"a":"1","b":"2"},"payload":"hello"}`, lines[1])
+	})
+
+	t.Run("pretty", func(t *testing.T) {
+		s, err := NewToLog(testLogVertex(dfv1.Log{Format: dfv1.LogSinkFormatPretty}), simplebuffer.NewInMemoryBuffer("from", 5))
+		assert.NoError(t, err)
+		out := new(bytes.Buffer)
+		s.setOutput(out)
+		s.Write(context.Background(), []isb.Message{message})
+		assert.Contains(t, out.String(), `(out) id=1 key=k eventTime=2021-11-09T15:00:00Z a="1" b="2"`+"\n{\n  \"x\": 1\n}\n")
+	})
+
+	t.Run("sampling and rate limit", func(t *testing.T) {
+		percentage := uint32(50)
+		s, err := NewToLog(testLogVertex(dfv1.Log{SamplePercentage: &percentage}), simplebuffer.NewInMemoryBuffer("from", 5))
+		assert.NoError(t, err)
+		out := new(bytes.Buffer)
+		s.setOutput(out)
+		messages := make([]isb.Message, 1000)
+		for i := range messages {
+			messages[i] = message
+		}
+		_, errs := s.Write(context.Background(), messages)
+		assert.Equal(t, make([]error, 1000), errs)
+		n := strings.Count(out.String(), "\n")
+		assert.Greater(t, n, 350)
+		assert.Less(t, n, 650)
+
+		max := uint32(10)
+		s, err = NewToLog(testLogVertex(dfv1.Log{MaxMessagesPerSecond: &max}), simplebuffer.NewInMemoryBuffer("from", 5))
+		assert.NoError(t, err)
+		out.Reset()
+		s.setOutput(out)
+		_, errs = s.Write(context.Background(), messages)
+		assert.Equal(t, make([]error, 1000), errs)
+		assert.Equal(t, 10, strings.Count(out.String(), "\n"))
+	})
+}
+
 type ForwardToAllVertex struct {
 }
 
@@ -11,3 +11,10 @@ var logSinkReadCount = promauto.NewCounterVec(prometheus.CounterOpts{
 	Name:      "write_total",
 	Help:      "Total number of messages written to log sink",
 }, []string{"vertex", "pipeline"})
+
+// logSinkSkippedCount is used to indicate the number of messages not printed by the sampling or the rate limit
+var logSinkSkippedCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "log_sink",
+	Name:      "skipped_total",
+	Help:      "Total number of messages not printed by the log sink because of the sampling or the rate limit",
+}, []string{"vertex", "pipeline"})
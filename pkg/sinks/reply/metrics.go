@@ -0,0 +1,21 @@
+package reply
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// replySinkWriteCount is used to indicate the number of replies written to the reply buffers
+var replySinkWriteCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reply_sink",
+	Name:      "write_total",
+	Help:      "Total number of replies written to the reply buffers",
+}, []string{"vertex", "pipeline"})
+
+// replySinkDropped is used to indicate the number of messages dropped by the reply sink, because they are not from a
+// request-reply source, or they fail to be written to the reply buffers
+var replySinkDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reply_sink",
+	Name:      "dropped_total",
+	Help:      "Total number of messages dropped by the reply sink",
+}, []string{"vertex", "pipeline", "reason"})
@@ -0,0 +1,209 @@
+package reply
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	"github.com/numaproj/numaflow/pkg/udf/applier"
+	"go.uber.org/zap"
+)
+
+// WriterFactory creates the writer of a reply buffer.
+type WriterFactory func(bufferName string) (isb.BufferWriter, error)
+
+// ToReply returns the messages to the http sources in request-reply mode, each message is written to the reply buffer
+// of the source replica the request was received by, which is carried in the message metadata.
+type ToReply struct {
+	name         string
+	pipelineName string
+	// bufferPrefix is the prefix of the reply buffers of the pipeline
+	bufferPrefix string
+	isdf         *forward.InterStepDataForward
+	newWriter    WriterFactory
+	writers      map[string]isb.BufferWriter
+	logger       *zap.SugaredLogger
+	// auditRecorder records the audit IDs of the processed messages
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced messages
+	tracer *telemetry.Tracer
+}
+
+type Option func(*ToReply) error
+
+func WithLogger(log *zap.SugaredLogger) Option {
+	return func(t *ToReply) error {
+		t.logger = log
+		return nil
+	}
+}
+
+// WithAuditRecorder records the audit IDs of the processed messages
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(t *ToReply) error {
+		t.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced messages
+func WithTracer(tracer *telemetry.Tracer) Option {
+	return func(t *ToReply) error {
+		t.tracer = tracer
+		return nil
+	}
+}
+
+// NewToReply returns ToReply type.
+func NewToReply(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, newWriter WriterFactory, opts ...Option) (*ToReply, error) {
+	toReply := &ToReply{
+		name:         vertex.Spec.Name,
+		pipelineName: vertex.Spec.PipelineName,
+		bufferPrefix: fmt.Sprintf("%s-%s-", vertex.Namespace, vertex.Spec.PipelineName),
+		newWriter:    newWriter,
+		writers:      make(map[string]isb.BufferWriter),
+	}
+	for _, o := range opts {
+		if err := o(toReply); err != nil {
+			return nil, err
+		}
+	}
+	if toReply.logger == nil {
+		toReply.logger = logging.NewLogger()
+	}
+
+	forwardOpts := []forward.Option{forward.WithLogger(toReply.logger)}
+	if x := vertex.Spec.Limits; x != nil {
+		if x.ReadBatchSize != nil {
+			forwardOpts = append(forwardOpts, forward.WithReadBatchSize(int64(*x.ReadBatchSize)))
+		}
+	}
+	if vertex.Spec.TenantKey != "" {
+		forwardOpts = append(forwardOpts, forward.WithTenantKey(vertex.Spec.TenantKey))
+	}
+	if ages := vertex.GetFromBufferMaxMessageAges(); len(ages) > 0 {
+		forwardOpts = append(forwardOpts, forward.WithMaxMessageAges(ages))
+	}
+	if toReply.auditRecorder != nil {
+		forwardOpts = append(forwardOpts, forward.WithAuditRecorder(toReply.auditRecorder))
+	}
+	if toReply.tracer != nil {
+		forwardOpts = append(forwardOpts, forward.WithTracer(toReply.tracer))
+	}
+	isdf, err := forward.NewInterStepDataForward(vertex, fromBuffer, map[string]isb.BufferWriter{toReply.name: toReply}, forward.All, applier.Terminal, forwardOpts...)
+	if err != nil {
+		return nil, err
+	}
+	toReply.isdf = isdf
+	return toReply, nil
+}
+
+// GetName returns the name.
+func (s *ToReply) GetName() string {
+	return s.name
+}
+
+// Write writes the messages to the reply buffers in their metadata. The replies are best effort, the messages without
+// a valid reply buffer, or failing to be written, are dropped, and the requests waiting for them time out.
+func (s *ToReply) Write(ctx context.Context, messages []isb.Message) ([]isb.Offset, []error) {
+	replies := make(map[string][]isb.Message)
+	for _, m := range messages {
+		replyTo := m.Metadata[dfv1.ReplyToKey]
+		if replyTo == "" || m.Metadata[dfv1.ReplyCorrelationIDKey] == "" {
+			s.drop(1, "NoReplyTo")
+			continue
+		}
+		if !s.isReplyBuffer(replyTo) {
+			s.logger.Warnw("Invalid reply buffer, dropping the message", zap.String("replyTo", replyTo))
+			s.drop(1, "InvalidReplyTo")
+			continue
+		}
+		replies[replyTo] = append(replies[replyTo], m)
+	}
+	for replyTo, msgs := range replies {
+		writer, err := s.getWriter(replyTo)
+		if err != nil {
+			s.logger.Errorw("Failed to create the reply buffer writer", zap.String("replyTo", replyTo), zap.Error(err))
+			s.drop(len(msgs), "WriteFailed")
+			continue
+		}
+		_, errs := writer.Write(ctx, msgs)
+		for _, err := range errs {
+			if err != nil {
+				s.logger.Errorw("Failed to write the reply", zap.String("replyTo", replyTo), zap.Error(err))
+				s.drop(1, "WriteFailed")
+			} else {
+				replySinkWriteCount.With(map[string]string{"vertex": s.name, "pipeline": s.pipelineName}).Inc()
+			}
+		}
+	}
+	return nil, make([]error, len(messages))
+}
+
+func (s *ToReply) drop(n int, reason string) {
+	replySinkDropped.With(map[string]string{"vertex": s.name, "pipeline": s.pipelineName, "reason": reason}).Add(float64(n))
+}
+
+// isReplyBuffer tells if the buffer name is a reply buffer of the pipeline, so that the metadata of the messages can
+// not be used to write to any other buffer.
+func (s *ToReply) isReplyBuffer(bufferName string) bool {
+	if !strings.HasPrefix(bufferName, s.bufferPrefix) {
+		return false
+	}
+	i := strings.LastIndex(bufferName, "-reply-")
+	if i < len(s.bufferPrefix) {
+		return false
+	}
+	_, err := strconv.ParseUint(bufferName[i+len("-reply-"):], 10, 32)
+	return err == nil
+}
+
+func (s *ToReply) getWriter(bufferName string) (isb.BufferWriter, error) {
+	if w, ok := s.writers[bufferName]; ok {
+		return w, nil
+	}
+	w, err := s.newWriter(bufferName)
+	if err != nil {
+		return nil, err
+	}
+	s.writers[bufferName] = w
+	return w, nil
+}
+
+// Close closes the writers of the reply buffers.
+func (s *ToReply) Close() error {
+	for _, w := range s.writers {
+		if err := w.Close(); err != nil {
+			s.logger.Errorw("Failed to close the reply buffer writer", zap.String("buffer", w.GetName()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Start starts sinking to the reply buffers.
+func (s *ToReply) Start() <-chan struct{} {
+	return s.isdf.Start()
+}
+
+// Stop stops sinking
+func (s *ToReply) Stop() {
+	s.isdf.Stop()
+}
+
+// ForceStop stops sinking
+func (s *ToReply) ForceStop() {
+	s.isdf.ForceStop()
+}
+
+// CheckProgress checks the sinking makes progress
+func (s *ToReply) CheckProgress(threshold time.Duration) error {
+	return s.isdf.CheckProgress(threshold)
+}
@@ -0,0 +1,98 @@
+package reply
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/simplebuffer"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testVertex = &dfv1.Vertex{
+	ObjectMeta: v1.ObjectMeta{Namespace: "test-ns", Name: "test-pl-out"},
+	Spec: dfv1.VertexSpec{
+		PipelineName:   "test-pl",
+		AbstractVertex: dfv1.AbstractVertex{Name: "out"},
+	},
+}
+
+func replyMessage(id, correlationID, replyTo string) isb.Message {
+	metadata := map[string]string{}
+	if correlationID != "" {
+		metadata[dfv1.ReplyCorrelationIDKey] = correlationID
+	}
+	if replyTo != "" {
+		metadata[dfv1.ReplyToKey] = replyTo
+	}
+	return isb.Message{Header: isb.Header{ID: id, Metadata: metadata}, Body: isb.Body{Payload: []byte("reply-" + id)}}
+}
+
+func TestToReply_Write(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 10)
+	buffers := map[string]*simplebuffer.InMemoryBuffer{}
+	newWriter := func(bufferName string) (isb.BufferWriter, error) {
+		if bufferName == "test-ns-test-pl-in-reply-1" {
+			return nil, fmt.Errorf("no such buffer")
+		}
+		b := simplebuffer.NewInMemoryBuffer(bufferName, 10)
+		buffers[bufferName] = b
+		return b, nil
+	}
+	s, err := NewToReply(testVertex, fromStep, newWriter)
+	assert.NoError(t, err)
+
+	_, errs := s.Write(ctx, []isb.Message{
+		replyMessage("1", "a", "test-ns-test-pl-in-reply-0"),
+		replyMessage("2", "b", "test-ns-test-pl-in-reply-0"),
+		replyMessage("3", "c", "test-ns-test-pl-in-reply-1"),
+		replyMessage("4", "", "test-ns-test-pl-in-reply-0"),
+		replyMessage("5", "d", ""),
+		replyMessage("6", "e", "test-ns-test-pl-in-p1"),
+		replyMessage("7", "f", "test-ns-another-pl-in-reply-0"),
+	})
+	assert.Equal(t, make([]error, 7), errs)
+	assert.Equal(t, 1, len(buffers))
+	msgs, err := buffers["test-ns-test-pl-in-reply-0"].Read(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("reply-1"), msgs[0].Payload)
+	assert.Equal(t, "a", msgs[0].Metadata[dfv1.ReplyCorrelationIDKey])
+	assert.Equal(t, []byte("reply-2"), msgs[1].Payload)
+	assert.True(t, buffers["test-ns-test-pl-in-reply-0"].IsEmpty())
+	assert.NoError(t, s.Close())
+}
+
+func TestToReply_isReplyBuffer(t *testing.T) {
+	s := &ToReply{bufferPrefix: "test-ns-test-pl-"}
+	assert.True(t, s.isReplyBuffer("test-ns-test-pl-in-reply-0"))
+	assert.True(t, s.isReplyBuffer("test-ns-test-pl-my-reply-source-reply-12"))
+	assert.False(t, s.isReplyBuffer("test-ns-test-pl-in-reply-"))
+	assert.False(t, s.isReplyBuffer("test-ns-test-pl-in-reply-x"))
+	assert.False(t, s.isReplyBuffer("test-ns-test-pl-reply-0"))
+	assert.False(t, s.isReplyBuffer("test-ns-test-pl-in-p1"))
+	assert.False(t, s.isReplyBuffer("other-ns-test-pl-in-reply-0"))
+}
+
+func TestToReply_Start(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 10)
+	replyBuffer := simplebuffer.NewInMemoryBuffer("test-ns-test-pl-in-reply-0", 10)
+	s, err := NewToReply(testVertex, fromStep, func(string) (isb.BufferWriter, error) { return replyBuffer, nil })
+	assert.NoError(t, err)
+	stopped := s.Start()
+	_, errs := fromStep.Write(ctx, []isb.Message{replyMessage("1", "a", replyBuffer.GetName())})
+	assert.Equal(t, make([]error, 1), errs)
+	msgs, err := replyBuffer.Read(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(msgs))
+	assert.Equal(t, []byte("reply-1"), msgs[0].Payload)
+	s.Stop()
+	<-stopped
+}
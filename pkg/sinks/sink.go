@@ -4,19 +4,27 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
+	"github.com/numaproj/numaflow/pkg/isb/fanin"
+	"github.com/numaproj/numaflow/pkg/isb/forward"
 	jetstreamisb "github.com/numaproj/numaflow/pkg/isb/jetstream"
+	kafkaisb "github.com/numaproj/numaflow/pkg/isb/kafka"
 	redisisb "github.com/numaproj/numaflow/pkg/isb/redis"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
 	"github.com/numaproj/numaflow/pkg/metrics"
+	"github.com/numaproj/numaflow/pkg/shared/lifecycle"
 
 	"go.uber.org/zap"
 
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	kafkasink "github.com/numaproj/numaflow/pkg/sinks/kafka"
 	logsink "github.com/numaproj/numaflow/pkg/sinks/logger"
+	replysink "github.com/numaproj/numaflow/pkg/sinks/reply"
 	udsink "github.com/numaproj/numaflow/pkg/sinks/udsink"
 )
 
@@ -31,32 +39,57 @@ func (u *SinkProcessor) Start(ctx context.Context) error {
 	log := logging.FromContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	var reader isb.BufferReader
-	var err error
-	fromBufferName := u.Vertex.GetFromBuffers()[0]
+	var readers []isb.BufferReader
+	fromBuffers := u.Vertex.GetFromBuffers()
 	switch u.ISBSvcType {
 	case dfv1.ISBSvcTypeRedis:
 		redisClient := clients.NewInClusterRedisClient()
-		fromGroup := fromBufferName + "-group"
 		consumer := fmt.Sprintf("%s-%v", u.Vertex.Name, u.Replica)
-		reader = redisisb.NewBufferRead(ctx, redisClient, fromBufferName, fromGroup, consumer)
+		for _, fromBufferName := range fromBuffers {
+			fromGroup := fromBufferName + "-group"
+			readers = append(readers, redisisb.NewBufferRead(ctx, redisClient, fromBufferName, fromGroup, consumer))
+		}
 	case dfv1.ISBSvcTypeJetStream:
-		streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, fromBufferName)
-		jetStreamClient := clients.NewInClusterJetStreamClient()
-		reader, err = jetstreamisb.NewJetStreamBufferReader(ctx, jetStreamClient, fromBufferName, streamName, streamName)
-		if err != nil {
-			return err
+		jetStreamClient := clients.InClusterSharedJetStreamClient()
+		for _, fromBufferName := range fromBuffers {
+			streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, fromBufferName)
+			reader, err := jetstreamisb.NewJetStreamBufferReader(ctx, jetStreamClient, fromBufferName, streamName, streamName, jetstreamisb.ReadOptionsFor(u.Vertex, fromBufferName)...)
+			if err != nil {
+				return err
+			}
+			readers = append(readers, reader)
+		}
+	case dfv1.ISBSvcTypeKafka:
+		kafkaClient := clients.NewInClusterKafkaClient()
+		for _, fromBufferName := range fromBuffers {
+			reader, err := kafkaisb.NewKafkaBufferReader(ctx, kafkaClient, fromBufferName, fromBufferName)
+			if err != nil {
+				return err
+			}
+			readers = append(readers, reader)
 		}
 	default:
 		return fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
 	}
+	reader, err := fanin.NewReader(readers, u.Vertex.GetFromBufferReadWeights())
+	if err != nil {
+		return err
+	}
 
-	sinker, err := u.getSinker(reader, log)
+	auditRecorder, err := audit.NewInClusterRecorder(ctx, u.ISBSvcType, u.Vertex, u.Hostname, log)
+	if err != nil {
+		return fmt.Errorf("failed to create the audit recorder, error: %w", err)
+	}
+	tracer := telemetry.NewInClusterTracer(u.Vertex, u.Hostname)
+	if tracer != nil {
+		go tracer.Run(ctx)
+	}
+	sinker, err := u.getSinker(ctx, reader, auditRecorder, tracer, log)
 	if err != nil {
 		return fmt.Errorf("failed to find a sink, errpr: %w", err)
 	}
 
-	log.Infow("Start processing sink messages", zap.String("isbs", string(u.ISBSvcType)), zap.String("from", fromBufferName))
+	log.Infow("Start processing sink messages", zap.String("isbs", string(u.ISBSvcType)), zap.Strings("from", fromBuffers))
 	stopped := sinker.Start()
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
@@ -69,29 +102,83 @@ func (u *SinkProcessor) Start(ctx context.Context) error {
 		}
 	}()
 
-	if shutdown, err := metrics.StartMetricsServer(ctx); err != nil {
+	drainer := lifecycle.NewDrainer()
+	// Not ready once drained, so that the controller knows the replica can be deleted when scaling down.
+	metricsOpts := []metrics.Option{metrics.WithReadiness(func() bool { return !drainer.Drained() })}
+	// Not live once the forwarder is stuck, so that the pod is restarted.
+	if c, ok := sinker.(forward.ProgressChecker); ok {
+		if threshold := u.Vertex.Spec.Liveness.GetStuckThreshold(); threshold > 0 {
+			metricsOpts = append(metricsOpts, metrics.WithLiveness(func() error { return c.CheckProgress(threshold) }))
+		}
+	}
+	if shutdown, err := metrics.StartMetricsServer(ctx, metricsOpts...); err != nil {
 		return fmt.Errorf("failed to start metrics server, error: %w", err)
 	} else {
 		defer func() { _ = shutdown(context.Background()) }()
 	}
 
-	<-ctx.Done()
-	log.Info("SIGTERM, exiting...")
+	if u.Vertex.Spec.Sink.UDSink != nil { // the sidecar container waits for the draining in its pre-stop hook
+		if shutdown, err := lifecycle.StartPreStopServer(ctx, drainer); err != nil {
+			return fmt.Errorf("failed to start pre-stop hook server, error: %w", err)
+		} else {
+			defer func() { _ = shutdown(context.Background()) }()
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		log.Info("SIGTERM, exiting...")
+	case <-lifecycle.WatchDrainRequest(ctx, 5*time.Second):
+		log.Info("Marked to be drained before scaling down, stop processing...")
+	}
 	sinker.Stop()
 	wg.Wait()
+	drainer.Done()
+	<-ctx.Done()
 	log.Info("Exited...")
 	return nil
 }
 
 // getSinker takes in the logger from the parent context
-func (u *SinkProcessor) getSinker(reader isb.BufferReader, logger *zap.SugaredLogger) (Sinker, error) {
+func (u *SinkProcessor) getSinker(ctx context.Context, reader isb.BufferReader, auditRecorder *audit.Recorder, tracer *telemetry.Tracer, logger *zap.SugaredLogger) (Sinker, error) {
 	sink := u.Vertex.Spec.Sink
 	if x := sink.Log; x != nil {
-		return logsink.NewToLog(u.Vertex, reader, logsink.WithLogger(logger))
+		return logsink.NewToLog(u.Vertex, reader, logsink.WithLogger(logger), logsink.WithAuditRecorder(auditRecorder), logsink.WithTracer(tracer))
 	} else if x := sink.Kafka; x != nil {
-		return kafkasink.NewToKafka(u.Vertex, reader, kafkasink.WithLogger(logger))
+		return kafkasink.NewToKafka(u.Vertex, reader, kafkasink.WithLogger(logger), kafkasink.WithAuditRecorder(auditRecorder), kafkasink.WithTracer(tracer))
 	} else if x := sink.UDSink; x != nil {
-		return udsink.NewUserDefinedSink(u.Vertex, reader, udsink.WithLogger(logger))
+		return udsink.NewUserDefinedSink(u.Vertex, reader, udsink.WithLogger(logger), udsink.WithAuditRecorder(auditRecorder), udsink.WithTracer(tracer))
+	} else if x := sink.Reply; x != nil {
+		newWriter, err := u.getReplyWriterFactory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return replysink.NewToReply(u.Vertex, reader, newWriter, replysink.WithLogger(logger), replysink.WithAuditRecorder(auditRecorder), replysink.WithTracer(tracer))
 	}
 	return nil, fmt.Errorf("invalid sink spec")
 }
+
+// getReplyWriterFactory returns the factory creating the writers of the reply buffers of the http sources, the writers
+// live as long as the context.
+func (u *SinkProcessor) getReplyWriterFactory(ctx context.Context) (replysink.WriterFactory, error) {
+	switch u.ISBSvcType {
+	case dfv1.ISBSvcTypeRedis:
+		redisClient := clients.NewInClusterRedisClient()
+		return func(bufferName string) (isb.BufferWriter, error) {
+			return redisisb.NewBufferWrite(ctx, redisClient, bufferName, bufferName+"-group"), nil
+		}, nil
+	case dfv1.ISBSvcTypeJetStream:
+		jetStreamClient := clients.InClusterSharedJetStreamClient()
+		return func(bufferName string) (isb.BufferWriter, error) {
+			streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, bufferName)
+			return jetstreamisb.NewJetStreamBufferWriter(ctx, jetStreamClient, bufferName, streamName, streamName)
+		}, nil
+	case dfv1.ISBSvcTypeKafka:
+		kafkaClient := clients.NewInClusterKafkaClient()
+		return func(bufferName string) (isb.BufferWriter, error) {
+			return kafkaisb.NewKafkaBufferWriter(ctx, kafkaClient, bufferName, bufferName)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
+	}
+}
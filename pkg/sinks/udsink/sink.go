@@ -6,7 +6,9 @@ import (
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
 	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
 	"github.com/numaproj/numaflow/pkg/udf/applier"
@@ -20,6 +22,10 @@ type userDefinedSink struct {
 	isdf         *forward.InterStepDataForward
 	logger       *zap.SugaredLogger
 	udsink       *udsHTTPBasedUDSink
+	// auditRecorder records the audit IDs of the processed messages
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced messages
+	tracer *telemetry.Tracer
 }
 
 type Option func(*userDefinedSink) error
@@ -31,6 +37,22 @@ func WithLogger(log *zap.SugaredLogger) Option {
 	}
 }
 
+// WithAuditRecorder records the audit IDs of the processed messages
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(t *userDefinedSink) error {
+		t.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced messages
+func WithTracer(tracer *telemetry.Tracer) Option {
+	return func(t *userDefinedSink) error {
+		t.tracer = tracer
+		return nil
+	}
+}
+
 // NewUserDefinedSink returns genericSink type.
 func NewUserDefinedSink(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, opts ...Option) (*userDefinedSink, error) {
 	s := new(userDefinedSink)
@@ -52,8 +74,23 @@ func NewUserDefinedSink(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, opts .
 			forwardOpts = append(forwardOpts, forward.WithReadBatchSize(int64(*x.ReadBatchSize)))
 		}
 	}
+	if x := vertex.Spec.SlowStart; x != nil {
+		forwardOpts = append(forwardOpts, forward.WithSlowStart(x.GetDuration(), int64(x.GetInitialReadBatchSize())))
+	}
 	contentType := sharedutil.LookupEnvStringOr(dfv1.EnvUDSinkContentType, string(dfv1.MsgPackType))
 	s.udsink = NewUDSHTTPBasedUDSink(dfv1.PathVarRun+"/udsink.sock", withTimeout(20*time.Second), withContentType(dfv1.ContentType(contentType)))
+	if vertex.Spec.TenantKey != "" {
+		forwardOpts = append(forwardOpts, forward.WithTenantKey(vertex.Spec.TenantKey))
+	}
+	if ages := vertex.GetFromBufferMaxMessageAges(); len(ages) > 0 {
+		forwardOpts = append(forwardOpts, forward.WithMaxMessageAges(ages))
+	}
+	if s.auditRecorder != nil {
+		forwardOpts = append(forwardOpts, forward.WithAuditRecorder(s.auditRecorder))
+	}
+	if s.tracer != nil {
+		forwardOpts = append(forwardOpts, forward.WithTracer(s.tracer))
+	}
 	isdf, err := forward.NewInterStepDataForward(vertex, fromBuffer, map[string]isb.BufferWriter{name: s}, forward.All, applier.Terminal, forwardOpts...)
 	if err != nil {
 		return nil, err
@@ -100,3 +137,8 @@ func (s *userDefinedSink) Stop() {
 func (s *userDefinedSink) ForceStop() {
 	s.isdf.ForceStop()
 }
+
+// CheckProgress checks the sinking makes progress
+func (s *userDefinedSink) CheckProgress(threshold time.Duration) error {
+	return s.isdf.CheckProgress(threshold)
+}
@@ -7,11 +7,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net"
 	"net/http"
 	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/httpclient"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	sinksdk "github.com/numaproj/numaflow/sdks/golang/sink"
 	"github.com/vmihailenco/msgpack/v5"
@@ -50,18 +50,12 @@ func NewUDSHTTPBasedUDSink(socketPath string, opts ...udsSinkOption) *udsHTTPBas
 	for _, o := range opts {
 		o(options)
 	}
-	var httpClient *http.Client
-	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
-	httpTransport.MaxIdleConns = 100
-	httpTransport.MaxConnsPerHost = 100
-	httpTransport.MaxIdleConnsPerHost = 100
-	httpTransport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-		return net.Dial("unix", socketPath)
-	}
-	httpClient = &http.Client{
-		Transport: httpTransport,
-		Timeout:   options.httpClientTimeout,
-	}
+	httpClient := httpclient.NewClient(options.httpClientTimeout,
+		httpclient.WithUnixSocket(socketPath),
+		// all our connects are loop back
+		httpclient.WithMaxIdleConns(100),
+		httpclient.WithMaxConnsPerHost(100),
+	)
 	return &udsHTTPBasedUDSink{
 		client:      httpClient,
 		contentType: options.contentType,
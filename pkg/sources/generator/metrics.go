@@ -18,3 +18,10 @@ var tickgenSourceCount = promauto.NewCounterVec(prometheus.CounterOpts{
 	Name:      "total",
 	Help:      "Total number of times tickgen source has ticked",
 }, []string{"vertex", "pipeline"})
+
+// tickgenSourceRate is used to indicate the number of messages generated per time unit by the load profile
+var tickgenSourceRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: "tickgen_source",
+	Name:      "rate",
+	Help:      "Number of messages generated per time unit by the load profile",
+}, []string{"vertex", "pipeline"})
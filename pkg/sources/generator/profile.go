@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// rateFunc returns the number of the records to generate in the time unit, given the time elapsed since the start.
+type rateFunc func(elapsed time.Duration) int
+
+// newRateFunc returns the rate function of a load profile, rpu being the base rate.
+func newRateFunc(rpu int, lp *dfv1.LoadProfile) (rateFunc, error) {
+	if lp == nil || lp.Type == "" || lp.Type == dfv1.LoadProfileSteady {
+		return func(time.Duration) int { return rpu }, nil
+	}
+	peak := rpu
+	if lp.PeakRPU != nil {
+		peak = int(*lp.PeakRPU)
+	}
+	period := lp.GetPeriod()
+	if period <= 0 {
+		return nil, fmt.Errorf("load profile period should be greater than 0")
+	}
+	switch lp.Type {
+	case dfv1.LoadProfileSpike:
+		spike := lp.GetSpikeDuration()
+		return func(elapsed time.Duration) int {
+			if elapsed%period < spike {
+				return peak
+			}
+			return rpu
+		}, nil
+	case dfv1.LoadProfileSine:
+		// starts from the trough, reaches the peak at the half of the period
+		return func(elapsed time.Duration) int {
+			phase := 2 * math.Pi * float64(elapsed%period) / float64(period)
+			return rpu + int(math.Round(float64(peak-rpu)*(1-math.Cos(phase))/2))
+		}, nil
+	case dfv1.LoadProfileRamp:
+		return func(elapsed time.Duration) int {
+			if elapsed >= period {
+				return peak
+			}
+			return rpu + int(math.Round(float64(peak-rpu)*float64(elapsed)/float64(period)))
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported load profile %q", lp.Type)
+	}
+}
+
+// keyPicker picks the keys of the generated records, it's safe for concurrent use.
+type keyPicker struct {
+	sync.Mutex
+	keys [][]byte
+	next func() uint64
+}
+
+func newKeyPicker(k *dfv1.GeneratorKeys) (*keyPicker, error) {
+	if k.Count <= 0 {
+		return nil, fmt.Errorf("key count should be greater than 0")
+	}
+	kp := &keyPicker{}
+	for i := int32(0); i < k.Count; i++ {
+		kp.keys = append(kp.keys, []byte(fmt.Sprintf("key-%d", i)))
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	switch k.Distribution {
+	case "", dfv1.KeyDistributionUniform:
+		kp.next = func() uint64 { return uint64(r.Int63n(int64(k.Count))) }
+	case dfv1.KeyDistributionZipf:
+		if k.Count == 1 {
+			kp.next = func() uint64 { return 0 }
+			break
+		}
+		z := rand.NewZipf(r, 1.1, 1, uint64(k.Count-1))
+		kp.next = z.Uint64
+	default:
+		return nil, fmt.Errorf("unsupported key distribution %q", k.Distribution)
+	}
+	return kp, nil
+}
+
+func (kp *keyPicker) pick() []byte {
+	kp.Lock()
+	defer kp.Unlock()
+	return kp.keys[kp.next()]
+}
+
+// jitterFunc returns the sorted delays of n records from the beginning of the time unit.
+type jitterFunc func(n int) []time.Duration
+
+// newJitterFunc returns the jitter function of the distribution, the delays being capped at the max.
+func newJitterFunc(j *dfv1.GeneratorJitter) (jitterFunc, error) {
+	max := j.GetMax()
+	if max <= 0 {
+		return nil, fmt.Errorf("jitter max should be greater than 0")
+	}
+	var mu sync.Mutex
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var next func() float64 // a delay in units of max
+	switch j.Distribution {
+	case "", dfv1.JitterDistributionUniform:
+		next = r.Float64
+	case dfv1.JitterDistributionNormal:
+		// 99.7% of the delays are within 3 standard deviations, i.e. between 0 and max
+		next = func() float64 { return 0.5 + r.NormFloat64()/6 }
+	case dfv1.JitterDistributionExponential:
+		// the mean is a quarter of max, 98% of the delays are less than max
+		next = func() float64 { return r.ExpFloat64() / 4 }
+	default:
+		return nil, fmt.Errorf("unsupported jitter distribution %q", j.Distribution)
+	}
+	return func(n int) []time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		delays := make([]time.Duration, n)
+		for i := range delays {
+			d := time.Duration(next() * float64(max))
+			if d < 0 {
+				d = 0
+			} else if d > max {
+				d = max
+			}
+			delays[i] = d
+		}
+		sort.Slice(delays, func(i, k int) bool { return delays[i] < delays[k] })
+		return delays
+	}, nil
+}
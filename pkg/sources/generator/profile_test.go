@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+func TestNewRateFunc(t *testing.T) {
+	period := &metav1.Duration{Duration: 100 * time.Second}
+	t.Run("steady", func(t *testing.T) {
+		f, err := newRateFunc(5, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, f(time.Hour))
+	})
+	t.Run("spike", func(t *testing.T) {
+		f, err := newRateFunc(5, &dfv1.LoadProfile{Type: dfv1.LoadProfileSpike, PeakRPU: pointer.Int64(50), Period: period, SpikeDuration: &metav1.Duration{Duration: 10 * time.Second}})
+		assert.NoError(t, err)
+		assert.Equal(t, 50, f(0))
+		assert.Equal(t, 50, f(9*time.Second))
+		assert.Equal(t, 5, f(10*time.Second))
+		assert.Equal(t, 50, f(105*time.Second))
+	})
+	t.Run("sine", func(t *testing.T) {
+		f, err := newRateFunc(10, &dfv1.LoadProfile{Type: dfv1.LoadProfileSine, PeakRPU: pointer.Int64(30), Period: period})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, f(0))
+		assert.Equal(t, 20, f(25*time.Second))
+		assert.Equal(t, 30, f(50*time.Second))
+		assert.Equal(t, 10, f(100*time.Second))
+	})
+	t.Run("ramp", func(t *testing.T) {
+		f, err := newRateFunc(10, &dfv1.LoadProfile{Type: dfv1.LoadProfileRamp, PeakRPU: pointer.Int64(110), Period: period})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, f(0))
+		assert.Equal(t, 60, f(50*time.Second))
+		assert.Equal(t, 110, f(time.Hour))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newRateFunc(10, &dfv1.LoadProfile{Type: "square"})
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyPicker(t *testing.T) {
+	_, err := newKeyPicker(&dfv1.GeneratorKeys{})
+	assert.Error(t, err)
+	for _, d := range []dfv1.KeyDistribution{dfv1.KeyDistributionUniform, dfv1.KeyDistributionZipf} {
+		kp, err := newKeyPicker(&dfv1.GeneratorKeys{Count: 3, Distribution: d})
+		assert.NoError(t, err)
+		seen := map[string]int{}
+		for i := 0; i < 1000; i++ {
+			seen[string(kp.pick())]++
+		}
+		assert.Len(t, seen, 3)
+		if d == dfv1.KeyDistributionZipf {
+			assert.Greater(t, seen["key-0"], seen["key-2"])
+		}
+	}
+}
+
+func TestJitterFunc(t *testing.T) {
+	_, err := newJitterFunc(&dfv1.GeneratorJitter{})
+	assert.Error(t, err)
+	max := &metav1.Duration{Duration: time.Second}
+	_, err = newJitterFunc(&dfv1.GeneratorJitter{Max: max, Distribution: "pareto"})
+	assert.Error(t, err)
+	for _, d := range []dfv1.JitterDistribution{dfv1.JitterDistributionUniform, dfv1.JitterDistributionNormal, dfv1.JitterDistributionExponential} {
+		jitter, err := newJitterFunc(&dfv1.GeneratorJitter{Max: max, Distribution: d})
+		assert.NoError(t, err)
+		delays := jitter(1000)
+		assert.Len(t, delays, 1000)
+		var sum time.Duration
+		for i, delay := range delays {
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, time.Second)
+			if i > 0 {
+				assert.GreaterOrEqual(t, delay, delays[i-1])
+			}
+			sum += delay
+		}
+		mean := sum / 1000
+		switch d {
+		case dfv1.JitterDistributionExponential:
+			assert.Less(t, mean, 400*time.Millisecond)
+		default:
+			assert.Greater(t, mean, 400*time.Millisecond)
+			assert.Less(t, mean, 600*time.Millisecond)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+var (
+	fakeFirstNames = []string{"James", "Mary", "Robert", "Patricia", "John", "Jennifer", "Michael", "Linda", "David", "Elizabeth", "Wei", "Priya", "Carlos", "Yuki", "Fatima", "Olga"}
+	fakeLastNames  = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Wang", "Patel", "Rodriguez", "Tanaka", "Khan", "Ivanova", "Martin", "Lee"}
+	fakeCities     = []string{"New York", "London", "Tokyo", "Paris", "Berlin", "Sydney", "Toronto", "Mumbai", "Shanghai", "Sao Paulo", "Cairo", "Madrid", "Seoul", "Mexico City", "Moscow", "Lagos"}
+	fakeCountries  = []string{"US", "GB", "JP", "FR", "DE", "AU", "CA", "IN", "CN", "BR", "EG", "ES", "KR", "MX", "RU", "NG"}
+	fakeDomains    = []string{"example.com", "example.org", "example.net", "test.io"}
+	fakeWords      = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet", "kilo", "lima", "mike", "november", "oscar", "papa"}
+)
+
+// fakeFuncMap returns the functions generating fake data in the payload templates.
+func fakeFuncMap() template.FuncMap {
+	pick := func(s []string) string { return s[rand.Intn(len(s))] }
+	return template.FuncMap{
+		"fakeFirstName": func() string { return pick(fakeFirstNames) },
+		"fakeLastName":  func() string { return pick(fakeLastNames) },
+		"fakeName":      func() string { return pick(fakeFirstNames) + " " + pick(fakeLastNames) },
+		"fakeEmail": func() string {
+			return strings.ToLower(pick(fakeFirstNames)+"."+pick(fakeLastNames)) + "@" + pick(fakeDomains)
+		},
+		"fakeCity":    func() string { return pick(fakeCities) },
+		"fakeCountry": func() string { return pick(fakeCountries) },
+		"fakeWord":    func() string { return pick(fakeWords) },
+		"fakeIPv4": func() string {
+			return fmt.Sprintf("%d.%d.%d.%d", rand.Intn(223)+1, rand.Intn(256), rand.Intn(256), rand.Intn(254)+1)
+		},
+		"fakeBool": func() bool { return rand.Intn(2) == 1 },
+		// randFloat returns a random number in [min, max)
+		"randFloat": func(min, max float64) float64 { return min + rand.Float64()*(max-min) },
+	}
+}
+
+// templateData is the data the payload templates are executed with.
+type templateData struct {
+	// Createdts is the creation time of the payload in nanoseconds
+	Createdts int64
+}
+
+// newTemplateGenerator returns the function generating the payloads from the template, the size is ignored.
+func newTemplateGenerator(text string) (func(int32) []byte, error) {
+	tpl, err := template.New("payload").Funcs(sprig.TxtFuncMap()).Funcs(fakeFuncMap()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the payload template, %w", err)
+	}
+	return func(int32) []byte {
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, templateData{Createdts: time.Now().UnixNano()}); err != nil {
+			log.Errorw("Failed to execute the payload template", "error", err)
+		}
+		return buf.Bytes()
+	}, nil
+}
@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTemplateGenerator(t *testing.T) {
+	_, err := newTemplateGenerator(`{"name": "{{ fakeName }"}`)
+	assert.Error(t, err)
+	_, err = newTemplateGenerator(`{"name": "{{ fakeNonExisting }}"}`)
+	assert.Error(t, err)
+
+	genfn, err := newTemplateGenerator(`{"id": "{{ uuidv4 }}", "email": "{{ fakeEmail }}", "ip": "{{ fakeIPv4 }}", "amount": {{ randFloat 1 10 }}, "Createdts": {{ .Createdts }}}`)
+	assert.NoError(t, err)
+	before := time.Now().UnixNano()
+	payload := genfn(1024)
+	var v map[string]interface{}
+	assert.NoError(t, json.Unmarshal(payload, &v))
+	assert.Len(t, v["id"], 36)
+	assert.True(t, strings.Contains(v["email"].(string), "@"))
+	assert.Len(t, strings.Split(v["ip"].(string), "."), 4)
+	assert.GreaterOrEqual(t, v["amount"], 1.0)
+	assert.Less(t, v["amount"], 10.0)
+	// the event time is taken from the Createdts field
+	assert.GreaterOrEqual(t, parseTime(payload), before)
+}
@@ -6,14 +6,19 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
 	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/numaproj/numaflow/pkg/udf/applier"
 	"go.uber.org/zap"
@@ -33,6 +38,7 @@ type payload struct {
 // internal construct of this package
 type record struct {
 	data   []byte
+	key    []byte
 	offset int64
 }
 
@@ -54,6 +60,14 @@ type memgen struct {
 	srcchan chan record
 	// rpu - records per time unit
 	rpu int
+	// loadProfile varies the records per time unit over time, steady if it's nil
+	loadProfile *dfv1.LoadProfile
+	// rate returns the records per time unit given the time elapsed since the start
+	rate rateFunc
+	// keySpec sets the keys of the records, no keys if it's nil
+	keySpec *dfv1.GeneratorKeys
+	// keys picks the keys of the records
+	keys *keyPicker
 	// msgSize is the size of each generated message
 	msgSize int32
 	// timeunit - ticker will fire once per timeunit and generates
@@ -61,6 +75,8 @@ type memgen struct {
 	timeunit time.Duration
 	// genfn function that generates a payload as a byte array
 	genfn func(int32) []byte
+	// jitter returns the delays of the records generated per time unit, all generated at the beginning if it's nil
+	jitter jitterFunc
 	// name is the name of the source node
 	name string
 	// pipelineName is the name of the pipeline
@@ -70,10 +86,25 @@ type memgen struct {
 	cancel context.CancelFunc
 	// forwarder to read from the source and write to the interstep buffer.
 	forwarder *forward.InterStepDataForward
+	// rateLimiter admits the messages read from the source, not limited if it's nil
+	rateLimiter forward.RateLimiter
+	// traceRecorders sample the messages written to the traced buffers
+	traceRecorders tracing.Recorders
+	// auditRecorder stamps and records the audit IDs of the emitted messages
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced messages
+	tracer *telemetry.Tracer
 	// lifecycleCtx context is used to control the lifecycle of this instance.
 	lifecycleCtx context.Context
 	// read timeout for the reader
 	readTimeout time.Duration
+	// maxMessages is the number of records generated before reaching the end, unbounded if it's 0
+	maxMessages int64
+	// generated is the number of records generated, and sent is the number of them sent to srcchan
+	generated int64
+	sent      int64
+	// endOnce stops the forwarder once, after all the records are read
+	endOnce sync.Once
 
 	logger *zap.SugaredLogger
 }
@@ -88,6 +119,89 @@ func WithLogger(l *zap.SugaredLogger) Option {
 	}
 }
 
+// WithRateLimiter sets the rate limiter admitting the messages read from the source
+func WithRateLimiter(l forward.RateLimiter) Option {
+	return func(o *memgen) error {
+		o.rateLimiter = l
+		return nil
+	}
+}
+
+// WithTraceRecorders samples the messages written to the traced buffers
+func WithTraceRecorders(r tracing.Recorders) Option {
+	return func(o *memgen) error {
+		o.traceRecorders = r
+		return nil
+	}
+}
+
+// WithAuditRecorder stamps the audit IDs of the emitted messages, and records them
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(o *memgen) error {
+		o.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced messages
+func WithTracer(tracer *telemetry.Tracer) Option {
+	return func(o *memgen) error {
+		o.tracer = tracer
+		return nil
+	}
+}
+
+// WithLoadProfile sets the load profile varying the records per time unit over time
+func WithLoadProfile(lp *dfv1.LoadProfile) Option {
+	return func(o *memgen) error {
+		o.loadProfile = lp
+		return nil
+	}
+}
+
+// WithKeys sets the keys of the generated records
+func WithKeys(k *dfv1.GeneratorKeys) Option {
+	return func(o *memgen) error {
+		o.keySpec = k
+		return nil
+	}
+}
+
+// WithMaxMessages sets the number of records generated before reaching the end
+func WithMaxMessages(n int64) Option {
+	return func(o *memgen) error {
+		if n <= 0 {
+			return fmt.Errorf("max messages should be greater than 0")
+		}
+		o.maxMessages = n
+		return nil
+	}
+}
+
+// WithTemplate sets the Go template generating the payloads
+func WithTemplate(text string) Option {
+	return func(o *memgen) error {
+		genfn, err := newTemplateGenerator(text)
+		if err != nil {
+			return err
+		}
+		o.genfn = genfn
+		return nil
+	}
+}
+
+// WithJitter spreads the records generated per time unit over the random delays of the jitter
+func WithJitter(j *dfv1.GeneratorJitter) Option {
+	return func(o *memgen) error {
+		jitter, err := newJitterFunc(j)
+		if err != nil {
+			return err
+		}
+		o.jitter = jitter
+		return nil
+	}
+}
+
 func WithReadTimeOut(timeout time.Duration) Option {
 	return func(o *memgen) error {
 		o.readTimeout = timeout
@@ -99,7 +213,7 @@ func WithReadTimeOut(timeout time.Duration) Option {
 // ctx  - context passed by the cmd/start.go a new context with cancel
 //        is created for use by this vertex.
 // name - name of this vertex
-// rpu  - no of records to generate per time unit. by default the channel buffer size is set to 5*rpu
+// rpu  - no of records to generate per time unit, the base rate of the load profile. the channel buffer size is set to 5*max(rpu, peak rpu)
 // msgSize - size of each generated message
 // timeunit - unit of time per tick. could be any golang time.Duration.
 // writers - destinations to write to
@@ -111,7 +225,6 @@ func NewMemGen(vertex *dfv1.Vertex, rpu int, msgSize int32, timeunit time.Durati
 		name:         vertex.Spec.Name,
 		pipelineName: vertex.Spec.PipelineName,
 		genfn:        recordGenerator,
-		srcchan:      make(chan record, rpu*5),
 		readTimeout:  3 * time.Second, // default timeout
 	}
 
@@ -120,6 +233,22 @@ func NewMemGen(vertex *dfv1.Vertex, rpu int, msgSize int32, timeunit time.Durati
 			return nil, err
 		}
 	}
+	rate, err := newRateFunc(rpu, gensrc.loadProfile)
+	if err != nil {
+		return nil, err
+	}
+	gensrc.rate = rate
+	// the channel buffer size is 5 times the highest rate of the profile
+	maxRate := rpu
+	if x := gensrc.loadProfile; x != nil && x.PeakRPU != nil && int(*x.PeakRPU) > maxRate {
+		maxRate = int(*x.PeakRPU)
+	}
+	gensrc.srcchan = make(chan record, maxRate*5)
+	if gensrc.keySpec != nil {
+		if gensrc.keys, err = newKeyPicker(gensrc.keySpec); err != nil {
+			return nil, err
+		}
+	}
 	if gensrc.logger == nil {
 		gensrc.logger = logging.NewLogger()
 	}
@@ -141,7 +270,22 @@ func NewMemGen(vertex *dfv1.Vertex, rpu int, msgSize int32, timeunit time.Durati
 			forwardOpts = append(forwardOpts, forward.WithReadBatchSize(int64(*x.ReadBatchSize)))
 		}
 	}
+	if gensrc.rateLimiter != nil {
+		forwardOpts = append(forwardOpts, forward.WithRateLimiter(gensrc.rateLimiter))
+	}
+	if gensrc.traceRecorders != nil {
+		forwardOpts = append(forwardOpts, forward.WithTraceRecorders(gensrc.traceRecorders))
+	}
+	if gensrc.auditRecorder != nil {
+		forwardOpts = append(forwardOpts, forward.WithAuditRecorder(gensrc.auditRecorder))
+	}
+	if gensrc.tracer != nil {
+		forwardOpts = append(forwardOpts, forward.WithTracer(gensrc.tracer))
+	}
 	// we pass in the context to forwarder as well so that it can shut down when we cancel the context
+	if vertex.Spec.TenantKey != "" {
+		forwardOpts = append(forwardOpts, forward.WithTenantKey(vertex.Spec.TenantKey))
+	}
 	forwarder, err := forward.NewInterStepDataForward(vertex, gensrc, destinations, forward.All, applier.Terminal, forwardOpts...)
 	if err != nil {
 		return nil, err
@@ -176,9 +320,12 @@ func (mg *memgen) Read(ctx context.Context, count int64) ([]*isb.ReadMessage, er
 		select {
 		case r := <-mg.srcchan:
 			tickgenSourceReadCount.With(map[string]string{"vertex": mg.name, "pipeline": mg.pipelineName}).Inc()
-			msgs = append(msgs, newreadmessage(r.data, r.offset))
+			msgs = append(msgs, newreadmessage(r.data, r.key, r.offset))
 		case <-time.After(mg.readTimeout):
 			mg.logger.Debugw("Timed out waiting for messages to read.", zap.Duration("waited", mg.readTimeout))
+			if len(msgs) == 0 {
+				mg.checkEnd()
+			}
 			return msgs, nil
 		}
 	}
@@ -186,6 +333,18 @@ func (mg *memgen) Read(ctx context.Context, count int64) ([]*isb.ReadMessage, er
 
 }
 
+// checkEnd stops the forwarder once all the records are generated and forwarded. It's only checked when nothing is
+// read, so that the forwarder has no message in flight to finish with the stopped context.
+func (mg *memgen) checkEnd() {
+	if mg.maxMessages == 0 || atomic.LoadInt64(&mg.sent) < mg.maxMessages || len(mg.srcchan) > 0 {
+		return
+	}
+	mg.endOnce.Do(func() {
+		mg.logger.Infow("Reached the end of the generator", zap.Int64("maxMessages", mg.maxMessages))
+		mg.forwarder.Stop()
+	})
+}
+
 // Ack acknowledges an array of offset.
 func (mg *memgen) Ack(_ context.Context, offsets []isb.Offset) []error {
 	return make([]error, len(offsets))
@@ -206,20 +365,24 @@ func (mg *memgen) ForceStop() {
 
 }
 
+// CheckProgress checks the forwarding makes progress
+func (mg *memgen) CheckProgress(threshold time.Duration) error {
+	return mg.forwarder.CheckProgress(threshold)
+}
+
 // Start starts reading from the source
 // context is used to control the lifecycle of this component.
 // this context will be used to shutdown the vertex once a os.signal is received.
 func (mg *memgen) Start() <-chan struct{} {
-	mg.generator(mg.lifecycleCtx, mg.rpu, mg.timeunit)
+	mg.generator(mg.lifecycleCtx, mg.timeunit)
 	return mg.forwarder.Start()
 }
 
 // generator fires once per time unit and generates records and writes them to the channel
-func (mg *memgen) generator(ctx context.Context, rate int, timeunit time.Duration) {
+func (mg *memgen) generator(ctx context.Context, timeunit time.Duration) {
 	go func() {
 		var rcount int32 = 0
-		// we are capping the limit at 10000 msgs / second
-		var limit = int32(10000 / rate)
+		start := time.Now()
 		ticker := time.NewTicker(timeunit)
 		defer ticker.Stop()
 		for {
@@ -231,20 +394,47 @@ func (mg *memgen) generator(ctx context.Context, rate int, timeunit time.Duratio
 				return
 			case <-ticker.C:
 				tickgenSourceCount.With(map[string]string{"vertex": mg.name, "pipeline": mg.pipelineName})
+				rate := mg.rate(time.Since(start))
+				if rate <= 0 {
+					continue
+				}
+				tickgenSourceRate.With(map[string]string{"vertex": mg.name, "pipeline": mg.pipelineName}).Set(float64(rate))
+				// we are capping the limit at 10000 msgs / second
+				var limit = int32(10000 / rate)
 				// swapped implies that the rcount is at limit
 				if !atomic.CompareAndSwapInt32(&rcount, limit-1, limit) {
 					go func() {
 						atomic.AddInt32(&rcount, 1)
 						defer atomic.AddInt32(&rcount, -1)
+						var delays []time.Duration
+						if mg.jitter != nil {
+							delays = mg.jitter(rate)
+						}
+						tickedAt := time.Now()
 						for i := 0; i < rate; i++ {
+							if delays != nil {
+								if wait := time.Until(tickedAt.Add(delays[i])); wait > 0 {
+									select {
+									case <-ctx.Done():
+										return
+									case <-time.After(wait):
+									}
+								}
+							}
+							if mg.maxMessages > 0 && atomic.AddInt64(&mg.generated, 1) > mg.maxMessages {
+								return
+							}
 							payload := mg.genfn(mg.msgSize)
 							r := record{data: payload, offset: time.Now().UTC().UnixNano()}
+							if mg.keys != nil {
+								r.key = mg.keys.pick()
+							}
 							select {
 							case <-ctx.Done():
 								log.Info("Context.Done is called. returning from the inner function")
 								return
 							case mg.srcchan <- r:
-
+								atomic.AddInt64(&mg.sent, 1)
 							}
 						}
 					}()
@@ -254,12 +444,13 @@ func (mg *memgen) generator(ctx context.Context, rate int, timeunit time.Duratio
 	}()
 }
 
-func newreadmessage(payload []byte, offset int64) *isb.ReadMessage {
+func newreadmessage(payload []byte, key []byte, offset int64) *isb.ReadMessage {
 	msg := isb.Message{
 		Header: isb.Header{
 			// TODO: insert the right time based on the generator
 			PaneInfo: isb.PaneInfo{EventTime: timefromNanos(parseTime(payload))},
 			ID:       strconv.FormatInt(offset, 10),
+			Key:      key,
 		},
 		Body: isb.Body{Payload: payload},
 	}
@@ -30,6 +30,50 @@ func TestRead(t *testing.T) {
 	assert.Equal(t, 5, len(msgs))
 }
 
+func TestReadWithKeys(t *testing.T) {
+	dest := simplebuffer.NewInMemoryBuffer("writer", 20)
+	ctx := context.Background()
+	vertex := &dfv1.Vertex{ObjectMeta: v1.ObjectMeta{
+		Name: "memgen",
+	}}
+	mgen, err := NewMemGen(vertex, 5, 8, time.Millisecond, []isb.BufferWriter{dest}, WithKeys(&dfv1.GeneratorKeys{Count: 2}), WithLoadProfile(&dfv1.LoadProfile{Type: dfv1.LoadProfileSteady}))
+	assert.NoError(t, err)
+	_ = mgen.Start()
+
+	msgs, err := dest.Read(ctx, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(msgs))
+	for _, m := range msgs {
+		assert.Contains(t, []string{"key-0", "key-1"}, string(m.Key))
+	}
+	mgen.Stop()
+}
+
+func TestReadWithMaxMessages(t *testing.T) {
+	dest := simplebuffer.NewInMemoryBuffer("writer", 20)
+	ctx := context.Background()
+	vertex := &dfv1.Vertex{ObjectMeta: v1.ObjectMeta{
+		Name: "memgen",
+	}}
+	_, err := NewMemGen(vertex, 5, 8, time.Millisecond, []isb.BufferWriter{dest}, WithMaxMessages(0))
+	assert.Error(t, err)
+	mgen, err := NewMemGen(vertex, 5, 8, time.Millisecond, []isb.BufferWriter{dest}, WithMaxMessages(12), WithReadTimeOut(10*time.Millisecond))
+	assert.NoError(t, err)
+	stopped := mgen.Start()
+
+	// the forwarder stops by itself once all the messages are forwarded
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the generator to reach the end")
+	}
+	msgs, err := dest.Read(ctx, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, len(msgs))
+	assert.True(t, dest.IsEmpty())
+	mgen.Stop()
+}
+
 // Intention of this test is test the wiring for stop.
 // initially a set of records will be written and subsequently
 // when stop is invoked, we make sure that we have infact read all the messages
@@ -109,3 +153,26 @@ func TestTimeForInvalidTime(t *testing.T) {
 	parsedtime := timefromNanos(nanotime)
 	assert.True(t, parsedtime.UnixNano() > 0)
 }
+
+func TestReadWithTemplateAndJitter(t *testing.T) {
+	dest := simplebuffer.NewInMemoryBuffer("writer", 20)
+	ctx := context.Background()
+	vertex := &dfv1.Vertex{ObjectMeta: v1.ObjectMeta{
+		Name: "memgen",
+	}}
+	_, err := NewMemGen(vertex, 5, 8, time.Millisecond, []isb.BufferWriter{dest}, WithTemplate("{{ .Createdts"))
+	assert.Error(t, err)
+	jitter := &dfv1.GeneratorJitter{Max: &v1.Duration{Duration: 10 * time.Millisecond}, Distribution: dfv1.JitterDistributionExponential}
+	mgen, err := NewMemGen(vertex, 5, 8, 10*time.Millisecond, []isb.BufferWriter{dest}, WithTemplate(`{"city": "{{ fakeCity }}", "Createdts": {{ .Createdts }}}`), WithJitter(jitter))
+	assert.NoError(t, err)
+	_ = mgen.Start()
+
+	msgs, err := dest.Read(ctx, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(msgs))
+	for _, m := range msgs {
+		assert.Contains(t, string(m.Payload), `"city": "`)
+		assert.Equal(t, parseTime(m.Payload), m.EventTime.UnixNano())
+	}
+	mgen.Stop()
+}
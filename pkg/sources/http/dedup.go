@@ -0,0 +1,52 @@
+package http
+
+import (
+	"sync"
+	"time"
+)
+
+type dedupEntry struct {
+	id      string
+	expires time.Time
+}
+
+// dedupCache remembers the IDs of the messages ingested within a window, the expired ones are removed in the order
+// they are added.
+type dedupCache struct {
+	window time.Duration
+	now    func() time.Time
+
+	lock    sync.Mutex
+	expires map[string]time.Time
+	queue   []dedupEntry
+}
+
+func newDedupCache(window time.Duration) *dedupCache {
+	return &dedupCache{
+		window:  window,
+		now:     time.Now,
+		expires: make(map[string]time.Time),
+	}
+}
+
+// add adds the ID, it returns false if the ID has been added within the window.
+func (c *dedupCache) add(id string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	for len(c.queue) > 0 && !c.queue[0].expires.After(now) {
+		e := c.queue[0]
+		c.queue = c.queue[1:]
+		// Only removed if it's not added again after expired
+		if c.expires[e.id] == e.expires {
+			delete(c.expires, e.id)
+		}
+	}
+	if exp, ok := c.expires[id]; ok && exp.After(now) {
+		return false
+	}
+	exp := now.Add(c.window)
+	c.expires[id] = exp
+	c.queue = append(c.queue, dedupEntry{id: id, expires: exp})
+	return true
+}
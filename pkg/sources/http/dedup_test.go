@@ -0,0 +1,30 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupCache(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newDedupCache(time.Minute)
+	c.now = func() time.Time { return now }
+	assert.True(t, c.add("a"))
+	assert.False(t, c.add("a"))
+	now = now.Add(30 * time.Second)
+	assert.True(t, c.add("b"))
+	assert.False(t, c.add("a"))
+	now = now.Add(31 * time.Second)
+	// "a" expired, "b" not yet
+	assert.True(t, c.add("a"))
+	assert.False(t, c.add("b"))
+	now = now.Add(30 * time.Second)
+	assert.True(t, c.add("b"))
+	assert.False(t, c.add("a"))
+	now = now.Add(time.Hour)
+	assert.True(t, c.add("c"))
+	assert.Len(t, c.expires, 1)
+	assert.Len(t, c.queue, 1)
+}
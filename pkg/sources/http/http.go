@@ -2,21 +2,46 @@ package http
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
 	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	sharedtls "github.com/numaproj/numaflow/pkg/shared/tls"
 	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+	"github.com/numaproj/numaflow/pkg/sources/sharding"
 	"github.com/numaproj/numaflow/pkg/udf/applier"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// minRetryAfter is the Retry-After returned to the clients when the downstream buffers just reach the usage limit
+	minRetryAfter = 1 * time.Second
+	// maxRetryAfter is the Retry-After returned to the clients when the downstream buffers are completely full
+	maxRetryAfter = 30 * time.Second
+	// replyReadBatchSize is the max number of replies read from the reply buffer at a time
+	replyReadBatchSize = 100
+	// forwardedByHeader marks the requests forwarded by the other replicas to the replica owning them
+	forwardedByHeader = "X-Numaflow-Forwarded-By"
 )
 
 type httpSource struct {
@@ -26,9 +51,36 @@ type httpSource struct {
 	bufferSize  int
 	messages    chan *isb.ReadMessage
 	logger      *zap.SugaredLogger
+	// usageReporters are the downstream buffers which are able to report their usages
+	usageReporters []isb.BufferUsageReporter
+	// limiter limits the admission rate of the requests, nil means not limited
+	limiter *rate.Limiter
 
 	forwarder *forward.InterStepDataForward
-	shutdown  func(context.Context) error
+	// rateLimiter admits the messages read from the source, not limited if it's nil
+	rateLimiter forward.RateLimiter
+	// traceRecorders sample the messages written to the traced buffers
+	traceRecorders tracing.Recorders
+	// auditRecorder stamps and records the audit IDs of the emitted messages
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced messages
+	tracer   *telemetry.Tracer
+	shutdown func(context.Context) error
+
+	// replyReader reads the replies of the requests in request-reply mode from the reply buffer of the replica
+	replyReader isb.BufferReader
+	// replyWaiters are the requests waiting for the replies, keyed by the correlation IDs
+	replyWaiters map[string]chan []byte
+	replyLock    sync.Mutex
+	stopReplies  context.CancelFunc
+	repliesDone  chan struct{}
+
+	// sharder tells the replicas owning the requests by their shard keys, nil if the requests are not sharded
+	sharder *sharding.Sharder
+	// dedup drops the requests with the IDs ingested within the dedup window, nil if not deduplicated
+	dedup *dedupCache
+	// forwardTransport forwards the requests to the replicas owning them
+	forwardTransport http.RoundTripper
 }
 
 type Option func(*httpSource) error
@@ -41,6 +93,38 @@ func WithLogger(l *zap.SugaredLogger) Option {
 	}
 }
 
+// WithRateLimiter sets the rate limiter admitting the messages read from the source
+func WithRateLimiter(l forward.RateLimiter) Option {
+	return func(o *httpSource) error {
+		o.rateLimiter = l
+		return nil
+	}
+}
+
+// WithTraceRecorders samples the messages written to the traced buffers
+func WithTraceRecorders(r tracing.Recorders) Option {
+	return func(o *httpSource) error {
+		o.traceRecorders = r
+		return nil
+	}
+}
+
+// WithAuditRecorder stamps the audit IDs of the emitted messages, and records them
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(o *httpSource) error {
+		o.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced messages
+func WithTracer(tracer *telemetry.Tracer) Option {
+	return func(o *httpSource) error {
+		o.tracer = tracer
+		return nil
+	}
+}
+
 // WithReadTimeout is used to set the read timeout for the from buffer
 func WithReadTimeout(t time.Duration) Option {
 	return func(o *httpSource) error {
@@ -49,6 +133,22 @@ func WithReadTimeout(t time.Duration) Option {
 	}
 }
 
+// WithReplyReader sets the reader of the reply buffer in request-reply mode
+func WithReplyReader(r isb.BufferReader) Option {
+	return func(o *httpSource) error {
+		o.replyReader = r
+		return nil
+	}
+}
+
+// WithSharder distributes the requests across the replicas by their shard keys
+func WithSharder(s *sharding.Sharder) Option {
+	return func(o *httpSource) error {
+		o.sharder = s
+		return nil
+	}
+}
+
 func WithBufferSize(s int) Option {
 	return func(o *httpSource) error {
 		o.bufferSize = s
@@ -62,6 +162,8 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 		ready:       false,
 		bufferSize:  1000,            // default size
 		readTimeout: 1 * time.Second, // default timeout
+
+		replyWaiters: make(map[string]chan []byte),
 	}
 	for _, o := range opts {
 		operr := o(h)
@@ -73,6 +175,14 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 		h.logger = logging.NewLogger()
 	}
 	h.messages = make(chan *isb.ReadMessage, h.bufferSize)
+	for _, w := range writers {
+		if r, ok := w.(isb.BufferUsageReporter); ok {
+			h.usageReporters = append(h.usageReporters, r)
+		}
+	}
+	if x := vertex.Spec.Source.HTTP.RateLimit; x != nil && *x > 0 {
+		h.limiter = rate.NewLimiter(rate.Limit(*x), int(*x))
+	}
 
 	auth := ""
 	if x := vertex.Spec.Source.HTTP.Auth; x != nil && x.Token != nil {
@@ -82,6 +192,33 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 			auth = string(s)
 		}
 	}
+	var signatureKey []byte
+	signatureHeader := ""
+	if x := vertex.Spec.Source.HTTP.Signature; x != nil {
+		if s, err := sharedutil.GetSecretFromVolume(x.Secret); err != nil {
+			return nil, fmt.Errorf("failed to get signature secret, %w", err)
+		} else {
+			signatureKey = []byte(s)
+		}
+		signatureHeader = x.GetHeader()
+	}
+	var replyTimeout time.Duration
+	if x := vertex.Spec.Source.HTTP; x.IsRequestReply() {
+		replyTimeout = x.RequestReply.GetTimeout()
+	}
+	defaultEncoding := ""
+	if x := vertex.Spec.Source.Encoding; x != nil {
+		defaultEncoding = string(x.Default)
+	}
+	shardHeader := ""
+	if x := vertex.Spec.Source.HTTP.Sharding; x != nil {
+		shardHeader = x.GetHeader()
+		if w := x.GetDedupWindow(); w > 0 {
+			h.dedup = newDedupCache(w)
+		}
+		// The replicas serve with the self-signed certificates generated on start
+		h.forwardTransport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		if !h.ready {
@@ -92,7 +229,7 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 		w.WriteHeader(204)
 	})
 	mux.HandleFunc("/vertices/"+vertex.Spec.Name, func(w http.ResponseWriter, r *http.Request) {
-		if auth != "" && r.Header.Get("Authorization") != "Bearer "+auth {
+		if auth != "" && !validToken(auth, r.Header.Get("Authorization")) {
 			w.WriteHeader(403)
 			_, _ = w.Write([]byte("403 forbidden\n"))
 			return
@@ -102,6 +239,27 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 			_, _ = w.Write([]byte("503 not ready\n"))
 			return
 		}
+		// The requests forwarded by the other replicas are ingested here, not to be forwarded again
+		if key := r.Header.Get(shardHeader); h.sharder != nil && key != "" && r.Header.Get(forwardedByHeader) == "" {
+			if owner, local := h.sharder.Owner(key); !local {
+				httpSourceForwarded.With(map[string]string{"vertex": vertex.Spec.Name, "pipeline": vertex.Spec.PipelineName}).Inc()
+				h.forwardTo(owner, w, r)
+				return
+			}
+		}
+		if ok, retryAfter := h.admit(); !ok {
+			httpSourceThrottled.With(map[string]string{"vertex": vertex.Spec.Name, "pipeline": vertex.Spec.PipelineName}).Inc()
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			w.WriteHeader(429)
+			_, _ = w.Write([]byte("429 too many requests\n"))
+			return
+		}
+		if replyTimeout > 0 && h.replyReader == nil {
+			// The replica has no reply buffer, which happens when it's beyond the max replicas
+			w.WriteHeader(503)
+			_, _ = w.Write([]byte("503 no reply buffer\n"))
+			return
+		}
 		msg, err := ioutil.ReadAll(r.Body)
 		_ = r.Body.Close()
 		if err != nil {
@@ -109,16 +267,56 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 			_, _ = w.Write([]byte(err.Error()))
 			return
 		}
+		if signatureKey != nil && !validSignature(signatureKey, r.Header.Get(signatureHeader), msg) {
+			w.WriteHeader(403)
+			_, _ = w.Write([]byte("403 invalid signature\n"))
+			return
+		}
+		encoding := r.Header.Get("Content-Encoding")
+		if encoding == "" {
+			encoding = defaultEncoding
+		}
+		if msg, err = sharedutil.Decompress(encoding, msg); err != nil {
+			w.WriteHeader(400)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
 
 		id := r.Header.Get(dfv1.KeyMetaID)
 		if id == "" {
 			id = uuid.New().String()
+		} else if h.dedup != nil && !h.dedup.add(id) {
+			httpSourceDuplicates.With(map[string]string{"vertex": vertex.Spec.Name, "pipeline": vertex.Spec.PipelineName}).Inc()
+			if replyTimeout > 0 {
+				// The reply goes to the request ingested first
+				w.WriteHeader(409)
+				_, _ = w.Write([]byte("409 duplicate request\n"))
+				return
+			}
+			w.WriteHeader(204)
+			return
+		}
+		metadata := metadataFromHeaders(r.Header)
+		// The reply keys are reserved, not to be set by the clients
+		delete(metadata, dfv1.ReplyCorrelationIDKey)
+		delete(metadata, dfv1.ReplyToKey)
+		var reply <-chan []byte
+		if replyTimeout > 0 {
+			correlationID := uuid.New().String()
+			if metadata == nil {
+				metadata = make(map[string]string)
+			}
+			metadata[dfv1.ReplyCorrelationIDKey] = correlationID
+			metadata[dfv1.ReplyToKey] = h.replyReader.GetName()
+			reply = h.waitForReply(correlationID)
+			defer h.stopWaiting(correlationID)
 		}
 		m := &isb.ReadMessage{
 			Message: isb.Message{
 				Header: isb.Header{
 					PaneInfo: isb.PaneInfo{EventTime: time.Now()},
 					ID:       id,
+					Metadata: metadata,
 				},
 				Body: isb.Body{
 					Payload: msg,
@@ -127,7 +325,22 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 			ReadOffset: isb.SimpleOffset(func() string { return id }),
 		}
 		h.messages <- m
-		w.WriteHeader(204)
+		if reply == nil {
+			w.WriteHeader(204)
+			return
+		}
+		timer := time.NewTimer(replyTimeout)
+		defer timer.Stop()
+		select {
+		case payload := <-reply:
+			w.WriteHeader(200)
+			_, _ = w.Write(payload)
+		case <-timer.C:
+			httpSourceReplyTimeout.With(map[string]string{"vertex": vertex.Spec.Name, "pipeline": vertex.Spec.PipelineName}).Inc()
+			w.WriteHeader(504)
+			_, _ = w.Write([]byte("504 reply timeout\n"))
+		case <-r.Context().Done(): // the client has gone
+		}
 	})
 	cer, err := sharedtls.GenerateX509KeyPair()
 	if err != nil {
@@ -158,6 +371,21 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 			forwardOpts = append(forwardOpts, forward.WithReadBatchSize(int64(*x.ReadBatchSize)))
 		}
 	}
+	if h.rateLimiter != nil {
+		forwardOpts = append(forwardOpts, forward.WithRateLimiter(h.rateLimiter))
+	}
+	if h.traceRecorders != nil {
+		forwardOpts = append(forwardOpts, forward.WithTraceRecorders(h.traceRecorders))
+	}
+	if h.auditRecorder != nil {
+		forwardOpts = append(forwardOpts, forward.WithAuditRecorder(h.auditRecorder))
+	}
+	if h.tracer != nil {
+		forwardOpts = append(forwardOpts, forward.WithTracer(h.tracer))
+	}
+	if vertex.Spec.TenantKey != "" {
+		forwardOpts = append(forwardOpts, forward.WithTenantKey(vertex.Spec.TenantKey))
+	}
 	forwarder, err := forward.NewInterStepDataForward(vertex, h, destinations, forward.All, applier.Terminal, forwardOpts...)
 	if err != nil {
 		h.logger.Errorw("Error instantiating the forwarder", zap.Error(err))
@@ -167,6 +395,149 @@ func New(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Option) (*http
 	return h, nil
 }
 
+// forwardTo forwards the request to the replica owning it, and relays the response. The clients are asked to retry if
+// the replica is not reachable, which happens until the sharder learns it's gone.
+func (h *httpSource) forwardTo(owner sharding.Member, w http.ResponseWriter, r *http.Request) {
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = "https"
+			req.URL.Host = owner.Address
+			req.Header.Set(forwardedByHeader, h.name)
+		},
+		Transport: h.forwardTransport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			h.logger.Warnw("Failed to forward the request to the replica owning it", zap.Int("replica", owner.Replica), zap.String("address", owner.Address), zap.Error(err))
+			w.Header().Set("Retry-After", strconv.Itoa(int(minRetryAfter.Seconds())))
+			w.WriteHeader(503)
+			_, _ = w.Write([]byte("503 owner not reachable\n"))
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// admit decides whether a request should be admitted, it returns how long the client should wait before retrying if
+// the request is not admitted.
+func (h *httpSource) admit() (bool, time.Duration) {
+	if len(h.messages) >= cap(h.messages) {
+		return false, minRetryAfter
+	}
+	for _, r := range h.usageReporters {
+		if d := retryAfter(r.GetUsage(), r.GetUsageLimit()); d > 0 {
+			return false, d
+		}
+	}
+	if h.limiter != nil {
+		reservation := h.limiter.Reserve()
+		if d := reservation.Delay(); d > 0 {
+			reservation.Cancel()
+			return false, d
+		}
+	}
+	return true, 0
+}
+
+// retryAfter returns how long the clients should back off based on the usage of a downstream buffer, it grows linearly
+// from minRetryAfter to maxRetryAfter while the usage goes from the usage limit to 100%. 0 means no need to back off.
+func retryAfter(usage, usageLimit float64) time.Duration {
+	if usage < usageLimit {
+		return 0
+	}
+	if usageLimit >= 1 {
+		return minRetryAfter
+	}
+	ratio := math.Min((usage-usageLimit)/(1-usageLimit), 1)
+	return minRetryAfter + time.Duration(ratio*float64(maxRetryAfter-minRetryAfter))
+}
+
+// validToken returns whether the Authorization header carries the bearer token, it's compared in constant time so that
+// the token can not be guessed by timing the responses.
+func validToken(token, authorization string) bool {
+	return subtle.ConstantTimeCompare([]byte(authorization), []byte("Bearer "+token)) == 1
+}
+
+// validSignature returns whether the signature is the HMAC-SHA256 of the payload computed with the key. The signature is
+// hex encoded, optionally prefixed with "sha256=".
+func validSignature(key []byte, signature string, payload []byte) bool {
+	if signature == "" {
+		return false
+	}
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(payload)
+	return hmac.Equal(actual, mac.Sum(nil))
+}
+
+// waitForReply registers a request waiting for the reply with the correlation ID.
+func (h *httpSource) waitForReply(correlationID string) <-chan []byte {
+	h.replyLock.Lock()
+	defer h.replyLock.Unlock()
+	c := make(chan []byte, 1)
+	h.replyWaiters[correlationID] = c
+	return c
+}
+
+// stopWaiting unregisters the request waiting for the reply with the correlation ID.
+func (h *httpSource) stopWaiting(correlationID string) {
+	h.replyLock.Lock()
+	defer h.replyLock.Unlock()
+	delete(h.replyWaiters, correlationID)
+}
+
+// deliverReplies hands the replies to the requests waiting for them. Only the first reply of a request is delivered,
+// the replies of the requests no longer waiting, e.g. timed out, are discarded.
+func (h *httpSource) deliverReplies(replies []*isb.ReadMessage) {
+	h.replyLock.Lock()
+	defer h.replyLock.Unlock()
+	for _, r := range replies {
+		correlationID := r.Metadata[dfv1.ReplyCorrelationIDKey]
+		c, ok := h.replyWaiters[correlationID]
+		if !ok {
+			h.logger.Debugw("No request waiting for the reply, discarding it", zap.String("correlationID", correlationID))
+			continue
+		}
+		select {
+		case c <- r.Payload:
+		default: // already replied
+		}
+	}
+}
+
+// collectReplies keeps reading the replies from the reply buffer until the context is cancelled.
+func (h *httpSource) collectReplies(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		replies, err := h.replyReader.Read(ctx, replyReadBatchSize)
+		if err != nil {
+			h.logger.Errorw("Failed to read the replies", zap.Error(err))
+		}
+		if len(replies) > 0 {
+			h.deliverReplies(replies)
+			offsets := make([]isb.Offset, len(replies))
+			for i, r := range replies {
+				offsets[i] = r.ReadOffset
+			}
+			for _, err := range h.replyReader.Ack(ctx, offsets) {
+				if err != nil {
+					h.logger.Errorw("Failed to ack the replies", zap.Error(err))
+					break
+				}
+			}
+		} else if err != nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
 func (h *httpSource) GetName() string {
 	return h.name
 }
@@ -196,6 +567,14 @@ func (h *httpSource) Close() error {
 	if err := h.shutdown(context.Background()); err != nil {
 		return err
 	}
+	// The replies are collected until the pending requests are done
+	if h.stopReplies != nil {
+		h.stopReplies()
+		<-h.repliesDone
+		if err := h.replyReader.Close(); err != nil {
+			h.logger.Errorw("Failed to close the reply reader", zap.Error(err))
+		}
+	}
 	h.logger.Info("HTTP source server shutdown")
 	return nil
 }
@@ -203,6 +582,10 @@ func (h *httpSource) Close() error {
 func (h *httpSource) Stop() {
 	h.logger.Info("Stopping http reader...")
 	h.ready = false
+	if h.sharder != nil {
+		// The other replicas take over the requests it owns
+		h.sharder.Leave(context.Background())
+	}
 	h.forwarder.Stop()
 }
 
@@ -210,7 +593,46 @@ func (h *httpSource) ForceStop() {
 	h.Stop()
 }
 
+// CheckProgress checks the forwarding makes progress
+func (h *httpSource) CheckProgress(threshold time.Duration) error {
+	return h.forwarder.CheckProgress(threshold)
+}
+
 func (h *httpSource) Start() <-chan struct{} {
 	defer func() { h.ready = true }()
+	if h.replyReader != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		h.stopReplies = cancel
+		h.repliesDone = make(chan struct{})
+		go func() {
+			defer close(h.repliesDone)
+			h.collectReplies(ctx)
+		}()
+	}
 	return h.forwarder.Start()
 }
+
+// metadataFromHeaders returns the metadata in the headers prefixed with x-numaflow-metadata-, the keys are lower cased
+// without the prefix, nil if there is no such header. The W3C traceparent header is kept as well, so that the message
+// continues the trace of the request.
+func metadataFromHeaders(header http.Header) map[string]string {
+	var metadata map[string]string
+	for k, v := range header {
+		k = strings.ToLower(k)
+		if k == dfv1.TraceParentKey && len(v) > 0 {
+			if metadata == nil {
+				metadata = make(map[string]string)
+			}
+			metadata[k] = v[0]
+			continue
+		}
+		if !strings.HasPrefix(k, dfv1.KeyMetaMetadataPrefix) || k == dfv1.KeyMetaMetadataPrefix || len(v) == 0 {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[strings.TrimPrefix(k, dfv1.KeyMetaMetadataPrefix)] = v[0]
+	}
+	return metadata
+}
@@ -1,15 +1,40 @@
 package http
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/isb/simplebuffer"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	"github.com/numaproj/numaflow/pkg/sources/sharding"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
 )
 
+type usageReporter struct {
+	usage      float64
+	usageLimit float64
+}
+
+func (r usageReporter) GetUsage() float64 {
+	return r.usage
+}
+
+func (r usageReporter) GetUsageLimit() float64 {
+	return r.usageLimit
+}
+
 func TestWithBufferSize(t *testing.T) {
 	h := &httpSource{
 		bufferSize: 10,
@@ -51,3 +76,149 @@ func Test_NewHTTP(t *testing.T) {
 	h.Stop()
 	assert.False(t, h.ready)
 }
+
+func Test_retryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), retryAfter(0.5, 0.8))
+	assert.Equal(t, minRetryAfter, retryAfter(0.8, 0.8))
+	assert.Equal(t, maxRetryAfter, retryAfter(1, 0.8))
+	assert.Equal(t, maxRetryAfter, retryAfter(1.2, 0.8))
+	assert.Equal(t, minRetryAfter+(maxRetryAfter-minRetryAfter)/2, retryAfter(0.9, 0.8).Round(time.Millisecond))
+	assert.Equal(t, minRetryAfter, retryAfter(1, 1))
+}
+
+func Test_admit(t *testing.T) {
+	t.Run("not limited", func(t *testing.T) {
+		h := &httpSource{messages: make(chan *isb.ReadMessage, 1)}
+		ok, d := h.admit()
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), d)
+	})
+
+	t.Run("local buffer full", func(t *testing.T) {
+		h := &httpSource{messages: make(chan *isb.ReadMessage, 1)}
+		h.messages <- &isb.ReadMessage{}
+		ok, d := h.admit()
+		assert.False(t, ok)
+		assert.Equal(t, minRetryAfter, d)
+	})
+
+	t.Run("downstream buffer usage", func(t *testing.T) {
+		h := &httpSource{
+			messages:       make(chan *isb.ReadMessage, 1),
+			usageReporters: []isb.BufferUsageReporter{usageReporter{usage: 0.1, usageLimit: 0.8}, usageReporter{usage: 1, usageLimit: 0.8}},
+		}
+		ok, d := h.admit()
+		assert.False(t, ok)
+		assert.Equal(t, maxRetryAfter, d)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		h := &httpSource{
+			messages: make(chan *isb.ReadMessage, 10),
+			limiter:  rate.NewLimiter(rate.Limit(1), 1),
+		}
+		ok, _ := h.admit()
+		assert.True(t, ok)
+		ok, d := h.admit()
+		assert.False(t, ok)
+		assert.True(t, d > 0 && d <= time.Second)
+	})
+}
+
+func Test_validToken(t *testing.T) {
+	assert.True(t, validToken("abc", "Bearer abc"))
+	assert.False(t, validToken("abc", "Bearer abcd"))
+	assert.False(t, validToken("abc", "abc"))
+	assert.False(t, validToken("abc", ""))
+}
+
+func Test_validSignature(t *testing.T) {
+	key := []byte("secret")
+	payload := []byte("hello world")
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+	assert.True(t, validSignature(key, "sha256="+signature, payload))
+	assert.True(t, validSignature(key, signature, payload))
+	assert.False(t, validSignature(key, "", payload))
+	assert.False(t, validSignature(key, "sha256=xyz", payload))
+	assert.False(t, validSignature(key, "sha256="+signature, []byte("hello")))
+	assert.False(t, validSignature([]byte("other"), "sha256="+signature, payload))
+}
+
+func Test_metadataFromHeaders(t *testing.T) {
+	header := http.Header{}
+	assert.Nil(t, metadataFromHeaders(header))
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Numaflow-Metadata-", "ignored")
+	assert.Nil(t, metadataFromHeaders(header))
+	header.Set("X-Numaflow-Metadata-Tenant", "a")
+	header.Set("x-numaflow-metadata-correlation-id", "abc")
+	assert.Equal(t, map[string]string{"tenant": "a", "correlation-id": "abc"}, metadataFromHeaders(header))
+	header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.Equal(t, map[string]string{"tenant": "a", "correlation-id": "abc", "traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, metadataFromHeaders(header))
+}
+
+func Test_deliverReplies(t *testing.T) {
+	h := &httpSource{logger: logging.NewLogger(), replyWaiters: make(map[string]chan []byte)}
+	reply := h.waitForReply("a")
+	_ = h.waitForReply("b")
+	h.stopWaiting("b")
+	h.deliverReplies([]*isb.ReadMessage{
+		{Message: isb.Message{Header: isb.Header{Metadata: map[string]string{dfv1.ReplyCorrelationIDKey: "a"}}, Body: isb.Body{Payload: []byte("first")}}},
+		{Message: isb.Message{Header: isb.Header{Metadata: map[string]string{dfv1.ReplyCorrelationIDKey: "a"}}, Body: isb.Body{Payload: []byte("second")}}},
+		{Message: isb.Message{Header: isb.Header{Metadata: map[string]string{dfv1.ReplyCorrelationIDKey: "b"}}, Body: isb.Body{Payload: []byte("late")}}},
+	})
+	assert.Equal(t, []byte("first"), <-reply)
+	assert.Equal(t, 0, len(reply))
+	assert.Equal(t, 1, len(h.replyWaiters))
+}
+
+func Test_collectReplies(t *testing.T) {
+	replyBuffer := simplebuffer.NewInMemoryBuffer("reply", 10)
+	h := &httpSource{logger: logging.NewLogger(), replyWaiters: make(map[string]chan []byte), replyReader: replyBuffer}
+	reply := h.waitForReply("abc")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, errs := replyBuffer.Write(ctx, []isb.Message{{Header: isb.Header{ID: "1", Metadata: map[string]string{dfv1.ReplyCorrelationIDKey: "abc"}}, Body: isb.Body{Payload: []byte("hello")}}})
+	assert.Equal(t, make([]error, 1), errs)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.collectReplies(ctx)
+	}()
+	select {
+	case payload := <-reply:
+		assert.Equal(t, []byte("hello"), payload)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the reply")
+	}
+	<-done
+	assert.True(t, replyBuffer.IsEmpty())
+}
+
+func TestForwardTo(t *testing.T) {
+	owner := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-v", r.Header.Get(forwardedByHeader))
+		assert.Equal(t, "/vertices/test-v", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "hello", string(body))
+		w.WriteHeader(204)
+	}))
+	defer owner.Close()
+	h := &httpSource{
+		name:             "test-v",
+		logger:           logging.NewLogger(),
+		forwardTransport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}
+
+	w := httptest.NewRecorder()
+	h.forwardTo(sharding.Member{Replica: 1, Address: strings.TrimPrefix(owner.URL, "https://")}, w, httptest.NewRequest(http.MethodPost, "/vertices/test-v", strings.NewReader("hello")))
+	assert.Equal(t, 204, w.Code)
+
+	owner.Close()
+	w = httptest.NewRecorder()
+	h.forwardTo(sharding.Member{Replica: 1, Address: strings.TrimPrefix(owner.URL, "https://")}, w, httptest.NewRequest(http.MethodPost, "/vertices/test-v", strings.NewReader("hello")))
+	assert.Equal(t, 503, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+}
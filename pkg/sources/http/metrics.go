@@ -0,0 +1,34 @@
+package http
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// httpSourceThrottled is used to indicate the number of requests rejected with 429 by the http source
+var httpSourceThrottled = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "http_source",
+	Name:      "throttled_total",
+	Help:      "Total number of requests rejected with 429",
+}, []string{"vertex", "pipeline"})
+
+// httpSourceReplyTimeout is used to indicate the number of requests in request-reply mode timed out waiting for the replies
+var httpSourceReplyTimeout = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "http_source",
+	Name:      "reply_timeout_total",
+	Help:      "Total number of requests timed out waiting for the replies",
+}, []string{"vertex", "pipeline"})
+
+// httpSourceForwarded is used to indicate the number of requests forwarded to the replicas owning their shard keys
+var httpSourceForwarded = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "http_source",
+	Name:      "forwarded_total",
+	Help:      "Total number of requests forwarded to the replicas owning their shard keys",
+}, []string{"vertex", "pipeline"})
+
+// httpSourceDuplicates is used to indicate the number of requests dropped for their IDs ingested within the dedup window
+var httpSourceDuplicates = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "http_source",
+	Name:      "duplicates_total",
+	Help:      "Total number of duplicate requests dropped",
+}, []string{"vertex", "pipeline"})
@@ -13,6 +13,8 @@ type consumerHandler struct {
 	readycloser  sync.Once
 	messages     chan *sarama.ConsumerMessage
 	sess         sarama.ConsumerGroupSession
+	// discarded is called with the number of the messages discarded at the beginning of a new session
+	discarded func(n int)
 }
 
 // new handler initializes the channel for passing messages
@@ -25,6 +27,12 @@ func newConsumerHandler(readChanSize int) *consumerHandler {
 
 // Setup is run at the beginning of a new session, before ConsumeClaim
 func (consumer *consumerHandler) Setup(sess sarama.ConsumerGroupSession) error {
+	// The messages consumed but not read yet belong to the previous session, whose partitions could be assigned to the
+	// other replicas by the rebalance. They are discarded not to be ingested twice, as they haven't been acknowledged,
+	// the new owners of the partitions consume them again from the committed offsets.
+	if n := consumer.discardPending(); n > 0 && consumer.discarded != nil {
+		consumer.discarded(n)
+	}
 	consumer.sess = sess
 	consumer.readycloser.Do(func() {
 		close(consumer.ready)
@@ -50,3 +58,16 @@ func (consumer *consumerHandler) ConsumeClaim(session sarama.ConsumerGroupSessio
 
 	return nil
 }
+
+// discardPending discards the messages consumed but not read yet, it returns the number of them.
+func (consumer *consumerHandler) discardPending() int {
+	n := 0
+	for {
+		select {
+		case <-consumer.messages:
+			n++
+		default:
+			return n
+		}
+	}
+}
@@ -65,3 +65,22 @@ func TestMessageHandling(t *testing.T) {
 	assert.Equal(t, []byte(key), readmsg.Header.Key)
 	assert.Equal(t, expectedoffset, readmsg.ReadOffset.String())
 }
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+}
+
+func TestConsumerHandler_SetupDiscardsPending(t *testing.T) {
+	h := newConsumerHandler(10)
+	discarded := 0
+	h.discarded = func(n int) { discarded += n }
+	assert.NoError(t, h.Setup(fakeSession{}))
+	assert.Equal(t, 0, discarded)
+	// consumed in the previous session but not read yet
+	for i := 0; i < 3; i++ {
+		h.messages <- &sarama.ConsumerMessage{Topic: "t", Partition: 0, Offset: int64(i)}
+	}
+	assert.NoError(t, h.Setup(fakeSession{}))
+	assert.Equal(t, 3, discarded)
+	assert.Empty(t, h.messages)
+}
@@ -25,3 +25,11 @@ var kafkaSourceOffsetAckErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 	Name:      "ack_error_total",
 	Help:      "Total number of Kafka ID Errors",
 }, []string{"vertex", "pipeline"})
+
+// kafkaSourceRebalanceDiscarded is used to indicate the number of the messages consumed but not read yet, which are
+// discarded at the rebalances of the consumer group
+var kafkaSourceRebalanceDiscarded = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "kafka_source",
+	Name:      "rebalance_discarded_total",
+	Help:      "Total number of messages discarded at the rebalances, which are consumed again from the committed offsets",
+}, []string{"vertex", "pipeline"})
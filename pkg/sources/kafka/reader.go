@@ -14,7 +14,10 @@ import (
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
 	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
 	"github.com/numaproj/numaflow/pkg/shared/util"
 	"github.com/numaproj/numaflow/pkg/udf/applier"
 )
@@ -32,6 +35,14 @@ type KafkaSource struct {
 	brokers []string
 	// forwarder that writes the consumed data to destination
 	forwarder *forward.InterStepDataForward
+	// rateLimiter admits the messages read from the source, not limited if it's nil
+	rateLimiter forward.RateLimiter
+	// traceRecorders sample the messages written to the traced buffers
+	traceRecorders tracing.Recorders
+	// auditRecorder stamps and records the audit IDs of the emitted messages
+	auditRecorder *audit.Recorder
+	// tracer records the spans of the traced messages
+	tracer *telemetry.Tracer
 	// context cancel function
 	cancelfn context.CancelFunc
 	// lifecycle context
@@ -48,6 +59,8 @@ type KafkaSource struct {
 	handlerbuffer int
 	// read timeout for the from buffer
 	readTimeout time.Duration
+	// content encoding of the messages without a content-encoding header
+	defaultEncoding string
 }
 
 type Option func(*KafkaSource) error
@@ -60,6 +73,38 @@ func WithLogger(l *zap.SugaredLogger) Option {
 	}
 }
 
+// WithRateLimiter sets the rate limiter admitting the messages read from the source
+func WithRateLimiter(l forward.RateLimiter) Option {
+	return func(o *KafkaSource) error {
+		o.rateLimiter = l
+		return nil
+	}
+}
+
+// WithTraceRecorders samples the messages written to the traced buffers
+func WithTraceRecorders(r tracing.Recorders) Option {
+	return func(o *KafkaSource) error {
+		o.traceRecorders = r
+		return nil
+	}
+}
+
+// WithAuditRecorder stamps the audit IDs of the emitted messages, and records them
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(o *KafkaSource) error {
+		o.auditRecorder = r
+		return nil
+	}
+}
+
+// WithTracer records the spans of the traced messages
+func WithTracer(tracer *telemetry.Tracer) Option {
+	return func(o *KafkaSource) error {
+		o.tracer = tracer
+		return nil
+	}
+}
+
 // WithBufferSize is used to return size of message channel information
 func WithBufferSize(s int) Option {
 	return func(o *KafkaSource) error {
@@ -100,7 +145,7 @@ func (r *KafkaSource) Read(_ context.Context, count int64) ([]*isb.ReadMessage,
 		select {
 		case m := <-r.handler.messages:
 			kafkaSourceReadCount.With(map[string]string{"vertex": r.name, "pipeline": r.pipelineName}).Inc()
-			msgs = append(msgs, toReadMessage(m))
+			msgs = append(msgs, r.toReadMessage(m))
 
 		case <-time.After(r.readTimeout):
 			// log that timeout has happened and don't return an error
@@ -156,6 +201,11 @@ func (r *KafkaSource) ForceStop() {
 	r.Stop()
 }
 
+// CheckProgress checks the forwarding makes progress
+func (r *KafkaSource) CheckProgress(threshold time.Duration) error {
+	return r.forwarder.CheckProgress(threshold)
+}
+
 func (r *KafkaSource) Close() error {
 	r.logger.Info("Closing kafka reader...")
 	// finally, shut down the client
@@ -176,6 +226,9 @@ func NewKafkaSource(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Opt
 		readTimeout:   1 * time.Second, // default timeout
 		handlerbuffer: 100,             // default buffer size for kafka reads
 	}
+	if x := vertex.Spec.Source.Encoding; x != nil {
+		kafkasource.defaultEncoding = string(x.Default)
+	}
 
 	for _, o := range opts {
 		operr := o(kafkasource)
@@ -198,6 +251,10 @@ func NewKafkaSource(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Opt
 		}
 	}
 
+	if err := util.SetSASLConfig(config, source.SASL); err != nil {
+		return nil, err
+	}
+
 	kafkasource.config = config
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -207,6 +264,10 @@ func NewKafkaSource(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Opt
 	kafkasource.stopch = make(chan struct{})
 
 	handler := newConsumerHandler(kafkasource.handlerbuffer)
+	handler.discarded = func(n int) {
+		kafkaSourceRebalanceDiscarded.With(map[string]string{"vertex": kafkasource.name, "pipeline": kafkasource.pipelineName}).Add(float64(n))
+		kafkasource.logger.Infow("Discarded the messages consumed before the rebalance", zap.Int("discarded", n))
+	}
 	kafkasource.handler = handler
 
 	destinations := make(map[string]isb.BufferWriter, len(writers))
@@ -220,6 +281,21 @@ func NewKafkaSource(vertex *dfv1.Vertex, writers []isb.BufferWriter, opts ...Opt
 			forwardOpts = append(forwardOpts, forward.WithReadBatchSize(int64(*x.ReadBatchSize)))
 		}
 	}
+	if kafkasource.rateLimiter != nil {
+		forwardOpts = append(forwardOpts, forward.WithRateLimiter(kafkasource.rateLimiter))
+	}
+	if kafkasource.traceRecorders != nil {
+		forwardOpts = append(forwardOpts, forward.WithTraceRecorders(kafkasource.traceRecorders))
+	}
+	if kafkasource.auditRecorder != nil {
+		forwardOpts = append(forwardOpts, forward.WithAuditRecorder(kafkasource.auditRecorder))
+	}
+	if kafkasource.tracer != nil {
+		forwardOpts = append(forwardOpts, forward.WithTracer(kafkasource.tracer))
+	}
+	if vertex.Spec.TenantKey != "" {
+		forwardOpts = append(forwardOpts, forward.WithTenantKey(vertex.Spec.TenantKey))
+	}
 	forwarder, err := forward.NewInterStepDataForward(vertex, kafkasource, destinations, forward.All, applier.Terminal, forwardOpts...)
 	if err != nil {
 		kafkasource.logger.Errorw("Error instantiating the forwarder", zap.Error(err))
@@ -269,16 +345,26 @@ func (r *KafkaSource) startConsumer() {
 	close(r.stopch)
 }
 
-func toReadMessage(m *sarama.ConsumerMessage) *isb.ReadMessage {
+func (r *KafkaSource) toReadMessage(m *sarama.ConsumerMessage) *isb.ReadMessage {
 
 	offset := toOffset(m.Topic, m.Partition, m.Offset)
+	payload := m.Value
+	if encoding := contentEncoding(m.Headers, r.defaultEncoding); encoding != "" {
+		// the message can not be rejected, so it's forwarded as it is if it can not be decompressed
+		if decompressed, err := util.Decompress(encoding, m.Value); err != nil {
+			r.logger.Errorw("Failed to decompress the message", zap.String("offset", offset), zap.String("encoding", encoding), zap.Error(err))
+		} else {
+			payload = decompressed
+		}
+	}
 	msg := isb.Message{
 		Header: isb.Header{
 			PaneInfo: isb.PaneInfo{EventTime: m.Timestamp},
 			ID:       offset,
 			Key:      m.Key,
+			Metadata: metadataFromHeaders(m.Headers),
 		},
-		Body: isb.Body{Payload: m.Value},
+		Body: isb.Body{Payload: payload},
 	}
 
 	return &isb.ReadMessage{
@@ -287,6 +373,32 @@ func toReadMessage(m *sarama.ConsumerMessage) *isb.ReadMessage {
 	}
 }
 
+// contentEncoding returns the value of the content-encoding record header, or the default encoding if it's absent.
+func contentEncoding(headers []*sarama.RecordHeader, defaultEncoding string) string {
+	for _, h := range headers {
+		if h != nil && strings.EqualFold(string(h.Key), "content-encoding") {
+			return string(h.Value)
+		}
+	}
+	return defaultEncoding
+}
+
+// metadataFromHeaders returns the metadata in the record headers prefixed with x-numaflow-metadata-, the keys are
+// without the prefix, nil if there is no such header.
+func metadataFromHeaders(headers []*sarama.RecordHeader) map[string]string {
+	var metadata map[string]string
+	for _, h := range headers {
+		if h == nil || len(h.Key) <= len(dfv1.KeyMetaMetadataPrefix) || !strings.EqualFold(string(h.Key[:len(dfv1.KeyMetaMetadataPrefix)]), dfv1.KeyMetaMetadataPrefix) {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[string(h.Key[len(dfv1.KeyMetaMetadataPrefix):])] = string(h.Value)
+	}
+	return metadata
+}
+
 func toOffset(topic string, partition int32, offset int64) string {
 	// TODO handle this elegantly
 	return fmt.Sprintf("%s:%v:%v", topic, partition, offset)
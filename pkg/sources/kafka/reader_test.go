@@ -5,10 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Shopify/sarama"
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
 	"github.com/numaproj/numaflow/pkg/isb/simplebuffer"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
+	"github.com/numaproj/numaflow/pkg/shared/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -116,3 +118,32 @@ func TestToOffset(t *testing.T) {
 	expected := fmt.Sprintf("%s:%v:%v", topic, partition, offset)
 	assert.Equal(t, expected, formattedoffset)
 }
+
+func TestToReadMessage(t *testing.T) {
+	ks := &KafkaSource{logger: logging.NewLogger()}
+	compressed, err := util.Compress("gzip", []byte("hello world"))
+	assert.NoError(t, err)
+
+	m := ks.toReadMessage(&sarama.ConsumerMessage{Topic: "t", Partition: 1, Offset: 2, Value: []byte("hello world")})
+	assert.Equal(t, "t:1:2", m.ID)
+	assert.Equal(t, []byte("hello world"), m.Payload)
+
+	m = ks.toReadMessage(&sarama.ConsumerMessage{Value: compressed, Headers: []*sarama.RecordHeader{{Key: []byte("Content-Encoding"), Value: []byte("gzip")}}})
+	assert.Equal(t, []byte("hello world"), m.Payload)
+
+	ks.defaultEncoding = "gzip"
+	m = ks.toReadMessage(&sarama.ConsumerMessage{Value: compressed})
+	assert.Equal(t, []byte("hello world"), m.Payload)
+
+	// not decompressed if it's invalid
+	m = ks.toReadMessage(&sarama.ConsumerMessage{Value: []byte("hello world")})
+	assert.Equal(t, []byte("hello world"), m.Payload)
+	assert.Nil(t, m.Metadata)
+
+	m = ks.toReadMessage(&sarama.ConsumerMessage{Value: []byte("hello world"), Headers: []*sarama.RecordHeader{
+		{Key: []byte("X-Numaflow-Metadata-tenant"), Value: []byte("a")},
+		{Key: []byte("x-numaflow-metadata-"), Value: []byte("ignored")},
+		{Key: []byte("other"), Value: []byte("ignored")},
+	}})
+	assert.Equal(t, map[string]string{"tenant": "a"}, m.Metadata)
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+)
+
+const (
+	jetStreamBucketKey = "tokens"
+	// number of attempts to update the bucket state when the other replicas update it concurrently
+	maxConflictRetries = 5
+)
+
+// jetStreamBucketState is the state of the token bucket stored in the JetStream key-value store.
+type jetStreamBucketState struct {
+	Tokens float64 `json:"tokens"`
+	// UnixNano of the last refill
+	Time int64 `json:"time"`
+}
+
+// jetStreamBucket is a token bucket kept in a JetStream key-value store, the concurrent updates are serialized with
+// the revisions of the key. It's refilled with the clocks of the replicas, so it relies on them being in sync.
+type jetStreamBucket struct {
+	kv    nats.KeyValue
+	key   string
+	rate  float64
+	burst int
+	now   func() time.Time
+}
+
+// NewJetStreamLimiter returns a rate limiter sharing a token bucket with the other replicas of a source vertex in the
+// JetStream key-value store, it falls back to the fallback limiter if the key-value store is not accessible.
+func NewJetStreamLimiter(js nats.JetStreamContext, pipelineName, vertexName string, messagesPerSecond float64, burst int, fallback forward.RateLimiter, logger *zap.SugaredLogger) (forward.RateLimiter, error) {
+	kv, err := createJetStreamKeyValue(js, pipelineName, vertexName)
+	if err != nil {
+		return nil, err
+	}
+	b := &jetStreamBucket{kv: kv, key: jetStreamBucketKey, rate: messagesPerSecond, burst: burst, now: time.Now}
+	return newSharedLimiter(b, messagesPerSecond, fallback, logger), nil
+}
+
+// NewJetStreamTenantLimiter returns a rate limiter sharing a token bucket for each tenant with the other replicas of a
+// source vertex in the JetStream key-value store, each of the buckets falls back to a limiter returned by newFallback
+// if the key-value store is not accessible.
+func NewJetStreamTenantLimiter(js nats.JetStreamContext, pipelineName, vertexName string, messagesPerSecond float64, burst int, newFallback func() forward.RateLimiter, logger *zap.SugaredLogger) (forward.TenantRateLimiter, error) {
+	kv, err := createJetStreamKeyValue(js, pipelineName, vertexName)
+	if err != nil {
+		return nil, err
+	}
+	return newTenantLimiter(func(tenant string) forward.RateLimiter {
+		b := &jetStreamBucket{kv: kv, key: jetStreamTenantBucketKey(tenant), rate: messagesPerSecond, burst: burst, now: time.Now}
+		return newSharedLimiter(b, messagesPerSecond, newFallback(), logger)
+	}), nil
+}
+
+func createJetStreamKeyValue(js nats.JetStreamContext, pipelineName, vertexName string) (nats.KeyValue, error) {
+	bucketName := fmt.Sprintf("%s-%s_RATELIMIT", pipelineName, vertexName)
+	kv, err := js.CreateKeyValue(&nats.KeyValueConfig{
+		Bucket:  bucketName,
+		History: 1,
+		TTL:     bucketTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the rate limit bucket %q, %w", bucketName, err)
+	}
+	return kv, nil
+}
+
+// jetStreamTenantBucketKey returns the key of the token bucket of a tenant, the tenant is encoded since only a limited
+// set of characters is allowed in the keys.
+func jetStreamTenantBucketKey(tenant string) string {
+	if tenant == "" {
+		return jetStreamBucketKey
+	}
+	return jetStreamBucketKey + "." + base64.RawURLEncoding.EncodeToString([]byte(tenant))
+}
+
+func (b *jetStreamBucket) take(_ context.Context, n int) (int, error) {
+	for i := 0; i < maxConflictRetries; i++ {
+		now := b.now()
+		state := jetStreamBucketState{Tokens: float64(b.burst), Time: now.UnixNano()}
+		var revision uint64
+		entry, err := b.kv.Get(b.key)
+		if err != nil && !errors.Is(err, nats.ErrKeyNotFound) {
+			return 0, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(entry.Value(), &state); err != nil {
+				return 0, fmt.Errorf("failed to unmarshal the rate limit bucket state, %w", err)
+			}
+			revision = entry.Revision()
+		}
+		taken := refill(&state, now, b.rate, b.burst, n)
+		data, err := json.Marshal(state)
+		if err != nil {
+			return 0, err
+		}
+		if revision == 0 {
+			_, err = b.kv.Create(b.key, data)
+		} else {
+			_, err = b.kv.Update(b.key, data, revision)
+		}
+		if err == nil {
+			return taken, nil
+		}
+		if !isConflict(err) {
+			return 0, err
+		}
+	}
+	// Lost all the races to the other replicas, take nothing this time.
+	return 0, nil
+}
+
+// refill refills the bucket for the time elapsed since the last refill, takes up to n tokens, and returns the number
+// of tokens taken.
+func refill(state *jetStreamBucketState, now time.Time, messagesPerSecond float64, burst, n int) int {
+	if elapsed := now.UnixNano() - state.Time; elapsed > 0 {
+		state.Tokens = math.Min(float64(burst), state.Tokens+float64(elapsed)/float64(time.Second)*messagesPerSecond)
+		state.Time = now.UnixNano()
+	}
+	taken := int(math.Min(float64(n), math.Floor(state.Tokens)))
+	if taken < 0 {
+		taken = 0
+	}
+	state.Tokens -= float64(taken)
+	return taken
+}
+
+// isConflict tells if the key has been updated by someone else since it was read.
+func isConflict(err error) bool {
+	return strings.Contains(err.Error(), "wrong last sequence")
+}
@@ -0,0 +1,101 @@
+//go:build isb_jetstream
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+var natsJetStreamUrl = "nats://localhost:4222"
+
+func TestJetStreamLimiter_Wait(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	conn, err := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", "")).Connect(ctx)
+	assert.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+	defer func() { _ = js.DeleteKeyValue("test-pipeline-in_RATELIMIT") }()
+
+	fallback := &countingLimiter{}
+	// 3 replicas sharing a limit of 100 messages per second, with a burst of 10
+	var limiters []*sharedLimiter
+	for i := 0; i < 3; i++ {
+		l, err := NewJetStreamLimiter(js, "test-pipeline", "in", 100, 10, fallback, nil)
+		assert.NoError(t, err)
+		limiters = append(limiters, l.(*sharedLimiter))
+	}
+
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	for _, l := range limiters {
+		wg.Add(1)
+		go func(l *sharedLimiter) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				assert.NoError(t, l.Wait(ctx, 5))
+			}
+		}(l)
+	}
+	wg.Wait()
+	// 150 messages with 10 in the burst take at least 1.4 seconds at 100 messages per second
+	assert.GreaterOrEqual(t, time.Since(start), 1300*time.Millisecond)
+	assert.Equal(t, 0, fallback.admitted)
+}
+
+func TestJetStreamTenantLimiter_WaitTenant(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	conn, err := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", "")).Connect(ctx)
+	assert.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+	defer func() { _ = js.DeleteKeyValue("test-pipeline-tenant-in_RATELIMIT") }()
+
+	fallback := &countingLimiter{}
+	newFallback := func() forward.RateLimiter { return fallback }
+	// 2 replicas sharing a limit of 100 messages per second for each tenant, with a burst of 10
+	var limiters []forward.TenantRateLimiter
+	for i := 0; i < 2; i++ {
+		l, err := NewJetStreamTenantLimiter(js, "test-pipeline-tenant", "in", 100, 10, newFallback, nil)
+		assert.NoError(t, err)
+		limiters = append(limiters, l)
+	}
+
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	for _, l := range limiters {
+		for _, tenant := range []string{"a", "b"} {
+			wg.Add(1)
+			go func(l forward.TenantRateLimiter, tenant string) {
+				defer wg.Done()
+				for i := 0; i < 6; i++ {
+					assert.NoError(t, l.WaitTenant(ctx, tenant, 5))
+				}
+			}(l, tenant)
+		}
+	}
+	wg.Wait()
+	// 60 messages of each tenant with 10 in the burst take at least 0.5 seconds at 100 messages per second, the
+	// tenants don't share the limit
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 400*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+	assert.Equal(t, 0, fallback.admitted)
+	kv, err := js.KeyValue("test-pipeline-tenant-in_RATELIMIT")
+	assert.NoError(t, err)
+	keys, err := kv.Keys()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tokens.YQ", "tokens.Yg"}, keys)
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	// bounds of the time to wait before taking the tokens refilled in the shared bucket again
+	minRefillWait = 10 * time.Millisecond
+	maxRefillWait = time.Second
+	// the shared bucket state expires if none of the replicas takes tokens within the time
+	bucketTTL = time.Hour
+)
+
+// bucket is a token bucket shared by the replicas of a source vertex.
+type bucket interface {
+	// take takes up to n tokens from the bucket after refilling it, and returns the number of tokens taken.
+	take(ctx context.Context, n int) (int, error)
+}
+
+// sharedLimiter admits the messages with the tokens taken from a shared bucket, it falls back to a local limiter if
+// the shared bucket is not accessible.
+type sharedLimiter struct {
+	bucket   bucket
+	rate     float64
+	fallback forward.RateLimiter
+	logger   *zap.SugaredLogger
+}
+
+func newSharedLimiter(b bucket, messagesPerSecond float64, fallback forward.RateLimiter, logger *zap.SugaredLogger) *sharedLimiter {
+	if logger == nil {
+		logger = logging.NewLogger()
+	}
+	return &sharedLimiter{bucket: b, rate: messagesPerSecond, fallback: fallback, logger: logger}
+}
+
+// Wait blocks until n messages are admitted, or the context is done.
+func (l *sharedLimiter) Wait(ctx context.Context, n int) error {
+	for n > 0 {
+		taken, err := l.bucket.take(ctx, n)
+		if err != nil {
+			l.logger.Warnw("Failed to take tokens from the shared rate limit bucket, falling back to the local rate limiter", zap.Error(err))
+			return l.fallback.Wait(ctx, n)
+		}
+		n -= taken
+		if n == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(refillWait(n, l.rate)):
+		}
+	}
+	return nil
+}
+
+// refillWait returns how long it takes to refill n tokens, the other replicas compete for the refilled tokens, so it
+// is capped to retry taking them at least every maxRefillWait.
+func refillWait(n int, messagesPerSecond float64) time.Duration {
+	d := time.Duration(float64(n) / messagesPerSecond * float64(time.Second))
+	if d < minRefillWait {
+		return minRefillWait
+	}
+	if d > maxRefillWait {
+		return maxRefillWait
+	}
+	return d
+}
+
+// localLimiter admits the messages with a token bucket in the memory of the replica.
+type localLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewLocalLimiter returns a rate limiter only accounting the messages of the current replica.
+func NewLocalLimiter(messagesPerSecond float64, burst int) forward.RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &localLimiter{limiter: rate.NewLimiter(rate.Limit(messagesPerSecond), burst)}
+}
+
+// Wait blocks until n messages are admitted, or the context is done.
+func (l *localLimiter) Wait(ctx context.Context, n int) error {
+	// WaitN fails if n exceeds the burst, so wait for at most a burst each time.
+	for n > 0 {
+		m := n
+		if b := l.limiter.Burst(); m > b {
+			m = b
+		}
+		if err := l.limiter.WaitN(ctx, m); err != nil {
+			return err
+		}
+		n -= m
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBucket struct {
+	lock   sync.Mutex
+	tokens int
+	err    error
+}
+
+func (b *fakeBucket) take(_ context.Context, n int) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.err != nil {
+		return 0, b.err
+	}
+	if n > b.tokens {
+		n = b.tokens
+	}
+	b.tokens -= n
+	return n, nil
+}
+
+func (b *fakeBucket) add(n int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.tokens += n
+}
+
+type countingLimiter struct {
+	admitted int
+}
+
+func (l *countingLimiter) Wait(_ context.Context, n int) error {
+	l.admitted += n
+	return nil
+}
+
+func TestSharedLimiter_Wait(t *testing.T) {
+	t.Run("enough tokens", func(t *testing.T) {
+		b := &fakeBucket{tokens: 10}
+		l := newSharedLimiter(b, 10, &countingLimiter{}, nil)
+		assert.NoError(t, l.Wait(context.Background(), 6))
+		assert.Equal(t, 4, b.tokens)
+	})
+
+	t.Run("wait for refill", func(t *testing.T) {
+		b := &fakeBucket{tokens: 2}
+		l := newSharedLimiter(b, 100, &countingLimiter{}, nil)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			b.add(5)
+		}()
+		assert.NoError(t, l.Wait(context.Background(), 5))
+		assert.Equal(t, 2, b.tokens)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		b := &fakeBucket{}
+		l := newSharedLimiter(b, 1, &countingLimiter{}, nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.Error(t, l.Wait(ctx, 1))
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		b := &fakeBucket{tokens: 10, err: errors.New("unavailable")}
+		fallback := &countingLimiter{}
+		l := newSharedLimiter(b, 10, fallback, nil)
+		assert.NoError(t, l.Wait(context.Background(), 3))
+		assert.Equal(t, 3, fallback.admitted)
+		assert.Equal(t, 10, b.tokens)
+	})
+}
+
+func TestRefillWait(t *testing.T) {
+	assert.Equal(t, minRefillWait, refillWait(1, 1000))
+	assert.Equal(t, 500*time.Millisecond, refillWait(5, 10))
+	assert.Equal(t, maxRefillWait, refillWait(100, 10))
+}
+
+func TestRefill(t *testing.T) {
+	start := time.Unix(1636470000, 0)
+	state := &jetStreamBucketState{Tokens: 10, Time: start.UnixNano()}
+	assert.Equal(t, 4, refill(state, start, 10, 10, 4))
+	assert.Equal(t, float64(6), state.Tokens)
+	// half a second refills 5 tokens, capped by the burst
+	assert.Equal(t, 10, refill(state, start.Add(500*time.Millisecond), 10, 10, 20))
+	assert.Equal(t, float64(0), state.Tokens)
+	assert.Equal(t, start.Add(500*time.Millisecond).UnixNano(), state.Time)
+	// the clock of the replica is behind the last refill
+	assert.Equal(t, 0, refill(state, start, 10, 10, 1))
+	assert.Equal(t, start.Add(500*time.Millisecond).UnixNano(), state.Time)
+}
+
+func TestLocalLimiter_Wait(t *testing.T) {
+	l := NewLocalLimiter(1000, 5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// more than the burst
+	assert.NoError(t, l.Wait(ctx, 12))
+}
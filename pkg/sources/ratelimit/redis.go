@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+// takeLuaScript refills the token bucket stored in a hash with the time of the Redis server, and takes up to n tokens.
+// KEYS[1] is the hash, ARGV are the rate, the burst, n and the TTL of the hash in seconds.
+const takeLuaScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'time')
+local tokens = tonumber(state[1])
+local last = tonumber(state[2])
+if tokens == nil or last == nil then
+  tokens = burst
+  last = now
+end
+if now > last then
+  tokens = math.min(burst, tokens + (now - last) * rate)
+  last = now
+end
+local taken = math.max(0, math.min(n, math.floor(tokens)))
+tokens = tokens - taken
+redis.call('HSET', KEYS[1], 'tokens', string.format('%.6f', tokens), 'time', string.format('%.6f', last))
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return taken
+`
+
+var takeScript = redis.NewScript(takeLuaScript)
+
+// redisBucket is a token bucket kept in a Redis hash, it's updated atomically with a Lua script.
+type redisBucket struct {
+	client *clients.RedisClient
+	key    string
+	rate   float64
+	burst  int
+}
+
+// NewRedisLimiter returns a rate limiter sharing a token bucket with the other replicas of a source vertex in Redis,
+// it falls back to the fallback limiter if Redis is not accessible.
+func NewRedisLimiter(client *clients.RedisClient, pipelineName, vertexName string, messagesPerSecond float64, burst int, fallback forward.RateLimiter, logger *zap.SugaredLogger) forward.RateLimiter {
+	b := &redisBucket{
+		client: client,
+		key:    redisBucketKey(pipelineName, vertexName, ""),
+		rate:   messagesPerSecond,
+		burst:  burst,
+	}
+	return newSharedLimiter(b, messagesPerSecond, fallback, logger)
+}
+
+// NewRedisTenantLimiter returns a rate limiter sharing a token bucket for each tenant with the other replicas of a
+// source vertex in Redis, each of the buckets falls back to a limiter returned by newFallback if Redis is not accessible.
+func NewRedisTenantLimiter(client *clients.RedisClient, pipelineName, vertexName string, messagesPerSecond float64, burst int, newFallback func() forward.RateLimiter, logger *zap.SugaredLogger) forward.TenantRateLimiter {
+	return newTenantLimiter(func(tenant string) forward.RateLimiter {
+		b := &redisBucket{
+			client: client,
+			key:    redisBucketKey(pipelineName, vertexName, tenant),
+			rate:   messagesPerSecond,
+			burst:  burst,
+		}
+		return newSharedLimiter(b, messagesPerSecond, newFallback(), logger)
+	})
+}
+
+func redisBucketKey(pipelineName, vertexName, tenant string) string {
+	if tenant == "" {
+		return fmt.Sprintf("%s-%s-ratelimit", pipelineName, vertexName)
+	}
+	return fmt.Sprintf("%s-%s-ratelimit-%s", pipelineName, vertexName, tenant)
+}
+
+func (b *redisBucket) take(ctx context.Context, n int) (int, error) {
+	return takeScript.Run(ctx, b.client.Client, []string{b.key}, b.rate, b.burst, n, int(bucketTTL.Seconds())).Int()
+}
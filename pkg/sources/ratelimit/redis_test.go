@@ -0,0 +1,74 @@
+//go:build isb_redis
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+func TestRedisLimiter_Wait(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := clients.NewRedisClient(&goredis.UniversalOptions{Addrs: []string{":6379"}})
+	defer func() { _ = client.DeleteKeys(ctx, "test-pipeline-in-ratelimit") }()
+
+	fallback := &countingLimiter{}
+	// 3 replicas sharing a limit of 100 messages per second, with a burst of 10
+	var limiters []*sharedLimiter
+	for i := 0; i < 3; i++ {
+		limiters = append(limiters, NewRedisLimiter(client, "test-pipeline", "in", 100, 10, fallback, nil).(*sharedLimiter))
+	}
+
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	for _, l := range limiters {
+		wg.Add(1)
+		go func(l *sharedLimiter) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				assert.NoError(t, l.Wait(ctx, 5))
+			}
+		}(l)
+	}
+	wg.Wait()
+	// 150 messages with 10 in the burst take at least 1.4 seconds at 100 messages per second
+	assert.GreaterOrEqual(t, time.Since(start), 1300*time.Millisecond)
+	assert.Equal(t, 0, fallback.admitted)
+}
+
+func TestRedisTenantLimiter_WaitTenant(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := clients.NewRedisClient(&goredis.UniversalOptions{Addrs: []string{":6379"}})
+	defer func() { _ = client.DeleteKeys(ctx, "test-pipeline-in-ratelimit-a", "test-pipeline-in-ratelimit-b") }()
+
+	fallback := &countingLimiter{}
+	l := NewRedisTenantLimiter(client, "test-pipeline", "in", 100, 10, func() forward.RateLimiter { return fallback }, nil)
+	start := time.Now()
+	wg := &sync.WaitGroup{}
+	for _, tenant := range []string{"a", "b"} {
+		wg.Add(1)
+		go func(tenant string) {
+			defer wg.Done()
+			for i := 0; i < 6; i++ {
+				assert.NoError(t, l.WaitTenant(ctx, tenant, 5))
+			}
+		}(tenant)
+	}
+	wg.Wait()
+	// 30 messages of each tenant with 10 in the burst take at least 0.2 seconds at 100 messages per second, the
+	// tenants don't share the limit
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 150*time.Millisecond)
+	assert.Less(t, elapsed, 500*time.Millisecond)
+	assert.Equal(t, 0, fallback.admitted)
+}
@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+)
+
+// tenantLimiter admits the messages of each tenant with a separate rate limiter, which is created when the tenant is
+// seen for the first time.
+type tenantLimiter struct {
+	lock       sync.Mutex
+	limiters   map[string]forward.RateLimiter
+	newLimiter func(tenant string) forward.RateLimiter
+}
+
+func newTenantLimiter(newLimiter func(tenant string) forward.RateLimiter) *tenantLimiter {
+	return &tenantLimiter{limiters: make(map[string]forward.RateLimiter), newLimiter: newLimiter}
+}
+
+// Wait blocks until n messages without a tenant are admitted, or the context is done.
+func (l *tenantLimiter) Wait(ctx context.Context, n int) error {
+	return l.WaitTenant(ctx, "", n)
+}
+
+// WaitTenant blocks until n messages of the tenant are admitted, or the context is done.
+func (l *tenantLimiter) WaitTenant(ctx context.Context, tenant string, n int) error {
+	return l.limiter(tenant).Wait(ctx, n)
+}
+
+func (l *tenantLimiter) limiter(tenant string) forward.RateLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	limiter, ok := l.limiters[tenant]
+	if !ok {
+		limiter = l.newLimiter(tenant)
+		l.limiters[tenant] = limiter
+	}
+	return limiter
+}
+
+// NewLocalTenantLimiter returns a rate limiter only accounting the messages of the current replica, with a separate
+// rate limit for each tenant.
+func NewLocalTenantLimiter(messagesPerSecond float64, burst int) forward.TenantRateLimiter {
+	return newTenantLimiter(func(string) forward.RateLimiter {
+		return NewLocalLimiter(messagesPerSecond, burst)
+	})
+}
@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+)
+
+func TestTenantLimiter_WaitTenant(t *testing.T) {
+	buckets := map[string]*fakeBucket{}
+	l := newTenantLimiter(func(tenant string) forward.RateLimiter {
+		b := &fakeBucket{tokens: 10}
+		buckets[tenant] = b
+		return newSharedLimiter(b, 1000, NewLocalLimiter(1000, 10), nil)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, l.WaitTenant(ctx, "a", 3))
+	assert.NoError(t, l.WaitTenant(ctx, "a", 2))
+	assert.NoError(t, l.WaitTenant(ctx, "b", 4))
+	assert.NoError(t, l.Wait(ctx, 1))
+	assert.Len(t, buckets, 3)
+	assert.Equal(t, 5, buckets["a"].tokens)
+	assert.Equal(t, 6, buckets["b"].tokens)
+	assert.Equal(t, 9, buckets[""].tokens)
+}
+
+func TestLocalTenantLimiter_WaitTenant(t *testing.T) {
+	l := NewLocalTenantLimiter(1000, 5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, l.WaitTenant(ctx, "a", 12))
+	assert.NoError(t, l.WaitTenant(ctx, "b", 12))
+}
+
+func TestJetStreamTenantBucketKey(t *testing.T) {
+	assert.Equal(t, "tokens", jetStreamTenantBucketKey(""))
+	assert.Equal(t, "tokens.YQ", jetStreamTenantBucketKey("a"))
+	assert.Equal(t, "tokens.YS9iIGM", jetStreamTenantBucketKey("a/b c"))
+}
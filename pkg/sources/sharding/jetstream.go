@@ -0,0 +1,74 @@
+package sharding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+)
+
+// jetStreamRegistry keeps a key for each member in a JetStream key-value store, whose entries expire if they are not
+// renewed within the TTL of the bucket.
+type jetStreamRegistry struct {
+	kv nats.KeyValue
+}
+
+// NewJetStreamRegistry returns a registry of the replicas of a source vertex in the JetStream key-value store.
+func NewJetStreamRegistry(js nats.JetStreamContext, pipelineName, vertexName string) (Registry, error) {
+	bucketName := fmt.Sprintf("%s-%s_SHARDING", pipelineName, vertexName)
+	kv, err := js.CreateKeyValue(&nats.KeyValueConfig{
+		Bucket:  bucketName,
+		History: 1,
+		TTL:     memberTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the sharding bucket %q, %w", bucketName, err)
+	}
+	return &jetStreamRegistry{kv: kv}, nil
+}
+
+func (r *jetStreamRegistry) Register(_ context.Context, m Member) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = r.kv.Put(jetStreamMemberKey(m), data)
+	return err
+}
+
+func (r *jetStreamRegistry) Unregister(_ context.Context, m Member) error {
+	return r.kv.Delete(jetStreamMemberKey(m))
+}
+
+func (r *jetStreamRegistry) Members(_ context.Context) ([]Member, error) {
+	keys, err := r.kv.Keys()
+	if err != nil {
+		if errors.Is(err, nats.ErrNoKeysFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var members []Member
+	for _, k := range keys {
+		entry, err := r.kv.Get(k)
+		if err != nil {
+			if errors.Is(err, nats.ErrKeyNotFound) { // expired or unregistered in the meantime
+				continue
+			}
+			return nil, err
+		}
+		var m Member
+		if err := json.Unmarshal(entry.Value(), &m); err != nil {
+			return nil, fmt.Errorf("failed to decode the member %q, %w", k, err)
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func jetStreamMemberKey(m Member) string {
+	return "replica-" + strconv.Itoa(m.Replica)
+}
@@ -0,0 +1,43 @@
+//go:build isb_jetstream
+
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+var natsJetStreamUrl = "nats://localhost:4222"
+
+func TestJetStreamRegistry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	conn, err := clients.NewDefaultJetStreamClient(natsJetStreamUrl, nats.UserInfo("", "")).Connect(ctx)
+	assert.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	assert.NoError(t, err)
+	defer func() { _ = js.DeleteKeyValue("test-pipeline-in_SHARDING") }()
+
+	r, err := NewJetStreamRegistry(js, "test-pipeline", "in")
+	assert.NoError(t, err)
+	members, err := r.Members(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+	assert.NoError(t, r.Register(ctx, newMember(0)))
+	assert.NoError(t, r.Register(ctx, newMember(1)))
+	assert.NoError(t, r.Register(ctx, newMember(1)))
+	members, err = r.Members(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Member{newMember(0), newMember(1)}, members)
+	assert.NoError(t, r.Unregister(ctx, newMember(0)))
+	members, err = r.Members(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Member{newMember(1)}, members)
+}
@@ -0,0 +1,77 @@
+package sharding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+// redisRegistration is a member with the expiry of its registration, in UnixNano.
+type redisRegistration struct {
+	Member
+	Expires int64 `json:"expires"`
+}
+
+// redisRegistry keeps the members in a Redis hash, with a field for each member. The fields can't expire on their own,
+// the expired ones are removed while getting the members. It relies on the clocks of the replicas being in sync.
+type redisRegistry struct {
+	client *clients.RedisClient
+	key    string
+	now    func() time.Time
+}
+
+// NewRedisRegistry returns a registry of the replicas of a source vertex in Redis.
+func NewRedisRegistry(client *clients.RedisClient, pipelineName, vertexName string) Registry {
+	return &redisRegistry{
+		client: client,
+		key:    fmt.Sprintf("%s-%s-sharding", pipelineName, vertexName),
+		now:    time.Now,
+	}
+}
+
+func (r *redisRegistry) Register(ctx context.Context, m Member) error {
+	data, err := json.Marshal(redisRegistration{Member: m, Expires: r.now().Add(memberTTL).UnixNano()})
+	if err != nil {
+		return err
+	}
+	pipe := r.client.Client.TxPipeline()
+	pipe.HSet(ctx, r.key, strconv.Itoa(m.Replica), data)
+	// The hash is removed once all the replicas are gone
+	pipe.Expire(ctx, r.key, memberTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *redisRegistry) Unregister(ctx context.Context, m Member) error {
+	return r.client.Client.HDel(ctx, r.key, strconv.Itoa(m.Replica)).Err()
+}
+
+func (r *redisRegistry) Members(ctx context.Context) ([]Member, error) {
+	fields, err := r.client.Client.HGetAll(ctx, r.key).Result()
+	if err != nil {
+		return nil, err
+	}
+	now := r.now().UnixNano()
+	var members []Member
+	var expired []string
+	for f, v := range fields {
+		var reg redisRegistration
+		if err := json.Unmarshal([]byte(v), &reg); err != nil {
+			return nil, fmt.Errorf("failed to decode the member %q, %w", f, err)
+		}
+		if reg.Expires <= now {
+			expired = append(expired, f)
+			continue
+		}
+		members = append(members, reg.Member)
+	}
+	if len(expired) > 0 {
+		// Best effort, the others remove them otherwise
+		_ = r.client.Client.HDel(ctx, r.key, expired...).Err()
+	}
+	return members, nil
+}
@@ -0,0 +1,37 @@
+//go:build isb_redis
+
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+)
+
+func TestRedisRegistry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := clients.NewRedisClient(&goredis.UniversalOptions{Addrs: []string{":6379"}})
+	r := NewRedisRegistry(client, "test-pipeline", "in").(*redisRegistry)
+	defer func() { _ = client.Client.Del(ctx, r.key).Err() }()
+
+	assert.NoError(t, r.Register(ctx, newMember(0)))
+	assert.NoError(t, r.Register(ctx, newMember(1)))
+	members, err := r.Members(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Member{newMember(0), newMember(1)}, members)
+	assert.NoError(t, r.Unregister(ctx, newMember(0)))
+	members, err = r.Members(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Member{newMember(1)}, members)
+	// expired without being renewed
+	r.now = func() time.Time { return time.Now().Add(memberTTL + time.Second) }
+	members, err = r.Members(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+}
@@ -0,0 +1,181 @@
+/*
+Package sharding distributes the shard keys across the replicas of a source vertex. The live replicas register
+themselves in the Inter-Step Buffer Service, and each key is owned by one of them by rendezvous hashing, so that only
+the keys of the replicas joining or leaving move to the others.
+*/
+package sharding
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	// heartbeatInterval is how often a replica renews its registration and refreshes the live members
+	heartbeatInterval = 5 * time.Second
+	// memberTTL is how long a registration lives without being renewed
+	memberTTL = 3 * heartbeatInterval
+)
+
+// Member is a replica of a source vertex taking part in the sharding.
+type Member struct {
+	Replica int `json:"replica"`
+	// Address is the host:port of the replica, which the requests it owns are forwarded to
+	Address string `json:"address"`
+}
+
+// Registry keeps the registrations of the live members in the Inter-Step Buffer Service.
+type Registry interface {
+	// Register registers the member, or renews its registration.
+	Register(ctx context.Context, m Member) error
+	// Unregister removes the registration of the member.
+	Unregister(ctx context.Context, m Member) error
+	// Members returns the members whose registrations are alive.
+	Members(ctx context.Context) ([]Member, error)
+}
+
+// Sharder tells the owners of the shard keys among the live members. The members are refreshed periodically, the
+// ones last known are kept if the registry is not accessible.
+type Sharder struct {
+	registry Registry
+	self     Member
+	logger   *zap.SugaredLogger
+
+	lock    sync.RWMutex
+	members []Member
+	left    bool
+}
+
+func NewSharder(registry Registry, self Member, logger *zap.SugaredLogger) *Sharder {
+	if logger == nil {
+		logger = logging.NewLogger()
+	}
+	return &Sharder{
+		registry: registry,
+		self:     self,
+		logger:   logger,
+		members:  []Member{self},
+	}
+}
+
+// Run keeps the registration of the replica and refreshes the members until the context is done or the replica leaves.
+func (s *Sharder) Run(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		if !s.refresh(ctx) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh renews the registration and refreshes the members, it returns false once the replica has left.
+func (s *Sharder) refresh(ctx context.Context) bool {
+	s.lock.RLock()
+	left := s.left
+	s.lock.RUnlock()
+	if left {
+		return false
+	}
+	if err := s.registry.Register(ctx, s.self); err != nil {
+		s.logger.Warnw("Failed to register the replica for sharding", zap.Error(err))
+	}
+	members, err := s.registry.Members(ctx)
+	if err != nil {
+		s.logger.Warnw("Failed to get the members for sharding, using the ones last known", zap.Error(err))
+		return true
+	}
+	s.setMembers(members)
+	return true
+}
+
+// setMembers sets the members, the replica itself is always one of them unless it has left.
+func (s *Sharder) setMembers(members []Member) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.left {
+		return
+	}
+	found := false
+	for _, m := range members {
+		if m.Replica == s.self.Replica {
+			found = true
+			break
+		}
+	}
+	if !found {
+		members = append(members, s.self)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Replica < members[j].Replica })
+	s.members = members
+}
+
+// Leave unregisters the replica, so that the others take over its keys. The replica doesn't own any key afterwards.
+func (s *Sharder) Leave(ctx context.Context) {
+	s.lock.Lock()
+	s.left = true
+	members := make([]Member, 0, len(s.members))
+	for _, m := range s.members {
+		if m.Replica != s.self.Replica {
+			members = append(members, m)
+		}
+	}
+	s.members = members
+	s.lock.Unlock()
+	if err := s.registry.Unregister(ctx, s.self); err != nil {
+		s.logger.Warnw("Failed to unregister the replica for sharding", zap.Error(err))
+	}
+}
+
+// Owner returns the member owning the key, and whether it's the replica itself. The replica owns all the keys if there
+// is no other member.
+func (s *Sharder) Owner(key string) (Member, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if len(s.members) == 0 {
+		return s.self, true
+	}
+	owner := s.members[0]
+	var maxScore uint64
+	for i, m := range s.members {
+		if score := rendezvousScore(key, m.Replica); i == 0 || score > maxScore {
+			owner, maxScore = m, score
+		}
+	}
+	return owner, owner.Replica == s.self.Replica
+}
+
+// Members returns the members last known.
+func (s *Sharder) Members() []Member {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return append([]Member(nil), s.members...)
+}
+
+// rendezvousScore returns the score of the replica for the key, the key is owned by the replica with the highest score.
+func rendezvousScore(key string, replica int) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return mix64(h.Sum64() ^ mix64(uint64(replica)))
+}
+
+// mix64 is the finalizer of SplitMix64, which spreads the differences of the inputs to all the bits, FNV alone doesn't
+// for the inputs differing only in the last bytes.
+func mix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,133 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memRegistry struct {
+	lock    sync.Mutex
+	members map[int]Member
+	err     error
+}
+
+func newMemRegistry() *memRegistry {
+	return &memRegistry{members: map[int]Member{}}
+}
+
+func (r *memRegistry) Register(_ context.Context, m Member) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.members[m.Replica] = m
+	return nil
+}
+
+func (r *memRegistry) Unregister(_ context.Context, m Member) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.members, m.Replica)
+	return nil
+}
+
+func (r *memRegistry) Members(_ context.Context) ([]Member, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	var members []Member
+	for _, m := range r.members {
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func newMember(replica int) Member {
+	return Member{Replica: replica, Address: fmt.Sprintf("in-%d.in-headless.default.svc:8443", replica)}
+}
+
+func TestSharder_Owner(t *testing.T) {
+	ctx := context.Background()
+	registry := newMemRegistry()
+	var sharders []*Sharder
+	for i := 0; i < 3; i++ {
+		sharders = append(sharders, NewSharder(registry, newMember(i), nil))
+	}
+	// alone before refreshed
+	_, local := sharders[1].Owner("a")
+	assert.True(t, local)
+	for _, s := range sharders {
+		assert.True(t, s.refresh(ctx))
+	}
+	for _, s := range sharders {
+		assert.True(t, s.refresh(ctx))
+		assert.Len(t, s.Members(), 3)
+	}
+
+	owned := map[int]int{}
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		owner, local := sharders[0].Owner(key)
+		// all the replicas agree on the owner
+		for _, s := range sharders {
+			o, l := s.Owner(key)
+			assert.Equal(t, owner, o)
+			assert.Equal(t, l, o.Replica == s.self.Replica)
+		}
+		assert.Equal(t, local, owner.Replica == 0)
+		owned[owner.Replica]++
+	}
+	for r := 0; r < 3; r++ {
+		assert.InDelta(t, 1000, owned[r], 200, "replica %d owns %d keys", r, owned[r])
+	}
+
+	// only the keys of the replica leaving move to the others
+	before := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		o, _ := sharders[0].Owner(key)
+		before[key] = o.Replica
+	}
+	sharders[2].Leave(ctx)
+	assert.False(t, sharders[2].refresh(ctx))
+	assert.True(t, sharders[0].refresh(ctx))
+	assert.Len(t, sharders[0].Members(), 2)
+	for key, r := range before {
+		o, _ := sharders[0].Owner(key)
+		if r != 2 {
+			assert.Equal(t, r, o.Replica)
+		} else {
+			assert.NotEqual(t, 2, o.Replica)
+		}
+		_, local := sharders[2].Owner(key)
+		assert.False(t, local)
+	}
+}
+
+func TestSharder_RegistryFailure(t *testing.T) {
+	ctx := context.Background()
+	registry := newMemRegistry()
+	s0, s1 := NewSharder(registry, newMember(0), nil), NewSharder(registry, newMember(1), nil)
+	assert.True(t, s0.refresh(ctx))
+	assert.True(t, s1.refresh(ctx))
+	assert.True(t, s0.refresh(ctx))
+	assert.Len(t, s0.Members(), 2)
+	registry.err = errors.New("unavailable")
+	// the members last known are kept
+	assert.True(t, s0.refresh(ctx))
+	assert.Equal(t, []Member{newMember(0), newMember(1)}, s0.Members())
+}
+
+func TestSharder_SelfAlwaysMember(t *testing.T) {
+	s := NewSharder(newMemRegistry(), newMember(1), nil)
+	s.setMembers([]Member{newMember(2), newMember(0)})
+	assert.Equal(t, []Member{newMember(0), newMember(1), newMember(2)}, s.Members())
+}
@@ -4,19 +4,28 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/audit"
+	"github.com/numaproj/numaflow/pkg/isb/forward"
 	jetstreamisb "github.com/numaproj/numaflow/pkg/isb/jetstream"
+	kafkaisb "github.com/numaproj/numaflow/pkg/isb/kafka"
 	redisisb "github.com/numaproj/numaflow/pkg/isb/redis"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
 	"github.com/numaproj/numaflow/pkg/metrics"
+	"github.com/numaproj/numaflow/pkg/shared/lifecycle"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/numaproj/numaflow/pkg/sources/generator"
 	"github.com/numaproj/numaflow/pkg/sources/http"
 	"github.com/numaproj/numaflow/pkg/sources/kafka"
+	"github.com/numaproj/numaflow/pkg/sources/ratelimit"
+	"github.com/numaproj/numaflow/pkg/sources/sharding"
 )
 
 type SourceProcessor struct {
@@ -42,11 +51,17 @@ func (u *SourceProcessor) Start(ctx context.Context) error {
 			if x.BufferUsageLimit != nil {
 				writeOpts = append(writeOpts, redisisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
 			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, redisisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, redisisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
 		}
 		redisClient := clients.NewInClusterRedisClient()
 		for _, b := range toBuffers {
 			group := b + "-group"
-			writer := redisisb.NewBufferWrite(ctx, redisClient, b, group, writeOpts...)
+			writer := redisisb.NewBufferWrite(ctx, redisClient, b, group, append(writeOpts, redisisb.WriterOptionsFor(u.Vertex, b)...)...)
 			writers = append(writers, writer)
 		}
 	case dfv1.ISBSvcTypeJetStream:
@@ -58,11 +73,41 @@ func (u *SourceProcessor) Start(ctx context.Context) error {
 			if x.BufferUsageLimit != nil {
 				writeOpts = append(writeOpts, jetstreamisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
 			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
 		}
+		jetStreamClient := clients.InClusterSharedJetStreamClient()
 		for _, b := range toBuffers {
 			streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, b)
-			jetStreamClient := clients.NewInClusterJetStreamClient()
-			writer, err := jetstreamisb.NewJetStreamBufferWriter(ctx, jetStreamClient, b, streamName, streamName, writeOpts...)
+			writer, err := jetstreamisb.NewJetStreamBufferWriter(ctx, jetStreamClient, b, streamName, streamName, append(writeOpts, jetstreamisb.WriterOptionsFor(u.Vertex, b)...)...)
+			if err != nil {
+				return err
+			}
+			writers = append(writers, writer)
+		}
+	case dfv1.ISBSvcTypeKafka:
+		writeOpts := []kafkaisb.WriteOption{}
+		if x := u.Vertex.Spec.Limits; x != nil {
+			if x.BufferMaxLength != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxLength(int64(*x.BufferMaxLength)))
+			}
+			if x.BufferUsageLimit != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
+			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
+		}
+		kafkaClient := clients.NewInClusterKafkaClient()
+		for _, b := range toBuffers {
+			writer, err := kafkaisb.NewKafkaBufferWriter(ctx, kafkaClient, b, b, writeOpts...)
 			if err != nil {
 				return err
 			}
@@ -72,47 +117,262 @@ func (u *SourceProcessor) Start(ctx context.Context) error {
 		return fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
 	}
 
-	sourcer, err := u.getSourcer(writers, log)
+	rateLimiter, err := u.getRateLimiter(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to create the rate limiter, error: %w", err)
+	}
+	replyReader, err := u.getReplyReader(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create the reply buffer reader, error: %w", err)
+	}
+	auditRecorder, err := audit.NewInClusterRecorder(ctx, u.ISBSvcType, u.Vertex, u.Hostname, log)
+	if err != nil {
+		return fmt.Errorf("failed to create the audit recorder, error: %w", err)
+	}
+	traceRecorders := tracing.NewRecorders(u.Vertex, u.Hostname)
+	tracer := telemetry.NewInClusterTracer(u.Vertex, u.Hostname)
+	if tracer != nil {
+		go tracer.Run(ctx)
+	}
+	sharder, err := u.getSharder(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to create the sharder, error: %w", err)
+	}
+	if sharder != nil {
+		go sharder.Run(ctx)
+	}
+	sourcer, err := u.getSourcer(writers, rateLimiter, replyReader, sharder, traceRecorders, auditRecorder, tracer, log)
 	if err != nil {
 		return fmt.Errorf("failed to find a sourcer, error: %w", err)
 	}
 
 	log.Infow("Start processing source messages", zap.String("isbs", string(u.ISBSvcType)), zap.Strings("to", toBuffers))
 	stopped := sourcer.Start()
+	finished := make(chan struct{})
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		for {
-			<-stopped
-			log.Info("Sourcer stopped, exiting source processor...")
-			return
-		}
+		<-stopped
+		log.Info("Sourcer stopped, exiting source processor...")
+		close(finished)
 	}()
+	// A bounded source stops by itself once it reaches the end, the replica then exits without being restarted
+	var ended <-chan struct{}
+	if u.Vertex.Spec.Source.IsBounded() {
+		ended = finished
+	}
 
-	if shutdown, err := metrics.StartMetricsServer(ctx); err != nil {
+	drainer := lifecycle.NewDrainer()
+	// Not ready once drained, so that the controller knows the replica can be deleted when scaling down.
+	metricsOpts := []metrics.Option{metrics.WithReadiness(func() bool { return !drainer.Drained() }), metrics.WithHandler(dfv1.VertexTracesPath, traceRecorders)}
+	// Not live once the forwarder is stuck, so that the pod is restarted.
+	if c, ok := sourcer.(forward.ProgressChecker); ok {
+		if threshold := u.Vertex.Spec.Liveness.GetStuckThreshold(); threshold > 0 {
+			metricsOpts = append(metricsOpts, metrics.WithLiveness(func() error { return c.CheckProgress(threshold) }))
+		}
+	}
+	if shutdown, err := metrics.StartMetricsServer(ctx, metricsOpts...); err != nil {
 		return fmt.Errorf("failed to start metrics server, error: %w", err)
 	} else {
 		defer func() { _ = shutdown(context.Background()) }()
 	}
 
-	<-ctx.Done()
-	log.Info("SIGTERM, exiting...")
+	select {
+	case <-ctx.Done():
+		log.Info("SIGTERM, exiting...")
+	case <-lifecycle.WatchDrainRequest(ctx, 5*time.Second):
+		log.Info("Marked to be drained before scaling down, stop processing...")
+	case <-ended:
+		wg.Wait()
+		drainer.Done()
+		log.Info("Reached the end of the source, exited...")
+		return nil
+	}
 	sourcer.Stop()
 	wg.Wait()
+	drainer.Done()
+	<-ctx.Done()
 	log.Info("Exited...")
 	return nil
 }
 
 // getSourcer is used to send the sourcer information
-func (u *SourceProcessor) getSourcer(writers []isb.BufferWriter, logger *zap.SugaredLogger) (Sourcer, error) {
+func (u *SourceProcessor) getSourcer(writers []isb.BufferWriter, rateLimiter forward.RateLimiter, replyReader isb.BufferReader, sharder *sharding.Sharder, traceRecorders tracing.Recorders, auditRecorder *audit.Recorder, tracer *telemetry.Tracer, logger *zap.SugaredLogger) (Sourcer, error) {
 	src := u.Vertex.Spec.Source
 	if x := src.Generator; x != nil {
-		return generator.NewMemGen(u.Vertex, int(*x.RPU), *x.MsgSize, x.Duration.Duration, writers, generator.WithLogger(logger))
+		opts := []generator.Option{generator.WithLogger(logger)}
+		if rateLimiter != nil {
+			opts = append(opts, generator.WithRateLimiter(rateLimiter))
+		}
+		if traceRecorders != nil {
+			opts = append(opts, generator.WithTraceRecorders(traceRecorders))
+		}
+		if auditRecorder != nil {
+			opts = append(opts, generator.WithAuditRecorder(auditRecorder))
+		}
+		if tracer != nil {
+			opts = append(opts, generator.WithTracer(tracer))
+		}
+		if x.LoadProfile != nil {
+			opts = append(opts, generator.WithLoadProfile(x.LoadProfile))
+		}
+		if x.Keys != nil {
+			opts = append(opts, generator.WithKeys(x.Keys))
+		}
+		if x.MaxMessages != nil {
+			opts = append(opts, generator.WithMaxMessages(*x.MaxMessages))
+		}
+		if x.Template != nil {
+			opts = append(opts, generator.WithTemplate(*x.Template))
+		}
+		if x.Jitter != nil {
+			opts = append(opts, generator.WithJitter(x.Jitter))
+		}
+		return generator.NewMemGen(u.Vertex, int(*x.RPU), *x.MsgSize, x.Duration.Duration, writers, opts...)
 	} else if x := src.Kafka; x != nil {
-		return kafka.NewKafkaSource(u.Vertex, writers, kafka.WithGroupName(x.ConsumerGroupName), kafka.WithLogger(logger))
+		opts := []kafka.Option{kafka.WithGroupName(x.GetConsumerGroupName(u.Vertex.Spec.PipelineName, u.Vertex.Spec.Name)), kafka.WithLogger(logger)}
+		if rateLimiter != nil {
+			opts = append(opts, kafka.WithRateLimiter(rateLimiter))
+		}
+		if traceRecorders != nil {
+			opts = append(opts, kafka.WithTraceRecorders(traceRecorders))
+		}
+		if auditRecorder != nil {
+			opts = append(opts, kafka.WithAuditRecorder(auditRecorder))
+		}
+		if tracer != nil {
+			opts = append(opts, kafka.WithTracer(tracer))
+		}
+		return kafka.NewKafkaSource(u.Vertex, writers, opts...)
 	} else if x := src.HTTP; x != nil {
-		return http.New(u.Vertex, writers, http.WithLogger(logger))
+		opts := []http.Option{http.WithLogger(logger)}
+		if rateLimiter != nil {
+			opts = append(opts, http.WithRateLimiter(rateLimiter))
+		}
+		if traceRecorders != nil {
+			opts = append(opts, http.WithTraceRecorders(traceRecorders))
+		}
+		if auditRecorder != nil {
+			opts = append(opts, http.WithAuditRecorder(auditRecorder))
+		}
+		if tracer != nil {
+			opts = append(opts, http.WithTracer(tracer))
+		}
+		if replyReader != nil {
+			opts = append(opts, http.WithReplyReader(replyReader))
+		}
+		if sharder != nil {
+			opts = append(opts, http.WithSharder(sharder))
+		}
+		return http.New(u.Vertex, writers, opts...)
 	}
 	return nil, fmt.Errorf("invalid source spec")
 }
+
+// getReplyReader returns the reader of the reply buffer of the replica, if the source is an http source in request-reply
+// mode. It's nil if the replica is beyond the max replicas, which has no reply buffer.
+func (u *SourceProcessor) getReplyReader(ctx context.Context) (isb.BufferReader, error) {
+	replyBuffers := u.Vertex.GetReplyBuffers()
+	if u.Replica >= len(replyBuffers) {
+		return nil, nil
+	}
+	b := replyBuffers[u.Replica]
+	switch u.ISBSvcType {
+	case dfv1.ISBSvcTypeRedis:
+		return redisisb.NewBufferRead(ctx, clients.NewInClusterRedisClient(), b, b+"-group", fmt.Sprintf("%s-%v", u.Vertex.Name, u.Replica)), nil
+	case dfv1.ISBSvcTypeJetStream:
+		streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, b)
+		return jetstreamisb.NewJetStreamBufferReader(ctx, clients.InClusterSharedJetStreamClient(), b, streamName, streamName)
+	case dfv1.ISBSvcTypeKafka:
+		return kafkaisb.NewKafkaBufferReader(ctx, clients.NewInClusterKafkaClient(), b, b)
+	default:
+		return nil, fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
+	}
+}
+
+// getSharder returns the sharder distributing the requests of an http source across the replicas, which register
+// themselves in the ISB Service. It's nil if the source is not sharded, or the ISB Service is Kafka, which has no shared
+// state for the replicas to register.
+func (u *SourceProcessor) getSharder(ctx context.Context, logger *zap.SugaredLogger) (*sharding.Sharder, error) {
+	x := u.Vertex.Spec.Source.HTTP
+	if x == nil || x.Sharding == nil {
+		return nil, nil
+	}
+	self := sharding.Member{
+		Replica: u.Replica,
+		// Resolved with the headless service of the vertex, which is the subdomain of the pods
+		Address: fmt.Sprintf("%s.%s.%s.svc:%d", u.Hostname, u.Vertex.GetHeadlessServiceName(), u.Vertex.Namespace, dfv1.VertexHTTPSPort),
+	}
+	var registry sharding.Registry
+	switch u.ISBSvcType {
+	case dfv1.ISBSvcTypeRedis:
+		registry = sharding.NewRedisRegistry(clients.NewInClusterRedisClient(), u.Vertex.Spec.PipelineName, u.Vertex.Spec.Name)
+	case dfv1.ISBSvcTypeJetStream:
+		conn, err := clients.NewInClusterJetStreamClient().Connect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		js, err := conn.JetStream()
+		if err != nil {
+			return nil, err
+		}
+		if registry, err = sharding.NewJetStreamRegistry(js, u.Vertex.Spec.PipelineName, u.Vertex.Spec.Name); err != nil {
+			return nil, err
+		}
+	case dfv1.ISBSvcTypeKafka:
+		logger.Warn("The http source sharding is not supported by the Kafka ISB Service, each replica ingests the requests it receives")
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
+	}
+	return sharding.NewSharder(registry, self, logger), nil
+}
+
+// getRateLimiter returns the rate limiter shared by the replicas of the source vertex through the ISB Service, nil if
+// the source is not rate limited. It falls back to an even share of the rate limit for each replica if the ISB Service
+// is not accessible. With a tenant key, the rate limit applies to each tenant separately.
+func (u *SourceProcessor) getRateLimiter(ctx context.Context, logger *zap.SugaredLogger) (forward.RateLimiter, error) {
+	x := u.Vertex.Spec.Source.RateLimit
+	if x == nil {
+		return nil, nil
+	}
+	rate, burst := float64(x.MessagesPerSecond), x.GetBurst()
+	replicas := u.Vertex.Spec.GetReplicas()
+	if replicas < 1 {
+		replicas = 1
+	}
+	newFallback := func() forward.RateLimiter {
+		return ratelimit.NewLocalLimiter(rate/float64(replicas), burst/replicas)
+	}
+	tenantKey := u.Vertex.Spec.TenantKey
+	switch u.ISBSvcType {
+	case dfv1.ISBSvcTypeRedis:
+		if tenantKey != "" {
+			return ratelimit.NewRedisTenantLimiter(clients.NewInClusterRedisClient(), u.Vertex.Spec.PipelineName, u.Vertex.Spec.Name, rate, burst, newFallback, logger), nil
+		}
+		return ratelimit.NewRedisLimiter(clients.NewInClusterRedisClient(), u.Vertex.Spec.PipelineName, u.Vertex.Spec.Name, rate, burst, newFallback(), logger), nil
+	case dfv1.ISBSvcTypeJetStream:
+		conn, err := clients.NewInClusterJetStreamClient().Connect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		js, err := conn.JetStream()
+		if err != nil {
+			return nil, err
+		}
+		if tenantKey != "" {
+			return ratelimit.NewJetStreamTenantLimiter(js, u.Vertex.Spec.PipelineName, u.Vertex.Spec.Name, rate, burst, newFallback, logger)
+		}
+		return ratelimit.NewJetStreamLimiter(js, u.Vertex.Spec.PipelineName, u.Vertex.Spec.Name, rate, burst, newFallback(), logger)
+	case dfv1.ISBSvcTypeKafka:
+		// Kafka has no shared state for the replicas to count the messages, each replica takes an even share of the rate limit
+		logger.Warn("The rate limit is not shared by the replicas with the Kafka ISB Service, each replica takes an even share")
+		if tenantKey != "" {
+			return ratelimit.NewLocalTenantLimiter(rate/float64(replicas), burst/replicas), nil
+		}
+		return newFallback(), nil
+	default:
+		return nil, fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
+	}
+}
@@ -1,11 +1,16 @@
 package applier
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // ApplyUDFErr represents any UDF related error
 type ApplyUDFErr struct {
 	UserUDFErr bool
 	Message    string
+	// Timeout is true if the UDF did not respond in time
+	Timeout bool
 	InternalErr
 }
 
@@ -28,3 +33,33 @@ func (e ApplyUDFErr) IsInternalErr() bool {
 func (e ApplyUDFErr) Error() string {
 	return fmt.Sprint(e.Message)
 }
+
+// ErrorClass classifies the errors of applying the UDF, to tell the problems of the user code from the ones of the platform.
+type ErrorClass string
+
+const (
+	// ErrorClassTimeout is the UDF not responding in time
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassProtocol is the UDF not reachable, or the request or response not encoded or decoded
+	ErrorClassProtocol ErrorClass = "protocol"
+	// ErrorClassUser is the user code failing
+	ErrorClassUser ErrorClass = "user"
+	// ErrorClassPlatform is any other error, e.g. failing to write the outputs streamed by the UDF
+	ErrorClassPlatform ErrorClass = "platform"
+)
+
+// ClassifyError returns the class of the error of applying the UDF.
+func ClassifyError(err error) ErrorClass {
+	var e ApplyUDFErr
+	if !errors.As(err, &e) {
+		return ErrorClassPlatform
+	}
+	switch {
+	case e.Timeout:
+		return ErrorClassTimeout
+	case e.IsInternalErr():
+		return ErrorClassProtocol
+	default:
+		return ErrorClassUser
+	}
+}
@@ -0,0 +1,16 @@
+package applier
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	assert.Equal(t, ErrorClassUser, ClassifyError(ApplyUDFErr{UserUDFErr: true, Message: "failed"}))
+	assert.Equal(t, ErrorClassTimeout, ClassifyError(ApplyUDFErr{UserUDFErr: true, Message: "timed out", Timeout: true}))
+	assert.Equal(t, ErrorClassProtocol, ClassifyError(ApplyUDFErr{Message: "unreachable", InternalErr: InternalErr{Flag: true}}))
+	assert.Equal(t, ErrorClassProtocol, ClassifyError(fmt.Errorf("wrapped, %w", ApplyUDFErr{InternalErr: InternalErr{Flag: true}})))
+	assert.Equal(t, ErrorClassPlatform, ClassifyError(fmt.Errorf("failed to write")))
+}
@@ -0,0 +1,77 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+// InProcessUDF applies the user defined function compiled into the binary, it is invoked in the same process without
+// any serialization.
+type InProcessUDF struct {
+	handle funcsdk.Handle
+}
+
+var _ Applier = (*InProcessUDF)(nil)
+var _ BatchApplier = (*InProcessUDF)(nil)
+
+// NewInProcessUDF returns InProcessUDF.
+func NewInProcessUDF(handle funcsdk.Handle) *InProcessUDF {
+	return &InProcessUDF{handle: handle}
+}
+
+// Apply applies the user defined function.
+func (u *InProcessUDF) Apply(ctx context.Context, readMessage *isb.ReadMessage) ([]*isb.Message, error) {
+	messages, err := u.call(funcsdk.ContextWithMetadata(ctx, readMessage.Metadata), readMessage.Key, readMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return toWriteMessages(readMessage, &messages), nil
+}
+
+// ApplyBatch applies the user defined function on the messages one by one, there is no per-call overhead to save for
+// the in-process function, it is implemented so that the batch mode works for it as well.
+func (u *InProcessUDF) ApplyBatch(ctx context.Context, readMessages []*isb.ReadMessage) ([][]*isb.Message, error) {
+	results := make([][]*isb.Message, len(readMessages))
+	for i, m := range readMessages {
+		writeMessages, err := u.Apply(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = writeMessages
+	}
+	return results, nil
+}
+
+// call invokes the function, the errors and panics of the function are returned as user errors.
+func (u *InProcessUDF) call(ctx context.Context, key, payload []byte) (messages funcsdk.Messages, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = ApplyUDFErr{
+				UserUDFErr: true,
+				Message:    fmt.Sprintf("in-process function panicked, %v", r),
+				InternalErr: InternalErr{
+					Flag:        false,
+					MainCarDown: false,
+				},
+			}
+		}
+	}()
+	messages, err = u.handle(ctx, key, payload)
+	if err != nil {
+		return nil, ApplyUDFErr{
+			UserUDFErr: true,
+			Message:    fmt.Sprintf("in-process function failed, %s", err),
+			InternalErr: InternalErr{
+				Flag:        false,
+				MainCarDown: false,
+			},
+		}
+	}
+	if len(messages) == 0 { // same as the UDF server, no result means DROP
+		messages = append(messages, funcsdk.MessageToDrop())
+	}
+	return messages, nil
+}
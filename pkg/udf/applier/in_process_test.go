@@ -0,0 +1,80 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+func TestInProcessUDF_Apply(t *testing.T) {
+	ctx := context.Background()
+	readMessages := testutils.BuildTestReadMessages(int64(2), time.Unix(1636470000, 0))
+
+	u := NewInProcessUDF(func(_ context.Context, key, msg []byte) (funcsdk.Messages, error) {
+		return funcsdk.MessagesBuilder().Append(funcsdk.MessageTo("to", msg)), nil
+	})
+	results, err := u.Apply(ctx, &readMessages[0])
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, []byte("to"), results[0].Key)
+	assert.Equal(t, readMessages[0].Payload, results[0].Payload)
+	assert.Equal(t, readMessages[0].ReadOffset.String()+"-0", results[0].ID)
+	assert.Equal(t, readMessages[0].EventTime, results[0].EventTime)
+
+	batchResults, err := u.ApplyBatch(ctx, []*isb.ReadMessage{&readMessages[0], &readMessages[1]})
+	assert.NoError(t, err)
+	assert.Len(t, batchResults, 2)
+	assert.Equal(t, readMessages[1].Payload, batchResults[1][0].Payload)
+}
+
+func TestInProcessUDF_ApplyMetadata(t *testing.T) {
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+	readMessages[0].Metadata = map[string]string{"correlation-id": "abc"}
+	u := NewInProcessUDF(func(ctx context.Context, key, msg []byte) (funcsdk.Messages, error) {
+		metadata := funcsdk.MetadataFromContext(ctx)
+		assert.Equal(t, "abc", metadata["correlation-id"])
+		metadata["route"] = "x"
+		return funcsdk.MessagesBuilder().Append(funcsdk.MessageTo("to", msg).WithMetadata(metadata)), nil
+	})
+	results, err := u.Apply(context.Background(), &readMessages[0])
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, map[string]string{"correlation-id": "abc", "route": "x"}, results[0].Metadata)
+	// the metadata of the read message is not modified by the function
+	assert.Equal(t, map[string]string{"correlation-id": "abc"}, readMessages[0].Metadata)
+}
+
+func TestInProcessUDF_ApplyDrop(t *testing.T) {
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+	u := NewInProcessUDF(func(_ context.Context, key, msg []byte) (funcsdk.Messages, error) {
+		return nil, nil
+	})
+	results, err := u.Apply(context.Background(), &readMessages[0])
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, []byte(funcsdk.DROP), results[0].Key)
+}
+
+func TestInProcessUDF_ApplyError(t *testing.T) {
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+	u := NewInProcessUDF(func(_ context.Context, key, msg []byte) (funcsdk.Messages, error) {
+		return nil, fmt.Errorf("test error")
+	})
+	_, err := u.Apply(context.Background(), &readMessages[0])
+	assert.Error(t, err)
+	assert.True(t, err.(ApplyUDFErr).IsUserUDFErr())
+
+	u = NewInProcessUDF(func(_ context.Context, key, msg []byte) (funcsdk.Messages, error) {
+		panic("test panic")
+	})
+	_, err = u.Apply(context.Background(), &readMessages[0])
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "test panic")
+}
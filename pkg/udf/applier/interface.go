@@ -12,6 +12,18 @@ type Applier interface {
 	Apply(ctx context.Context, message *isb.ReadMessage) ([]*isb.Message, error)
 }
 
+// BatchApplier applies the UDF on a batch of read messages in one call, the results are returned in the same order as
+// the read messages. Same as Applier, any UserError will be retried here, while InternalErr can be returned.
+type BatchApplier interface {
+	ApplyBatch(ctx context.Context, messages []*isb.ReadMessage) ([][]*isb.Message, error)
+}
+
+// StreamApplier applies the UDF on a read message, and hands over the output messages in chunks as they are produced by
+// the UDF instead of returning them all at once. If handling a chunk fails, the call is aborted with the error.
+type StreamApplier interface {
+	ApplyStream(ctx context.Context, message *isb.ReadMessage, chunkSize int, handle func([]*isb.Message) error) error
+}
+
 // ApplyFunc untility function used to create a Applier implementation
 type ApplyFunc func(context.Context, *isb.ReadMessage) ([]*isb.Message, error)
 
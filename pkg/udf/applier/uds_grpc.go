@@ -0,0 +1,185 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	functionpb "github.com/numaproj/numaflow/pkg/apis/proto/function"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	"github.com/numaproj/numaflow/pkg/udf/client"
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+// UDSGRPCBasedUDF applies user defined function over gRPC (over Unix Domain Socket) client/server where server is the UDF.
+type UDSGRPCBasedUDF struct {
+	client *client.Client
+	// streaming is declared by the UDF in the readiness check if it implements MapStreamFn
+	streaming bool
+	// timeout is the timeout of the calls, for the streaming calls it's the max time to wait for the next output
+	timeout time.Duration
+}
+
+var _ Applier = (*UDSGRPCBasedUDF)(nil)
+var _ StreamApplier = (*UDSGRPCBasedUDF)(nil)
+
+// NewUDSGRPCBasedUDF returns UDSGRPCBasedUDF calling the UDF with the client, the timeout applies to each of the calls.
+func NewUDSGRPCBasedUDF(c *client.Client, timeout time.Duration) *UDSGRPCBasedUDF {
+	return &UDSGRPCBasedUDF{client: c, timeout: timeout}
+}
+
+// Close closes the connection to the UDF.
+func (u *UDSGRPCBasedUDF) Close() error {
+	return u.client.Close()
+}
+
+// WaitUntilReady waits till the UDF is ready, and takes the capabilities declared by the UDF in the response.
+func (u *UDSGRPCBasedUDF) WaitUntilReady(ctx context.Context) error {
+	resp, err := u.client.WaitUntilReady(ctx)
+	if err != nil {
+		return err
+	}
+	u.streaming = resp.GetStreaming()
+	return nil
+}
+
+// Streaming tells if the UDF declares the outputs can be streamed in the readiness check.
+func (u *UDSGRPCBasedUDF) Streaming() bool {
+	return u.streaming
+}
+
+// Apply applies the user defined function. Same as the HTTP based UDF, the failed calls are retried a few times before
+// giving up, except when the UDF is unreachable, which is an internal error.
+func (u *UDSGRPCBasedUDF) Apply(ctx context.Context, readMessage *isb.ReadMessage) ([]*isb.Message, error) {
+	datum, err := toDatum(readMessage)
+	if err != nil {
+		return nil, err
+	}
+	retryCount := 3
+	for i := 1; ; i++ {
+		callCtx, cancel := context.WithTimeout(ctx, u.timeout)
+		outputs, err := u.client.MapFn(callCtx, datum)
+		cancel()
+		if err == nil {
+			writeMessages := make([]*isb.Message, 0, len(outputs))
+			for j, d := range outputs {
+				writeMessages = append(writeMessages, toWriteMessage(readMessage, fromDatum(d), j))
+			}
+			return writeMessages, nil
+		}
+		if ctx.Err() != nil || status.Code(err) == codes.Unavailable {
+			return nil, grpcCallErr(err)
+		}
+		if i >= retryCount {
+			return nil, ApplyUDFErr{
+				UserUDFErr: true,
+				Message:    fmt.Sprintf("ran out of retry limit, %s", err),
+				Timeout:    status.Code(err) == codes.DeadlineExceeded,
+				InternalErr: InternalErr{
+					Flag:        false,
+					MainCarDown: false,
+				},
+			}
+		}
+		logging.FromContext(ctx).Warnf("MapFn failed (%d/%d), %s", i, retryCount, err)
+	}
+}
+
+// ApplyStream applies the user defined function with the outputs streamed back, they are handed over in chunks of the
+// chunk size as they arrive. The call is aborted if there is no output within the timeout. The outputs are identified
+// by their positions in the stream, same as Apply, so that the chunks handed over again on retries can be deduplicated.
+func (u *UDSGRPCBasedUDF) ApplyStream(ctx context.Context, readMessage *isb.ReadMessage, chunkSize int, handle func([]*isb.Message) error) error {
+	datum, err := toDatum(readMessage)
+	if err != nil {
+		return err
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	idle := time.AfterFunc(u.timeout, cancel)
+	defer idle.Stop()
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	chunk := make([]*isb.Message, 0, chunkSize)
+	i := 0
+	// handleErr tells the errors of handling the chunks from the ones of the stream
+	var handleErr error
+	err = u.client.MapStreamFn(ctx, datum, func(d *functionpb.Datum) error {
+		idle.Reset(u.timeout)
+		chunk = append(chunk, toWriteMessage(readMessage, fromDatum(d), i))
+		i++
+		if len(chunk) >= chunkSize {
+			if handleErr = handle(chunk); handleErr != nil {
+				return handleErr
+			}
+			chunk = make([]*isb.Message, 0, chunkSize)
+		}
+		return nil
+	})
+	if handleErr != nil {
+		return handleErr
+	}
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return grpcCallErr(err)
+		}
+		return ApplyUDFErr{
+			UserUDFErr: true,
+			Message:    fmt.Sprintf("UDF failed in the middle of the stream, %s", err),
+			// canceled by the idle timer
+			Timeout: ctx.Err() != nil && parent.Err() == nil,
+			InternalErr: InternalErr{
+				Flag:        false,
+				MainCarDown: false,
+			},
+		}
+	}
+	if len(chunk) > 0 {
+		return handle(chunk)
+	}
+	return nil
+}
+
+// toDatum converts the read message to the input datum of the UDF.
+func toDatum(readMessage *isb.ReadMessage) (*functionpb.Datum, error) {
+	eventTime, err := types.TimestampProto(readMessage.EventTime)
+	if err != nil {
+		return nil, ApplyUDFErr{
+			UserUDFErr: false,
+			Message:    fmt.Sprintf("invalid event time, %s", err),
+			InternalErr: InternalErr{
+				Flag:        true,
+				MainCarDown: false,
+			},
+		}
+	}
+	return &functionpb.Datum{
+		Key:       readMessage.Key,
+		Value:     readMessage.Payload,
+		EventTime: eventTime,
+		Metadata:  readMessage.Metadata,
+	}, nil
+}
+
+// fromDatum converts the output datum of the UDF to the message returned by the UDF.
+func fromDatum(d *functionpb.Datum) funcsdk.Message {
+	return funcsdk.Message{Key: d.GetKey(), Value: d.GetValue(), Metadata: d.GetMetadata()}
+}
+
+// grpcCallErr is the error of a call which can't reach the UDF, it's an internal error of the platform.
+func grpcCallErr(err error) error {
+	return ApplyUDFErr{
+		UserUDFErr: false,
+		Message:    fmt.Sprintf("gRPC call to the UDF failed, %s", err),
+		InternalErr: InternalErr{
+			Flag:        true,
+			MainCarDown: false,
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	functionpb "github.com/numaproj/numaflow/pkg/apis/proto/function"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	"github.com/numaproj/numaflow/pkg/udf/client"
+)
+
+type fakeFunctionServer struct {
+	functionpb.UnimplementedUserDefinedFunctionServer
+	calls int
+}
+
+func (s *fakeFunctionServer) MapFn(ctx context.Context, d *functionpb.Datum) (*functionpb.DatumList, error) {
+	s.calls++
+	if string(d.GetValue()) == "fail" {
+		return nil, status.Error(codes.Internal, "test error")
+	}
+	eventTime, _ := types.TimestampFromProto(d.GetEventTime())
+	return &functionpb.DatumList{Elements: []*functionpb.Datum{
+		{Key: []byte("even"), Value: d.GetValue()},
+		{Key: []byte("odd"), Value: []byte(eventTime.UTC().Format(time.RFC3339)), Metadata: map[string]string{"x": "y"}},
+	}}, nil
+}
+
+func (s *fakeFunctionServer) MapStreamFn(d *functionpb.Datum, stream functionpb.UserDefinedFunction_MapStreamFnServer) error {
+	for i := 0; i < 5; i++ {
+		if err := stream.Send(&functionpb.Datum{Key: []byte(fmt.Sprint(i)), Value: d.GetValue()}); err != nil {
+			return err
+		}
+	}
+	if string(d.GetValue()) == "fail" {
+		return status.Error(codes.Internal, "test error")
+	}
+	return nil
+}
+
+func (s *fakeFunctionServer) IsReady(context.Context, *emptypb.Empty) (*functionpb.ReadyResponse, error) {
+	return &functionpb.ReadyResponse{Ready: true, Streaming: true}, nil
+}
+
+func newTestGRPCBasedUDF(t *testing.T, s *fakeFunctionServer) *UDSGRPCBasedUDF {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "function.sock")
+	listener, err := net.Listen("unix", path)
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	functionpb.RegisterUserDefinedFunctionServer(server, s)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	c, err := client.New(client.WithSockAddr(path))
+	assert.NoError(t, err)
+	u := NewUDSGRPCBasedUDF(c, time.Second)
+	t.Cleanup(func() { _ = u.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, u.WaitUntilReady(ctx))
+	return u
+}
+
+func TestGRPCBasedUDF_Apply(t *testing.T) {
+	ctx := context.Background()
+	s := &fakeFunctionServer{}
+	u := newTestGRPCBasedUDF(t, s)
+	assert.True(t, u.Streaming())
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+	readMessages[0].Metadata = map[string]string{"a": "b"}
+
+	t.Run("test outputs", func(t *testing.T) {
+		results, err := u.Apply(ctx, &readMessages[0])
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, readMessages[0].ReadOffset.String()+"-0", results[0].ID)
+		assert.Equal(t, readMessages[0].Payload, results[0].Payload)
+		assert.Equal(t, map[string]string{"a": "b"}, results[0].Metadata)
+		assert.Equal(t, readMessages[0].ReadOffset.String()+"-1", results[1].ID)
+		assert.Equal(t, []byte(readMessages[0].EventTime.UTC().Format(time.RFC3339)), results[1].Payload)
+		assert.Equal(t, map[string]string{"x": "y"}, results[1].Metadata)
+	})
+
+	t.Run("test user error", func(t *testing.T) {
+		s.calls = 0
+		m := readMessages[0]
+		m.Payload = []byte("fail")
+		_, err := u.Apply(ctx, &m)
+		assert.Error(t, err)
+		assert.True(t, err.(ApplyUDFErr).IsUserUDFErr())
+		assert.Equal(t, ErrorClassUser, ClassifyError(err))
+		assert.Equal(t, 3, s.calls)
+	})
+
+	t.Run("test UDF down", func(t *testing.T) {
+		c, err := client.New(client.WithSockAddr(filepath.Join(t.TempDir(), "none.sock")))
+		assert.NoError(t, err)
+		down := NewUDSGRPCBasedUDF(c, time.Second)
+		defer func() { _ = down.Close() }()
+		_, err = down.Apply(ctx, &readMessages[0])
+		assert.Error(t, err)
+		assert.True(t, err.(ApplyUDFErr).IsInternalErr())
+		assert.Equal(t, ErrorClassProtocol, ClassifyError(err))
+	})
+}
+
+func TestGRPCBasedUDF_ApplyStream(t *testing.T) {
+	ctx := context.Background()
+	u := newTestGRPCBasedUDF(t, &fakeFunctionServer{})
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+
+	t.Run("test chunks", func(t *testing.T) {
+		var chunks [][]*isb.Message
+		err := u.ApplyStream(ctx, &readMessages[0], 2, func(chunk []*isb.Message) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, chunks, 3)
+		assert.Len(t, chunks[2], 1)
+		for i, m := range append(append(chunks[0], chunks[1]...), chunks[2]...) {
+			assert.Equal(t, fmt.Sprintf("%s-%d", readMessages[0].ReadOffset.String(), i), m.ID)
+			assert.Equal(t, []byte(fmt.Sprint(i)), m.Key)
+		}
+	})
+
+	t.Run("test UDF error", func(t *testing.T) {
+		m := readMessages[0]
+		m.Payload = []byte("fail")
+		err := u.ApplyStream(ctx, &m, 2, func(chunk []*isb.Message) error { return nil })
+		assert.Error(t, err)
+		assert.True(t, err.(ApplyUDFErr).IsUserUDFErr())
+	})
+
+	t.Run("test handle error", func(t *testing.T) {
+		errHandle := fmt.Errorf("handle error")
+		err := u.ApplyStream(ctx, &readMessages[0], 2, func(chunk []*isb.Message) error { return errHandle })
+		assert.Equal(t, errHandle, err)
+	})
+}
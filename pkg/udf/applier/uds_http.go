@@ -12,12 +12,14 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/shared/httpclient"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
 	"github.com/vmihailenco/msgpack/v5"
@@ -26,13 +28,22 @@ import (
 // UDSHTTPBasedUDF applies user defined function over HTTP (over Unix Domain Socket) client/server where server is the UDF.
 type UDSHTTPBasedUDF struct {
 	client *http.Client
+	// maxBatchSize is the max number of messages in a batch declared by the UDF in the readiness check, 0 means no limit
+	maxBatchSize int
+	// streaming is declared by the UDF in the readiness check if the outputs can be streamed
+	streaming bool
+	// timeout is the timeout of the calls, for the streaming calls it's the max time to wait for the next output
+	timeout time.Duration
 }
 
 var _ Applier = (*UDSHTTPBasedUDF)(nil)
+var _ BatchApplier = (*UDSHTTPBasedUDF)(nil)
+var _ StreamApplier = (*UDSHTTPBasedUDF)(nil)
 
 // options for HTTPBasedUDF
 type options struct {
 	httpClientTimeout time.Duration
+	maxIdleConns      int
 }
 
 // Option to apply different options
@@ -51,48 +62,78 @@ func WithHTTPClientTimeout(t time.Duration) Option {
 	return httpClientTimeout(t)
 }
 
+type maxIdleConns int
+
+func (m maxIdleConns) apply(opts *options) {
+	opts.maxIdleConns = int(m)
+}
+
+// WithMaxIdleConns sets the max number of the idle connections to the UDF kept by the HTTP Client
+func WithMaxIdleConns(n int) Option {
+	return maxIdleConns(n)
+}
+
 // NewUDSHTTPBasedUDF returns UDSHTTPBasedUDF.
 // Parameter - socketPath, Unix Domain Socket path
 func NewUDSHTTPBasedUDF(socketPath string, opts ...Option) *UDSHTTPBasedUDF {
 	options := options{
 		// default REST standard is 10 seconds
 		httpClientTimeout: time.Second * 10,
+		// all our connects are loop back
+		maxIdleConns: 100,
 	}
 	for _, o := range opts {
 		o.apply(&options)
 	}
 
-	var httpClient *http.Client
 	// https://www.loginradius.com/blog/async/tune-the-go-http-client-for-high-performance/
-	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
-	// all our connects are loop back
-	httpTransport.MaxIdleConns = 100
-	httpTransport.MaxConnsPerHost = 100
-	httpTransport.MaxIdleConnsPerHost = 100
-	httpTransport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-		return net.Dial("unix", socketPath)
-	}
-	httpClient = &http.Client{
-		Transport: httpTransport,
-		Timeout:   options.httpClientTimeout,
-	}
+	httpClient := httpclient.NewClient(options.httpClientTimeout,
+		httpclient.WithUnixSocket(socketPath),
+		httpclient.WithMaxIdleConns(options.maxIdleConns),
+		httpclient.WithMaxConnsPerHost(100),
+	)
 
 	return &UDSHTTPBasedUDF{
-		client: httpClient,
+		client:  httpClient,
+		timeout: options.httpClientTimeout,
 	}
 }
 
 // Apply applies the user defined function.
 func (u *UDSHTTPBasedUDF) Apply(ctx context.Context, readMessage *isb.ReadMessage) ([]*isb.Message, error) {
-	payload := readMessage.Body.Payload
-	offset := readMessage.ReadOffset
-	parentPaneInfo := readMessage.PaneInfo
+	headers, err := messageHeaders(readMessage)
+	if err != nil {
+		return nil, err
+	}
+	data, contentType, err := u.post(ctx, "http://unix/messages", readMessage.Body.Payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := unmarshalMessages(contentType, data)
+	if err != nil {
+		return nil, err
+	}
+	return toWriteMessages(readMessage, messages), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "http://unix/messages", bytes.NewBuffer(payload))
-	// looking at the code, err returned by NewRequestWithContext are InternalErrs which cannot be resolved by retrying.
-	// Let's return InternalErr and pause the pipeline.
+// ApplyStream applies the user defined function with the outputs streamed back, they are handed over in chunks of the
+// chunk size as they arrive. The call is aborted if there is no output within the timeout. The outputs are identified
+// by their positions in the stream, same as Apply, so that the chunks handed over again on retries can be deduplicated.
+func (u *UDSHTTPBasedUDF) ApplyStream(ctx context.Context, readMessage *isb.ReadMessage, chunkSize int, handle func([]*isb.Message) error) error {
+	headers, err := messageHeaders(readMessage)
 	if err != nil {
-		return nil, ApplyUDFErr{
+		return err
+	}
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	idle := time.AfterFunc(u.timeout, cancel)
+	defer idle.Stop()
+	// timedOut tells if the call is canceled by the idle timer
+	timedOut := func() bool { return ctx.Err() != nil && parent.Err() == nil }
+	req, err := http.NewRequestWithContext(ctx, "POST", "http://unix/messages/stream", bytes.NewBuffer(readMessage.Body.Payload))
+	if err != nil {
+		return ApplyUDFErr{
 			UserUDFErr: false,
 			Message:    fmt.Sprintf("http.NewRequestWithContext failed, %s", err),
 			InternalErr: InternalErr{
@@ -101,8 +142,163 @@ func (u *UDSHTTPBasedUDF) Apply(ctx context.Context, readMessage *isb.ReadMessag
 			},
 		}
 	}
-	req.Header.Set(dfv1.UDFApplierMessageKey, string(readMessage.Key))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	// the whole stream could take longer than the client timeout, which is applied to each output instead
+	client := *u.client
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return ApplyUDFErr{
+			UserUDFErr: false,
+			Message:    fmt.Sprintf("client.Do failed, %s", err),
+			Timeout:    timedOut(),
+			InternalErr: InternalErr{
+				Flag:        true,
+				MainCarDown: false,
+			},
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return ApplyUDFErr{
+			UserUDFErr: true,
+			Message:    fmt.Sprintf("maincar returned statusCode=%d %s", resp.StatusCode, data),
+			InternalErr: InternalErr{
+				Flag:        false,
+				MainCarDown: false,
+			},
+		}
+	}
+	decode, err := newStreamDecoder(dfv1.ContentType(resp.Header.Get("Content-Type")), resp.Body)
+	if err != nil {
+		return err
+	}
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	chunk := make([]*isb.Message, 0, chunkSize)
+	for i := 0; ; i++ {
+		m := funcsdk.Message{}
+		if err := decode(&m); err == io.EOF {
+			break
+		} else if err != nil {
+			return ApplyUDFErr{
+				UserUDFErr: true,
+				Message:    fmt.Sprintf("failed to read the output stream, %s", err),
+				Timeout:    timedOut(),
+				InternalErr: InternalErr{
+					Flag:        false,
+					MainCarDown: false,
+				},
+			}
+		}
+		idle.Reset(u.timeout)
+		chunk = append(chunk, toWriteMessage(readMessage, m, i))
+		if len(chunk) >= chunkSize {
+			if err := handle(chunk); err != nil {
+				return err
+			}
+			chunk = make([]*isb.Message, 0, chunkSize)
+		}
+	}
+	// the error of the UDF is only known at the end of the stream
+	if msg := resp.Trailer.Get(dfv1.UDFApplierStreamErrorKey); msg != "" {
+		return ApplyUDFErr{
+			UserUDFErr: true,
+			Message:    fmt.Sprintf("UDF failed in the middle of the stream, %s", msg),
+			InternalErr: InternalErr{
+				Flag:        false,
+				MainCarDown: false,
+			},
+		}
+	}
+	if len(chunk) > 0 {
+		return handle(chunk)
+	}
+	return nil
+}
+
+// Streaming tells if the UDF declares the outputs can be streamed in the readiness check.
+func (u *UDSHTTPBasedUDF) Streaming() bool {
+	return u.streaming
+}
+
+// ApplyBatch applies the user defined function on the whole batch in one call, or in chunks of the max batch size
+// declared by the UDF. The results are returned in the same order as the messages.
+func (u *UDSHTTPBasedUDF) ApplyBatch(ctx context.Context, readMessages []*isb.ReadMessage) ([][]*isb.Message, error) {
+	if u.maxBatchSize <= 0 || len(readMessages) <= u.maxBatchSize {
+		return u.applyBatch(ctx, readMessages)
+	}
+	writeMessages := make([][]*isb.Message, 0, len(readMessages))
+	for start := 0; start < len(readMessages); start += u.maxBatchSize {
+		end := start + u.maxBatchSize
+		if end > len(readMessages) {
+			end = len(readMessages)
+		}
+		results, err := u.applyBatch(ctx, readMessages[start:end])
+		if err != nil {
+			return nil, err
+		}
+		writeMessages = append(writeMessages, results...)
+	}
+	return writeMessages, nil
+}
+
+// MaxBatchSize returns the max number of messages in a batch declared by the UDF in the readiness check, 0 if there is
+// no limit.
+func (u *UDSHTTPBasedUDF) MaxBatchSize() int {
+	return u.maxBatchSize
+}
+
+// applyBatch applies the user defined function on the batch in one call. The messages are identified by their indexes
+// in the batch, and the results are returned in the same order as the messages.
+func (u *UDSHTTPBasedUDF) applyBatch(ctx context.Context, readMessages []*isb.ReadMessage) ([][]*isb.Message, error) {
+	batch := make([]funcsdk.BatchMessage, len(readMessages))
+	for i, m := range readMessages {
+		batch[i] = funcsdk.BatchMessage{ID: strconv.Itoa(i), Key: m.Key, Value: m.Payload, Metadata: m.Metadata}
+	}
+	payload, err := msgpack.Marshal(&batch)
+	if err != nil {
+		return nil, ApplyUDFErr{
+			UserUDFErr: false,
+			Message:    fmt.Sprintf("marshal batch failed, %s", err),
+			InternalErr: InternalErr{
+				Flag:        true,
+				MainCarDown: false,
+			},
+		}
+	}
+	data, contentType, err := u.post(ctx, "http://unix/messages/batch", payload, map[string]string{"Content-Type": string(dfv1.MsgPackType)})
+	if err != nil {
+		return nil, err
+	}
+	results, err := unmarshalBatchResults(contentType, data)
+	if err != nil {
+		return nil, err
+	}
+	writeMessages := make([][]*isb.Message, len(readMessages))
+	for i, m := range readMessages {
+		messages, ok := results[strconv.Itoa(i)]
+		if !ok {
+			return nil, ApplyUDFErr{
+				UserUDFErr: true,
+				Message:    fmt.Sprintf("no result for message %q in the batch", m.ID),
+				InternalErr: InternalErr{
+					Flag:        false,
+					MainCarDown: false,
+				},
+			}
+		}
+		writeMessages[i] = toWriteMessages(m, messages)
+	}
+	return writeMessages, nil
+}
 
+// post sends the payload to the UDF, and returns the response body and its content type.
+func (u *UDSHTTPBasedUDF) post(ctx context.Context, url string, payload []byte, headers map[string]string) ([]byte, dfv1.ContentType, error) {
 	// hold results (from response)
 	var data []byte
 	var contentType dfv1.ContentType
@@ -115,10 +311,27 @@ retry:
 	for i < retryCount && failed {
 		i++
 		failed = false
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
+		// looking at the code, err returned by NewRequestWithContext are InternalErrs which cannot be resolved by retrying.
+		// Let's return InternalErr and pause the pipeline.
+		if err != nil {
+			return nil, "", ApplyUDFErr{
+				UserUDFErr: false,
+				Message:    fmt.Sprintf("http.NewRequestWithContext failed, %s", err),
+				InternalErr: InternalErr{
+					Flag:        true,
+					MainCarDown: false,
+				},
+			}
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
 		if resp, err := u.client.Do(req); err != nil {
-			return nil, ApplyUDFErr{
+			return nil, "", ApplyUDFErr{
 				UserUDFErr: false,
 				Message:    fmt.Sprintf("client.Do failed, %s", err),
+				Timeout:    os.IsTimeout(err),
 				InternalErr: InternalErr{
 					Flag:        true,
 					MainCarDown: false,
@@ -143,7 +356,7 @@ retry:
 	}
 	// ran out of retry limit
 	if failed {
-		return nil, ApplyUDFErr{
+		return nil, "", ApplyUDFErr{
 			UserUDFErr: true,
 			Message:    "ran out of retry limit",
 			InternalErr: InternalErr{
@@ -152,32 +365,63 @@ retry:
 			},
 		}
 	}
-	messages, err := unmarshalMessages(contentType, data)
-	if err != nil {
-		return nil, err
+	return data, contentType, nil
+}
+
+// messageHeaders returns the HTTP headers passing the key and the metadata of the read message to the UDF.
+func messageHeaders(readMessage *isb.ReadMessage) (map[string]string, error) {
+	headers := map[string]string{dfv1.UDFApplierMessageKey: string(readMessage.Key)}
+	if len(readMessage.Metadata) > 0 {
+		metadata, err := json.Marshal(readMessage.Metadata)
+		if err != nil {
+			return nil, ApplyUDFErr{
+				UserUDFErr: false,
+				Message:    fmt.Sprintf("marshal message metadata failed, %s", err),
+				InternalErr: InternalErr{
+					Flag:        true,
+					MainCarDown: false,
+				},
+			}
+		}
+		headers[dfv1.UDFApplierMessageMetadataKey] = string(metadata)
 	}
+	return headers, nil
+}
+
+// toWriteMessages converts the UDF results to the messages to be written to the buffers. The messages carry over the
+// metadata of the read message unless the UDF sets their own.
+func toWriteMessages(readMessage *isb.ReadMessage, messages *funcsdk.Messages) []*isb.Message {
 	writeMessages := []*isb.Message{}
 	for i, m := range messages.Items() {
-		key := m.Key
-		if key == nil {
-			key = []byte{}
-		}
-		writeMessage := &isb.Message{
-			Header: isb.Header{
-				PaneInfo: parentPaneInfo,
-				ID:       fmt.Sprintf("%s-%d", offset.String(), i),
-				Key:      key,
-			},
-			Body: isb.Body{
-				Payload: m.Value,
-			},
-		}
-		writeMessages = append(writeMessages, writeMessage)
+		writeMessages = append(writeMessages, toWriteMessage(readMessage, m, i))
 	}
-	return writeMessages, nil
+	return writeMessages
 }
 
-// WaitUntilReady waits till the readyURL is available
+// toWriteMessage converts the i-th output of the UDF on the read message to the message to be written to the buffers.
+func toWriteMessage(readMessage *isb.ReadMessage, m funcsdk.Message, i int) *isb.Message {
+	key := m.Key
+	if key == nil {
+		key = []byte{}
+	}
+	metadata := m.Metadata
+	if metadata == nil {
+		metadata = readMessage.Metadata
+	}
+	return &isb.Message{
+		Header: isb.Header{
+			PaneInfo: readMessage.PaneInfo,
+			ID:       fmt.Sprintf("%s-%d", readMessage.ReadOffset.String(), i),
+			Key:      key,
+			Metadata: metadata,
+		},
+		Body: isb.Body{
+			Payload: m.Value,
+		},
+	}
+}
+
+// WaitUntilReady waits till the readyURL is available, and takes the max batch size declared by the UDF in the response.
 func (u *UDSHTTPBasedUDF) WaitUntilReady(ctx context.Context) error {
 	for {
 		select {
@@ -188,6 +432,14 @@ func (u *UDSHTTPBasedUDF) WaitUntilReady(ctx context.Context) error {
 				_, _ = io.Copy(io.Discard, resp.Body)
 				_ = resp.Body.Close()
 				if resp.StatusCode < 300 {
+					if v := resp.Header.Get(dfv1.UDFApplierMaxBatchSizeKey); v != "" {
+						if n, err := strconv.Atoi(v); err != nil || n < 0 {
+							logging.FromContext(ctx).Warnf("Ignoring the invalid max batch size %q declared by the UDF", v)
+						} else {
+							u.maxBatchSize = n
+						}
+					}
+					u.streaming = resp.Header.Get(dfv1.UDFApplierStreamingKey) == "true"
 					return nil
 				}
 			}
@@ -212,3 +464,42 @@ func unmarshalMessages(contentType dfv1.ContentType, data []byte) (*funcsdk.Mess
 	}
 	return messages, nil
 }
+
+// newStreamDecoder returns a function decoding the messages from the stream one after another, it returns io.EOF at the
+// end of the stream.
+func newStreamDecoder(contentType dfv1.ContentType, r io.Reader) (func(*funcsdk.Message) error, error) {
+	switch contentType {
+	case dfv1.JsonType:
+		dec := json.NewDecoder(r)
+		return func(m *funcsdk.Message) error { return dec.Decode(m) }, nil
+	case dfv1.MsgPackType:
+		dec := msgpack.NewDecoder(r)
+		return func(m *funcsdk.Message) error { return dec.Decode(m) }, nil
+	default:
+		return nil, ApplyUDFErr{
+			UserUDFErr: true,
+			Message:    fmt.Sprintf("unsupported UDF Content-Type %q", string(contentType)),
+			InternalErr: InternalErr{
+				Flag:        false,
+				MainCarDown: false,
+			},
+		}
+	}
+}
+
+func unmarshalBatchResults(contentType dfv1.ContentType, data []byte) (map[string]*funcsdk.Messages, error) {
+	results := map[string]*funcsdk.Messages{}
+	switch contentType {
+	case dfv1.JsonType:
+		if err := json.Unmarshal(data, &results); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal batch results from the response with json, %w", err)
+		}
+	case dfv1.MsgPackType:
+		if err := msgpack.Unmarshal(data, &results); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal batch results from the response with msgpack, %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported UDF Content-Type %q", string(contentType))
+	}
+	return results, nil
+}
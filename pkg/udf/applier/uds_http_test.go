@@ -55,6 +55,25 @@ func TestHTTPBasedUDF_WaitUntilReady(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestHTTPBasedUDF_WaitUntilReadyMaxBatchSize(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(dfv1.UDFApplierMaxBatchSizeKey, "20")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	ts.Listener = listener
+	ts.Start()
+	defer ts.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = testClient
+	assert.Equal(t, 0, u.MaxBatchSize())
+	assert.NoError(t, u.WaitUntilReady(context.Background()))
+	assert.Equal(t, 20, u.MaxBatchSize())
+}
+
 func TestHTTPBasedUDF_BasicApply(t *testing.T) {
 	t.Run("test 200", func(t *testing.T) {
 		u := NewUDSHTTPBasedUDF(testSocketPath)
@@ -168,3 +187,285 @@ func TestHTTPBasedUDF_Apply(t *testing.T) {
 	assert.Equal(t, expectedResults, results)
 	assert.Equal(t, expectedKeys, resultKeys)
 }
+
+func TestHTTPBasedUDF_ApplyBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/messages/batch", r.URL.Path)
+		assert.Equal(t, string(dfv1.MsgPackType), r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		batch := []funcsdk.BatchMessage{}
+		_ = msgpack.Unmarshal(body, &batch)
+		results := funcsdk.BatchResults{}
+		// return the results in the reverse order, they are expected to be matched by IDs
+		for i := len(batch) - 1; i >= 0; i-- {
+			results[batch[i].ID] = funcsdk.MessagesBuilder().Append(funcsdk.Message{Key: batch[i].Key, Value: batch[i].Value})
+		}
+		b, _ := msgpack.Marshal(results)
+		w.Header().Add("Content-Type", string(dfv1.MsgPackType))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = testClient
+	readMessages := testutils.BuildTestReadMessages(int64(5), time.Unix(1636470000, 0))
+	batch := make([]*isb.ReadMessage, len(readMessages))
+	for i := range readMessages {
+		batch[i] = &readMessages[i]
+	}
+	results, err := u.ApplyBatch(ctx, batch)
+	assert.NoError(t, err)
+	assert.Len(t, results, len(batch))
+	for i, m := range batch {
+		assert.Len(t, results[i], 1)
+		assert.Equal(t, m.Payload, results[i][0].Payload)
+		assert.Equal(t, m.ReadOffset.String()+"-0", results[i][0].ID)
+	}
+}
+
+func TestHTTPBasedUDF_ApplyMetadata(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msgs funcsdk.Messages
+		switch r.URL.Path {
+		case "/messages/batch":
+			body, _ := ioutil.ReadAll(r.Body)
+			batch := []funcsdk.BatchMessage{}
+			_ = msgpack.Unmarshal(body, &batch)
+			results := funcsdk.BatchResults{}
+			for _, m := range batch {
+				assert.Equal(t, map[string]string{"tenant": "a"}, m.Metadata)
+				results[m.ID] = funcsdk.MessagesBuilder().Append(funcsdk.Message{Key: m.Key, Value: m.Value})
+			}
+			b, _ := msgpack.Marshal(results)
+			w.Header().Add("Content-Type", string(dfv1.MsgPackType))
+			_, _ = w.Write(b)
+			return
+		default:
+			assert.Equal(t, `{"tenant":"a"}`, r.Header.Get(dfv1.UDFApplierMessageMetadataKey))
+			// one message inherits the metadata of the input, the other one replaces it
+			msgs = funcsdk.MessagesBuilder().
+				Append(funcsdk.MessageTo("inherit", []byte("test"))).
+				Append(funcsdk.MessageTo("replace", []byte("test")).WithMetadata(map[string]string{"tenant": "b"}))
+		}
+		b, _ := msgpack.Marshal(msgs)
+		w.Header().Add("Content-Type", string(dfv1.MsgPackType))
+		_, _ = w.Write(b)
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = testClient
+	readMessages := testutils.BuildTestReadMessages(int64(2), time.Unix(1636470000, 0))
+	for i := range readMessages {
+		readMessages[i].Metadata = map[string]string{"tenant": "a"}
+	}
+	results, err := u.Apply(ctx, &readMessages[0])
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, map[string]string{"tenant": "a"}, results[0].Metadata)
+	assert.Equal(t, map[string]string{"tenant": "b"}, results[1].Metadata)
+
+	batchResults, err := u.ApplyBatch(ctx, []*isb.ReadMessage{&readMessages[0], &readMessages[1]})
+	assert.NoError(t, err)
+	assert.Len(t, batchResults, 2)
+	for _, r := range batchResults {
+		assert.Equal(t, map[string]string{"tenant": "a"}, r[0].Metadata)
+	}
+}
+
+func TestHTTPBasedUDF_ApplyBatchChunks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var batchSizes []int
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		batch := []funcsdk.BatchMessage{}
+		_ = msgpack.Unmarshal(body, &batch)
+		batchSizes = append(batchSizes, len(batch))
+		results := funcsdk.BatchResults{}
+		for _, m := range batch {
+			results[m.ID] = funcsdk.MessagesBuilder().Append(funcsdk.Message{Key: m.Key, Value: m.Value})
+		}
+		b, _ := msgpack.Marshal(results)
+		w.Header().Add("Content-Type", string(dfv1.MsgPackType))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = testClient
+	u.maxBatchSize = 2
+	readMessages := testutils.BuildTestReadMessages(int64(5), time.Unix(1636470000, 0))
+	batch := make([]*isb.ReadMessage, len(readMessages))
+	for i := range readMessages {
+		batch[i] = &readMessages[i]
+	}
+	results, err := u.ApplyBatch(ctx, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 1}, batchSizes)
+	assert.Len(t, results, len(batch))
+	for i, m := range batch {
+		assert.Len(t, results[i], 1)
+		assert.Equal(t, m.Payload, results[i][0].Payload)
+		assert.Equal(t, m.ReadOffset.String()+"-0", results[i][0].ID)
+	}
+}
+
+func TestHTTPBasedUDF_ApplyTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = &http.Client{Transport: testTransport, Timeout: 50 * time.Millisecond}
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+	_, err := u.Apply(ctx, &readMessages[0])
+	assert.Error(t, err)
+	assert.Equal(t, ErrorClassTimeout, ClassifyError(err))
+}
+
+func TestHTTPBasedUDF_ApplyBatchMissingResult(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := msgpack.Marshal(funcsdk.BatchResults{})
+		w.Header().Add("Content-Type", string(dfv1.MsgPackType))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = testClient
+	readMessages := testutils.BuildTestReadMessages(int64(2), time.Unix(1636470000, 0))
+	_, err := u.ApplyBatch(ctx, []*isb.ReadMessage{&readMessages[0], &readMessages[1]})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no result")
+}
+
+func TestHTTPBasedUDF_ApplyStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/messages/stream", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Trailer", dfv1.UDFApplierStreamErrorKey)
+		w.Header().Set("Content-Type", string(dfv1.MsgPackType))
+		w.WriteHeader(http.StatusOK)
+		enc := msgpack.NewEncoder(w)
+		for i := 0; i < 5; i++ {
+			_ = enc.Encode(funcsdk.Message{Key: []byte(fmt.Sprint(i)), Value: body})
+			w.(http.Flusher).Flush()
+		}
+		if string(body) == "fail" {
+			w.Header().Set(dfv1.UDFApplierStreamErrorKey, "test error")
+		}
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = testClient
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+
+	t.Run("test chunks", func(t *testing.T) {
+		var chunks [][]*isb.Message
+		err := u.ApplyStream(ctx, &readMessages[0], 2, func(chunk []*isb.Message) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Len(t, chunks, 3)
+		assert.Len(t, chunks[2], 1)
+		for i, m := range append(append(chunks[0], chunks[1]...), chunks[2]...) {
+			assert.Equal(t, fmt.Sprintf("%s-%d", readMessages[0].ReadOffset.String(), i), m.ID)
+			assert.Equal(t, []byte(fmt.Sprint(i)), m.Key)
+			assert.Equal(t, readMessages[0].Payload, m.Payload)
+		}
+	})
+
+	t.Run("test chunk handling error", func(t *testing.T) {
+		err := u.ApplyStream(ctx, &readMessages[0], 2, func(chunk []*isb.Message) error {
+			return fmt.Errorf("write error")
+		})
+		assert.EqualError(t, err, "write error")
+	})
+
+	t.Run("test UDF error", func(t *testing.T) {
+		m := readMessages[0]
+		m.Payload = []byte("fail")
+		var handled int
+		err := u.ApplyStream(ctx, &m, 2, func(chunk []*isb.Message) error {
+			handled += len(chunk)
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "test error")
+		// the last partial chunk is not handed over once the UDF fails
+		assert.Equal(t, 4, handled)
+	})
+}
+
+func TestHTTPBasedUDF_WaitUntilReadyStreaming(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(dfv1.UDFApplierStreamingKey, "true")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	_ = os.Remove(testSocketPath)
+	listener, _ := net.Listen("unix", testSocketPath)
+	defer func() { _ = listener.Close() }()
+	ts.Listener = listener
+	ts.Start()
+	defer ts.Close()
+
+	u := NewUDSHTTPBasedUDF(testSocketPath)
+	u.client = testClient
+	assert.False(t, u.Streaming())
+	assert.NoError(t, u.WaitUntilReady(context.Background()))
+	assert.True(t, u.Streaming())
+}
@@ -0,0 +1,32 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// WarmUp sends a number of synthetic messages to the Applier before the real traffic comes in, it gives the UDFs with
+// cold start cost (JIT compiling, model loading, etc.) a chance to get ready. The output of the UDF is discarded.
+func WarmUp(ctx context.Context, a Applier, key, payload []byte, requests int) error {
+	for i := 0; i < requests; i++ {
+		id := fmt.Sprintf("warm-up-%d", i)
+		msg := &isb.ReadMessage{
+			Message: isb.Message{
+				Header: isb.Header{
+					PaneInfo: isb.PaneInfo{EventTime: time.Now()},
+					ID:       id,
+					Key:      key,
+				},
+				Body: isb.Body{Payload: payload},
+			},
+			ReadOffset: isb.SimpleOffset(func() string { return id }),
+		}
+		if _, err := a.Apply(ctx, msg); err != nil {
+			return fmt.Errorf("warm-up request %d failed, %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmUp(t *testing.T) {
+	t.Run("test all requests sent", func(t *testing.T) {
+		count := 0
+		a := ApplyFunc(func(ctx context.Context, msg *isb.ReadMessage) ([]*isb.Message, error) {
+			count++
+			assert.Equal(t, "k", string(msg.Key))
+			assert.Equal(t, "hello", string(msg.Payload))
+			assert.Equal(t, fmt.Sprintf("warm-up-%d", count-1), msg.ReadOffset.String())
+			return nil, nil
+		})
+		err := WarmUp(context.Background(), a, []byte("k"), []byte("hello"), 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("test failure", func(t *testing.T) {
+		count := 0
+		a := ApplyFunc(func(ctx context.Context, msg *isb.ReadMessage) ([]*isb.Message, error) {
+			count++
+			return nil, fmt.Errorf("not ready")
+		})
+		err := WarmUp(context.Background(), a, nil, []byte("hello"), 3)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not ready")
+		assert.Equal(t, 1, count)
+	})
+}
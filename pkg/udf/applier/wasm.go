@@ -0,0 +1,236 @@
+package applier
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+// The functions a WASM module exports to be used as a UDF.
+//
+//	alloc(size i32) i32           allocates size bytes in the module memory, and returns the pointer.
+//	udf(ptr i32, len i32) i64     processes the input record at ptr, and returns the results packed as (ptr << 32 | len).
+//	dealloc(ptr i32, size i32)    optional, frees the memory of the input and the results after each call.
+//
+// Both the input and the results are encoded as records, each of which is a little-endian uint32 length of the key,
+// the key, a little-endian uint32 length of the value and the value. The input has exactly one record, and the results
+// have zero or more records, each of them is a message to write, no record means DROP.
+const (
+	wasmFuncAlloc   = "alloc"
+	wasmFuncUDF     = "udf"
+	wasmFuncDealloc = "dealloc"
+)
+
+// WASMUDF applies the user defined function compiled to a WebAssembly module, it is executed in-process with the wazero
+// runtime. A module instance is not safe for concurrent use, so the instances are pooled, one for each concurrent call.
+type WASMUDF struct {
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	config   wazero.ModuleConfig
+	pool     chan api.Module
+}
+
+var _ Applier = (*WASMUDF)(nil)
+var _ BatchApplier = (*WASMUDF)(nil)
+
+// NewWASMUDF compiles the WASM module, and returns WASMUDF keeping up to maxIdle idle instances.
+// The WASI imports are provided, so that the modules built by the common toolchains with WASI support can be used.
+func NewWASMUDF(ctx context.Context, module []byte, maxIdle int) (*WASMUDF, error) {
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		_ = r.Close(ctx)
+		return nil, fmt.Errorf("failed to instantiate wasi, %w", err)
+	}
+	compiled, err := r.CompileModule(ctx, module)
+	if err != nil {
+		_ = r.Close(ctx)
+		return nil, fmt.Errorf("failed to compile wasm module, %w", err)
+	}
+	for _, name := range []string{wasmFuncAlloc, wasmFuncUDF} {
+		if _, ok := compiled.ExportedFunctions()[name]; !ok {
+			_ = r.Close(ctx)
+			return nil, fmt.Errorf("wasm module does not export function %q", name)
+		}
+	}
+	if maxIdle < 1 {
+		maxIdle = 1
+	}
+	u := &WASMUDF{
+		runtime:  r,
+		compiled: compiled,
+		// Anonymous instances, so that the module can be instantiated multiple times.
+		// "_initialize" is the start function of the WASI reactor modules, it is skipped if not exported.
+		config: wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize").WithStdout(os.Stdout).WithStderr(os.Stderr),
+		pool:   make(chan api.Module, maxIdle),
+	}
+	// Instantiate one to fail fast on the modules which can not be started.
+	m, err := u.acquire(ctx)
+	if err != nil {
+		_ = r.Close(ctx)
+		return nil, err
+	}
+	u.release(ctx, m)
+	return u, nil
+}
+
+// Apply applies the user defined function.
+func (u *WASMUDF) Apply(ctx context.Context, readMessage *isb.ReadMessage) ([]*isb.Message, error) {
+	messages, err := u.call(ctx, readMessage.Key, readMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return toWriteMessages(readMessage, &messages), nil
+}
+
+// ApplyBatch applies the user defined function on the messages one by one, there is no per-call overhead to save for
+// the in-process module, it is implemented so that the batch mode works for it as well.
+func (u *WASMUDF) ApplyBatch(ctx context.Context, readMessages []*isb.ReadMessage) ([][]*isb.Message, error) {
+	results := make([][]*isb.Message, len(readMessages))
+	for i, m := range readMessages {
+		writeMessages, err := u.Apply(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = writeMessages
+	}
+	return results, nil
+}
+
+// Close closes the runtime and all the module instances.
+func (u *WASMUDF) Close(ctx context.Context) error {
+	return u.runtime.Close(ctx)
+}
+
+func (u *WASMUDF) acquire(ctx context.Context) (api.Module, error) {
+	select {
+	case m := <-u.pool:
+		return m, nil
+	default:
+	}
+	m, err := u.runtime.InstantiateModule(ctx, u.compiled, u.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate wasm module, %w", err)
+	}
+	return m, nil
+}
+
+func (u *WASMUDF) release(ctx context.Context, m api.Module) {
+	select {
+	case u.pool <- m:
+	default:
+		_ = m.Close(ctx)
+	}
+}
+
+// call invokes the module, the traps and malformed results of the module are returned as user errors.
+func (u *WASMUDF) call(ctx context.Context, key, payload []byte) (funcsdk.Messages, error) {
+	m, err := u.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := invokeWASM(ctx, m, key, payload)
+	if err != nil {
+		// The memory of the instance might be left in a broken state, do not reuse it.
+		_ = m.Close(ctx)
+		return nil, ApplyUDFErr{
+			UserUDFErr: true,
+			Message:    fmt.Sprintf("wasm function failed, %s", err),
+			InternalErr: InternalErr{
+				Flag:        false,
+				MainCarDown: false,
+			},
+		}
+	}
+	u.release(ctx, m)
+	if len(messages) == 0 { // same as the UDF server, no result means DROP
+		messages = append(messages, funcsdk.MessageToDrop())
+	}
+	return messages, nil
+}
+
+func invokeWASM(ctx context.Context, m api.Module, key, payload []byte) (funcsdk.Messages, error) {
+	input := encodeWASMRecord(nil, key, payload)
+	res, err := m.ExportedFunction(wasmFuncAlloc).Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate memory, %w", err)
+	}
+	inPtr := uint32(res[0])
+	if !m.Memory().Write(inPtr, input) {
+		return nil, fmt.Errorf("allocated memory [%d, %d) is out of range", inPtr, inPtr+uint32(len(input)))
+	}
+	res, err = m.ExportedFunction(wasmFuncUDF).Call(ctx, uint64(inPtr), uint64(len(input)))
+	if err != nil {
+		return nil, err
+	}
+	outPtr, outLen := uint32(res[0]>>32), uint32(res[0])
+	var messages funcsdk.Messages
+	if outLen > 0 {
+		output, ok := m.Memory().Read(outPtr, outLen)
+		if !ok {
+			return nil, fmt.Errorf("results [%d, %d) are out of range", outPtr, outPtr+outLen)
+		}
+		// The memory view is only valid until the next call, decode copies the bytes out.
+		if messages, err = decodeWASMRecords(output); err != nil {
+			return nil, err
+		}
+	}
+	if dealloc := m.ExportedFunction(wasmFuncDealloc); dealloc != nil {
+		if _, err := dealloc.Call(ctx, uint64(inPtr), uint64(len(input))); err != nil {
+			return nil, fmt.Errorf("failed to free memory, %w", err)
+		}
+		if outLen > 0 && outPtr != inPtr {
+			if _, err := dealloc.Call(ctx, uint64(outPtr), uint64(outLen)); err != nil {
+				return nil, fmt.Errorf("failed to free memory, %w", err)
+			}
+		}
+	}
+	return messages, nil
+}
+
+func encodeWASMRecord(dst []byte, key, value []byte) []byte {
+	var n [4]byte
+	binary.LittleEndian.PutUint32(n[:], uint32(len(key)))
+	dst = append(dst, n[:]...)
+	dst = append(dst, key...)
+	binary.LittleEndian.PutUint32(n[:], uint32(len(value)))
+	dst = append(dst, n[:]...)
+	return append(dst, value...)
+}
+
+func decodeWASMRecords(data []byte) (funcsdk.Messages, error) {
+	var messages funcsdk.Messages
+	next := func() ([]byte, error) {
+		if len(data) < 4 {
+			return nil, fmt.Errorf("malformed results, %d bytes left for the length", len(data))
+		}
+		n := binary.LittleEndian.Uint32(data)
+		data = data[4:]
+		if uint32(len(data)) < n {
+			return nil, fmt.Errorf("malformed results, length %d exceeds the %d bytes left", n, len(data))
+		}
+		b := make([]byte, n)
+		copy(b, data)
+		data = data[n:]
+		return b, nil
+	}
+	for len(data) > 0 {
+		key, err := next()
+		if err != nil {
+			return nil, err
+		}
+		value, err := next()
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, funcsdk.Message{Key: key, Value: value})
+	}
+	return messages, nil
+}
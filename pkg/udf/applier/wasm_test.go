@@ -0,0 +1,134 @@
+package applier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/testutils"
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+// testWASMModule assembles a module exporting "memory", a bump allocator "alloc", and "udf" with the given body.
+func testWASMModule(udfBody ...byte) []byte {
+	section := func(id byte, content ...byte) []byte {
+		return append([]byte{id, byte(len(content))}, content...)
+	}
+	allocBody := []byte{0x00, 0x23, 0x00, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x24, 0x00, 0x0b} // return top; top += size
+	code := []byte{0x02, byte(len(allocBody))}
+	code = append(code, allocBody...)
+	code = append(code, byte(len(udfBody)))
+	code = append(code, udfBody...)
+	m := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	m = append(m, section(0x01, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e)...) // (i32)->i32, (i32,i32)->i64
+	m = append(m, section(0x03, 0x02, 0x00, 0x01)...)
+	m = append(m, section(0x05, 0x01, 0x00, 0x01)...)                         // 1 page
+	m = append(m, section(0x06, 0x01, 0x7f, 0x01, 0x41, 0x80, 0x08, 0x0b)...) // mut i32 top = 1024
+	m = append(m, section(0x07, 0x03,
+		0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+		0x05, 'a', 'l', 'l', 'o', 'c', 0x00, 0x00,
+		0x03, 'u', 'd', 'f', 0x00, 0x01)...)
+	return append(m, section(0x0a, code...)...)
+}
+
+var (
+	// returns the input record as the result
+	wasmEcho = testWASMModule(0x00, 0x20, 0x00, 0xad, 0x42, 0x20, 0x86, 0x20, 0x01, 0xad, 0x84, 0x0b)
+	// returns no result
+	wasmDrop = testWASMModule(0x00, 0x42, 0x00, 0x0b)
+	// returns a result beyond the memory
+	wasmOutOfRange = testWASMModule(0x00, 0x42, 0x7f, 0x0b)
+	// traps
+	wasmTrap = testWASMModule(0x00, 0x00, 0x0b)
+)
+
+func TestWASMUDF_Apply(t *testing.T) {
+	ctx := context.Background()
+	u, err := NewWASMUDF(ctx, wasmEcho, 2)
+	assert.NoError(t, err)
+	defer func() { _ = u.Close(ctx) }()
+
+	readMessages := testutils.BuildTestReadMessages(int64(10), time.Unix(1636470000, 0))
+	var wg sync.WaitGroup
+	for i := range readMessages {
+		wg.Add(1)
+		go func(m *isb.ReadMessage) {
+			defer wg.Done()
+			results, err := u.Apply(ctx, m)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(results))
+			assert.Equal(t, string(m.Key), string(results[0].Key))
+			assert.Equal(t, m.Payload, results[0].Payload)
+		}(&readMessages[i])
+	}
+	wg.Wait()
+
+	batch := []*isb.ReadMessage{&readMessages[0], &readMessages[1]}
+	results, err := u.ApplyBatch(ctx, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, readMessages[1].Payload, results[1][0].Payload)
+}
+
+func TestWASMUDF_ApplyDrop(t *testing.T) {
+	ctx := context.Background()
+	u, err := NewWASMUDF(ctx, wasmDrop, 1)
+	assert.NoError(t, err)
+	defer func() { _ = u.Close(ctx) }()
+
+	readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+	results, err := u.Apply(ctx, &readMessages[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, []byte(funcsdk.DROP), results[0].Key)
+}
+
+func TestWASMUDF_ApplyErr(t *testing.T) {
+	ctx := context.Background()
+	for name, module := range map[string][]byte{"trap": wasmTrap, "out of range": wasmOutOfRange} {
+		t.Run(name, func(t *testing.T) {
+			u, err := NewWASMUDF(ctx, module, 1)
+			assert.NoError(t, err)
+			defer func() { _ = u.Close(ctx) }()
+			readMessages := testutils.BuildTestReadMessages(int64(1), time.Unix(1636470000, 0))
+			_, err = u.Apply(ctx, &readMessages[0])
+			assert.Error(t, err)
+			var udfErr ApplyUDFErr
+			assert.True(t, errors.As(err, &udfErr))
+			assert.True(t, udfErr.UserUDFErr)
+			// a new instance replaces the failed one
+			_, err = u.Apply(ctx, &readMessages[0])
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewWASMUDF(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewWASMUDF(ctx, []byte("not a module"), 1)
+	assert.Error(t, err)
+	module := testWASMModule(0x00, 0x42, 0x00, 0x0b)
+	module[bytes.Index(module, []byte(wasmFuncUDF))] = 'x' // rename "udf"
+	_, err = NewWASMUDF(ctx, module, 1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("does not export function %q", wasmFuncUDF))
+}
+
+func Test_decodeWASMRecords(t *testing.T) {
+	data := encodeWASMRecord(nil, []byte("k1"), []byte("v1"))
+	data = encodeWASMRecord(data, nil, []byte("v2"))
+	messages, err := decodeWASMRecords(data)
+	assert.NoError(t, err)
+	assert.Equal(t, funcsdk.Messages{{Key: []byte("k1"), Value: []byte("v1")}, {Key: []byte{}, Value: []byte("v2")}}, messages)
+	_, err = decodeWASMRecords(data[:len(data)-1])
+	assert.Error(t, err)
+	_, err = decodeWASMRecords(data[:2])
+	assert.Error(t, err)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/numaproj/numaflow/pkg/shared/logging"
 	"github.com/numaproj/numaflow/pkg/udf/builtin/cat"
 	"github.com/numaproj/numaflow/pkg/udf/builtin/filter"
+	"github.com/numaproj/numaflow/pkg/udf/builtin/infer"
+	"github.com/numaproj/numaflow/pkg/udf/builtin/transform"
 	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
 	"go.uber.org/zap"
 )
@@ -28,6 +30,11 @@ func (b *Builtin) Start(ctx context.Context) error {
 	return nil
 }
 
+// Handle returns the function to be invoked in-process instead of being served, e.g. to replay messages locally.
+func (b *Builtin) Handle() (funcsdk.Handle, error) {
+	return b.excutor()
+}
+
 func (b *Builtin) excutor() (funcsdk.Handle, error) {
 	// TODO: deal with args later
 	switch b.Name {
@@ -35,6 +42,10 @@ func (b *Builtin) excutor() (funcsdk.Handle, error) {
 		return cat.New(), nil
 	case "filter":
 		return filter.New(b.KWArgs)
+	case "infer":
+		return infer.New(b.KWArgs)
+	case "transform":
+		return transform.New(b.KWArgs)
 
 	default:
 		return nil, fmt.Errorf("unrecognized function %q", b.Name)
@@ -16,6 +16,14 @@ func TestGetExecutors(t *testing.T) {
 				Name:   "filter",
 				KWArgs: map[string]string{"expression": `json(payload).a=="b"`},
 			},
+			{
+				Name:   "infer",
+				KWArgs: map[string]string{"url": "http://localhost:8000", "model": "m", "input.x": `json(payload).a`},
+			},
+			{
+				Name:   "transform",
+				KWArgs: map[string]string{"expression": `{"a": json(payload).a}`},
+			},
 		}
 		for _, b := range builtins {
 			e, err := b.excutor()
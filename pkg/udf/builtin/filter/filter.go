@@ -9,26 +9,30 @@ import (
 )
 
 type filter struct {
-	expression string
+	program expr.Program
 }
 
 func New(args map[string]string) (funcsdk.Handle, error) {
-	expr, existing := args["expression"]
+	expression, existing := args["expression"]
 	if !existing {
 		return nil, fmt.Errorf("missing \"expression\"")
 	}
+	program, err := expr.Compile(expr.Language(args["language"]), expression)
+	if err != nil {
+		return nil, err
+	}
 	f := filter{
-		expression: expr,
+		program: program,
 	}
 	return func(ctx context.Context, key, msg []byte) (funcsdk.Messages, error) {
-		resultMsg, err := f.apply(msg)
+		resultMsg, err := f.apply(key, msg)
 		return funcsdk.MessagesBuilder().Append(resultMsg), err
 	}, nil
 }
 
-func (f filter) apply(msg []byte) (funcsdk.Message, error) {
+func (f filter) apply(key, msg []byte) (funcsdk.Message, error) {
 
-	result, err := expr.EvalBool(f.expression, msg)
+	result, err := expr.Bool(f.program, expr.Env{Key: key, Payload: msg})
 	if err != nil {
 		return funcsdk.MessageToDrop(), err
 	}
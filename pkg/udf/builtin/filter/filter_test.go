@@ -34,6 +34,13 @@ func TestExpression(t *testing.T) {
 	t.Run("invalid expression", func(t *testing.T) {
 		args := map[string]string{"expression": "ab\nc"}
 
+		_, err := New(args)
+		assert.Error(t, err)
+	})
+
+	t.Run("non-bool expression", func(t *testing.T) {
+		args := map[string]string{"expression": "json(payload).test"}
+
 		handle, err := New(args)
 		assert.NoError(t, err)
 
@@ -42,6 +49,26 @@ func TestExpression(t *testing.T) {
 		assert.Equal(t, "", string(result.Items()[0].Value))
 	})
 
+	t.Run("cel expression valid", func(t *testing.T) {
+		args := map[string]string{"language": "cel", "expression": `key == "k1" && json(payload).item[1].id == 2.0`}
+
+		handle, err := New(args)
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), []byte("k1"), []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.Equal(t, jsonMsg, string(result.Items()[0].Value))
+
+		result, err = handle(context.Background(), []byte("k2"), []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.Equal(t, "", string(result.Items()[0].Value))
+	})
+
+	t.Run("unsupported language", func(t *testing.T) {
+		_, err := New(map[string]string{"language": "lua", "expression": "true"})
+		assert.Error(t, err)
+	})
+
 	t.Run("Json expression invalid", func(t *testing.T) {
 		args := map[string]string{"expression": "int(json(payload).item[1].id) == 3"}
 
@@ -64,6 +91,21 @@ func TestExpression(t *testing.T) {
 		assert.Equal(t, "", string(result.Items()[0].Value))
 	})
 
+	t.Run("key expression valid", func(t *testing.T) {
+		args := map[string]string{"expression": `key == "k1" && int(json(payload).test) > 20`}
+
+		handle, err := New(args)
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), []byte("k1"), []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.Equal(t, jsonMsg, string(result.Items()[0].Value))
+
+		result, err = handle(context.Background(), []byte("k2"), []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.Equal(t, "", string(result.Items()[0].Value))
+	})
+
 	t.Run("base64 expression valid", func(t *testing.T) {
 		args := map[string]string{"expression": "sprig.contains(sprig.b64dec(payload),'numaflow')"}
 
@@ -0,0 +1,247 @@
+package infer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/shared/expr"
+	"github.com/numaproj/numaflow/pkg/shared/httpclient"
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+const (
+	inputPrefix      = "input."
+	defaultDatatype  = "FP32"
+	defaultResultKey = "inference"
+	defaultTimeout   = 10 * time.Second
+)
+
+// tensorInput maps the message payload to an input tensor of the model.
+type tensorInput struct {
+	name    string
+	program expr.Program
+}
+
+// infer calls a model served by an inference server which speaks the KServe v2 (Open Inference) HTTP protocol,
+// e.g. Triton Inference Server or ONNX Runtime Server, and enriches the message with the model outputs.
+type infer struct {
+	client    *http.Client
+	url       string
+	datatype  string
+	inputs    []tensorInput
+	outputs   []string
+	resultKey string
+}
+
+// inferRequest is the KServe v2 inference request body.
+type inferRequest struct {
+	Inputs  []inferTensor          `json:"inputs"`
+	Outputs []inferRequestedOutput `json:"outputs,omitempty"`
+}
+
+type inferRequestedOutput struct {
+	Name string `json:"name"`
+}
+
+type inferTensor struct {
+	Name     string        `json:"name"`
+	Shape    []int         `json:"shape"`
+	Datatype string        `json:"datatype"`
+	Data     []interface{} `json:"data"`
+}
+
+// inferResponse is the KServe v2 inference response body.
+type inferResponse struct {
+	ModelName string        `json:"model_name"`
+	Outputs   []inferTensor `json:"outputs"`
+}
+
+func New(args map[string]string) (funcsdk.Handle, error) {
+	baseURL, existing := args["url"]
+	if !existing || baseURL == "" {
+		return nil, fmt.Errorf("missing \"url\"")
+	}
+	model, existing := args["model"]
+	if !existing || model == "" {
+		return nil, fmt.Errorf("missing \"model\"")
+	}
+	url := fmt.Sprintf("%s/v2/models/%s", strings.TrimSuffix(baseURL, "/"), model)
+	if version := args["version"]; version != "" {
+		url += "/versions/" + version
+	}
+	f := infer{
+		url:       url + "/infer",
+		datatype:  defaultDatatype,
+		resultKey: defaultResultKey,
+	}
+	if x := args["datatype"]; x != "" {
+		f.datatype = strings.ToUpper(x)
+	}
+	if x := args["resultKey"]; x != "" {
+		f.resultKey = x
+	}
+	engine, err := expr.NewEngine(expr.Language(args["language"]))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range args {
+		if strings.HasPrefix(k, inputPrefix) && len(k) > len(inputPrefix) {
+			program, err := engine.Compile(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expression of %q, %w", k, err)
+			}
+			f.inputs = append(f.inputs, tensorInput{name: strings.TrimPrefix(k, inputPrefix), program: program})
+		}
+	}
+	if len(f.inputs) == 0 {
+		return nil, fmt.Errorf("missing input tensor mappings, at least one \"%s<tensor-name>\" is required", inputPrefix)
+	}
+	// keep the request body stable
+	sort.Slice(f.inputs, func(i, j int) bool { return f.inputs[i].name < f.inputs[j].name })
+	if x := args["outputs"]; x != "" {
+		for _, o := range strings.Split(x, ",") {
+			if o = strings.TrimSpace(o); o != "" {
+				f.outputs = append(f.outputs, o)
+			}
+		}
+	}
+	timeout := defaultTimeout
+	if x := args["timeout"]; x != "" {
+		d, err := time.ParseDuration(x)
+		if err != nil {
+			return nil, fmt.Errorf("invalid \"timeout\" %q, %w", x, err)
+		}
+		timeout = d
+	}
+	// the clients share the connections and the DNS cache, unless they have their own limit of the idle connections
+	var clientOpts []httpclient.Option
+	if x := args["maxIdleConns"]; x != "" {
+		n, err := strconv.Atoi(x)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid \"maxIdleConns\" %q, it should be a positive integer", x)
+		}
+		clientOpts = append(clientOpts, httpclient.WithMaxIdleConns(n))
+	}
+	f.client = httpclient.NewClient(timeout, clientOpts...)
+	return func(ctx context.Context, key, msg []byte) (funcsdk.Messages, error) {
+		resultMsg, err := f.apply(ctx, msg)
+		return funcsdk.MessagesBuilder().Append(resultMsg), err
+	}, nil
+}
+
+func (f infer) apply(ctx context.Context, msg []byte) (funcsdk.Message, error) {
+	payload := make(map[string]interface{})
+	if err := json.Unmarshal(msg, &payload); err != nil {
+		return funcsdk.MessageToDrop(), fmt.Errorf("failed to unmarshal the payload as a JSON object, %w", err)
+	}
+	req := inferRequest{}
+	for _, in := range f.inputs {
+		v, err := in.program.Eval(expr.Env{Payload: msg})
+		if err != nil {
+			return funcsdk.MessageToDrop(), err
+		}
+		shape, data, err := toTensor(v)
+		if err != nil {
+			return funcsdk.MessageToDrop(), fmt.Errorf("failed to build input tensor %q, %w", in.name, err)
+		}
+		req.Inputs = append(req.Inputs, inferTensor{Name: in.name, Shape: shape, Datatype: f.datatype, Data: data})
+	}
+	for _, o := range f.outputs {
+		req.Outputs = append(req.Outputs, inferRequestedOutput{Name: o})
+	}
+	resp, err := f.call(ctx, req)
+	if err != nil {
+		return funcsdk.MessageToDrop(), err
+	}
+	results := make(map[string]interface{}, len(resp.Outputs))
+	for _, o := range resp.Outputs {
+		results[o.Name] = o.Data
+	}
+	payload[f.resultKey] = results
+	result, err := json.Marshal(payload)
+	if err != nil {
+		return funcsdk.MessageToDrop(), fmt.Errorf("failed to marshal the enriched payload, %w", err)
+	}
+	return funcsdk.MessageToAll(result), nil
+}
+
+func (f infer) call(ctx context.Context, body inferRequest) (*inferResponse, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal inference request, %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call inference server, %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inference response, %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("inference server returned statusCode=%d, %s", resp.StatusCode, data)
+	}
+	result := &inferResponse{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal inference response, %w", err)
+	}
+	return result, nil
+}
+
+// toTensor flattens the evaluated expression result in row-major order, and returns the shape along with the data.
+func toTensor(v interface{}) ([]int, []interface{}, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		if v == nil {
+			return nil, nil, fmt.Errorf("expression result is nil")
+		}
+		return []int{1}, []interface{}{v}, nil
+	}
+	if len(list) == 0 {
+		return []int{0}, []interface{}{}, nil
+	}
+	var innerShape []int
+	data := []interface{}{}
+	for i, e := range list {
+		s, d, err := toTensor(e)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, nested := e.([]interface{}); !nested {
+			s = nil
+		}
+		if i == 0 {
+			innerShape = s
+		} else if !equalShape(innerShape, s) {
+			return nil, nil, fmt.Errorf("ragged nested lists are not supported")
+		}
+		data = append(data, d...)
+	}
+	return append([]int{len(list)}, innerShape...), data, nil
+}
+
+func equalShape(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package infer
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _key = []byte("")
+var jsonMsg = `{"id": 1, "features": {"a": 0.5, "b": 1.5}}`
+
+func TestNew(t *testing.T) {
+	t.Run("missing url", func(t *testing.T) {
+		_, err := New(map[string]string{"model": "m", "input.x": "1"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing \"url\"")
+	})
+
+	t.Run("missing model", func(t *testing.T) {
+		_, err := New(map[string]string{"url": "http://localhost", "input.x": "1"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing \"model\"")
+	})
+
+	t.Run("missing inputs", func(t *testing.T) {
+		_, err := New(map[string]string{"url": "http://localhost", "model": "m"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing input tensor mappings")
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		_, err := New(map[string]string{"url": "http://localhost", "model": "m", "input.x": "1", "timeout": "abc"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid \"timeout\"")
+	})
+
+	t.Run("invalid maxIdleConns", func(t *testing.T) {
+		_, err := New(map[string]string{"url": "http://localhost", "model": "m", "input.x": "1", "maxIdleConns": "0"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid \"maxIdleConns\"")
+	})
+
+	t.Run("invalid input expression", func(t *testing.T) {
+		_, err := New(map[string]string{"url": "http://localhost", "model": "m", "input.x": "ab\nc"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid expression of \"input.x\"")
+	})
+}
+
+func TestInfer(t *testing.T) {
+	var received inferRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/models/fraud/versions/2/infer", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &received)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model_name": "fraud", "outputs": [{"name": "score", "datatype": "FP32", "shape": [1], "data": [0.9]}]}`))
+	}))
+	defer ts.Close()
+
+	handle, err := New(map[string]string{
+		"url":          ts.URL,
+		"model":        "fraud",
+		"version":      "2",
+		"input.INPUT0": "[[json(payload).features.a, json(payload).features.b]]",
+		"input.INPUT1": "int(json(payload).id)",
+		"outputs":      "score",
+		"resultKey":    "fraud",
+		"datatype":     "fp64",
+	})
+	assert.NoError(t, err)
+
+	result, err := handle(context.Background(), _key, []byte(jsonMsg))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(received.Inputs))
+	assert.Equal(t, "INPUT0", received.Inputs[0].Name)
+	assert.Equal(t, []int{1, 2}, received.Inputs[0].Shape)
+	assert.Equal(t, "FP64", received.Inputs[0].Datatype)
+	assert.Equal(t, []interface{}{0.5, 1.5}, received.Inputs[0].Data)
+	assert.Equal(t, "INPUT1", received.Inputs[1].Name)
+	assert.Equal(t, []int{1}, received.Inputs[1].Shape)
+	assert.Equal(t, []inferRequestedOutput{{Name: "score"}}, received.Outputs)
+
+	enriched := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(result.Items()[0].Value, &enriched))
+	assert.Equal(t, float64(1), enriched["id"])
+	assert.Equal(t, map[string]interface{}{"score": []interface{}{0.9}}, enriched["fraud"])
+}
+
+func TestInfer_errors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "unexpected shape"}`))
+	}))
+	defer ts.Close()
+
+	handle, err := New(map[string]string{"url": ts.URL, "model": "m", "input.x": "json(payload).id"})
+	assert.NoError(t, err)
+
+	t.Run("server error", func(t *testing.T) {
+		result, err := handle(context.Background(), _key, []byte(jsonMsg))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "statusCode=400")
+		assert.Equal(t, "", string(result.Items()[0].Value))
+	})
+
+	t.Run("not a json object", func(t *testing.T) {
+		_, err := handle(context.Background(), _key, []byte("hello"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to unmarshal the payload")
+	})
+}
+
+func Test_toTensor(t *testing.T) {
+	shape, data, err := toTensor(1.0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, shape)
+	assert.Equal(t, []interface{}{1.0}, data)
+
+	shape, data, err = toTensor([]interface{}{[]interface{}{1, 2, 3}, []interface{}{4, 5, 6}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, shape)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6}, data)
+
+	_, _, err = toTensor([]interface{}{[]interface{}{1, 2}, []interface{}{3}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ragged")
+
+	_, _, err = toTensor(nil)
+	assert.Error(t, err)
+}
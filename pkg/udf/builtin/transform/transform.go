@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/numaproj/numaflow/pkg/shared/expr"
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+// transform replaces the payload of each message with the result of the expression.
+type transform struct {
+	program expr.Program
+}
+
+func New(args map[string]string) (funcsdk.Handle, error) {
+	expression, existing := args["expression"]
+	if !existing || expression == "" {
+		return nil, fmt.Errorf("missing \"expression\"")
+	}
+	program, err := expr.Compile(expr.Language(args["language"]), expression)
+	if err != nil {
+		return nil, err
+	}
+	f := transform{
+		program: program,
+	}
+	return func(ctx context.Context, key, msg []byte) (funcsdk.Messages, error) {
+		resultMsg, err := f.apply(key, msg)
+		return funcsdk.MessagesBuilder().Append(resultMsg), err
+	}, nil
+}
+
+// apply evaluates the expression against the message. A string result is used as the payload as is, nil drops the
+// message, and any other result is marshaled into JSON, e.g. a map built with `{"name": json(payload).name}`.
+func (f transform) apply(key, msg []byte) (funcsdk.Message, error) {
+	result, err := f.program.Eval(expr.Env{Key: key, Payload: msg})
+	if err != nil {
+		return funcsdk.MessageToDrop(), err
+	}
+	switch v := result.(type) {
+	case nil:
+		return funcsdk.MessageToDrop(), nil
+	case string:
+		return funcsdk.MessageToAll([]byte(v)), nil
+	case []byte:
+		return funcsdk.MessageToAll(v), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return funcsdk.MessageToDrop(), fmt.Errorf("failed to marshal the expression result, %w", err)
+		}
+		return funcsdk.MessageToAll(b), nil
+	}
+}
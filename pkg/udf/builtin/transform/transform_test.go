@@ -0,0 +1,77 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var jsonMsg = `{"id": 21, "name": "bala", "item": [{"id": 1, "price": 2.5},{"id": 2, "price": 3}]}`
+
+func TestTransform(t *testing.T) {
+	t.Run("missing expression", func(t *testing.T) {
+		_, err := New(map[string]string{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing")
+	})
+
+	t.Run("map result", func(t *testing.T) {
+		handle, err := New(map[string]string{"expression": `{"user": json(payload).name, "key": key, "count": len(json(payload).item)}`})
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), []byte("k1"), []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"user": "bala", "key": "k1", "count": 2}`, string(result.Items()[0].Value))
+	})
+
+	t.Run("string result", func(t *testing.T) {
+		handle, err := New(map[string]string{"expression": `sprig.upper(json(payload).name)`})
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), nil, []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.Equal(t, "BALA", string(result.Items()[0].Value))
+	})
+
+	t.Run("number result", func(t *testing.T) {
+		handle, err := New(map[string]string{"expression": `json(payload).item[0].price * 2`})
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), nil, []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.Equal(t, "5", string(result.Items()[0].Value))
+	})
+
+	t.Run("nil result", func(t *testing.T) {
+		handle, err := New(map[string]string{"expression": `json(payload).id > 100 ? json(payload) : nil`})
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), nil, []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.Equal(t, "", string(result.Items()[0].Value))
+	})
+
+	t.Run("cel", func(t *testing.T) {
+		handle, err := New(map[string]string{"language": "cel", "expression": `{"user": json(payload).name, "key": key, "count": size(json(payload).item)}`})
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), []byte("k1"), []byte(jsonMsg))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"user": "bala", "key": "k1", "count": 2}`, string(result.Items()[0].Value))
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := New(map[string]string{"expression": "ab\nc"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		handle, err := New(map[string]string{"expression": "json(payload).name"})
+		assert.NoError(t, err)
+
+		result, err := handle(context.Background(), nil, []byte("abc"))
+		assert.Error(t, err)
+		assert.Equal(t, "", string(result.Items()[0].Value))
+	})
+}
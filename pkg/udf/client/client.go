@@ -0,0 +1,98 @@
+/*
+Package client is the Go client of the gRPC user defined function protocol defined in pkg/apis/proto/function. It's used
+by the numa container to call the UDF container in the same pod over a unix domain socket, so that the UDFs can be
+written in any language with gRPC support.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	functionpb "github.com/numaproj/numaflow/pkg/apis/proto/function"
+)
+
+type Client struct {
+	conn    *grpc.ClientConn
+	grpcClt functionpb.UserDefinedFunctionClient
+}
+
+// New returns a client of the UDF serving the gRPC protocol on the unix domain socket, the connection is established
+// lazily, use WaitUntilReady to wait for the UDF to be up.
+func New(opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(o.maxMessageSize), grpc.MaxCallSendMsgSize(o.maxMessageSize)),
+	}, o.dialOptions...)
+	conn, err := grpc.Dial("unix://"+o.sockAddr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial the UDF at %q, %w", o.sockAddr, err)
+	}
+	return &Client{conn: conn, grpcClt: functionpb.NewUserDefinedFunctionClient(conn)}, nil
+}
+
+// Close closes the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// IsReady returns the readiness of the UDF.
+func (c *Client) IsReady(ctx context.Context) (*functionpb.ReadyResponse, error) {
+	return c.grpcClt.IsReady(ctx, &emptypb.Empty{})
+}
+
+// WaitUntilReady waits till the UDF is ready, and returns its readiness response which declares the capabilities of
+// the UDF.
+func (c *Client) WaitUntilReady(ctx context.Context) (*functionpb.ReadyResponse, error) {
+	for {
+		if resp, err := c.IsReady(ctx); err == nil && resp.GetReady() {
+			return resp, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to wait for ready: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// MapFn applies the UDF to the datum, and returns the output data.
+func (c *Client) MapFn(ctx context.Context, datum *functionpb.Datum) ([]*functionpb.Datum, error) {
+	list, err := c.grpcClt.MapFn(ctx, datum)
+	if err != nil {
+		return nil, err
+	}
+	return list.GetElements(), nil
+}
+
+// MapStreamFn applies the UDF to the datum, and hands over the output data one by one as they are streamed back. If
+// handling an output fails, the call is aborted with the error.
+func (c *Client) MapStreamFn(ctx context.Context, datum *functionpb.Datum, handle func(*functionpb.Datum) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.grpcClt.MapStreamFn(ctx, datum)
+	if err != nil {
+		return err
+	}
+	for {
+		d, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := handle(d); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	functionpb "github.com/numaproj/numaflow/pkg/apis/proto/function"
+)
+
+type fakeFunctionServer struct {
+	functionpb.UnimplementedUserDefinedFunctionServer
+	ready bool
+}
+
+func (s *fakeFunctionServer) MapFn(ctx context.Context, d *functionpb.Datum) (*functionpb.DatumList, error) {
+	if string(d.GetValue()) == "fail" {
+		return nil, status.Error(codes.Internal, "failed")
+	}
+	return &functionpb.DatumList{Elements: []*functionpb.Datum{
+		{Key: d.GetKey(), Value: d.GetValue()},
+		{Key: d.GetKey(), Value: append(d.GetValue(), '!')},
+	}}, nil
+}
+
+func (s *fakeFunctionServer) MapStreamFn(d *functionpb.Datum, stream functionpb.UserDefinedFunction_MapStreamFnServer) error {
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(&functionpb.Datum{Key: d.GetKey(), Value: d.GetValue()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeFunctionServer) IsReady(ctx context.Context, _ *emptypb.Empty) (*functionpb.ReadyResponse, error) {
+	return &functionpb.ReadyResponse{Ready: s.ready, Streaming: true}, nil
+}
+
+func newTestClient(t *testing.T, s *fakeFunctionServer) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	functionpb.RegisterUserDefinedFunctionServer(server, s)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	c, err := New(WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.Dial()
+	})))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestClient_WaitUntilReady(t *testing.T) {
+	t.Run("test ready", func(t *testing.T) {
+		c := newTestClient(t, &fakeFunctionServer{ready: true})
+		resp, err := c.WaitUntilReady(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, resp.GetStreaming())
+	})
+
+	t.Run("test not ready", func(t *testing.T) {
+		c := newTestClient(t, &fakeFunctionServer{})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := c.WaitUntilReady(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestClient_MapFn(t *testing.T) {
+	c := newTestClient(t, &fakeFunctionServer{ready: true})
+	outputs, err := c.MapFn(context.Background(), &functionpb.Datum{Key: []byte("k"), Value: []byte("v")})
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 2)
+	assert.Equal(t, []byte("v!"), outputs[1].GetValue())
+
+	_, err = c.MapFn(context.Background(), &functionpb.Datum{Value: []byte("fail")})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestClient_MapStreamFn(t *testing.T) {
+	c := newTestClient(t, &fakeFunctionServer{ready: true})
+	count := 0
+	err := c.MapStreamFn(context.Background(), &functionpb.Datum{Key: []byte("k"), Value: []byte("v")}, func(d *functionpb.Datum) error {
+		count++
+		assert.Equal(t, []byte("v"), d.GetValue())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	errStop := errors.New("stop")
+	err = c.MapStreamFn(context.Background(), &functionpb.Datum{}, func(d *functionpb.Datum) error {
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultSockAddr is the unix domain socket the UDF container serves the gRPC user defined function protocol on
+	DefaultSockAddr = "/var/run/numaflow/function.sock"
+	// DefaultMaxMessageSize is the max size of the messages sent to or received from the UDF, 64MiB
+	DefaultMaxMessageSize = 64 * 1024 * 1024
+)
+
+type options struct {
+	sockAddr       string
+	maxMessageSize int
+	// dialOptions are appended to the default dial options
+	dialOptions []grpc.DialOption
+}
+
+func defaultOptions() *options {
+	return &options{
+		sockAddr:       DefaultSockAddr,
+		maxMessageSize: DefaultMaxMessageSize,
+	}
+}
+
+type Option func(*options)
+
+// WithSockAddr sets the path of the unix domain socket served by the UDF container.
+func WithSockAddr(addr string) Option {
+	return func(o *options) {
+		o.sockAddr = addr
+	}
+}
+
+// WithMaxMessageSize sets the max size of the messages sent to or received from the UDF.
+func WithMaxMessageSize(size int) Option {
+	return func(o *options) {
+		o.maxMessageSize = size
+	}
+}
+
+// WithDialOptions appends the gRPC dial options, they take precedence over the default ones.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
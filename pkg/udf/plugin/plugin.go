@@ -0,0 +1,73 @@
+/*
+Package plugin is the registry of the user defined functions compiled into the numa binary. The registered functions are
+selected by name with "udf.plugin.name" in the vertex spec, and invoked in-process without a UDF container, which
+eliminates the sidecar and the serialization for the ultra-low-latency vertices.
+
+The functions are registered before the commands get executed, for example:
+
+	func main() {
+		plugin.Register("my-function", func(ctx context.Context, key, msg []byte) (funcsdk.Messages, error) {
+			return funcsdk.MessagesBuilder().Append(funcsdk.MessageToAll(msg)), nil
+		})
+		commands.Execute()
+	}
+
+Since the functions run in the same process as the platform code, this is only meant for trusted environments.
+*/
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+var (
+	lock     sync.RWMutex
+	registry = make(map[string]funcsdk.Handle)
+)
+
+// Register makes a function available by the name. It panics if the name is empty, the function is nil, or the name
+// has been registered.
+func Register(name string, handle funcsdk.Handle) {
+	lock.Lock()
+	defer lock.Unlock()
+	if name == "" {
+		panic("plugin: function name is empty")
+	}
+	if handle == nil {
+		panic(fmt.Sprintf("plugin: function %q is nil", name))
+	}
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("plugin: function %q is registered twice", name))
+	}
+	registry[name] = handle
+}
+
+// Lookup returns the function registered by the name.
+func Lookup(name string) (funcsdk.Handle, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	if h, ok := registry[name]; ok {
+		return h, nil
+	}
+	return nil, fmt.Errorf("plugin function %q is not registered, registered functions: %v", name, registeredNames())
+}
+
+// Registered returns the sorted names of the registered functions.
+func Registered() []string {
+	lock.RLock()
+	defer lock.RUnlock()
+	return registeredNames()
+}
+
+func registeredNames() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,32 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	funcsdk "github.com/numaproj/numaflow/sdks/golang/function"
+)
+
+func testHandle(_ context.Context, _, msg []byte) (funcsdk.Messages, error) {
+	return funcsdk.MessagesBuilder().Append(funcsdk.MessageToAll(msg)), nil
+}
+
+func TestRegister(t *testing.T) {
+	Register("test-b", testHandle)
+	Register("test-a", testHandle)
+	assert.Equal(t, []string{"test-a", "test-b"}, Registered())
+
+	h, err := Lookup("test-a")
+	assert.NoError(t, err)
+	assert.NotNil(t, h)
+
+	_, err = Lookup("test-c")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "test-a test-b")
+
+	assert.Panics(t, func() { Register("test-a", testHandle) })
+	assert.Panics(t, func() { Register("", testHandle) })
+	assert.Panics(t, func() { Register("test-d", nil) })
+}
@@ -0,0 +1,83 @@
+package reduce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Aggregator accumulates the payloads of the messages of a key in a window, and produces the aggregate when the window
+// is closed.
+type Aggregator interface {
+	// Add accumulates a payload, an error means the payload is invalid for the function.
+	Add(payload []byte) error
+	// Result returns the aggregate of the payloads added.
+	Result() []byte
+}
+
+// builtinAggregator returns the function creating the aggregators of a builtin reduce function.
+func builtinAggregator(name string) (func() Aggregator, error) {
+	switch name {
+	case "count":
+		return func() Aggregator { return &countAggregator{} }, nil
+	case "sum":
+		return func() Aggregator { return &numberAggregator{merge: func(a, b float64) float64 { return a + b }} }, nil
+	case "min":
+		return func() Aggregator {
+			return &numberAggregator{merge: func(a, b float64) float64 {
+				if b < a {
+					return b
+				}
+				return a
+			}}
+		}, nil
+	case "max":
+		return func() Aggregator {
+			return &numberAggregator{merge: func(a, b float64) float64 {
+				if b > a {
+					return b
+				}
+				return a
+			}}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized reduce function %q", name)
+	}
+}
+
+type countAggregator struct {
+	n int64
+}
+
+func (c *countAggregator) Add(_ []byte) error {
+	c.n++
+	return nil
+}
+
+func (c *countAggregator) Result() []byte {
+	return []byte(strconv.FormatInt(c.n, 10))
+}
+
+// numberAggregator takes the payloads as numbers and merges them one by one.
+type numberAggregator struct {
+	merge func(a, b float64) float64
+	value float64
+	set   bool
+}
+
+func (n *numberAggregator) Add(payload []byte) error {
+	v, err := strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+	if err != nil {
+		return fmt.Errorf("payload is not a number, %w", err)
+	}
+	if !n.set {
+		n.value, n.set = v, true
+		return nil
+	}
+	n.value = n.merge(n.value, v)
+	return nil
+}
+
+func (n *numberAggregator) Result() []byte {
+	return []byte(strconv.FormatFloat(n.value, 'f', -1, 64))
+}
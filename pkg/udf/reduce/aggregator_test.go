@@ -0,0 +1,27 @@
+package reduce
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinAggregator(t *testing.T) {
+	tests := map[string]string{"count": "3", "sum": "4.5", "min": "-1", "max": "3.5"}
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			newAggregator, err := builtinAggregator(name)
+			assert.NoError(t, err)
+			a := newAggregator()
+			for _, p := range []string{"2", " -1\n", "3.5"} {
+				assert.NoError(t, a.Add([]byte(p)))
+			}
+			assert.Equal(t, expected, string(a.Result()))
+		})
+	}
+	newAggregator, err := builtinAggregator("sum")
+	assert.NoError(t, err)
+	assert.Error(t, newAggregator().Add([]byte("abc")))
+	_, err = builtinAggregator("avg")
+	assert.Error(t, err)
+}
@@ -0,0 +1,278 @@
+/*
+Package reduce does the Read (fromBuffer) -> Group by key and window -> Aggregate -> Forward (toBuffers) -> Ack
+(fromBuffer) loop of the reduce vertices. The messages are acknowledged only after the aggregates of their windows are
+written, so that the open windows survive the restarts of the vertex.
+*/
+package reduce
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/fanin"
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
+)
+
+// maxRetryInterval is the max interval of retrying the failed writes.
+const maxRetryInterval = 5 * time.Second
+
+// DataForward reads the messages, aggregates them by key in the windows of their event time, and forwards the
+// aggregates when the watermark passes the end of the windows.
+type DataForward struct {
+	fromBuffer   isb.BufferReader
+	toBuffers    map[string]isb.BufferWriter
+	fsd          forward.ToWhichStepDecider
+	windower     fixedWindower
+	pbq          *pbq
+	watermark    *watermark
+	opts         options
+	vertexName   string
+	pipelineName string
+}
+
+// NewDataForward creates the forwarder of a reduce vertex.
+func NewDataForward(vertex *dfv1.Vertex, fromBuffer isb.BufferReader, toBuffers map[string]isb.BufferWriter, fsd forward.ToWhichStepDecider, opts ...Option) (*DataForward, error) {
+	r := vertex.Spec.Reduce
+	if r == nil || r.Window.Fixed == nil || r.Window.Fixed.GetLength() <= 0 || r.Builtin == nil {
+		return nil, fmt.Errorf("invalid reduce vertex %q, a fixed window and a builtin function are required", vertex.Spec.Name)
+	}
+	newAggregator, err := builtinAggregator(r.Builtin.Name)
+	if err != nil {
+		return nil, err
+	}
+	o := &options{
+		readBatchSize: dfv1.DefaultPipelineReadBatchSize,
+		retryInterval: time.Millisecond,
+		idleInterval:  100 * time.Millisecond,
+		logger:        logging.NewLogger(),
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	length := r.Window.Fixed.GetLength()
+	return &DataForward{
+		fromBuffer:   fromBuffer,
+		toBuffers:    toBuffers,
+		fsd:          fsd,
+		windower:     fixedWindower{length: length},
+		pbq:          newPBQ(newAggregator),
+		watermark:    newWatermark(r.GetAllowedLateness(), length),
+		opts:         *o,
+		vertexName:   vertex.Spec.Name,
+		pipelineName: vertex.Spec.PipelineName,
+	}, nil
+}
+
+// Run forwards the aggregates until the context is done, the messages of the windows still open are left
+// unacknowledged to be read again after the restart.
+func (df *DataForward) Run(ctx context.Context) {
+	log := df.opts.logger
+	defer df.close()
+	log.Info("Starting reduce forwarder...")
+	for {
+		select {
+		case <-ctx.Done():
+			log.Infow("Shutting down, the open windows are rebuilt after the restart", zap.Int("openPartitions", df.pbq.len()))
+			return
+		default:
+		}
+		n, err := df.readAChunk(ctx)
+		if err != nil {
+			log.Errorw("Failed to read from the buffer", zap.Error(err))
+		}
+		wm := df.watermark.get(time.Now())
+		if err := df.closeWindows(ctx, wm); err != nil {
+			log.Errorw("Failed to forward the aggregates of the closed windows", zap.Error(err))
+		}
+		labels := map[string]string{"vertex": df.vertexName, "pipeline": df.pipelineName}
+		reduceOpenPartitions.With(labels).Set(float64(df.pbq.len()))
+		if !wm.IsZero() {
+			reduceWatermark.With(labels).Set(float64(wm.UnixMilli()))
+		}
+		if n == 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(df.opts.idleInterval):
+			}
+		}
+	}
+}
+
+// readAChunk reads a chunk of messages and adds them to the open windows. The messages of the closed windows, the ones
+// older than the max message ages and the ones rejected by the reduce function are dropped.
+func (df *DataForward) readAChunk(ctx context.Context) (int, error) {
+	messages, err := df.fromBuffer.Read(ctx, df.opts.readBatchSize)
+	reduceReadCount.With(map[string]string{"vertex": df.vertexName, "pipeline": df.pipelineName}).Add(float64(len(messages)))
+	now := time.Now()
+	var dropped []isb.Offset
+	for _, m := range messages {
+		if m.ContentEncoding != "" {
+			payload, err := sharedutil.Decompress(m.ContentEncoding, m.Payload)
+			if err != nil {
+				df.opts.logger.Errorw("Failed to decompress the payload, dropping the message", zap.String("id", m.ID), zap.Error(err))
+				df.drop(&dropped, m, "invalid")
+				continue
+			}
+			m.Payload, m.ContentEncoding = payload, ""
+		}
+		eventTime := m.EventTime
+		if eventTime.IsZero() { // not set by the source, falls back to the processing time
+			eventTime = now
+		} else if age, ok := df.opts.maxMessageAges[fanin.BufferName(m.ReadOffset, df.fromBuffer.GetName())]; ok && now.Sub(eventTime) > age {
+			df.drop(&dropped, m, "expired")
+			continue
+		}
+		w := df.windower.assign(eventTime)
+		if !w.End.After(df.watermark.get(now)) {
+			df.drop(&dropped, m, "late")
+			continue
+		}
+		if err := df.pbq.add(w, m); err != nil {
+			df.opts.logger.Warnw("The reduce function rejected the message, dropping it", zap.String("id", m.ID), zap.Error(err))
+			df.drop(&dropped, m, "invalid")
+			continue
+		}
+		df.watermark.observe(eventTime, now)
+	}
+	df.ack(ctx, dropped)
+	return len(messages), err
+}
+
+func (df *DataForward) drop(dropped *[]isb.Offset, m *isb.ReadMessage, reason string) {
+	*dropped = append(*dropped, m.ReadOffset)
+	reduceDroppedCount.With(map[string]string{"vertex": df.vertexName, "pipeline": df.pipelineName, "reason": reason}).Inc()
+}
+
+// closeWindows writes the aggregates of the windows closed by the watermark, and then acknowledges their messages.
+func (df *DataForward) closeWindows(ctx context.Context, wm time.Time) error {
+	closed := df.pbq.closeUntil(wm)
+	if len(closed) == 0 {
+		return nil
+	}
+	messageToStep := make(map[string][]isb.Message)
+	for step := range df.toBuffers {
+		messageToStep[step] = nil
+	}
+	var offsets []isb.Offset
+	for _, p := range closed {
+		if err := df.whereToStep(df.toMessage(p), messageToStep); err != nil {
+			return err
+		}
+		offsets = append(offsets, p.offsets...)
+	}
+	for step, messages := range messageToStep {
+		if len(messages) == 0 {
+			continue
+		}
+		if err := df.writeToBuffer(ctx, df.toBuffers[step], messages); err != nil {
+			return err
+		}
+	}
+	reduceWindowCount.With(map[string]string{"vertex": df.vertexName, "pipeline": df.pipelineName}).Add(float64(len(closed)))
+	df.ack(ctx, offsets)
+	return nil
+}
+
+// toMessage builds the message of the aggregate of a partition. The ID is derived from the window and the key, so that
+// the aggregate rebuilt after a restart is deduplicated if the exactly-once writes are on.
+func (df *DataForward) toMessage(p *partition) isb.Message {
+	return isb.Message{
+		Header: isb.Header{
+			PaneInfo: isb.PaneInfo{
+				// The event time falls in the window
+				EventTime: p.window.End.Add(-time.Millisecond),
+				StartTime: p.window.Start,
+				EndTime:   p.window.End,
+				IsWindow:  true,
+			},
+			ID:  fmt.Sprintf("%s-%d-%s", df.vertexName, p.window.End.UnixMilli(), p.key),
+			Key: p.key,
+		},
+		Body: isb.Body{Payload: p.aggregator.Result()},
+	}
+}
+
+// whereToStep decides the buffers the aggregate goes to, the same as the map vertices.
+func (df *DataForward) whereToStep(m isb.Message, messageToStep map[string][]isb.Message) error {
+	to, err := df.fsd.WhereTo(m.Key)
+	if err != nil {
+		return fmt.Errorf("failed to decide where to forward the aggregate, %w", err)
+	}
+	switch {
+	case sharedutil.StringSliceContains(to, dfv1.MessageKeyAll):
+		for step := range df.toBuffers {
+			messageToStep[step] = append(messageToStep[step], m)
+		}
+	case sharedutil.StringSliceContains(to, dfv1.MessageKeyDrop):
+	default:
+		for _, t := range to {
+			if _, ok := messageToStep[t]; !ok {
+				df.opts.logger.Errorw("No such destination, dropping the aggregate", zap.String("to", t), zap.String("id", m.ID))
+				continue
+			}
+			messageToStep[t] = append(messageToStep[t], m)
+		}
+	}
+	return nil
+}
+
+// writeToBuffer writes the messages to a buffer, the failed ones are retried until the context is done.
+func (df *DataForward) writeToBuffer(ctx context.Context, toBuffer isb.BufferWriter, messages []isb.Message) error {
+	df.opts.traceRecorders.Record(toBuffer.GetName(), messages)
+	interval := df.opts.retryInterval
+	for {
+		_, errs := toBuffer.Write(ctx, messages)
+		var failed []isb.Message
+		for i, err := range errs {
+			if err != nil {
+				failed = append(failed, messages[i])
+			}
+		}
+		if len(failed) == 0 {
+			return nil
+		}
+		df.opts.logger.Errorw("Retrying the failed writes", zap.String("buffer", toBuffer.GetName()), zap.Int("failed", len(failed)))
+		messages = failed
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped while retrying the writes to buffer %q, %w", toBuffer.GetName(), ctx.Err())
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+}
+
+// ack acknowledges the offsets, the failures are logged, the messages are then redelivered and dropped as late.
+func (df *DataForward) ack(ctx context.Context, offsets []isb.Offset) {
+	if len(offsets) == 0 {
+		return
+	}
+	for _, err := range df.fromBuffer.Ack(ctx, offsets) {
+		if err != nil {
+			df.opts.logger.Errorw("Failed to ack", zap.Error(err))
+		}
+	}
+}
+
+func (df *DataForward) close() {
+	log := df.opts.logger
+	if err := df.fromBuffer.Close(); err != nil {
+		log.Errorw("Failed to close buffer reader", zap.Error(err))
+	}
+	for _, w := range df.toBuffers {
+		if err := w.Close(); err != nil {
+			log.Errorw("Failed to close buffer writer", zap.Error(err), zap.String("bufferTo", w.GetName()))
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package reduce
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/simplebuffer"
+)
+
+var testStartTime = time.Unix(1636470000, 0).UTC()
+
+type toOneBuffer struct{}
+
+func (toOneBuffer) WhereTo(_ []byte) ([]string, error) {
+	return []string{"to1"}, nil
+}
+
+func testReduceVertex(function string) *dfv1.Vertex {
+	return &dfv1.Vertex{Spec: dfv1.VertexSpec{
+		PipelineName: "test-pl",
+		AbstractVertex: dfv1.AbstractVertex{
+			Name: "test-reduce",
+			Reduce: &dfv1.Reduce{
+				Window:  dfv1.Window{Fixed: &dfv1.FixedWindow{Length: &metav1.Duration{Duration: 10 * time.Second}}},
+				Builtin: &dfv1.ReduceFunction{Name: function},
+			},
+		},
+	}}
+}
+
+func testMessage(i int, key string, eventTime time.Time, payload string) isb.Message {
+	return isb.Message{
+		Header: isb.Header{
+			PaneInfo: isb.PaneInfo{EventTime: eventTime},
+			ID:       fmt.Sprintf("%d", i),
+			Key:      []byte(key),
+		},
+		Body: isb.Body{Payload: []byte(payload)},
+	}
+}
+
+func TestNewDataForward(t *testing.T) {
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 10)
+	toSteps := map[string]isb.BufferWriter{"to1": simplebuffer.NewInMemoryBuffer("to1", 10)}
+	_, err := NewDataForward(testReduceVertex("avg"), fromStep, toSteps, toOneBuffer{})
+	assert.Error(t, err)
+	v := testReduceVertex("sum")
+	v.Spec.Reduce.Window.Fixed = nil
+	_, err = NewDataForward(v, fromStep, toSteps, toOneBuffer{})
+	assert.Error(t, err)
+	_, err = NewDataForward(testReduceVertex("sum"), fromStep, toSteps, toOneBuffer{}, WithReadBatchSize(0))
+	assert.Error(t, err)
+}
+
+func TestDataForward_closeWindows(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 10)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	df, err := NewDataForward(testReduceVertex("sum"), fromStep, map[string]isb.BufferWriter{"to1": to1}, toOneBuffer{}, WithReadBatchSize(4))
+	assert.NoError(t, err)
+
+	_, errs := fromStep.Write(ctx, []isb.Message{
+		testMessage(0, "a", testStartTime.Add(1*time.Second), "1"),
+		testMessage(1, "b", testStartTime.Add(2*time.Second), "5"),
+		testMessage(2, "a", testStartTime.Add(3*time.Second), "2"),
+		testMessage(3, "a", testStartTime.Add(12*time.Second), "7"),
+	})
+	assert.Equal(t, make([]error, 4), errs)
+	n, err := df.readAChunk(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, 3, df.pbq.len())
+
+	// the watermark is at the largest event time, which closes the first window
+	assert.NoError(t, df.closeWindows(ctx, df.watermark.get(time.Now())))
+	assert.Equal(t, 1, df.pbq.len())
+	out, err := to1.Read(ctx, 2)
+	assert.NoError(t, err)
+	assert.Len(t, out, 2)
+	assert.Equal(t, "a", string(out[0].Key))
+	assert.Equal(t, "3", string(out[0].Payload))
+	assert.Equal(t, "b", string(out[1].Key))
+	assert.Equal(t, "5", string(out[1].Payload))
+	assert.True(t, out[0].IsWindow)
+	assert.Equal(t, testStartTime, out[0].StartTime.UTC())
+	assert.Equal(t, testStartTime.Add(10*time.Second), out[0].EndTime.UTC())
+	assert.Equal(t, "test-reduce-1636470010000-a", out[0].ID)
+
+	// the message of the closed window is dropped
+	_, errs = fromStep.Write(ctx, []isb.Message{testMessage(4, "a", testStartTime.Add(5*time.Second), "9")})
+	assert.Equal(t, make([]error, 1), errs)
+	df.opts.readBatchSize = 1
+	_, err = df.readAChunk(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, df.pbq.len())
+	assert.Equal(t, float64(1), testutil.ToFloat64(reduceDroppedCount.WithLabelValues("test-reduce", "test-pl", "late")))
+}
+
+func TestDataForward_maxMessageAges(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	fromStep := simplebuffer.NewInMemoryBuffer("from", 10)
+	to1 := simplebuffer.NewInMemoryBuffer("to1", 10)
+	df, err := NewDataForward(testReduceVertex("sum"), fromStep, map[string]isb.BufferWriter{"to1": to1}, toOneBuffer{}, WithReadBatchSize(3), WithMaxMessageAges(map[string]time.Duration{"from": time.Hour}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	_, errs := fromStep.Write(ctx, []isb.Message{
+		testMessage(0, "a", now.Add(-2*time.Hour), "1"),
+		testMessage(1, "a", now, "2"),
+		testMessage(2, "b", time.Time{}, "3"),
+	})
+	assert.Equal(t, make([]error, 3), errs)
+	n, err := df.readAChunk(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 2, df.pbq.len())
+	assert.Equal(t, float64(1), testutil.ToFloat64(reduceDroppedCount.WithLabelValues("test-reduce", "test-pl", "expired")))
+}
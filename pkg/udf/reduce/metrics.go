@@ -0,0 +1,36 @@
+package reduce
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var reduceDroppedCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce",
+	Name:      "dropped_total",
+	Help:      "Total number of messages dropped by the reduce vertex, either arriving after their windows are closed, older than the max message age, or rejected by the reduce function",
+}, []string{"vertex", "pipeline", "reason"})
+
+var reduceWindowCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce",
+	Name:      "window_total",
+	Help:      "Total number of aggregates of the closed windows",
+}, []string{"vertex", "pipeline"})
+
+var reduceOpenPartitions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: "reduce",
+	Name:      "open_partitions",
+	Help:      "Number of the keys in the open windows",
+}, []string{"vertex", "pipeline"})
+
+var reduceReadCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Subsystem: "reduce",
+	Name:      "read_total",
+	Help:      "Total number of messages read by the reduce vertex",
+}, []string{"vertex", "pipeline"})
+
+var reduceWatermark = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: "reduce",
+	Name:      "watermark",
+	Help:      "Watermark of the reduce vertex in Unix milliseconds, 0 before any message is read",
+}, []string{"vertex", "pipeline"})
@@ -0,0 +1,79 @@
+package reduce
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
+)
+
+type options struct {
+	// readBatchSize is the max number of messages read in one call
+	readBatchSize int64
+	// retryInterval is the initial interval of retrying the failed writes
+	retryInterval time.Duration
+	// idleInterval is the interval of checking the watermark when nothing is read
+	idleInterval time.Duration
+	// traceRecorders sample the aggregates written to the traced to buffers
+	traceRecorders tracing.Recorders
+	// maxMessageAges are the time to live of the messages of the from buffers by their event time, keyed by the from
+	// buffer names
+	maxMessageAges map[string]time.Duration
+	logger         *zap.SugaredLogger
+}
+
+type Option func(*options) error
+
+// WithReadBatchSize sets the max number of messages read in one call.
+func WithReadBatchSize(n int64) Option {
+	return func(o *options) error {
+		if n <= 0 {
+			return fmt.Errorf("read batch size should be greater than 0")
+		}
+		o.readBatchSize = n
+		return nil
+	}
+}
+
+// WithRetryInterval sets the initial interval of retrying the failed writes.
+func WithRetryInterval(d time.Duration) Option {
+	return func(o *options) error {
+		o.retryInterval = d
+		return nil
+	}
+}
+
+// WithIdleInterval sets the interval of checking the watermark when nothing is read.
+func WithIdleInterval(d time.Duration) Option {
+	return func(o *options) error {
+		o.idleInterval = d
+		return nil
+	}
+}
+
+// WithTraceRecorders samples the aggregates written to the traced to buffers.
+func WithTraceRecorders(r tracing.Recorders) Option {
+	return func(o *options) error {
+		o.traceRecorders = r
+		return nil
+	}
+}
+
+// WithMaxMessageAges sets the time to live of the messages of the from buffers, keyed by the from buffer names. The
+// messages older than it by their event time are dropped.
+func WithMaxMessageAges(ages map[string]time.Duration) Option {
+	return func(o *options) error {
+		o.maxMessageAges = ages
+		return nil
+	}
+}
+
+// WithLogger sets the logger.
+func WithLogger(l *zap.SugaredLogger) Option {
+	return func(o *options) error {
+		o.logger = l
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package reduce
+
+import (
+	"sort"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// partitionID identifies the partition of a key in a window.
+type partitionID struct {
+	end int64
+	key string
+}
+
+// partition holds the aggregate of a key in a window, and the offsets of the messages aggregated.
+type partition struct {
+	window     Window
+	key        []byte
+	aggregator Aggregator
+	offsets    []isb.Offset
+}
+
+// pbq is the persisted buffer queue of the open windows. The aggregates are kept in memory, while the messages are
+// persisted by the inter-step buffer, as they are only acknowledged after the aggregates of their windows are written.
+// If the vertex restarts, the unacknowledged messages are read again, and the open windows are rebuilt from them.
+type pbq struct {
+	newAggregator func() Aggregator
+	partitions    map[partitionID]*partition
+}
+
+func newPBQ(newAggregator func() Aggregator) *pbq {
+	return &pbq{
+		newAggregator: newAggregator,
+		partitions:    make(map[partitionID]*partition),
+	}
+}
+
+// add aggregates the message into the partition of its key in the window. The message is not added if its payload is
+// rejected by the aggregator.
+func (q *pbq) add(w Window, m *isb.ReadMessage) error {
+	id := partitionID{end: w.End.UnixNano(), key: string(m.Key)}
+	p, ok := q.partitions[id]
+	if !ok {
+		p = &partition{window: w, key: m.Key, aggregator: q.newAggregator()}
+	}
+	if err := p.aggregator.Add(m.Payload); err != nil {
+		return err
+	}
+	p.offsets = append(p.offsets, m.ReadOffset)
+	q.partitions[id] = p
+	return nil
+}
+
+// closeUntil removes and returns the partitions of the windows ending no later than the watermark, ordered by the
+// window end and then the key.
+func (q *pbq) closeUntil(watermark time.Time) []*partition {
+	var result []*partition
+	for id, p := range q.partitions {
+		if !p.window.End.After(watermark) {
+			result = append(result, p)
+			delete(q.partitions, id)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].window.End.Equal(result[j].window.End) {
+			return result[i].window.End.Before(result[j].window.End)
+		}
+		return string(result[i].key) < string(result[j].key)
+	})
+	return result
+}
+
+func (q *pbq) len() int {
+	return len(q.partitions)
+}
@@ -0,0 +1,173 @@
+package reduce
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/fanin"
+	"github.com/numaproj/numaflow/pkg/isb/forward"
+	jetstreamisb "github.com/numaproj/numaflow/pkg/isb/jetstream"
+	kafkaisb "github.com/numaproj/numaflow/pkg/isb/kafka"
+	redisisb "github.com/numaproj/numaflow/pkg/isb/redis"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
+	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
+	"github.com/numaproj/numaflow/pkg/metrics"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+type ReduceProcessor struct {
+	ISBSvcType dfv1.ISBSvcType
+	Vertex     *dfv1.Vertex
+	Hostname   string
+	Replica    int
+}
+
+func (u *ReduceProcessor) Start(ctx context.Context) error {
+	log := logging.FromContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var readers []isb.BufferReader
+	fromBuffers := u.Vertex.GetFromBuffers()
+	toBuffers := u.Vertex.GetToBuffers()
+	writers := make(map[string]isb.BufferWriter)
+	switch u.ISBSvcType {
+	case dfv1.ISBSvcTypeRedis:
+		redisClient := clients.NewInClusterRedisClient()
+		consumer := fmt.Sprintf("%s-%v", u.Vertex.Name, u.Replica)
+		for _, fromBufferName := range fromBuffers {
+			fromGroup := fromBufferName + "-group"
+			readers = append(readers, redisisb.NewBufferRead(ctx, redisClient, fromBufferName, fromGroup, consumer))
+		}
+		writeOpts := []redisisb.Option{}
+		if x := u.Vertex.Spec.Limits; x != nil {
+			if x.BufferMaxLength != nil {
+				writeOpts = append(writeOpts, redisisb.WithMaxLength(int64(*x.BufferMaxLength)))
+			}
+			if x.BufferUsageLimit != nil {
+				writeOpts = append(writeOpts, redisisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
+			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, redisisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, redisisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
+		}
+		for _, b := range toBuffers {
+			writers[b] = redisisb.NewBufferWrite(ctx, redisClient, b, b+"-group", append(writeOpts, redisisb.WriterOptionsFor(u.Vertex, b)...)...)
+		}
+	case dfv1.ISBSvcTypeJetStream:
+		jetStreamClient := clients.InClusterSharedJetStreamClient()
+		for _, fromBufferName := range fromBuffers {
+			fromStreamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, fromBufferName)
+			reader, err := jetstreamisb.NewJetStreamBufferReader(ctx, jetStreamClient, fromBufferName, fromStreamName, fromStreamName, jetstreamisb.ReadOptionsFor(u.Vertex, fromBufferName)...)
+			if err != nil {
+				return err
+			}
+			readers = append(readers, reader)
+		}
+		writeOpts := []jetstreamisb.WriteOption{}
+		if x := u.Vertex.Spec.Limits; x != nil {
+			if x.BufferMaxLength != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithMaxLength(int64(*x.BufferMaxLength)))
+			}
+			if x.BufferUsageLimit != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
+			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
+		}
+		for _, b := range toBuffers {
+			streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, b)
+			writer, err := jetstreamisb.NewJetStreamBufferWriter(ctx, jetStreamClient, b, streamName, streamName, append(writeOpts, jetstreamisb.WriterOptionsFor(u.Vertex, b)...)...)
+			if err != nil {
+				return err
+			}
+			writers[b] = writer
+		}
+	case dfv1.ISBSvcTypeKafka:
+		kafkaClient := clients.NewInClusterKafkaClient()
+		for _, fromBufferName := range fromBuffers {
+			reader, err := kafkaisb.NewKafkaBufferReader(ctx, kafkaClient, fromBufferName, fromBufferName)
+			if err != nil {
+				return err
+			}
+			readers = append(readers, reader)
+		}
+		writeOpts := []kafkaisb.WriteOption{}
+		if x := u.Vertex.Spec.Limits; x != nil {
+			if x.BufferMaxLength != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxLength(int64(*x.BufferMaxLength)))
+			}
+			if x.BufferUsageLimit != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
+			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
+		}
+		for _, b := range toBuffers {
+			writer, err := kafkaisb.NewKafkaBufferWriter(ctx, kafkaClient, b, b, writeOpts...)
+			if err != nil {
+				return err
+			}
+			writers[b] = writer
+		}
+	default:
+		return fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
+	}
+	reader, err := fanin.NewReader(readers, u.Vertex.GetFromBufferReadWeights())
+	if err != nil {
+		return err
+	}
+
+	conditionalForwarder := forward.NewConditionalForwarder(u.Vertex)
+
+	opts := []Option{WithLogger(log)}
+	if x := u.Vertex.Spec.Limits; x != nil && x.ReadBatchSize != nil {
+		opts = append(opts, WithReadBatchSize(int64(*x.ReadBatchSize)))
+	}
+	traceRecorders := tracing.NewRecorders(u.Vertex, u.Hostname)
+	if traceRecorders != nil {
+		opts = append(opts, WithTraceRecorders(traceRecorders))
+	}
+	if ages := u.Vertex.GetFromBufferMaxMessageAges(); len(ages) > 0 {
+		opts = append(opts, WithMaxMessageAges(ages))
+	}
+	forwarder, err := NewDataForward(u.Vertex, reader, writers, conditionalForwarder, opts...)
+	if err != nil {
+		return err
+	}
+	log.Infow("Start processing reduce messages", zap.String("isbs", string(u.ISBSvcType)), zap.Strings("from", fromBuffers), zap.Any("to", toBuffers))
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		forwarder.Run(ctx)
+		log.Info("Forwarder stopped, exiting reduce processor...")
+	}()
+
+	if shutdown, err := metrics.StartMetricsServer(ctx, metrics.WithHandler(dfv1.VertexTracesPath, traceRecorders)); err != nil {
+		return fmt.Errorf("failed to start metrics server, error: %w", err)
+	} else {
+		defer func() { _ = shutdown(context.Background()) }()
+	}
+
+	<-ctx.Done()
+	log.Info("SIGTERM, exiting...")
+	wg.Wait()
+	log.Info("Exited...")
+	return nil
+}
@@ -0,0 +1,43 @@
+package reduce
+
+import (
+	"time"
+)
+
+// watermark tracks the watermark of the vertex from the event times of the messages read. It trails the largest event
+// time read by the allowed lateness, and it's extrapolated with the wall clock once no messages are read for the idle
+// timeout, so that the last windows are closed after the input stops. It never goes backwards.
+type watermark struct {
+	allowedLateness time.Duration
+	idleTimeout     time.Duration
+	maxEventTime    time.Time
+	lastRead        time.Time
+	current         time.Time
+}
+
+func newWatermark(allowedLateness, idleTimeout time.Duration) *watermark {
+	return &watermark{allowedLateness: allowedLateness, idleTimeout: idleTimeout}
+}
+
+// observe records the event time of a message read at now.
+func (w *watermark) observe(eventTime, now time.Time) {
+	if eventTime.After(w.maxEventTime) {
+		w.maxEventTime = eventTime
+	}
+	w.lastRead = now
+}
+
+// get returns the watermark at now.
+func (w *watermark) get(now time.Time) time.Time {
+	if w.maxEventTime.IsZero() {
+		return w.current
+	}
+	wm := w.maxEventTime.Add(-w.allowedLateness)
+	if idle := now.Sub(w.lastRead); idle >= w.idleTimeout {
+		wm = wm.Add(idle)
+	}
+	if wm.After(w.current) {
+		w.current = wm
+	}
+	return w.current
+}
@@ -0,0 +1,31 @@
+package reduce
+
+import (
+	"fmt"
+	"time"
+)
+
+// Window is the time range [Start, End) of the event time.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (w Window) String() string {
+	return fmt.Sprintf("[%s, %s)", w.Start.UTC().Format(time.RFC3339Nano), w.End.UTC().Format(time.RFC3339Nano))
+}
+
+// fixedWindower assigns the event times to the non-overlapping windows of a fixed length, aligned to the epoch.
+type fixedWindower struct {
+	length time.Duration
+}
+
+func (f fixedWindower) assign(eventTime time.Time) Window {
+	ns := eventTime.UnixNano()
+	offset := ns % int64(f.length)
+	if offset < 0 {
+		offset += int64(f.length)
+	}
+	start := time.Unix(0, ns-offset)
+	return Window{Start: start, End: start.Add(f.length)}
+}
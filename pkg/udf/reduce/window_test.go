@@ -0,0 +1,36 @@
+package reduce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedWindower_assign(t *testing.T) {
+	f := fixedWindower{length: time.Minute}
+	w := f.assign(time.Unix(125, 0))
+	assert.Equal(t, time.Unix(120, 0), w.Start)
+	assert.Equal(t, time.Unix(180, 0), w.End)
+	w = f.assign(time.Unix(120, 0))
+	assert.Equal(t, time.Unix(120, 0), w.Start)
+	w = f.assign(time.Unix(-1, 0))
+	assert.Equal(t, time.Unix(-60, 0), w.Start)
+	assert.Equal(t, time.Unix(0, 0), w.End)
+}
+
+func TestWatermark(t *testing.T) {
+	now := time.Unix(1000, 0)
+	w := newWatermark(2*time.Second, 10*time.Second)
+	assert.True(t, w.get(now).IsZero())
+	w.observe(time.Unix(100, 0), now)
+	assert.Equal(t, time.Unix(98, 0), w.get(now))
+	// never goes backwards
+	w.observe(time.Unix(90, 0), now)
+	assert.Equal(t, time.Unix(98, 0), w.get(now))
+	// extrapolated once idle
+	assert.Equal(t, time.Unix(98, 0), w.get(now.Add(9*time.Second)))
+	assert.Equal(t, time.Unix(110, 0), w.get(now.Add(12*time.Second)))
+	w.observe(time.Unix(101, 0), now.Add(13*time.Second))
+	assert.Equal(t, time.Unix(110, 0), w.get(now.Add(13*time.Second)))
+}
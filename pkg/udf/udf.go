@@ -3,6 +3,8 @@ package udf
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"sync"
 	"time"
 
@@ -10,16 +12,25 @@ import (
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/isb/fanin"
 	"github.com/numaproj/numaflow/pkg/isb/forward"
 	jetstreamisb "github.com/numaproj/numaflow/pkg/isb/jetstream"
+	kafkaisb "github.com/numaproj/numaflow/pkg/isb/kafka"
 	redisisb "github.com/numaproj/numaflow/pkg/isb/redis"
+	"github.com/numaproj/numaflow/pkg/isb/telemetry"
+	"github.com/numaproj/numaflow/pkg/isb/tracing"
 	"github.com/numaproj/numaflow/pkg/isbsvc/clients"
 	"github.com/numaproj/numaflow/pkg/metrics"
+	"github.com/numaproj/numaflow/pkg/shared/lifecycle"
 	"github.com/numaproj/numaflow/pkg/shared/logging"
-	sharedutil "github.com/numaproj/numaflow/pkg/shared/util"
 	"github.com/numaproj/numaflow/pkg/udf/applier"
+	udfclient "github.com/numaproj/numaflow/pkg/udf/client"
+	"github.com/numaproj/numaflow/pkg/udf/plugin"
 )
 
+// udfHTTPSockAddr is the unix domain socket the UDF container serves the HTTP protocol on
+var udfHTTPSockAddr = dfv1.PathVarRun + "/udf.sock"
+
 type UDFProcessor struct {
 	ISBSvcType dfv1.ISBSvcType
 	Vertex     *dfv1.Vertex
@@ -31,17 +42,22 @@ func (u *UDFProcessor) Start(ctx context.Context) error {
 	log := logging.FromContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	var reader isb.BufferReader
+	var readers []isb.BufferReader
 	var err error
-	fromBufferName := u.Vertex.GetFromBuffers()[0]
+	fromBuffers := u.Vertex.GetFromBuffers()
 	toBuffers := u.Vertex.GetToBuffers()
 	writers := make(map[string]isb.BufferWriter)
+	deadLetterQueues := u.Vertex.GetDeadLetterQueues()
+	// deadLetterWriters are keyed by the from buffer names
+	deadLetterWriters := make(map[string]isb.BufferWriter)
 	switch u.ISBSvcType {
 	case dfv1.ISBSvcTypeRedis:
 		redisClient := clients.NewInClusterRedisClient()
-		fromGroup := fromBufferName + "-group"
 		consumer := fmt.Sprintf("%s-%v", u.Vertex.Name, u.Replica)
-		reader = redisisb.NewBufferRead(ctx, redisClient, fromBufferName, fromGroup, consumer)
+		for _, fromBufferName := range fromBuffers {
+			fromGroup := fromBufferName + "-group"
+			readers = append(readers, redisisb.NewBufferRead(ctx, redisClient, fromBufferName, fromGroup, consumer))
+		}
 		writeOpts := []redisisb.Option{}
 		if x := u.Vertex.Spec.Limits; x != nil {
 			if x.BufferMaxLength != nil {
@@ -50,17 +66,30 @@ func (u *UDFProcessor) Start(ctx context.Context) error {
 			if x.BufferUsageLimit != nil {
 				writeOpts = append(writeOpts, redisisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
 			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, redisisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, redisisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
 		}
 		for _, b := range toBuffers {
-			writer := redisisb.NewBufferWrite(ctx, redisClient, b, b+"-group", writeOpts...)
+			writer := redisisb.NewBufferWrite(ctx, redisClient, b, b+"-group", append(writeOpts, redisisb.WriterOptionsFor(u.Vertex, b)...)...)
 			writers[string(b)] = writer
 		}
+		for b := range deadLetterQueues {
+			dlqBufferName := dfv1.DeadLetterBufferName(b)
+			deadLetterWriters[b] = redisisb.NewBufferWrite(ctx, redisClient, dlqBufferName, dlqBufferName+"-group", writeOpts...)
+		}
 	case dfv1.ISBSvcTypeJetStream:
-		fromStreamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, fromBufferName)
-		jetStreamClient := clients.NewInClusterJetStreamClient()
-		reader, err = jetstreamisb.NewJetStreamBufferReader(ctx, jetStreamClient, fromBufferName, fromStreamName, fromStreamName)
-		if err != nil {
-			return err
+		jetStreamClient := clients.InClusterSharedJetStreamClient()
+		for _, fromBufferName := range fromBuffers {
+			fromStreamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, fromBufferName)
+			reader, err := jetstreamisb.NewJetStreamBufferReader(ctx, jetStreamClient, fromBufferName, fromStreamName, fromStreamName, jetstreamisb.ReadOptionsFor(u.Vertex, fromBufferName)...)
+			if err != nil {
+				return err
+			}
+			readers = append(readers, reader)
 		}
 		writeOpts := []jetstreamisb.WriteOption{}
 		if x := u.Vertex.Spec.Limits; x != nil {
@@ -70,42 +99,136 @@ func (u *UDFProcessor) Start(ctx context.Context) error {
 			if x.BufferUsageLimit != nil {
 				writeOpts = append(writeOpts, jetstreamisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
 			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, jetstreamisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
 		}
 		for _, b := range toBuffers {
 			streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, b)
-			writer, err := jetstreamisb.NewJetStreamBufferWriter(ctx, jetStreamClient, b, streamName, streamName, writeOpts...)
+			writer, err := jetstreamisb.NewJetStreamBufferWriter(ctx, jetStreamClient, b, streamName, streamName, append(writeOpts, jetstreamisb.WriterOptionsFor(u.Vertex, b)...)...)
 			if err != nil {
 				return err
 			}
 			writers[string(b)] = writer
 		}
+		for b := range deadLetterQueues {
+			dlqBufferName := dfv1.DeadLetterBufferName(b)
+			streamName := fmt.Sprintf("%s-%s", u.Vertex.Spec.PipelineName, dlqBufferName)
+			writer, err := jetstreamisb.NewJetStreamBufferWriter(ctx, jetStreamClient, dlqBufferName, streamName, streamName, writeOpts...)
+			if err != nil {
+				return err
+			}
+			deadLetterWriters[b] = writer
+		}
+	case dfv1.ISBSvcTypeKafka:
+		kafkaClient := clients.NewInClusterKafkaClient()
+		for _, fromBufferName := range fromBuffers {
+			reader, err := kafkaisb.NewKafkaBufferReader(ctx, kafkaClient, fromBufferName, fromBufferName)
+			if err != nil {
+				return err
+			}
+			readers = append(readers, reader)
+		}
+		writeOpts := []kafkaisb.WriteOption{}
+		if x := u.Vertex.Spec.Limits; x != nil {
+			if x.BufferMaxLength != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxLength(int64(*x.BufferMaxLength)))
+			}
+			if x.BufferUsageLimit != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithBufferUsageLimit(float64(*x.BufferUsageLimit)/100))
+			}
+			if x.MaxKeySize != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxKeySize(int(*x.MaxKeySize)))
+			}
+			if x.MaxHeaderSize != nil {
+				writeOpts = append(writeOpts, kafkaisb.WithMaxHeaderSize(int(*x.MaxHeaderSize)))
+			}
+		}
+		for _, b := range toBuffers {
+			writer, err := kafkaisb.NewKafkaBufferWriter(ctx, kafkaClient, b, b, writeOpts...)
+			if err != nil {
+				return err
+			}
+			writers[string(b)] = writer
+		}
+		for b := range deadLetterQueues {
+			dlqBufferName := dfv1.DeadLetterBufferName(b)
+			writer, err := kafkaisb.NewKafkaBufferWriter(ctx, kafkaClient, dlqBufferName, dlqBufferName, writeOpts...)
+			if err != nil {
+				return err
+			}
+			deadLetterWriters[b] = writer
+		}
 	default:
 		return fmt.Errorf("unrecognized isbs type %q", u.ISBSvcType)
 	}
+	reader, err := fanin.NewReader(readers, u.Vertex.GetFromBufferReadWeights())
+	if err != nil {
+		return err
+	}
 
-	conditionalForwarder := forward.GoWhere(func(key []byte) ([]string, error) {
-		result := []string{}
-		_key := string(key)
-		if _key == dfv1.MessageKeyAll || _key == dfv1.MessageKeyDrop {
-			result = append(result, _key)
-			return result, nil
-		}
-		for _, to := range u.Vertex.Spec.ToVertices {
-			// If returned key is not "ALL" or "DROP", and there's no conditions defined in the edge,
-			// treat it as "ALL"?
-			if to.Conditions == nil || len(to.Conditions.KeyIn) == 0 || sharedutil.StringSliceContains(to.Conditions.KeyIn, _key) {
-				result = append(result, u.Vertex.GetToBufferName(to.Name))
-			}
-		}
-		return result, nil
-	})
+	conditionalForwarder := forward.NewConditionalForwarder(u.Vertex)
 
-	udfHandler := applier.NewUDSHTTPBasedUDF(dfv1.PathVarRun+"/udf.sock", applier.WithHTTPClientTimeout(120*time.Second))
-	// Readiness check
-	if err := udfHandler.WaitUntilReady(ctx); err != nil {
-		return fmt.Errorf("failed on UDF readiness check, %w", err)
+	var udfHandler applier.Applier
+	if x := u.Vertex.Spec.UDF.Plugin; x != nil {
+		handle, err := plugin.Lookup(x.Name)
+		if err != nil {
+			return err
+		}
+		log.Infow("Using in-process plugin function", zap.String("name", x.Name))
+		udfHandler = applier.NewInProcessUDF(handle)
+	} else if x := u.Vertex.Spec.UDF.WASM; x != nil {
+		module, err := ioutil.ReadFile(x.GetPath())
+		if err != nil {
+			return fmt.Errorf("failed to read wasm module, %w", err)
+		}
+		// One instance for each of the concurrent UDF calls.
+		maxIdle := 1
+		if l := u.Vertex.Spec.Limits; l != nil && l.UDFWorkers != nil {
+			maxIdle = int(*l.UDFWorkers)
+		}
+		wasmHandler, err := applier.NewWASMUDF(ctx, module, maxIdle)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = wasmHandler.Close(context.Background()) }()
+		log.Infow("Using wasm module", zap.String("path", x.GetPath()), zap.Int("size", len(module)))
+		udfHandler = wasmHandler
+	} else {
+		// The protocol is told by the socket the UDF container serves on.
+		sockAddr, err := waitForSocket(ctx, udfHTTPSockAddr, udfclient.DefaultSockAddr)
+		if err != nil {
+			return fmt.Errorf("failed on UDF readiness check, %w", err)
+		}
+		if sockAddr == udfclient.DefaultSockAddr {
+			c, err := udfclient.New()
+			if err != nil {
+				return err
+			}
+			grpcHandler := applier.NewUDSGRPCBasedUDF(c, 120*time.Second)
+			defer func() { _ = grpcHandler.Close() }()
+			// Readiness check
+			if err := grpcHandler.WaitUntilReady(ctx); err != nil {
+				return fmt.Errorf("failed on UDF readiness check, %w", err)
+			}
+			log.Info("Using the gRPC based UDF")
+			udfHandler = grpcHandler
+		} else {
+			httpHandler := applier.NewUDSHTTPBasedUDF(udfHTTPSockAddr, applier.WithHTTPClientTimeout(120*time.Second))
+			// Readiness check
+			if err := httpHandler.WaitUntilReady(ctx); err != nil {
+				return fmt.Errorf("failed on UDF readiness check, %w", err)
+			}
+			udfHandler = httpHandler
+		}
 	}
-	log.Infow("Start processing udf messages", zap.String("isbs", string(u.ISBSvcType)), zap.String("from", fromBufferName), zap.Any("to", toBuffers))
+	if x := u.Vertex.Spec.UDF.WarmUp; x != nil {
+		u.warmUp(ctx, udfHandler, x)
+	}
+	log.Infow("Start processing udf messages", zap.String("isbs", string(u.ISBSvcType)), zap.Strings("from", fromBuffers), zap.Any("to", toBuffers))
 	opts := []forward.Option{forward.WithLogger(log)}
 	if x := u.Vertex.Spec.Limits; x != nil {
 		if x.ReadBatchSize != nil {
@@ -115,6 +238,46 @@ func (u *UDFProcessor) Start(ctx context.Context) error {
 			opts = append(opts, forward.WithUDFConcurrency(int(*x.UDFWorkers)))
 		}
 	}
+	if u.Vertex.Spec.UDF.Batch {
+		opts = append(opts, forward.WithUDFBatch())
+		if h, ok := udfHandler.(*applier.UDSHTTPBasedUDF); ok && h.MaxBatchSize() > 0 {
+			readBatchSize := uint64(dfv1.DefaultPipelineReadBatchSize)
+			if x := u.Vertex.Spec.Limits; x != nil && x.ReadBatchSize != nil {
+				readBatchSize = *x.ReadBatchSize
+			}
+			if readBatchSize > uint64(h.MaxBatchSize()) {
+				log.Infow("The read batch size exceeds the max batch size declared by the UDF, the read batches are sent to the UDF in chunks", zap.Uint64("readBatchSize", readBatchSize), zap.Int("maxBatchSize", h.MaxBatchSize()))
+			}
+		}
+	} else if h, ok := udfHandler.(interface{ Streaming() bool }); ok && h.Streaming() {
+		// the streamed outputs are written in chunks of the read batch size
+		chunkSize := uint64(dfv1.DefaultPipelineReadBatchSize)
+		if x := u.Vertex.Spec.Limits; x != nil && x.ReadBatchSize != nil {
+			chunkSize = *x.ReadBatchSize
+		}
+		log.Infow("The UDF streams the outputs, they are written to the buffers in chunks", zap.Uint64("chunkSize", chunkSize))
+		opts = append(opts, forward.WithUDFStreaming(int(chunkSize)))
+	}
+	if x := u.Vertex.Spec.SlowStart; x != nil {
+		opts = append(opts, forward.WithSlowStart(x.GetDuration(), int64(x.GetInitialReadBatchSize())))
+	}
+	if u.Vertex.Spec.TenantKey != "" {
+		opts = append(opts, forward.WithTenantKey(u.Vertex.Spec.TenantKey))
+	}
+	if ages := u.Vertex.GetFromBufferMaxMessageAges(); len(ages) > 0 {
+		opts = append(opts, forward.WithMaxMessageAges(ages))
+	}
+	for b, x := range deadLetterQueues {
+		opts = append(opts, forward.WithDeadLetterQueue(b, deadLetterWriters[b], x.GetMaxRetries()))
+	}
+	traceRecorders := tracing.NewRecorders(u.Vertex, u.Hostname)
+	if traceRecorders != nil {
+		opts = append(opts, forward.WithTraceRecorders(traceRecorders))
+	}
+	if tracer := telemetry.NewInClusterTracer(u.Vertex, u.Hostname); tracer != nil {
+		opts = append(opts, forward.WithTracer(tracer))
+		go tracer.Run(ctx)
+	}
 	forwarder, err := forward.NewInterStepDataForward(u.Vertex, reader, writers, conditionalForwarder, udfHandler, opts...)
 	if err != nil {
 		return err
@@ -132,16 +295,67 @@ func (u *UDFProcessor) Start(ctx context.Context) error {
 		}
 	}()
 
-	if shutdown, err := metrics.StartMetricsServer(ctx); err != nil {
+	drainer := lifecycle.NewDrainer()
+	// Not ready once drained, so that the controller knows the replica can be deleted when scaling down.
+	metricsOpts := []metrics.Option{metrics.WithReadiness(func() bool { return !drainer.Drained() }), metrics.WithHandler(dfv1.VertexTracesPath, traceRecorders)}
+	// Not live once the forwarder is stuck, so that the pod is restarted.
+	if threshold := u.Vertex.Spec.Liveness.GetStuckThreshold(); threshold > 0 {
+		metricsOpts = append(metricsOpts, metrics.WithLiveness(func() error { return forwarder.CheckProgress(threshold) }))
+	}
+	if shutdown, err := metrics.StartMetricsServer(ctx, metricsOpts...); err != nil {
 		return fmt.Errorf("failed to start metrics server, error: %w", err)
 	} else {
 		defer func() { _ = shutdown(context.Background()) }()
 	}
 
-	<-ctx.Done()
-	log.Info("SIGTERM, exiting...")
+	if u.Vertex.Spec.UDF.Plugin == nil && u.Vertex.Spec.UDF.WASM == nil { // the sidecar container waits for the draining in its pre-stop hook
+		if shutdown, err := lifecycle.StartPreStopServer(ctx, drainer); err != nil {
+			return fmt.Errorf("failed to start pre-stop hook server, error: %w", err)
+		} else {
+			defer func() { _ = shutdown(context.Background()) }()
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		log.Info("SIGTERM, exiting...")
+	case <-lifecycle.WatchDrainRequest(ctx, 5*time.Second):
+		log.Info("Marked to be drained before scaling down, stop processing...")
+	}
 	forwarder.Stop()
 	wg.Wait()
+	drainer.Done()
+	<-ctx.Done()
 	log.Info("Exited...")
 	return nil
 }
+
+// warmUp calls the UDF with the synthetic messages before reading from the buffer, failures are logged and ignored.
+func (u *UDFProcessor) warmUp(ctx context.Context, udfHandler applier.Applier, w *dfv1.UDFWarmUp) {
+	log := logging.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, w.GetTimeout())
+	defer cancel()
+	start := time.Now()
+	log.Infow("Warming up the UDF", zap.Int("requests", w.GetRequests()), zap.Duration("timeout", w.GetTimeout()))
+	if err := applier.WarmUp(ctx, udfHandler, []byte(w.Key), []byte(w.Payload), w.GetRequests()); err != nil {
+		log.Warnw("Failed to warm up the UDF, start processing anyway", zap.Error(err))
+		return
+	}
+	log.Infow("Finished warming up the UDF", zap.Duration("took", time.Since(start)))
+}
+
+// waitForSocket waits till one of the unix domain sockets is created, and returns its path.
+func waitForSocket(ctx context.Context, paths ...string) (string, error) {
+	for {
+		for _, p := range paths {
+			if _, err := os.Stat(p); err == nil {
+				return p, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("failed to wait for the UDF socket: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package udf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForSocket(t *testing.T) {
+	dir := t.TempDir()
+	httpSock := filepath.Join(dir, "udf.sock")
+	grpcSock := filepath.Join(dir, "function.sock")
+
+	t.Run("test timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := waitForSocket(ctx, httpSock, grpcSock)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("test found", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(grpcSock, nil, 0600))
+		p, err := waitForSocket(context.Background(), httpSock, grpcSock)
+		assert.NoError(t, err)
+		assert.Equal(t, grpcSock, p)
+	})
+}
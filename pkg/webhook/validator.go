@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	isbsvcctrl "github.com/numaproj/numaflow/controllers/isbsvc"
+	plctrl "github.com/numaproj/numaflow/controllers/pipeline"
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+// pipelineValidator validates the pipelines being created or updated.
+type pipelineValidator struct {
+	decoder *admission.Decoder
+}
+
+func (v *pipelineValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+func (v *pipelineValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	pl := &dfv1.Pipeline{}
+	if err := v.decoder.Decode(req, pl); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// A pipeline being deleted only gets its finalizers removed
+	if pl.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+	if err := plctrl.ValidatePipeline(pl); err != nil {
+		return denied(err)
+	}
+	if req.Operation == admissionv1.Update {
+		old := &dfv1.Pipeline{}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := plctrl.ValidatePipelineUpdate(old, pl); err != nil {
+			return denied(err)
+		}
+	}
+	return admission.Allowed("").WithWarnings(plctrl.GetPipelineWarnings(pl)...)
+}
+
+// isbSvcValidator validates the InterStepBufferServices being created or updated.
+type isbSvcValidator struct {
+	decoder *admission.Decoder
+}
+
+func (v *isbSvcValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+func (v *isbSvcValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	isbs := &dfv1.InterStepBufferService{}
+	if err := v.decoder.Decode(req, isbs); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if isbs.DeletionTimestamp != nil {
+		return admission.Allowed("")
+	}
+	if err := isbsvcctrl.ValidateInterStepBufferService(isbs); err != nil {
+		return denied(err)
+	}
+	return admission.Allowed("")
+}
+
+// denied returns a response denying the request, with the error as the message shown to the users.
+func denied(err error) admission.Response {
+	resp := admission.Denied(err.Error())
+	resp.Result.Message = err.Error()
+	return resp
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+)
+
+var testPipeline = &dfv1.Pipeline{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-pl",
+		Namespace: "test-ns",
+	},
+	Spec: dfv1.PipelineSpec{
+		Vertices: []dfv1.AbstractVertex{
+			{Name: "input", Source: &dfv1.Source{}},
+			{Name: "p1", UDF: &dfv1.UDF{Builtin: &dfv1.Function{Name: "cat"}}},
+			{Name: "output", Sink: &dfv1.Sink{}},
+		},
+		Edges: []dfv1.Edge{
+			{From: "input", To: "p1"},
+			{From: "p1", To: "output"},
+		},
+	},
+}
+
+func newDecoder(t *testing.T) *admission.Decoder {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	assert.NoError(t, dfv1.AddToScheme(scheme))
+	d, err := admission.NewDecoder(scheme)
+	assert.NoError(t, err)
+	return d
+}
+
+func newRequest(t *testing.T, op admissionv1.Operation, obj, old runtime.Object) admission.Request {
+	t.Helper()
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: op}}
+	raw, err := json.Marshal(obj)
+	assert.NoError(t, err)
+	req.Object = runtime.RawExtension{Raw: raw}
+	if old != nil {
+		raw, err := json.Marshal(old)
+		assert.NoError(t, err)
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return req
+}
+
+func TestPipelineValidator(t *testing.T) {
+	v := &pipelineValidator{}
+	assert.NoError(t, v.InjectDecoder(newDecoder(t)))
+	ctx := context.TODO()
+
+	t.Run("valid pipeline", func(t *testing.T) {
+		resp := v.Handle(ctx, newRequest(t, admissionv1.Create, testPipeline, nil))
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("valid pipeline with warnings", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Vertices[1].NodeSelector = map[string]string{"large": strings.Repeat("x", 600*1024)}
+		resp := v.Handle(ctx, newRequest(t, admissionv1.Create, pl, nil))
+		assert.True(t, resp.Allowed)
+		assert.Len(t, resp.Warnings, 1)
+	})
+
+	t.Run("unknown vertex in edges", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Edges = append(pl.Spec.Edges, dfv1.Edge{From: "p1", To: "nonexistent"})
+		resp := v.Handle(ctx, newRequest(t, admissionv1.Create, pl, nil))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, `no vertex named "nonexistent"`)
+	})
+
+	t.Run("isbsvc name changed", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.InterStepBufferServiceName = "another"
+		resp := v.Handle(ctx, newRequest(t, admissionv1.Update, pl, testPipeline))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Message, "immutable")
+	})
+
+	t.Run("being deleted", func(t *testing.T) {
+		pl := testPipeline.DeepCopy()
+		pl.Spec.Edges = nil
+		now := metav1.Now()
+		pl.DeletionTimestamp = &now
+		resp := v.Handle(ctx, newRequest(t, admissionv1.Update, pl, testPipeline))
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create}}
+		req.Object = runtime.RawExtension{Raw: []byte("{")}
+		resp := v.Handle(ctx, req)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, int32(400), resp.Result.Code)
+	})
+}
+
+func TestISBSvcValidator(t *testing.T) {
+	v := &isbSvcValidator{}
+	assert.NoError(t, v.InjectDecoder(newDecoder(t)))
+	ctx := context.TODO()
+	isbs := &dfv1.InterStepBufferService{
+		ObjectMeta: metav1.ObjectMeta{Name: dfv1.DefaultISBSvcName, Namespace: "test-ns"},
+		Spec: dfv1.InterStepBufferServiceSpec{
+			JetStream: &dfv1.JetStreamBufferService{Version: "2.7.1"},
+		},
+	}
+	resp := v.Handle(ctx, newRequest(t, admissionv1.Create, isbs, nil))
+	assert.True(t, resp.Allowed)
+
+	isbs.Spec.JetStream.Version = ""
+	resp = v.Handle(ctx, newRequest(t, admissionv1.Create, isbs, nil))
+	assert.False(t, resp.Allowed)
+	assert.Contains(t, resp.Result.Message, "spec.jetstream.version")
+}
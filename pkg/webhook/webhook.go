@@ -0,0 +1,54 @@
+/*
+Package webhook implements the validating admission webhook of the Pipeline and InterStepBufferService objects, so that
+an invalid spec is rejected at admission time instead of failing later in the reconciliation.
+*/
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+)
+
+const (
+	// PipelineValidationPath is the path of the Pipeline validating webhook
+	PipelineValidationPath = "/validate-pipeline"
+	// ISBSvcValidationPath is the path of the InterStepBufferService validating webhook
+	ISBSvcValidationPath = "/validate-isbsvc"
+)
+
+type webhookServer struct {
+	port    int
+	certDir string
+}
+
+// NewWebhookServer returns a webhook server listening on the port, with the tls.crt and tls.key in the certDir.
+func NewWebhookServer(port int, certDir string) *webhookServer {
+	return &webhookServer{
+		port:    port,
+		certDir: certDir,
+	}
+}
+
+// Run starts the webhook server, and blocks until the context is cancelled.
+func (ws *webhookServer) Run(ctx context.Context) error {
+	log := logging.FromContext(ctx)
+	scheme := runtime.NewScheme()
+	if err := dfv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add scheme, %w", err)
+	}
+	server := &webhook.Server{
+		Port:    ws.port,
+		CertDir: ws.certDir,
+	}
+	server.Register(PipelineValidationPath, &webhook.Admission{Handler: &pipelineValidator{}})
+	server.Register(ISBSvcValidationPath, &webhook.Admission{Handler: &isbSvcValidator{}})
+	log.Infow("Starting webhook server", zap.Int("port", ws.port))
+	return server.StartStandalone(ctx, scheme)
+}
@@ -0,0 +1,150 @@
+package function
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	functionpb "github.com/numaproj/numaflow/pkg/apis/proto/function"
+)
+
+const grpcSockAddr = "/var/run/numaflow/function.sock"
+
+type useGRPC bool
+
+func (f useGRPC) apply(opts *options) {
+	opts.grpc = bool(f)
+}
+
+// WithGRPC serves the handler with the gRPC user defined function protocol at /var/run/numaflow/function.sock instead
+// of HTTP, the batch mode is not supported with gRPC.
+func WithGRPC() Option {
+	return useGRPC(true)
+}
+
+// eventTimeKey is the context key of the event time of the input message.
+type eventTimeKey struct{}
+
+// EventTimeFromContext returns the event time of the input message, it's only available with the gRPC protocol.
+func EventTimeFromContext(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(eventTimeKey{}).(time.Time)
+	return t, ok
+}
+
+// grpcServer implements the gRPC user defined function protocol with the handlers.
+type grpcServer struct {
+	functionpb.UnimplementedUserDefinedFunctionServer
+	handler Handle
+	// stream is nil if the outputs are not streamed
+	stream StreamHandle
+}
+
+var _ functionpb.UserDefinedFunctionServer = (*grpcServer)(nil)
+
+func (s *grpcServer) MapFn(ctx context.Context, d *functionpb.Datum) (*functionpb.DatumList, error) {
+	messages, err := s.handler(datumContext(ctx, d), d.GetKey(), d.GetValue())
+	if err != nil {
+		log.Printf("Failed to process input message, %s", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(messages) == 0 { // Return a DROP message
+		messages = append(messages, MessageToDrop())
+	}
+	list := &functionpb.DatumList{Elements: make([]*functionpb.Datum, 0, len(messages))}
+	for _, m := range messages {
+		list.Elements = append(list.Elements, toDatum(m))
+	}
+	return list, nil
+}
+
+func (s *grpcServer) MapStreamFn(d *functionpb.Datum, stream functionpb.UserDefinedFunction_MapStreamFnServer) error {
+	if s.stream == nil {
+		return status.Error(codes.Unimplemented, "the outputs are not streamed, use MapFn instead")
+	}
+	emitted := 0
+	err := s.stream(datumContext(stream.Context(), d), d.GetKey(), d.GetValue(), func(m Message) error {
+		emitted++
+		return stream.Send(toDatum(m))
+	})
+	if err == nil && emitted == 0 { // Return a DROP message
+		err = stream.Send(toDatum(MessageToDrop()))
+	}
+	if err != nil {
+		log.Printf("Failed to process input message, %s", err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func (s *grpcServer) IsReady(context.Context, *emptypb.Empty) (*functionpb.ReadyResponse, error) {
+	return &functionpb.ReadyResponse{Ready: true, Streaming: s.stream != nil}, nil
+}
+
+// datumContext returns a copy of the context carrying the metadata and the event time of the input datum.
+func datumContext(ctx context.Context, d *functionpb.Datum) context.Context {
+	ctx = ContextWithMetadata(ctx, d.GetMetadata())
+	if d.GetEventTime() != nil {
+		if t, err := types.TimestampFromProto(d.GetEventTime()); err == nil {
+			ctx = context.WithValue(ctx, eventTimeKey{}, t)
+		}
+	}
+	return ctx
+}
+
+func toDatum(m Message) *functionpb.Datum {
+	return &functionpb.Datum{Key: m.Key, Value: m.Value, Metadata: m.Metadata}
+}
+
+// startGRPCWithContext serves the gRPC user defined function protocol on the unix domain socket until the context is
+// done. stream is nil if the outputs are not streamed.
+func startGRPCWithContext(ctx context.Context, handler Handle, stream StreamHandle, opts options) error {
+	return serveGRPC(ctx, grpcSockAddr, &grpcServer{handler: handler, stream: stream}, opts.drainTimeout)
+}
+
+func serveGRPC(ctx context.Context, path string, s *grpcServer, drainTimeout time.Duration) error {
+	if err := os.Remove(path); !os.IsNotExist(err) && err != nil {
+		return err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = listener.Close() }()
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(64*1024*1024), grpc.MaxSendMsgSize(64*1024*1024))
+	functionpb.RegisterUserDefinedFunctionServer(server, s)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	log.Printf("udf gRPC server is ready")
+
+	// wait for signal
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	log.Println("udf gRPC server is now shutting down")
+	defer log.Println("udf gRPC server has exited")
+
+	// let's not wait indefinitely
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(drainTimeout):
+		server.Stop()
+	}
+	return nil
+}
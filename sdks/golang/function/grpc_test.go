@@ -0,0 +1,119 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	functionpb "github.com/numaproj/numaflow/pkg/apis/proto/function"
+)
+
+func newTestGRPCClient(t *testing.T, s *grpcServer) functionpb.UserDefinedFunctionClient {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "function.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, serveGRPC(ctx, path, s, time.Second))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	// dialing before the socket is created would wait for the reconnection backoff
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+	conn, err := grpc.Dial("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return functionpb.NewUserDefinedFunctionClient(conn)
+}
+
+func TestGRPCServer_MapFn(t *testing.T) {
+	var eventTime time.Time
+	handler := func(ctx context.Context, key, msg []byte) (Messages, error) {
+		if string(msg) == "fail" {
+			return nil, fmt.Errorf("test error")
+		}
+		if string(msg) == "drop" {
+			return nil, nil
+		}
+		eventTime, _ = EventTimeFromContext(ctx)
+		md := MetadataFromContext(ctx)
+		md["seen"] = "true"
+		return MessagesBuilder().Append(MessageTo(string(key), msg).WithMetadata(md)), nil
+	}
+	c := newTestGRPCClient(t, &grpcServer{handler: handler})
+	ctx := context.Background()
+
+	resp, err := c.IsReady(ctx, &emptypb.Empty{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	assert.True(t, resp.GetReady())
+	assert.False(t, resp.GetStreaming())
+
+	now := time.Unix(1661169600, 0).UTC()
+	ts, _ := types.TimestampProto(now)
+	list, err := c.MapFn(ctx, &functionpb.Datum{Key: []byte("k"), Value: []byte("v"), EventTime: ts, Metadata: map[string]string{"a": "b"}})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetElements(), 1)
+	assert.Equal(t, []byte("k"), list.GetElements()[0].GetKey())
+	assert.Equal(t, map[string]string{"a": "b", "seen": "true"}, list.GetElements()[0].GetMetadata())
+	assert.True(t, now.Equal(eventTime))
+
+	list, err = c.MapFn(ctx, &functionpb.Datum{Value: []byte("drop")})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(DROP), list.GetElements()[0].GetKey())
+
+	_, err = c.MapFn(ctx, &functionpb.Datum{Value: []byte("fail")})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	stream, err := c.MapStreamFn(ctx, &functionpb.Datum{Value: []byte("v")})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestGRPCServer_MapStreamFn(t *testing.T) {
+	c := newTestGRPCClient(t, &grpcServer{handler: collect(explodeTestHandler), stream: explodeTestHandler})
+	ctx := context.Background()
+
+	resp, err := c.IsReady(ctx, &emptypb.Empty{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	assert.True(t, resp.GetStreaming())
+
+	stream, err := c.MapStreamFn(ctx, &functionpb.Datum{Value: []byte("abc")})
+	assert.NoError(t, err)
+	for _, b := range []byte("abc") {
+		d, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{b}, d.GetValue())
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	stream, err = c.MapStreamFn(ctx, &functionpb.Datum{Value: []byte("fail")})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	list, err := c.MapFn(ctx, &functionpb.Datum{Value: []byte("ab")})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetElements(), 2)
+}
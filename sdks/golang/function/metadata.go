@@ -0,0 +1,22 @@
+package function
+
+import "context"
+
+type metadataKey struct{}
+
+// ContextWithMetadata returns a copy of the context carrying the metadata of the input message.
+func ContextWithMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// MetadataFromContext returns a copy of the metadata of the input message, which is safe to be modified and set to
+// the output messages with Message.WithMetadata. It's empty if the input message has no metadata.
+func MetadataFromContext(ctx context.Context) map[string]string {
+	metadata := map[string]string{}
+	if md, ok := ctx.Value(metadataKey{}).(map[string]string); ok {
+		for k, v := range md {
+			metadata[k] = v
+		}
+	}
+	return metadata
+}
@@ -1,6 +1,19 @@
 // Package golang provides an interface to write UDF in golang which will be exposed over HTTP. It accepts a handler of the following definition
 //  func(ctx context.Context, key, msg []byte) (messages Messages, err error)
 // which will be invoked for message. If error is returned, the HTTP StatusCode will be set to 500.
+//
+// The metadata of the input message is available with MetadataFromContext(ctx), the output messages carry it over
+// unless they are given new metadata with Message.WithMetadata.
+//
+// The handler is also exposed at `/messages/batch` for the batch mode, where a list of BatchMessage is posted in one
+// request, and the BatchResults keyed by the message IDs are returned.
+//
+// With StartStream, a handler emitting the output messages one by one is exposed at `/messages/stream` as well, where
+// the outputs are streamed back as they are emitted.
+//
+// With WithGRPC, the handlers are served with the gRPC user defined function protocol at
+// /var/run/numaflow/function.sock instead, where the event time of the input message is also available with
+// EventTimeFromContext(ctx).
 package function
 
 import (
@@ -13,6 +26,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -25,7 +39,9 @@ const (
 
 	envUDFContentType = "NUMAFLOW_UDF_CONTENT_TYPE"
 
-	messagekey = "x-numa-message-key"
+	messagekey         = "x-numa-message-key"
+	messageMetadataKey = "x-numa-message-metadata"
+	maxBatchSizeKey    = "x-numa-max-batch-size"
 )
 
 type Handle func(ctx context.Context, key, msg []byte) (Messages, error)
@@ -33,6 +49,11 @@ type Handle func(ctx context.Context, key, msg []byte) (Messages, error)
 // options for starting the http udf server
 type options struct {
 	drainTimeout time.Duration
+	maxBatchSize int
+	// streaming declares the output messages can be streamed at `/messages/stream`
+	streaming bool
+	// grpc serves the handler with the gRPC protocol instead of HTTP
+	grpc bool
 }
 
 // Option to apply different options
@@ -52,7 +73,19 @@ func WithDrainTimeout(f time.Duration) Option {
 	return drainTimeout(f)
 }
 
-// Start starts the HTTP Server after registering the handler at `/messages` endpoint.
+type maxBatchSize int
+
+func (f maxBatchSize) apply(opts *options) {
+	opts.maxBatchSize = int(f)
+}
+
+// WithMaxBatchSize declares the max number of messages the handler is able to process in one batch, it is sent to the
+// platform in the readiness check, and the larger read batches are sent in chunks. Default is 0, which means no limit.
+func WithMaxBatchSize(n int) Option {
+	return maxBatchSize(n)
+}
+
+// Start starts the HTTP Server after registering the handler at `/messages` endpoint, or the gRPC server with WithGRPC.
 func Start(ctx context.Context, handler Handle, opts ...Option) {
 	options := options{
 		drainTimeout: time.Minute,
@@ -64,6 +97,12 @@ func Start(ctx context.Context, handler Handle, opts ...Option) {
 
 	ctxWithSignal, stop := signal.NotifyContext(ctx, syscall.SIGTERM)
 	defer stop()
+	if options.grpc {
+		if err := startGRPCWithContext(ctxWithSignal, handler, nil, options); err != nil {
+			panic(err)
+		}
+		return
+	}
 	if err := startWithContext(ctxWithSignal, handler, options); err != nil {
 		panic(err)
 	}
@@ -76,9 +115,15 @@ func udf(ctx context.Context, w http.ResponseWriter, r *http.Request, handler fu
 		_ = r.Body.Close()
 		if err != nil {
 			return nil, err
-		} else {
-			return handler(ctx, []byte(k), in)
 		}
+		if md := r.Header.Get(messageMetadataKey); md != "" {
+			metadata := map[string]string{}
+			if err := json.Unmarshal([]byte(md), &metadata); err != nil {
+				return nil, fmt.Errorf("unmarshal message metadata failed, %w", err)
+			}
+			return handler(ContextWithMetadata(ctx, metadata), []byte(k), in)
+		}
+		return handler(ctx, []byte(k), in)
 	}()
 	if err != nil {
 		log.Printf("Failed to read and process input message, %s", err)
@@ -104,6 +149,90 @@ func udf(ctx context.Context, w http.ResponseWriter, r *http.Request, handler fu
 	}
 }
 
+func batchUDF(ctx context.Context, w http.ResponseWriter, r *http.Request, handler func(ctx context.Context, key, msg []byte) (Messages, error), contentType string) {
+	results, err := func() (BatchResults, error) {
+		in, err := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestContentType := r.Header.Get("Content-Type")
+		if requestContentType == "" {
+			requestContentType = contentType
+		}
+		batch, err := unmarshalBatch(in, requestContentType)
+		if err != nil {
+			return nil, err
+		}
+		results := make(BatchResults, len(batch))
+		for _, m := range batch {
+			messages, err := handler(ContextWithMetadata(ctx, m.Metadata), m.Key, m.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to process message %q, %w", m.ID, err)
+			}
+			if len(messages) == 0 { // Return a DROP message
+				messages = append(messages, MessageToDrop())
+			}
+			results[m.ID] = messages
+		}
+		return results, nil
+	}()
+	if err != nil {
+		log.Printf("Failed to read and process input batch, %s", err)
+		w.WriteHeader(500)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	b, err := marshalBatchResults(results, contentType)
+	if err != nil {
+		log.Printf("Marshal batch results failed, %s", err)
+		w.WriteHeader(500)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Add("Content-Type", contentType)
+	w.WriteHeader(200)
+	if n, err := w.Write(b); err != nil {
+		log.Printf("Write failed (wrote: %d bytes), %s", n, err)
+	}
+}
+
+func unmarshalBatch(data []byte, contentType string) ([]BatchMessage, error) {
+	batch := []BatchMessage{}
+	switch contentType {
+	case contentTypeJson:
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return nil, fmt.Errorf("unmarshal batch with json failed, %w", err)
+		}
+	case contentTypeMsgPack:
+		if err := msgpack.Unmarshal(data, &batch); err != nil {
+			return nil, fmt.Errorf("unmarshal batch with msgpack failed, %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Content-Type %q", contentType)
+	}
+	return batch, nil
+}
+
+func marshalBatchResults(results BatchResults, contentType string) ([]byte, error) {
+	switch contentType {
+	case contentTypeJson:
+		b, err := json.Marshal(&results)
+		if err != nil {
+			return nil, fmt.Errorf("marshal batch results with json failed, %w", err)
+		}
+		return b, nil
+	case contentTypeMsgPack:
+		b, err := msgpack.Marshal(&results)
+		if err != nil {
+			return nil, fmt.Errorf("marshal batch results with msgpack failed, %w", err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unsupported Content-Type %q", contentType)
+	}
+}
+
 func marshalMessages(messages Messages, contentType string) ([]byte, error) {
 	switch contentType {
 	case contentTypeJson:
@@ -123,6 +252,18 @@ func marshalMessages(messages Messages, contentType string) ([]byte, error) {
 	}
 }
 
+// ready responds to the readiness check, and declares the max batch size if there is one, and if the outputs can be
+// streamed.
+func ready(w http.ResponseWriter, opts options) {
+	if opts.maxBatchSize > 0 {
+		w.Header().Set(maxBatchSizeKey, strconv.Itoa(opts.maxBatchSize))
+	}
+	if opts.streaming {
+		w.Header().Set(streamingKey, "true")
+	}
+	w.WriteHeader(204)
+}
+
 func startWithContext(ctx context.Context, handler func(ctx context.Context, key, msg []byte) (Messages, error), opts options) error {
 	contentType := os.Getenv(envUDFContentType)
 	if contentType == "" { // defaults to application/msgpack
@@ -132,11 +273,14 @@ func startWithContext(ctx context.Context, handler func(ctx context.Context, key
 		return fmt.Errorf("unsupported Content-Type %q", contentType)
 	}
 	http.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(204)
+		ready(w, opts)
 	})
 	http.HandleFunc("/messages", func(w http.ResponseWriter, r *http.Request) {
 		udf(ctx, w, r, handler, contentType)
 	})
+	http.HandleFunc("/messages/batch", func(w http.ResponseWriter, r *http.Request) {
+		batchUDF(ctx, w, r, handler, contentType)
+	})
 
 	path := "/var/run/numaflow/udf.sock"
 	if err := os.Remove(path); !os.IsNotExist(err) && err != nil {
@@ -3,6 +3,7 @@ package function
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -84,6 +85,106 @@ func TestStart_testReady(t *testing.T) {
 	assert.Equal(t, 500, res.StatusCode)
 }
 
+func TestReady(t *testing.T) {
+	w := httptest.NewRecorder()
+	ready(w, options{})
+	assert.Equal(t, 204, w.Result().StatusCode)
+	assert.Empty(t, w.Result().Header.Get(maxBatchSizeKey))
+
+	w = httptest.NewRecorder()
+	ready(w, options{maxBatchSize: 50})
+	assert.Equal(t, 204, w.Result().StatusCode)
+	assert.Equal(t, "50", w.Result().Header.Get(maxBatchSizeKey))
+}
+
+func TestBatchUDF(t *testing.T) {
+	ctx := context.Background()
+	batch := []BatchMessage{{ID: "0", Key: []byte("k"), Value: []byte("hello")}, {ID: "1", Value: []byte{}}}
+	body, err := msgpack.Marshal(batch)
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/messages/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", contentTypeMsgPack)
+	w := httptest.NewRecorder()
+	batchUDF(ctx, w, req, dummyTestHandler, contentTypeJson)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, contentTypeJson, res.Header.Get("Content-Type"))
+	data, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	results := BatchResults{}
+	assert.NoError(t, json.Unmarshal(data, &results))
+	assert.Equal(t, []byte("hello"), results["0"][0].Value)
+	// empty results are turned into a DROP message
+	assert.Equal(t, []byte(DROP), results["1"][0].Key)
+
+	// 50X
+	req = httptest.NewRequest(http.MethodPost, "/messages/batch", bytes.NewBuffer(body))
+	w = httptest.NewRecorder()
+	batchUDF(ctx, w, req, func(ctx context.Context, key, msg []byte) (Messages, error) {
+		return nil, fmt.Errorf("test error")
+	}, contentTypeMsgPack)
+	res = w.Result()
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, 500, res.StatusCode)
+
+	// invalid request body
+	req = httptest.NewRequest(http.MethodPost, "/messages/batch", bytes.NewBufferString("invalid"))
+	req.Header.Set("Content-Type", contentTypeJson)
+	w = httptest.NewRecorder()
+	batchUDF(ctx, w, req, dummyTestHandler, contentTypeMsgPack)
+	res = w.Result()
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, 500, res.StatusCode)
+}
+
+func TestUDF_metadata(t *testing.T) {
+	ctx := context.Background()
+	handler := func(ctx context.Context, key, msg []byte) (Messages, error) {
+		metadata := MetadataFromContext(ctx)
+		metadata["seen"] = "true"
+		return MessagesBuilder().Append(MessageTo("", msg).WithMetadata(metadata)), nil
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/messages", bytes.NewBufferString("hello"))
+	req.Header.Set(messageMetadataKey, `{"tenant":"a"}`)
+	w := httptest.NewRecorder()
+	udf(ctx, w, req, handler, contentTypeMsgPack)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, 200, res.StatusCode)
+	data, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	messages := []Message{}
+	assert.NoError(t, msgpack.Unmarshal(data, &messages))
+	assert.Equal(t, map[string]string{"tenant": "a", "seen": "true"}, messages[0].Metadata)
+
+	// invalid metadata
+	req = httptest.NewRequest(http.MethodPost, "/messages", bytes.NewBufferString("hello"))
+	req.Header.Set(messageMetadataKey, "invalid")
+	w = httptest.NewRecorder()
+	udf(ctx, w, req, handler, contentTypeMsgPack)
+	res = w.Result()
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, 500, res.StatusCode)
+
+	// batch
+	body, err := msgpack.Marshal([]BatchMessage{{ID: "0", Value: []byte("hello"), Metadata: map[string]string{"tenant": "b"}}})
+	assert.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPost, "/messages/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", contentTypeMsgPack)
+	w = httptest.NewRecorder()
+	batchUDF(ctx, w, req, handler, contentTypeMsgPack)
+	res = w.Result()
+	defer func() { _ = res.Body.Close() }()
+	assert.Equal(t, 200, res.StatusCode)
+	data, err = ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	results := BatchResults{}
+	assert.NoError(t, msgpack.Unmarshal(data, &results))
+	assert.Equal(t, map[string]string{"tenant": "b", "seen": "true"}, results["0"][0].Metadata)
+}
+
 func dummyTestHandler(_ context.Context, key, m []byte) (messages Messages, error error) {
 	if len(m) == 0 {
 		return nil, nil
@@ -0,0 +1,128 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	streamingKey   = "x-numa-streaming"
+	streamErrorKey = "X-Numa-Stream-Error"
+)
+
+// StreamHandle processes a message and emits the output messages one by one as they are produced, so that a message
+// producing a large number of outputs does not need to hold them in memory. An error returned by emit means the
+// platform is no longer receiving, the handler should stop and return it.
+type StreamHandle func(ctx context.Context, key, msg []byte, emit func(Message) error) error
+
+// StartStream starts the HTTP Server with a streaming handler. The output messages are streamed back at
+// `/messages/stream` as they are emitted, and the platform writes them to the downstream buffers in chunks. The handler
+// is also exposed at `/messages` and `/messages/batch`, where the outputs are collected before responding.
+func StartStream(ctx context.Context, handler StreamHandle, opts ...Option) {
+	options := options{
+		drainTimeout: time.Minute,
+		streaming:    true,
+	}
+	for _, o := range opts {
+		o.apply(&options)
+	}
+	ctxWithSignal, stop := signal.NotifyContext(ctx, syscall.SIGTERM)
+	defer stop()
+	if options.grpc {
+		if err := startGRPCWithContext(ctxWithSignal, collect(handler), handler, options); err != nil {
+			panic(err)
+		}
+		return
+	}
+	contentType := os.Getenv(envUDFContentType)
+	http.HandleFunc("/messages/stream", func(w http.ResponseWriter, r *http.Request) {
+		streamUDF(ctxWithSignal, w, r, handler, contentType)
+	})
+	if err := startWithContext(ctxWithSignal, collect(handler), options); err != nil {
+		panic(err)
+	}
+}
+
+// collect turns a streaming handler into a handler returning all the output messages at once.
+func collect(handler StreamHandle) Handle {
+	return func(ctx context.Context, key, msg []byte) (Messages, error) {
+		messages := MessagesBuilder()
+		err := handler(ctx, key, msg, func(m Message) error {
+			messages = messages.Append(m)
+			return nil
+		})
+		return messages, err
+	}
+}
+
+// streamUDF writes the output messages to the response one after another as they are emitted, in the encoding of the
+// content type. As the status code is sent before the handler returns, an error of the handler is sent in the trailer.
+func streamUDF(ctx context.Context, w http.ResponseWriter, r *http.Request, handler StreamHandle, contentType string) {
+	if contentType == "" {
+		contentType = contentTypeMsgPack
+	}
+	k := r.Header.Get(messagekey)
+	in, err := ioutil.ReadAll(r.Body)
+	_ = r.Body.Close()
+	if err != nil {
+		log.Printf("Failed to read input message, %s", err)
+		w.WriteHeader(500)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	if md := r.Header.Get(messageMetadataKey); md != "" {
+		metadata := map[string]string{}
+		if err := json.Unmarshal([]byte(md), &metadata); err != nil {
+			w.WriteHeader(500)
+			_, _ = w.Write([]byte(fmt.Sprintf("unmarshal message metadata failed, %s", err)))
+			return
+		}
+		ctx = ContextWithMetadata(ctx, metadata)
+	}
+	encode, err := newStreamEncoder(w, contentType)
+	if err != nil {
+		w.WriteHeader(500)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	w.Header().Set("Trailer", streamErrorKey)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(200)
+	emitted := 0
+	err = handler(ctx, []byte(k), in, func(m Message) error {
+		emitted++
+		return encode(m)
+	})
+	if err == nil && emitted == 0 { // Return a DROP message
+		err = encode(MessageToDrop())
+	}
+	if err != nil {
+		log.Printf("Failed to process input message, %s", err)
+		w.Header().Set(streamErrorKey, err.Error())
+	}
+}
+
+// newStreamEncoder returns a function encoding the messages to the writer one after another.
+func newStreamEncoder(w io.Writer, contentType string) (func(Message) error, error) {
+	switch contentType {
+	case contentTypeJson:
+		enc := json.NewEncoder(w)
+		return func(m Message) error { return enc.Encode(&m) }, nil
+	case contentTypeMsgPack:
+		enc := msgpack.NewEncoder(w)
+		return func(m Message) error { return enc.Encode(&m) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported Content-Type %q", contentType)
+	}
+}
@@ -0,0 +1,97 @@
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func explodeTestHandler(_ context.Context, _, msg []byte, emit func(Message) error) error {
+	if string(msg) == "fail" {
+		if err := emit(MessageToAll(msg)); err != nil {
+			return err
+		}
+		return fmt.Errorf("test error")
+	}
+	for _, b := range msg {
+		if err := emit(MessageToAll([]byte{b})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamUDF(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("test stream", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/messages/stream", bytes.NewBufferString("abc"))
+		w := httptest.NewRecorder()
+		streamUDF(ctx, w, req, explodeTestHandler, contentTypeMsgPack)
+		res := w.Result()
+		defer func() { _ = res.Body.Close() }()
+		assert.Equal(t, 200, res.StatusCode)
+		assert.Equal(t, contentTypeMsgPack, res.Header.Get("Content-Type"))
+		dec := msgpack.NewDecoder(res.Body)
+		for _, b := range []byte("abc") {
+			m := Message{}
+			assert.NoError(t, dec.Decode(&m))
+			assert.Equal(t, []byte{b}, m.Value)
+		}
+		assert.Equal(t, io.EOF, dec.Decode(&Message{}))
+		assert.Empty(t, res.Trailer.Get(streamErrorKey))
+	})
+
+	t.Run("test drop", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/messages/stream", bytes.NewBufferString(""))
+		w := httptest.NewRecorder()
+		streamUDF(ctx, w, req, explodeTestHandler, contentTypeJson)
+		res := w.Result()
+		defer func() { _ = res.Body.Close() }()
+		assert.Equal(t, 200, res.StatusCode)
+		m := Message{}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&m))
+		assert.Equal(t, []byte(DROP), m.Key)
+	})
+
+	t.Run("test error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/messages/stream", bytes.NewBufferString("fail"))
+		w := httptest.NewRecorder()
+		streamUDF(ctx, w, req, explodeTestHandler, contentTypeMsgPack)
+		res := w.Result()
+		defer func() { _ = res.Body.Close() }()
+		assert.Equal(t, 200, res.StatusCode)
+		assert.Equal(t, "test error", res.Trailer.Get(streamErrorKey))
+	})
+
+	t.Run("test invalid metadata", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/messages/stream", bytes.NewBufferString("abc"))
+		req.Header.Set(messageMetadataKey, "invalid")
+		w := httptest.NewRecorder()
+		streamUDF(ctx, w, req, explodeTestHandler, contentTypeMsgPack)
+		assert.Equal(t, 500, w.Result().StatusCode)
+	})
+}
+
+func TestCollect(t *testing.T) {
+	messages, err := collect(explodeTestHandler)(context.Background(), nil, []byte("ab"))
+	assert.NoError(t, err)
+	assert.Equal(t, Messages{MessageToAll([]byte("a")), MessageToAll([]byte("b"))}, messages)
+	_, err = collect(explodeTestHandler)(context.Background(), nil, []byte("fail"))
+	assert.Error(t, err)
+}
+
+func TestReady_streaming(t *testing.T) {
+	w := httptest.NewRecorder()
+	ready(w, options{streaming: true})
+	assert.Equal(t, 204, w.Result().StatusCode)
+	assert.Equal(t, "true", w.Result().Header.Get(streamingKey))
+}
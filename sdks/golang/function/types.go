@@ -11,6 +11,14 @@ var (
 type Message struct {
 	Key   []byte
 	Value []byte
+	// Metadata replaces the metadata of the input message if it's not nil, otherwise the input metadata is carried over
+	Metadata map[string]string `json:",omitempty" msgpack:",omitempty"`
+}
+
+// WithMetadata returns a copy of the Message with the metadata, which replaces the metadata of the input message
+func (m Message) WithMetadata(metadata map[string]string) Message {
+	m.Metadata = metadata
+	return m
 }
 
 // MessageToDrop creates a Message to be dropped
@@ -30,6 +38,17 @@ func MessageTo(to string, value []byte) Message {
 
 type Messages []Message
 
+// BatchMessage is an input message of a batch call, ID identifies the message in the batch
+type BatchMessage struct {
+	ID       string
+	Key      []byte
+	Value    []byte
+	Metadata map[string]string `json:",omitempty" msgpack:",omitempty"`
+}
+
+// BatchResults are the results of a batch call, keyed by the IDs of the input messages
+type BatchResults map[string]Messages
+
 // MessagesBuilder returns an empty instance of Messages
 func MessagesBuilder() Messages {
 	return Messages{}
@@ -8,6 +8,7 @@ import (
 
 	dfv1 "github.com/numaproj/numaflow/pkg/apis/numaflow/v1alpha1"
 	flowpkg "github.com/numaproj/numaflow/pkg/client/clientset/versioned/typed/numaflow/v1alpha1"
+	daemonclient "github.com/numaproj/numaflow/pkg/client/daemon"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -142,6 +143,16 @@ func (w *When) VertexPodPortForward(vertexName string, localPort, remotePort int
 	return w
 }
 
+// DaemonClient returns a client of the pipeline daemon service via port-forward, the caller is responsible for closing it.
+func (w *When) DaemonClient() *daemonclient.DaemonClient {
+	w.t.Helper()
+	c, err := daemonclient.NewDaemonServiceClientWithPortForward(context.Background(), w.restConfig, Namespace, w.pipeline.Name)
+	if err != nil {
+		w.t.Fatalf("failed to create daemon client: %v", err)
+	}
+	return c
+}
+
 func (w *When) TerminateAllPodPortForwards() *When {
 	w.t.Helper()
 	if len(w.portForwarderStopChanels) > 0 {
@@ -19,6 +19,8 @@ package numaflow
 import (
 	"fmt"
 	"runtime"
+
+	"golang.org/x/mod/semver"
 )
 
 // Version information set by link flags during build. We fall back to these sane
@@ -79,3 +81,17 @@ func GetVersion() Version {
 		Platform:     fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
 	}
 }
+
+// CheckVersionSkew returns an error if this version is incompatible with the expected one, i.e. they are of different
+// major or minor versions, where the protocols between the components could change. The check is skipped if either of
+// them is not a release version, e.g. a development build.
+func CheckVersionSkew(expected string) error {
+	current := GetVersion().Version
+	if !semver.IsValid(current) || !semver.IsValid(expected) {
+		return nil
+	}
+	if semver.MajorMinor(current) != semver.MajorMinor(expected) {
+		return fmt.Errorf("numaflow version %s is incompatible with the expected version %s", current, expected)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package numaflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVersionSkew(t *testing.T) {
+	defer func(v, c, tag, s string) {
+		version, gitCommit, gitTag, gitTreeState = v, c, tag, s
+	}(version, gitCommit, gitTag, gitTreeState)
+
+	version, gitCommit, gitTag, gitTreeState = "latest", "", "", ""
+	assert.NoError(t, CheckVersionSkew("v0.6.0"))
+
+	version, gitCommit, gitTag, gitTreeState = "v0.6.1", "0123456789", "v0.6.1", "clean"
+	assert.NoError(t, CheckVersionSkew("v0.6.0"))
+	assert.NoError(t, CheckVersionSkew("latest+unknown"))
+	assert.NoError(t, CheckVersionSkew(""))
+	assert.Error(t, CheckVersionSkew("v0.7.0"))
+	assert.Error(t, CheckVersionSkew("v1.6.1"))
+
+	gitTreeState = "dirty"
+	assert.Equal(t, "v0.6.1+0123456.dirty", GetVersion().Version)
+	assert.NoError(t, CheckVersionSkew("v0.6.2"))
+	assert.Error(t, CheckVersionSkew("v0.5.2"))
+}